@@ -0,0 +1,233 @@
+// Package action 提供单方法的动作类型支持，同一个动作可以注册为路由、队列任务、事件监听器和命令处理器
+//
+// 动作是带有 Handle(ctx, request) (response, error) 方法的结构体（也可以是 Handle(ctx, request) error），
+// 对应 Laravel 社区常用的 Action 类：业务逻辑写在一个地方，由不同的入口适配输入和输出。
+// 动作可以直接传入实例，也可以传入容器中的服务标识符，标识符在每次调用时从容器解析，
+// 因此动作的依赖和普通服务一样由容器注入。
+//
+// 主要特性：
+// - Invoke 按 Handle 方法的参数类型转换输入，结构体参数可以从 map 或 JSON 解码
+// - Route 和 HTTP 把动作适配为 HTTP 处理器，请求输入解码为参数，返回值写为 JSON 或响应对象
+// - Job 把容器中的动作和输入投递到队列
+// - Listener、ContextListener 和 Command 把动作适配为事件监听器和命令总线处理器
+//
+// 包结构：
+// - action.go - Invoke、Func、默认容器和 ServiceProvider
+// - adapters.go - HTTP、队列任务、事件监听器和命令处理器适配
+//
+// 使用示例：
+//
+//	type CreateOrder struct {
+//		orders OrderRepository
+//	}
+//
+//	type CreateOrderInput struct {
+//		ProductID int64 `json:"product_id"`
+//		Quantity  int   `json:"quantity"`
+//	}
+//
+//	func (a *CreateOrder) Handle(ctx context.Context, input CreateOrderInput) (*Order, error) { ... }
+//
+//	app.Singleton("actions.create_order", func(c container.Container) interface{} {
+//		return &CreateOrder{orders: c.MustMake("orders").(OrderRepository)}
+//	})
+//
+//	router.Post("/orders", handler.HandlerFunc(action.HTTP("actions.create_order")))
+//	dispatcher.Map(CreateOrderInput{}, action.Command("actions.create_order"))
+//	job, _ := action.NewJob("actions.create_order", CreateOrderInput{ProductID: 1, Quantity: 2})
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+var (
+	// ErrInvalidAction 值没有 Handle(ctx, request) (response, error) 或 Handle(ctx, request) error 方法
+	ErrInvalidAction = errors.New("action: invalid action")
+
+	// ErrInvalidInput 输入不能转换为 Handle 的参数类型
+	ErrInvalidInput = errors.New("action: invalid input")
+
+	// ErrNoContainer 动作是服务标识符，但没有设置容器
+	ErrNoContainer = errors.New("action: no container, call action.SetContainer")
+)
+
+// Func 函数形式的动作
+type Func[Req, Res any] func(ctx context.Context, request Req) (Res, error)
+
+// Handle 调用函数
+func (f Func[Req, Res]) Handle(ctx context.Context, request Req) (Res, error) {
+	return f(ctx, request)
+}
+
+// defaultContainer 解析服务标识符形式的动作使用的容器
+var defaultContainer atomic.Value
+
+// SetContainer 设置解析服务标识符形式的动作使用的容器，ServiceProvider 在注册时设置
+func SetContainer(c container.Container) {
+	defaultContainer.Store(&c)
+}
+
+// Container 解析动作使用的容器，未设置时返回 nil
+func Container() container.Container {
+	if c, ok := defaultContainer.Load().(*container.Container); ok {
+		return *c
+	}
+	return nil
+}
+
+// Resolve 解析动作：字符串作为服务标识符从容器解析，其他值原样返回
+func Resolve(action interface{}) (interface{}, error) {
+	abstract, ok := action.(string)
+	if !ok {
+		return action, nil
+	}
+	c := Container()
+	if c == nil {
+		return nil, ErrNoContainer
+	}
+	resolved, err := c.Make(abstract)
+	if err != nil {
+		return nil, fmt.Errorf("action: resolve %s: %w", abstract, err)
+	}
+	return resolved, nil
+}
+
+// Invoke 解析动作并调用 Handle，input 按 Handle 的参数类型转换，只返回错误的动作结果为 nil
+//
+// input 可以直接赋值给参数时原样传入；参数是指针时也接受其指向的值；
+// 其他输入（map、json.RawMessage、[]byte 或其他结构体）按 JSON 解码为参数。
+func Invoke(ctx context.Context, action, input interface{}) (interface{}, error) {
+	resolved, err := Resolve(action)
+	if err != nil {
+		return nil, err
+	}
+	method, err := handleMethod(resolved)
+	if err != nil {
+		return nil, err
+	}
+	argument, err := convertInput(input, method.Type().In(1))
+	if err != nil {
+		return nil, err
+	}
+	results := method.Call([]reflect.Value{reflect.ValueOf(ctx), argument})
+	last := results[len(results)-1]
+	if !last.IsNil() {
+		err = last.Interface().(error)
+	}
+	if len(results) == 1 {
+		return nil, err
+	}
+	return results[0].Interface(), err
+}
+
+// RequestType 动作 Handle 方法的参数类型，action 不能是服务标识符
+func RequestType(action interface{}) (reflect.Type, error) {
+	method, err := handleMethod(action)
+	if err != nil {
+		return nil, err
+	}
+	return method.Type().In(1), nil
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+
+	// signatures 已校验的动作类型
+	signatures sync.Map
+)
+
+// handleMethod 动作的 Handle 方法，签名不符时返回 ErrInvalidAction
+func handleMethod(action interface{}) (reflect.Value, error) {
+	if action == nil {
+		return reflect.Value{}, fmt.Errorf("%w: nil", ErrInvalidAction)
+	}
+	value := reflect.ValueOf(action)
+	method := value.MethodByName("Handle")
+	if !method.IsValid() {
+		return reflect.Value{}, fmt.Errorf("%w: %T has no Handle method", ErrInvalidAction, action)
+	}
+	if _, ok := signatures.Load(value.Type()); ok {
+		return method, nil
+	}
+	t := method.Type()
+	valid := t.NumIn() == 2 && t.In(0) == contextType && !t.IsVariadic() &&
+		(t.NumOut() == 1 || t.NumOut() == 2) && t.Out(t.NumOut()-1) == errorType
+	if !valid {
+		return reflect.Value{}, fmt.Errorf("%w: %T.Handle must be func(context.Context, Request) (Response, error) or func(context.Context, Request) error", ErrInvalidAction, action)
+	}
+	signatures.Store(value.Type(), true)
+	return method, nil
+}
+
+// convertInput 把输入转换为参数类型
+func convertInput(input interface{}, t reflect.Type) (reflect.Value, error) {
+	if input == nil {
+		return reflect.Zero(t), nil
+	}
+	v := reflect.ValueOf(input)
+	switch {
+	case v.Type().AssignableTo(t):
+		return v, nil
+	case t.Kind() == reflect.Ptr && v.Type().AssignableTo(t.Elem()):
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(v)
+		return ptr, nil
+	case v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Type().AssignableTo(t):
+		return v.Elem(), nil
+	}
+
+	var data []byte
+	switch raw := input.(type) {
+	case json.RawMessage:
+		data = raw
+	case []byte:
+		data = raw
+	default:
+		encoded, err := json.Marshal(input)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		data = encoded
+	}
+	target := reflect.New(t)
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: decode %s: %v", ErrInvalidInput, t, err)
+	}
+	return target.Elem(), nil
+}
+
+// ServiceProvider 设置解析动作使用的容器，并在默认队列注册表中注册 Job
+type ServiceProvider struct{}
+
+// Register 设置容器并注册 Job
+func (p *ServiceProvider) Register(c container.Container) error {
+	SetContainer(c)
+	queue.Register(&Job{})
+	return nil
+}
+
+// Boot 无需启动逻辑
+func (p *ServiceProvider) Boot(c container.Container) error {
+	return nil
+}
+
+// Provides 不提供延迟加载的服务
+func (p *ServiceProvider) Provides() []string {
+	return nil
+}
+
+// IsDeferred 不延迟加载
+func (p *ServiceProvider) IsDeferred() bool {
+	return false
+}
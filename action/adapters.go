@@ -0,0 +1,159 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/bus"
+	"github.com/cnote0/laraveldoc/exceptions"
+	"github.com/cnote0/laraveldoc/queue"
+	"github.com/cnote0/laraveldoc/routing"
+)
+
+var (
+	httpRequestType = reflect.TypeOf((*http.Request)(nil))
+	requestType     = reflect.TypeOf((*routing.RequestInterface)(nil)).Elem()
+)
+
+// HTTP 把动作适配为返回错误的 HTTP 处理函数，可以交给 exceptions.Handler.HandlerFunc 渲染错误
+//
+// Handle 的参数为 *http.Request 或 routing.RequestInterface 时直接传入请求，
+// 否则把请求输入（查询参数和请求体，见 routing.Request.All）解码为参数。返回值的写法：
+// 实现了 WriteTo(http.ResponseWriter) error 的响应（routing.Response、routing.Redirect）直接写入，
+// 其他 routing.ResponseInterface 按状态码、响应头和内容写入，字符串为 text/plain，
+// nil 或只返回错误的动作为 204，其余编码为 JSON。
+func HTTP(action interface{}) func(w http.ResponseWriter, r *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		resolved, err := Resolve(action)
+		if err != nil {
+			return err
+		}
+		t, err := RequestType(resolved)
+		if err != nil {
+			return err
+		}
+		var input interface{}
+		switch {
+		case t == httpRequestType:
+			input = r
+		case t == requestType:
+			input = routing.NewRequest(r)
+		default:
+			request := routing.NewRequest(r)
+			all := request.All()
+			if err := request.ParseError(); err != nil {
+				return err
+			}
+			input = all
+		}
+		response, err := Invoke(r.Context(), resolved, input)
+		if err != nil {
+			return err
+		}
+		return writeResponse(w, response)
+	}
+}
+
+// Route 把动作适配为 http.Handler，可以直接注册为路由动作
+//
+// 错误通过 exceptions.AbortWith 中止请求，需要在 exceptions.Handler.Middleware 之内使用；
+// 需要自行处理错误时使用 HTTP。
+func Route(action interface{}) http.Handler {
+	handler := HTTP(action)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := handler(w, r); err != nil {
+			exceptions.AbortWith(err)
+		}
+	})
+}
+
+// writeResponse 写入动作的返回值
+func writeResponse(w http.ResponseWriter, response interface{}) error {
+	if value := reflect.ValueOf(response); response == nil ||
+		(value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil() {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+	switch r := response.(type) {
+	case interface {
+		WriteTo(w http.ResponseWriter) error
+	}:
+		return r.WriteTo(w)
+	case routing.ResponseInterface:
+		for name, values := range r.GetHeaders() {
+			for _, value := range values {
+				w.Header().Add(name, value)
+			}
+		}
+		w.WriteHeader(r.GetStatusCode())
+		_, err := w.Write([]byte(r.GetContent()))
+		return err
+	case string:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, err := w.Write([]byte(r))
+		return err
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("action: encode response: %w", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(body)
+	return err
+}
+
+// Job 在队列中执行容器中的动作，动作必须是服务标识符，输入编码为 JSON
+//
+// Job 需要在 Worker 使用的注册表中注册，ServiceProvider 会注册到 queue.DefaultRegistry。
+type Job struct {
+	queue.Queueable
+
+	// Action 动作的服务标识符
+	Action string `json:"action"`
+
+	// Input 动作输入的 JSON
+	Input json.RawMessage `json:"input"`
+}
+
+// NewJob 创建执行动作的队列任务
+func NewJob(action string, input interface{}) (*Job, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("action: encode input for %s: %w", action, err)
+	}
+	return &Job{Action: action, Input: data}, nil
+}
+
+// Handle 从容器解析动作并以解码后的输入调用
+func (j *Job) Handle(ctx context.Context) error {
+	_, err := Invoke(ctx, j.Action, j.Input)
+	return err
+}
+
+// Listener 把动作适配为事件监听器，事件作为输入，动作收到 context.Background()
+func Listener(action interface{}) application.EventListener {
+	return func(event interface{}) error {
+		_, err := Invoke(context.Background(), action, event)
+		return err
+	}
+}
+
+// ContextListener 把动作适配为接收上下文的事件监听器，动作收到分发事件时的 ctx，
+// 通过 application.AddContextListener 注册
+func ContextListener(action interface{}) application.ContextEventListener {
+	return func(ctx context.Context, event interface{}) error {
+		_, err := Invoke(ctx, action, event)
+		return err
+	}
+}
+
+// Command 把动作适配为命令总线处理器，命令作为输入，返回动作的结果
+func Command(action interface{}) bus.Handler {
+	return bus.HandlerFunc(func(ctx context.Context, command interface{}) (interface{}, error) {
+		return Invoke(ctx, action, command)
+	})
+}
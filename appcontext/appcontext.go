@@ -0,0 +1,130 @@
+// Package appcontext 提供 Laravel 风格的上下文数据，对应 Laravel 11 的 Context 门面
+//
+// Repository 保存一次请求或一个任务中共享的数据（例如 trace_id、request_id、当前租户），
+// 通过 context.Context 传递。普通数据会自动附加到日志记录，隐藏数据只在代码中读取；
+// 两者都会随投递的队列任务写入载荷，Worker 处理任务时还原，使追踪标识跨越异步边界。
+//
+// 每个请求或任务应该有自己的 Repository：HTTP 请求使用 Middleware，队列 Worker 处理任务时自动创建。
+// 在 goroutine 中并发修改时使用 Scope 复制一份，避免影响调用方。
+// 经过队列载荷还原的值是 JSON 解码的结果，数字为 float64，结构体为 map[string]interface{}。
+//
+// 包结构：
+// - appcontext.go - context.Context 传递、包级便捷函数和 HTTP 中间件
+// - repository.go - Repository 数据和隐藏数据、载荷编码
+// - log.go - 把上下文附加到 application.LoggerInterface 和 slog 日志
+// - requestid.go - X-Request-Id 请求 ID 中间件和发出请求时的传递
+//
+// 使用示例：
+//
+//	http.ListenAndServe(":8080", appcontext.Middleware(mux))
+//	// 或者同时分配请求 ID：appcontext.RequestIDMiddleware(mux)
+//
+//	func (c *OrderController) Store(w http.ResponseWriter, r *http.Request) {
+//		ctx := r.Context()
+//		appcontext.Add(ctx, "trace_id", r.Header.Get("X-Trace-Id"))
+//		appcontext.AddHidden(ctx, "api_key", key)
+//		q.Push(ctx, &ProcessOrder{ID: id}, "") // 任务的 Handle 中 appcontext.Get(ctx, "trace_id") 仍然可用
+//	}
+//
+//	slog.SetDefault(slog.New(appcontext.NewSlogHandler(slog.NewJSONHandler(os.Stdout, nil))))
+//	slog.InfoContext(ctx, "order created") // 记录中包含 trace_id
+package appcontext
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type repositoryKey struct{}
+
+// WithRepository 返回携带 Repository 的上下文
+func WithRepository(ctx context.Context, repository *Repository) context.Context {
+	return context.WithValue(ctx, repositoryKey{}, repository)
+}
+
+// From 获取上下文中的 Repository，没有时返回 nil
+//
+// nil *Repository 的方法可以安全调用：读取返回空值，写入被忽略。
+func From(ctx context.Context) *Repository {
+	repository, _ := ctx.Value(repositoryKey{}).(*Repository)
+	return repository
+}
+
+// Ensure 上下文中没有 Repository 时附加一个新的
+func Ensure(ctx context.Context) context.Context {
+	if From(ctx) != nil {
+		return ctx
+	}
+	return WithRepository(ctx, NewRepository())
+}
+
+// Scope 复制当前的 Repository 并加入 data，返回携带副本的上下文
+//
+// 对副本的修改不影响调用方，适合传给 goroutine 或为一段代码临时添加数据。
+func Scope(ctx context.Context, data map[string]interface{}) context.Context {
+	repository := From(ctx).Clone()
+	for key, value := range data {
+		repository.Add(key, value)
+	}
+	return WithRepository(ctx, repository)
+}
+
+// Add 向上下文的 Repository 添加数据
+func Add(ctx context.Context, key string, value interface{}) {
+	From(ctx).Add(key, value)
+}
+
+// AddHidden 向上下文的 Repository 添加隐藏数据
+func AddHidden(ctx context.Context, key string, value interface{}) {
+	From(ctx).AddHidden(key, value)
+}
+
+// Get 获取上下文中的数据
+func Get(ctx context.Context, key string) interface{} {
+	return From(ctx).Get(key)
+}
+
+// GetHidden 获取上下文中的隐藏数据
+func GetHidden(ctx context.Context, key string) interface{} {
+	return From(ctx).GetHidden(key)
+}
+
+// Forget 删除上下文中的数据
+func Forget(ctx context.Context, keys ...string) {
+	From(ctx).Forget(keys...)
+}
+
+// All 上下文中的所有数据，不含隐藏数据
+func All(ctx context.Context) map[string]interface{} {
+	return From(ctx).All()
+}
+
+// Dehydrate 把上下文的 Repository 编码为队列载荷，没有数据时返回 nil
+func Dehydrate(ctx context.Context) (json.RawMessage, error) {
+	repository := From(ctx)
+	if repository.IsEmpty() {
+		return nil, nil
+	}
+	return json.Marshal(repository)
+}
+
+// Hydrate 从队列载荷还原 Repository，返回携带它的上下文
+//
+// 总是附加新的 Repository，任务之间不会共享数据；payload 为空时 Repository 为空。
+func Hydrate(ctx context.Context, payload json.RawMessage) (context.Context, error) {
+	repository := NewRepository()
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, repository); err != nil {
+			return ctx, err
+		}
+	}
+	return WithRepository(ctx, repository), nil
+}
+
+// Middleware 为每个请求附加新的 Repository
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithRepository(r.Context(), NewRepository())))
+	})
+}
@@ -0,0 +1,60 @@
+package appcontext
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// Logger 返回附加了上下文数据的日志记录器，隐藏数据不会写入
+func Logger(ctx context.Context, logger application.LoggerInterface) application.LoggerInterface {
+	data := From(ctx).All()
+	if len(data) == 0 {
+		return logger
+	}
+	return logger.WithContext(data)
+}
+
+// SlogHandler 把上下文数据作为属性附加到每条 slog 记录
+type SlogHandler struct {
+	handler slog.Handler
+}
+
+// NewSlogHandler 包装 slog.Handler，需要使用 InfoContext 等带上下文的方法记录日志
+func NewSlogHandler(handler slog.Handler) *SlogHandler {
+	return &SlogHandler{handler: handler}
+}
+
+// Enabled 由被包装的 Handler 决定
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle 附加上下文数据后交给被包装的 Handler，键按名称排序
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	data := From(ctx).All()
+	if len(data) > 0 {
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		record = record.Clone()
+		for _, key := range keys {
+			record.AddAttrs(slog.Any(key, data[key]))
+		}
+	}
+	return h.handler.Handle(ctx, record)
+}
+
+// WithAttrs 返回带有属性的 Handler
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SlogHandler{handler: h.handler.WithAttrs(attrs)}
+}
+
+// WithGroup 返回带有分组的 Handler
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	return &SlogHandler{handler: h.handler.WithGroup(name)}
+}
@@ -0,0 +1,272 @@
+package appcontext
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Repository 上下文数据，对应 Laravel 的 Illuminate\Log\Context\Repository
+//
+// 并发安全。所有方法都可以在 nil 上调用。
+type Repository struct {
+	mu     sync.RWMutex
+	data   map[string]interface{}
+	hidden map[string]interface{}
+}
+
+// NewRepository 创建空的 Repository
+func NewRepository() *Repository {
+	return &Repository{data: make(map[string]interface{}), hidden: make(map[string]interface{})}
+}
+
+// Add 添加数据，已存在时覆盖
+func (r *Repository) Add(key string, value interface{}) *Repository {
+	if r != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.data[key] = value
+	}
+	return r
+}
+
+// AddIf 数据不存在时添加，返回是否添加
+func (r *Repository) AddIf(key string, value interface{}) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.data[key]; ok {
+		return false
+	}
+	r.data[key] = value
+	return true
+}
+
+// Push 把值追加到列表数据，键不存在时创建列表
+func (r *Repository) Push(key string, values ...interface{}) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return push(r.data, key, values)
+}
+
+// Get 获取数据，不存在时返回 nil
+func (r *Repository) Get(key string) interface{} {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.data[key]
+}
+
+// Has 数据是否存在
+func (r *Repository) Has(key string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.data[key]
+	return ok
+}
+
+// Forget 删除数据
+func (r *Repository) Forget(keys ...string) *Repository {
+	if r != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for _, key := range keys {
+			delete(r.data, key)
+		}
+	}
+	return r
+}
+
+// All 所有数据的副本
+func (r *Repository) All() map[string]interface{} {
+	if r == nil {
+		return map[string]interface{}{}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return copyMap(r.data)
+}
+
+// Only 指定键的数据
+func (r *Repository) Only(keys ...string) map[string]interface{} {
+	result := make(map[string]interface{}, len(keys))
+	if r == nil {
+		return result
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, key := range keys {
+		if value, ok := r.data[key]; ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// AddHidden 添加隐藏数据，隐藏数据不会写入日志
+func (r *Repository) AddHidden(key string, value interface{}) *Repository {
+	if r != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.hidden[key] = value
+	}
+	return r
+}
+
+// PushHidden 把值追加到隐藏的列表数据
+func (r *Repository) PushHidden(key string, values ...interface{}) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return push(r.hidden, key, values)
+}
+
+// GetHidden 获取隐藏数据，不存在时返回 nil
+func (r *Repository) GetHidden(key string) interface{} {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.hidden[key]
+}
+
+// HasHidden 隐藏数据是否存在
+func (r *Repository) HasHidden(key string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.hidden[key]
+	return ok
+}
+
+// ForgetHidden 删除隐藏数据
+func (r *Repository) ForgetHidden(keys ...string) *Repository {
+	if r != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for _, key := range keys {
+			delete(r.hidden, key)
+		}
+	}
+	return r
+}
+
+// AllHidden 所有隐藏数据的副本
+func (r *Repository) AllHidden() map[string]interface{} {
+	if r == nil {
+		return map[string]interface{}{}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return copyMap(r.hidden)
+}
+
+// IsEmpty 是否没有任何数据和隐藏数据
+func (r *Repository) IsEmpty() bool {
+	if r == nil {
+		return true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.data) == 0 && len(r.hidden) == 0
+}
+
+// Flush 清空数据和隐藏数据
+func (r *Repository) Flush() *Repository {
+	if r != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.data = make(map[string]interface{})
+		r.hidden = make(map[string]interface{})
+	}
+	return r
+}
+
+// Clone 复制一份，列表数据也会复制，nil 时返回空的 Repository
+func (r *Repository) Clone() *Repository {
+	clone := NewRepository()
+	if r == nil {
+		return clone
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	clone.data = copyMap(r.data)
+	clone.hidden = copyMap(r.hidden)
+	return clone
+}
+
+// repositoryJSON 载荷中的编码格式
+type repositoryJSON struct {
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Hidden map[string]interface{} `json:"hidden,omitempty"`
+}
+
+// MarshalJSON 编码数据和隐藏数据
+func (r *Repository) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("{}"), nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return json.Marshal(repositoryJSON{Data: r.data, Hidden: r.hidden})
+}
+
+// UnmarshalJSON 解码并替换全部数据
+func (r *Repository) UnmarshalJSON(body []byte) error {
+	var decoded repositoryJSON
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return fmt.Errorf("appcontext: decode repository: %w", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data, r.hidden = decoded.Data, decoded.Hidden
+	if r.data == nil {
+		r.data = make(map[string]interface{})
+	}
+	if r.hidden == nil {
+		r.hidden = make(map[string]interface{})
+	}
+	return nil
+}
+
+// push 追加到列表，已有的值不是列表时返回错误
+func push(m map[string]interface{}, key string, values []interface{}) error {
+	current, ok := m[key]
+	if !ok {
+		m[key] = append([]interface{}{}, values...)
+		return nil
+	}
+	list, ok := current.([]interface{})
+	if !ok {
+		return fmt.Errorf("appcontext: unable to push value onto context stack for key [%s]", key)
+	}
+	m[key] = append(list, values...)
+	return nil
+}
+
+// copyMap 复制 map，列表数据复制一层
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		if list, ok := value.([]interface{}); ok {
+			value = append([]interface{}{}, list...)
+		}
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package appcontext
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+const (
+	// HeaderRequestID 请求 ID 使用的请求头和响应头
+	HeaderRequestID = "X-Request-Id"
+
+	// KeyRequestID 请求 ID 在 Repository 中的键
+	KeyRequestID = "request_id"
+)
+
+// maxRequestIDLength 接受的外部请求 ID 的最大长度
+const maxRequestIDLength = 128
+
+// RequestID 获取上下文中的请求 ID，没有时返回空字符串
+func RequestID(ctx context.Context) string {
+	id, _ := Get(ctx, KeyRequestID).(string)
+	return id
+}
+
+// RequestIDMiddleware 为每个请求分配或沿用请求 ID 的中间件
+//
+// 请求带有格式合法的 X-Request-Id 时沿用该值（由上游服务或网关生成），否则生成 UUID。
+// 请求 ID 以 request_id 写入请求的 Repository（没有时附加新的），因此会出现在
+// Logger、SlogHandler 的每条日志、队列任务载荷和 Telescope 记录中；
+// 同时写回请求的 X-Request-Id 请求头，并在响应头中返回。
+//
+//	http.ListenAndServe(":8080", appcontext.RequestIDMiddleware(mux))
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(HeaderRequestID)
+		if !validRequestID(id) {
+			id = str.UUID()
+		}
+		ctx := Ensure(r.Context())
+		Add(ctx, KeyRequestID, id)
+
+		r = r.WithContext(ctx)
+		r.Header.Set(HeaderRequestID, id)
+		w.Header().Set(HeaderRequestID, id)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequestIDTransport 把上下文中的请求 ID 写入发出请求的 X-Request-Id 请求头，用于跨服务关联
+//
+// 签名与 httpclient.Middleware 相同，可以直接传给 Factory.GlobalMiddleware；
+// 请求已经带有该请求头或上下文中没有请求 ID 时不做修改。
+func RequestIDTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		id := RequestID(req.Context())
+		if id == "" || req.Header.Get(HeaderRequestID) != "" {
+			return next.RoundTrip(req)
+		}
+		req = req.Clone(req.Context())
+		req.Header.Set(HeaderRequestID, id)
+		return next.RoundTrip(req)
+	})
+}
+
+// roundTripperFunc 函数形式的 http.RoundTripper
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip 实现 http.RoundTripper
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// validRequestID 外部请求 ID 是否非空、不超过 128 个字符且只包含字母、数字和 "-_.:"，
+// 避免把任意内容写入日志和响应头
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == ':') {
+			return false
+		}
+	}
+	return true
+}
@@ -11,6 +11,10 @@
 // - 多驱动缓存系统
 // - 完整的命令行工具支持
 // - HTTP 和控制台内核管理
+// - HTTP 服务器：TLS/ALPN、HTTP/3 适配器、端口复用和优雅关闭
+// - 日志通道和缓存存储配置的函数式选项构造（NewLogChannelConfig、NewCacheStoreConfig）
+// - 启动计时：引导程序和服务提供者的 Register、Boot 耗时报告（BootProfiler、BootReport）
+// - EventServiceProvider：按 Handle(event) 方法的参数类型自动发现监听器（DiscoverEventsWithin）
 //
 // 使用示例：
 //
@@ -57,6 +61,7 @@ package application
 
 import (
 	"context"
+	"os"
 	"time"
 
 	"github.com/cnote0/laraveldoc/container"
@@ -181,6 +186,44 @@ type Application interface {
 	//   app.SetDebug(false) // 禁用调试
 	SetDebug(debug bool)
 
+	// GetLocale 获取当前语言
+	//
+	// 返回应用程序当前使用的语言，对应 config/app.php 的 locale。
+	//
+	// 示例：
+	//   locale := app.GetLocale() // "en"
+	GetLocale() string
+
+	// SetLocale 设置当前语言
+	//
+	// 设置应用程序当前使用的语言，同时更新容器中 translator 的语言。
+	//
+	// 示例：
+	//   app.SetLocale("zh_CN")
+	SetLocale(locale string)
+
+	// GetFallbackLocale 获取备用语言
+	//
+	// 当前语言缺少翻译时使用的语言，对应 config/app.php 的 fallback_locale。
+	//
+	// 示例：
+	//   fallback := app.GetFallbackLocale() // "en"
+	GetFallbackLocale() string
+
+	// SetFallbackLocale 设置备用语言
+	//
+	// 示例：
+	//   app.SetFallbackLocale("en")
+	SetFallbackLocale(locale string)
+
+	// IsLocale 检查当前语言是否为指定语言
+	//
+	// 示例：
+	//   if app.IsLocale("zh_CN") {
+	//       // 中文特定逻辑
+	//   }
+	IsLocale(locale string) bool
+
 	// BasePath 获取应用根路径
 	//
 	// 返回应用程序的根目录路径，可以拼接子路径。
@@ -290,7 +333,7 @@ type Application interface {
 
 	// GetProviders 获取已注册的服务提供者
 	//
-	// 返回指定类型的所有已注册服务提供者实例。
+	// 返回指定类型的所有已注册服务提供者实例，provider 为 nil 时返回所有已注册的服务提供者。
 	//
 	// 示例：
 	//   provider := &DatabaseServiceProvider{}
@@ -320,7 +363,7 @@ type Application interface {
 
 	// Terminate 终止应用程序
 	//
-	// 优雅地关闭应用程序，清理资源。
+	// 优雅地关闭应用程序，清理资源，并按注册顺序调用 Terminating 注册的回调。
 	//
 	// 示例：
 	//   defer func() {
@@ -331,6 +374,18 @@ type Application interface {
 	//   }()
 	Terminate() error
 
+	// Terminating 注册应用终止时调用的回调
+	//
+	// 回调在 Terminate 中按注册顺序调用，回调返回的错误合并后由 Terminate 返回。
+	// 服务提供者通常在 Boot 中注册回调，清理进程级的状态。
+	//
+	// 示例：
+	//   app.Terminating(func() error {
+	//       facade.DefaultRealtime().ClearSwaps()
+	//       return nil
+	//   })
+	Terminating(callback func() error)
+
 	// GetNamespace 获取应用命名空间
 	//
 	// 返回应用程序的命名空间，用于类和服务的自动解析。
@@ -453,6 +508,15 @@ type InputInterface interface {
 	SetInteractive(interactive bool)
 }
 
+// 输出详细程度，与 Symfony Console 的取值一致
+const (
+	VerbosityQuiet       = 16
+	VerbosityNormal      = 32
+	VerbosityVerbose     = 64
+	VerbosityVeryVerbose = 128
+	VerbosityDebug       = 256
+)
+
 // OutputInterface 输出接口
 type OutputInterface interface {
 	// Write 写入内容
@@ -561,6 +625,33 @@ type InputArgument struct {
 	Default interface{}
 }
 
+// 参数模式，与 Symfony Console 的 InputArgument 常量一致
+const (
+	// InputArgumentRequired 参数必须提供
+	InputArgumentRequired = 1
+
+	// InputArgumentOptional 参数可选
+	InputArgumentOptional = 2
+
+	// InputArgumentIsArray 参数接收剩余的所有值
+	InputArgumentIsArray = 4
+)
+
+// 选项模式，与 Symfony Console 的 InputOption 常量一致
+const (
+	// InputOptionValueNone 选项不接受值（开关）
+	InputOptionValueNone = 1
+
+	// InputOptionValueRequired 选项必须带值
+	InputOptionValueRequired = 2
+
+	// InputOptionValueOptional 选项的值可选
+	InputOptionValueOptional = 4
+
+	// InputOptionValueIsArray 选项可以多次指定
+	InputOptionValueIsArray = 8
+)
+
 // InputOption 输入选项
 type InputOption struct {
 	// Name 选项名
@@ -644,6 +735,10 @@ type ArtisanInterface interface {
 	// Register 注册命令
 	Register(name string) CommandInterface
 
+	// Command 按 Laravel 风格的签名注册闭包命令，例如 "mail:send {user} {--queue=}"，
+	// 签名语法见 console.ParseSignature，签名无效时 panic
+	Command(signature string, code func(input InputInterface, output OutputInterface) error) CommandInterface
+
 	// AddCommands 添加多个命令
 	AddCommands(commands []CommandInterface) error
 
@@ -810,6 +905,21 @@ type CommandInterface interface {
 	IsEnabled() bool
 }
 
+// SignalableCommand 可以处理进程信号的命令，对应 Symfony 的 SignalableCommandInterface
+//
+// 控制台内核在执行实现了该接口的命令时监听 GetSubscribedSignals 返回的信号，
+// 收到信号时调用 HandleSignal，命令结束后取消监听。长时间运行的命令（队列 Worker、调度器）
+// 可以借此在 SIGTERM、SIGINT 时停止接收新任务并清理资源。
+type SignalableCommand interface {
+	CommandInterface
+
+	// GetSubscribedSignals 需要处理的信号
+	GetSubscribedSignals() []os.Signal
+
+	// HandleSignal 处理信号，在独立的 goroutine 中按收到的顺序调用
+	HandleSignal(signal os.Signal)
+}
+
 // HelperSetInterface 帮助集接口
 type HelperSetInterface interface {
 	// Set 设置帮助器
@@ -882,6 +992,9 @@ type EventDispatcher interface {
 // EventListener 事件监听器
 type EventListener func(event interface{}) error
 
+// ContextEventListener 接收上下文的事件监听器，可以把请求的截止时间和追踪信息传给数据库、队列等调用
+type ContextEventListener func(ctx context.Context, event interface{}) error
+
 // EventSubscriber 事件订阅者接口
 type EventSubscriber interface {
 	// GetSubscribedEvents 获取订阅的事件
@@ -1004,6 +1117,9 @@ type CacheManager interface {
 
 // CacheStore 缓存存储接口
 type CacheStore interface {
+	// WithContext 返回之后的操作使用 ctx 的缓存存储，用于传递截止时间和追踪信息
+	WithContext(ctx context.Context) CacheStore
+
 	// Get 获取缓存
 	Get(key string) (interface{}, error)
 
@@ -0,0 +1,237 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// BootPhase 启动阶段
+type BootPhase string
+
+const (
+	// PhaseBootstrap 引导程序的 Bootstrap
+	PhaseBootstrap BootPhase = "bootstrap"
+
+	// PhaseRegister 服务提供者的 Register
+	PhaseRegister BootPhase = "register"
+
+	// PhaseBoot 服务提供者的 Boot
+	PhaseBoot BootPhase = "boot"
+)
+
+// BootTiming 一个引导程序或服务提供者在一个阶段的耗时
+type BootTiming struct {
+	// Phase 阶段
+	Phase BootPhase `json:"phase"`
+
+	// Name 引导程序的 Name() 或服务提供者的类型名，例如 "*providers.RouteServiceProvider"
+	Name string `json:"name"`
+
+	// Started 开始时间
+	Started time.Time `json:"started"`
+
+	// Duration 耗时
+	Duration time.Duration `json:"duration"`
+
+	// Error 返回的错误信息
+	Error string `json:"error,omitempty"`
+}
+
+// BootReport 应用启动耗时报告，Timings 按开始顺序排列
+type BootReport struct {
+	Timings []BootTiming `json:"timings"`
+}
+
+// Total 所有阶段的总耗时
+func (r BootReport) Total() time.Duration {
+	var total time.Duration
+	for _, timing := range r.Timings {
+		total += timing.Duration
+	}
+	return total
+}
+
+// Phase 指定阶段的耗时
+func (r BootReport) Phase(phase BootPhase) []BootTiming {
+	var timings []BootTiming
+	for _, timing := range r.Timings {
+		if timing.Phase == phase {
+			timings = append(timings, timing)
+		}
+	}
+	return timings
+}
+
+// Slowest 耗时最长的 n 项，n 小于等于 0 时返回全部，按耗时从长到短排列
+func (r BootReport) Slowest(n int) []BootTiming {
+	timings := append([]BootTiming(nil), r.Timings...)
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].Duration > timings[j].Duration
+	})
+	if n > 0 && n < len(timings) {
+		timings = timings[:n]
+	}
+	return timings
+}
+
+// Exceeding 耗时超过 threshold 的项，按开始顺序排列
+func (r BootReport) Exceeding(threshold time.Duration) []BootTiming {
+	var timings []BootTiming
+	for _, timing := range r.Timings {
+		if timing.Duration > threshold {
+			timings = append(timings, timing)
+		}
+	}
+	return timings
+}
+
+// BootProfiler 记录引导程序和服务提供者各阶段的耗时
+//
+// Application 的实现在 BootstrapWith、RegisterProvider 和 BootProviders 中通过 Measure、
+// MeasureBootstrapper 和 MeasureProvider 计时，大型应用据此找到拖慢启动的服务提供者。
+// 耗时超过 Threshold 的项以 warning 级别写入 Logger。
+type BootProfiler struct {
+	// Threshold 记录慢启动日志的阈值，为 0 时使用 DefaultBootThreshold
+	Threshold time.Duration
+
+	// Logger 慢启动日志，为空时不记录日志，只收集报告
+	Logger LoggerInterface
+
+	mu      sync.Mutex
+	timings []BootTiming
+	now     func() time.Time
+}
+
+// ProfiledApplication 提供启动计时器的应用，是 Application 之外的可选接口
+//
+// BootstrapWith、RegisterProvider 和 BootProviders 通过计时器记录每个引导程序
+// 和服务提供者的耗时，超过阈值的项写入日志；app:boot-report 命令输出报告。
+//
+// 示例：
+//
+//	for _, timing := range application.BootProfilerOf(app).Report().Slowest(5) {
+//		fmt.Printf("%-8s %-50s %s\n", timing.Phase, timing.Name, timing.Duration)
+//	}
+type ProfiledApplication interface {
+	Application
+
+	// BootProfiler 获取启动计时器
+	BootProfiler() *BootProfiler
+}
+
+// BootProfilerOf 应用的启动计时器，app 没有实现 ProfiledApplication 时返回 nil，
+// nil 计时器的 Report 返回空报告
+func BootProfilerOf(app Application) *BootProfiler {
+	if profiled, ok := app.(ProfiledApplication); ok {
+		return profiled.BootProfiler()
+	}
+	return nil
+}
+
+// DefaultBootThreshold 默认的慢启动阈值
+const DefaultBootThreshold = 100 * time.Millisecond
+
+// NewBootProfiler 创建启动计时器，threshold 为 0 时使用 DefaultBootThreshold
+func NewBootProfiler(threshold time.Duration, logger LoggerInterface) *BootProfiler {
+	return &BootProfiler{Threshold: threshold, Logger: logger}
+}
+
+// Measure 执行 fn 并记录阶段和名称的耗时，返回 fn 的错误；p 为 nil 时只执行 fn
+func (p *BootProfiler) Measure(phase BootPhase, name string, fn func() error) error {
+	if p == nil {
+		return fn()
+	}
+	now := p.clock()
+	started := now()
+	err := fn()
+	timing := BootTiming{Phase: phase, Name: name, Started: started, Duration: now().Sub(started)}
+	if err != nil {
+		timing.Error = err.Error()
+	}
+	p.mu.Lock()
+	p.timings = append(p.timings, timing)
+	p.mu.Unlock()
+	p.logSlow(timing)
+	return err
+}
+
+// MeasureBootstrapper 执行引导程序并计时
+func (p *BootProfiler) MeasureBootstrapper(app Application, bootstrapper Bootstrapper) error {
+	return p.Measure(PhaseBootstrap, bootstrapper.Name(), func() error {
+		return bootstrapper.Bootstrap(app)
+	})
+}
+
+// MeasureProvider 执行服务提供者的 Register 或 Boot 并计时
+func (p *BootProfiler) MeasureProvider(phase BootPhase, provider container.ServiceProvider, c container.Container) error {
+	return p.Measure(phase, ProviderName(provider), func() error {
+		if phase == PhaseBoot {
+			return provider.Boot(c)
+		}
+		return provider.Register(c)
+	})
+}
+
+// ProviderName 报告中服务提供者的名称，为类型名
+func ProviderName(provider container.ServiceProvider) string {
+	return fmt.Sprintf("%T", provider)
+}
+
+// Report 到目前为止的耗时报告
+func (p *BootProfiler) Report() BootReport {
+	if p == nil {
+		return BootReport{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return BootReport{Timings: append([]BootTiming(nil), p.timings...)}
+}
+
+// Reset 清空记录，重新启动前调用
+func (p *BootProfiler) Reset() {
+	p.mu.Lock()
+	p.timings = nil
+	p.mu.Unlock()
+}
+
+// SetClock 设置计时使用的时钟，nil 恢复为 time.Now，用于测试
+func (p *BootProfiler) SetClock(now func() time.Time) {
+	p.mu.Lock()
+	p.now = now
+	p.mu.Unlock()
+}
+
+// clock 计时使用的时钟
+func (p *BootProfiler) clock() func() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.now == nil {
+		return time.Now
+	}
+	return p.now
+}
+
+// threshold 慢启动阈值
+func (p *BootProfiler) threshold() time.Duration {
+	if p.Threshold > 0 {
+		return p.Threshold
+	}
+	return DefaultBootThreshold
+}
+
+// logSlow 耗时超过阈值时记录 warning 日志
+func (p *BootProfiler) logSlow(timing BootTiming) {
+	if p.Logger == nil || timing.Duration <= p.threshold() {
+		return
+	}
+	_ = p.Logger.Warning(fmt.Sprintf("Slow %s: %s took %s", timing.Phase, timing.Name, timing.Duration.Round(time.Microsecond)), map[string]interface{}{
+		"phase":       string(timing.Phase),
+		"name":        timing.Name,
+		"duration_ms": float64(timing.Duration.Microseconds()) / 1000,
+		"threshold":   p.threshold().String(),
+	})
+}
@@ -0,0 +1,154 @@
+package application
+
+import (
+	"fmt"
+	"time"
+)
+
+// CacheStoreConfig 缓存存储配置，对应 config/cache.php 的 stores.* 项
+//
+// 由 NewCacheStoreConfig 创建，ToMap 转换为 CacheManager.Extend 的回调收到的配置。
+type CacheStoreConfig struct {
+	// Driver 驱动名称，例如 "array"、"file"、"database"、"redis"（driver）
+	Driver string
+
+	// Prefix 键前缀，默认 "laraveldoc_cache_"（prefix）
+	Prefix string
+
+	// TTL 不指定过期时间时的默认缓存时间，0 表示永不过期（ttl）
+	TTL time.Duration
+
+	// Path file 的缓存目录，默认 "storage/framework/cache/data"（path）
+	Path string
+
+	// Connection database 和 redis 使用的连接名称，默认 "default"（connection）
+	Connection string
+
+	// Table database 的缓存表名，默认 "cache"（table）
+	Table string
+
+	// LockConnection 原子锁使用的连接，为空时与 Connection 相同（lock_connection）
+	LockConnection string
+}
+
+// CacheOption 缓存存储配置选项
+type CacheOption func(*CacheStoreConfig)
+
+// WithCachePrefix 键前缀
+func WithCachePrefix(prefix string) CacheOption {
+	return func(c *CacheStoreConfig) {
+		c.Prefix = prefix
+	}
+}
+
+// WithCacheTTL 默认缓存时间
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(c *CacheStoreConfig) {
+		c.TTL = ttl
+	}
+}
+
+// WithCachePath file 的缓存目录
+func WithCachePath(path string) CacheOption {
+	return func(c *CacheStoreConfig) {
+		c.Path = path
+	}
+}
+
+// WithCacheConnection database 和 redis 使用的连接，lockConnection 为空时与 connection 相同
+func WithCacheConnection(connection, lockConnection string) CacheOption {
+	return func(c *CacheStoreConfig) {
+		c.Connection = connection
+		c.LockConnection = lockConnection
+	}
+}
+
+// WithCacheTable database 的缓存表名
+func WithCacheTable(table string) CacheOption {
+	return func(c *CacheStoreConfig) {
+		c.Table = table
+	}
+}
+
+// NewCacheStoreConfig 应用选项、补全缺省值并校验缓存存储配置
+//
+// 示例：
+//
+//	redis, err := application.NewCacheStoreConfig("redis",
+//		application.WithCacheConnection("cache", "locks"),
+//		application.WithCacheTTL(time.Hour),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func NewCacheStoreConfig(driver string, opts ...CacheOption) (*CacheStoreConfig, error) {
+	config := &CacheStoreConfig{Driver: driver}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.Prefix == "" {
+		config.Prefix = "laraveldoc_cache_"
+	}
+	switch driver {
+	case "file":
+		if config.Path == "" {
+			config.Path = "storage/framework/cache/data"
+		}
+	case "database", "redis":
+		if config.Connection == "" {
+			config.Connection = "default"
+		}
+		if config.Table == "" && driver == "database" {
+			config.Table = "cache"
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate 校验缓存存储配置，用于直接构造的 CacheStoreConfig
+func (c *CacheStoreConfig) Validate() error {
+	switch {
+	case c.Driver == "":
+		return fmt.Errorf("%w: cache driver is required", ErrInvalidConfig)
+	case c.TTL < 0:
+		return fmt.Errorf("%w: cache ttl must not be negative", ErrInvalidConfig)
+	case c.Driver == "file" && c.Path == "":
+		return fmt.Errorf("%w: file cache store requires a path", ErrInvalidConfig)
+	case c.Driver == "database" && c.Table == "":
+		return fmt.Errorf("%w: database cache store requires a table", ErrInvalidConfig)
+	case c.Path != "" && c.Driver != "file":
+		return fmt.Errorf("%w: path is only used by the file driver, not %s", ErrInvalidConfig, c.Driver)
+	case c.Table != "" && c.Driver != "database":
+		return fmt.Errorf("%w: table is only used by the database driver, not %s", ErrInvalidConfig, c.Driver)
+	case c.LockConnection != "" && c.Connection == "":
+		return fmt.Errorf("%w: lock_connection requires connection", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// ToMap 转换为 CacheManager.Extend 的回调收到的配置，未设置的项省略
+func (c *CacheStoreConfig) ToMap() map[string]interface{} {
+	config := map[string]interface{}{"driver": c.Driver}
+	if c.Prefix != "" {
+		config["prefix"] = c.Prefix
+	}
+	if c.TTL != 0 {
+		config["ttl"] = c.TTL
+	}
+	if c.Path != "" {
+		config["path"] = c.Path
+	}
+	if c.Connection != "" {
+		config["connection"] = c.Connection
+	}
+	if c.Table != "" {
+		config["table"] = c.Table
+	}
+	if c.LockConnection != "" {
+		config["lock_connection"] = c.LockConnection
+	}
+	return config
+}
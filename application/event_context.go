@@ -0,0 +1,27 @@
+package application
+
+import "context"
+
+// ContextEventDispatcher 支持接收上下文的监听器的事件分发器，是 EventDispatcher 之外的可选接口
+//
+// DispatchWithContext 把分发时的 ctx 传给监听器，Dispatch 传入 context.Background()。
+// 注册监听器时通过包级函数 AddContextListener，不需要关心分发器是否实现了本接口。
+type ContextEventDispatcher interface {
+	EventDispatcher
+
+	// AddContextListener 添加接收上下文的监听器
+	AddContextListener(eventName string, listener ContextEventListener, priority int) error
+}
+
+// AddContextListener 向 dispatcher 添加接收上下文的监听器
+//
+// dispatcher 实现了 ContextEventDispatcher 时调用其 AddContextListener；
+// 否则包装为 EventListener 注册，监听器收到 context.Background()。
+func AddContextListener(dispatcher EventDispatcher, eventName string, listener ContextEventListener, priority int) error {
+	if d, ok := dispatcher.(ContextEventDispatcher); ok {
+		return d.AddContextListener(eventName, listener, priority)
+	}
+	return dispatcher.AddListener(eventName, func(event interface{}) error {
+		return listener(context.Background(), event)
+	}, priority)
+}
@@ -0,0 +1,216 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// DiscoveredListener 由 laraveldoc-gen events 生成的清单登记的监听器
+type DiscoveredListener struct {
+	// Package 监听器类型所在包的导入路径
+	Package string
+
+	// Listener 监听器类型名，例如 "SendShipmentNotification"
+	Listener string
+
+	// Event 事件名，为事件类型的 reflect 名称，例如 "*events.OrderShipped"
+	Event string
+
+	// Handle 从容器解析监听器并调用其 Handle 方法，事件类型不符时忽略
+	Handle func(ctx context.Context, c container.Container, event interface{}) error
+}
+
+// discoveredListeners 进程内登记的监听器，按登记顺序排列
+var discoveredListeners struct {
+	mu        sync.RWMutex
+	listeners []DiscoveredListener
+}
+
+// DiscoverListener 登记监听器类型 listener 对事件类型 E 的处理函数，由生成的清单在 init 中调用
+//
+// 登记的监听器只有在 EventServiceProvider.DiscoverEventsWithin 包含其所在包时才注册到事件分发器。
+func DiscoverListener[E any](pkg, listener string, handle func(ctx context.Context, c container.Container, event E) error) {
+	discoveredListeners.mu.Lock()
+	defer discoveredListeners.mu.Unlock()
+	discoveredListeners.listeners = append(discoveredListeners.listeners, DiscoveredListener{
+		Package:  pkg,
+		Listener: listener,
+		Event:    EventNameOf[E](),
+		Handle: func(ctx context.Context, c container.Container, event interface{}) error {
+			typed, ok := event.(E)
+			if !ok {
+				return nil
+			}
+			return handle(ctx, c, typed)
+		},
+	})
+}
+
+// DiscoveredListeners 所在包与 packages 匹配的已登记监听器
+//
+// packages 为导入路径，以 "/..." 结尾时同时匹配子包，例如 "example.com/shop/app/listeners/..."。
+func DiscoveredListeners(packages ...string) []DiscoveredListener {
+	discoveredListeners.mu.RLock()
+	defer discoveredListeners.mu.RUnlock()
+	var listeners []DiscoveredListener
+	for _, listener := range discoveredListeners.listeners {
+		for _, pattern := range packages {
+			if matchPackage(pattern, listener.Package) {
+				listeners = append(listeners, listener)
+				break
+			}
+		}
+	}
+	return listeners
+}
+
+// matchPackage 导入路径是否与模式匹配
+func matchPackage(pattern, pkg string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return pkg == prefix || strings.HasPrefix(pkg, prefix+"/")
+	}
+	return pkg == pattern
+}
+
+// EventNameOf 事件类型 E 的事件名，与分发器在 eventName 为空时使用的名称一致，例如 "*events.OrderShipped"
+func EventNameOf[E any]() string {
+	return reflect.TypeOf((*E)(nil)).Elem().String()
+}
+
+// MakeListener 创建监听器，容器中以 "*pkg.Type" 绑定了监听器时从容器解析，否则返回零值
+//
+// 需要注入依赖的监听器在服务提供者中绑定：
+//
+//	c.Bind(application.EventNameOf[*listeners.SendShipmentNotification](), func(c container.Container) interface{} {
+//		return &listeners.SendShipmentNotification{Mailer: c.MustMake("mailer").(mail.Mailer)}
+//	}, false)
+func MakeListener[T any](c container.Container) *T {
+	abstract := EventNameOf[*T]()
+	if c != nil && c.Bound(abstract) {
+		if instance, err := c.Make(abstract); err == nil {
+			if listener, ok := instance.(*T); ok {
+				return listener
+			}
+		}
+	}
+	return new(T)
+}
+
+// EventServiceProvider 注册事件监听器和订阅者，对应 Laravel 的 EventServiceProvider
+//
+// Listen 为手动登记的监听器；DiscoverEventsWithin 指定的包中的监听器从生成的清单自动注册，
+// 监听器的 Handle(event *events.OrderShipped) 或 Handle(ctx, event *events.OrderShipped) 方法
+// 决定监听的事件，不再需要手动维护 Listen。清单由 laraveldoc-gen events 生成：
+//
+//	//go:generate go run github.com/cnote0/laraveldoc/cmd/laraveldoc-gen events -output events_manifest.go ../listeners/...
+//
+//	provider := (&application.EventServiceProvider{}).DiscoverEventsWithin("example.com/shop/app/listeners/...")
+//	app.RegisterProvider(provider, false)
+type EventServiceProvider struct {
+	// Listen 事件名到监听器的映射
+	Listen map[string][]EventListener
+
+	// Subscribe 事件订阅者
+	Subscribe []EventSubscriber
+
+	// Discover 自动发现监听器的包的导入路径，以 "/..." 结尾时包括子包
+	Discover []string
+
+	// Aliases 发现的事件类型名到分发时使用的事件名的映射，
+	// 用于以固定名称分发的事件，例如 {"*verification.Verified": verification.EventVerified}
+	Aliases map[string]string
+}
+
+// DiscoverEventsWithin 在指定的包中自动发现监听器
+func (p *EventServiceProvider) DiscoverEventsWithin(packages ...string) *EventServiceProvider {
+	p.Discover = append(p.Discover, packages...)
+	return p
+}
+
+// Register 无需注册服务
+func (p *EventServiceProvider) Register(c container.Container) error {
+	return nil
+}
+
+// Boot 向容器中的 "events" 分发器注册监听器、订阅者和发现的监听器
+func (p *EventServiceProvider) Boot(c container.Container) error {
+	instance, err := c.Make("events")
+	if err != nil {
+		return err
+	}
+	dispatcher, ok := instance.(EventDispatcher)
+	if !ok {
+		return nil
+	}
+	for _, event := range sortedKeys(p.Listen) {
+		for _, listener := range p.Listen[event] {
+			if err := dispatcher.AddListener(event, listener, 0); err != nil {
+				return err
+			}
+		}
+	}
+	for _, subscriber := range p.Subscribe {
+		if err := dispatcher.AddSubscriber(subscriber); err != nil {
+			return err
+		}
+	}
+	for _, discovered := range DiscoveredListeners(p.Discover...) {
+		handle := discovered.Handle
+		listener := func(ctx context.Context, event interface{}) error {
+			return handle(ctx, c, event)
+		}
+		if err := AddContextListener(dispatcher, p.eventName(discovered.Event), listener, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Events 事件名到监听器名称的映射，手动登记的监听器为函数名，发现的监听器为 "导入路径.类型名"，用于诊断
+func (p *EventServiceProvider) Events() map[string][]string {
+	events := make(map[string][]string)
+	for event, listeners := range p.Listen {
+		for _, listener := range listeners {
+			events[event] = append(events[event], runtime.FuncForPC(reflect.ValueOf(listener).Pointer()).Name())
+		}
+	}
+	for _, discovered := range DiscoveredListeners(p.Discover...) {
+		name := p.eventName(discovered.Event)
+		events[name] = append(events[name], discovered.Package+"."+discovered.Listener)
+	}
+	return events
+}
+
+// eventName 发现的事件类型名对应的事件名
+func (p *EventServiceProvider) eventName(event string) string {
+	if alias, ok := p.Aliases[event]; ok {
+		return alias
+	}
+	return event
+}
+
+// sortedKeys 排序后的键，保证注册顺序稳定
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Provides 不提供延迟加载的服务
+func (p *EventServiceProvider) Provides() []string {
+	return nil
+}
+
+// IsDeferred 不延迟加载
+func (p *EventServiceProvider) IsDeferred() bool {
+	return false
+}
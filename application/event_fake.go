@@ -0,0 +1,242 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// TestingT EventFake 使用的测试接口，*testing.T 和 *testing.B 都满足
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// DispatchedEvent EventFake 记录的事件
+type DispatchedEvent struct {
+	// Name 事件名称，分发时未指定名称则为事件的类型名
+	Name  string
+	Event interface{}
+}
+
+// EventFake 测试用的事件分发器，对应 Laravel 的 Event::fake
+//
+// 被伪造的事件只记录，不会调用监听器。指定了伪造的事件名称时，其余事件交给原分发器处理。
+// 监听器的注册和查询始终交给原分发器。
+type EventFake struct {
+	t      TestingT
+	next   EventDispatcher
+	events map[string]bool
+
+	mu         sync.Mutex
+	dispatched []*DispatchedEvent
+}
+
+// FakeEvents 把容器中的 "events" 替换为 EventFake，测试结束时恢复原分发器
+//
+// 已经解析并缓存了分发器的门面（例如 facades.Event）同时替换为 EventFake。
+//
+// eventNames 为要伪造的事件名称，不传时伪造全部事件。
+//
+// 使用示例：
+//
+//	func TestRegistration(t *testing.T) {
+//		events := application.FakeEvents(t, app, "user.registered")
+//		// 执行注册
+//		events.AssertDispatched("user.registered", func(e *application.DispatchedEvent) bool {
+//			return e.Event.(*UserRegistered).Email == "taylor@example.com"
+//		})
+//		events.AssertNotDispatched("user.deleted")
+//	}
+func FakeEvents(t TestingT, c container.Container, eventNames ...string) *EventFake {
+	t.Helper()
+	var previous EventDispatcher
+	if c.Bound("events") {
+		resolved, err := c.Make("events")
+		if err != nil {
+			t.Errorf("application: resolve events: %v", err)
+		}
+		previous, _ = resolved.(EventDispatcher)
+	}
+	fake := NewEventFake(t, previous, eventNames...)
+	if err := c.Instance("events", fake); err != nil {
+		t.Errorf("application: swap events: %v", err)
+	}
+	t.Cleanup(func() {
+		if previous != nil {
+			_ = c.Instance("events", previous)
+		}
+	})
+	SwapFacade(t, "events", fake)
+	return fake
+}
+
+// NewEventFake 创建 EventFake
+//
+// next 处理未伪造的事件和监听器注册，可以为 nil；eventNames 为空时伪造全部事件。
+func NewEventFake(t TestingT, next EventDispatcher, eventNames ...string) *EventFake {
+	fake := &EventFake{t: t, next: next}
+	if len(eventNames) > 0 {
+		fake.events = make(map[string]bool, len(eventNames))
+		for _, name := range eventNames {
+			fake.events[name] = true
+		}
+	}
+	return fake
+}
+
+// Dispatch 记录事件，返回事件本身
+func (f *EventFake) Dispatch(event interface{}, eventName string) interface{} {
+	return f.DispatchWithContext(context.Background(), event, eventName)
+}
+
+// DispatchWithContext 记录事件，返回事件本身
+func (f *EventFake) DispatchWithContext(ctx context.Context, event interface{}, eventName string) interface{} {
+	name := eventName
+	if name == "" && event != nil {
+		name = reflect.TypeOf(event).String()
+	}
+	if !f.shouldFake(name) {
+		return f.next.DispatchWithContext(ctx, event, eventName)
+	}
+	f.mu.Lock()
+	f.dispatched = append(f.dispatched, &DispatchedEvent{Name: name, Event: event})
+	f.mu.Unlock()
+	return event
+}
+
+// AddListener 添加原分发器的监听器
+func (f *EventFake) AddListener(eventName string, listener EventListener, priority int) error {
+	if f.next == nil {
+		return nil
+	}
+	return f.next.AddListener(eventName, listener, priority)
+}
+
+// AddContextListener 添加原分发器接收上下文的监听器，实现 ContextEventDispatcher
+func (f *EventFake) AddContextListener(eventName string, listener ContextEventListener, priority int) error {
+	if f.next == nil {
+		return nil
+	}
+	return AddContextListener(f.next, eventName, listener, priority)
+}
+
+// AddSubscriber 添加原分发器的订阅者
+func (f *EventFake) AddSubscriber(subscriber EventSubscriber) error {
+	if f.next == nil {
+		return nil
+	}
+	return f.next.AddSubscriber(subscriber)
+}
+
+// RemoveListener 移除原分发器的监听器
+func (f *EventFake) RemoveListener(eventName string, listener EventListener) error {
+	if f.next == nil {
+		return nil
+	}
+	return f.next.RemoveListener(eventName, listener)
+}
+
+// RemoveSubscriber 移除原分发器的订阅者
+func (f *EventFake) RemoveSubscriber(subscriber EventSubscriber) error {
+	if f.next == nil {
+		return nil
+	}
+	return f.next.RemoveSubscriber(subscriber)
+}
+
+// GetListeners 获取原分发器的监听器
+func (f *EventFake) GetListeners(eventName string) []EventListener {
+	if f.next == nil {
+		return nil
+	}
+	return f.next.GetListeners(eventName)
+}
+
+// GetListenerPriority 获取原分发器中监听器的优先级
+func (f *EventFake) GetListenerPriority(eventName string, listener EventListener) (int, error) {
+	if f.next == nil {
+		return 0, nil
+	}
+	return f.next.GetListenerPriority(eventName, listener)
+}
+
+// HasListeners 原分发器中是否有监听器
+func (f *EventFake) HasListeners(eventName string) bool {
+	return f.next != nil && f.next.HasListeners(eventName)
+}
+
+// Dispatched 返回名称为 eventName 且满足条件的已分发事件，eventName 为空时不按名称过滤
+func (f *EventFake) Dispatched(eventName string, filter ...func(e *DispatchedEvent) bool) []*DispatchedEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []*DispatchedEvent
+	for _, dispatched := range f.dispatched {
+		if eventName != "" && dispatched.Name != eventName {
+			continue
+		}
+		if len(filter) > 0 && filter[0] != nil && !filter[0](dispatched) {
+			continue
+		}
+		matched = append(matched, dispatched)
+	}
+	return matched
+}
+
+// AssertDispatched 断言分发过名称为 eventName 的事件，可选的 filter 进一步筛选
+func (f *EventFake) AssertDispatched(eventName string, filter ...func(e *DispatchedEvent) bool) bool {
+	f.t.Helper()
+	if len(f.Dispatched(eventName, filter...)) == 0 {
+		f.t.Errorf("application: the expected [%s] event was not dispatched", eventName)
+		return false
+	}
+	return true
+}
+
+// AssertDispatchedTimes 断言名称为 eventName 的事件分发了 times 次
+func (f *EventFake) AssertDispatchedTimes(eventName string, times int) bool {
+	f.t.Helper()
+	if n := len(f.Dispatched(eventName)); n != times {
+		f.t.Errorf("application: the expected [%s] event was dispatched [%d] times instead of [%d] times", eventName, n, times)
+		return false
+	}
+	return true
+}
+
+// AssertNotDispatched 断言没有分发过名称为 eventName 且满足条件的事件
+func (f *EventFake) AssertNotDispatched(eventName string, filter ...func(e *DispatchedEvent) bool) bool {
+	f.t.Helper()
+	if len(f.Dispatched(eventName, filter...)) > 0 {
+		f.t.Errorf("application: the unexpected [%s] event was dispatched", eventName)
+		return false
+	}
+	return true
+}
+
+// AssertNothingDispatched 断言没有分发任何被伪造的事件
+func (f *EventFake) AssertNothingDispatched() bool {
+	f.t.Helper()
+	if n := len(f.Dispatched("")); n > 0 {
+		f.t.Errorf("application: [%d] unexpected events were dispatched", n)
+		return false
+	}
+	return true
+}
+
+// AssertListening 断言原分发器中 eventName 有监听器
+func (f *EventFake) AssertListening(eventName string) bool {
+	f.t.Helper()
+	if !f.HasListeners(eventName) {
+		f.t.Errorf("application: event [%s] does not have any listeners", eventName)
+		return false
+	}
+	return true
+}
+
+// shouldFake 事件是否被伪造，没有原分发器时伪造全部事件
+func (f *EventFake) shouldFake(eventName string) bool {
+	return f.next == nil || f.events == nil || f.events[eventName]
+}
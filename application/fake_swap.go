@@ -0,0 +1,28 @@
+package application
+
+import "sync/atomic"
+
+// FacadeSwapper 按访问器替换门面底层服务的函数，返回撤销这次替换的函数
+type FacadeSwapper func(accessor string, instance interface{}) (restore func())
+
+// facadeSwapper facade 包初始化时注册的替换函数
+//
+// facade 导入了 application，application 不能反过来导入 facade，因此由 facade 在 init 中注册。
+var facadeSwapper atomic.Value
+
+// RegisterFacadeSwapper 注册替换门面底层服务的函数，由 facade 包在 init 中调用
+func RegisterFacadeSwapper(swapper FacadeSwapper) {
+	facadeSwapper.Store(swapper)
+}
+
+// SwapFacade 测试期间把访问器为 accessor 的门面的底层服务替换为 instance，测试结束时撤销
+//
+// 各包的 Fake 在替换默认管理器或容器绑定之外调用它，使已经解析并缓存了底层服务的门面（例如 facades.Queue）也看到假服务。
+// 程序没有导入 facade 包时不存在门面，不做处理。
+func SwapFacade(t TestingT, accessor string, instance interface{}) {
+	swapper, _ := facadeSwapper.Load().(FacadeSwapper)
+	if swapper == nil {
+		return
+	}
+	t.Cleanup(swapper(accessor, instance))
+}
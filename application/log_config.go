@@ -0,0 +1,154 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidConfig 日志通道或缓存存储配置无效，NewLogChannelConfig、NewCacheStoreConfig 和 Validate 返回的错误满足 errors.Is
+var ErrInvalidConfig = errors.New("application: invalid config")
+
+// LogLevels RFC 5424 日志级别，从高到低
+var LogLevels = []string{"emergency", "alert", "critical", "error", "warning", "notice", "info", "debug"}
+
+// LogChannelConfig 日志通道配置，对应 config/logging.php 的 channels.* 项
+//
+// 由 NewLogChannelConfig 创建，ToMap 转换为 LogManager.Build 和 Extend 的回调收到的配置。
+type LogChannelConfig struct {
+	// Driver 驱动名称，例如 "single"、"daily"、"stack"、"stderr"、"syslog"（driver）
+	Driver string
+
+	// Level 记录的最低级别，默认 "debug"（level）
+	Level string
+
+	// Path 日志文件路径，single 和 daily 默认 "storage/logs/laraveldoc.log"（path）
+	Path string
+
+	// Days daily 保留的天数，默认 14（days）
+	Days int
+
+	// Channels stack 汇总的通道名称（channels）
+	Channels []string
+
+	// IgnoreExceptions stack 中的通道写入失败时不返回错误（ignore_exceptions）
+	IgnoreExceptions bool
+}
+
+// LogOption 日志通道配置选项
+type LogOption func(*LogChannelConfig)
+
+// WithLogLevel 记录的最低级别
+func WithLogLevel(level string) LogOption {
+	return func(c *LogChannelConfig) {
+		c.Level = level
+	}
+}
+
+// WithLogPath 日志文件路径
+func WithLogPath(path string) LogOption {
+	return func(c *LogChannelConfig) {
+		c.Path = path
+	}
+}
+
+// WithLogDays daily 保留的天数
+func WithLogDays(days int) LogOption {
+	return func(c *LogChannelConfig) {
+		c.Days = days
+	}
+}
+
+// WithLogChannels stack 汇总的通道
+func WithLogChannels(channels ...string) LogOption {
+	return func(c *LogChannelConfig) {
+		c.Channels = channels
+	}
+}
+
+// WithLogIgnoreExceptions stack 中的通道写入失败时不返回错误
+func WithLogIgnoreExceptions() LogOption {
+	return func(c *LogChannelConfig) {
+		c.IgnoreExceptions = true
+	}
+}
+
+// NewLogChannelConfig 应用选项、补全缺省值并校验日志通道配置
+//
+// 示例：
+//
+//	daily, err := application.NewLogChannelConfig("daily", application.WithLogLevel("info"), application.WithLogDays(30))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	logger := logs.Build(daily.ToMap())
+func NewLogChannelConfig(driver string, opts ...LogOption) (*LogChannelConfig, error) {
+	config := &LogChannelConfig{Driver: driver}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.Level == "" {
+		config.Level = "debug"
+	}
+	if config.Path == "" && (driver == "single" || driver == "daily") {
+		config.Path = "storage/logs/laraveldoc.log"
+	}
+	if config.Days == 0 && driver == "daily" {
+		config.Days = 14
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate 校验日志通道配置，用于直接构造的 LogChannelConfig
+func (c *LogChannelConfig) Validate() error {
+	switch {
+	case c.Driver == "":
+		return fmt.Errorf("%w: log driver is required", ErrInvalidConfig)
+	case c.Level != "" && !validLogLevel(c.Level):
+		return fmt.Errorf("%w: unknown log level %q", ErrInvalidConfig, c.Level)
+	case (c.Driver == "single" || c.Driver == "daily") && c.Path == "":
+		return fmt.Errorf("%w: %s log channel requires a path", ErrInvalidConfig, c.Driver)
+	case c.Days < 0:
+		return fmt.Errorf("%w: log days must not be negative", ErrInvalidConfig)
+	case c.Days > 0 && c.Driver != "daily":
+		return fmt.Errorf("%w: days is only used by the daily driver, not %s", ErrInvalidConfig, c.Driver)
+	case c.Driver == "stack" && len(c.Channels) == 0:
+		return fmt.Errorf("%w: stack log channel requires channels", ErrInvalidConfig)
+	case c.Driver != "stack" && (len(c.Channels) > 0 || c.IgnoreExceptions):
+		return fmt.Errorf("%w: channels and ignore_exceptions are only used by the stack driver, not %s", ErrInvalidConfig, c.Driver)
+	}
+	return nil
+}
+
+// validLogLevel 是否为 LogLevels 中的级别
+func validLogLevel(level string) bool {
+	for _, l := range LogLevels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// ToMap 转换为 LogManager.Build 接受的配置，未设置的项省略
+func (c *LogChannelConfig) ToMap() map[string]interface{} {
+	config := map[string]interface{}{"driver": c.Driver}
+	if c.Level != "" {
+		config["level"] = c.Level
+	}
+	if c.Path != "" {
+		config["path"] = c.Path
+	}
+	if c.Days != 0 {
+		config["days"] = c.Days
+	}
+	if len(c.Channels) > 0 {
+		config["channels"] = c.Channels
+	}
+	if c.IgnoreExceptions {
+		config["ignore_exceptions"] = true
+	}
+	return config
+}
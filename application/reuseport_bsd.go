@@ -0,0 +1,8 @@
+//go:build darwin || freebsd
+
+package application
+
+import "syscall"
+
+// soReusePort BSD 系统的 SO_REUSEPORT 选项值
+const soReusePort = syscall.SO_REUSEPORT
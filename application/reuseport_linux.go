@@ -0,0 +1,6 @@
+//go:build linux && !mips && !mipsle && !mips64 && !mips64le && !sparc64
+
+package application
+
+// soReusePort Linux 的 SO_REUSEPORT 选项值，syscall 包中没有定义
+const soReusePort = 0xf
@@ -0,0 +1,6 @@
+//go:build linux && (mips || mipsle || mips64 || mips64le || sparc64)
+
+package application
+
+// soReusePort Linux MIPS 和 SPARC64 的 SO_REUSEPORT 选项值，与其他架构不同
+const soReusePort = 0x200
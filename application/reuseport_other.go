@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd
+
+package application
+
+import (
+	"errors"
+	"syscall"
+)
+
+// reusePort 当前平台不支持 SO_REUSEPORT
+func reusePort(network, address string, conn syscall.RawConn) error {
+	return errors.New("application: SO_REUSEPORT is not supported on this platform")
+}
@@ -0,0 +1,20 @@
+//go:build linux || darwin || freebsd
+
+package application
+
+import "syscall"
+
+// reusePort 为监听套接字设置 SO_REUSEADDR 和 SO_REUSEPORT
+func reusePort(network, address string, conn syscall.RawConn) error {
+	var sockErr error
+	err := conn.Control(func(fd uintptr) {
+		if sockErr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1); sockErr != nil {
+			return
+		}
+		sockErr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, soReusePort, 1)
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
@@ -0,0 +1,440 @@
+package application
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrServerRunning 服务器已经在运行
+var ErrServerRunning = errors.New("application: server is already running")
+
+// ServerConfig HTTP 服务器配置，对应配置中的 server.* 项
+type ServerConfig struct {
+	// Addr 监听地址，默认 ":8080"（server.addr）
+	Addr string
+
+	// CertFile、KeyFile TLS 证书和私钥文件，都设置时启用 HTTPS 和 HTTP/2（server.tls.cert、server.tls.key）
+	CertFile string
+	KeyFile  string
+
+	// TLSConfig 自定义 TLS 配置，优先于证书文件中未设置的部分
+	TLSConfig *tls.Config
+
+	// ReadTimeout 读取整个请求的超时，默认 30 秒（server.read_timeout）
+	ReadTimeout time.Duration
+
+	// ReadHeaderTimeout 读取请求头的超时，默认 10 秒（server.read_header_timeout）
+	ReadHeaderTimeout time.Duration
+
+	// WriteTimeout 写入响应的超时，默认 60 秒（server.write_timeout）
+	WriteTimeout time.Duration
+
+	// IdleTimeout keep-alive 连接的空闲超时，默认 120 秒（server.idle_timeout）
+	IdleTimeout time.Duration
+
+	// ShutdownTimeout 关闭时等待进行中请求完成的最长时间，默认 30 秒（server.shutdown_timeout）
+	ShutdownTimeout time.Duration
+
+	// MaxHeaderBytes 请求头最大字节数，默认 1 MB（server.max_header_bytes）
+	MaxHeaderBytes int
+
+	// ReusePort 使用 SO_REUSEPORT 监听，多个进程可以绑定同一端口，用于零停机重启（server.reuse_port）
+	ReusePort bool
+}
+
+// ServerConfigFrom 从配置读取 server.* 项，未配置的项使用默认值
+//
+// 时间可以是 time.Duration、表示秒数的数字或 "30s" 形式的字符串。
+func ServerConfigFrom(config Config) ServerConfig {
+	get := func(key string) interface{} { return config.Get("server."+key, nil) }
+	return ServerConfig{
+		Addr:              configString(get("addr")),
+		CertFile:          configString(get("tls.cert")),
+		KeyFile:           configString(get("tls.key")),
+		ReadTimeout:       configDuration(get("read_timeout")),
+		ReadHeaderTimeout: configDuration(get("read_header_timeout")),
+		WriteTimeout:      configDuration(get("write_timeout")),
+		IdleTimeout:       configDuration(get("idle_timeout")),
+		ShutdownTimeout:   configDuration(get("shutdown_timeout")),
+		MaxHeaderBytes:    configInt(get("max_header_bytes")),
+		ReusePort:         configBool(get("reuse_port")),
+	}
+}
+
+// HTTP3Server HTTP/3 服务器，由适配器包装 quic-go 等 QUIC 实现
+type HTTP3Server interface {
+	// ListenAndServe 监听 UDP 端口并处理请求，关闭后返回
+	ListenAndServe() error
+
+	// Shutdown 停止接受新连接并等待进行中的请求完成
+	Shutdown(ctx context.Context) error
+}
+
+// HTTP3Adapter 创建 HTTP/3 服务器，tlsConfig 与 HTTPS 服务器共用证书
+//
+// 框架本身不依赖任何 QUIC 实现，不提供内置的适配器；需要 HTTP/3 的应用在自己的模块中引入 quic-go，
+// 按下面的方式实现适配器：
+//
+//	server.WithHTTP3(func(addr string, tlsConfig *tls.Config, handler http.Handler) application.HTTP3Server {
+//		return &quicServer{Server: &http3.Server{Addr: addr, TLSConfig: http3.ConfigureTLSConfig(tlsConfig), Handler: handler}}
+//	})
+type HTTP3Adapter func(addr string, tlsConfig *tls.Config, handler http.Handler) HTTP3Server
+
+// Server HTTP 服务器，把 HTTP 内核或 http.Handler 绑定到 net/http
+//
+// 配置了证书时使用 TLS，通过 ALPN 协商 HTTP/2 和 HTTP/1.1；设置 HTTP/3 适配器后同时在同一端口的 UDP 上
+// 提供 HTTP/3，并在 TCP 响应中添加 Alt-Svc 响应头。Run 在 ctx 取消时优雅关闭：Draining 变为 true
+// （健康检查可以据此返回 503），停止接受新连接，关闭空闲连接，并在 ShutdownTimeout 内等待进行中的请求完成。
+//
+// 使用示例：
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+//	kernel := app.MustMake("http.kernel").(application.Kernel)
+//	server := application.NewServer(application.KernelHandler(kernel), application.ServerConfigFrom(config))
+//	if err := server.Run(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+type Server struct {
+	config  ServerConfig
+	handler http.Handler
+	http3   HTTP3Adapter
+
+	mu       sync.Mutex
+	server   *http.Server
+	h3       HTTP3Server
+	listener net.Listener
+	draining atomic.Bool
+}
+
+// NewServer 创建服务器
+func NewServer(handler http.Handler, config ServerConfig) *Server {
+	if config.Addr == "" {
+		config.Addr = ":8080"
+	}
+	if config.ReadTimeout == 0 {
+		config.ReadTimeout = 30 * time.Second
+	}
+	if config.ReadHeaderTimeout == 0 {
+		config.ReadHeaderTimeout = 10 * time.Second
+	}
+	if config.WriteTimeout == 0 {
+		config.WriteTimeout = 60 * time.Second
+	}
+	if config.IdleTimeout == 0 {
+		config.IdleTimeout = 120 * time.Second
+	}
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = 30 * time.Second
+	}
+	if config.MaxHeaderBytes == 0 {
+		config.MaxHeaderBytes = http.DefaultMaxHeaderBytes
+	}
+	return &Server{config: config, handler: handler}
+}
+
+// WithHTTP3 设置 HTTP/3 适配器，只在启用 TLS 时生效
+func (s *Server) WithHTTP3(adapter HTTP3Adapter) *Server {
+	s.http3 = adapter
+	return s
+}
+
+// Config 服务器配置
+func (s *Server) Config() ServerConfig {
+	return s.config
+}
+
+// Addr 实际监听的地址，未启动时返回 nil
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Draining 服务器是否正在关闭
+func (s *Server) Draining() bool {
+	return s.draining.Load()
+}
+
+// Run 监听并处理请求，ctx 取消时优雅关闭，正常关闭时返回 nil
+//
+// 服务器在启动处理请求的 goroutine 之前创建，ctx 在启动前或启动过程中取消时同样能关闭服务器并返回。
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := s.Listen(ctx)
+	if err != nil {
+		return err
+	}
+	server, h3, err := s.start(listener)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	errs := make(chan error, 1)
+	go func() { errs <- s.serve(listener, server, h3) }()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	return <-errs
+}
+
+// Listen 按配置监听 TCP 地址，ReusePort 时设置 SO_REUSEPORT
+func (s *Server) Listen(ctx context.Context) (net.Listener, error) {
+	var lc net.ListenConfig
+	if s.config.ReusePort {
+		lc.Control = reusePort
+	}
+	return lc.Listen(ctx, "tcp", s.config.Addr)
+}
+
+// Serve 在 listener 上处理请求，关闭后返回 nil；启动失败时关闭 listener 并返回错误
+func (s *Server) Serve(listener net.Listener) error {
+	server, h3, err := s.start(listener)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	return s.serve(listener, server, h3)
+}
+
+// start 创建 HTTP 服务器和 HTTP/3 服务器并登记为当前服务器，Shutdown 从此时起可以关闭它们
+func (s *Server) start(listener net.Listener) (*http.Server, HTTP3Server, error) {
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	handler := s.handler
+	if tlsConfig != nil && s.http3 != nil {
+		handler = s.altSvc(handler, listener.Addr())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		return nil, nil, ErrServerRunning
+	}
+	s.draining.Store(false)
+	s.listener = listener
+	s.server = &http.Server{
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadTimeout:       s.config.ReadTimeout,
+		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
+		WriteTimeout:      s.config.WriteTimeout,
+		IdleTimeout:       s.config.IdleTimeout,
+		MaxHeaderBytes:    s.config.MaxHeaderBytes,
+	}
+	if tlsConfig != nil && s.http3 != nil {
+		s.h3 = s.http3(listener.Addr().String(), tlsConfig, s.handler)
+	}
+	return s.server, s.h3, nil
+}
+
+// serve 处理请求直到服务器关闭；Shutdown 在 serve 开始前调用时 net/http 立即返回 ErrServerClosed 并关闭 listener
+func (s *Server) serve(listener net.Listener, server *http.Server, h3 HTTP3Server) error {
+	defer func() {
+		s.mu.Lock()
+		s.server, s.h3, s.listener = nil, nil, nil
+		s.mu.Unlock()
+	}()
+
+	errs := make(chan error, 2)
+	if h3 != nil {
+		go func() { errs <- ignoreClosed(h3.ListenAndServe()) }()
+	}
+	var err error
+	if server.TLSConfig != nil {
+		err = server.ServeTLS(listener, "", "")
+	} else {
+		err = server.Serve(listener)
+	}
+	if err = ignoreClosed(err); err != nil {
+		listener.Close()
+		if h3 != nil {
+			_ = h3.Shutdown(context.Background())
+		}
+		return err
+	}
+	if h3 != nil {
+		return <-errs
+	}
+	return nil
+}
+
+// Shutdown 优雅关闭：停止接受新连接，等待进行中的请求完成或 ctx 结束
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	server, h3 := s.server, s.h3
+	s.mu.Unlock()
+	if server == nil {
+		return nil
+	}
+	s.draining.Store(true)
+	server.SetKeepAlivesEnabled(false)
+
+	var wg sync.WaitGroup
+	var h3Err error
+	if h3 != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h3Err = h3.Shutdown(ctx)
+		}()
+	}
+	err := server.Shutdown(ctx)
+	wg.Wait()
+	return errors.Join(err, h3Err)
+}
+
+// tlsConfig 配置了证书或 TLSConfig 时返回 TLS 配置，ALPN 依次协商 h2 和 http/1.1
+func (s *Server) tlsConfig() (*tls.Config, error) {
+	if s.config.TLSConfig == nil && (s.config.CertFile == "" || s.config.KeyFile == "") {
+		return nil, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if s.config.TLSConfig != nil {
+		config = s.config.TLSConfig.Clone()
+	}
+	if s.config.CertFile != "" && s.config.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(s.config.CertFile, s.config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("application: load TLS certificate: %w", err)
+		}
+		config.Certificates = append(config.Certificates, certificate)
+	}
+	if len(config.NextProtos) == 0 {
+		config.NextProtos = []string{"h2", "http/1.1"}
+	}
+	return config, nil
+}
+
+// altSvc 在 TCP 响应中通告 HTTP/3
+func (s *Server) altSvc(next http.Handler, addr net.Addr) http.Handler {
+	port := "443"
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		port = strconv.Itoa(tcp.Port)
+	}
+	value := `h3=":` + port + `"; ma=86400`
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor < 3 {
+			w.Header().Set("Alt-Svc", value)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ignoreClosed 把服务器关闭产生的 http.ErrServerClosed 视为正常结束
+func ignoreClosed(err error) error {
+	if errors.Is(err, http.ErrServerClosed) || errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// KernelHandler 把 HTTP 内核包装为 http.Handler
+//
+// 内核以 *http.Request 调用 HandleWithContext，返回的响应按以下方式写入：
+// 实现 http.Handler 时调用 ServeHTTP，实现 WriteTo(http.ResponseWriter) error 时调用 WriteTo
+// （例如 *routing.Response），[]byte 和 string 直接写入；处理出错时返回 500。
+// 响应写出后调用内核的 Terminate。
+func KernelHandler(kernel Kernel) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, err := kernel.HandleWithContext(r.Context(), r)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		switch v := response.(type) {
+		case http.Handler:
+			v.ServeHTTP(w, r)
+		case interface {
+			WriteTo(w http.ResponseWriter) error
+		}:
+			_ = v.WriteTo(w)
+		case []byte:
+			_, _ = w.Write(v)
+		case string:
+			_, _ = w.Write([]byte(v))
+		case nil:
+		default:
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		_ = kernel.Terminate(r, response)
+	})
+}
+
+// configString 配置值转换为字符串
+func configString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// configBool 配置值转换为布尔值，支持 bool 和 "true"、"1" 等字符串
+func configBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		parsed, _ := strconv.ParseBool(v)
+		return parsed
+	}
+	return false
+}
+
+// configInt 配置值转换为整数，支持数字和数字字符串
+func configInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		parsed, _ := strconv.Atoi(v)
+		return parsed
+	}
+	return 0
+}
+
+// configDuration 配置值转换为时间：time.Duration、秒数或 time.ParseDuration 格式的字符串
+func configDuration(value interface{}) time.Duration {
+	switch v := value.(type) {
+	case time.Duration:
+		return v
+	case int:
+		return time.Duration(v) * time.Second
+	case int64:
+		return time.Duration(v) * time.Second
+	case float64:
+		return time.Duration(v * float64(time.Second))
+	case string:
+		if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.Duration(seconds * float64(time.Second))
+		}
+		parsed, _ := time.ParseDuration(v)
+		return parsed
+	}
+	return 0
+}
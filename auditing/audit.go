@@ -0,0 +1,177 @@
+package auditing
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+)
+
+// Audit 审计记录模型
+//
+// Audit 对应 audits 表中的一行，记录一次模型变更的完整信息：
+// 谁（User）、何时（CreatedAt）、做了什么（Event、OldValues、NewValues）
+// 以及请求元数据（URL、IPAddress、UserAgent）。
+//
+// 使用示例：
+//
+//	// 迁移 audits 表
+//	migrator.AutoMigrate(&auditing.Audit{})
+//
+//	// 查看变更内容
+//	for field, change := range audit.GetModified() {
+//		fmt.Printf("%s: %v -> %v\n", field, change.Old, change.New)
+//	}
+type Audit struct {
+	// ID 主键
+	ID uint `gorm:"primarykey" json:"id"`
+
+	// AuditableType 审计对象类型
+	//
+	// 示例：
+	//   AuditableType: "posts"
+	AuditableType string `gorm:"size:191;index:idx_audits_auditable" json:"auditable_type"`
+
+	// AuditableID 审计对象主键
+	//
+	// 示例：
+	//   AuditableID: "42"
+	AuditableID string `gorm:"size:191;index:idx_audits_auditable" json:"auditable_id"`
+
+	// Event 审计事件
+	//
+	// 示例：
+	//   Event: auditing.EventUpdated
+	Event string `gorm:"size:32" json:"event"`
+
+	// OldValues 变更前的属性
+	//
+	// 创建事件中为空。
+	OldValues AuditValues `gorm:"type:text" json:"old_values"`
+
+	// NewValues 变更后的属性
+	//
+	// 删除事件中为空。
+	NewValues AuditValues `gorm:"type:text" json:"new_values"`
+
+	// UserType 操作者类型
+	//
+	// 示例：
+	//   UserType: "users"
+	UserType string `gorm:"size:191;index:idx_audits_user" json:"user_type"`
+
+	// UserID 操作者主键
+	//
+	// 控制台或队列中没有操作者时为空。
+	UserID string `gorm:"size:191;index:idx_audits_user" json:"user_id"`
+
+	// URL 请求地址
+	//
+	// 控制台命令中记录为 "console"。
+	URL string `gorm:"type:text" json:"url"`
+
+	// IPAddress 客户端 IP
+	IPAddress string `gorm:"size:45" json:"ip_address"`
+
+	// UserAgent 客户端 User-Agent
+	UserAgent string `gorm:"size:1023" json:"user_agent"`
+
+	// Tags 标签
+	//
+	// 示例：
+	//   Tags: []string{"billing", "admin"}
+	Tags []string `gorm:"serializer:json" json:"tags"`
+
+	// CreatedAt 审计时间
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName 返回审计表名
+func (Audit) TableName() string {
+	return "audits"
+}
+
+// AuditChange 单个字段的变更
+type AuditChange struct {
+	// Old 变更前的值
+	Old interface{} `json:"old"`
+
+	// New 变更后的值
+	New interface{} `json:"new"`
+}
+
+// GetModified 获取变更的字段
+//
+// 合并 OldValues 和 NewValues，返回值发生变化的字段。
+//
+// 示例：
+//
+//	audit := &Audit{
+//		OldValues: AuditValues{"title": "Draft", "views": 1},
+//		NewValues: AuditValues{"title": "Published", "views": 1},
+//	}
+//	modified := audit.GetModified()
+//	// modified: {"title": {Old: "Draft", New: "Published"}}
+func (a *Audit) GetModified() map[string]AuditChange {
+	modified := make(map[string]AuditChange)
+	for key, oldValue := range a.OldValues {
+		newValue, ok := a.NewValues[key]
+		if !ok || !reflect.DeepEqual(oldValue, newValue) {
+			modified[key] = AuditChange{Old: oldValue, New: newValue}
+		}
+	}
+	for key, newValue := range a.NewValues {
+		if _, ok := a.OldValues[key]; !ok {
+			modified[key] = AuditChange{New: newValue}
+		}
+	}
+	return modified
+}
+
+// AuditValues 审计属性集合
+//
+// AuditValues 以 JSON 形式存储在数据库中，实现了 sql.Scanner 和 driver.Valuer 接口。
+//
+// 使用示例：
+//
+//	values := AuditValues{"status": "paid", "amount": 100}
+//	value, _ := values.Value() // `{"amount":100,"status":"paid"}`
+type AuditValues map[string]interface{}
+
+// Scan 实现 sql.Scanner 接口
+//
+// 支持 NULL、[]byte 和 string 类型的 JSON 数据。
+func (v *AuditValues) Scan(value interface{}) error {
+	var data []byte
+	switch raw := value.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case []byte:
+		data = raw
+	case string:
+		data = []byte(raw)
+	default:
+		return errors.New("auditing: unsupported AuditValues source type")
+	}
+	if len(data) == 0 {
+		*v = nil
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Value 实现 driver.Valuer 接口
+//
+// 空集合存储为 NULL。
+func (v AuditValues) Value() (driver.Value, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,105 @@
+package auditing
+
+// 审计事件名称
+const (
+	EventCreated  = "created"
+	EventUpdated  = "updated"
+	EventDeleted  = "deleted"
+	EventRestored = "restored"
+)
+
+// Auditable 可审计模型接口
+//
+// Auditable 相当于 Laravel 中的 Auditable trait，模型实现此接口后，
+// AuditObserver 会在模型事件触发时记录属性变更。
+//
+// 使用示例：
+//
+//	type User struct {
+//		database.Model
+//		Name     string `json:"name"`
+//		Email    string `json:"email"`
+//		Password string `json:"-"`
+//	}
+//
+//	func (u *User) AuditableType() string {
+//		return "users"
+//	}
+//
+//	func (u *User) AuditableID() string {
+//		return strconv.FormatUint(uint64(u.ID), 10)
+//	}
+//
+//	func (u *User) AuditInclude() []string {
+//		return nil // 审计所有字段
+//	}
+//
+//	func (u *User) AuditExclude() []string {
+//		return []string{"password", "remember_token"}
+//	}
+//
+//	func (u *User) AuditEvents() []string {
+//		return []string{auditing.EventCreated, auditing.EventUpdated, auditing.EventDeleted}
+//	}
+//
+//	func (u *User) TransformAudit(audit *auditing.Audit) {
+//		audit.Tags = append(audit.Tags, "account")
+//	}
+type Auditable interface {
+	// AuditableType 获取审计对象类型
+	//
+	// 写入 audits 表的 auditable_type 列，通常是表名或模型名。
+	//
+	// 示例：
+	//   func (p *Post) AuditableType() string {
+	//       return "posts"
+	//   }
+	AuditableType() string
+
+	// AuditableID 获取审计对象主键
+	//
+	// 写入 audits 表的 auditable_id 列，使用字符串以兼容 UUID 主键。
+	AuditableID() string
+
+	// AuditInclude 获取需要审计的字段
+	//
+	// 返回 nil 或空切片时审计除 AuditExclude 之外的所有字段。
+	//
+	// 示例：
+	//   func (o *Order) AuditInclude() []string {
+	//       return []string{"status", "amount"}
+	//   }
+	AuditInclude() []string
+
+	// AuditExclude 获取不需要审计的字段
+	//
+	// 敏感字段（密码、令牌等）应该在这里排除。
+	//
+	// 示例：
+	//   func (u *User) AuditExclude() []string {
+	//       return []string{"password"}
+	//   }
+	AuditExclude() []string
+
+	// AuditEvents 获取需要审计的事件
+	//
+	// 返回 nil 时审计所有事件。
+	//
+	// 示例：
+	//   func (l *Log) AuditEvents() []string {
+	//       return []string{auditing.EventDeleted} // 只记录删除
+	//   }
+	AuditEvents() []string
+
+	// TransformAudit 在写入前修改审计记录
+	//
+	// 可以用于添加标签、脱敏字段或补充元数据。
+	//
+	// 示例：
+	//   func (p *Payment) TransformAudit(audit *auditing.Audit) {
+	//       if v, ok := audit.NewValues["card_number"]; ok {
+	//           audit.NewValues["card_number"] = mask(v)
+	//       }
+	//   }
+	TransformAudit(audit *Audit)
+}
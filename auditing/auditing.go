@@ -0,0 +1,67 @@
+// Package auditing 提供 Laravel Auditing 风格的模型审计协议定义
+//
+// 本包通过模型事件记录模型属性的变更历史，每次创建、更新、删除、恢复
+// 都会把变更前后的属性差异连同操作者、时间和请求元数据写入 audits 表，
+// 并提供按记录查询历史的 API。
+//
+// 主要特性：
+// - Auditable 模型特性（包含/排除字段、审计事件、数据转换）
+// - 基于模型事件的自动审计
+// - 变更前后差异（old_values / new_values）
+// - 操作者与请求元数据（URL、IP、User-Agent）
+// - 按记录查询审计历史和还原历史状态
+//
+// 包结构：
+// - auditable.go - Auditable 审计模型接口
+// - audit.go - Audit 审计记录模型和 AuditValues 属性集合
+// - auditor.go - Auditor 审计器、AuditObserver 模型事件观察者、Resolver 元数据解析器
+// - history.go - AuditHistory 和 AuditQuery 审计历史查询接口
+//
+// 使用示例：
+//
+//	// 模型声明为可审计
+//	type Post struct {
+//		database.Model
+//		Title   string `json:"title"`
+//		Content string `json:"content"`
+//		Secret  string `json:"-"`
+//	}
+//
+//	func (p *Post) AuditableType() string {
+//		return "posts"
+//	}
+//
+//	func (p *Post) AuditableID() string {
+//		return strconv.FormatUint(uint64(p.ID), 10)
+//	}
+//
+//	func (p *Post) AuditInclude() []string {
+//		return nil // 审计除 AuditExclude 之外的所有字段
+//	}
+//
+//	func (p *Post) AuditExclude() []string {
+//		return []string{"secret"}
+//	}
+//
+//	func (p *Post) AuditEvents() []string {
+//		return nil // 审计所有事件
+//	}
+//
+//	func (p *Post) TransformAudit(audit *auditing.Audit) {}
+//
+//	var _ auditing.Auditable = (*Post)(nil)
+//
+//	// 注册模型事件观察者
+//	observer := container.MustMake("auditing.observer").(auditing.AuditObserver)
+//
+//	// 查询某条记录的审计历史
+//	history := container.MustMake("auditing.history").(auditing.AuditHistory)
+//	audits, err := history.ForRecord(post).Event(auditing.EventUpdated).Latest().Get(ctx)
+//
+//	// 查看具体的字段变更
+//	for _, audit := range audits {
+//		for field, change := range audit.GetModified() {
+//			fmt.Printf("%s: %v -> %v\n", field, change.Old, change.New)
+//		}
+//	}
+package auditing
@@ -0,0 +1,123 @@
+package auditing
+
+import "context"
+
+// Auditor 审计器接口
+//
+// Auditor 负责计算模型属性差异、补充元数据并写入 audits 表。
+// 通常由 AuditObserver 在模型事件中调用，也可以手动调用记录自定义事件。
+//
+// 使用示例：
+//
+//	auditor := container.MustMake("auditor").(auditing.Auditor)
+//
+//	// 手动记录一次审计
+//	audit, err := auditor.Audit(ctx, post, auditing.EventUpdated,
+//		map[string]interface{}{"title": "Old"},
+//		map[string]interface{}{"title": "New"},
+//	)
+//
+//	// 临时关闭审计（如批量导入）
+//	auditor.WithoutAuditing(func() error {
+//		return importer.Run(ctx)
+//	})
+type Auditor interface {
+	// Audit 记录一次审计
+	//
+	// 根据模型的 AuditInclude/AuditExclude 过滤属性，只记录发生变化的字段。
+	// 如果过滤后没有变化，或事件不在 AuditEvents 中，返回 nil, nil。
+	//
+	// 示例：
+	//   audit, err := auditor.Audit(ctx, user, auditing.EventUpdated, oldAttrs, newAttrs)
+	Audit(ctx context.Context, model Auditable, event string, oldValues, newValues map[string]interface{}) (*Audit, error)
+
+	// IsAuditingEnabled 是否启用审计
+	IsAuditingEnabled() bool
+
+	// WithoutAuditing 在不记录审计的情况下执行回调
+	//
+	// 示例：
+	//   err := auditor.WithoutAuditing(func() error {
+	//       return db.Save(&user).Error()
+	//   })
+	WithoutAuditing(callback func() error) error
+
+	// SetResolver 设置元数据解析器
+	SetResolver(resolver Resolver)
+}
+
+// AuditObserver 审计模型事件观察者接口
+//
+// AuditObserver 监听模型事件，在事件触发时调用 Auditor 记录变更。
+// 方法中的 original 为模型在数据库中的原始属性，current 为当前属性。
+//
+// 使用示例：
+//
+//	events := app.MustMake("events").(application.EventDispatcher)
+//	observer := app.MustMake("auditing.observer").(auditing.AuditObserver)
+//
+//	events.AddListener("eloquent.updated", func(event interface{}) error {
+//		e := event.(*ModelEvent)
+//		return observer.Updated(e.Context, e.Model.(auditing.Auditable), e.Original, e.Attributes)
+//	}, 0)
+type AuditObserver interface {
+	// Created 模型创建后
+	Created(ctx context.Context, model Auditable, current map[string]interface{}) error
+
+	// Updated 模型更新后
+	Updated(ctx context.Context, model Auditable, original, current map[string]interface{}) error
+
+	// Deleted 模型删除后
+	Deleted(ctx context.Context, model Auditable, original map[string]interface{}) error
+
+	// Restored 软删除模型恢复后
+	Restored(ctx context.Context, model Auditable, current map[string]interface{}) error
+}
+
+// Metadata 审计元数据
+//
+// Metadata 描述发起变更的操作者和请求信息。
+type Metadata struct {
+	// UserType 操作者类型
+	UserType string
+
+	// UserID 操作者主键
+	UserID string
+
+	// URL 请求地址
+	URL string
+
+	// IPAddress 客户端 IP
+	IPAddress string
+
+	// UserAgent 客户端 User-Agent
+	UserAgent string
+
+	// Tags 附加标签
+	Tags []string
+}
+
+// Resolver 审计元数据解析器接口
+//
+// Resolver 从上下文中解析当前操作者和请求信息，
+// HTTP 请求中通常由中间件把用户和请求写入 context.Context。
+//
+// 使用示例：
+//
+//	type RequestResolver struct{}
+//
+//	func (r *RequestResolver) Resolve(ctx context.Context) auditing.Metadata {
+//		request, ok := ctx.Value(requestKey).(routing.RequestInterface)
+//		if !ok {
+//			return auditing.Metadata{URL: "console"}
+//		}
+//		return auditing.Metadata{
+//			URL:       request.GetURI(),
+//			IPAddress: request.IP(),
+//			UserAgent: request.UserAgent(),
+//		}
+//	}
+type Resolver interface {
+	// Resolve 解析元数据
+	Resolve(ctx context.Context) Metadata
+}
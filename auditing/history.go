@@ -0,0 +1,87 @@
+package auditing
+
+import (
+	"context"
+	"time"
+)
+
+// AuditHistory 审计历史查询接口
+//
+// AuditHistory 是查询审计记录的入口，可以按记录、按操作者查询。
+//
+// 使用示例：
+//
+//	history := container.MustMake("auditing.history").(auditing.AuditHistory)
+//
+//	// 某篇文章的所有更新记录
+//	audits, err := history.ForRecord(post).Event(auditing.EventUpdated).Get(ctx)
+//
+//	// 某个用户最近一周的操作
+//	audits, err := history.ByUser("users", "1").
+//		Between(time.Now().AddDate(0, 0, -7), time.Now()).
+//		Latest().
+//		Get(ctx)
+//
+//	// 还原文章在某个时间点的状态
+//	state, err := history.ForRecord(post).StateAt(ctx, yesterday)
+type AuditHistory interface {
+	// ForRecord 查询指定记录的审计历史
+	ForRecord(model Auditable) AuditQuery
+
+	// ForType 查询指定类型的审计历史
+	//
+	// 示例：
+	//   history.ForType("posts").Event(auditing.EventDeleted).Get(ctx)
+	ForType(auditableType string) AuditQuery
+
+	// ByUser 查询指定操作者的审计历史
+	ByUser(userType string, userID string) AuditQuery
+}
+
+// AuditQuery 审计查询构建器接口
+type AuditQuery interface {
+	// Event 按事件过滤
+	Event(events ...string) AuditQuery
+
+	// Between 按时间范围过滤
+	Between(from time.Time, to time.Time) AuditQuery
+
+	// WithTag 按标签过滤
+	WithTag(tags ...string) AuditQuery
+
+	// Field 只保留修改了指定字段的审计记录
+	//
+	// 示例：
+	//   history.ForRecord(order).Field("status").Get(ctx)
+	Field(fields ...string) AuditQuery
+
+	// Latest 按时间倒序
+	Latest() AuditQuery
+
+	// Oldest 按时间正序
+	Oldest() AuditQuery
+
+	// Limit 限制数量
+	Limit(limit int) AuditQuery
+
+	// Offset 跳过数量
+	Offset(offset int) AuditQuery
+
+	// Get 获取审计记录
+	Get(ctx context.Context) ([]Audit, error)
+
+	// First 获取第一条审计记录
+	First(ctx context.Context) (*Audit, error)
+
+	// Count 统计审计记录数量
+	Count(ctx context.Context) (int64, error)
+
+	// StateAt 还原记录在指定时间点的属性
+	//
+	// 按时间顺序回放审计记录中的 NewValues。
+	//
+	// 示例：
+	//   state, err := history.ForRecord(post).StateAt(ctx, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	//   title := state["title"]
+	StateAt(ctx context.Context, at time.Time) (map[string]interface{}, error)
+}
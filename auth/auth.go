@@ -0,0 +1,100 @@
+// Package auth 提供 Laravel 风格的认证协议定义
+//
+// 认证由 Guard（守卫）和 UserProvider（用户提供者）组成：守卫决定如何从请求中识别用户
+// （会话、API 令牌等），用户提供者决定如何从存储中取回用户（Eloquent 模型、数据库表）。
+// 配置与 Laravel 的 config/auth.php 对应，可以定义多个命名守卫并共享用户提供者。
+//
+// 守卫是无状态的，请求级的状态（当前请求、会话、已解析的用户）保存在 context 中的 State 上，
+// 由 Authenticate 中间件或 WithState 创建，因此同一个守卫可以被并发请求共享。
+//
+// 包结构：
+// - auth.go - Manager 管理器接口、Credentials 和默认管理器
+// - user.go - Authenticatable 可认证用户接口、MustVerifyEmail 契约和 GenericUser
+// - guard.go - Guard、StatefulGuard、UserProvider、Hasher 和 Session 接口
+// - state.go - State 请求级认证状态和 context 辅助函数
+// - middleware.go - Authenticate、EnsureEmailIsVerified 中间件和 AuthenticationError
+//
+// 子包 driver 提供 session、token 守卫，eloquent、database 用户提供者和 Manager 的实现，
+// 子包 sanctum 提供 Sanctum 风格的个人访问令牌认证，子包 jwt 提供 JWT 守卫和 OpenID Connect 集成，
+// 子包 passwords 提供密码重置，子包 verification 提供邮箱验证。
+//
+// 使用示例：
+//
+//	manager := container.MustMake("auth").(auth.Manager)
+//	http.Handle("/dashboard", auth.Authenticate(manager).Handler(dashboard))
+//
+//	func dashboard(w http.ResponseWriter, r *http.Request) {
+//		user := auth.UserFromContext(r.Context())
+//		...
+//	}
+//
+//	// 登录
+//	guard, _ := manager.Guard("web")
+//	ok, err := guard.(auth.StatefulGuard).Attempt(r.Context(), auth.Credentials{
+//		"email":    r.FormValue("email"),
+//		"password": r.FormValue("password"),
+//	}, r.FormValue("remember") != "")
+package auth
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var (
+	// ErrNoManager 没有设置默认认证管理器
+	ErrNoManager = errors.New("auth: no default manager, call auth.SetDefaultManager")
+
+	// ErrNoState 当前 context 中没有请求级认证状态，需要经过 Authenticate 中间件或调用 WithState
+	ErrNoState = errors.New("auth: no request state in context")
+
+	// ErrNoSession 有状态守卫需要会话，但请求状态中没有会话
+	ErrNoSession = errors.New("auth: guard requires a session")
+
+	// ErrNoHasher 用户提供者校验密码时没有配置 Hasher
+	ErrNoHasher = errors.New("auth: user provider has no hasher")
+)
+
+// Credentials 登录凭证，例如 {"email": ..., "password": ...}
+//
+// 键名包含 "password" 的字段只用于校验密码，不参与查询用户。
+type Credentials map[string]interface{}
+
+// Password 获取凭证中的密码
+func (c Credentials) Password() string {
+	password, _ := c["password"].(string)
+	return password
+}
+
+// Manager 认证管理器接口
+//
+// 守卫配置的 "driver" 字段决定守卫类型，"provider" 字段引用用户提供者配置。
+type Manager interface {
+	// Guard 获取守卫，不传名称时使用默认守卫
+	Guard(name ...string) (Guard, error)
+
+	// CreateUserProvider 按名称创建用户提供者
+	CreateUserProvider(name string) (UserProvider, error)
+
+	// GetDefaultGuard 获取默认守卫名称
+	GetDefaultGuard() string
+
+	// SetDefaultGuard 设置默认守卫名称
+	SetDefaultGuard(name string)
+}
+
+// defaultManager 默认认证管理器
+var defaultManager atomic.Value
+
+// SetDefaultManager 设置默认认证管理器
+func SetDefaultManager(manager Manager) {
+	defaultManager.Store(&manager)
+}
+
+// DefaultManager 获取默认认证管理器，未设置时返回 nil
+func DefaultManager() Manager {
+	if manager, ok := defaultManager.Load().(*Manager); ok {
+		return *manager
+	}
+	return nil
+}
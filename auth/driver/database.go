@@ -0,0 +1,87 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// DatabaseUserProvider 数据表用户提供者，对应 Laravel 的 DatabaseUserProvider
+//
+// 直接查询数据表，返回 auth.GenericUser，唯一标识列为 id，"记住我"令牌列为 remember_token。
+type DatabaseUserProvider struct {
+	db     database.DB
+	table  string
+	hasher auth.Hasher
+}
+
+var (
+	_ auth.UserProvider     = (*DatabaseUserProvider)(nil)
+	_ auth.PasswordRehasher = (*DatabaseUserProvider)(nil)
+)
+
+// NewDatabaseUserProvider 创建数据表用户提供者
+func NewDatabaseUserProvider(db database.DB, table string, hasher auth.Hasher) *DatabaseUserProvider {
+	return &DatabaseUserProvider{db: db, table: table, hasher: hasher}
+}
+
+// RetrieveByID 按唯一标识获取用户
+func (p *DatabaseUserProvider) RetrieveByID(ctx context.Context, identifier interface{}) (auth.Authenticatable, error) {
+	return p.first(p.db.WithContext(ctx).Table(p.table).Where("id = ?", identifier))
+}
+
+// RetrieveByToken 按唯一标识和"记住我"令牌获取用户
+func (p *DatabaseUserProvider) RetrieveByToken(ctx context.Context, identifier interface{}, token string) (auth.Authenticatable, error) {
+	user, err := p.RetrieveByID(ctx, identifier)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	if !tokenMatches(user.GetRememberToken(), token) {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// UpdateRememberToken 更新用户的"记住我"令牌
+func (p *DatabaseUserProvider) UpdateRememberToken(ctx context.Context, user auth.Authenticatable, token string) error {
+	user.SetRememberToken(token)
+	return p.db.WithContext(ctx).Table(p.table).
+		Where(user.GetAuthIdentifierName()+" = ?", user.GetAuthIdentifier()).
+		UpdateColumn(user.GetRememberTokenName(), token).Error()
+}
+
+// RetrieveByCredentials 按凭证获取用户，规则与 EloquentUserProvider 相同
+func (p *DatabaseUserProvider) RetrieveByCredentials(ctx context.Context, credentials auth.Credentials) (auth.Authenticatable, error) {
+	tx, ok := applyCredentials(p.db.WithContext(ctx).Table(p.table), credentials)
+	if !ok {
+		return nil, nil
+	}
+	return p.first(tx)
+}
+
+// ValidateCredentials 校验凭证中的密码
+func (p *DatabaseUserProvider) ValidateCredentials(ctx context.Context, user auth.Authenticatable, credentials auth.Credentials) (bool, error) {
+	return validatePassword(p.hasher, user, credentials)
+}
+
+// RehashPasswordIfRequired 哈希需要升级时重新生成并保存
+func (p *DatabaseUserProvider) RehashPasswordIfRequired(ctx context.Context, user auth.Authenticatable, credentials auth.Credentials, force bool) error {
+	return rehashPassword(p.hasher, user, credentials, force, func(column, hashed string) error {
+		return p.db.WithContext(ctx).Table(p.table).
+			Where(user.GetAuthIdentifierName()+" = ?", user.GetAuthIdentifier()).
+			UpdateColumn(column, hashed).Error()
+	})
+}
+
+// first 查询第一行，不存在时返回 nil
+func (p *DatabaseUserProvider) first(tx database.DB) (auth.Authenticatable, error) {
+	var rows []map[string]interface{}
+	if err := tx.Limit(1).Find(&rows).Error(); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return auth.NewGenericUser(rows[0]), nil
+}
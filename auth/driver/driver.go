@@ -0,0 +1,122 @@
+// Package driver 提供 auth 包协议的参考实现
+//
+// 包含 session、token 两种内置守卫，基于闭包的 RequestGuard，eloquent、database 两种用户提供者，
+// 以及 Manager 实现。用户提供者基于 database.DB 查询，可以使用 gormbridge 或 memdb 的实现。
+//
+// 包结构：
+// - driver.go - 配置读取、随机令牌和守卫通用辅助函数
+// - session.go - SessionGuard 会话守卫和"记住我" Cookie
+// - token.go - TokenGuard API 令牌守卫
+// - request.go - RequestGuard 闭包守卫
+// - eloquent.go - EloquentUserProvider 模型用户提供者
+// - database.go - DatabaseUserProvider 数据表用户提供者
+// - manager.go - Manager 实现
+//
+// 配置示例：
+//
+//	manager := driver.NewManager(map[string]map[string]interface{}{
+//		"web": {"driver": "session", "provider": "users"},
+//		"api": {"driver": "token", "provider": "users", "hash": true},
+//	}, map[string]map[string]interface{}{
+//		"users":  {"driver": "eloquent", "db": db, "model": func() auth.Authenticatable { return &User{} }},
+//		"admins": {"driver": "database", "db": db, "table": "admins"},
+//	}, "web")
+//	manager.SetHasher(hasher)
+//	manager.SetKey(appKey)
+//	auth.SetDefaultManager(manager)
+package driver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/auth"
+)
+
+// stringOption 读取字符串配置
+func stringOption(config map[string]interface{}, key, fallback string) string {
+	if value, ok := config[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// boolOption 读取布尔配置
+func boolOption(config map[string]interface{}, key string, fallback bool) bool {
+	if value, ok := config[key].(bool); ok {
+		return value
+	}
+	return fallback
+}
+
+// durationOption 读取时长配置，整数按分钟解释（与 Laravel 的 auth 配置一致）
+func durationOption(config map[string]interface{}, key string, fallback time.Duration) time.Duration {
+	switch value := config[key].(type) {
+	case time.Duration:
+		return value
+	case int:
+		return time.Duration(value) * time.Minute
+	case int64:
+		return time.Duration(value) * time.Minute
+	case float64:
+		return time.Duration(value * float64(time.Minute))
+	case string:
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+// clientOption 读取对象配置
+func clientOption[T any](config map[string]interface{}, key string) (T, error) {
+	client, ok := config[key].(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("auth: config %q must be %T, got %T", key, zero, config[key])
+	}
+	return client, nil
+}
+
+// randomToken 生成 n 字节的十六进制随机令牌
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// check 守卫当前请求是否已认证
+func check(ctx context.Context, guard auth.Guard) bool {
+	user, err := guard.User(ctx)
+	return err == nil && user != nil
+}
+
+// id 守卫当前用户的唯一标识
+func id(ctx context.Context, guard auth.Guard) interface{} {
+	user, err := guard.User(ctx)
+	if err != nil || user == nil {
+		return nil
+	}
+	return user.GetAuthIdentifier()
+}
+
+// hasUser 守卫是否已经在当前请求中解析出用户
+func hasUser(ctx context.Context, name string) bool {
+	state := auth.StateFromContext(ctx)
+	return state != nil && state.Guard(name).User != nil
+}
+
+// setUser 设置守卫在当前请求中的用户
+func setUser(ctx context.Context, name string, user auth.Authenticatable) {
+	state := auth.StateFromContext(ctx)
+	if state == nil {
+		return
+	}
+	guard := state.Guard(name)
+	guard.User, guard.Resolved, guard.LoggedOut = user, true, false
+}
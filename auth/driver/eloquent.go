@@ -0,0 +1,166 @@
+package driver
+
+import (
+	"context"
+	"crypto/subtle"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// EloquentUserProvider 模型用户提供者，对应 Laravel 的 EloquentUserProvider
+//
+// model 返回一个新的用户模型指针，查询结果会扫描到其中。
+type EloquentUserProvider struct {
+	db     database.DB
+	model  func() auth.Authenticatable
+	hasher auth.Hasher
+}
+
+var (
+	_ auth.UserProvider     = (*EloquentUserProvider)(nil)
+	_ auth.PasswordRehasher = (*EloquentUserProvider)(nil)
+)
+
+// NewEloquentUserProvider 创建模型用户提供者
+func NewEloquentUserProvider(db database.DB, model func() auth.Authenticatable, hasher auth.Hasher) *EloquentUserProvider {
+	return &EloquentUserProvider{db: db, model: model, hasher: hasher}
+}
+
+// RetrieveByID 按唯一标识获取用户
+func (p *EloquentUserProvider) RetrieveByID(ctx context.Context, identifier interface{}) (auth.Authenticatable, error) {
+	user := p.model()
+	return p.first(user, p.db.WithContext(ctx).Model(user).Where(user.GetAuthIdentifierName()+" = ?", identifier))
+}
+
+// RetrieveByToken 按唯一标识和"记住我"令牌获取用户
+func (p *EloquentUserProvider) RetrieveByToken(ctx context.Context, identifier interface{}, token string) (auth.Authenticatable, error) {
+	user, err := p.RetrieveByID(ctx, identifier)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	if !tokenMatches(user.GetRememberToken(), token) {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// UpdateRememberToken 更新用户的"记住我"令牌，不触发模型钩子和更新时间戳
+func (p *EloquentUserProvider) UpdateRememberToken(ctx context.Context, user auth.Authenticatable, token string) error {
+	user.SetRememberToken(token)
+	return p.db.WithContext(ctx).Model(p.model()).
+		Where(user.GetAuthIdentifierName()+" = ?", user.GetAuthIdentifier()).
+		UpdateColumn(user.GetRememberTokenName(), token).Error()
+}
+
+// RetrieveByCredentials 按凭证获取用户，凭证中的密码字段不参与查询
+//
+// 值为切片时生成 IN 条件，值为 func(database.DB) database.DB 时作为查询作用域调用。
+func (p *EloquentUserProvider) RetrieveByCredentials(ctx context.Context, credentials auth.Credentials) (auth.Authenticatable, error) {
+	user := p.model()
+	tx, ok := applyCredentials(p.db.WithContext(ctx).Model(user), credentials)
+	if !ok {
+		return nil, nil
+	}
+	return p.first(user, tx)
+}
+
+// ValidateCredentials 校验凭证中的密码
+func (p *EloquentUserProvider) ValidateCredentials(ctx context.Context, user auth.Authenticatable, credentials auth.Credentials) (bool, error) {
+	return validatePassword(p.hasher, user, credentials)
+}
+
+// RehashPasswordIfRequired 哈希需要升级时重新生成并保存，用户需要实现 auth.PasswordUpdatable
+func (p *EloquentUserProvider) RehashPasswordIfRequired(ctx context.Context, user auth.Authenticatable, credentials auth.Credentials, force bool) error {
+	return rehashPassword(p.hasher, user, credentials, force, func(column, hashed string) error {
+		return p.db.WithContext(ctx).Model(p.model()).
+			Where(user.GetAuthIdentifierName()+" = ?", user.GetAuthIdentifier()).
+			UpdateColumn(column, hashed).Error()
+	})
+}
+
+// first 查询第一条记录，不存在时返回 nil
+func (p *EloquentUserProvider) first(user auth.Authenticatable, tx database.DB) (auth.Authenticatable, error) {
+	tx = tx.Limit(1).Find(user)
+	if err := tx.Error(); err != nil {
+		return nil, err
+	}
+	if tx.RowsAffected() == 0 {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// applyCredentials 把凭证转换为查询条件，没有可用条件时返回 false
+func applyCredentials(tx database.DB, credentials auth.Credentials) (database.DB, bool) {
+	keys := make([]string, 0, len(credentials))
+	for key := range credentials {
+		if !strings.Contains(key, "password") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return tx, false
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch value := credentials[key].(type) {
+		case func(database.DB) database.DB:
+			tx = tx.Scopes(value)
+		case []byte:
+			tx = tx.Where(key+" = ?", value)
+		default:
+			if value != nil && reflect.TypeOf(value).Kind() == reflect.Slice {
+				tx = tx.Where(key+" IN ?", value)
+			} else {
+				tx = tx.Where(key+" = ?", value)
+			}
+		}
+	}
+	return tx, true
+}
+
+// validatePassword 使用 Hasher 校验凭证中的密码
+func validatePassword(hasher auth.Hasher, user auth.Authenticatable, credentials auth.Credentials) (bool, error) {
+	if hasher == nil {
+		return false, auth.ErrNoHasher
+	}
+	password := credentials.Password()
+	if password == "" {
+		return false, nil
+	}
+	return hasher.Check(password, user.GetAuthPassword()), nil
+}
+
+// rehashPassword 哈希需要升级时用凭证中的明文重新生成，通过 save 写入存储
+//
+// Hasher 不支持重新哈希或用户不支持更新密码时跳过。
+func rehashPassword(hasher auth.Hasher, user auth.Authenticatable, credentials auth.Credentials, force bool, save func(column, hashed string) error) error {
+	rehasher, ok := hasher.(auth.Rehasher)
+	if !ok {
+		return nil
+	}
+	updatable, ok := user.(auth.PasswordUpdatable)
+	password := credentials.Password()
+	if !ok || password == "" || (!force && !rehasher.NeedsRehash(user.GetAuthPassword())) {
+		return nil
+	}
+	hashed, err := rehasher.Make(password)
+	if err != nil {
+		return err
+	}
+	if err := save(updatable.GetAuthPasswordName(), hashed); err != nil {
+		return err
+	}
+	updatable.SetAuthPassword(hashed)
+	return nil
+}
+
+// tokenMatches 以常数时间比较"记住我"令牌
+func tokenMatches(stored, token string) bool {
+	return stored != "" && subtle.ConstantTimeCompare([]byte(stored), []byte(token)) == 1
+}
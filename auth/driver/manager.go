@@ -0,0 +1,204 @@
+package driver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// GuardFactory 守卫工厂，provider 为守卫配置中 "provider" 引用的用户提供者，未配置时为 nil
+type GuardFactory func(name string, config map[string]interface{}, provider auth.UserProvider) (auth.Guard, error)
+
+// ProviderFactory 用户提供者工厂
+type ProviderFactory func(config map[string]interface{}) (auth.UserProvider, error)
+
+// Manager 认证管理器实现
+//
+// 守卫在首次使用时创建并缓存，内置 session、token 守卫和 eloquent、database 用户提供者。
+// session 守卫的 "remember" 配置为"记住我" Cookie 的有效期，整数按分钟解释，
+// "rehash_on_login" 控制登录成功后是否按需重新哈希密码（默认开启）；
+// token 守卫支持 "input_key"、"storage_key" 和 "hash" 配置。
+type Manager struct {
+	mu                sync.RWMutex
+	guardConfig       map[string]map[string]interface{}
+	providerConfig    map[string]map[string]interface{}
+	defaultGuard      string
+	guards            map[string]auth.Guard
+	guardFactories    map[string]GuardFactory
+	providerFactories map[string]ProviderFactory
+	hasher            auth.Hasher
+	key               []byte
+}
+
+var _ auth.Manager = (*Manager)(nil)
+
+// NewManager 创建认证管理器，guards 和 providers 分别为守卫、用户提供者名称到配置的映射
+func NewManager(guards, providers map[string]map[string]interface{}, defaultGuard string) *Manager {
+	m := &Manager{
+		guardConfig:       guards,
+		providerConfig:    providers,
+		defaultGuard:      defaultGuard,
+		guards:            make(map[string]auth.Guard),
+		guardFactories:    make(map[string]GuardFactory),
+		providerFactories: make(map[string]ProviderFactory),
+	}
+	m.Extend("session", func(name string, config map[string]interface{}, provider auth.UserProvider) (auth.Guard, error) {
+		if provider == nil {
+			return nil, fmt.Errorf("auth: guard [%s] requires a provider", name)
+		}
+		guard := NewSessionGuard(name, provider).SetKey(m.key).RehashOnLogin(boolOption(config, "rehash_on_login", true))
+		return guard.SetRememberDuration(durationOption(config, "remember", guard.remember)), nil
+	})
+	m.Extend("token", func(name string, config map[string]interface{}, provider auth.UserProvider) (auth.Guard, error) {
+		if provider == nil {
+			return nil, fmt.Errorf("auth: guard [%s] requires a provider", name)
+		}
+		return NewTokenGuard(name, provider,
+			stringOption(config, "input_key", ""),
+			stringOption(config, "storage_key", ""),
+			boolOption(config, "hash", false)), nil
+	})
+	m.ExtendProvider("eloquent", func(config map[string]interface{}) (auth.UserProvider, error) {
+		db, err := clientOption[database.DB](config, "db")
+		if err != nil {
+			return nil, err
+		}
+		model, err := clientOption[func() auth.Authenticatable](config, "model")
+		if err != nil {
+			return nil, err
+		}
+		return NewEloquentUserProvider(db, model, m.hasherFor(config)), nil
+	})
+	m.ExtendProvider("database", func(config map[string]interface{}) (auth.UserProvider, error) {
+		db, err := clientOption[database.DB](config, "db")
+		if err != nil {
+			return nil, err
+		}
+		return NewDatabaseUserProvider(db, stringOption(config, "table", "users"), m.hasherFor(config)), nil
+	})
+	return m
+}
+
+// Guard 获取守卫，不传名称时使用默认守卫
+func (m *Manager) Guard(name ...string) (auth.Guard, error) {
+	guardName := m.GetDefaultGuard()
+	if len(name) > 0 && name[0] != "" {
+		guardName = name[0]
+	}
+
+	m.mu.RLock()
+	guard, ok := m.guards[guardName]
+	m.mu.RUnlock()
+	if ok {
+		return guard, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if guard, ok := m.guards[guardName]; ok {
+		return guard, nil
+	}
+	config, ok := m.guardConfig[guardName]
+	if !ok {
+		return nil, fmt.Errorf("auth: guard [%s] is not defined", guardName)
+	}
+	driverName, _ := config["driver"].(string)
+	factory, ok := m.guardFactories[driverName]
+	if !ok {
+		return nil, fmt.Errorf("auth: guard driver [%s] is not supported", driverName)
+	}
+
+	var provider auth.UserProvider
+	if providerName, _ := config["provider"].(string); providerName != "" {
+		var err error
+		if provider, err = m.createUserProvider(providerName); err != nil {
+			return nil, err
+		}
+	}
+	guard, err := factory(guardName, config, provider)
+	if err != nil {
+		return nil, err
+	}
+	m.guards[guardName] = guard
+	return guard, nil
+}
+
+// CreateUserProvider 按名称创建用户提供者
+func (m *Manager) CreateUserProvider(name string) (auth.UserProvider, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.createUserProvider(name)
+}
+
+// createUserProvider 创建用户提供者，调用方需要持有锁
+func (m *Manager) createUserProvider(name string) (auth.UserProvider, error) {
+	config, ok := m.providerConfig[name]
+	if !ok {
+		return nil, fmt.Errorf("auth: user provider [%s] is not defined", name)
+	}
+	driverName, _ := config["driver"].(string)
+	factory, ok := m.providerFactories[driverName]
+	if !ok {
+		return nil, fmt.Errorf("auth: user provider driver [%s] is not supported", driverName)
+	}
+	return factory(config)
+}
+
+// GetDefaultGuard 获取默认守卫名称
+func (m *Manager) GetDefaultGuard() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultGuard
+}
+
+// SetDefaultGuard 设置默认守卫名称
+func (m *Manager) SetDefaultGuard(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultGuard = name
+}
+
+// Extend 注册守卫驱动
+func (m *Manager) Extend(driver string, factory GuardFactory) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.guardFactories[driver] = factory
+}
+
+// ExtendProvider 注册用户提供者驱动
+func (m *Manager) ExtendProvider(driver string, factory ProviderFactory) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.providerFactories[driver] = factory
+}
+
+// ViaRequest 注册基于闭包的守卫驱动，对应 Laravel 的 Auth::viaRequest
+func (m *Manager) ViaRequest(driver string, callback RequestCallback) {
+	m.Extend(driver, func(name string, config map[string]interface{}, provider auth.UserProvider) (auth.Guard, error) {
+		return NewRequestGuard(name, callback, provider), nil
+	})
+}
+
+// SetHasher 设置用户提供者校验密码使用的 Hasher，需要在获取守卫前调用
+func (m *Manager) SetHasher(hasher auth.Hasher) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hasher = hasher
+}
+
+// SetKey 设置"记住我" Cookie 的签名密钥，通常为应用密钥，需要在获取守卫前调用
+func (m *Manager) SetKey(key []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.key = key
+}
+
+// hasherFor 读取用户提供者配置中的 "hasher"，未配置时使用管理器的 Hasher，调用方需要持有锁
+func (m *Manager) hasherFor(config map[string]interface{}) auth.Hasher {
+	if hasher, ok := config["hasher"].(auth.Hasher); ok {
+		return hasher
+	}
+	return m.hasher
+}
@@ -0,0 +1,87 @@
+package driver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/cnote0/laraveldoc/auth"
+)
+
+// RequestCallback 从请求中解析用户的闭包，未认证时返回 nil
+type RequestCallback func(ctx context.Context, r *http.Request, provider auth.UserProvider) (auth.Authenticatable, error)
+
+// RequestGuard 闭包守卫，对应 Laravel 的 Auth::viaRequest
+//
+// 适用于自定义请求头、签名等简单的认证方式，不需要编写完整的守卫。
+type RequestGuard struct {
+	name     string
+	callback RequestCallback
+	provider auth.UserProvider
+}
+
+var _ auth.Guard = (*RequestGuard)(nil)
+
+// NewRequestGuard 创建闭包守卫，provider 可以为 nil
+func NewRequestGuard(name string, callback RequestCallback, provider auth.UserProvider) *RequestGuard {
+	return &RequestGuard{name: name, callback: callback, provider: provider}
+}
+
+// Check 当前请求是否已认证
+func (g *RequestGuard) Check(ctx context.Context) bool {
+	return check(ctx, g)
+}
+
+// Guest 当前请求是否为访客
+func (g *RequestGuard) Guest(ctx context.Context) bool {
+	return !check(ctx, g)
+}
+
+// ID 获取当前用户的唯一标识
+func (g *RequestGuard) ID(ctx context.Context) interface{} {
+	return id(ctx, g)
+}
+
+// HasUser 当前请求是否已经解析出用户
+func (g *RequestGuard) HasUser(ctx context.Context) bool {
+	return hasUser(ctx, g.name)
+}
+
+// SetUser 设置当前请求的用户
+func (g *RequestGuard) SetUser(ctx context.Context, user auth.Authenticatable) {
+	setUser(ctx, g.name, user)
+}
+
+// User 获取当前用户
+func (g *RequestGuard) User(ctx context.Context) (auth.Authenticatable, error) {
+	state := auth.StateFromContext(ctx)
+	if state == nil {
+		return nil, auth.ErrNoState
+	}
+	guard := state.Guard(g.name)
+	if guard.Resolved {
+		return guard.User, nil
+	}
+	user, err := g.callback(ctx, state.Request, g.provider)
+	if err != nil {
+		return nil, err
+	}
+	guard.User, guard.Resolved = user, true
+	return user, nil
+}
+
+// Validate 以凭证作为查询参数构造请求并调用闭包，与 Laravel 的行为一致
+func (g *RequestGuard) Validate(ctx context.Context, credentials auth.Credentials) (bool, error) {
+	query := url.Values{}
+	for key, value := range credentials {
+		if s, ok := value.(string); ok {
+			query.Set(key, s)
+		}
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "/?"+query.Encode(), nil)
+	if err != nil {
+		return false, err
+	}
+	user, err := g.callback(ctx, r, g.provider)
+	return user != nil, err
+}
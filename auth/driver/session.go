@@ -0,0 +1,353 @@
+package driver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/auth"
+)
+
+// ErrMissingKey "记住我" Cookie 需要签名密钥
+var ErrMissingKey = errors.New("auth: remember cookie requires a signing key, call Manager.SetKey")
+
+// SessionGuard 会话守卫，对应 Laravel 的 SessionGuard
+//
+// 登录用户的唯一标识保存在会话的 login_{name} 键中；"记住我"时额外写入 remember_{name} Cookie，
+// 内容为唯一标识、记住我令牌和密码哈希的摘要，使用 HMAC-SHA256 签名，修改密码后旧 Cookie 自动失效。
+type SessionGuard struct {
+	name     string
+	provider auth.UserProvider
+	key      []byte
+	remember time.Duration
+	rehash   bool
+}
+
+var _ auth.StatefulGuard = (*SessionGuard)(nil)
+
+// NewSessionGuard 创建会话守卫
+func NewSessionGuard(name string, provider auth.UserProvider) *SessionGuard {
+	return &SessionGuard{name: name, provider: provider, remember: 400 * 24 * time.Hour, rehash: true}
+}
+
+// SetKey 设置"记住我" Cookie 的签名密钥
+func (g *SessionGuard) SetKey(key []byte) *SessionGuard {
+	g.key = key
+	return g
+}
+
+// SetRememberDuration 设置"记住我" Cookie 的有效期，默认 400 天
+func (g *SessionGuard) SetRememberDuration(d time.Duration) *SessionGuard {
+	g.remember = d
+	return g
+}
+
+// RehashOnLogin 设置 Attempt 成功后是否按需重新哈希密码，默认开启
+//
+// 用户提供者需要实现 auth.PasswordRehasher，Hasher 需要实现 auth.Rehasher。
+func (g *SessionGuard) RehashOnLogin(rehash bool) *SessionGuard {
+	g.rehash = rehash
+	return g
+}
+
+// Name 获取守卫名称
+func (g *SessionGuard) Name() string {
+	return g.name
+}
+
+// SessionKey 会话中保存用户唯一标识的键
+func (g *SessionGuard) SessionKey() string {
+	return "login_" + g.name
+}
+
+// RecallerName "记住我" Cookie 的名称
+func (g *SessionGuard) RecallerName() string {
+	return "remember_" + g.name
+}
+
+// Check 当前请求是否已认证
+func (g *SessionGuard) Check(ctx context.Context) bool {
+	return check(ctx, g)
+}
+
+// Guest 当前请求是否为访客
+func (g *SessionGuard) Guest(ctx context.Context) bool {
+	return !check(ctx, g)
+}
+
+// ID 获取当前用户的唯一标识
+func (g *SessionGuard) ID(ctx context.Context) interface{} {
+	return id(ctx, g)
+}
+
+// HasUser 当前请求是否已经解析出用户
+func (g *SessionGuard) HasUser(ctx context.Context) bool {
+	return hasUser(ctx, g.name)
+}
+
+// SetUser 设置当前请求的用户
+func (g *SessionGuard) SetUser(ctx context.Context, user auth.Authenticatable) {
+	setUser(ctx, g.name, user)
+}
+
+// User 获取当前用户，依次从会话和"记住我" Cookie 中解析
+func (g *SessionGuard) User(ctx context.Context) (auth.Authenticatable, error) {
+	state := auth.StateFromContext(ctx)
+	if state == nil {
+		return nil, auth.ErrNoState
+	}
+	guard := state.Guard(g.name)
+	if guard.LoggedOut || guard.Resolved {
+		return guard.User, nil
+	}
+
+	if state.Session != nil {
+		if identifier := state.Session.Get(g.SessionKey()); identifier != nil {
+			user, err := g.provider.RetrieveByID(ctx, identifier)
+			if err != nil {
+				return nil, err
+			}
+			if user != nil {
+				guard.User, guard.Resolved = user, true
+				return user, nil
+			}
+		}
+	}
+
+	user, err := g.userFromRecaller(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		if state.Session != nil {
+			if err := g.updateSession(state.Session, user.GetAuthIdentifier()); err != nil {
+				return nil, err
+			}
+		}
+		guard.ViaRemember = true
+	}
+	guard.User, guard.Resolved = user, true
+	return user, nil
+}
+
+// Validate 校验凭证是否有效
+func (g *SessionGuard) Validate(ctx context.Context, credentials auth.Credentials) (bool, error) {
+	user, err := g.retrieveValid(ctx, credentials)
+	return user != nil, err
+}
+
+// Attempt 使用凭证登录，成功后按需重新哈希密码
+func (g *SessionGuard) Attempt(ctx context.Context, credentials auth.Credentials, remember bool) (bool, error) {
+	user, err := g.retrieveValid(ctx, credentials)
+	if err != nil || user == nil {
+		return false, err
+	}
+	if rehasher, ok := g.provider.(auth.PasswordRehasher); ok && g.rehash {
+		if err := rehasher.RehashPasswordIfRequired(ctx, user, credentials, false); err != nil {
+			return false, err
+		}
+	}
+	if err := g.Login(ctx, user, remember); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Once 使用凭证认证当前请求，不写入会话和 Cookie
+func (g *SessionGuard) Once(ctx context.Context, credentials auth.Credentials) (bool, error) {
+	user, err := g.retrieveValid(ctx, credentials)
+	if err != nil || user == nil {
+		return false, err
+	}
+	g.SetUser(ctx, user)
+	return true, nil
+}
+
+// Login 登录用户
+//
+// 会话 ID 会重新生成以防止会话固定攻击；remember 为 true 时确保用户有"记住我"令牌并写入 Cookie。
+func (g *SessionGuard) Login(ctx context.Context, user auth.Authenticatable, remember bool) error {
+	state := auth.StateFromContext(ctx)
+	if state == nil {
+		return auth.ErrNoState
+	}
+	if state.Session == nil {
+		return auth.ErrNoSession
+	}
+	if err := g.updateSession(state.Session, user.GetAuthIdentifier()); err != nil {
+		return err
+	}
+	if remember {
+		if len(g.key) == 0 {
+			return ErrMissingKey
+		}
+		if user.GetRememberTokenName() == "" {
+			return fmt.Errorf("auth: user %T does not support remember tokens", user)
+		}
+		if user.GetRememberToken() == "" {
+			if err := g.cycleRememberToken(ctx, user); err != nil {
+				return err
+			}
+		}
+		g.queueRecallerCookie(state, user)
+	}
+	g.SetUser(ctx, user)
+	return nil
+}
+
+// LoginUsingID 按唯一标识登录用户
+func (g *SessionGuard) LoginUsingID(ctx context.Context, identifier interface{}, remember bool) (auth.Authenticatable, error) {
+	user, err := g.provider.RetrieveByID(ctx, identifier)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	if err := g.Login(ctx, user, remember); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Logout 注销当前用户
+//
+// 会话中的登录标识和"记住我" Cookie 被清除，用户的"记住我"令牌会被轮换，使其他设备上的 Cookie 失效。
+func (g *SessionGuard) Logout(ctx context.Context) error {
+	state := auth.StateFromContext(ctx)
+	if state == nil {
+		return auth.ErrNoState
+	}
+	user, err := g.User(ctx)
+	if err != nil {
+		return err
+	}
+	if state.Session != nil {
+		state.Session.Forget(g.SessionKey())
+	}
+	if state.Request != nil && state.Writer != nil {
+		if _, err := state.Request.Cookie(g.RecallerName()); err == nil {
+			http.SetCookie(state.Writer, &http.Cookie{Name: g.RecallerName(), Path: "/", MaxAge: -1})
+		}
+	}
+	if user != nil && user.GetRememberToken() != "" {
+		if err := g.cycleRememberToken(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	guard := state.Guard(g.name)
+	guard.User, guard.Resolved, guard.ViaRemember, guard.LoggedOut = nil, true, false, true
+	return nil
+}
+
+// ViaRemember 当前用户是否通过"记住我" Cookie 认证
+func (g *SessionGuard) ViaRemember(ctx context.Context) bool {
+	if state := auth.StateFromContext(ctx); state != nil {
+		return state.Guard(g.name).ViaRemember
+	}
+	return false
+}
+
+// retrieveValid 按凭证获取用户并校验密码，凭证无效时返回 nil
+func (g *SessionGuard) retrieveValid(ctx context.Context, credentials auth.Credentials) (auth.Authenticatable, error) {
+	user, err := g.provider.RetrieveByCredentials(ctx, credentials)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	ok, err := g.provider.ValidateCredentials(ctx, user, credentials)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return user, nil
+}
+
+// updateSession 保存登录标识并重新生成会话 ID
+func (g *SessionGuard) updateSession(session auth.Session, identifier interface{}) error {
+	session.Put(g.SessionKey(), identifier)
+	return session.Migrate(true)
+}
+
+// cycleRememberToken 生成新的"记住我"令牌并保存
+func (g *SessionGuard) cycleRememberToken(ctx context.Context, user auth.Authenticatable) error {
+	token, err := randomToken(30)
+	if err != nil {
+		return err
+	}
+	user.SetRememberToken(token)
+	return g.provider.UpdateRememberToken(ctx, user, token)
+}
+
+// queueRecallerCookie 写入"记住我" Cookie
+func (g *SessionGuard) queueRecallerCookie(state *auth.State, user auth.Authenticatable) {
+	if state.Writer == nil {
+		return
+	}
+	payload := fmt.Sprint(user.GetAuthIdentifier()) + "|" + user.GetRememberToken() + "|" + g.passwordDigest(user)
+	http.SetCookie(state.Writer, &http.Cookie{
+		Name:     g.RecallerName(),
+		Value:    base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + g.sign(payload),
+		Path:     "/",
+		Expires:  time.Now().Add(g.remember),
+		HttpOnly: true,
+		Secure:   state.Request != nil && state.Request.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// userFromRecaller 从"记住我" Cookie 中解析用户，Cookie 无效时返回 nil
+func (g *SessionGuard) userFromRecaller(ctx context.Context, state *auth.State) (auth.Authenticatable, error) {
+	if len(g.key) == 0 || state.Request == nil {
+		return nil, nil
+	}
+	cookie, err := state.Request.Cookie(g.RecallerName())
+	if err != nil {
+		return nil, nil
+	}
+	encoded, signature, ok := strings.Cut(cookie.Value, ".")
+	if !ok {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(g.sign(string(raw)))) {
+		return nil, nil
+	}
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return nil, nil
+	}
+
+	// 整数主键还原为整数，避免严格比较类型的存储（如 memdb）匹配失败
+	var identifier interface{} = parts[0]
+	if n, err := strconv.ParseInt(parts[0], 10, 64); err == nil {
+		identifier = n
+	}
+	user, err := g.provider.RetrieveByToken(ctx, identifier, parts[1])
+	if err != nil || user == nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(parts[2]), []byte(g.passwordDigest(user))) != 1 {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// sign 计算签名
+func (g *SessionGuard) sign(payload string) string {
+	mac := hmac.New(sha256.New, g.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// passwordDigest 密码哈希的摘要，Cookie 中不直接存放密码哈希
+func (g *SessionGuard) passwordDigest(user auth.Authenticatable) string {
+	mac := hmac.New(sha256.New, g.key)
+	mac.Write([]byte("password|" + user.GetAuthPassword()))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
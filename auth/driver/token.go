@@ -0,0 +1,128 @@
+package driver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/auth"
+)
+
+// TokenGuard API 令牌守卫，对应 Laravel 的 TokenGuard
+//
+// 令牌依次从查询参数或表单字段 inputKey、Authorization: Bearer 请求头、Basic 认证的密码中读取，
+// 然后按 storageKey 列查询用户。hash 为 true 时数据库中保存的是令牌的 SHA-256 十六进制摘要。
+type TokenGuard struct {
+	name       string
+	provider   auth.UserProvider
+	inputKey   string
+	storageKey string
+	hash       bool
+}
+
+var _ auth.Guard = (*TokenGuard)(nil)
+
+// NewTokenGuard 创建令牌守卫，inputKey 和 storageKey 为空时使用 "api_token"
+func NewTokenGuard(name string, provider auth.UserProvider, inputKey, storageKey string, hash bool) *TokenGuard {
+	if inputKey == "" {
+		inputKey = "api_token"
+	}
+	if storageKey == "" {
+		storageKey = "api_token"
+	}
+	return &TokenGuard{name: name, provider: provider, inputKey: inputKey, storageKey: storageKey, hash: hash}
+}
+
+// Check 当前请求是否已认证
+func (g *TokenGuard) Check(ctx context.Context) bool {
+	return check(ctx, g)
+}
+
+// Guest 当前请求是否为访客
+func (g *TokenGuard) Guest(ctx context.Context) bool {
+	return !check(ctx, g)
+}
+
+// ID 获取当前用户的唯一标识
+func (g *TokenGuard) ID(ctx context.Context) interface{} {
+	return id(ctx, g)
+}
+
+// HasUser 当前请求是否已经解析出用户
+func (g *TokenGuard) HasUser(ctx context.Context) bool {
+	return hasUser(ctx, g.name)
+}
+
+// SetUser 设置当前请求的用户
+func (g *TokenGuard) SetUser(ctx context.Context, user auth.Authenticatable) {
+	setUser(ctx, g.name, user)
+}
+
+// User 获取当前用户
+func (g *TokenGuard) User(ctx context.Context) (auth.Authenticatable, error) {
+	state := auth.StateFromContext(ctx)
+	if state == nil {
+		return nil, auth.ErrNoState
+	}
+	guard := state.Guard(g.name)
+	if guard.Resolved {
+		return guard.User, nil
+	}
+
+	var user auth.Authenticatable
+	if token := g.TokenForRequest(state.Request); token != "" {
+		var err error
+		if user, err = g.retrieve(ctx, token); err != nil {
+			return nil, err
+		}
+	}
+	guard.User, guard.Resolved = user, true
+	return user, nil
+}
+
+// Validate 校验凭证中 inputKey 字段的令牌是否有效
+func (g *TokenGuard) Validate(ctx context.Context, credentials auth.Credentials) (bool, error) {
+	token, _ := credentials[g.inputKey].(string)
+	if token == "" {
+		return false, nil
+	}
+	user, err := g.retrieve(ctx, token)
+	return user != nil, err
+}
+
+// TokenForRequest 从请求中读取令牌
+func (g *TokenGuard) TokenForRequest(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	if token := r.FormValue(g.inputKey); token != "" {
+		return token
+	}
+	if token := BearerToken(r); token != "" {
+		return token
+	}
+	if _, password, ok := r.BasicAuth(); ok {
+		return password
+	}
+	return ""
+}
+
+// retrieve 按令牌查询用户
+func (g *TokenGuard) retrieve(ctx context.Context, token string) (auth.Authenticatable, error) {
+	if g.hash {
+		sum := sha256.Sum256([]byte(token))
+		token = hex.EncodeToString(sum[:])
+	}
+	return g.provider.RetrieveByCredentials(ctx, auth.Credentials{g.storageKey: token})
+}
+
+// BearerToken 读取 Authorization: Bearer 请求头中的令牌
+func BearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package auth
+
+import "context"
+
+// Guard 守卫接口，识别当前请求的用户
+//
+// 所有方法都从 ctx 中的 State 读取请求，并把解析出的用户缓存在 State 上，
+// 同一请求内多次调用 User 只会查询一次用户提供者。
+type Guard interface {
+	// Check 当前请求是否已认证
+	Check(ctx context.Context) bool
+
+	// Guest 当前请求是否为访客
+	Guest(ctx context.Context) bool
+
+	// User 获取当前用户，未认证时返回 nil
+	User(ctx context.Context) (Authenticatable, error)
+
+	// ID 获取当前用户的唯一标识，未认证时返回 nil
+	ID(ctx context.Context) interface{}
+
+	// Validate 校验凭证是否有效，不改变认证状态
+	Validate(ctx context.Context, credentials Credentials) (bool, error)
+
+	// HasUser 当前请求是否已经解析出用户
+	HasUser(ctx context.Context) bool
+
+	// SetUser 设置当前请求的用户
+	SetUser(ctx context.Context, user Authenticatable)
+}
+
+// StatefulGuard 有状态守卫接口，通过会话和"记住我" Cookie 保持登录状态
+type StatefulGuard interface {
+	Guard
+
+	// Attempt 使用凭证登录，remember 为 true 时写入"记住我" Cookie
+	Attempt(ctx context.Context, credentials Credentials, remember bool) (bool, error)
+
+	// Once 使用凭证认证当前请求，不写入会话和 Cookie
+	Once(ctx context.Context, credentials Credentials) (bool, error)
+
+	// Login 登录用户，remember 为 true 时写入"记住我" Cookie
+	Login(ctx context.Context, user Authenticatable, remember bool) error
+
+	// LoginUsingID 按唯一标识登录用户，用户不存在时返回 nil
+	LoginUsingID(ctx context.Context, id interface{}, remember bool) (Authenticatable, error)
+
+	// Logout 注销当前用户，清除会话和"记住我" Cookie
+	Logout(ctx context.Context) error
+
+	// ViaRemember 当前用户是否通过"记住我" Cookie 认证
+	ViaRemember(ctx context.Context) bool
+}
+
+// UserProvider 用户提供者接口
+//
+// 找不到用户时返回 nil, nil，错误只用于存储故障。
+type UserProvider interface {
+	// RetrieveByID 按唯一标识获取用户
+	RetrieveByID(ctx context.Context, identifier interface{}) (Authenticatable, error)
+
+	// RetrieveByToken 按唯一标识和"记住我"令牌获取用户
+	RetrieveByToken(ctx context.Context, identifier interface{}, token string) (Authenticatable, error)
+
+	// UpdateRememberToken 更新用户的"记住我"令牌
+	UpdateRememberToken(ctx context.Context, user Authenticatable, token string) error
+
+	// RetrieveByCredentials 按凭证（不含密码）获取用户
+	RetrieveByCredentials(ctx context.Context, credentials Credentials) (Authenticatable, error)
+
+	// ValidateCredentials 校验凭证中的密码
+	ValidateCredentials(ctx context.Context, user Authenticatable, credentials Credentials) (bool, error)
+}
+
+// Hasher 密码哈希校验接口
+type Hasher interface {
+	// Check 校验明文与哈希是否匹配
+	Check(value, hashedValue string) bool
+}
+
+// Rehasher 支持重新生成哈希的 Hasher，hashing.Manager 和各 hashing 驱动都满足该接口
+type Rehasher interface {
+	Hasher
+
+	// Make 生成哈希
+	Make(value string) (string, error)
+
+	// NeedsRehash 哈希是否需要按当前算法和参数重新生成
+	NeedsRehash(hashedValue string) bool
+}
+
+// PasswordRehasher 可以在登录成功后重新哈希密码的用户提供者
+//
+// 有状态守卫在 Attempt 校验凭证成功后调用，用于平滑升级哈希算法或成本参数。
+type PasswordRehasher interface {
+	// RehashPasswordIfRequired 哈希需要升级（或 force 为 true）时用凭证中的明文重新生成并保存
+	RehashPasswordIfRequired(ctx context.Context, user Authenticatable, credentials Credentials, force bool) error
+}
+
+// Session 会话接口，有状态守卫通过它保存登录用户
+type Session interface {
+	// Get 获取会话值，不存在时返回 nil
+	Get(key string) interface{}
+
+	// Put 设置会话值
+	Put(key string, value interface{})
+
+	// Forget 删除会话值
+	Forget(keys ...string)
+
+	// Migrate 重新生成会话 ID，destroy 为 true 时删除旧会话，用于防止会话固定攻击
+	Migrate(destroy bool) error
+}
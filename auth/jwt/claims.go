@@ -0,0 +1,112 @@
+package jwt
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Claims JWT 声明集合
+type Claims map[string]interface{}
+
+// String 获取字符串声明
+func (c Claims) String(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+// Subject 获取 sub 声明
+func (c Claims) Subject() string {
+	return c.String("sub")
+}
+
+// Issuer 获取 iss 声明
+func (c Claims) Issuer() string {
+	return c.String("iss")
+}
+
+// Audience 获取 aud 声明，兼容字符串和数组两种形式
+func (c Claims) Audience() []string {
+	switch value := c["aud"].(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []interface{}:
+		audience := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				audience = append(audience, s)
+			}
+		}
+		return audience
+	}
+	return nil
+}
+
+// Scopes 获取 scope（空格分隔）或 scp（数组）声明中的权限范围
+func (c Claims) Scopes() []string {
+	if scope := c.String("scope"); scope != "" {
+		return strings.Fields(scope)
+	}
+	switch value := c["scp"].(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		scopes := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes
+	}
+	return nil
+}
+
+// HasScope 是否包含权限范围
+func (c Claims) HasScope(scope string) bool {
+	for _, item := range c.Scopes() {
+		if item == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpiresAt 获取 exp 声明
+func (c Claims) ExpiresAt() (time.Time, bool) {
+	return c.Time("exp")
+}
+
+// NotBefore 获取 nbf 声明
+func (c Claims) NotBefore() (time.Time, bool) {
+	return c.Time("nbf")
+}
+
+// IssuedAt 获取 iat 声明
+func (c Claims) IssuedAt() (time.Time, bool) {
+	return c.Time("iat")
+}
+
+// Time 获取 NumericDate 类型的声明（Unix 秒）
+func (c Claims) Time(name string) (time.Time, bool) {
+	var seconds float64
+	switch value := c[name].(type) {
+	case float64:
+		seconds = value
+	case int64:
+		seconds = float64(value)
+	case int:
+		seconds = float64(value)
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		seconds = f
+	default:
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), true
+}
@@ -0,0 +1,208 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/auth/driver"
+)
+
+// Guard JWT 守卫
+//
+// 从 Authorization: Bearer 请求头读取令牌并验证，通过 ClaimsUserProvider 把声明映射为用户。
+// 令牌无效时请求视为访客；声明保存在请求状态中，可以通过 Claims 访问。
+type Guard struct {
+	name     string
+	verifier *Verifier
+	users    ClaimsUserProvider
+}
+
+var _ auth.Guard = (*Guard)(nil)
+
+// NewGuard 创建 JWT 守卫
+func NewGuard(name string, verifier *Verifier, users ClaimsUserProvider) *Guard {
+	return &Guard{name: name, verifier: verifier, users: users}
+}
+
+// Extend 在认证管理器中注册 jwt 守卫驱动
+//
+// 守卫配置：
+//   - "secret"：HS256 共享密钥（string 或 []byte）
+//   - "public_key"：RS256 或 EdDSA 公钥
+//   - "jwks_url"：远程 JWKS 地址
+//   - "issuer"：签发者；同时设置 "discover": true 时通过 OpenID Connect 发现文档获取 JWKS
+//   - "audience"：可接受的受众（string 或 []string）
+//   - "algorithms"：允许的算法
+//   - "leeway"：时钟偏差，整数按秒解释
+//   - "claims"：ClaimsUserProvider；未设置时，配置了 "provider" 则按 sub 查询本地用户，否则使用 StatelessUsers
+func Extend(manager *driver.Manager) {
+	manager.Extend("jwt", func(name string, config map[string]interface{}, provider auth.UserProvider) (auth.Guard, error) {
+		verifier, err := verifierFromConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("auth: guard [%s]: %w", name, err)
+		}
+		users, ok := config["claims"].(ClaimsUserProvider)
+		switch {
+		case ok:
+		case provider != nil:
+			users = NewSubjectUsers(provider, "")
+		default:
+			users = StatelessUsers{}
+		}
+		return NewGuard(name, verifier, users), nil
+	})
+}
+
+// Check 当前请求是否已认证
+func (g *Guard) Check(ctx context.Context) bool {
+	user, err := g.User(ctx)
+	return err == nil && user != nil
+}
+
+// Guest 当前请求是否为访客
+func (g *Guard) Guest(ctx context.Context) bool {
+	return !g.Check(ctx)
+}
+
+// ID 获取当前用户的唯一标识
+func (g *Guard) ID(ctx context.Context) interface{} {
+	user, err := g.User(ctx)
+	if err != nil || user == nil {
+		return nil
+	}
+	return user.GetAuthIdentifier()
+}
+
+// HasUser 当前请求是否已经解析出用户
+func (g *Guard) HasUser(ctx context.Context) bool {
+	state := auth.StateFromContext(ctx)
+	return state != nil && state.Guard(g.name).User != nil
+}
+
+// SetUser 设置当前请求的用户
+func (g *Guard) SetUser(ctx context.Context, user auth.Authenticatable) {
+	if state := auth.StateFromContext(ctx); state != nil {
+		guard := state.Guard(g.name)
+		guard.User, guard.Resolved, guard.LoggedOut = user, true, false
+	}
+}
+
+// User 获取当前用户
+func (g *Guard) User(ctx context.Context) (auth.Authenticatable, error) {
+	state := auth.StateFromContext(ctx)
+	if state == nil {
+		return nil, auth.ErrNoState
+	}
+	guard := state.Guard(g.name)
+	if guard.Resolved {
+		return guard.User, nil
+	}
+	guard.Resolved = true
+	if state.Request == nil {
+		return nil, nil
+	}
+	token := driver.BearerToken(state.Request)
+	if token == "" {
+		return nil, nil
+	}
+	claims, err := g.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, nil
+	}
+	user, err := g.users.RetrieveByClaims(ctx, claims)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	guard.User, guard.Token = user, claims
+	return user, nil
+}
+
+// Validate 校验凭证中 "token" 字段的令牌是否有效并能映射到用户
+func (g *Guard) Validate(ctx context.Context, credentials auth.Credentials) (bool, error) {
+	token, _ := credentials["token"].(string)
+	if token == "" {
+		return false, nil
+	}
+	claims, err := g.verifier.Verify(ctx, token)
+	if err != nil {
+		return false, nil
+	}
+	user, err := g.users.RetrieveByClaims(ctx, claims)
+	return user != nil, err
+}
+
+// Claims 获取认证当前请求的令牌声明，未认证时返回 nil
+func (g *Guard) Claims(ctx context.Context) Claims {
+	if _, err := g.User(ctx); err != nil {
+		return nil
+	}
+	claims, _ := auth.StateFromContext(ctx).Guard(g.name).Token.(Claims)
+	return claims
+}
+
+// verifierFromConfig 根据守卫配置创建验证器
+func verifierFromConfig(config map[string]interface{}) (*Verifier, error) {
+	options := VerifierOptions{}
+	options.Issuer, _ = config["issuer"].(string)
+	switch value := config["audience"].(type) {
+	case string:
+		options.Audience = []string{value}
+	case []string:
+		options.Audience = value
+	}
+	options.Algorithms, _ = config["algorithms"].([]string)
+	switch value := config["leeway"].(type) {
+	case time.Duration:
+		options.Leeway = value
+	case int:
+		options.Leeway = time.Duration(value) * time.Second
+	}
+
+	if discover, _ := config["discover"].(bool); discover {
+		if options.Issuer == "" {
+			return nil, fmt.Errorf("jwt: discovery requires an issuer")
+		}
+		clientID := ""
+		if len(options.Audience) > 0 {
+			clientID = options.Audience[0]
+		}
+		verifier, err := NewOIDCVerifier(context.Background(), options.Issuer, clientID, JWKSOptions{})
+		if err != nil {
+			return nil, err
+		}
+		verifier.options.Audience = options.Audience
+		verifier.options.Leeway = options.Leeway
+		return verifier, nil
+	}
+
+	switch {
+	case config["secret"] != nil:
+		var secret []byte
+		switch value := config["secret"].(type) {
+		case string:
+			secret = []byte(value)
+		case []byte:
+			secret = value
+		default:
+			return nil, fmt.Errorf("jwt: config \"secret\" must be string or []byte, got %T", value)
+		}
+		if len(options.Algorithms) == 0 {
+			options.Algorithms = []string{HS256}
+		}
+		return NewVerifier(StaticKeys{"": secret}, options), nil
+	case config["public_key"] != nil:
+		if len(options.Algorithms) == 0 {
+			options.Algorithms = []string{RS256, EdDSA}
+		}
+		return NewVerifier(StaticKeys{"": config["public_key"]}, options), nil
+	case config["jwks_url"] != nil:
+		url, _ := config["jwks_url"].(string)
+		if len(options.Algorithms) == 0 {
+			options.Algorithms = []string{RS256, EdDSA}
+		}
+		return NewVerifier(NewJWKS(url, JWKSOptions{}), options), nil
+	}
+	return nil, fmt.Errorf("jwt: one of \"secret\", \"public_key\", \"jwks_url\" or \"discover\" is required")
+}
@@ -0,0 +1,26 @@
+// Package jwt 提供 JWT 守卫和 OpenID Connect 集成
+//
+// 支持 HS256、RS256 和 EdDSA（Ed25519）签名，验证密钥可以是静态密钥、远程 JWKS（带缓存和密钥轮换），
+// 或通过 OpenID Connect 发现文档自动获取。位于身份提供方之后的服务可以不依赖会话完成认证：
+// 守卫把已验证的声明交给 ClaimsUserProvider 映射为用户，RequireToken 中间件则只校验令牌，
+// 不需要本地用户。只使用标准库实现。
+//
+// 包结构：
+// - claims.go - Claims 声明集合和注册声明读取
+// - token.go - Sign 签发、Verifier 验证器和错误定义
+// - keys.go - KeySet、StaticKeys、JWK 和 JWKS 远程密钥集合
+// - oidc.go - Discover 发现文档和 NewOIDCVerifier
+// - provider.go - ClaimsUserProvider、StatelessUsers、SubjectUsers 和 ClaimsUser
+// - guard.go - Guard JWT 守卫和认证管理器驱动注册
+// - middleware.go - RequireToken、RequireScopes 中间件
+//
+// 使用示例：
+//
+//	jwt.Extend(manager)
+//	// config/auth.php 中的守卫
+//	"api": {"driver": "jwt", "issuer": "https://id.example.com", "audience": "orders-api", "discover": true}
+//
+//	// 不映射用户的服务间调用
+//	verifier, _ := jwt.NewOIDCVerifier(ctx, "https://id.example.com", "orders-api", jwt.JWKSOptions{})
+//	http.Handle("/internal/", jwt.RequireToken(verifier)(jwt.RequireScopes("orders:read")(internal)))
+package jwt
@@ -0,0 +1,228 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeySet 验证密钥集合
+type KeySet interface {
+	// Key 按令牌头中的 kid 和 alg 获取验证密钥，找不到时返回 ErrKeyNotFound
+	Key(ctx context.Context, kid, algorithm string) (interface{}, error)
+}
+
+// StaticKeys 静态密钥集合，kid 到密钥的映射
+//
+// 键为空字符串的密钥用于不带 kid 的令牌，也作为找不到 kid 时的后备。
+type StaticKeys map[string]interface{}
+
+// Key 获取验证密钥
+func (k StaticKeys) Key(ctx context.Context, kid, algorithm string) (interface{}, error) {
+	if key, ok := k[kid]; ok {
+		return key, nil
+	}
+	if key, ok := k[""]; ok {
+		return key, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+// JWK JSON Web Key
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	X         string `json:"x,omitempty"`
+	K         string `json:"k,omitempty"`
+}
+
+// PublicKey 把 JWK 转换为验证密钥：RSA 为 *rsa.PublicKey，OKP Ed25519 为 ed25519.PublicKey，oct 为 []byte
+func (j JWK) PublicKey() (interface{}, error) {
+	switch j.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: invalid RSA modulus in key %q", j.KeyID)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("jwt: invalid RSA exponent in key %q", j.KeyID)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if j.Curve != "Ed25519" {
+			return nil, fmt.Errorf("jwt: unsupported OKP curve %q", j.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("jwt: invalid Ed25519 key %q", j.KeyID)
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		k, err := base64.RawURLEncoding.DecodeString(j.K)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: invalid symmetric key %q", j.KeyID)
+		}
+		return k, nil
+	}
+	return nil, fmt.Errorf("jwt: unsupported key type %q", j.KeyType)
+}
+
+// ParseJWKS 解析 JWKS 文档，跳过不支持的密钥和非签名用途的密钥
+func ParseJWKS(data []byte) (map[string]JWK, error) {
+	var document struct {
+		Keys []JWK `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("jwt: invalid JWKS document: %w", err)
+	}
+	keys := make(map[string]JWK, len(document.Keys))
+	for _, key := range document.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		keys[key.KeyID] = key
+	}
+	return keys, nil
+}
+
+// JWKSOptions 远程密钥集合选项
+type JWKSOptions struct {
+	// Client HTTP 客户端，默认为 10 秒超时的客户端
+	Client *http.Client
+
+	// TTL 缓存有效期，响应带 Cache-Control: max-age 时以其为准，默认 1 小时
+	TTL time.Duration
+
+	// MinRefreshInterval 遇到未知 kid 时两次强制刷新的最小间隔，防止伪造 kid 导致频繁请求，默认 1 分钟
+	MinRefreshInterval time.Duration
+}
+
+// JWKS 远程密钥集合，从身份提供方的 jwks_uri 获取并缓存公钥
+//
+// 缓存过期或遇到未知 kid（身份提供方轮换密钥）时重新获取。
+type JWKS struct {
+	url     string
+	options JWKSOptions
+
+	mu        sync.Mutex
+	keys      map[string]JWK
+	fetchedAt time.Time
+	expiresAt time.Time
+}
+
+// NewJWKS 创建远程密钥集合
+func NewJWKS(url string, options JWKSOptions) *JWKS {
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if options.TTL <= 0 {
+		options.TTL = time.Hour
+	}
+	if options.MinRefreshInterval <= 0 {
+		options.MinRefreshInterval = time.Minute
+	}
+	return &JWKS{url: url, options: options}
+}
+
+// Key 获取验证密钥
+func (j *JWKS) Key(ctx context.Context, kid, algorithm string) (interface{}, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	if j.keys == nil || now.After(j.expiresAt) {
+		if err := j.refresh(ctx, now); err != nil {
+			return nil, err
+		}
+	}
+	key, ok := j.lookup(kid, algorithm)
+	if !ok && now.Sub(j.fetchedAt) >= j.options.MinRefreshInterval {
+		if err := j.refresh(ctx, now); err != nil {
+			return nil, err
+		}
+		key, ok = j.lookup(kid, algorithm)
+	}
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key.PublicKey()
+}
+
+// lookup 按 kid 查找密钥；令牌没有 kid 时使用唯一与算法匹配的密钥
+func (j *JWKS) lookup(kid, algorithm string) (JWK, bool) {
+	if kid != "" {
+		key, ok := j.keys[kid]
+		return key, ok && compatible(key, algorithm)
+	}
+	var found JWK
+	matches := 0
+	for _, key := range j.keys {
+		if compatible(key, algorithm) {
+			found = key
+			matches++
+		}
+	}
+	return found, matches == 1
+}
+
+// refresh 获取 JWKS 文档，调用方需要持有锁
+func (j *JWKS) refresh(ctx context.Context, now time.Time) error {
+	data, header, err := fetch(ctx, j.options.Client, j.url)
+	if err != nil {
+		return err
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+	ttl := j.options.TTL
+	if maxAge, ok := cacheMaxAge(header.Get("Cache-Control")); ok {
+		ttl = maxAge
+	}
+	j.keys, j.fetchedAt, j.expiresAt = keys, now, now.Add(ttl)
+	return nil
+}
+
+// compatible 密钥是否可用于算法
+func compatible(key JWK, algorithm string) bool {
+	if key.Algorithm != "" && key.Algorithm != algorithm {
+		return false
+	}
+	switch algorithm {
+	case HS256:
+		return key.KeyType == "oct"
+	case RS256:
+		return key.KeyType == "RSA"
+	case EdDSA:
+		return key.KeyType == "OKP"
+	}
+	return false
+}
+
+// cacheMaxAge 解析 Cache-Control 的 max-age
+func cacheMaxAge(header string) (time.Duration, bool) {
+	for _, directive := range strings.Split(header, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(directive), "=")
+		if ok && strings.EqualFold(name, "max-age") {
+			if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+				return time.Duration(seconds) * time.Second, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,145 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwksServer 返回 keys 的 JWKS 端点，记录请求次数
+func jwksServer(t *testing.T, keys *atomic.Value, cacheControl string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys.Load()})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// okp Ed25519 公钥的 JWK
+func okp(kid string, public ed25519.PublicKey) JWK {
+	return JWK{KeyType: "OKP", KeyID: kid, Curve: "Ed25519", Use: "sig", X: base64.RawURLEncoding.EncodeToString(public)}
+}
+
+func TestJWKSVerifiesAndRotates(t *testing.T) {
+	public1, private1, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public2, private2, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys atomic.Value
+	keys.Store([]JWK{okp("k1", public1)})
+	server, requests := jwksServer(t, &keys, "")
+
+	jwks := NewJWKS(server.URL, JWKSOptions{MinRefreshInterval: time.Nanosecond})
+	v := NewVerifier(jwks, VerifierOptions{})
+	token1, err := Sign(Claims{"sub": "1"}, EdDSA, private1, "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify(context.Background(), token1); err != nil {
+		t.Fatalf("Verify(k1) error = %v", err)
+	}
+	if _, err := v.Verify(context.Background(), token1); err != nil {
+		t.Fatalf("Verify(k1) again error = %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1 (cached)", got)
+	}
+
+	// 身份提供方轮换密钥后，未知 kid 触发刷新
+	keys.Store([]JWK{okp("k1", public1), okp("k2", public2)})
+	token2, err := Sign(Claims{"sub": "2"}, EdDSA, private2, "k2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if _, err := v.Verify(context.Background(), token2); err != nil {
+		t.Fatalf("Verify(k2) after rotation error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", got)
+	}
+}
+
+func TestJWKSLimitsRefreshForUnknownKeyIDs(t *testing.T) {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys atomic.Value
+	keys.Store([]JWK{okp("k1", public)})
+	server, requests := jwksServer(t, &keys, "")
+
+	jwks := NewJWKS(server.URL, JWKSOptions{MinRefreshInterval: time.Hour})
+	for i := 0; i < 5; i++ {
+		if _, err := jwks.Key(context.Background(), "forged", EdDSA); !errors.Is(err, ErrKeyNotFound) {
+			t.Fatalf("Key(forged) error = %v, want ErrKeyNotFound", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times for forged kids, want 1", got)
+	}
+}
+
+func TestJWKSRejectsIncompatibleKeys(t *testing.T) {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryption := okp("enc", public)
+	encryption.Use = "enc"
+	var keys atomic.Value
+	keys.Store([]JWK{okp("k1", public), encryption, {KeyType: "oct", KeyID: "hmac", Algorithm: HS256, K: "c2VjcmV0"}})
+	server, _ := jwksServer(t, &keys, "")
+
+	jwks := NewJWKS(server.URL, JWKSOptions{})
+	if _, err := jwks.Key(context.Background(), "k1", RS256); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Key(k1, RS256) error = %v, want ErrKeyNotFound", err)
+	}
+	if _, err := jwks.Key(context.Background(), "hmac", EdDSA); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Key(hmac, EdDSA) error = %v, want ErrKeyNotFound", err)
+	}
+	if _, err := jwks.Key(context.Background(), "enc", EdDSA); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Key(enc) error = %v, want ErrKeyNotFound for an encryption key", err)
+	}
+	key, err := jwks.Key(context.Background(), "", EdDSA)
+	if err != nil {
+		t.Fatalf("Key(no kid) error = %v", err)
+	}
+	if !public.Equal(key) {
+		t.Error("Key(no kid) did not return the only Ed25519 key")
+	}
+}
+
+func TestCacheMaxAge(t *testing.T) {
+	tests := map[string]time.Duration{
+		"max-age=300":                   5 * time.Minute,
+		"public, max-age=60, immutable": time.Minute,
+		"no-cache":                      0,
+		"max-age=0":                     0,
+		"max-age=abc":                   0,
+	}
+	for header, want := range tests {
+		got, ok := cacheMaxAge(header)
+		if ok != (want > 0) || got != want {
+			t.Errorf("cacheMaxAge(%q) = %v, %v, want %v", header, got, ok, want)
+		}
+	}
+}
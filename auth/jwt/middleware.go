@@ -0,0 +1,99 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/auth/driver"
+)
+
+// claimsKey context 中声明的键
+type claimsKey struct{}
+
+// WithClaims 把已验证的声明绑定到 context
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext 获取 RequireToken 中间件验证的声明，不存在时返回 nil
+func ClaimsFromContext(ctx context.Context) Claims {
+	claims, _ := ctx.Value(claimsKey{}).(Claims)
+	return claims
+}
+
+// RequireToken 要求请求携带有效 Bearer 令牌的中间件
+//
+// 验证器负责校验签名、有效期、签发者和受众，失败时按 RFC 6750 返回 401 和 WWW-Authenticate 头；
+// 验证通过的声明通过 WithClaims 绑定到请求 context。适用于不需要映射本地用户的服务间调用。
+func RequireToken(verifier *Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := driver.BearerToken(r)
+			if token == "" {
+				challenge(w, http.StatusUnauthorized, "", "")
+				return
+			}
+			claims, err := verifier.Verify(r.Context(), token)
+			if err != nil {
+				if !isTokenError(err) {
+					http.Error(w, err.Error(), http.StatusServiceUnavailable)
+					return
+				}
+				challenge(w, http.StatusUnauthorized, "invalid_token", strings.TrimPrefix(err.Error(), "jwt: "))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+// RequireScopes 要求令牌包含全部权限范围的中间件，需要放在 RequireToken 之后
+func RequireScopes(scopes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := ClaimsFromContext(r.Context())
+			if claims == nil {
+				challenge(w, http.StatusUnauthorized, "", "")
+				return
+			}
+			for _, scope := range scopes {
+				if !claims.HasScope(scope) {
+					challenge(w, http.StatusForbidden, "insufficient_scope", fmt.Sprintf("scope %q is required", scope))
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// challenge 写入 Bearer 认证质询
+func challenge(w http.ResponseWriter, status int, code, description string) {
+	value := `Bearer`
+	if code != "" {
+		value += fmt.Sprintf(` error=%q, error_description=%q`, code, description)
+	}
+	w.Header().Set("WWW-Authenticate", value)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	message := "Unauthenticated."
+	if status == http.StatusForbidden {
+		message = description
+	}
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+// isTokenError 错误是否由令牌本身引起（而非获取密钥失败等服务端问题）
+func isTokenError(err error) bool {
+	for _, target := range []error{ErrMalformed, ErrUnsupportedAlgorithm, ErrInvalidKey, ErrKeyNotFound,
+		ErrSignature, ErrExpired, ErrNotYetValid, ErrInvalidIssuer, ErrInvalidAudience} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ProviderMetadata OpenID Connect 发现文档
+type ProviderMetadata struct {
+	Issuer                string   `json:"issuer"`
+	JWKSURI               string   `json:"jwks_uri"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint,omitempty"`
+	SigningAlgorithms     []string `json:"id_token_signing_alg_values_supported,omitempty"`
+}
+
+// Discover 获取身份提供方的 /.well-known/openid-configuration
+//
+// 文档中的 issuer 必须与传入的 issuer 一致，client 为 nil 时使用 http.DefaultClient。
+func Discover(ctx context.Context, client *http.Client, issuer string) (*ProviderMetadata, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	data, _, err := fetch(ctx, client, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return nil, err
+	}
+	var metadata ProviderMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("jwt: invalid discovery document: %w", err)
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, fmt.Errorf("jwt: discovery issuer %q does not match %q", metadata.Issuer, issuer)
+	}
+	if metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("jwt: discovery document of %q has no jwks_uri", issuer)
+	}
+	return &metadata, nil
+}
+
+// NewOIDCVerifier 通过发现文档创建验证器，校验签发者并要求受众包含 clientID
+//
+// 只接受非对称签名算法（RS256、EdDSA），身份提供方声明了支持的算法时取交集。
+func NewOIDCVerifier(ctx context.Context, issuer, clientID string, options JWKSOptions) (*Verifier, error) {
+	metadata, err := Discover(ctx, options.Client, issuer)
+	if err != nil {
+		return nil, err
+	}
+	algorithms := []string{RS256, EdDSA}
+	if len(metadata.SigningAlgorithms) > 0 {
+		supported := make([]string, 0, 2)
+		for _, algorithm := range metadata.SigningAlgorithms {
+			if algorithm == RS256 || algorithm == EdDSA {
+				supported = append(supported, algorithm)
+			}
+		}
+		if len(supported) == 0 {
+			return nil, fmt.Errorf("%w: %q supports %v", ErrUnsupportedAlgorithm, issuer, metadata.SigningAlgorithms)
+		}
+		algorithms = supported
+	}
+
+	var audience []string
+	if clientID != "" {
+		audience = []string{clientID}
+	}
+	return NewVerifier(NewJWKS(metadata.JWKSURI, options), VerifierOptions{
+		Issuer:            metadata.Issuer,
+		Audience:          audience,
+		Algorithms:        algorithms,
+		RequireExpiration: true,
+	}), nil
+}
+
+// fetch 获取 JSON 文档
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, http.Header, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("jwt: fetch %s: %w", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("jwt: fetch %s: unexpected status %s", url, response.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, response.Header, nil
+}
@@ -0,0 +1,108 @@
+package jwt
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cnote0/laraveldoc/auth"
+)
+
+// ClaimsUserProvider 根据已验证的声明获取用户
+type ClaimsUserProvider interface {
+	// RetrieveByClaims 获取用户，用户不存在时返回 nil
+	RetrieveByClaims(ctx context.Context, claims Claims) (auth.Authenticatable, error)
+}
+
+// ClaimsUserProviderFunc 函数形式的 ClaimsUserProvider
+type ClaimsUserProviderFunc func(ctx context.Context, claims Claims) (auth.Authenticatable, error)
+
+// RetrieveByClaims 实现 ClaimsUserProvider
+func (f ClaimsUserProviderFunc) RetrieveByClaims(ctx context.Context, claims Claims) (auth.Authenticatable, error) {
+	return f(ctx, claims)
+}
+
+// StatelessUsers 不查询存储，直接以声明构造 ClaimsUser，适用于身份完全由身份提供方管理的服务
+type StatelessUsers struct{}
+
+// RetrieveByClaims 实现 ClaimsUserProvider，没有 sub 声明时返回 nil
+func (StatelessUsers) RetrieveByClaims(ctx context.Context, claims Claims) (auth.Authenticatable, error) {
+	if claims.Subject() == "" {
+		return nil, nil
+	}
+	return NewClaimsUser(claims), nil
+}
+
+// SubjectUsers 以声明的值作为唯一标识，从 auth.UserProvider 获取本地用户
+type SubjectUsers struct {
+	provider auth.UserProvider
+	claim    string
+}
+
+// NewSubjectUsers 创建 SubjectUsers，claim 为空时使用 "sub"
+func NewSubjectUsers(provider auth.UserProvider, claim string) *SubjectUsers {
+	if claim == "" {
+		claim = "sub"
+	}
+	return &SubjectUsers{provider: provider, claim: claim}
+}
+
+// RetrieveByClaims 实现 ClaimsUserProvider，整数形式的标识会转换为整数
+func (s *SubjectUsers) RetrieveByClaims(ctx context.Context, claims Claims) (auth.Authenticatable, error) {
+	var identifier interface{}
+	switch value := claims[s.claim].(type) {
+	case string:
+		if value == "" {
+			return nil, nil
+		}
+		identifier = value
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			identifier = n
+		}
+	case float64:
+		identifier = int64(value)
+	default:
+		return nil, nil
+	}
+	return s.provider.RetrieveByID(ctx, identifier)
+}
+
+// ClaimsUser 由声明构造的用户，唯一标识为 sub
+type ClaimsUser struct {
+	// Claims 令牌声明
+	Claims Claims
+}
+
+var _ auth.Authenticatable = (*ClaimsUser)(nil)
+
+// NewClaimsUser 创建 ClaimsUser
+func NewClaimsUser(claims Claims) *ClaimsUser {
+	return &ClaimsUser{Claims: claims}
+}
+
+// GetAuthIdentifierName 获取唯一标识的名称
+func (u *ClaimsUser) GetAuthIdentifierName() string {
+	return "sub"
+}
+
+// GetAuthIdentifier 获取唯一标识
+func (u *ClaimsUser) GetAuthIdentifier() interface{} {
+	return u.Claims.Subject()
+}
+
+// GetAuthPassword 令牌用户没有密码
+func (u *ClaimsUser) GetAuthPassword() string {
+	return ""
+}
+
+// GetRememberToken 令牌用户不支持"记住我"
+func (u *ClaimsUser) GetRememberToken() string {
+	return ""
+}
+
+// SetRememberToken 令牌用户不支持"记住我"
+func (u *ClaimsUser) SetRememberToken(token string) {}
+
+// GetRememberTokenName 令牌用户不支持"记住我"
+func (u *ClaimsUser) GetRememberTokenName() string {
+	return ""
+}
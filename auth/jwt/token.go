@@ -0,0 +1,264 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 支持的签名算法
+const (
+	// HS256 HMAC-SHA256，密钥为 []byte
+	HS256 = "HS256"
+
+	// RS256 RSASSA-PKCS1-v1_5 SHA-256，签名使用 *rsa.PrivateKey，验证使用 *rsa.PublicKey
+	RS256 = "RS256"
+
+	// EdDSA Ed25519，签名使用 ed25519.PrivateKey，验证使用 ed25519.PublicKey
+	EdDSA = "EdDSA"
+)
+
+// 错误定义
+var (
+	// ErrMalformed 令牌格式错误
+	ErrMalformed = errors.New("jwt: malformed token")
+
+	// ErrUnsupportedAlgorithm 不支持或不允许的签名算法
+	ErrUnsupportedAlgorithm = errors.New("jwt: unsupported algorithm")
+
+	// ErrInvalidKey 密钥类型与算法不匹配
+	ErrInvalidKey = errors.New("jwt: key type does not match algorithm")
+
+	// ErrKeyNotFound 找不到验证令牌的密钥
+	ErrKeyNotFound = errors.New("jwt: verification key not found")
+
+	// ErrSignature 签名无效
+	ErrSignature = errors.New("jwt: invalid signature")
+
+	// ErrExpired 令牌已过期
+	ErrExpired = errors.New("jwt: token is expired")
+
+	// ErrNotYetValid 令牌尚未生效
+	ErrNotYetValid = errors.New("jwt: token is not valid yet")
+
+	// ErrInvalidIssuer 签发者不匹配
+	ErrInvalidIssuer = errors.New("jwt: invalid issuer")
+
+	// ErrInvalidAudience 受众不匹配
+	ErrInvalidAudience = errors.New("jwt: invalid audience")
+)
+
+// Header JOSE 头
+type Header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// Sign 签发令牌，kid 为空时头中不包含 kid
+func Sign(claims Claims, algorithm string, key interface{}, kid string) (string, error) {
+	header, err := json.Marshal(Header{Algorithm: algorithm, Type: "JWT", KeyID: kid})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch algorithm {
+	case HS256:
+		secret, ok := key.([]byte)
+		if !ok {
+			return "", ErrInvalidKey
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(input))
+		signature = mac.Sum(nil)
+	case RS256:
+		private, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return "", ErrInvalidKey
+		}
+		digest := sha256.Sum256([]byte(input))
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, digest[:]); err != nil {
+			return "", err
+		}
+	case EdDSA:
+		private, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return "", ErrInvalidKey
+		}
+		signature = ed25519.Sign(private, []byte(input))
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// VerifierOptions 验证选项
+type VerifierOptions struct {
+	// Issuer 要求的签发者，为空时不校验
+	Issuer string
+
+	// Audience 可接受的受众，令牌的 aud 至少包含其中之一，为空时不校验
+	Audience []string
+
+	// Algorithms 允许的签名算法，为空时允许全部支持的算法
+	Algorithms []string
+
+	// Leeway 校验 exp、nbf 时容忍的时钟偏差
+	Leeway time.Duration
+
+	// RequireExpiration 是否要求令牌包含 exp
+	RequireExpiration bool
+}
+
+// Verifier 令牌验证器
+type Verifier struct {
+	keys    KeySet
+	options VerifierOptions
+	now     func() time.Time
+}
+
+// NewVerifier 创建令牌验证器
+func NewVerifier(keys KeySet, options VerifierOptions) *Verifier {
+	return &Verifier{keys: keys, options: options, now: time.Now}
+}
+
+// Verify 校验签名、有效期、签发者和受众，返回声明
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+	var header Header
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if !v.allowed(header.Algorithm) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, header.Algorithm)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	key, err := v.keys.Key(ctx, header.KeyID, header.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Algorithm, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	return claims, v.validate(claims)
+}
+
+// allowed 算法是否被允许
+func (v *Verifier) allowed(algorithm string) bool {
+	if algorithm != HS256 && algorithm != RS256 && algorithm != EdDSA {
+		return false
+	}
+	if len(v.options.Algorithms) == 0 {
+		return true
+	}
+	for _, item := range v.options.Algorithms {
+		if item == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// validate 校验注册声明
+func (v *Verifier) validate(claims Claims) error {
+	now := v.now()
+	if exp, ok := claims.ExpiresAt(); ok {
+		if !now.Before(exp.Add(v.options.Leeway)) {
+			return ErrExpired
+		}
+	} else if v.options.RequireExpiration {
+		return ErrExpired
+	}
+	if nbf, ok := claims.NotBefore(); ok && now.Add(v.options.Leeway).Before(nbf) {
+		return ErrNotYetValid
+	}
+	if v.options.Issuer != "" && claims.Issuer() != v.options.Issuer {
+		return ErrInvalidIssuer
+	}
+	if len(v.options.Audience) > 0 {
+		for _, audience := range claims.Audience() {
+			for _, accepted := range v.options.Audience {
+				if audience == accepted {
+					return nil
+				}
+			}
+		}
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
+// verifySignature 按算法校验签名，密钥类型不匹配时返回 ErrInvalidKey（防止算法混淆攻击）
+func verifySignature(algorithm string, key interface{}, input string, signature []byte) error {
+	switch algorithm {
+	case HS256:
+		secret, ok := key.([]byte)
+		if !ok {
+			return ErrInvalidKey
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(input))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return ErrSignature
+		}
+	case RS256:
+		public, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrInvalidKey
+		}
+		digest := sha256.Sum256([]byte(input))
+		if rsa.VerifyPKCS1v15(public, crypto.SHA256, digest[:], signature) != nil {
+			return ErrSignature
+		}
+	case EdDSA:
+		public, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return ErrInvalidKey
+		}
+		if !ed25519.Verify(public, []byte(input), signature) {
+			return ErrSignature
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, algorithm)
+	}
+	return nil
+}
+
+// decodeSegment 解码 base64url JSON 段
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformed
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return ErrMalformed
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 7515 附录 A.1 的 HS256 示例
+func TestVerifyRFC7515HS256(t *testing.T) {
+	const token = "eyJ0eXAiOiJKV1QiLA0KICJhbGciOiJIUzI1NiJ9" +
+		".eyJpc3MiOiJqb2UiLA0KICJleHAiOjEzMDA4MTkzODAsDQogImh0dHA6Ly9leGFtcGxlLmNvbS9pc19yb290Ijp0cnVlfQ" +
+		".dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	key, err := base64.RawURLEncoding.DecodeString("AyM1SysPpbyDfgZld3umj1qzKObwVMkoqQ-EstJQLr_T-1qS0gZH75aKtMN3Yj0iPS4hcgUuTwjAzZr1Z9CAow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := NewVerifier(StaticKeys{"": key}, VerifierOptions{Issuer: "joe"})
+	v.now = func() time.Time { return time.Unix(1300819379, 0) }
+	claims, err := v.Verify(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if claims["http://example.com/is_root"] != true {
+		t.Errorf("claims = %v", claims)
+	}
+
+	v.now = func() time.Time { return time.Unix(1300819380, 0) }
+	if _, err := v.Verify(context.Background(), token); !errors.Is(err, ErrExpired) {
+		t.Errorf("Verify() at exp error = %v, want ErrExpired", err)
+	}
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		algorithm string
+		sign      interface{}
+		verify    interface{}
+	}{
+		{HS256, []byte("0123456789abcdef0123456789abcdef"), []byte("0123456789abcdef0123456789abcdef")},
+		{RS256, rsaKey, &rsaKey.PublicKey},
+		{EdDSA, edPrivate, edPublic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			token, err := Sign(Claims{"sub": "42", "exp": time.Now().Add(time.Hour).Unix()}, tt.algorithm, tt.sign, "key-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var header Header
+			if err := decodeSegment(strings.Split(token, ".")[0], &header); err != nil {
+				t.Fatal(err)
+			}
+			if header.Algorithm != tt.algorithm || header.KeyID != "key-1" || header.Type != "JWT" {
+				t.Errorf("header = %+v", header)
+			}
+
+			v := NewVerifier(StaticKeys{"key-1": tt.verify}, VerifierOptions{Algorithms: []string{tt.algorithm}})
+			claims, err := v.Verify(context.Background(), token)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if claims.Subject() != "42" {
+				t.Errorf("Subject() = %q, want 42", claims.Subject())
+			}
+
+			// 篡改载荷后签名失效
+			parts := strings.Split(token, ".")
+			parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1","exp":9999999999}`))
+			if _, err := v.Verify(context.Background(), strings.Join(parts, ".")); !errors.Is(err, ErrSignature) {
+				t.Errorf("Verify(tampered) error = %v, want ErrSignature", err)
+			}
+		})
+	}
+}
+
+func TestSignRejectsMismatchedKeys(t *testing.T) {
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Sign(Claims{}, HS256, edPrivate, ""); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Sign(HS256, ed25519 key) error = %v, want ErrInvalidKey", err)
+	}
+	if _, err := Sign(Claims{}, RS256, []byte("secret"), ""); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Sign(RS256, []byte) error = %v, want ErrInvalidKey", err)
+	}
+	if _, err := Sign(Claims{}, "none", nil, ""); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("Sign(none) error = %v, want ErrUnsupportedAlgorithm", err)
+	}
+}
+
+// unsigned 生成指定头和载荷、签名为 signature 的令牌
+func unsigned(header, payload string, signature []byte) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(header)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifyRejectsAlgorithmAttacks(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := NewVerifier(StaticKeys{"": &rsaKey.PublicKey}, VerifierOptions{})
+
+	// alg=none 的未签名令牌
+	none := unsigned(`{"alg":"none","typ":"JWT"}`, `{"sub":"admin"}`, nil)
+	if _, err := v.Verify(context.Background(), none); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("Verify(alg=none) error = %v, want ErrUnsupportedAlgorithm", err)
+	}
+
+	// 以 RSA 公钥作为 HMAC 密钥签名的 HS256 令牌（算法混淆攻击）
+	public, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin"}`))
+	mac := hmac.New(sha256.New, public)
+	mac.Write([]byte(header + "." + payload))
+	confused := header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	if _, err := v.Verify(context.Background(), confused); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Verify(HS256 with RSA public key) error = %v, want ErrInvalidKey", err)
+	}
+
+	// 不在允许列表中的算法
+	restricted := NewVerifier(StaticKeys{"": []byte("secret")}, VerifierOptions{Algorithms: []string{RS256}})
+	token, err := Sign(Claims{}, HS256, []byte("secret"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := restricted.Verify(context.Background(), token); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("Verify(disallowed HS256) error = %v, want ErrUnsupportedAlgorithm", err)
+	}
+}
+
+func TestVerifyRejectsMalformedTokens(t *testing.T) {
+	v := NewVerifier(StaticKeys{"": []byte("secret")}, VerifierOptions{})
+	valid, err := Sign(Claims{"sub": "1"}, HS256, []byte("secret"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(valid, ".")
+	for name, token := range map[string]string{
+		"empty":          "",
+		"two segments":   parts[0] + "." + parts[1],
+		"four segments":  valid + ".x",
+		"bad header":     "!!!." + parts[1] + "." + parts[2],
+		"header not obj": base64.RawURLEncoding.EncodeToString([]byte(`"HS256"`)) + "." + parts[1] + "." + parts[2],
+		"bad signature":  parts[0] + "." + parts[1] + ".!!!",
+	} {
+		if _, err := v.Verify(context.Background(), token); !errors.Is(err, ErrMalformed) {
+			t.Errorf("%s: Verify() error = %v, want ErrMalformed", name, err)
+		}
+	}
+}
+
+func TestVerifyRegisteredClaims(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name    string
+		claims  Claims
+		options VerifierOptions
+		want    error
+	}{
+		{"valid", Claims{"exp": now.Add(time.Minute).Unix()}, VerifierOptions{}, nil},
+		{"expired", Claims{"exp": now.Add(-time.Second).Unix()}, VerifierOptions{}, ErrExpired},
+		{"expired within leeway", Claims{"exp": now.Add(-time.Second).Unix()}, VerifierOptions{Leeway: time.Minute}, nil},
+		{"missing exp", Claims{}, VerifierOptions{RequireExpiration: true}, ErrExpired},
+		{"not yet valid", Claims{"nbf": now.Add(time.Minute).Unix()}, VerifierOptions{}, ErrNotYetValid},
+		{"nbf within leeway", Claims{"nbf": now.Add(time.Second).Unix()}, VerifierOptions{Leeway: time.Minute}, nil},
+		{"issuer", Claims{"iss": "https://id.example.com"}, VerifierOptions{Issuer: "https://id.example.com"}, nil},
+		{"wrong issuer", Claims{"iss": "https://evil.example.com"}, VerifierOptions{Issuer: "https://id.example.com"}, ErrInvalidIssuer},
+		{"audience string", Claims{"aud": "api"}, VerifierOptions{Audience: []string{"api"}}, nil},
+		{"audience array", Claims{"aud": []string{"web", "api"}}, VerifierOptions{Audience: []string{"api"}}, nil},
+		{"wrong audience", Claims{"aud": []string{"web"}}, VerifierOptions{Audience: []string{"api"}}, ErrInvalidAudience},
+		{"missing audience", Claims{}, VerifierOptions{Audience: []string{"api"}}, ErrInvalidAudience},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := Sign(tt.claims, HS256, secret, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := NewVerifier(StaticKeys{"": secret}, tt.options)
+			v.now = func() time.Time { return now }
+			if _, err := v.Verify(context.Background(), token); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimsScopes(t *testing.T) {
+	tests := []struct {
+		claims Claims
+		want   []string
+	}{
+		{Claims{"scope": "read write"}, []string{"read", "write"}},
+		{Claims{"scp": []interface{}{"read", "write"}}, []string{"read", "write"}},
+		{Claims{"scp": "read"}, []string{"read"}},
+		{Claims{}, nil},
+	}
+	for _, tt := range tests {
+		if got := tt.claims.Scopes(); strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("%v.Scopes() = %v, want %v", tt.claims, got, tt.want)
+		}
+	}
+	if !(Claims{"scope": "read write"}).HasScope("write") {
+		t.Error("HasScope(write) = false")
+	}
+}
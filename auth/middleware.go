@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrUnauthenticated 请求未认证
+var ErrUnauthenticated = errors.New("auth: unauthenticated")
+
+// AuthenticationError 认证失败错误，对应 Laravel 的 AuthenticationException
+type AuthenticationError struct {
+	// Guards 尝试过的守卫
+	Guards []string
+
+	// RedirectTo 未认证时的跳转地址
+	RedirectTo string
+}
+
+// Error 实现 error 接口
+func (e *AuthenticationError) Error() string {
+	return ErrUnauthenticated.Error()
+}
+
+// Is 使 errors.Is(err, ErrUnauthenticated) 成立
+func (e *AuthenticationError) Is(target error) bool {
+	return target == ErrUnauthenticated
+}
+
+// Middleware 认证中间件，对应 Laravel 的 auth 中间件
+//
+// 依次尝试指定的守卫（默认使用默认守卫），第一个认证成功的守卫的用户通过 WithUser 绑定到请求 context，
+// 之后在处理器中可以用 UserFromContext 获取。
+type Middleware struct {
+	manager         Manager
+	guards          []string
+	session         func(r *http.Request) Session
+	redirectTo      string
+	optional        bool
+	unauthenticated func(w http.ResponseWriter, r *http.Request, err *AuthenticationError)
+}
+
+// Authenticate 创建认证中间件
+func Authenticate(manager Manager, guards ...string) *Middleware {
+	return &Middleware{manager: manager, guards: guards}
+}
+
+// UseSession 设置获取请求会话的函数，使用 session 守卫时必须设置
+func (m *Middleware) UseSession(session func(r *http.Request) Session) *Middleware {
+	m.session = session
+	return m
+}
+
+// RedirectTo 设置未认证时的跳转地址，JSON 请求始终返回 401
+func (m *Middleware) RedirectTo(path string) *Middleware {
+	m.redirectTo = path
+	return m
+}
+
+// Optional 允许访客通过，只负责绑定请求状态和已认证用户，适用于登录页等路由
+func (m *Middleware) Optional() *Middleware {
+	m.optional = true
+	return m
+}
+
+// Unauthenticated 设置未认证时的处理函数，替代默认的 401 或跳转响应
+func (m *Middleware) Unauthenticated(handler func(w http.ResponseWriter, r *http.Request, err *AuthenticationError)) *Middleware {
+	m.unauthenticated = handler
+	return m
+}
+
+// Handler 包装 HTTP 处理器
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		state := StateFromContext(ctx)
+		if state == nil {
+			var session Session
+			if m.session != nil {
+				session = m.session(r)
+			}
+			state = NewState(w, r, session)
+			ctx = WithState(ctx, state)
+		}
+
+		guards := m.guards
+		if len(guards) == 0 {
+			guards = []string{""}
+		}
+		for _, name := range guards {
+			guard, err := m.manager.Guard(name)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			user, err := guard.User(ctx)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if user != nil {
+				ctx = WithUser(ctx, user)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		}
+
+		if m.optional {
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		m.fail(w, r.WithContext(ctx), &AuthenticationError{Guards: m.guards, RedirectTo: m.redirectTo})
+	})
+}
+
+// fail 响应未认证请求
+func (m *Middleware) fail(w http.ResponseWriter, r *http.Request, err *AuthenticationError) {
+	if m.unauthenticated != nil {
+		m.unauthenticated(w, r, err)
+		return
+	}
+	if err.RedirectTo != "" && !ExpectsJSON(r) {
+		http.Redirect(w, r, err.RedirectTo, http.StatusFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": "Unauthenticated."})
+}
+
+// EnsureEmailIsVerified 要求用户已验证邮箱的中间件，对应 Laravel 的 verified 中间件，需要放在 Authenticate 之后
+//
+// 用户未登录，或实现了 MustVerifyEmail 但邮箱未验证时，JSON 请求返回 403，
+// 其他请求跳转到 redirectTo（通常为验证提示页）；redirectTo 为空时始终返回 403。
+func EnsureEmailIsVerified(redirectTo string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := UserFromContext(r.Context())
+			verifiable, mustVerify := user.(MustVerifyEmail)
+			if user != nil && (!mustVerify || verifiable.HasVerifiedEmail()) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if redirectTo != "" && !ExpectsJSON(r) {
+				http.Redirect(w, r, redirectTo, http.StatusFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "Your email address is not verified."})
+		})
+	}
+}
+
+// ExpectsJSON 请求是否期望 JSON 响应（Ajax 请求或 Accept 包含 json）
+func ExpectsJSON(r *http.Request) bool {
+	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), "json")
+}
@@ -0,0 +1,188 @@
+package passwords
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/auth"
+)
+
+// ResetCallback 令牌校验通过后保存新密码
+type ResetCallback func(ctx context.Context, user CanResetPassword, password string) error
+
+// PasswordValidator 校验新密码，返回 *ValidationError 表示未通过
+type PasswordValidator func(credentials auth.Credentials) error
+
+// Broker 密码重置代理，对应 Laravel 的 PasswordBroker
+type Broker struct {
+	tokens    TokenRepository
+	users     auth.UserProvider
+	sender    ResetLinkSender
+	events    application.EventDispatcher
+	validator PasswordValidator
+}
+
+// NewBroker 创建密码重置代理
+func NewBroker(tokens TokenRepository, users auth.UserProvider, sender ResetLinkSender) *Broker {
+	return &Broker{tokens: tokens, users: users, sender: sender, validator: DefaultPasswordValidator(8)}
+}
+
+// SetEvents 设置事件分发器
+func (b *Broker) SetEvents(events application.EventDispatcher) *Broker {
+	b.events = events
+	return b
+}
+
+// SetPasswordValidator 设置新密码校验，传入 nil 时不校验
+func (b *Broker) SetPasswordValidator(validator PasswordValidator) *Broker {
+	b.validator = validator
+	return b
+}
+
+// DefaultPasswordValidator 要求密码至少 min 个字符，并且提供了 password_confirmation 时必须一致
+func DefaultPasswordValidator(min int) PasswordValidator {
+	return func(credentials auth.Credentials) error {
+		password := credentials.Password()
+		if utf8.RuneCountInString(password) < min {
+			return &ValidationError{Field: "password", Message: fmt.Sprintf("must be at least %d characters", min)}
+		}
+		if confirmation, ok := credentials["password_confirmation"]; ok && confirmation != password {
+			return &ValidationError{Field: "password", Message: "confirmation does not match"}
+		}
+		return nil
+	}
+}
+
+// SendResetLink 向凭证对应的用户发送重置链接
+func (b *Broker) SendResetLink(ctx context.Context, credentials auth.Credentials) (Status, error) {
+	user, err := b.GetUser(ctx, credentials)
+	if err != nil || user == nil {
+		return StatusInvalidUser, err
+	}
+	recently, err := b.tokens.RecentlyCreatedToken(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	if recently {
+		return StatusResetThrottled, nil
+	}
+	token, err := b.tokens.Create(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	if err := b.sender.SendResetLink(ctx, user, token); err != nil {
+		return "", err
+	}
+	b.dispatch(ctx, &PasswordResetLinkSent{User: user}, EventPasswordResetLinkSent)
+	return StatusResetLinkSent, nil
+}
+
+// Reset 校验令牌和新密码后调用 callback 保存密码并删除令牌
+//
+// callback 为 nil 时通过用户提供者的 auth.PasswordRehasher 保存新密码，并更换"记住我"令牌，
+// 使其他设备上的记住登录失效。
+func (b *Broker) Reset(ctx context.Context, credentials auth.Credentials, callback ResetCallback) (Status, error) {
+	user, status, err := b.validateReset(ctx, credentials)
+	if err != nil || user == nil {
+		return status, err
+	}
+	if callback == nil {
+		callback = b.resetPassword
+	}
+	if err := callback(ctx, user, credentials.Password()); err != nil {
+		return "", err
+	}
+	if err := b.tokens.Delete(ctx, user); err != nil {
+		return "", err
+	}
+	b.dispatch(ctx, &PasswordReset{User: user}, EventPasswordReset)
+	return StatusPasswordReset, nil
+}
+
+// GetUser 按凭证获取用户，凭证中的 token 和密码字段不参与查询
+func (b *Broker) GetUser(ctx context.Context, credentials auth.Credentials) (CanResetPassword, error) {
+	query := make(auth.Credentials, len(credentials))
+	for key, value := range credentials {
+		if key != "token" {
+			query[key] = value
+		}
+	}
+	user, err := b.users.RetrieveByCredentials(ctx, query)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	resettable, ok := user.(CanResetPassword)
+	if !ok {
+		return nil, fmt.Errorf("passwords: %T does not implement CanResetPassword", user)
+	}
+	return resettable, nil
+}
+
+// CreateToken 为用户创建令牌，不发送链接
+func (b *Broker) CreateToken(ctx context.Context, user CanResetPassword) (string, error) {
+	return b.tokens.Create(ctx, user)
+}
+
+// DeleteToken 删除用户的令牌
+func (b *Broker) DeleteToken(ctx context.Context, user CanResetPassword) error {
+	return b.tokens.Delete(ctx, user)
+}
+
+// TokenExists 令牌是否有效
+func (b *Broker) TokenExists(ctx context.Context, user CanResetPassword, token string) (bool, error) {
+	return b.tokens.Exists(ctx, user, token)
+}
+
+// validateReset 校验用户、新密码和令牌
+func (b *Broker) validateReset(ctx context.Context, credentials auth.Credentials) (CanResetPassword, Status, error) {
+	user, err := b.GetUser(ctx, credentials)
+	if err != nil || user == nil {
+		return nil, StatusInvalidUser, err
+	}
+	if b.validator != nil {
+		if err := b.validator(credentials); err != nil {
+			var validation *ValidationError
+			if errors.As(err, &validation) {
+				return nil, StatusInvalidPassword, err
+			}
+			return nil, "", err
+		}
+	}
+	token, _ := credentials["token"].(string)
+	exists, err := b.tokens.Exists(ctx, user, token)
+	if err != nil {
+		return nil, "", err
+	}
+	if !exists {
+		return nil, StatusInvalidToken, nil
+	}
+	return user, "", nil
+}
+
+// resetPassword 默认的保存新密码逻辑
+func (b *Broker) resetPassword(ctx context.Context, user CanResetPassword, password string) error {
+	rehasher, ok := b.users.(auth.PasswordRehasher)
+	if !ok {
+		return fmt.Errorf("passwords: %T cannot update passwords, pass a ResetCallback", b.users)
+	}
+	if err := rehasher.RehashPasswordIfRequired(ctx, user, auth.Credentials{"password": password}, true); err != nil {
+		return err
+	}
+	raw := make([]byte, 30)
+	if _, err := rand.Read(raw); err != nil {
+		return err
+	}
+	return b.users.UpdateRememberToken(ctx, user, hex.EncodeToString(raw))
+}
+
+// dispatch 分发事件
+func (b *Broker) dispatch(ctx context.Context, event interface{}, name string) {
+	if b.events != nil {
+		b.events.DispatchWithContext(ctx, event, name)
+	}
+}
@@ -0,0 +1,66 @@
+package passwords
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cnote0/laraveldoc/mail"
+)
+
+// MailSender 通过邮件发送重置链接
+type MailSender struct {
+	mailer  mail.Mailer
+	url     func(token, email string) string
+	expires int
+}
+
+var _ ResetLinkSender = (*MailSender)(nil)
+
+// NewMailSender 创建邮件发送器，url 根据令牌和邮箱生成重置页面地址
+func NewMailSender(mailer mail.Mailer, url func(token, email string) string) *MailSender {
+	return &MailSender{mailer: mailer, url: url, expires: 60}
+}
+
+// SetExpireMinutes 设置邮件中提示的有效分钟数，应与令牌仓库一致
+func (s *MailSender) SetExpireMinutes(minutes int) *MailSender {
+	s.expires = minutes
+	return s
+}
+
+// SendResetLink 实现 ResetLinkSender
+func (s *MailSender) SendResetLink(ctx context.Context, user CanResetPassword, token string) error {
+	email := user.GetEmailForPasswordReset()
+	return s.mailer.To(mail.NewAddress(email, "")).SendNow(ctx, &ResetPasswordMail{
+		URL:     s.url(token, email),
+		Expires: s.expires,
+	})
+}
+
+// ResetPasswordMail 重置密码邮件
+type ResetPasswordMail struct {
+	URL     string
+	Expires int
+}
+
+// Envelope 实现 mail.Mailable
+func (m *ResetPasswordMail) Envelope() mail.Envelope {
+	return mail.Envelope{Subject: "Reset Password Notification"}
+}
+
+// Content 实现 mail.Mailable
+func (m *ResetPasswordMail) Content() mail.Content {
+	text := fmt.Sprintf("You are receiving this email because we received a password reset request for your account.\n\n"+
+		"[Reset Password](%s)\n\n"+
+		"This password reset link will expire in %d minutes.\n\n"+
+		"If you did not request a password reset, no further action is required.\n", m.URL, m.Expires)
+	html, err := mail.RenderMarkdown(text, "Reset Password Notification")
+	if err != nil {
+		return mail.Content{TextString: text}
+	}
+	return mail.Content{HTMLString: html, TextString: text}
+}
+
+// Attachments 实现 mail.Mailable
+func (m *ResetPasswordMail) Attachments() []mail.Attachment {
+	return nil
+}
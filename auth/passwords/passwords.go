@@ -0,0 +1,112 @@
+// Package passwords 提供 Laravel 风格的密码重置
+//
+// PasswordBroker 负责生成重置令牌、发送重置链接和校验令牌后重置密码，
+// 令牌以 SHA-256 摘要保存在 password_reset_tokens 表中，默认 60 分钟过期，
+// 同一用户 60 秒内只能申请一次。重置成功和发送链接后通过 application.EventDispatcher 分发事件。
+//
+// 包结构：
+// - passwords.go - Status 状态、CanResetPassword 契约、ResetLinkSender 和事件
+// - tokens.go - TokenRepository 接口、PasswordResetToken 表结构和 DatabaseTokenRepository
+// - broker.go - PasswordBroker
+// - mail.go - MailSender 通过邮件发送重置链接
+//
+// 使用示例：
+//
+//	broker := passwords.NewBroker(passwords.NewDatabaseTokenRepository(db, 0, 0), users,
+//		passwords.NewMailSender(mailer, func(token, email string) string {
+//			return "https://example.com/reset-password?token=" + token + "&email=" + url.QueryEscape(email)
+//		}))
+//
+//	status, err := broker.SendResetLink(ctx, auth.Credentials{"email": r.FormValue("email")})
+//
+//	status, err = broker.Reset(ctx, auth.Credentials{
+//		"email":                 r.FormValue("email"),
+//		"token":                 r.FormValue("token"),
+//		"password":              r.FormValue("password"),
+//		"password_confirmation": r.FormValue("password_confirmation"),
+//	}, nil)
+package passwords
+
+import (
+	"context"
+
+	"github.com/cnote0/laraveldoc/auth"
+)
+
+// Status 密码重置结果，取值与 Laravel 的语言包键一致，可以直接用于翻译
+type Status string
+
+const (
+	// StatusResetLinkSent 重置链接已发送
+	StatusResetLinkSent Status = "passwords.sent"
+
+	// StatusPasswordReset 密码已重置
+	StatusPasswordReset Status = "passwords.reset"
+
+	// StatusInvalidUser 找不到用户
+	StatusInvalidUser Status = "passwords.user"
+
+	// StatusInvalidToken 令牌无效或已过期
+	StatusInvalidToken Status = "passwords.token"
+
+	// StatusInvalidPassword 新密码未通过校验
+	StatusInvalidPassword Status = "passwords.password"
+
+	// StatusResetThrottled 申请过于频繁
+	StatusResetThrottled Status = "passwords.throttled"
+)
+
+// 事件名称
+const (
+	// EventPasswordResetLinkSent 重置链接已发送，事件为 *PasswordResetLinkSent
+	EventPasswordResetLinkSent = "auth.password_reset_link_sent"
+
+	// EventPasswordReset 密码已重置，事件为 *PasswordReset
+	EventPasswordReset = "auth.password_reset"
+)
+
+// CanResetPassword 可以重置密码的用户，对应 Laravel 的 CanResetPassword 契约
+type CanResetPassword interface {
+	auth.Authenticatable
+
+	// GetEmailForPasswordReset 获取接收重置链接的邮箱
+	GetEmailForPasswordReset() string
+}
+
+// ResetLinkSender 发送重置链接
+type ResetLinkSender interface {
+	// SendResetLink 把明文令牌发送给用户
+	SendResetLink(ctx context.Context, user CanResetPassword, token string) error
+}
+
+// ResetLinkSenderFunc 函数形式的 ResetLinkSender
+type ResetLinkSenderFunc func(ctx context.Context, user CanResetPassword, token string) error
+
+// SendResetLink 实现 ResetLinkSender
+func (f ResetLinkSenderFunc) SendResetLink(ctx context.Context, user CanResetPassword, token string) error {
+	return f(ctx, user, token)
+}
+
+// PasswordResetLinkSent 重置链接已发送事件
+type PasswordResetLinkSent struct {
+	User CanResetPassword
+}
+
+// PasswordReset 密码已重置事件
+type PasswordReset struct {
+	User CanResetPassword
+}
+
+// ValidationError 新密码校验失败
+type ValidationError struct {
+	// Field 字段名
+	Field string
+
+	// Message 原因
+	Message string
+}
+
+// Error 实现 error 接口
+func (e *ValidationError) Error() string {
+	return "passwords: " + e.Field + ": " + e.Message
+}
@@ -0,0 +1,137 @@
+package passwords
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// TokenRepository 重置令牌仓库
+type TokenRepository interface {
+	// Create 为用户创建令牌并返回明文，用户原有的令牌被删除
+	Create(ctx context.Context, user CanResetPassword) (string, error)
+
+	// Exists 令牌是否存在且未过期
+	Exists(ctx context.Context, user CanResetPassword, token string) (bool, error)
+
+	// RecentlyCreatedToken 用户是否在节流时间内申请过令牌
+	RecentlyCreatedToken(ctx context.Context, user CanResetPassword) (bool, error)
+
+	// Delete 删除用户的令牌
+	Delete(ctx context.Context, user CanResetPassword) error
+
+	// DeleteExpired 删除全部过期令牌
+	DeleteExpired(ctx context.Context) error
+}
+
+// PasswordResetToken 重置令牌记录，同时是 password_reset_tokens 表的迁移定义
+type PasswordResetToken struct {
+	Email     string    `gorm:"primaryKey;size:255"`
+	Token     string    `gorm:"size:255"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+// TableName 表名
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+// DatabaseTokenRepository 基于数据表的令牌仓库，对应 Laravel 的 DatabaseTokenRepository
+type DatabaseTokenRepository struct {
+	db       database.DB
+	expires  time.Duration
+	throttle time.Duration
+	now      func() time.Time
+}
+
+var _ TokenRepository = (*DatabaseTokenRepository)(nil)
+
+// NewDatabaseTokenRepository 创建令牌仓库，expires 默认 60 分钟，throttle 默认 60 秒
+func NewDatabaseTokenRepository(db database.DB, expires, throttle time.Duration) *DatabaseTokenRepository {
+	if expires <= 0 {
+		expires = 60 * time.Minute
+	}
+	if throttle <= 0 {
+		throttle = 60 * time.Second
+	}
+	return &DatabaseTokenRepository{db: db, expires: expires, throttle: throttle, now: time.Now}
+}
+
+// Migrate 创建 password_reset_tokens 表
+func (r *DatabaseTokenRepository) Migrate() error {
+	return r.db.AutoMigrate(&PasswordResetToken{})
+}
+
+// Expires 令牌有效期
+func (r *DatabaseTokenRepository) Expires() time.Duration {
+	return r.expires
+}
+
+// Create 为用户创建令牌
+func (r *DatabaseTokenRepository) Create(ctx context.Context, user CanResetPassword) (string, error) {
+	if err := r.Delete(ctx, user); err != nil {
+		return "", err
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+	record := &PasswordResetToken{Email: user.GetEmailForPasswordReset(), Token: hashToken(token), CreatedAt: r.now()}
+	if err := r.db.WithContext(ctx).Create(record).Error(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Exists 令牌是否存在且未过期
+func (r *DatabaseTokenRepository) Exists(ctx context.Context, user CanResetPassword, token string) (bool, error) {
+	record, err := r.find(ctx, user)
+	if err != nil || record == nil {
+		return false, err
+	}
+	if !record.CreatedAt.Add(r.expires).After(r.now()) {
+		return false, nil
+	}
+	return subtle.ConstantTimeCompare([]byte(record.Token), []byte(hashToken(token))) == 1, nil
+}
+
+// RecentlyCreatedToken 用户是否在节流时间内申请过令牌
+func (r *DatabaseTokenRepository) RecentlyCreatedToken(ctx context.Context, user CanResetPassword) (bool, error) {
+	record, err := r.find(ctx, user)
+	if err != nil || record == nil {
+		return false, err
+	}
+	return record.CreatedAt.Add(r.throttle).After(r.now()), nil
+}
+
+// Delete 删除用户的令牌
+func (r *DatabaseTokenRepository) Delete(ctx context.Context, user CanResetPassword) error {
+	return r.db.WithContext(ctx).Where("email = ?", user.GetEmailForPasswordReset()).Delete(&PasswordResetToken{}).Error()
+}
+
+// DeleteExpired 删除全部过期令牌
+func (r *DatabaseTokenRepository) DeleteExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).Where("created_at < ?", r.now().Add(-r.expires)).Delete(&PasswordResetToken{}).Error()
+}
+
+// find 查询用户的令牌记录，不存在时返回 nil
+func (r *DatabaseTokenRepository) find(ctx context.Context, user CanResetPassword) (*PasswordResetToken, error) {
+	var records []PasswordResetToken
+	err := r.db.WithContext(ctx).Where("email = ?", user.GetEmailForPasswordReset()).Limit(1).Find(&records).Error()
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	return &records[0], nil
+}
+
+// hashToken 计算令牌的 SHA-256 摘要，令牌本身是高熵随机值，不需要慢哈希
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
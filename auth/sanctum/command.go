@@ -0,0 +1,37 @@
+package sanctum
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// RegisterCommands 注册 sanctum:prune-expired 命令
+//
+// expiration 为全局有效期，应与守卫配置一致。
+func RegisterCommands(artisan application.ArtisanInterface, tokens *Tokens, expiration time.Duration) {
+	artisan.Register("sanctum:prune-expired").
+		SetDescription("Prune tokens expired for more than specified number of hours").
+		AddOption("hours", "", application.InputOptionValueRequired, "The number of hours to retain expired Sanctum tokens", 24).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			hours := 24
+			switch value := input.GetOption("hours").(type) {
+			case int:
+				hours = value
+			case string:
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					return fmt.Errorf("sanctum: invalid --hours value %q", value)
+				}
+				hours = n
+			}
+			deleted, err := tokens.PruneExpired(context.Background(), expiration, hours)
+			if err != nil {
+				return err
+			}
+			return output.WriteLine(fmt.Sprintf("Tokens expired for more than %d hours pruned successfully (%d deleted).", hours, deleted), application.VerbosityNormal)
+		})
+}
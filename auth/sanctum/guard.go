@@ -0,0 +1,198 @@
+package sanctum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/auth/driver"
+)
+
+// Guard 个人访问令牌守卫
+//
+// 从 Authorization: Bearer 请求头读取令牌，令牌有效且未过期时按 tokenable_id 从用户提供者获取用户，
+// 并更新令牌的最后使用时间。当前令牌保存在请求状态中，可以通过 CurrentAccessToken 和 TokenCan 访问。
+type Guard struct {
+	name       string
+	tokens     *Tokens
+	provider   auth.UserProvider
+	expiration time.Duration
+}
+
+var _ auth.Guard = (*Guard)(nil)
+
+// NewGuard 创建令牌守卫，expiration 为令牌从创建起的全局有效期，为 0 时不限制
+func NewGuard(name string, tokens *Tokens, provider auth.UserProvider, expiration time.Duration) *Guard {
+	return &Guard{name: name, tokens: tokens, provider: provider, expiration: expiration}
+}
+
+// Extend 在认证管理器中注册 sanctum 守卫驱动
+//
+// 守卫配置的 "expiration" 为全局有效期，整数按分钟解释：
+//
+//	"api": {"driver": "sanctum", "provider": "users", "expiration": 60 * 24 * 30}
+func Extend(manager *driver.Manager, tokens *Tokens) {
+	manager.Extend("sanctum", func(name string, config map[string]interface{}, provider auth.UserProvider) (auth.Guard, error) {
+		var expiration time.Duration
+		switch value := config["expiration"].(type) {
+		case time.Duration:
+			expiration = value
+		case int:
+			expiration = time.Duration(value) * time.Minute
+		}
+		return NewGuard(name, tokens, provider, expiration), nil
+	})
+}
+
+// Check 当前请求是否已认证
+func (g *Guard) Check(ctx context.Context) bool {
+	user, err := g.User(ctx)
+	return err == nil && user != nil
+}
+
+// Guest 当前请求是否为访客
+func (g *Guard) Guest(ctx context.Context) bool {
+	return !g.Check(ctx)
+}
+
+// ID 获取当前用户的唯一标识
+func (g *Guard) ID(ctx context.Context) interface{} {
+	user, err := g.User(ctx)
+	if err != nil || user == nil {
+		return nil
+	}
+	return user.GetAuthIdentifier()
+}
+
+// HasUser 当前请求是否已经解析出用户
+func (g *Guard) HasUser(ctx context.Context) bool {
+	state := auth.StateFromContext(ctx)
+	return state != nil && state.Guard(g.name).User != nil
+}
+
+// SetUser 设置当前请求的用户
+func (g *Guard) SetUser(ctx context.Context, user auth.Authenticatable) {
+	if state := auth.StateFromContext(ctx); state != nil {
+		guard := state.Guard(g.name)
+		guard.User, guard.Resolved, guard.LoggedOut = user, true, false
+	}
+}
+
+// User 获取当前用户
+func (g *Guard) User(ctx context.Context) (auth.Authenticatable, error) {
+	state := auth.StateFromContext(ctx)
+	if state == nil {
+		return nil, auth.ErrNoState
+	}
+	guard := state.Guard(g.name)
+	if guard.Resolved {
+		return guard.User, nil
+	}
+	guard.Resolved = true
+	if state.Request == nil {
+		return nil, nil
+	}
+	plain := driver.BearerToken(state.Request)
+	if plain == "" {
+		return nil, nil
+	}
+
+	token, user, err := g.resolve(ctx, plain)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	if err := g.tokens.Touch(ctx, token); err != nil {
+		return nil, err
+	}
+	guard.User, guard.Token = user, token
+	return user, nil
+}
+
+// Validate 校验凭证中 "token" 字段的明文令牌是否有效
+func (g *Guard) Validate(ctx context.Context, credentials auth.Credentials) (bool, error) {
+	plain, _ := credentials["token"].(string)
+	if plain == "" {
+		return false, nil
+	}
+	_, user, err := g.resolve(ctx, plain)
+	return user != nil, err
+}
+
+// CurrentAccessToken 获取认证当前请求的令牌，未通过令牌认证时返回 nil
+func (g *Guard) CurrentAccessToken(ctx context.Context) *PersonalAccessToken {
+	if _, err := g.User(ctx); err != nil {
+		return nil
+	}
+	token, _ := auth.StateFromContext(ctx).Guard(g.name).Token.(*PersonalAccessToken)
+	return token
+}
+
+// TokenCan 当前令牌是否拥有权限
+func (g *Guard) TokenCan(ctx context.Context, ability string) bool {
+	token := g.CurrentAccessToken(ctx)
+	return token != nil && token.Can(ability)
+}
+
+// CheckAbilities 要求当前令牌拥有全部权限的中间件，需要放在 auth.Authenticate 之后
+func (g *Guard) CheckAbilities(abilities ...string) func(http.Handler) http.Handler {
+	return g.abilityMiddleware(func(ctx context.Context) bool {
+		for _, ability := range abilities {
+			if !g.TokenCan(ctx, ability) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// CheckForAnyAbility 要求当前令牌拥有任一权限的中间件，需要放在 auth.Authenticate 之后
+func (g *Guard) CheckForAnyAbility(abilities ...string) func(http.Handler) http.Handler {
+	return g.abilityMiddleware(func(ctx context.Context) bool {
+		for _, ability := range abilities {
+			if g.TokenCan(ctx, ability) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// abilityMiddleware 权限检查中间件，未认证返回 401，权限不足返回 403
+func (g *Guard) abilityMiddleware(allowed func(ctx context.Context) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status, message := http.StatusUnauthorized, "Unauthenticated."
+			if g.CurrentAccessToken(r.Context()) != nil {
+				if allowed(r.Context()) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				status, message = http.StatusForbidden, "Invalid ability provided."
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+		})
+	}
+}
+
+// resolve 查找有效令牌及其用户，令牌无效、过期或用户不存在时返回 nil
+func (g *Guard) resolve(ctx context.Context, plain string) (*PersonalAccessToken, auth.Authenticatable, error) {
+	token, err := g.tokens.Find(ctx, plain)
+	if err != nil || token == nil || token.Expired(g.tokens.now(), g.expiration) {
+		return nil, nil, err
+	}
+
+	var identifier interface{} = token.TokenableID
+	if n, err := strconv.ParseInt(token.TokenableID, 10, 64); err == nil {
+		identifier = n
+	}
+	user, err := g.provider.RetrieveByID(ctx, identifier)
+	if err != nil || user == nil || tokenableType(user) != token.TokenableType {
+		return nil, nil, err
+	}
+	return token, user, nil
+}
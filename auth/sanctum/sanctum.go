@@ -0,0 +1,27 @@
+// Package sanctum 提供 Laravel Sanctum 风格的个人访问令牌认证
+//
+// 令牌以 SHA-256 摘要保存在 personal_access_tokens 表中，明文令牌（"{id}|{token}"）只在创建时返回一次。
+// 每个令牌有名称、权限列表和可选的过期时间，守卫从 Authorization: Bearer 请求头认证请求，
+// 处理器通过 TokenCan 检查当前令牌的权限。
+//
+// 包结构：
+// - token.go - PersonalAccessToken 令牌模型（即表结构）、Abilities 和 NewAccessToken
+// - tokens.go - Tokens 令牌仓库：创建、查找、吊销和清理过期令牌
+// - guard.go - Guard 令牌守卫、权限检查中间件和认证管理器驱动注册
+// - command.go - sanctum:prune-expired 命令
+//
+// 使用示例：
+//
+//	tokens := sanctum.NewTokens(db)
+//	tokens.Migrate()
+//	sanctum.Extend(manager, tokens)
+//
+//	// 签发令牌
+//	issued, _ := tokens.Create(ctx, user, "deploy-bot", []string{"server:update"}, nil)
+//	fmt.Fprint(w, issued.PlainTextToken)
+//
+//	// 认证请求并检查权限
+//	guard, _ := manager.Guard("api")
+//	api := auth.Authenticate(manager, "api").Handler(
+//		guard.(*sanctum.Guard).CheckAbilities("server:update")(deployHandler))
+package sanctum
@@ -0,0 +1,97 @@
+package sanctum
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PersonalAccessToken 个人访问令牌模型，同时是 personal_access_tokens 表的迁移定义
+//
+// Token 列保存明文令牌的 SHA-256 十六进制摘要，明文只在创建时返回一次。
+type PersonalAccessToken struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	TokenableType string     `gorm:"size:255;index:personal_access_tokens_tokenable_index" json:"tokenable_type"`
+	TokenableID   string     `gorm:"size:255;index:personal_access_tokens_tokenable_index" json:"tokenable_id"`
+	Name          string     `gorm:"size:255" json:"name"`
+	Token         string     `gorm:"size:64;uniqueIndex" json:"-"`
+	Abilities     Abilities  `gorm:"type:text" json:"abilities"`
+	LastUsedAt    *time.Time `json:"last_used_at"`
+	ExpiresAt     *time.Time `gorm:"index" json:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName 表名
+func (PersonalAccessToken) TableName() string {
+	return "personal_access_tokens"
+}
+
+// Can 令牌是否拥有权限，"*" 表示拥有全部权限
+func (t *PersonalAccessToken) Can(ability string) bool {
+	return t.Abilities.Has("*") || t.Abilities.Has(ability)
+}
+
+// Cant 令牌是否缺少权限
+func (t *PersonalAccessToken) Cant(ability string) bool {
+	return !t.Can(ability)
+}
+
+// Expired 令牌在 now 时是否已过期
+//
+// expiration 为全局有效期（从创建时间算起），为 0 时只检查令牌自身的 ExpiresAt。
+func (t *PersonalAccessToken) Expired(now time.Time, expiration time.Duration) bool {
+	if expiration > 0 && !t.CreatedAt.Add(expiration).After(now) {
+		return true
+	}
+	return t.ExpiresAt != nil && !t.ExpiresAt.After(now)
+}
+
+// Abilities 令牌权限列表，以 JSON 数组存储
+type Abilities []string
+
+// Has 是否包含权限
+func (a Abilities) Has(ability string) bool {
+	for _, item := range a {
+		if item == ability {
+			return true
+		}
+	}
+	return false
+}
+
+// Value 实现 driver.Valuer
+func (a Abilities) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(a))
+	return string(data), err
+}
+
+// Scan 实现 sql.Scanner
+func (a *Abilities) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("sanctum: cannot scan %T into Abilities", value)
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// NewAccessToken 新创建的令牌
+type NewAccessToken struct {
+	// AccessToken 令牌记录
+	AccessToken *PersonalAccessToken `json:"accessToken"`
+
+	// PlainTextToken 明文令牌，格式为 "{id}|{token}"，只在创建时可见
+	PlainTextToken string `json:"plainTextToken"`
+}
@@ -0,0 +1,195 @@
+package sanctum
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// Tokenable 可以自定义令牌所属类型名的用户，未实现时使用结构体类型名
+type Tokenable interface {
+	auth.Authenticatable
+
+	// TokenableType 令牌所属的类型名，对应 Laravel 的 morph class
+	TokenableType() string
+}
+
+// Tokens 个人访问令牌仓库，对应 Laravel Sanctum 的 HasApiTokens
+type Tokens struct {
+	db     database.DB
+	prefix string
+	now    func() time.Time
+}
+
+// NewTokens 创建令牌仓库
+func NewTokens(db database.DB) *Tokens {
+	return &Tokens{db: db, now: time.Now}
+}
+
+// SetPrefix 设置明文令牌前缀，便于密钥扫描工具识别泄漏的令牌
+//
+// 设置前缀后明文令牌末尾会附加 CRC32 校验值，与 Laravel Sanctum 的 token_prefix 一致。
+func (t *Tokens) SetPrefix(prefix string) *Tokens {
+	t.prefix = prefix
+	return t
+}
+
+// Migrate 创建 personal_access_tokens 表
+func (t *Tokens) Migrate() error {
+	return t.db.AutoMigrate(&PersonalAccessToken{})
+}
+
+// Create 为用户创建令牌，abilities 为空时拥有全部权限，expiresAt 为 nil 时不单独过期
+func (t *Tokens) Create(ctx context.Context, user auth.Authenticatable, name string, abilities []string, expiresAt *time.Time) (*NewAccessToken, error) {
+	if len(abilities) == 0 {
+		abilities = []string{"*"}
+	}
+	plain, err := t.generate()
+	if err != nil {
+		return nil, err
+	}
+	token := &PersonalAccessToken{
+		TokenableType: tokenableType(user),
+		TokenableID:   fmt.Sprint(user.GetAuthIdentifier()),
+		Name:          name,
+		Token:         hashToken(plain),
+		Abilities:     abilities,
+		ExpiresAt:     expiresAt,
+	}
+	if err := t.db.WithContext(ctx).Create(token).Error(); err != nil {
+		return nil, err
+	}
+	return &NewAccessToken{AccessToken: token, PlainTextToken: fmt.Sprintf("%d|%s", token.ID, plain)}, nil
+}
+
+// Find 按明文令牌查找记录，令牌不存在时返回 nil
+//
+// "{id}|{token}" 格式按 ID 查找后以常数时间比较摘要，不带 ID 的令牌直接按摘要查找。
+func (t *Tokens) Find(ctx context.Context, plain string) (*PersonalAccessToken, error) {
+	id, secret, ok := strings.Cut(plain, "|")
+	if !ok {
+		return t.first(t.db.WithContext(ctx).Where("token = ?", hashToken(plain)))
+	}
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+	token, err := t.first(t.db.WithContext(ctx).Where("id = ?", uint(n)))
+	if err != nil || token == nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(token.Token), []byte(hashToken(secret))) != 1 {
+		return nil, nil
+	}
+	return token, nil
+}
+
+// For 获取用户的全部令牌
+func (t *Tokens) For(ctx context.Context, user auth.Authenticatable) ([]PersonalAccessToken, error) {
+	var tokens []PersonalAccessToken
+	err := t.owned(ctx, user).Order("id").Find(&tokens).Error()
+	return tokens, err
+}
+
+// Revoke 删除用户的指定令牌
+func (t *Tokens) Revoke(ctx context.Context, user auth.Authenticatable, id uint) error {
+	return t.owned(ctx, user).Where("id = ?", id).Delete(&PersonalAccessToken{}).Error()
+}
+
+// RevokeAll 删除用户的全部令牌
+func (t *Tokens) RevokeAll(ctx context.Context, user auth.Authenticatable) error {
+	return t.owned(ctx, user).Delete(&PersonalAccessToken{}).Error()
+}
+
+// Touch 更新令牌的最后使用时间
+func (t *Tokens) Touch(ctx context.Context, token *PersonalAccessToken) error {
+	now := t.now()
+	token.LastUsedAt = &now
+	return t.db.WithContext(ctx).Model(&PersonalAccessToken{}).Where("id = ?", token.ID).UpdateColumn("last_used_at", now).Error()
+}
+
+// PruneExpired 删除过期超过 hours 的令牌，返回删除数量
+//
+// expiration 为全局有效期，与守卫配置一致；为 0 时只按令牌自身的 expires_at 清理。
+func (t *Tokens) PruneExpired(ctx context.Context, expiration time.Duration, hours int) (int64, error) {
+	cutoff := t.now().Add(-time.Duration(hours) * time.Hour)
+	tx := t.db.WithContext(ctx).Where("expires_at IS NOT NULL AND expires_at < ?", cutoff).Delete(&PersonalAccessToken{})
+	if err := tx.Error(); err != nil {
+		return 0, err
+	}
+	deleted := tx.RowsAffected()
+	if expiration > 0 {
+		tx = t.db.WithContext(ctx).Where("created_at < ?", cutoff.Add(-expiration)).Delete(&PersonalAccessToken{})
+		if err := tx.Error(); err != nil {
+			return deleted, err
+		}
+		deleted += tx.RowsAffected()
+	}
+	return deleted, nil
+}
+
+// owned 限定为用户拥有的令牌
+func (t *Tokens) owned(ctx context.Context, user auth.Authenticatable) database.DB {
+	return t.db.WithContext(ctx).Model(&PersonalAccessToken{}).
+		Where("tokenable_type = ? AND tokenable_id = ?", tokenableType(user), fmt.Sprint(user.GetAuthIdentifier()))
+}
+
+// first 查询第一条令牌，不存在时返回 nil
+func (t *Tokens) first(tx database.DB) (*PersonalAccessToken, error) {
+	var tokens []PersonalAccessToken
+	if err := tx.Limit(1).Find(&tokens).Error(); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return &tokens[0], nil
+}
+
+// generate 生成 40 位随机明文令牌，设置前缀时附加 CRC32 校验值
+func (t *Tokens) generate() (string, error) {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, 40)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = alphabet[n.Int64()]
+	}
+	token := t.prefix + string(b)
+	if t.prefix != "" {
+		token += fmt.Sprintf("%08x", crc32.ChecksumIEEE(b))
+	}
+	return token, nil
+}
+
+// hashToken 计算明文令牌的 SHA-256 摘要
+func hashToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenableType 令牌所属的类型名
+func tokenableType(user auth.Authenticatable) string {
+	if tokenable, ok := user.(Tokenable); ok {
+		return tokenable.TokenableType()
+	}
+	typ := reflect.TypeOf(user)
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.String()
+}
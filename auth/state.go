@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// State 请求级认证状态
+//
+// 保存当前请求、响应、会话以及每个守卫解析出的用户，由 Authenticate 中间件创建，
+// 也可以在自定义的 HTTP 处理流程中通过 NewState 和 WithState 手动绑定。
+type State struct {
+	// Request 当前请求
+	Request *http.Request
+
+	// Writer 当前响应，守卫通过它写入"记住我" Cookie
+	Writer http.ResponseWriter
+
+	// Session 当前会话，无状态请求（如 API）可以为 nil
+	Session Session
+
+	mu     sync.Mutex
+	guards map[string]*GuardState
+}
+
+// GuardState 单个守卫在当前请求中的状态
+type GuardState struct {
+	// User 已解析的用户
+	User Authenticatable
+
+	// Resolved 是否已经尝试过解析用户
+	Resolved bool
+
+	// ViaRemember 用户是否通过"记住我" Cookie 认证
+	ViaRemember bool
+
+	// LoggedOut 用户是否已在本次请求中注销
+	LoggedOut bool
+
+	// Token 认证当前请求所用的令牌，例如个人访问令牌或 JWT，由守卫决定具体类型
+	Token interface{}
+}
+
+// NewState 创建请求级认证状态
+func NewState(w http.ResponseWriter, r *http.Request, session Session) *State {
+	return &State{Request: r, Writer: w, Session: session, guards: make(map[string]*GuardState)}
+}
+
+// Guard 获取守卫的状态，不存在时创建
+func (s *State) Guard(name string) *GuardState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.guards[name]
+	if !ok {
+		state = &GuardState{}
+		s.guards[name] = state
+	}
+	return state
+}
+
+// stateKey context 中 State 的键
+type stateKey struct{}
+
+// userKey context 中当前用户的键
+type userKey struct{}
+
+// WithState 把请求级认证状态绑定到 context
+func WithState(ctx context.Context, state *State) context.Context {
+	return context.WithValue(ctx, stateKey{}, state)
+}
+
+// StateFromContext 获取 context 中的请求级认证状态，不存在时返回 nil
+func StateFromContext(ctx context.Context) *State {
+	state, _ := ctx.Value(stateKey{}).(*State)
+	return state
+}
+
+// WithUser 把已认证用户绑定到 context
+func WithUser(ctx context.Context, user Authenticatable) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext 获取 Authenticate 中间件认证的用户，不存在时返回 nil
+func UserFromContext(ctx context.Context) Authenticatable {
+	user, _ := ctx.Value(userKey{}).(Authenticatable)
+	return user
+}
@@ -0,0 +1,134 @@
+package auth
+
+// Authenticatable 可认证用户接口，对应 Laravel 的 Authenticatable 契约
+//
+// Eloquent 用户模型实现该接口后即可交给 eloquent 用户提供者使用：
+//
+//	type User struct {
+//		database.Model
+//		Email         string
+//		Password      string
+//		RememberToken string
+//	}
+//
+//	func (u *User) GetAuthIdentifierName() string   { return "id" }
+//	func (u *User) GetAuthIdentifier() interface{}  { return u.ID }
+//	func (u *User) GetAuthPassword() string         { return u.Password }
+//	func (u *User) GetRememberToken() string        { return u.RememberToken }
+//	func (u *User) SetRememberToken(token string)   { u.RememberToken = token }
+//	func (u *User) GetRememberTokenName() string    { return "remember_token" }
+type Authenticatable interface {
+	// GetAuthIdentifierName 获取唯一标识的列名
+	GetAuthIdentifierName() string
+
+	// GetAuthIdentifier 获取唯一标识
+	GetAuthIdentifier() interface{}
+
+	// GetAuthPassword 获取密码哈希
+	GetAuthPassword() string
+
+	// GetRememberToken 获取"记住我"令牌
+	GetRememberToken() string
+
+	// SetRememberToken 设置"记住我"令牌
+	SetRememberToken(token string)
+
+	// GetRememberTokenName 获取"记住我"令牌的列名，返回空字符串表示不支持
+	GetRememberTokenName() string
+}
+
+// PasswordUpdatable 可以更新密码哈希的用户，登录时重新哈希密码需要用户实现该接口
+type PasswordUpdatable interface {
+	// GetAuthPasswordName 获取密码列名
+	GetAuthPasswordName() string
+
+	// SetAuthPassword 设置密码哈希
+	SetAuthPassword(hashed string)
+}
+
+// MustVerifyEmail 需要验证邮箱的用户，对应 Laravel 的 MustVerifyEmail 契约
+type MustVerifyEmail interface {
+	// HasVerifiedEmail 邮箱是否已验证
+	HasVerifiedEmail() bool
+
+	// MarkEmailAsVerified 标记邮箱已验证，只修改内存中的用户，由调用方保存
+	MarkEmailAsVerified()
+
+	// GetEmailForVerification 获取需要验证的邮箱
+	GetEmailForVerification() string
+}
+
+// GenericUser 基于属性映射的通用用户，database 用户提供者返回该类型
+type GenericUser struct {
+	// Attributes 用户属性，即数据表的一行
+	Attributes map[string]interface{}
+}
+
+var (
+	_ Authenticatable   = (*GenericUser)(nil)
+	_ PasswordUpdatable = (*GenericUser)(nil)
+)
+
+// NewGenericUser 创建通用用户
+func NewGenericUser(attributes map[string]interface{}) *GenericUser {
+	if attributes == nil {
+		attributes = make(map[string]interface{})
+	}
+	return &GenericUser{Attributes: attributes}
+}
+
+// Get 获取属性
+func (u *GenericUser) Get(key string) interface{} {
+	return u.Attributes[key]
+}
+
+// GetAuthIdentifierName 获取唯一标识的列名
+func (u *GenericUser) GetAuthIdentifierName() string {
+	return "id"
+}
+
+// GetAuthIdentifier 获取唯一标识
+func (u *GenericUser) GetAuthIdentifier() interface{} {
+	return u.Attributes[u.GetAuthIdentifierName()]
+}
+
+// GetAuthPassword 获取密码哈希
+func (u *GenericUser) GetAuthPassword() string {
+	return stringValue(u.Attributes["password"])
+}
+
+// GetAuthPasswordName 获取密码列名
+func (u *GenericUser) GetAuthPasswordName() string {
+	return "password"
+}
+
+// SetAuthPassword 设置密码哈希
+func (u *GenericUser) SetAuthPassword(hashed string) {
+	u.Attributes[u.GetAuthPasswordName()] = hashed
+}
+
+// GetRememberToken 获取"记住我"令牌
+func (u *GenericUser) GetRememberToken() string {
+	return stringValue(u.Attributes[u.GetRememberTokenName()])
+}
+
+// SetRememberToken 设置"记住我"令牌
+func (u *GenericUser) SetRememberToken(token string) {
+	u.Attributes[u.GetRememberTokenName()] = token
+}
+
+// GetRememberTokenName 获取"记住我"令牌的列名
+func (u *GenericUser) GetRememberTokenName() string {
+	return "remember_token"
+}
+
+// stringValue 把数据库返回的字符串或字节切片转换为字符串
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
@@ -0,0 +1,221 @@
+// Package verification 提供 Laravel 风格的邮箱验证
+//
+// Verifier 为实现 auth.MustVerifyEmail 的用户生成带 id 和 hash（邮箱的 SHA-256 摘要）参数的
+// 临时签名 URL 并通过邮件发送，Handler 校验签名和当前登录用户后标记邮箱已验证，
+// 保存后分发 auth.verified 事件。验证前的路由通过 auth.EnsureEmailIsVerified 中间件保护。
+//
+// 包结构：
+// - verification.go - Verifier、Verified 事件、VerifyEmailMail 邮件和验证处理器
+//
+// 使用示例：
+//
+//	verifier := verification.NewVerifier(routing.NewURLSigner(appKey), "https://example.com/email/verify").
+//		SetSaver(verification.DatabaseSaver(db, "users", "email_verified_at"))
+//
+//	_ = verifier.SendVerificationEmail(ctx, mailer, user)
+//
+//	mux.Handle("/email/verify", authenticate.Handler(verifier.Handler("/home")))
+//	mux.Handle("/dashboard", authenticate.Handler(auth.EnsureEmailIsVerified("/email/verify-notice")(dashboard)))
+package verification
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/mail"
+	"github.com/cnote0/laraveldoc/routing"
+)
+
+// EventVerified 邮箱已验证，事件为 *Verified
+const EventVerified = "auth.verified"
+
+// ErrInvalidLink 验证链接与当前用户不匹配
+var ErrInvalidLink = errors.New("verification: link does not match the authenticated user")
+
+// Verified 邮箱已验证事件
+type Verified struct {
+	User auth.MustVerifyEmail
+}
+
+// Saver 保存已标记验证的用户
+type Saver func(ctx context.Context, user auth.MustVerifyEmail) error
+
+// DatabaseSaver 把 column 更新为当前时间的 Saver，用户需要实现 auth.Authenticatable
+func DatabaseSaver(db database.DB, table, column string) Saver {
+	return func(ctx context.Context, user auth.MustVerifyEmail) error {
+		authenticatable, ok := user.(auth.Authenticatable)
+		if !ok {
+			return fmt.Errorf("verification: %T does not implement auth.Authenticatable", user)
+		}
+		return db.WithContext(ctx).Table(table).
+			Where(authenticatable.GetAuthIdentifierName()+" = ?", authenticatable.GetAuthIdentifier()).
+			UpdateColumn(column, time.Now()).Error()
+	}
+}
+
+// Verifier 邮箱验证
+type Verifier struct {
+	signer  *routing.URLSigner
+	url     string
+	expires time.Duration
+	saver   Saver
+	events  application.EventDispatcher
+}
+
+// NewVerifier 创建验证器，verifyURL 为验证路由的完整地址，链接默认 60 分钟过期
+func NewVerifier(signer *routing.URLSigner, verifyURL string) *Verifier {
+	return &Verifier{signer: signer, url: verifyURL, expires: 60 * time.Minute}
+}
+
+// SetExpires 设置链接有效期
+func (v *Verifier) SetExpires(expires time.Duration) *Verifier {
+	v.expires = expires
+	return v
+}
+
+// SetSaver 设置保存验证状态的方式，未设置时只修改内存中的用户
+func (v *Verifier) SetSaver(saver Saver) *Verifier {
+	v.saver = saver
+	return v
+}
+
+// SetEvents 设置事件分发器
+func (v *Verifier) SetEvents(events application.EventDispatcher) *Verifier {
+	v.events = events
+	return v
+}
+
+// VerificationURL 为用户生成临时签名的验证链接
+func (v *Verifier) VerificationURL(user auth.Authenticatable) (string, error) {
+	verifiable, ok := user.(auth.MustVerifyEmail)
+	if !ok {
+		return "", fmt.Errorf("verification: %T does not implement auth.MustVerifyEmail", user)
+	}
+	u, err := url.Parse(v.url)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("id", fmt.Sprint(user.GetAuthIdentifier()))
+	query.Set("hash", emailHash(verifiable.GetEmailForVerification()))
+	u.RawQuery = query.Encode()
+	return v.signer.TemporarySign(u.String(), v.expires)
+}
+
+// SendVerificationEmail 发送验证邮件，已验证的用户不发送
+func (v *Verifier) SendVerificationEmail(ctx context.Context, mailer mail.Mailer, user auth.Authenticatable) error {
+	verifiable, ok := user.(auth.MustVerifyEmail)
+	if ok && verifiable.HasVerifiedEmail() {
+		return nil
+	}
+	link, err := v.VerificationURL(user)
+	if err != nil {
+		return err
+	}
+	return mailer.To(mail.NewAddress(verifiable.GetEmailForVerification(), "")).
+		SendNow(ctx, &VerifyEmailMail{URL: link})
+}
+
+// Verify 校验请求中的签名，以及 id、hash 与用户一致后标记邮箱已验证
+func (v *Verifier) Verify(ctx context.Context, u *url.URL, user auth.Authenticatable) error {
+	if err := v.signer.Validate(u); err != nil {
+		return err
+	}
+	verifiable, ok := user.(auth.MustVerifyEmail)
+	if !ok {
+		return fmt.Errorf("verification: %T does not implement auth.MustVerifyEmail", user)
+	}
+	query := u.Query()
+	if query.Get("id") != fmt.Sprint(user.GetAuthIdentifier()) ||
+		subtle.ConstantTimeCompare([]byte(query.Get("hash")), []byte(emailHash(verifiable.GetEmailForVerification()))) != 1 {
+		return ErrInvalidLink
+	}
+	if verifiable.HasVerifiedEmail() {
+		return nil
+	}
+	verifiable.MarkEmailAsVerified()
+	if v.saver != nil {
+		if err := v.saver(ctx, verifiable); err != nil {
+			return err
+		}
+	}
+	if v.events != nil {
+		v.events.DispatchWithContext(ctx, &Verified{User: verifiable}, EventVerified)
+	}
+	return nil
+}
+
+// Handler 验证路由处理器，需要放在 auth.Authenticate 之后
+//
+// 验证成功后跳转到 redirectTo（JSON 请求返回 204），签名无效、过期或与当前用户不匹配时返回 403。
+func (v *Verifier) Handler(redirectTo string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := auth.UserFromContext(r.Context())
+		if user == nil {
+			writeJSON(w, http.StatusUnauthorized, "Unauthenticated.")
+			return
+		}
+		err := v.Verify(r.Context(), r.URL, user)
+		switch {
+		case errors.Is(err, routing.ErrInvalidSignature), errors.Is(err, routing.ErrSignatureExpired), errors.Is(err, ErrInvalidLink):
+			writeJSON(w, http.StatusForbidden, "Invalid verification link.")
+		case err != nil:
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		case redirectTo == "" || auth.ExpectsJSON(r):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Redirect(w, r, redirectTo+"?verified=1", http.StatusFound)
+		}
+	})
+}
+
+// VerifyEmailMail 邮箱验证邮件
+type VerifyEmailMail struct {
+	URL string
+}
+
+// Envelope 实现 mail.Mailable
+func (m *VerifyEmailMail) Envelope() mail.Envelope {
+	return mail.Envelope{Subject: "Verify Email Address"}
+}
+
+// Content 实现 mail.Mailable
+func (m *VerifyEmailMail) Content() mail.Content {
+	text := fmt.Sprintf("Please click the button below to verify your email address.\n\n"+
+		"[Verify Email Address](%s)\n\n"+
+		"If you did not create an account, no further action is required.\n", m.URL)
+	html, err := mail.RenderMarkdown(text, "Verify Email Address")
+	if err != nil {
+		return mail.Content{TextString: text}
+	}
+	return mail.Content{HTMLString: html, TextString: text}
+}
+
+// Attachments 实现 mail.Mailable
+func (m *VerifyEmailMail) Attachments() []mail.Attachment {
+	return nil
+}
+
+// emailHash 计算邮箱的摘要，链接中不直接暴露邮箱
+func emailHash(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeJSON 写入 {"message": ...} 响应
+func writeJSON(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
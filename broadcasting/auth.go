@@ -0,0 +1,46 @@
+package broadcasting
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// AuthHandler /broadcasting/auth 授权接口
+//
+// 客户端以表单提交 socket_id 和 channel_name，user 从请求中获取当前用户（例如读取会话），
+// 未登录时返回 nil。对应 Laravel 的 Broadcast::routes()。
+func AuthHandler(broadcaster Broadcaster, user func(r *http.Request) interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		request := AuthRequest{
+			SocketID:    r.FormValue("socket_id"),
+			ChannelName: r.FormValue("channel_name"),
+			User:        user(r),
+		}
+		if request.ChannelName == "" {
+			http.Error(w, "channel_name is required", http.StatusBadRequest)
+			return
+		}
+		if IsPrivateChannel(request.ChannelName) && request.User == nil {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		response, err := broadcaster.Auth(r.Context(), request)
+		if errors.Is(err, ErrAccessDenied) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
@@ -0,0 +1,90 @@
+package broadcasting
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// 内置驱动名称
+const (
+	// DriverRedis 通过 Redis PUBLISH 广播，由 Echo Server 等服务转发给客户端
+	DriverRedis = "redis"
+
+	// DriverPusher 通过 Pusher HTTP API 广播，兼容 Pusher 协议的服务（如 Soketi）也可使用
+	DriverPusher = "pusher"
+
+	// DriverWebSocket 内置 WebSocket 服务，直接向连接到本进程的客户端广播
+	DriverWebSocket = "websocket"
+
+	// DriverLog 将广播写入日志
+	DriverLog = "log"
+
+	// DriverNull 丢弃广播
+	DriverNull = "null"
+)
+
+// ErrNoManager 没有设置默认广播管理器
+var ErrNoManager = errors.New("broadcasting: no default manager, call broadcasting.SetDefaultManager")
+
+// AuthRequest 频道授权请求
+type AuthRequest struct {
+	// SocketID 客户端连接 ID
+	SocketID string
+
+	// ChannelName 完整频道名称（带 private-、presence- 前缀）
+	ChannelName string
+
+	// User 当前用户，未登录时为 nil
+	User interface{}
+}
+
+// Broadcaster 广播器接口
+type Broadcaster interface {
+	// Broadcast 向频道广播事件，payload 中的 "socket" 字段指定需要排除的连接
+	Broadcast(ctx context.Context, channels []string, event string, payload map[string]interface{}) error
+
+	// Auth 授权私有和在线频道的订阅，返回写给客户端的 JSON 响应
+	//
+	// 用户无权访问时返回 ErrAccessDenied。
+	Auth(ctx context.Context, request AuthRequest) (interface{}, error)
+}
+
+// Manager 广播管理器接口
+//
+// 连接配置与 Laravel 的 config/broadcasting.php 对应，每个连接的 "driver" 字段决定使用的驱动。
+type Manager interface {
+	// Connection 获取广播器，不传名称时使用默认连接
+	Connection(name ...string) (Broadcaster, error)
+
+	// GetDefaultConnection 获取默认连接名称
+	GetDefaultConnection() string
+
+	// SetDefaultConnection 设置默认连接名称
+	SetDefaultConnection(name string)
+
+	// Extend 注册驱动，channels 为管理器共享的频道授权注册表
+	Extend(driver string, factory func(config map[string]interface{}, channels *Channels) (Broadcaster, error))
+
+	// Channel 注册频道授权规则
+	Channel(pattern string, handler interface{}) Manager
+
+	// Event 广播事件，未实现 ShouldBroadcastNow 的事件投递到队列
+	Event(ctx context.Context, event ShouldBroadcast) error
+}
+
+// defaultManager 默认广播管理器
+var defaultManager atomic.Value
+
+// SetDefaultManager 设置默认广播管理器，排队的广播由 Worker 通过它获取广播器
+func SetDefaultManager(manager Manager) {
+	defaultManager.Store(&manager)
+}
+
+// DefaultManager 获取默认广播管理器，未设置时返回 nil
+func DefaultManager() Manager {
+	if manager, ok := defaultManager.Load().(*Manager); ok {
+		return *manager
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Package broadcasting 提供 Laravel 风格的事件广播协议定义
+//
+// 本包定义广播事件、频道、频道授权和 Broadcaster 广播器的接口，
+// 设计参考 Laravel 的 Illuminate\Broadcasting。广播器和管理器的实现位于子包 driver。
+//
+// 主要特性：
+// - ShouldBroadcast 事件契约，默认通过队列广播，ShouldBroadcastNow 立即广播
+// - 公共、私有（private-）和在线（presence-）频道
+// - 频道授权回调，支持 "orders.{id}" 参数和通过容器解析的授权器
+// - 通过 context 传递 Socket ID，实现 toOthers 排除当前连接
+// - /broadcasting/auth 授权接口
+// - redis、pusher、websocket（内置 WebSocket 服务）、log、null 驱动
+//
+// 包结构：
+// - channel.go - Channel 频道
+// - event.go - ShouldBroadcast 广播事件接口和事件名称、载荷解析
+// - broadcaster.go - Broadcaster 广播器和 Manager 管理器接口
+// - channels.go - Channels 频道授权注册表和 ChannelAuthorizer 授权器接口
+// - auth.go - AuthHandler 授权 HTTP 处理器
+// - queued.go - BroadcastEvent 队列广播任务
+//
+// 使用示例：
+//
+//	type OrderShipped struct {
+//		queue.Queued
+//		OrderID uint   `json:"order_id"`
+//		Status  string `json:"status"`
+//	}
+//
+//	func (e *OrderShipped) BroadcastOn() []broadcasting.Channel {
+//		return []broadcasting.Channel{broadcasting.PrivateChannel(fmt.Sprintf("orders.%d", e.OrderID))}
+//	}
+//
+//	manager.Channel("orders.{orderId}", broadcasting.AuthorizerFunc(
+//		func(ctx context.Context, user interface{}, params map[string]string) (interface{}, error) {
+//			return user.(*User).OwnsOrder(params["orderId"]), nil
+//		}))
+//
+//	err := manager.Event(broadcasting.ToOthers(ctx, r.Header.Get("X-Socket-ID")), &OrderShipped{OrderID: 1, Status: "shipped"})
+package broadcasting
@@ -0,0 +1,73 @@
+package broadcasting
+
+import "strings"
+
+// 频道名称前缀
+const (
+	PrivatePrefix  = "private-"
+	PresencePrefix = "presence-"
+)
+
+// Channel 广播频道
+//
+// 私有和在线频道的名称带有前缀，客户端订阅时需要先通过授权。
+type Channel struct {
+	Name string
+}
+
+// PublicChannel 公共频道，任何客户端都可以订阅
+func PublicChannel(name string) Channel {
+	return Channel{Name: name}
+}
+
+// PrivateChannel 私有频道，订阅前需要授权
+func PrivateChannel(name string) Channel {
+	return Channel{Name: PrivatePrefix + name}
+}
+
+// PresenceChannel 在线频道，授权时返回成员信息，订阅者可以看到频道内的其他成员
+func PresenceChannel(name string) Channel {
+	return Channel{Name: PresencePrefix + name}
+}
+
+// String 频道名称
+func (c Channel) String() string {
+	return c.Name
+}
+
+// IsPrivate 是否为私有频道（包括在线频道）
+func (c Channel) IsPrivate() bool {
+	return IsPrivateChannel(c.Name)
+}
+
+// IsPresence 是否为在线频道
+func (c Channel) IsPresence() bool {
+	return IsPresenceChannel(c.Name)
+}
+
+// IsPrivateChannel 频道名称是否需要授权（private- 或 presence- 前缀）
+func IsPrivateChannel(name string) bool {
+	return strings.HasPrefix(name, PrivatePrefix) || strings.HasPrefix(name, PresencePrefix)
+}
+
+// IsPresenceChannel 频道名称是否为在线频道
+func IsPresenceChannel(name string) bool {
+	return strings.HasPrefix(name, PresencePrefix)
+}
+
+// NormalizeChannelName 去掉 private- 和 presence- 前缀，用于匹配授权规则
+func NormalizeChannelName(name string) string {
+	if strings.HasPrefix(name, PresencePrefix) {
+		return strings.TrimPrefix(name, PresencePrefix)
+	}
+	return strings.TrimPrefix(name, PrivatePrefix)
+}
+
+// ChannelNames 频道名称列表
+func ChannelNames(channels []Channel) []string {
+	names := make([]string, len(channels))
+	for i, channel := range channels {
+		names[i] = channel.Name
+	}
+	return names
+}
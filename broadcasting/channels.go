@@ -0,0 +1,169 @@
+package broadcasting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// ErrAccessDenied 用户无权订阅频道
+var ErrAccessDenied = errors.New("broadcasting: access denied")
+
+// ChannelAuthorizer 频道授权器，对应 Laravel 的频道类
+type ChannelAuthorizer interface {
+	// Join 判断用户能否加入频道
+	//
+	// 私有频道返回 true 表示允许；在线频道返回成员信息（如 map 或结构体），
+	// 返回 nil 或 false 表示拒绝。params 为频道名称中的参数。
+	Join(ctx context.Context, user interface{}, params map[string]string) (interface{}, error)
+}
+
+// AuthorizerFunc 函数形式的频道授权器
+type AuthorizerFunc func(ctx context.Context, user interface{}, params map[string]string) (interface{}, error)
+
+// Join 调用函数
+func (f AuthorizerFunc) Join(ctx context.Context, user interface{}, params map[string]string) (interface{}, error) {
+	return f(ctx, user, params)
+}
+
+// UserIdentifier 提供用户 ID 的用户，在线频道的成员 ID 取自此接口
+type UserIdentifier interface {
+	GetAuthIdentifier() interface{}
+}
+
+// PresenceMember 在线频道成员
+type PresenceMember struct {
+	UserID   interface{} `json:"user_id"`
+	UserInfo interface{} `json:"user_info,omitempty"`
+}
+
+// Channels 频道授权注册表
+//
+// 对应 Laravel 的 routes/channels.php。规则按注册顺序匹配，第一个匹配的规则生效。
+type Channels struct {
+	container container.Container
+
+	mu    sync.RWMutex
+	rules []channelRule
+}
+
+type channelRule struct {
+	pattern string
+	regexp  *regexp.Regexp
+	params  []string
+	handler interface{}
+}
+
+var channelParam = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// NewChannels 创建频道授权注册表，c 用于解析以服务标识符注册的授权器，可以为 nil
+func NewChannels(c container.Container) *Channels {
+	return &Channels{container: c}
+}
+
+// Channel 注册频道授权规则
+//
+// pattern 不带 private-、presence- 前缀，"{name}" 匹配一段不含 "." 的参数，"*" 匹配任意字符。
+// handler 可以是 ChannelAuthorizer、AuthorizerFunc 签名的函数，或者容器中的服务标识符。
+func (c *Channels) Channel(pattern string, handler interface{}) *Channels {
+	var params []string
+	expression := "^"
+	last := 0
+	for _, match := range channelParam.FindAllStringSubmatchIndex(pattern, -1) {
+		expression += regexp.QuoteMeta(pattern[last:match[0]]) + `([^.]+)`
+		params = append(params, pattern[match[2]:match[3]])
+		last = match[1]
+	}
+	expression += regexp.QuoteMeta(pattern[last:]) + "$"
+	expression = strings.ReplaceAll(expression, `\*`, `.*`)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rules = append(c.rules, channelRule{
+		pattern: pattern,
+		regexp:  regexp.MustCompile(expression),
+		params:  params,
+		handler: handler,
+	})
+	return c
+}
+
+// Authorize 校验用户能否订阅频道，返回授权器的结果
+//
+// 公共频道直接通过。没有匹配的规则、授权器返回 nil 或 false 时返回 ErrAccessDenied。
+func (c *Channels) Authorize(ctx context.Context, user interface{}, channelName string) (interface{}, error) {
+	if !IsPrivateChannel(channelName) {
+		return true, nil
+	}
+	name := NormalizeChannelName(channelName)
+
+	c.mu.RLock()
+	var matched *channelRule
+	var values []string
+	for i := range c.rules {
+		if m := c.rules[i].regexp.FindStringSubmatch(name); m != nil {
+			matched, values = &c.rules[i], m[1:]
+			break
+		}
+	}
+	c.mu.RUnlock()
+	if matched == nil {
+		return nil, fmt.Errorf("%w: no authorization rule for [%s]", ErrAccessDenied, channelName)
+	}
+
+	params := make(map[string]string, len(matched.params))
+	for i, param := range matched.params {
+		params[param] = values[i]
+	}
+	authorizer, err := c.resolve(matched.handler)
+	if err != nil {
+		return nil, err
+	}
+	result, err := authorizer.Join(ctx, user, params)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || result == false {
+		return nil, fmt.Errorf("%w: [%s]", ErrAccessDenied, channelName)
+	}
+	return result, nil
+}
+
+// Member 在线频道的成员信息
+func (c *Channels) Member(user interface{}, result interface{}) PresenceMember {
+	member := PresenceMember{UserInfo: result}
+	if identifier, ok := user.(UserIdentifier); ok {
+		member.UserID = identifier.GetAuthIdentifier()
+	}
+	if result == true {
+		member.UserInfo = nil
+	}
+	return member
+}
+
+// resolve 解析授权器
+func (c *Channels) resolve(handler interface{}) (ChannelAuthorizer, error) {
+	switch h := handler.(type) {
+	case ChannelAuthorizer:
+		return h, nil
+	case func(ctx context.Context, user interface{}, params map[string]string) (interface{}, error):
+		return AuthorizerFunc(h), nil
+	}
+	if c.container == nil {
+		return nil, fmt.Errorf("broadcasting: cannot resolve channel authorizer %v without a container", handler)
+	}
+	resolved, err := c.container.Make(handler)
+	if err != nil {
+		return nil, fmt.Errorf("broadcasting: resolve channel authorizer: %w", err)
+	}
+	authorizer, ok := resolved.(ChannelAuthorizer)
+	if !ok {
+		return nil, fmt.Errorf("broadcasting: resolved %T does not implement ChannelAuthorizer", resolved)
+	}
+	return authorizer, nil
+}
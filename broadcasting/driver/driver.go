@@ -0,0 +1,105 @@
+// Package driver 提供 broadcasting 包协议的参考实现
+//
+// 包含 redis、pusher、websocket、log、null 五种广播器和 Manager 实现。
+// redis 驱动只依赖本包定义的 RedisPublisher 接口，由使用方为 go-redis 等客户端编写适配器；
+// pusher 驱动通过标准库调用 Pusher HTTP API；websocket 驱动基于 routing 包的 WebSocket 支持，
+// 在本进程内提供兼容 Pusher 协议的 WebSocket 服务，可以直接使用 pusher-js / Laravel Echo 连接。
+//
+// 包结构：
+// - driver.go - 配置读取辅助函数和授权响应
+// - redis.go - RedisBroadcaster 和 RedisPublisher 接口
+// - pusher.go - PusherBroadcaster
+// - websocket.go - WebSocketBroadcaster 内置 WebSocket 服务
+// - log.go - LogBroadcaster、NullBroadcaster
+// - manager.go - Manager 实现
+//
+// 配置示例：
+//
+//	manager := driver.NewManager(map[string]map[string]interface{}{
+//		"redis":     {"driver": "redis", "client": redisAdapter, "prefix": "app_database_"},
+//		"pusher":    {"driver": "pusher", "app_id": id, "key": key, "secret": secret, "cluster": "mt1"},
+//		"websocket": {"driver": "websocket", "user": currentUser},
+//	}, "websocket")
+//	manager.Channel("orders.{orderId}", authorizeOrder)
+//	broadcasting.SetDefaultManager(manager)
+//
+//	ws, _ := manager.Connection("websocket")
+//	router.WebSocket("/app/{key}", ws.(*driver.WebSocketBroadcaster).Serve)
+//	http.Handle("/broadcasting/auth", broadcasting.AuthHandler(ws, currentUser))
+package driver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/broadcasting"
+)
+
+// stringOption 读取字符串配置
+func stringOption(config map[string]interface{}, key, fallback string) string {
+	switch value := config[key].(type) {
+	case string:
+		if value != "" {
+			return value
+		}
+	case int:
+		return fmt.Sprint(value)
+	}
+	return fallback
+}
+
+// durationOption 读取时长配置，整数按秒解释（与 Laravel 配置一致）
+func durationOption(config map[string]interface{}, key string, fallback time.Duration) time.Duration {
+	switch value := config[key].(type) {
+	case time.Duration:
+		return value
+	case int:
+		return time.Duration(value) * time.Second
+	case int64:
+		return time.Duration(value) * time.Second
+	case float64:
+		return time.Duration(value * float64(time.Second))
+	case string:
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+// clientOption 读取客户端对象配置
+func clientOption[T any](config map[string]interface{}, key string) (T, error) {
+	client, ok := config[key].(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("broadcasting: connection config %q must be %T, got %T", key, zero, config[key])
+	}
+	return client, nil
+}
+
+// authorize 校验订阅权限并生成 Laravel 格式的授权响应
+//
+// 私有频道返回 true，在线频道返回 {"channel_data": {"user_id": ..., "user_info": ...}}。
+func authorize(ctx context.Context, channels *broadcasting.Channels, request broadcasting.AuthRequest) (interface{}, error) {
+	result, err := channels.Authorize(ctx, request.User, request.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	if broadcasting.IsPresenceChannel(request.ChannelName) {
+		return map[string]interface{}{"channel_data": channels.Member(request.User, result)}, nil
+	}
+	return true, nil
+}
+
+// splitSocket 从载荷中取出 socket 字段，返回不含该字段的副本
+func splitSocket(payload map[string]interface{}) (map[string]interface{}, string) {
+	socket, _ := payload["socket"].(string)
+	data := make(map[string]interface{}, len(payload))
+	for key, value := range payload {
+		if key != "socket" {
+			data[key] = value
+		}
+	}
+	return data, socket
+}
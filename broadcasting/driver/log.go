@@ -0,0 +1,75 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/cnote0/laraveldoc/broadcasting"
+)
+
+// Logger 日志接口，application.LoggerInterface 满足此接口
+type Logger interface {
+	Info(message string, context map[string]interface{}) error
+}
+
+// stdLogger 使用标准库 log 输出
+type stdLogger struct{}
+
+func (stdLogger) Info(message string, context map[string]interface{}) error {
+	data, _ := json.Marshal(context)
+	log.Printf("%s %s", message, data)
+	return nil
+}
+
+// LogBroadcaster 将广播写入日志
+type LogBroadcaster struct {
+	logger   Logger
+	channels *broadcasting.Channels
+}
+
+var _ broadcasting.Broadcaster = (*LogBroadcaster)(nil)
+
+// NewLogBroadcaster 创建日志广播器，配置项 logger 未设置时使用标准库 log
+func NewLogBroadcaster(config map[string]interface{}, channels *broadcasting.Channels) *LogBroadcaster {
+	logger, ok := config["logger"].(Logger)
+	if !ok {
+		logger = stdLogger{}
+	}
+	return &LogBroadcaster{logger: logger, channels: channels}
+}
+
+// Broadcast 写入日志
+func (b *LogBroadcaster) Broadcast(ctx context.Context, channels []string, event string, payload map[string]interface{}) error {
+	return b.logger.Info("broadcasting ["+event+"]", map[string]interface{}{
+		"channels": channels,
+		"payload":  payload,
+	})
+}
+
+// Auth 授权频道订阅
+func (b *LogBroadcaster) Auth(ctx context.Context, request broadcasting.AuthRequest) (interface{}, error) {
+	return authorize(ctx, b.channels, request)
+}
+
+// NullBroadcaster 丢弃所有广播
+type NullBroadcaster struct {
+	channels *broadcasting.Channels
+}
+
+var _ broadcasting.Broadcaster = (*NullBroadcaster)(nil)
+
+// NewNullBroadcaster 创建空广播器
+func NewNullBroadcaster(channels *broadcasting.Channels) *NullBroadcaster {
+	return &NullBroadcaster{channels: channels}
+}
+
+// Broadcast 不做任何处理
+func (b *NullBroadcaster) Broadcast(ctx context.Context, channels []string, event string, payload map[string]interface{}) error {
+	return nil
+}
+
+// Auth 授权频道订阅
+func (b *NullBroadcaster) Auth(ctx context.Context, request broadcasting.AuthRequest) (interface{}, error) {
+	return authorize(ctx, b.channels, request)
+}
@@ -0,0 +1,186 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/broadcasting"
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// Manager 广播管理器实现
+//
+// 广播器在首次使用时创建并缓存，内置 redis、pusher、websocket、log、null 驱动，
+// 所有广播器共享同一个频道授权注册表。
+type Manager struct {
+	mu                sync.RWMutex
+	config            map[string]map[string]interface{}
+	defaultConnection string
+	connections       map[string]broadcasting.Broadcaster
+	factories         map[string]func(config map[string]interface{}, channels *broadcasting.Channels) (broadcasting.Broadcaster, error)
+	channels          *broadcasting.Channels
+	queue             queue.Manager
+}
+
+var _ broadcasting.Manager = (*Manager)(nil)
+
+// NewManager 创建广播管理器，c 用于解析频道授权器，可以为 nil
+func NewManager(config map[string]map[string]interface{}, defaultConnection string, c container.Container) *Manager {
+	m := &Manager{
+		config:            config,
+		defaultConnection: defaultConnection,
+		connections:       make(map[string]broadcasting.Broadcaster),
+		factories:         make(map[string]func(config map[string]interface{}, channels *broadcasting.Channels) (broadcasting.Broadcaster, error)),
+		channels:          broadcasting.NewChannels(c),
+	}
+	m.Extend(broadcasting.DriverRedis, func(config map[string]interface{}, channels *broadcasting.Channels) (broadcasting.Broadcaster, error) {
+		return NewRedisBroadcaster(config, channels)
+	})
+	m.Extend(broadcasting.DriverPusher, func(config map[string]interface{}, channels *broadcasting.Channels) (broadcasting.Broadcaster, error) {
+		return NewPusherBroadcaster(config, channels)
+	})
+	m.Extend(broadcasting.DriverWebSocket, func(config map[string]interface{}, channels *broadcasting.Channels) (broadcasting.Broadcaster, error) {
+		return NewWebSocketBroadcaster(config, channels), nil
+	})
+	m.Extend(broadcasting.DriverLog, func(config map[string]interface{}, channels *broadcasting.Channels) (broadcasting.Broadcaster, error) {
+		return NewLogBroadcaster(config, channels), nil
+	})
+	m.Extend(broadcasting.DriverNull, func(config map[string]interface{}, channels *broadcasting.Channels) (broadcasting.Broadcaster, error) {
+		return NewNullBroadcaster(channels), nil
+	})
+	return m
+}
+
+// Connection 获取广播器，不传名称时使用默认连接
+func (m *Manager) Connection(name ...string) (broadcasting.Broadcaster, error) {
+	connection := m.GetDefaultConnection()
+	if len(name) > 0 && name[0] != "" {
+		connection = name[0]
+	}
+
+	m.mu.RLock()
+	broadcaster, ok := m.connections[connection]
+	m.mu.RUnlock()
+	if ok {
+		return broadcaster, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if broadcaster, ok := m.connections[connection]; ok {
+		return broadcaster, nil
+	}
+	config, ok := m.config[connection]
+	if !ok {
+		return nil, fmt.Errorf("broadcasting: connection [%s] is not defined", connection)
+	}
+	driver, _ := config["driver"].(string)
+	factory, ok := m.factories[driver]
+	if !ok {
+		return nil, fmt.Errorf("broadcasting: driver [%s] is not supported", driver)
+	}
+	broadcaster, err := factory(config, m.channels)
+	if err != nil {
+		return nil, err
+	}
+	m.connections[connection] = broadcaster
+	return broadcaster, nil
+}
+
+// GetDefaultConnection 获取默认连接名称
+func (m *Manager) GetDefaultConnection() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultConnection
+}
+
+// SetDefaultConnection 设置默认连接名称
+func (m *Manager) SetDefaultConnection(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultConnection = name
+}
+
+// Extend 注册驱动
+func (m *Manager) Extend(driver string, factory func(config map[string]interface{}, channels *broadcasting.Channels) (broadcasting.Broadcaster, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.factories[driver] = factory
+}
+
+// Channel 注册频道授权规则
+func (m *Manager) Channel(pattern string, handler interface{}) broadcasting.Manager {
+	m.channels.Channel(pattern, handler)
+	return m
+}
+
+// Channels 获取频道授权注册表
+func (m *Manager) Channels() *broadcasting.Channels {
+	return m.channels
+}
+
+// SetQueue 设置投递排队广播使用的队列管理器，未设置时使用 queue.DefaultManager
+func (m *Manager) SetQueue(manager queue.Manager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queue = manager
+}
+
+// Event 广播事件
+func (m *Manager) Event(ctx context.Context, event broadcasting.ShouldBroadcast) error {
+	if !broadcasting.ShouldBroadcastEvent(event) {
+		return nil
+	}
+	channels := broadcasting.ChannelNames(event.BroadcastOn())
+	if len(channels) == 0 {
+		return nil
+	}
+	payload, err := broadcasting.Payload(ctx, event)
+	if err != nil {
+		return err
+	}
+	var connection string
+	if via, ok := event.(broadcasting.BroadcastsVia); ok {
+		connection = via.BroadcastConnection()
+	}
+	name := broadcasting.EventName(event)
+
+	if _, now := event.(broadcasting.ShouldBroadcastNow); now {
+		broadcaster, err := m.Connection(connection)
+		if err != nil {
+			return err
+		}
+		return broadcaster.Broadcast(ctx, channels, name, payload)
+	}
+
+	m.mu.RLock()
+	manager := m.queue
+	m.mu.RUnlock()
+	if manager == nil {
+		manager = queue.DefaultManager()
+	}
+	if manager == nil {
+		return queue.ErrNoManager
+	}
+	var queueConnection, queueName string
+	if route, ok := event.(queue.QueueRoute); ok {
+		queueConnection, queueName = route.ViaConnection(), route.ViaQueue()
+	}
+	var names []string
+	if queueConnection != "" {
+		names = append(names, queueConnection)
+	}
+	q, err := manager.Connection(names...)
+	if err != nil {
+		return err
+	}
+	_, err = q.Push(ctx, &broadcasting.BroadcastEvent{
+		Connection: connection,
+		Channels:   channels,
+		Event:      name,
+		Payload:    payload,
+	}, queueName)
+	return err
+}
@@ -0,0 +1,152 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/broadcasting"
+)
+
+// pusherChannelLimit Pusher 单次请求的最大频道数
+const pusherChannelLimit = 100
+
+// PusherBroadcaster Pusher HTTP API 广播器
+//
+// 兼容 Pusher 协议的服务（Soketi、Laravel Reverb 等）通过 host、port、scheme 配置使用。
+type PusherBroadcaster struct {
+	appID    string
+	key      string
+	secret   string
+	endpoint string
+	client   *http.Client
+	channels *broadcasting.Channels
+}
+
+var _ broadcasting.Broadcaster = (*PusherBroadcaster)(nil)
+
+// NewPusherBroadcaster 创建 Pusher 广播器
+//
+// 配置项：app_id、key、secret、cluster（默认 mt1）、host（默认 api-{cluster}.pusher.com）、
+// port、scheme（默认 https）、client（*http.Client）、timeout（默认 30 秒）。
+func NewPusherBroadcaster(config map[string]interface{}, channels *broadcasting.Channels) (*PusherBroadcaster, error) {
+	b := &PusherBroadcaster{
+		appID:    stringOption(config, "app_id", ""),
+		key:      stringOption(config, "key", ""),
+		secret:   stringOption(config, "secret", ""),
+		channels: channels,
+	}
+	if b.appID == "" || b.key == "" || b.secret == "" {
+		return nil, fmt.Errorf("broadcasting: pusher requires app_id, key and secret")
+	}
+	host := stringOption(config, "host", "api-"+stringOption(config, "cluster", "mt1")+".pusher.com")
+	if port := stringOption(config, "port", ""); port != "" {
+		host += ":" + port
+	}
+	b.endpoint = stringOption(config, "scheme", "https") + "://" + host
+	client, ok := config["client"].(*http.Client)
+	if !ok {
+		client = &http.Client{Timeout: durationOption(config, "timeout", 30*time.Second)}
+	}
+	b.client = client
+	return b, nil
+}
+
+// Broadcast 通过 Pusher 触发事件，超过 100 个频道时分批请求
+func (b *PusherBroadcaster) Broadcast(ctx context.Context, channels []string, event string, payload map[string]interface{}) error {
+	data, socket := splitSocket(payload)
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(channels); start += pusherChannelLimit {
+		end := start + pusherChannelLimit
+		if end > len(channels) {
+			end = len(channels)
+		}
+		body := map[string]interface{}{
+			"name":     event,
+			"channels": channels[start:end],
+			"data":     string(encoded),
+		}
+		if socket != "" {
+			body["socket_id"] = socket
+		}
+		if err := b.trigger(ctx, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trigger 发送签名的事件请求
+func (b *PusherBroadcaster) trigger(ctx context.Context, body map[string]interface{}) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	path := "/apps/" + b.appID + "/events"
+	sum := md5.Sum(raw)
+	query := url.Values{
+		"auth_key":       {b.key},
+		"auth_timestamp": {strconv.FormatInt(time.Now().Unix(), 10)},
+		"auth_version":   {"1.0"},
+		"body_md5":       {hex.EncodeToString(sum[:])},
+	}
+	// url.Values.Encode 按键排序，满足 Pusher 签名要求
+	query.Set("auth_signature", b.sign("POST\n"+path+"\n"+query.Encode()))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint+path+"?"+query.Encode(), bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("broadcasting: pusher request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("broadcasting: pusher responded %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// Auth 授权频道订阅并生成 Pusher 签名
+func (b *PusherBroadcaster) Auth(ctx context.Context, request broadcasting.AuthRequest) (interface{}, error) {
+	result, err := b.channels.Authorize(ctx, request.User, request.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	toSign := request.SocketID + ":" + request.ChannelName
+	response := map[string]string{}
+	if broadcasting.IsPresenceChannel(request.ChannelName) {
+		channelData, err := json.Marshal(b.channels.Member(request.User, result))
+		if err != nil {
+			return nil, err
+		}
+		toSign += ":" + string(channelData)
+		response["channel_data"] = string(channelData)
+	}
+	response["auth"] = b.key + ":" + b.sign(toSign)
+	return response, nil
+}
+
+// sign HMAC-SHA256 签名
+func (b *PusherBroadcaster) sign(value string) string {
+	mac := hmac.New(sha256.New, []byte(b.secret))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
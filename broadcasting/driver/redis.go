@@ -0,0 +1,68 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cnote0/laraveldoc/broadcasting"
+)
+
+// RedisPublisher Redis 发布接口
+//
+// 使用方为 go-redis 等客户端编写适配器，例如：
+//
+//	func (a *adapter) Publish(ctx context.Context, channel, message string) error {
+//		return a.client.Publish(ctx, channel, message).Err()
+//	}
+type RedisPublisher interface {
+	Publish(ctx context.Context, channel, message string) error
+}
+
+// RedisBroadcaster Redis 广播器
+//
+// 消息格式与 Laravel 相同：{"event": ..., "data": ..., "socket": ...}，
+// 由 Laravel Echo Server 等订阅 Redis 的服务转发给客户端。
+type RedisBroadcaster struct {
+	client   RedisPublisher
+	prefix   string
+	channels *broadcasting.Channels
+}
+
+var _ broadcasting.Broadcaster = (*RedisBroadcaster)(nil)
+
+// NewRedisBroadcaster 创建 Redis 广播器，配置项：client（RedisPublisher）、prefix（频道前缀）
+func NewRedisBroadcaster(config map[string]interface{}, channels *broadcasting.Channels) (*RedisBroadcaster, error) {
+	client, err := clientOption[RedisPublisher](config, "client")
+	if err != nil {
+		return nil, err
+	}
+	return &RedisBroadcaster{
+		client:   client,
+		prefix:   stringOption(config, "prefix", ""),
+		channels: channels,
+	}, nil
+}
+
+// Broadcast 向每个频道发布消息
+func (b *RedisBroadcaster) Broadcast(ctx context.Context, channels []string, event string, payload map[string]interface{}) error {
+	data, socket := splitSocket(payload)
+	message, err := json.Marshal(map[string]interface{}{
+		"event":  event,
+		"data":   data,
+		"socket": socket,
+	})
+	if err != nil {
+		return err
+	}
+	for _, channel := range channels {
+		if err := b.client.Publish(ctx, b.prefix+channel, string(message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Auth 授权频道订阅
+func (b *RedisBroadcaster) Auth(ctx context.Context, request broadcasting.AuthRequest) (interface{}, error) {
+	return authorize(ctx, b.channels, request)
+}
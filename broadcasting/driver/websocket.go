@@ -0,0 +1,366 @@
+package driver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/broadcasting"
+	"github.com/cnote0/laraveldoc/routing"
+)
+
+// WebSocketBroadcaster 内置 WebSocket 广播服务
+//
+// 实现 Pusher 协议的服务端子集：pusher:subscribe、pusher:unsubscribe、pusher:ping、
+// 在线频道成员事件和 client- 客户端事件。私有和在线频道在订阅时直接使用 WebSocket
+// 升级请求中的用户进行授权，不校验客户端提交的签名。
+//
+// 广播只送达连接到本进程的客户端，多实例部署时需要配合 redis 驱动或使用 Pusher 协议服务。
+type WebSocketBroadcaster struct {
+	channels        *broadcasting.Channels
+	user            func(r *http.Request) interface{}
+	options         routing.WebSocketOptions
+	activityTimeout time.Duration
+
+	mu       sync.RWMutex
+	clients  map[string]*wsClient
+	channel  map[string]map[*wsClient]struct{}
+	presence map[string]map[string]*presenceMembership
+}
+
+var _ broadcasting.Broadcaster = (*WebSocketBroadcaster)(nil)
+
+// wsClient WebSocket 客户端连接
+type wsClient struct {
+	id      string
+	conn    routing.WebSocketConn
+	user    interface{}
+	members map[string]broadcasting.PresenceMember
+}
+
+// presenceMembership 在线频道中同一用户的连接数
+type presenceMembership struct {
+	member      broadcasting.PresenceMember
+	connections int
+}
+
+// wsMessage Pusher 协议消息
+type wsMessage struct {
+	Event   string          `json:"event"`
+	Channel string          `json:"channel,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// NewWebSocketBroadcaster 创建 WebSocket 广播服务
+//
+// 配置项：user（func(*http.Request) interface{}，获取当前用户）、
+// check_origin（func(*http.Request) bool）、activity_timeout（默认 120 秒）、
+// max_message_size（字节，默认 10 KiB）。
+func NewWebSocketBroadcaster(config map[string]interface{}, channels *broadcasting.Channels) *WebSocketBroadcaster {
+	user, ok := config["user"].(func(r *http.Request) interface{})
+	if !ok {
+		user = func(r *http.Request) interface{} { return nil }
+	}
+	options := routing.WebSocketOptions{MaxMessageSize: 10 << 10}
+	if checkOrigin, ok := config["check_origin"].(func(r *http.Request) bool); ok {
+		options.CheckOrigin = checkOrigin
+	}
+	if size, ok := config["max_message_size"].(int); ok && size > 0 {
+		options.MaxMessageSize = int64(size)
+	}
+	return &WebSocketBroadcaster{
+		channels:        channels,
+		user:            user,
+		options:         options,
+		activityTimeout: durationOption(config, "activity_timeout", 120*time.Second),
+		clients:         make(map[string]*wsClient),
+		channel:         make(map[string]map[*wsClient]struct{}),
+		presence:        make(map[string]map[string]*presenceMembership),
+	}
+}
+
+// Handler 返回 http.Handler，升级请求并处理连接
+func (b *WebSocketBroadcaster) Handler() http.Handler {
+	return routing.WebSocketHTTPHandler(b.Serve, b.options)
+}
+
+// Serve 处理已升级的 WebSocket 连接，可以直接作为 routing.WebSocketHandler 注册到路由
+func (b *WebSocketBroadcaster) Serve(ctx context.Context, conn routing.WebSocketConn) {
+	client := &wsClient{
+		id:      newSocketID(),
+		conn:    conn,
+		user:    b.user(conn.Request()),
+		members: make(map[string]broadcasting.PresenceMember),
+	}
+	b.mu.Lock()
+	b.clients[client.id] = client
+	b.mu.Unlock()
+	defer b.disconnect(client)
+
+	_ = b.send(client, "pusher:connection_established", "", map[string]interface{}{
+		"socket_id":        client.id,
+		"activity_timeout": int(b.activityTimeout / time.Second),
+	})
+
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if messageType != routing.TextMessage {
+			continue
+		}
+		var message wsMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			_ = b.sendError(client, 4200, "invalid message")
+			continue
+		}
+		b.handle(ctx, client, message)
+	}
+}
+
+// handle 处理客户端消息
+func (b *WebSocketBroadcaster) handle(ctx context.Context, client *wsClient, message wsMessage) {
+	switch {
+	case message.Event == "pusher:ping":
+		_ = b.send(client, "pusher:pong", "", map[string]interface{}{})
+	case message.Event == "pusher:subscribe":
+		var data struct {
+			Channel string `json:"channel"`
+		}
+		_ = json.Unmarshal(message.Data, &data)
+		b.subscribe(ctx, client, data.Channel)
+	case message.Event == "pusher:unsubscribe":
+		var data struct {
+			Channel string `json:"channel"`
+		}
+		_ = json.Unmarshal(message.Data, &data)
+		b.unsubscribe(client, data.Channel)
+	case strings.HasPrefix(message.Event, "client-"):
+		b.whisper(client, message)
+	}
+}
+
+// subscribe 授权并订阅频道
+func (b *WebSocketBroadcaster) subscribe(ctx context.Context, client *wsClient, channel string) {
+	if channel == "" {
+		_ = b.sendError(client, 4009, "channel is required")
+		return
+	}
+	result, err := b.channels.Authorize(ctx, client.user, channel)
+	if err == nil && broadcasting.IsPrivateChannel(channel) && client.user == nil {
+		err = broadcasting.ErrAccessDenied
+	}
+	if err != nil {
+		status := 500
+		if errors.Is(err, broadcasting.ErrAccessDenied) {
+			status = 403
+		}
+		_ = b.send(client, "pusher:subscription_error", channel, map[string]interface{}{
+			"type":   "AuthError",
+			"error":  err.Error(),
+			"status": status,
+		})
+		return
+	}
+
+	b.mu.Lock()
+	if b.channel[channel] == nil {
+		b.channel[channel] = make(map[*wsClient]struct{})
+	}
+	b.channel[channel][client] = struct{}{}
+
+	var added *broadcasting.PresenceMember
+	var presence map[string]interface{}
+	if broadcasting.IsPresenceChannel(channel) {
+		member := b.channels.Member(client.user, result)
+		client.members[channel] = member
+		if b.presence[channel] == nil {
+			b.presence[channel] = make(map[string]*presenceMembership)
+		}
+		key := fmt.Sprint(member.UserID)
+		membership, ok := b.presence[channel][key]
+		if !ok {
+			membership = &presenceMembership{member: member}
+			b.presence[channel][key] = membership
+			added = &member
+		}
+		membership.connections++
+		presence = presenceData(b.presence[channel])
+	}
+	others := b.subscribers(channel, client.id)
+	b.mu.Unlock()
+
+	data := map[string]interface{}{}
+	if presence != nil {
+		data["presence"] = presence
+	}
+	_ = b.send(client, "pusher_internal:subscription_succeeded", channel, data)
+	if added != nil {
+		for _, other := range others {
+			_ = b.send(other, "pusher_internal:member_added", channel, added)
+		}
+	}
+}
+
+// unsubscribe 取消订阅，在线频道中用户的最后一个连接离开时通知其他成员
+func (b *WebSocketBroadcaster) unsubscribe(client *wsClient, channel string) {
+	b.mu.Lock()
+	subscribers, ok := b.channel[channel]
+	if !ok {
+		b.mu.Unlock()
+		return
+	}
+	if _, subscribed := subscribers[client]; !subscribed {
+		b.mu.Unlock()
+		return
+	}
+	delete(subscribers, client)
+	if len(subscribers) == 0 {
+		delete(b.channel, channel)
+	}
+
+	var removed *broadcasting.PresenceMember
+	if member, ok := client.members[channel]; ok {
+		delete(client.members, channel)
+		key := fmt.Sprint(member.UserID)
+		if membership, ok := b.presence[channel][key]; ok {
+			membership.connections--
+			if membership.connections <= 0 {
+				delete(b.presence[channel], key)
+				removed = &broadcasting.PresenceMember{UserID: member.UserID}
+			}
+		}
+		if len(b.presence[channel]) == 0 {
+			delete(b.presence, channel)
+		}
+	}
+	others := b.subscribers(channel, client.id)
+	b.mu.Unlock()
+
+	if removed != nil {
+		for _, other := range others {
+			_ = b.send(other, "pusher_internal:member_removed", channel, removed)
+		}
+	}
+}
+
+// whisper 转发客户端事件给同一私有频道的其他订阅者
+func (b *WebSocketBroadcaster) whisper(client *wsClient, message wsMessage) {
+	if !broadcasting.IsPrivateChannel(message.Channel) {
+		_ = b.sendError(client, 4301, "client events are only allowed on private and presence channels")
+		return
+	}
+	b.mu.RLock()
+	_, subscribed := b.channel[message.Channel][client]
+	others := b.subscribers(message.Channel, client.id)
+	b.mu.RUnlock()
+	if !subscribed {
+		return
+	}
+	for _, other := range others {
+		_ = other.conn.WriteJSON(message)
+	}
+}
+
+// disconnect 连接关闭时取消所有订阅
+func (b *WebSocketBroadcaster) disconnect(client *wsClient) {
+	b.mu.RLock()
+	var channels []string
+	for channel, subscribers := range b.channel {
+		if _, ok := subscribers[client]; ok {
+			channels = append(channels, channel)
+		}
+	}
+	b.mu.RUnlock()
+	for _, channel := range channels {
+		b.unsubscribe(client, channel)
+	}
+	b.mu.Lock()
+	delete(b.clients, client.id)
+	b.mu.Unlock()
+}
+
+// Broadcast 向本进程中订阅了频道的客户端发送事件
+func (b *WebSocketBroadcaster) Broadcast(ctx context.Context, channels []string, event string, payload map[string]interface{}) error {
+	data, socket := splitSocket(payload)
+	for _, channel := range channels {
+		b.mu.RLock()
+		subscribers := b.subscribers(channel, socket)
+		b.mu.RUnlock()
+		for _, client := range subscribers {
+			// 单个客户端写入失败不影响其他客户端，断开的连接由读循环清理
+			_ = b.send(client, event, channel, data)
+		}
+	}
+	return nil
+}
+
+// Auth 授权频道订阅，用于 /broadcasting/auth 接口
+func (b *WebSocketBroadcaster) Auth(ctx context.Context, request broadcasting.AuthRequest) (interface{}, error) {
+	return authorize(ctx, b.channels, request)
+}
+
+// Connections 当前连接数
+func (b *WebSocketBroadcaster) Connections() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.clients)
+}
+
+// subscribers 频道的订阅者，排除指定 Socket ID，调用方持有锁
+func (b *WebSocketBroadcaster) subscribers(channel, except string) []*wsClient {
+	clients := make([]*wsClient, 0, len(b.channel[channel]))
+	for client := range b.channel[channel] {
+		if client.id != except {
+			clients = append(clients, client)
+		}
+	}
+	return clients
+}
+
+// send 发送 Pusher 协议消息，data 按协议编码为 JSON 字符串
+func (b *WebSocketBroadcaster) send(client *wsClient, event, channel string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	quoted, err := json.Marshal(string(encoded))
+	if err != nil {
+		return err
+	}
+	return client.conn.WriteJSON(wsMessage{Event: event, Channel: channel, Data: quoted})
+}
+
+// sendError 发送 pusher:error
+func (b *WebSocketBroadcaster) sendError(client *wsClient, code int, message string) error {
+	return client.conn.WriteJSON(map[string]interface{}{
+		"event": "pusher:error",
+		"data":  map[string]interface{}{"code": code, "message": message},
+	})
+}
+
+// presenceData 在线频道的成员列表
+func presenceData(members map[string]*presenceMembership) map[string]interface{} {
+	ids := make([]interface{}, 0, len(members))
+	hash := make(map[string]interface{}, len(members))
+	for key, membership := range members {
+		ids = append(ids, membership.member.UserID)
+		hash[key] = membership.member.UserInfo
+	}
+	return map[string]interface{}{"ids": ids, "hash": hash, "count": len(members)}
+}
+
+// newSocketID 生成 Pusher 格式的 Socket ID（两段数字）
+func newSocketID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return fmt.Sprintf("%d.%d", binary.BigEndian.Uint32(b[:4])%1000000000, binary.BigEndian.Uint32(b[4:])%1000000000)
+}
@@ -0,0 +1,106 @@
+package broadcasting
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+// ShouldBroadcast 需要广播的事件
+//
+// 广播默认通过队列进行，事件可以嵌入 queue.Queued 指定连接和队列。
+// 载荷默认为事件的 JSON 序列化结果。
+type ShouldBroadcast interface {
+	// BroadcastOn 广播的频道
+	BroadcastOn() []Channel
+}
+
+// ShouldBroadcastNow 不经过队列、立即广播的事件
+type ShouldBroadcastNow interface {
+	ShouldBroadcast
+	BroadcastNow()
+}
+
+// BroadcastsAs 自定义广播事件名称，默认为事件的类型名
+type BroadcastsAs interface {
+	BroadcastAs() string
+}
+
+// BroadcastsWith 自定义广播载荷
+type BroadcastsWith interface {
+	BroadcastWith() map[string]interface{}
+}
+
+// BroadcastsWhen 按条件广播，返回 false 时不广播
+type BroadcastsWhen interface {
+	BroadcastWhen() bool
+}
+
+// BroadcastsVia 指定广播连接，默认使用默认连接
+type BroadcastsVia interface {
+	BroadcastConnection() string
+}
+
+type socketKey struct{}
+
+// ToOthers 返回携带当前连接 Socket ID 的 context，广播时排除该连接
+//
+// Socket ID 通常来自客户端请求的 X-Socket-ID 头。
+func ToOthers(ctx context.Context, socketID string) context.Context {
+	if socketID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, socketKey{}, socketID)
+}
+
+// SocketID 获取 ToOthers 设置的 Socket ID
+func SocketID(ctx context.Context) string {
+	socketID, _ := ctx.Value(socketKey{}).(string)
+	return socketID
+}
+
+// EventName 广播事件名称
+func EventName(event ShouldBroadcast) string {
+	if named, ok := event.(BroadcastsAs); ok {
+		return named.BroadcastAs()
+	}
+	t := reflect.TypeOf(event)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// Payload 广播载荷，ctx 中有 Socket ID 时写入 "socket" 字段
+func Payload(ctx context.Context, event ShouldBroadcast) (map[string]interface{}, error) {
+	var payload map[string]interface{}
+	if with, ok := event.(BroadcastsWith); ok {
+		payload = make(map[string]interface{})
+		for key, value := range with.BroadcastWith() {
+			payload[key] = value
+		}
+	} else {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, err
+		}
+		if payload == nil {
+			payload = make(map[string]interface{})
+		}
+	}
+	if socketID := SocketID(ctx); socketID != "" {
+		payload["socket"] = socketID
+	}
+	return payload, nil
+}
+
+// ShouldBroadcastEvent 事件是否需要广播（BroadcastWhen 条件）
+func ShouldBroadcastEvent(event ShouldBroadcast) bool {
+	if when, ok := event.(BroadcastsWhen); ok {
+		return when.BroadcastWhen()
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package broadcasting
+
+import (
+	"context"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+func init() {
+	queue.Register(&BroadcastEvent{})
+}
+
+// BroadcastEvent 广播事件的队列任务
+//
+// 事件名称和载荷在投递时计算，Worker 通过 DefaultManager 获取广播器，
+// 因此 Worker 进程需要调用 SetDefaultManager。
+type BroadcastEvent struct {
+	queue.Queueable
+
+	// Connection 广播连接名称，为空时使用默认连接
+	Connection string `json:"connection,omitempty"`
+
+	// Channels 频道名称
+	Channels []string `json:"channels"`
+
+	// Event 事件名称
+	Event string `json:"event"`
+
+	// Payload 载荷
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// JobName 固定任务名称
+func (j *BroadcastEvent) JobName() string {
+	return "broadcasting.BroadcastEvent"
+}
+
+// Handle 广播事件
+func (j *BroadcastEvent) Handle(ctx context.Context) error {
+	manager := DefaultManager()
+	if manager == nil {
+		return ErrNoManager
+	}
+	broadcaster, err := manager.Connection(j.Connection)
+	if err != nil {
+		return err
+	}
+	return broadcaster.Broadcast(ctx, j.Channels, j.Event, j.Payload)
+}
@@ -0,0 +1,51 @@
+// Package bus 提供 Laravel 风格的命令总线
+//
+// 命令是描述一次操作的普通结构体，由映射的处理器或命令自身的 Handle 方法执行。
+// 实现 queue.ShouldQueue 的命令投递到队列异步执行，其余命令在当前 goroutine 中同步执行。
+// 处理器外层可以包裹管道中间件，例如数据库事务和日志。
+//
+// 主要特性：
+// - Dispatch 按命令类型自动选择同步或队列执行
+// - DispatchSync 强制同步执行
+// - DispatchAfterResponse 在响应发送后执行
+// - 命令到处理器的映射，处理器可以通过容器解析
+// - 管道中间件（Transaction、Logging）
+// - FakeDispatcher 测试替身，记录分发的命令并提供断言
+//
+// 包结构：
+// - dispatcher.go - Dispatcher 命令分发器接口和 Handler 处理器接口
+// - pipe.go - Pipe 管道中间件和 Transaction、Logging 内置中间件
+// - default.go - Dispatcher 的默认实现
+// - fake.go - FakeDispatcher 假分发器和测试断言
+//
+// 使用示例：
+//
+//	type CreateOrder struct {
+//		UserID uint
+//		Items  []Item
+//	}
+//
+//	type CreateOrderHandler struct{ Orders *OrderRepository }
+//
+//	func (h *CreateOrderHandler) Handle(ctx context.Context, command interface{}) (interface{}, error) {
+//		cmd := command.(*CreateOrder)
+//		return h.Orders.Create(ctx, cmd.UserID, cmd.Items)
+//	}
+//
+//	dispatcher := bus.NewDispatcher(app, queueManager)
+//	dispatcher.Map(&CreateOrder{}, "handlers.create_order") // 每次分发时通过容器解析
+//	dispatcher.PipeThrough(bus.Transaction(db), bus.Logging(logger))
+//
+//	order, err := dispatcher.Dispatch(ctx, &CreateOrder{UserID: 1, Items: items})
+//
+//	// 自处理的排队命令
+//	type SendWelcomeEmail struct {
+//		queue.Queued
+//		UserID uint `json:"user_id"`
+//	}
+//
+//	func (c *SendWelcomeEmail) Handle(ctx context.Context) error { ... }
+//
+//	dispatcher.Dispatch(ctx, &SendWelcomeEmail{UserID: 1}) // 投递到队列
+//	dispatcher.DispatchAfterResponse(ctx, &AuditVisit{Path: r.URL.Path})
+package bus
@@ -0,0 +1,184 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// dispatcher Dispatcher 的默认实现
+type dispatcher struct {
+	container container.Container
+	manager   queue.Manager
+
+	mu       sync.RWMutex
+	handlers map[reflect.Type]interface{}
+	pipes    []Pipe
+	after    []afterResponse
+}
+
+type afterResponse struct {
+	ctx     context.Context
+	command interface{}
+}
+
+// NewDispatcher 创建命令分发器
+//
+// c 用于解析以服务标识符映射的处理器，可以为 nil；
+// manager 用于投递排队命令，为 nil 时使用 queue.DefaultManager。
+func NewDispatcher(c container.Container, manager queue.Manager) Dispatcher {
+	return &dispatcher{
+		container: c,
+		manager:   manager,
+		handlers:  make(map[reflect.Type]interface{}),
+	}
+}
+
+// Dispatch 分发命令
+func (d *dispatcher) Dispatch(ctx context.Context, command interface{}) (interface{}, error) {
+	if _, ok := command.(queue.ShouldQueue); ok {
+		return d.DispatchToQueue(ctx, command)
+	}
+	return d.DispatchSync(ctx, command)
+}
+
+// DispatchSync 同步执行命令
+func (d *dispatcher) DispatchSync(ctx context.Context, command interface{}) (interface{}, error) {
+	d.mu.RLock()
+	pipes := d.pipes
+	d.mu.RUnlock()
+	return through(pipes, d.handle)(ctx, command)
+}
+
+// handle 调用处理器
+func (d *dispatcher) handle(ctx context.Context, command interface{}) (interface{}, error) {
+	if d.HasCommandHandler(command) {
+		handler, err := d.GetCommandHandler(command)
+		if err != nil {
+			return nil, err
+		}
+		return handler.Handle(ctx, command)
+	}
+	switch cmd := command.(type) {
+	case SelfHandlingWithResult:
+		return cmd.Handle(ctx)
+	case SelfHandling:
+		return nil, cmd.Handle(ctx)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoHandler, commandName(command))
+}
+
+// DispatchToQueue 将命令投递到队列
+func (d *dispatcher) DispatchToQueue(ctx context.Context, command interface{}) (string, error) {
+	job, ok := command.(queue.Job)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotQueueable, commandName(command))
+	}
+	manager := d.manager
+	if manager == nil {
+		manager = queue.DefaultManager()
+	}
+	if manager == nil {
+		return "", queue.ErrNoManager
+	}
+
+	var connection, queueName string
+	if route, ok := command.(queue.QueueRoute); ok {
+		connection, queueName = route.ViaConnection(), route.ViaQueue()
+	}
+	var names []string
+	if connection != "" {
+		names = append(names, connection)
+	}
+	q, err := manager.Connection(names...)
+	if err != nil {
+		return "", err
+	}
+	return q.Push(ctx, job, queueName)
+}
+
+// DispatchAfterResponse 登记在 Terminate 时执行的命令
+func (d *dispatcher) DispatchAfterResponse(ctx context.Context, command interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.after = append(d.after, afterResponse{ctx: context.WithoutCancel(ctx), command: command})
+}
+
+// Terminate 执行登记的命令
+func (d *dispatcher) Terminate(ctx context.Context) error {
+	d.mu.Lock()
+	pending := d.after
+	d.after = nil
+	d.mu.Unlock()
+
+	var errs []error
+	for _, item := range pending {
+		if _, err := d.DispatchSync(item.ctx, item.command); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Map 映射命令处理器
+func (d *dispatcher) Map(command interface{}, handler interface{}) Dispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[commandType(command)] = handler
+	return d
+}
+
+// HasCommandHandler 命令是否映射了处理器
+func (d *dispatcher) HasCommandHandler(command interface{}) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.handlers[commandType(command)]
+	return ok
+}
+
+// GetCommandHandler 获取命令处理器
+func (d *dispatcher) GetCommandHandler(command interface{}) (Handler, error) {
+	d.mu.RLock()
+	mapped, ok := d.handlers[commandType(command)]
+	d.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoHandler, commandName(command))
+	}
+	if handler, ok := mapped.(Handler); ok {
+		return handler, nil
+	}
+	if d.container == nil {
+		return nil, fmt.Errorf("bus: cannot resolve handler %v for %s without a container", mapped, commandName(command))
+	}
+	resolved, err := d.container.Make(mapped)
+	if err != nil {
+		return nil, fmt.Errorf("bus: resolve handler for %s: %w", commandName(command), err)
+	}
+	handler, ok := resolved.(Handler)
+	if !ok {
+		return nil, fmt.Errorf("bus: resolved handler %T for %s does not implement bus.Handler", resolved, commandName(command))
+	}
+	return handler, nil
+}
+
+// PipeThrough 设置管道中间件
+func (d *dispatcher) PipeThrough(pipes ...Pipe) Dispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pipes = append([]Pipe{}, pipes...)
+	return d
+}
+
+// commandType 命令的映射键，指针和值类型视为同一命令
+func commandType(command interface{}) reflect.Type {
+	t := reflect.TypeOf(command)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,118 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// 分发错误
+var (
+	// ErrNoHandler 命令没有映射处理器，也没有 Handle 方法
+	ErrNoHandler = errors.New("bus: no handler for command")
+
+	// ErrNotQueueable 排队的命令没有实现 queue.Job
+	ErrNotQueueable = errors.New("bus: queued command must implement queue.Job")
+
+	// ErrNoDispatcher 没有设置默认分发器
+	ErrNoDispatcher = errors.New("bus: no default dispatcher, call bus.SetDefaultDispatcher")
+)
+
+// Dispatcher 命令分发器接口
+//
+// 对应 Laravel 的 Illuminate\Bus\Dispatcher。
+type Dispatcher interface {
+	// Dispatch 分发命令
+	//
+	// 实现 queue.ShouldQueue 的命令投递到队列，返回任务 ID；
+	// 其余命令同步执行，返回处理器的结果。
+	Dispatch(ctx context.Context, command interface{}) (interface{}, error)
+
+	// DispatchSync 在当前 goroutine 中同步执行命令，即使命令实现了 queue.ShouldQueue
+	DispatchSync(ctx context.Context, command interface{}) (interface{}, error)
+
+	// DispatchToQueue 将命令投递到队列，返回任务 ID
+	//
+	// 连接和队列取自命令的 queue.QueueRoute，为空时使用默认值。
+	DispatchToQueue(ctx context.Context, command interface{}) (string, error)
+
+	// DispatchAfterResponse 在 Terminate 时同步执行命令
+	//
+	// HTTP 内核在响应发送后调用 Terminate，适合不影响响应的收尾工作。
+	DispatchAfterResponse(ctx context.Context, command interface{})
+
+	// Terminate 执行所有 DispatchAfterResponse 登记的命令，返回合并后的错误
+	Terminate(ctx context.Context) error
+
+	// Map 将命令类型映射到处理器
+	//
+	// command 是命令的示例值，指针和值类型视为同一命令。
+	// handler 可以是 Handler 实例，或者容器中的服务标识符（每次分发时解析，结果必须实现 Handler）。
+	Map(command interface{}, handler interface{}) Dispatcher
+
+	// HasCommandHandler 命令是否映射了处理器
+	HasCommandHandler(command interface{}) bool
+
+	// GetCommandHandler 获取命令映射的处理器，未映射时返回 ErrNoHandler
+	GetCommandHandler(command interface{}) (Handler, error)
+
+	// PipeThrough 设置处理器外层的管道中间件，按顺序由外到内执行
+	PipeThrough(pipes ...Pipe) Dispatcher
+}
+
+// Handler 命令处理器
+type Handler interface {
+	Handle(ctx context.Context, command interface{}) (interface{}, error)
+}
+
+// HandlerFunc 函数形式的命令处理器
+type HandlerFunc func(ctx context.Context, command interface{}) (interface{}, error)
+
+// Handle 调用函数
+func (f HandlerFunc) Handle(ctx context.Context, command interface{}) (interface{}, error) {
+	return f(ctx, command)
+}
+
+// SelfHandling 自处理命令，签名与 queue.Job 的 Handle 相同
+type SelfHandling interface {
+	Handle(ctx context.Context) error
+}
+
+// SelfHandlingWithResult 有返回值的自处理命令
+type SelfHandlingWithResult interface {
+	Handle(ctx context.Context) (interface{}, error)
+}
+
+// defaultDispatcher 默认命令分发器
+var defaultDispatcher atomic.Value
+
+// SetDefaultDispatcher 设置默认命令分发器，供包级 Dispatch 等便捷函数使用
+func SetDefaultDispatcher(dispatcher Dispatcher) {
+	defaultDispatcher.Store(&dispatcher)
+}
+
+// DefaultDispatcher 获取默认命令分发器，未设置时返回 nil
+func DefaultDispatcher() Dispatcher {
+	if dispatcher, ok := defaultDispatcher.Load().(*Dispatcher); ok {
+		return *dispatcher
+	}
+	return nil
+}
+
+// Dispatch 通过默认分发器分发命令
+func Dispatch(ctx context.Context, command interface{}) (interface{}, error) {
+	dispatcher := DefaultDispatcher()
+	if dispatcher == nil {
+		return nil, ErrNoDispatcher
+	}
+	return dispatcher.Dispatch(ctx, command)
+}
+
+// DispatchSync 通过默认分发器同步执行命令
+func DispatchSync(ctx context.Context, command interface{}) (interface{}, error) {
+	dispatcher := DefaultDispatcher()
+	if dispatcher == nil {
+		return nil, ErrNoDispatcher
+	}
+	return dispatcher.DispatchSync(ctx, command)
+}
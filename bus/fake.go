@@ -0,0 +1,257 @@
+package bus
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// TestingT Fake 使用的测试接口，*testing.T 和 *testing.B 都满足
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// DispatchedCommand FakeDispatcher 记录的命令
+type DispatchedCommand struct {
+	Command interface{}
+
+	// Queued 命令被投递到队列（Dispatch 排队命令或 DispatchToQueue）
+	Queued bool
+
+	// AfterResponse 命令通过 DispatchAfterResponse 登记
+	AfterResponse bool
+}
+
+// FakeDispatcher 测试用的命令分发器，对应 Laravel 的 Bus::fake
+//
+// 被伪造的命令只记录不执行，Terminate 也不会执行登记的命令。
+// 指定了伪造的命令类型时，其余命令交给原分发器处理。
+type FakeDispatcher struct {
+	t        TestingT
+	next     Dispatcher
+	commands map[reflect.Type]bool
+
+	mu         sync.Mutex
+	dispatched []*DispatchedCommand
+}
+
+// Fake 把默认分发器替换为 FakeDispatcher，测试结束时恢复原分发器
+//
+// 访问器为 "bus" 的门面同时替换为 FakeDispatcher。
+//
+// commands 为要伪造的命令示例值，不传时伪造全部命令。
+//
+// 使用示例：
+//
+//	func TestCheckout(t *testing.T) {
+//		fake := bus.Fake(t)
+//		// 执行下单
+//		fake.AssertDispatched(&CreateOrder{}, func(c *bus.DispatchedCommand) bool {
+//			return c.Command.(*CreateOrder).UserID == 1
+//		})
+//		fake.AssertNotDispatched(&RefundOrder{})
+//	}
+func Fake(t TestingT, commands ...interface{}) *FakeDispatcher {
+	t.Helper()
+	previous := DefaultDispatcher()
+	fake := NewFakeDispatcher(t, previous, commands...)
+	SetDefaultDispatcher(fake)
+	t.Cleanup(func() {
+		SetDefaultDispatcher(previous)
+	})
+	application.SwapFacade(t, "bus", fake)
+	return fake
+}
+
+// NewFakeDispatcher 创建 FakeDispatcher
+//
+// next 处理未伪造的命令，可以为 nil；commands 为空时伪造全部命令。
+func NewFakeDispatcher(t TestingT, next Dispatcher, commands ...interface{}) *FakeDispatcher {
+	fake := &FakeDispatcher{t: t, next: next}
+	if len(commands) > 0 {
+		fake.commands = make(map[reflect.Type]bool, len(commands))
+		for _, command := range commands {
+			fake.commands[commandType(command)] = true
+		}
+	}
+	return fake
+}
+
+// Dispatch 记录命令，排队命令记为已投递到队列
+func (f *FakeDispatcher) Dispatch(ctx context.Context, command interface{}) (interface{}, error) {
+	if !f.shouldFake(command) {
+		return f.next.Dispatch(ctx, command)
+	}
+	_, queued := command.(queue.ShouldQueue)
+	f.record(&DispatchedCommand{Command: command, Queued: queued})
+	return nil, nil
+}
+
+// DispatchSync 记录同步命令
+func (f *FakeDispatcher) DispatchSync(ctx context.Context, command interface{}) (interface{}, error) {
+	if !f.shouldFake(command) {
+		return f.next.DispatchSync(ctx, command)
+	}
+	f.record(&DispatchedCommand{Command: command})
+	return nil, nil
+}
+
+// DispatchToQueue 记录排队命令，返回随机的任务 ID
+func (f *FakeDispatcher) DispatchToQueue(ctx context.Context, command interface{}) (string, error) {
+	if !f.shouldFake(command) {
+		return f.next.DispatchToQueue(ctx, command)
+	}
+	f.record(&DispatchedCommand{Command: command, Queued: true})
+	return queue.NewUUID(), nil
+}
+
+// DispatchAfterResponse 记录响应后执行的命令
+func (f *FakeDispatcher) DispatchAfterResponse(ctx context.Context, command interface{}) {
+	if !f.shouldFake(command) {
+		f.next.DispatchAfterResponse(ctx, command)
+		return
+	}
+	f.record(&DispatchedCommand{Command: command, AfterResponse: true})
+}
+
+// Terminate 执行原分发器登记的命令，伪造的命令不会执行
+func (f *FakeDispatcher) Terminate(ctx context.Context) error {
+	if f.next == nil {
+		return nil
+	}
+	return f.next.Terminate(ctx)
+}
+
+// Map 映射原分发器的命令处理器
+func (f *FakeDispatcher) Map(command interface{}, handler interface{}) Dispatcher {
+	if f.next != nil {
+		f.next.Map(command, handler)
+	}
+	return f
+}
+
+// HasCommandHandler 原分发器是否映射了处理器
+func (f *FakeDispatcher) HasCommandHandler(command interface{}) bool {
+	return f.next != nil && f.next.HasCommandHandler(command)
+}
+
+// GetCommandHandler 获取原分发器映射的处理器
+func (f *FakeDispatcher) GetCommandHandler(command interface{}) (Handler, error) {
+	if f.next == nil {
+		return nil, ErrNoHandler
+	}
+	return f.next.GetCommandHandler(command)
+}
+
+// PipeThrough 设置原分发器的管道中间件
+func (f *FakeDispatcher) PipeThrough(pipes ...Pipe) Dispatcher {
+	if f.next != nil {
+		f.next.PipeThrough(pipes...)
+	}
+	return f
+}
+
+// Dispatched 返回与 command 同类型且满足条件的已分发命令，command 为 nil 时不按类型过滤
+func (f *FakeDispatcher) Dispatched(command interface{}, filter ...func(c *DispatchedCommand) bool) []*DispatchedCommand {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	typ := commandType(command)
+	var matched []*DispatchedCommand
+	for _, dispatched := range f.dispatched {
+		if typ != nil && commandType(dispatched.Command) != typ {
+			continue
+		}
+		if len(filter) > 0 && filter[0] != nil && !filter[0](dispatched) {
+			continue
+		}
+		matched = append(matched, dispatched)
+	}
+	return matched
+}
+
+// AssertDispatched 断言分发过与 command 同类型的命令，可选的 filter 进一步筛选
+func (f *FakeDispatcher) AssertDispatched(command interface{}, filter ...func(c *DispatchedCommand) bool) bool {
+	f.t.Helper()
+	if len(f.Dispatched(command, filter...)) == 0 {
+		f.t.Errorf("bus: the expected [%s] command was not dispatched", commandName(command))
+		return false
+	}
+	return true
+}
+
+// AssertDispatchedTimes 断言与 command 同类型的命令分发了 times 次
+func (f *FakeDispatcher) AssertDispatchedTimes(command interface{}, times int) bool {
+	f.t.Helper()
+	if n := len(f.Dispatched(command)); n != times {
+		f.t.Errorf("bus: the expected [%s] command was dispatched [%d] times instead of [%d] times", commandName(command), n, times)
+		return false
+	}
+	return true
+}
+
+// AssertNotDispatched 断言没有分发过与 command 同类型且满足条件的命令
+func (f *FakeDispatcher) AssertNotDispatched(command interface{}, filter ...func(c *DispatchedCommand) bool) bool {
+	f.t.Helper()
+	if len(f.Dispatched(command, filter...)) > 0 {
+		f.t.Errorf("bus: the unexpected [%s] command was dispatched", commandName(command))
+		return false
+	}
+	return true
+}
+
+// AssertDispatchedSync 断言同步执行过与 command 同类型的命令
+func (f *FakeDispatcher) AssertDispatchedSync(command interface{}) bool {
+	f.t.Helper()
+	if len(f.Dispatched(command, func(c *DispatchedCommand) bool { return !c.Queued && !c.AfterResponse })) == 0 {
+		f.t.Errorf("bus: the expected [%s] command was not dispatched synchronously", commandName(command))
+		return false
+	}
+	return true
+}
+
+// AssertDispatchedToQueue 断言投递过与 command 同类型的命令到队列
+func (f *FakeDispatcher) AssertDispatchedToQueue(command interface{}) bool {
+	f.t.Helper()
+	if len(f.Dispatched(command, func(c *DispatchedCommand) bool { return c.Queued })) == 0 {
+		f.t.Errorf("bus: the expected [%s] command was not pushed to the queue", commandName(command))
+		return false
+	}
+	return true
+}
+
+// AssertDispatchedAfterResponse 断言登记过与 command 同类型的响应后命令
+func (f *FakeDispatcher) AssertDispatchedAfterResponse(command interface{}) bool {
+	f.t.Helper()
+	if len(f.Dispatched(command, func(c *DispatchedCommand) bool { return c.AfterResponse })) == 0 {
+		f.t.Errorf("bus: the expected [%s] command was not dispatched after sending the response", commandName(command))
+		return false
+	}
+	return true
+}
+
+// AssertNothingDispatched 断言没有分发任何命令
+func (f *FakeDispatcher) AssertNothingDispatched() bool {
+	f.t.Helper()
+	if n := len(f.Dispatched(nil)); n > 0 {
+		f.t.Errorf("bus: [%d] unexpected commands were dispatched", n)
+		return false
+	}
+	return true
+}
+
+// shouldFake 命令是否被伪造，没有原分发器时伪造全部命令
+func (f *FakeDispatcher) shouldFake(command interface{}) bool {
+	return f.next == nil || f.commands == nil || f.commands[commandType(command)]
+}
+
+// record 记录命令
+func (f *FakeDispatcher) record(dispatched *DispatchedCommand) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dispatched = append(f.dispatched, dispatched)
+}
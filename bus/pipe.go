@@ -0,0 +1,107 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/pipeline"
+)
+
+// Next 调用管道中的下一层
+type Next func(ctx context.Context, command interface{}) (interface{}, error)
+
+// Pipe 命令管道中间件
+type Pipe interface {
+	Handle(ctx context.Context, command interface{}, next Next) (interface{}, error)
+}
+
+// PipeFunc 函数形式的管道中间件
+type PipeFunc func(ctx context.Context, command interface{}, next Next) (interface{}, error)
+
+// Handle 调用函数
+func (f PipeFunc) Handle(ctx context.Context, command interface{}, next Next) (interface{}, error) {
+	return f(ctx, command, next)
+}
+
+// through 按顺序包裹管道，pipes[0] 在最外层
+func through(pipes []Pipe, handler Next) Next {
+	stages := make([]pipeline.Pipe[interface{}, interface{}], len(pipes))
+	for i, pipe := range pipes {
+		stages[i] = pipeline.PipeFunc[interface{}, interface{}](func(ctx context.Context, command interface{}, next pipeline.Handler[interface{}, interface{}]) (interface{}, error) {
+			return pipe.Handle(ctx, command, Next(next))
+		})
+	}
+	return Next(pipeline.Compose(stages, pipeline.Handler[interface{}, interface{}](handler)))
+}
+
+type txKey struct{}
+
+// Transaction 在数据库事务中执行命令，处理器返回错误时回滚
+//
+// 处理器通过 Tx 获取事务连接。嵌套分发的命令复用外层事务。
+func Transaction(db database.DB) Pipe {
+	return PipeFunc(func(ctx context.Context, command interface{}, next Next) (interface{}, error) {
+		if _, ok := Tx(ctx); ok {
+			return next(ctx, command)
+		}
+		var result interface{}
+		err := db.WithContext(ctx).Transaction(func(tx database.DB) error {
+			var err error
+			result, err = next(context.WithValue(ctx, txKey{}, tx), command)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+}
+
+// Tx 获取 Transaction 中间件开启的事务连接
+func Tx(ctx context.Context) (database.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(database.DB)
+	return tx, ok
+}
+
+// Logger 日志接口，application.LoggerInterface 满足此接口
+type Logger interface {
+	Info(message string, context map[string]interface{}) error
+	Error(message string, context map[string]interface{}) error
+}
+
+// Logging 记录命令的执行耗时和错误
+func Logging(logger Logger) Pipe {
+	return PipeFunc(func(ctx context.Context, command interface{}, next Next) (interface{}, error) {
+		start := time.Now()
+		result, err := next(ctx, command)
+		fields := map[string]interface{}{
+			"command":  commandName(command),
+			"duration": time.Since(start).String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+			_ = logger.Error("command failed", fields)
+			return result, err
+		}
+		_ = logger.Info("command handled", fields)
+		return result, nil
+	})
+}
+
+// commandName 命令的类型名称
+func commandName(command interface{}) string {
+	t := reflect.TypeOf(command)
+	if t == nil {
+		return "<nil>"
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return fmt.Sprintf("%s.%s", t.PkgPath(), t.Name())
+}
@@ -0,0 +1,37 @@
+// Package cache 提供 Laravel 风格的缓存存储、锁和限流器协议定义
+//
+// 本包定义键值存储（Store）、原子锁（Cache::lock）和限流器（RateLimiter）的接口，
+// 队列中间件、任务唯一性、queue:restart 等功能基于这些接口实现。
+//
+// 主要特性：
+// - 带过期时间的键值存储
+// - 带过期时间和所有者的原子锁
+// - 阻塞等待获取锁
+// - 基于固定窗口的限流器
+// - 内存存储实现
+//
+// 包结构：
+// - store.go - Store 键值存储接口
+// - lock.go - Lock 原子锁和 LockProvider 锁提供者接口
+// - rate_limiter.go - RateLimiter 限流器接口和 Limit 限流规则
+// - memory.go - MemoryStore 内存存储、锁和限流器实现
+//
+// 使用示例：
+//
+//	store := cache.NewMemoryStore()
+//
+//	// 原子锁
+//	lock := store.Lock("reports:generate", 10*time.Second)
+//	if ok, _ := lock.Get(ctx); ok {
+//		defer lock.Release(ctx)
+//		generateReports()
+//	}
+//
+//	// 限流
+//	limit := cache.PerMinute(60).By("api:" + userID)
+//	if tooMany, _ := store.TooManyAttempts(ctx, limit.Key, limit.MaxAttempts); tooMany {
+//		retryAfter, _ := store.AvailableIn(ctx, limit.Key)
+//		return fmt.Errorf("too many requests, retry in %s", retryAfter)
+//	}
+//	store.Hit(ctx, limit.Key, limit.Decay)
+package cache
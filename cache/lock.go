@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLockTimeout 在等待时间内未能获取锁
+var ErrLockTimeout = errors.New("cache: unable to acquire lock")
+
+// Lock 原子锁接口
+//
+// 锁带有所有者标识，只有所有者可以释放。跨进程释放锁时通过
+// LockProvider.RestoreLock 使用相同的所有者恢复锁。
+type Lock interface {
+	// Get 尝试获取锁，不等待
+	Get(ctx context.Context) (bool, error)
+
+	// Block 在 wait 时间内反复尝试获取锁，超时返回 ErrLockTimeout
+	Block(ctx context.Context, wait time.Duration) error
+
+	// Release 释放锁，只有所有者可以释放
+	Release(ctx context.Context) (bool, error)
+
+	// ForceRelease 不检查所有者强制释放锁
+	ForceRelease(ctx context.Context) error
+
+	// Owner 所有者标识
+	Owner() string
+}
+
+// LockProvider 锁提供者接口
+type LockProvider interface {
+	// Lock 创建锁，ttl 为 0 表示不过期，owner 为空时随机生成
+	Lock(name string, ttl time.Duration, owner ...string) Lock
+
+	// RestoreLock 使用已知的所有者恢复锁
+	RestoreLock(name, owner string) Lock
+}
@@ -0,0 +1,281 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// MemoryStore 进程内的键值存储、锁和限流器实现
+//
+// 只在单个进程内有效，适合单机部署和测试。
+type MemoryStore struct {
+	mu       sync.Mutex
+	locks    map[string]memoryLockEntry
+	counters map[string]memoryCounter
+	items    map[string]memoryItem
+	now      func() time.Time
+}
+
+type memoryItem struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+type memoryLockEntry struct {
+	owner     string
+	expiresAt time.Time
+}
+
+type memoryCounter struct {
+	hits      int
+	expiresAt time.Time
+}
+
+var (
+	_ Store        = (*MemoryStore)(nil)
+	_ LockProvider = (*MemoryStore)(nil)
+	_ RateLimiter  = (*MemoryStore)(nil)
+)
+
+// NewMemoryStore 创建内存存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		locks:    make(map[string]memoryLockEntry),
+		counters: make(map[string]memoryCounter),
+		items:    make(map[string]memoryItem),
+		now:      time.Now,
+	}
+}
+
+// SetClock 设置计算过期时间使用的时钟，nil 恢复为 time.Now，用于测试
+func (s *MemoryStore) SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	s.mu.Lock()
+	s.now = now
+	s.mu.Unlock()
+}
+
+// item 获取未过期的缓存项，调用方持有锁
+func (s *MemoryStore) item(key string) (memoryItem, bool) {
+	item, ok := s.items[key]
+	if ok && !item.expiresAt.IsZero() && !item.expiresAt.After(s.now()) {
+		delete(s.items, key)
+		return memoryItem{}, false
+	}
+	return item, ok
+}
+
+// Get 获取缓存值
+func (s *MemoryStore) Get(ctx context.Context, key string) (interface{}, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.item(key)
+	return item.value, ok, nil
+}
+
+// Put 写入缓存
+func (s *MemoryStore) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item := memoryItem{value: value}
+	if ttl > 0 {
+		item.expiresAt = s.now().Add(ttl)
+	}
+	s.items[key] = item
+	return nil
+}
+
+// Forever 永久写入缓存
+func (s *MemoryStore) Forever(ctx context.Context, key string, value interface{}) error {
+	return s.Put(ctx, key, value, 0)
+}
+
+// Increment 原子递增整数值
+func (s *MemoryStore) Increment(ctx context.Context, key string, by int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, _ := s.item(key)
+	current, _ := item.value.(int64)
+	item.value = current + by
+	s.items[key] = item
+	return current + by, nil
+}
+
+// Forget 删除缓存
+func (s *MemoryStore) Forget(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.item(key)
+	delete(s.items, key)
+	return ok, nil
+}
+
+// Flush 清空缓存、锁和限流计数
+func (s *MemoryStore) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = make(map[string]memoryItem)
+	s.locks = make(map[string]memoryLockEntry)
+	s.counters = make(map[string]memoryCounter)
+	return nil
+}
+
+// Lock 创建锁
+func (s *MemoryStore) Lock(name string, ttl time.Duration, owner ...string) Lock {
+	lock := &memoryLock{store: s, name: name, ttl: ttl}
+	if len(owner) > 0 && owner[0] != "" {
+		lock.owner = owner[0]
+	} else {
+		lock.owner = randomOwner()
+	}
+	return lock
+}
+
+// RestoreLock 使用已知的所有者恢复锁
+func (s *MemoryStore) RestoreLock(name, owner string) Lock {
+	return s.Lock(name, 0, owner)
+}
+
+// memoryLock 内存锁
+type memoryLock struct {
+	store *MemoryStore
+	name  string
+	ttl   time.Duration
+	owner string
+}
+
+func (l *memoryLock) Get(ctx context.Context) (bool, error) {
+	s := l.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if entry, ok := s.locks[l.name]; ok && (entry.expiresAt.IsZero() || entry.expiresAt.After(now)) {
+		return false, nil
+	}
+	entry := memoryLockEntry{owner: l.owner}
+	if l.ttl > 0 {
+		entry.expiresAt = now.Add(l.ttl)
+	}
+	s.locks[l.name] = entry
+	return true, nil
+}
+
+func (l *memoryLock) Block(ctx context.Context, wait time.Duration) error {
+	deadline := time.Now().Add(wait)
+	for {
+		if ok, err := l.Get(ctx); err != nil || ok {
+			return err
+		}
+		if !time.Now().Before(deadline) {
+			return ErrLockTimeout
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+func (l *memoryLock) Release(ctx context.Context) (bool, error) {
+	s := l.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.locks[l.name]; ok && entry.owner == l.owner {
+		delete(s.locks, l.name)
+		return true, nil
+	}
+	return false, nil
+}
+
+func (l *memoryLock) ForceRelease(ctx context.Context) error {
+	s := l.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.locks, l.name)
+	return nil
+}
+
+func (l *memoryLock) Owner() string {
+	return l.owner
+}
+
+// counter 获取未过期的计数，调用方持有锁
+func (s *MemoryStore) counter(key string) memoryCounter {
+	counter, ok := s.counters[key]
+	if ok && !counter.expiresAt.After(s.now()) {
+		delete(s.counters, key)
+		return memoryCounter{}
+	}
+	return counter
+}
+
+// TooManyAttempts 是否已达到最大尝试次数
+func (s *MemoryStore) TooManyAttempts(ctx context.Context, key string, maxAttempts int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counter(key).hits >= maxAttempts, nil
+}
+
+// Hit 增加尝试次数
+func (s *MemoryStore) Hit(ctx context.Context, key string, decay time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counter := s.counter(key)
+	if counter.hits == 0 {
+		counter.expiresAt = s.now().Add(decay)
+	}
+	counter.hits++
+	s.counters[key] = counter
+	return counter.hits, nil
+}
+
+// Attempts 当前尝试次数
+func (s *MemoryStore) Attempts(ctx context.Context, key string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counter(key).hits, nil
+}
+
+// RemainingAttempts 剩余尝试次数
+func (s *MemoryStore) RemainingAttempts(ctx context.Context, key string, maxAttempts int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if remaining := maxAttempts - s.counter(key).hits; remaining > 0 {
+		return remaining, nil
+	}
+	return 0, nil
+}
+
+// AvailableIn 距离窗口重置的时间
+func (s *MemoryStore) AvailableIn(ctx context.Context, key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counter := s.counter(key)
+	if counter.hits == 0 {
+		return 0, nil
+	}
+	return counter.expiresAt.Sub(s.now()), nil
+}
+
+// Clear 清除计数
+func (s *MemoryStore) Clear(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.counters, key)
+	return nil
+}
+
+// randomOwner 生成随机的锁所有者
+func randomOwner() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
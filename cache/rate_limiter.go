@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimiter 限流器接口
+//
+// 对应 Laravel 的 Illuminate\Cache\RateLimiter，使用固定时间窗口计数。
+type RateLimiter interface {
+	// TooManyAttempts 是否已达到最大尝试次数
+	TooManyAttempts(ctx context.Context, key string, maxAttempts int) (bool, error)
+
+	// Hit 增加尝试次数，第一次尝试时开始 decay 时长的窗口，返回当前次数
+	Hit(ctx context.Context, key string, decay time.Duration) (int, error)
+
+	// Attempts 当前尝试次数
+	Attempts(ctx context.Context, key string) (int, error)
+
+	// RemainingAttempts 剩余尝试次数
+	RemainingAttempts(ctx context.Context, key string, maxAttempts int) (int, error)
+
+	// AvailableIn 距离窗口重置的时间
+	AvailableIn(ctx context.Context, key string) (time.Duration, error)
+
+	// Clear 清除计数
+	Clear(ctx context.Context, key string) error
+}
+
+// Limit 限流规则
+//
+// 使用示例：
+//
+//	limit := cache.PerMinute(60).By(userID)
+//	limit := cache.PerMinutes(5, 100)
+//	limit := cache.PerHour(1000).By("tenant:" + tenantID)
+type Limit struct {
+	// Key 限流键
+	Key string
+
+	// MaxAttempts 窗口内的最大次数
+	MaxAttempts int
+
+	// Decay 窗口时长
+	Decay time.Duration
+}
+
+// PerSecond 每秒 maxAttempts 次
+func PerSecond(maxAttempts int) Limit {
+	return Limit{MaxAttempts: maxAttempts, Decay: time.Second}
+}
+
+// PerMinute 每分钟 maxAttempts 次
+func PerMinute(maxAttempts int) Limit {
+	return Limit{MaxAttempts: maxAttempts, Decay: time.Minute}
+}
+
+// PerMinutes 每 minutes 分钟 maxAttempts 次
+func PerMinutes(minutes, maxAttempts int) Limit {
+	return Limit{MaxAttempts: maxAttempts, Decay: time.Duration(minutes) * time.Minute}
+}
+
+// PerHour 每小时 maxAttempts 次
+func PerHour(maxAttempts int) Limit {
+	return Limit{MaxAttempts: maxAttempts, Decay: time.Hour}
+}
+
+// PerDay 每天 maxAttempts 次
+func PerDay(maxAttempts int) Limit {
+	return Limit{MaxAttempts: maxAttempts, Decay: 24 * time.Hour}
+}
+
+// By 设置限流键
+func (l Limit) By(key string) Limit {
+	l.Key = key
+	return l
+}
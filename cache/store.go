@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store 键值缓存存储接口
+//
+// 对应 Laravel 的 Illuminate\Contracts\Cache\Store。
+type Store interface {
+	// Get 获取缓存值，不存在或已过期时 ok 为 false
+	Get(ctx context.Context, key string) (value interface{}, ok bool, err error)
+
+	// Put 写入缓存，ttl 为 0 表示永不过期
+	Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+
+	// Forever 永久写入缓存
+	Forever(ctx context.Context, key string, value interface{}) error
+
+	// Increment 原子递增整数值，不存在时从 0 开始，返回新值
+	Increment(ctx context.Context, key string, by int64) (int64, error)
+
+	// Forget 删除缓存
+	Forget(ctx context.Context, key string) (bool, error)
+
+	// Flush 清空缓存
+	Flush(ctx context.Context) error
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// snapshotHeader 生成的接口快照文件的首行，类型检查时跳过带有该首行的文件
+const snapshotHeader = "// Code generated by laraveldoc-gen contracts; DO NOT EDIT."
+
+// contractsOptions contracts 子命令的参数
+type contractsOptions struct {
+	dir    string
+	output string
+}
+
+// runContracts 执行 contracts 子命令
+func runContracts(args []string) error {
+	opts := contractsOptions{}
+	flags := flag.NewFlagSet("contracts", flag.ContinueOnError)
+	flags.StringVar(&opts.dir, "dir", ".", "directory of the contracts/vN package")
+	flags.StringVar(&opts.output, "output", "snapshot.go", "output file, relative to -dir")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: laraveldoc-gen contracts [-dir contracts/vN] [-output snapshot.go]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	source, err := generateSnapshot(opts.dir)
+	if err != nil {
+		return err
+	}
+	output := opts.output
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(opts.dir, output)
+	}
+	return os.WriteFile(output, source, 0o644)
+}
+
+// generateSnapshot 类型检查 contracts/vN 包，为每个别名生成方法集快照和双向的编译期断言
+//
+// 别名指向的接口增加、删除方法或修改签名时，快照与别名不再互相满足，包无法编译。
+func generateSnapshot(dir string) ([]byte, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if len(file.Comments) > 0 && file.Comments[0].Pos() < file.Package &&
+			strings.HasPrefix(file.Comments[0].List[0].Text, snapshotHeader) {
+			continue
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(files[0].Name.Name, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &snapshotWriter{pkg: pkg, imports: make(map[string]string), used: make(map[string]bool)}
+	scope := pkg.Scope()
+	var names []string
+	for _, name := range scope.Names() {
+		if obj, ok := scope.Lookup(name).(*types.TypeName); ok && obj.IsAlias() && obj.Exported() {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no exported type aliases to snapshot")
+	}
+	for _, name := range names {
+		if err := s.snapshot(name, scope.Lookup(name).Type()); err != nil {
+			return nil, err
+		}
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return s.source(filepath.Base(abs))
+}
+
+// snapshotWriter 快照文件的生成状态
+type snapshotWriter struct {
+	pkg     *types.Package
+	imports map[string]string
+	used    map[string]bool
+	types   bytes.Buffer
+	asserts bytes.Buffer
+}
+
+// qualifier 其他包的类型加上导入名，同名的包使用别名
+func (s *snapshotWriter) qualifier(pkg *types.Package) string {
+	if pkg == s.pkg {
+		return ""
+	}
+	if alias, ok := s.imports[pkg.Path()]; ok {
+		return alias
+	}
+	alias := pkg.Name()
+	for i := 2; s.used[alias]; i++ {
+		alias = pkg.Name() + strconv.Itoa(i)
+	}
+	s.used[alias] = true
+	s.imports[pkg.Path()] = alias
+	return alias
+}
+
+// snapshot 生成一个别名的快照类型和断言
+func (s *snapshotWriter) snapshot(name string, typ types.Type) error {
+	snapshot := "snapshot" + name
+	switch underlying := typ.Underlying().(type) {
+	case *types.Interface:
+		fmt.Fprintf(&s.types, "\t%s interface {\n", snapshot)
+		for i := 0; i < underlying.NumMethods(); i++ {
+			method := underlying.Method(i)
+			if !method.Exported() {
+				return fmt.Errorf("%s has unexported method %s and cannot be snapshotted", name, method.Name())
+			}
+			var sig bytes.Buffer
+			types.WriteSignature(&sig, method.Type().(*types.Signature), s.qualifier)
+			fmt.Fprintf(&s.types, "\t\t%s%s\n", method.Name(), sig.String())
+		}
+		s.types.WriteString("\t}\n\n")
+	case *types.Signature:
+		fmt.Fprintf(&s.types, "\t%s %s\n\n", snapshot, types.TypeString(underlying, s.qualifier))
+	default:
+		return fmt.Errorf("%s is a %T, only interface and func aliases can be snapshotted", name, underlying)
+	}
+	fmt.Fprintf(&s.asserts, "\t_ %s = %s(nil)\n\t_ %s = %s(nil)\n", name, snapshot, snapshot, name)
+	return nil
+}
+
+// source 格式化后的快照文件
+func (s *snapshotWriter) source(version string) ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\npackage %s\n\n", snapshotHeader, s.pkg.Name())
+	paths := make([]string, 0, len(s.imports))
+	for importPath := range s.imports {
+		paths = append(paths, importPath)
+	}
+	// 标准库在前，其他包在后，两组之间空一行
+	sort.Slice(paths, func(i, j int) bool {
+		if std := isStdlib(paths[i]); std != isStdlib(paths[j]) {
+			return std
+		}
+		return paths[i] < paths[j]
+	})
+	out.WriteString("import (\n")
+	for i, importPath := range paths {
+		if i > 0 && isStdlib(importPath) != isStdlib(paths[i-1]) {
+			out.WriteString("\n")
+		}
+		if alias := s.imports[importPath]; alias != path.Base(importPath) {
+			fmt.Fprintf(&out, "\t%s %q\n", alias, importPath)
+		} else {
+			fmt.Fprintf(&out, "\t%q\n", importPath)
+		}
+	}
+	out.WriteString(")\n\n")
+	fmt.Fprintf(&out, "// %s 发布时各别名指向的接口的方法集快照\n", version)
+	out.WriteString("//\n// 别名指向的接口增加、删除方法或修改签名时下面的断言无法编译：\n")
+	out.WriteString("// 不兼容的变更应发布新版本并登记在 contracts.Changes 中，或改为新增可选接口。\n")
+	out.WriteString("type (\n")
+	out.Write(s.types.Bytes())
+	out.WriteString(")\n\n")
+	out.WriteString("// 快照与别名互相满足，方法集完全一致\n")
+	out.WriteString("var (\n")
+	out.Write(s.asserts.Bytes())
+	out.WriteString(")\n")
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, out.Bytes())
+	}
+	return formatted, nil
+}
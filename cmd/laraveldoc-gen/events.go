@@ -0,0 +1,393 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// eventsHeader 生成的清单文件的首行，扫描时跳过带有该首行的文件
+const eventsHeader = "// Code generated by laraveldoc-gen events; DO NOT EDIT."
+
+// eventsOptions events 子命令的参数
+type eventsOptions struct {
+	output string
+	pkg    string
+}
+
+// listenerHandler 监听器类型的 Handle 方法
+type listenerHandler struct {
+	pkgPath      string
+	listener     string
+	eventPath    string
+	eventType    string
+	pointer      bool
+	withContext  bool
+	returnsError bool
+}
+
+// runEvents 执行 events 子命令
+func runEvents(args []string) error {
+	opts := eventsOptions{}
+	flags := flag.NewFlagSet("events", flag.ContinueOnError)
+	flags.StringVar(&opts.output, "output", "", "output file (default: stdout)")
+	flags.StringVar(&opts.pkg, "package", "", "package name of the generated file (default: the package in the output directory)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: laraveldoc-gen events [-output file] [-package name] [packages]")
+		fmt.Fprintln(flags.Output(), `Packages are listener directories, "./..." walks recursively (default: ./...).`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	handlers, err := scanListeners(patterns)
+	if err != nil {
+		return err
+	}
+	for _, h := range handlers {
+		fmt.Fprintf(os.Stderr, "%s.%s listens for %s\n", h.pkgPath, h.listener, h.eventName())
+	}
+
+	outputPath := ""
+	if opts.output != "" {
+		dir := filepath.Dir(opts.output)
+		if opts.pkg == "" {
+			opts.pkg = packageNameIn(dir)
+		}
+		if importPath, err := packageImportPath(dir); err == nil {
+			outputPath = importPath
+		}
+	}
+	if opts.pkg == "" {
+		opts.pkg = "main"
+	}
+	source, err := generateManifest(opts.pkg, outputPath, handlers)
+	if err != nil {
+		return err
+	}
+	if opts.output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.output), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(opts.output, source, 0o644)
+}
+
+// eventName 事件类型的显示名称，与运行时的 application.EventNameOf 一致
+func (h listenerHandler) eventName() string {
+	name := path.Base(h.eventPath) + "." + h.eventType
+	if h.pointer {
+		name = "*" + name
+	}
+	return name
+}
+
+// packageNameIn 目录中已有 Go 文件的包名，没有时取目录名
+func packageNameIn(dir string) string {
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+		if err == nil {
+			return file.Name.Name
+		}
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	return strings.NewReplacer("-", "_", ".", "_").Replace(filepath.Base(abs))
+}
+
+// scanListeners 扫描目录中的监听器类型，按包、监听器和事件排序
+func scanListeners(patterns []string) ([]listenerHandler, error) {
+	files, err := goFiles(patterns)
+	if err != nil {
+		return nil, err
+	}
+	byDir := make(map[string][]string)
+	var dirs []string
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		dir := filepath.Dir(file)
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], file)
+	}
+
+	var handlers []listenerHandler
+	for _, dir := range dirs {
+		found, err := scanPackage(dir, byDir[dir])
+		if err != nil {
+			return nil, err
+		}
+		handlers = append(handlers, found...)
+	}
+	sort.SliceStable(handlers, func(i, j int) bool {
+		a, b := handlers[i], handlers[j]
+		if a.pkgPath != b.pkgPath {
+			return a.pkgPath < b.pkgPath
+		}
+		if a.listener != b.listener {
+			return a.listener < b.listener
+		}
+		return a.eventName() < b.eventName()
+	})
+	return handlers, nil
+}
+
+// scanPackage 扫描一个包中带有 Handle(event) 方法的导出类型
+func scanPackage(dir string, filenames []string) ([]listenerHandler, error) {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, filename := range filenames {
+		file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if generatedManifest(file) {
+			continue
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	pkgPath, err := packageImportPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[string]bool)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+				for _, spec := range gen.Specs {
+					types[spec.(*ast.TypeSpec).Name.Name] = true
+				}
+			}
+		}
+	}
+
+	var handlers []listenerHandler
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Name.Name != "Handle" {
+				continue
+			}
+			h, ok := listenerMethod(file, fn, types)
+			if !ok {
+				continue
+			}
+			h.pkgPath = pkgPath
+			if h.eventPath == "" {
+				h.eventPath = pkgPath
+			}
+			handlers = append(handlers, h)
+		}
+	}
+	return handlers, nil
+}
+
+// generatedManifest 文件是否为 events 生成的清单
+func generatedManifest(file *ast.File) bool {
+	return len(file.Comments) > 0 && file.Comments[0].Pos() < file.Package &&
+		strings.HasPrefix(file.Comments[0].List[0].Text, eventsHeader)
+}
+
+// listenerMethod 解析 Handle(event E) [error] 或 Handle(ctx context.Context, event E) [error]
+func listenerMethod(file *ast.File, fn *ast.FuncDecl, types map[string]bool) (listenerHandler, bool) {
+	var h listenerHandler
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	ident, ok := recv.(*ast.Ident)
+	if !ok || !ident.IsExported() {
+		return h, false
+	}
+	h.listener = ident.Name
+
+	var params []ast.Expr
+	for _, field := range fieldList(fn.Type.Params) {
+		for range max(len(field.Names), 1) {
+			params = append(params, field.Type)
+		}
+	}
+	switch len(params) {
+	case 1:
+	case 2:
+		if !isSelector(file, params[0], "context", "Context") {
+			return h, false
+		}
+		h.withContext = true
+	default:
+		return h, false
+	}
+
+	results := fieldList(fn.Type.Results)
+	switch {
+	case len(results) == 0:
+	case len(results) == 1 && len(results[0].Names) <= 1:
+		if id, ok := results[0].Type.(*ast.Ident); !ok || id.Name != "error" {
+			return h, false
+		}
+		h.returnsError = true
+	default:
+		return h, false
+	}
+
+	event := params[len(params)-1]
+	if star, ok := event.(*ast.StarExpr); ok {
+		h.pointer = true
+		event = star.X
+	}
+	switch t := event.(type) {
+	case *ast.Ident:
+		// 同一个包中声明的导出事件类型
+		if !types[t.Name] || !t.IsExported() {
+			return h, false
+		}
+		h.eventType = t.Name
+	case *ast.SelectorExpr:
+		x, ok := t.X.(*ast.Ident)
+		if !ok {
+			return h, false
+		}
+		spec := findImport(file, x.Name)
+		if spec == nil {
+			return h, false
+		}
+		h.eventPath, _ = strconv.Unquote(spec.Path.Value)
+		h.eventType = t.Sel.Name
+		if h.eventPath == "context" {
+			return h, false
+		}
+	default:
+		return h, false
+	}
+	return h, true
+}
+
+// isSelector 表达式是否为导入路径 importPath 中的 name
+func isSelector(file *ast.File, expr ast.Expr, importPath, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	spec := findImport(file, x.Name)
+	if spec == nil {
+		return false
+	}
+	p, _ := strconv.Unquote(spec.Path.Value)
+	return p == importPath
+}
+
+// generateManifest 生成在 init 中调用 application.DiscoverListener 的清单
+func generateManifest(pkg, outputPath string, handlers []listenerHandler) ([]byte, error) {
+	const (
+		applicationPath = "github.com/cnote0/laraveldoc/application"
+		containerPath   = "github.com/cnote0/laraveldoc/container"
+	)
+	imports := map[string]string{"context": "context", applicationPath: "application", containerPath: "container"}
+	used := map[string]bool{"context": true, "application": true, "container": true, "c": true, "ctx": true, "event": true}
+	qualifier := func(importPath string) string {
+		if importPath == outputPath {
+			return ""
+		}
+		alias, ok := imports[importPath]
+		if !ok {
+			base := strings.NewReplacer("-", "_", ".", "_").Replace(path.Base(importPath))
+			alias = base
+			for i := 2; used[alias]; i++ {
+				alias = base + strconv.Itoa(i)
+			}
+			used[alias] = true
+			imports[importPath] = alias
+		}
+		return alias + "."
+	}
+
+	var body bytes.Buffer
+	for _, h := range handlers {
+		eventType := qualifier(h.eventPath) + h.eventType
+		if h.pointer {
+			eventType = "*" + eventType
+		}
+		args := "event"
+		if h.withContext {
+			args = "ctx, event"
+		}
+		call := fmt.Sprintf("application.MakeListener[%s%s](c).Handle(%s)", qualifier(h.pkgPath), h.listener, args)
+		fmt.Fprintf(&body, "application.DiscoverListener(%q, %q, func(ctx context.Context, c container.Container, event %s) error {\n", h.pkgPath, h.listener, eventType)
+		if h.returnsError {
+			fmt.Fprintf(&body, "return %s\n", call)
+		} else {
+			fmt.Fprintf(&body, "%s\nreturn nil\n", call)
+		}
+		body.WriteString("})\n")
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\npackage %s\n\n", eventsHeader, pkg)
+	if len(handlers) > 0 {
+		paths := make([]string, 0, len(imports))
+		for importPath := range imports {
+			paths = append(paths, importPath)
+		}
+		// 标准库在前，其他包在后，两组之间空一行
+		sort.Slice(paths, func(i, j int) bool {
+			if std := isStdlib(paths[i]); std != isStdlib(paths[j]) {
+				return std
+			}
+			return paths[i] < paths[j]
+		})
+		out.WriteString("import (\n")
+		for i, importPath := range paths {
+			if i > 0 && isStdlib(importPath) != isStdlib(paths[i-1]) {
+				out.WriteString("\n")
+			}
+			if alias := imports[importPath]; alias != path.Base(importPath) {
+				fmt.Fprintf(&out, "\t%s %q\n", alias, importPath)
+			} else {
+				fmt.Fprintf(&out, "\t%q\n", importPath)
+			}
+		}
+		out.WriteString(")\n\n")
+		out.WriteString("// init 登记发现的监听器，由 application.EventServiceProvider.DiscoverEventsWithin 注册到事件分发器\n")
+		fmt.Fprintf(&out, "func init() {\n%s}\n", body.Bytes())
+	}
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, out.Bytes())
+	}
+	return formatted, nil
+}
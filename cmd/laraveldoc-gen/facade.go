@@ -0,0 +1,759 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 门面的生成方式
+const (
+	// modeStatic 嵌入 facade.StaticFacade，方法通过 CallMethod 委托
+	modeStatic = "static"
+
+	// modeContainer 每次调用时从容器解析服务，直接调用接口方法
+	modeContainer = "container"
+
+	// modeRealtime 生成实时门面代理并在 init 中注册到 facade.RegisterRealtime，直接调用接口方法
+	modeRealtime = "realtime"
+)
+
+// facadeOptions facade 子命令的参数
+type facadeOptions struct {
+	source     string
+	iface      string
+	name       string
+	accessor   string
+	pkg        string
+	mode       string
+	output     string
+	importPath string
+}
+
+// method 接口方法
+type method struct {
+	name     string
+	params   []*ast.Field
+	results  []*ast.Field
+	variadic bool
+	file     *ast.File
+}
+
+// param 生成代码中的参数
+type param struct {
+	name string
+	typ  string
+}
+
+// runFacade 执行 facade 子命令
+func runFacade(args []string) error {
+	opts := facadeOptions{}
+	flags := flag.NewFlagSet("facade", flag.ContinueOnError)
+	flags.StringVar(&opts.source, "source", ".", "directory of the package declaring the service interface")
+	flags.StringVar(&opts.iface, "interface", "", "name of the service interface (required)")
+	flags.StringVar(&opts.name, "name", "", "name of the generated facade type (default: the interface name)")
+	flags.StringVar(&opts.accessor, "accessor", "", "container binding of the service, used by -mode=container (default: lowercased interface name)")
+	flags.StringVar(&opts.pkg, "package", "facades", "package name of the generated file")
+	flags.StringVar(&opts.mode, "mode", modeStatic, `"static" delegates through facade.StaticFacade.CallMethod, "container" resolves the service from the container on every call, "realtime" generates a proxy registered with facade.RegisterRealtime`)
+	flags.StringVar(&opts.output, "output", "", "output file (default: stdout)")
+	flags.StringVar(&opts.importPath, "import", "", "import path of the source package (default: derived from go.mod)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if opts.iface == "" {
+		flags.Usage()
+		return errors.New("-interface is required")
+	}
+	if opts.name == "" {
+		opts.name = opts.iface
+	}
+	if opts.accessor == "" {
+		opts.accessor = strings.ToLower(opts.iface)
+	}
+	if opts.mode != modeStatic && opts.mode != modeContainer && opts.mode != modeRealtime {
+		return fmt.Errorf("unknown mode %q, expected %q, %q or %q", opts.mode, modeStatic, modeContainer, modeRealtime)
+	}
+
+	source, err := generateFacade(opts)
+	if err != nil {
+		return err
+	}
+	if opts.output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.output), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(opts.output, source, 0o644)
+}
+
+// facadeGenerator 生成单个门面文件
+type facadeGenerator struct {
+	opts      facadeOptions
+	files     []*ast.File
+	srcPkg    string
+	qualifier string
+	srcImport string
+	imports   map[string]string
+	buf       bytes.Buffer
+}
+
+// generateFacade 解析源码包中的接口并生成门面源码
+func generateFacade(opts facadeOptions) ([]byte, error) {
+	g := &facadeGenerator{opts: opts, imports: make(map[string]string)}
+	if err := g.parse(); err != nil {
+		return nil, err
+	}
+	methods, err := g.methods(opts.iface, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("interface %s has no methods", opts.iface)
+	}
+	sort.SliceStable(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+
+	if g.qualifier != "" {
+		importPath := opts.importPath
+		if importPath == "" {
+			if importPath, err = packageImportPath(opts.source); err != nil {
+				return nil, err
+			}
+		}
+		g.srcImport = importPath
+	}
+
+	switch opts.mode {
+	case modeStatic:
+		err = g.static(methods)
+	case modeContainer:
+		err = g.container(methods)
+	case modeRealtime:
+		err = g.realtime(methods)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by laraveldoc-gen facade; DO NOT EDIT.\n\npackage %s\n\n", opts.pkg)
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for importPath := range g.imports {
+			paths = append(paths, importPath)
+		}
+		// 标准库在前，其他包在后，两组之间空一行
+		sort.Slice(paths, func(i, j int) bool {
+			if std := isStdlib(paths[i]); std != isStdlib(paths[j]) {
+				return std
+			}
+			return paths[i] < paths[j]
+		})
+		out.WriteString("import (\n")
+		for i, importPath := range paths {
+			if i > 0 && isStdlib(importPath) != isStdlib(paths[i-1]) {
+				out.WriteString("\n")
+			}
+			if alias := g.imports[importPath]; alias != path.Base(importPath) {
+				fmt.Fprintf(&out, "\t%s %q\n", alias, importPath)
+			} else {
+				fmt.Fprintf(&out, "\t%q\n", importPath)
+			}
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.buf.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, out.Bytes())
+	}
+	return formatted, nil
+}
+
+// parse 解析源码包，测试文件除外
+func (g *facadeGenerator) parse() error {
+	entries, err := os.ReadDir(g.opts.source)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(g.opts.source, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		g.files = append(g.files, file)
+		g.srcPkg = file.Name.Name
+	}
+	if len(g.files) == 0 {
+		return fmt.Errorf("no Go files in %s", g.opts.source)
+	}
+
+	sourceDir, err := filepath.Abs(g.opts.source)
+	if err != nil {
+		return err
+	}
+	outputDir := ""
+	if g.opts.output != "" {
+		if outputDir, err = filepath.Abs(filepath.Dir(g.opts.output)); err != nil {
+			return err
+		}
+	}
+	if outputDir != sourceDir || g.opts.pkg != g.srcPkg {
+		g.qualifier = g.srcPkg
+	}
+	return nil
+}
+
+// methods 收集接口的方法，同一个包中嵌入的接口会展开
+func (g *facadeGenerator) methods(name string, seen map[string]bool) ([]method, error) {
+	if seen[name] {
+		return nil, nil
+	}
+	seen[name] = true
+	iface, file := g.lookupInterface(name)
+	if iface == nil {
+		return nil, fmt.Errorf("interface %s not found in %s", name, g.opts.source)
+	}
+
+	var methods []method
+	for _, field := range iface.Methods.List {
+		switch typ := field.Type.(type) {
+		case *ast.FuncType:
+			for _, ident := range field.Names {
+				m := method{name: ident.Name, params: fieldList(typ.Params), results: fieldList(typ.Results), file: file}
+				if n := len(m.params); n > 0 {
+					_, m.variadic = m.params[n-1].Type.(*ast.Ellipsis)
+				}
+				if ident.IsExported() {
+					methods = append(methods, m)
+				}
+			}
+		case *ast.Ident:
+			embedded, err := g.methods(typ.Name, seen)
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, embedded...)
+		default:
+			return nil, fmt.Errorf("interface %s embeds %s, only interfaces from the same package are supported; declare its methods explicitly", name, types.ExprString(field.Type))
+		}
+	}
+	return methods, nil
+}
+
+// lookupInterface 在源码包中查找接口声明
+func (g *facadeGenerator) lookupInterface(name string) (*ast.InterfaceType, *ast.File) {
+	for _, file := range g.files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.Name != name || ts.TypeParams != nil {
+					continue
+				}
+				if iface, ok := ts.Type.(*ast.InterfaceType); ok {
+					return iface, file
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// static 生成嵌入 facade.StaticFacade 的门面
+func (g *facadeGenerator) static(methods []method) error {
+	g.addImport("github.com/cnote0/laraveldoc/facade", "facade")
+	name, service := g.opts.name, g.opts.iface
+	if g.qualifier != "" {
+		service = g.qualifier + "." + service
+	}
+	fmt.Fprintf(&g.buf, "// %s %s 的门面，方法通过 StaticFacade.CallMethod 委托给底层服务\n", name, service)
+	fmt.Fprintf(&g.buf, "type %s struct {\n\tfacade.StaticFacade\n}\n\n", name)
+	fmt.Fprintf(&g.buf, "// New%s 创建门面\n", name)
+	fmt.Fprintf(&g.buf, "func New%[1]s(f facade.StaticFacade) *%[1]s {\n\treturn &%[1]s{StaticFacade: f}\n}\n", name)
+
+	defined := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		defined[m.name] = true
+	}
+	for _, m := range methods {
+		params, results, err := g.signature(m)
+		if err != nil {
+			return err
+		}
+		// 第一个参数为 context.Context 的方法以该 ctx 解析底层服务，作用域和 MockContext 的替换才能生效
+		if len(params) > 0 && params[0].typ == "context.Context" {
+			g.staticMethod(m.name, m.name, "CallMethodWithContext", params[0].name+", ", params, results, m.variadic)
+			continue
+		}
+		g.staticMethod(m.name, m.name, "CallMethod", "", params, results, m.variadic)
+
+		if defined[m.name+"WithContext"] {
+			continue
+		}
+		g.addImport("context", "context")
+		withContext := []param{{name: "ctx", typ: "context.Context"}}
+		for i, p := range params {
+			if p.name == "ctx" {
+				p.name = "arg" + strconv.Itoa(i)
+			}
+			withContext = append(withContext, p)
+		}
+		g.staticMethod(m.name+"WithContext", m.name, "CallMethodWithContext", "ctx, ", withContext, results, m.variadic)
+	}
+	return nil
+}
+
+// staticMethod 生成一个通过 CallMethod 或 CallMethodWithContext 委托的方法
+//
+// ctxArg 不为空时 params[0] 为 context.Context，作为 CallMethodWithContext 的 ctx 传入，不放入参数列表。
+func (g *facadeGenerator) staticMethod(name, target, call, ctxArg string, params, results []param, variadic bool) {
+	args := params
+	if ctxArg != "" {
+		args = params[1:]
+	}
+	names := make([]string, len(args))
+	for i, p := range args {
+		names[i] = p.name
+	}
+
+	if name != target {
+		fmt.Fprintf(&g.buf, "\n// %s 带上下文调用 %s\n", name, target)
+	} else {
+		fmt.Fprintf(&g.buf, "\n// %s 调用底层服务的 %s\n", name, target)
+	}
+	fmt.Fprintf(&g.buf, "func (f *%s) %s(%s)%s {\n", g.opts.name, name, paramList(params, variadic), resultList(results))
+	callExpr := fmt.Sprintf("f.%s(%s%q, []interface{}{%s})", call, ctxArg, target, strings.Join(names, ", "))
+
+	returnsError := len(results) > 0 && results[len(results)-1].typ == "error"
+	switch {
+	case len(results) == 0:
+		fmt.Fprintf(&g.buf, "\tif _, err := %s; err != nil {\n\t\tpanic(err)\n\t}\n}\n", callExpr)
+		return
+	case returnsError:
+		fmt.Fprintf(&g.buf, "\tresults, err := %s\n\tif err != nil {\n", callExpr)
+		zeros := make([]string, len(results))
+		for i, r := range results[:len(results)-1] {
+			fmt.Fprintf(&g.buf, "\t\tvar %s %s\n", r.name, r.typ)
+			zeros[i] = r.name
+		}
+		zeros[len(results)-1] = "err"
+		fmt.Fprintf(&g.buf, "\t\treturn %s\n\t}\n", strings.Join(zeros, ", "))
+	default:
+		fmt.Fprintf(&g.buf, "\tresults, err := %s\n\tif err != nil {\n\t\tpanic(err)\n\t}\n", callExpr)
+	}
+
+	values := make([]string, len(results))
+	for i, r := range results {
+		fmt.Fprintf(&g.buf, "\t%s, _ := results[%d].(%s)\n", r.name, i, r.typ)
+		values[i] = r.name
+	}
+	fmt.Fprintf(&g.buf, "\treturn %s\n}\n", strings.Join(values, ", "))
+}
+
+// container 生成每次调用时从容器解析服务的门面
+func (g *facadeGenerator) container(methods []method) error {
+	g.addImport("github.com/cnote0/laraveldoc/container", "container")
+	name, service := g.opts.name, g.serviceType()
+	fmt.Fprintf(&g.buf, "// %s %s 的门面，每次调用时从容器解析 %q\n", name, service, g.opts.accessor)
+	fmt.Fprintf(&g.buf, "type %s struct {\n\tcontainer container.Container\n}\n\n", name)
+	fmt.Fprintf(&g.buf, "// New%s 创建门面\n", name)
+	fmt.Fprintf(&g.buf, "func New%[1]s(c container.Container) *%[1]s {\n\treturn &%[1]s{container: c}\n}\n\n", name)
+	fmt.Fprintf(&g.buf, "// Root 从容器解析底层服务，未绑定或类型不符时 panic\n")
+	fmt.Fprintf(&g.buf, "func (f *%s) Root() %s {\n\treturn f.container.MustMake(%q).(%s)\n}\n", name, service, g.opts.accessor, service)
+	if g.hasContextMethod(methods) {
+		g.addImport("context", "context")
+		fmt.Fprintf(&g.buf, "\n// RootContext 以 ctx 从容器解析底层服务，未绑定或类型不符时 panic\n")
+		fmt.Fprintf(&g.buf, "func (f *%s) RootContext(ctx context.Context) %s {\n\troot, err := container.MakeWithContext(ctx, f.container, %q)\n\tif err != nil {\n\t\tpanic(err)\n\t}\n\treturn root.(%s)\n}\n", name, service, g.opts.accessor, service)
+	}
+	return g.direct(methods)
+}
+
+// realtime 生成实时门面代理，底层服务通过嵌入的 facade.StaticFacade 解析，方法直接调用而不使用反射
+func (g *facadeGenerator) realtime(methods []method) error {
+	g.addImport("github.com/cnote0/laraveldoc/facade", "facade")
+	name, service := g.opts.name, g.serviceType()
+	fmt.Fprintf(&g.buf, "func init() {\n\tfacade.RegisterRealtime((*%s)(nil), func(f facade.StaticFacade) interface{} {\n\t\treturn New%s(f)\n\t})\n}\n\n", service, name)
+	fmt.Fprintf(&g.buf, "// %s %s 的实时门面代理，方法直接调用底层服务\n", name, service)
+	fmt.Fprintf(&g.buf, "type %s struct {\n\tfacade.StaticFacade\n}\n\n", name)
+	fmt.Fprintf(&g.buf, "// New%s 创建代理\n", name)
+	fmt.Fprintf(&g.buf, "func New%[1]s(f facade.StaticFacade) *%[1]s {\n\treturn &%[1]s{StaticFacade: f}\n}\n\n", name)
+	fmt.Fprintf(&g.buf, "// Root 获取底层服务，解析失败或类型不符时 panic\n")
+	fmt.Fprintf(&g.buf, "func (f *%s) Root() %s {\n\troot, err := f.GetFacadeRoot()\n\tif err != nil {\n\t\tpanic(err)\n\t}\n\treturn root.(%s)\n}\n", name, service, service)
+	if g.hasContextMethod(methods) {
+		g.addImport("context", "context")
+		fmt.Fprintf(&g.buf, "\n// RootContext 以 ctx 获取底层服务，从 ctx 的门面作用域开始解析，解析失败或类型不符时 panic\n")
+		fmt.Fprintf(&g.buf, "func (f *%s) RootContext(ctx context.Context) %s {\n\troot, err := facade.FacadeRootContext(ctx, f.StaticFacade)\n\tif err != nil {\n\t\tpanic(err)\n\t}\n\treturn root.(%s)\n}\n", name, service, service)
+	}
+	return g.direct(methods)
+}
+
+// direct 生成通过 f.Root() 直接调用底层服务的方法，第一个参数为 context.Context 的方法通过 f.RootContext(ctx) 解析
+func (g *facadeGenerator) direct(methods []method) error {
+	name := g.opts.name
+	for _, m := range methods {
+		params, results, err := g.signature(m)
+		if err != nil {
+			return err
+		}
+		args := make([]string, len(params))
+		for i, p := range params {
+			args[i] = p.name
+		}
+		if m.variadic {
+			args[len(args)-1] += "..."
+		}
+		fmt.Fprintf(&g.buf, "\n// %s 调用底层服务的 %s\n", m.name, m.name)
+		fmt.Fprintf(&g.buf, "func (f *%s) %s(%s)%s {\n", name, m.name, paramList(params, m.variadic), resultList(results))
+		root := "f.Root()"
+		if len(params) > 0 && params[0].typ == "context.Context" {
+			root = "f.RootContext(" + params[0].name + ")"
+		}
+		call := fmt.Sprintf("%s.%s(%s)", root, m.name, strings.Join(args, ", "))
+		if len(results) == 0 {
+			fmt.Fprintf(&g.buf, "\t%s\n}\n", call)
+		} else {
+			fmt.Fprintf(&g.buf, "\treturn %s\n}\n", call)
+		}
+	}
+	return nil
+}
+
+// hasContextMethod 是否有第一个参数为 context.Context 的方法
+func (g *facadeGenerator) hasContextMethod(methods []method) bool {
+	for _, m := range methods {
+		params, _, err := g.signature(m)
+		if err == nil && len(params) > 0 && params[0].typ == "context.Context" {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceType 生成代码中服务接口的类型名
+func (g *facadeGenerator) serviceType() string {
+	return g.qualified(g.opts.iface)
+}
+
+// qualified 源码包中的类型名，生成到其他包时加上包名并导入源码包
+func (g *facadeGenerator) qualified(name string) string {
+	if g.qualifier == "" {
+		return name
+	}
+	g.addImport(g.srcImport, g.qualifier)
+	return g.qualifier + "." + name
+}
+
+// signature 生成方法的参数和返回值，未命名或与生成代码冲突的参数重新命名
+func (g *facadeGenerator) signature(m method) ([]param, []param, error) {
+	reserved := map[string]bool{"f": true, "results": true, "err": true, "context": true, "facade": true, "container": true}
+	for _, alias := range g.imports {
+		reserved[alias] = true
+	}
+	if g.qualifier != "" {
+		reserved[g.qualifier] = true
+	}
+	for _, spec := range m.file.Imports {
+		reserved[importName(spec)] = true
+	}
+
+	var params []param
+	for _, field := range m.params {
+		typ, err := g.typeString(field.Type, m.file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s.%s: %w", g.opts.iface, m.name, err)
+		}
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			typ = "[]" + strings.TrimPrefix(typ, "...")
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{{Name: "_"}}
+		}
+		for _, ident := range names {
+			name := ident.Name
+			if name == "_" || reserved[name] || isResultName(name) {
+				name = "arg" + strconv.Itoa(len(params))
+			}
+			params = append(params, param{name: name, typ: typ})
+		}
+	}
+
+	var results []param
+	for _, field := range m.results {
+		typ, err := g.typeString(field.Type, m.file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s.%s: %w", g.opts.iface, m.name, err)
+		}
+		count := max(len(field.Names), 1)
+		for range count {
+			results = append(results, param{name: "r" + strconv.Itoa(len(results)), typ: typ})
+		}
+	}
+	return params, results, nil
+}
+
+// isResultName 是否与生成的返回值变量名 r0、r1... 冲突
+func isResultName(name string) bool {
+	if len(name) < 2 || name[0] != 'r' {
+		return false
+	}
+	_, err := strconv.Atoi(name[1:])
+	return err == nil
+}
+
+// typeString 类型表达式的源码，源码包中的类型加上包名限定，并记录用到的导入
+func (g *facadeGenerator) typeString(expr ast.Expr, file *ast.File) (string, error) {
+	var err error
+	var render func(ast.Expr) string
+	list := func(fields *ast.FieldList, sep string) string {
+		if fields == nil {
+			return ""
+		}
+		parts := make([]string, 0, len(fields.List))
+		for _, field := range fields.List {
+			typ := render(field.Type)
+			if len(field.Names) == 0 {
+				parts = append(parts, typ)
+				continue
+			}
+			names := make([]string, len(field.Names))
+			for i, ident := range field.Names {
+				names[i] = ident.Name
+			}
+			parts = append(parts, strings.Join(names, ", ")+" "+typ)
+		}
+		return strings.Join(parts, sep)
+	}
+	render = func(expr ast.Expr) string {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			if types.Universe.Lookup(e.Name) != nil || g.qualifier == "" {
+				return e.Name
+			}
+			if !e.IsExported() {
+				err = fmt.Errorf("unexported type %s cannot be used outside package %s", e.Name, g.srcPkg)
+			}
+			return g.qualified(e.Name)
+		case *ast.SelectorExpr:
+			pkg, ok := e.X.(*ast.Ident)
+			if !ok {
+				err = fmt.Errorf("unsupported type %s", types.ExprString(e))
+				return ""
+			}
+			spec := findImport(file, pkg.Name)
+			if spec == nil {
+				err = fmt.Errorf("unknown package %s in type %s", pkg.Name, types.ExprString(e))
+				return ""
+			}
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			g.addImport(importPath, pkg.Name)
+			return pkg.Name + "." + e.Sel.Name
+		case *ast.StarExpr:
+			return "*" + render(e.X)
+		case *ast.ArrayType:
+			if e.Len == nil {
+				return "[]" + render(e.Elt)
+			}
+			return "[" + render(e.Len) + "]" + render(e.Elt)
+		case *ast.Ellipsis:
+			return "..." + render(e.Elt)
+		case *ast.MapType:
+			return "map[" + render(e.Key) + "]" + render(e.Value)
+		case *ast.ChanType:
+			switch e.Dir {
+			case ast.SEND:
+				return "chan<- " + render(e.Value)
+			case ast.RECV:
+				return "<-chan " + render(e.Value)
+			}
+			return "chan " + render(e.Value)
+		case *ast.FuncType:
+			s := "func(" + list(e.Params, ", ") + ")"
+			if e.Results != nil && len(e.Results.List) > 0 {
+				results := list(e.Results, ", ")
+				if len(e.Results.List) > 1 || len(e.Results.List[0].Names) > 0 {
+					results = "(" + results + ")"
+				}
+				s += " " + results
+			}
+			return s
+		case *ast.InterfaceType:
+			if e.Methods == nil || len(e.Methods.List) == 0 {
+				return "interface{}"
+			}
+			methods := make([]string, 0, len(e.Methods.List))
+			for _, field := range e.Methods.List {
+				if fn, ok := field.Type.(*ast.FuncType); ok && len(field.Names) > 0 {
+					methods = append(methods, field.Names[0].Name+strings.TrimPrefix(render(fn), "func"))
+				} else {
+					methods = append(methods, render(field.Type))
+				}
+			}
+			return "interface{ " + strings.Join(methods, "; ") + " }"
+		case *ast.StructType:
+			if e.Fields == nil || len(e.Fields.List) == 0 {
+				return "struct{}"
+			}
+			return "struct{ " + list(e.Fields, "; ") + " }"
+		case *ast.IndexExpr:
+			return render(e.X) + "[" + render(e.Index) + "]"
+		case *ast.IndexListExpr:
+			indices := make([]string, len(e.Indices))
+			for i, index := range e.Indices {
+				indices[i] = render(index)
+			}
+			return render(e.X) + "[" + strings.Join(indices, ", ") + "]"
+		case *ast.ParenExpr:
+			return "(" + render(e.X) + ")"
+		case *ast.BasicLit:
+			return e.Value
+		default:
+			err = fmt.Errorf("unsupported type %s", types.ExprString(expr))
+			return ""
+		}
+	}
+	s := render(expr)
+	return s, err
+}
+
+// addImport 记录生成代码需要的导入
+func (g *facadeGenerator) addImport(importPath, alias string) {
+	g.imports[importPath] = alias
+}
+
+// isStdlib 是否为标准库，标准库路径的第一段不含 "."
+func isStdlib(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// findImport 查找文件中以 name 引用的导入
+func findImport(file *ast.File, name string) *ast.ImportSpec {
+	for _, spec := range file.Imports {
+		if importName(spec) == name {
+			return spec
+		}
+	}
+	return nil
+}
+
+// importName 导入在文件中的引用名，未指定别名时取路径的最后一段（去掉 .vN 等版本后缀）
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	importPath, _ := strconv.Unquote(spec.Path.Value)
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil && path.Dir(importPath) != "." {
+			base = path.Base(path.Dir(importPath))
+		}
+	}
+	if i := strings.IndexAny(base, ".-"); i > 0 {
+		base = base[:i]
+	}
+	return base
+}
+
+// packageImportPath 根据 go.mod 推导目录的导入路径
+func packageImportPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		if module, err := modulePath(filepath.Join(root, "go.mod")); err == nil {
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return path.Join(module, filepath.ToSlash(rel)), nil
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("no go.mod found for %s, specify -import", dir)
+		}
+	}
+}
+
+// modulePath 读取 go.mod 中的模块路径
+func modulePath(gomod string) (string, error) {
+	file, err := os.Open(gomod)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if module, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s has no module directive", gomod)
+}
+
+// fieldList 字段列表，nil 时返回空
+func fieldList(fields *ast.FieldList) []*ast.Field {
+	if fields == nil {
+		return nil
+	}
+	return fields.List
+}
+
+// paramList 参数列表源码，可变参数的最后一个参数写作 ...T
+func paramList(params []param, variadic bool) string {
+	parts := make([]string, len(params))
+	for i, p := range params {
+		typ := p.typ
+		if variadic && i == len(params)-1 {
+			typ = "..." + strings.TrimPrefix(typ, "[]")
+		}
+		parts[i] = p.name + " " + typ
+	}
+	return strings.Join(parts, ", ")
+}
+
+// resultList 返回值列表源码
+func resultList(results []param) string {
+	switch len(results) {
+	case 0:
+		return ""
+	case 1:
+		return " " + results[0].typ
+	}
+	types := make([]string, len(results))
+	for i, r := range results {
+		types[i] = r.typ
+	}
+	return " (" + strings.Join(types, ", ") + ")"
+}
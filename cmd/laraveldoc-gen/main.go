@@ -0,0 +1,76 @@
+// Command laraveldoc-gen 代码生成工具
+//
+// 子命令：
+//
+//	facade    根据服务接口生成强类型门面
+//	upgrade   把 contracts/vN 的导入升级到新版本，改写迁移的接口和重命名的方法，报告需要手动处理的变更
+//	events    扫描监听器的 Handle(event) 方法，生成 EventServiceProvider 自动发现使用的清单
+//	contracts 为 contracts/vN 的别名生成接口快照，别名指向的接口变化时编译失败
+//
+// 包结构：
+// - main.go - 子命令分发
+// - facade.go - facade 子命令：解析服务接口并生成门面
+// - upgrade.go - upgrade 子命令：按 contracts.Changes 改写源码
+// - events.go - events 子命令：扫描监听器并生成 application.DiscoverListener 清单
+// - contracts.go - contracts 子命令：类型检查 contracts/vN 并生成方法集快照
+//
+// 使用示例：
+//
+//	//go:generate go run github.com/cnote0/laraveldoc/cmd/laraveldoc-gen facade -source ../cache -interface Store -name Cache -accessor cache -output cache.go
+//
+//	go run ./cmd/laraveldoc-gen facade -source ./mail -interface Mailer -name Mail -accessor mailer -mode container -output app/facades/mail.go
+//
+//	// 实时门面代理，init 中注册到 facade.RegisterRealtime
+//	//go:generate go run github.com/cnote0/laraveldoc/cmd/laraveldoc-gen facade -source . -interface PaymentGateway -name PaymentGatewayProxy -package payments -mode realtime -output payment_proxy.go
+//
+//	// 先查看报告，再用 -w 写入
+//	go run github.com/cnote0/laraveldoc/cmd/laraveldoc-gen upgrade -from v1 -to v2 ./...
+//
+//	// 在 app/providers 中生成 app/listeners 下所有监听器的清单
+//	//go:generate go run github.com/cnote0/laraveldoc/cmd/laraveldoc-gen events -output events_manifest.go ../listeners/...
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// usage 顶层帮助
+const usage = `Usage: laraveldoc-gen <command> [flags]
+
+Commands:
+  facade    Generate a typed facade for a service interface
+  upgrade   Upgrade contracts/vN imports and report breaking interface changes
+  events    Generate the listener manifest used by event discovery
+  contracts Generate the interface snapshot that guards contracts/vN aliases
+
+Run "laraveldoc-gen <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "facade":
+		err = runFacade(os.Args[2:])
+	case "upgrade":
+		err = runUpgrade(os.Args[2:])
+	case "events":
+		err = runEvents(os.Args[2:])
+	case "contracts":
+		err = runContracts(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "laraveldoc-gen: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "laraveldoc-gen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/contracts"
+)
+
+// contractsPath contracts/vN 的导入路径前缀
+const contractsPath = "github.com/cnote0/laraveldoc/contracts/"
+
+// upgradeOptions upgrade 子命令的参数
+type upgradeOptions struct {
+	from    string
+	to      string
+	write   bool
+	changes []contracts.Change
+}
+
+// finding 需要手动处理的位置或已改写的位置
+type finding struct {
+	pos     token.Position
+	message string
+}
+
+// runUpgrade 执行 upgrade 子命令
+func runUpgrade(args []string) error {
+	opts := upgradeOptions{}
+	flags := flag.NewFlagSet("upgrade", flag.ContinueOnError)
+	flags.StringVar(&opts.from, "from", "", "contracts version the code currently uses, e.g. v1 (required)")
+	flags.StringVar(&opts.to, "to", contracts.Current, "contracts version to upgrade to")
+	flags.BoolVar(&opts.write, "w", false, "write the rewritten files instead of only reporting")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: laraveldoc-gen upgrade -from vN [-to vM] [-w] [packages]")
+		fmt.Fprintln(flags.Output(), `Packages are directories, "./..." walks recursively (default: ./...).`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if opts.from == "" {
+		flags.Usage()
+		return errors.New("-from is required")
+	}
+	changes, err := contracts.Between(opts.from, opts.to)
+	if err != nil {
+		return err
+	}
+	opts.changes = changes
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	files, err := goFiles(patterns)
+	if err != nil {
+		return err
+	}
+
+	var rewritten, manual int
+	for _, file := range files {
+		source, findings, changed, err := upgradeFile(file, opts)
+		if err != nil {
+			return err
+		}
+		for _, f := range findings {
+			fmt.Printf("%s: %s\n", f.pos, f.message)
+			if strings.HasPrefix(f.message, "manual: ") {
+				manual++
+			}
+		}
+		if !changed {
+			continue
+		}
+		rewritten++
+		if !opts.write {
+			fmt.Printf("%s: would rewrite (run with -w)\n", file)
+			continue
+		}
+		if err := os.WriteFile(file, source, 0o644); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("contracts %s -> %s: %d change(s) registered, %d file(s) rewritten, %d place(s) need manual changes\n",
+		opts.from, opts.to, len(changes), rewritten, manual)
+	return nil
+}
+
+// goFiles 展开目录和 "dir/..." 为 Go 源文件，跳过 vendor、testdata 和以 "." 或 "_" 开头的目录
+func goFiles(patterns []string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		dir, recursive := strings.CutSuffix(pattern, "...")
+		dir = filepath.Clean(strings.TrimSuffix(dir, "/"))
+		if dir == "" {
+			dir = "."
+		}
+		err := filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				base := entry.Name()
+				if name != dir && (!recursive || base == "vendor" || base == "testdata" ||
+					strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(name, ".go") {
+				files = append(files, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// upgradeFile 改写单个文件，返回格式化后的源码、发现的位置和是否有改动
+func upgradeFile(filename string, opts upgradeOptions) ([]byte, []finding, bool, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	u := &upgrader{fset: fset, file: file, opts: opts}
+	u.rewriteContractsImport()
+	for _, change := range opts.changes {
+		u.apply(change)
+	}
+	if !u.changed {
+		return nil, u.findings, false, nil
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, nil, false, fmt.Errorf("%s: %w", filename, err)
+	}
+	return buf.Bytes(), u.findings, true, nil
+}
+
+// upgrader 单个文件的改写状态
+type upgrader struct {
+	fset     *token.FileSet
+	file     *ast.File
+	opts     upgradeOptions
+	findings []finding
+	changed  bool
+}
+
+// report 记录位置，manual 为 true 时需要手动处理
+func (u *upgrader) report(pos token.Pos, manual bool, message string) {
+	if manual {
+		message = "manual: " + message
+	}
+	u.findings = append(u.findings, finding{pos: u.fset.Position(pos), message: message})
+}
+
+// rewriteContractsImport 把 contracts/<from> 的导入改写为 contracts/<to>
+func (u *upgrader) rewriteContractsImport() {
+	if u.opts.from == u.opts.to {
+		return
+	}
+	for _, spec := range u.file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if importPath != contractsPath+u.opts.from {
+			continue
+		}
+		spec.Path.Value = strconv.Quote(contractsPath + u.opts.to)
+		u.changed = true
+		u.report(spec.Pos(), false, fmt.Sprintf("import %s -> %s", importPath, contractsPath+u.opts.to))
+	}
+}
+
+// references 文件中引用 importPath 的包名，未导入时返回空
+func (u *upgrader) references(importPath string) string {
+	for _, spec := range u.file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == importPath {
+			return importName(spec)
+		}
+	}
+	return ""
+}
+
+// usesContracts 文件是否导入了 contracts/vN，接口别名可能被引用
+func (u *upgrader) usesContracts() bool {
+	for _, spec := range u.file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); strings.HasPrefix(p, contractsPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply 处理一个变更
+func (u *upgrader) apply(change contracts.Change) {
+	if change.Kind == contracts.InterfaceMoved {
+		u.moveInterface(change)
+		return
+	}
+	// 没有类型信息，方法按名称匹配，只检查导入了接口所在包或 contracts/vN 的文件
+	if u.references(change.Package) == "" && !u.usesContracts() {
+		return
+	}
+	ast.Inspect(u.file, func(node ast.Node) bool {
+		var ident *ast.Ident
+		switch n := node.(type) {
+		case *ast.FuncDecl:
+			if n.Recv != nil && n.Name.Name == change.Method {
+				ident = n.Name
+			}
+		case *ast.SelectorExpr:
+			if n.Sel.Name == change.Method {
+				ident = n.Sel
+			}
+		}
+		if ident == nil {
+			return true
+		}
+		if change.Kind == contracts.MethodRenamed {
+			ident.Name = change.Replacement
+			u.changed = true
+			u.report(ident.Pos(), false, change.String()+" (check that the receiver implements "+change.Interface+")")
+			return true
+		}
+		u.report(ident.Pos(), true, change.String())
+		return true
+	})
+}
+
+// moveInterface 把 pkg.Interface 改写为新包的新接口，并调整导入
+func (u *upgrader) moveInterface(change contracts.Change) {
+	name := u.references(change.Package)
+	if name == "" {
+		return
+	}
+	dot := strings.LastIndex(change.Replacement, ".")
+	if dot <= 0 {
+		u.report(u.file.Package, true, change.String()+" (invalid replacement, expected importpath.Name)")
+		return
+	}
+	newPath, newName := change.Replacement[:dot], change.Replacement[dot+1:]
+	newPkg := u.references(newPath)
+	if newPkg == "" {
+		newPkg = path.Base(newPath)
+	}
+	moved := false
+	ast.Inspect(u.file, func(node ast.Node) bool {
+		sel, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && x.Name == name && sel.Sel.Name == change.Interface {
+			x.Name = newPkg
+			sel.Sel.Name = newName
+			moved = true
+			u.report(sel.Pos(), false, change.String())
+		}
+		return true
+	})
+	if !moved {
+		return
+	}
+	u.changed = true
+	if u.references(newPath) == "" {
+		u.addImport(newPath)
+	}
+	if !u.selectorUsed(name) {
+		u.removeImport(change.Package)
+	}
+}
+
+// selectorUsed 文件中是否还有 name.X 形式的引用
+func (u *upgrader) selectorUsed(name string) bool {
+	used := false
+	ast.Inspect(u.file, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == name {
+				used = true
+			}
+		}
+		return !used
+	})
+	return used
+}
+
+// addImport 在第一个 import 声明中追加导入
+func (u *upgrader) addImport(importPath string) {
+	spec := &ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(importPath)}}
+	for _, decl := range u.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if !gen.Lparen.IsValid() {
+			gen.Lparen = gen.Pos()
+			gen.Rparen = gen.End()
+		}
+		gen.Specs = append(gen.Specs, spec)
+		u.file.Imports = append(u.file.Imports, spec)
+		return
+	}
+	gen := &ast.GenDecl{Tok: token.IMPORT, Specs: []ast.Spec{spec}}
+	u.file.Decls = append([]ast.Decl{gen}, u.file.Decls...)
+	u.file.Imports = append(u.file.Imports, spec)
+}
+
+// removeImport 删除不再使用的导入
+func (u *upgrader) removeImport(importPath string) {
+	matches := func(spec ast.Spec) bool {
+		p, _ := strconv.Unquote(spec.(*ast.ImportSpec).Path.Value)
+		return p == importPath
+	}
+	for _, decl := range u.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			if !matches(spec) {
+				specs = append(specs, spec)
+			}
+		}
+		gen.Specs = specs
+	}
+	imports := u.file.Imports[:0]
+	for _, spec := range u.file.Imports {
+		if !matches(spec) {
+			imports = append(imports, spec)
+		}
+	}
+	u.file.Imports = imports
+}
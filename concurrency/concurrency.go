@@ -0,0 +1,174 @@
+// Package concurrency 提供 Laravel 风格的并发任务辅助，对应 Concurrency::run 和 Concurrency::defer
+//
+// 任务在 goroutine 中并发执行，结果按任务顺序返回。默认第一个错误会取消传给其他任务的 context，
+// 其余任务退出后返回该错误；CollectErrors 模式不取消，返回全部任务合并的错误。任务中的 panic
+// 被恢复为 *TaskError，不会使进程崩溃。
+//
+// 主要特性：
+// - Run 和 RunWith 执行返回相同类型结果的任务，Limit 限制最大并发数
+// - Go 执行只返回错误的任务，结果由闭包写入各自的变量，适合并行执行不同类型的查询
+// - Defer 把任务推迟到应用终止阶段（响应发送后）执行
+//
+// 包结构：
+// - concurrency.go - Run、RunWith、Go、Defer 和 TaskError
+//
+// 使用示例：
+//
+//	counts, err := concurrency.Run(ctx,
+//		func(ctx context.Context) (int64, error) { return countUsers(ctx) },
+//		func(ctx context.Context) (int64, error) { return countOrders(ctx) },
+//	)
+//
+//	var user User
+//	var orders []Order
+//	err := concurrency.Go(ctx,
+//		func(ctx context.Context) error { return db.WithContext(ctx).First(&user, id).Error() },
+//		func(ctx context.Context) error { return db.WithContext(ctx).Where("user_id = ?", id).Find(&orders).Error() },
+//	)
+//
+//	concurrency.Defer(app, func(ctx context.Context) error { return metrics.Flush(ctx) })
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPanic 任务发生 panic
+var ErrPanic = errors.New("concurrency: task panicked")
+
+// Task 返回结果的任务
+type Task[T any] func(ctx context.Context) (T, error)
+
+// TaskError 任务失败，Index 为任务在参数中的位置
+type TaskError struct {
+	// Index 任务下标
+	Index int
+
+	// Err 任务返回的错误
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("concurrency: task %d: %v", e.Index, e.Err)
+}
+
+// Unwrap 返回任务的错误
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// Config 并发执行配置
+type Config struct {
+	// Limit 最大并发数，0 表示不限制
+	Limit int
+
+	// CollectErrors 为 true 时不在第一个错误时取消，等待全部任务结束后返回 errors.Join 合并的 *TaskError
+	CollectErrors bool
+}
+
+// Run 并发执行任务，按任务顺序返回结果，第一个错误取消其余任务并返回该错误（*TaskError）
+func Run[T any](ctx context.Context, tasks ...Task[T]) ([]T, error) {
+	return RunWith(ctx, Config{}, tasks...)
+}
+
+// RunWith 按配置并发执行任务，按任务顺序返回结果，失败任务的结果为零值
+//
+// 等待中的任务在 context 取消后不再启动，其错误为 context 的错误。
+func RunWith[T any](ctx context.Context, config Config, tasks ...Task[T]) ([]T, error) {
+	results := make([]T, len(tasks))
+	if len(tasks) == 0 {
+		return results, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var sem chan struct{}
+	if config.Limit > 0 {
+		sem = make(chan struct{}, config.Limit)
+	}
+	errs := make([]error, len(tasks))
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	for i, task := range tasks {
+		wg.Add(1)
+		go func(i int, task Task[T]) {
+			defer wg.Done()
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					errs[i] = &TaskError{Index: i, Err: ctx.Err()}
+					return
+				}
+			}
+			result, err := call(ctx, task)
+			if err != nil {
+				errs[i] = &TaskError{Index: i, Err: err}
+				if !config.CollectErrors {
+					once.Do(func() {
+						first = errs[i]
+						cancel()
+					})
+				}
+				return
+			}
+			results[i] = result
+		}(i, task)
+	}
+	wg.Wait()
+
+	if !config.CollectErrors {
+		return results, first
+	}
+	return results, errors.Join(errs...)
+}
+
+// Go 并发执行只返回错误的任务，第一个错误取消其余任务并返回该错误（*TaskError）
+func Go(ctx context.Context, tasks ...func(ctx context.Context) error) error {
+	_, err := Run(ctx, errorTasks(tasks)...)
+	return err
+}
+
+// Terminator 可以注册终止回调的应用，application.Application 满足该接口
+type Terminator interface {
+	Terminating(callback func() error)
+}
+
+// Defer 在应用终止时并发执行任务，对应 Concurrency::defer
+//
+// 任务使用不会被取消的 context，全部执行完毕后合并的错误由应用的 Terminate 返回。
+func Defer(app Terminator, tasks ...func(ctx context.Context) error) {
+	app.Terminating(func() error {
+		_, err := RunWith(context.Background(), Config{CollectErrors: true}, errorTasks(tasks)...)
+		return err
+	})
+}
+
+// errorTasks 把只返回错误的任务包装为 Task
+func errorTasks(tasks []func(ctx context.Context) error) []Task[struct{}] {
+	wrapped := make([]Task[struct{}], len(tasks))
+	for i, task := range tasks {
+		wrapped[i] = func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, task(ctx)
+		}
+	}
+	return wrapped
+}
+
+// call 执行任务，把 panic 转换为错误
+func call[T any](ctx context.Context, task Task[T]) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrPanic, r)
+		}
+	}()
+	return task(ctx)
+}
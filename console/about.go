@@ -0,0 +1,284 @@
+package console
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/routing"
+)
+
+// defaultAbout 默认的 about 信息收集器
+var defaultAbout atomic.Value
+
+// AboutCommand about 命令的信息收集器，对应 Laravel 的 AboutCommand
+//
+// 内置 Environment、Drivers、Application 三个分区，服务提供者可以通过 Add 添加自己的分区：
+//
+//	console.DefaultAboutCommand().Add("Billing", map[string]interface{}{
+//		"Gateway": "stripe",
+//		"Webhooks": func() interface{} { return webhooks.Count() },
+//	})
+type AboutCommand struct {
+	mu       sync.Mutex
+	sections []aboutSection
+}
+
+// aboutSection 一个分区，条目按添加顺序输出
+type aboutSection struct {
+	name    string
+	entries []aboutEntry
+}
+
+// aboutEntry 一条信息，value 为 func() interface{} 时在执行命令时求值
+type aboutEntry struct {
+	key   string
+	value interface{}
+}
+
+// NewAboutCommand 创建信息收集器
+func NewAboutCommand() *AboutCommand {
+	return &AboutCommand{}
+}
+
+// SetDefaultAboutCommand 设置默认的信息收集器
+func SetDefaultAboutCommand(about *AboutCommand) {
+	defaultAbout.Store(about)
+}
+
+// DefaultAboutCommand 获取默认的信息收集器，RegisterAboutCommand 未指定收集器时使用
+func DefaultAboutCommand() *AboutCommand {
+	if about, ok := defaultAbout.Load().(*AboutCommand); ok {
+		return about
+	}
+	about := NewAboutCommand()
+	if defaultAbout.CompareAndSwap(nil, about) {
+		return about
+	}
+	return defaultAbout.Load().(*AboutCommand)
+}
+
+// Add 向分区添加信息，同一个分区可以多次添加，同一分区中的键按字母顺序排列
+//
+// 值可以是任意值，也可以是 func() interface{}，在执行 about 命令时求值。
+func (a *AboutCommand) Add(section string, data map[string]interface{}) *AboutCommand {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]aboutEntry, len(keys))
+	for i, key := range keys {
+		entries[i] = aboutEntry{key: key, value: data[key]}
+	}
+	a.add(section, entries...)
+	return a
+}
+
+// add 按给定顺序向分区添加信息
+func (a *AboutCommand) add(section string, entries ...aboutEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i := range a.sections {
+		if a.sections[i].name == section {
+			a.sections[i].entries = append(a.sections[i].entries, entries...)
+			return
+		}
+	}
+	a.sections = append(a.sections, aboutSection{name: section, entries: entries})
+}
+
+// snapshot 复制当前的分区
+func (a *AboutCommand) snapshot() []aboutSection {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	sections := make([]aboutSection, len(a.sections))
+	for i, section := range a.sections {
+		sections[i] = aboutSection{name: section.name, entries: append([]aboutEntry(nil), section.entries...)}
+	}
+	return sections
+}
+
+// RegisterAboutCommand 注册 about 命令，about 为 nil 时使用 DefaultAboutCommand
+//
+// --only 只显示指定的分区（不区分大小写，多个用逗号分隔），--json 以 JSON 输出，
+// 分区名和键转为蛇形命名，例如 {"environment": {"debug_mode": false}}。
+func RegisterAboutCommand(artisan application.ArtisanInterface, app application.Application, about *AboutCommand) {
+	artisan.Register("about").
+		SetDescription("Display basic information about your application").
+		AddOption("only", "", application.InputOptionValueRequired, "The section to display", "").
+		AddOption("json", "", application.InputOptionValueNone, "Output the information as JSON", false).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			collector := about
+			if collector == nil {
+				collector = DefaultAboutCommand()
+			}
+			sections := append(applicationSections(app), collector.snapshot()...)
+			sections = filterSections(sections, stringOption(input, "only"))
+
+			if boolOption(input, "json") {
+				return writeAboutJSON(output, sections)
+			}
+			components := NewComponents(output)
+			for _, section := range sections {
+				if err := output.WriteLine("", application.VerbosityNormal); err != nil {
+					return err
+				}
+				if err := components.TwoColumnDetail(components.style(styleGreen+styleBold, section.name), ""); err != nil {
+					return err
+				}
+				for _, entry := range section.entries {
+					if err := components.TwoColumnDetail(entry.key, formatAboutValue(entry.value)); err != nil {
+						return err
+					}
+				}
+			}
+			return output.WriteLine("", application.VerbosityNormal)
+		})
+}
+
+// applicationSections 内置分区：运行环境、驱动和应用概况
+func applicationSections(app application.Application) []aboutSection {
+	config, _ := resolve[application.Config](app, "config")
+	configValue := func(key string) interface{} {
+		return func() interface{} {
+			if config == nil {
+				return nil
+			}
+			return config.Get(key, nil)
+		}
+	}
+
+	environment := aboutSection{name: "Environment", entries: []aboutEntry{
+		{"Application Name", configValue("app.name")},
+		{"Application Version", app.Version()},
+		{"Go Version", runtime.Version()},
+		{"Environment", app.Environment()},
+		{"Debug Mode", debugMode(app.IsDebug())},
+		{"URL", configValue("app.url")},
+		{"Locale", app.GetLocale()},
+		{"Timezone", configValue("app.timezone")},
+	}}
+
+	drivers := aboutSection{name: "Drivers", entries: []aboutEntry{
+		{"Broadcasting", configValue("broadcasting.default")},
+		{"Cache", configValue("cache.default")},
+		{"Database", configValue("database.default")},
+		{"Logs", configValue("logging.default")},
+		{"Mail", configValue("mail.default")},
+		{"Queue", configValue("queue.default")},
+		{"Session", configValue("session.driver")},
+	}}
+
+	summary := aboutSection{name: "Application", entries: []aboutEntry{
+		{"Providers", len(app.GetProviders(nil))},
+		{"Routes", func() interface{} {
+			if router, ok := resolve[routing.Router](app, "router"); ok && router.GetRoutes() != nil {
+				return router.GetRoutes().Count()
+			}
+			return nil
+		}},
+		{"Migrations", func() interface{} {
+			files, err := filepath.Glob(filepath.Join(app.DatabasePath("migrations"), "*.go"))
+			if err != nil {
+				return nil
+			}
+			return len(files)
+		}},
+	}}
+	return []aboutSection{environment, drivers, summary}
+}
+
+// resolve 从容器解析服务，未绑定或类型不符时返回 false
+func resolve[T any](app application.Application, abstract string) (T, bool) {
+	var zero T
+	if !app.Bound(abstract) {
+		return zero, false
+	}
+	instance, err := app.Make(abstract)
+	if err != nil {
+		return zero, false
+	}
+	service, ok := instance.(T)
+	return service, ok
+}
+
+// debugMode 调试模式的显示值
+func debugMode(debug bool) string {
+	if debug {
+		return "ENABLED"
+	}
+	return "OFF"
+}
+
+// filterSections 按 --only 筛选分区
+func filterSections(sections []aboutSection, only string) []aboutSection {
+	if strings.TrimSpace(only) == "" {
+		return sections
+	}
+	var filtered []aboutSection
+	for _, name := range strings.Split(only, ",") {
+		for _, section := range sections {
+			if strings.EqualFold(section.name, strings.TrimSpace(name)) || aboutKey(section.name) == aboutKey(name) {
+				filtered = append(filtered, section)
+			}
+		}
+	}
+	return filtered
+}
+
+// writeAboutJSON 以 JSON 输出
+func writeAboutJSON(output application.OutputInterface, sections []aboutSection) error {
+	data := make(map[string]map[string]interface{}, len(sections))
+	for _, section := range sections {
+		values := make(map[string]interface{}, len(section.entries))
+		for _, entry := range section.entries {
+			values[aboutKey(entry.key)] = resolveAboutValue(entry.value)
+		}
+		data[aboutKey(section.name)] = values
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return output.WriteLine(string(content), application.VerbosityNormal)
+}
+
+// aboutKey JSON 中使用的键，例如 "Debug Mode" 转为 "debug_mode"
+func aboutKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "_")
+}
+
+// resolveAboutValue 对延迟求值的信息求值
+func resolveAboutValue(value interface{}) interface{} {
+	if fn, ok := value.(func() interface{}); ok {
+		return fn()
+	}
+	return value
+}
+
+// formatAboutValue 信息的显示值，nil 和空字符串显示为 "-"
+func formatAboutValue(value interface{}) string {
+	switch v := resolveAboutValue(value).(type) {
+	case nil:
+		return "-"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
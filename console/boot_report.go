@@ -0,0 +1,93 @@
+package console
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// RegisterBootReportCommand 注册 app:boot-report 命令，输出应用启动时每个引导程序和服务提供者的耗时
+//
+//	app:boot-report                    按启动顺序列出全部耗时，超过阈值的项标为 SLOW
+//	app:boot-report --slowest=10       只列出最慢的 10 项
+//	app:boot-report --threshold=50ms   使用 50ms 作为阈值，默认使用计时器的阈值
+//	app:boot-report --json             以 JSON 输出
+//
+// app 没有实现 application.ProfiledApplication 时报告为空。
+func RegisterBootReportCommand(artisan application.ArtisanInterface, app application.Application) {
+	artisan.Register("app:boot-report").
+		SetDescription("Display how long each bootstrapper and service provider took to boot").
+		AddOption("slowest", "", application.InputOptionValueRequired, "Only display the given number of slowest entries", "").
+		AddOption("threshold", "", application.InputOptionValueRequired, "Mark entries slower than the given duration, e.g. 50ms", "").
+		AddOption("json", "", application.InputOptionValueNone, "Output the report as JSON", false).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			profiler := application.BootProfilerOf(app)
+			report := profiler.Report()
+
+			threshold := application.DefaultBootThreshold
+			if profiler != nil && profiler.Threshold > 0 {
+				threshold = profiler.Threshold
+			}
+			if value := stringOption(input, "threshold"); value != "" {
+				parsed, err := time.ParseDuration(value)
+				if err != nil {
+					return fmt.Errorf("console: invalid --threshold %q: %w", value, err)
+				}
+				threshold = parsed
+			}
+			timings := report.Timings
+			if value := stringOption(input, "slowest"); value != "" {
+				n, err := strconv.Atoi(value)
+				if err != nil || n <= 0 {
+					return fmt.Errorf("console: --slowest must be a positive number, got %q", value)
+				}
+				timings = report.Slowest(n)
+			}
+
+			if boolOption(input, "json") {
+				content, err := json.Marshal(map[string]interface{}{
+					"total":     report.Total(),
+					"threshold": threshold,
+					"timings":   timings,
+				})
+				if err != nil {
+					return err
+				}
+				return output.WriteLine(string(content), application.VerbosityNormal)
+			}
+
+			components := NewComponents(output)
+			if len(report.Timings) == 0 {
+				return components.Info("No boot timings were recorded.")
+			}
+			rows := make([][]string, 0, len(timings))
+			for _, timing := range timings {
+				status := ""
+				switch {
+				case timing.Error != "":
+					status = components.style(styleRed, "FAILED")
+				case timing.Duration > threshold:
+					status = components.style(styleYellow, "SLOW")
+				}
+				rows = append(rows, []string{string(timing.Phase), timing.Name, formatBootDuration(timing.Duration), status})
+			}
+			if err := components.Table([]string{"Phase", "Name", "Duration", ""}, rows, AlignLeft, AlignLeft, AlignRight); err != nil {
+				return err
+			}
+			if err := components.TwoColumnDetail("Total", formatBootDuration(report.Total())); err != nil {
+				return err
+			}
+			if slow := len(report.Exceeding(threshold)); slow > 0 {
+				return components.Warn(fmt.Sprintf("%d entries took longer than %s.", slow, threshold))
+			}
+			return nil
+		})
+}
+
+// formatBootDuration 以毫秒显示耗时，例如 "12.35ms"
+func formatBootDuration(d time.Duration) string {
+	return strconv.FormatFloat(float64(d.Microseconds())/1000, 'f', 2, 64) + "ms"
+}
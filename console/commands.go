@@ -0,0 +1,180 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// RegisterCommands 注册 make:* 代码生成命令和 stub:publish 命令
+//
+// 生成的文件位置：
+//
+//	make:controller  app/http/controllers（--resource 资源控制器，--api 不含 Create、Edit 的资源控制器）
+//	make:model       app/models（--migration、--factory、--seeder 同时生成迁移、工厂和数据填充）
+//	make:middleware  app/http/middleware
+//	make:job         app/jobs
+//	make:event       app/events
+//	make:listener    app/listeners（--event 指定监听的事件）
+//	make:provider    app/providers
+//	make:policy      app/policies（--model 指定授权的模型）
+//	make:request     app/http/requests
+//
+// 目标文件已存在时返回 ErrFileExists，--force 覆盖。
+func RegisterCommands(artisan application.ArtisanInterface, generator *Generator) {
+	makeCommand(artisan, generator, "make:controller", "Create a new controller", "Controller", "app/http/controllers",
+		func(input application.InputInterface) (string, map[string]string) {
+			switch {
+			case boolOption(input, "api"):
+				return "controller.api.stub", nil
+			case boolOption(input, "resource"):
+				return "controller.stub", nil
+			}
+			return "controller.plain.stub", nil
+		}).
+		AddOption("resource", "r", application.InputOptionValueNone, "Generate a resource controller", false).
+		AddOption("api", "", application.InputOptionValueNone, "Exclude the create and edit methods from the controller", false)
+
+	artisan.Register("make:model").
+		SetDescription("Create a new model").
+		AddArgument("name", application.InputArgumentRequired, "The name of the model", nil).
+		AddOption("migration", "m", application.InputOptionValueNone, "Create a new migration file for the model", false).
+		AddOption("factory", "f", application.InputOptionValueNone, "Create a new factory for the model", false).
+		AddOption("seeder", "s", application.InputOptionValueNone, "Create a new seeder for the model", false).
+		AddOption("force", "", application.InputOptionValueNone, "Create the file even if it already exists", false).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			name := stringArgument(input, "name")
+			force := boolOption(input, "force")
+			model, _, err := parseName(name)
+			if err != nil {
+				return err
+			}
+			replacements := modelReplacements(model)
+			files := []File{{Type: "Model", Stub: "model.stub", Dir: "app/models", Name: name, Replacements: replacements}}
+			if boolOption(input, "migration") {
+				migration := generator.now().Format("2006_01_02_150405") + "_create_" + replacements["table"] + "_table"
+				files = append(files, File{
+					Type:         "Migration",
+					Stub:         "migration.create.stub",
+					Dir:          "database/migrations",
+					Name:         "Create" + str.Studly(replacements["table"]) + "Table",
+					FileName:     migration,
+					Replacements: withReplacement(replacements, "migration", migration),
+				})
+			}
+			if boolOption(input, "factory") {
+				files = append(files, File{Type: "Factory", Stub: "factory.stub", Dir: "database/factories", Name: model + "Factory", Replacements: replacements})
+			}
+			if boolOption(input, "seeder") {
+				files = append(files, File{Type: "Seeder", Stub: "seeder.stub", Dir: "database/seeders", Name: model + "Seeder", Replacements: replacements})
+			}
+			for _, file := range files {
+				if err := generate(generator, output, file, force); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	makeCommand(artisan, generator, "make:middleware", "Create a new HTTP middleware", "Middleware", "app/http/middleware", stub("middleware.stub"))
+	makeCommand(artisan, generator, "make:job", "Create a new job", "Job", "app/jobs", stub("job.stub"))
+	makeCommand(artisan, generator, "make:event", "Create a new event", "Event", "app/events", stub("event.stub"))
+
+	makeCommand(artisan, generator, "make:listener", "Create a new event listener", "Listener", "app/listeners",
+		func(input application.InputInterface) (string, map[string]string) {
+			if event := stringOption(input, "event"); event != "" {
+				return "listener.typed.stub", map[string]string{"event": event}
+			}
+			return "listener.stub", nil
+		}).
+		AddOption("event", "e", application.InputOptionValueRequired, "The event being listened for", "")
+
+	makeCommand(artisan, generator, "make:provider", "Create a new service provider", "Provider", "app/providers", stub("provider.stub"))
+
+	makeCommand(artisan, generator, "make:policy", "Create a new policy", "Policy", "app/policies",
+		func(input application.InputInterface) (string, map[string]string) {
+			model := stringOption(input, "model")
+			if model == "" {
+				return "policy.plain.stub", nil
+			}
+			replacements := modelReplacements(model)
+			// 与 user 参数重名时改用 model，与 Laravel 的 policy stub 一致
+			if replacements["modelVariable"] == "user" {
+				replacements["modelVariable"] = "model"
+			}
+			return "policy.stub", replacements
+		}).
+		AddOption("model", "m", application.InputOptionValueRequired, "The model that the policy applies to", "")
+
+	makeCommand(artisan, generator, "make:request", "Create a new form request", "Request", "app/http/requests", stub("request.stub"))
+
+	artisan.Register("stub:publish").
+		SetDescription("Publish all stubs that are available for customization").
+		AddOption("force", "", application.InputOptionValueNone, "Overwrite any existing files", false).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			published, err := generator.PublishStubs(boolOption(input, "force"))
+			if err != nil {
+				return err
+			}
+			return output.WriteLine(fmt.Sprintf("INFO  %d stubs published to [%s].", len(published), generator.StubPath()), application.VerbosityNormal)
+		})
+}
+
+// makeCommand 注册只生成一个文件的命令，resolve 根据输入选择 stub 和额外的占位符
+func makeCommand(artisan application.ArtisanInterface, generator *Generator, name, description, typ, dir string, resolve func(input application.InputInterface) (string, map[string]string)) application.CommandInterface {
+	return artisan.Register(name).
+		SetDescription(description).
+		AddArgument("name", application.InputArgumentRequired, "The name of the "+strings.ToLower(typ), nil).
+		AddOption("force", "", application.InputOptionValueNone, "Create the file even if it already exists", false).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			stubName, replacements := resolve(input)
+			file := File{Type: typ, Stub: stubName, Dir: dir, Name: stringArgument(input, "name"), Replacements: replacements}
+			return generate(generator, output, file, boolOption(input, "force"))
+		})
+}
+
+// generate 生成文件并输出结果
+func generate(generator *Generator, output application.OutputInterface, file File, force bool) error {
+	relative, err := generator.Generate(file, force)
+	if err != nil {
+		return err
+	}
+	return output.WriteLine(fmt.Sprintf("INFO  %s [%s] created successfully.", file.Type, relative), application.VerbosityNormal)
+}
+
+// stub 固定使用一个 stub
+func stub(name string) func(application.InputInterface) (string, map[string]string) {
+	return func(application.InputInterface) (string, map[string]string) {
+		return name, nil
+	}
+}
+
+// withReplacement 复制占位符并追加一项
+func withReplacement(replacements map[string]string, key, value string) map[string]string {
+	merged := make(map[string]string, len(replacements)+1)
+	for k, v := range replacements {
+		merged[k] = v
+	}
+	merged[key] = value
+	return merged
+}
+
+// stringArgument 读取字符串参数
+func stringArgument(input application.InputInterface, name string) string {
+	value, _ := input.GetArgument(name).(string)
+	return value
+}
+
+// stringOption 读取字符串选项
+func stringOption(input application.InputInterface, name string) string {
+	value, _ := input.GetOption(name).(string)
+	return value
+}
+
+// boolOption 读取开关选项
+func boolOption(input application.InputInterface, name string) bool {
+	value, _ := input.GetOption(name).(bool)
+	return value
+}
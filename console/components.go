@@ -0,0 +1,193 @@
+package console
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// ANSI 样式，只在输出为装饰模式时使用
+const (
+	styleReset  = "\x1b[0m"
+	styleBold   = "\x1b[1m"
+	styleGray   = "\x1b[90m"
+	styleGreen  = "\x1b[32m"
+	styleRed    = "\x1b[31m"
+	styleYellow = "\x1b[33m"
+	styleInfo   = "\x1b[44;37;1m"
+	styleWarn   = "\x1b[43;30;1m"
+	styleError  = "\x1b[41;37;1m"
+)
+
+// defaultWidth 无法获取终端宽度时的输出宽度
+const defaultWidth = 80
+
+// Components 命令输出组件，对应 Laravel 命令中的 $this->components
+//
+// 在 OutputInterface 之上提供提示块、两列详情、任务行、表格、进度条和加载动画，
+// 输出为装饰模式（IsDecorated）时使用 ANSI 颜色，进度条和加载动画原地刷新；
+// 否则输出纯文本，适合写入日志文件。
+type Components struct {
+	output application.OutputInterface
+	width  int
+	now    func() time.Time
+}
+
+// NewComponents 创建输出组件，宽度取 COLUMNS 环境变量，未设置时为 80
+func NewComponents(output application.OutputInterface) *Components {
+	width := defaultWidth
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		width = min(columns, 150)
+	}
+	return &Components{output: output, width: width, now: time.Now}
+}
+
+// SetWidth 设置输出宽度
+func (c *Components) SetWidth(width int) *Components {
+	c.width = width
+	return c
+}
+
+// Info 信息提示块
+func (c *Components) Info(message string) error {
+	return c.block("INFO", styleInfo, message)
+}
+
+// Warn 警告提示块
+func (c *Components) Warn(message string) error {
+	return c.block("WARN", styleWarn, message)
+}
+
+// Error 错误提示块
+func (c *Components) Error(message string) error {
+	return c.block("ERROR", styleError, message)
+}
+
+// Alert 醒目的提示框
+func (c *Components) Alert(message string) error {
+	border := strings.Repeat("*", displayWidth(message)+12)
+	lines := []string{"", "  " + border, "  *     " + message + "     *", "  " + border, ""}
+	for i, line := range lines {
+		lines[i] = c.style(styleYellow, line)
+	}
+	return c.writeLines(lines...)
+}
+
+// Line 普通行
+func (c *Components) Line(message string) error {
+	return c.writeLines("  " + message)
+}
+
+// BulletList 项目符号列表
+func (c *Components) BulletList(items ...string) error {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = "  " + c.style(styleGray, "⇂") + " " + item
+	}
+	return c.writeLines(lines...)
+}
+
+// TwoColumnDetail 两列详情，中间用点填充到输出宽度
+//
+//	APP_ENV ................................................. production
+func (c *Components) TwoColumnDetail(first, second string) error {
+	dots := max(c.width-displayWidth(first)-displayWidth(second)-6, 1)
+	line := "  " + first + " " + c.style(styleGray, strings.Repeat(".", dots)) + " " + second
+	return c.writeLines(line)
+}
+
+// Task 执行任务并输出任务行，成功时显示 DONE，失败时显示 FAIL 并返回 task 的错误
+//
+//	Caching the configuration ............................... 12ms DONE
+func (c *Components) Task(description string, task func() error) error {
+	started := c.now()
+	if c.output.IsDecorated() {
+		if err := c.output.Write([]string{"  " + description + " "}, false, application.VerbosityNormal); err != nil {
+			return err
+		}
+	}
+	err := task()
+	status := c.style(styleGreen+styleBold, "DONE")
+	if err != nil {
+		status = c.style(styleRed+styleBold, "FAIL")
+	}
+	elapsed := formatDuration(c.now().Sub(started))
+	dots := max(c.width-displayWidth(description)-len(elapsed)-len("DONE")-7, 1)
+	line := c.style(styleGray, strings.Repeat(".", dots)+" "+elapsed) + " " + status
+	if !c.output.IsDecorated() {
+		line = "  " + description + " " + line
+	}
+	if writeErr := c.writeLines(line); writeErr != nil && err == nil {
+		return writeErr
+	}
+	return err
+}
+
+// block 带标签的提示块
+func (c *Components) block(label, style, message string) error {
+	return c.writeLines("", "  "+c.style(style, " "+label+" ")+" "+message, "")
+}
+
+// style 装饰模式下添加 ANSI 样式
+func (c *Components) style(style, text string) string {
+	if !c.output.IsDecorated() || text == "" {
+		return text
+	}
+	return style + text + styleReset
+}
+
+// writeLines 逐行输出
+func (c *Components) writeLines(lines ...string) error {
+	for _, line := range lines {
+		if err := c.output.WriteLine(line, application.VerbosityNormal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatDuration 任务耗时，与 Laravel 一致按毫秒或秒显示
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	if d < time.Minute {
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+	return d.Round(time.Second).String()
+}
+
+// displayWidth 字符串在终端中的显示宽度，东亚宽字符按 2 计算，忽略 ANSI 转义序列
+func displayWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			if end := strings.IndexByte(s[i:], 'm'); end >= 0 {
+				i += end + 1
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case isWide(r):
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// isWide 是否为东亚宽字符
+func isWide(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana) ||
+		(r >= 0x3000 && r <= 0x303F) || (r >= 0xFF01 && r <= 0xFF60) || (r >= 0xFFE0 && r <= 0xFFE6)
+}
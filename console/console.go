@@ -0,0 +1,69 @@
+// Package console 提供 Artisan 命令行的通用实现
+//
+// 包含基于 stub 模板的代码生成器（make:* 命令，对应 Laravel 的 GeneratorCommand），
+// 命令输出组件（对应 Laravel 命令中的 $this->components），
+// Laravel 风格的命令签名解析和闭包命令注册（对应 Artisan::command），
+// 基于缓存锁的命令隔离（对应 Isolatable 命令的 --isolated 选项），
+// 命令的信号处理（对应 SignalableCommandInterface 和 $this->trap），
+// 把服务提供者登记的文件发布到应用中的 vendor:publish 命令，
+// 汇总应用信息的 about 命令，以及输出启动耗时的 app:boot-report 命令。
+// 生成的代码按 Go 的习惯组织：目录和包名为小写，文件名为蛇形命名，
+// 例如 make:controller Admin/UserController 生成 app/http/controllers/admin/user_controller.go，包名为 admin。
+// 引用模型的 stub（迁移、工厂、策略）假定模型位于 {namespace}/app/models 包。
+//
+// 包结构：
+// - console.go - 包文档
+// - generator.go - Generator 代码生成器和占位符替换
+// - stubs.go - 内置 stub 模板
+// - commands.go - make:* 和 stub:publish 命令
+// - components.go - Components 输出组件：提示块、两列详情、任务行
+// - table.go - 表格
+// - progress.go - 进度条和加载动画
+// - signature.go - 命令签名解析和闭包命令
+// - isolation.go - Isolate 命令隔离和 ExitCodeError
+// - signals.go - 信号处理
+// - publish.go - Publisher 和 vendor:publish 命令
+// - about.go - AboutCommand 和 about 命令
+// - boot_report.go - app:boot-report 启动耗时报告命令
+//
+// 使用示例：
+//
+//	generator := console.NewGenerator(app.BasePath(), app.GetNamespace())
+//	console.RegisterCommands(app.GetArtisan(), generator)
+//
+//	// go run ./cmd/artisan make:model Post --migration --factory --seeder
+//	// go run ./cmd/artisan make:listener SendShipmentNotification --event=OrderShipped
+//	// go run ./cmd/artisan stub:publish
+//
+//	console.RegisterPublishCommand(app.GetArtisan(), console.NewPublisher(app.BasePath()))
+//	// go run ./cmd/artisan vendor:publish --tag=billing-config --force
+//
+//	console.DefaultAboutCommand().Add("Billing", map[string]interface{}{"Gateway": "stripe"})
+//	console.RegisterAboutCommand(app.GetArtisan(), app, nil)
+//	// go run ./cmd/artisan about --only=environment,billing
+//
+//	console.RegisterBootReportCommand(app.GetArtisan(), app)
+//	// go run ./cmd/artisan app:boot-report --slowest=10 --threshold=50ms
+//
+//	components := console.NewComponents(output)
+//	components.Info("Caching the configuration.")
+//	components.Task("Caching routes", cacheRoutes)
+//	components.TwoColumnDetail("APP_ENV", "production")
+//	components.Table([]string{"ID", "Name", "Orders"}, rows, console.AlignLeft, console.AlignLeft, console.AlignRight)
+//
+//	bar := components.ProgressBar(len(users))
+//	bar.Start()
+//	for _, user := range users {
+//		process(user)
+//		bar.Advance()
+//	}
+//	bar.Finish()
+//
+//	err := components.Spin("Fetching releases...", fetchReleases)
+//
+//	console.Command(artisan, "mail:send {user* : The IDs of the users} {--Q|queue=default}", sendMail).
+//		SetDescription("Send a marketing email")
+//
+//	// go run ./cmd/artisan report:generate --isolated=12
+//	console.Isolate(artisan.Register("report:generate"), cache.NewMemoryStore(), nil, generateReport)
+package console
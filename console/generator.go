@@ -0,0 +1,209 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// ErrFileExists 目标文件已存在且没有指定 --force
+var ErrFileExists = errors.New("console: file already exists")
+
+// File 要生成的文件
+type File struct {
+	// Type 显示名称，例如 "Controller"
+	Type string
+
+	// Stub stub 文件名，例如 "controller.stub"
+	Stub string
+
+	// Dir 目标目录，相对于基础路径，例如 "app/http/controllers"
+	Dir string
+
+	// Name 类型名称，可以用 "/" 或 "\" 带子目录，例如 "Admin/UserController"
+	Name string
+
+	// FileName 文件名（不含 .go），为空时使用类型名称的蛇形命名
+	FileName string
+
+	// Replacements 额外的占位符
+	Replacements map[string]string
+}
+
+// Generator 代码生成器，对应 Laravel 的 GeneratorCommand
+//
+// 根据 stub 模板生成 Go 源文件。stub 优先从自定义目录（默认为 basePath/stubs）读取，
+// 不存在时使用内置模板，可以通过 stub:publish 导出内置模板后修改。
+// namespace 为应用的 Go 模块路径（application.GetNamespace），用于生成包之间的 import。
+type Generator struct {
+	basePath  string
+	namespace string
+	stubPath  string
+	now       func() time.Time
+}
+
+// NewGenerator 创建代码生成器
+func NewGenerator(basePath, namespace string) *Generator {
+	return &Generator{
+		basePath:  basePath,
+		namespace: namespace,
+		stubPath:  filepath.Join(basePath, "stubs"),
+		now:       time.Now,
+	}
+}
+
+// SetStubPath 设置自定义 stub 目录
+func (g *Generator) SetStubPath(path string) *Generator {
+	g.stubPath = path
+	return g
+}
+
+// StubPath 自定义 stub 目录
+func (g *Generator) StubPath() string {
+	return g.stubPath
+}
+
+// Namespace 应用的 Go 模块路径
+func (g *Generator) Namespace() string {
+	return g.namespace
+}
+
+// Stub 读取 stub 模板，自定义目录中没有时使用内置模板
+func (g *Generator) Stub(name string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(g.stubPath, name))
+	if err == nil {
+		return string(content), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	if stub, ok := defaultStubs[name]; ok {
+		return stub, nil
+	}
+	return "", fmt.Errorf("console: stub [%s] does not exist", name)
+}
+
+// Generate 生成文件，返回相对于基础路径的文件路径
+//
+// 内置占位符：package 为目标目录的包名，class 为类型名称，module 为应用的 Go 模块路径，
+// 其余占位符由 file.Replacements 提供。生成的代码会经过 gofmt 格式化，
+// 目标文件已存在且 force 为 false 时返回 ErrFileExists。
+func (g *Generator) Generate(file File, force bool) (string, error) {
+	class, subdirs, err := parseName(file.Name)
+	if err != nil {
+		return "", err
+	}
+	dir := path.Join(append([]string{filepath.ToSlash(file.Dir)}, subdirs...)...)
+	fileName := file.FileName
+	if fileName == "" {
+		fileName = str.Snake(class)
+	}
+	relative := path.Join(dir, fileName+".go")
+	target := filepath.Join(g.basePath, filepath.FromSlash(relative))
+
+	if _, err := os.Stat(target); err == nil && !force {
+		return relative, fmt.Errorf("%w: %s", ErrFileExists, relative)
+	}
+
+	stub, err := g.Stub(file.Stub)
+	if err != nil {
+		return "", err
+	}
+	replacements := map[string]string{
+		"package": path.Base(dir),
+		"class":   class,
+		"module":  g.namespace,
+	}
+	for key, value := range file.Replacements {
+		replacements[key] = value
+	}
+	source, err := format.Source([]byte(replace(stub, replacements)))
+	if err != nil {
+		return "", fmt.Errorf("console: stub [%s] does not produce valid Go for %s: %w", file.Stub, relative, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+	return relative, os.WriteFile(target, source, 0o644)
+}
+
+// PublishStubs 把内置 stub 导出到自定义目录，已存在的文件只在 force 为 true 时覆盖
+//
+// 返回写入的文件名。
+func (g *Generator) PublishStubs(force bool) ([]string, error) {
+	if err := os.MkdirAll(g.stubPath, 0o755); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(defaultStubs))
+	for name := range defaultStubs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var published []string
+	for _, name := range names {
+		target := filepath.Join(g.stubPath, name)
+		if _, err := os.Stat(target); err == nil && !force {
+			continue
+		}
+		if err := os.WriteFile(target, []byte(defaultStubs[name]), 0o644); err != nil {
+			return published, err
+		}
+		published = append(published, name)
+	}
+	return published, nil
+}
+
+// replace 替换 {{ key }} 和 {{key}} 形式的占位符
+func replace(stub string, replacements map[string]string) string {
+	pairs := make([]string, 0, len(replacements)*4)
+	for key, value := range replacements {
+		pairs = append(pairs, "{{ "+key+" }}", value, "{{"+key+"}}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(stub)
+}
+
+// parseName 解析类型名称，返回首字母大写的类型名和小写的子目录
+func parseName(name string) (string, []string, error) {
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".go")
+	segments := strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' })
+	if len(segments) == 0 {
+		return "", nil, errors.New("console: name is required")
+	}
+	class := str.Ucfirst(segments[len(segments)-1])
+	if !token.IsIdentifier(class) {
+		return "", nil, fmt.Errorf("console: %q is not a valid Go identifier", class)
+	}
+	subdirs := make([]string, 0, len(segments)-1)
+	for _, segment := range segments[:len(segments)-1] {
+		pkg := strings.ReplaceAll(str.Snake(segment), "_", "")
+		if !token.IsIdentifier(pkg) || token.IsKeyword(pkg) {
+			return "", nil, fmt.Errorf("console: %q is not a valid Go package name", segment)
+		}
+		subdirs = append(subdirs, pkg)
+	}
+	return class, subdirs, nil
+}
+
+// modelReplacements 引用模型的 stub 使用的占位符
+func modelReplacements(model string) map[string]string {
+	variable := str.Camel(model)
+	if token.IsKeyword(variable) {
+		variable += "Model"
+	}
+	return map[string]string{
+		"model":         model,
+		"modelVariable": variable,
+		"table":         str.Plural(str.Snake(model)),
+	}
+}
@@ -0,0 +1,111 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/cache"
+)
+
+// defaultIsolationExpiresAt 未实现 Isolatable 时隔离锁的有效期，与 Laravel 一致为一小时
+const defaultIsolationExpiresAt = time.Hour
+
+// ExitCodeError 以指定退出码结束命令
+//
+// 控制台内核在命令返回该错误时应使用 Code 作为退出码；Code 为 0 表示正常退出。
+type ExitCodeError struct {
+	Code    int
+	Message string
+}
+
+// Error 实现 error 接口
+func (e *ExitCodeError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("console: command exited with code %d", e.Code)
+}
+
+// Isolatable 隔离锁配置，对应 Laravel Isolatable 命令中的 isolatableId 和 isolationLockExpiresAt
+type Isolatable interface {
+	// IsolatableID 锁标识，只有标识相同的实例互斥；返回空字符串时整个命令互斥
+	IsolatableID(input application.InputInterface) string
+
+	// IsolationLockExpiresAt 锁的有效期，进程异常退出时锁在到期后自动释放
+	IsolationLockExpiresAt(input application.InputInterface) time.Duration
+}
+
+// Isolate 让命令支持 --isolated 选项，对应 Laravel 的 Isolatable 命令
+//
+// 带 --isolated 运行时先通过 locks 获取名为 "framework/command-{name}" 的锁，
+// 使用共享的缓存（例如 Redis）时锁跨服务器生效。锁已被其他实例持有时输出提示并跳过执行，
+// 默认以 0 退出；--isolated=12 时返回 Code 为 12 的 ExitCodeError。
+// isolatable 为 nil 时整个命令互斥，锁一小时后过期。
+//
+//	console.Isolate(artisan.Register("report:generate"), locks, nil, generateReport)
+func Isolate(command application.CommandInterface, locks cache.LockProvider, isolatable Isolatable, code func(input application.InputInterface, output application.OutputInterface) error) application.CommandInterface {
+	command.AddOption("isolated", "", application.InputOptionValueOptional,
+		"Do not run the command if another instance of the command is already running", false)
+
+	return command.SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+		if !input.HasParameterOption([]string{"--isolated"}, true) {
+			return code(input, output)
+		}
+		exitCode, err := isolatedExitCode(input.GetOption("isolated"))
+		if err != nil {
+			return err
+		}
+
+		name, ttl := "framework/command-"+command.GetName(), defaultIsolationExpiresAt
+		if isolatable != nil {
+			if id := isolatable.IsolatableID(input); id != "" {
+				name += "-" + id
+			}
+			ttl = isolatable.IsolationLockExpiresAt(input)
+		}
+
+		ctx := context.Background()
+		lock := locks.Lock(name, ttl)
+		acquired, err := lock.Get(ctx)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			message := fmt.Sprintf("The [%s] command is already running.", command.GetName())
+			if err := NewComponents(output).Info(message); err != nil {
+				return err
+			}
+			if exitCode != 0 {
+				return &ExitCodeError{Code: exitCode, Message: message}
+			}
+			return nil
+		}
+		defer lock.Release(ctx)
+
+		return code(input, output)
+	})
+}
+
+// isolatedExitCode 解析 --isolated 的值，未带值时为 0
+func isolatedExitCode(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case nil, bool:
+		return 0, nil
+	case int:
+		return v, nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		code, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("console: invalid --isolated exit code %q", v)
+		}
+		return code, nil
+	default:
+		return 0, fmt.Errorf("console: invalid --isolated exit code %v", value)
+	}
+}
@@ -0,0 +1,192 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// 进度条刷新参数
+const (
+	progressBarWidth    = 28
+	progressRedrawEvery = 100 * time.Millisecond
+)
+
+// spinnerFrames 加载动画帧
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// ProgressBar 进度条，可以在多个 goroutine 中调用 Advance
+//
+// 装饰模式下原地刷新（最多每 100ms 一次）；否则每前进 10% 输出一行。
+//
+//	12/40 [=======>--------------------]  30% 3s, ETA 7s
+type ProgressBar struct {
+	mu       sync.Mutex
+	c        *Components
+	total    int
+	current  int
+	started  time.Time
+	redrawn  time.Time
+	printed  int
+	finished bool
+}
+
+// ProgressBar 创建进度条，total 为 0 时不显示百分比和 ETA
+func (c *Components) ProgressBar(total int) *ProgressBar {
+	return &ProgressBar{c: c, total: max(total, 0), printed: -1}
+}
+
+// Start 开始计时并显示进度条
+func (b *ProgressBar) Start() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.started = b.c.now()
+	return b.render(true)
+}
+
+// Advance 前进 step 步，默认为 1
+func (b *ProgressBar) Advance(step ...int) error {
+	n := 1
+	if len(step) > 0 {
+		n = step[0]
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.setProgress(b.current+n, false)
+}
+
+// SetProgress 设置当前进度
+func (b *ProgressBar) SetProgress(current int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.setProgress(current, false)
+}
+
+// Finish 完成进度条，进度设为总数并换行
+func (b *ProgressBar) Finish() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.finished {
+		return nil
+	}
+	current := b.current
+	if b.total > 0 {
+		current = b.total
+	}
+	if err := b.setProgress(current, true); err != nil {
+		return err
+	}
+	b.finished = true
+	if b.c.output.IsDecorated() {
+		return b.c.output.WriteLine("", application.VerbosityNormal)
+	}
+	return nil
+}
+
+// Current 当前进度
+func (b *ProgressBar) Current() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current
+}
+
+// setProgress 更新进度并按需刷新
+func (b *ProgressBar) setProgress(current int, force bool) error {
+	if b.started.IsZero() {
+		b.started = b.c.now()
+	}
+	b.current = max(current, 0)
+	if b.total > 0 {
+		b.current = min(b.current, b.total)
+	}
+	return b.render(force)
+}
+
+// render 输出进度条
+func (b *ProgressBar) render(force bool) error {
+	now := b.c.now()
+	if b.c.output.IsDecorated() {
+		if !force && now.Sub(b.redrawn) < progressRedrawEvery {
+			return nil
+		}
+		b.redrawn = now
+		return b.c.output.Write([]string{"\r\x1b[2K" + b.line(now)}, false, application.VerbosityNormal)
+	}
+
+	step := b.current
+	if b.total > 0 {
+		step = b.current * 10 / b.total
+	}
+	if step == b.printed {
+		return nil
+	}
+	b.printed = step
+	return b.c.output.WriteLine(b.line(now), application.VerbosityNormal)
+}
+
+// line 进度条文本
+func (b *ProgressBar) line(now time.Time) string {
+	elapsed := now.Sub(b.started)
+	if b.total == 0 {
+		return fmt.Sprintf(" %d [%s] %s", b.current, strings.Repeat("-", progressBarWidth), formatElapsed(elapsed))
+	}
+
+	filled := b.current * progressBarWidth / b.total
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat("-", progressBarWidth-filled-1)
+	}
+	digits := len(fmt.Sprint(b.total))
+	line := fmt.Sprintf(" %*d/%d [%s] %3d%% %s", digits, b.current, b.total, bar, b.current*100/b.total, formatElapsed(elapsed))
+	if b.current > 0 && b.current < b.total {
+		eta := time.Duration(float64(elapsed) / float64(b.current) * float64(b.total-b.current))
+		line += ", ETA " + formatElapsed(eta)
+	}
+	return line
+}
+
+// formatElapsed 进度条使用的时长，精确到秒
+func formatElapsed(d time.Duration) string {
+	if d < time.Second {
+		return "< 1s"
+	}
+	return d.Round(time.Second).String()
+}
+
+// Spin 执行 fn 并在等待时显示加载动画，返回 fn 的错误
+//
+// 非装饰模式下只输出一次 message。
+func (c *Components) Spin(message string, fn func() error) error {
+	if !c.output.IsDecorated() {
+		if err := c.Line(message); err != nil {
+			return err
+		}
+		return fn()
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(80 * time.Millisecond)
+		defer ticker.Stop()
+		for frame := 0; ; frame++ {
+			_ = c.output.Write([]string{"\r\x1b[2K  " + c.style(styleYellow, spinnerFrames[frame%len(spinnerFrames)]) + " " + message}, false, application.VerbosityNormal)
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	defer func() {
+		close(done)
+		<-stopped
+		_ = c.output.Write([]string{"\r\x1b[2K"}, false, application.VerbosityNormal)
+	}()
+	return fn()
+}
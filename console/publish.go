@@ -0,0 +1,254 @@
+package console
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/prompts"
+)
+
+// publishManifest 已发布文件清单的位置，相对于应用根目录
+const publishManifest = "bootstrap/cache/published.json"
+
+// PublishedFile 清单中的一条记录
+type PublishedFile struct {
+	Provider    string    `json:"provider"`
+	Source      string    `json:"source"`
+	Tags        []string  `json:"tags,omitempty"`
+	Checksum    string    `json:"checksum"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Publisher 把服务提供者登记的文件复制到应用中，对应 Laravel 的 vendor:publish
+//
+// 每次发布后更新 bootstrap/cache/published.json 清单，记录目标文件的来源和校验和，
+// 键为相对于应用根目录的目标路径。
+type Publisher struct {
+	basePath string
+	now      func() time.Time
+}
+
+// NewPublisher 创建发布器
+func NewPublisher(basePath string) *Publisher {
+	return &Publisher{basePath: basePath, now: time.Now}
+}
+
+// Publish 发布一项，返回写入的目标文件（相对路径）和因已存在而跳过的文件
+//
+// 目标文件已存在时，force 为 true 直接覆盖；否则调用 overwrite 询问，overwrite 为 nil 时跳过。
+func (p *Publisher) Publish(item container.Publishable, force bool, overwrite func(target string) (bool, error)) (published, skipped []string, err error) {
+	manifest, err := p.Manifest()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if len(published) > 0 {
+			if saveErr := p.saveManifest(manifest); saveErr != nil && err == nil {
+				err = saveErr
+			}
+		}
+	}()
+
+	err = fs.WalkDir(item.FS, item.Source, func(name string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		target := item.Target
+		if name != item.Source {
+			target = path.Join(item.Target, strings.TrimPrefix(name, item.Source+"/"))
+		}
+		destination := filepath.Join(p.basePath, filepath.FromSlash(target))
+
+		if _, statErr := os.Stat(destination); statErr == nil && !force {
+			ok := false
+			if overwrite != nil {
+				var askErr error
+				if ok, askErr = overwrite(target); askErr != nil {
+					return askErr
+				}
+			}
+			if !ok {
+				skipped = append(skipped, target)
+				return nil
+			}
+		}
+
+		content, readErr := fs.ReadFile(item.FS, name)
+		if readErr != nil {
+			return readErr
+		}
+		if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(destination, content, 0o644); err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		manifest[target] = PublishedFile{
+			Provider:    item.Provider,
+			Source:      name,
+			Tags:        item.Tags,
+			Checksum:    hex.EncodeToString(sum[:]),
+			PublishedAt: p.now().UTC(),
+		}
+		published = append(published, target)
+		return nil
+	})
+	return published, skipped, err
+}
+
+// Manifest 读取已发布文件清单，清单不存在时返回空清单
+func (p *Publisher) Manifest() (map[string]PublishedFile, error) {
+	manifest := make(map[string]PublishedFile)
+	content, err := os.ReadFile(filepath.Join(p.basePath, publishManifest))
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("console: invalid publish manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// saveManifest 写入已发布文件清单
+func (p *Publisher) saveManifest(manifest map[string]PublishedFile) error {
+	content, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(p.basePath, publishManifest)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, append(content, '\n'), 0o644)
+}
+
+// RegisterPublishCommand 注册 vendor:publish 命令
+//
+//	vendor:publish --provider=billing.ServiceProvider   发布指定提供者的所有文件
+//	vendor:publish --tag=billing-config --tag=views     发布指定标签的文件
+//	vendor:publish --all                                发布所有文件
+//
+// 未指定时交互式选择。目标文件已存在时，--force 覆盖，交互模式下逐个询问，否则跳过。
+func RegisterPublishCommand(artisan application.ArtisanInterface, publisher *Publisher) {
+	artisan.Register("vendor:publish").
+		SetDescription("Publish any publishable assets from service providers").
+		AddOption("provider", "", application.InputOptionValueRequired, "The service provider that has assets you want to publish", "").
+		AddOption("tag", "", application.InputOptionValueRequired|application.InputOptionValueIsArray, "One or many tags that have assets you want to publish", []string{}).
+		AddOption("all", "", application.InputOptionValueNone, "Publish assets for all service providers without prompt", false).
+		AddOption("force", "", application.InputOptionValueNone, "Overwrite any existing files", false).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			components := NewComponents(output)
+			prompter := prompts.ForCommand(input, output)
+
+			provider := stringOption(input, "provider")
+			tags, _ := input.GetOption("tag").([]string)
+			if provider == "" && len(tags) == 0 && !boolOption(input, "all") {
+				var err error
+				if provider, tags, err = choosePublishable(prompter); err != nil {
+					return err
+				}
+			}
+
+			var items []container.Publishable
+			if len(tags) == 0 {
+				items = container.PathsToPublish(provider, "")
+			}
+			for _, tag := range tags {
+				items = appendPublishable(items, container.PathsToPublish(provider, tag))
+			}
+			if len(items) == 0 {
+				if len(tags) > 0 {
+					return components.Info("No publishable resources for tag [" + strings.Join(tags, ", ") + "].")
+				}
+				return components.Info("No publishable resources for provider [" + provider + "].")
+			}
+
+			var overwrite func(string) (bool, error)
+			if prompter.IsInteractive() {
+				overwrite = func(target string) (bool, error) {
+					return prompter.Confirm("The ["+target+"] file already exists. Do you want to overwrite it?", false)
+				}
+			}
+			force := boolOption(input, "force")
+			for _, item := range items {
+				published, skipped, err := publisher.Publish(item, force, overwrite)
+				for _, target := range published {
+					if err := components.TwoColumnDetail("Copying ["+item.Provider+"] to ["+target+"]", "DONE"); err != nil {
+						return err
+					}
+				}
+				for _, target := range skipped {
+					if err := components.TwoColumnDetail("File ["+target+"] already exists", "SKIPPED"); err != nil {
+						return err
+					}
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return components.Info("Publishing complete.")
+		})
+}
+
+// choosePublishable 交互式选择要发布的提供者或标签，非交互模式下返回错误
+func choosePublishable(prompter *prompts.Prompter) (string, []string, error) {
+	const all = "All providers and tags"
+	choices := []string{all}
+	for _, provider := range container.PublishableProviders() {
+		choices = append(choices, "Provider: "+provider)
+	}
+	for _, tag := range container.PublishableTags() {
+		choices = append(choices, "Tag: "+tag)
+	}
+	if !prompter.IsInteractive() {
+		return "", nil, errors.New("console: specify --provider, --tag or --all when running without interaction")
+	}
+	choice, err := prompter.Choice("Which provider or tag's files would you like to publish?", choices, prompts.Default(all))
+	if err != nil {
+		return "", nil, err
+	}
+	switch {
+	case strings.HasPrefix(choice, "Provider: "):
+		return strings.TrimPrefix(choice, "Provider: "), nil, nil
+	case strings.HasPrefix(choice, "Tag: "):
+		return "", []string{strings.TrimPrefix(choice, "Tag: ")}, nil
+	}
+	return "", nil, nil
+}
+
+// appendPublishable 合并发布项，去掉重复的目标路径并保持按目标路径排序
+func appendPublishable(items, more []container.Publishable) []container.Publishable {
+	for _, item := range more {
+		duplicate := false
+		for _, existing := range items {
+			if existing.Provider == item.Provider && existing.Target == item.Target {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Target < items[j].Target })
+	return items
+}
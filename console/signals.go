@@ -0,0 +1,63 @@
+package console
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// ListenForSignals 为实现了 application.SignalableCommand 的命令监听信号，返回取消监听的函数
+//
+// 控制台内核在执行命令前调用，命令结束后调用返回的函数；其他命令返回空函数。
+//
+//	defer console.ListenForSignals(command)()
+//	code, err := command.Run(input, output)
+func ListenForSignals(command application.CommandInterface) func() {
+	signalable, ok := command.(application.SignalableCommand)
+	if !ok {
+		return func() {}
+	}
+	return Trap(signalable.GetSubscribedSignals(), signalable.HandleSignal)
+}
+
+// Trap 监听信号并交给 handler 处理，返回取消监听的函数，对应 Laravel 命令中的 $this->trap
+//
+// 闭包命令没有自己的类型，可以在执行函数中直接使用：
+//
+//	defer console.Trap([]os.Signal{os.Interrupt, syscall.SIGTERM}, func(os.Signal) {
+//		worker.Stop()
+//	})()
+//
+// handler 在独立的 goroutine 中按收到的顺序调用；取消监听时等待正在执行的 handler 返回，
+// 之后信号恢复默认行为。signals 为空时返回空函数，不会监听所有信号。
+func Trap(signals []os.Signal, handler func(os.Signal)) func() {
+	if len(signals) == 0 {
+		return func() {}
+	}
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case sig := <-received:
+				handler(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+			<-stopped
+		})
+	}
+}
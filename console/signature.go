@@ -0,0 +1,211 @@
+package console
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+var (
+	// signatureName 签名开头的命令名
+	signatureName = regexp.MustCompile(`^\s*([^\s{]+)`)
+
+	// signatureToken 花括号中的参数或选项
+	signatureToken = regexp.MustCompile(`\{\s*(.*?)\s*\}`)
+
+	// signatureDescription 参数或选项与描述之间的分隔
+	signatureDescription = regexp.MustCompile(`\s+:\s+`)
+
+	// arrayDefault 带默认值的数组 "name=*a,b"
+	arrayDefault = regexp.MustCompile(`^(.+)=\*(.+)$`)
+
+	// valueDefault 带默认值 "name=value"
+	valueDefault = regexp.MustCompile(`^(.+)=(.+)$`)
+)
+
+// Signature 解析后的命令签名，对应 Laravel 的 Console\Parser
+type Signature struct {
+	// Name 命令名
+	Name string
+
+	// Arguments 参数，按声明顺序
+	Arguments []application.InputArgument
+
+	// Options 选项，按声明顺序
+	Options []application.InputOption
+}
+
+// ParseSignature 解析 Laravel 风格的命令签名
+//
+// 参数：
+//
+//	{user}             必需参数
+//	{user?}            可选参数
+//	{user=foo}         带默认值的可选参数
+//	{user*}            必需的数组参数，接收剩余的所有值
+//	{user?*}           可选的数组参数
+//	{user=*foo,bar}    带默认值的数组参数
+//
+// 选项：
+//
+//	{--queue}          开关，默认为 false
+//	{--queue=}         带值的选项，默认为 ""
+//	{--queue=default}  带默认值的选项
+//	{--queue=*}        可以多次指定的选项
+//	{--Q|queue}        带快捷方式的选项
+//
+// 参数和选项后可以用 " : " 跟描述，例如 {user : The ID of the user}。
+func ParseSignature(signature string) (*Signature, error) {
+	name := signatureName.FindStringSubmatch(signature)
+	if name == nil {
+		return nil, fmt.Errorf("console: unable to determine command name from signature %q", signature)
+	}
+	s := &Signature{Name: name[1]}
+
+	seen := make(map[string]bool)
+	optional, array := false, false
+	for _, match := range signatureToken.FindAllStringSubmatch(signature, -1) {
+		token, description := match[1], ""
+		if parts := signatureDescription.Split(token, 2); len(parts) == 2 {
+			token, description = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		}
+
+		if strings.HasPrefix(token, "--") {
+			option, err := parseOption(strings.TrimPrefix(token, "--"), description)
+			if err != nil {
+				return nil, fmt.Errorf("console: signature %q: %w", signature, err)
+			}
+			if seen["--"+option.Name] {
+				return nil, fmt.Errorf("console: signature %q: duplicate option %q", signature, option.Name)
+			}
+			seen["--"+option.Name] = true
+			s.Options = append(s.Options, option)
+			continue
+		}
+
+		argument, err := parseArgument(token, description)
+		if err != nil {
+			return nil, fmt.Errorf("console: signature %q: %w", signature, err)
+		}
+		switch {
+		case seen[argument.Name]:
+			return nil, fmt.Errorf("console: signature %q: duplicate argument %q", signature, argument.Name)
+		case array:
+			return nil, fmt.Errorf("console: signature %q: argument %q is declared after an array argument", signature, argument.Name)
+		case optional && argument.Mode&application.InputArgumentRequired != 0:
+			return nil, fmt.Errorf("console: signature %q: required argument %q is declared after an optional argument", signature, argument.Name)
+		}
+		seen[argument.Name] = true
+		optional = optional || argument.Mode&application.InputArgumentRequired == 0
+		array = argument.Mode&application.InputArgumentIsArray != 0
+		s.Arguments = append(s.Arguments, argument)
+	}
+	return s, nil
+}
+
+// Apply 把参数和选项添加到命令
+func (s *Signature) Apply(command application.CommandInterface) application.CommandInterface {
+	for _, argument := range s.Arguments {
+		command.AddArgument(argument.Name, argument.Mode, argument.Description, argument.Default)
+	}
+	for _, option := range s.Options {
+		command.AddOption(option.Name, option.Shortcut, option.Mode, option.Description, option.Default)
+	}
+	return command
+}
+
+// Command 按签名注册闭包命令，对应 Laravel 的 Artisan::command
+//
+// 签名无效时 panic，命令通常在启动时注册，与 regexp.MustCompile 一致。
+//
+//	console.Command(artisan, "mail:send {user : The ID of the user} {--Q|queue= : The queue to use}",
+//		func(input application.InputInterface, output application.OutputInterface) error {
+//			user := input.GetArgument("user").(string)
+//			queue := input.GetOption("queue").(string)
+//			...
+//		}).SetDescription("Send a marketing email to a user")
+func Command(artisan application.ArtisanInterface, signature string, code func(input application.InputInterface, output application.OutputInterface) error) application.CommandInterface {
+	parsed, err := ParseSignature(signature)
+	if err != nil {
+		panic(err)
+	}
+	return parsed.Apply(artisan.Register(parsed.Name)).SetCode(code)
+}
+
+// parseArgument 解析参数
+func parseArgument(token, description string) (application.InputArgument, error) {
+	argument := application.InputArgument{Description: description}
+	switch {
+	case strings.HasSuffix(token, "?*"):
+		argument.Name, argument.Mode = strings.TrimSuffix(token, "?*"), application.InputArgumentIsArray
+	case strings.HasSuffix(token, "*"):
+		argument.Name, argument.Mode = strings.TrimSuffix(token, "*"), application.InputArgumentIsArray|application.InputArgumentRequired
+	case strings.HasSuffix(token, "?"):
+		argument.Name, argument.Mode = strings.TrimSuffix(token, "?"), application.InputArgumentOptional
+	default:
+		if m := arrayDefault.FindStringSubmatch(token); m != nil {
+			argument.Name, argument.Mode, argument.Default = m[1], application.InputArgumentIsArray, splitDefaults(m[2])
+		} else if m := valueDefault.FindStringSubmatch(token); m != nil {
+			argument.Name, argument.Mode, argument.Default = m[1], application.InputArgumentOptional, m[2]
+		} else {
+			argument.Name, argument.Mode = token, application.InputArgumentRequired
+		}
+	}
+	if !validInputName(argument.Name) {
+		return argument, fmt.Errorf("invalid argument name %q", argument.Name)
+	}
+	return argument, nil
+}
+
+// parseOption 解析选项（不含 "--" 前缀）
+func parseOption(token, description string) (application.InputOption, error) {
+	option := application.InputOption{Description: description}
+	if shortcut, rest, ok := strings.Cut(token, "|"); ok {
+		option.Shortcut, token = strings.TrimSpace(shortcut), strings.TrimSpace(rest)
+		if !validInputName(option.Shortcut) {
+			return option, fmt.Errorf("invalid shortcut %q", option.Shortcut)
+		}
+	}
+	switch {
+	case strings.HasSuffix(token, "=*"):
+		option.Name, option.Mode = strings.TrimSuffix(token, "=*"), application.InputOptionValueOptional|application.InputOptionValueIsArray
+	case strings.HasSuffix(token, "="):
+		option.Name, option.Mode, option.Default = strings.TrimSuffix(token, "="), application.InputOptionValueOptional, ""
+	default:
+		if m := arrayDefault.FindStringSubmatch(token); m != nil {
+			option.Name, option.Mode, option.Default = m[1], application.InputOptionValueOptional|application.InputOptionValueIsArray, splitDefaults(m[2])
+		} else if m := valueDefault.FindStringSubmatch(token); m != nil {
+			option.Name, option.Mode, option.Default = m[1], application.InputOptionValueOptional, m[2]
+		} else {
+			option.Name, option.Mode, option.Default = token, application.InputOptionValueNone, false
+		}
+	}
+	if !validInputName(option.Name) {
+		return option, fmt.Errorf("invalid option name %q", option.Name)
+	}
+	return option, nil
+}
+
+// splitDefaults 拆分逗号分隔的数组默认值
+func splitDefaults(value string) []string {
+	parts := strings.Split(value, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return parts
+}
+
+// validInputName 参数和选项名只能包含字母、数字、"-"、"_" 和 ":"
+func validInputName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '-' || r == '_' || r == ':' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,371 @@
+package console
+
+// defaultStubs 内置 stub 模板，文件名与 Laravel 的 stubs 目录一致
+//
+// 占位符写作 {{ name }} 或 {{name}}，可用的占位符见 Generator.Generate。
+var defaultStubs = map[string]string{
+	"controller.plain.stub": `package {{ package }}
+
+// {{ class }} 控制器
+type {{ class }} struct{}
+`,
+
+	"controller.stub": `package {{ package }}
+
+import "net/http"
+
+// {{ class }} 资源控制器
+type {{ class }} struct{}
+
+// Index 列表
+func (c *{{ class }}) Index(w http.ResponseWriter, r *http.Request) {
+}
+
+// Create 创建表单
+func (c *{{ class }}) Create(w http.ResponseWriter, r *http.Request) {
+}
+
+// Store 保存新资源
+func (c *{{ class }}) Store(w http.ResponseWriter, r *http.Request) {
+}
+
+// Show 显示资源
+func (c *{{ class }}) Show(w http.ResponseWriter, r *http.Request) {
+}
+
+// Edit 编辑表单
+func (c *{{ class }}) Edit(w http.ResponseWriter, r *http.Request) {
+}
+
+// Update 更新资源
+func (c *{{ class }}) Update(w http.ResponseWriter, r *http.Request) {
+}
+
+// Destroy 删除资源
+func (c *{{ class }}) Destroy(w http.ResponseWriter, r *http.Request) {
+}
+`,
+
+	"controller.api.stub": `package {{ package }}
+
+import "net/http"
+
+// {{ class }} API 资源控制器
+type {{ class }} struct{}
+
+// Index 列表
+func (c *{{ class }}) Index(w http.ResponseWriter, r *http.Request) {
+}
+
+// Store 保存新资源
+func (c *{{ class }}) Store(w http.ResponseWriter, r *http.Request) {
+}
+
+// Show 显示资源
+func (c *{{ class }}) Show(w http.ResponseWriter, r *http.Request) {
+}
+
+// Update 更新资源
+func (c *{{ class }}) Update(w http.ResponseWriter, r *http.Request) {
+}
+
+// Destroy 删除资源
+func (c *{{ class }}) Destroy(w http.ResponseWriter, r *http.Request) {
+}
+`,
+
+	"model.stub": `package {{ package }}
+
+import "github.com/cnote0/laraveldoc/database"
+
+// {{ class }} 模型
+type {{ class }} struct {
+	database.Model
+}
+
+// TableName 表名
+func ({{ class }}) TableName() string {
+	return "{{ table }}"
+}
+`,
+
+	"migration.create.stub": `package {{ package }}
+
+import (
+	"github.com/cnote0/laraveldoc/database"
+
+	"{{ module }}/app/models"
+)
+
+// {{ class }} 创建 {{ table }} 表
+type {{ class }} struct{}
+
+// Name 迁移名称
+func ({{ class }}) Name() string {
+	return "{{ migration }}"
+}
+
+// Up 执行迁移
+func ({{ class }}) Up(db database.DB) error {
+	return db.Migrator().CreateTable(&models.{{ model }}{})
+}
+
+// Down 回滚迁移
+func ({{ class }}) Down(db database.DB) error {
+	return db.Migrator().DropTable(&models.{{ model }}{})
+}
+`,
+
+	"factory.stub": `package {{ package }}
+
+import (
+	"context"
+
+	"github.com/cnote0/laraveldoc/database"
+
+	"{{ module }}/app/models"
+)
+
+// {{ class }} {{ model }} 模型工厂
+type {{ class }} struct {
+	count  int
+	states []func({{ modelVariable }} *models.{{ model }})
+}
+
+// New{{ class }} 创建模型工厂
+func New{{ class }}() *{{ class }} {
+	return &{{ class }}{count: 1}
+}
+
+// Definition 模型的默认属性
+func (f *{{ class }}) Definition() models.{{ model }} {
+	return models.{{ model }}{}
+}
+
+// Count 设置创建的数量
+func (f *{{ class }}) Count(count int) *{{ class }} {
+	f.count = count
+	return f
+}
+
+// State 追加修改属性的状态
+func (f *{{ class }}) State(state func({{ modelVariable }} *models.{{ model }})) *{{ class }} {
+	f.states = append(f.states, state)
+	return f
+}
+
+// Make 创建模型但不保存
+func (f *{{ class }}) Make() []*models.{{ model }} {
+	items := make([]*models.{{ model }}, 0, f.count)
+	for i := 0; i < f.count; i++ {
+		{{ modelVariable }} := f.Definition()
+		for _, apply := range f.states {
+			apply(&{{ modelVariable }})
+		}
+		items = append(items, &{{ modelVariable }})
+	}
+	return items
+}
+
+// Create 创建并保存模型
+func (f *{{ class }}) Create(ctx context.Context, db database.DB) ([]*models.{{ model }}, error) {
+	items := f.Make()
+	if err := db.WithContext(ctx).Create(&items).Error(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+`,
+
+	"seeder.stub": `package {{ package }}
+
+import (
+	"context"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// {{ class }} 数据填充
+type {{ class }} struct{}
+
+// Run 填充数据
+func (s *{{ class }}) Run(ctx context.Context, db database.DB) error {
+	return nil
+}
+`,
+
+	"middleware.stub": `package {{ package }}
+
+import "net/http"
+
+// {{ class }} 中间件
+func {{ class }}(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+	})
+}
+`,
+
+	"job.stub": `package {{ package }}
+
+import (
+	"context"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// {{ class }} 队列任务，投递前需要注册到 queue.Registry
+type {{ class }} struct {
+	queue.Queued
+}
+
+// Handle 执行任务
+func (j *{{ class }}) Handle(ctx context.Context) error {
+	return nil
+}
+`,
+
+	"event.stub": `package {{ package }}
+
+// {{ class }} 事件
+type {{ class }} struct{}
+`,
+
+	"listener.stub": `package {{ package }}
+
+// {{ class }} 事件监听器
+type {{ class }} struct{}
+
+// Handle 处理事件，签名与 application.EventListener 一致
+func (l *{{ class }}) Handle(event interface{}) error {
+	return nil
+}
+`,
+
+	"listener.typed.stub": `package {{ package }}
+
+import (
+	"context"
+
+	"{{ module }}/app/events"
+)
+
+// {{ class }} {{ event }} 事件监听器
+//
+// laraveldoc-gen events 根据 Handle 的参数类型把监听器登记到清单，
+// EventServiceProvider.DiscoverEventsWithin 包含本包时自动注册。
+type {{ class }} struct{}
+
+// Handle 处理 {{ event }} 事件
+func (l *{{ class }}) Handle(ctx context.Context, event *events.{{ event }}) error {
+	return nil
+}
+`,
+
+	"provider.stub": `package {{ package }}
+
+import "github.com/cnote0/laraveldoc/container"
+
+// {{ class }} 服务提供者
+type {{ class }} struct{}
+
+// Register 注册服务
+func (p *{{ class }}) Register(c container.Container) error {
+	return nil
+}
+
+// Boot 引导服务
+func (p *{{ class }}) Boot(c container.Container) error {
+	return nil
+}
+
+// Provides 提供的服务
+func (p *{{ class }}) Provides() []string {
+	return nil
+}
+
+// IsDeferred 是否延迟加载
+func (p *{{ class }}) IsDeferred() bool {
+	return false
+}
+`,
+
+	"policy.plain.stub": `package {{ package }}
+
+// {{ class }} 授权策略
+type {{ class }} struct{}
+`,
+
+	"policy.stub": `package {{ package }}
+
+import (
+	"github.com/cnote0/laraveldoc/auth"
+
+	"{{ module }}/app/models"
+)
+
+// {{ class }} {{ model }} 授权策略
+type {{ class }} struct{}
+
+// ViewAny 是否可以查看列表
+func (p *{{ class }}) ViewAny(user auth.Authenticatable) bool {
+	return false
+}
+
+// View 是否可以查看
+func (p *{{ class }}) View(user auth.Authenticatable, {{ modelVariable }} *models.{{ model }}) bool {
+	return false
+}
+
+// Create 是否可以创建
+func (p *{{ class }}) Create(user auth.Authenticatable) bool {
+	return false
+}
+
+// Update 是否可以更新
+func (p *{{ class }}) Update(user auth.Authenticatable, {{ modelVariable }} *models.{{ model }}) bool {
+	return false
+}
+
+// Delete 是否可以删除
+func (p *{{ class }}) Delete(user auth.Authenticatable, {{ modelVariable }} *models.{{ model }}) bool {
+	return false
+}
+
+// Restore 是否可以恢复
+func (p *{{ class }}) Restore(user auth.Authenticatable, {{ modelVariable }} *models.{{ model }}) bool {
+	return false
+}
+
+// ForceDelete 是否可以永久删除
+func (p *{{ class }}) ForceDelete(user auth.Authenticatable, {{ modelVariable }} *models.{{ model }}) bool {
+	return false
+}
+`,
+
+	"request.stub": `package {{ package }}
+
+import (
+	"net/http"
+
+	"github.com/cnote0/laraveldoc/exceptions"
+)
+
+// {{ class }} 表单请求
+type {{ class }} struct{}
+
+// Authorize 当前用户是否可以发起请求
+func (f *{{ class }}) Authorize(r *http.Request) bool {
+	return false
+}
+
+// Validate 校验请求数据，失败时返回 *exceptions.ValidationError
+func (f *{{ class }}) Validate() error {
+	errors := map[string][]string{}
+	if len(errors) > 0 {
+		return exceptions.NewValidationError(errors)
+	}
+	return nil
+}
+`,
+}
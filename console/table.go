@@ -0,0 +1,88 @@
+package console
+
+import "strings"
+
+// Alignment 表格列对齐方式
+type Alignment int
+
+const (
+	// AlignLeft 左对齐
+	AlignLeft Alignment = iota
+
+	// AlignRight 右对齐，适合数字列
+	AlignRight
+
+	// AlignCenter 居中
+	AlignCenter
+)
+
+// Table 输出表格，格式与 Symfony Console 的默认表格一致
+//
+// alignments 依次为每列的对齐方式，未指定的列左对齐；行的单元格少于表头时补空。
+//
+//	+----+-------+--------+
+//	| ID | Name  | Orders |
+//	+----+-------+--------+
+//	| 1  | Alice |     12 |
+//	+----+-------+--------+
+func (c *Components) Table(headers []string, rows [][]string, alignments ...Alignment) error {
+	columns := len(headers)
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	widths := make([]int, columns)
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+
+	separator := "+"
+	for _, width := range widths {
+		separator += strings.Repeat("-", width+2) + "+"
+	}
+	line := func(cells []string, header bool) string {
+		var b strings.Builder
+		b.WriteString("|")
+		for i, width := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			align := AlignLeft
+			if i < len(alignments) && !header {
+				align = alignments[i]
+			}
+			text := pad(cell, width, align)
+			if header {
+				text = c.style(styleGreen, text)
+			}
+			b.WriteString(" " + text + " |")
+		}
+		return b.String()
+	}
+
+	lines := []string{separator}
+	if len(headers) > 0 {
+		lines = append(lines, line(headers, true), separator)
+	}
+	for _, row := range rows {
+		lines = append(lines, line(row, false))
+	}
+	if len(rows) > 0 {
+		lines = append(lines, separator)
+	}
+	return c.writeLines(lines...)
+}
+
+// pad 按对齐方式填充到指定显示宽度
+func pad(text string, width int, align Alignment) string {
+	gap := max(width-displayWidth(text), 0)
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", gap) + text
+	case AlignCenter:
+		return strings.Repeat(" ", gap/2) + text + strings.Repeat(" ", gap-gap/2)
+	}
+	return text + strings.Repeat(" ", gap)
+}
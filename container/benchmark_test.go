@@ -0,0 +1,95 @@
+package container_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/support/benchmark"
+)
+
+// 每次解析的内存分配预算，超出时 TestAllocationBudget 失败
+const (
+	// resolveBudget 解析已共享的实例不应分配
+	resolveBudget = 0
+
+	// contextResolveBudget MakeWithContext 不应在容器之外增加分配
+	contextResolveBudget = 0
+)
+
+// instances 只保存共享实例的容器，只实现 Make 和 MakeWithContext
+type instances struct {
+	container.Container
+	shared map[interface{}]interface{}
+}
+
+// Make 实现 container.Container
+func (c *instances) Make(abstract interface{}) (interface{}, error) {
+	if instance, ok := c.shared[abstract]; ok {
+		return instance, nil
+	}
+	return nil, container.NewBindingNotFoundError(abstract)
+}
+
+// MakeWithContext 实现 container.ContextResolver
+func (c *instances) MakeWithContext(ctx context.Context, abstract interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Make(abstract)
+}
+
+// makeOnly 只有 Make 方法的解析器，MakeWithContext 走回退路径
+type makeOnly struct {
+	c *instances
+}
+
+// Make 解析服务
+func (r makeOnly) Make(abstract interface{}) (interface{}, error) {
+	return r.c.Make(abstract)
+}
+
+// cacheStore 用于解析的服务
+type cacheStore struct{}
+
+// resolveSuite 每个请求都会经过的解析用例
+func resolveSuite() *benchmark.Suite {
+	c := &instances{shared: map[interface{}]interface{}{"cache": &cacheStore{}}}
+	ctx := context.Background()
+	return benchmark.NewSuite(
+		benchmark.ContainerResolve("resolve cache", c, "cache", resolveBudget),
+		benchmark.Case{
+			Name: "resolve cache with context",
+			Run: func() {
+				if _, err := container.MakeWithContext(ctx, c, "cache"); err != nil {
+					panic(err)
+				}
+			},
+			MaxAllocs: contextResolveBudget,
+		},
+		benchmark.Case{
+			Name: "resolve cache with context fallback",
+			Run: func() {
+				if _, err := container.MakeWithContext(ctx, makeOnly{c: c}, "cache"); err != nil {
+					panic(err)
+				}
+			},
+			MaxAllocs: contextResolveBudget,
+		},
+	)
+}
+
+func TestAllocationBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation budget runs each case for the default benchmark time")
+	}
+	report := resolveSuite().Run()
+	t.Log("\n" + report.String())
+	if err := report.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkResolve(b *testing.B) {
+	resolveSuite().Benchmark(b)
+}
@@ -9,6 +9,8 @@
 // - 上下文绑定
 // - 依赖注入
 // - 服务提供者模式
+// - 服务提供者的可发布文件（vendor:publish）
+// - 带上下文解析（可选接口 ContextResolver 和 MakeWithContext），工厂函数可以接收请求的截止时间和追踪信息
 //
 // 包结构：
 // - container_interface.go - Container 核心接口
@@ -16,6 +18,9 @@
 // - resolver.go - Resolver 依赖解析器接口
 // - contextual_binding.go - ContextualBinding 上下文绑定接口
 // - binding.go - Binding 绑定信息结构体
+// - publish.go - 服务提供者的可发布文件登记
+// - errors.go - ErrBindingNotFound 和 BindingResolutionError 解析错误
+// - context.go - ContextResolver 和 MakeWithContext 带上下文解析
 //
 // 使用示例：
 //
@@ -87,7 +87,8 @@ type Container interface {
 
 	// Make 解析服务
 	//
-	// 从容器中解析指定的服务，如果服务未绑定或解析失败，返回错误。
+	// 从容器中解析指定的服务，如果服务未绑定或解析失败，返回 *BindingResolutionError，
+	// 未绑定时 errors.Is(err, ErrBindingNotFound) 成立。
 	//
 	// 示例：
 	//   mailer, err := container.Make("mailer")
@@ -0,0 +1,36 @@
+package container
+
+import "context"
+
+// ContextResolver 支持带上下文解析的容器，是 Container 之外的可选接口
+//
+// 实现了本接口的容器在工厂函数声明为 func(ctx context.Context, c Container) interface{} 时传入 ctx，
+// 可以把截止时间和追踪信息传给创建连接等耗时操作；ctx 已取消时返回 ctx.Err()。
+// Make 等价于以 context.Background() 调用 MakeWithContext。
+// 调用方通过包级函数 MakeWithContext 解析，不需要关心容器是否实现了本接口。
+//
+// 示例：
+//
+//	c.Bind("report.client", func(ctx context.Context, c container.Container) interface{} {
+//		return reports.Dial(ctx, c.MustMake("config").(Config).Get("reports.url", "").(string))
+//	}, false)
+//	client, err := container.MakeWithContext(r.Context(), c, "report.client")
+type ContextResolver interface {
+	MakeWithContext(ctx context.Context, abstract interface{}) (interface{}, error)
+}
+
+// MakeWithContext 带上下文从 resolver 解析服务
+//
+// resolver 实现了 ContextResolver 时调用其 MakeWithContext；
+// 否则（例如只有 Make 方法的请求级容器）在 ctx 未取消时调用 Make。
+func MakeWithContext(ctx context.Context, resolver interface {
+	Make(abstract interface{}) (interface{}, error)
+}, abstract interface{}) (interface{}, error) {
+	if r, ok := resolver.(ContextResolver); ok {
+		return r.MakeWithContext(ctx, abstract)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return resolver.Make(abstract)
+}
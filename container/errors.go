@@ -0,0 +1,44 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrBindingNotFound 服务没有绑定，用于 errors.Is 判断
+var ErrBindingNotFound = errors.New("container: binding not found")
+
+// BindingResolutionError 服务解析失败，对应 Laravel 的 BindingResolutionException
+//
+// 服务没有绑定时 Err 为 ErrBindingNotFound；工厂函数或构造函数失败时 Err 为其错误。
+// Container 的实现应从 Make、MakeWith 返回本类型的错误，调用方用 errors.Is 或 errors.As 区分原因：
+//
+//	service, err := c.Make("mailer")
+//	if errors.Is(err, container.ErrBindingNotFound) {
+//		service = &LogMailer{}
+//	}
+type BindingResolutionError struct {
+	// Abstract 解析的服务标识符
+	Abstract interface{}
+
+	// Err 失败原因
+	Err error
+}
+
+// NewBindingNotFoundError 服务没有绑定的解析错误
+func NewBindingNotFoundError(abstract interface{}) *BindingResolutionError {
+	return &BindingResolutionError{Abstract: abstract, Err: ErrBindingNotFound}
+}
+
+// Error 实现 error 接口
+func (e *BindingResolutionError) Error() string {
+	if errors.Is(e.Err, ErrBindingNotFound) {
+		return fmt.Sprintf("container: %v is not bound", e.Abstract)
+	}
+	return fmt.Sprintf("container: resolve %v: %v", e.Abstract, e.Err)
+}
+
+// Unwrap 返回失败原因
+func (e *BindingResolutionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,147 @@
+package container
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Publishable 服务提供者可发布到应用中的文件或目录
+type Publishable struct {
+	// Provider 服务提供者名称，见 ProviderName
+	Provider string
+
+	// FS 源文件所在的文件系统
+	FS fs.FS
+
+	// Source FS 中的源路径，可以是文件或目录
+	Source string
+
+	// Target 相对于应用根目录的目标路径
+	Target string
+
+	// Tags 分组标签，例如 "config"、"migrations"、"views"
+	Tags []string
+}
+
+var (
+	publishMu    sync.RWMutex
+	publishables []Publishable
+)
+
+// Publishes 登记服务提供者可发布的文件或目录，对应 Laravel 的 ServiceProvider::publishes
+//
+// paths 的键为源路径（文件系统路径），值为相对于应用根目录的目标路径；tags 为分组标签，
+// vendor:publish --tag 按标签发布。通常在提供者的 Boot 方法中调用：
+//
+//	func (p *BillingServiceProvider) Boot(c container.Container) error {
+//		container.Publishes(p, map[string]string{
+//			"/path/to/billing/config/billing.go": "config/billing.go",
+//		}, "billing-config")
+//		return nil
+//	}
+func Publishes(provider ServiceProvider, paths map[string]string, tags ...string) {
+	for source, target := range paths {
+		source = filepath.Clean(source)
+		register(provider, os.DirFS(filepath.Dir(source)), filepath.Base(source), target, tags)
+	}
+}
+
+// PublishesFS 登记 fsys 中可发布的文件或目录，适合通过 embed.FS 随包分发的配置、迁移和视图
+//
+//	//go:embed stubs/config.go migrations
+//	var assets embed.FS
+//
+//	container.PublishesFS(p, assets, map[string]string{
+//		"migrations": "database/migrations",
+//	}, "billing-migrations")
+func PublishesFS(provider ServiceProvider, fsys fs.FS, paths map[string]string, tags ...string) {
+	for source, target := range paths {
+		register(provider, fsys, source, target, tags)
+	}
+}
+
+// register 登记一个发布项，同一提供者重复登记相同的目标路径时合并标签
+func register(provider ServiceProvider, fsys fs.FS, source, target string, tags []string) {
+	name := ProviderName(provider)
+	publishMu.Lock()
+	defer publishMu.Unlock()
+	for i, p := range publishables {
+		if p.Provider == name && p.Target == target {
+			for _, tag := range tags {
+				if !slices.Contains(p.Tags, tag) {
+					publishables[i].Tags = append(publishables[i].Tags, tag)
+				}
+			}
+			publishables[i].FS, publishables[i].Source = fsys, source
+			return
+		}
+	}
+	publishables = append(publishables, Publishable{
+		Provider: name,
+		FS:       fsys,
+		Source:   source,
+		Target:   target,
+		Tags:     slices.Clone(tags),
+	})
+}
+
+// PathsToPublish 按提供者和标签筛选发布项，provider 或 tag 为空时不按该条件筛选，结果按目标路径排序
+func PathsToPublish(provider, tag string) []Publishable {
+	publishMu.RLock()
+	defer publishMu.RUnlock()
+	var result []Publishable
+	for _, p := range publishables {
+		if (provider == "" || p.Provider == provider) && (tag == "" || slices.Contains(p.Tags, tag)) {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Target < result[j].Target })
+	return result
+}
+
+// PublishableProviders 登记了发布项的服务提供者名称
+func PublishableProviders() []string {
+	publishMu.RLock()
+	defer publishMu.RUnlock()
+	var names []string
+	for _, p := range publishables {
+		if !slices.Contains(names, p.Provider) {
+			names = append(names, p.Provider)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PublishableTags 所有发布项的标签
+func PublishableTags() []string {
+	publishMu.RLock()
+	defer publishMu.RUnlock()
+	var tags []string
+	for _, p := range publishables {
+		for _, tag := range p.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// ProviderName 服务提供者名称，为去掉指针的类型名，例如 "billing.ServiceProvider"
+func ProviderName(provider ServiceProvider) string {
+	t := reflect.TypeOf(provider)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
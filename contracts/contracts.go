@@ -0,0 +1,149 @@
+// Package contracts 记录框架接口的版本和不兼容变更，供 contracts/vN 和 laraveldoc-gen upgrade 使用
+//
+// 框架接口按族（缓存、队列、路由……）汇总在 contracts/vN 包中，对应 Laravel 的 Illuminate\Contracts。
+// 下游实现和使用接口时导入 contracts/vN，而不是直接依赖各个包的接口：
+// 当前版本的 contracts/vN 中的类型是各包接口的别名；接口发生不兼容变更时发布 contracts/vN+1，
+// 旧版本包保留旧的接口定义，并提供把旧实现适配为新接口的垫片（AdaptXxx），
+// 旧实现因此可以继续编译和注册，直到迁移到新版本。
+//
+// contracts/vN 中的 snapshot.go 由 laraveldoc-gen contracts 生成，别名指向的接口发生变化时编译失败，
+// 不会在不知情的情况下破坏下游实现；兼容的扩展应以新增可选接口的方式提供
+// （例如 container.ContextResolver、application.ContextEventDispatcher），不需要登记。
+//
+// 每个不兼容变更都要登记在 Changes 中，laraveldoc-gen upgrade 根据登记改写导入路径、
+// 迁移的接口和重命名的方法，并报告需要手动处理的删除和签名变更。
+//
+// 主要特性：
+// - Current 当前接口版本，Versions 已发布的全部版本
+// - Change 不兼容变更记录，Between 查询两个版本之间的变更
+//
+// 包结构：
+// - contracts.go - 版本、Change 变更记录和登记表
+//
+// 使用示例：
+//
+//	import contracts "github.com/cnote0/laraveldoc/contracts/v1"
+//
+//	var _ contracts.CacheStore = (*RedisStore)(nil)
+//
+//	// 升级到新版本：改写导入并列出需要手动处理的方法
+//	go run github.com/cnote0/laraveldoc/cmd/laraveldoc-gen upgrade -from v1 -to v2 -w ./...
+package contracts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Current 当前接口版本
+const Current = "v1"
+
+// Versions 已发布的接口版本，按发布顺序排列
+var Versions = []string{"v1"}
+
+// ChangeKind 不兼容变更的类型
+type ChangeKind string
+
+const (
+	// MethodRemoved 方法被删除，需要手动迁移调用和实现
+	MethodRemoved ChangeKind = "removed"
+
+	// MethodRenamed 方法改名，签名不变，upgrade 改写调用和实现的方法名
+	MethodRenamed ChangeKind = "renamed"
+
+	// SignatureChanged 方法签名变更，需要手动修改调用和实现
+	SignatureChanged ChangeKind = "signature"
+
+	// InterfaceMoved 接口迁移到其他包或改名，upgrade 改写引用和导入
+	InterfaceMoved ChangeKind = "moved"
+)
+
+// Change 一次不兼容的接口变更
+type Change struct {
+	// Since 引入变更的版本
+	Since string
+
+	// Kind 变更类型
+	Kind ChangeKind
+
+	// Package 接口所在包的导入路径
+	Package string
+
+	// Interface 接口名
+	Interface string
+
+	// Method 方法名，InterfaceMoved 时为空
+	Method string
+
+	// Replacement 新的方法名，或 InterfaceMoved 时新接口的 "导入路径.接口名"
+	Replacement string
+
+	// Note 迁移说明
+	Note string
+}
+
+// String 变更的描述，例如 "cache.Store.Tags removed in v2: use TaggedStore"
+func (c Change) String() string {
+	name := c.Package[strings.LastIndex(c.Package, "/")+1:] + "." + c.Interface
+	if c.Method != "" {
+		name += "." + c.Method
+	}
+	var description string
+	switch c.Kind {
+	case MethodRenamed:
+		description = fmt.Sprintf("%s renamed to %s in %s", name, c.Replacement, c.Since)
+	case InterfaceMoved:
+		description = fmt.Sprintf("%s moved to %s in %s", name, c.Replacement, c.Since)
+	case SignatureChanged:
+		description = fmt.Sprintf("%s changed signature in %s", name, c.Since)
+	default:
+		description = fmt.Sprintf("%s removed in %s", name, c.Since)
+	}
+	if c.Note != "" {
+		description += ": " + c.Note
+	}
+	return description
+}
+
+// Changes 全部不兼容变更的登记表，按版本顺序追加；v1 为第一个版本，没有变更
+var Changes []Change
+
+// Between 版本 from（不含）到 to（含）之间的变更，版本未发布时返回错误
+func Between(from, to string) ([]Change, error) {
+	start, err := versionIndex(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := versionIndex(to)
+	if err != nil {
+		return nil, err
+	}
+	if start > end {
+		return nil, fmt.Errorf("contracts: cannot downgrade from %s to %s", from, to)
+	}
+	var changes []Change
+	for _, change := range Changes {
+		index, err := versionIndex(change.Since)
+		if err != nil {
+			return nil, err
+		}
+		if index > start && index <= end {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// versionIndex 版本在 Versions 中的位置
+func versionIndex(version string) (int, error) {
+	for i, v := range Versions {
+		if v == version {
+			return i, nil
+		}
+	}
+	if n, err := strconv.Atoi(strings.TrimPrefix(version, "v")); err != nil || n < 1 || !strings.HasPrefix(version, "v") {
+		return 0, fmt.Errorf("contracts: invalid version %q, expected vN", version)
+	}
+	return 0, fmt.Errorf("contracts: version %s is not released, latest is %s", version, Versions[len(Versions)-1])
+}
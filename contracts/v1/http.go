@@ -0,0 +1,43 @@
+package contracts
+
+import (
+	"github.com/cnote0/laraveldoc/exceptions"
+	"github.com/cnote0/laraveldoc/routing"
+	"github.com/cnote0/laraveldoc/session"
+)
+
+// 路由
+type (
+	// Router 路由器
+	Router = routing.Router
+
+	// Route 路由
+	Route = routing.Route
+
+	// URLGenerator URL 生成器
+	URLGenerator = routing.UrlGenerator
+
+	// Request HTTP 请求
+	Request = routing.RequestInterface
+
+	// Response HTTP 响应
+	Response = routing.ResponseInterface
+
+	// Middleware 中间件
+	Middleware = routing.Middleware
+)
+
+// 会话
+type (
+	// SessionHandler 会话存储处理器
+	SessionHandler = session.Handler
+)
+
+// 异常处理
+type (
+	// ExceptionHandler 异常处理器
+	ExceptionHandler = exceptions.ExceptionHandler
+
+	// ExceptionReporter 错误上报器
+	ExceptionReporter = exceptions.Reporter
+)
@@ -0,0 +1,67 @@
+package contracts
+
+import (
+	"github.com/cnote0/laraveldoc/broadcasting"
+	"github.com/cnote0/laraveldoc/bus"
+	"github.com/cnote0/laraveldoc/mail"
+	"github.com/cnote0/laraveldoc/queue"
+	"github.com/cnote0/laraveldoc/translation"
+	"github.com/cnote0/laraveldoc/view"
+)
+
+// 队列
+type (
+	// Queue 队列连接
+	Queue = queue.Queue
+
+	// QueueManager 队列管理器
+	QueueManager = queue.Manager
+
+	// QueueJob 队列任务
+	QueueJob = queue.Job
+
+	// QueueWorker 队列 Worker
+	QueueWorker = queue.Worker
+
+	// ShouldQueue 需要排队的任务、监听器或邮件
+	ShouldQueue = queue.ShouldQueue
+)
+
+// 命令总线
+type (
+	// BusDispatcher 命令总线调度器
+	BusDispatcher = bus.Dispatcher
+
+	// BusHandler 命令处理器
+	BusHandler = bus.Handler
+)
+
+// 邮件
+type (
+	// Mailer 邮件发送器
+	Mailer = mail.Mailer
+
+	// Mailable 可发送的邮件
+	Mailable = mail.Mailable
+
+	// MailTransport 邮件传输
+	MailTransport = mail.Transport
+)
+
+// 广播
+type (
+	// Broadcaster 广播器
+	Broadcaster = broadcasting.Broadcaster
+
+	// ShouldBroadcast 需要广播的事件
+	ShouldBroadcast = broadcasting.ShouldBroadcast
+)
+
+// 翻译和视图
+type (
+	// Translator 翻译器
+	Translator = translation.Translator
+
+	// ViewFactory 视图工厂
+	ViewFactory = view.Factory
+)
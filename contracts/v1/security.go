@@ -0,0 +1,34 @@
+package contracts
+
+import (
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/encryption"
+	"github.com/cnote0/laraveldoc/hashing"
+)
+
+// 认证
+type (
+	// AuthManager 认证管理器
+	AuthManager = auth.Manager
+
+	// Guard 认证守卫
+	Guard = auth.Guard
+
+	// StatefulGuard 有状态的认证守卫
+	StatefulGuard = auth.StatefulGuard
+
+	// UserProvider 用户提供者
+	UserProvider = auth.UserProvider
+
+	// Authenticatable 可认证的用户
+	Authenticatable = auth.Authenticatable
+)
+
+// 哈希和加密
+type (
+	// Hasher 哈希器
+	Hasher = hashing.Hasher
+
+	// Encrypter 加密器
+	Encrypter = encryption.Encrypter
+)
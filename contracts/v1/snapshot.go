@@ -0,0 +1,816 @@
+// Code generated by laraveldoc-gen contracts; DO NOT EDIT.
+
+package contracts
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/broadcasting"
+	"github.com/cnote0/laraveldoc/bus"
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/hashing"
+	"github.com/cnote0/laraveldoc/mail"
+	"github.com/cnote0/laraveldoc/queue"
+	"github.com/cnote0/laraveldoc/routing"
+	"github.com/cnote0/laraveldoc/storage"
+)
+
+// v1 发布时各别名指向的接口的方法集快照
+//
+// 别名指向的接口增加、删除方法或修改签名时下面的断言无法编译：
+// 不兼容的变更应发布新版本并登记在 contracts.Changes 中，或改为新增可选接口。
+type (
+	snapshotApplication interface {
+		Alias(abstract interface{}, alias interface{}) error
+		BasePath(path ...string) string
+		Bind(abstract interface{}, concrete interface{}, shared bool) error
+		BindIf(abstract interface{}, concrete interface{}, shared bool) error
+		BootProviders() error
+		Bootstrap() error
+		BootstrapWith(bootstrappers []application.Bootstrapper) error
+		Bound(abstract interface{}) bool
+		Build(concrete reflect.Type) (interface{}, error)
+		Call(instance interface{}, method string, parameters map[string]interface{}) ([]interface{}, error)
+		ConfigPath(path ...string) string
+		DatabasePath(path ...string) string
+		Environment() string
+		Extend(abstract interface{}, closure func(interface{}, container.Container) interface{}) error
+		Flush()
+		GetBindings() map[interface{}]container.Binding
+		GetFallbackLocale() string
+		GetLocale() string
+		GetNamespace() string
+		GetProviders(provider container.ServiceProvider) []container.ServiceProvider
+		Instance(abstract interface{}, instance interface{}) error
+		IsDebug() bool
+		IsDevelopment() bool
+		IsEnvironment(environments ...string) bool
+		IsLocale(locale string) bool
+		IsProduction() bool
+		IsShared(abstract interface{}) bool
+		Make(abstract interface{}) (interface{}, error)
+		MakeWith(abstract interface{}, parameters map[string]interface{}) (interface{}, error)
+		MustMake(abstract interface{}) interface{}
+		PublicPath(path ...string) string
+		RegisterConfiguredProviders() error
+		RegisterProvider(provider container.ServiceProvider, force bool) container.ServiceProvider
+		Resolved(abstract interface{}) bool
+		ResourcePath(path ...string) string
+		SetDebug(debug bool)
+		SetFallbackLocale(locale string)
+		SetLocale(locale string)
+		SetNamespace(namespace string)
+		Singleton(abstract interface{}, concrete interface{}) error
+		StoragePath(path ...string) string
+		Tag(abstracts []interface{}, tag string) error
+		Tagged(tag string) []interface{}
+		Terminate() error
+		Terminating(callback func() error)
+		Version() string
+		When(concrete interface{}) container.ContextualBinding
+	}
+
+	snapshotAuthManager interface {
+		CreateUserProvider(name string) (auth.UserProvider, error)
+		GetDefaultGuard() string
+		Guard(name ...string) (auth.Guard, error)
+		SetDefaultGuard(name string)
+	}
+
+	snapshotAuthenticatable interface {
+		GetAuthIdentifier() interface{}
+		GetAuthIdentifierName() string
+		GetAuthPassword() string
+		GetRememberToken() string
+		GetRememberTokenName() string
+		SetRememberToken(token string)
+	}
+
+	snapshotBroadcaster interface {
+		Auth(ctx context.Context, request broadcasting.AuthRequest) (interface{}, error)
+		Broadcast(ctx context.Context, channels []string, event string, payload map[string]interface{}) error
+	}
+
+	snapshotBusDispatcher interface {
+		Dispatch(ctx context.Context, command interface{}) (interface{}, error)
+		DispatchAfterResponse(ctx context.Context, command interface{})
+		DispatchSync(ctx context.Context, command interface{}) (interface{}, error)
+		DispatchToQueue(ctx context.Context, command interface{}) (string, error)
+		GetCommandHandler(command interface{}) (bus.Handler, error)
+		HasCommandHandler(command interface{}) bool
+		Map(command interface{}, handler interface{}) bus.Dispatcher
+		PipeThrough(pipes ...bus.Pipe) bus.Dispatcher
+		Terminate(ctx context.Context) error
+	}
+
+	snapshotBusHandler interface {
+		Handle(ctx context.Context, command interface{}) (interface{}, error)
+	}
+
+	snapshotCacheLock interface {
+		Block(ctx context.Context, wait time.Duration) error
+		ForceRelease(ctx context.Context) error
+		Get(ctx context.Context) (bool, error)
+		Owner() string
+		Release(ctx context.Context) (bool, error)
+	}
+
+	snapshotCacheLockProvider interface {
+		Lock(name string, ttl time.Duration, owner ...string) cache.Lock
+		RestoreLock(name string, owner string) cache.Lock
+	}
+
+	snapshotCacheStore interface {
+		Flush(ctx context.Context) error
+		Forever(ctx context.Context, key string, value interface{}) error
+		Forget(ctx context.Context, key string) (bool, error)
+		Get(ctx context.Context, key string) (value interface{}, ok bool, err error)
+		Increment(ctx context.Context, key string, by int64) (int64, error)
+		Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	}
+
+	snapshotConfig interface {
+		All() map[string]interface{}
+		Get(key string, defaultValue interface{}) interface{}
+		Has(key string) bool
+		OffsetExists(key string) bool
+		OffsetGet(key string) interface{}
+		OffsetSet(key string, value interface{})
+		OffsetUnset(key string)
+		Prepend(key string, value interface{}) error
+		Push(key string, value interface{}) error
+		Set(key string, value interface{}) error
+	}
+
+	snapshotConsoleKernel interface {
+		Bootstrap() error
+		Call(command string, parameters map[string]interface{}) (int, error)
+		GetApplication() application.Application
+		GetArtisan() application.ArtisanInterface
+		Handle(request interface{}) (interface{}, error)
+		HandleConsole(input application.InputInterface, output application.OutputInterface) (int, error)
+		HandleWithContext(ctx context.Context, request interface{}) (interface{}, error)
+		Queue(command string, parameters map[string]interface{}) error
+		SetApplication(app application.Application)
+		SetArtisan(artisan application.ArtisanInterface)
+		Terminate(request interface{}, response interface{}) error
+	}
+
+	snapshotContainer interface {
+		Alias(abstract interface{}, alias interface{}) error
+		Bind(abstract interface{}, concrete interface{}, shared bool) error
+		BindIf(abstract interface{}, concrete interface{}, shared bool) error
+		Bound(abstract interface{}) bool
+		Build(concrete reflect.Type) (interface{}, error)
+		Call(instance interface{}, method string, parameters map[string]interface{}) ([]interface{}, error)
+		Extend(abstract interface{}, closure func(interface{}, container.Container) interface{}) error
+		Flush()
+		GetBindings() map[interface{}]container.Binding
+		Instance(abstract interface{}, instance interface{}) error
+		IsShared(abstract interface{}) bool
+		Make(abstract interface{}) (interface{}, error)
+		MakeWith(abstract interface{}, parameters map[string]interface{}) (interface{}, error)
+		MustMake(abstract interface{}) interface{}
+		Resolved(abstract interface{}) bool
+		Singleton(abstract interface{}, concrete interface{}) error
+		Tag(abstracts []interface{}, tag string) error
+		Tagged(tag string) []interface{}
+		When(concrete interface{}) container.ContextualBinding
+	}
+
+	snapshotContextualBinding interface {
+		Give(implementation interface{}) error
+		GiveConfig(configKey string) error
+		GiveTagged(tag string) error
+		Needs(abstract interface{}) container.ContextualBinding
+	}
+
+	snapshotDB interface {
+		AddError(err error) error
+		Assign(attrs ...interface{}) database.DB
+		Association(column string) database.Association
+		Attrs(attrs ...interface{}) database.DB
+		AutoMigrate(dst ...interface{}) error
+		Begin(opts ...*sql.TxOptions) database.DB
+		Close() error
+		Commit() database.DB
+		Count(count *int64) database.DB
+		Create(value interface{}) database.DB
+		CreateInBatches(value interface{}, batchSize int) database.DB
+		Debug() database.DB
+		Delete(value interface{}, conds ...interface{}) database.DB
+		Distinct(args ...interface{}) database.DB
+		DryRun() database.DB
+		Error() error
+		Exec(sql string, values ...interface{}) database.DB
+		Find(dest interface{}, conds ...interface{}) database.DB
+		FindInBatches(dest interface{}, batchSize int, fc func(tx database.DB, batch int) error) database.DB
+		First(dest interface{}, conds ...interface{}) database.DB
+		FirstOrCreate(dest interface{}, conds ...interface{}) database.DB
+		FirstOrInit(dest interface{}, conds ...interface{}) database.DB
+		Get(key string) (interface{}, bool)
+		GetErrors() []error
+		Group(name string) database.DB
+		Having(query interface{}, args ...interface{}) database.DB
+		InstanceGet(key string) (interface{}, bool)
+		InstanceSet(key string, value interface{}) database.DB
+		Joins(query string, args ...interface{}) database.DB
+		Last(dest interface{}, conds ...interface{}) database.DB
+		Limit(limit int) database.DB
+		Migrator() database.Migrator
+		Model(value interface{}) database.DB
+		Not(query interface{}, args ...interface{}) database.DB
+		Offset(offset int) database.DB
+		Omit(columns ...string) database.DB
+		Or(query interface{}, args ...interface{}) database.DB
+		Order(value interface{}) database.DB
+		Pluck(column string, dest interface{}) database.DB
+		Preload(query string, args ...interface{}) database.DB
+		Raw(sql string, values ...interface{}) database.DB
+		Rollback() database.DB
+		RollbackTo(name string) database.DB
+		Row() *sql.Row
+		Rows() (*sql.Rows, error)
+		RowsAffected() int64
+		Save(value interface{}) database.DB
+		SavePoint(name string) database.DB
+		Scan(dest interface{}) database.DB
+		ScanRows(rows *sql.Rows, dest interface{}) error
+		Scopes(funcs ...func(database.DB) database.DB) database.DB
+		Select(query interface{}, args ...interface{}) database.DB
+		Session(config *database.SessionConfig) database.DB
+		Set(key string, value interface{}) database.DB
+		SqlDB() (*sql.DB, error)
+		Table(name string, args ...interface{}) database.DB
+		Take(dest interface{}, conds ...interface{}) database.DB
+		Transaction(fc func(tx database.DB) error, opts ...*sql.TxOptions) error
+		Unscoped() database.DB
+		Update(column string, value interface{}) database.DB
+		UpdateColumn(column string, value interface{}) database.DB
+		UpdateColumns(values interface{}) database.DB
+		Updates(values interface{}) database.DB
+		Where(query interface{}, args ...interface{}) database.DB
+		WithContext(ctx context.Context) database.DB
+	}
+
+	snapshotDatabaseManager interface {
+		Connection(name string) database.DB
+		Disconnect(name string) error
+		Extend(driver string, resolver func(config map[string]interface{}, name string) (database.DB, error))
+		GetConnections() map[string]database.DB
+		GetDefaultConnection() string
+		GetReconnectPolicy() database.ReconnectPolicy
+		HealthCheck(ctx context.Context) map[string]database.ConnectionHealth
+		Purge(name string) error
+		Reconnect(name string) (database.DB, error)
+		SetDefaultConnection(name string)
+		SetReconnectPolicy(policy database.ReconnectPolicy)
+	}
+
+	snapshotEncrypter interface {
+		Decrypt(payload string, dest interface{}) error
+		DecryptString(payload string) (string, error)
+		Encrypt(value interface{}) (string, error)
+		EncryptString(value string) (string, error)
+		GetKey() []byte
+		GetPreviousKeys() [][]byte
+	}
+
+	snapshotEventDispatcher interface {
+		AddListener(eventName string, listener application.EventListener, priority int) error
+		AddSubscriber(subscriber application.EventSubscriber) error
+		Dispatch(event interface{}, eventName string) interface{}
+		DispatchWithContext(ctx context.Context, event interface{}, eventName string) interface{}
+		GetListenerPriority(eventName string, listener application.EventListener) (int, error)
+		GetListeners(eventName string) []application.EventListener
+		HasListeners(eventName string) bool
+		RemoveListener(eventName string, listener application.EventListener) error
+		RemoveSubscriber(subscriber application.EventSubscriber) error
+	}
+
+	snapshotExceptionHandler interface {
+		Render(w http.ResponseWriter, r *http.Request, err error)
+		RenderForConsole(w io.Writer, err error)
+		Report(ctx context.Context, err error)
+		ShouldReport(err error) bool
+	}
+
+	snapshotExceptionReporter interface {
+		Report(ctx context.Context, err error, context map[string]interface{}) error
+	}
+
+	snapshotFilesystem interface {
+		Copy(ctx context.Context, from string, to string) error
+		Delete(ctx context.Context, paths ...string) error
+		DeleteDirectory(ctx context.Context, path string) error
+		Directories(ctx context.Context, directory string, recursive bool) ([]string, error)
+		Exists(ctx context.Context, path string) (bool, error)
+		Files(ctx context.Context, directory string, recursive bool) ([]string, error)
+		Get(ctx context.Context, path string) ([]byte, error)
+		GetVisibility(ctx context.Context, path string) (string, error)
+		LastModified(ctx context.Context, path string) (time.Time, error)
+		MakeDirectory(ctx context.Context, path string) error
+		Move(ctx context.Context, from string, to string) error
+		Put(ctx context.Context, path string, contents []byte, options ...storage.WriteOptions) error
+		PutStream(ctx context.Context, path string, contents io.Reader, options ...storage.WriteOptions) error
+		ReadStream(ctx context.Context, path string) (io.ReadCloser, error)
+		SetVisibility(ctx context.Context, path string, visibility string) error
+		Size(ctx context.Context, path string) (int64, error)
+		TemporaryURL(ctx context.Context, path string, expiration time.Duration) (string, error)
+		URL(path string) string
+	}
+
+	snapshotFilesystemManager interface {
+		Disk(name ...string) (storage.Filesystem, error)
+		Extend(driver string, factory func(config map[string]interface{}) (storage.Filesystem, error))
+		ForgetDisk(names ...string)
+		GetDefaultDisk() string
+		Set(name string, disk storage.Filesystem)
+		SetDefaultDisk(name string)
+	}
+
+	snapshotGuard interface {
+		Check(ctx context.Context) bool
+		Guest(ctx context.Context) bool
+		HasUser(ctx context.Context) bool
+		ID(ctx context.Context) interface{}
+		SetUser(ctx context.Context, user auth.Authenticatable)
+		User(ctx context.Context) (auth.Authenticatable, error)
+		Validate(ctx context.Context, credentials auth.Credentials) (bool, error)
+	}
+
+	snapshotHTTPKernel interface {
+		Bootstrap() error
+		GetApplication() application.Application
+		Handle(request interface{}) (interface{}, error)
+		HandleWithContext(ctx context.Context, request interface{}) (interface{}, error)
+		SetApplication(app application.Application)
+		Terminate(request interface{}, response interface{}) error
+	}
+
+	snapshotHasher interface {
+		Check(value string, hashedValue string) bool
+		Info(hashedValue string) hashing.Info
+		Make(value string) (string, error)
+		NeedsRehash(hashedValue string) bool
+	}
+
+	snapshotLogger interface {
+		Alert(message string, context map[string]interface{}) error
+		Critical(message string, context map[string]interface{}) error
+		Debug(message string, context map[string]interface{}) error
+		Emergency(message string, context map[string]interface{}) error
+		Error(message string, context map[string]interface{}) error
+		Info(message string, context map[string]interface{}) error
+		Log(level string, message string, context map[string]interface{}) error
+		Notice(message string, context map[string]interface{}) error
+		Warning(message string, context map[string]interface{}) error
+		WithContext(context map[string]interface{}) application.LoggerInterface
+	}
+
+	snapshotMailTransport interface {
+		Send(ctx context.Context, message *mail.Message) error
+		String() string
+	}
+
+	snapshotMailable interface {
+		Attachments() []mail.Attachment
+		Content() mail.Content
+		Envelope() mail.Envelope
+	}
+
+	snapshotMailer interface {
+		Bcc(addresses ...mail.Address) *mail.PendingMail
+		Cc(addresses ...mail.Address) *mail.PendingMail
+		Later(ctx context.Context, delay time.Duration, mailable mail.Mailable) (string, error)
+		Name() string
+		Queue(ctx context.Context, mailable mail.Mailable) (string, error)
+		QueueMessage(ctx context.Context, message *mail.Message, options mail.QueueOptions) (string, error)
+		Render(ctx context.Context, mailable mail.Mailable) (*mail.Message, error)
+		Send(ctx context.Context, mailable mail.Mailable) error
+		SendMessage(ctx context.Context, message *mail.Message) error
+		SendNow(ctx context.Context, mailable mail.Mailable) error
+		To(addresses ...mail.Address) *mail.PendingMail
+		Transport() mail.Transport
+	}
+
+	snapshotMiddleware interface {
+		Handle(request routing.RequestInterface, next func(routing.RequestInterface) routing.ResponseInterface) routing.ResponseInterface
+	}
+
+	snapshotMigrator interface {
+		AddColumn(dst interface{}, field string) error
+		AlterColumn(dst interface{}, field string) error
+		AutoMigrate(dst ...interface{}) error
+		ColumnTypes(dst interface{}) ([]database.ColumnType, error)
+		CreateConstraint(dst interface{}, name string) error
+		CreateIndex(dst interface{}, name string) error
+		CreateTable(dst ...interface{}) error
+		CreateView(name string, option database.ViewOption) error
+		CurrentDatabase() string
+		DropColumn(dst interface{}, field string) error
+		DropConstraint(dst interface{}, name string) error
+		DropIndex(dst interface{}, name string) error
+		DropTable(dst ...interface{}) error
+		DropView(name string) error
+		GetTables() ([]string, error)
+		HasColumn(dst interface{}, field string) bool
+		HasConstraint(dst interface{}, name string) bool
+		HasIndex(dst interface{}, name string) bool
+		HasTable(dst interface{}) bool
+		RenameColumn(dst interface{}, oldName string, field string) error
+		RenameIndex(dst interface{}, oldName string, newName string) error
+		RenameTable(oldName interface{}, newName interface{}) error
+	}
+
+	snapshotQueryBuilder interface {
+		Avg(column string) (float64, error)
+		Count() (int64, error)
+		Decrement(column string, amount int64) (int64, error)
+		Delete() (int64, error)
+		Distinct() database.QueryBuilder
+		Exists() (bool, error)
+		Explain() ([]map[string]interface{}, error)
+		Find(id interface{}) (map[string]interface{}, error)
+		First() (map[string]interface{}, error)
+		Get() ([]map[string]interface{}, error)
+		GroupBy(columns ...string) database.QueryBuilder
+		Having(column string, operator string, value interface{}) database.QueryBuilder
+		Increment(column string, amount int64) (int64, error)
+		Insert(values ...map[string]interface{}) error
+		InsertGetID(values map[string]interface{}) (int64, error)
+		Join(table string, first string, operator string, second string) database.QueryBuilder
+		Latest(column ...string) database.QueryBuilder
+		LeftJoin(table string, first string, operator string, second string) database.QueryBuilder
+		Limit(limit int) database.QueryBuilder
+		Max(column string) (interface{}, error)
+		Min(column string) (interface{}, error)
+		Offset(offset int) database.QueryBuilder
+		OrWhere(column string, operator string, value interface{}) database.QueryBuilder
+		OrderBy(column string, direction string) database.QueryBuilder
+		Pluck(column string) ([]interface{}, error)
+		Select(columns ...string) database.QueryBuilder
+		Sum(column string) (float64, error)
+		Table(name string) database.QueryBuilder
+		ToSQL() (string, []interface{})
+		Truncate() error
+		Update(values map[string]interface{}) (int64, error)
+		Value(column string) (interface{}, error)
+		Where(column string, operator string, value interface{}) database.QueryBuilder
+		WhereBetween(column string, from interface{}, to interface{}) database.QueryBuilder
+		WhereIn(column string, values []interface{}) database.QueryBuilder
+		WhereNotIn(column string, values []interface{}) database.QueryBuilder
+		WhereNotNull(column string) database.QueryBuilder
+		WhereNull(column string) database.QueryBuilder
+		WhereRaw(sql string, bindings ...interface{}) database.QueryBuilder
+		WithContext(ctx context.Context) database.QueryBuilder
+	}
+
+	snapshotQueue interface {
+		Bulk(ctx context.Context, jobs []queue.Job, queue string) error
+		ConnectionName() string
+		Later(ctx context.Context, delay time.Duration, job queue.Job, queue string) (string, error)
+		LaterRaw(ctx context.Context, delay time.Duration, payload []byte, queue string) (string, error)
+		Pop(ctx context.Context, queue string) (queue.QueuedJob, error)
+		Push(ctx context.Context, job queue.Job, queue string) (string, error)
+		PushRaw(ctx context.Context, payload []byte, queue string) (string, error)
+		SetConnectionName(name string)
+		Size(ctx context.Context, queue string) (int64, error)
+	}
+
+	snapshotQueueJob interface {
+		Backoff() []time.Duration
+		Failed(ctx context.Context, err error)
+		Handle(ctx context.Context) error
+		Timeout() time.Duration
+		Tries() int
+	}
+
+	snapshotQueueManager interface {
+		Connected(name string) bool
+		Connection(name ...string) (queue.Queue, error)
+		Extend(driver string, resolver func(config map[string]interface{}, name string) (queue.Queue, error))
+		Failer() queue.FailedJobProvider
+		GetDefaultConnection() string
+		SetDefaultConnection(name string)
+	}
+
+	snapshotQueueWorker interface {
+		Daemon(ctx context.Context, connection string, queue string, options queue.WorkerOptions) error
+		Listen(listener func(ctx context.Context, event interface{}))
+		Pause()
+		Process(ctx context.Context, connection string, job queue.QueuedJob, options queue.WorkerOptions) error
+		Resume()
+		RunNextJob(ctx context.Context, connection string, queue string, options queue.WorkerOptions) error
+		Stop()
+	}
+
+	snapshotRateLimiter interface {
+		Attempts(ctx context.Context, key string) (int, error)
+		AvailableIn(ctx context.Context, key string) (time.Duration, error)
+		Clear(ctx context.Context, key string) error
+		Hit(ctx context.Context, key string, decay time.Duration) (int, error)
+		RemainingAttempts(ctx context.Context, key string, maxAttempts int) (int, error)
+		TooManyAttempts(ctx context.Context, key string, maxAttempts int) (bool, error)
+	}
+
+	snapshotRequest interface {
+		All() map[string]interface{}
+		Context() context.Context
+		Cookie(name string, defaultValue string) string
+		File(key string) routing.UploadedFile
+		GetCookies() map[string]string
+		GetHeader(name string) string
+		GetHeaders() map[string][]string
+		GetInput(key string, defaultValue interface{}) interface{}
+		GetMethod() string
+		GetPath() string
+		GetQuery() string
+		GetRoute() routing.Route
+		GetRouteResolver() func() routing.Route
+		GetURI() string
+		Has(key string) bool
+		HasFile(key string) bool
+		HasHeader(name string) bool
+		IP() string
+		Old(key string, defaultValue interface{}) interface{}
+		ParseError() error
+		SetRoute(route routing.Route)
+		SetRouteResolver(resolver func() routing.Route)
+		UserAgent() string
+		WithContext(ctx context.Context) routing.RequestInterface
+	}
+
+	snapshotResponse interface {
+		AddHeader(name string, value string) routing.ResponseInterface
+		GetContent() string
+		GetHeaders() map[string][]string
+		GetStatusCode() int
+		RemoveHeader(name string) routing.ResponseInterface
+		Send() error
+		SendContent() error
+		SendHeaders() error
+		SetContent(content string) routing.ResponseInterface
+		SetHeader(name string, value string) routing.ResponseInterface
+		SetStatusCode(code int) routing.ResponseInterface
+		WithCookie(cookie routing.Cookie) routing.ResponseInterface
+		WithoutCookie(name string) routing.ResponseInterface
+	}
+
+	snapshotRoute interface {
+		Bind(request routing.RequestInterface) error
+		Compile() routing.CompiledRoute
+		Defaults(key string, value interface{}) routing.Route
+		Domain(domain string) routing.Route
+		GetAction() interface{}
+		GetCompiled() routing.CompiledRoute
+		GetController() string
+		GetControllerClass() string
+		GetControllerMethod() string
+		GetMethods() []string
+		GetMiddleware() []string
+		GetName() string
+		GetPrefix() string
+		GetURI() string
+		GetWhere() map[string]string
+		Matches(request routing.RequestInterface) bool
+		Middleware(middleware ...string) routing.Route
+		Name(name string) routing.Route
+		Run() routing.ResponseInterface
+		SetAction(action interface{}) routing.Route
+		SetMethods(methods []string) routing.Route
+		SetMiddleware(middleware []string) routing.Route
+		SetName(name string) routing.Route
+		SetPrefix(prefix string) routing.Route
+		SetURI(uri string) routing.Route
+		SetWhere(wheres map[string]string) routing.Route
+		Where(name string, expression string) routing.Route
+		WhereAlpha(name string) routing.Route
+		WhereAlphaNumeric(name string) routing.Route
+		WhereNumber(name string) routing.Route
+		WhereUuid(name string) routing.Route
+		WithoutMiddleware(middleware ...string) routing.Route
+	}
+
+	snapshotRouter interface {
+		APIResource(name string, controller string, options map[string]interface{}) routing.RouteCollection
+		Any(uri string, action interface{}) routing.Route
+		Delete(uri string, action interface{}) routing.Route
+		Dispatch(request routing.RequestInterface) routing.ResponseInterface
+		DispatchToRoute(request routing.RequestInterface) routing.ResponseInterface
+		Domain(domain string) *routing.RouteRegistrar
+		Fallback(action interface{}) routing.Route
+		Get(uri string, action interface{}) routing.Route
+		GetRoutes() routing.RouteCollection
+		Group(attributes map[string]interface{}, callback func(routing.Router)) routing.Router
+		Match(methods []string, uri string, action interface{}) routing.Route
+		Middleware(middleware ...string) *routing.RouteRegistrar
+		Name(name string) *routing.RouteRegistrar
+		Namespace(namespace string) *routing.RouteRegistrar
+		Options(uri string, action interface{}) routing.Route
+		Patch(uri string, action interface{}) routing.Route
+		Pattern(name string, converter routing.ParamConverter) routing.Router
+		PermanentRedirect(uri string, destination string) routing.Route
+		Post(uri string, action interface{}) routing.Route
+		Prefix(prefix string) *routing.RouteRegistrar
+		Put(uri string, action interface{}) routing.Route
+		Redirect(uri string, destination string, status int) routing.Route
+		RegisterController(controller interface{}) (routing.RouteCollection, error)
+		Resource(name string, controller string, options map[string]interface{}) routing.RouteCollection
+		Spa(entry string) routing.Route
+		SpaFS(fsys fs.FS, entry string) routing.Route
+		Static(prefix string, dir string) routing.Route
+		StaticFS(prefix string, fsys fs.FS) routing.Route
+		Stats() routing.Stats
+		Version(version string) *routing.RouteRegistrar
+		View(uri string, view string, data map[string]interface{}) routing.Route
+		WebSocket(uri string, handler routing.WebSocketHandler) routing.Route
+		Where(name string, expression string) *routing.RouteRegistrar
+	}
+
+	snapshotServiceProvider interface {
+		Boot(container container.Container) error
+		IsDeferred() bool
+		Provides() []string
+		Register(container container.Container) error
+	}
+
+	snapshotSessionHandler interface {
+		Destroy(ctx context.Context, id string) error
+		Read(ctx context.Context, id string) (map[string]interface{}, error)
+		Write(ctx context.Context, id string, data map[string]interface{}, lifetime time.Duration) error
+	}
+
+	snapshotShouldBroadcast interface {
+		BroadcastOn() []broadcasting.Channel
+	}
+
+	snapshotShouldQueue interface {
+		ShouldQueue()
+	}
+
+	snapshotStatefulGuard interface {
+		Attempt(ctx context.Context, credentials auth.Credentials, remember bool) (bool, error)
+		Check(ctx context.Context) bool
+		Guest(ctx context.Context) bool
+		HasUser(ctx context.Context) bool
+		ID(ctx context.Context) interface{}
+		Login(ctx context.Context, user auth.Authenticatable, remember bool) error
+		LoginUsingID(ctx context.Context, id interface{}, remember bool) (auth.Authenticatable, error)
+		Logout(ctx context.Context) error
+		Once(ctx context.Context, credentials auth.Credentials) (bool, error)
+		SetUser(ctx context.Context, user auth.Authenticatable)
+		User(ctx context.Context) (auth.Authenticatable, error)
+		Validate(ctx context.Context, credentials auth.Credentials) (bool, error)
+		ViaRemember(ctx context.Context) bool
+	}
+
+	snapshotTranslator interface {
+		AddLines(lines map[string]string, locale string, namespace ...string)
+		Choice(key string, number int, replace map[string]string, locale ...string) string
+		Get(key string, replace map[string]string, locale ...string) string
+		GetFallback() string
+		GetLocale() string
+		Has(key string, fallback bool, locale ...string) bool
+		SetLocale(locale string)
+	}
+
+	snapshotURLGenerator interface {
+		Action(action string, parameters map[string]interface{}, absolute bool) string
+		Asset(path string, secure bool) string
+		Current() string
+		Full() string
+		GetRequest() routing.RequestInterface
+		Previous(fallback string) string
+		Route(name string, parameters map[string]interface{}, absolute bool) string
+		SecureAsset(path string) string
+		SetRequest(request routing.RequestInterface)
+		To(path string, parameters map[string]interface{}, secure bool) string
+	}
+
+	snapshotUserProvider interface {
+		RetrieveByCredentials(ctx context.Context, credentials auth.Credentials) (auth.Authenticatable, error)
+		RetrieveByID(ctx context.Context, identifier interface{}) (auth.Authenticatable, error)
+		RetrieveByToken(ctx context.Context, identifier interface{}, token string) (auth.Authenticatable, error)
+		UpdateRememberToken(ctx context.Context, user auth.Authenticatable, token string) error
+		ValidateCredentials(ctx context.Context, user auth.Authenticatable, credentials auth.Credentials) (bool, error)
+	}
+
+	snapshotViewFactory interface {
+		Exists(name string) bool
+		Render(name string, data map[string]interface{}) (string, error)
+		Share(key string, value interface{})
+	}
+)
+
+// 快照与别名互相满足，方法集完全一致
+var (
+	_ Application               = snapshotApplication(nil)
+	_ snapshotApplication       = Application(nil)
+	_ AuthManager               = snapshotAuthManager(nil)
+	_ snapshotAuthManager       = AuthManager(nil)
+	_ Authenticatable           = snapshotAuthenticatable(nil)
+	_ snapshotAuthenticatable   = Authenticatable(nil)
+	_ Broadcaster               = snapshotBroadcaster(nil)
+	_ snapshotBroadcaster       = Broadcaster(nil)
+	_ BusDispatcher             = snapshotBusDispatcher(nil)
+	_ snapshotBusDispatcher     = BusDispatcher(nil)
+	_ BusHandler                = snapshotBusHandler(nil)
+	_ snapshotBusHandler        = BusHandler(nil)
+	_ CacheLock                 = snapshotCacheLock(nil)
+	_ snapshotCacheLock         = CacheLock(nil)
+	_ CacheLockProvider         = snapshotCacheLockProvider(nil)
+	_ snapshotCacheLockProvider = CacheLockProvider(nil)
+	_ CacheStore                = snapshotCacheStore(nil)
+	_ snapshotCacheStore        = CacheStore(nil)
+	_ Config                    = snapshotConfig(nil)
+	_ snapshotConfig            = Config(nil)
+	_ ConsoleKernel             = snapshotConsoleKernel(nil)
+	_ snapshotConsoleKernel     = ConsoleKernel(nil)
+	_ Container                 = snapshotContainer(nil)
+	_ snapshotContainer         = Container(nil)
+	_ ContextualBinding         = snapshotContextualBinding(nil)
+	_ snapshotContextualBinding = ContextualBinding(nil)
+	_ DB                        = snapshotDB(nil)
+	_ snapshotDB                = DB(nil)
+	_ DatabaseManager           = snapshotDatabaseManager(nil)
+	_ snapshotDatabaseManager   = DatabaseManager(nil)
+	_ Encrypter                 = snapshotEncrypter(nil)
+	_ snapshotEncrypter         = Encrypter(nil)
+	_ EventDispatcher           = snapshotEventDispatcher(nil)
+	_ snapshotEventDispatcher   = EventDispatcher(nil)
+	_ ExceptionHandler          = snapshotExceptionHandler(nil)
+	_ snapshotExceptionHandler  = ExceptionHandler(nil)
+	_ ExceptionReporter         = snapshotExceptionReporter(nil)
+	_ snapshotExceptionReporter = ExceptionReporter(nil)
+	_ Filesystem                = snapshotFilesystem(nil)
+	_ snapshotFilesystem        = Filesystem(nil)
+	_ FilesystemManager         = snapshotFilesystemManager(nil)
+	_ snapshotFilesystemManager = FilesystemManager(nil)
+	_ Guard                     = snapshotGuard(nil)
+	_ snapshotGuard             = Guard(nil)
+	_ HTTPKernel                = snapshotHTTPKernel(nil)
+	_ snapshotHTTPKernel        = HTTPKernel(nil)
+	_ Hasher                    = snapshotHasher(nil)
+	_ snapshotHasher            = Hasher(nil)
+	_ Logger                    = snapshotLogger(nil)
+	_ snapshotLogger            = Logger(nil)
+	_ MailTransport             = snapshotMailTransport(nil)
+	_ snapshotMailTransport     = MailTransport(nil)
+	_ Mailable                  = snapshotMailable(nil)
+	_ snapshotMailable          = Mailable(nil)
+	_ Mailer                    = snapshotMailer(nil)
+	_ snapshotMailer            = Mailer(nil)
+	_ Middleware                = snapshotMiddleware(nil)
+	_ snapshotMiddleware        = Middleware(nil)
+	_ Migrator                  = snapshotMigrator(nil)
+	_ snapshotMigrator          = Migrator(nil)
+	_ QueryBuilder              = snapshotQueryBuilder(nil)
+	_ snapshotQueryBuilder      = QueryBuilder(nil)
+	_ Queue                     = snapshotQueue(nil)
+	_ snapshotQueue             = Queue(nil)
+	_ QueueJob                  = snapshotQueueJob(nil)
+	_ snapshotQueueJob          = QueueJob(nil)
+	_ QueueManager              = snapshotQueueManager(nil)
+	_ snapshotQueueManager      = QueueManager(nil)
+	_ QueueWorker               = snapshotQueueWorker(nil)
+	_ snapshotQueueWorker       = QueueWorker(nil)
+	_ RateLimiter               = snapshotRateLimiter(nil)
+	_ snapshotRateLimiter       = RateLimiter(nil)
+	_ Request                   = snapshotRequest(nil)
+	_ snapshotRequest           = Request(nil)
+	_ Response                  = snapshotResponse(nil)
+	_ snapshotResponse          = Response(nil)
+	_ Route                     = snapshotRoute(nil)
+	_ snapshotRoute             = Route(nil)
+	_ Router                    = snapshotRouter(nil)
+	_ snapshotRouter            = Router(nil)
+	_ ServiceProvider           = snapshotServiceProvider(nil)
+	_ snapshotServiceProvider   = ServiceProvider(nil)
+	_ SessionHandler            = snapshotSessionHandler(nil)
+	_ snapshotSessionHandler    = SessionHandler(nil)
+	_ ShouldBroadcast           = snapshotShouldBroadcast(nil)
+	_ snapshotShouldBroadcast   = ShouldBroadcast(nil)
+	_ ShouldQueue               = snapshotShouldQueue(nil)
+	_ snapshotShouldQueue       = ShouldQueue(nil)
+	_ StatefulGuard             = snapshotStatefulGuard(nil)
+	_ snapshotStatefulGuard     = StatefulGuard(nil)
+	_ Translator                = snapshotTranslator(nil)
+	_ snapshotTranslator        = Translator(nil)
+	_ URLGenerator              = snapshotURLGenerator(nil)
+	_ snapshotURLGenerator      = URLGenerator(nil)
+	_ UserProvider              = snapshotUserProvider(nil)
+	_ snapshotUserProvider      = UserProvider(nil)
+	_ ViewFactory               = snapshotViewFactory(nil)
+	_ snapshotViewFactory       = ViewFactory(nil)
+)
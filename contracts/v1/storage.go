@@ -0,0 +1,46 @@
+package contracts
+
+import (
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/storage"
+)
+
+// 数据库
+type (
+	// DB 数据库连接
+	DB = database.DB
+
+	// DatabaseManager 数据库连接管理器
+	DatabaseManager = database.DatabaseManager
+
+	// Migrator 迁移器
+	Migrator = database.Migrator
+
+	// QueryBuilder 查询构建器
+	QueryBuilder = database.QueryBuilder
+)
+
+// 缓存
+type (
+	// CacheStore 缓存存储
+	CacheStore = cache.Store
+
+	// CacheLock 原子锁
+	CacheLock = cache.Lock
+
+	// CacheLockProvider 锁提供者
+	CacheLockProvider = cache.LockProvider
+
+	// RateLimiter 限流器
+	RateLimiter = cache.RateLimiter
+)
+
+// 文件存储
+type (
+	// Filesystem 存储磁盘
+	Filesystem = storage.Filesystem
+
+	// FilesystemManager 存储磁盘管理器
+	FilesystemManager = storage.Manager
+)
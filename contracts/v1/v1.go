@@ -0,0 +1,75 @@
+// Package contracts 框架接口的第 1 版，对应 Laravel 的 Illuminate\Contracts
+//
+// 本包中的类型是各包接口的别名，实现和使用这些类型与直接使用各包的接口完全相同。
+// 下游代码依赖本包而不是各包的接口，接口发生不兼容变更时：
+// 新版本发布为 contracts/v2，本包保留 v1 的接口定义（不再是别名），
+// 并提供 AdaptXxx 垫片把 v1 的实现适配为新接口，登记在 contracts.Changes 中。
+// 用 laraveldoc-gen upgrade 把导入改写为新版本并列出需要手动迁移的方法。
+//
+// snapshot.go 记录了 v1 发布时每个别名的方法集，并断言快照与别名互相满足：
+// 各包的接口增加、删除方法或修改签名时本包无法编译，变更必须以新增可选接口的方式实现，
+// 或者发布 contracts/v2。快照只在确认变更兼容（例如只修改了文档）后用 go generate 重新生成。
+//
+// 类型名为 "族名 + 接口名"，例如 CacheStore、QueueJob；族内的核心接口省略族名，例如 Container、Router。
+// 泛型接口（repository.Repository）不能声明别名，直接使用原包。
+//
+// 包结构：
+// - v1.go - 容器、应用、配置、日志和事件
+// - http.go - 路由、请求、响应、中间件、会话和异常处理
+// - security.go - 认证、哈希和加密
+// - storage.go - 数据库、缓存、锁、限流和文件存储
+// - messaging.go - 队列、命令总线、邮件、广播、翻译和视图
+// - snapshot.go - laraveldoc-gen contracts 生成的方法集快照和编译期断言
+//
+// 使用示例：
+//
+//	import contracts "github.com/cnote0/laraveldoc/contracts/v1"
+//
+//	// 编译期检查实现是否满足 v1 接口
+//	var _ contracts.CacheStore = (*RedisStore)(nil)
+//
+//	func NewReportService(db contracts.DB, cache contracts.CacheStore, queue contracts.Queue) *ReportService
+package contracts
+
+//go:generate go run github.com/cnote0/laraveldoc/cmd/laraveldoc-gen contracts -dir .
+
+import (
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// Version 本包的接口版本
+const Version = "v1"
+
+// 容器
+type (
+	// Container 服务容器
+	Container = container.Container
+
+	// ContextualBinding 上下文绑定
+	ContextualBinding = container.ContextualBinding
+
+	// ServiceProvider 服务提供者
+	ServiceProvider = container.ServiceProvider
+)
+
+// 应用
+type (
+	// Application 应用实例
+	Application = application.Application
+
+	// HTTPKernel HTTP 内核
+	HTTPKernel = application.Kernel
+
+	// ConsoleKernel 控制台内核
+	ConsoleKernel = application.ConsoleKernel
+
+	// Config 配置仓库
+	Config = application.Config
+
+	// Logger 日志
+	Logger = application.LoggerInterface
+
+	// EventDispatcher 事件调度器
+	EventDispatcher = application.EventDispatcher
+)
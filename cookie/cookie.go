@@ -0,0 +1,247 @@
+// Package cookie 提供 Laravel 风格的 Cookie 工厂、Cookie 队列和 Cookie 加密，对应 Illuminate\Cookie
+//
+// Jar 按默认路径、域名等配置创建 Cookie，并把排队的 Cookie 在响应时附加到响应中；
+// 每个请求的队列由 Jar.Middleware 放入请求的 context，请求之间互不影响。
+// EncryptCookies 中间件解密请求中的 Cookie 并加密响应设置的 Cookie，
+// 密文中带有与 Cookie 名称绑定的前缀，一个 Cookie 的值不能被挪用为另一个 Cookie 的值。
+//
+// 包结构：
+// - cookie.go - Jar Cookie 工厂、Cookie 队列和 AddQueuedCookiesToResponse 中间件
+// - encrypt.go - EncryptCookies 中间件和 Cookie 值前缀
+//
+// 使用示例：
+//
+//	jar := cookie.NewJar(cookie.Config{Secure: true})
+//	encrypt := cookie.NewEncryptCookies(encrypter, "locale")
+//	http.ListenAndServe(":8080", encrypt.Middleware(jar.Middleware(sessions.Middleware(mux))))
+//
+//	func (c *ThemeController) Update(w http.ResponseWriter, r *http.Request) {
+//		jar := cookie.FromContext(r.Context())
+//		jar.Queue(jar.Forever("theme", "dark"))
+//		jar.Queue(jar.Forget("legacy_theme"))
+//	}
+package cookie
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// foreverMinutes Forever 使用的有效期，五年
+const foreverMinutes = 5 * 365 * 24 * 60
+
+// Config Jar 创建 Cookie 使用的默认属性
+type Config struct {
+	// Path 默认路径，默认 "/"
+	Path string
+
+	// Domain 默认域名
+	Domain string
+
+	// Secure 默认只通过 HTTPS 发送
+	Secure bool
+
+	// SameSite 默认 SameSite 属性，默认 Lax
+	SameSite http.SameSite
+}
+
+// Jar Cookie 工厂和队列，对应 Laravel 的 Illuminate\Cookie\CookieJar
+//
+// 队列按名称和路径保存 Cookie，同名同路径的 Cookie 后排队的替换先排队的。
+// Jar 的方法并发安全，并且都可以在 nil 上调用：Make 使用默认配置，排队被忽略。
+type Jar struct {
+	config Config
+
+	mu     sync.Mutex
+	queued []*http.Cookie
+}
+
+// NewJar 创建 Cookie 工厂
+func NewJar(config Config) *Jar {
+	if config.Path == "" {
+		config.Path = "/"
+	}
+	if config.SameSite == 0 {
+		config.SameSite = http.SameSiteLaxMode
+	}
+	return &Jar{config: config}
+}
+
+// Config Cookie 默认属性
+func (j *Jar) Config() Config {
+	if j == nil {
+		return Config{Path: "/", SameSite: http.SameSiteLaxMode}
+	}
+	return j.config
+}
+
+// Make 创建 minutes 分钟后过期的 Cookie，minutes 为 0 时创建浏览器关闭即失效的会话 Cookie
+//
+// 创建的 Cookie 使用默认的路径、域名、Secure 和 SameSite，并设置 HttpOnly，需要时可以直接修改返回值。
+func (j *Jar) Make(name, value string, minutes int) *http.Cookie {
+	config := j.Config()
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     config.Path,
+		Domain:   config.Domain,
+		Secure:   config.Secure,
+		HttpOnly: true,
+		SameSite: config.SameSite,
+	}
+	if minutes != 0 {
+		cookie.MaxAge = minutes * 60
+		cookie.Expires = time.Now().Add(time.Duration(minutes) * time.Minute)
+	}
+	return cookie
+}
+
+// Forever 创建五年后过期的 Cookie
+func (j *Jar) Forever(name, value string) *http.Cookie {
+	return j.Make(name, value, foreverMinutes)
+}
+
+// Forget 创建使浏览器删除 Cookie 的过期 Cookie
+func (j *Jar) Forget(name string) *http.Cookie {
+	cookie := j.Make(name, "", 0)
+	cookie.MaxAge = -1
+	cookie.Expires = time.Unix(1, 0)
+	return cookie
+}
+
+// Queue 把 Cookie 加入队列，在响应时附加到响应中
+func (j *Jar) Queue(cookies ...*http.Cookie) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, cookie := range cookies {
+		j.unqueue(cookie.Name, cookie.Path)
+		j.queued = append(j.queued, cookie)
+	}
+}
+
+// Unqueue 把 Cookie 移出队列，path 为空时移除所有路径下的同名 Cookie
+func (j *Jar) Unqueue(name, path string) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.unqueue(name, path)
+}
+
+// HasQueued 队列中是否有该 Cookie，path 为空时匹配任意路径
+func (j *Jar) HasQueued(name, path string) bool {
+	return j.Queued(name, path) != nil
+}
+
+// Queued 获取队列中的 Cookie，path 为空时返回第一个同名 Cookie，没有时返回 nil
+func (j *Jar) Queued(name, path string) *http.Cookie {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, cookie := range j.queued {
+		if cookie.Name == name && (path == "" || cookie.Path == path) {
+			return cookie
+		}
+	}
+	return nil
+}
+
+// QueuedCookies 获取队列中的全部 Cookie
+func (j *Jar) QueuedCookies() []*http.Cookie {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]*http.Cookie(nil), j.queued...)
+}
+
+// FlushQueuedCookies 清空队列并返回其中的 Cookie
+func (j *Jar) FlushQueuedCookies() []*http.Cookie {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	queued := j.queued
+	j.queued = nil
+	return queued
+}
+
+// unqueue 移除队列中的 Cookie，调用方持有锁
+func (j *Jar) unqueue(name, path string) {
+	kept := j.queued[:0]
+	for _, cookie := range j.queued {
+		if cookie.Name != name || (path != "" && cookie.Path != path) {
+			kept = append(kept, cookie)
+		}
+	}
+	for i := len(kept); i < len(j.queued); i++ {
+		j.queued[i] = nil
+	}
+	j.queued = kept
+}
+
+// Middleware 把排队的 Cookie 附加到响应的中间件，对应 Laravel 的 AddQueuedCookiesToResponse
+//
+// 每个请求使用与 j 配置相同、队列为空的 Jar，通过 FromContext 获取；
+// 响应第一次写入前（处理器没有写入时在处理器返回后）写入队列中的 Cookie。
+func (j *Jar) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jar := &Jar{config: j.Config()}
+		qw := &queueWriter{ResponseWriter: w}
+		qw.commit = func() {
+			for _, cookie := range jar.FlushQueuedCookies() {
+				http.SetCookie(w, cookie)
+			}
+		}
+		next.ServeHTTP(qw, r.WithContext(WithJar(r.Context(), jar)))
+		qw.once.Do(qw.commit)
+	})
+}
+
+// queueWriter 在响应第一次写入前写入排队的 Cookie
+type queueWriter struct {
+	http.ResponseWriter
+	once   sync.Once
+	commit func()
+}
+
+// WriteHeader 写入排队的 Cookie 后写入状态码
+func (w *queueWriter) WriteHeader(status int) {
+	w.once.Do(w.commit)
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write 写入排队的 Cookie 后写入内容
+func (w *queueWriter) Write(b []byte) (int, error) {
+	w.once.Do(w.commit)
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap 供 http.ResponseController 访问底层的 ResponseWriter
+func (w *queueWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// jarKey context 中请求 Jar 的键
+type jarKey struct{}
+
+// WithJar 返回带有 jar 的 context
+func WithJar(ctx context.Context, jar *Jar) context.Context {
+	return context.WithValue(ctx, jarKey{}, jar)
+}
+
+// FromContext 获取 context 中的 Jar，没有时返回 nil
+func FromContext(ctx context.Context) *Jar {
+	jar, _ := ctx.Value(jarKey{}).(*Jar)
+	return jar
+}
@@ -0,0 +1,155 @@
+package cookie
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/encryption"
+)
+
+// EncryptCookies 加密 Cookie 的中间件，对应 Laravel 的 Illuminate\Cookie\Middleware\EncryptCookies
+//
+// 请求中的 Cookie 先解密再交给处理器，无法解密或名称前缀不匹配的 Cookie 被丢弃；
+// 响应设置的 Cookie 在写入前加密，值为空的删除 Cookie 保持原样。
+// 例外列表中的 Cookie 不做处理，例如需要由前端脚本读取的 Cookie。
+//
+// EncryptCookies 应放在 Jar.Middleware 和 session.Manager.Middleware 外层，
+// 才能加密它们写入的 Cookie。
+type EncryptCookies struct {
+	encrypter encryption.Encrypter
+
+	mu     sync.RWMutex
+	except map[string]bool
+}
+
+// NewEncryptCookies 创建加密中间件，except 为不加密的 Cookie 名称
+func NewEncryptCookies(encrypter encryption.Encrypter, except ...string) *EncryptCookies {
+	e := &EncryptCookies{encrypter: encrypter, except: make(map[string]bool)}
+	e.Disable(except...)
+	return e
+}
+
+// Disable 把 Cookie 加入例外列表
+func (e *EncryptCookies) Disable(names ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, name := range names {
+		e.except[name] = true
+	}
+}
+
+// IsDisabled Cookie 是否在例外列表中
+func (e *EncryptCookies) IsDisabled(name string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.except[name]
+}
+
+// Encrypt 加密 Cookie 的值，值前带有名称前缀
+func (e *EncryptCookies) Encrypt(name, value string) (string, error) {
+	return e.encrypter.EncryptString(ValuePrefix(name, e.encrypter.GetKey()) + value)
+}
+
+// Decrypt 解密 Cookie 的值并校验名称前缀，前缀可以由当前密钥或之前的密钥生成
+func (e *EncryptCookies) Decrypt(name, payload string) (string, error) {
+	plain, err := e.encrypter.DecryptString(payload)
+	if err != nil {
+		return "", err
+	}
+	keys := append([][]byte{e.encrypter.GetKey()}, e.encrypter.GetPreviousKeys()...)
+	for _, key := range keys {
+		if value, ok := strings.CutPrefix(plain, ValuePrefix(name, key)); ok {
+			return value, nil
+		}
+	}
+	return "", encryption.ErrDecrypt
+}
+
+// Middleware 解密请求 Cookie、加密响应 Cookie 的中间件
+//
+// 响应 Cookie 在第一次写入前（处理器没有写入时在处理器返回后）加密，加密失败的 Cookie 不会发送。
+func (e *EncryptCookies) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.Clone(r.Context())
+		e.decryptRequest(r)
+
+		ew := &encryptWriter{ResponseWriter: w}
+		ew.commit = func() { e.encryptResponse(w.Header()) }
+		next.ServeHTTP(ew, r)
+		ew.once.Do(ew.commit)
+	})
+}
+
+// decryptRequest 用解密后的值替换请求的 Cookie 请求头
+func (e *EncryptCookies) decryptRequest(r *http.Request) {
+	cookies := r.Cookies()
+	r.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if !e.IsDisabled(cookie.Name) {
+			value, err := e.Decrypt(cookie.Name, cookie.Value)
+			if err != nil {
+				continue
+			}
+			cookie.Value = value
+		}
+		r.AddCookie(cookie)
+	}
+}
+
+// encryptResponse 加密 Set-Cookie 响应头中的 Cookie
+func (e *EncryptCookies) encryptResponse(header http.Header) {
+	lines := header.Values("Set-Cookie")
+	if len(lines) == 0 {
+		return
+	}
+	encrypted := make([]string, 0, len(lines))
+	for _, line := range lines {
+		cookie, err := http.ParseSetCookie(line)
+		if err != nil || cookie.Value == "" || e.IsDisabled(cookie.Name) {
+			encrypted = append(encrypted, line)
+			continue
+		}
+		if cookie.Value, err = e.Encrypt(cookie.Name, cookie.Value); err != nil {
+			continue
+		}
+		encrypted = append(encrypted, cookie.String())
+	}
+	header["Set-Cookie"] = encrypted
+}
+
+// encryptWriter 在响应第一次写入前加密响应 Cookie
+type encryptWriter struct {
+	http.ResponseWriter
+	once   sync.Once
+	commit func()
+}
+
+// WriteHeader 加密响应 Cookie 后写入状态码
+func (w *encryptWriter) WriteHeader(status int) {
+	w.once.Do(w.commit)
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write 加密响应 Cookie 后写入内容
+func (w *encryptWriter) Write(b []byte) (int, error) {
+	w.once.Do(w.commit)
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap 供 http.ResponseController 访问底层的 ResponseWriter
+func (w *encryptWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// ValuePrefix Cookie 值的名称前缀，对应 Laravel 的 CookieValuePrefix::create
+//
+// 前缀为 HMAC-SHA1(name + "v", key) 的十六进制加 "|"，把密文与 Cookie 名称绑定。
+func ValuePrefix(name string, key []byte) string {
+	mac := hmac.New(sha1.New, key)
+	mac.Write([]byte(name + "v"))
+	return hex.EncodeToString(mac.Sum(nil)) + "|"
+}
@@ -0,0 +1,344 @@
+// Package data 提供 spatie/laravel-data 风格的数据对象，把请求、数组和模型映射为强类型结构体
+//
+// 数据对象是普通的结构体，属性名来自 json 标签，验证规则写在 validate 标签中（规则语法与 Laravel 相同，
+// 用 "|" 分隔）。From 先按规则验证输入，未通过时返回 *exceptions.ValidationError（422），
+// 再把输入映射到结构体；表单中的字符串按字段类型转换为数字和布尔值。
+// 嵌套的结构体和结构体切片按点号路径验证（"items.0.quantity"）。
+// Lazy 属性只在 Include 时计算，ToMap、Transform 和 Response 把数据对象转换回响应数据。
+//
+// 主要特性：
+// - From 支持 *http.Request、routing.RequestInterface、map、JSON 和模型（按 database.ToMap 序列化）
+// - validate 标签和 DataObject.Rules 声明规则，HasMessages 自定义错误消息，设置了默认翻译器时使用 validation.* 翻译
+// - Collect 映射数据对象集合，Transform 和 Response 输出单个对象或集合
+// - Lazy 延迟属性，按点号路径 Include 嵌套的延迟属性
+//
+// 包结构：
+// - data.go - DataObject 等钩子接口、From、Collect 和输入转换
+// - validate.go - 验证规则和错误消息
+// - transform.go - Lazy、ToMap、Transform 和 Response
+//
+// 使用示例：
+//
+//	type OrderItemData struct {
+//		ProductID int64 `json:"product_id" validate:"required|integer"`
+//		Quantity  int   `json:"quantity" validate:"required|integer|min:1"`
+//	}
+//
+//	type OrderData struct {
+//		Email    string                  `json:"email" validate:"required|email|max:255"`
+//		Items    []OrderItemData         `json:"items" validate:"required|array|min:1"`
+//		Customer data.Lazy[CustomerData] `json:"customer"`
+//	}
+//
+//	func (c *OrderController) Store(w http.ResponseWriter, r *http.Request) error {
+//		order, err := data.From[OrderData](r)
+//		if err != nil {
+//			return err // *exceptions.ValidationError 渲染为 422 或跳转回表单
+//		}
+//		order.Customer = data.LazyOf(func() CustomerData { return c.customers.Find(order.Email) })
+//		response, err := data.Response(order, http.StatusCreated, "customer")
+//		if err != nil {
+//			return err
+//		}
+//		return response.WriteTo(w)
+//	}
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/exceptions"
+	"github.com/cnote0/laraveldoc/routing"
+)
+
+// ErrInvalidSource 输入源不能转换为属性映射
+var ErrInvalidSource = errors.New("data: invalid source")
+
+// DataObject 在 validate 标签之外声明验证规则的数据对象，键为属性的点号路径，规则追加在标签规则之后
+type DataObject interface {
+	Rules() map[string]string
+}
+
+// HasMessages 自定义错误消息的数据对象，键为 "属性.规则"，例如 "email.required"
+type HasMessages interface {
+	Messages() map[string]string
+}
+
+// Preparable 在验证前调整输入的数据对象，对应 laravel-data 的 prepareForPipeline
+type Preparable interface {
+	Prepare(input map[string]interface{}) map[string]interface{}
+}
+
+// From 验证输入并映射为数据对象 T
+//
+// source 可以是 *http.Request、routing.RequestInterface（查询参数和请求体）、map[string]interface{}、
+// JSON（[]byte、json.RawMessage）或模型（结构体或结构体指针，按 database.ToMap 序列化）。
+// 验证未通过时返回 *exceptions.ValidationError。
+func From[T any](source interface{}) (T, error) {
+	var object T
+	input, err := Input(source)
+	if err != nil {
+		return object, err
+	}
+	if preparable, ok := any(&object).(Preparable); ok {
+		input = preparable.Prepare(input)
+	}
+	if err := Validate[T](input); err != nil {
+		return object, err
+	}
+	if err := decode(input, &object); err != nil {
+		return object, err
+	}
+	return object, nil
+}
+
+// Collect 把多个输入映射为数据对象切片，items 为切片（例如 []map[string]interface{} 或模型切片）
+//
+// 验证错误的属性名带有下标前缀，例如 "2.email"。
+func Collect[T any](items interface{}) ([]T, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w: cannot collect %T", ErrInvalidSource, items)
+	}
+	objects := make([]T, v.Len())
+	messages := make(map[string][]string)
+	for i := 0; i < v.Len(); i++ {
+		object, err := From[T](v.Index(i).Interface())
+		var failed *exceptions.ValidationError
+		switch {
+		case errors.As(err, &failed):
+			for attribute, list := range failed.Errors {
+				messages[strconv.Itoa(i)+"."+attribute] = list
+			}
+		case err != nil:
+			return nil, fmt.Errorf("data: item %d: %w", i, err)
+		}
+		objects[i] = object
+	}
+	if len(messages) > 0 {
+		return nil, exceptions.NewValidationError(messages)
+	}
+	return objects, nil
+}
+
+// Input 把输入源转换为属性映射，source 的类型见 From
+func Input(source interface{}) (map[string]interface{}, error) {
+	switch s := source.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return s, nil
+	case *http.Request:
+		request := routing.NewRequest(s)
+		input := request.All()
+		if err := request.ParseError(); err != nil {
+			return nil, err
+		}
+		return nestBrackets(input), nil
+	case routing.RequestInterface:
+		return nestBrackets(s.All()), nil
+	case []byte:
+		return decodeJSON(s)
+	case json.RawMessage:
+		return decodeJSON(s)
+	}
+	t := reflect.TypeOf(source)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		return database.ToMap(source)
+	}
+	return nil, fmt.Errorf("%w: %T", ErrInvalidSource, source)
+}
+
+// nestBrackets 把表单的方括号键展开为嵌套结构，"items[0][quantity]" 为 items 列表第一个元素的 quantity
+func nestBrackets(input map[string]interface{}) map[string]interface{} {
+	nested := make(map[string]interface{}, len(input))
+	for key, value := range input {
+		open := strings.IndexByte(key, '[')
+		if open <= 0 || !strings.HasSuffix(key, "]") {
+			nested[key] = value
+			continue
+		}
+		segments := append([]string{key[:open]}, strings.Split(key[open+1:len(key)-1], "][")...)
+		node := nested
+		for i, segment := range segments {
+			if i == len(segments)-1 {
+				node[segment] = value
+				break
+			}
+			child, ok := node[segment].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	for key, value := range nested {
+		nested[key] = listify(value)
+	}
+	return nested
+}
+
+// listify 把键为 0 到 n-1 的 map 转换为列表
+func listify(value interface{}) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	for key, item := range m {
+		m[key] = listify(item)
+	}
+	list := make([]interface{}, len(m))
+	for key, item := range m {
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(m) || strconv.Itoa(i) != key {
+			return m
+		}
+		list[i] = item
+	}
+	if len(list) == 0 {
+		return m
+	}
+	return list
+}
+
+// decodeJSON 解码 JSON 对象
+func decodeJSON(raw []byte) (map[string]interface{}, error) {
+	var input map[string]interface{}
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSource, err)
+	}
+	return input, nil
+}
+
+// decode 按字段类型转换输入后映射到结构体
+func decode(input map[string]interface{}, target interface{}) error {
+	coerced := coerce(input, reflect.TypeOf(target).Elem())
+	raw, err := json.Marshal(coerced)
+	if err != nil {
+		return fmt.Errorf("data: encode input: %w", err)
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("data: decode %T: %w", target, err)
+	}
+	return nil
+}
+
+// coerce 把表单中的字符串按字段类型转换为数字和布尔值，递归处理嵌套的结构体、切片和 Lazy
+func coerce(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if inner, ok := lazyElem(t); ok {
+		return coerce(value, inner)
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return v
+		}
+		fields := fieldTypes(t)
+		coerced := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if fieldType, ok := fields[key]; ok {
+				coerced[key] = coerce(item, fieldType)
+			} else {
+				coerced[key] = item
+			}
+		}
+		return coerced
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return v
+		}
+		coerced := make([]interface{}, len(v))
+		for i, item := range v {
+			coerced[i] = coerce(item, t.Elem())
+		}
+		return coerced
+	case string:
+		text := strings.TrimSpace(v)
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			if text == "" {
+				return nil
+			}
+			if _, err := strconv.ParseFloat(text, 64); err == nil {
+				return json.Number(text)
+			}
+		case reflect.Bool:
+			if b, ok := parseBool(text); ok {
+				return b
+			}
+		}
+	}
+	return value
+}
+
+// fieldTypes 结构体属性名到字段类型的映射，嵌入的结构体展开到同一层
+func fieldTypes(t reflect.Type) map[string]reflect.Type {
+	types := make(map[string]reflect.Type)
+	for _, field := range fields(t) {
+		types[field.name] = field.typ
+	}
+	return types
+}
+
+// field 数据对象的属性
+type field struct {
+	name  string
+	index []int
+	typ   reflect.Type
+	rules string
+}
+
+// fields 结构体的属性，属性名为 json 标签名，没有时为字段名，json:"-" 和未导出的字段跳过
+func fields(t reflect.Type) []field {
+	var result []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			for _, embedded := range fields(f.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				result = append(result, embedded)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		result = append(result, field{name: name, index: []int{i}, typ: f.Type, rules: f.Tag.Get("validate")})
+	}
+	return result
+}
+
+// parseBool 解析表单中的布尔值
+func parseBool(text string) (bool, bool) {
+	switch strings.ToLower(text) {
+	case "1", "true", "on", "yes":
+		return true, true
+	case "0", "false", "off", "no", "":
+		return false, true
+	}
+	return false, false
+}
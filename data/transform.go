@@ -0,0 +1,213 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/routing"
+)
+
+// Lazy 延迟属性，只有在 ToMap、Transform 或 Response 中 Include 时才计算，对应 laravel-data 的 Lazy
+//
+// 直接用 encoding/json 编码时总是计算并输出值。零值 Lazy 没有值，输出 null。
+type Lazy[T any] struct {
+	resolve func() T
+}
+
+// LazyOf 创建延迟计算的属性
+func LazyOf[T any](resolve func() T) Lazy[T] {
+	return Lazy[T]{resolve: resolve}
+}
+
+// LazyValue 创建已有值的延迟属性，仍然只在 Include 时输出
+func LazyValue[T any](value T) Lazy[T] {
+	return Lazy[T]{resolve: func() T { return value }}
+}
+
+// Value 计算属性值，零值 Lazy 返回 T 的零值
+func (l Lazy[T]) Value() T {
+	if l.resolve == nil {
+		var zero T
+		return zero
+	}
+	return l.resolve()
+}
+
+// IsSet 是否设置了值或计算函数
+func (l Lazy[T]) IsSet() bool {
+	return l.resolve != nil
+}
+
+// MarshalJSON 计算并编码属性值
+func (l Lazy[T]) MarshalJSON() ([]byte, error) {
+	if l.resolve == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(l.resolve())
+}
+
+// UnmarshalJSON 解码为已有值的延迟属性
+func (l *Lazy[T]) UnmarshalJSON(raw []byte) error {
+	if string(raw) == "null" {
+		*l = Lazy[T]{}
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return err
+	}
+	*l = LazyValue(value)
+	return nil
+}
+
+// lazyValue 计算延迟属性的值，返回 false 表示没有设置
+func (l Lazy[T]) lazyValue() (interface{}, bool) {
+	if l.resolve == nil {
+		return nil, false
+	}
+	return l.resolve(), true
+}
+
+// lazyType 延迟属性的值类型
+func (Lazy[T]) lazyType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// lazyProperty 所有 Lazy 实例化类型实现的接口
+type lazyProperty interface {
+	lazyValue() (interface{}, bool)
+	lazyType() reflect.Type
+}
+
+var lazyPropertyType = reflect.TypeOf((*lazyProperty)(nil)).Elem()
+
+// isLazy 类型是否为 Lazy
+func isLazy(t reflect.Type) bool {
+	return t.Implements(lazyPropertyType)
+}
+
+// lazyElem Lazy 的值类型
+func lazyElem(t reflect.Type) (reflect.Type, bool) {
+	if !isLazy(t) {
+		return nil, false
+	}
+	return reflect.Zero(t).Interface().(lazyProperty).lazyType(), true
+}
+
+// ToMap 把数据对象转换为 map，嵌套的数据对象和切片递归转换，Lazy 属性只在 include 中时计算
+//
+// include 为属性的点号路径，"customer.orders" 同时包含 customer 和其中的 orders；
+// "*" 包含当前层的全部延迟属性。
+func ToMap(object interface{}, include ...string) (map[string]interface{}, error) {
+	transformed, err := Transform(object, include...)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := transformed.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("data: %T is not a data object", object)
+	}
+	return m, nil
+}
+
+// Transform 把数据对象或数据对象切片转换为可以编码为 JSON 的值，include 见 ToMap
+func Transform(value interface{}, include ...string) (interface{}, error) {
+	return transform(reflect.ValueOf(value), includeTree(include))
+}
+
+// Response 把数据对象或集合编码为 JSON 响应，status 为 0 时使用 200，include 见 ToMap
+func Response(value interface{}, status int, include ...string) (*routing.Response, error) {
+	transformed, err := Transform(value, include...)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(transformed)
+	if err != nil {
+		return nil, fmt.Errorf("data: encode response: %w", err)
+	}
+	response := routing.NewResponse(string(body), status)
+	response.SetHeader("Content-Type", "application/json")
+	return response, nil
+}
+
+// includes 包含的延迟属性树
+type includes map[string]includes
+
+// includeTree 把点号路径转换为树
+func includeTree(paths []string) includes {
+	tree := includes{}
+	for _, path := range paths {
+		node := tree
+		for _, segment := range strings.Split(path, ".") {
+			child, ok := node[segment]
+			if !ok {
+				child = includes{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+// transform 递归转换值
+func transform(v reflect.Value, include includes) (interface{}, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if isValueStruct(v.Type()) {
+			return v.Interface(), nil
+		}
+		result := make(map[string]interface{})
+		for _, f := range fields(v.Type()) {
+			fieldValue := v.FieldByIndex(f.index)
+			nested := include[f.name]
+			if isLazy(f.typ) {
+				if _, ok := include[f.name]; !ok {
+					if _, all := include["*"]; !all {
+						continue
+					}
+				}
+				resolved, ok := fieldValue.Interface().(lazyProperty).lazyValue()
+				if !ok {
+					result[f.name] = nil
+					continue
+				}
+				fieldValue = reflect.ValueOf(resolved)
+			}
+			transformed, err := transform(fieldValue, nested)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.name, err)
+			}
+			result[f.name] = transformed
+		}
+		return result, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return []interface{}{}, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface(), nil
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			item, err := transform(v.Index(i), include)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	}
+	return v.Interface(), nil
+}
@@ -0,0 +1,472 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cnote0/laraveldoc/exceptions"
+	"github.com/cnote0/laraveldoc/translation"
+)
+
+// defaultMessages 没有翻译时的英文错误消息，与 Laravel 的 lang/en/validation.php 相同
+var defaultMessages = map[string]string{
+	"accepted":        "The :attribute field must be accepted.",
+	"array":           "The :attribute field must be an array.",
+	"between.array":   "The :attribute field must have between :min and :max items.",
+	"between.numeric": "The :attribute field must be between :min and :max.",
+	"between.string":  "The :attribute field must be between :min and :max characters.",
+	"boolean":         "The :attribute field must be true or false.",
+	"confirmed":       "The :attribute field confirmation does not match.",
+	"date":            "The :attribute field must be a valid date.",
+	"email":           "The :attribute field must be a valid email address.",
+	"in":              "The selected :attribute is invalid.",
+	"integer":         "The :attribute field must be an integer.",
+	"max.array":       "The :attribute field must not have more than :max items.",
+	"max.numeric":     "The :attribute field must not be greater than :max.",
+	"max.string":      "The :attribute field must not be greater than :max characters.",
+	"min.array":       "The :attribute field must have at least :min items.",
+	"min.numeric":     "The :attribute field must be at least :min.",
+	"min.string":      "The :attribute field must be at least :min characters.",
+	"not_in":          "The selected :attribute is invalid.",
+	"numeric":         "The :attribute field must be a number.",
+	"regex":           "The :attribute field format is invalid.",
+	"required":        "The :attribute field is required.",
+	"size.array":      "The :attribute field must contain :size items.",
+	"size.numeric":    "The :attribute field must be :size.",
+	"size.string":     "The :attribute field must be :size characters.",
+	"string":          "The :attribute field must be a string.",
+	"url":             "The :attribute field must be a valid URL.",
+	"uuid":            "The :attribute field must be a valid UUID.",
+}
+
+// uuidPattern UUID 的格式
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ruleSet 一个属性路径的规则，typ 为字段类型，用于判断 min、max 等规则按数值、长度还是数量比较
+type ruleSet struct {
+	path  string
+	rules []string
+	typ   reflect.Type
+}
+
+// Validate 按数据对象 T 的 validate 标签和 Rules 验证输入，未通过时返回 *exceptions.ValidationError
+//
+// 支持的规则：required、nullable、sometimes、string、integer、numeric、boolean、array、email、url、uuid、date、
+// accepted、confirmed、min、max、between、size、in、not_in、regex，不认识的规则视为未通过。
+// 除 required 外的规则在属性缺失或为空时跳过；nullable 的属性为 null 时跳过，sometimes 的属性缺失时跳过。
+func Validate[T any](input map[string]interface{}) error {
+	var object T
+	sets := collectRules(reflect.TypeOf(object), "")
+	if declaring, ok := any(&object).(DataObject); ok {
+		types := make(map[string]reflect.Type, len(sets))
+		for _, set := range sets {
+			types[set.path] = set.typ
+		}
+		paths := make([]string, 0)
+		for path := range declaring.Rules() {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			sets = append(sets, ruleSet{path: path, rules: splitRules(declaring.Rules()[path]), typ: types[path]})
+		}
+	}
+	var custom map[string]string
+	if messaging, ok := any(&object).(HasMessages); ok {
+		custom = messaging.Messages()
+	}
+
+	messages := make(map[string][]string)
+	for _, set := range sets {
+		for _, attribute := range expandPath(input, set.path) {
+			for _, failure := range validateAttribute(input, attribute, set) {
+				messages[attribute] = append(messages[attribute], message(custom, set.path, attribute, failure))
+			}
+		}
+	}
+	if len(messages) > 0 {
+		return exceptions.NewValidationError(messages)
+	}
+	return nil
+}
+
+// collectRules 收集结构体字段的规则，嵌套结构体使用点号路径，结构体切片使用 "*"
+func collectRules(t reflect.Type, prefix string) []ruleSet {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || isLazy(t) {
+		return nil
+	}
+	var sets []ruleSet
+	for _, f := range fields(t) {
+		path := prefix + f.name
+		if f.rules != "" {
+			sets = append(sets, ruleSet{path: path, rules: splitRules(f.rules), typ: f.typ})
+		}
+		elem := f.typ
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		switch elem.Kind() {
+		case reflect.Struct:
+			if !isValueStruct(elem) {
+				sets = append(sets, collectRules(elem, path+".")...)
+			}
+		case reflect.Slice, reflect.Array:
+			sets = append(sets, collectRules(elem.Elem(), path+".*.")...)
+		}
+	}
+	return sets
+}
+
+// isValueStruct 作为单个值的结构体，例如 time.Time 和实现了 JSON 反序列化的类型
+func isValueStruct(t reflect.Type) bool {
+	return t == reflect.TypeOf(time.Time{}) || reflect.PointerTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem())
+}
+
+// splitRules 拆分 "|" 分隔的规则，regex 规则的参数可能包含 "|"，因此 regex 之后的部分作为一条规则
+func splitRules(rules string) []string {
+	var result []string
+	for rules != "" {
+		if strings.HasPrefix(rules, "regex:") {
+			result = append(result, rules)
+			break
+		}
+		rule, rest, _ := strings.Cut(rules, "|")
+		if rule = strings.TrimSpace(rule); rule != "" {
+			result = append(result, rule)
+		}
+		rules = rest
+	}
+	return result
+}
+
+// expandPath 把带 "*" 的路径展开为输入中实际存在的属性路径
+func expandPath(input map[string]interface{}, path string) []string {
+	before, after, ok := strings.Cut(path, ".*")
+	if !ok {
+		return []string{path}
+	}
+	items, _ := lookup(input, before)
+	list, _ := items.([]interface{})
+	var paths []string
+	for i := range list {
+		paths = append(paths, expandPath(input, before+"."+strconv.Itoa(i)+after)...)
+	}
+	return paths
+}
+
+// lookup 按点号路径取值
+func lookup(input map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = input
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			current = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// failure 未通过的规则，key 为消息的键（例如 "min.string"），replace 为消息的占位符
+type failure struct {
+	rule    string
+	key     string
+	replace map[string]string
+}
+
+// validateAttribute 验证一个属性，返回未通过的规则
+func validateAttribute(input map[string]interface{}, attribute string, set ruleSet) []failure {
+	value, present := lookup(input, attribute)
+	has := func(name string) bool {
+		for _, rule := range set.rules {
+			if rule == name {
+				return true
+			}
+		}
+		return false
+	}
+	if has("sometimes") && !present {
+		return nil
+	}
+	if has("nullable") && present && value == nil {
+		return nil
+	}
+	empty := isEmpty(value)
+
+	var failures []failure
+	for _, rule := range set.rules {
+		name, parameter, _ := strings.Cut(rule, ":")
+		if name == "required" {
+			if !present || empty {
+				failures = append(failures, failure{rule: name, key: name})
+			}
+			continue
+		}
+		if !present || empty {
+			continue
+		}
+		if f, ok := checkRule(input, attribute, value, name, parameter, set); !ok {
+			failures = append(failures, f)
+		}
+	}
+	return failures
+}
+
+// checkRule 检查一条规则
+func checkRule(input map[string]interface{}, attribute string, value interface{}, name, parameter string, set ruleSet) (failure, bool) {
+	f := failure{rule: name, key: name}
+	switch name {
+	case "nullable", "sometimes":
+		return f, true
+	case "string":
+		_, ok := value.(string)
+		return f, ok
+	case "integer":
+		text, ok := scalarText(value)
+		if !ok {
+			return f, false
+		}
+		_, err := strconv.ParseInt(text, 10, 64)
+		return f, err == nil
+	case "numeric":
+		_, ok := numericValue(value)
+		return f, ok
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return f, true
+		case string:
+			return f, v == "0" || v == "1" || v == "true" || v == "false"
+		}
+		text, _ := scalarText(value)
+		return f, text == "0" || text == "1"
+	case "array":
+		switch value.(type) {
+		case []interface{}, map[string]interface{}:
+			return f, true
+		}
+		return f, false
+	case "email":
+		text, _ := value.(string)
+		address, err := mail.ParseAddress(text)
+		return f, err == nil && address.Address == text
+	case "url":
+		text, _ := value.(string)
+		u, err := url.ParseRequestURI(text)
+		return f, err == nil && u.Scheme != "" && u.Host != ""
+	case "uuid":
+		text, _ := value.(string)
+		return f, uuidPattern.MatchString(text)
+	case "date":
+		text, _ := value.(string)
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if _, err := time.Parse(layout, text); err == nil {
+				return f, true
+			}
+		}
+		return f, false
+	case "accepted":
+		text, _ := scalarText(value)
+		return f, value == true || text == "1" || text == "yes" || text == "on" || text == "true"
+	case "confirmed":
+		confirmation, _ := lookup(input, attribute+"_confirmation")
+		return f, fmt.Sprint(confirmation) == fmt.Sprint(value)
+	case "in", "not_in":
+		text, _ := scalarText(value)
+		found := false
+		for _, option := range strings.Split(parameter, ",") {
+			if option == text {
+				found = true
+				break
+			}
+		}
+		return f, found == (name == "in")
+	case "regex":
+		pattern, err := regexp.Compile(strings.Trim(parameter, "/"))
+		text, _ := value.(string)
+		return f, err == nil && pattern.MatchString(text)
+	case "min", "max", "size", "between":
+		return checkSize(value, name, parameter, set)
+	}
+	return failure{rule: name, key: "unknown", replace: map[string]string{"rule": name}}, false
+}
+
+// checkSize 检查 min、max、size 和 between，数值字段比较数值，字符串比较字符数，切片比较元素数
+func checkSize(value interface{}, name, parameter string, set ruleSet) (failure, bool) {
+	kind := sizeKind(value, set)
+	var size float64
+	switch kind {
+	case "numeric":
+		size, _ = numericValue(value)
+	case "array":
+		switch v := value.(type) {
+		case []interface{}:
+			size = float64(len(v))
+		case map[string]interface{}:
+			size = float64(len(v))
+		}
+	default:
+		text, _ := scalarText(value)
+		size = float64(utf8.RuneCountInString(text))
+	}
+
+	f := failure{rule: name, key: name + "." + kind}
+	bounds := strings.Split(parameter, ",")
+	limits := make([]float64, len(bounds))
+	for i, bound := range bounds {
+		limits[i], _ = strconv.ParseFloat(strings.TrimSpace(bound), 64)
+	}
+	switch name {
+	case "min":
+		f.replace = map[string]string{"min": bounds[0]}
+		return f, size >= limits[0]
+	case "max":
+		f.replace = map[string]string{"max": bounds[0]}
+		return f, size <= limits[0]
+	case "size":
+		f.replace = map[string]string{"size": bounds[0]}
+		return f, size == limits[0]
+	}
+	if len(limits) < 2 {
+		return f, false
+	}
+	f.replace = map[string]string{"min": bounds[0], "max": bounds[1]}
+	return f, size >= limits[0] && size <= limits[1]
+}
+
+// sizeKind 按字段类型判断大小规则的比较方式，没有字段类型时按值判断
+func sizeKind(value interface{}, set ruleSet) string {
+	t := set.typ
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != nil {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return "numeric"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return "array"
+		case reflect.String:
+			return "string"
+		}
+	}
+	for _, rule := range set.rules {
+		if rule == "numeric" || rule == "integer" {
+			return "numeric"
+		}
+	}
+	switch value.(type) {
+	case float64, json.Number, int, int64:
+		return "numeric"
+	case []interface{}, map[string]interface{}:
+		return "array"
+	}
+	return "string"
+}
+
+// isEmpty 值是否为空：nil、空白字符串、空切片或空 map
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// scalarText 标量值的文本形式
+func scalarText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v), true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		if v {
+			return "1", true
+		}
+		return "0", true
+	case int, int64, int32, uint, uint64:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
+
+// numericValue 数值，字符串形式的数字也接受
+func numericValue(value interface{}) (float64, bool) {
+	if _, ok := value.(bool); ok {
+		return 0, false
+	}
+	text, ok := scalarText(value)
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(text, 64)
+	return number, err == nil
+}
+
+// message 错误消息：数据对象的自定义消息、默认翻译器的 validation.* 翻译，最后是英文默认消息
+func message(custom map[string]string, path, attribute string, f failure) string {
+	replace := map[string]string{"attribute": strings.ReplaceAll(attribute, "_", " ")}
+	for name, value := range f.replace {
+		replace[name] = value
+	}
+	for _, key := range []string{attribute + "." + f.rule, path + "." + f.rule, f.rule} {
+		if text, ok := custom[key]; ok {
+			return replacePlaceholders(text, replace)
+		}
+	}
+	if translator := translation.DefaultTranslator(); translator != nil && translator.Has("validation."+f.key, true) {
+		return translation.ValidationMessage(translator, f.key, attribute, f.replace)
+	}
+	text, ok := defaultMessages[f.key]
+	if !ok {
+		text = "The :attribute field has an unsupported rule :rule."
+	}
+	return replacePlaceholders(text, replace)
+}
+
+// replacePlaceholders 替换 :name 形式的占位符，较长的名称先替换
+func replacePlaceholders(text string, replace map[string]string) string {
+	names := make([]string, 0, len(replace))
+	for name := range replace {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	for _, name := range names {
+		text = strings.ReplaceAll(text, ":"+name, replace[name])
+	}
+	return text
+}
@@ -0,0 +1,265 @@
+// Package archive 提供模型归档：把不再活跃的旧记录从热表移到归档表或存储磁盘，并支持恢复
+//
+// 与 Laravel 的 Prunable 类似，模型通过 Archivable 返回需要归档的记录的查询条件，
+// 但记录不是被删除，而是按块写入归档目标后再从热表删除，满足合规留存的同时保持热表精简。
+// 每一块是一个批次，批次名可以用于恢复。
+//
+// 主要特性：
+// - Archivable 模型契约，查询条件与 Prunable 一致
+// - 按块归档，每块先写入目标再从热表删除，不会丢失记录
+// - 归档表目标（同库或其他库）和存储磁盘目标（JSON Lines、CSV）
+// - 按批次恢复记录到热表
+// - model:archive 和 model:archive-restore 命令
+//
+// 包结构：
+// - archive.go - Archivable 契约、Archiver 归档器和 Config 配置
+// - target.go - Target 归档目标接口和 TableTarget 归档表目标
+// - disk.go - DiskTarget 存储磁盘目标和 Format 文件格式（JSON Lines、CSV）
+// - command.go - model:archive、model:archive-restore 命令
+//
+// 使用示例：
+//
+//	type Order struct {
+//		database.Model
+//		Status string
+//	}
+//
+//	func (Order) Archivable(query database.DB) database.DB {
+//		return query.Where("status = ? AND created_at < ?", "completed", time.Now().AddDate(-1, 0, 0))
+//	}
+//
+//	disk, _ := storageManager.Disk("s3")
+//	archiver := archive.New(archive.Config{DB: db, Target: archive.NewDiskTarget(disk, "archive", archive.JSONLines)})
+//	result, err := archiver.Archive(ctx, Order{})
+//	// result.Batches: ["20261016T080000.000000000-0001", ...]
+//
+//	restored, err := archiver.Restore(ctx, Order{}, result.Batches[0])
+//
+//	archive.RegisterCommands(artisan, archiver, Order{}, Invoice{})
+//	// php artisan model:archive --model=Order --pretend
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// DefaultChunkSize 每批归档的默认记录数
+const DefaultChunkSize = 1000
+
+var (
+	// ErrNoTarget 没有配置归档目标
+	ErrNoTarget = errors.New("archive: no archive target configured")
+
+	// ErrBatchNotFound 归档批次不存在
+	ErrBatchNotFound = errors.New("archive: batch not found")
+
+	// ErrNotDeleted 归档后从热表删除记录失败，继续归档会重复写入同一批记录
+	ErrNotDeleted = errors.New("archive: archived rows were not deleted from the source table")
+)
+
+// Row 一条记录，键为列名
+type Row = map[string]interface{}
+
+// Archivable 可归档的模型，对应 Laravel 的 Prunable
+type Archivable interface {
+	// Archivable 在热表查询上添加条件，选出需要归档的记录
+	Archivable(query database.DB) database.DB
+}
+
+// Config 归档器配置
+type Config struct {
+	// DB 热表所在的数据库连接
+	DB database.DB
+
+	// Target 归档目标
+	Target Target
+
+	// ChunkSize 每批归档的记录数，默认 DefaultChunkSize
+	ChunkSize int
+}
+
+// Result 一次归档的结果
+type Result struct {
+	// Archived 归档的记录数
+	Archived int64
+
+	// Batches 产生的批次名，按归档顺序
+	Batches []string
+}
+
+// Archiver 归档器
+type Archiver struct {
+	config Config
+	now    func() time.Time
+}
+
+// New 创建归档器
+func New(config Config) *Archiver {
+	if config.ChunkSize <= 0 {
+		config.ChunkSize = DefaultChunkSize
+	}
+	return &Archiver{config: config, now: time.Now}
+}
+
+// Archive 按块归档模型 Archivable 选出的记录
+//
+// 每块先写入归档目标，成功后再按主键从热表删除。删除失败时返回错误，已写入目标的这一块保留在归档中，
+// 重新运行会再次归档这些记录，恢复时以热表中已存在的记录为准跳过重复。
+func (a *Archiver) Archive(ctx context.Context, model Archivable) (Result, error) {
+	var result Result
+	if a.config.Target == nil {
+		return result, ErrNoTarget
+	}
+	table, key := TableName(model), KeyName(model)
+	stamp := a.now().UTC().Format("20060102T150405.000000000")
+	for chunk := 1; ; chunk++ {
+		var rows []Row
+		query := model.Archivable(a.config.DB.WithContext(ctx).Table(table))
+		if err := query.Order(key).Limit(a.config.ChunkSize).Find(&rows).Error(); err != nil {
+			return result, fmt.Errorf("archive: select %s: %w", table, err)
+		}
+		if len(rows) == 0 {
+			return result, nil
+		}
+
+		batch := fmt.Sprintf("%s-%04d", stamp, chunk)
+		if err := a.config.Target.Store(ctx, table, batch, rows); err != nil {
+			return result, fmt.Errorf("archive: store %s batch %s: %w", table, batch, err)
+		}
+		keys := make([]interface{}, len(rows))
+		for i, row := range rows {
+			keys[i] = row[key]
+		}
+		deleted := a.config.DB.WithContext(ctx).Table(table).Where(key+" IN ?", keys).Delete(Row{})
+		if err := deleted.Error(); err != nil {
+			return result, fmt.Errorf("archive: delete archived %s rows: %w", table, err)
+		}
+		result.Archived += int64(len(rows))
+		result.Batches = append(result.Batches, batch)
+		if deleted.RowsAffected() == 0 {
+			return result, fmt.Errorf("%w: %s batch %s", ErrNotDeleted, table, batch)
+		}
+		if len(rows) < a.config.ChunkSize {
+			return result, nil
+		}
+	}
+}
+
+// Pretend 需要归档的记录数，不做任何修改
+func (a *Archiver) Pretend(ctx context.Context, model Archivable) (int64, error) {
+	var count int64
+	err := model.Archivable(a.config.DB.WithContext(ctx).Table(TableName(model))).Count(&count).Error()
+	return count, err
+}
+
+// Batches 模型已归档的批次名，按名称（即归档时间）排序
+func (a *Archiver) Batches(ctx context.Context, model Archivable) ([]string, error) {
+	if a.config.Target == nil {
+		return nil, ErrNoTarget
+	}
+	return a.config.Target.Batches(ctx, TableName(model))
+}
+
+// Restore 把一个批次的记录写回热表并从归档中删除，热表中已存在的主键被跳过，返回写回的记录数
+func (a *Archiver) Restore(ctx context.Context, model Archivable, batch string) (int64, error) {
+	if a.config.Target == nil {
+		return 0, ErrNoTarget
+	}
+	table, key := TableName(model), KeyName(model)
+	rows, err := a.config.Target.Load(ctx, table, batch)
+	if err != nil {
+		return 0, err
+	}
+
+	var restored int64
+	err = a.config.DB.WithContext(ctx).Transaction(func(tx database.DB) error {
+		keys := make([]interface{}, len(rows))
+		for i, row := range rows {
+			keys[i] = row[key]
+		}
+		var existing []interface{}
+		if len(keys) > 0 {
+			if err := tx.Table(table).Where(key+" IN ?", keys).Pluck(key, &existing).Error(); err != nil {
+				return err
+			}
+		}
+		skip := make(map[string]bool, len(existing))
+		for _, k := range existing {
+			skip[fmt.Sprint(k)] = true
+		}
+		var missing []Row
+		for _, row := range rows {
+			if !skip[fmt.Sprint(row[key])] {
+				missing = append(missing, row)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		restored = int64(len(missing))
+		return tx.Table(table).Create(missing).Error()
+	})
+	if err != nil {
+		return 0, fmt.Errorf("archive: restore %s batch %s: %w", table, batch, err)
+	}
+	if err := a.config.Target.Forget(ctx, table, batch); err != nil {
+		return restored, err
+	}
+	return restored, nil
+}
+
+// TableName 模型的表名，同 database.TableName
+func TableName(model interface{}) string {
+	return database.TableName(model)
+}
+
+// KeyName 模型的主键列：gorm 标签为 primaryKey 的字段，默认为 id
+func KeyName(model interface{}) string {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if name, ok := primaryKey(t); ok {
+		return name
+	}
+	return "id"
+}
+
+// primaryKey 查找标记为主键的字段，包括嵌入的结构体
+func primaryKey(t reflect.Type) (string, bool) {
+	if t.Kind() != reflect.Struct {
+		return "", false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		for _, setting := range strings.Split(field.Tag.Get("gorm"), ";") {
+			if strings.EqualFold(strings.TrimSpace(setting), "primaryKey") {
+				return columnName(field), true
+			}
+		}
+		if field.Anonymous {
+			if name, ok := primaryKey(field.Type); ok {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// columnName 字段的列名：gorm 标签的 column，否则为字段名的 snake_case
+func columnName(field reflect.StructField) string {
+	for _, setting := range strings.Split(field.Tag.Get("gorm"), ";") {
+		if name, value, ok := strings.Cut(setting, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "column") {
+			return strings.TrimSpace(value)
+		}
+	}
+	return str.Snake(field.Name)
+}
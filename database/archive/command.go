@@ -0,0 +1,126 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// RegisterCommands 注册 model:archive 和 model:archive-restore 命令
+//
+// 模型按类型名（例如 Order）选择。model:archive 不带 --model 时归档全部模型，
+// --pretend 只显示需要归档的记录数；model:archive-restore 不带批次名时列出模型已归档的批次。
+func RegisterCommands(artisan application.ArtisanInterface, archiver *Archiver, models ...Archivable) {
+	byName := make(map[string]Archivable, len(models))
+	names := make([]string, 0, len(models))
+	for _, model := range models {
+		name := modelName(model)
+		byName[name] = model
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	artisan.Register("model:archive").
+		SetDescription("Archive models that are no longer needed").
+		AddOption("model", "", application.InputOptionValueRequired|application.InputOptionValueIsArray, "Class names of the models to be archived", nil).
+		AddOption("pretend", "", application.InputOptionValueNone, "Display the number of archivable records found instead of archiving them", nil).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			selected := names
+			if requested := stringsOption(input.GetOption("model")); len(requested) > 0 {
+				selected = requested
+			}
+			ctx := context.Background()
+			for _, name := range selected {
+				model, ok := byName[name]
+				if !ok {
+					return fmt.Errorf("archive: unknown model %q, expected one of %s", name, strings.Join(names, ", "))
+				}
+				if pretend, _ := input.GetOption("pretend").(bool); pretend {
+					count, err := archiver.Pretend(ctx, model)
+					if err != nil {
+						return err
+					}
+					if err := output.WriteLine(fmt.Sprintf("%d [%s] records will be archived.", count, name), application.VerbosityNormal); err != nil {
+						return err
+					}
+					continue
+				}
+				result, err := archiver.Archive(ctx, model)
+				if err != nil {
+					return err
+				}
+				if err := output.WriteLine(fmt.Sprintf("%d [%s] records have been archived in %d batches.", result.Archived, name, len(result.Batches)), application.VerbosityNormal); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	artisan.Register("model:archive-restore").
+		SetDescription("Restore archived model batches").
+		AddArgument("model", application.InputArgumentRequired, "Class name of the archived model", nil).
+		AddArgument("batches", application.InputArgumentOptional|application.InputArgumentIsArray, "Batches to restore, lists the archived batches when omitted", nil).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			name, _ := input.GetArgument("model").(string)
+			model, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("archive: unknown model %q, expected one of %s", name, strings.Join(names, ", "))
+			}
+			ctx := context.Background()
+			batches := stringsOption(input.GetArgument("batches"))
+			if len(batches) == 0 {
+				archived, err := archiver.Batches(ctx, model)
+				if err != nil {
+					return err
+				}
+				for _, batch := range archived {
+					if err := output.WriteLine(batch, application.VerbosityNormal); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			for _, batch := range batches {
+				restored, err := archiver.Restore(ctx, model, batch)
+				if err != nil {
+					return err
+				}
+				if err := output.WriteLine(fmt.Sprintf("%d [%s] records have been restored from batch %s.", restored, name, batch), application.VerbosityNormal); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+// modelName 模型的类型名
+func modelName(model interface{}) string {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// stringsOption 读取数组参数或选项
+func stringsOption(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/storage"
+)
+
+// Format 归档文件格式
+//
+// 内置 JSONLines 和 CSV，Parquet 等列式格式可以基于第三方库实现本接口。
+// 两种内置格式都以 RFC 3339 保存时间，恢复时符合该格式的字符串转换回 time.Time。
+type Format interface {
+	// Extension 文件扩展名，不含点
+	Extension() string
+
+	// Encode 编码一个批次的记录
+	Encode(rows []Row) ([]byte, error)
+
+	// Decode 解码一个批次的记录
+	Decode(data []byte) ([]Row, error)
+}
+
+var (
+	// JSONLines 每行一条 JSON 记录，整数恢复为 int64
+	JSONLines Format = jsonLinesFormat{}
+
+	// CSV 第一行为列名，NULL 写为空值，恢复后其他值为字符串，由数据库按列类型转换
+	CSV Format = csvFormat{}
+)
+
+// DiskTarget 把每个批次导出为存储磁盘上的一个文件：目录/表名/批次名.扩展名
+type DiskTarget struct {
+	disk      storage.Filesystem
+	directory string
+	format    Format
+}
+
+var _ Target = (*DiskTarget)(nil)
+
+// NewDiskTarget 创建存储磁盘目标，directory 为空时使用 "archive"，format 为 nil 时使用 JSONLines
+func NewDiskTarget(disk storage.Filesystem, directory string, format Format) *DiskTarget {
+	if directory == "" {
+		directory = "archive"
+	}
+	if format == nil {
+		format = JSONLines
+	}
+	return &DiskTarget{disk: disk, directory: strings.Trim(directory, "/"), format: format}
+}
+
+// Path 批次文件的路径
+func (t *DiskTarget) Path(table, batch string) string {
+	return path.Join(t.directory, table, batch+"."+t.format.Extension())
+}
+
+// Store 写入批次文件
+func (t *DiskTarget) Store(ctx context.Context, table, batch string, rows []Row) error {
+	data, err := t.format.Encode(rows)
+	if err != nil {
+		return err
+	}
+	return t.disk.Put(ctx, t.Path(table, batch), data)
+}
+
+// Load 读取批次文件
+func (t *DiskTarget) Load(ctx context.Context, table, batch string) ([]Row, error) {
+	data, err := t.disk.Get(ctx, t.Path(table, batch))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s %s", ErrBatchNotFound, table, batch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t.format.Decode(data)
+}
+
+// Forget 删除批次文件
+func (t *DiskTarget) Forget(ctx context.Context, table, batch string) error {
+	return t.disk.Delete(ctx, t.Path(table, batch))
+}
+
+// Batches 目录中的批次名
+func (t *DiskTarget) Batches(ctx context.Context, table string) ([]string, error) {
+	files, err := t.disk.Files(ctx, path.Join(t.directory, table), false)
+	if err != nil {
+		return nil, err
+	}
+	suffix := "." + t.format.Extension()
+	var batches []string
+	for _, file := range files {
+		if name := path.Base(file); strings.HasSuffix(name, suffix) {
+			batches = append(batches, strings.TrimSuffix(name, suffix))
+		}
+	}
+	sort.Strings(batches)
+	return batches, nil
+}
+
+// jsonLinesFormat JSON Lines 格式
+type jsonLinesFormat struct{}
+
+// Extension 文件扩展名
+func (jsonLinesFormat) Extension() string {
+	return "jsonl"
+}
+
+// Encode 每行一条记录
+func (jsonLinesFormat) Encode(rows []Row) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, row := range rows {
+		if err := encoder.Encode(row); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode 逐行解码，整数转换为 int64，其他数字为 float64
+func (jsonLinesFormat) Decode(data []byte) ([]Row, error) {
+	var rows []Row
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		var row Row
+		if err := decoder.Decode(&row); err != nil {
+			return nil, err
+		}
+		for column, value := range row {
+			if number, ok := value.(json.Number); ok {
+				if n, err := number.Int64(); err == nil {
+					row[column] = n
+				} else if f, err := number.Float64(); err == nil {
+					row[column] = f
+				}
+			}
+			if text, ok := value.(string); ok {
+				row[column] = restoreText(text)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+// csvFormat CSV 格式
+type csvFormat struct{}
+
+// Extension 文件扩展名
+func (csvFormat) Extension() string {
+	return "csv"
+}
+
+// Encode 第一行为全部记录的列名并集，按名称排序
+func (csvFormat) Encode(rows []Row) ([]byte, error) {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+	record := make([]string, len(columns))
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = csvValue(row[column])
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// Decode 空值恢复为 nil，其他值为字符串
+func (csvFormat) Decode(data []byte) ([]Row, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	columns := records[0]
+	rows := make([]Row, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(Row, len(columns))
+		for i, column := range columns {
+			if i < len(record) && record[i] != "" {
+				row[column] = restoreText(record[i])
+			} else {
+				row[column] = nil
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// csvValue 值的 CSV 文本，时间使用 RFC 3339
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprint(value)
+}
+
+// restoreText 把 RFC 3339 格式的时间文本恢复为 time.Time，其他文本原样返回
+func restoreText(text string) interface{} {
+	if len(text) >= len(time.DateOnly) && text[4] == '-' && strings.ContainsRune(text, 'T') {
+		if t, err := time.Parse(time.RFC3339Nano, text); err == nil {
+			return t
+		}
+	}
+	return text
+}
@@ -0,0 +1,124 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// 归档表中记录批次和归档时间的列
+const (
+	BatchColumn      = "archive_batch"
+	ArchivedAtColumn = "archived_at"
+)
+
+// Target 归档目标
+type Target interface {
+	// Store 保存一个批次的记录
+	Store(ctx context.Context, table, batch string, rows []Row) error
+
+	// Load 读取一个批次的记录，批次不存在时返回 ErrBatchNotFound
+	Load(ctx context.Context, table, batch string) ([]Row, error)
+
+	// Forget 删除一个批次
+	Forget(ctx context.Context, table, batch string) error
+
+	// Batches 表已归档的批次名，按名称排序
+	Batches(ctx context.Context, table string) ([]string, error)
+}
+
+// TableTarget 把记录写入归档表，归档表与热表列相同，另有 archive_batch 和 archived_at 两列
+//
+// 归档表可以在其他数据库连接中（例如专用的归档库），使用 Migrate 创建。
+type TableTarget struct {
+	db     database.DB
+	suffix string
+	now    func() time.Time
+}
+
+var _ Target = (*TableTarget)(nil)
+
+// NewTableTarget 创建归档表目标，归档表名为热表名加 suffix，suffix 为空时使用 "_archive"
+func NewTableTarget(db database.DB, suffix string) *TableTarget {
+	if suffix == "" {
+		suffix = "_archive"
+	}
+	return &TableTarget{db: db, suffix: suffix, now: time.Now}
+}
+
+// Table 热表对应的归档表名
+func (t *TableTarget) Table(table string) string {
+	return table + t.suffix
+}
+
+// Migrate 按模型结构创建归档表，并加上 archive_batch 和 archived_at 列
+//
+// 模型上的唯一索引会一并创建，记录被归档、恢复后再次归档时会违反唯一约束，这类模型应手动建表。
+func (t *TableTarget) Migrate(ctx context.Context, models ...Archivable) error {
+	for _, model := range models {
+		typ := reflect.TypeOf(model)
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		archived := reflect.StructOf([]reflect.StructField{
+			{Name: "Record", Type: typ, Tag: `gorm:"embedded"`},
+			{Name: "ArchiveBatch", Type: reflect.TypeOf(""), Tag: `gorm:"column:archive_batch;size:64;index"`},
+			{Name: "ArchivedAt", Type: reflect.TypeOf(time.Time{}), Tag: `gorm:"column:archived_at"`},
+		})
+		if err := t.db.WithContext(ctx).Table(t.Table(TableName(model))).AutoMigrate(reflect.New(archived).Interface()); err != nil {
+			return fmt.Errorf("archive: migrate %s: %w", t.Table(TableName(model)), err)
+		}
+	}
+	return nil
+}
+
+// Store 写入归档表
+func (t *TableTarget) Store(ctx context.Context, table, batch string, rows []Row) error {
+	now := t.now()
+	archived := make([]Row, len(rows))
+	for i, row := range rows {
+		copied := make(Row, len(row)+2)
+		for column, value := range row {
+			copied[column] = value
+		}
+		copied[BatchColumn] = batch
+		copied[ArchivedAtColumn] = now
+		archived[i] = copied
+	}
+	return t.db.WithContext(ctx).Table(t.Table(table)).Create(archived).Error()
+}
+
+// Load 读取一个批次，去掉归档列
+func (t *TableTarget) Load(ctx context.Context, table, batch string) ([]Row, error) {
+	var rows []Row
+	if err := t.db.WithContext(ctx).Table(t.Table(table)).Where(BatchColumn+" = ?", batch).Find(&rows).Error(); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrBatchNotFound, table, batch)
+	}
+	for _, row := range rows {
+		delete(row, BatchColumn)
+		delete(row, ArchivedAtColumn)
+	}
+	return rows, nil
+}
+
+// Forget 从归档表删除一个批次
+func (t *TableTarget) Forget(ctx context.Context, table, batch string) error {
+	return t.db.WithContext(ctx).Table(t.Table(table)).Where(BatchColumn+" = ?", batch).Delete(Row{}).Error()
+}
+
+// Batches 归档表中的批次名
+func (t *TableTarget) Batches(ctx context.Context, table string) ([]string, error) {
+	var batches []string
+	if err := t.db.WithContext(ctx).Table(t.Table(table)).Distinct(BatchColumn).Pluck(BatchColumn, &batches).Error(); err != nil {
+		return nil, err
+	}
+	sort.Strings(batches)
+	return batches, nil
+}
@@ -0,0 +1,201 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidConfig 连接或会话配置无效，NewDatabaseConfig、NewSessionConfig 和 Validate 返回的错误满足 errors.Is
+var ErrInvalidConfig = errors.New("database: invalid config")
+
+// DatabaseConfig 数据库连接配置，对应 config/database.php 的 connections.* 项
+//
+// 推荐用 NewDatabaseConfig 创建：选项只设置需要的字段，新增字段不影响已有代码，
+// 缺省值按驱动补全，无效的组合在启动时返回错误。ToMap 转换为 DatabaseManager.Extend 的驱动收到的配置。
+type DatabaseConfig struct {
+	// Driver 驱动名称，例如 "mysql"、"pgsql"、"sqlite"、"sqlsrv"（driver）
+	Driver string
+
+	// DSN 完整的连接字符串，设置后忽略 Host、Port、Username 和 Password（url）
+	DSN string
+
+	// Host 主机，默认 "127.0.0.1"，sqlite 不使用（host）
+	Host string
+
+	// Port 端口，默认按驱动取 3306、5432 或 1433（port）
+	Port int
+
+	// Database 数据库名，sqlite 为文件路径或 ":memory:"（database）
+	Database string
+
+	// Username、Password 认证信息（username、password）
+	Username string
+	Password string
+
+	// Charset 字符集，mysql 默认 "utf8mb4"，pgsql 默认 "utf8"（charset）
+	Charset string
+
+	// Prefix 表名前缀（prefix）
+	Prefix string
+
+	// MaxOpenConns 最大打开连接数，0 表示不限制（max_open_conns）
+	MaxOpenConns int
+
+	// MaxIdleConns 最大空闲连接数，不能超过 MaxOpenConns（max_idle_conns）
+	MaxIdleConns int
+
+	// ConnMaxLifetime 连接最长使用时间，0 表示不限制（conn_max_lifetime）
+	ConnMaxLifetime time.Duration
+}
+
+// ConfigOption DatabaseConfig 选项
+type ConfigOption func(*DatabaseConfig)
+
+// WithDriver 驱动名称
+func WithDriver(driver string) ConfigOption {
+	return func(c *DatabaseConfig) {
+		c.Driver = driver
+	}
+}
+
+// WithDSN 完整的连接字符串
+func WithDSN(dsn string) ConfigOption {
+	return func(c *DatabaseConfig) {
+		c.DSN = dsn
+	}
+}
+
+// WithHost 主机和端口，port 为 0 时使用驱动的默认端口
+func WithHost(host string, port int) ConfigOption {
+	return func(c *DatabaseConfig) {
+		c.Host = host
+		c.Port = port
+	}
+}
+
+// WithDatabase 数据库名，sqlite 为文件路径
+func WithDatabase(name string) ConfigOption {
+	return func(c *DatabaseConfig) {
+		c.Database = name
+	}
+}
+
+// WithCredentials 用户名和密码
+func WithCredentials(username, password string) ConfigOption {
+	return func(c *DatabaseConfig) {
+		c.Username = username
+		c.Password = password
+	}
+}
+
+// WithCharset 字符集
+func WithCharset(charset string) ConfigOption {
+	return func(c *DatabaseConfig) {
+		c.Charset = charset
+	}
+}
+
+// WithPrefix 表名前缀
+func WithPrefix(prefix string) ConfigOption {
+	return func(c *DatabaseConfig) {
+		c.Prefix = prefix
+	}
+}
+
+// WithPool 连接池大小和连接最长使用时间
+func WithPool(maxOpen, maxIdle int, maxLifetime time.Duration) ConfigOption {
+	return func(c *DatabaseConfig) {
+		c.MaxOpenConns = maxOpen
+		c.MaxIdleConns = maxIdle
+		c.ConnMaxLifetime = maxLifetime
+	}
+}
+
+// NewDatabaseConfig 应用选项、补全缺省值并校验连接配置
+//
+// 示例：
+//
+//	config, err := database.NewDatabaseConfig(
+//		database.WithDriver("mysql"),
+//		database.WithDatabase("forge"),
+//		database.WithCredentials("forge", os.Getenv("DB_PASSWORD")),
+//		database.WithPool(50, 10, time.Hour),
+//	)
+//	if err != nil {
+//		log.Fatal(err) // database: invalid config: ...
+//	}
+func NewDatabaseConfig(opts ...ConfigOption) (*DatabaseConfig, error) {
+	config := &DatabaseConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	config.applyDefaults()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// defaultPorts 各驱动的默认端口
+var defaultPorts = map[string]int{"mysql": 3306, "pgsql": 5432, "sqlsrv": 1433}
+
+// applyDefaults 按驱动补全主机、端口和字符集
+func (c *DatabaseConfig) applyDefaults() {
+	if c.Driver == "sqlite" || c.DSN != "" {
+		return
+	}
+	if c.Host == "" {
+		c.Host = "127.0.0.1"
+	}
+	if c.Port == 0 {
+		c.Port = defaultPorts[c.Driver]
+	}
+	if c.Charset == "" {
+		switch c.Driver {
+		case "mysql":
+			c.Charset = "utf8mb4"
+		case "pgsql":
+			c.Charset = "utf8"
+		}
+	}
+}
+
+// Validate 校验连接配置，用于直接构造的 DatabaseConfig
+func (c *DatabaseConfig) Validate() error {
+	switch {
+	case c.Driver == "":
+		return fmt.Errorf("%w: driver is required", ErrInvalidConfig)
+	case c.Driver == "sqlite" && c.DSN == "" && c.Database == "":
+		return fmt.Errorf("%w: sqlite requires a database path or \":memory:\"", ErrInvalidConfig)
+	case c.Port < 0 || c.Port > 65535:
+		return fmt.Errorf("%w: port %d out of range", ErrInvalidConfig, c.Port)
+	case c.MaxOpenConns < 0 || c.MaxIdleConns < 0 || c.ConnMaxLifetime < 0:
+		return fmt.Errorf("%w: pool settings must not be negative", ErrInvalidConfig)
+	case c.MaxOpenConns > 0 && c.MaxIdleConns > c.MaxOpenConns:
+		return fmt.Errorf("%w: max idle connections %d exceed max open connections %d", ErrInvalidConfig, c.MaxIdleConns, c.MaxOpenConns)
+	}
+	return nil
+}
+
+// ToMap 转换为 DatabaseManager.Extend 的驱动收到的配置，键与 config/database.php 一致，未设置的项省略
+func (c *DatabaseConfig) ToMap() map[string]interface{} {
+	config := map[string]interface{}{"driver": c.Driver}
+	set := func(key string, value interface{}, zero bool) {
+		if !zero {
+			config[key] = value
+		}
+	}
+	set("url", c.DSN, c.DSN == "")
+	set("host", c.Host, c.Host == "")
+	set("port", c.Port, c.Port == 0)
+	set("database", c.Database, c.Database == "")
+	set("username", c.Username, c.Username == "")
+	set("password", c.Password, c.Password == "")
+	set("charset", c.Charset, c.Charset == "")
+	set("prefix", c.Prefix, c.Prefix == "")
+	set("max_open_conns", c.MaxOpenConns, c.MaxOpenConns == 0)
+	set("max_idle_conns", c.MaxIdleConns, c.MaxIdleConns == 0)
+	set("conn_max_lifetime", c.ConnMaxLifetime, c.ConnMaxLifetime == 0)
+	return config
+}
@@ -11,21 +11,44 @@
 // - 查询构建器和原生SQL支持
 // - 事务管理和连接池
 // - 软删除和模型工厂
+// - 包含已加载关联、访问器和类型转换的模型序列化
+// - 严格模式：禁止懒加载、静默丢弃属性和访问不存在的属性
+// - 子模型保存后按批更新父模型时间戳（Touches）
+// - 属性默认值（Defaults）和 Make、FirstOrNew
+// - 枚举字段赋值校验和枚举列定义（EnumColumn）
+// - 金额和定点数列定义（MoneyColumn、DecimalColumn）和 decimal:2 转换
+// - 函数式选项创建连接和会话配置（NewDatabaseConfig、NewSessionConfig），补全缺省值并校验
 //
 // 包结构：
 // - db_interface.go - DB 核心数据库接口
 // - model.go - Model 基础模型和 DeletedAt 软删除结构体
 // - logger_interface.go - LoggerInterface 日志接口
-// - session_config.go - SessionConfig 会话配置
+// - session_config.go - SessionConfig 会话配置、SessionOption 选项和 NewSessionConfig
 // - association.go - Association 关联接口
 // - migrator.go - Migrator 迁移器接口
 // - query_builder.go - QueryBuilder 查询构建器接口
+// - explain.go - ExplainConfig 慢查询执行计划配置和 PlanLogger 接口
 // - eloquent.go - EloquentModel 和 EloquentBuilder 接口
 // - relationships.go - 各种关联关系接口
 // - migration.go - Migration 和 SchemaBuilder 迁移相关接口
 // - factory.go - Factory 工厂接口
 // - manager.go - DatabaseManager 数据库管理器接口
-// - config.go - DatabaseConfig 配置结构体
+// - health.go - ConnectionHealth 健康状况和 ReconnectPolicy 重连策略
+// - config.go - DatabaseConfig 连接配置、ConfigOption 选项和 NewDatabaseConfig
+// - serialize.go - Serializer 模型序列化（ToMap、ToJSON）
+// - strict.go - 严格模式开关、Fill 批量赋值和 GetAttribute
+// - touch.go - HasTouches 和 Touch 父模型时间戳更新
+// - defaults.go - HasDefaults 属性默认值、Make 和 FirstOrNew
+// - enum.go - EnumColumn 枚举列定义
+// - decimal.go - DecimalColumn 和 MoneyColumn 定点数列定义
+// - errors.go - ErrRecordNotFound、ErrLostConnection 驱动无关的错误
+//
+// 测试辅助位于子包 dbtest（RefreshDatabase、数据库断言），
+// 子包 memdb 提供 DB 和 QueryBuilder 的内存参考实现，
+// 子包 sqlscan 提供 sqlx 兼容的结构体扫描和 *sqlx.DB 适配器，
+// 子包 gormbridge 基于真实的 *gorm.DB 实现 DB、Migrator 和 Association。
+// 子包 archive 把旧记录按批归档到归档表或存储磁盘，并支持按批次恢复。
+// 子包 spatial 提供空间列类型、Geo 值类型和 PostGIS、MySQL 的空间查询作用域。
 //
 // 使用示例：
 //
@@ -13,6 +13,12 @@ type DB interface {
 	// 数据库连接管理
 	WithContext(ctx context.Context) DB
 	Session(config *SessionConfig) DB
+
+	// Debug 开启调试模式，记录所有执行的 SQL
+	//
+	// 如果会话配置了 SessionConfig.Explain，超过慢查询阈值的语句
+	// 会按方言执行 EXPLAIN（Postgres 上为 EXPLAIN ANALYZE），
+	// 并通过 PlanLogger 记录执行计划。
 	Debug() DB
 	DryRun() DB
 
@@ -67,7 +73,12 @@ type DB interface {
 	Exec(sql string, values ...interface{}) DB
 	Row() *sql.Row
 	Rows() (*sql.Rows, error)
+
+	// Scan 把结果扫描到 dest，结构体字段优先按 `db` 标签匹配列名，
+	// 支持嵌入结构体和可空类型，规则与 sqlscan 包一致
 	Scan(dest interface{}) DB
+
+	// ScanRows 把 rows 的当前行扫描到 dest，字段映射规则与 Scan 相同
 	ScanRows(rows *sql.Rows, dest interface{}) error
 	Pluck(column string, dest interface{}) DB
 
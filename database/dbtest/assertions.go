@@ -0,0 +1,96 @@
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// AssertDatabaseHas 断言表中存在满足条件的记录
+//
+// 软删除的记录也会被计入。
+//
+// 示例：
+//
+//	dbtest.AssertDatabaseHas(t, db, "users", map[string]interface{}{
+//		"email":  "john@example.com",
+//		"active": true,
+//	})
+func AssertDatabaseHas(t testing.TB, db database.DB, table string, conditions map[string]interface{}) {
+	t.Helper()
+
+	if count := countRecords(t, db, table, conditions); count == 0 {
+		t.Errorf("dbtest: failed asserting that table [%s] has a row matching %v", table, conditions)
+	}
+}
+
+// AssertDatabaseMissing 断言表中不存在满足条件的记录
+//
+// 示例：
+//
+//	dbtest.AssertDatabaseMissing(t, db, "users", map[string]interface{}{
+//		"email": "deleted@example.com",
+//	})
+func AssertDatabaseMissing(t testing.TB, db database.DB, table string, conditions map[string]interface{}) {
+	t.Helper()
+
+	if count := countRecords(t, db, table, conditions); count != 0 {
+		t.Errorf("dbtest: failed asserting that table [%s] has no row matching %v, found %d", table, conditions, count)
+	}
+}
+
+// AssertDatabaseCount 断言表中记录数量
+//
+// 示例：
+//
+//	dbtest.AssertDatabaseCount(t, db, "orders", 3)
+func AssertDatabaseCount(t testing.TB, db database.DB, table string, expected int64) {
+	t.Helper()
+
+	if count := countRecords(t, db, table, nil); count != expected {
+		t.Errorf("dbtest: failed asserting that table [%s] has %d rows, found %d", table, expected, count)
+	}
+}
+
+// AssertSoftDeleted 断言满足条件的记录已被软删除
+//
+// column 为软删除列名，默认 deleted_at。
+//
+// 示例：
+//
+//	dbtest.AssertSoftDeleted(t, db, "posts", map[string]interface{}{"id": post.ID})
+func AssertSoftDeleted(t testing.TB, db database.DB, table string, conditions map[string]interface{}, column ...string) {
+	t.Helper()
+
+	deletedAt := "deleted_at"
+	if len(column) > 0 && column[0] != "" {
+		deletedAt = column[0]
+	}
+
+	var count int64
+	query := db.Unscoped().Table(table).Where(deletedAt + " IS NOT NULL")
+	if len(conditions) > 0 {
+		query = query.Where(conditions)
+	}
+	if err := query.Count(&count).Error(); err != nil {
+		t.Fatalf("dbtest: count %s: %v", table, err)
+	}
+	if count == 0 {
+		t.Errorf("dbtest: failed asserting that table [%s] has a soft deleted row matching %v", table, conditions)
+	}
+}
+
+// countRecords 统计满足条件的记录数量，包含软删除记录
+func countRecords(t testing.TB, db database.DB, table string, conditions map[string]interface{}) int64 {
+	t.Helper()
+
+	var count int64
+	query := db.Unscoped().Table(table)
+	if len(conditions) > 0 {
+		query = query.Where(conditions)
+	}
+	if err := query.Count(&count).Error(); err != nil {
+		t.Fatalf("dbtest: count %s: %v", table, err)
+	}
+	return count
+}
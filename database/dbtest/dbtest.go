@@ -0,0 +1,121 @@
+// Package dbtest 提供 Laravel 风格的数据库测试辅助协议
+//
+// 本包对应 Laravel 的 RefreshDatabase、DatabaseMigrations、DatabaseTruncation
+// 测试 trait 以及数据库断言，所有辅助函数都接受任意 database.DB 连接，
+// 并通过 testing.TB 的 Cleanup 自动恢复数据库状态。
+//
+// 包结构：
+// - dbtest.go - RefreshDatabase、DatabaseMigrations、DatabaseTruncation 测试 trait
+// - assertions.go - AssertDatabaseHas、AssertDatabaseMissing、AssertDatabaseCount、AssertSoftDeleted 断言
+//
+// 使用示例：
+//
+//	func TestCreateUser(t *testing.T) {
+//		// 每个测试包裹在事务中，结束时回滚
+//		db := dbtest.RefreshDatabase(t, connection)
+//
+//		repo := NewUserRepository(db)
+//		repo.Create(&User{Email: "john@example.com"})
+//
+//		dbtest.AssertDatabaseHas(t, db, "users", map[string]interface{}{
+//			"email": "john@example.com",
+//		})
+//	}
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// RefreshDatabase 在事务中运行测试
+//
+// 开启一个事务并返回事务连接，测试结束时自动回滚，
+// 测试中的所有写入都不会留在数据库中。被测代码必须使用返回的连接。
+//
+// 示例：
+//
+//	func TestOrder(t *testing.T) {
+//		db := dbtest.RefreshDatabase(t, manager.Connection("testing"))
+//		db.Create(&Order{Amount: 100})
+//	}
+func RefreshDatabase(t testing.TB, db database.DB) database.DB {
+	t.Helper()
+
+	tx := db.Begin()
+	if err := tx.Error(); err != nil {
+		t.Fatalf("dbtest: begin transaction: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := tx.Rollback().Error(); err != nil {
+			t.Errorf("dbtest: rollback transaction: %v", err)
+		}
+	})
+	return tx
+}
+
+// DatabaseMigrations 在测试前迁移模型，测试后删除对应的表
+//
+// 适用于无法使用事务的场景（如测试本身需要提交事务）。
+//
+// 示例：
+//
+//	func TestImport(t *testing.T) {
+//		dbtest.DatabaseMigrations(t, db, &User{}, &Order{})
+//		// ...
+//	}
+func DatabaseMigrations(t testing.TB, db database.DB, models ...interface{}) {
+	t.Helper()
+
+	migrator := db.Migrator()
+	if err := migrator.AutoMigrate(models...); err != nil {
+		t.Fatalf("dbtest: migrate: %v", err)
+	}
+	t.Cleanup(func() {
+		// 逆序删除，先删除依赖方的表
+		for i := len(models) - 1; i >= 0; i-- {
+			if err := migrator.DropTable(models[i]); err != nil {
+				t.Errorf("dbtest: drop table: %v", err)
+			}
+		}
+	})
+}
+
+// DatabaseTruncation 在测试结束后清空表
+//
+// tables 为空时清空连接中的所有表，except 中的表会被保留（如 migrations 表）。
+//
+// 示例：
+//
+//	dbtest.DatabaseTruncation(t, db, nil, "migrations")
+//	dbtest.DatabaseTruncation(t, db, []string{"users", "orders"})
+func DatabaseTruncation(t testing.TB, db database.DB, tables []string, except ...string) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		targets := tables
+		if len(targets) == 0 {
+			all, err := db.Migrator().GetTables()
+			if err != nil {
+				t.Errorf("dbtest: list tables: %v", err)
+				return
+			}
+			targets = all
+		}
+
+		skip := make(map[string]bool, len(except))
+		for _, table := range except {
+			skip[table] = true
+		}
+		for _, table := range targets {
+			if skip[table] {
+				continue
+			}
+			// DELETE 在所有方言中可用，SQLite 不支持 TRUNCATE
+			if err := db.Exec("DELETE FROM " + table).Error(); err != nil {
+				t.Errorf("dbtest: truncate %s: %v", table, err)
+			}
+		}
+	})
+}
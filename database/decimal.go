@@ -0,0 +1,22 @@
+package database
+
+import "fmt"
+
+// DecimalColumn 定点数列的类型定义，对应 Blueprint 的 decimal，precision 为总位数，scale 为小数位数
+//
+// PostgreSQL 和 SQLite 为 numeric(p, s)，MySQL 和 SQL Server 为 decimal(p, s)。
+// SQLite 按数值亲和性存储，超过 15 位有效数字的值会失去精度，需要精确存储时使用 TEXT 列。
+func DecimalColumn(dialect string, precision, scale int) string {
+	switch dialect {
+	case DialectPostgres, DialectSQLite:
+		return fmt.Sprintf("numeric(%d, %d)", precision, scale)
+	}
+	return fmt.Sprintf("decimal(%d, %d)", precision, scale)
+}
+
+// MoneyColumn 金额列的类型定义，为 19 位有效数字、4 位小数的定点数，与 decimal.Decimal 字段和 decimal:2 转换配合使用
+//
+//	db.Exec("ALTER TABLE products ADD COLUMN price " + database.MoneyColumn(database.DialectMySQL) + " NOT NULL DEFAULT 0")
+func MoneyColumn(dialect string) string {
+	return DecimalColumn(dialect, 19, 4)
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// HasDefaults 声明属性默认值的模型，对应 Eloquent 的 $attributes
+//
+// 默认值只填充零值字段，键为属性名（json 标签或字段名的 snake_case）。属性在 Casts 中声明为
+// json、array 或 object 而字段是 string 或 []byte 时，非字符串的默认值会先编码为 JSON。
+//
+//	func (u *User) Defaults() map[string]interface{} {
+//		return map[string]interface{}{"role": "member", "active": true, "settings": map[string]interface{}{"theme": "light"}}
+//	}
+type HasDefaults interface {
+	Defaults() map[string]interface{}
+}
+
+// ApplyDefaults 为模型的零值字段填充默认值
+//
+// 先应用 HasDefaults 中的默认值，再为仍为零值且声明了 json、array、object 转换的 string 或 []byte 字段
+// 填充空 JSON（array 为 "[]"，其余为 "{}"），使新模型在写入数据库之前就是可解析的。
+func ApplyDefaults(model interface{}) error {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("database: cannot apply defaults to %T, a model pointer is required", model)
+	}
+	fields := attributeFields(v.Elem().Type())
+	var casts map[string]string
+	if h, ok := model.(HasCasts); ok {
+		casts = h.Casts()
+	}
+
+	if h, ok := model.(HasDefaults); ok {
+		for name, value := range h.Defaults() {
+			index, ok := fields[name]
+			if !ok {
+				return fmt.Errorf("database: %T has a default for unknown attribute %q", model, name)
+			}
+			field := v.Elem().FieldByIndex(index)
+			if !field.IsZero() {
+				continue
+			}
+			value, err := defaultForCast(value, casts[name], field.Type())
+			if err != nil {
+				return fmt.Errorf("database: default %T.%s: %w", model, name, err)
+			}
+			converted, err := convertAttribute(value, field.Type())
+			if err != nil {
+				return fmt.Errorf("database: default %T.%s: %w", model, name, err)
+			}
+			field.Set(converted)
+		}
+	}
+
+	for name, cast := range casts {
+		index, ok := fields[name]
+		if !ok {
+			continue
+		}
+		field := v.Elem().FieldByIndex(index)
+		if !field.IsZero() || !isJSONCast(cast) || !isTextKind(field.Type()) {
+			continue
+		}
+		empty := "{}"
+		if strings.HasPrefix(cast, "array") {
+			empty = "[]"
+		}
+		converted, err := convertAttribute(empty, field.Type())
+		if err != nil {
+			return fmt.Errorf("database: default %T.%s: %w", model, name, err)
+		}
+		field.Set(converted)
+	}
+	return nil
+}
+
+// Make 创建填充了默认值和属性的新模型，不写入数据库，对应 Model::make
+//
+//	user, err := database.Make[User](map[string]interface{}{"name": "John"})
+func Make[T any](attributes map[string]interface{}) (*T, error) {
+	model := new(T)
+	if err := ApplyDefaults(model); err != nil {
+		return nil, err
+	}
+	if len(attributes) > 0 {
+		if err := Fill(model, attributes); err != nil {
+			return nil, err
+		}
+	}
+	return model, nil
+}
+
+// FirstOrNew 按 attributes 查找第一条记录，不存在时用 attributes 和 values 创建新模型（不写入数据库），
+// 返回的 bool 表示记录是否已存在，对应 firstOrNew
+//
+//	user, exists, err := database.FirstOrNew[User](db, map[string]interface{}{"email": email}, map[string]interface{}{"name": name})
+func FirstOrNew[T any](db DB, attributes map[string]interface{}, values map[string]interface{}) (*T, bool, error) {
+	var found []T
+	tx := db.Where(attributes).Limit(1).Find(&found)
+	if err := tx.Error(); err != nil {
+		return nil, false, err
+	}
+	if len(found) > 0 {
+		return &found[0], true, nil
+	}
+	merged := make(map[string]interface{}, len(attributes)+len(values))
+	for name, value := range attributes {
+		merged[name] = value
+	}
+	for name, value := range values {
+		merged[name] = value
+	}
+	model, err := Make[T](merged)
+	return model, false, err
+}
+
+// defaultForCast 按转换类型处理默认值：JSON 转换的文本字段把非字符串默认值编码为 JSON
+func defaultForCast(value interface{}, cast string, t reflect.Type) (interface{}, error) {
+	if value == nil || !isJSONCast(cast) || !isTextKind(t) {
+		return value, nil
+	}
+	switch value.(type) {
+	case string, []byte, json.RawMessage:
+		return value, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// isJSONCast 转换类型是否为 JSON
+func isJSONCast(cast string) bool {
+	kind, _, _ := strings.Cut(cast, ":")
+	return kind == "json" || kind == "array" || kind == "object"
+}
+
+// isTextKind 类型是否为 string 或 []byte
+func isTextKind(t reflect.Type) bool {
+	return t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/support/enum"
+)
+
+// EnumColumn 枚举列的类型定义，对应 Blueprint 的 enum，枚举值来自 enum.Enumerable 的 Cases
+//
+// 字符串枚举在 MySQL 中为 ENUM('a', 'b')，其他数据库为 varchar(255) 加 CHECK 约束；
+// 整数枚举在所有数据库中为 integer 加 CHECK 约束。
+//
+//	db.Exec("ALTER TABLE orders ADD COLUMN status " + database.EnumColumn[Status](database.DialectPostgres, "status"))
+func EnumColumn[T enum.Enumerable[T]](dialect, column string) string {
+	var zero T
+	numeric := reflect.TypeOf(zero).Kind() != reflect.String
+	values := enum.Values[T]()
+	literals := make([]string, len(values))
+	for i, value := range values {
+		switch {
+		case numeric:
+			literals[i] = value
+		case dialect == DialectSQLServer:
+			literals[i] = "N'" + strings.ReplaceAll(value, "'", "''") + "'"
+		default:
+			literals[i] = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+		}
+	}
+	list := strings.Join(literals, ", ")
+	if dialect == DialectMySQL && !numeric {
+		return "ENUM(" + list + ")"
+	}
+
+	typ := "varchar(255)"
+	switch {
+	case numeric:
+		typ = "integer"
+	case dialect == DialectSQLServer:
+		typ = "nvarchar(255)"
+	}
+	return fmt.Sprintf("%s CHECK (%s IN (%s))", typ, quoteIdentifier(dialect, column), list)
+}
+
+// quoteIdentifier 按方言引用标识符
+func quoteIdentifier(dialect, identifier string) string {
+	switch dialect {
+	case DialectMySQL:
+		return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+	case DialectSQLServer:
+		return "[" + strings.ReplaceAll(identifier, "]", "]]") + "]"
+	}
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
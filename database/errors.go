@@ -0,0 +1,14 @@
+package database
+
+import "errors"
+
+// 驱动无关的错误，DB 的实现把驱动自身的错误转换为可以用 errors.Is 判断的这些错误
+var (
+	// ErrRecordNotFound First、Last、Take 等查询没有找到记录，对应 Laravel 的 ModelNotFoundException
+	//
+	// 信息与 gorm.ErrRecordNotFound 相同；gormbridge 返回的错误同时满足两者的 errors.Is。
+	ErrRecordNotFound = errors.New("record not found")
+
+	// ErrLostConnection 数据库连接已断开，IsLostConnection 判定为断线的驱动错误满足 errors.Is
+	ErrLostConnection = errors.New("database: lost connection")
+)
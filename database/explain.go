@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// 数据库方言
+const (
+	DialectMySQL     = "mysql"
+	DialectPostgres  = "postgres"
+	DialectSQLite    = "sqlite"
+	DialectSQLServer = "sqlserver"
+)
+
+// ExplainStatement 生成方言相关的执行计划语句
+//
+// analyze 为 true 时请求实际执行并统计耗时：Postgres 使用 EXPLAIN ANALYZE，
+// MySQL 使用 EXPLAIN ANALYZE（8.0.18+），SQLite 只支持 EXPLAIN QUERY PLAN，
+// SQL Server 使用 SHOWPLAN，返回空字符串表示需要以会话选项的方式开启。
+// EXPLAIN ANALYZE 会实际执行语句，因此只对只读的 SELECT 和 WITH 语句生效；
+// INSERT、UPDATE、DELETE 等写操作，以及带修改数据的 CTE 或 SELECT ... INTO 的语句使用普通的 EXPLAIN，避免被再次执行。
+//
+// 示例：
+//
+//	ExplainStatement(DialectPostgres, "SELECT * FROM users", true)
+//	// "EXPLAIN ANALYZE SELECT * FROM users"
+//
+//	ExplainStatement(DialectSQLite, "SELECT * FROM users", true)
+//	// "EXPLAIN QUERY PLAN SELECT * FROM users"
+func ExplainStatement(dialect string, sql string, analyze bool) string {
+	switch dialect {
+	case DialectPostgres, DialectMySQL:
+		if analyze && isSelectStatement(sql) {
+			return "EXPLAIN ANALYZE " + sql
+		}
+		return "EXPLAIN " + sql
+	case DialectSQLite:
+		return "EXPLAIN QUERY PLAN " + sql
+	case DialectSQLServer:
+		return ""
+	}
+	return "EXPLAIN " + sql
+}
+
+// isSelectStatement 语句是否只读：去掉开头的空白和注释后以 SELECT 或 WITH 开头，
+// 且引号和注释之外不含 INSERT、UPDATE、DELETE、MERGE 或 INTO
+//
+// WITH 可以带修改数据的 CTE（WITH d AS (DELETE ... RETURNING *) SELECT ...），
+// SELECT ... INTO 会创建表或写出文件，SELECT ... FOR UPDATE 会加锁，这些语句都只做普通的 EXPLAIN。
+// 在 SELECT 中调用有副作用的函数无法从语句中识别。
+func isSelectStatement(sql string) bool {
+	words := sqlKeywords(sql)
+	if len(words) == 0 || !strings.EqualFold(words[0], "SELECT") && !strings.EqualFold(words[0], "WITH") {
+		return false
+	}
+	for _, word := range words[1:] {
+		switch strings.ToUpper(word) {
+		case "INSERT", "UPDATE", "DELETE", "MERGE", "INTO":
+			return false
+		}
+	}
+	return true
+}
+
+// sqlKeywords 依次返回 sql 中引号、注释之外由字母组成的单词
+//
+// 注释或引号没有闭合时返回 nil，调用方按不能识别的语句处理。
+func sqlKeywords(sql string) []string {
+	var words []string
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				return words
+			}
+			i += end + 1
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil
+			}
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(sql[i+1:], c)
+			if end < 0 {
+				return nil
+			}
+			i += end + 2
+		case isSQLLetter(c):
+			start := i
+			for i < len(sql) && (isSQLLetter(sql[i]) || sql[i] == '_' || sql[i] >= '0' && sql[i] <= '9') {
+				i++
+			}
+			words = append(words, sql[start:i])
+		case c == '_' || c >= '0' && c <= '9':
+			// 标识符中间的数字和下划线，跳过整个标识符
+			for i < len(sql) && (isSQLLetter(sql[i]) || sql[i] == '_' || sql[i] >= '0' && sql[i] <= '9') {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+	return words
+}
+
+// isSQLLetter c 是否为 ASCII 字母
+func isSQLLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// ExplainConfig 慢查询执行计划配置
+//
+// ExplainConfig 通过 SessionConfig.Explain 配置，在 Debug 模式下，
+// 执行时间超过 SlowThreshold 的语句会自动执行 EXPLAIN 并记录执行计划。
+//
+// 使用示例：
+//
+//	tx := db.Session(&database.SessionConfig{
+//		Explain: &database.ExplainConfig{
+//			SlowThreshold: 200 * time.Millisecond,
+//			Analyze:       true,
+//		},
+//	}).Debug()
+type ExplainConfig struct {
+	// SlowThreshold 慢查询阈值
+	//
+	// 为 0 时不自动记录执行计划。
+	SlowThreshold time.Duration
+
+	// Analyze 是否使用 EXPLAIN ANALYZE
+	//
+	// 注意 ANALYZE 会再次实际执行语句，因此只对 SELECT 和 WITH 语句生效，
+	// 写操作使用普通的 EXPLAIN。
+	Analyze bool
+
+	// Dialect 数据库方言
+	//
+	// 为空时使用当前连接的方言。
+	Dialect string
+}
+
+// QueryPlan 查询执行计划
+type QueryPlan struct {
+	// SQL 原始语句
+	SQL string
+
+	// Vars 绑定参数
+	Vars []interface{}
+
+	// Dialect 数据库方言
+	Dialect string
+
+	// Elapsed 原始语句执行耗时
+	Elapsed time.Duration
+
+	// Rows 执行计划的每一行
+	Rows []map[string]interface{}
+}
+
+// PlanLogger 执行计划日志接口
+//
+// 实现了 LoggerInterface 的日志器可以额外实现 PlanLogger，
+// Debug 模式下慢查询的执行计划会通过 TracePlan 记录；未实现时以 Warn 记录。
+//
+// 使用示例：
+//
+//	func (l *AppLogger) TracePlan(ctx context.Context, plan database.QueryPlan) {
+//		l.Warn(ctx, "slow query %s (%s)", plan.SQL, plan.Elapsed)
+//		for _, row := range plan.Rows {
+//			l.Warn(ctx, "  %v", row)
+//		}
+//	}
+type PlanLogger interface {
+	// TracePlan 记录慢查询执行计划
+	TracePlan(ctx context.Context, plan QueryPlan)
+}
@@ -0,0 +1,400 @@
+// Package gormbridge 基于真实 *gorm.DB 实现 database.DB、Migrator 和 Association 接口
+//
+// 本包通过泛型约束 GormDB 描述 *gorm.DB 的方法集合，*gorm.DB 在结构上满足该约束，
+// 因此协议模块本身不需要依赖 gorm.io/gorm，由使用方的 go.mod 引入 gorm 即可。
+// 只有 Session、Error、RowsAffected 等依赖 gorm 具体类型的部分通过反射桥接。
+//
+// 包结构：
+// - gormbridge.go - GormDB 约束、DB 桥接实现和 New 构造函数
+// - session.go - SessionConfig 到 gorm.Session 的转换和字段读取
+// - migrator.go - Migrator 迁移器桥接
+//
+// 使用示例：
+//
+//	gdb, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+//	if err != nil {
+//		return err
+//	}
+//	db := gormbridge.New(gdb) // database.DB
+//	container.Instance("database", db)
+//
+//	var user User
+//	db.Where("email = ?", email).First(&user)
+//
+//	// 需要直接访问 gorm 时取回原始连接
+//	raw := db.Gorm() // *gorm.DB
+//
+// 限制：
+// - SessionConfig.Logger 与 gorm 的 logger.Interface 签名不同，会被忽略，请在 gorm.Config 中配置日志
+// - SessionConfig.Explain 需要 gorm 侧的回调插件配合，本包不处理
+// - GetErrors 返回 gorm 合并后的错误，gorm v2 不保留独立的错误列表
+package gormbridge
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// GormDB *gorm.DB 的方法集合
+//
+// 类型参数 T 即 *gorm.DB 本身，链式方法返回 T。
+type GormDB[T any] interface {
+	WithContext(ctx context.Context) T
+	Debug() T
+
+	Model(value interface{}) T
+	Table(name string, args ...interface{}) T
+	Select(query interface{}, args ...interface{}) T
+	Omit(columns ...string) T
+	Where(query interface{}, args ...interface{}) T
+	Or(query interface{}, args ...interface{}) T
+	Not(query interface{}, args ...interface{}) T
+
+	Create(value interface{}) T
+	CreateInBatches(value interface{}, batchSize int) T
+	Save(value interface{}) T
+
+	Find(dest interface{}, conds ...interface{}) T
+	FindInBatches(dest interface{}, batchSize int, fc func(tx T, batch int) error) T
+	First(dest interface{}, conds ...interface{}) T
+	Last(dest interface{}, conds ...interface{}) T
+	Take(dest interface{}, conds ...interface{}) T
+	FirstOrInit(dest interface{}, conds ...interface{}) T
+	FirstOrCreate(dest interface{}, conds ...interface{}) T
+
+	Update(column string, value interface{}) T
+	Updates(values interface{}) T
+	UpdateColumn(column string, value interface{}) T
+	UpdateColumns(values interface{}) T
+
+	Delete(value interface{}, conds ...interface{}) T
+	Unscoped() T
+
+	Count(count *int64) T
+	Distinct(args ...interface{}) T
+	Group(name string) T
+	Having(query interface{}, args ...interface{}) T
+	Joins(query string, args ...interface{}) T
+	Preload(query string, args ...interface{}) T
+
+	Limit(limit int) T
+	Offset(offset int) T
+	Order(value interface{}) T
+
+	Raw(sql string, values ...interface{}) T
+	Exec(sql string, values ...interface{}) T
+	Row() *sql.Row
+	Rows() (*sql.Rows, error)
+	Scan(dest interface{}) T
+	ScanRows(rows *sql.Rows, dest interface{}) error
+	Pluck(column string, dest interface{}) T
+
+	Begin(opts ...*sql.TxOptions) T
+	Commit() T
+	Rollback() T
+	SavePoint(name string) T
+	RollbackTo(name string) T
+	Transaction(fc func(tx T) error, opts ...*sql.TxOptions) error
+
+	AutoMigrate(dst ...interface{}) error
+
+	Scopes(funcs ...func(T) T) T
+	Attrs(attrs ...interface{}) T
+	Assign(attrs ...interface{}) T
+
+	Set(key string, value interface{}) T
+	Get(key string) (interface{}, bool)
+	InstanceSet(key string, value interface{}) T
+	InstanceGet(key string) (interface{}, bool)
+
+	AddError(err error) error
+	DB() (*sql.DB, error)
+}
+
+// ErrForeignScope 作用域函数返回了不是由本包创建的 DB
+var ErrForeignScope = errors.New("gormbridge: scope returned a DB not created by gormbridge")
+
+// DB 基于 *gorm.DB 的 database.DB 实现
+type DB[T GormDB[T]] struct {
+	db T
+}
+
+// New 包装 *gorm.DB
+func New[T GormDB[T]](db T) *DB[T] {
+	return &DB[T]{db: db}
+}
+
+// Gorm 获取原始的 *gorm.DB
+func (d *DB[T]) Gorm() T {
+	return d.db
+}
+
+// wrap 包装链式调用的结果
+func (d *DB[T]) wrap(db T) database.DB {
+	return &DB[T]{db: db}
+}
+
+// unwrap 取回 database.DB 中的 *gorm.DB
+func unwrap[T GormDB[T]](db database.DB) (T, bool) {
+	if b, ok := db.(*DB[T]); ok {
+		return b.db, true
+	}
+	var zero T
+	return zero, false
+}
+
+// gormValue 实现 migrator.go 中的 gormValuer，用于桥接 gorm.ViewOption.Query
+func (d *DB[T]) gormValue() interface{} {
+	return d.db
+}
+
+func (d *DB[T]) WithContext(ctx context.Context) database.DB { return d.wrap(d.db.WithContext(ctx)) }
+
+// Session 创建新会话，配置字段按名称复制到 gorm.Session
+func (d *DB[T]) Session(config *database.SessionConfig) database.DB {
+	db, err := session(d.db, config)
+	if err != nil {
+		d.db.AddError(err)
+		return d
+	}
+	return d.wrap(db)
+}
+
+func (d *DB[T]) Debug() database.DB { return d.wrap(d.db.Debug()) }
+
+// DryRun 等价于 Session(&SessionConfig{DryRun: true})
+func (d *DB[T]) DryRun() database.DB {
+	return d.Session(&database.SessionConfig{DryRun: true})
+}
+
+func (d *DB[T]) Model(value interface{}) database.DB { return d.wrap(d.db.Model(value)) }
+func (d *DB[T]) Table(name string, args ...interface{}) database.DB {
+	return d.wrap(d.db.Table(name, args...))
+}
+func (d *DB[T]) Select(query interface{}, args ...interface{}) database.DB {
+	return d.wrap(d.db.Select(query, args...))
+}
+func (d *DB[T]) Omit(columns ...string) database.DB { return d.wrap(d.db.Omit(columns...)) }
+func (d *DB[T]) Where(query interface{}, args ...interface{}) database.DB {
+	return d.wrap(d.db.Where(query, args...))
+}
+func (d *DB[T]) Or(query interface{}, args ...interface{}) database.DB {
+	return d.wrap(d.db.Or(query, args...))
+}
+func (d *DB[T]) Not(query interface{}, args ...interface{}) database.DB {
+	return d.wrap(d.db.Not(query, args...))
+}
+
+func (d *DB[T]) Create(value interface{}) database.DB { return d.wrap(d.db.Create(value)) }
+func (d *DB[T]) CreateInBatches(value interface{}, batchSize int) database.DB {
+	return d.wrap(d.db.CreateInBatches(value, batchSize))
+}
+func (d *DB[T]) Save(value interface{}) database.DB { return d.wrap(d.db.Save(value)) }
+
+func (d *DB[T]) Find(dest interface{}, conds ...interface{}) database.DB {
+	return d.wrap(d.db.Find(dest, conds...))
+}
+func (d *DB[T]) FindInBatches(dest interface{}, batchSize int, fc func(tx database.DB, batch int) error) database.DB {
+	return d.wrap(d.db.FindInBatches(dest, batchSize, func(tx T, batch int) error {
+		return fc(d.wrap(tx), batch)
+	}))
+}
+func (d *DB[T]) First(dest interface{}, conds ...interface{}) database.DB {
+	return d.wrap(d.db.First(dest, conds...))
+}
+func (d *DB[T]) Last(dest interface{}, conds ...interface{}) database.DB {
+	return d.wrap(d.db.Last(dest, conds...))
+}
+func (d *DB[T]) Take(dest interface{}, conds ...interface{}) database.DB {
+	return d.wrap(d.db.Take(dest, conds...))
+}
+func (d *DB[T]) FirstOrInit(dest interface{}, conds ...interface{}) database.DB {
+	return d.wrap(d.db.FirstOrInit(dest, conds...))
+}
+func (d *DB[T]) FirstOrCreate(dest interface{}, conds ...interface{}) database.DB {
+	return d.wrap(d.db.FirstOrCreate(dest, conds...))
+}
+
+func (d *DB[T]) Update(column string, value interface{}) database.DB {
+	return d.wrap(d.db.Update(column, value))
+}
+func (d *DB[T]) Updates(values interface{}) database.DB { return d.wrap(d.db.Updates(values)) }
+func (d *DB[T]) UpdateColumn(column string, value interface{}) database.DB {
+	return d.wrap(d.db.UpdateColumn(column, value))
+}
+func (d *DB[T]) UpdateColumns(values interface{}) database.DB {
+	return d.wrap(d.db.UpdateColumns(values))
+}
+
+func (d *DB[T]) Delete(value interface{}, conds ...interface{}) database.DB {
+	return d.wrap(d.db.Delete(value, conds...))
+}
+func (d *DB[T]) Unscoped() database.DB { return d.wrap(d.db.Unscoped()) }
+
+func (d *DB[T]) Count(count *int64) database.DB           { return d.wrap(d.db.Count(count)) }
+func (d *DB[T]) Distinct(args ...interface{}) database.DB { return d.wrap(d.db.Distinct(args...)) }
+func (d *DB[T]) Group(name string) database.DB            { return d.wrap(d.db.Group(name)) }
+func (d *DB[T]) Having(query interface{}, args ...interface{}) database.DB {
+	return d.wrap(d.db.Having(query, args...))
+}
+func (d *DB[T]) Joins(query string, args ...interface{}) database.DB {
+	return d.wrap(d.db.Joins(query, args...))
+}
+func (d *DB[T]) Preload(query string, args ...interface{}) database.DB {
+	return d.wrap(d.db.Preload(query, args...))
+}
+
+func (d *DB[T]) Limit(limit int) database.DB         { return d.wrap(d.db.Limit(limit)) }
+func (d *DB[T]) Offset(offset int) database.DB       { return d.wrap(d.db.Offset(offset)) }
+func (d *DB[T]) Order(value interface{}) database.DB { return d.wrap(d.db.Order(value)) }
+
+func (d *DB[T]) Raw(sql string, values ...interface{}) database.DB {
+	return d.wrap(d.db.Raw(sql, values...))
+}
+func (d *DB[T]) Exec(sql string, values ...interface{}) database.DB {
+	return d.wrap(d.db.Exec(sql, values...))
+}
+func (d *DB[T]) Row() *sql.Row                     { return d.db.Row() }
+func (d *DB[T]) Rows() (*sql.Rows, error)          { return d.db.Rows() }
+func (d *DB[T]) Scan(dest interface{}) database.DB { return d.wrap(d.db.Scan(dest)) }
+func (d *DB[T]) ScanRows(rows *sql.Rows, dest interface{}) error {
+	return d.db.ScanRows(rows, dest)
+}
+func (d *DB[T]) Pluck(column string, dest interface{}) database.DB {
+	return d.wrap(d.db.Pluck(column, dest))
+}
+
+func (d *DB[T]) Begin(opts ...*sql.TxOptions) database.DB { return d.wrap(d.db.Begin(opts...)) }
+func (d *DB[T]) Commit() database.DB                      { return d.wrap(d.db.Commit()) }
+func (d *DB[T]) Rollback() database.DB                    { return d.wrap(d.db.Rollback()) }
+func (d *DB[T]) SavePoint(name string) database.DB        { return d.wrap(d.db.SavePoint(name)) }
+func (d *DB[T]) RollbackTo(name string) database.DB       { return d.wrap(d.db.RollbackTo(name)) }
+func (d *DB[T]) Transaction(fc func(tx database.DB) error, opts ...*sql.TxOptions) error {
+	return d.db.Transaction(func(tx T) error {
+		return fc(d.wrap(tx))
+	}, opts...)
+}
+
+// Association 返回 *gorm.Association，它本身满足 database.Association
+func (d *DB[T]) Association(column string) database.Association {
+	association, err := association(d.db, column)
+	if err != nil {
+		return errAssociation{err: err}
+	}
+	return association
+}
+
+func (d *DB[T]) AutoMigrate(dst ...interface{}) error { return d.db.AutoMigrate(dst...) }
+
+// Migrator 返回包装后的 gorm.Migrator
+func (d *DB[T]) Migrator() database.Migrator {
+	return newMigrator(d.db)
+}
+
+// Scopes 应用作用域，作用域函数必须返回由本包创建的 DB
+func (d *DB[T]) Scopes(funcs ...func(database.DB) database.DB) database.DB {
+	scopes := make([]func(T) T, len(funcs))
+	for i, fn := range funcs {
+		fn := fn
+		scopes[i] = func(tx T) T {
+			result, ok := unwrap[T](fn(d.wrap(tx)))
+			if !ok {
+				tx.AddError(ErrForeignScope)
+				return tx
+			}
+			return result
+		}
+	}
+	return d.wrap(d.db.Scopes(scopes...))
+}
+func (d *DB[T]) Attrs(attrs ...interface{}) database.DB  { return d.wrap(d.db.Attrs(attrs...)) }
+func (d *DB[T]) Assign(attrs ...interface{}) database.DB { return d.wrap(d.db.Assign(attrs...)) }
+
+func (d *DB[T]) Set(key string, value interface{}) database.DB {
+	return d.wrap(d.db.Set(key, value))
+}
+func (d *DB[T]) Get(key string) (interface{}, bool) { return d.db.Get(key) }
+func (d *DB[T]) InstanceSet(key string, value interface{}) database.DB {
+	return d.wrap(d.db.InstanceSet(key, value))
+}
+func (d *DB[T]) InstanceGet(key string) (interface{}, bool) { return d.db.InstanceGet(key) }
+
+func (d *DB[T]) AddError(err error) error { return d.db.AddError(err) }
+
+// GetErrors gorm v2 把多个错误合并到 Error 字段，这里最多返回一个元素
+func (d *DB[T]) GetErrors() []error {
+	if err := d.Error(); err != nil {
+		return []error{err}
+	}
+	return nil
+}
+
+// Error 读取 gorm.DB.Error 字段，记录不存在和连接断开的错误转换为 database 包的错误
+func (d *DB[T]) Error() error {
+	err, _ := field(d.db, "Error").(error)
+	return translateError(err)
+}
+
+// driverError 同时满足 gorm 原始错误和 database 包错误的 errors.Is
+type driverError struct {
+	err      error
+	sentinel error
+}
+
+// Error 原始错误的信息
+func (e *driverError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 返回原始错误和 database 包的错误
+func (e *driverError) Unwrap() []error {
+	return []error{e.err, e.sentinel}
+}
+
+// translateError 把 gorm.ErrRecordNotFound 和断线错误包装为 database.ErrRecordNotFound、database.ErrLostConnection
+//
+// 本包不依赖 gorm，gorm.ErrRecordNotFound 按信息识别。
+func translateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case err.Error() == database.ErrRecordNotFound.Error() && !errors.Is(err, database.ErrRecordNotFound):
+		return &driverError{err: err, sentinel: database.ErrRecordNotFound}
+	case database.IsLostConnection(err) && !errors.Is(err, database.ErrLostConnection):
+		return &driverError{err: err, sentinel: database.ErrLostConnection}
+	}
+	return err
+}
+
+// RowsAffected 读取 gorm.DB.RowsAffected 字段
+func (d *DB[T]) RowsAffected() int64 {
+	rows, _ := field(d.db, "RowsAffected").(int64)
+	return rows
+}
+
+func (d *DB[T]) SqlDB() (*sql.DB, error) { return d.db.DB() }
+
+// Close 关闭底层的 *sql.DB 连接池
+func (d *DB[T]) Close() error {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return fmt.Errorf("gormbridge: close: %w", err)
+	}
+	return sqlDB.Close()
+}
+
+// errAssociation 无法获取关联时返回的占位实现，所有方法返回同一错误
+type errAssociation struct {
+	err error
+}
+
+func (a errAssociation) Find(out interface{}, conds ...interface{}) error { return a.err }
+func (a errAssociation) Append(values ...interface{}) error               { return a.err }
+func (a errAssociation) Replace(values ...interface{}) error              { return a.err }
+func (a errAssociation) Delete(values ...interface{}) error               { return a.err }
+func (a errAssociation) Clear() error                                     { return a.err }
+func (a errAssociation) Count() int64                                     { return 0 }
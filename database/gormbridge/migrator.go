@@ -0,0 +1,129 @@
+package gormbridge
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// gormMigrator gorm.Migrator 中与 database.Migrator 签名一致的方法
+//
+// ColumnTypes 和 CreateView 使用了 gorm 的具体类型，通过反射桥接。
+type gormMigrator interface {
+	AutoMigrate(dst ...interface{}) error
+	CurrentDatabase() string
+	CreateTable(dst ...interface{}) error
+	DropTable(dst ...interface{}) error
+	HasTable(dst interface{}) bool
+	RenameTable(oldName, newName interface{}) error
+	GetTables() ([]string, error)
+	AddColumn(dst interface{}, field string) error
+	DropColumn(dst interface{}, field string) error
+	AlterColumn(dst interface{}, field string) error
+	HasColumn(dst interface{}, field string) bool
+	RenameColumn(dst interface{}, oldName, field string) error
+	DropView(name string) error
+	CreateConstraint(dst interface{}, name string) error
+	DropConstraint(dst interface{}, name string) error
+	HasConstraint(dst interface{}, name string) bool
+	CreateIndex(dst interface{}, name string) error
+	DropIndex(dst interface{}, name string) error
+	HasIndex(dst interface{}, name string) bool
+	RenameIndex(dst interface{}, oldName, newName string) error
+}
+
+// gormValuer 可以取回原始 *gorm.DB 的 DB
+type gormValuer interface {
+	gormValue() interface{}
+}
+
+// Migrator 基于 gorm.Migrator 的 database.Migrator 实现
+type Migrator struct {
+	gormMigrator
+	raw reflect.Value
+	err error
+}
+
+var _ database.Migrator = (*Migrator)(nil)
+
+// newMigrator 调用 (*gorm.DB).Migrator() 并包装结果
+func newMigrator(db interface{}) *Migrator {
+	results, err := call(db, "Migrator")
+	if err != nil {
+		return &Migrator{gormMigrator: errMigrator{err}, err: err}
+	}
+	m, ok := results[0].Interface().(gormMigrator)
+	if !ok {
+		err := fmt.Errorf("gormbridge: %s is not a gorm.Migrator", results[0].Type())
+		return &Migrator{gormMigrator: errMigrator{err}, err: err}
+	}
+	return &Migrator{gormMigrator: m, raw: reflect.ValueOf(m)}
+}
+
+// ColumnTypes 获取列类型，gorm.ColumnType 是 database.ColumnType 的超集
+func (m *Migrator) ColumnTypes(dst interface{}) ([]database.ColumnType, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	results := m.raw.MethodByName("ColumnTypes").Call([]reflect.Value{reflect.ValueOf(&dst).Elem()})
+	if err, _ := results[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	columns := make([]database.ColumnType, 0, results[0].Len())
+	for i := 0; i < results[0].Len(); i++ {
+		column, ok := results[0].Index(i).Interface().(database.ColumnType)
+		if !ok {
+			return nil, fmt.Errorf("gormbridge: %s does not implement database.ColumnType", results[0].Index(i).Type())
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// CreateView 创建视图，option.Query 必须是由本包创建的 DB
+func (m *Migrator) CreateView(name string, option database.ViewOption) error {
+	if m.err != nil {
+		return m.err
+	}
+	method := m.raw.MethodByName("CreateView")
+	target := reflect.New(method.Type().In(1)).Elem()
+	target.FieldByName("Replace").SetBool(option.Replace)
+	target.FieldByName("CheckOption").SetString(option.CheckOption)
+	if option.Query != nil {
+		valuer, ok := option.Query.(gormValuer)
+		if !ok {
+			return fmt.Errorf("gormbridge: view query must be created by gormbridge, got %T", option.Query)
+		}
+		target.FieldByName("Query").Set(reflect.ValueOf(valuer.gormValue()))
+	}
+	results := method.Call([]reflect.Value{reflect.ValueOf(name), target})
+	err, _ := results[0].Interface().(error)
+	return err
+}
+
+// errMigrator 无法获取 gorm.Migrator 时的占位实现，所有方法返回同一错误
+type errMigrator struct {
+	err error
+}
+
+func (m errMigrator) AutoMigrate(dst ...interface{}) error                { return m.err }
+func (m errMigrator) CurrentDatabase() string                             { return "" }
+func (m errMigrator) CreateTable(dst ...interface{}) error                { return m.err }
+func (m errMigrator) DropTable(dst ...interface{}) error                  { return m.err }
+func (m errMigrator) HasTable(dst interface{}) bool                       { return false }
+func (m errMigrator) RenameTable(oldName, newName interface{}) error      { return m.err }
+func (m errMigrator) GetTables() ([]string, error)                        { return nil, m.err }
+func (m errMigrator) AddColumn(dst interface{}, field string) error       { return m.err }
+func (m errMigrator) DropColumn(dst interface{}, field string) error      { return m.err }
+func (m errMigrator) AlterColumn(dst interface{}, field string) error     { return m.err }
+func (m errMigrator) HasColumn(dst interface{}, field string) bool        { return false }
+func (m errMigrator) RenameColumn(dst interface{}, o, field string) error { return m.err }
+func (m errMigrator) DropView(name string) error                          { return m.err }
+func (m errMigrator) CreateConstraint(dst interface{}, name string) error { return m.err }
+func (m errMigrator) DropConstraint(dst interface{}, name string) error   { return m.err }
+func (m errMigrator) HasConstraint(dst interface{}, name string) bool     { return false }
+func (m errMigrator) CreateIndex(dst interface{}, name string) error      { return m.err }
+func (m errMigrator) DropIndex(dst interface{}, name string) error        { return m.err }
+func (m errMigrator) HasIndex(dst interface{}, name string) bool          { return false }
+func (m errMigrator) RenameIndex(dst interface{}, o, n string) error      { return m.err }
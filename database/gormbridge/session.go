@@ -0,0 +1,99 @@
+package gormbridge
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// sessionFields 按名称复制到 gorm.Session 的 SessionConfig 字段
+var sessionFields = []string{
+	"DryRun",
+	"PrepareStmt",
+	"NewDB",
+	"SkipHooks",
+	"SkipDefaultTransaction",
+	"DisableNestedTransaction",
+	"AllowGlobalUpdate",
+	"FullSaveAssociations",
+	"QueryFields",
+	"Context",
+	"NowFunc",
+	"CreateBatchSize",
+}
+
+// session 调用 (*gorm.DB).Session(&gorm.Session{...})
+//
+// gorm.Session 的类型从方法签名中获取，只复制名称和类型都匹配的字段。
+func session[T any](db T, config *database.SessionConfig) (T, error) {
+	var zero T
+	method := reflect.ValueOf(db).MethodByName("Session")
+	if !method.IsValid() || method.Type().NumIn() != 1 || method.Type().In(0).Kind() != reflect.Ptr {
+		return zero, fmt.Errorf("gormbridge: %T has no Session(*gorm.Session) method", db)
+	}
+
+	target := reflect.New(method.Type().In(0).Elem())
+	if config != nil {
+		source := reflect.ValueOf(config).Elem()
+		for _, name := range sessionFields {
+			from := source.FieldByName(name)
+			to := target.Elem().FieldByName(name)
+			if !to.IsValid() || !to.CanSet() || !from.Type().AssignableTo(to.Type()) {
+				continue
+			}
+			to.Set(from)
+		}
+	}
+
+	result, ok := method.Call([]reflect.Value{target})[0].Interface().(T)
+	if !ok {
+		return zero, fmt.Errorf("gormbridge: %T.Session returned unexpected type", db)
+	}
+	return result, nil
+}
+
+// field 读取 *gorm.DB 的导出字段，如 Error 和 RowsAffected
+func field(db interface{}, name string) interface{} {
+	v := reflect.ValueOf(db)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.FieldByName(name)
+	if !f.IsValid() || !f.CanInterface() {
+		return nil
+	}
+	return f.Interface()
+}
+
+// association 调用 (*gorm.DB).Association(column)
+func association(db interface{}, column string) (database.Association, error) {
+	results, err := call(db, "Association", column)
+	if err != nil {
+		return nil, err
+	}
+	association, ok := results[0].Interface().(database.Association)
+	if !ok {
+		return nil, fmt.Errorf("gormbridge: %s does not implement database.Association", results[0].Type())
+	}
+	return association, nil
+}
+
+// call 通过反射调用方法
+func call(receiver interface{}, name string, args ...interface{}) ([]reflect.Value, error) {
+	method := reflect.ValueOf(receiver).MethodByName(name)
+	if !method.IsValid() {
+		return nil, fmt.Errorf("gormbridge: %T has no method %s", receiver, name)
+	}
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		in[i] = reflect.ValueOf(arg)
+	}
+	return method.Call(in), nil
+}
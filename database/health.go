@@ -0,0 +1,159 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// ConnectionHealth 连接健康状况
+//
+// ConnectionHealth 是 DatabaseManager.HealthCheck 对单个连接的检查结果。
+//
+// 使用示例：
+//
+//	health := manager.HealthCheck(ctx)["mysql"]
+//	if health.Healthy && health.ReplicaLag != nil && *health.ReplicaLag > 5*time.Second {
+//		log.Println("replica lagging behind")
+//	}
+type ConnectionHealth struct {
+	// Name 连接名称
+	Name string `json:"name"`
+
+	// Driver 驱动名称
+	Driver string `json:"driver"`
+
+	// Healthy 是否健康
+	Healthy bool `json:"healthy"`
+
+	// Latency Ping 耗时
+	Latency time.Duration `json:"latency"`
+
+	// Pool 连接池统计
+	Pool sql.DBStats `json:"pool"`
+
+	// ReplicaLag 只读副本延迟
+	//
+	// 驱动不支持或连接不是副本时为 nil。
+	ReplicaLag *time.Duration `json:"replica_lag,omitempty"`
+
+	// Error 检查失败的原因
+	Error string `json:"error,omitempty"`
+
+	// CheckedAt 检查时间
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// ReconnectPolicy 断线重连策略
+//
+// ReconnectPolicy 使用指数退避计算每次重连前的等待时间。
+//
+// 使用示例：
+//
+//	policy := database.ReconnectPolicy{
+//		MaxAttempts:  5,
+//		InitialDelay: 100 * time.Millisecond,
+//		MaxDelay:     5 * time.Second,
+//		Multiplier:   2,
+//	}
+//	policy.Backoff(1) // 100ms
+//	policy.Backoff(3) // 400ms
+type ReconnectPolicy struct {
+	// MaxAttempts 最大重连次数
+	//
+	// 为 0 时不自动重连。
+	MaxAttempts int
+
+	// InitialDelay 首次重连前的等待时间
+	InitialDelay time.Duration
+
+	// MaxDelay 最长等待时间
+	MaxDelay time.Duration
+
+	// Multiplier 退避倍数
+	//
+	// 小于 1 时按 1 处理（固定间隔）。
+	Multiplier float64
+}
+
+// DefaultReconnectPolicy 返回默认的断线重连策略
+//
+// 最多重连 3 次，等待 100ms、200ms、400ms。
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		MaxAttempts:  3,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   2,
+	}
+}
+
+// Backoff 计算第 attempt 次重连前的等待时间
+//
+// attempt 从 1 开始，超过 MaxAttempts 时返回 -1 表示放弃重连。
+func (p ReconnectPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 || attempt > p.MaxAttempts {
+		return -1
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := float64(p.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
+// lostConnectionMessages 表示连接已断开的错误信息片段
+var lostConnectionMessages = []string{
+	"server has gone away",
+	"no connection to the server",
+	"lost connection",
+	"is dead or not enabled",
+	"error while sending",
+	"decryption failed or bad record mac",
+	"server closed the connection unexpectedly",
+	"ssl connection has been closed unexpectedly",
+	"error writing data to the connection",
+	"child connection forced to terminate due to client_idle_limit",
+	"query_wait_timeout",
+	"reset by peer",
+	"physical connection is not usable",
+	"tcp provider: error code 0x68",
+	"broken pipe",
+	"connection refused",
+	"bad connection",
+	"the database system is shutting down",
+	"connection timed out",
+}
+
+// IsLostConnection 判断错误是否由连接断开引起
+//
+// 与 Laravel 的 DetectsLostConnections 类似，通过错误信息识别断线（不包括死锁等并非断线的错误），
+// 同时识别 ErrLostConnection 和 driver.ErrBadConn。
+//
+// 示例：
+//
+//	if database.IsLostConnection(err) {
+//		db, err = manager.Reconnect("mysql")
+//	}
+func IsLostConnection(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrLostConnection) || errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, fragment := range lostConnectionMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package database
+
+import "context"
+
+// DatabaseManager 数据库管理器接口
+//
+// DatabaseManager 管理多个命名数据库连接，负责连接的创建、复用、
+// 断开重连，以及连接健康检查。
+//
+// 使用示例：
+//
+//	manager := container.MustMake("db").(database.DatabaseManager)
+//
+//	// 默认连接
+//	db := manager.Connection("")
+//
+//	// 指定连接
+//	analytics := manager.Connection("analytics")
+//
+//	// 断线重连
+//	db, err := manager.Reconnect("mysql")
+//
+//	// 健康检查
+//	for name, health := range manager.HealthCheck(ctx) {
+//		fmt.Printf("%s: healthy=%v latency=%s\n", name, health.Healthy, health.Latency)
+//	}
+type DatabaseManager interface {
+	// Connection 获取连接
+	//
+	// name 为空时返回默认连接。
+	//
+	// 示例：
+	//   db := manager.Connection("pgsql")
+	Connection(name string) DB
+
+	// GetDefaultConnection 获取默认连接名
+	GetDefaultConnection() string
+
+	// SetDefaultConnection 设置默认连接名
+	SetDefaultConnection(name string)
+
+	// GetConnections 获取所有已建立的连接
+	GetConnections() map[string]DB
+
+	// Reconnect 重新建立连接
+	//
+	// 断开旧连接并按配置重新连接。
+	Reconnect(name string) (DB, error)
+
+	// Disconnect 断开连接
+	Disconnect(name string) error
+
+	// Purge 断开并移除连接
+	//
+	// 下次调用 Connection 时会重新创建。
+	Purge(name string) error
+
+	// Extend 注册自定义连接驱动
+	//
+	// 示例：
+	//   manager.Extend("clickhouse", func(config map[string]interface{}, name string) (database.DB, error) {
+	//       return openClickHouse(config["dsn"].(string))
+	//   })
+	Extend(driver string, resolver func(config map[string]interface{}, name string) (DB, error))
+
+	// SetReconnectPolicy 设置断线重连策略
+	//
+	// 连接执行语句时返回的错误被 IsLostConnection 判定为断线时，
+	// 管理器按策略退避重连并重试一次语句（事务中不重试）。
+	//
+	// 示例：
+	//   manager.SetReconnectPolicy(database.DefaultReconnectPolicy())
+	SetReconnectPolicy(policy ReconnectPolicy)
+
+	// GetReconnectPolicy 获取断线重连策略
+	GetReconnectPolicy() ReconnectPolicy
+
+	// HealthCheck 检查所有已配置连接的健康状况
+	//
+	// 对每个连接执行 Ping 并收集连接池统计信息，
+	// 支持的驱动（MySQL、Postgres）还会报告只读副本延迟。
+	// 返回的结果可以直接用于健康检查端点。
+	//
+	// 示例：
+	//   ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	//   defer cancel()
+	//   report := manager.HealthCheck(ctx)
+	//   if !report["mysql"].Healthy {
+	//       log.Println(report["mysql"].Error)
+	//   }
+	HealthCheck(ctx context.Context) map[string]ConnectionHealth
+}
@@ -0,0 +1,411 @@
+package memdb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// row 一行数据，列名到值
+type row map[string]interface{}
+
+// clone 复制行数据
+func (r row) clone() row {
+	c := make(row, len(r))
+	for k, v := range r {
+		c[k] = v
+	}
+	return c
+}
+
+// condition 单个查询条件
+//
+// operator 为 "group" 时 value 为 []condition，整体作为一个括号内的条件，Not 用它对多个条件整体取反。
+type condition struct {
+	or       bool
+	not      bool
+	column   string
+	operator string
+	value    interface{}
+}
+
+// order 排序
+type order struct {
+	column string
+	desc   bool
+}
+
+// matches 判断行是否满足条件
+//
+// 条件按 SQL 优先级组合：AND 优先于 OR，即 a AND b OR c 等价于 (a AND b) OR c。
+func matches(r row, conditions []condition) (bool, error) {
+	if len(conditions) == 0 {
+		return true, nil
+	}
+	group := true
+	for i, c := range conditions {
+		if i > 0 && c.or {
+			if group {
+				return true, nil
+			}
+			group = true
+		}
+		if !group {
+			continue
+		}
+		ok, err := c.evaluate(r)
+		if err != nil {
+			return false, err
+		}
+		group = ok
+	}
+	return group, nil
+}
+
+// evaluate 判断单个条件
+func (c condition) evaluate(r row) (bool, error) {
+	ok, err := c.test(r)
+	if err != nil {
+		return false, err
+	}
+	if c.not {
+		return !ok, nil
+	}
+	return ok, nil
+}
+
+func (c condition) test(r row) (bool, error) {
+	if c.operator == "group" {
+		return matches(r, c.value.([]condition))
+	}
+	actual := normalize(r[c.column])
+	switch c.operator {
+	case "=", "==":
+		return equal(actual, c.value), nil
+	case "!=", "<>":
+		return actual != nil && !equal(actual, c.value), nil
+	case ">", ">=", "<", "<=":
+		cmp, ok := compare(actual, c.value)
+		if !ok {
+			return false, nil
+		}
+		switch c.operator {
+		case ">":
+			return cmp > 0, nil
+		case ">=":
+			return cmp >= 0, nil
+		case "<":
+			return cmp < 0, nil
+		}
+		return cmp <= 0, nil
+	case "in", "not in":
+		values := toSlice(c.value)
+		found := false
+		for _, v := range values {
+			if equal(actual, v) {
+				found = true
+				break
+			}
+		}
+		if c.operator == "in" {
+			return found, nil
+		}
+		return actual != nil && !found, nil
+	case "is null":
+		return actual == nil, nil
+	case "is not null":
+		return actual != nil, nil
+	case "between":
+		bounds := toSlice(c.value)
+		if len(bounds) != 2 {
+			return false, fmt.Errorf("memdb: between on %s requires 2 values", c.column)
+		}
+		low, ok1 := compare(actual, bounds[0])
+		high, ok2 := compare(actual, bounds[1])
+		return ok1 && ok2 && low >= 0 && high <= 0, nil
+	case "like", "not like":
+		pattern, ok := normalize(c.value).(string)
+		str, isString := actual.(string)
+		if !ok || !isString {
+			return false, nil
+		}
+		matched := likePattern(pattern).MatchString(str)
+		if c.operator == "like" {
+			return matched, nil
+		}
+		return !matched, nil
+	}
+	return false, fmt.Errorf("memdb: unsupported operator %q", c.operator)
+}
+
+// likeCache 缓存 LIKE 模式编译结果
+var likeCache sync.Map // map[string]*regexp.Regexp
+
+// likePattern 把 SQL LIKE 模式转换为不区分大小写的正则
+func likePattern(pattern string) *regexp.Regexp {
+	if re, ok := likeCache.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	var b strings.Builder
+	b.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	re := regexp.MustCompile(b.String())
+	likeCache.Store(pattern, re)
+	return re
+}
+
+// normalize 统一值的表示：解引用指针、调用 driver.Valuer、[]byte 转换为字符串
+func normalize(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return value
+		}
+		value = v
+		if value == nil {
+			return nil
+		}
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+		value = rv.Interface()
+	}
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return value
+}
+
+// toSlice 把切片或数组转换为 []interface{}
+func toSlice(value interface{}) []interface{} {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []interface{}{value}
+	}
+	values := make([]interface{}, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values
+}
+
+// equal 判断两个值是否相等，数字按数值比较
+func equal(a, b interface{}) bool {
+	a, b = normalize(a), normalize(b)
+	if a == nil || b == nil {
+		return false // SQL 中 NULL 不等于任何值
+	}
+	if cmp, ok := compare(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compare 比较两个值，返回 -1、0、1，类型不可比较时 ok 为 false
+func compare(a, b interface{}) (int, bool) {
+	a, b = normalize(a), normalize(b)
+	if a == nil || b == nil {
+		return 0, false
+	}
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+		return 0, false
+	}
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y), true
+		}
+	}
+	return 0, false
+}
+
+// toFloat 把数字和布尔值转换为 float64
+func toFloat(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sortRows 按排序规则稳定排序，NULL 在升序时排在最前
+func sortRows(rows []row, orders []order) {
+	if len(orders) == 0 {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, o := range orders {
+			a, b := normalize(rows[i][o.column]), normalize(rows[j][o.column])
+			var cmp int
+			switch {
+			case a == nil && b == nil:
+				cmp = 0
+			case a == nil:
+				cmp = -1
+			case b == nil:
+				cmp = 1
+			default:
+				cmp, _ = compare(a, b)
+			}
+			if cmp == 0 {
+				continue
+			}
+			if o.desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+// paginate 应用 offset 和 limit，负数表示不限制
+func paginate(rows []row, offset, limit int) []row {
+	if offset > 0 {
+		if offset >= len(rows) {
+			return nil
+		}
+		rows = rows[offset:]
+	}
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// columnName 去掉表名前缀和引号，如 `users`.`name` -> name
+func columnName(column string) string {
+	column = strings.TrimSpace(column)
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		column = column[i+1:]
+	}
+	return strings.Trim(column, "`\"[]")
+}
+
+// clauseRegexp 解析简单的 SQL 条件片段
+var clauseRegexp = regexp.MustCompile(`(?is)^\s*([\w."` + "`" + `\[\]]+)\s*(=|==|!=|<>|>=|<=|>|<|not\s+like|like|not\s+in|in|is\s+not\s+null|is\s+null|between)\s*(.*?)\s*$`)
+
+// andRegexp 按 AND 拆分条件
+var andRegexp = regexp.MustCompile(`(?i)\s+and\s+`)
+
+// orRegexp 检测不支持的 OR 连接
+var orRegexp = regexp.MustCompile(`(?i)\s+or\s+`)
+
+// listRegexp 匹配 IN 的占位符列表，如 (?, ?, ?)
+var listRegexp = regexp.MustCompile(`^\(\s*\?(\s*,\s*\?)*\s*\)$`)
+
+// parseClause 解析字符串条件，如 "age > ? AND name LIKE ?"
+//
+// 只支持由 AND 连接的简单比较、IN、LIKE、IS NULL 和 BETWEEN，
+// 不支持括号、OR 和函数调用，遇到时返回错误而不是静默匹配不到数据。
+func parseClause(query string, args []interface{}) ([]condition, error) {
+	if orRegexp.MatchString(query) {
+		return nil, fmt.Errorf("memdb: unsupported condition %q: OR is not supported", query)
+	}
+	parts := andRegexp.Split(query, -1)
+	var conditions []condition
+	for i := 0; i < len(parts); i++ {
+		part := parts[i]
+		// BETWEEN ? AND ? 被 AND 拆开，重新合并
+		if strings.HasSuffix(strings.ToLower(strings.TrimSpace(part)), "between ?") && i+1 < len(parts) {
+			part += " AND " + parts[i+1]
+			i++
+		}
+		m := clauseRegexp.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("memdb: unsupported condition %q", part)
+		}
+		operator := strings.Join(strings.Fields(strings.ToLower(m[2])), " ")
+		placeholders := strings.Count(m[3], "?")
+		if placeholders > len(args) {
+			return nil, fmt.Errorf("memdb: not enough arguments for condition %q", part)
+		}
+		c := condition{column: columnName(m[1]), operator: operator}
+		switch {
+		case operator == "between":
+			if placeholders != 2 {
+				return nil, fmt.Errorf("memdb: unsupported condition %q", part)
+			}
+			c.value = []interface{}{args[0], args[1]}
+		case operator == "in" || operator == "not in":
+			switch {
+			case placeholders == 1 && (m[3] == "?" || listRegexp.MatchString(m[3])):
+				c.value = args[0]
+			case placeholders > 1 && listRegexp.MatchString(m[3]):
+				c.value = append([]interface{}(nil), args[:placeholders]...)
+			default:
+				return nil, fmt.Errorf("memdb: unsupported condition %q", part)
+			}
+		case placeholders > 1:
+			return nil, fmt.Errorf("memdb: unsupported condition %q", part)
+		case placeholders == 1:
+			c.value = args[0]
+		case placeholders == 0 && operator != "is null" && operator != "is not null":
+			return nil, fmt.Errorf("memdb: condition %q must use placeholders", part)
+		}
+		args = args[placeholders:]
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
+
+// parseOrder 解析排序字符串，如 "age desc, name"
+func parseOrder(value string) []order {
+	var orders []order
+	for _, part := range strings.Split(value, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		orders = append(orders, order{
+			column: columnName(fields[0]),
+			desc:   len(fields) > 1 && strings.EqualFold(fields[1], "desc"),
+		})
+	}
+	return orders
+}
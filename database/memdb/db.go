@@ -0,0 +1,1503 @@
+package memdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// DB database.DB 的内存实现
+//
+// 与 GORM 一致，每个链式方法都返回新的会话，原会话不受影响；
+// 终结方法（Find、Create、Update 等）执行后通过 Error() 和 RowsAffected() 获取结果。
+//
+// 使用示例：
+//
+//	db := memdb.New()
+//	db.AutoMigrate(&User{})
+//
+//	db.Create(&User{Name: "John", Age: 30})
+//
+//	var user User
+//	if err := db.Where("name = ?", "John").First(&user).Error(); err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	err := db.Transaction(func(tx database.DB) error {
+//		return tx.Model(&user).Update("age", 31).Error()
+//	})
+type DB struct {
+	store        *Store
+	tx           *txState
+	stmt         *statement
+	settings     map[string]interface{}
+	ctx          context.Context
+	nowFunc      func() time.Time
+	dryRun       bool
+	allowGlobal  bool
+	errs         []error
+	rowsAffected int64
+}
+
+// statement 当前会话的查询状态
+type statement struct {
+	table      string
+	model      interface{}
+	conditions []condition
+	orders     []order
+	limit      int
+	offset     int
+	selects    []string
+	omits      []string
+	distinct   bool
+	unscoped   bool
+	attrs      []interface{}
+	assigns    []interface{}
+	instance   map[string]interface{}
+}
+
+func newStatement() *statement {
+	return &statement{limit: -1, offset: -1, instance: make(map[string]interface{})}
+}
+
+func (s *statement) clone() *statement {
+	c := *s
+	c.conditions = append([]condition(nil), s.conditions...)
+	c.orders = append([]order(nil), s.orders...)
+	c.selects = append([]string(nil), s.selects...)
+	c.omits = append([]string(nil), s.omits...)
+	c.attrs = append([]interface{}(nil), s.attrs...)
+	c.assigns = append([]interface{}(nil), s.assigns...)
+	c.instance = make(map[string]interface{}, len(s.instance))
+	for k, v := range s.instance {
+		c.instance[k] = v
+	}
+	return &c
+}
+
+// New 创建使用独立存储的内存数据库
+func New() *DB {
+	return Open(NewStore())
+}
+
+// Open 创建使用指定存储的内存数据库会话
+//
+// 多个会话共享同一个 Store 时可以看到彼此的数据。
+func Open(store *Store) *DB {
+	return &DB{
+		store:    store,
+		stmt:     newStatement(),
+		settings: make(map[string]interface{}),
+		ctx:      context.Background(),
+		nowFunc:  time.Now,
+	}
+}
+
+// Store 获取底层存储
+func (db *DB) Store() *Store {
+	return db.store
+}
+
+// Query 获取共享当前存储和事务的查询构建器
+//
+// 示例：
+//
+//	users, err := db.Query().Table("users").Where("age", ">", 18).Get()
+func (db *DB) Query() database.QueryBuilder {
+	return &QueryBuilder{db: db.fresh(), limit: -1, offset: -1}
+}
+
+// clone 复制会话
+func (db *DB) clone() *DB {
+	c := *db
+	c.stmt = db.stmt.clone()
+	c.errs = append([]error(nil), db.errs...)
+	c.settings = make(map[string]interface{}, len(db.settings))
+	for k, v := range db.settings {
+		c.settings[k] = v
+	}
+	c.rowsAffected = 0
+	return &c
+}
+
+// fresh 复制会话并清空查询状态
+func (db *DB) fresh() *DB {
+	c := db.clone()
+	c.stmt = newStatement()
+	c.errs = nil
+	return c
+}
+
+// WithContext 设置上下文，之后的查询和写入在 ctx 取消后返回 ctx.Err()
+func (db *DB) WithContext(ctx context.Context) database.DB {
+	c := db.clone()
+	if ctx != nil {
+		c.ctx = ctx
+	}
+	return c
+}
+
+// Session 按配置创建新会话
+//
+// 支持 NewDB、DryRun、AllowGlobalUpdate、Context 和 NowFunc，其他选项被忽略。
+func (db *DB) Session(config *database.SessionConfig) database.DB {
+	c := db.clone()
+	if config == nil {
+		return c
+	}
+	if config.NewDB {
+		c.stmt = newStatement()
+	}
+	if config.DryRun {
+		c.dryRun = true
+	}
+	if config.AllowGlobalUpdate {
+		c.allowGlobal = true
+	}
+	if config.Context != nil {
+		c.ctx = config.Context
+	}
+	if config.NowFunc != nil {
+		c.nowFunc = config.NowFunc
+	}
+	return c
+}
+
+// Debug 内存实现中不输出日志
+func (db *DB) Debug() database.DB {
+	return db.clone()
+}
+
+// DryRun 只构建语句不写入数据
+func (db *DB) DryRun() database.DB {
+	c := db.clone()
+	c.dryRun = true
+	return c
+}
+
+// Model 设置模型
+func (db *DB) Model(value interface{}) database.DB {
+	c := db.clone()
+	c.stmt.model = value
+	return c
+}
+
+// Table 设置表名，忽略别名
+func (db *DB) Table(name string, args ...interface{}) database.DB {
+	c := db.clone()
+	if fields := strings.Fields(name); len(fields) > 0 {
+		c.stmt.table = strings.Trim(fields[0], "`\"")
+	}
+	return c
+}
+
+// Select 设置查询或更新的列
+func (db *DB) Select(query interface{}, args ...interface{}) database.DB {
+	c := db.clone()
+	c.stmt.selects = append(c.stmt.selects, columnList(query, args)...)
+	return c
+}
+
+// Omit 设置忽略的列
+func (db *DB) Omit(columns ...string) database.DB {
+	c := db.clone()
+	for _, column := range columns {
+		c.stmt.omits = append(c.stmt.omits, columnList(column, nil)...)
+	}
+	return c
+}
+
+// Where 添加 AND 条件
+func (db *DB) Where(query interface{}, args ...interface{}) database.DB {
+	return db.addConditions(query, args, false, false)
+}
+
+// Or 添加 OR 条件
+func (db *DB) Or(query interface{}, args ...interface{}) database.DB {
+	return db.addConditions(query, args, true, false)
+}
+
+// Not 添加 NOT 条件，与 gorm 一致，多个条件整体取反：Not(map{a, b}) 为 NOT (a AND b)
+func (db *DB) Not(query interface{}, args ...interface{}) database.DB {
+	return db.addConditions(query, args, false, true)
+}
+
+func (db *DB) addConditions(query interface{}, args []interface{}, or bool, not bool) *DB {
+	c := db.clone()
+	conditions, err := c.buildConditions(query, args)
+	if err != nil {
+		c.AddError(err)
+		return c
+	}
+	if not && len(conditions) > 1 {
+		conditions = []condition{{operator: "group", value: conditions}}
+	}
+	for i := range conditions {
+		conditions[i].not = not
+		conditions[i].or = or && i == 0
+	}
+	c.stmt.conditions = append(c.stmt.conditions, conditions...)
+	return c
+}
+
+// buildConditions 把 Where 的参数转换为条件
+func (db *DB) buildConditions(query interface{}, args []interface{}) ([]condition, error) {
+	switch q := query.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(q) == "" {
+			return nil, nil
+		}
+		return parseClause(q, args)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(q))
+		for key := range q {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		conditions := make([]condition, 0, len(q))
+		for _, key := range keys {
+			conditions = append(conditions, equalityCondition(columnName(key), q[key]))
+		}
+		return conditions, nil
+	}
+
+	rv := reflect.ValueOf(query)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch {
+	case rv.Kind() == reflect.Struct && parseSchema(query) != nil:
+		sch := parseSchema(query)
+		var conditions []condition
+		for _, f := range sch.fields {
+			fv := rv.FieldByIndex(f.index)
+			if fv.IsZero() {
+				continue
+			}
+			value, err := storedValue(fv)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition{column: f.column, operator: "=", value: value})
+		}
+		return conditions, nil
+	case isNumberKind(rv.Kind()):
+		return []condition{{column: db.primaryKeyColumn(nil), operator: "=", value: rv.Interface()}}, nil
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+		return []condition{{column: db.primaryKeyColumn(nil), operator: "in", value: query}}, nil
+	}
+	return nil, fmt.Errorf("%w: condition of type %T", ErrUnsupported, query)
+}
+
+// equalityCondition 根据值类型生成 =、IN 或 IS NULL 条件
+func equalityCondition(column string, value interface{}) condition {
+	if normalize(value) == nil {
+		return condition{column: column, operator: "is null"}
+	}
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8) || rv.Kind() == reflect.Array {
+		return condition{column: column, operator: "in", value: value}
+	}
+	return condition{column: column, operator: "=", value: value}
+}
+
+// columnList 解析列名列表，支持 "a, b"、[]string 和多个字符串参数
+func columnList(query interface{}, args []interface{}) []string {
+	var columns []string
+	switch q := query.(type) {
+	case string:
+		for _, part := range strings.Split(q, ",") {
+			if part = strings.TrimSpace(part); part != "" && part != "*" {
+				columns = append(columns, columnName(part))
+			}
+		}
+	case []string:
+		for _, column := range q {
+			columns = append(columns, columnName(column))
+		}
+	}
+	for _, arg := range args {
+		if column, ok := arg.(string); ok {
+			columns = append(columns, columnName(column))
+		}
+	}
+	return columns
+}
+
+// Create 插入记录
+//
+// 支持结构体指针、结构体切片、map[string]interface{} 和 []map[string]interface{}。
+// 自增主键和 CreatedAt/UpdatedAt 会写回结构体。
+func (db *DB) Create(value interface{}) database.DB {
+	c := db.clone()
+	err := c.access(true, func(ts tables) error {
+		return c.forEachRecord(value, func(sch *schema, record reflect.Value, values map[string]interface{}) error {
+			return c.insert(ts, sch, record, values)
+		})
+	})
+	if err != nil {
+		c.AddError(err)
+	}
+	return c
+}
+
+// CreateInBatches 分批插入记录，内存实现中等同于 Create
+func (db *DB) CreateInBatches(value interface{}, batchSize int) database.DB {
+	return db.Create(value)
+}
+
+// Save 保存记录，主键为零值时插入，否则整体更新（不存在时插入）
+func (db *DB) Save(value interface{}) database.DB {
+	c := db.clone()
+	err := c.access(true, func(ts tables) error {
+		return c.forEachRecord(value, func(sch *schema, record reflect.Value, values map[string]interface{}) error {
+			if sch == nil || sch.primaryKey == nil || record.FieldByIndex(sch.primaryKey.index).IsZero() {
+				return c.insert(ts, sch, record, values)
+			}
+			return c.replace(ts, sch, record)
+		})
+	})
+	if err != nil {
+		c.AddError(err)
+	}
+	return c
+}
+
+// forEachRecord 遍历 Create/Save 的每一条记录
+func (db *DB) forEachRecord(value interface{}, fn func(sch *schema, record reflect.Value, values map[string]interface{}) error) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return fn(nil, reflect.Value{}, v)
+	case []map[string]interface{}:
+		for _, values := range v {
+			if err := fn(nil, reflect.Value{}, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	sch := parseSchema(value)
+	if sch == nil {
+		return fmt.Errorf("%w: create value of type %T", ErrUnsupported, value)
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Struct {
+		if !rv.CanAddr() {
+			return fmt.Errorf("memdb: %T must be a pointer", value)
+		}
+		return fn(sch, rv, nil)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		record := rv.Index(i)
+		for record.Kind() == reflect.Ptr {
+			record = record.Elem()
+		}
+		if err := fn(sch, record, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableFor 获取操作的表名
+func (db *DB) tableFor(sch *schema) (string, error) {
+	if db.stmt.table != "" {
+		return db.stmt.table, nil
+	}
+	if sch != nil {
+		return sch.table, nil
+	}
+	if model := parseSchema(db.stmt.model); model != nil {
+		return model.table, nil
+	}
+	return "", errors.New("memdb: table not set, use Table or Model")
+}
+
+// primaryKeyColumn 获取主键列名，默认 id
+func (db *DB) primaryKeyColumn(sch *schema) string {
+	if sch == nil {
+		sch = parseSchema(db.stmt.model)
+	}
+	if sch != nil && sch.primaryKey != nil {
+		return sch.primaryKey.column
+	}
+	return "id"
+}
+
+// ensureTable 获取表，不存在时自动创建
+func ensureTable(ts tables, name string) *table {
+	t, ok := ts[name]
+	if !ok {
+		t = newTable(name)
+		ts[name] = t
+	}
+	return t
+}
+
+// insert 插入一条记录
+func (db *DB) insert(ts tables, sch *schema, record reflect.Value, values map[string]interface{}) error {
+	name, err := db.tableFor(sch)
+	if err != nil {
+		return err
+	}
+
+	var r row
+	now := db.nowFunc()
+	if record.IsValid() {
+		if sch.createdAt != nil && record.FieldByIndex(sch.createdAt.index).IsZero() {
+			if err := assign(record.FieldByIndex(sch.createdAt.index), now); err != nil {
+				return err
+			}
+		}
+		if sch.updatedAt != nil && record.FieldByIndex(sch.updatedAt.index).IsZero() {
+			if err := assign(record.FieldByIndex(sch.updatedAt.index), now); err != nil {
+				return err
+			}
+		}
+		if r, err = sch.toRow(record); err != nil {
+			return err
+		}
+	} else {
+		r = make(row, len(values))
+		for column, value := range values {
+			r[columnName(column)] = normalize(value)
+		}
+	}
+	for _, column := range db.stmt.omits {
+		delete(r, column)
+	}
+	if db.dryRun {
+		return nil
+	}
+
+	t := ensureTable(ts, name)
+	pk := db.primaryKeyColumn(sch)
+	if err := t.insert(r, pk, sch == nil || sch.primaryKey == nil || sch.primaryKey.auto); err != nil {
+		return err
+	}
+	db.rowsAffected++
+
+	if record.IsValid() && sch.primaryKey != nil {
+		return assign(record.FieldByIndex(sch.primaryKey.index), r[pk])
+	}
+	return nil
+}
+
+// insert 插入行，auto 为 true 时为零值主键分配自增值
+func (t *table) insert(r row, pk string, auto bool) error {
+	id := normalize(r[pk])
+	if auto && (id == nil || reflect.ValueOf(id).IsZero()) {
+		r[pk] = t.nextID
+		t.nextID++
+	} else if id != nil {
+		for _, existing := range t.rows {
+			if equal(existing[pk], id) {
+				return fmt.Errorf("%w: %s.%s = %v", ErrDuplicateKey, t.name, pk, id)
+			}
+		}
+		if n, ok := toFloat(id); ok && int64(n) >= t.nextID {
+			t.nextID = int64(n) + 1
+		}
+	}
+	t.rows = append(t.rows, r)
+	t.addRowColumns(r)
+	return nil
+}
+
+// replace 用结构体的全部字段更新已存在的行，不存在时插入
+func (db *DB) replace(ts tables, sch *schema, record reflect.Value) error {
+	name, err := db.tableFor(sch)
+	if err != nil {
+		return err
+	}
+	if sch.updatedAt != nil {
+		if err := assign(record.FieldByIndex(sch.updatedAt.index), db.nowFunc()); err != nil {
+			return err
+		}
+	}
+	r, err := sch.toRow(record)
+	if err != nil {
+		return err
+	}
+	pk := sch.primaryKey.column
+
+	t := ensureTable(ts, name)
+	for _, existing := range t.rows {
+		if !equal(existing[pk], r[pk]) {
+			continue
+		}
+		if db.dryRun {
+			return nil
+		}
+		for column, value := range db.filterColumns(r) {
+			existing[column] = value
+		}
+		t.addRowColumns(existing)
+		db.rowsAffected++
+		return nil
+	}
+	return db.insert(ts, sch, record, nil)
+}
+
+// filterColumns 按 Select 和 Omit 过滤列
+func (db *DB) filterColumns(values row) row {
+	filtered := make(row, len(values))
+	selected := make(map[string]bool, len(db.stmt.selects))
+	for _, column := range db.stmt.selects {
+		selected[column] = true
+	}
+	for column, value := range values {
+		if len(selected) > 0 && !selected[column] {
+			continue
+		}
+		filtered[column] = value
+	}
+	for _, column := range db.stmt.omits {
+		delete(filtered, column)
+	}
+	return filtered
+}
+
+// Find 查询记录
+//
+// dest 可以是结构体切片、结构体指针切片、[]map[string]interface{}、
+// 结构体指针（取第一条，不存在时不报错）或 map[string]interface{}。
+func (db *DB) Find(dest interface{}, conds ...interface{}) database.DB {
+	return db.finish(dest, conds, nil, -1, false)
+}
+
+// First 按主键升序获取第一条记录
+func (db *DB) First(dest interface{}, conds ...interface{}) database.DB {
+	sch := parseSchema(dest)
+	return db.finish(dest, conds, []order{{column: db.primaryKeyColumn(sch)}}, 1, true)
+}
+
+// Last 按主键降序获取第一条记录
+func (db *DB) Last(dest interface{}, conds ...interface{}) database.DB {
+	sch := parseSchema(dest)
+	return db.finish(dest, conds, []order{{column: db.primaryKeyColumn(sch), desc: true}}, 1, true)
+}
+
+// Take 不排序获取一条记录
+func (db *DB) Take(dest interface{}, conds ...interface{}) database.DB {
+	return db.finish(dest, conds, nil, 1, true)
+}
+
+// finish 执行查询并写入 dest
+func (db *DB) finish(dest interface{}, conds []interface{}, orders []order, limit int, mustFind bool) *DB {
+	c := db.clone()
+	if len(conds) > 0 {
+		conditions, err := c.buildConditions(conds[0], conds[1:])
+		if err != nil {
+			c.AddError(err)
+			return c
+		}
+		c.stmt.conditions = append(c.stmt.conditions, conditions...)
+	}
+	if orders != nil && len(c.stmt.orders) == 0 {
+		c.stmt.orders = orders
+	}
+	if limit >= 0 {
+		c.stmt.limit = limit
+	}
+
+	rows, sch, err := c.query(dest)
+	if err != nil {
+		c.AddError(err)
+		return c
+	}
+	if mustFind && len(rows) == 0 {
+		c.AddError(ErrRecordNotFound)
+		return c
+	}
+	c.rowsAffected = int64(len(rows))
+	if err := c.fillDest(dest, rows, sch); err != nil {
+		c.AddError(err)
+	}
+	return c
+}
+
+// query 获取满足条件的行副本，已排序和分页
+func (db *DB) query(dest interface{}) ([]row, *schema, error) {
+	sch := parseSchema(dest)
+	if sch == nil {
+		sch = parseSchema(db.stmt.model)
+	}
+	name, err := db.tableFor(sch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rows []row
+	err = db.access(false, func(ts tables) error {
+		t, ok := ts[name]
+		if !ok {
+			return nil
+		}
+		for _, r := range t.rows {
+			ok, err := db.visible(r, sch)
+			if err != nil {
+				return err
+			}
+			if ok {
+				rows = append(rows, r.clone())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sortRows(rows, db.stmt.orders)
+	return paginate(rows, db.stmt.offset, db.stmt.limit), sch, nil
+}
+
+// visible 行是否满足会话条件，带软删除字段的模型默认排除已删除的行
+func (db *DB) visible(r row, sch *schema) (bool, error) {
+	if sch != nil && sch.softDelete != nil && !db.stmt.unscoped && normalize(r[sch.softDelete.column]) != nil {
+		return false, nil
+	}
+	return matches(r, db.stmt.conditions)
+}
+
+// fillDest 把查询结果写入 dest
+func (db *DB) fillDest(dest interface{}, rows []row, sch *schema) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("memdb: destination must be a non-nil pointer, got %T", dest)
+	}
+	var only map[string]bool
+	if len(db.stmt.selects) > 0 {
+		only = make(map[string]bool, len(db.stmt.selects))
+		for _, column := range db.stmt.selects {
+			only[column] = true
+		}
+	}
+
+	elem := rv.Elem()
+	switch elem.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(elem.Type(), len(rows), len(rows))
+		for i, r := range rows {
+			if err := fillValue(slice.Index(i), r, sch, only); err != nil {
+				return err
+			}
+		}
+		elem.Set(slice)
+		return nil
+	case reflect.Struct, reflect.Map:
+		if len(rows) == 0 {
+			return nil
+		}
+		return fillValue(elem, rows[0], sch, only)
+	}
+	return fmt.Errorf("%w: destination of type %T", ErrUnsupported, dest)
+}
+
+// fillValue 把一行写入结构体、结构体指针或 map
+func fillValue(v reflect.Value, r row, sch *schema, only map[string]bool) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return fillValue(v.Elem(), r, sch, only)
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for column, value := range r {
+			if only != nil && !only[column] {
+				continue
+			}
+			if value == nil {
+				v.SetMapIndex(reflect.ValueOf(column), reflect.Zero(v.Type().Elem()))
+				continue
+			}
+			v.SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(value))
+		}
+		return nil
+	case reflect.Struct:
+		if sch == nil || sch.modelType != v.Type() {
+			sch = parseSchema(v.Addr().Interface())
+		}
+		return sch.fill(v, r, only)
+	}
+	return fmt.Errorf("%w: destination element of type %s", ErrUnsupported, v.Type())
+}
+
+// FindInBatches 按主键顺序分批查询
+func (db *DB) FindInBatches(dest interface{}, batchSize int, fc func(tx database.DB, batch int) error) database.DB {
+	c := db.clone()
+	if batchSize <= 0 {
+		c.AddError(errors.New("memdb: batch size must be positive"))
+		return c
+	}
+	sch := parseSchema(dest)
+	if len(c.stmt.orders) == 0 {
+		c.stmt.orders = []order{{column: c.primaryKeyColumn(sch)}}
+	}
+	c.stmt.limit = -1
+	rows, sch, err := c.query(dest)
+	if err != nil {
+		c.AddError(err)
+		return c
+	}
+	for batch, start := 1, 0; start < len(rows); batch, start = batch+1, start+batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		if err := c.fillDest(dest, rows[start:end], sch); err != nil {
+			c.AddError(err)
+			return c
+		}
+		tx := c.clone()
+		tx.rowsAffected = int64(end - start)
+		if err := fc(tx, batch); err != nil {
+			c.AddError(err)
+			return c
+		}
+		c.rowsAffected += int64(end - start)
+	}
+	return c
+}
+
+// FirstOrInit 获取第一条记录，不存在时用条件和 Attrs 初始化 dest（不写入）
+func (db *DB) FirstOrInit(dest interface{}, conds ...interface{}) database.DB {
+	c, found := db.firstOrInit(dest, conds)
+	if found {
+		c.AddErrorIf(c.applyAttributes(dest, c.stmt.assigns))
+	}
+	return c
+}
+
+// FirstOrCreate 获取第一条记录，不存在时用条件和 Attrs 创建
+//
+// 存在时如果设置了 Assign，会把 Assign 的值更新到数据库。
+func (db *DB) FirstOrCreate(dest interface{}, conds ...interface{}) database.DB {
+	c, found := db.firstOrInit(dest, conds)
+	if c.Error() != nil {
+		return c
+	}
+	if !found {
+		created := c.fresh().Table(c.stmt.table).Create(dest).(*DB)
+		created.errs = append(c.errs, created.errs...)
+		return created
+	}
+	if len(c.stmt.assigns) == 0 {
+		return c
+	}
+	if err := c.applyAttributes(dest, c.stmt.assigns); err != nil {
+		c.AddError(err)
+		return c
+	}
+	saved := c.fresh().Table(c.stmt.table).Save(dest).(*DB)
+	saved.errs = append(c.errs, saved.errs...)
+	return saved
+}
+
+// firstOrInit 查找第一条记录，不存在时初始化 dest
+func (db *DB) firstOrInit(dest interface{}, conds []interface{}) (*DB, bool) {
+	c := db.First(dest, conds...).(*DB)
+	if !errors.Is(c.Error(), ErrRecordNotFound) {
+		return c, c.Error() == nil
+	}
+
+	c.errs = nil
+	initial := make(map[string]interface{})
+	for _, cond := range c.stmt.conditions {
+		if cond.operator == "=" && !cond.or && !cond.not {
+			initial[cond.column] = cond.value
+		}
+	}
+	if err := c.applyAttributes(dest, []interface{}{initial}); err != nil {
+		c.AddError(err)
+		return c, false
+	}
+	attributes := append(append([]interface{}{}, c.stmt.attrs...), c.stmt.assigns...)
+	c.AddErrorIf(c.applyAttributes(dest, attributes))
+	return c, false
+}
+
+// applyAttributes 把 map 或结构体的非零字段写入 dest
+func (db *DB) applyAttributes(dest interface{}, attributes []interface{}) error {
+	sch := parseSchema(dest)
+	if sch == nil {
+		return fmt.Errorf("%w: destination of type %T", ErrUnsupported, dest)
+	}
+	rv := reflect.ValueOf(dest)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	for _, attribute := range attributes {
+		values, err := attributeMap(attribute)
+		if err != nil {
+			return err
+		}
+		for column, value := range values {
+			if f, ok := sch.byColumn[columnName(column)]; ok {
+				if err := assign(rv.FieldByIndex(f.index), normalize(value)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// attributeMap 把 map 或结构体的非零字段转换为列名到值的映射
+func attributeMap(value interface{}) (map[string]interface{}, error) {
+	if m, ok := value.(map[string]interface{}); ok {
+		values := make(map[string]interface{}, len(m))
+		for column, v := range m {
+			values[columnName(column)] = v
+		}
+		return values, nil
+	}
+	sch := parseSchema(value)
+	if sch == nil {
+		return nil, fmt.Errorf("%w: attributes of type %T", ErrUnsupported, value)
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	values := make(map[string]interface{})
+	for _, f := range sch.fields {
+		fv := rv.FieldByIndex(f.index)
+		if fv.IsZero() {
+			continue
+		}
+		v, err := storedValue(fv)
+		if err != nil {
+			return nil, err
+		}
+		values[f.column] = v
+	}
+	return values, nil
+}
+
+// Update 更新单个列，同时更新 updated_at
+func (db *DB) Update(column string, value interface{}) database.DB {
+	return db.update(map[string]interface{}{column: value}, true)
+}
+
+// Updates 更新多个列，结构体只更新非零字段，同时更新 updated_at
+func (db *DB) Updates(values interface{}) database.DB {
+	m, err := attributeMap(values)
+	if err != nil {
+		c := db.clone()
+		c.AddError(err)
+		return c
+	}
+	return db.update(m, true)
+}
+
+// UpdateColumn 更新单个列，不更新 updated_at
+func (db *DB) UpdateColumn(column string, value interface{}) database.DB {
+	return db.update(map[string]interface{}{column: value}, false)
+}
+
+// UpdateColumns 更新多个列，不更新 updated_at
+func (db *DB) UpdateColumns(values interface{}) database.DB {
+	m, err := attributeMap(values)
+	if err != nil {
+		c := db.clone()
+		c.AddError(err)
+		return c
+	}
+	return db.update(m, false)
+}
+
+// update 更新满足条件的行，并把新值写回 Model 指向的结构体
+func (db *DB) update(values map[string]interface{}, touch bool) *DB {
+	c := db.clone()
+	sch := parseSchema(c.stmt.model)
+	if err := c.scopeToModel(sch, c.stmt.model); err != nil {
+		c.AddError(err)
+		return c
+	}
+	if len(c.stmt.conditions) == 0 && !c.allowGlobal {
+		c.AddError(ErrMissingWhereClause)
+		return c
+	}
+	name, err := c.tableFor(sch)
+	if err != nil {
+		c.AddError(err)
+		return c
+	}
+
+	changes := make(row, len(values))
+	for column, value := range values {
+		changes[columnName(column)] = normalize(value)
+	}
+	changes = c.filterColumns(changes)
+	if touch && sch != nil && sch.updatedAt != nil {
+		if _, ok := changes[sch.updatedAt.column]; !ok {
+			changes[sch.updatedAt.column] = c.nowFunc()
+		}
+	}
+
+	err = c.access(true, func(ts tables) error {
+		t, ok := ts[name]
+		if !ok {
+			return nil
+		}
+		for _, r := range t.rows {
+			ok, err := c.visible(r, sch)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			c.rowsAffected++
+			if c.dryRun {
+				continue
+			}
+			for column, value := range changes {
+				r[column] = value
+			}
+			t.addRowColumns(r)
+		}
+		return nil
+	})
+	if err != nil {
+		c.AddError(err)
+		return c
+	}
+
+	if sch != nil && reflect.ValueOf(c.stmt.model).Kind() == reflect.Ptr {
+		if rv := reflect.ValueOf(c.stmt.model).Elem(); rv.Kind() == reflect.Struct {
+			c.AddErrorIf(sch.fill(rv, changes, nil))
+		}
+	}
+	return c
+}
+
+// scopeToModel 把模型的主键加入条件，value 为结构体切片时使用 IN
+func (db *DB) scopeToModel(sch *schema, value interface{}) error {
+	if sch == nil || sch.primaryKey == nil || value == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		pk := rv.FieldByIndex(sch.primaryKey.index)
+		if !pk.IsZero() {
+			db.stmt.conditions = append(db.stmt.conditions, condition{column: sch.primaryKey.column, operator: "=", value: pk.Interface()})
+		}
+	case reflect.Slice, reflect.Array:
+		var ids []interface{}
+		for i := 0; i < rv.Len(); i++ {
+			record := rv.Index(i)
+			for record.Kind() == reflect.Ptr {
+				record = record.Elem()
+			}
+			if pk := record.FieldByIndex(sch.primaryKey.index); !pk.IsZero() {
+				ids = append(ids, pk.Interface())
+			}
+		}
+		if len(ids) > 0 {
+			db.stmt.conditions = append(db.stmt.conditions, condition{column: sch.primaryKey.column, operator: "in", value: ids})
+		}
+	}
+	return nil
+}
+
+// Delete 删除记录，带 DeletedAt 字段的模型执行软删除
+func (db *DB) Delete(value interface{}, conds ...interface{}) database.DB {
+	c := db.clone()
+	sch := parseSchema(value)
+	if sch == nil {
+		sch = parseSchema(c.stmt.model)
+	}
+	if err := c.scopeToModel(sch, value); err != nil {
+		c.AddError(err)
+		return c
+	}
+	if len(conds) > 0 {
+		conditions, err := c.buildConditions(conds[0], conds[1:])
+		if err != nil {
+			c.AddError(err)
+			return c
+		}
+		c.stmt.conditions = append(c.stmt.conditions, conditions...)
+	}
+	if len(c.stmt.conditions) == 0 && !c.allowGlobal {
+		c.AddError(ErrMissingWhereClause)
+		return c
+	}
+	name, err := c.tableFor(sch)
+	if err != nil {
+		c.AddError(err)
+		return c
+	}
+
+	soft := sch != nil && sch.softDelete != nil && !c.stmt.unscoped
+	now := c.nowFunc()
+	err = c.access(true, func(ts tables) error {
+		t, ok := ts[name]
+		if !ok {
+			return nil
+		}
+		kept := t.rows[:0:0]
+		for _, r := range t.rows {
+			ok, err := c.visible(r, sch)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				kept = append(kept, r)
+				continue
+			}
+			c.rowsAffected++
+			switch {
+			case c.dryRun:
+				kept = append(kept, r)
+			case soft:
+				r[sch.softDelete.column] = now
+				kept = append(kept, r)
+			}
+		}
+		t.rows = kept
+		return nil
+	})
+	if err != nil {
+		c.AddError(err)
+	}
+	return c
+}
+
+// Unscoped 包含软删除的记录，Delete 时执行物理删除
+func (db *DB) Unscoped() database.DB {
+	c := db.clone()
+	c.stmt.unscoped = true
+	return c
+}
+
+// Count 统计记录数量，忽略排序和分页
+//
+// 设置了 Distinct 时统计所选列的不同值数量。
+func (db *DB) Count(count *int64) database.DB {
+	c := db.clone()
+	counting := c.clone()
+	counting.stmt.limit, counting.stmt.offset, counting.stmt.orders = -1, -1, nil
+	rows, _, err := counting.query(nil)
+	if err != nil {
+		c.AddError(err)
+		return c
+	}
+	if c.stmt.distinct && len(c.stmt.selects) > 0 {
+		rows = distinctRows(rows, c.stmt.selects)
+	}
+	*count = int64(len(rows))
+	return c
+}
+
+// distinctRows 按列去重
+func distinctRows(rows []row, columns []string) []row {
+	var unique []row
+	for _, r := range rows {
+		duplicate := false
+		for _, u := range unique {
+			same := true
+			for _, column := range columns {
+				if !equal(r[column], u[column]) && !(normalize(r[column]) == nil && normalize(u[column]) == nil) {
+					same = false
+					break
+				}
+			}
+			if same {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			unique = append(unique, r)
+		}
+	}
+	return unique
+}
+
+// Distinct 去重，参数为去重的列
+func (db *DB) Distinct(args ...interface{}) database.DB {
+	c := db.clone()
+	c.stmt.distinct = true
+	if len(args) > 0 {
+		c.stmt.selects = append(c.stmt.selects, columnList(args[0], args[1:])...)
+	}
+	return c
+}
+
+// Group 内存实现不支持分组
+func (db *DB) Group(name string) database.DB {
+	return db.unsupported("Group")
+}
+
+// Having 内存实现不支持分组条件
+func (db *DB) Having(query interface{}, args ...interface{}) database.DB {
+	return db.unsupported("Having")
+}
+
+// Joins 内存实现不支持连接查询
+func (db *DB) Joins(query string, args ...interface{}) database.DB {
+	return db.unsupported("Joins")
+}
+
+// Preload 内存实现不加载关联，调用被忽略
+func (db *DB) Preload(query string, args ...interface{}) database.DB {
+	return db.clone()
+}
+
+// Limit 限制数量，-1 表示不限制
+func (db *DB) Limit(limit int) database.DB {
+	c := db.clone()
+	c.stmt.limit = limit
+	return c
+}
+
+// Offset 跳过数量，-1 表示不跳过
+func (db *DB) Offset(offset int) database.DB {
+	c := db.clone()
+	c.stmt.offset = offset
+	return c
+}
+
+// Order 排序，支持 "age desc, name" 形式的字符串
+func (db *DB) Order(value interface{}) database.DB {
+	c := db.clone()
+	s, ok := value.(string)
+	if !ok {
+		c.AddError(fmt.Errorf("%w: order of type %T", ErrUnsupported, value))
+		return c
+	}
+	c.stmt.orders = append(c.stmt.orders, parseOrder(s)...)
+	return c
+}
+
+// Raw 内存实现不支持原生 SQL
+func (db *DB) Raw(sql string, values ...interface{}) database.DB {
+	return db.unsupported("Raw")
+}
+
+// Exec 内存实现不支持原生 SQL
+func (db *DB) Exec(sql string, values ...interface{}) database.DB {
+	return db.unsupported("Exec")
+}
+
+// Row 内存实现没有 *sql.Row，返回 nil
+func (db *DB) Row() *sql.Row {
+	return nil
+}
+
+// Rows 内存实现没有 *sql.Rows
+func (db *DB) Rows() (*sql.Rows, error) {
+	return nil, fmt.Errorf("%w: Rows", ErrUnsupported)
+}
+
+// Scan 把查询结果写入 dest，等同于 Find
+func (db *DB) Scan(dest interface{}) database.DB {
+	return db.finish(dest, nil, nil, -1, false)
+}
+
+// ScanRows 内存实现没有 *sql.Rows
+func (db *DB) ScanRows(rows *sql.Rows, dest interface{}) error {
+	return fmt.Errorf("%w: ScanRows", ErrUnsupported)
+}
+
+// Pluck 查询单列的值到切片
+func (db *DB) Pluck(column string, dest interface{}) database.DB {
+	c := db.clone()
+	column = columnName(column)
+	rows, _, err := c.query(nil)
+	if err != nil {
+		c.AddError(err)
+		return c
+	}
+	if c.stmt.distinct {
+		rows = distinctRows(rows, []string{column})
+	}
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		c.AddError(fmt.Errorf("memdb: pluck destination must be a pointer to slice, got %T", dest))
+		return c
+	}
+	slice := reflect.MakeSlice(rv.Elem().Type(), len(rows), len(rows))
+	for i, r := range rows {
+		if err := assign(slice.Index(i), r[column]); err != nil {
+			c.AddError(err)
+			return c
+		}
+	}
+	rv.Elem().Set(slice)
+	c.rowsAffected = int64(len(rows))
+	return c
+}
+
+// Begin 开始事务
+//
+// 在事务会话上调用 Begin 会开始嵌套事务，提交时写入外层事务。
+func (db *DB) Begin(opts ...*sql.TxOptions) database.DB {
+	c := db.clone()
+	var snapshot tables
+	if err := db.access(false, func(ts tables) error {
+		snapshot = ts.clone()
+		return nil
+	}); err != nil {
+		c.AddError(err)
+		return c
+	}
+	c.tx = &txState{parent: db.tx, tables: snapshot, savepoints: make(map[string]tables)}
+	return c
+}
+
+// Commit 提交事务
+func (db *DB) Commit() database.DB {
+	c := db.clone()
+	if c.tx == nil {
+		c.AddError(ErrTxDone)
+		return c
+	}
+	c.tx.mu.Lock()
+	defer c.tx.mu.Unlock()
+	if c.tx.done {
+		c.AddError(ErrTxDone)
+		return c
+	}
+	c.tx.done = true
+
+	if parent := c.tx.parent; parent != nil {
+		parent.mu.Lock()
+		defer parent.mu.Unlock()
+		if parent.done {
+			c.AddError(ErrTxDone)
+			return c
+		}
+		parent.tables = c.tx.tables
+		return c
+	}
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	c.store.tables = c.tx.tables
+	return c
+}
+
+// Rollback 回滚事务
+func (db *DB) Rollback() database.DB {
+	c := db.clone()
+	if c.tx == nil {
+		c.AddError(ErrTxDone)
+		return c
+	}
+	c.tx.mu.Lock()
+	defer c.tx.mu.Unlock()
+	if c.tx.done {
+		c.AddError(ErrTxDone)
+		return c
+	}
+	c.tx.done = true
+	c.tx.tables = nil
+	return c
+}
+
+// SavePoint 创建保存点
+func (db *DB) SavePoint(name string) database.DB {
+	c := db.clone()
+	if c.tx == nil {
+		c.AddError(ErrTxDone)
+		return c
+	}
+	c.AddErrorIf(c.access(false, func(ts tables) error {
+		c.tx.savepoints[name] = ts.clone()
+		return nil
+	}))
+	return c
+}
+
+// RollbackTo 回滚到保存点
+func (db *DB) RollbackTo(name string) database.DB {
+	c := db.clone()
+	if c.tx == nil {
+		c.AddError(ErrTxDone)
+		return c
+	}
+	c.tx.mu.Lock()
+	defer c.tx.mu.Unlock()
+	snapshot, ok := c.tx.savepoints[name]
+	if !ok {
+		c.AddError(fmt.Errorf("memdb: savepoint %q does not exist", name))
+		return c
+	}
+	c.tx.tables = snapshot.clone()
+	return c
+}
+
+// Transaction 在事务中执行回调，返回错误或 panic 时回滚
+func (db *DB) Transaction(fc func(tx database.DB) error, opts ...*sql.TxOptions) (err error) {
+	tx := db.Begin(opts...)
+	if err := tx.Error(); err != nil {
+		return err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+	if err := fc(tx); err != nil {
+		return err
+	}
+	committed = true
+	return tx.Commit().Error()
+}
+
+// Association 内存实现不支持关联操作
+func (db *DB) Association(column string) database.Association {
+	return unsupportedAssociation{}
+}
+
+// AutoMigrate 创建模型对应的表
+func (db *DB) AutoMigrate(dst ...interface{}) error {
+	return db.Migrator().AutoMigrate(dst...)
+}
+
+// Migrator 获取迁移器
+func (db *DB) Migrator() database.Migrator {
+	return &Migrator{db: db}
+}
+
+// Scopes 依次应用作用域函数
+func (db *DB) Scopes(funcs ...func(database.DB) database.DB) database.DB {
+	var current database.DB = db.clone()
+	for _, fn := range funcs {
+		current = fn(current)
+	}
+	return current
+}
+
+// Attrs 设置 FirstOrInit/FirstOrCreate 未找到记录时使用的属性
+func (db *DB) Attrs(attrs ...interface{}) database.DB {
+	c := db.clone()
+	c.stmt.attrs = append(c.stmt.attrs, attrs...)
+	return c
+}
+
+// Assign 设置 FirstOrInit/FirstOrCreate 无论是否找到记录都使用的属性
+func (db *DB) Assign(attrs ...interface{}) database.DB {
+	c := db.clone()
+	c.stmt.assigns = append(c.stmt.assigns, attrs...)
+	return c
+}
+
+// Set 设置会话值
+func (db *DB) Set(key string, value interface{}) database.DB {
+	c := db.clone()
+	c.settings[key] = value
+	return c
+}
+
+// Get 获取会话值
+func (db *DB) Get(key string) (interface{}, bool) {
+	value, ok := db.settings[key]
+	return value, ok
+}
+
+// InstanceSet 设置当前语句的值
+func (db *DB) InstanceSet(key string, value interface{}) database.DB {
+	c := db.clone()
+	c.stmt.instance[key] = value
+	return c
+}
+
+// InstanceGet 获取当前语句的值
+func (db *DB) InstanceGet(key string) (interface{}, bool) {
+	value, ok := db.stmt.instance[key]
+	return value, ok
+}
+
+// AddError 记录错误
+func (db *DB) AddError(err error) error {
+	if err != nil {
+		db.errs = append(db.errs, err)
+	}
+	return err
+}
+
+// AddErrorIf 错误不为 nil 时记录
+func (db *DB) AddErrorIf(err error) {
+	if err != nil {
+		db.AddError(err)
+	}
+}
+
+// GetErrors 获取所有错误
+func (db *DB) GetErrors() []error {
+	return append([]error(nil), db.errs...)
+}
+
+// Error 获取错误，多个错误时合并
+func (db *DB) Error() error {
+	switch len(db.errs) {
+	case 0:
+		return nil
+	case 1:
+		return db.errs[0]
+	}
+	return errors.Join(db.errs...)
+}
+
+// RowsAffected 获取受影响的行数
+func (db *DB) RowsAffected() int64 {
+	return db.rowsAffected
+}
+
+// SqlDB 内存实现没有 *sql.DB
+func (db *DB) SqlDB() (*sql.DB, error) {
+	return nil, fmt.Errorf("%w: SqlDB", ErrUnsupported)
+}
+
+// Close 内存实现无需关闭
+func (db *DB) Close() error {
+	return nil
+}
+
+// unsupported 返回记录了 ErrUnsupported 的会话
+func (db *DB) unsupported(method string) *DB {
+	c := db.clone()
+	c.AddError(fmt.Errorf("%w: %s", ErrUnsupported, method))
+	return c
+}
+
+// unsupportedAssociation 不支持的关联操作
+type unsupportedAssociation struct{}
+
+func (unsupportedAssociation) Find(out interface{}, conds ...interface{}) error {
+	return ErrUnsupported
+}
+func (unsupportedAssociation) Append(values ...interface{}) error  { return ErrUnsupported }
+func (unsupportedAssociation) Replace(values ...interface{}) error { return ErrUnsupported }
+func (unsupportedAssociation) Delete(values ...interface{}) error  { return ErrUnsupported }
+func (unsupportedAssociation) Clear() error                        { return ErrUnsupported }
+func (unsupportedAssociation) Count() int64                        { return 0 }
+
+var _ database.DB = (*DB)(nil)
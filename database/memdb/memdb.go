@@ -0,0 +1,200 @@
+// Package memdb 提供 database.DB 和 database.QueryBuilder 的内存参考实现
+//
+// memdb 把表存储在内存中，支持基础的条件、排序、分页、软删除和事务语义，
+// 让仓库层的单元测试不需要 SQLite 或 Docker。它不是 SQL 引擎：
+// 字符串条件只支持由 AND 连接的简单比较（=、<>、>、<、LIKE、IN ? 或 IN (?, ?)、IS NULL、BETWEEN），
+// OR 和其他条件返回错误，
+// Joins、Group、Having、Raw、Exec 和关联操作会返回 ErrUnsupported，Preload 被忽略。
+//
+// 包结构：
+// - memdb.go - Store 内存存储和表数据
+// - db.go - database.DB 实现
+// - query_builder.go - database.QueryBuilder 实现
+// - migrator.go - database.Migrator 实现
+// - schema.go - 模型结构体和列的映射
+// - condition.go - 条件求值、比较和排序
+//
+// 使用示例：
+//
+//	func TestUserRepository(t *testing.T) {
+//		db := memdb.New()
+//		db.AutoMigrate(&User{})
+//
+//		repo := NewUserRepository(db)
+//		repo.Create(&User{Name: "John", Age: 30})
+//
+//		var users []User
+//		db.Where("age > ?", 18).Order("name").Limit(10).Find(&users)
+//
+//		// 以表为中心的查询构建器共享同一份数据
+//		count, _ := db.Query().Table("users").Where("name", "=", "John").Count()
+//	}
+package memdb
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// 错误定义
+var (
+	// ErrUnsupported 内存实现不支持的操作
+	ErrUnsupported = errors.New("memdb: operation not supported by in-memory database")
+
+	// ErrRecordNotFound 记录不存在，与 database.ErrRecordNotFound 相同
+	ErrRecordNotFound = database.ErrRecordNotFound
+
+	// ErrMissingWhereClause 更新或删除时缺少条件
+	ErrMissingWhereClause = errors.New("WHERE conditions required")
+
+	// ErrDuplicateKey 主键重复
+	ErrDuplicateKey = errors.New("memdb: duplicated primary key")
+
+	// ErrTxDone 事务已经提交或回滚
+	ErrTxDone = errors.New("memdb: transaction has already been committed or rolled back")
+)
+
+// table 内存表
+type table struct {
+	name    string
+	columns []string
+	rows    []row
+	nextID  int64
+	indexes map[string]bool
+}
+
+// newTable 创建内存表
+func newTable(name string) *table {
+	return &table{name: name, nextID: 1, indexes: make(map[string]bool)}
+}
+
+// clone 深复制表数据
+func (t *table) clone() *table {
+	c := &table{
+		name:    t.name,
+		columns: append([]string(nil), t.columns...),
+		rows:    make([]row, len(t.rows)),
+		nextID:  t.nextID,
+		indexes: make(map[string]bool, len(t.indexes)),
+	}
+	for i, r := range t.rows {
+		c.rows[i] = r.clone()
+	}
+	for k, v := range t.indexes {
+		c.indexes[k] = v
+	}
+	return c
+}
+
+// addColumns 记录新出现的列
+func (t *table) addColumns(columns ...string) {
+	for _, column := range columns {
+		found := false
+		for _, existing := range t.columns {
+			if existing == column {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.columns = append(t.columns, column)
+		}
+	}
+}
+
+// addRowColumns 记录行中新出现的列，按列名排序保证稳定
+func (t *table) addRowColumns(r row) {
+	columns := make([]string, 0, len(r))
+	for column := range r {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	t.addColumns(columns...)
+}
+
+// tables 一组表
+type tables map[string]*table
+
+// clone 深复制所有表
+func (ts tables) clone() tables {
+	c := make(tables, len(ts))
+	for name, t := range ts {
+		c[name] = t.clone()
+	}
+	return c
+}
+
+// Store 内存存储
+//
+// Store 保存所有表数据，可以被多个 DB 会话共享。
+type Store struct {
+	mu     sync.RWMutex
+	tables tables
+}
+
+// NewStore 创建空的内存存储
+func NewStore() *Store {
+	return &Store{tables: make(tables)}
+}
+
+// Reset 清空所有表
+//
+// 示例：
+//
+//	t.Cleanup(store.Reset)
+func (s *Store) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables = make(tables)
+}
+
+// Tables 获取所有表名
+func (s *Store) Tables() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// txState 事务状态
+//
+// 事务开始时复制父级（Store 或外层事务）的全部表数据，
+// 提交时用快照整体替换父级数据，回滚时直接丢弃。
+// 这种快照隔离适用于单元测试中的串行场景。
+type txState struct {
+	mu         sync.Mutex
+	parent     *txState
+	tables     tables
+	savepoints map[string]tables
+	done       bool
+}
+
+// access 在持有锁的情况下访问表数据
+func (db *DB) access(write bool, fn func(ts tables) error) error {
+	if err := db.ctx.Err(); err != nil {
+		return err
+	}
+	if db.tx != nil {
+		db.tx.mu.Lock()
+		defer db.tx.mu.Unlock()
+		if db.tx.done {
+			return ErrTxDone
+		}
+		return fn(db.tx.tables)
+	}
+	if write {
+		db.store.mu.Lock()
+		defer db.store.mu.Unlock()
+	} else {
+		db.store.mu.RLock()
+		defer db.store.mu.RUnlock()
+	}
+	return fn(db.store.tables)
+}
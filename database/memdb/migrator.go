@@ -0,0 +1,288 @@
+package memdb
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// Migrator database.Migrator 的内存实现
+//
+// 表和列只记录名称，不校验类型；索引和约束只记录名称。
+// ColumnTypes 和视图操作返回 ErrUnsupported。
+type Migrator struct {
+	db *DB
+}
+
+// AutoMigrate 创建模型对应的表并记录列
+func (m *Migrator) AutoMigrate(dst ...interface{}) error {
+	return m.CreateTable(dst...)
+}
+
+// CurrentDatabase 返回 "memory"
+func (m *Migrator) CurrentDatabase() string {
+	return "memory"
+}
+
+// CreateTable 创建表，已存在时补充缺少的列
+func (m *Migrator) CreateTable(dst ...interface{}) error {
+	return m.db.access(true, func(ts tables) error {
+		for _, value := range dst {
+			name, sch, err := m.resolve(value)
+			if err != nil {
+				return err
+			}
+			t := ensureTable(ts, name)
+			if sch != nil {
+				for _, f := range sch.fields {
+					t.addColumns(f.column)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// DropTable 删除表
+func (m *Migrator) DropTable(dst ...interface{}) error {
+	return m.db.access(true, func(ts tables) error {
+		for _, value := range dst {
+			name, _, err := m.resolve(value)
+			if err != nil {
+				return err
+			}
+			delete(ts, name)
+		}
+		return nil
+	})
+}
+
+// HasTable 表是否存在
+func (m *Migrator) HasTable(dst interface{}) bool {
+	found := false
+	m.withTable(dst, func(t *table) error {
+		found = true
+		return nil
+	})
+	return found
+}
+
+// RenameTable 重命名表
+func (m *Migrator) RenameTable(oldName, newName interface{}) error {
+	return m.db.access(true, func(ts tables) error {
+		from, _, err := m.resolve(oldName)
+		if err != nil {
+			return err
+		}
+		to, _, err := m.resolve(newName)
+		if err != nil {
+			return err
+		}
+		t, ok := ts[from]
+		if !ok {
+			return fmt.Errorf("memdb: table %s does not exist", from)
+		}
+		delete(ts, from)
+		t.name = to
+		ts[to] = t
+		return nil
+	})
+}
+
+// GetTables 获取所有表名
+func (m *Migrator) GetTables() ([]string, error) {
+	var names []string
+	err := m.db.access(false, func(ts tables) error {
+		for name := range ts {
+			names = append(names, name)
+		}
+		return nil
+	})
+	sort.Strings(names)
+	return names, err
+}
+
+// AddColumn 添加列，field 可以是字段名或列名
+func (m *Migrator) AddColumn(dst interface{}, field string) error {
+	return m.withTable(dst, func(t *table) error {
+		t.addColumns(m.column(dst, field))
+		return nil
+	})
+}
+
+// DropColumn 删除列及其数据
+func (m *Migrator) DropColumn(dst interface{}, field string) error {
+	column := m.column(dst, field)
+	return m.withTable(dst, func(t *table) error {
+		columns := t.columns[:0:0]
+		for _, existing := range t.columns {
+			if existing != column {
+				columns = append(columns, existing)
+			}
+		}
+		t.columns = columns
+		for _, r := range t.rows {
+			delete(r, column)
+		}
+		return nil
+	})
+}
+
+// AlterColumn 内存实现不记录列类型，无需修改
+func (m *Migrator) AlterColumn(dst interface{}, field string) error {
+	return m.withTable(dst, func(t *table) error { return nil })
+}
+
+// HasColumn 列是否存在
+func (m *Migrator) HasColumn(dst interface{}, field string) bool {
+	column := m.column(dst, field)
+	found := false
+	m.withTable(dst, func(t *table) error {
+		for _, existing := range t.columns {
+			if existing == column {
+				found = true
+			}
+		}
+		return nil
+	})
+	return found
+}
+
+// RenameColumn 重命名列及其数据
+func (m *Migrator) RenameColumn(dst interface{}, oldName, field string) error {
+	from, to := m.column(dst, oldName), m.column(dst, field)
+	return m.withTable(dst, func(t *table) error {
+		for i, existing := range t.columns {
+			if existing == from {
+				t.columns[i] = to
+			}
+		}
+		for _, r := range t.rows {
+			if value, ok := r[from]; ok {
+				r[to] = value
+				delete(r, from)
+			}
+		}
+		return nil
+	})
+}
+
+// ColumnTypes 内存实现不记录列类型
+func (m *Migrator) ColumnTypes(dst interface{}) ([]database.ColumnType, error) {
+	return nil, fmt.Errorf("%w: ColumnTypes", ErrUnsupported)
+}
+
+// CreateView 内存实现不支持视图
+func (m *Migrator) CreateView(name string, option database.ViewOption) error {
+	return fmt.Errorf("%w: CreateView", ErrUnsupported)
+}
+
+// DropView 内存实现不支持视图
+func (m *Migrator) DropView(name string) error {
+	return fmt.Errorf("%w: DropView", ErrUnsupported)
+}
+
+// CreateConstraint 记录约束名称
+func (m *Migrator) CreateConstraint(dst interface{}, name string) error {
+	return m.setIndex(dst, "constraint:"+name, true)
+}
+
+// DropConstraint 移除约束名称
+func (m *Migrator) DropConstraint(dst interface{}, name string) error {
+	return m.setIndex(dst, "constraint:"+name, false)
+}
+
+// HasConstraint 约束是否存在
+func (m *Migrator) HasConstraint(dst interface{}, name string) bool {
+	return m.hasIndex(dst, "constraint:"+name)
+}
+
+// CreateIndex 记录索引名称
+func (m *Migrator) CreateIndex(dst interface{}, name string) error {
+	return m.setIndex(dst, "index:"+name, true)
+}
+
+// DropIndex 移除索引名称
+func (m *Migrator) DropIndex(dst interface{}, name string) error {
+	return m.setIndex(dst, "index:"+name, false)
+}
+
+// HasIndex 索引是否存在
+func (m *Migrator) HasIndex(dst interface{}, name string) bool {
+	return m.hasIndex(dst, "index:"+name)
+}
+
+// RenameIndex 重命名索引
+func (m *Migrator) RenameIndex(dst interface{}, oldName, newName string) error {
+	return m.withTable(dst, func(t *table) error {
+		if !t.indexes["index:"+oldName] {
+			return fmt.Errorf("memdb: index %s does not exist", oldName)
+		}
+		delete(t.indexes, "index:"+oldName)
+		t.indexes["index:"+newName] = true
+		return nil
+	})
+}
+
+func (m *Migrator) setIndex(dst interface{}, key string, exists bool) error {
+	return m.withTable(dst, func(t *table) error {
+		if exists {
+			t.indexes[key] = true
+		} else {
+			delete(t.indexes, key)
+		}
+		return nil
+	})
+}
+
+func (m *Migrator) hasIndex(dst interface{}, key string) bool {
+	found := false
+	m.withTable(dst, func(t *table) error {
+		found = t.indexes[key]
+		return nil
+	})
+	return found
+}
+
+// resolve 解析表名，dst 可以是表名字符串或模型
+func (m *Migrator) resolve(dst interface{}) (string, *schema, error) {
+	if name, ok := dst.(string); ok {
+		return name, nil, nil
+	}
+	sch := parseSchema(dst)
+	if sch == nil {
+		return "", nil, fmt.Errorf("%w: migrate value of type %s", ErrUnsupported, reflect.TypeOf(dst))
+	}
+	return sch.table, sch, nil
+}
+
+// column 把字段名转换为列名
+func (m *Migrator) column(dst interface{}, field string) string {
+	if sch := parseSchema(dst); sch != nil {
+		for _, f := range sch.fields {
+			if f.name == field {
+				return f.column
+			}
+		}
+	}
+	return columnName(field)
+}
+
+// withTable 在持有写锁的情况下访问已存在的表
+func (m *Migrator) withTable(dst interface{}, fn func(t *table) error) error {
+	name, _, err := m.resolve(dst)
+	if err != nil {
+		return err
+	}
+	return m.db.access(true, func(ts tables) error {
+		t, ok := ts[name]
+		if !ok {
+			return fmt.Errorf("memdb: table %s does not exist", name)
+		}
+		return fn(t)
+	})
+}
+
+var _ database.Migrator = (*Migrator)(nil)
@@ -0,0 +1,585 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// QueryBuilder database.QueryBuilder 的内存实现
+//
+// QueryBuilder 与创建它的 DB 共享存储和事务，以表为中心操作 map 形式的行，
+// 不处理软删除。与 database.DB 不同，链式方法直接修改并返回当前构建器。
+//
+// 使用示例：
+//
+//	qb := db.Query()
+//	id, _ := qb.Table("users").InsertGetID(map[string]interface{}{"name": "John", "age": 30})
+//
+//	users, err := db.Query().Table("users").
+//		Where("age", ">=", 18).
+//		OrderBy("name", "asc").
+//		Limit(10).
+//		Get()
+type QueryBuilder struct {
+	db         *DB
+	table      string
+	columns    []string
+	distinct   bool
+	conditions []condition
+	orders     []order
+	limit      int
+	offset     int
+}
+
+// Table 设置查询的表
+func (q *QueryBuilder) Table(name string) database.QueryBuilder {
+	q.table = name
+	return q
+}
+
+// WithContext 设置上下文
+func (q *QueryBuilder) WithContext(ctx context.Context) database.QueryBuilder {
+	q.db = q.db.WithContext(ctx).(*DB)
+	return q
+}
+
+// Select 设置查询列
+func (q *QueryBuilder) Select(columns ...string) database.QueryBuilder {
+	for _, column := range columns {
+		q.columns = append(q.columns, columnList(column, nil)...)
+	}
+	return q
+}
+
+// Distinct 去重
+func (q *QueryBuilder) Distinct() database.QueryBuilder {
+	q.distinct = true
+	return q
+}
+
+// Where 添加 AND 条件
+func (q *QueryBuilder) Where(column string, operator string, value interface{}) database.QueryBuilder {
+	return q.where(false, column, operator, value)
+}
+
+// OrWhere 添加 OR 条件
+func (q *QueryBuilder) OrWhere(column string, operator string, value interface{}) database.QueryBuilder {
+	return q.where(true, column, operator, value)
+}
+
+func (q *QueryBuilder) where(or bool, column string, operator string, value interface{}) *QueryBuilder {
+	q.conditions = append(q.conditions, condition{
+		or:       or,
+		column:   columnName(column),
+		operator: strings.Join(strings.Fields(strings.ToLower(operator)), " "),
+		value:    value,
+	})
+	return q
+}
+
+// WhereIn 添加 IN 条件
+func (q *QueryBuilder) WhereIn(column string, values []interface{}) database.QueryBuilder {
+	return q.where(false, column, "in", values)
+}
+
+// WhereNotIn 添加 NOT IN 条件
+func (q *QueryBuilder) WhereNotIn(column string, values []interface{}) database.QueryBuilder {
+	return q.where(false, column, "not in", values)
+}
+
+// WhereNull 添加 IS NULL 条件
+func (q *QueryBuilder) WhereNull(column string) database.QueryBuilder {
+	return q.where(false, column, "is null", nil)
+}
+
+// WhereNotNull 添加 IS NOT NULL 条件
+func (q *QueryBuilder) WhereNotNull(column string) database.QueryBuilder {
+	return q.where(false, column, "is not null", nil)
+}
+
+// WhereBetween 添加 BETWEEN 条件
+func (q *QueryBuilder) WhereBetween(column string, from interface{}, to interface{}) database.QueryBuilder {
+	return q.where(false, column, "between", []interface{}{from, to})
+}
+
+// WhereRaw 添加原生条件，只支持 parseClause 能解析的简单条件
+func (q *QueryBuilder) WhereRaw(sql string, bindings ...interface{}) database.QueryBuilder {
+	conditions, err := parseClause(sql, bindings)
+	if err != nil {
+		q.db.AddError(err)
+		return q
+	}
+	q.conditions = append(q.conditions, conditions...)
+	return q
+}
+
+// Join 内存实现不支持连接查询
+func (q *QueryBuilder) Join(table string, first string, operator string, second string) database.QueryBuilder {
+	q.db.AddError(fmt.Errorf("%w: Join", ErrUnsupported))
+	return q
+}
+
+// LeftJoin 内存实现不支持连接查询
+func (q *QueryBuilder) LeftJoin(table string, first string, operator string, second string) database.QueryBuilder {
+	q.db.AddError(fmt.Errorf("%w: LeftJoin", ErrUnsupported))
+	return q
+}
+
+// GroupBy 内存实现不支持分组
+func (q *QueryBuilder) GroupBy(columns ...string) database.QueryBuilder {
+	q.db.AddError(fmt.Errorf("%w: GroupBy", ErrUnsupported))
+	return q
+}
+
+// Having 内存实现不支持分组条件
+func (q *QueryBuilder) Having(column string, operator string, value interface{}) database.QueryBuilder {
+	q.db.AddError(fmt.Errorf("%w: Having", ErrUnsupported))
+	return q
+}
+
+// OrderBy 排序
+func (q *QueryBuilder) OrderBy(column string, direction string) database.QueryBuilder {
+	q.orders = append(q.orders, order{column: columnName(column), desc: strings.EqualFold(direction, "desc")})
+	return q
+}
+
+// Latest 按时间列倒序，默认 created_at
+func (q *QueryBuilder) Latest(column ...string) database.QueryBuilder {
+	name := "created_at"
+	if len(column) > 0 {
+		name = column[0]
+	}
+	return q.OrderBy(name, "desc")
+}
+
+// Limit 限制数量
+func (q *QueryBuilder) Limit(limit int) database.QueryBuilder {
+	q.limit = limit
+	return q
+}
+
+// Offset 跳过数量
+func (q *QueryBuilder) Offset(offset int) database.QueryBuilder {
+	q.offset = offset
+	return q
+}
+
+// rows 获取满足条件的行副本，已排序和分页
+func (q *QueryBuilder) rows() ([]row, error) {
+	if err := q.db.Error(); err != nil {
+		return nil, err
+	}
+	if q.table == "" {
+		return nil, fmt.Errorf("memdb: table not set")
+	}
+	var rows []row
+	err := q.db.access(false, func(ts tables) error {
+		t, ok := ts[q.table]
+		if !ok {
+			return nil
+		}
+		for _, r := range t.rows {
+			ok, err := matches(r, q.conditions)
+			if err != nil {
+				return err
+			}
+			if ok {
+				rows = append(rows, r.clone())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortRows(rows, q.orders)
+	return paginate(rows, q.offset, q.limit), nil
+}
+
+// Get 获取所有结果
+func (q *QueryBuilder) Get() ([]map[string]interface{}, error) {
+	rows, err := q.rows()
+	if err != nil {
+		return nil, err
+	}
+	if len(q.columns) > 0 {
+		for i, r := range rows {
+			selected := make(row, len(q.columns))
+			for _, column := range q.columns {
+				selected[column] = r[column]
+			}
+			rows[i] = selected
+		}
+	}
+	if q.distinct && len(q.columns) > 0 {
+		rows = distinctRows(rows, q.columns)
+	}
+	results := make([]map[string]interface{}, len(rows))
+	for i, r := range rows {
+		results[i] = r
+	}
+	return results, nil
+}
+
+// First 获取第一条结果
+func (q *QueryBuilder) First() (map[string]interface{}, error) {
+	q.limit = 1
+	results, err := q.Get()
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrRecordNotFound
+	}
+	return results[0], nil
+}
+
+// Find 根据主键 id 获取结果
+func (q *QueryBuilder) Find(id interface{}) (map[string]interface{}, error) {
+	return q.where(false, "id", "=", id).First()
+}
+
+// Value 获取第一条结果的单个列值
+func (q *QueryBuilder) Value(column string) (interface{}, error) {
+	result, err := q.First()
+	if err != nil {
+		return nil, err
+	}
+	return result[columnName(column)], nil
+}
+
+// Pluck 获取单列的值列表
+func (q *QueryBuilder) Pluck(column string) ([]interface{}, error) {
+	rows, err := q.rows()
+	if err != nil {
+		return nil, err
+	}
+	column = columnName(column)
+	if q.distinct {
+		rows = distinctRows(rows, []string{column})
+	}
+	values := make([]interface{}, len(rows))
+	for i, r := range rows {
+		values[i] = r[column]
+	}
+	return values, nil
+}
+
+// Count 统计数量
+func (q *QueryBuilder) Count() (int64, error) {
+	rows, err := q.rows()
+	if err != nil {
+		return 0, err
+	}
+	if q.distinct && len(q.columns) > 0 {
+		rows = distinctRows(rows, q.columns)
+	}
+	return int64(len(rows)), nil
+}
+
+// Exists 是否存在结果
+func (q *QueryBuilder) Exists() (bool, error) {
+	count, err := q.Count()
+	return count > 0, err
+}
+
+// Max 最大值
+func (q *QueryBuilder) Max(column string) (interface{}, error) {
+	return q.extreme(column, 1)
+}
+
+// Min 最小值
+func (q *QueryBuilder) Min(column string) (interface{}, error) {
+	return q.extreme(column, -1)
+}
+
+func (q *QueryBuilder) extreme(column string, sign int) (interface{}, error) {
+	values, err := q.Pluck(column)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	for _, value := range values {
+		if normalize(value) == nil {
+			continue
+		}
+		if result == nil {
+			result = value
+			continue
+		}
+		if cmp, ok := compare(value, result); ok && cmp*sign > 0 {
+			result = value
+		}
+	}
+	return result, nil
+}
+
+// Sum 求和
+func (q *QueryBuilder) Sum(column string) (float64, error) {
+	values, err := q.Pluck(column)
+	if err != nil {
+		return 0, err
+	}
+	var sum float64
+	for _, value := range values {
+		if n, ok := toFloat(normalize(value)); ok {
+			sum += n
+		}
+	}
+	return sum, nil
+}
+
+// Avg 平均值，忽略 NULL
+func (q *QueryBuilder) Avg(column string) (float64, error) {
+	values, err := q.Pluck(column)
+	if err != nil {
+		return 0, err
+	}
+	var sum float64
+	var count int
+	for _, value := range values {
+		if n, ok := toFloat(normalize(value)); ok {
+			sum += n
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	return sum / float64(count), nil
+}
+
+// Insert 插入记录
+func (q *QueryBuilder) Insert(values ...map[string]interface{}) error {
+	_, err := q.insert(values...)
+	return err
+}
+
+// InsertGetID 插入记录并返回自增主键
+func (q *QueryBuilder) InsertGetID(values map[string]interface{}) (int64, error) {
+	return q.insert(values)
+}
+
+func (q *QueryBuilder) insert(values ...map[string]interface{}) (int64, error) {
+	if err := q.db.Error(); err != nil {
+		return 0, err
+	}
+	if q.table == "" {
+		return 0, fmt.Errorf("memdb: table not set")
+	}
+	var id int64
+	err := q.db.access(true, func(ts tables) error {
+		t := ensureTable(ts, q.table)
+		for _, value := range values {
+			r := make(row, len(value))
+			for column, v := range value {
+				r[columnName(column)] = normalize(v)
+			}
+			if err := t.insert(r, "id", true); err != nil {
+				return err
+			}
+			if n, ok := toFloat(r["id"]); ok {
+				id = int64(n)
+			}
+		}
+		return nil
+	})
+	return id, err
+}
+
+// Update 更新记录，返回受影响行数
+func (q *QueryBuilder) Update(values map[string]interface{}) (int64, error) {
+	return q.modify(func(r row) error {
+		for column, value := range values {
+			r[columnName(column)] = normalize(value)
+		}
+		return nil
+	})
+}
+
+// Increment 自增列
+func (q *QueryBuilder) Increment(column string, amount int64) (int64, error) {
+	column = columnName(column)
+	return q.modify(func(r row) error {
+		current := normalize(r[column])
+		if current == nil {
+			r[column] = amount
+			return nil
+		}
+		switch n := current.(type) {
+		case float32, float64:
+			f, _ := toFloat(n)
+			r[column] = f + float64(amount)
+		default:
+			f, ok := toFloat(n)
+			if !ok {
+				return fmt.Errorf("memdb: cannot increment non-numeric column %s", column)
+			}
+			r[column] = int64(f) + amount
+		}
+		return nil
+	})
+}
+
+// Decrement 自减列
+func (q *QueryBuilder) Decrement(column string, amount int64) (int64, error) {
+	return q.Increment(column, -amount)
+}
+
+// modify 修改满足条件的行，忽略排序和分页
+func (q *QueryBuilder) modify(fn func(r row) error) (int64, error) {
+	if err := q.db.Error(); err != nil {
+		return 0, err
+	}
+	var affected int64
+	err := q.db.access(true, func(ts tables) error {
+		t, ok := ts[q.table]
+		if !ok {
+			return nil
+		}
+		for _, r := range t.rows {
+			ok, err := matches(r, q.conditions)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if err := fn(r); err != nil {
+				return err
+			}
+			t.addRowColumns(r)
+			affected++
+		}
+		return nil
+	})
+	return affected, err
+}
+
+// Delete 删除记录，返回受影响行数
+func (q *QueryBuilder) Delete() (int64, error) {
+	if err := q.db.Error(); err != nil {
+		return 0, err
+	}
+	var affected int64
+	err := q.db.access(true, func(ts tables) error {
+		t, ok := ts[q.table]
+		if !ok {
+			return nil
+		}
+		kept := t.rows[:0:0]
+		for _, r := range t.rows {
+			ok, err := matches(r, q.conditions)
+			if err != nil {
+				return err
+			}
+			if ok {
+				affected++
+				continue
+			}
+			kept = append(kept, r)
+		}
+		t.rows = kept
+		return nil
+	})
+	return affected, err
+}
+
+// Truncate 清空表并重置自增值
+func (q *QueryBuilder) Truncate() error {
+	return q.db.access(true, func(ts tables) error {
+		if t, ok := ts[q.table]; ok {
+			t.rows = nil
+			t.nextID = 1
+		}
+		return nil
+	})
+}
+
+// ToSQL 获取等价的 SQL 和绑定参数，仅用于调试
+func (q *QueryBuilder) ToSQL() (string, []interface{}) {
+	var b strings.Builder
+	var bindings []interface{}
+
+	b.WriteString("SELECT ")
+	if q.distinct {
+		b.WriteString("DISTINCT ")
+	}
+	if len(q.columns) == 0 {
+		b.WriteString("*")
+	} else {
+		b.WriteString(strings.Join(q.columns, ", "))
+	}
+	b.WriteString(" FROM " + q.table)
+
+	for i, c := range q.conditions {
+		switch {
+		case i == 0:
+			b.WriteString(" WHERE ")
+		case c.or:
+			b.WriteString(" OR ")
+		default:
+			b.WriteString(" AND ")
+		}
+		if c.not {
+			b.WriteString("NOT ")
+		}
+		switch c.operator {
+		case "is null", "is not null":
+			b.WriteString(c.column + " " + strings.ToUpper(c.operator))
+		case "in", "not in":
+			values := toSlice(c.value)
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+			b.WriteString(c.column + " " + strings.ToUpper(c.operator) + " (" + placeholders + ")")
+			bindings = append(bindings, values...)
+		case "between":
+			b.WriteString(c.column + " BETWEEN ? AND ?")
+			bindings = append(bindings, toSlice(c.value)...)
+		default:
+			b.WriteString(c.column + " " + strings.ToUpper(c.operator) + " ?")
+			bindings = append(bindings, c.value)
+		}
+	}
+	for i, o := range q.orders {
+		if i == 0 {
+			b.WriteString(" ORDER BY ")
+		} else {
+			b.WriteString(", ")
+		}
+		direction := "ASC"
+		if o.desc {
+			direction = "DESC"
+		}
+		b.WriteString(o.column + " " + direction)
+	}
+	if q.limit >= 0 {
+		fmt.Fprintf(&b, " LIMIT %d", q.limit)
+	}
+	if q.offset > 0 {
+		fmt.Fprintf(&b, " OFFSET %d", q.offset)
+	}
+	return b.String(), bindings
+}
+
+// Explain 返回模拟的执行计划
+//
+// 内存实现总是全表扫描，格式与 SQLite 的 EXPLAIN QUERY PLAN 相同。
+func (q *QueryBuilder) Explain() ([]map[string]interface{}, error) {
+	if err := q.db.Error(); err != nil {
+		return nil, err
+	}
+	plan := []map[string]interface{}{
+		{"id": 2, "parent": 0, "notused": 0, "detail": "SCAN " + q.table},
+	}
+	if len(q.orders) > 0 {
+		plan = append(plan, map[string]interface{}{
+			"id": 3, "parent": 0, "notused": 0, "detail": "USE TEMP B-TREE FOR ORDER BY",
+		})
+	}
+	return plan, nil
+}
+
+var _ database.QueryBuilder = (*QueryBuilder)(nil)
@@ -0,0 +1,322 @@
+package memdb
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// schema 模型结构体的列信息
+type schema struct {
+	table      string
+	fields     []*field
+	byColumn   map[string]*field
+	primaryKey *field
+	softDelete *field
+	createdAt  *field
+	updatedAt  *field
+	modelType  reflect.Type
+}
+
+// field 模型字段
+type field struct {
+	name   string
+	column string
+	index  []int
+	typ    reflect.Type
+	auto   bool // 自增主键
+}
+
+var (
+	schemaCache sync.Map // map[reflect.Type]*schema
+
+	timeType      = reflect.TypeOf(time.Time{})
+	valuerType    = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerType   = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	tableNamerTyp = reflect.TypeOf((*interface{ TableName() string })(nil)).Elem()
+)
+
+// parseSchema 解析模型的列信息
+//
+// value 可以是结构体、结构体指针、结构体切片或它们的指针，
+// 无法解析时返回 nil。
+func parseSchema(value interface{}) *schema {
+	typ := reflect.TypeOf(value)
+	for typ != nil && (typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct || typ == timeType {
+		return nil
+	}
+	if cached, ok := schemaCache.Load(typ); ok {
+		return cached.(*schema)
+	}
+
+	s := &schema{
+		modelType: typ,
+		byColumn:  make(map[string]*field),
+	}
+	s.table = tableName(typ)
+	collectFields(s, typ, nil)
+	if s.primaryKey == nil {
+		if f, ok := s.byColumn["id"]; ok {
+			s.primaryKey = f
+		}
+	}
+	if s.primaryKey != nil {
+		switch s.primaryKey.typ.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s.primaryKey.auto = true
+		}
+	}
+
+	actual, _ := schemaCache.LoadOrStore(typ, s)
+	return actual.(*schema)
+}
+
+// collectFields 收集结构体字段，展开嵌入的结构体
+func collectFields(s *schema, typ reflect.Type, parent []int) {
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := parseTag(sf.Tag.Get("gorm"))
+		if _, ignored := tag["-"]; ignored {
+			continue
+		}
+
+		index := append(append([]int{}, parent...), i)
+		ft := sf.Type
+		_, embedded := tag["embedded"]
+		if (sf.Anonymous || embedded) && ft.Kind() == reflect.Struct && !isValueType(ft) {
+			collectFields(s, ft, index)
+			continue
+		}
+		if !isColumnType(ft) {
+			continue // 关联关系字段
+		}
+
+		f := &field{
+			name:   sf.Name,
+			column: tag["column"],
+			index:  index,
+			typ:    ft,
+		}
+		if f.column == "" {
+			f.column = snakeCase(sf.Name)
+		}
+		if _, ok := s.byColumn[f.column]; ok {
+			continue // 外层字段优先
+		}
+		s.fields = append(s.fields, f)
+		s.byColumn[f.column] = f
+
+		if _, ok := tag["primarykey"]; ok {
+			s.primaryKey = f
+		}
+		switch {
+		case ft.Name() == "DeletedAt" && ft.Kind() == reflect.Struct:
+			s.softDelete = f
+		case f.column == "created_at":
+			s.createdAt = f
+		case f.column == "updated_at":
+			s.updatedAt = f
+		}
+	}
+}
+
+// parseTag 解析 gorm 标签，键统一为小写
+func parseTag(tag string) map[string]string {
+	settings := make(map[string]string)
+	for _, part := range strings.Split(tag, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, ":")
+		settings[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return settings
+}
+
+// isValueType 是否作为单个列值存储的结构体类型
+func isValueType(t reflect.Type) bool {
+	return t == timeType || t.Implements(valuerType) || reflect.PointerTo(t).Implements(scannerType)
+}
+
+// isColumnType 字段类型是否对应数据库列
+func isColumnType(t reflect.Type) bool {
+	if isValueType(t) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Func, reflect.Chan, reflect.Interface:
+		return false
+	case reflect.Ptr:
+		return isColumnType(t.Elem())
+	case reflect.Slice, reflect.Array:
+		elem := t.Elem()
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		return elem.Kind() != reflect.Struct || isValueType(elem)
+	}
+	return true
+}
+
+// tableName 根据类型推断表名
+//
+// 优先使用 TableName() 方法，否则使用蛇形复数形式，如 UserProfile -> user_profiles、Person -> people。
+func tableName(typ reflect.Type) string {
+	if typ.Implements(tableNamerTyp) {
+		return reflect.Zero(typ).Interface().(interface{ TableName() string }).TableName()
+	}
+	if reflect.PointerTo(typ).Implements(tableNamerTyp) {
+		return reflect.New(typ).Interface().(interface{ TableName() string }).TableName()
+	}
+	return str.Plural(snakeCase(typ.Name()))
+}
+
+// snakeCase 转换为蛇形命名，保留连续大写缩写，如 UserID -> user_id、HTTPServer -> http_server
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// toRow 把结构体转换为行数据
+//
+// 实现了 driver.Valuer 的字段以 Value() 的结果存储。
+func (s *schema) toRow(v reflect.Value) (row, error) {
+	r := make(row, len(s.fields))
+	for _, f := range s.fields {
+		value, err := storedValue(v.FieldByIndex(f.index))
+		if err != nil {
+			return nil, err
+		}
+		r[f.column] = value
+	}
+	return r, nil
+}
+
+// fill 把行数据写回结构体
+func (s *schema) fill(v reflect.Value, r row, only map[string]bool) error {
+	for _, f := range s.fields {
+		value, ok := r[f.column]
+		if !ok || (only != nil && !only[f.column]) {
+			continue
+		}
+		if err := assign(v.FieldByIndex(f.index), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storedValue 获取字段的存储值
+func storedValue(v reflect.Value) (interface{}, error) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	if v.CanAddr() {
+		if valuer, ok := v.Addr().Interface().(driver.Valuer); ok {
+			return valuer.Value()
+		}
+	}
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return append([]byte(nil), v.Bytes()...), nil
+	}
+	return v.Interface(), nil
+}
+
+// assign 把存储值写入字段，支持 sql.Scanner、指针和类型转换
+func assign(dst reflect.Value, value interface{}) error {
+	if dst.CanAddr() {
+		if scanner, ok := dst.Addr().Interface().(sql.Scanner); ok {
+			return scanner.Scan(value)
+		}
+	}
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		ptr := reflect.New(dst.Type().Elem())
+		if err := assign(ptr.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+
+	src := reflect.ValueOf(value)
+	for src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		src = src.Elem()
+	}
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case isNumberKind(src.Kind()) && isNumberKind(dst.Kind()), src.Type().ConvertibleTo(dst.Type()) && src.Kind() == dst.Kind():
+		dst.Set(src.Convert(dst.Type()))
+	case src.Kind() == reflect.String && dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+		dst.SetBytes([]byte(src.String()))
+	case src.Kind() == reflect.Slice && src.Type().Elem().Kind() == reflect.Uint8 && dst.Kind() == reflect.String:
+		dst.SetString(string(src.Bytes()))
+	default:
+		return &ConversionError{From: src.Type(), To: dst.Type()}
+	}
+	return nil
+}
+
+// ConversionError 存储值无法写入目标字段
+type ConversionError struct {
+	From reflect.Type
+	To   reflect.Type
+}
+
+func (e *ConversionError) Error() string {
+	return "memdb: cannot assign " + e.From.String() + " to " + e.To.String()
+}
+
+// isNumberKind 是否为数字类型
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -3,7 +3,10 @@ package database
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"reflect"
 	"time"
+
+	"github.com/cnote0/laraveldoc/support/str"
 )
 
 // Model 基础模型结构体
@@ -183,3 +186,21 @@ func (dt *DeletedAt) UnmarshalJSON(data []byte) error {
 	dt.Valid = true
 	return nil
 }
+
+// TableName 模型的表名，对应 Eloquent 的 getTable
+//
+// 模型实现 TableName() string 时使用其结果，否则为类型名的 snake_case 复数形式，
+// 例如 UserProfile 为 user_profiles，Person 为 people，Category 为 categories。
+func TableName(model interface{}) string {
+	if namer, ok := model.(interface{ TableName() string }); ok {
+		return namer.TableName()
+	}
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if namer, ok := reflect.New(t).Interface().(interface{ TableName() string }); ok {
+		return namer.TableName()
+	}
+	return str.Plural(str.Snake(t.Name()))
+}
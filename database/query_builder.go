@@ -0,0 +1,181 @@
+package database
+
+import "context"
+
+// QueryBuilder Laravel 风格的查询构建器接口
+//
+// QueryBuilder 对应 Laravel 的 Query Builder（DB::table），以表为中心构建查询，
+// 结果以 map 形式返回，不需要预先定义模型结构体。
+//
+// 使用示例：
+//
+//	builder := container.MustMake("db.query").(database.QueryBuilder)
+//
+//	// 查询
+//	users, err := builder.Table("users").
+//		Select("id", "name", "email").
+//		Where("age", ">", 18).
+//		WhereIn("status", []interface{}{"active", "pending"}).
+//		OrderBy("created_at", "desc").
+//		Limit(10).
+//		Get()
+//
+//	// 聚合
+//	count, err := builder.Table("orders").Where("paid", "=", true).Count()
+//
+//	// 写入
+//	id, err := builder.Table("users").InsertGetID(map[string]interface{}{
+//		"name":  "John",
+//		"email": "john@example.com",
+//	})
+//
+//	// 查看执行计划
+//	plan, err := builder.Table("orders").Where("user_id", "=", 1).Explain()
+type QueryBuilder interface {
+	// Table 设置查询的表
+	Table(name string) QueryBuilder
+
+	// WithContext 设置上下文
+	WithContext(ctx context.Context) QueryBuilder
+
+	// Select 设置查询列
+	Select(columns ...string) QueryBuilder
+
+	// Distinct 去重
+	Distinct() QueryBuilder
+
+	// Where 添加 AND 条件
+	//
+	// 示例：
+	//   builder.Where("age", ">=", 18)
+	//   builder.Where("name", "like", "J%")
+	Where(column string, operator string, value interface{}) QueryBuilder
+
+	// OrWhere 添加 OR 条件
+	OrWhere(column string, operator string, value interface{}) QueryBuilder
+
+	// WhereIn 添加 IN 条件
+	WhereIn(column string, values []interface{}) QueryBuilder
+
+	// WhereNotIn 添加 NOT IN 条件
+	WhereNotIn(column string, values []interface{}) QueryBuilder
+
+	// WhereNull 添加 IS NULL 条件
+	WhereNull(column string) QueryBuilder
+
+	// WhereNotNull 添加 IS NOT NULL 条件
+	WhereNotNull(column string) QueryBuilder
+
+	// WhereBetween 添加 BETWEEN 条件
+	WhereBetween(column string, from interface{}, to interface{}) QueryBuilder
+
+	// WhereRaw 添加原生条件
+	//
+	// 示例：
+	//   builder.WhereRaw("price > IF(state = 'TX', ?, 100)", 200)
+	WhereRaw(sql string, bindings ...interface{}) QueryBuilder
+
+	// Join 内连接
+	//
+	// 示例：
+	//   builder.Table("users").Join("orders", "users.id", "=", "orders.user_id")
+	Join(table string, first string, operator string, second string) QueryBuilder
+
+	// LeftJoin 左连接
+	LeftJoin(table string, first string, operator string, second string) QueryBuilder
+
+	// GroupBy 分组
+	GroupBy(columns ...string) QueryBuilder
+
+	// Having 分组条件
+	Having(column string, operator string, value interface{}) QueryBuilder
+
+	// OrderBy 排序
+	//
+	// direction 为 "asc" 或 "desc"。
+	OrderBy(column string, direction string) QueryBuilder
+
+	// Latest 按时间列倒序，默认 created_at
+	Latest(column ...string) QueryBuilder
+
+	// Limit 限制数量
+	Limit(limit int) QueryBuilder
+
+	// Offset 跳过数量
+	Offset(offset int) QueryBuilder
+
+	// Get 获取所有结果
+	Get() ([]map[string]interface{}, error)
+
+	// First 获取第一条结果
+	//
+	// 没有结果时返回错误。
+	First() (map[string]interface{}, error)
+
+	// Find 根据主键获取结果
+	Find(id interface{}) (map[string]interface{}, error)
+
+	// Value 获取第一条结果的单个列值
+	Value(column string) (interface{}, error)
+
+	// Pluck 获取单列的值列表
+	Pluck(column string) ([]interface{}, error)
+
+	// Count 统计数量
+	Count() (int64, error)
+
+	// Exists 是否存在结果
+	Exists() (bool, error)
+
+	// Max 最大值
+	Max(column string) (interface{}, error)
+
+	// Min 最小值
+	Min(column string) (interface{}, error)
+
+	// Sum 求和
+	Sum(column string) (float64, error)
+
+	// Avg 平均值
+	Avg(column string) (float64, error)
+
+	// Insert 插入记录
+	Insert(values ...map[string]interface{}) error
+
+	// InsertGetID 插入记录并返回自增主键
+	InsertGetID(values map[string]interface{}) (int64, error)
+
+	// Update 更新记录，返回受影响行数
+	Update(values map[string]interface{}) (int64, error)
+
+	// Increment 自增列
+	Increment(column string, amount int64) (int64, error)
+
+	// Decrement 自减列
+	Decrement(column string, amount int64) (int64, error)
+
+	// Delete 删除记录，返回受影响行数
+	Delete() (int64, error)
+
+	// Truncate 清空表
+	Truncate() error
+
+	// ToSQL 获取生成的 SQL 和绑定参数
+	//
+	// 示例：
+	//   sql, bindings := builder.Table("users").Where("id", "=", 1).ToSQL()
+	//   // sql: "SELECT * FROM users WHERE id = ?", bindings: [1]
+	ToSQL() (string, []interface{})
+
+	// Explain 获取查询执行计划
+	//
+	// 根据当前连接的方言生成 EXPLAIN 语句（参见 ExplainStatement），
+	// 每一行执行计划以 map 形式返回，列名取决于数据库。
+	//
+	// 示例：
+	//   plan, err := builder.Table("orders").Where("user_id", "=", 1).Explain()
+	//   for _, row := range plan {
+	//       fmt.Println(row["type"], row["key"], row["rows"]) // MySQL
+	//   }
+	Explain() ([]map[string]interface{}, error)
+}
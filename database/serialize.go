@@ -0,0 +1,541 @@
+package database
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/support/decimal"
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// DefaultDateFormat 序列化日期的默认格式，UTC 的 ISO-8601，与 Laravel 7+ 的默认输出一致
+const DefaultDateFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// ErrLazyLoad 访问了未加载的关联，只在禁止懒加载时返回
+var ErrLazyLoad = errors.New("database: attempted to lazy load relation")
+
+// LazyLoadingViolation 访问了未加载的关联
+type LazyLoadingViolation struct {
+	// Model 模型类型
+	Model string
+
+	// Relation 关联字段名
+	Relation string
+}
+
+// Error 实现 error 接口
+func (e *LazyLoadingViolation) Error() string {
+	return fmt.Sprintf("%v: %s.%s", ErrLazyLoad, e.Model, e.Relation)
+}
+
+// Unwrap 支持 errors.Is(err, ErrLazyLoad)
+func (e *LazyLoadingViolation) Unwrap() error {
+	return ErrLazyLoad
+}
+
+// HasHidden 序列化时隐藏部分属性的模型，对应 $hidden
+type HasHidden interface {
+	Hidden() []string
+}
+
+// HasVisible 序列化时只输出部分属性的模型，对应 $visible
+type HasVisible interface {
+	Visible() []string
+}
+
+// HasAppends 序列化时追加访问器属性的模型，对应 $appends
+type HasAppends interface {
+	Appends() []string
+}
+
+// HasCasts 序列化时转换属性类型的模型，对应 $casts
+//
+// 支持的类型：int、float、string、bool、json（把 JSON 字符串或 []byte 解码为值）、timestamp（Unix 秒）、
+// date（按 "2006-01-02"）、datetime（按序列化日期格式），以及 "date:格式"、"datetime:格式"（Go 时间格式），
+// decimal:位数（按 decimal.Decimal 四舍五入后输出字符串，例如 decimal:2 为 "19.90"）。
+type HasCasts interface {
+	Casts() map[string]string
+}
+
+// SerializesDates 自定义日期序列化格式的模型，对应 serializeDate
+type SerializesDates interface {
+	SerializeDateFormat() string
+}
+
+// SerializeOptions 序列化选项
+type SerializeOptions struct {
+	// DateFormat 默认日期格式，模型实现 SerializesDates 时以模型为准，默认 DefaultDateFormat
+	DateFormat string
+
+	// Location 日期转换到的时区，默认 UTC
+	Location *time.Location
+
+	// PreventLazyLoading 出现在 Visible 或 Appends 中的关联没有加载时返回 *LazyLoadingViolation
+	// （设置了 HandleLazyLoadingViolationUsing 时交给处理函数），
+	// 否则静默省略
+	PreventLazyLoading bool
+}
+
+// Serializer 模型序列化器，对应 Eloquent 的 toArray 和 toJson
+//
+// 属性名取 json 标签，没有标签时为字段名的 snake_case；嵌入的结构体（例如 Model）展开到同一层。
+// 类型为模型结构体、模型指针或模型切片的字段视为关联：已加载（非 nil）的关联递归序列化，
+// 未加载的关联被省略，序列化本身从不查询数据库。
+//
+// 访问器是模型上名为 Get{属性名 StudlyCase}Attribute、无参数、一个返回值的方法，
+// 覆盖同名属性的值，Appends 中的属性只能来自访问器。之后依次应用 Casts、日期格式化，
+// 最后按 Visible、Hidden 过滤。
+//
+// 使用示例：
+//
+//	type User struct {
+//		database.Model
+//		FirstName string  `json:"first_name"`
+//		LastName  string  `json:"last_name"`
+//		Password  string  `json:"password"`
+//		Settings  string  `json:"settings"`
+//		Posts     []*Post `json:"posts" gorm:"foreignKey:UserID"`
+//	}
+//
+//	func (u *User) Hidden() []string              { return []string{"password"} }
+//	func (u *User) Appends() []string             { return []string{"full_name"} }
+//	func (u *User) Casts() map[string]string      { return map[string]string{"settings": "json"} }
+//	func (u *User) SerializeDateFormat() string   { return "2006-01-02 15:04:05" }
+//	func (u *User) GetFullNameAttribute() string  { return u.FirstName + " " + u.LastName }
+//
+//	db.Preload("Posts").First(&user, 1)
+//	data, err := database.ToJSON(&user) // 包含 posts、full_name，不含 password
+type Serializer struct {
+	options SerializeOptions
+}
+
+// NewSerializer 创建序列化器
+func NewSerializer(options SerializeOptions) *Serializer {
+	if options.DateFormat == "" {
+		options.DateFormat = DefaultDateFormat
+	}
+	if options.Location == nil {
+		options.Location = time.UTC
+	}
+	return &Serializer{options: options}
+}
+
+// defaultSerializer ToMap、ToJSON 使用的序列化器，是否禁止懒加载取决于 PreventLazyLoading
+func defaultSerializer() *Serializer {
+	return NewSerializer(SerializeOptions{PreventLazyLoading: PreventsLazyLoading()})
+}
+
+// ToMap 使用默认选项把模型序列化为 map
+func ToMap(model interface{}) (map[string]interface{}, error) {
+	return defaultSerializer().ToMap(model)
+}
+
+// ToJSON 使用默认选项把模型、模型指针或模型切片序列化为 JSON
+func ToJSON(value interface{}) ([]byte, error) {
+	return defaultSerializer().ToJSON(value)
+}
+
+// ToMap 把模型（结构体或结构体指针）序列化为 map，nil 指针返回 nil
+func (s *Serializer) ToMap(model interface{}) (map[string]interface{}, error) {
+	v := reflect.ValueOf(model)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("database: cannot serialize %T as a model", model)
+	}
+	serialized, err := s.relation(v, reflect.Struct, make(map[uintptr]bool))
+	if err != nil {
+		return nil, err
+	}
+	return serialized.(map[string]interface{}), nil
+}
+
+// ToJSON 把模型、模型指针或模型切片序列化为 JSON
+func (s *Serializer) ToJSON(value interface{}) ([]byte, error) {
+	serialized, err := s.Serialize(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(serialized)
+}
+
+// Serialize 把模型序列化为 map，模型切片序列化为 []interface{}，其他值原样返回
+func (s *Serializer) Serialize(value interface{}) (interface{}, error) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if relation, ok := relationType(v.Type()); ok {
+		return s.relation(v, relation, make(map[uintptr]bool))
+	}
+	return value, nil
+}
+
+// model 序列化一个模型结构体，visiting 记录正在序列化的模型地址，用于跳过循环引用
+func (s *Serializer) model(v reflect.Value, visiting map[uintptr]bool) (map[string]interface{}, error) {
+	instance := modelInstance(v)
+	attributes := make(map[string]interface{})
+	relations := make(map[string]reflect.Value)
+	collectFields(v, attributes, relations)
+
+	visible := stringSet(instance, func(m interface{}) ([]string, bool) {
+		if h, ok := m.(HasVisible); ok {
+			return h.Visible(), true
+		}
+		return nil, false
+	})
+	hidden := stringSet(instance, func(m interface{}) ([]string, bool) {
+		if h, ok := m.(HasHidden); ok {
+			return h.Hidden(), true
+		}
+		return nil, false
+	})
+	var appends []string
+	if h, ok := instance.(HasAppends); ok {
+		appends = h.Appends()
+	}
+
+	// 访问器覆盖同名属性，Appends 中的属性只来自访问器
+	for name := range attributes {
+		if value, ok := accessor(instance, name); ok {
+			attributes[name] = value
+		}
+	}
+	for _, name := range appends {
+		if value, ok := accessor(instance, name); ok {
+			attributes[name] = value
+		} else if _, isRelation := relations[name]; !isRelation {
+			return nil, fmt.Errorf("database: %T appends %q but has no Get%sAttribute accessor", instance, name, str.Studly(name))
+		}
+	}
+
+	var casts map[string]string
+	if h, ok := instance.(HasCasts); ok {
+		casts = h.Casts()
+	}
+	dateFormat := s.options.DateFormat
+	if h, ok := instance.(SerializesDates); ok && h.SerializeDateFormat() != "" {
+		dateFormat = h.SerializeDateFormat()
+	}
+	for name, value := range attributes {
+		cast, err := s.cast(value, casts[name], dateFormat)
+		if err != nil {
+			return nil, fmt.Errorf("database: cast %T.%s: %w", instance, name, err)
+		}
+		attributes[name] = cast
+	}
+
+	for name, field := range relations {
+		if !field.IsValid() || isNilValue(field) {
+			if s.options.PreventLazyLoading && (visible[name] || contains(appends, name)) {
+				if err := violation(&lazyLoadingHandler, &LazyLoadingViolation{Model: v.Type().Name(), Relation: name}); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		relation, _ := relationType(field.Type())
+		serialized, err := s.relation(field, relation, visiting)
+		if err != nil {
+			return nil, err
+		}
+		if serialized != nil {
+			attributes[name] = serialized
+		}
+	}
+
+	for name := range attributes {
+		if (len(visible) > 0 && !visible[name]) || hidden[name] {
+			delete(attributes, name)
+		}
+	}
+	return attributes, nil
+}
+
+// relation 序列化关联：模型、模型指针或模型切片，循环引用的模型被省略
+func (s *Serializer) relation(v reflect.Value, kind reflect.Kind, visiting map[uintptr]bool) (interface{}, error) {
+	if kind == reflect.Slice {
+		if v.IsNil() {
+			return nil, nil
+		}
+		items := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, err := s.relation(v.Index(i), reflect.Struct, visiting)
+			if err != nil {
+				return nil, err
+			}
+			if item != nil {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return v.Interface(), nil
+	}
+	if v.CanAddr() {
+		if visiting[v.Addr().Pointer()] {
+			return nil, nil
+		}
+		visiting[v.Addr().Pointer()] = true
+		defer delete(visiting, v.Addr().Pointer())
+	}
+	return s.model(v, visiting)
+}
+
+// cast 按转换类型转换属性值，日期按格式输出，零值日期输出 null
+func (s *Serializer) cast(value interface{}, cast string, dateFormat string) (interface{}, error) {
+	kind, format, _ := strings.Cut(cast, ":")
+	if t, ok := timeValue(value); ok {
+		if t == nil {
+			return nil, nil
+		}
+		local := t.In(s.options.Location)
+		switch {
+		case kind == "timestamp":
+			return local.Unix(), nil
+		case kind == "date" && format == "":
+			return local.Format(time.DateOnly), nil
+		case format != "":
+			return local.Format(format), nil
+		}
+		return local.Format(dateFormat), nil
+	}
+	if value == nil {
+		return nil, nil
+	}
+	switch kind {
+	case "":
+		return value, nil
+	case "string":
+		if b, ok := value.([]byte); ok {
+			return string(b), nil
+		}
+		return fmt.Sprint(value), nil
+	case "int", "integer":
+		return strconv.ParseInt(strings.TrimSpace(fmt.Sprint(value)), 10, 64)
+	case "float", "double", "real":
+		return strconv.ParseFloat(strings.TrimSpace(fmt.Sprint(value)), 64)
+	case "bool", "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return v != "" && v != "0" && !strings.EqualFold(v, "false"), nil
+		}
+		return fmt.Sprint(value) != "0", nil
+	case "decimal":
+		// decimal:2 四舍五入到两位小数并输出字符串，不经过 float64
+		d, err := decimal.FromValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if format == "" {
+			return d.String(), nil
+		}
+		places, err := strconv.ParseInt(format, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decimal cast %q", cast)
+		}
+		return d.StringFixed(int32(places)), nil
+	case "json", "array", "object":
+		var raw []byte
+		switch v := value.(type) {
+		case string:
+			raw = []byte(v)
+		case []byte:
+			raw = v
+		case json.RawMessage:
+			raw = v
+		default:
+			return value, nil
+		}
+		if len(raw) == 0 {
+			return nil, nil
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return nil, err
+		}
+		return decoded, nil
+	}
+	return nil, fmt.Errorf("unsupported cast %q", cast)
+}
+
+// collectFields 收集属性和关联字段，嵌入的结构体展开到同一层
+func collectFields(v reflect.Value, attributes map[string]interface{}, relations map[string]reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		if field.Anonymous {
+			embedded := value
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && !isValueType(embedded.Type()) {
+				collectFields(embedded, attributes, relations)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name, ok := attributeName(field)
+		if !ok {
+			continue
+		}
+		if _, isRelation := relationType(field.Type); isRelation {
+			relations[name] = value
+			continue
+		}
+		attributes[name] = value.Interface()
+	}
+}
+
+// attributeName 字段的属性名：json 标签名，没有时为 snake_case，json:"-" 的字段跳过
+func attributeName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return str.Snake(field.Name), true
+}
+
+// relationType 类型是否为关联（模型结构体、模型指针或模型切片），返回 reflect.Struct 或 reflect.Slice
+func relationType(t reflect.Type) (reflect.Kind, bool) {
+	kind := reflect.Struct
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		kind = reflect.Slice
+		t = t.Elem()
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isValueType(t) {
+		return 0, false
+	}
+	return kind, true
+}
+
+// isValueType 结构体是否应作为单个属性值（时间、实现了 JSON 或数据库值接口的类型）
+func isValueType(t reflect.Type) bool {
+	if t == reflect.TypeOf(time.Time{}) {
+		return true
+	}
+	ptr := reflect.PointerTo(t)
+	for _, iface := range []reflect.Type{
+		reflect.TypeOf((*json.Marshaler)(nil)).Elem(),
+		reflect.TypeOf((*driver.Valuer)(nil)).Elem(),
+	} {
+		if t.Implements(iface) || ptr.Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// timeValue 值是否为日期（包括有 Time() time.Time 方法的类型），nil 表示空日期（零值、无效的 DeletedAt 或 nil 指针）
+func timeValue(value interface{}) (*time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return nil, true
+		}
+		return &v, true
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return nil, true
+		}
+		return v, true
+	case DeletedAt:
+		if !v.Valid {
+			return nil, true
+		}
+		return &v.Time, true
+	case interface{ Time() time.Time }:
+		// datetime.DateTime 等包装类型
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, true
+		}
+		t := v.Time()
+		if t.IsZero() {
+			return nil, true
+		}
+		return &t, true
+	}
+	return nil, false
+}
+
+// modelInstance 模型的指针形式（可寻址时）以便调用指针接收者的方法
+func modelInstance(v reflect.Value) interface{} {
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	copied := reflect.New(v.Type())
+	copied.Elem().Set(v)
+	return copied.Interface()
+}
+
+// accessor 调用 Get{Name}Attribute 访问器
+func accessor(instance interface{}, name string) (interface{}, bool) {
+	method := reflect.ValueOf(instance).MethodByName("Get" + str.Studly(name) + "Attribute")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil, false
+	}
+	return method.Call(nil)[0].Interface(), true
+}
+
+// stringSet 把模型返回的属性列表转换为集合
+func stringSet(instance interface{}, get func(interface{}) ([]string, bool)) map[string]bool {
+	names, ok := get(instance)
+	if !ok {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// isNilValue 指针、切片、接口是否为 nil
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Interface, reflect.Map:
+		return v.IsNil()
+	}
+	return false
+}
+
+// contains 切片是否包含值
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -2,12 +2,14 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
 // SessionConfig 会话配置结构体
 //
 // SessionConfig 用于配置数据库会话的行为，包括事务模式、钩子设置等。
+// 可以用 NewSessionConfig 和 Session* 选项创建，无效的组合返回 ErrInvalidConfig。
 type SessionConfig struct {
 	DryRun                   bool
 	PrepareStmt              bool
@@ -22,4 +24,116 @@ type SessionConfig struct {
 	Logger                   LoggerInterface
 	NowFunc                  func() time.Time
 	CreateBatchSize          int
+	Explain                  *ExplainConfig
+}
+
+// SessionOption SessionConfig 选项
+type SessionOption func(*SessionConfig)
+
+// SessionDryRun 只生成语句不执行
+func SessionDryRun() SessionOption {
+	return func(c *SessionConfig) { c.DryRun = true }
+}
+
+// SessionPrepareStmt 缓存预编译语句
+func SessionPrepareStmt() SessionOption {
+	return func(c *SessionConfig) { c.PrepareStmt = true }
+}
+
+// SessionNewDB 不继承原会话的查询条件
+func SessionNewDB() SessionOption {
+	return func(c *SessionConfig) { c.NewDB = true }
+}
+
+// SessionSkipHooks 跳过模型钩子
+func SessionSkipHooks() SessionOption {
+	return func(c *SessionConfig) { c.SkipHooks = true }
+}
+
+// SessionSkipDefaultTransaction 单条写操作不使用默认事务
+func SessionSkipDefaultTransaction() SessionOption {
+	return func(c *SessionConfig) { c.SkipDefaultTransaction = true }
+}
+
+// SessionDisableNestedTransaction 嵌套事务不使用保存点
+func SessionDisableNestedTransaction() SessionOption {
+	return func(c *SessionConfig) { c.DisableNestedTransaction = true }
+}
+
+// SessionAllowGlobalUpdate 允许不带条件的更新和删除
+func SessionAllowGlobalUpdate() SessionOption {
+	return func(c *SessionConfig) { c.AllowGlobalUpdate = true }
+}
+
+// SessionFullSaveAssociations 保存时更新全部关联
+func SessionFullSaveAssociations() SessionOption {
+	return func(c *SessionConfig) { c.FullSaveAssociations = true }
+}
+
+// SessionQueryFields 查询时列出全部字段而不是 *
+func SessionQueryFields() SessionOption {
+	return func(c *SessionConfig) { c.QueryFields = true }
+}
+
+// SessionContext 会话使用的上下文
+func SessionContext(ctx context.Context) SessionOption {
+	return func(c *SessionConfig) { c.Context = ctx }
+}
+
+// SessionLogger 会话使用的日志器
+func SessionLogger(logger LoggerInterface) SessionOption {
+	return func(c *SessionConfig) { c.Logger = logger }
+}
+
+// SessionNowFunc 生成时间戳使用的时钟
+func SessionNowFunc(now func() time.Time) SessionOption {
+	return func(c *SessionConfig) { c.NowFunc = now }
+}
+
+// SessionCreateBatchSize 批量插入的批大小
+func SessionCreateBatchSize(size int) SessionOption {
+	return func(c *SessionConfig) { c.CreateBatchSize = size }
+}
+
+// SessionExplain 慢查询执行计划配置
+func SessionExplain(explain *ExplainConfig) SessionOption {
+	return func(c *SessionConfig) { c.Explain = explain }
+}
+
+// NewSessionConfig 应用选项并校验会话配置
+//
+// 示例：
+//
+//	config, err := database.NewSessionConfig(
+//		database.SessionContext(ctx),
+//		database.SessionCreateBatchSize(500),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	tx := db.Session(config)
+func NewSessionConfig(opts ...SessionOption) (*SessionConfig, error) {
+	config := &SessionConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate 校验会话配置，用于直接构造的 SessionConfig
+//
+// DryRun 不执行语句，不能与会再次执行语句的 EXPLAIN ANALYZE 同时使用。
+func (c *SessionConfig) Validate() error {
+	switch {
+	case c.CreateBatchSize < 0:
+		return fmt.Errorf("%w: create batch size %d must not be negative", ErrInvalidConfig, c.CreateBatchSize)
+	case c.Explain != nil && c.Explain.SlowThreshold < 0:
+		return fmt.Errorf("%w: explain slow threshold must not be negative", ErrInvalidConfig)
+	case c.Explain != nil && c.Explain.Analyze && c.DryRun:
+		return fmt.Errorf("%w: explain analyze executes statements and cannot be used with dry run", ErrInvalidConfig)
+	}
+	return nil
 }
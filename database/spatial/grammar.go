@@ -0,0 +1,185 @@
+package spatial
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// ColumnType 空间列类型，对应 Blueprint 的 point、lineString、polygon、geography
+type ColumnType string
+
+// 空间列类型
+const (
+	// PointColumn 点
+	PointColumn ColumnType = "point"
+
+	// LineStringColumn 折线
+	LineStringColumn ColumnType = "linestring"
+
+	// PolygonColumn 多边形
+	PolygonColumn ColumnType = "polygon"
+
+	// GeometryColumn 任意几何类型
+	GeometryColumn ColumnType = "geometry"
+
+	// GeographyColumn 地理类型，PostGIS 中为 geography(Geometry)，MySQL 中为 SRID 4326 的 GEOMETRY
+	GeographyColumn ColumnType = "geography"
+)
+
+// Grammar 空间 SQL 语法
+//
+// 距离以米为单位，按球面距离计算。查询作用域配合 database.DB 的 Scopes 使用。
+type Grammar struct {
+	dialect string
+	srid    int
+}
+
+var (
+	// PostGIS PostgreSQL + PostGIS 语法
+	PostGIS = Grammar{dialect: database.DialectPostgres, srid: DefaultSRID}
+
+	// MySQL MySQL 8 语法
+	MySQL = Grammar{dialect: database.DialectMySQL, srid: DefaultSRID}
+)
+
+// ForDialect 按 database.DialectPostgres、database.DialectMySQL 获取语法
+func ForDialect(dialect string) (Grammar, error) {
+	switch dialect {
+	case database.DialectPostgres:
+		return PostGIS, nil
+	case database.DialectMySQL:
+		return MySQL, nil
+	}
+	return Grammar{}, fmt.Errorf("spatial: unsupported dialect %q", dialect)
+}
+
+// WithSRID 使用其他 SRID 的语法
+func (g Grammar) WithSRID(srid int) Grammar {
+	g.srid = srid
+	return g
+}
+
+// SRID 语法使用的空间参考系统
+func (g Grammar) SRID() int {
+	return g.srid
+}
+
+// ColumnDefinition 列类型定义，例如 PostGIS 的 geography(Point, 4326)、MySQL 的 POINT SRID 4326
+func (g Grammar) ColumnDefinition(typ ColumnType) string {
+	if g.dialect == database.DialectPostgres {
+		switch typ {
+		case GeographyColumn:
+			return fmt.Sprintf("geography(Geometry, %d)", g.srid)
+		case GeometryColumn:
+			return fmt.Sprintf("geometry(Geometry, %d)", g.srid)
+		}
+		return fmt.Sprintf("geometry(%s, %d)", postgisType(typ), g.srid)
+	}
+	if typ == GeographyColumn {
+		typ = GeometryColumn
+	}
+	return fmt.Sprintf("%s SRID %d", strings.ToUpper(string(typ)), g.srid)
+}
+
+// AddColumn 添加空间列的语句，MySQL 的空间索引要求列不能为 NULL
+func (g Grammar) AddColumn(table, column string, typ ColumnType, nullable bool) string {
+	null := " NOT NULL"
+	if nullable {
+		null = " NULL"
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s%s", g.quote(table), g.quote(column), g.ColumnDefinition(typ), null)
+}
+
+// SpatialIndex 创建空间索引的语句，PostGIS 使用 GiST 索引
+func (g Grammar) SpatialIndex(table, column string) string {
+	name := g.quote(table + "_" + column + "_spatialindex")
+	if g.dialect == database.DialectPostgres {
+		return fmt.Sprintf("CREATE INDEX %s ON %s USING GIST (%s)", name, g.quote(table), g.quote(column))
+	}
+	return fmt.Sprintf("CREATE SPATIAL INDEX %s ON %s (%s)", name, g.quote(table), g.quote(column))
+}
+
+// GeomFromText 把 WKT 参数转换为几何值的 SQL 片段，用于写入 MySQL 或比较
+//
+//	db.Exec("UPDATE stores SET location = "+grammar.GeomFromText()+" WHERE id = ?", point.WKT(), id)
+func (g Grammar) GeomFromText() string {
+	if g.dialect == database.DialectPostgres {
+		return fmt.Sprintf("ST_GeomFromText(?, %d)", g.srid)
+	}
+	return fmt.Sprintf("ST_GeomFromText(?, %d, 'axis-order=long-lat')", g.srid)
+}
+
+// WhereDistance 到点的距离（米）满足比较条件，operator 为 <、<=、>、>=、=
+func (g Grammar) WhereDistance(column string, point Point, operator string, meters float64) func(database.DB) database.DB {
+	return func(db database.DB) database.DB {
+		if !validOperator(operator) {
+			_ = db.AddError(fmt.Errorf("spatial: invalid distance operator %q", operator))
+			return db
+		}
+		return db.Where(g.distance(column, "?")+" "+operator+" ?", point.WKT(), meters)
+	}
+}
+
+// WhereWithin 列中的几何对象位于多边形内
+func (g Grammar) WhereWithin(column string, area Polygon) func(database.DB) database.DB {
+	return func(db database.DB) database.DB {
+		return db.Where(fmt.Sprintf("ST_Within(%s, %s)", g.quote(column), g.GeomFromText()), area.WKT())
+	}
+}
+
+// OrderByDistance 按到点的距离排序，direction 为 "desc" 时由远到近
+func (g Grammar) OrderByDistance(column string, point Point, direction string) func(database.DB) database.DB {
+	return func(db database.DB) database.DB {
+		order := " ASC"
+		if strings.EqualFold(direction, "desc") {
+			order = " DESC"
+		}
+		// Order 不支持参数，坐标只包含数字，直接写入语句
+		return db.Order(g.distance(column, "'"+point.WKT()+"'") + order)
+	}
+}
+
+// distance 到 WKT 点的球面距离表达式，point 为参数占位符或 WKT 字面量
+func (g Grammar) distance(column, point string) string {
+	if g.dialect == database.DialectPostgres {
+		return fmt.Sprintf("ST_Distance(%s::geography, ST_GeomFromText(%s, %d)::geography)", g.quote(column), point, g.srid)
+	}
+	return fmt.Sprintf("ST_Distance_Sphere(%s, ST_GeomFromText(%s, %d, 'axis-order=long-lat'))", g.quote(column), point, g.srid)
+}
+
+// quote 引用标识符，支持 "table.column"
+func (g Grammar) quote(identifier string) string {
+	quote := "`"
+	if g.dialect == database.DialectPostgres {
+		quote = `"`
+	}
+	parts := strings.Split(identifier, ".")
+	for i, part := range parts {
+		parts[i] = quote + strings.ReplaceAll(part, quote, quote+quote) + quote
+	}
+	return strings.Join(parts, ".")
+}
+
+// postgisType PostGIS 的几何子类型名
+func postgisType(typ ColumnType) string {
+	switch typ {
+	case PointColumn:
+		return "Point"
+	case LineStringColumn:
+		return "LineString"
+	case PolygonColumn:
+		return "Polygon"
+	}
+	return "Geometry"
+}
+
+// validOperator 是否为允许的比较运算符
+func validOperator(operator string) bool {
+	switch operator {
+	case "<", "<=", ">", ">=", "=":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,296 @@
+// Package spatial 提供空间数据列、Geo 值类型和空间查询辅助，支持 PostGIS 和 MySQL 8
+//
+// 坐标使用经纬度（X 为经度、Y 为纬度），默认 SRID 为 4326（WGS 84）。
+// Geo 可以直接作为模型字段：读取时识别 WKB、EWKB、PostGIS 的十六进制 EWKB、MySQL 的内部格式和 WKT，
+// 写入时输出 EWKT（PostGIS 可以直接接受），MySQL 需要用 Grammar.GeomFromText 包装参数。
+//
+// 主要特性：
+// - Point、LineString、Polygon 几何类型和 WKT 输出
+// - Geo 值类型，实现 sql.Scanner、driver.Valuer 和 GeoJSON 序列化
+// - 迁移用的空间列类型（包括 PostGIS 的 geography）和空间索引语句
+// - WhereDistance、WhereWithin、OrderByDistance 查询作用域
+//
+// 包结构：
+// - spatial.go - Geometry 几何类型和 Geo 值类型
+// - wkb.go - WKB、EWKB、MySQL 内部格式和 WKT 的解析与编码
+// - grammar.go - Grammar 列类型、空间索引和查询作用域
+//
+// 使用示例：
+//
+//	type Store struct {
+//		database.Model
+//		Name     string
+//		Location spatial.Geo `gorm:"type:geography(Point,4326)"`
+//	}
+//
+//	grammar := spatial.PostGIS // 或 spatial.MySQL
+//	db.Exec(grammar.AddColumn("stores", "location", spatial.PointColumn, false))
+//	db.Exec(grammar.SpatialIndex("stores", "location"))
+//
+//	here := spatial.At(52.52, 13.40)
+//	db.Create(&Store{Name: "Mitte", Location: spatial.NewGeo(here)})
+//
+//	var nearby []Store
+//	db.Scopes(
+//		grammar.WhereDistance("location", here, "<=", 5000),
+//		grammar.OrderByDistance("location", here, "asc"),
+//	).Find(&nearby)
+package spatial
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultSRID 默认空间参考系统 WGS 84
+const DefaultSRID = 4326
+
+var (
+	// ErrUnsupportedGeometry 不支持的几何类型
+	ErrUnsupportedGeometry = errors.New("spatial: unsupported geometry type")
+
+	// ErrInvalidGeometry 无法解析的几何数据
+	ErrInvalidGeometry = errors.New("spatial: invalid geometry data")
+)
+
+// Geometry 几何对象
+type Geometry interface {
+	// WKT Well-Known Text 表示
+	WKT() string
+
+	// GeoJSON GeoJSON 的 type 和 coordinates
+	GeoJSON() map[string]interface{}
+}
+
+// Point 点，X 为经度，Y 为纬度
+type Point struct {
+	X float64
+	Y float64
+}
+
+// At 按纬度、经度创建点
+func At(lat, lng float64) Point {
+	return Point{X: lng, Y: lat}
+}
+
+// Lat 纬度
+func (p Point) Lat() float64 {
+	return p.Y
+}
+
+// Lng 经度
+func (p Point) Lng() float64 {
+	return p.X
+}
+
+// WKT 例如 POINT(13.4 52.52)
+func (p Point) WKT() string {
+	return "POINT(" + p.coordinates() + ")"
+}
+
+// GeoJSON GeoJSON 表示
+func (p Point) GeoJSON() map[string]interface{} {
+	return map[string]interface{}{"type": "Point", "coordinates": []float64{p.X, p.Y}}
+}
+
+// coordinates "X Y" 形式的坐标
+func (p Point) coordinates() string {
+	return formatFloat(p.X) + " " + formatFloat(p.Y)
+}
+
+// LineString 折线
+type LineString []Point
+
+// WKT 例如 LINESTRING(0 0,1 1)
+func (l LineString) WKT() string {
+	return "LINESTRING(" + joinPoints(l) + ")"
+}
+
+// GeoJSON GeoJSON 表示
+func (l LineString) GeoJSON() map[string]interface{} {
+	return map[string]interface{}{"type": "LineString", "coordinates": pointCoordinates(l)}
+}
+
+// Polygon 多边形，第一个环为外环，其余为内环（洞），每个环首尾相同
+type Polygon []LineString
+
+// WKT 例如 POLYGON((0 0,1 0,1 1,0 0))
+func (p Polygon) WKT() string {
+	rings := make([]string, len(p))
+	for i, ring := range p {
+		rings[i] = "(" + joinPoints(ring) + ")"
+	}
+	return "POLYGON(" + strings.Join(rings, ",") + ")"
+}
+
+// GeoJSON GeoJSON 表示
+func (p Polygon) GeoJSON() map[string]interface{} {
+	rings := make([][][]float64, len(p))
+	for i, ring := range p {
+		rings[i] = pointCoordinates(ring)
+	}
+	return map[string]interface{}{"type": "Polygon", "coordinates": rings}
+}
+
+// Geo 可为空的几何值，用作模型字段
+type Geo struct {
+	// Geometry 几何对象
+	Geometry Geometry
+
+	// SRID 空间参考系统，0 表示未指定
+	SRID int
+
+	// Valid 是否不为 NULL
+	Valid bool
+}
+
+// NewGeo 创建 SRID 为 4326 的几何值
+func NewGeo(geometry Geometry) Geo {
+	return Geo{Geometry: geometry, SRID: DefaultSRID, Valid: true}
+}
+
+// Point 几何值是否为点
+func (g Geo) Point() (Point, bool) {
+	p, ok := g.Geometry.(Point)
+	return p, ok && g.Valid
+}
+
+// String EWKT 表示，NULL 为空字符串
+func (g Geo) String() string {
+	if !g.Valid || g.Geometry == nil {
+		return ""
+	}
+	if g.SRID == 0 {
+		return g.Geometry.WKT()
+	}
+	return "SRID=" + strconv.Itoa(g.SRID) + ";" + g.Geometry.WKT()
+}
+
+// Scan 实现 sql.Scanner，支持 WKB、EWKB、十六进制 EWKB、MySQL 内部格式、WKT 和 EWKT
+func (g *Geo) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*g = Geo{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidGeometry, value)
+	}
+	geometry, srid, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	*g = Geo{Geometry: geometry, SRID: srid, Valid: true}
+	return nil
+}
+
+// Value 实现 driver.Valuer，输出 EWKT 文本，NULL 为 nil
+func (g Geo) Value() (driver.Value, error) {
+	if !g.Valid || g.Geometry == nil {
+		return nil, nil
+	}
+	return g.String(), nil
+}
+
+// MarshalJSON 序列化为 GeoJSON 几何对象，NULL 为 null
+func (g Geo) MarshalJSON() ([]byte, error) {
+	if !g.Valid || g.Geometry == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(g.Geometry.GeoJSON())
+}
+
+// UnmarshalJSON 从 GeoJSON 几何对象反序列化，SRID 为 4326
+func (g *Geo) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*g = Geo{}
+		return nil
+	}
+	var object struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	var geometry Geometry
+	switch object.Type {
+	case "Point":
+		var c []float64
+		if err := json.Unmarshal(object.Coordinates, &c); err != nil || len(c) < 2 {
+			return fmt.Errorf("%w: point coordinates", ErrInvalidGeometry)
+		}
+		geometry = Point{X: c[0], Y: c[1]}
+	case "LineString":
+		var c [][]float64
+		if err := json.Unmarshal(object.Coordinates, &c); err != nil {
+			return err
+		}
+		line, err := lineFromCoordinates(c)
+		if err != nil {
+			return err
+		}
+		geometry = line
+	case "Polygon":
+		var c [][][]float64
+		if err := json.Unmarshal(object.Coordinates, &c); err != nil {
+			return err
+		}
+		polygon := make(Polygon, len(c))
+		for i, ring := range c {
+			line, err := lineFromCoordinates(ring)
+			if err != nil {
+				return err
+			}
+			polygon[i] = line
+		}
+		geometry = polygon
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedGeometry, object.Type)
+	}
+	*g = NewGeo(geometry)
+	return nil
+}
+
+// lineFromCoordinates GeoJSON 坐标数组转换为折线
+func lineFromCoordinates(coordinates [][]float64) (LineString, error) {
+	line := make(LineString, len(coordinates))
+	for i, c := range coordinates {
+		if len(c) < 2 {
+			return nil, fmt.Errorf("%w: coordinate %v", ErrInvalidGeometry, c)
+		}
+		line[i] = Point{X: c[0], Y: c[1]}
+	}
+	return line, nil
+}
+
+// joinPoints WKT 中逗号分隔的坐标列表
+func joinPoints(points []Point) string {
+	parts := make([]string, len(points))
+	for i, p := range points {
+		parts[i] = p.coordinates()
+	}
+	return strings.Join(parts, ",")
+}
+
+// pointCoordinates GeoJSON 坐标数组
+func pointCoordinates(points []Point) [][]float64 {
+	coordinates := make([][]float64, len(points))
+	for i, p := range points {
+		coordinates[i] = []float64{p.X, p.Y}
+	}
+	return coordinates
+}
+
+// formatFloat 最短的十进制表示
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,311 @@
+package spatial
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WKB 几何类型编号
+const (
+	wkbPoint      = 1
+	wkbLineString = 2
+	wkbPolygon    = 3
+)
+
+// EWKB 类型编号中的标志位
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// Parse 解析几何数据，返回几何对象和 SRID（未包含时为 0）
+//
+// 依次识别：WKT 和 EWKT 文本、十六进制编码的（E）WKB、MySQL 内部格式（4 字节小端 SRID 加 WKB）、（E）WKB。
+func Parse(data []byte) (Geometry, int, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, 0, ErrInvalidGeometry
+	}
+	if first := data[0] | 0x20; 'a' <= first && first <= 'z' && !isHex(data) {
+		return ParseWKT(string(data))
+	}
+	if isHex(data) {
+		decoded := make([]byte, hex.DecodedLen(len(data)))
+		if _, err := hex.Decode(decoded, data); err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", ErrInvalidGeometry, err)
+		}
+		data = decoded
+	}
+	if len(data) >= 9 && data[4] <= 1 {
+		if geometry, srid, err := decodeWKB(data[4:]); err == nil {
+			if srid == 0 {
+				srid = int(binary.LittleEndian.Uint32(data[:4]))
+			}
+			return geometry, srid, nil
+		}
+	}
+	return decodeWKB(data)
+}
+
+// MarshalWKB 编码为小端 WKB，srid 不为 0 时编码为 EWKB
+func MarshalWKB(geometry Geometry, srid int) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(1)
+	write := func(v interface{}) {
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	typeFlag := func(t uint32) uint32 {
+		if srid != 0 {
+			return t | ewkbSRID
+		}
+		return t
+	}
+	writeSRID := func() {
+		if srid != 0 {
+			write(uint32(srid))
+		}
+	}
+	writePoints := func(points []Point) {
+		write(uint32(len(points)))
+		for _, p := range points {
+			write(p.X)
+			write(p.Y)
+		}
+	}
+	switch g := geometry.(type) {
+	case Point:
+		write(typeFlag(wkbPoint))
+		writeSRID()
+		write(g.X)
+		write(g.Y)
+	case LineString:
+		write(typeFlag(wkbLineString))
+		writeSRID()
+		writePoints(g)
+	case Polygon:
+		write(typeFlag(wkbPolygon))
+		writeSRID()
+		write(uint32(len(g)))
+		for _, ring := range g {
+			writePoints(ring)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedGeometry, geometry)
+	}
+	return buf.Bytes(), nil
+}
+
+// wkbReader 顺序读取 WKB
+type wkbReader struct {
+	data  []byte
+	order binary.ByteOrder
+	err   error
+}
+
+// uint32 读取无符号整数
+func (r *wkbReader) uint32() uint32 {
+	if r.err != nil || len(r.data) < 4 {
+		r.err = ErrInvalidGeometry
+		return 0
+	}
+	v := r.order.Uint32(r.data)
+	r.data = r.data[4:]
+	return v
+}
+
+// float64 读取浮点数
+func (r *wkbReader) float64() float64 {
+	if r.err != nil || len(r.data) < 8 {
+		r.err = ErrInvalidGeometry
+		return 0
+	}
+	v := math.Float64frombits(r.order.Uint64(r.data))
+	r.data = r.data[8:]
+	return v
+}
+
+// points 读取坐标列表，dims 为每个坐标的维数
+func (r *wkbReader) points(dims int) []Point {
+	n := r.uint32()
+	if r.err != nil || uint64(n)*uint64(dims)*8 > uint64(len(r.data)) {
+		r.err = ErrInvalidGeometry
+		return nil
+	}
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = r.point(dims)
+	}
+	return points
+}
+
+// point 读取一个坐标，忽略 Z 和 M
+func (r *wkbReader) point(dims int) Point {
+	p := Point{X: r.float64(), Y: r.float64()}
+	for i := 2; i < dims; i++ {
+		r.float64()
+	}
+	return p
+}
+
+// decodeWKB 解析（E）WKB，要求恰好读完全部数据
+func decodeWKB(data []byte) (Geometry, int, error) {
+	if len(data) < 5 || data[0] > 1 {
+		return nil, 0, ErrInvalidGeometry
+	}
+	r := &wkbReader{data: data[1:], order: binary.BigEndian}
+	if data[0] == 1 {
+		r.order = binary.LittleEndian
+	}
+	typ := r.uint32()
+	srid := 0
+	if typ&ewkbSRID != 0 {
+		srid = int(r.uint32())
+	}
+	dims := 2
+	if typ&ewkbZ != 0 {
+		dims++
+	}
+	if typ&ewkbM != 0 {
+		dims++
+	}
+	typ &^= ewkbZ | ewkbM | ewkbSRID
+	// ISO WKB：1001 为 Z，2001 为 M，3001 为 ZM
+	switch typ / 1000 {
+	case 1, 2:
+		dims++
+	case 3:
+		dims += 2
+	}
+	typ %= 1000
+
+	var geometry Geometry
+	switch typ {
+	case wkbPoint:
+		geometry = r.point(dims)
+	case wkbLineString:
+		geometry = LineString(r.points(dims))
+	case wkbPolygon:
+		n := r.uint32()
+		if r.err == nil && uint64(n)*4 > uint64(len(r.data)) {
+			r.err = ErrInvalidGeometry
+		}
+		if r.err == nil {
+			polygon := make(Polygon, n)
+			for i := range polygon {
+				polygon[i] = r.points(dims)
+			}
+			geometry = polygon
+		}
+	default:
+		return nil, 0, fmt.Errorf("%w: WKB type %d", ErrUnsupportedGeometry, typ)
+	}
+	if r.err != nil || len(r.data) != 0 {
+		return nil, 0, ErrInvalidGeometry
+	}
+	return geometry, srid, nil
+}
+
+// ParseWKT 解析 WKT 或 EWKT（"SRID=4326;POINT(1 2)"），只使用前两个坐标维度
+func ParseWKT(text string) (Geometry, int, error) {
+	text = strings.TrimSpace(text)
+	srid := 0
+	if prefix, rest, ok := strings.Cut(text, ";"); ok && strings.HasPrefix(strings.ToUpper(prefix), "SRID=") {
+		n, err := strconv.Atoi(prefix[5:])
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %s", ErrInvalidGeometry, prefix)
+		}
+		srid, text = n, strings.TrimSpace(rest)
+	}
+	open := strings.IndexByte(text, '(')
+	if open < 0 || !strings.HasSuffix(text, ")") {
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidGeometry, text)
+	}
+	kind := strings.Fields(strings.ToUpper(text[:open]))
+	if len(kind) == 0 {
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidGeometry, text)
+	}
+	body := text[open+1 : len(text)-1]
+
+	switch kind[0] {
+	case "POINT":
+		p, err := parseWKTPoint(body)
+		return p, srid, err
+	case "LINESTRING":
+		line, err := parseWKTPoints(body)
+		return line, srid, err
+	case "POLYGON":
+		var polygon Polygon
+		for _, ring := range splitRings(body) {
+			line, err := parseWKTPoints(ring)
+			if err != nil {
+				return nil, 0, err
+			}
+			polygon = append(polygon, line)
+		}
+		if len(polygon) == 0 {
+			return nil, 0, fmt.Errorf("%w: %q", ErrInvalidGeometry, text)
+		}
+		return polygon, srid, nil
+	}
+	return nil, 0, fmt.Errorf("%w: %s", ErrUnsupportedGeometry, kind[0])
+}
+
+// parseWKTPoint 解析 "X Y" 坐标
+func parseWKTPoint(text string) (Point, error) {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return Point{}, fmt.Errorf("%w: coordinate %q", ErrInvalidGeometry, text)
+	}
+	x, errX := strconv.ParseFloat(fields[0], 64)
+	y, errY := strconv.ParseFloat(fields[1], 64)
+	if errX != nil || errY != nil {
+		return Point{}, fmt.Errorf("%w: coordinate %q", ErrInvalidGeometry, text)
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+// parseWKTPoints 解析逗号分隔的坐标列表
+func parseWKTPoints(text string) (LineString, error) {
+	var line LineString
+	for _, part := range strings.Split(text, ",") {
+		p, err := parseWKTPoint(part)
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, p)
+	}
+	return line, nil
+}
+
+// splitRings 拆分 "(…),(…)" 形式的环
+func splitRings(text string) []string {
+	var rings []string
+	for {
+		start := strings.IndexByte(text, '(')
+		end := strings.IndexByte(text, ')')
+		if start < 0 || end < start {
+			return rings
+		}
+		rings = append(rings, text[start+1:end])
+		text = text[end+1:]
+	}
+}
+
+// isHex 是否为偶数长度的十六进制文本
+func isHex(data []byte) bool {
+	if len(data)%2 != 0 {
+		return false
+	}
+	for _, b := range data {
+		if !('0' <= b && b <= '9' || 'a' <= b && b <= 'f' || 'A' <= b && b <= 'F') {
+			return false
+		}
+	}
+	return true
+}
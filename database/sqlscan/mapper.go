@@ -0,0 +1,175 @@
+package sqlscan
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// Mapper 列名到结构体字段的映射器
+//
+// 字段名按以下顺序确定：
+// 1. `db:"name"` 标签（sqlx 约定），`db:"-"` 表示忽略
+// 2. `gorm:"column:name"` 标签
+// 3. NameFunc 转换字段名，默认蛇形命名（UserID -> user_id）
+//
+// 匿名嵌入的结构体字段会被展开，带 db 标签的具名结构体字段以 "标签." 为前缀展开，
+// 如 `db:"author"` 的 Name 字段对应列 "author.name"。
+//
+// 使用示例：
+//
+//	mapper := sqlscan.NewMapper(strings.ToLower) // 与 sqlx 默认行为一致
+//	scanner := &sqlscan.Scanner{Mapper: mapper}
+type Mapper struct {
+	// NameFunc 没有标签时把字段名转换为列名
+	NameFunc func(string) string
+
+	cache sync.Map // map[reflect.Type]map[string][]int
+}
+
+// NewMapper 创建映射器，nameFunc 为 nil 时使用蛇形命名
+func NewMapper(nameFunc func(string) string) *Mapper {
+	if nameFunc == nil {
+		nameFunc = SnakeCase
+	}
+	return &Mapper{NameFunc: nameFunc}
+}
+
+// defaultMapper 默认映射器
+var defaultMapper = NewMapper(nil)
+
+// FieldMap 获取结构体类型的列名到字段索引的映射，列名均为小写
+func (m *Mapper) FieldMap(t reflect.Type) map[string][]int {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if cached, ok := m.cache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+	fields := make(map[string][]int)
+	m.collect(t, nil, "", fields)
+	actual, _ := m.cache.LoadOrStore(t, fields)
+	return actual.(map[string][]int)
+}
+
+// collect 收集字段，外层字段优先于嵌入结构体中的同名字段
+func (m *Mapper) collect(t reflect.Type, parent []int, prefix string, fields map[string][]int) {
+	type nested struct {
+		index  []int
+		typ    reflect.Type
+		prefix string
+	}
+	var embedded []nested
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		tag := sf.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			tag = name
+		}
+		index := append(append([]int{}, parent...), i)
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !IsScannable(ft) {
+			switch {
+			case sf.Anonymous && tag == "":
+				embedded = append(embedded, nested{index, ft, prefix})
+				continue
+			case tag != "":
+				embedded = append(embedded, nested{index, ft, prefix + tag + "."})
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name := tag
+		if name == "" {
+			name = gormColumn(sf.Tag.Get("gorm"))
+		}
+		if name == "" {
+			name = m.NameFunc(sf.Name)
+		}
+		name = strings.ToLower(prefix + name)
+		if _, exists := fields[name]; !exists {
+			fields[name] = index
+		}
+	}
+
+	for _, e := range embedded {
+		m.collect(e.typ, e.index, e.prefix, fields)
+	}
+}
+
+// gormColumn 从 gorm 标签中提取 column 设置
+func gormColumn(tag string) string {
+	for _, part := range strings.Split(tag, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if ok && strings.EqualFold(key, "column") {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// IsScannable 类型是否作为单个列值扫描
+//
+// time.Time 和实现了 sql.Scanner 的结构体（如 sql.NullString、database.DeletedAt）
+// 不会被展开。
+func IsScannable(t reflect.Type) bool {
+	return t == timeType || reflect.PointerTo(t).Implements(scannerType) || t.Kind() != reflect.Struct
+}
+
+// SnakeCase 转换为蛇形命名，保留连续大写缩写，如 UserID -> user_id、HTTPServer -> http_server
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// fieldByIndex 获取字段，沿途为 nil 的嵌入指针会被分配
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(x)
+	}
+	return v
+}
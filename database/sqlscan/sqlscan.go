@@ -0,0 +1,290 @@
+// Package sqlscan 提供 sqlx 兼容的结构体扫描协议和辅助函数
+//
+// 本包把 *sql.Rows 的列按 `db` 标签映射到结构体字段，支持嵌入结构体、
+// 指针和 sql.Null* 等可空类型。database.DB 的实现在 Raw(...).Scan(dest) 和
+// ScanRows 中应使用本包，使同一个结构体可以同时用于 GORM 和 sqlx。
+//
+// 包结构：
+// - sqlscan.go - Scanner 扫描器、Select 和 Get 查询辅助函数
+// - mapper.go - Mapper 列名到字段的映射
+// - sqlx.go - Queryer/Execer 接口和 *sqlx.DB 适配器
+//
+// 使用示例：
+//
+//	type Author struct {
+//		ID   int64  `db:"id"`
+//		Name string `db:"name"`
+//	}
+//
+//	type Post struct {
+//		database.Model
+//		Title     string         `db:"title"`
+//		Summary   sql.NullString `db:"summary"`
+//		Published *time.Time     `db:"published_at"`
+//		Author    Author         `db:"author"` // 对应列 author.id、author.name
+//	}
+//
+//	// 直接扫描 *sql.Rows
+//	rows, err := sqlDB.QueryContext(ctx, "SELECT * FROM posts")
+//	var posts []Post
+//	err = sqlscan.ScanAll(rows, &posts)
+//
+//	// 查询并扫描
+//	var post Post
+//	err = sqlscan.Get(ctx, sqlDB, &post, "SELECT * FROM posts WHERE id = ?", 1)
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Scanner 结构体扫描器
+//
+// 使用示例：
+//
+//	scanner := &sqlscan.Scanner{Strict: true}
+//	err := scanner.ScanAll(rows, &users) // 存在无法映射的列时返回错误
+type Scanner struct {
+	// Mapper 列名映射器，为 nil 时使用默认的蛇形命名映射器
+	Mapper *Mapper
+
+	// Strict 严格模式
+	//
+	// 为 true 时结果中存在无法映射到字段的列会返回错误（与 sqlx 一致），
+	// 为 false 时忽略这些列。
+	Strict bool
+}
+
+// defaultScanner 默认扫描器，忽略无法映射的列
+var defaultScanner = &Scanner{}
+
+// ScanAll 使用默认扫描器扫描所有行，参见 Scanner.ScanAll
+func ScanAll(rows *sql.Rows, dest interface{}) error {
+	return defaultScanner.ScanAll(rows, dest)
+}
+
+// ScanOne 使用默认扫描器扫描第一行，参见 Scanner.ScanOne
+func ScanOne(rows *sql.Rows, dest interface{}) error {
+	return defaultScanner.ScanOne(rows, dest)
+}
+
+// Select 执行查询并把所有行扫描到切片
+//
+// 示例：
+//
+//	var users []User
+//	err := sqlscan.Select(ctx, db, &users, "SELECT * FROM users WHERE age > ?", 18)
+func Select(ctx context.Context, q Queryer, dest interface{}, query string, args ...interface{}) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return ScanAll(rows, dest)
+}
+
+// Get 执行查询并扫描第一行，没有结果时返回 sql.ErrNoRows
+//
+// 示例：
+//
+//	var user User
+//	err := sqlscan.Get(ctx, db, &user, "SELECT * FROM users WHERE id = ?", 1)
+//	if errors.Is(err, sql.ErrNoRows) {
+//		// 用户不存在
+//	}
+func Get(ctx context.Context, q Queryer, dest interface{}, query string, args ...interface{}) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return ScanOne(rows, dest)
+}
+
+// ScanAll 扫描所有行并关闭 rows
+//
+// dest 可以是：
+// - *[]Struct 或 *[]*Struct
+// - *[]map[string]interface{}
+// - *[]T（结果只有一列时，T 为标量或 sql.Scanner）
+// - *Struct、*map[string]interface{} 或 *T（只取第一行，没有结果时不报错）
+func (s *Scanner) ScanAll(rows *sql.Rows, dest interface{}) error {
+	defer rows.Close()
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("sqlscan: destination must be a non-nil pointer, got %T", dest)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	slice := rv.Elem()
+	if slice.Kind() != reflect.Slice || slice.Type().Elem().Kind() == reflect.Uint8 {
+		if rows.Next() {
+			if err := s.scanRow(rows, columns, rv.Elem()); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	}
+
+	result := reflect.MakeSlice(slice.Type(), 0, 0)
+	elemType := slice.Type().Elem()
+	for rows.Next() {
+		elem := reflect.New(elemType).Elem()
+		if err := s.scanRow(rows, columns, elem); err != nil {
+			return err
+		}
+		result = reflect.Append(result, elem)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	slice.Set(result)
+	return nil
+}
+
+// ScanOne 扫描第一行并关闭 rows，没有结果时返回 sql.ErrNoRows
+func (s *Scanner) ScanOne(rows *sql.Rows, dest interface{}) error {
+	defer rows.Close()
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("sqlscan: destination must be a non-nil pointer, got %T", dest)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := s.scanRow(rows, columns, rv.Elem()); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+// ScanRow 把当前行扫描到 dest，不移动游标也不关闭 rows
+//
+// 适合在自己的 rows.Next() 循环中使用，对应 database.DB 的 ScanRows。
+//
+// 示例：
+//
+//	for rows.Next() {
+//		var user User
+//		if err := scanner.ScanRow(rows, &user); err != nil {
+//			return err
+//		}
+//	}
+func (s *Scanner) ScanRow(rows *sql.Rows, dest interface{}) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("sqlscan: destination must be a non-nil pointer, got %T", dest)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	return s.scanRow(rows, columns, rv.Elem())
+}
+
+// scanRow 把当前行扫描到 v
+func (s *Scanner) scanRow(rows *sql.Rows, columns []string, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		if v.Elem().Kind() == reflect.Struct && !IsScannable(v.Elem().Type()) {
+			return s.scanRow(rows, columns, v.Elem())
+		}
+	}
+
+	switch {
+	case v.Kind() == reflect.Map:
+		return scanMap(rows, columns, v)
+	case v.Kind() == reflect.Struct && !IsScannable(v.Type()):
+		return s.scanStruct(rows, columns, v)
+	}
+
+	if len(columns) != 1 {
+		return fmt.Errorf("sqlscan: scanning %d columns into non-struct type %s", len(columns), v.Type())
+	}
+	return rows.Scan(v.Addr().Interface())
+}
+
+// scanStruct 按列名映射扫描到结构体
+func (s *Scanner) scanStruct(rows *sql.Rows, columns []string, v reflect.Value) error {
+	mapper := s.Mapper
+	if mapper == nil {
+		mapper = defaultMapper
+	}
+	fields := mapper.FieldMap(v.Type())
+
+	targets := make([]interface{}, len(columns))
+	var missing []string
+	for i, column := range columns {
+		index, ok := fields[strings.ToLower(column)]
+		if !ok {
+			missing = append(missing, column)
+			targets[i] = new(interface{})
+			continue
+		}
+		targets[i] = fieldByIndex(v, index).Addr().Interface()
+	}
+	if s.Strict && len(missing) > 0 {
+		return &MissingFieldError{Columns: missing, Type: v.Type()}
+	}
+	return rows.Scan(targets...)
+}
+
+// scanMap 扫描到 map[string]interface{}，[]byte 转换为字符串
+func scanMap(rows *sql.Rows, columns []string, v reflect.Value) error {
+	if v.Type().Key().Kind() != reflect.String {
+		return errors.New("sqlscan: map destination must have string keys")
+	}
+	values := make([]interface{}, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return err
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	for i, column := range columns {
+		value := values[i]
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		if value == nil {
+			v.SetMapIndex(reflect.ValueOf(column), reflect.Zero(v.Type().Elem()))
+			continue
+		}
+		v.SetMapIndex(reflect.ValueOf(column), reflect.ValueOf(value))
+	}
+	return nil
+}
+
+// MissingFieldError 严格模式下存在无法映射的列
+type MissingFieldError struct {
+	// Columns 无法映射的列
+	Columns []string
+
+	// Type 目标结构体类型
+	Type reflect.Type
+}
+
+func (e *MissingFieldError) Error() string {
+	return fmt.Sprintf("sqlscan: missing destination name %s in %s", strings.Join(e.Columns, ", "), e.Type)
+}
@@ -0,0 +1,132 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// Queryer 可执行查询的连接
+//
+// *sql.DB、*sql.Tx、*sql.Conn、*sqlx.DB 和 *sqlx.Tx 都满足此接口。
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Execer 可执行语句的连接
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// SqlxDB *sqlx.DB 满足的方法集合
+//
+// 本包不依赖 sqlx，*sqlx.DB 通过嵌入的 *sql.DB 和 DriverName 方法满足此接口。
+type SqlxDB interface {
+	Queryer
+	Execer
+
+	// DriverName 驱动名称
+	DriverName() string
+
+	// PingContext 检查连接
+	PingContext(ctx context.Context) error
+
+	// Stats 连接池统计
+	Stats() sql.DBStats
+
+	// Close 关闭连接
+	Close() error
+}
+
+// Connection 基于已有 *sqlx.DB 的原生连接
+//
+// Connection 让已经使用 sqlx 的项目把现有连接交给本框架，
+// 共享同一个连接池，并使用与 sqlx 相同的 `db` 标签扫描结果。
+//
+// 使用示例：
+//
+//	sqlxDB := sqlx.MustConnect("postgres", dsn)
+//	conn := sqlscan.FromSqlx(sqlxDB)
+//	container.Instance("db.raw", conn)
+//
+//	var users []User
+//	err := conn.Select(ctx, &users, "SELECT * FROM users WHERE active = $1", true)
+type Connection struct {
+	db      SqlxDB
+	scanner *Scanner
+}
+
+// FromSqlx 把 *sqlx.DB 包装为连接
+//
+// *sqlx.DB 默认使用 strings.ToLower 映射字段名，这里保持 sqlx 的行为。
+func FromSqlx(db SqlxDB) *Connection {
+	return &Connection{db: db, scanner: &Scanner{Mapper: NewMapper(strings.ToLower), Strict: true}}
+}
+
+// FromSQL 把 *sql.DB 包装为连接，字段名使用蛇形命名
+func FromSQL(db *sql.DB, driverName string) *Connection {
+	return &Connection{db: sqlDB{DB: db, driver: driverName}, scanner: defaultScanner}
+}
+
+// WithScanner 设置扫描器
+func (c *Connection) WithScanner(scanner *Scanner) *Connection {
+	return &Connection{db: c.db, scanner: scanner}
+}
+
+// DriverName 驱动名称
+func (c *Connection) DriverName() string {
+	return c.db.DriverName()
+}
+
+// Select 查询并扫描所有行
+func (c *Connection) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return c.scanner.ScanAll(rows, dest)
+}
+
+// Get 查询并扫描第一行，没有结果时返回 sql.ErrNoRows
+func (c *Connection) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return c.scanner.ScanOne(rows, dest)
+}
+
+// Exec 执行语句
+func (c *Connection) Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(ctx, query, args...)
+}
+
+// QueryContext 执行查询，使 Connection 本身也满足 Queryer
+func (c *Connection) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(ctx, query, args...)
+}
+
+// Ping 检查连接
+func (c *Connection) Ping(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
+// Stats 连接池统计
+func (c *Connection) Stats() sql.DBStats {
+	return c.db.Stats()
+}
+
+// Close 关闭连接
+func (c *Connection) Close() error {
+	return c.db.Close()
+}
+
+// sqlDB 为 *sql.DB 补充 DriverName
+type sqlDB struct {
+	*sql.DB
+	driver string
+}
+
+func (d sqlDB) DriverName() string {
+	return d.driver
+}
@@ -0,0 +1,334 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cnote0/laraveldoc/support/enum"
+)
+
+// ErrDiscardedAttribute 批量赋值时属性不可填充，只在 PreventSilentlyDiscardingAttributes 开启时返回
+var ErrDiscardedAttribute = errors.New("database: attempted to fill attributes that are not fillable")
+
+// ErrMissingAttribute 访问了模型上不存在的属性，只在 PreventAccessingMissingAttributes 开启时返回
+var ErrMissingAttribute = errors.New("database: attempted to access missing attribute")
+
+// DiscardedAttributeViolation 批量赋值时被丢弃的属性
+type DiscardedAttributeViolation struct {
+	// Model 模型类型
+	Model string
+
+	// Attributes 被丢弃的属性名，已排序
+	Attributes []string
+}
+
+// Error 实现 error 接口
+func (e *DiscardedAttributeViolation) Error() string {
+	return fmt.Sprintf("%v: %s [%s]", ErrDiscardedAttribute, e.Model, strings.Join(e.Attributes, ", "))
+}
+
+// Unwrap 支持 errors.Is(err, ErrDiscardedAttribute)
+func (e *DiscardedAttributeViolation) Unwrap() error {
+	return ErrDiscardedAttribute
+}
+
+// MissingAttributeViolation 访问了模型上不存在的属性
+type MissingAttributeViolation struct {
+	// Model 模型类型
+	Model string
+
+	// Attribute 属性名
+	Attribute string
+}
+
+// Error 实现 error 接口
+func (e *MissingAttributeViolation) Error() string {
+	return fmt.Sprintf("%v: %s.%s", ErrMissingAttribute, e.Model, e.Attribute)
+}
+
+// Unwrap 支持 errors.Is(err, ErrMissingAttribute)
+func (e *MissingAttributeViolation) Unwrap() error {
+	return ErrMissingAttribute
+}
+
+// HasFillable 声明可批量赋值属性的模型，对应 $fillable
+type HasFillable interface {
+	Fillable() []string
+}
+
+// HasGuarded 声明禁止批量赋值属性的模型，对应 $guarded，["*"] 表示全部禁止
+type HasGuarded interface {
+	Guarded() []string
+}
+
+// ViolationHandler 严格模式违规的处理函数，设置后违规交给它处理（例如只记录日志），不再返回错误
+type ViolationHandler func(err error)
+
+var (
+	preventLazyLoading       atomic.Bool
+	preventDiscarding        atomic.Bool
+	preventMissingAttributes atomic.Bool
+
+	lazyLoadingHandler atomic.Pointer[ViolationHandler]
+	discardedHandler   atomic.Pointer[ViolationHandler]
+	missingHandler     atomic.Pointer[ViolationHandler]
+)
+
+// ShouldBeStrict 同时开启或关闭三种严格模式检查，对应 Model::shouldBeStrict
+//
+// 通常在非生产环境开启，尽早发现 N+1 查询和拼错的属性名：
+//
+//	database.ShouldBeStrict(!app.IsProduction())
+func ShouldBeStrict(strict bool) {
+	PreventLazyLoading(strict)
+	PreventSilentlyDiscardingAttributes(strict)
+	PreventAccessingMissingAttributes(strict)
+}
+
+// PreventLazyLoading 开启后序列化访问未加载的关联、CheckLazyLoading 返回 *LazyLoadingViolation
+func PreventLazyLoading(prevent bool) {
+	preventLazyLoading.Store(prevent)
+}
+
+// PreventsLazyLoading 是否禁止懒加载
+func PreventsLazyLoading() bool {
+	return preventLazyLoading.Load()
+}
+
+// PreventSilentlyDiscardingAttributes 开启后 Fill 遇到不可填充的属性返回 *DiscardedAttributeViolation，
+// 关闭时这些属性被静默丢弃
+func PreventSilentlyDiscardingAttributes(prevent bool) {
+	preventDiscarding.Store(prevent)
+}
+
+// PreventsSilentlyDiscardingAttributes 是否禁止静默丢弃属性
+func PreventsSilentlyDiscardingAttributes() bool {
+	return preventDiscarding.Load()
+}
+
+// PreventAccessingMissingAttributes 开启后 GetAttribute 访问不存在的属性返回 *MissingAttributeViolation，
+// 关闭时返回 nil 值
+func PreventAccessingMissingAttributes(prevent bool) {
+	preventMissingAttributes.Store(prevent)
+}
+
+// PreventsAccessingMissingAttributes 是否禁止访问不存在的属性
+func PreventsAccessingMissingAttributes() bool {
+	return preventMissingAttributes.Load()
+}
+
+// HandleLazyLoadingViolationUsing 自定义懒加载违规的处理，nil 恢复为返回错误
+func HandleLazyLoadingViolationUsing(handler ViolationHandler) {
+	storeHandler(&lazyLoadingHandler, handler)
+}
+
+// HandleDiscardedAttributeViolationUsing 自定义丢弃属性违规的处理，nil 恢复为返回错误
+func HandleDiscardedAttributeViolationUsing(handler ViolationHandler) {
+	storeHandler(&discardedHandler, handler)
+}
+
+// HandleMissingAttributeViolationUsing 自定义访问不存在属性违规的处理，nil 恢复为返回错误
+func HandleMissingAttributeViolationUsing(handler ViolationHandler) {
+	storeHandler(&missingHandler, handler)
+}
+
+// CheckLazyLoading 供关联实现在懒加载前调用，禁止懒加载时返回 *LazyLoadingViolation
+func CheckLazyLoading(model interface{}, relation string) error {
+	if !PreventsLazyLoading() {
+		return nil
+	}
+	return violation(&lazyLoadingHandler, &LazyLoadingViolation{Model: modelName(model), Relation: relation})
+}
+
+// Fill 批量赋值，对应 Eloquent 的 fill
+//
+// 属性名与序列化相同（json 标签或字段名的 snake_case）。模型实现 HasFillable 时只填充其中的属性，
+// 实现 HasGuarded 时跳过其中的属性，都没有实现时全部可填充；主键和时间戳始终受保护。
+// 不可填充或不存在的属性默认被静默丢弃，开启 PreventSilentlyDiscardingAttributes 时返回错误且不修改模型。
+// 枚举类型（enum.Enumerable）的字段按底层值转换，不是枚举值时返回 enum.ErrInvalidValue；
+// 实现了 sql.Scanner 的字段（例如 datetime.DateTime）通过 Scan 转换。
+//
+//	err := database.Fill(&user, map[string]interface{}{"name": "John", "is_admin": true})
+func Fill(model interface{}, attributes map[string]interface{}) error {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("database: cannot fill %T, a model pointer is required", model)
+	}
+	fields := attributeFields(v.Elem().Type())
+	fillable := stringSet(model, func(m interface{}) ([]string, bool) {
+		if h, ok := m.(HasFillable); ok {
+			return h.Fillable(), true
+		}
+		return nil, false
+	})
+	guarded := stringSet(model, func(m interface{}) ([]string, bool) {
+		if h, ok := m.(HasGuarded); ok {
+			return h.Guarded(), true
+		}
+		return nil, false
+	})
+
+	var discarded []string
+	assignments := make(map[string]reflect.Value, len(attributes))
+	for name, value := range attributes {
+		index, exists := fields[name]
+		if !exists || !isFillable(name, fillable, guarded) {
+			discarded = append(discarded, name)
+			continue
+		}
+		field := v.Elem().FieldByIndex(index)
+		converted, err := convertAttribute(value, field.Type())
+		if err != nil {
+			return fmt.Errorf("database: fill %T.%s: %w", model, name, err)
+		}
+		assignments[name] = converted
+	}
+	if len(discarded) > 0 && PreventsSilentlyDiscardingAttributes() {
+		sort.Strings(discarded)
+		if err := violation(&discardedHandler, &DiscardedAttributeViolation{Model: modelName(model), Attributes: discarded}); err != nil {
+			return err
+		}
+	}
+	for name, value := range assignments {
+		v.Elem().FieldByIndex(fields[name]).Set(value)
+	}
+	return nil
+}
+
+// GetAttribute 按属性名读取模型的值，访问器优先，对应 Eloquent 的 getAttribute
+//
+// 属性不存在时返回 nil，开启 PreventAccessingMissingAttributes 时返回 *MissingAttributeViolation。
+func GetAttribute(model interface{}, name string) (interface{}, error) {
+	v := reflect.ValueOf(model)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("database: cannot get attribute %q of nil %T", name, model)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("database: cannot get attribute %q of %T", name, model)
+	}
+	if value, ok := accessor(modelInstance(v), name); ok {
+		return value, nil
+	}
+	if index, ok := attributeFields(v.Type())[name]; ok {
+		return v.FieldByIndex(index).Interface(), nil
+	}
+	if !PreventsAccessingMissingAttributes() {
+		return nil, nil
+	}
+	return nil, violation(&missingHandler, &MissingAttributeViolation{Model: modelName(model), Attribute: name})
+}
+
+// guardedAttributes 始终受保护、不能批量赋值的属性
+var guardedAttributes = map[string]bool{"id": true, "created_at": true, "updated_at": true, "deleted_at": true}
+
+// isFillable 属性是否可批量赋值
+func isFillable(name string, fillable, guarded map[string]bool) bool {
+	if fillable != nil {
+		return fillable[name]
+	}
+	if guardedAttributes[name] || guarded["*"] {
+		return false
+	}
+	return !guarded[name]
+}
+
+// attributeFields 属性名到字段索引的映射，嵌入的结构体展开到同一层，外层字段优先
+func attributeFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	var walk func(t reflect.Type, prefix []int)
+	walk = func(t reflect.Type, prefix []int) {
+		var embedded []reflect.StructField
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			index := append(append([]int(nil), prefix...), i)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct && !isValueType(field.Type) {
+				field.Index = index
+				embedded = append(embedded, field)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name, ok := attributeName(field); ok {
+				if _, exists := fields[name]; !exists {
+					fields[name] = index
+				}
+			}
+		}
+		for _, field := range embedded {
+			walk(field.Type, field.Index)
+		}
+	}
+	walk(t, nil)
+	return fields
+}
+
+// convertAttribute 把值转换为字段类型
+func convertAttribute(value interface{}, t reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(t), nil
+	}
+	if _, ok := enum.CasesOf(t); ok {
+		// 枚举字段只接受枚举值，字符串和数字按底层值查找
+		return enum.Convert(t, value)
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Type().AssignableTo(t):
+		return v, nil
+	case t.Kind() == reflect.String && !isTextKind(v.Type()):
+		// 整数转换为字符串得到的是字符而不是数字文本，不做转换
+	case v.Type().ConvertibleTo(t):
+		return v.Convert(t), nil
+	case t.Kind() == reflect.Ptr && v.Type().AssignableTo(t.Elem()):
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(v)
+		return ptr, nil
+	case reflect.PointerTo(t).Implements(reflect.TypeOf((*sql.Scanner)(nil)).Elem()):
+		// datetime.DateTime 等实现了 sql.Scanner 的字段按扫描数据库值的方式转换
+		target := reflect.New(t)
+		if err := target.Interface().(sql.Scanner).Scan(value); err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot assign %T to %s: %w", value, t, err)
+		}
+		return target.Elem(), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot assign %T to %s", value, t)
+}
+
+// violation 把违规交给处理函数，没有处理函数时返回违规本身
+func violation(handler *atomic.Pointer[ViolationHandler], err error) error {
+	if h := handler.Load(); h != nil {
+		(*h)(err)
+		return nil
+	}
+	return err
+}
+
+// storeHandler 保存处理函数，nil 清除
+func storeHandler(target *atomic.Pointer[ViolationHandler], handler ViolationHandler) {
+	if handler == nil {
+		target.Store(nil)
+		return
+	}
+	target.Store(&handler)
+}
+
+// modelName 模型的类型名
+func modelName(model interface{}) string {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "<nil>"
+	}
+	return t.Name()
+}
@@ -0,0 +1,244 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// touchChunkSize 一条 UPDATE 中最多包含的父模型主键数量
+const touchChunkSize = 1000
+
+// HasTouches 保存后需要更新父模型 updated_at 的模型，对应 $touches
+//
+// 返回的是 belongs-to 关联的名称，可以是字段名（Post）或属性名（post）。外键取 gorm 标签
+// foreignKey 指定的字段，默认为关联名加 ID（PostID），父模型主键列取 references 标签，默认为 id。
+//
+//	type Comment struct {
+//		database.Model
+//		PostID uint
+//		Post   *Post
+//	}
+//
+//	func (c *Comment) Touches() []string { return []string{"post"} }
+type HasTouches interface {
+	Touches() []string
+}
+
+// Touch 更新模型 Touches 中父模型的 updated_at，对应 touchOwners
+//
+// models 可以是模型指针、模型或模型切片。同一父模型类型的主键合并到一条
+// UPDATE ... WHERE id IN (...) 中，批量操作后调用一次即可，不会为每行各执行一次更新。
+// 已加载的父关联会同步更新内存中的 UpdatedAt，并且如果父模型也实现了 HasTouches，
+// 继续向上更新它的父模型。没有 updated_at 列的父模型被跳过。
+func Touch(db DB, models ...interface{}) error {
+	now := time.Now()
+	seen := make(map[reflect.Type]map[string]bool)
+	round := flattenModels(models)
+	for len(round) > 0 {
+		groups := make(map[reflect.Type]*touchGroup)
+		var next []reflect.Value
+		for _, model := range round {
+			instance, ok := modelInstance(model).(HasTouches)
+			if !ok {
+				continue
+			}
+			for _, name := range instance.Touches() {
+				owner, err := resolveOwner(model, name)
+				if err != nil {
+					return err
+				}
+				if owner == nil {
+					continue
+				}
+				group, ok := groups[owner.parent]
+				if !ok {
+					group = &touchGroup{parent: owner.parent, key: owner.key}
+					groups[owner.parent] = group
+				}
+				if seen[owner.parent] == nil {
+					seen[owner.parent] = make(map[string]bool)
+				}
+				id := fmt.Sprint(owner.id)
+				if !seen[owner.parent][id] {
+					seen[owner.parent][id] = true
+					group.ids = append(group.ids, owner.id)
+				}
+				if owner.loaded.IsValid() {
+					group.loaded = append(group.loaded, owner.loaded)
+					next = append(next, owner.loaded)
+				}
+			}
+		}
+
+		parents := make([]reflect.Type, 0, len(groups))
+		for parent := range groups {
+			parents = append(parents, parent)
+		}
+		sort.Slice(parents, func(i, j int) bool { return parents[i].String() < parents[j].String() })
+		for _, parent := range parents {
+			if err := groups[parent].touch(db, now); err != nil {
+				return err
+			}
+		}
+		round = next
+	}
+	return nil
+}
+
+// SaveWithTouches 保存模型后更新其父模型的 updated_at
+func SaveWithTouches(db DB, model interface{}) error {
+	if err := db.Save(model).Error(); err != nil {
+		return err
+	}
+	return Touch(db, model)
+}
+
+// CreateInBatchesWithTouches 分批创建模型，全部创建后按父模型合并更新 updated_at
+func CreateInBatchesWithTouches(db DB, models interface{}, batchSize int) error {
+	if err := db.CreateInBatches(models, batchSize).Error(); err != nil {
+		return err
+	}
+	return Touch(db, models)
+}
+
+// touchGroup 同一父模型类型需要更新的主键
+type touchGroup struct {
+	parent reflect.Type
+	key    string
+	ids    []interface{}
+	loaded []reflect.Value
+}
+
+// touch 分块执行 UPDATE，并同步已加载父模型的 UpdatedAt
+func (g *touchGroup) touch(db DB, now time.Time) error {
+	if _, ok := attributeFields(g.parent)["updated_at"]; !ok {
+		return nil
+	}
+	for start := 0; start < len(g.ids); start += touchChunkSize {
+		end := start + touchChunkSize
+		if end > len(g.ids) {
+			end = len(g.ids)
+		}
+		model := reflect.New(g.parent).Interface()
+		if err := db.Model(model).Where(g.key+" IN ?", g.ids[start:end]).UpdateColumn("updated_at", now).Error(); err != nil {
+			return fmt.Errorf("database: touch %s: %w", g.parent.Name(), err)
+		}
+	}
+	for _, loaded := range g.loaded {
+		if index, ok := attributeFields(g.parent)["updated_at"]; ok {
+			if field := loaded.FieldByIndex(index); field.CanSet() && field.Type() == reflect.TypeOf(now) {
+				field.Set(reflect.ValueOf(now))
+			}
+		}
+	}
+	return nil
+}
+
+// touchOwner 需要更新的父模型
+type touchOwner struct {
+	parent reflect.Type
+	key    string
+	id     interface{}
+	loaded reflect.Value
+}
+
+// resolveOwner 按关联名找到父模型类型、主键列和外键值，外键为零值时返回 nil
+func resolveOwner(model reflect.Value, name string) (*touchOwner, error) {
+	relation, ok := relationField(model.Type(), name)
+	if !ok {
+		return nil, fmt.Errorf("database: %s touches unknown relation %q", model.Type().Name(), name)
+	}
+	parent := relation.Type
+	for parent.Kind() == reflect.Ptr {
+		parent = parent.Elem()
+	}
+	if parent.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("database: %s.%s is not a belongs-to relation", model.Type().Name(), relation.Name)
+	}
+
+	foreignKey := gormSetting(relation.Tag, "foreignKey")
+	if foreignKey == "" {
+		foreignKey = relation.Name + "ID"
+	}
+	fk, ok := model.Type().FieldByName(foreignKey)
+	if !ok {
+		return nil, fmt.Errorf("database: %s has no foreign key field %s for relation %s", model.Type().Name(), foreignKey, relation.Name)
+	}
+	id := model.FieldByIndex(fk.Index)
+	for id.Kind() == reflect.Ptr {
+		if id.IsNil() {
+			return nil, nil
+		}
+		id = id.Elem()
+	}
+	if id.IsZero() {
+		return nil, nil
+	}
+
+	owner := &touchOwner{parent: parent, key: "id", id: id.Interface()}
+	if references := gormSetting(relation.Tag, "references"); references != "" {
+		owner.key = str.Snake(references)
+	}
+	loaded := model.FieldByIndex(relation.Index)
+	for loaded.Kind() == reflect.Ptr {
+		if loaded.IsNil() {
+			return owner, nil
+		}
+		loaded = loaded.Elem()
+	}
+	owner.loaded = loaded
+	return owner, nil
+}
+
+// relationField 按字段名或属性名查找关联字段
+func relationField(t reflect.Type, name string) (reflect.StructField, bool) {
+	if field, ok := t.FieldByName(name); ok {
+		return field, true
+	}
+	if index, ok := attributeFields(t)[name]; ok {
+		return t.FieldByIndex(index), true
+	}
+	return reflect.StructField{}, false
+}
+
+// gormSetting 读取 gorm 标签中的设置，键不区分大小写
+func gormSetting(tag reflect.StructTag, key string) string {
+	for _, setting := range strings.Split(tag.Get("gorm"), ";") {
+		name, value, ok := strings.Cut(setting, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), key) {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// flattenModels 把模型、模型指针和模型切片展开为可寻址的结构体值
+func flattenModels(models []interface{}) []reflect.Value {
+	var values []reflect.Value
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			values = append(values, v)
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		}
+	}
+	for _, model := range models {
+		walk(reflect.ValueOf(model))
+	}
+	return values
+}
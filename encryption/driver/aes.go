@@ -0,0 +1,133 @@
+package driver
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/cnote0/laraveldoc/encryption"
+)
+
+// AESEncrypter AES-256-GCM 加密器，对应 Laravel 使用 aes-256-gcm 时的 Encrypter
+type AESEncrypter struct {
+	key      []byte
+	previous [][]byte
+	aeads    []cipher.AEAD
+}
+
+var _ encryption.Encrypter = (*AESEncrypter)(nil)
+
+// NewAESEncrypter 创建加密器，key 用于加密和解密，previous 只用于解密
+func NewAESEncrypter(key []byte, previous ...[]byte) (*AESEncrypter, error) {
+	e := &AESEncrypter{key: key, previous: previous}
+	for _, k := range append([][]byte{key}, previous...) {
+		if len(k) != KeySize {
+			return nil, encryption.ErrInvalidKey
+		}
+		block, err := aes.NewCipher(k)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		e.aeads = append(e.aeads, aead)
+	}
+	return e, nil
+}
+
+// Encrypt 把值序列化为 JSON 后加密
+func (e *AESEncrypter) Encrypt(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return e.encrypt(data)
+}
+
+// Decrypt 解密后把 JSON 反序列化到 dest
+func (e *AESEncrypter) Decrypt(payload string, dest interface{}) error {
+	data, err := e.decrypt(payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// EncryptString 加密字符串
+func (e *AESEncrypter) EncryptString(value string) (string, error) {
+	return e.encrypt([]byte(value))
+}
+
+// DecryptString 解密字符串
+func (e *AESEncrypter) DecryptString(payload string) (string, error) {
+	data, err := e.decrypt(payload)
+	return string(data), err
+}
+
+// GetKey 获取当前密钥
+func (e *AESEncrypter) GetKey() []byte {
+	return e.key
+}
+
+// GetPreviousKeys 获取之前的密钥
+func (e *AESEncrypter) GetPreviousKeys() [][]byte {
+	return e.previous
+}
+
+// encrypt 使用当前密钥加密，认证标签单独放在 tag 字段
+func (e *AESEncrypter) encrypt(plaintext []byte) (string, error) {
+	aead := e.aeads[0]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nil, nonce, plaintext, nil)
+	split := len(sealed) - aead.Overhead()
+	data, err := json.Marshal(encryption.Payload{
+		IV:    base64.StdEncoding.EncodeToString(nonce),
+		Value: base64.StdEncoding.EncodeToString(sealed[:split]),
+		Tag:   base64.StdEncoding.EncodeToString(sealed[split:]),
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// decrypt 依次尝试当前密钥和之前的密钥
+func (e *AESEncrypter) decrypt(payload string) ([]byte, error) {
+	nonce, sealed, err := e.parse(payload)
+	if err != nil {
+		return nil, err
+	}
+	for _, aead := range e.aeads {
+		if plaintext, err := aead.Open(nil, nonce, sealed, nil); err == nil {
+			return plaintext, nil
+		}
+	}
+	return nil, encryption.ErrDecrypt
+}
+
+// parse 解析载荷，返回 nonce 和附带认证标签的密文
+func (e *AESEncrypter) parse(payload string) ([]byte, []byte, error) {
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, nil, encryption.ErrInvalidPayload
+	}
+	var p encryption.Payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, nil, encryption.ErrInvalidPayload
+	}
+	nonce, err1 := base64.StdEncoding.DecodeString(p.IV)
+	value, err2 := base64.StdEncoding.DecodeString(p.Value)
+	tag, err3 := base64.StdEncoding.DecodeString(p.Tag)
+	if err1 != nil || err2 != nil || err3 != nil ||
+		len(nonce) != e.aeads[0].NonceSize() || len(tag) != e.aeads[0].Overhead() {
+		return nil, nil, encryption.ErrInvalidPayload
+	}
+	return nonce, append(value, tag...), nil
+}
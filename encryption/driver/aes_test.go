@@ -0,0 +1,235 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cnote0/laraveldoc/encryption"
+)
+
+// mustKey 生成随机密钥
+func mustKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// decodePayload 解码加密结果中的 Payload
+func decodePayload(t *testing.T, payload string) encryption.Payload {
+	t.Helper()
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p encryption.Payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// encodePayload 编码 Payload
+func encodePayload(t *testing.T, p encryption.Payload) string {
+	t.Helper()
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// NIST GCM 规范（McGrew & Viega）的 AES-256 测试用例 15，检查密文和认证标签在载荷中的拆分
+func TestAESEncrypterDecryptsKnownAnswer(t *testing.T) {
+	unhex := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	key := unhex("feffe9928665731c6d6a8f9467308308feffe9928665731c6d6a8f9467308308")
+	plaintext := unhex("d9313225f88406e5a55909c5aff5269a86a7a9531534f7da2e4c303d8a318a72" +
+		"1c3c0c95956809532fcf0e2449a6b525b16aedf5aa0de657ba637b391aafd255")
+	payload := encodePayload(t, encryption.Payload{
+		IV: base64.StdEncoding.EncodeToString(unhex("cafebabefacedbaddecaf888")),
+		Value: base64.StdEncoding.EncodeToString(unhex("522dc1f099567d07f47f37a32a84427d643a8cdcbfe5c0c97598a2bd2555d1aa" +
+			"8cb08e48590dbb3da7b08b1056828838c5f61e6393ba7a0abcc9f662898015ad")),
+		Tag: base64.StdEncoding.EncodeToString(unhex("b094dac5d93471bdec1a502270e3cc6c")),
+	})
+
+	e, err := NewAESEncrypter(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := e.DecryptString(payload)
+	if err != nil {
+		t.Fatalf("DecryptString() error = %v", err)
+	}
+	if !bytes.Equal([]byte(got), plaintext) {
+		t.Errorf("DecryptString() = %x, want %x", got, plaintext)
+	}
+}
+
+func TestAESEncrypterRoundTrip(t *testing.T) {
+	e, err := NewAESEncrypter(mustKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := e.EncryptString("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := decodePayload(t, payload)
+	if iv, _ := base64.StdEncoding.DecodeString(p.IV); len(iv) != 12 {
+		t.Errorf("iv is %d bytes, want 12", len(iv))
+	}
+	if tag, _ := base64.StdEncoding.DecodeString(p.Tag); len(tag) != 16 {
+		t.Errorf("tag is %d bytes, want 16", len(tag))
+	}
+	if got, err := e.DecryptString(payload); err != nil || got != "secret" {
+		t.Errorf("DecryptString() = %q, %v, want secret", got, err)
+	}
+
+	// 每次加密使用新的 nonce
+	again, err := e.EncryptString("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == payload || decodePayload(t, again).IV == p.IV {
+		t.Error("two encryptions of the same value reused the nonce")
+	}
+
+	type session struct {
+		UserID int      `json:"user_id"`
+		Roles  []string `json:"roles"`
+	}
+	encrypted, err := e.Encrypt(session{UserID: 42, Roles: []string{"admin"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decrypted session
+	if err := e.Decrypt(encrypted, &decrypted); err != nil {
+		t.Fatal(err)
+	}
+	if decrypted.UserID != 42 || len(decrypted.Roles) != 1 || decrypted.Roles[0] != "admin" {
+		t.Errorf("Decrypt() = %+v", decrypted)
+	}
+}
+
+func TestAESEncrypterKeyRotation(t *testing.T) {
+	oldKey, newKey := mustKey(t), mustKey(t)
+	old, err := NewAESEncrypter(oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := NewAESEncrypter(newKey, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacy, err := old.EncryptString("issued before rotation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := rotated.DecryptString(legacy); err != nil || got != "issued before rotation" {
+		t.Errorf("rotated.DecryptString(old payload) = %q, %v", got, err)
+	}
+
+	// 新的载荷只使用当前密钥加密
+	fresh, err := rotated.EncryptString("issued after rotation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.DecryptString(fresh); !errors.Is(err, encryption.ErrDecrypt) {
+		t.Errorf("old.DecryptString(new payload) error = %v, want ErrDecrypt", err)
+	}
+	if !bytes.Equal(rotated.GetKey(), newKey) || len(rotated.GetPreviousKeys()) != 1 {
+		t.Error("GetKey or GetPreviousKeys returned the wrong keys")
+	}
+}
+
+func TestAESEncrypterRejectsTamperedPayloads(t *testing.T) {
+	e, err := NewAESEncrypter(mustKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := e.EncryptString("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := decodePayload(t, payload)
+	flip := func(field string) string {
+		b, _ := base64.StdEncoding.DecodeString(field)
+		b[0] ^= 1
+		return base64.StdEncoding.EncodeToString(b)
+	}
+
+	tampered := map[string]encryption.Payload{
+		"value": {IV: p.IV, Value: flip(p.Value), Tag: p.Tag},
+		"tag":   {IV: p.IV, Value: p.Value, Tag: flip(p.Tag)},
+		"iv":    {IV: flip(p.IV), Value: p.Value, Tag: p.Tag},
+	}
+	for name, tp := range tampered {
+		if _, err := e.DecryptString(encodePayload(t, tp)); !errors.Is(err, encryption.ErrDecrypt) {
+			t.Errorf("tampered %s: error = %v, want ErrDecrypt", name, err)
+		}
+	}
+
+	other, err := NewAESEncrypter(mustKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.DecryptString(payload); !errors.Is(err, encryption.ErrDecrypt) {
+		t.Errorf("DecryptString with another key error = %v, want ErrDecrypt", err)
+	}
+
+	invalid := map[string]string{
+		"not base64":   "!!!",
+		"not json":     base64.StdEncoding.EncodeToString([]byte("secret")),
+		"short iv":     encodePayload(t, encryption.Payload{IV: base64.StdEncoding.EncodeToString([]byte("short")), Value: p.Value, Tag: p.Tag}),
+		"missing tag":  encodePayload(t, encryption.Payload{IV: p.IV, Value: p.Value}),
+		"bad value":    encodePayload(t, encryption.Payload{IV: p.IV, Value: "!!!", Tag: p.Tag}),
+		"empty string": "",
+	}
+	for name, value := range invalid {
+		if _, err := e.DecryptString(value); !errors.Is(err, encryption.ErrInvalidPayload) {
+			t.Errorf("%s: error = %v, want ErrInvalidPayload", name, err)
+		}
+	}
+}
+
+func TestNewAESEncrypterRejectsInvalidKeys(t *testing.T) {
+	if _, err := NewAESEncrypter(make([]byte, 16)); !errors.Is(err, encryption.ErrInvalidKey) {
+		t.Errorf("16 byte key error = %v, want ErrInvalidKey", err)
+	}
+	if _, err := NewAESEncrypter(mustKey(t), make([]byte, 31)); !errors.Is(err, encryption.ErrInvalidKey) {
+		t.Errorf("31 byte previous key error = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	key := mustKey(t)
+	formatted := FormatKey(key)
+	parsed, err := ParseKey(" " + formatted + "\n")
+	if err != nil || !bytes.Equal(parsed, key) {
+		t.Errorf("ParseKey(FormatKey(key)) = %x, %v", parsed, err)
+	}
+	if raw, err := ParseKey("0123456789abcdef0123456789abcdef"); err != nil || len(raw) != KeySize {
+		t.Errorf("ParseKey(raw) = %x, %v", raw, err)
+	}
+	if _, err := ParseKey("base64:!!!"); !errors.Is(err, encryption.ErrInvalidKey) {
+		t.Errorf("ParseKey(invalid base64) error = %v, want ErrInvalidKey", err)
+	}
+	keys, err := ParseKeys(formatted + ", ," + FormatKey(mustKey(t)))
+	if err != nil || len(keys) != 2 || !bytes.Equal(keys[0], key) {
+		t.Errorf("ParseKeys() = %d keys, %v", len(keys), err)
+	}
+}
@@ -0,0 +1,72 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// envKeyPattern 匹配 .env 中的 APP_KEY 行
+var envKeyPattern = regexp.MustCompile(`(?m)^APP_KEY=.*$`)
+
+// RegisterCommands 注册 key:generate 命令，envFile 为 .env 文件路径
+//
+// --show 只输出新密钥不写入文件；文件中已有非空 APP_KEY 时需要 --force 才会覆盖，
+// 覆盖前应把旧密钥加入 APP_PREVIOUS_KEYS，否则旧数据无法解密。
+func RegisterCommands(artisan application.ArtisanInterface, envFile string) {
+	artisan.Register("key:generate").
+		SetDescription("Set the application key").
+		AddOption("show", "", application.InputOptionValueNone, "Display the key instead of modifying files", false).
+		AddOption("force", "", application.InputOptionValueNone, "Force the operation to run when a key is already set", false).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			key, err := GenerateKey()
+			if err != nil {
+				return err
+			}
+			formatted := FormatKey(key)
+			if boolInput(input.GetOption("show")) {
+				return output.WriteLine(formatted, application.VerbosityNormal)
+			}
+			if err := WriteEnvKey(envFile, formatted, boolInput(input.GetOption("force"))); err != nil {
+				return err
+			}
+			return output.WriteLine("Application key set successfully.", application.VerbosityNormal)
+		})
+}
+
+// WriteEnvKey 把 APP_KEY 写入 .env 文件，文件不存在时创建
+func WriteEnvKey(envFile, key string, force bool) error {
+	content, err := os.ReadFile(envFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	line := "APP_KEY=" + key
+	env := string(content)
+	if current := envKeyPattern.FindString(env); current != "" {
+		if strings.TrimSpace(strings.TrimPrefix(current, "APP_KEY=")) != "" && !force {
+			return fmt.Errorf("encryption: APP_KEY is already set in %s, use --force to overwrite", envFile)
+		}
+		env = envKeyPattern.ReplaceAllLiteralString(env, line)
+	} else {
+		if env != "" && !strings.HasSuffix(env, "\n") {
+			env += "\n"
+		}
+		env += line + "\n"
+	}
+	return os.WriteFile(envFile, []byte(env), 0o600)
+}
+
+// boolInput 读取布尔选项
+func boolInput(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "0" && v != "false"
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+// Package driver 提供 encryption 包协议的参考实现
+//
+// AESEncrypter 使用标准库 crypto/aes 和 crypto/cipher 实现 AES-256-GCM，
+// 每次加密使用随机 12 字节 nonce。密钥使用 Laravel 的 "base64:" 前缀格式保存在 APP_KEY 中，
+// 之前的密钥以逗号分隔保存在 APP_PREVIOUS_KEYS 中。
+//
+// 包结构：
+// - driver.go - 密钥生成和解析
+// - aes.go - AESEncrypter
+// - command.go - key:generate 命令
+//
+// 使用示例：
+//
+//	key, _ := driver.ParseKey(os.Getenv("APP_KEY"))
+//	previous, _ := driver.ParseKeys(os.Getenv("APP_PREVIOUS_KEYS"))
+//	encrypter, err := driver.NewAESEncrypter(key, previous...)
+//	encryption.SetDefaultEncrypter(encrypter)
+package driver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/encryption"
+)
+
+// KeySize AES-256 密钥长度
+const KeySize = 32
+
+// keyPrefix 密钥字符串的前缀
+const keyPrefix = "base64:"
+
+// GenerateKey 生成随机密钥
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// FormatKey 把密钥格式化为 "base64:..." 字符串
+func FormatKey(key []byte) string {
+	return keyPrefix + base64.StdEncoding.EncodeToString(key)
+}
+
+// ParseKey 解析密钥字符串，支持 "base64:" 前缀，没有前缀时按原始字节使用
+func ParseKey(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, keyPrefix) {
+		return []byte(value), nil
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, keyPrefix))
+	if err != nil {
+		return nil, encryption.ErrInvalidKey
+	}
+	return key, nil
+}
+
+// ParseKeys 解析逗号分隔的多个密钥，忽略空项
+func ParseKeys(value string) ([][]byte, error) {
+	var keys [][]byte
+	for _, item := range strings.Split(value, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		key, err := ParseKey(item)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
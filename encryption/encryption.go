@@ -0,0 +1,88 @@
+// Package encryption 提供 Laravel 风格的加密协议定义
+//
+// Encrypter 使用应用密钥对数据进行认证加密，EncryptString/DecryptString 处理字符串，
+// Encrypt/Decrypt 先把值序列化为 JSON 再加密。密文为 base64 编码的 JSON 载荷，
+// 包含 iv、value、mac、tag 字段，与 Laravel 的 aes-256-gcm 载荷格式一致。
+// 解密时依次尝试当前密钥和之前的密钥，用于在不使旧数据失效的前提下轮换 APP_KEY。
+//
+// 包结构：
+// - encryption.go - Encrypter 接口、Payload 载荷、错误和默认加密器
+//
+// 子包 driver 提供 AES-256-GCM 实现、密钥生成解析和 key:generate 命令。
+//
+// 使用示例：
+//
+//	encrypter := container.MustMake("encrypter").(encryption.Encrypter)
+//	payload, _ := encrypter.EncryptString("secret")
+//	plain, err := encrypter.DecryptString(payload)
+//
+//	_ = encrypter.Encrypt(map[string]int{"id": 1})
+//	var value map[string]int
+//	err = encrypter.Decrypt(payload, &value)
+package encryption
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var (
+	// ErrNoEncrypter 没有设置默认加密器
+	ErrNoEncrypter = errors.New("encryption: no default encrypter, call encryption.SetDefaultEncrypter")
+
+	// ErrInvalidPayload 载荷格式无效
+	ErrInvalidPayload = errors.New("encryption: the payload is invalid")
+
+	// ErrDecrypt 载荷无法用任何密钥解密，密钥错误或数据被篡改
+	ErrDecrypt = errors.New("encryption: could not decrypt the data")
+
+	// ErrInvalidKey 密钥长度不符合加密算法的要求
+	ErrInvalidKey = errors.New("encryption: invalid key length")
+)
+
+// Encrypter 加密器接口，对应 Laravel 的 Illuminate\Contracts\Encryption\Encrypter 和 StringEncrypter
+type Encrypter interface {
+	// Encrypt 把值序列化为 JSON 后加密
+	Encrypt(value interface{}) (string, error)
+
+	// Decrypt 解密后把 JSON 反序列化到 dest
+	Decrypt(payload string, dest interface{}) error
+
+	// EncryptString 加密字符串，不进行序列化
+	EncryptString(value string) (string, error)
+
+	// DecryptString 解密字符串
+	DecryptString(payload string) (string, error)
+
+	// GetKey 获取当前密钥
+	GetKey() []byte
+
+	// GetPreviousKeys 获取之前的密钥，只用于解密
+	GetPreviousKeys() [][]byte
+}
+
+// Payload 密文载荷，各字段均为 base64 编码
+//
+// GCM 模式下 mac 为空、tag 为认证标签，与 Laravel 保持一致。
+type Payload struct {
+	IV    string `json:"iv"`
+	Value string `json:"value"`
+	MAC   string `json:"mac"`
+	Tag   string `json:"tag"`
+}
+
+// defaultEncrypter 默认加密器
+var defaultEncrypter atomic.Value
+
+// SetDefaultEncrypter 设置默认加密器
+func SetDefaultEncrypter(encrypter Encrypter) {
+	defaultEncrypter.Store(&encrypter)
+}
+
+// DefaultEncrypter 获取默认加密器，未设置时返回 nil
+func DefaultEncrypter() Encrypter {
+	if encrypter, ok := defaultEncrypter.Load().(*Encrypter); ok {
+		return *encrypter
+	}
+	return nil
+}
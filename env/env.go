@@ -0,0 +1,353 @@
+// Package env 提供 Laravel 风格的环境变量读取，对应 env() 辅助函数和 vlucas/phpdotenv
+//
+// Repository 从进程环境变量和 .env 文件读取配置，进程环境变量优先，
+// 后加载的文件覆盖先加载的文件（.env.local 覆盖 .env）。
+// 读取是空值安全的：变量未定义、值为 null 或 (null) 时返回默认值，类型转换失败时同样返回默认值，
+// 并在 Report 中列出；必需的变量在启动时用 Require 或 MustString 校验，而不是在第一次使用时才发现缺失。
+//
+// 主要特性：
+// - String、Int、Bool、Float、Duration、List 类型化读取和默认值
+// - Require 一次列出全部缺失的必需变量，MustString 缺失时 panic
+// - .env 解析：注释、export、单双引号、多行值、转义和 ${NAME} 变量替换
+// - Report 列出读取了但未定义、定义了但未读取和格式无效的变量，调试模式下在应用终止时输出
+//
+// 包结构：
+// - env.go - Repository、类型化读取、Require 和默认实例
+// - parse.go - Parse .env 解析和 ParseError
+// - report.go - Report 未定义、未使用和无效变量报告
+// - provider.go - ServiceProvider 加载文件、校验必需变量和调试报告
+//
+// 使用示例：
+//
+//	if err := env.Load(".env", ".env.local"); err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := env.Require("APP_KEY", "DB_PASSWORD"); err != nil {
+//		log.Fatal(err) // env: missing required variables: APP_KEY, DB_PASSWORD
+//	}
+//
+//	key := env.MustString("APP_KEY")
+//	debug := env.Bool("APP_DEBUG", false)
+//	port := env.Int("DB_PORT", 3306)
+//	timeout := env.Duration("HTTP_TIMEOUT", 30*time.Second) // "30s" 或表示秒数的 "30"
+//	hosts := env.List("TRUSTED_HOSTS", nil)                // 逗号分隔
+//
+//	// .env
+//	// APP_NAME="Laraveldoc App"
+//	// APP_URL=https://example.com
+//	// ASSET_URL=${APP_URL}/assets  # 行内注释
+//	// PRIVATE_KEY="-----BEGIN KEY-----
+//	// ...
+//	// -----END KEY-----"
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrMissing 必需的变量未定义或为空，MissingError 满足 errors.Is
+var ErrMissing = errors.New("env: missing required variable")
+
+// MissingError 缺失的必需变量
+type MissingError struct {
+	// Keys 缺失的变量名，按 Require 的参数顺序
+	Keys []string
+}
+
+// Error 实现 error 接口
+func (e *MissingError) Error() string {
+	if len(e.Keys) == 1 {
+		return "env: missing required variable " + e.Keys[0]
+	}
+	return "env: missing required variables: " + strings.Join(e.Keys, ", ")
+}
+
+// Is 匹配 ErrMissing
+func (e *MissingError) Is(target error) bool {
+	return target == ErrMissing
+}
+
+// Repository 环境变量仓库
+//
+// 方法并发安全。读取的变量名被记录下来，用于 Report。
+type Repository struct {
+	mu      sync.Mutex
+	lookup  func(string) (string, bool)
+	values  map[string]string
+	sources map[string]string
+	read    map[string]bool
+	invalid map[string]string
+}
+
+// New 创建从进程环境变量读取的仓库
+func New() *Repository {
+	return NewWithLookup(os.LookupEnv)
+}
+
+// NewWithLookup 创建从 lookup 读取的仓库，lookup 为 nil 时只读取加载的文件，用于测试
+func NewWithLookup(lookup func(string) (string, bool)) *Repository {
+	if lookup == nil {
+		lookup = func(string) (string, bool) { return "", false }
+	}
+	return &Repository{
+		lookup:  lookup,
+		values:  make(map[string]string),
+		sources: make(map[string]string),
+		read:    make(map[string]bool),
+		invalid: make(map[string]string),
+	}
+}
+
+// Load 按顺序加载 .env 文件，后加载的文件覆盖先加载的值，不存在的文件被跳过
+//
+// 文件中的 ${NAME} 可以引用进程环境变量和之前加载的文件中的变量。
+func (r *Repository) Load(files ...string) error {
+	for _, file := range files {
+		f, err := os.Open(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("env: %w", err)
+		}
+		values, err := parse(f, file, r.lookupLoaded)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		r.mu.Lock()
+		for key, value := range values {
+			r.values[key] = value
+			r.sources[key] = file
+		}
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// lookupLoaded 解析时替换 ${NAME}：进程环境变量优先，其次为已加载的值
+func (r *Repository) lookupLoaded(key string) (string, bool) {
+	if value, ok := r.lookup(key); ok {
+		return value, true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.values[key]
+	return value, ok
+}
+
+// Set 设置变量，覆盖文件中的值，进程环境变量仍然优先；用于测试
+func (r *Repository) Set(key, value string) {
+	r.mu.Lock()
+	r.values[key] = value
+	r.sources[key] = ""
+	r.mu.Unlock()
+}
+
+// Lookup 读取变量，未定义或值为 null、(null) 时返回 false
+//
+// 值为 empty 或 (empty) 时返回空字符串。
+func (r *Repository) Lookup(key string) (string, bool) {
+	r.mu.Lock()
+	r.read[key] = true
+	value, ok := r.values[key]
+	r.mu.Unlock()
+	if v, found := r.lookup(key); found {
+		value, ok = v, true
+	}
+	if !ok {
+		return "", false
+	}
+	switch strings.ToLower(value) {
+	case "null", "(null)":
+		return "", false
+	case "empty", "(empty)":
+		return "", true
+	}
+	return value, true
+}
+
+// String 读取字符串，未定义时返回 fallback
+func (r *Repository) String(key, fallback string) string {
+	if value, ok := r.Lookup(key); ok {
+		return value
+	}
+	return fallback
+}
+
+// Int 读取整数，未定义或不是整数时返回 fallback
+func (r *Repository) Int(key string, fallback int) int {
+	return typed(r, key, fallback, strconv.Atoi)
+}
+
+// Float 读取浮点数，未定义或不是数字时返回 fallback
+func (r *Repository) Float(key string, fallback float64) float64 {
+	return typed(r, key, fallback, func(value string) (float64, error) {
+		return strconv.ParseFloat(value, 64)
+	})
+}
+
+// Bool 读取布尔值，true、(true)、1、yes、on 为 true，false、(false)、0、no、off 和空值为 false，
+// 未定义或无法识别时返回 fallback
+func (r *Repository) Bool(key string, fallback bool) bool {
+	return typed(r, key, fallback, func(value string) (bool, error) {
+		switch strings.ToLower(value) {
+		case "true", "(true)", "1", "yes", "on":
+			return true, nil
+		case "false", "(false)", "0", "no", "off", "":
+			return false, nil
+		}
+		return false, errors.New("not a boolean")
+	})
+}
+
+// Duration 读取时长，支持 "1m30s" 形式和表示秒数的整数，未定义或格式无效时返回 fallback
+func (r *Repository) Duration(key string, fallback time.Duration) time.Duration {
+	return typed(r, key, fallback, func(value string) (time.Duration, error) {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second, nil
+		}
+		return time.ParseDuration(value)
+	})
+}
+
+// List 读取逗号分隔的列表，去掉每项首尾空白和空项，未定义时返回 fallback
+func (r *Repository) List(key string, fallback []string) []string {
+	value, ok := r.Lookup(key)
+	if !ok {
+		return fallback
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// typed 读取并转换变量，转换失败时记录到 Report 并返回 fallback
+func typed[T any](r *Repository, key string, fallback T, convert func(string) (T, error)) T {
+	value, ok := r.Lookup(key)
+	if !ok {
+		return fallback
+	}
+	converted, err := convert(strings.TrimSpace(value))
+	if err != nil {
+		r.mu.Lock()
+		r.invalid[key] = value
+		r.mu.Unlock()
+		return fallback
+	}
+	return converted
+}
+
+// Require 校验必需的变量都已定义且不为空，返回列出全部缺失变量的 *MissingError
+func (r *Repository) Require(keys ...string) error {
+	var missing []string
+	for _, key := range keys {
+		if value, ok := r.Lookup(key); !ok || value == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingError{Keys: missing}
+	}
+	return nil
+}
+
+// MustString 读取必需的字符串，未定义或为空时以 *MissingError panic
+func (r *Repository) MustString(key string) string {
+	if err := r.Require(key); err != nil {
+		panic(err)
+	}
+	value, _ := r.Lookup(key)
+	return value
+}
+
+// Keys 加载的文件和 Set 定义的变量名，已排序
+func (r *Repository) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make([]string, 0, len(r.values))
+	for key := range r.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// defaultRepository 默认仓库
+var defaultRepository atomic.Value
+
+func init() {
+	defaultRepository.Store(New())
+}
+
+// SetDefault 设置默认仓库
+func SetDefault(repository *Repository) {
+	defaultRepository.Store(repository)
+}
+
+// Default 默认仓库，初始为读取进程环境变量的 New()
+func Default() *Repository {
+	return defaultRepository.Load().(*Repository)
+}
+
+// Load 向默认仓库加载 .env 文件
+func Load(files ...string) error {
+	return Default().Load(files...)
+}
+
+// Lookup 从默认仓库读取变量
+func Lookup(key string) (string, bool) {
+	return Default().Lookup(key)
+}
+
+// String 从默认仓库读取字符串
+func String(key, fallback string) string {
+	return Default().String(key, fallback)
+}
+
+// Int 从默认仓库读取整数
+func Int(key string, fallback int) int {
+	return Default().Int(key, fallback)
+}
+
+// Float 从默认仓库读取浮点数
+func Float(key string, fallback float64) float64 {
+	return Default().Float(key, fallback)
+}
+
+// Bool 从默认仓库读取布尔值
+func Bool(key string, fallback bool) bool {
+	return Default().Bool(key, fallback)
+}
+
+// Duration 从默认仓库读取时长
+func Duration(key string, fallback time.Duration) time.Duration {
+	return Default().Duration(key, fallback)
+}
+
+// List 从默认仓库读取逗号分隔的列表
+func List(key string, fallback []string) []string {
+	return Default().List(key, fallback)
+}
+
+// Require 校验默认仓库中必需的变量
+func Require(keys ...string) error {
+	return Default().Require(keys...)
+}
+
+// MustString 从默认仓库读取必需的字符串，缺失时 panic
+func MustString(key string) string {
+	return Default().MustString(key)
+}
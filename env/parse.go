@@ -0,0 +1,268 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ParseError .env 文件格式错误
+type ParseError struct {
+	// File 文件名，Parse 解析的内容为空
+	File string
+
+	// Line 出错的行号，从 1 开始
+	Line int
+
+	// Message 错误说明
+	Message string
+}
+
+// Error 实现 error 接口
+func (e *ParseError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("env: line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("env: %s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// Parse 解析 .env 格式的内容，返回变量名到值的映射
+//
+// 支持的格式与 vlucas/phpdotenv 一致：
+//   - 空行和以 # 开头的注释行被忽略，行首可以有 export
+//   - 未加引号的值去掉首尾空白，空白后的 # 开始行内注释
+//   - 单引号内的值按字面保留，可以跨行
+//   - 双引号内的值可以跨行，支持 \n、\r、\t、\"、\\、\$ 转义
+//   - 未加引号和双引号的值中 ${NAME} 替换为进程环境变量或之前定义的变量，进程环境变量优先
+func Parse(r io.Reader) (map[string]string, error) {
+	return parse(r, "", os.LookupEnv)
+}
+
+// parse 解析内容，lookup 优先于文件中之前定义的变量用于替换 ${NAME}
+func parse(r io.Reader, file string, lookup func(string) (string, bool)) (map[string]string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{
+		src:    strings.ReplaceAll(string(content), "\r\n", "\n"),
+		file:   file,
+		line:   1,
+		lookup: lookup,
+		values: make(map[string]string),
+	}
+	if err := p.run(); err != nil {
+		return nil, err
+	}
+	return p.values, nil
+}
+
+// parser .env 内容的解析状态
+type parser struct {
+	src    string
+	pos    int
+	file   string
+	line   int
+	lookup func(string) (string, bool)
+	values map[string]string
+}
+
+// errorf 当前行的格式错误
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &ParseError{File: p.file, Line: p.line, Message: fmt.Sprintf(format, args...)}
+}
+
+// run 逐条解析赋值语句
+func (p *parser) run() error {
+	for p.pos < len(p.src) {
+		line := p.src[p.pos:]
+		if end := strings.IndexByte(line, '\n'); end >= 0 {
+			line = line[:end]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			p.advance(len(line))
+			p.newline()
+			continue
+		}
+		p.advance(len(line) - len(strings.TrimLeft(line, " \t")))
+		if err := p.assignment(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// advance 前进 n 个字节并统计换行
+func (p *parser) advance(n int) {
+	p.line += strings.Count(p.src[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// newline 跳过当前位置的换行
+func (p *parser) newline() {
+	if p.pos < len(p.src) && p.src[p.pos] == '\n' {
+		p.pos++
+		p.line++
+	}
+}
+
+// assignment 解析 [export] NAME=VALUE
+func (p *parser) assignment() error {
+	rest := p.src[p.pos:]
+	if strings.HasPrefix(rest, "export ") || strings.HasPrefix(rest, "export\t") {
+		rest = strings.TrimLeft(rest[len("export"):], " \t")
+		p.pos = len(p.src) - len(rest)
+	}
+	eq := strings.IndexAny(rest, "=\n")
+	if eq < 0 || rest[eq] != '=' {
+		return p.errorf("expected NAME=VALUE")
+	}
+	name := strings.TrimRight(rest[:eq], " \t")
+	if !validName(name) {
+		return p.errorf("invalid variable name %q", name)
+	}
+	p.advance(eq + 1)
+	p.advance(len(p.src[p.pos:]) - len(strings.TrimLeft(p.src[p.pos:], " \t")))
+
+	var value string
+	var err error
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "'"):
+		value, err = p.singleQuoted()
+	case strings.HasPrefix(p.src[p.pos:], `"`):
+		value, err = p.doubleQuoted()
+	default:
+		value = p.unquoted()
+	}
+	if err != nil {
+		return err
+	}
+	p.values[name] = value
+	return nil
+}
+
+// validName 变量名是否由字母、数字、下划线和点组成，且不以数字开头
+func validName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, c := range name {
+		if !(c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// unquoted 读取未加引号的值到行尾，去掉行内注释
+func (p *parser) unquoted() string {
+	line := p.src[p.pos:]
+	if end := strings.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+	}
+	p.advance(len(line))
+	p.newline()
+	value := line
+	for i := 0; i < len(value); i++ {
+		if value[i] == '#' && (i == 0 || value[i-1] == ' ' || value[i-1] == '\t') {
+			value = value[:i]
+			break
+		}
+	}
+	return p.interpolate(strings.TrimSpace(value))
+}
+
+// singleQuoted 读取单引号内的字面值
+func (p *parser) singleQuoted() (string, error) {
+	start := p.line
+	end := strings.IndexByte(p.src[p.pos+1:], '\'')
+	if end < 0 {
+		p.line = start
+		return "", p.errorf("unterminated single-quoted value")
+	}
+	value := p.src[p.pos+1 : p.pos+1+end]
+	p.advance(end + 2)
+	return value, p.trailing()
+}
+
+// doubleQuoted 读取双引号内的值，处理转义和变量替换
+func (p *parser) doubleQuoted() (string, error) {
+	start := p.line
+	var b strings.Builder
+	i := p.pos + 1
+	for ; i < len(p.src) && p.src[i] != '"'; i++ {
+		c := p.src[i]
+		if c != '\\' || i+1 >= len(p.src) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch p.src[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\':
+			b.WriteByte(p.src[i])
+		case '$':
+			// 转义的 $ 不参与变量替换，先替换为占位符
+			b.WriteString("\x00")
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(p.src[i])
+		}
+	}
+	if i >= len(p.src) {
+		p.line = start
+		return "", p.errorf("unterminated double-quoted value")
+	}
+	p.advance(i + 1 - p.pos)
+	value := strings.ReplaceAll(p.interpolate(b.String()), "\x00", "$")
+	return value, p.trailing()
+}
+
+// trailing 引号后只能有空白和注释
+func (p *parser) trailing() error {
+	line := p.src[p.pos:]
+	if end := strings.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+	}
+	if rest := strings.TrimSpace(line); rest != "" && !strings.HasPrefix(rest, "#") {
+		return p.errorf("unexpected %q after quoted value", rest)
+	}
+	p.advance(len(line))
+	p.newline()
+	return nil
+}
+
+// interpolate 把 ${NAME} 替换为 lookup 的值或之前定义的变量，都未定义时替换为空
+func (p *parser) interpolate(value string) string {
+	if !strings.Contains(value, "${") {
+		return value
+	}
+	var b strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(value[start:], '}')
+		if end < 0 {
+			break
+		}
+		b.WriteString(value[:start])
+		name := value[start+2 : start+end]
+		if v, ok := p.lookup(name); ok {
+			b.WriteString(v)
+		} else if v, ok := p.values[name]; ok {
+			b.WriteString(v)
+		}
+		value = value[start+end+1:]
+	}
+	b.WriteString(value)
+	return b.String()
+}
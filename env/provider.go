@@ -0,0 +1,76 @@
+package env
+
+import (
+	"io"
+	"os"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// ServiceProvider 加载 .env 文件、校验必需变量，并以 "env" 绑定仓库
+//
+// 应放在其他服务提供者之前注册，缺失必需变量时 Register 返回 *MissingError，应用启动失败。
+// 容器为 application.Application 且 IsDebug 为 true 时，应用终止时把 Report 写入 Output。
+type ServiceProvider struct {
+	// Repository 使用的仓库，为空时使用 Default()
+	Repository *Repository
+
+	// Files 按顺序加载的文件，为空时为 .env 和 .env.local
+	Files []string
+
+	// Required 必需的变量
+	Required []string
+
+	// Output 调试报告的输出，为空时为 os.Stderr
+	Output io.Writer
+}
+
+// Register 加载文件、校验必需变量并绑定仓库
+func (p *ServiceProvider) Register(c container.Container) error {
+	if p.Repository == nil {
+		p.Repository = Default()
+	}
+	files := p.Files
+	if len(files) == 0 {
+		files = []string{".env", ".env.local"}
+	}
+	if err := p.Repository.Load(files...); err != nil {
+		return err
+	}
+	if err := p.Repository.Require(p.Required...); err != nil {
+		return err
+	}
+	return c.Instance("env", p.Repository)
+}
+
+// Boot 调试模式下注册终止回调，输出变量使用报告
+func (p *ServiceProvider) Boot(c container.Container) error {
+	app, ok := c.(application.Application)
+	if !ok || !app.IsDebug() {
+		return nil
+	}
+	app.Terminating(func() error {
+		report := p.Repository.Report()
+		if report.Empty() {
+			return nil
+		}
+		output := p.Output
+		if output == nil {
+			output = os.Stderr
+		}
+		_, err := io.WriteString(output, report.String())
+		return err
+	})
+	return nil
+}
+
+// Provides 不提供延迟加载的服务
+func (p *ServiceProvider) Provides() []string {
+	return nil
+}
+
+// IsDeferred 不延迟加载
+func (p *ServiceProvider) IsDeferred() bool {
+	return false
+}
@@ -0,0 +1,78 @@
+package env
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Report 变量使用报告，用于发现拼写错误和过时的 .env 项
+type Report struct {
+	// Unknown 读取了但进程环境变量和 .env 文件都没有定义的变量
+	Unknown []string
+
+	// Unused .env 文件中定义了但从未读取的变量
+	Unused []string
+
+	// Invalid 值无法转换为读取类型的变量，键为变量名，值为原始值
+	Invalid map[string]string
+}
+
+// Report 当前的变量使用报告，各列表已排序
+//
+// 报告只反映到目前为止的读取，通常在应用启动完成或终止时生成。
+func (r *Repository) Report() Report {
+	r.mu.Lock()
+	read := make([]string, 0, len(r.read))
+	for key := range r.read {
+		read = append(read, key)
+	}
+	report := Report{Invalid: make(map[string]string, len(r.invalid))}
+	for key, source := range r.sources {
+		if source != "" && !r.read[key] {
+			report.Unused = append(report.Unused, key)
+		}
+	}
+	for key, value := range r.invalid {
+		report.Invalid[key] = value
+	}
+	defined := make(map[string]bool, len(read))
+	for _, key := range read {
+		_, defined[key] = r.values[key]
+	}
+	r.mu.Unlock()
+
+	for _, key := range read {
+		if _, ok := r.lookup(key); !ok && !defined[key] {
+			report.Unknown = append(report.Unknown, key)
+		}
+	}
+	sort.Strings(report.Unknown)
+	sort.Strings(report.Unused)
+	return report
+}
+
+// Empty 报告中没有任何变量
+func (r Report) Empty() bool {
+	return len(r.Unknown) == 0 && len(r.Unused) == 0 && len(r.Invalid) == 0
+}
+
+// String 多行的可读报告，没有问题时为空字符串
+func (r Report) String() string {
+	var b strings.Builder
+	if len(r.Unknown) > 0 {
+		fmt.Fprintf(&b, "env: read but not defined: %s\n", strings.Join(r.Unknown, ", "))
+	}
+	if len(r.Unused) > 0 {
+		fmt.Fprintf(&b, "env: defined but never read: %s\n", strings.Join(r.Unused, ", "))
+	}
+	keys := make([]string, 0, len(r.Invalid))
+	for key := range r.Invalid {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "env: invalid value for %s: %q\n", key, r.Invalid[key])
+	}
+	return b.String()
+}
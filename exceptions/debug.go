@@ -0,0 +1,230 @@
+package exceptions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// StackTracer 携带调用栈的错误，返回 runtime.Callers 得到的程序计数器
+type StackTracer interface {
+	StackTrace() []uintptr
+}
+
+// stackError WithStack 包装的错误
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+// WithStack 记录当前调用栈并包装错误，调试页面据此显示栈帧，err 为 nil 时返回 nil
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &stackError{err: err, pcs: callers(3)}
+}
+
+func (e *stackError) Error() string         { return e.err.Error() }
+func (e *stackError) Unwrap() error         { return e.err }
+func (e *stackError) StackTrace() []uintptr { return e.pcs }
+
+// callers 记录调用栈，skip 与 runtime.Callers 相同
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, 64)
+	return pcs[:runtime.Callers(skip, pcs)]
+}
+
+// Frame 调用栈中的一帧
+type Frame struct {
+	// Function 函数全名
+	Function string `json:"function"`
+
+	// File 源文件路径
+	File string `json:"file"`
+
+	// Line 行号
+	Line int `json:"line"`
+
+	// Application 是否为应用代码（非标准库和运行时）
+	Application bool `json:"-"`
+
+	// Snippet 出错行附近的源码
+	Snippet []SourceLine `json:"-"`
+}
+
+// SourceLine 一行源码
+type SourceLine struct {
+	Number  int
+	Code    string
+	Current bool
+}
+
+// DebugException 错误链中的一个错误
+type DebugException struct {
+	Type    string `json:"exception"`
+	Message string `json:"message"`
+}
+
+// DebugBinding 容器中的一个绑定
+type DebugBinding struct {
+	Abstract string
+	Concrete string
+	Shared   bool
+}
+
+// DebugReport 调试页面的数据
+type DebugReport struct {
+	Status     int
+	Title      string
+	Chain      []DebugException
+	Frames     []Frame
+	Method     string
+	URL        string
+	Headers    [][2]string
+	Query      [][2]string
+	Form       [][2]string
+	Bindings   []DebugBinding
+	GoVersion  string
+	StackFound bool
+}
+
+// sensitiveHeaders 调试页面中隐藏值的请求头
+var sensitiveHeaders = map[string]bool{"Authorization": true, "Cookie": true, "Proxy-Authorization": true, "X-Csrf-Token": true, "X-Xsrf-Token": true}
+
+// stackTrace 错误链中最内层的调用栈，PanicError 的调用栈优先
+func stackTrace(err error) []uintptr {
+	var panicked *PanicError
+	if errors.As(err, &panicked) && len(panicked.pcs) > 0 {
+		return panicked.pcs
+	}
+	var pcs []uintptr
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if tracer, ok := e.(StackTracer); ok {
+			pcs = tracer.StackTrace()
+		}
+	}
+	return pcs
+}
+
+// Frames 错误记录的调用栈帧，不附带源码，错误没有通过 WithStack 或 panic 记录调用栈时返回 nil
+func Frames(err error) []Frame {
+	return frames(stackTrace(err), 0)
+}
+
+// frames 把程序计数器转换为栈帧，去掉运行时和本包的帧，snippet 为附带的源码行数（前后各 snippet 行）
+func frames(pcs []uintptr, snippet int) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+	var result []Frame
+	iter := runtime.CallersFrames(pcs)
+	for {
+		f, more := iter.Next()
+		if f.Function != "" && !strings.HasPrefix(f.Function, "runtime.") && !strings.HasPrefix(f.Function, packagePath+".") {
+			frame := Frame{Function: f.Function, File: f.File, Line: f.Line, Application: !isStandardLibrary(f.Function)}
+			if snippet > 0 && frame.Application {
+				frame.Snippet = readSnippet(f.File, f.Line, snippet)
+			}
+			result = append(result, frame)
+		}
+		if !more {
+			return result
+		}
+	}
+}
+
+// packagePath 本包的导入路径
+var packagePath = reflect.TypeOf(Handler{}).PkgPath()
+
+// isStandardLibrary 函数是否属于标准库：导入路径的第一段不含点号且不是 main
+func isStandardLibrary(function string) bool {
+	if first, _, found := strings.Cut(function, "/"); found {
+		return !strings.Contains(first, ".")
+	}
+	first, _, _ := strings.Cut(function, ".")
+	return first != "main"
+}
+
+// readSnippet 读取 line 前后各 around 行源码，文件不可读时返回 nil
+func readSnippet(file string, line, around int) []SourceLine {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(string(content), "\n")
+	start, end := max(line-around, 1), min(line+around, len(lines))
+	snippet := make([]SourceLine, 0, end-start+1)
+	for n := start; n <= end; n++ {
+		snippet = append(snippet, SourceLine{Number: n, Code: lines[n-1], Current: n == line})
+	}
+	return snippet
+}
+
+// chain 错误链，WithStack 的包装不计入
+func chain(err error) []DebugException {
+	var result []DebugException
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if _, ok := e.(*stackError); !ok {
+			result = append(result, DebugException{Type: reflect.TypeOf(e).String(), Message: e.Error()})
+		}
+	}
+	return result
+}
+
+// DebugReport 收集错误、调用栈、请求和容器绑定信息，r 可以为 nil
+func (h *Handler) DebugReport(r *http.Request, err error) *DebugReport {
+	status := h.StatusCode(err)
+	pcs := stackTrace(err)
+	report := &DebugReport{
+		Status:     status,
+		Title:      http.StatusText(status),
+		Chain:      chain(err),
+		Frames:     frames(pcs, 6),
+		GoVersion:  runtime.Version(),
+		StackFound: len(pcs) > 0,
+	}
+	if r != nil {
+		report.Method = r.Method
+		report.URL = r.URL.String()
+		for name, values := range r.Header {
+			value := strings.Join(values, ", ")
+			if sensitiveHeaders[name] {
+				value = "********"
+			}
+			report.Headers = append(report.Headers, [2]string{name, value})
+		}
+		report.Query = pairs(r.URL.Query())
+		report.Form = pairs(r.PostForm)
+		sort.Slice(report.Headers, func(i, j int) bool { return report.Headers[i][0] < report.Headers[j][0] })
+	}
+	h.mu.RLock()
+	app := h.app
+	h.mu.RUnlock()
+	if app != nil {
+		for abstract, binding := range app.GetBindings() {
+			report.Bindings = append(report.Bindings, DebugBinding{
+				Abstract: fmt.Sprint(abstract),
+				Concrete: fmt.Sprintf("%T", binding.Concrete),
+				Shared:   binding.Shared,
+			})
+		}
+		sort.Slice(report.Bindings, func(i, j int) bool { return report.Bindings[i].Abstract < report.Bindings[j].Abstract })
+	}
+	return report
+}
+
+// pairs 把多值 map 转换为按名称排序的键值对
+func pairs(values map[string][]string) [][2]string {
+	result := make([][2]string, 0, len(values))
+	for name, list := range values {
+		result = append(result, [2]string{name, strings.Join(list, ", ")})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i][0] < result[j][0] })
+	return result
+}
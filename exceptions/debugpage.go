@@ -0,0 +1,71 @@
+package exceptions
+
+import (
+	"html/template"
+	"io"
+)
+
+// debugPage 调试错误页模板
+var debugPage = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ (index .Chain 0).Message }}</title>
+<style>
+body { margin: 0; font: 14px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; background: #f3f4f6; color: #111827; }
+header { background: #fff; border-bottom: 4px solid #ef4444; padding: 24px 32px; }
+header .type { color: #6b7280; font-family: ui-monospace, monospace; }
+header h1 { margin: 8px 0 0; font-size: 22px; }
+section { background: #fff; margin: 16px 32px; padding: 16px 24px; border-radius: 6px; }
+h2 { font-size: 15px; margin: 0 0 12px; text-transform: uppercase; letter-spacing: .05em; color: #374151; }
+.chain li { margin-bottom: 4px; }
+.frame { border-top: 1px solid #e5e7eb; padding: 8px 0; }
+.frame.vendor { color: #9ca3af; }
+.frame .fn { font-family: ui-monospace, monospace; font-weight: 600; }
+.frame .loc { font-family: ui-monospace, monospace; font-size: 12px; color: #6b7280; }
+pre { margin: 8px 0 0; background: #1f2937; color: #e5e7eb; padding: 8px 0; border-radius: 4px; overflow-x: auto; font-size: 12px; }
+pre span { display: block; padding: 0 12px; }
+pre span.current { background: #7f1d1d; }
+pre em { font-style: normal; color: #6b7280; display: inline-block; width: 48px; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+td { border-top: 1px solid #e5e7eb; padding: 4px 8px; vertical-align: top; font-family: ui-monospace, monospace; word-break: break-all; }
+td:first-child { width: 30%; color: #374151; }
+.muted { color: #6b7280; }
+</style>
+</head>
+<body>
+<header>
+<div class="type">{{ (index .Chain 0).Type }} · {{ .Status }} {{ .Title }} · {{ .GoVersion }}</div>
+<h1>{{ (index .Chain 0).Message }}</h1>
+</header>
+{{ if gt (len .Chain) 1 }}<section class="chain">
+<h2>Exception chain</h2>
+<ol>{{ range .Chain }}<li><strong>{{ .Type }}</strong>: {{ .Message }}</li>{{ end }}</ol>
+</section>{{ end }}
+<section>
+<h2>Stack frames</h2>
+{{ if .StackFound }}{{ range .Frames }}<div class="frame{{ if not .Application }} vendor{{ end }}">
+<div class="fn">{{ .Function }}</div>
+<div class="loc">{{ .File }}:{{ .Line }}</div>
+{{ if .Snippet }}<pre>{{ range .Snippet }}<span{{ if .Current }} class="current"{{ end }}><em>{{ .Number }}</em>{{ .Code }}</span>{{ end }}</pre>{{ end }}
+</div>{{ end }}{{ else }}<p class="muted">The error carries no stack trace. Wrap it with exceptions.WithStack where it is created to see frames here.</p>{{ end }}
+</section>
+{{ if .Method }}<section>
+<h2>Request</h2>
+<table><tr><td>Method</td><td>{{ .Method }}</td></tr><tr><td>URL</td><td>{{ .URL }}</td></tr></table>
+{{ if .Headers }}<h2 style="margin-top:16px">Headers</h2><table>{{ range .Headers }}<tr><td>{{ index . 0 }}</td><td>{{ index . 1 }}</td></tr>{{ end }}</table>{{ end }}
+{{ if .Query }}<h2 style="margin-top:16px">Query</h2><table>{{ range .Query }}<tr><td>{{ index . 0 }}</td><td>{{ index . 1 }}</td></tr>{{ end }}</table>{{ end }}
+{{ if .Form }}<h2 style="margin-top:16px">Form</h2><table>{{ range .Form }}<tr><td>{{ index . 0 }}</td><td>{{ index . 1 }}</td></tr>{{ end }}</table>{{ end }}
+</section>{{ end }}
+{{ if .Bindings }}<section>
+<h2>Container bindings</h2>
+<table>{{ range .Bindings }}<tr><td>{{ .Abstract }}</td><td>{{ .Concrete }}{{ if .Shared }} <span class="muted">(shared)</span>{{ end }}</td></tr>{{ end }}</table>
+</section>{{ end }}
+</body>
+</html>
+`))
+
+// renderDebugPage 渲染调试错误页
+func renderDebugPage(w io.Writer, report *DebugReport) error {
+	return debugPage.Execute(w, report)
+}
@@ -0,0 +1,137 @@
+// Package exceptions 提供 Laravel 风格的错误上报和渲染，对应 Illuminate\Foundation\Exceptions\Handler
+//
+// ExceptionHandler 是应用处理未捕获错误的唯一入口：Report 把错误交给日志和 Sentry、Bugsnag 等上报器，
+// Render 把错误转换为 HTTP 响应，RenderForConsole 输出到控制台。
+// 错误可以实现 Reportable、Renderable 自行处理上报和渲染，实现 StatusCoder 声明 HTTP 状态码，
+// 实现 ContextProvider 为上报附加数据。包名没有使用 errors，以免和标准库冲突。
+//
+// HTTPException 和 ValidationError 是常用的 HTTP 错误，处理器可以直接返回它们，
+// 也可以用 Abort、AbortIf、AbortUnless 中止请求，由 Handler.Middleware 转换为响应。
+//
+// HTTP 状态码按以下顺序确定：错误链中的 StatusCoder、MapStatus 注册的映射、内置映射
+// （auth.ErrUnauthenticated 为 401，签名 URL 无效或过期为 403，session.ErrTokenMismatch 为 419），都没有时为 500。
+// 4xx 错误默认不上报。需要 JSON 的请求渲染为 RFC 9457 problem details。
+//
+// 包结构：
+// - exceptions.go - ExceptionHandler、Reporter 接口和错误契约、默认处理器
+// - handler.go - Handler 实现：上报、状态码映射、节流、回调
+// - render.go - HTTP 渲染（problem details JSON、HTML）和控制台渲染
+// - lottery.go - Lottery 概率抽样和 Limit 限流，用于节流上报
+// - reporter.go - LogReporter 日志上报器
+// - http.go - HTTPException、ValidationError 和 Abort 辅助函数
+// - debug.go - WithStack 调用栈记录、栈帧和调试报告
+// - debugpage.go - 调试错误页模板
+//
+// 使用示例：
+//
+//	handler := exceptions.NewHandler().SetDebug(cfg.Debug).
+//		AddReporter(exceptions.LogReporter(logger)).
+//		AddReporter(sentryReporter).
+//		DontReport(context.Canceled).
+//		MapStatus(sql.ErrNoRows, http.StatusNotFound).
+//		Throttle(func(err error) exceptions.Throttle {
+//			if errors.Is(err, ErrUpstreamTimeout) {
+//				return exceptions.Odds(1, 100)
+//			}
+//			return nil
+//		})
+//
+//	exceptions.OnRender(handler, func(w http.ResponseWriter, r *http.Request, err *InvalidOrderError) bool {
+//		http.Redirect(w, r, "/orders", http.StatusSeeOther)
+//		return true
+//	})
+//
+//	http.ListenAndServe(":8080", handler.Middleware(mux))
+package exceptions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// ExceptionHandler 错误处理器，对应 Laravel 的 Illuminate\Contracts\Debug\ExceptionHandler
+type ExceptionHandler interface {
+	// Report 上报错误，不应上报的错误会被忽略
+	Report(ctx context.Context, err error)
+
+	// ShouldReport 错误是否应该上报
+	ShouldReport(err error) bool
+
+	// Render 把错误渲染为 HTTP 响应
+	Render(w http.ResponseWriter, r *http.Request, err error)
+
+	// RenderForConsole 把错误输出到控制台
+	RenderForConsole(w io.Writer, err error)
+}
+
+// Reporter 上报器，把错误发送到日志或 Sentry、Bugsnag 等错误追踪服务
+type Reporter interface {
+	Report(ctx context.Context, err error, context map[string]interface{}) error
+}
+
+// ReporterFunc 函数形式的上报器
+type ReporterFunc func(ctx context.Context, err error, context map[string]interface{}) error
+
+// Report 调用函数本身
+func (f ReporterFunc) Report(ctx context.Context, err error, context map[string]interface{}) error {
+	return f(ctx, err, context)
+}
+
+// Reportable 自行上报的错误，返回 true 时不再交给上报器
+type Reportable interface {
+	Report(ctx context.Context) bool
+}
+
+// Renderable 自行渲染的错误，返回 true 表示已写入响应
+type Renderable interface {
+	Render(w http.ResponseWriter, r *http.Request) bool
+}
+
+// StatusCoder 声明 HTTP 状态码的错误
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// ContextProvider 为上报提供附加数据的错误
+type ContextProvider interface {
+	Context() map[string]interface{}
+}
+
+// ShouldntReport 标记永远不上报的错误
+type ShouldntReport interface {
+	ShouldntReport()
+}
+
+// HeaderProvider 为响应提供响应头的错误
+type HeaderProvider interface {
+	HTTPHeaders() http.Header
+}
+
+// ProblemExtender 为 problem details 响应提供扩展成员的错误，例如验证错误的字段列表
+type ProblemExtender interface {
+	ProblemDetails() map[string]interface{}
+}
+
+var defaultHandler atomic.Value
+
+// SetDefaultHandler 设置默认错误处理器
+func SetDefaultHandler(handler ExceptionHandler) {
+	defaultHandler.Store(&handler)
+}
+
+// DefaultHandler 获取默认错误处理器，未设置时返回 nil
+func DefaultHandler() ExceptionHandler {
+	if handler, ok := defaultHandler.Load().(*ExceptionHandler); ok {
+		return *handler
+	}
+	return nil
+}
+
+// Report 使用默认错误处理器上报，对应 Laravel 的 report 函数，未设置默认处理器时忽略
+func Report(ctx context.Context, err error) {
+	if handler := DefaultHandler(); handler != nil {
+		handler.Report(ctx, err)
+	}
+}
@@ -0,0 +1,311 @@
+package exceptions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime/debug"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/appcontext"
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/routing"
+	"github.com/cnote0/laraveldoc/session"
+)
+
+// statusMapping 错误到状态码的映射
+type statusMapping struct {
+	target error
+	status int
+}
+
+// Handler 错误处理器实现
+//
+// 配置方法返回 Handler 本身，应在处理请求前完成配置。
+type Handler struct {
+	mu         sync.RWMutex
+	app        application.Application
+	debug      bool
+	reporters  []Reporter
+	dontReport []error
+	statuses   []statusMapping
+	throttle   func(err error) Throttle
+	context    []func(ctx context.Context, err error) map[string]interface{}
+	reportFns  []func(ctx context.Context, err error) bool
+	renderFns  []func(w http.ResponseWriter, r *http.Request, err error) bool
+}
+
+var _ ExceptionHandler = (*Handler)(nil)
+
+// defaultStatuses 内置的状态码映射
+var defaultStatuses = []statusMapping{
+	{auth.ErrUnauthenticated, http.StatusUnauthorized},
+	{routing.ErrInvalidSignature, http.StatusForbidden},
+	{routing.ErrSignatureExpired, http.StatusForbidden},
+	{session.ErrTokenMismatch, 419},
+}
+
+// NewHandler 创建错误处理器
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// SetDebug 设置调试模式，调试模式下 5xx 响应渲染为调试错误页
+func (h *Handler) SetDebug(debug bool) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.debug = debug
+	return h
+}
+
+// SetApplication 设置应用，app.IsDebug() 为 true 时同样开启调试模式，调试错误页会列出容器绑定
+func (h *Handler) SetApplication(app application.Application) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.app = app
+	return h
+}
+
+// Debug 是否为调试模式
+func (h *Handler) Debug() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.debug || (h.app != nil && h.app.IsDebug())
+}
+
+// AddReporter 添加上报器，错误会依次交给每个上报器
+func (h *Handler) AddReporter(reporter Reporter) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.reporters = append(h.reporters, reporter)
+	return h
+}
+
+// DontReport 不上报与 targets 匹配（errors.Is）的错误
+func (h *Handler) DontReport(targets ...error) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.dontReport = append(h.dontReport, targets...)
+	return h
+}
+
+// MapStatus 把与 target 匹配（errors.Is）的错误映射为 HTTP 状态码，先注册的优先
+func (h *Handler) MapStatus(target error, status int) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.statuses = append(h.statuses, statusMapping{target: target, status: status})
+	return h
+}
+
+// Throttle 设置上报节流，回调返回 nil 时不节流
+func (h *Handler) Throttle(throttle func(err error) Throttle) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.throttle = throttle
+	return h
+}
+
+// BuildContextUsing 添加所有上报都附加的数据
+func (h *Handler) BuildContextUsing(build func(ctx context.Context, err error) map[string]interface{}) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.context = append(h.context, build)
+	return h
+}
+
+// OnReport 为 E 类型的错误注册上报回调，回调返回 true 时不再交给上报器
+func OnReport[E error](h *Handler, callback func(ctx context.Context, err E) bool) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.reportFns = append(h.reportFns, func(ctx context.Context, err error) bool {
+		var target E
+		return errors.As(err, &target) && callback(ctx, target)
+	})
+	return h
+}
+
+// OnRender 为 E 类型的错误注册渲染回调，回调返回 true 表示已写入响应
+func OnRender[E error](h *Handler, callback func(w http.ResponseWriter, r *http.Request, err E) bool) *Handler {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.renderFns = append(h.renderFns, func(w http.ResponseWriter, r *http.Request, err error) bool {
+		var target E
+		return errors.As(err, &target) && callback(w, r, target)
+	})
+	return h
+}
+
+// ShouldReport 错误是否应该上报
+//
+// nil、实现 ShouldntReport、与 DontReport 匹配以及状态码为 4xx 的错误不上报。
+func (h *Handler) ShouldReport(err error) bool {
+	if err == nil {
+		return false
+	}
+	var marker ShouldntReport
+	if errors.As(err, &marker) {
+		return false
+	}
+	h.mu.RLock()
+	dontReport := h.dontReport
+	h.mu.RUnlock()
+	for _, target := range dontReport {
+		if errors.Is(err, target) {
+			return false
+		}
+	}
+	status := h.StatusCode(err)
+	return status < 400 || status >= 500
+}
+
+// Report 上报错误
+//
+// 依次经过 ShouldReport、节流、错误自身的 Report 方法和 OnReport 回调，
+// 都没有处理时交给所有上报器。上报器的错误被忽略，避免上报失败影响请求。
+func (h *Handler) Report(ctx context.Context, err error) {
+	if !h.ShouldReport(err) {
+		return
+	}
+	h.mu.RLock()
+	throttle, reportFns, reporters := h.throttle, h.reportFns, h.reporters
+	h.mu.RUnlock()
+
+	if throttle != nil {
+		if t := throttle(err); t != nil && !t.Allow(ctx, err) {
+			return
+		}
+	}
+	var reportable Reportable
+	if errors.As(err, &reportable) && reportable.Report(ctx) {
+		return
+	}
+	for _, fn := range reportFns {
+		if fn(ctx, err) {
+			return
+		}
+	}
+	context := h.buildContext(ctx, err)
+	for _, reporter := range reporters {
+		_ = reporter.Report(ctx, err, context)
+	}
+}
+
+// buildContext 上报附加数据：appcontext 数据、BuildContextUsing 的数据、错误自身的数据，后者覆盖前者
+func (h *Handler) buildContext(ctx context.Context, err error) map[string]interface{} {
+	context := appcontext.All(ctx)
+	h.mu.RLock()
+	builders := h.context
+	h.mu.RUnlock()
+	for _, build := range builders {
+		for key, value := range build(ctx, err) {
+			context[key] = value
+		}
+	}
+	var provider ContextProvider
+	if errors.As(err, &provider) {
+		for key, value := range provider.Context() {
+			context[key] = value
+		}
+	}
+	return context
+}
+
+// StatusCode 错误对应的 HTTP 状态码
+func (h *Handler) StatusCode(err error) int {
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		if status := coder.StatusCode(); status > 0 {
+			return status
+		}
+	}
+	h.mu.RLock()
+	statuses := h.statuses
+	h.mu.RUnlock()
+	for _, mappings := range [][]statusMapping{statuses, defaultStatuses} {
+		for _, mapping := range mappings {
+			if errors.Is(err, mapping.target) {
+				return mapping.status
+			}
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// HandleError 上报并渲染错误
+func (h *Handler) HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	h.Report(r.Context(), err)
+	h.Render(w, r, err)
+}
+
+// HandlerFunc 把返回错误的处理函数转换为 http.Handler，返回的错误会被上报并渲染
+func (h *Handler) HandlerFunc(fn func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			h.HandleError(w, r, err)
+		}
+	})
+}
+
+// Middleware 捕获处理器中的 panic，上报并渲染为错误响应，Abort 产生的错误按其状态码渲染
+//
+// http.ErrAbortHandler 会继续向上抛出，保持 net/http 中断连接的语义。
+func (h *Handler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			if abort, ok := recovered.(aborted); ok {
+				h.HandleError(w, r, abort.err)
+				return
+			}
+			h.HandleError(w, r, &PanicError{Value: recovered, Stack: debug.Stack(), pcs: callers(3)})
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// PanicError 由 panic 转换的错误
+type PanicError struct {
+	// Value recover 得到的值
+	Value interface{}
+
+	// Stack panic 时的调用栈
+	Stack []byte
+
+	pcs []uintptr
+}
+
+// Error 实现 error 接口
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// StackTrace 实现 StackTracer
+func (e *PanicError) StackTrace() []uintptr {
+	return e.pcs
+}
+
+// Unwrap 值为错误时返回该错误
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// ErrorType 错误的类型名称，fmt.Errorf 和 WithStack 包装的错误使用被包装错误的类型
+func ErrorType(err error) string {
+	for {
+		inner := errors.Unwrap(err)
+		if _, ok := err.(*stackError); !ok && (inner == nil || reflect.TypeOf(err).String() != "*fmt.wrapError") {
+			return reflect.TypeOf(err).String()
+		}
+		err = inner
+	}
+}
@@ -0,0 +1,287 @@
+package exceptions
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cnote0/laraveldoc/routing"
+	"github.com/cnote0/laraveldoc/session"
+)
+
+var (
+	// ErrNotFound 用于 errors.Is 判断 404 错误
+	ErrNotFound = &HTTPException{Status: http.StatusNotFound}
+
+	// ErrAccessDenied 用于 errors.Is 判断 403 错误
+	ErrAccessDenied = &HTTPException{Status: http.StatusForbidden}
+
+	// ErrTooManyRequests 用于 errors.Is 判断 429 错误
+	ErrTooManyRequests = &HTTPException{Status: http.StatusTooManyRequests}
+
+	// ErrValidation 用于 errors.Is 判断验证失败，任何错误包的 ValidationError 都满足；
+	// 判断特定错误包时使用 &ValidationError{Bag: "login"}
+	ErrValidation = &ValidationError{}
+)
+
+// HTTPException HTTP 错误，对应 Symfony 的 HttpException
+//
+// 错误信息会出现在响应中，Headers 会写入响应头。
+// errors.Is 按状态码比较，例如 errors.Is(err, exceptions.ErrNotFound)。
+type HTTPException struct {
+	// Status HTTP 状态码
+	Status int
+
+	// Message 错误信息，为空时使用状态码的标准描述
+	Message string
+
+	// Headers 响应头
+	Headers http.Header
+
+	// Err 引起错误的原因
+	Err error
+}
+
+// NewHTTPException 创建 HTTP 错误
+func NewHTTPException(status int, message ...string) *HTTPException {
+	e := &HTTPException{Status: status}
+	if len(message) > 0 {
+		e.Message = message[0]
+	}
+	return e
+}
+
+// NotFound 404 错误，对应 NotFoundHttpException
+func NotFound(message ...string) *HTTPException {
+	return NewHTTPException(http.StatusNotFound, message...)
+}
+
+// AccessDenied 403 错误，对应 AccessDeniedHttpException
+func AccessDenied(message ...string) *HTTPException {
+	return NewHTTPException(http.StatusForbidden, message...)
+}
+
+// TooManyRequests 429 错误，对应 TooManyRequestsHttpException，retryAfter 大于 0 时设置 Retry-After 响应头
+func TooManyRequests(retryAfter time.Duration, message ...string) *HTTPException {
+	e := NewHTTPException(http.StatusTooManyRequests, message...)
+	if retryAfter > 0 {
+		e.WithHeader("Retry-After", strconv.Itoa(int((retryAfter+time.Second-1)/time.Second)))
+	}
+	return e
+}
+
+// WithHeader 设置响应头
+func (e *HTTPException) WithHeader(name, value string) *HTTPException {
+	if e.Headers == nil {
+		e.Headers = make(http.Header)
+	}
+	e.Headers.Set(name, value)
+	return e
+}
+
+// Wrap 设置引起错误的原因
+func (e *HTTPException) Wrap(err error) *HTTPException {
+	e.Err = err
+	return e
+}
+
+// Error 实现 error 接口
+func (e *HTTPException) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if text := http.StatusText(e.Status); text != "" {
+		return text
+	}
+	return fmt.Sprintf("HTTP %d", e.Status)
+}
+
+// StatusCode 实现 StatusCoder
+func (e *HTTPException) StatusCode() int {
+	return e.Status
+}
+
+// HTTPHeaders 实现 HeaderProvider
+func (e *HTTPException) HTTPHeaders() http.Header {
+	return e.Headers
+}
+
+// Unwrap 返回引起错误的原因
+func (e *HTTPException) Unwrap() error {
+	return e.Err
+}
+
+// Is 状态码相同的 HTTPException 视为相同
+func (e *HTTPException) Is(target error) bool {
+	t, ok := target.(*HTTPException)
+	return ok && t.Status == e.Status
+}
+
+// ValidationError 验证失败错误，对应 Laravel 的 ValidationException
+//
+// 需要 JSON 的请求渲染为 422 problem details，errors 成员为字段到错误信息的映射；
+// 其他请求跳转到 RedirectTo（启用了会话时默认为上一个 URL），错误信息闪存到会话中 Bag 指定的错误包，旧输入闪存到会话。
+type ValidationError struct {
+	// Errors 字段到错误信息的映射
+	Errors map[string][]string
+
+	// Bag 错误包名称，为空时为默认错误包，对应 Laravel 的 errorBag
+	Bag string
+
+	// Status HTTP 状态码，默认 422
+	Status int
+
+	// RedirectTo 非 JSON 请求的跳转地址
+	RedirectTo string
+}
+
+// NewValidationError 创建验证失败错误
+func NewValidationError(errors map[string][]string) *ValidationError {
+	return &ValidationError{Errors: errors, Status: http.StatusUnprocessableEntity}
+}
+
+// WithMessage 为字段添加错误信息
+func (e *ValidationError) WithMessage(field, message string) *ValidationError {
+	if e.Errors == nil {
+		e.Errors = make(map[string][]string)
+	}
+	e.Errors[field] = append(e.Errors[field], message)
+	return e
+}
+
+// ErrorBag 设置闪存错误信息的错误包，同一页面有多个表单时区分各自的错误
+func (e *ValidationError) ErrorBag(bag string) *ValidationError {
+	e.Bag = bag
+	return e
+}
+
+// Is 目标 ValidationError 的 Bag 为空或与本错误相同时视为相同，errors.Is(err, ErrValidation) 判断任何验证失败
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(*ValidationError)
+	return ok && (t.Bag == "" || t.Bag == e.Bag || t.Bag == session.DefaultErrorBag && e.Bag == "")
+}
+
+// RedirectToURL 设置非 JSON 请求的跳转地址
+func (e *ValidationError) RedirectToURL(url string) *ValidationError {
+	e.RedirectTo = url
+	return e
+}
+
+// First 字段的第一条错误信息
+func (e *ValidationError) First(field string) string {
+	if messages := e.Errors[field]; len(messages) > 0 {
+		return messages[0]
+	}
+	return ""
+}
+
+// Error 第一条错误信息，有多条时附加剩余数量，与 Laravel 的消息格式相同
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Errors))
+	count := 0
+	for field, messages := range e.Errors {
+		if len(messages) > 0 {
+			fields = append(fields, field)
+			count += len(messages)
+		}
+	}
+	if count == 0 {
+		return "The given data was invalid."
+	}
+	sort.Strings(fields)
+	message := e.Errors[fields[0]][0]
+	switch remaining := count - 1; remaining {
+	case 0:
+		return message
+	case 1:
+		return message + " (and 1 more error)"
+	default:
+		return fmt.Sprintf("%s (and %d more errors)", message, remaining)
+	}
+}
+
+// StatusCode 实现 StatusCoder
+func (e *ValidationError) StatusCode() int {
+	if e.Status == 0 {
+		return http.StatusUnprocessableEntity
+	}
+	return e.Status
+}
+
+// ProblemDetails 实现 ProblemExtender
+func (e *ValidationError) ProblemDetails() map[string]interface{} {
+	errors := e.Errors
+	if errors == nil {
+		errors = map[string][]string{}
+	}
+	return map[string]interface{}{"errors": errors}
+}
+
+// ErrorMessages 字段到错误信息的映射，实现 routing.ErrorMessages，可以直接传给 RedirectResponse.WithErrors
+func (e *ValidationError) ErrorMessages() map[string][]string {
+	return e.Errors
+}
+
+// dontFlash 验证失败时不闪存到会话的输入
+var dontFlash = []string{"password", "password_confirmation", "current_password"}
+
+// Render 非 JSON 请求跳转到 RedirectTo，请求启用了会话时没有设置 RedirectTo 则跳转到上一个 URL
+//
+// 请求启用了会话时，错误信息闪存到会话中 Bag 指定的错误包，请求输入（不含密码字段）闪存为旧输入。
+func (e *ValidationError) Render(w http.ResponseWriter, r *http.Request) bool {
+	if WantsJSON(r) {
+		return false
+	}
+	store := session.FromContext(r.Context())
+	target := e.RedirectTo
+	if target == "" {
+		target = store.PreviousURL("")
+	}
+	if target == "" {
+		return false
+	}
+	if store != nil {
+		store.FlashErrorsIn(e.Bag, session.ErrorBag(e.Errors))
+		input := routing.NewRequest(r).All()
+		for _, key := range dontFlash {
+			delete(input, key)
+		}
+		store.FlashInput(input)
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+	return true
+}
+
+// aborted Abort 系列函数 panic 的值，由 Handler.Middleware 识别
+type aborted struct {
+	err error
+}
+
+// Abort 以 HTTP 错误中止请求，对应 Laravel 的 abort 函数
+//
+// Abort 通过 panic 中止处理器，需要在 Handler.Middleware 之内调用；
+// 能返回错误时应优先返回 NewHTTPException。
+func Abort(status int, message ...string) {
+	AbortWith(NewHTTPException(status, message...))
+}
+
+// AbortIf 条件成立时中止请求
+func AbortIf(condition bool, status int, message ...string) {
+	if condition {
+		Abort(status, message...)
+	}
+}
+
+// AbortUnless 条件不成立时中止请求
+func AbortUnless(condition bool, status int, message ...string) {
+	if !condition {
+		Abort(status, message...)
+	}
+}
+
+// AbortWith 以任意错误中止请求，例如 ValidationError
+func AbortWith(err error) {
+	panic(aborted{err: err})
+}
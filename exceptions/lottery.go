@@ -0,0 +1,90 @@
+package exceptions
+
+import (
+	"context"
+	"math/rand/v2"
+	"reflect"
+
+	"github.com/cnote0/laraveldoc/cache"
+)
+
+// Throttle 决定一次错误是否上报
+type Throttle interface {
+	Allow(ctx context.Context, err error) bool
+}
+
+// Lottery 概率抽样，对应 Laravel 的 Illuminate\Support\Lottery
+type Lottery struct {
+	chances int
+	outOf   int
+	random  func(n int) int
+}
+
+// Odds 以 chances/outOf 的概率中奖
+func Odds(chances, outOf int) *Lottery {
+	return &Lottery{chances: chances, outOf: outOf, random: rand.IntN}
+}
+
+// AlwaysWin 总是中奖的抽样，用于测试
+func AlwaysWin() *Lottery {
+	return Odds(1, 1)
+}
+
+// AlwaysLose 总是不中奖的抽样，用于测试
+func AlwaysLose() *Lottery {
+	return Odds(0, 1)
+}
+
+// Choose 抽一次
+func (l *Lottery) Choose() bool {
+	if l.outOf <= 0 || l.chances <= 0 {
+		return false
+	}
+	if l.chances >= l.outOf {
+		return true
+	}
+	return l.random(l.outOf) < l.chances
+}
+
+// Allow 实现 Throttle
+func (l *Lottery) Allow(ctx context.Context, err error) bool {
+	return l.Choose()
+}
+
+// LimitThrottle 按时间窗口限制上报次数
+type LimitThrottle struct {
+	limit   cache.Limit
+	limiter cache.RateLimiter
+}
+
+// fallbackLimiter 未指定限流器时使用的进程内存储
+var fallbackLimiter = cache.NewMemoryStore()
+
+// Limit 按 limit 限制上报次数，未设置键时按错误类型计数
+func Limit(limit cache.Limit) *LimitThrottle {
+	return &LimitThrottle{limit: limit, limiter: fallbackLimiter}
+}
+
+// Using 设置限流器，多进程部署时应使用共享存储
+func (t *LimitThrottle) Using(limiter cache.RateLimiter) *LimitThrottle {
+	t.limiter = limiter
+	return t
+}
+
+// Allow 窗口内未超过次数时计数并返回 true，限流器出错时允许上报
+func (t *LimitThrottle) Allow(ctx context.Context, err error) bool {
+	key := t.limit.Key
+	if key == "" {
+		key = reflect.TypeOf(err).String()
+	}
+	key = "exceptions:throttle:" + key
+	tooMany, limitErr := t.limiter.TooManyAttempts(ctx, key, t.limit.MaxAttempts)
+	if limitErr != nil {
+		return true
+	}
+	if tooMany {
+		return false
+	}
+	_, _ = t.limiter.Hit(ctx, key, t.limit.Decay)
+	return true
+}
@@ -0,0 +1,192 @@
+package exceptions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/auth"
+)
+
+// Problem RFC 9457 problem details
+type Problem struct {
+	// Type 问题类型 URI，默认 about:blank
+	Type string `json:"type"`
+
+	// Title 状态码的标准描述
+	Title string `json:"title"`
+
+	// Status HTTP 状态码
+	Status int `json:"status"`
+
+	// Detail 错误信息，5xx 错误只在调试模式下包含
+	Detail string `json:"detail,omitempty"`
+
+	// Extensions 扩展成员，与标准成员同级输出
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON 把扩展成员和标准成员编码到同一个对象
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+4)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	return json.Marshal(members)
+}
+
+// Render 把错误渲染为 HTTP 响应
+//
+// 依次尝试错误自身的 Render 方法和 OnRender 回调；未认证错误带有跳转地址且请求不需要 JSON 时跳转；
+// 否则按请求的 Accept 渲染为 problem details JSON 或 HTML 错误页。
+// 调试模式下 5xx 错误的 HTML 响应是调试错误页，包含错误链、带源码的栈帧、请求数据和容器绑定。
+func (h *Handler) Render(w http.ResponseWriter, r *http.Request, err error) {
+	var renderable Renderable
+	if errors.As(err, &renderable) && renderable.Render(w, r) {
+		return
+	}
+	h.mu.RLock()
+	renderFns := h.renderFns
+	h.mu.RUnlock()
+	for _, fn := range renderFns {
+		if fn(w, r, err) {
+			return
+		}
+	}
+
+	wantsJSON := WantsJSON(r)
+	var unauthenticated *auth.AuthenticationError
+	if errors.As(err, &unauthenticated) && unauthenticated.RedirectTo != "" && !wantsJSON {
+		http.Redirect(w, r, unauthenticated.RedirectTo, http.StatusFound)
+		return
+	}
+
+	var headers HeaderProvider
+	if errors.As(err, &headers) {
+		for name, values := range headers.HTTPHeaders() {
+			w.Header()[name] = values
+		}
+	}
+	problem := h.Problem(err)
+	if !wantsJSON && h.Debug() && problem.Status >= 500 {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(problem.Status)
+		_ = renderDebugPage(w, h.DebugReport(r, err))
+		return
+	}
+	if wantsJSON {
+		body, _ := json.Marshal(problem)
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(problem.Status)
+		_, _ = w.Write(body)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(problem.Status)
+	_, _ = io.WriteString(w, renderHTML(problem))
+}
+
+// Problem 把错误转换为 problem details
+//
+// 4xx 错误的信息总是包含在 detail 中，5xx 错误只在调试模式下包含，
+// 调试模式下还会加入 exception（错误类型）、chain（错误链）和 trace（调用栈）扩展成员，
+// 调用栈存在时 file、line 为第一个应用代码栈帧的位置。
+func (h *Handler) Problem(err error) Problem {
+	status := h.StatusCode(err)
+	problem := Problem{Type: "about:blank", Title: http.StatusText(status), Status: status}
+	switch {
+	case status == 419:
+		// Laravel 的 CSRF 令牌过期状态码，标准库没有描述
+		problem.Title = "Page Expired"
+	case problem.Title == "":
+		problem.Title = "Error"
+	}
+	debug := h.Debug()
+	if status < 500 || debug {
+		problem.Detail = err.Error()
+	}
+	var extender ProblemExtender
+	if errors.As(err, &extender) {
+		problem.Extensions = extender.ProblemDetails()
+	}
+	if debug {
+		if problem.Extensions == nil {
+			problem.Extensions = make(map[string]interface{})
+		}
+		problem.Extensions["exception"] = ErrorType(err)
+		problem.Extensions["chain"] = chain(err)
+		trace := frames(stackTrace(err), 0)
+		if trace == nil {
+			trace = []Frame{}
+		}
+		problem.Extensions["trace"] = trace
+		for _, frame := range trace {
+			if frame.Application {
+				problem.Extensions["file"], problem.Extensions["line"] = frame.File, frame.Line
+				break
+			}
+		}
+	}
+	return problem
+}
+
+// WantsJSON 请求是否需要 JSON 响应：Accept 包含 json，或者是 XHR 请求
+func WantsJSON(r *http.Request) bool {
+	if r.Header.Get("X-Requested-With") == "XMLHttpRequest" {
+		return true
+	}
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := mime.ParseMediaType(strings.TrimSpace(accept))
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			return true
+		}
+	}
+	return false
+}
+
+// renderHTML 简单的 HTML 错误页
+func renderHTML(problem Problem) string {
+	title := fmt.Sprintf("%d | %s", problem.Status, problem.Title)
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n<title>")
+	b.WriteString(html.EscapeString(title))
+	b.WriteString("</title>\n</head>\n<body>\n<h1>")
+	b.WriteString(html.EscapeString(title))
+	b.WriteString("</h1>\n")
+	if problem.Detail != "" {
+		b.WriteString("<p>")
+		b.WriteString(html.EscapeString(problem.Detail))
+		b.WriteString("</p>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// RenderForConsole 把错误输出到控制台
+//
+// 输出错误类型和信息，以及 errors.Unwrap 得到的错误链；调试模式下 PanicError 还会输出调用栈。
+func (h *Handler) RenderForConsole(w io.Writer, err error) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(w, "\n  %s\n\n  %s\n", ErrorType(err), err.Error())
+	for inner := errors.Unwrap(err); inner != nil; inner = errors.Unwrap(inner) {
+		fmt.Fprintf(w, "\n  Caused by %s: %s\n", ErrorType(inner), inner.Error())
+	}
+	var panicked *PanicError
+	if h.Debug() && errors.As(err, &panicked) {
+		fmt.Fprintf(w, "\n%s\n", panicked.Stack)
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,19 @@
+package exceptions
+
+import (
+	"context"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// LogReporter 把错误写入日志，错误信息作为日志消息，错误类型和附加数据写入日志上下文
+func LogReporter(logger application.LoggerInterface) Reporter {
+	return ReporterFunc(func(ctx context.Context, err error, context map[string]interface{}) error {
+		fields := make(map[string]interface{}, len(context)+1)
+		for key, value := range context {
+			fields[key] = value
+		}
+		fields["exception"] = ErrorType(err)
+		return logger.Error(err.Error(), fields)
+	})
+}
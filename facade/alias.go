@@ -0,0 +1,171 @@
+package facade
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrAliasConflict 别名已指向其他门面，或与其他已注册的门面同名
+var ErrAliasConflict = errors.New("facade: alias conflict")
+
+// ConfigRepository 读取别名配置使用的配置仓库，application.Config 满足该接口
+type ConfigRepository interface {
+	Get(key string, defaultValue interface{}) interface{}
+}
+
+// AliasLoader 门面别名加载器，对应 Laravel 的 Illuminate\Foundation\AliasLoader
+//
+// 别名指向门面管理器中注册的门面名称，在 Resolve 时才通过管理器查找，
+// 因此可以先登记别名、后注册门面。
+//
+// 使用示例：
+//
+//	// config/app.go
+//	"aliases": map[string]string{
+//		"Redis":    "Cache",
+//		"Database": "DB",
+//	}
+//
+//	loader := facade.NewAliasLoader(manager)
+//	if err := loader.LoadConfig(config, "app.aliases"); err != nil {
+//		return err
+//	}
+//	cache := loader.MustResolve("Redis")
+type AliasLoader struct {
+	manager FacadeManager
+
+	mu      sync.RWMutex
+	aliases map[string]string
+}
+
+// NewAliasLoader 创建别名加载器
+func NewAliasLoader(manager FacadeManager) *AliasLoader {
+	return &AliasLoader{manager: manager, aliases: make(map[string]string)}
+}
+
+// Alias 登记别名
+//
+// 别名已指向其他门面、与其他已注册的门面同名或指向自身时返回 ErrAliasConflict，
+// 重复登记相同的别名不报错。
+func (l *AliasLoader) Alias(alias, target string) error {
+	if alias == target {
+		return fmt.Errorf("%w: %s is aliased to itself", ErrAliasConflict, alias)
+	}
+	if l.manager.Has(alias) {
+		return fmt.Errorf("%w: %s is already a registered facade", ErrAliasConflict, alias)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if existing, ok := l.aliases[alias]; ok && existing != target {
+		return fmt.Errorf("%w: %s already points to %s, cannot alias it to %s", ErrAliasConflict, alias, existing, target)
+	}
+	l.aliases[alias] = target
+	return nil
+}
+
+// Load 批量登记别名，返回所有冲突合并后的错误，没有冲突的别名仍然登记
+func (l *AliasLoader) Load(aliases map[string]string) error {
+	names := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+	var errs []error
+	for _, alias := range names {
+		if err := l.Alias(alias, aliases[alias]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadConfig 从配置的 key（通常为 "app.aliases"）登记别名
+//
+// 配置值可以是 map[string]string 或值为字符串的 map[string]interface{}，未配置时不做处理。
+// 配置在所有门面注册之后加载，因此除了 Alias 检查的冲突（例如与已注册的门面 "DB" 同名），
+// 还检查别名指向的门面是否已注册，未注册时返回包装 ErrFacadeNotFound 的错误，别名仍然登记。
+func (l *AliasLoader) LoadConfig(config ConfigRepository, key string) error {
+	var aliases map[string]string
+	switch value := config.Get(key, nil).(type) {
+	case nil:
+		return nil
+	case map[string]string:
+		aliases = value
+	case map[string]interface{}:
+		aliases = make(map[string]string, len(value))
+		for alias, target := range value {
+			name, ok := target.(string)
+			if !ok {
+				return fmt.Errorf("facade: alias %s in %s must be a facade name, got %T", alias, key, target)
+			}
+			aliases[alias] = name
+		}
+	default:
+		return fmt.Errorf("facade: %s must be a map of alias to facade name, got %T", key, value)
+	}
+	errs := []error{l.Load(aliases)}
+	names := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+	for _, alias := range names {
+		if target := aliases[alias]; alias != target && !l.manager.Has(target) {
+			errs = append(errs, fmt.Errorf("%w: alias %s in %s points to %s", ErrFacadeNotFound, alias, key, target))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Resolve 通过门面管理器解析别名指向的门面
+func (l *AliasLoader) Resolve(alias string) (Facade, error) {
+	l.mu.RLock()
+	target, ok := l.aliases[alias]
+	l.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFacadeNotFound, alias)
+	}
+	return l.manager.Resolve(target)
+}
+
+// MustResolve 解析别名，失败时 panic
+func (l *AliasLoader) MustResolve(alias string) Facade {
+	facade, err := l.Resolve(alias)
+	if err != nil {
+		panic(err)
+	}
+	return facade
+}
+
+// Has 别名是否已登记
+func (l *AliasLoader) Has(alias string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.aliases[alias]
+	return ok
+}
+
+// Forget 移除别名
+func (l *AliasLoader) Forget(alias string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.aliases, alias)
+}
+
+// GetAliases 获取所有别名的副本，键为别名，值为门面名称
+func (l *AliasLoader) GetAliases() map[string]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	aliases := make(map[string]string, len(l.aliases))
+	for alias, target := range l.aliases {
+		aliases[alias] = target
+	}
+	return aliases
+}
+
+// Manager 获取别名解析使用的门面管理器
+func (l *AliasLoader) Manager() FacadeManager {
+	return l.manager
+}
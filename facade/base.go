@@ -0,0 +1,567 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// 门面错误
+var (
+	// ErrNoApplication 门面和包级都没有设置应用容器
+	ErrNoApplication = errors.New("facade: a facade root has not been set, call facade.SetFacadeApplication")
+
+	// ErrMethodNotFound 底层服务没有指定的方法
+	ErrMethodNotFound = errors.New("facade: method not found")
+
+	// ErrNoScope context 中没有门面作用域
+	ErrNoScope = errors.New("facade: context has no facade scope, call facade.WithFacadeScope")
+
+	// ErrNilRoot NewFor 的底层服务为 nil
+	ErrNilRoot = errors.New("facade: facade root is nil")
+)
+
+// Resolver 门面解析底层服务使用的应用容器，container.Container 满足该接口
+//
+// 同时实现 container.ContextResolver 时，CallMethodWithContext 以调用的 ctx 解析底层服务。
+type Resolver interface {
+	Make(abstract interface{}) (interface{}, error)
+}
+
+// defaultApplication 门面默认使用的应用容器
+var defaultApplication atomic.Value
+
+// SetFacadeApplication 设置所有门面默认使用的应用容器，对应 Laravel 的 Facade::setFacadeApplication
+func SetFacadeApplication(app Resolver) {
+	defaultApplication.Store(&app)
+}
+
+// FacadeApplication 获取门面默认使用的应用容器，未设置时返回 nil
+func FacadeApplication() Resolver {
+	if app, ok := defaultApplication.Load().(*Resolver); ok {
+		return *app
+	}
+	return nil
+}
+
+// Base 门面的默认实现，实现 StaticFacade 和 MockInterface
+//
+// 底层服务从应用容器按访问器解析并缓存，缓存和模拟对象的读写由读写锁保护，可以并发调用。
+// Mock 替换所有调用方看到的底层服务；并行测试应使用 MockContext，
+// 只替换通过携带同一作用域的 context 发起的调用。
+//
+// 使用示例：
+//
+//	Cache := facade.New("cache")
+//	results, err := Cache.CallMethod("Get", []interface{}{"key"})
+//
+//	// 生成的强类型门面嵌入 Base
+//	Mail := facades.NewMailer(facade.New("mailer"))
+type Base struct {
+	accessor string
+
+	mu           sync.RWMutex
+	app          interface{}
+	root         interface{}
+	resolved     interface{}
+	mock         interface{}
+	spy          *Spy
+	expectations *Expectations
+	partial      map[string]reflect.Value
+	realtime     *RealtimeFacade
+	contextual   bool
+	preventStale bool
+	err          error
+}
+
+var (
+	_ StaticFacade  = (*Base)(nil)
+	_ MockInterface = (*Base)(nil)
+)
+
+// New 创建门面，accessor 为底层服务在容器中的标识符
+func New(accessor string) *Base {
+	return &Base{accessor: accessor}
+}
+
+// NewFor 创建以 root 为底层服务的门面，不从容器解析，访问器为 root 的类型名
+//
+// root 为 nil 时返回的门面在获取底层服务时返回 ErrNilRoot。
+func NewFor(root interface{}) *Base {
+	if root == nil {
+		return &Base{accessor: "<nil>", err: ErrNilRoot}
+	}
+	return &Base{accessor: reflect.TypeOf(root).String(), root: root}
+}
+
+// GetFacadeAccessor 获取门面访问器
+func (b *Base) GetFacadeAccessor() string {
+	return b.accessor
+}
+
+// ShouldPreventStaleExecution 是否每次调用都重新解析底层服务
+func (b *Base) ShouldPreventStaleExecution() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.preventStale
+}
+
+// PreventStaleExecution 设置是否每次调用都重新解析底层服务
+func (b *Base) PreventStaleExecution(prevent bool) *Base {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.preventStale = prevent
+	b.resolved = nil
+	return b
+}
+
+// GetFacadeRoot 获取底层服务
+func (b *Base) GetFacadeRoot() (interface{}, error) {
+	return b.GetFacadeRootContext(context.Background())
+}
+
+// FacadeRootContext 以 ctx 获取门面的底层服务
+//
+// f 实现了 GetFacadeRootContext（例如 *Base）时从 ctx 的作用域开始解析，否则调用 GetFacadeRoot。
+func FacadeRootContext(ctx context.Context, f Facade) (interface{}, error) {
+	if r, ok := f.(interface {
+		GetFacadeRootContext(ctx context.Context) (interface{}, error)
+	}); ok {
+		return r.GetFacadeRootContext(ctx)
+	}
+	return f.GetFacadeRoot()
+}
+
+// GetFacadeRootContext 获取底层服务，依次查找 ctx 中的作用域、实时门面的替换、模拟对象、Swap 的替换、NewFor 指定的实例、缓存和应用容器
+//
+// ContextualFacade 在模拟对象之后从 ctx 作用域的请求级容器解析，不缓存也不使用应用容器。
+func (b *Base) GetFacadeRootContext(ctx context.Context) (interface{}, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if scope := ScopeFromContext(ctx); scope != nil {
+		if instance, ok := scope.Resolved(b.accessor); ok {
+			return instance, nil
+		}
+	}
+
+	b.mu.RLock()
+	mock, root, resolved, app, preventStale := b.mock, b.root, b.resolved, b.app, b.preventStale
+	b.mu.RUnlock()
+	if b.realtime != nil {
+		if instance, ok := b.realtime.swapped(reflect.TypeOf(root)); ok {
+			return instance, nil
+		}
+	}
+	if mock != nil {
+		return mock, nil
+	}
+	if instance, ok := Swapped(b.accessor); ok {
+		return instance, nil
+	}
+	if b.contextual {
+		return resolveContextual(ctx, b.accessor)
+	}
+	if root != nil {
+		return root, nil
+	}
+	if resolved != nil {
+		return resolved, nil
+	}
+
+	resolver, ok := app.(Resolver)
+	if app == nil {
+		resolver, ok = FacadeApplication(), true
+	}
+	if !ok {
+		return nil, fmt.Errorf("facade: application %T cannot resolve services", app)
+	}
+	if resolver == nil {
+		return nil, ErrNoApplication
+	}
+	instance, err := container.MakeWithContext(ctx, resolver, b.accessor)
+	if err != nil {
+		return nil, fmt.Errorf("facade: resolve %s: %w", b.accessor, err)
+	}
+	if preventStale {
+		return instance, nil
+	}
+
+	// 并发解析时保留先写入的实例，保证所有调用方看到同一个底层服务
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.resolved == nil {
+		b.resolved = instance
+	}
+	return b.resolved, nil
+}
+
+// ClearResolvedInstance 清除缓存的底层服务
+func (b *Base) ClearResolvedInstance() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resolved = nil
+}
+
+// SetFacadeApplication 设置门面使用的应用容器，为 nil 时使用包级的 FacadeApplication
+func (b *Base) SetFacadeApplication(app interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.app = app
+	b.resolved = nil
+}
+
+// GetFacadeApplication 获取门面使用的应用容器
+func (b *Base) GetFacadeApplication() interface{} {
+	b.mu.RLock()
+	app := b.app
+	b.mu.RUnlock()
+	if app == nil {
+		return FacadeApplication()
+	}
+	return app
+}
+
+// CallMethod 调用底层服务的方法
+func (b *Base) CallMethod(methodName string, args []interface{}) ([]interface{}, error) {
+	return b.CallMethodWithContext(context.Background(), methodName, args)
+}
+
+// CallMethodWithContext 调用底层服务的方法，底层服务从 ctx 的作用域开始解析
+//
+// 方法的第一个参数为 context.Context 而 args 中没有时，自动传入 ctx。
+// 设置了 ShouldReceive 且 ctx 的作用域没有替换底层服务时，调用由期望模拟处理；
+// PartialMock 模拟的方法由模拟函数处理，其他方法调用底层服务。
+// 调用结束后通知 Observe 注册的观察者，并记录到 Spy 创建的间谍。
+func (b *Base) CallMethodWithContext(ctx context.Context, methodName string, args []interface{}) ([]interface{}, error) {
+	b.mu.RLock()
+	spy, expectations, partial := b.spy, b.expectations, b.partial
+	b.mu.RUnlock()
+	var metadata map[string]interface{}
+	if partial != nil {
+		metadata = map[string]interface{}{MetadataIntercepted: false}
+	}
+	return instrumented(ctx, b.accessor, methodName, args, spy, metadata, func() ([]interface{}, error) {
+		if expectations != nil && !b.scoped(ctx) {
+			return b.expect(ctx, expectations, methodName, args)
+		}
+		if fn, ok := partial[methodName]; ok && !b.scoped(ctx) {
+			metadata[MetadataIntercepted] = true
+			return invokeFunc(ctx, fn, b.accessor+"."+methodName, args)
+		}
+		root, err := b.GetFacadeRootContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return Invoke(ctx, root, methodName, args)
+	})
+}
+
+// scoped ctx 的作用域是否替换了底层服务
+func (b *Base) scoped(ctx context.Context) bool {
+	if scope := ScopeFromContext(ctx); scope != nil {
+		_, ok := scope.Resolved(b.accessor)
+		return ok
+	}
+	return false
+}
+
+// expect 由期望模拟处理调用，底层服务可以解析时按方法签名转换返回值
+func (b *Base) expect(ctx context.Context, expectations *Expectations, methodName string, args []interface{}) ([]interface{}, error) {
+	values, err := expectations.Call(methodName, args)
+	if err != nil {
+		return nil, err
+	}
+	root, rootErr := b.GetFacadeRootContext(ctx)
+	if rootErr != nil {
+		return values, nil
+	}
+	method := reflect.ValueOf(root).MethodByName(methodName)
+	if !method.IsValid() {
+		return values, nil
+	}
+	return conform(values, method.Type())
+}
+
+// conform 把返回值按方法签名转换，缺少的返回值补零值
+func conform(values []interface{}, typ reflect.Type) ([]interface{}, error) {
+	if len(values) > typ.NumOut() {
+		return nil, fmt.Errorf("facade: %d return values for %s, which returns %d", len(values), typ, typ.NumOut())
+	}
+	results := make([]interface{}, typ.NumOut())
+	for i := range results {
+		var value interface{}
+		if i < len(values) {
+			value = values[i]
+		}
+		converted, err := argument(value, typ.Out(i))
+		if err != nil {
+			return nil, fmt.Errorf("facade: return value %d: %w", i, err)
+		}
+		results[i] = converted.Interface()
+	}
+	return results, nil
+}
+
+// HasMethod 底层服务是否有指定的方法
+func (b *Base) HasMethod(methodName string) bool {
+	root, err := b.GetFacadeRoot()
+	if err != nil {
+		return false
+	}
+	return reflect.ValueOf(root).MethodByName(methodName).IsValid()
+}
+
+// GetMethodSignature 获取底层服务方法的签名
+func (b *Base) GetMethodSignature(methodName string) (reflect.Type, error) {
+	root, err := b.GetFacadeRoot()
+	if err != nil {
+		return nil, err
+	}
+	method := reflect.ValueOf(root).MethodByName(methodName)
+	if !method.IsValid() {
+		return nil, fmt.Errorf("%w: %T.%s", ErrMethodNotFound, root, methodName)
+	}
+	return method.Type(), nil
+}
+
+// Mock 用 mock 替换所有调用方看到的底层服务
+func (b *Base) Mock(mock interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mock = mock
+}
+
+// MockContext 只在 ctx 的门面作用域中用 mock 替换底层服务，ctx 没有作用域时 panic
+func (b *Base) MockContext(ctx context.Context, mock interface{}) {
+	scope := ScopeFromContext(ctx)
+	if scope == nil {
+		panic(ErrNoScope)
+	}
+	scope.Swap(b.accessor, mock)
+}
+
+// MetadataIntercepted 设置了 PartialMock 时，调用记录的 Metadata 中标记调用是否由模拟函数处理的键
+const MetadataIntercepted = "intercepted"
+
+// PartialMock 只模拟 methods 中的方法，其他方法仍调用底层服务
+//
+// methods 的值为函数，参数处理与 Invoke 相同：第一个参数为 context.Context 时自动传入 ctx。
+// 门面没有间谍时同时开始记录调用，模拟和未模拟的调用都记录到间谍，
+// 调用记录的 Metadata[MetadataIntercepted] 标记调用是否由模拟函数处理。
+// methods 的值不是函数时 panic。
+//
+// 使用示例：
+//
+//	Cache.PartialMock(map[string]interface{}{
+//		"Get": func(key string) (interface{}, bool) { return "mocked", true },
+//	})
+//	Cache.GetSpy().ShouldHaveReceived("Put").With("key", facade.Any(), time.Hour)
+func (b *Base) PartialMock(methods map[string]interface{}) {
+	partial := make(map[string]reflect.Value, len(methods))
+	for name, fn := range methods {
+		value := reflect.ValueOf(fn)
+		if value.Kind() != reflect.Func {
+			panic(fmt.Sprintf("facade: partial mock %s.%s must be a function, got %T", b.accessor, name, fn))
+		}
+		partial[name] = value
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.partial = partial
+	if b.spy == nil {
+		b.spy = NewSpy(b.accessor)
+	}
+}
+
+// GetSpy 获取门面当前的间谍，没有间谍时返回 nil
+func (b *Base) GetSpy() *Spy {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.spy
+}
+
+// Spy 开始记录门面的调用，替换之前的间谍
+func (b *Base) Spy() SpyInterface {
+	return b.SpyWith(nil)
+}
+
+// SpyWith 开始记录门面的调用，替换之前的间谍
+//
+// t 不为 nil 时，测试结束时停止记录，并通过 t 报告尚未用 Assert 报告的失败验证。
+func (b *Base) SpyWith(t TestingT) *Spy {
+	spy := NewSpy(b.accessor)
+	b.mu.Lock()
+	b.spy = spy
+	b.mu.Unlock()
+	if t != nil {
+		t.Cleanup(func() {
+			t.Helper()
+			b.mu.Lock()
+			if b.spy == spy {
+				b.spy = nil
+			}
+			b.mu.Unlock()
+			if err := spy.Verify(); err != nil {
+				t.Errorf("%v", err)
+			}
+		})
+	}
+	return spy
+}
+
+// ClearSpy 停止记录门面的调用
+func (b *Base) ClearSpy() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spy = nil
+}
+
+// ShouldReceive 为门面安装期望模拟并声明方法调用期望
+func (b *Base) ShouldReceive(methodName string) ExpectationInterface {
+	return b.Expectations().ShouldReceive(methodName)
+}
+
+// Expectations 获取门面的期望模拟，尚未安装时安装新的期望模拟
+func (b *Base) Expectations() *Expectations {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.expectations == nil {
+		b.expectations = NewExpectations(b.accessor)
+	}
+	return b.expectations
+}
+
+// Strict 期望模拟开启严格模式
+func (b *Base) Strict() {
+	b.Expectations().Strict()
+}
+
+// VerifyAll 测试结束时验证期望模拟，通过 t 报告失败并清除模拟
+func (b *Base) VerifyAll(t TestingT) {
+	expectations := b.Expectations()
+	t.Cleanup(func() {
+		t.Helper()
+		b.mu.Lock()
+		if b.expectations == expectations {
+			b.expectations = nil
+		}
+		b.mu.Unlock()
+		expectations.VerifyAll(t)
+	})
+}
+
+// ClearMock 清除 Mock 设置的模拟对象、期望模拟、部分模拟和间谍
+func (b *Base) ClearMock() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mock = nil
+	b.expectations = nil
+	b.partial = nil
+	b.spy = nil
+}
+
+// IsMocked 是否设置了 Mock、期望模拟或部分模拟
+func (b *Base) IsMocked() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.mock != nil || b.expectations != nil || b.partial != nil
+}
+
+// GetMock 获取 Mock 设置的模拟对象
+func (b *Base) GetMock() interface{} {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.mock
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// Invoke 通过反射调用 root 的方法，返回全部返回值
+//
+// 方法的第一个参数为 context.Context 而 args 中没有时，自动传入 ctx。
+// 可变参数方法的最后一个参数既可以是切片（laraveldoc-gen 生成的门面如此传递），也可以逐个展开。
+// nil 参数按参数类型的零值传入。
+func Invoke(ctx context.Context, root interface{}, methodName string, args []interface{}) ([]interface{}, error) {
+	method := reflect.ValueOf(root).MethodByName(methodName)
+	if !method.IsValid() {
+		return nil, fmt.Errorf("%w: %T.%s", ErrMethodNotFound, root, methodName)
+	}
+	return invokeFunc(ctx, method, fmt.Sprintf("%T.%s", root, methodName), args)
+}
+
+// invokeFunc 通过反射调用函数，name 用于错误信息，参数处理与 Invoke 相同
+func invokeFunc(ctx context.Context, method reflect.Value, name string, args []interface{}) ([]interface{}, error) {
+	typ := method.Type()
+	if typ.NumIn() > 0 && typ.In(0) == contextType {
+		if len(args) == 0 || !isContext(args[0]) {
+			args = append([]interface{}{ctx}, args...)
+		}
+	}
+
+	numIn := typ.NumIn()
+	spread := typ.IsVariadic() && len(args) == numIn && isAssignable(args[numIn-1], typ.In(numIn-1))
+	switch {
+	case spread:
+	case typ.IsVariadic() && len(args) >= numIn-1:
+	case !typ.IsVariadic() && len(args) == numIn:
+	default:
+		return nil, fmt.Errorf("facade: %s expects %d arguments, got %d", name, numIn, len(args))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		paramType := typ.In(min(i, numIn-1))
+		if typ.IsVariadic() && i >= numIn-1 && !spread {
+			paramType = paramType.Elem()
+		}
+		value, err := argument(arg, paramType)
+		if err != nil {
+			return nil, fmt.Errorf("facade: %s argument %d: %w", name, i, err)
+		}
+		in[i] = value
+	}
+
+	var out []reflect.Value
+	if spread {
+		out = method.CallSlice(in)
+	} else {
+		out = method.Call(in)
+	}
+	results := make([]interface{}, len(out))
+	for i, value := range out {
+		results[i] = value.Interface()
+	}
+	return results, nil
+}
+
+// argument 把参数转换为方法参数类型的值
+func argument(arg interface{}, paramType reflect.Type) (reflect.Value, error) {
+	if arg == nil {
+		return reflect.Zero(paramType), nil
+	}
+	value := reflect.ValueOf(arg)
+	if value.Type().AssignableTo(paramType) {
+		return value, nil
+	}
+	if value.Type().ConvertibleTo(paramType) {
+		return value.Convert(paramType), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %T as %s", arg, paramType)
+}
+
+// isAssignable arg 是否可以直接作为 paramType 类型的参数
+func isAssignable(arg interface{}, paramType reflect.Type) bool {
+	return arg == nil || reflect.TypeOf(arg).AssignableTo(paramType)
+}
+
+// isContext arg 是否为 context.Context
+func isContext(arg interface{}) bool {
+	_, ok := arg.(context.Context)
+	return ok
+}
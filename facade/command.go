@@ -0,0 +1,72 @@
+package facade
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// aliasRow aliases:list 的一行
+type aliasRow struct {
+	Alias    string `json:"alias"`
+	Facade   string `json:"facade"`
+	Accessor string `json:"accessor,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// RegisterCommands 注册 aliases:list 命令
+//
+// 命令按别名排序列出别名、指向的门面和门面访问器，指向未注册门面的别名标记为 missing。
+func RegisterCommands(artisan application.ArtisanInterface, loader *AliasLoader) {
+	artisan.Register("aliases:list").
+		SetDescription("List the registered facade aliases").
+		AddOption("json", "", application.InputOptionValueNone, "Output the aliases as JSON", false).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			rows := aliasRows(loader)
+			if asJSON, _ := input.GetOption("json").(bool); asJSON {
+				encoded, err := json.Marshal(rows)
+				if err != nil {
+					return err
+				}
+				return output.WriteLine(string(encoded), application.VerbosityNormal)
+			}
+			if len(rows) == 0 {
+				return output.WriteLine("No facade aliases are registered.", application.VerbosityNormal)
+			}
+			width := len("Alias")
+			for _, row := range rows {
+				width = max(width, len(row.Alias))
+			}
+			for _, row := range rows {
+				line := fmt.Sprintf("%-*s => %s", width, row.Alias, row.Facade)
+				if row.Error != "" {
+					line += " (missing)"
+				} else if row.Accessor != "" {
+					line += " [" + row.Accessor + "]"
+				}
+				if err := output.WriteLine(line, application.VerbosityNormal); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+// aliasRows 按别名排序的别名列表
+func aliasRows(loader *AliasLoader) []aliasRow {
+	aliases := loader.GetAliases()
+	rows := make([]aliasRow, 0, len(aliases))
+	for alias, target := range aliases {
+		row := aliasRow{Alias: alias, Facade: target}
+		if facade, err := loader.Resolve(alias); err != nil {
+			row.Error = err.Error()
+		} else {
+			row.Accessor = facade.GetFacadeAccessor()
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Alias < rows[j].Alias })
+	return rows
+}
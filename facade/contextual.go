@@ -0,0 +1,147 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// ErrNoContainer context 中没有请求级容器
+var ErrNoContainer = errors.New("facade: context has no request container, call facade.WithFacadeContainer")
+
+// ContextualFacade 从请求级容器解析底层服务的门面
+//
+// 底层服务从 ctx 中 WithFacadeContainer 设置的容器按访问器解析，门面本身不缓存实例，
+// 因此并发请求各自看到自己的当前用户、租户数据库等请求级服务。
+// 作用域中的替换和 Mock 仍然优先；ctx 中没有请求级容器时返回 ErrNoContainer，
+// 不会回退到应用容器，避免请求级服务泄漏到其他请求。
+//
+// 使用示例：
+//
+//	var CurrentUser = facade.NewContextual("auth.user")
+//
+//	// 中间件中为每个请求创建子容器
+//	child := facade.NewChildContainer(app)
+//	child.Instance("auth.user", user)
+//	ctx = facade.WithFacadeContainer(ctx, child)
+//
+//	results, err := CurrentUser.CallMethodWithContext(ctx, "GetID", nil)
+type ContextualFacade struct {
+	*Base
+}
+
+// NewContextual 创建从请求级容器解析的门面，accessor 为底层服务在容器中的标识符
+func NewContextual(accessor string) *ContextualFacade {
+	base := New(accessor)
+	base.contextual = true
+	return &ContextualFacade{Base: base}
+}
+
+// resolveContextual 从 ctx 作用域的请求级容器解析 accessor
+func resolveContextual(ctx context.Context, accessor string) (interface{}, error) {
+	resolver := ScopeFromContext(ctx).Container()
+	if resolver == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoContainer, accessor)
+	}
+	instance, err := container.MakeWithContext(ctx, resolver, accessor)
+	if err != nil {
+		return nil, fmt.Errorf("facade: resolve %s: %w", accessor, err)
+	}
+	return instance, nil
+}
+
+// ChildContainer 请求级容器，保存请求级的实例和按请求缓存的绑定，其他服务从父容器解析
+//
+// 可以并发使用；同一绑定并发解析时保留先完成的实例。
+type ChildContainer struct {
+	parent Resolver
+
+	mu        sync.RWMutex
+	instances map[interface{}]interface{}
+	factories map[interface{}]func(c Resolver) (interface{}, error)
+}
+
+var _ Resolver = (*ChildContainer)(nil)
+
+// NewChildContainer 创建以 parent 为父容器的请求级容器，parent 为 nil 时只解析自身的绑定
+func NewChildContainer(parent Resolver) *ChildContainer {
+	return &ChildContainer{
+		parent:    parent,
+		instances: make(map[interface{}]interface{}),
+		factories: make(map[interface{}]func(c Resolver) (interface{}, error)),
+	}
+}
+
+// Instance 在请求级容器中登记实例
+func (c *ChildContainer) Instance(abstract, instance interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.instances[abstract] = instance
+	delete(c.factories, abstract)
+}
+
+// Scoped 在请求级容器中登记绑定，第一次解析时调用 factory，之后在本容器内复用实例
+//
+// factory 的参数为请求级容器本身，可以解析其他请求级服务和父容器的服务。
+func (c *ChildContainer) Scoped(abstract interface{}, factory func(c Resolver) (interface{}, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.factories[abstract] = factory
+	delete(c.instances, abstract)
+}
+
+// Make 解析服务，依次查找请求级实例、请求级绑定和父容器
+func (c *ChildContainer) Make(abstract interface{}) (interface{}, error) {
+	c.mu.RLock()
+	instance, ok := c.instances[abstract]
+	factory := c.factories[abstract]
+	c.mu.RUnlock()
+	if ok {
+		return instance, nil
+	}
+	if factory == nil {
+		if c.parent == nil {
+			return nil, container.NewBindingNotFoundError(abstract)
+		}
+		return c.parent.Make(abstract)
+	}
+
+	instance, err := factory(c)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.instances[abstract]; ok {
+		return existing, nil
+	}
+	c.instances[abstract] = instance
+	return instance, nil
+}
+
+// MakeWithContext 带上下文解析服务，ctx 已取消时返回 ctx.Err()，父容器以 ctx 解析
+func (c *ChildContainer) MakeWithContext(ctx context.Context, abstract interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	_, local := c.instances[abstract]
+	factory := c.factories[abstract]
+	c.mu.RUnlock()
+	if !local && factory == nil && c.parent != nil {
+		return container.MakeWithContext(ctx, c.parent, abstract)
+	}
+	return c.Make(abstract)
+}
+
+// Bound 服务是否在请求级容器中登记
+func (c *ChildContainer) Bound(abstract interface{}) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, instance := c.instances[abstract]
+	_, factory := c.factories[abstract]
+	return instance || factory
+}
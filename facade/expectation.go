@@ -0,0 +1,242 @@
+package facade
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// 期望模拟错误
+var (
+	// ErrUnexpectedCall 严格模式下调用了没有期望的方法，或调用次数超过期望
+	ErrUnexpectedCall = errors.New("facade: unexpected call")
+
+	// ErrCallOutOfOrder 有序期望没有按声明顺序调用
+	ErrCallOutOfOrder = errors.New("facade: call out of order")
+)
+
+// Expectations 基于期望的模拟，对应 Laravel 门面的 shouldReceive（Mockery）
+//
+// Base.ShouldReceive 为门面安装 Expectations，之后通过 CallMethod 发起的调用按声明顺序
+// 查找参数匹配且次数未用完的期望并返回其返回值。意外调用、超出次数和顺序错误在调用时
+// 返回错误并记为失败，由 Verify 或 VerifyAll 报告。
+//
+// 使用示例：
+//
+//	func TestCheckout(t *testing.T) {
+//		Payment.VerifyAll(t)
+//		Payment.Strict()
+//		Payment.ShouldReceive("Authorize").With(facade.Any(), int64(100)).Return("auth-1", nil).Ordered()
+//		Payment.ShouldReceive("Capture").With("auth-1").Return(nil).Ordered()
+//
+//		checkout.Complete(ctx, order)
+//	}
+type Expectations struct {
+	accessor string
+
+	mu           sync.Mutex
+	expectations []*Expectation
+	strict       bool
+	ordered      bool
+	lastOrdered  int
+	failures     []error
+}
+
+// NewExpectations 创建期望模拟，accessor 用于失败信息
+func NewExpectations(accessor string) *Expectations {
+	return &Expectations{accessor: accessor, lastOrdered: -1}
+}
+
+// ShouldReceive 声明方法调用期望
+func (m *Expectations) ShouldReceive(methodName string) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &Expectation{mock: m, method: methodName, index: len(m.expectations), min: 1, max: 1, ordered: m.ordered}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// Strict 开启严格模式，调用没有期望的方法记为失败
+func (m *Expectations) Strict() *Expectations {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.strict = true
+	return m
+}
+
+// Ordered 之后声明的期望都按声明顺序调用，对应 gomock.InOrder
+func (m *Expectations) Ordered() *Expectations {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ordered = true
+	return m
+}
+
+// Call 处理一次调用，返回匹配期望的返回值
+//
+// 非严格模式下没有期望的调用返回 nil 结果和 nil 错误。
+func (m *Expectations) Call(methodName string, args []interface{}) ([]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var matched, exhausted *Expectation
+	for _, e := range m.expectations {
+		if e.method != methodName || !matchFilters(e.filters, args) {
+			continue
+		}
+		if e.max >= 0 && e.calls >= e.max {
+			if exhausted == nil {
+				exhausted = e
+			}
+			continue
+		}
+		matched = e
+		break
+	}
+
+	call := formatCall(methodName, args)
+	if matched == nil {
+		if exhausted != nil {
+			exhausted.calls++
+			return nil, m.fail(fmt.Errorf("%w: facade %s: %s expected %s, called %s", ErrUnexpectedCall, m.accessor, call, describeTimes(exhausted.min, exhausted.max), plural(exhausted.calls)))
+		}
+		if !m.strict {
+			return nil, nil
+		}
+		return nil, m.fail(fmt.Errorf("%w: facade %s: %s%s", ErrUnexpectedCall, m.accessor, call, m.candidates(methodName, args)))
+	}
+
+	matched.calls++
+	if matched.ordered {
+		if matched.index < m.lastOrdered {
+			return nil, m.fail(fmt.Errorf("%w: facade %s: %s called after %s", ErrCallOutOfOrder, m.accessor, call, m.expectations[m.lastOrdered].describe()))
+		}
+		for _, e := range m.expectations[:matched.index] {
+			if e.ordered && e.calls < e.min {
+				return nil, m.fail(fmt.Errorf("%w: facade %s: %s called before %s", ErrCallOutOfOrder, m.accessor, call, e.describe()))
+			}
+		}
+		m.lastOrdered = matched.index
+	}
+	return matched.values, nil
+}
+
+// fail 记录失败并返回
+func (m *Expectations) fail(err error) error {
+	m.failures = append(m.failures, err)
+	return err
+}
+
+// candidates 同名方法的期望及参数差异，用于意外调用的失败信息
+func (m *Expectations) candidates(methodName string, args []interface{}) string {
+	var b strings.Builder
+	for _, e := range m.expectations {
+		if e.method != methodName {
+			continue
+		}
+		b.WriteString("\n  expected " + e.describe())
+		if diff := filterDiff(e.filters, args); diff != "" {
+			b.WriteString("  " + diff)
+		}
+	}
+	if b.Len() == 0 {
+		return ", no expectations were set for " + methodName
+	}
+	return b.String()
+}
+
+// Verify 返回调用时记录的失败和未满足次数的期望合并后的错误
+func (m *Expectations) Verify() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	errs := append([]error(nil), m.failures...)
+	for _, e := range m.expectations {
+		if e.calls < e.min {
+			errs = append(errs, fmt.Errorf("facade %s: expected %s to be called %s, called %s", m.accessor, e.describe(), describeTimes(e.min, e.max), plural(e.calls)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// VerifyAll 立即验证，失败时通过 t 报告并返回 false
+func (m *Expectations) VerifyAll(t TestingT) bool {
+	t.Helper()
+	if err := m.Verify(); err != nil {
+		t.Errorf("%v", err)
+		return false
+	}
+	return true
+}
+
+// Expectation ExpectationInterface 的实现
+type Expectation struct {
+	mock     *Expectations
+	method   string
+	index    int
+	filters  []argFilter
+	values   []interface{}
+	min, max int // max 为 -1 表示不限
+	calls    int
+	ordered  bool
+}
+
+var _ ExpectationInterface = (*Expectation)(nil)
+
+// With 期望的调用参数，可以包含 Matcher
+func (e *Expectation) With(args ...interface{}) ExpectationInterface {
+	return e.update(func() { e.filters = append(e.filters, argFilter{expected: args}) })
+}
+
+// WithArgs 使用 matcher 匹配调用参数
+func (e *Expectation) WithArgs(matcher func([]interface{}) bool) ExpectationInterface {
+	return e.update(func() { e.filters = append(e.filters, argFilter{match: matcher}) })
+}
+
+// Return 调用返回的值
+func (e *Expectation) Return(values ...interface{}) ExpectationInterface {
+	return e.update(func() { e.values = values })
+}
+
+// Times 期望调用 count 次
+func (e *Expectation) Times(count int) ExpectationInterface {
+	return e.update(func() { e.min, e.max = count, count })
+}
+
+// Once 期望调用一次
+func (e *Expectation) Once() ExpectationInterface {
+	return e.Times(1)
+}
+
+// Twice 期望调用两次
+func (e *Expectation) Twice() ExpectationInterface {
+	return e.Times(2)
+}
+
+// Never 期望不被调用
+func (e *Expectation) Never() ExpectationInterface {
+	return e.Times(0)
+}
+
+// AtLeast 期望至少调用 count 次
+func (e *Expectation) AtLeast(count int) ExpectationInterface {
+	return e.update(func() { e.min, e.max = count, -1 })
+}
+
+// Ordered 按声明顺序调用
+func (e *Expectation) Ordered() ExpectationInterface {
+	return e.update(func() { e.ordered = true })
+}
+
+// update 在期望模拟的锁内修改期望
+func (e *Expectation) update(fn func()) *Expectation {
+	e.mock.mu.Lock()
+	defer e.mock.mu.Unlock()
+	fn()
+	return e
+}
+
+// describe 期望的调用，例如 Send("user@example.com", Any())
+func (e *Expectation) describe() string {
+	return describeCall(e.method, e.filters)
+}
@@ -10,12 +10,36 @@
 // - 测试模拟支持
 // - 门面中间件
 // - 门面管理器
+// - 强类型门面代码生成（cmd/laraveldoc-gen facade）
+// - 并发安全的实例缓存和基于 context 的门面作用域
+// - 调用观察者和采样，接入指标和调试记录
+// - 核心服务的现成门面（子包 facades）
+// - 门面别名和 aliases:list 命令
+// - 间谍和参数匹配器（Any、AnyOfType、Satisfies、JSONMatching）
+// - 期望模拟（ShouldReceive）、有序期望、严格模式和 VerifyAll
+// - 部分模拟（PartialMock），未模拟的方法调用底层服务，两类调用都记录到间谍
+// - 从请求级容器解析的上下文门面（ContextualFacade）
 //
 // 包结构：
 // - facade_interface.go - Facade 核心接口
 // - static_facade.go - StaticFacade 静态门面接口
 // - facade_manager.go - FacadeManager 门面管理器接口
-// - mock_interface.go - MockInterface、SpyInterface、CallVerifier 测试相关接口
+// - mock_interface.go - MockInterface、SpyInterface、CallVerifier、ExpectationInterface 测试相关接口
+// - base.go - Base 门面默认实现（含 PartialMock）和 Invoke 反射调用
+// - scope.go - Scope 门面作用域、WithFacadeScope 和 WithFacadeContainer
+// - realtime.go - RealtimeFacade 实时门面、生成代理的注册和按类型的替换栈
+// - swap.go - Swap 按访问器替换底层服务，供各包的 Fake 使用
+// - instrument.go - CallObserver 调用观察者和采样
+// - manager.go - FacadeManager 的默认实现
+// - typed.go - Typed 类型安全的门面
+// - contextual.go - ContextualFacade 上下文门面和 ChildContainer 请求级容器
+// - alias.go - AliasLoader 门面别名加载器
+// - command.go - aliases:list 命令
+// - spy.go - Spy、Verification 间谍和调用验证
+// - matchers.go - Matcher 参数匹配器
+// - expectation.go - Expectations 期望模拟、有序期望和严格模式
+//
+// 子包 facades 提供 DB、Cache、Log 等核心门面和 FacadeServiceProvider。
 //
 // 使用示例：
 //
@@ -29,15 +53,23 @@
 //	Cache.Put("key", "value", time.Hour)
 //	Log.Info("User login", userID)
 //
-//	// 实时门面
-//	userService := facade.Real(&UserService{})
-//	userService.CreateUser(userData)
+//	// 实时门面（接口有 laraveldoc-gen 生成的代理时直接调用，否则回退到反射）
+//	if userService, ok := facade.Real(&UserService{}).(UserServiceContract); ok {
+//		userService.CreateUser(userData)
+//	}
 //
 //	// 测试模拟
 //	mockMailer := &MockMailer{}
 //	Mail.Mock(mockMailer)
 //	defer Mail.ClearMock()
 //
+//	// 并行测试只在作用域内替换
+//	ctx := facade.WithFacadeScope(context.Background())
+//	Mail.MockContext(ctx, mockMailer)
+//
+//	// 根据服务接口生成强类型门面（方法通过 CallMethod 委托）
+//	//go:generate go run github.com/cnote0/laraveldoc/cmd/laraveldoc-gen facade -source ../cache -interface Store -name Cache
+//
 //	// 门面管理器
 //	manager := NewFacadeManager()
 //	manager.Register("Payment", &PaymentFacade{})
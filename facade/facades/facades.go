@@ -0,0 +1,149 @@
+// Package facades 提供框架核心服务的现成门面
+//
+// 门面对应 Laravel 的 Illuminate\Support\Facades，底层服务按 Laravel 的绑定名称从容器解析，
+// 由 FacadeServiceProvider 注册到门面管理器并设置应用容器，使用方不需要为核心服务编写门面。
+//
+// 门面与容器绑定的对应关系：
+//
+//	DB         "database"    database.DB
+//	Cache      "cache"       application.CacheManager
+//	Log        "log"         application.LogManager
+//	Event      "events"      application.EventDispatcher
+//	Config     "config"      application.Config
+//	Route      "router"      routing.Router
+//	Queue      "queue"       queue.Manager
+//	Storage    "filesystem"  storage.Manager
+//	Auth       "auth"        auth.Manager
+//	Validator  "validator"   由应用绑定，通过 CallMethod 调用
+//
+// 使用示例：
+//
+//	app.Register(&facades.FacadeServiceProvider{})
+//	facade.RegisterCommands(app.GetArtisan(), app.MustMake("facade.aliases").(*facade.AliasLoader))
+//
+//	users := facades.DB.MustRoot(ctx).Table("users").Where("active = ?", true)
+//	facades.Log.MustRoot(ctx).Channel("daily").Info("user login", map[string]interface{}{"user_id": userID})
+//	disk, err := facades.Storage.MustRoot(ctx).Disk("s3")
+//
+//	// 测试中替换底层服务
+//	ctx = facade.WithFacadeScope(ctx)
+//	facades.Cache.MockContext(ctx, fakeCache)
+package facades
+
+import (
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/facade"
+	"github.com/cnote0/laraveldoc/queue"
+	"github.com/cnote0/laraveldoc/routing"
+	"github.com/cnote0/laraveldoc/storage"
+)
+
+// 核心门面
+var (
+	DB        = facade.NewTyped[database.DB]("database")
+	Cache     = facade.NewTyped[application.CacheManager]("cache")
+	Log       = facade.NewTyped[application.LogManager]("log")
+	Event     = facade.NewTyped[application.EventDispatcher]("events")
+	Config    = facade.NewTyped[application.Config]("config")
+	Route     = facade.NewTyped[routing.Router]("router")
+	Queue     = facade.NewTyped[queue.Manager]("queue")
+	Storage   = facade.NewTyped[storage.Manager]("filesystem")
+	Auth      = facade.NewTyped[auth.Manager]("auth")
+	Validator = facade.New("validator")
+)
+
+// Core 核心门面，键为注册到门面管理器的名称
+func Core() map[string]facade.Facade {
+	return map[string]facade.Facade{
+		"DB":        DB,
+		"Cache":     Cache,
+		"Log":       Log,
+		"Event":     Event,
+		"Config":    Config,
+		"Route":     Route,
+		"Queue":     Queue,
+		"Storage":   Storage,
+		"Auth":      Auth,
+		"Validator": Validator,
+	}
+}
+
+// FacadeServiceProvider 注册核心门面的服务提供者
+//
+// Register 把容器设置为门面的默认应用容器，把核心门面注册到门面管理器，
+// 并以 "facade.manager" 绑定管理器。Manager 为空时使用 facade.DefaultManager，
+// 默认管理器也未设置时创建新的管理器并设为默认。
+// Boot 从配置的 app.aliases 登记门面别名，别名加载器绑定为 "facade.aliases"，
+// 并在应用终止时撤销实时门面的所有替换。
+type FacadeServiceProvider struct {
+	Manager facade.FacadeManager
+	Aliases *facade.AliasLoader
+}
+
+// Register 注册核心门面
+func (p *FacadeServiceProvider) Register(c container.Container) error {
+	manager := p.Manager
+	if manager == nil {
+		manager = facade.DefaultManager()
+	}
+	if manager == nil {
+		manager = facade.NewFacadeManager()
+		facade.SetDefaultManager(manager)
+	}
+	p.Manager = manager
+
+	facade.SetFacadeApplication(c)
+	manager.SetContainer(c)
+	for name, f := range Core() {
+		if manager.Has(name) {
+			continue
+		}
+		if err := manager.Register(name, f); err != nil {
+			return err
+		}
+	}
+	if p.Aliases == nil {
+		p.Aliases = facade.NewAliasLoader(manager)
+	}
+	if err := c.Instance("facade.manager", manager); err != nil {
+		return err
+	}
+	return c.Instance("facade.aliases", p.Aliases)
+}
+
+// Boot 从配置登记门面别名，容器中没有配置时跳过
+//
+// 容器为 application.Application 时注册终止回调，应用终止时撤销实时门面的所有替换。
+func (p *FacadeServiceProvider) Boot(c container.Container) error {
+	if app, ok := c.(application.Application); ok {
+		app.Terminating(func() error {
+			facade.DefaultRealtime().ClearSwaps()
+			return nil
+		})
+	}
+	if !c.Bound("config") {
+		return nil
+	}
+	config, err := c.Make("config")
+	if err != nil {
+		return err
+	}
+	repository, ok := config.(facade.ConfigRepository)
+	if !ok {
+		return nil
+	}
+	return p.Aliases.LoadConfig(repository, "app.aliases")
+}
+
+// Provides 提供的服务
+func (p *FacadeServiceProvider) Provides() []string {
+	return []string{"facade.manager", "facade.aliases"}
+}
+
+// IsDeferred 门面需要在应用启动时注册
+func (p *FacadeServiceProvider) IsDeferred() bool {
+	return false
+}
@@ -0,0 +1,99 @@
+package facade
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CallObserver 门面调用观察者，在 Base.CallMethod 和 CallMethodWithContext 返回后调用
+//
+// accessor 为门面访问器。record.Error 为解析或调用错误；
+// 方法本身的最后一个返回值为非 nil 的 error 时也记为调用错误。
+type CallObserver func(accessor string, record CallRecord)
+
+var (
+	observersMu sync.Mutex
+	observers   atomic.Pointer[[]CallObserver]
+)
+
+// Observe 注册全局门面调用观察者，用于把门面调用接入指标和调试记录
+//
+// 没有观察者时 CallMethod 不计时也不构造 CallRecord。
+//
+// 使用示例：
+//
+//	facade.Observe(metrics.FacadeObserver(registry))
+//	facade.Observe(facade.Sample(0.1, t.FacadeObserver()))
+func Observe(observer CallObserver) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	var list []CallObserver
+	if current := observers.Load(); current != nil {
+		list = append(list, *current...)
+	}
+	list = append(list, observer)
+	observers.Store(&list)
+}
+
+// ClearObservers 移除所有门面调用观察者
+func ClearObservers() {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	observers.Store(nil)
+}
+
+// Sample 按比例采样的观察者，rate 在 0 到 1 之间，出错的调用总是记录
+func Sample(rate float64, observer CallObserver) CallObserver {
+	return func(accessor string, record CallRecord) {
+		if record.Error != nil || rate >= 1 || (rate > 0 && rand.Float64() < rate) {
+			observer(accessor, record)
+		}
+	}
+}
+
+// SlowerThan 只记录耗时不小于 threshold 的调用，出错的调用总是记录
+func SlowerThan(threshold time.Duration, observer CallObserver) CallObserver {
+	return func(accessor string, record CallRecord) {
+		if record.Error != nil || record.Duration >= threshold {
+			observer(accessor, record)
+		}
+	}
+}
+
+// instrumented 调用 call，有观察者或间谍时记录调用，通知观察者并记录到间谍
+//
+// metadata 在 call 返回后作为调用记录的 Metadata。
+func instrumented(ctx context.Context, accessor, methodName string, args []interface{}, spy *Spy, metadata map[string]interface{}, call func() ([]interface{}, error)) ([]interface{}, error) {
+	current := observers.Load()
+	if spy == nil && (current == nil || len(*current) == 0) {
+		return call()
+	}
+
+	start := time.Now()
+	results, err := call()
+	record := CallRecord{
+		Method:    methodName,
+		Args:      args,
+		Result:    results,
+		Timestamp: start,
+		Context:   ctx,
+		Error:     err,
+		Duration:  time.Since(start),
+		Metadata:  metadata,
+	}
+	if record.Error == nil && len(results) > 0 {
+		record.Error, _ = results[len(results)-1].(error)
+	}
+	if spy != nil {
+		spy.Record(record)
+	}
+	if current != nil {
+		for _, observer := range *current {
+			observer(accessor, record)
+		}
+	}
+	return results, err
+}
@@ -0,0 +1,145 @@
+package facade
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// 门面管理器错误
+var (
+	// ErrFacadeNotFound 门面未注册
+	ErrFacadeNotFound = errors.New("facade: facade not registered")
+
+	// ErrFacadeExists 同名门面已注册
+	ErrFacadeExists = errors.New("facade: facade already registered")
+)
+
+// manager FacadeManager 的默认实现
+type manager struct {
+	mu        sync.RWMutex
+	facades   map[string]Facade
+	container interface{}
+}
+
+// NewFacadeManager 创建门面管理器
+func NewFacadeManager() FacadeManager {
+	return &manager{facades: make(map[string]Facade)}
+}
+
+// Register 注册门面，同名门面已注册时返回 ErrFacadeExists
+//
+// 管理器设置了容器时，门面的应用容器同时设置为该容器。
+func (m *manager) Register(name string, facade Facade) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.facades[name]; ok {
+		return fmt.Errorf("%w: %s", ErrFacadeExists, name)
+	}
+	if m.container != nil {
+		facade.SetFacadeApplication(m.container)
+	}
+	m.facades[name] = facade
+	return nil
+}
+
+// RegisterBatch 批量注册门面，遇到错误时停止并返回
+func (m *manager) RegisterBatch(facades map[string]Facade) error {
+	for name, facade := range facades {
+		if err := m.Register(name, facade); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resolve 解析门面
+func (m *manager) Resolve(name string) (Facade, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	facade, ok := m.facades[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFacadeNotFound, name)
+	}
+	return facade, nil
+}
+
+// Has 门面是否已注册
+func (m *manager) Has(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.facades[name]
+	return ok
+}
+
+// MustResolve 解析门面，未注册时 panic
+func (m *manager) MustResolve(name string) Facade {
+	facade, err := m.Resolve(name)
+	if err != nil {
+		panic(err)
+	}
+	return facade
+}
+
+// Remove 移除门面，未注册时返回 ErrFacadeNotFound
+func (m *manager) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.facades[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrFacadeNotFound, name)
+	}
+	delete(m.facades, name)
+	return nil
+}
+
+// Clear 移除所有门面
+func (m *manager) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.facades = make(map[string]Facade)
+}
+
+// GetAll 获取所有门面的副本
+func (m *manager) GetAll() map[string]Facade {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	facades := make(map[string]Facade, len(m.facades))
+	for name, facade := range m.facades {
+		facades[name] = facade
+	}
+	return facades
+}
+
+// SetContainer 设置容器，并设置为所有已注册门面的应用容器
+func (m *manager) SetContainer(container interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.container = container
+	for _, facade := range m.facades {
+		facade.SetFacadeApplication(container)
+	}
+}
+
+// GetContainer 获取容器
+func (m *manager) GetContainer() interface{} {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.container
+}
+
+// defaultManager 默认门面管理器
+var defaultManager atomic.Value
+
+// SetDefaultManager 设置默认门面管理器
+func SetDefaultManager(manager FacadeManager) {
+	defaultManager.Store(&manager)
+}
+
+// DefaultManager 获取默认门面管理器，未设置时返回 nil
+func DefaultManager() FacadeManager {
+	if manager, ok := defaultManager.Load().(*FacadeManager); ok {
+		return *manager
+	}
+	return nil
+}
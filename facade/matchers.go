@@ -0,0 +1,162 @@
+package facade
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/support/arr"
+)
+
+// Matcher 参数匹配器，用于 CallVerifier.With 中代替字面值
+//
+// With 的参数不是 Matcher 时按 reflect.DeepEqual 比较。
+type Matcher interface {
+	// Match 参数是否匹配
+	Match(value interface{}) bool
+
+	// String 匹配器的描述，用于验证失败的信息
+	String() string
+}
+
+// matcherFunc 由函数和描述构成的匹配器
+type matcherFunc struct {
+	match       func(value interface{}) bool
+	description string
+}
+
+func (m matcherFunc) Match(value interface{}) bool { return m.match(value) }
+func (m matcherFunc) String() string               { return m.description }
+
+// Any 匹配任意参数，包括 nil
+func Any() Matcher {
+	return matcherFunc{match: func(interface{}) bool { return true }, description: "Any()"}
+}
+
+// AnyOfType 匹配类型为 T 的参数，T 为接口时匹配实现了该接口的参数
+//
+// 示例：
+//
+//	spy.ShouldHaveReceived("Put").With("key", facade.AnyOfType[time.Duration]())
+func AnyOfType[T any]() Matcher {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	return matcherFunc{
+		match: func(value interface{}) bool {
+			_, ok := value.(T)
+			return ok
+		},
+		description: "AnyOfType[" + typ.String() + "]()",
+	}
+}
+
+// Satisfies 匹配类型为 T 且 fn 返回 true 的参数
+//
+// 示例：
+//
+//	spy.ShouldHaveReceived("Charge").With(facade.Satisfies(func(amount int64) bool { return amount > 100 }))
+func Satisfies[T any](fn func(T) bool) Matcher {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	return matcherFunc{
+		match: func(value interface{}) bool {
+			typed, ok := value.(T)
+			return ok && fn(typed)
+		},
+		description: "Satisfies[" + typ.String() + "](fn)",
+	}
+}
+
+// JSONMatching 匹配 JSON 编码后 path 处的值等于 value 的参数
+//
+// 参数为 string、[]byte 或 json.RawMessage 时按 JSON 文本解析，其他参数先编码为 JSON。
+// path 为点号分隔的路径（见 support/arr.Get），为空时比较整个文档。
+// value 先经过 JSON 编码再解码后比较，因此 1 与 1.0、结构体与同字段的 map 相等；
+// value 为 Matcher 时用它匹配 path 处解码后的值。
+//
+// 示例：
+//
+//	spy.ShouldHaveReceived("Publish").With("orders", facade.JSONMatching("order.status", "paid"))
+func JSONMatching(path string, value interface{}) Matcher {
+	return matcherFunc{
+		match: func(arg interface{}) bool {
+			document, err := decodeJSON(arg)
+			if err != nil {
+				return false
+			}
+			actual := document
+			if path != "" {
+				object, ok := document.(map[string]interface{})
+				if !ok || !arr.Has(object, path) {
+					return false
+				}
+				actual = arr.Get(object, path)
+			}
+			if matcher, ok := value.(Matcher); ok {
+				return matcher.Match(actual)
+			}
+			expected, err := normalizeJSON(value)
+			return err == nil && reflect.DeepEqual(actual, expected)
+		},
+		description: fmt.Sprintf("JSONMatching(%q, %s)", path, formatValue(value)),
+	}
+}
+
+// decodeJSON 把参数解析为 JSON 文档
+func decodeJSON(arg interface{}) (interface{}, error) {
+	var data []byte
+	switch v := arg.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	default:
+		return normalizeJSON(arg)
+	}
+	var document interface{}
+	err := json.Unmarshal(data, &document)
+	return document, err
+}
+
+// normalizeJSON 把值编码为 JSON 再解码，得到与 encoding/json 解码结果一致的表示
+func normalizeJSON(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	err = json.Unmarshal(data, &document)
+	return document, err
+}
+
+// matchArgument 参数是否与期望值匹配
+func matchArgument(expected, actual interface{}) bool {
+	if matcher, ok := expected.(Matcher); ok {
+		return matcher.Match(actual)
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+// formatValue 格式化期望值或实际参数，字符串加引号，Matcher 使用其描述
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case Matcher:
+		return v.String()
+	case string:
+		return fmt.Sprintf("%q", v)
+	case nil:
+		return "nil"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// formatCall 格式化调用，例如 Send("user@example.com", 3)
+func formatCall(method string, args []interface{}) string {
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		formatted[i] = formatValue(arg)
+	}
+	return method + "(" + strings.Join(formatted, ", ") + ")"
+}
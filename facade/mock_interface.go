@@ -179,6 +179,35 @@ type MockInterface interface {
 	//   spy.ShouldHaveReceived("Error").Times(0)
 	Spy() SpyInterface
 
+	// ShouldReceive 设置方法调用期望
+	//
+	// 为门面安装期望模拟（Expectations），之后通过 CallMethod 发起的调用
+	// 按期望返回预设的值，不再调用底层服务。
+	//
+	// 示例：
+	//   Cache.ShouldReceive("Get").With("key").Return("value", nil).Once()
+	ShouldReceive(methodName string) ExpectationInterface
+
+	// Strict 严格模式
+	//
+	// 严格模式下调用没有设置期望的方法记为失败并返回 ErrUnexpectedCall，
+	// 否则返回零值。
+	//
+	// 示例：
+	//   Payment.Strict()
+	//   Payment.ShouldReceive("Charge").With(facade.Any()).Return(nil)
+	Strict()
+
+	// VerifyAll 在测试结束时验证所有期望
+	//
+	// 通过 t.Cleanup 注册：测试结束时报告意外调用、顺序错误和未满足的期望，并清除模拟。
+	//
+	// 示例：
+	//   Payment.VerifyAll(t)
+	//   Payment.ShouldReceive("Authorize").Ordered()
+	//   Payment.ShouldReceive("Capture").Ordered()
+	VerifyAll(t TestingT)
+
 	// ClearMock 清除模拟
 	//
 	// 恢复门面的原始实现，移除所有模拟设置。
@@ -207,3 +236,61 @@ type MockInterface interface {
 	//   mockCache.AssertExpectations(t)
 	GetMock() interface{}
 }
+
+// ExpectationInterface 方法调用期望接口
+//
+// ExpectationInterface 描述一个方法的期望调用：参数、返回值、次数以及调用顺序，
+// 行为与 gomock 的 Call、mockery 的 Call 相同。
+type ExpectationInterface interface {
+	// With 期望的调用参数
+	//
+	// 参数可以包含 Matcher，其他参数按 reflect.DeepEqual 比较。
+	//
+	// 示例：
+	//   Mail.ShouldReceive("Send").With("user@example.com", facade.Any())
+	With(args ...interface{}) ExpectationInterface
+
+	// WithArgs 使用自定义函数匹配调用参数
+	//
+	// 示例：
+	//   Order.ShouldReceive("Process").WithArgs(func(args []interface{}) bool {
+	//       return args[0].(*Order).Amount > 100
+	//   })
+	WithArgs(matcher func([]interface{}) bool) ExpectationInterface
+
+	// Return 调用返回的值
+	//
+	// 底层服务可以解析时，返回值按方法签名转换，缺少的返回值补零值。
+	//
+	// 示例：
+	//   Cache.ShouldReceive("Get").Return("value", true, nil)
+	Return(values ...interface{}) ExpectationInterface
+
+	// Times 期望的调用次数，未设置时期望调用一次
+	//
+	// 示例：
+	//   Log.ShouldReceive("Info").Times(3)
+	Times(count int) ExpectationInterface
+
+	// Once 期望调用一次
+	Once() ExpectationInterface
+
+	// Twice 期望调用两次
+	Twice() ExpectationInterface
+
+	// Never 期望不被调用
+	Never() ExpectationInterface
+
+	// AtLeast 期望至少调用 count 次
+	AtLeast(count int) ExpectationInterface
+
+	// Ordered 按顺序调用
+	//
+	// 设置了 Ordered 的期望必须按声明顺序满足：在前一个有序期望达到最少次数之前调用后一个，
+	// 或在后一个被调用之后再调用前一个，都记为顺序错误并返回 ErrCallOutOfOrder。
+	//
+	// 示例：
+	//   Payment.ShouldReceive("Authorize").Ordered()
+	//   Payment.ShouldReceive("Capture").Ordered()
+	Ordered() ExpectationInterface
+}
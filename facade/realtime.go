@@ -0,0 +1,202 @@
+package facade
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ProxyFactory 创建实时门面代理，返回值必须实现注册时的接口
+type ProxyFactory func(f StaticFacade) interface{}
+
+// RealtimeFacade 实时门面，把任意服务实例包装为门面，对应 Laravel 的 Facades\ 命名空间
+//
+// laraveldoc-gen facade -mode realtime 为接口生成代理，并在 init 中通过 RegisterRealtime 注册。
+// Create 为实现了已注册接口的实例返回代理，代理保留接口的方法签名并直接调用底层服务；
+// 没有匹配的代理时回退到 Base，方法通过 CallMethod 反射调用。
+//
+// 使用示例：
+//
+//	//go:generate go run github.com/cnote0/laraveldoc/cmd/laraveldoc-gen facade -source . -interface PaymentGateway -name PaymentGatewayProxy -mode realtime -output payment_proxy.go
+//
+//	// 没有注册 PaymentGateway 的代理时 Real 返回 *facade.Base，断言失败
+//	gateway, ok := facade.Real(&StripeGateway{}).(PaymentGateway)
+//	if !ok {
+//		return errors.New("PaymentGateway proxy is not registered")
+//	}
+//	gateway.Charge(ctx, amount)
+//
+//	// 代理嵌入 StaticFacade，测试中可以替换底层服务
+//	gateway.(*PaymentGatewayProxy).StaticFacade.(*facade.Base).Mock(&FakeGateway{})
+//
+//	// 或按类型替换所有 *StripeGateway 的实时门面，嵌套的替换按栈恢复
+//	t.Cleanup(facade.DefaultRealtime().Swap((*StripeGateway)(nil), &FakeGateway{}))
+type RealtimeFacade struct {
+	mu      sync.RWMutex
+	proxies []realtimeProxy
+	swaps   map[reflect.Type][]realtimeSwap
+	nextID  uint64
+}
+
+// realtimeSwap 替换栈中的一项
+type realtimeSwap struct {
+	id       uint64
+	instance interface{}
+}
+
+// realtimeProxy 接口类型和代理工厂
+type realtimeProxy struct {
+	iface   reflect.Type
+	factory ProxyFactory
+}
+
+// NewRealtimeFacade 创建实时门面
+func NewRealtimeFacade() *RealtimeFacade {
+	return &RealtimeFacade{}
+}
+
+// Register 注册接口的代理工厂，iface 为接口的 nil 指针，例如 (*PaymentGateway)(nil)
+//
+// 同一接口重复注册时替换原工厂。
+func (r *RealtimeFacade) Register(iface interface{}, factory ProxyFactory) {
+	typ := reflect.TypeOf(iface)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("facade: realtime proxy must be registered with an interface pointer such as (*Service)(nil), got %T", iface))
+	}
+	typ = typ.Elem()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, proxy := range r.proxies {
+		if proxy.iface == typ {
+			r.proxies[i].factory = factory
+			return
+		}
+	}
+	r.proxies = append(r.proxies, realtimeProxy{iface: typ, factory: factory})
+}
+
+// Create 为 target 创建实时门面
+//
+// target 实现了已注册的接口时返回该接口的代理，多个接口匹配时取最先注册的接口；
+// 否则返回以 target 为底层服务的 *Base。
+func (r *RealtimeFacade) Create(target interface{}) interface{} {
+	base := NewFor(target)
+	base.realtime = r
+	if factory := r.lookup(reflect.TypeOf(target)); factory != nil {
+		return factory(base)
+	}
+	return base
+}
+
+// HasProxy target 是否有已注册的代理
+func (r *RealtimeFacade) HasProxy(target interface{}) bool {
+	return r.lookup(reflect.TypeOf(target)) != nil
+}
+
+// lookup 查找 typ 实现的第一个已注册接口的代理工厂
+func (r *RealtimeFacade) lookup(typ reflect.Type) ProxyFactory {
+	if typ == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, proxy := range r.proxies {
+		if typ.Implements(proxy.iface) {
+			return proxy.factory
+		}
+	}
+	return nil
+}
+
+// Swap 把类型与 target 相同的实时门面的底层服务替换为 instance，返回撤销这次替换的函数
+//
+// target 可以是实例或类型化的 nil 指针，例如 (*StripeGateway)(nil)。
+// 替换按类型组成栈：嵌套的替换覆盖之前的替换，Restore 或返回的函数撤销后恢复之前的实例。
+// 返回的函数只撤销这一次替换，即使之后还有其他替换也不影响它们，重复调用不做处理。
+// 已经通过 Create 创建的实时门面同样生效，但 ctx 的门面作用域中的替换优先。
+func (r *RealtimeFacade) Swap(target, instance interface{}) func() {
+	typ := reflect.TypeOf(target)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.swaps == nil {
+		r.swaps = make(map[reflect.Type][]realtimeSwap)
+	}
+	r.nextID++
+	id := r.nextID
+	r.swaps[typ] = append(r.swaps[typ], realtimeSwap{id: id, instance: instance})
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		stack := r.swaps[typ]
+		for i, swap := range stack {
+			if swap.id == id {
+				r.setStack(typ, append(stack[:i:i], stack[i+1:]...))
+				return
+			}
+		}
+	}
+}
+
+// Restore 撤销类型与 target 相同的最近一次替换，没有替换时返回 false
+func (r *RealtimeFacade) Restore(target interface{}) bool {
+	typ := reflect.TypeOf(target)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stack := r.swaps[typ]
+	if len(stack) == 0 {
+		return false
+	}
+	r.setStack(typ, stack[:len(stack)-1])
+	return true
+}
+
+// ClearSwaps 撤销所有替换，应用终止时由 FacadeServiceProvider 注册的回调调用
+func (r *RealtimeFacade) ClearSwaps() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.swaps = nil
+}
+
+// Swapped 获取类型与 target 相同的实时门面当前替换的实例
+func (r *RealtimeFacade) Swapped(target interface{}) (interface{}, bool) {
+	return r.swapped(reflect.TypeOf(target))
+}
+
+// swapped 获取 typ 当前替换的实例
+func (r *RealtimeFacade) swapped(typ reflect.Type) (interface{}, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	stack := r.swaps[typ]
+	if len(stack) == 0 {
+		return nil, false
+	}
+	return stack[len(stack)-1].instance, true
+}
+
+// setStack 设置 typ 的替换栈，栈为空时删除
+func (r *RealtimeFacade) setStack(typ reflect.Type, stack []realtimeSwap) {
+	if len(stack) == 0 {
+		delete(r.swaps, typ)
+		return
+	}
+	r.swaps[typ] = stack
+}
+
+// defaultRealtime 生成的代理注册到的默认实时门面
+var defaultRealtime = NewRealtimeFacade()
+
+// DefaultRealtime 获取默认实时门面
+func DefaultRealtime() *RealtimeFacade {
+	return defaultRealtime
+}
+
+// RegisterRealtime 在默认实时门面中注册代理工厂，由生成的代码在 init 中调用
+func RegisterRealtime(iface interface{}, factory ProxyFactory) {
+	defaultRealtime.Register(iface, factory)
+}
+
+// Real 通过默认实时门面为 target 创建实时门面
+func Real(target interface{}) interface{} {
+	return defaultRealtime.Create(target)
+}
@@ -0,0 +1,107 @@
+package facade
+
+import (
+	"context"
+	"sync"
+)
+
+// Scope 门面作用域
+//
+// 作用域保存在 context 中，按访问器覆盖门面的底层服务。
+// 门面通过 CallMethodWithContext 或 GetFacadeRootContext 解析时先查找 context 中的作用域，
+// 因此并行测试可以各自替换同一个门面而互不影响。
+// 嵌套的作用域查找不到时回退到外层作用域。
+// WithFacadeContainer 创建的作用域还携带请求级的容器，供 ContextualFacade 解析底层服务。
+type Scope struct {
+	parent    *Scope
+	container Resolver
+
+	mu        sync.RWMutex
+	instances map[string]interface{}
+}
+
+type scopeKey struct{}
+
+// WithFacadeScope 返回携带新门面作用域的 context，ctx 中已有作用域时新作用域嵌套在其中
+//
+// 使用示例：
+//
+//	func TestSendInvoice(t *testing.T) {
+//		t.Parallel()
+//		ctx := facade.WithFacadeScope(context.Background())
+//		Mail.MockContext(ctx, &MockMailer{})
+//
+//		// 只有通过 ctx 调用的门面方法会使用 MockMailer
+//		err := invoices.Send(ctx, invoice)
+//	}
+func WithFacadeScope(ctx context.Context) context.Context {
+	scope := &Scope{parent: ScopeFromContext(ctx), instances: make(map[string]interface{})}
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// WithFacadeContainer 返回携带新门面作用域的 context，作用域使用 c 解析 ContextualFacade 的底层服务
+//
+// c 通常是每个请求创建的 ChildContainer，保存当前用户、租户数据库等请求级服务。
+//
+// 使用示例：
+//
+//	func FacadeScope(app facade.Resolver, next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			child := facade.NewChildContainer(app)
+//			child.Scoped("tenant.db", func(c facade.Resolver) (interface{}, error) {
+//				return tenants.Connect(r.Header.Get("X-Tenant"))
+//			})
+//			next.ServeHTTP(w, r.WithContext(facade.WithFacadeContainer(r.Context(), child)))
+//		})
+//	}
+func WithFacadeContainer(ctx context.Context, c Resolver) context.Context {
+	ctx = WithFacadeScope(ctx)
+	ScopeFromContext(ctx).container = c
+	return ctx
+}
+
+// ScopeFromContext 获取 ctx 中的门面作用域，没有时返回 nil
+func ScopeFromContext(ctx context.Context) *Scope {
+	if ctx == nil {
+		return nil
+	}
+	scope, _ := ctx.Value(scopeKey{}).(*Scope)
+	return scope
+}
+
+// Swap 在作用域中用 instance 替换访问器为 accessor 的门面的底层服务
+func (s *Scope) Swap(accessor string, instance interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.instances[accessor] = instance
+}
+
+// Forget 移除作用域中对 accessor 的替换，不影响外层作用域
+func (s *Scope) Forget(accessor string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.instances, accessor)
+}
+
+// Resolved 查找作用域及外层作用域中对 accessor 的替换
+func (s *Scope) Resolved(accessor string) (interface{}, bool) {
+	for scope := s; scope != nil; scope = scope.parent {
+		scope.mu.RLock()
+		instance, ok := scope.instances[accessor]
+		scope.mu.RUnlock()
+		if ok {
+			return instance, true
+		}
+	}
+	return nil, false
+}
+
+// Container 获取作用域或外层作用域中最近的请求级容器，没有时返回 nil
+func (s *Scope) Container() Resolver {
+	for scope := s; scope != nil; scope = scope.parent {
+		if scope.container != nil {
+			return scope.container
+		}
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+package facade
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TestingT Spy 报告验证失败使用的测试接口，*testing.T 满足该接口
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// Spy SpyInterface 的实现，记录门面的调用，不改变调用行为
+//
+// Base.Spy 和 Base.SpyWith 创建的间谍记录之后通过 CallMethod 和 CallMethodWithContext 发起的调用。
+// ShouldHaveReceived、ShouldNotHaveReceived 返回的 *Verification 在链式设置完成后求值：
+// 调用 Assert(t) 立即报告，或由 SpyWith(t) 在测试结束时报告所有尚未报告的验证。
+//
+// 使用示例：
+//
+//	spy := Mail.SpyWith(t)
+//	userService.Register(ctx, "user@example.com")
+//
+//	spy.ShouldHaveReceived("Send").With("user@example.com", facade.Any()).Times(1)
+//	spy.ShouldNotHaveReceived("Queue")
+//
+//	// 失败信息列出同名方法的实际调用和不匹配的参数：
+//	// facade mailer: expected Send("user@example.com", Any()) to be called exactly 1 time, called 0 times
+//	// recorded Send calls:
+//	//   Send("admin@example.com", "Welcome")  arg 0: want "user@example.com", got "admin@example.com"
+type Spy struct {
+	accessor string
+
+	mu            sync.Mutex
+	calls         []CallRecord
+	verifications []*Verification
+}
+
+var _ SpyInterface = (*Spy)(nil)
+
+// NewSpy 创建间谍，accessor 用于失败信息
+func NewSpy(accessor string) *Spy {
+	return &Spy{accessor: accessor}
+}
+
+// Record 记录一次调用
+func (s *Spy) Record(record CallRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, record)
+}
+
+// ShouldHaveReceived 验证方法被调用，默认至少一次
+func (s *Spy) ShouldHaveReceived(methodName string) CallVerifier {
+	return s.verify(methodName, 1, -1)
+}
+
+// ShouldNotHaveReceived 验证方法未被调用
+func (s *Spy) ShouldNotHaveReceived(methodName string) CallVerifier {
+	return s.verify(methodName, 0, 0)
+}
+
+// verify 创建验证并登记，供 Verify 和测试结束时报告
+func (s *Spy) verify(methodName string, min, max int) *Verification {
+	v := &Verification{spy: s, method: methodName, min: min, max: max}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verifications = append(s.verifications, v)
+	return v
+}
+
+// GetCallCount 获取方法的调用次数
+func (s *Spy) GetCallCount(methodName string) int {
+	count := 0
+	for _, call := range s.GetCalls() {
+		if call.Method == methodName {
+			count++
+		}
+	}
+	return count
+}
+
+// GetCalls 获取所有调用记录的副本
+func (s *Spy) GetCalls() []CallRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]CallRecord(nil), s.calls...)
+}
+
+// Reset 清除调用记录和验证
+func (s *Spy) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+	s.verifications = nil
+}
+
+// Verify 求值所有尚未报告的验证，返回失败合并后的错误
+func (s *Spy) Verify() error {
+	s.mu.Lock()
+	pending := make([]*Verification, 0, len(s.verifications))
+	for _, v := range s.verifications {
+		if !v.reported {
+			v.reported = true
+			pending = append(pending, v)
+		}
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, v := range pending {
+		if err := v.Err(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// argFilter 参数过滤条件
+type argFilter struct {
+	expected []interface{}
+	match    func(args []interface{}) bool
+}
+
+// Verification CallVerifier 的实现
+//
+// 次数默认为 ShouldHaveReceived 的至少一次或 ShouldNotHaveReceived 的零次，
+// 第一次调用 Times、AtLeast 或 AtMost 时替换默认值。
+type Verification struct {
+	spy      *Spy
+	method   string
+	filters  []argFilter
+	min, max int // max 为 -1 表示不限
+	counted  bool
+	reported bool
+}
+
+var _ CallVerifier = (*Verification)(nil)
+
+// Times 验证调用次数为 count
+func (v *Verification) Times(count int) CallVerifier {
+	v.counted = true
+	v.min, v.max = count, count
+	return v
+}
+
+// AtLeast 验证调用次数不少于 count
+func (v *Verification) AtLeast(count int) CallVerifier {
+	if !v.counted {
+		v.counted = true
+		v.max = -1
+	}
+	v.min = count
+	return v
+}
+
+// AtMost 验证调用次数不多于 count
+func (v *Verification) AtMost(count int) CallVerifier {
+	if !v.counted {
+		v.counted = true
+		v.min = 0
+	}
+	v.max = count
+	return v
+}
+
+// With 只计算参数与 args 匹配的调用，args 可以包含 Matcher
+func (v *Verification) With(args ...interface{}) CallVerifier {
+	v.filters = append(v.filters, argFilter{expected: args})
+	return v
+}
+
+// WithArgs 只计算 matcher 返回 true 的调用
+func (v *Verification) WithArgs(matcher func([]interface{}) bool) CallVerifier {
+	v.filters = append(v.filters, argFilter{match: matcher})
+	return v
+}
+
+// Err 求值验证，失败时返回列出实际调用的错误
+func (v *Verification) Err() error {
+	var candidates []CallRecord
+	var others []string
+	count := 0
+	for _, call := range v.spy.GetCalls() {
+		if call.Method != v.method {
+			others = append(others, formatCall(call.Method, call.Args))
+			continue
+		}
+		candidates = append(candidates, call)
+		if matchFilters(v.filters, call.Args) {
+			count++
+		}
+	}
+	if count >= v.min && (v.max < 0 || count <= v.max) {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "facade %s: expected %s to be called %s, called %s", v.spy.accessor, describeCall(v.method, v.filters), describeTimes(v.min, v.max), plural(count))
+	switch {
+	case len(candidates) > 0:
+		fmt.Fprintf(&b, "\nrecorded %s calls:", v.method)
+		for _, call := range candidates {
+			b.WriteString("\n  " + formatCall(call.Method, call.Args))
+			if diff := filterDiff(v.filters, call.Args); diff != "" {
+				b.WriteString("  " + diff)
+			}
+		}
+	case len(others) > 0:
+		fmt.Fprintf(&b, "\nno %s calls were recorded, other calls:\n  %s", v.method, strings.Join(others, "\n  "))
+	default:
+		b.WriteString("\nno calls were recorded")
+	}
+	return errors.New(b.String())
+}
+
+// Assert 立即求值验证，失败时通过 t 报告并返回 false，报告过的验证不再由 Spy.Verify 报告
+func (v *Verification) Assert(t TestingT) bool {
+	t.Helper()
+	v.spy.mu.Lock()
+	v.reported = true
+	v.spy.mu.Unlock()
+	if err := v.Err(); err != nil {
+		t.Errorf("%v", err)
+		return false
+	}
+	return true
+}
+
+// matchFilters 参数是否满足所有过滤条件
+func matchFilters(filters []argFilter, args []interface{}) bool {
+	for _, filter := range filters {
+		if filter.match != nil {
+			if !filter.match(args) {
+				return false
+			}
+			continue
+		}
+		if len(args) != len(filter.expected) {
+			return false
+		}
+		for i, expected := range filter.expected {
+			if !matchArgument(expected, args[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// filterDiff 描述参数与 With 的期望不一致的地方
+func filterDiff(filters []argFilter, args []interface{}) string {
+	var parts []string
+	for _, filter := range filters {
+		if filter.match != nil {
+			if !filter.match(args) {
+				parts = append(parts, "WithArgs matcher returned false")
+			}
+			continue
+		}
+		if len(args) != len(filter.expected) {
+			parts = append(parts, fmt.Sprintf("want %d args, got %d", len(filter.expected), len(args)))
+			continue
+		}
+		for i, expected := range filter.expected {
+			if !matchArgument(expected, args[i]) {
+				parts = append(parts, fmt.Sprintf("arg %d: want %s, got %s", i, formatValue(expected), formatValue(args[i])))
+			}
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// describeCall 期望的调用，例如 Send("user@example.com", Any())
+func describeCall(method string, filters []argFilter) string {
+	for _, filter := range filters {
+		if filter.match == nil {
+			return formatCall(method, filter.expected)
+		}
+	}
+	return method
+}
+
+// describeTimes 期望的调用次数，max 为 -1 表示不限
+func describeTimes(min, max int) string {
+	switch {
+	case max < 0:
+		return "at least " + plural(min)
+	case min == max:
+		return "exactly " + plural(min)
+	case min == 0:
+		return "at most " + plural(max)
+	default:
+		return fmt.Sprintf("between %d and %s", min, plural(max))
+	}
+}
+
+// plural 格式化次数
+func plural(count int) string {
+	if count == 1 {
+		return "1 time"
+	}
+	return fmt.Sprintf("%d times", count)
+}
@@ -0,0 +1,73 @@
+package facade
+
+import (
+	"sync"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// accessorSwap 按访问器进行的一次替换
+type accessorSwap struct {
+	id       uint64
+	instance interface{}
+}
+
+// 按访问器替换的底层服务，键为访问器，值为替换栈
+var (
+	swapMu     sync.RWMutex
+	swapNextID uint64
+	swaps      map[string][]accessorSwap
+)
+
+func init() {
+	application.RegisterFacadeSwapper(Swap)
+}
+
+// Swap 把访问器为 accessor 的所有门面的底层服务替换为 instance，返回撤销这次替换的函数，对应 Laravel 的 Facade::swap
+//
+// 已经解析并缓存了底层服务的门面同样生效，不需要调用 ClearResolvedInstance。
+// 替换按访问器组成栈：嵌套的替换覆盖之前的替换，返回的函数只撤销这一次替换，重复调用不做处理。
+// ctx 的门面作用域、实时门面的替换和 Mock 设置的模拟对象优先于这里的替换。
+// queue.Fake、mail.Fake、bus.Fake 和 application.FakeEvents 通过它让 facades.Queue 等门面看到假服务。
+//
+// 使用示例：
+//
+//	restore := facade.Swap("cache", fakeCache)
+//	defer restore()
+func Swap(accessor string, instance interface{}) func() {
+	swapMu.Lock()
+	defer swapMu.Unlock()
+	if swaps == nil {
+		swaps = make(map[string][]accessorSwap)
+	}
+	swapNextID++
+	id := swapNextID
+	swaps[accessor] = append(swaps[accessor], accessorSwap{id: id, instance: instance})
+	return func() {
+		swapMu.Lock()
+		defer swapMu.Unlock()
+		stack := swaps[accessor]
+		for i, swap := range stack {
+			if swap.id == id {
+				stack = append(stack[:i:i], stack[i+1:]...)
+				if len(stack) == 0 {
+					delete(swaps, accessor)
+				} else {
+					swaps[accessor] = stack
+				}
+				return
+			}
+		}
+	}
+}
+
+// Swapped 获取访问器为 accessor 的门面当前替换的实例
+func Swapped(accessor string) (interface{}, bool) {
+	swapMu.RLock()
+	defer swapMu.RUnlock()
+	stack := swaps[accessor]
+	if len(stack) == 0 {
+		return nil, false
+	}
+	return stack[len(stack)-1].instance, true
+}
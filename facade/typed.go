@@ -0,0 +1,46 @@
+package facade
+
+import (
+	"context"
+	"fmt"
+)
+
+// Typed 底层服务类型为 T 的门面，在 Base 的基础上提供类型安全的 Root
+//
+// 使用示例：
+//
+//	var Cache = facade.NewTyped[cache.Store]("cache")
+//
+//	store := Cache.MustRoot(ctx)
+//	value, ok, err := store.Get(ctx, "key")
+type Typed[T any] struct {
+	*Base
+}
+
+// NewTyped 创建底层服务类型为 T 的门面，accessor 为底层服务在容器中的标识符
+func NewTyped[T any](accessor string) *Typed[T] {
+	return &Typed[T]{Base: New(accessor)}
+}
+
+// Root 获取底层服务，解析顺序与 Base.GetFacadeRootContext 相同
+func (f *Typed[T]) Root(ctx context.Context) (T, error) {
+	var zero T
+	root, err := f.GetFacadeRootContext(ctx)
+	if err != nil {
+		return zero, err
+	}
+	service, ok := root.(T)
+	if !ok {
+		return zero, fmt.Errorf("facade: %s resolved to %T, which does not implement %T", f.GetFacadeAccessor(), root, (*T)(nil))
+	}
+	return service, nil
+}
+
+// MustRoot 获取底层服务，解析失败或类型不符时 panic
+func (f *Typed[T]) MustRoot(ctx context.Context) T {
+	service, err := f.Root(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
@@ -0,0 +1,111 @@
+package driver
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/features"
+)
+
+// ArrayDriver 内存驱动，值只保存在当前进程中，适合测试
+type ArrayDriver struct {
+	mu        sync.RWMutex
+	resolvers map[string]features.Resolver
+	values    map[string]map[string]interface{}
+}
+
+var _ features.Driver = (*ArrayDriver)(nil)
+
+// NewArrayDriver 创建内存驱动
+func NewArrayDriver() *ArrayDriver {
+	return &ArrayDriver{
+		resolvers: make(map[string]features.Resolver),
+		values:    make(map[string]map[string]interface{}),
+	}
+}
+
+// Define 定义功能
+func (d *ArrayDriver) Define(name string, resolver features.Resolver) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.resolvers[name] = resolver
+}
+
+// Defined 已定义的功能名称
+func (d *ArrayDriver) Defined() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return definedNames(d.resolvers)
+}
+
+// Get 获取作用域的功能值，没有保存值时解析并保存
+func (d *ArrayDriver) Get(ctx context.Context, name string, scope interface{}, scopeKey string) (interface{}, error) {
+	d.mu.RLock()
+	value, ok := d.values[name][scopeKey]
+	resolver, defined := d.resolvers[name]
+	d.mu.RUnlock()
+	if ok {
+		return value, nil
+	}
+	if !defined {
+		return false, nil
+	}
+	value, err := resolver(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	return value, d.Set(ctx, name, scopeKey, value)
+}
+
+// Set 保存作用域的功能值
+func (d *ArrayDriver) Set(ctx context.Context, name string, scopeKey string, value interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.values[name] == nil {
+		d.values[name] = make(map[string]interface{})
+	}
+	d.values[name][scopeKey] = value
+	return nil
+}
+
+// SetForAllScopes 更新所有已保存作用域的功能值
+func (d *ArrayDriver) SetForAllScopes(ctx context.Context, name string, value interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for scopeKey := range d.values[name] {
+		d.values[name][scopeKey] = value
+	}
+	return nil
+}
+
+// Delete 删除作用域的功能值
+func (d *ArrayDriver) Delete(ctx context.Context, name string, scopeKey string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.values[name], scopeKey)
+	return nil
+}
+
+// Purge 删除功能的所有值
+func (d *ArrayDriver) Purge(ctx context.Context, names ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(names) == 0 {
+		d.values = make(map[string]map[string]interface{})
+	}
+	for _, name := range names {
+		delete(d.values, name)
+	}
+	return nil
+}
+
+// definedNames 排序后的功能名称
+func definedNames(resolvers map[string]features.Resolver) []string {
+	names := make([]string, 0, len(resolvers))
+	for name := range resolvers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,189 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/features"
+)
+
+// FeatureRecord features 表结构，与 Pennant 的迁移一致
+type FeatureRecord struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"size:255;uniqueIndex:features_name_scope_unique"`
+	Scope     string `gorm:"size:255;uniqueIndex:features_name_scope_unique"`
+	Value     string `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName 表名
+func (FeatureRecord) TableName() string {
+	return "features"
+}
+
+// DatabaseDriver 数据库驱动，值以 JSON 保存在 features 表中
+//
+// 读取的值反序列化为 JSON 对应的 Go 类型，数字为 float64，对象为 map[string]interface{}。
+type DatabaseDriver struct {
+	db    database.DB
+	table string
+	now   func() time.Time
+
+	mu        sync.RWMutex
+	resolvers map[string]features.Resolver
+}
+
+var _ features.Driver = (*DatabaseDriver)(nil)
+
+// NewDatabaseDriver 创建数据库驱动，table 为空时使用 features
+func NewDatabaseDriver(db database.DB, table string) *DatabaseDriver {
+	if table == "" {
+		table = FeatureRecord{}.TableName()
+	}
+	return &DatabaseDriver{
+		db:        db,
+		table:     table,
+		now:       time.Now,
+		resolvers: make(map[string]features.Resolver),
+	}
+}
+
+// newDatabaseDriver 从存储配置创建，"db" 为 database.DB，"table" 为表名
+func newDatabaseDriver(config map[string]interface{}) (*DatabaseDriver, error) {
+	db, err := clientOption[database.DB](config, "db")
+	if err != nil {
+		return nil, err
+	}
+	return NewDatabaseDriver(db, stringOption(config, "table", "")), nil
+}
+
+// Migrate 创建 features 表
+func (d *DatabaseDriver) Migrate(ctx context.Context) error {
+	return d.query(ctx).AutoMigrate(&FeatureRecord{})
+}
+
+// Define 定义功能
+func (d *DatabaseDriver) Define(name string, resolver features.Resolver) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.resolvers[name] = resolver
+}
+
+// Defined 已定义的功能名称
+func (d *DatabaseDriver) Defined() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return definedNames(d.resolvers)
+}
+
+// Get 获取作用域的功能值，没有保存值时解析并保存
+//
+// 并发解析同一作用域时以先写入的值为准。
+func (d *DatabaseDriver) Get(ctx context.Context, name string, scope interface{}, scopeKey string) (interface{}, error) {
+	value, ok, err := d.retrieve(ctx, name, scopeKey)
+	if err != nil || ok {
+		return value, err
+	}
+
+	d.mu.RLock()
+	resolver, defined := d.resolvers[name]
+	d.mu.RUnlock()
+	if !defined {
+		return false, nil
+	}
+	value, err = resolver(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.insert(ctx, name, scopeKey, value); err != nil {
+		stored, ok, retrieveErr := d.retrieve(ctx, name, scopeKey)
+		if retrieveErr != nil || !ok {
+			return nil, err
+		}
+		return stored, nil
+	}
+	return value, nil
+}
+
+// Set 保存作用域的功能值
+func (d *DatabaseDriver) Set(ctx context.Context, name string, scopeKey string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	result := d.query(ctx).Where("name = ? AND scope = ?", name, scopeKey).Updates(map[string]interface{}{
+		"value":      string(encoded),
+		"updated_at": d.now(),
+	})
+	if err := result.Error(); err != nil {
+		return err
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+	return d.insert(ctx, name, scopeKey, value)
+}
+
+// SetForAllScopes 更新所有已保存作用域的功能值
+func (d *DatabaseDriver) SetForAllScopes(ctx context.Context, name string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return d.query(ctx).Where("name = ?", name).Updates(map[string]interface{}{
+		"value":      string(encoded),
+		"updated_at": d.now(),
+	}).Error()
+}
+
+// Delete 删除作用域的功能值
+func (d *DatabaseDriver) Delete(ctx context.Context, name string, scopeKey string) error {
+	return d.query(ctx).Where("name = ? AND scope = ?", name, scopeKey).Delete(&FeatureRecord{}).Error()
+}
+
+// Purge 删除功能的所有值
+func (d *DatabaseDriver) Purge(ctx context.Context, names ...string) error {
+	query := d.query(ctx)
+	if len(names) > 0 {
+		query = query.Where("name IN ?", names)
+	} else {
+		query = query.Session(&database.SessionConfig{AllowGlobalUpdate: true})
+	}
+	return query.Delete(&FeatureRecord{}).Error()
+}
+
+// retrieve 读取保存的值
+func (d *DatabaseDriver) retrieve(ctx context.Context, name, scopeKey string) (interface{}, bool, error) {
+	var records []FeatureRecord
+	if err := d.query(ctx).Where("name = ? AND scope = ?", name, scopeKey).Limit(1).Find(&records).Error(); err != nil {
+		return nil, false, err
+	}
+	if len(records) == 0 {
+		return nil, false, nil
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(records[0].Value), &value); err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// insert 写入新值
+func (d *DatabaseDriver) insert(ctx context.Context, name, scopeKey string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	now := d.now()
+	record := FeatureRecord{Name: name, Scope: scopeKey, Value: string(encoded), CreatedAt: now, UpdatedAt: now}
+	return d.query(ctx).Create(&record).Error()
+}
+
+// query 表查询
+func (d *DatabaseDriver) query(ctx context.Context) database.DB {
+	return d.db.WithContext(ctx).Table(d.table)
+}
@@ -0,0 +1,356 @@
+package driver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/auth"
+	"github.com/cnote0/laraveldoc/features"
+)
+
+// Decorator 功能开关实现，对应 Pennant 的 Decorator
+//
+// 在驱动之上提供作用域检查，并在进程内缓存读取过的值，
+// 同一个 Decorator 对同一作用域的重复检查不会再次访问驱动。
+// 其他进程修改的值在 FlushCache 之前不可见。
+type Decorator struct {
+	name   string
+	driver features.Driver
+
+	mu    sync.RWMutex
+	cache map[cacheKey]interface{}
+	scope func(ctx context.Context) interface{}
+}
+
+var _ features.Features = (*Decorator)(nil)
+
+// cacheKey 缓存键
+type cacheKey struct {
+	feature string
+	scope   string
+}
+
+// NewDecorator 创建功能开关，默认作用域为 auth.UserFromContext(ctx)
+func NewDecorator(name string, driver features.Driver) *Decorator {
+	return &Decorator{
+		name:   name,
+		driver: driver,
+		cache:  make(map[cacheKey]interface{}),
+		scope: func(ctx context.Context) interface{} {
+			if user := auth.UserFromContext(ctx); user != nil {
+				return user
+			}
+			return nil
+		},
+	}
+}
+
+// Name 存储名称
+func (d *Decorator) Name() string {
+	return d.name
+}
+
+// Driver 底层驱动
+func (d *Decorator) Driver() features.Driver {
+	return d.driver
+}
+
+// Define 定义功能
+func (d *Decorator) Define(name string, resolver features.Resolver) {
+	d.driver.Define(name, resolver)
+}
+
+// Defined 已定义的功能名称
+func (d *Decorator) Defined() []string {
+	return d.driver.Defined()
+}
+
+// ResolveScopeUsing 设置默认作用域的解析函数
+func (d *Decorator) ResolveScopeUsing(resolver func(ctx context.Context) interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.scope = resolver
+}
+
+// For 指定作用域
+func (d *Decorator) For(scopes ...interface{}) features.Interaction {
+	if len(scopes) == 0 {
+		scopes = []interface{}{nil}
+	}
+	return &interaction{decorator: d, scopes: scopes}
+}
+
+// forDefault 默认作用域
+func (d *Decorator) forDefault(ctx context.Context) features.Interaction {
+	d.mu.RLock()
+	resolve := d.scope
+	d.mu.RUnlock()
+	return d.For(resolve(ctx))
+}
+
+// Active 功能是否对默认作用域启用
+func (d *Decorator) Active(ctx context.Context, name string) (bool, error) {
+	return d.forDefault(ctx).Active(ctx, name)
+}
+
+// Inactive 功能是否对默认作用域未启用
+func (d *Decorator) Inactive(ctx context.Context, name string) (bool, error) {
+	return d.forDefault(ctx).Inactive(ctx, name)
+}
+
+// Value 默认作用域的功能值
+func (d *Decorator) Value(ctx context.Context, name string) (interface{}, error) {
+	return d.forDefault(ctx).Value(ctx, name)
+}
+
+// When 按功能是否对默认作用域启用调用回调
+func (d *Decorator) When(ctx context.Context, name string, whenActive func(value interface{}) error, whenInactive func() error) error {
+	return d.forDefault(ctx).When(ctx, name, whenActive, whenInactive)
+}
+
+// Activate 为默认作用域启用功能
+func (d *Decorator) Activate(ctx context.Context, name string, value ...interface{}) error {
+	return d.forDefault(ctx).Activate(ctx, name, value...)
+}
+
+// Deactivate 为默认作用域停用功能
+func (d *Decorator) Deactivate(ctx context.Context, name string) error {
+	return d.forDefault(ctx).Deactivate(ctx, name)
+}
+
+// ActivateForEveryone 为所有已保存值的作用域启用功能
+func (d *Decorator) ActivateForEveryone(ctx context.Context, name string, value ...interface{}) error {
+	if err := d.driver.SetForAllScopes(ctx, name, activeValue(value)); err != nil {
+		return err
+	}
+	d.forget(name)
+	return nil
+}
+
+// DeactivateForEveryone 为所有已保存值的作用域停用功能
+func (d *Decorator) DeactivateForEveryone(ctx context.Context, name string) error {
+	if err := d.driver.SetForAllScopes(ctx, name, false); err != nil {
+		return err
+	}
+	d.forget(name)
+	return nil
+}
+
+// Purge 删除功能保存的所有值
+func (d *Decorator) Purge(ctx context.Context, names ...string) error {
+	if err := d.driver.Purge(ctx, names...); err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		d.FlushCache()
+	}
+	d.forget(names...)
+	return nil
+}
+
+// FlushCache 清空进程内缓存的值
+func (d *Decorator) FlushCache() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cache = make(map[cacheKey]interface{})
+}
+
+// get 获取功能值，优先读取缓存
+func (d *Decorator) get(ctx context.Context, name string, scope interface{}) (interface{}, error) {
+	key := cacheKey{feature: name, scope: features.SerializeScope(scope)}
+	d.mu.RLock()
+	value, ok := d.cache[key]
+	d.mu.RUnlock()
+	if ok {
+		return value, nil
+	}
+
+	value, err := d.driver.Get(ctx, name, scope, key.scope)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	d.cache[key] = value
+	d.mu.Unlock()
+	return value, nil
+}
+
+// set 保存功能值并更新缓存
+func (d *Decorator) set(ctx context.Context, name string, scope interface{}, value interface{}) error {
+	key := cacheKey{feature: name, scope: features.SerializeScope(scope)}
+	if err := d.driver.Set(ctx, name, key.scope, value); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.cache[key] = value
+	d.mu.Unlock()
+	return nil
+}
+
+// delete 删除功能值和缓存
+func (d *Decorator) delete(ctx context.Context, name string, scope interface{}) error {
+	key := cacheKey{feature: name, scope: features.SerializeScope(scope)}
+	if err := d.driver.Delete(ctx, name, key.scope); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	delete(d.cache, key)
+	d.mu.Unlock()
+	return nil
+}
+
+// forget 删除功能在所有作用域的缓存
+func (d *Decorator) forget(names ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key := range d.cache {
+		for _, name := range names {
+			if key.feature == name {
+				delete(d.cache, key)
+			}
+		}
+	}
+}
+
+// activeValue 启用时保存的值，未指定时为 true
+func activeValue(value []interface{}) interface{} {
+	if len(value) > 0 {
+		return value[0]
+	}
+	return true
+}
+
+// interaction 指定作用域的功能检查
+type interaction struct {
+	decorator *Decorator
+	scopes    []interface{}
+}
+
+// Active 功能是否对所有作用域启用
+func (i *interaction) Active(ctx context.Context, name string) (bool, error) {
+	return i.AllAreActive(ctx, name)
+}
+
+// AllAreActive 所有功能是否都对所有作用域启用
+func (i *interaction) AllAreActive(ctx context.Context, names ...string) (bool, error) {
+	return i.every(ctx, names, true, true)
+}
+
+// SomeAreActive 每个作用域是否都至少启用了其中一个功能
+func (i *interaction) SomeAreActive(ctx context.Context, names ...string) (bool, error) {
+	return i.every(ctx, names, false, true)
+}
+
+// Inactive 功能是否对所有作用域未启用
+func (i *interaction) Inactive(ctx context.Context, name string) (bool, error) {
+	return i.AllAreInactive(ctx, name)
+}
+
+// AllAreInactive 所有功能是否都对所有作用域未启用
+func (i *interaction) AllAreInactive(ctx context.Context, names ...string) (bool, error) {
+	return i.every(ctx, names, true, false)
+}
+
+// SomeAreInactive 每个作用域是否都至少停用了其中一个功能
+func (i *interaction) SomeAreInactive(ctx context.Context, names ...string) (bool, error) {
+	return i.every(ctx, names, false, false)
+}
+
+// every 检查每个作用域：all 为 true 时要求所有功能的启用状态都等于 active，否则要求至少一个
+func (i *interaction) every(ctx context.Context, names []string, all, active bool) (bool, error) {
+	for _, scope := range i.scopes {
+		matched := 0
+		for _, name := range names {
+			value, err := i.decorator.get(ctx, name, scope)
+			if err != nil {
+				return false, err
+			}
+			if features.IsActive(value) == active {
+				matched++
+			}
+		}
+		if (all && matched < len(names)) || (!all && matched == 0) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Value 第一个作用域的功能值
+func (i *interaction) Value(ctx context.Context, name string) (interface{}, error) {
+	return i.decorator.get(ctx, name, i.scopes[0])
+}
+
+// Values 第一个作用域的多个功能值
+func (i *interaction) Values(ctx context.Context, names ...string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		value, err := i.Value(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// When 功能对所有作用域启用时调用 whenActive，否则调用 whenInactive
+func (i *interaction) When(ctx context.Context, name string, whenActive func(value interface{}) error, whenInactive func() error) error {
+	active, err := i.Active(ctx, name)
+	if err != nil {
+		return err
+	}
+	if active {
+		value, err := i.Value(ctx, name)
+		if err != nil {
+			return err
+		}
+		return whenActive(value)
+	}
+	if whenInactive != nil {
+		return whenInactive()
+	}
+	return nil
+}
+
+// Activate 为所有作用域启用功能
+func (i *interaction) Activate(ctx context.Context, name string, value ...interface{}) error {
+	for _, scope := range i.scopes {
+		if err := i.decorator.set(ctx, name, scope, activeValue(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deactivate 为所有作用域停用功能
+func (i *interaction) Deactivate(ctx context.Context, name string) error {
+	for _, scope := range i.scopes {
+		if err := i.decorator.set(ctx, name, scope, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Forget 删除所有作用域保存的值
+func (i *interaction) Forget(ctx context.Context, name string) error {
+	for _, scope := range i.scopes {
+		if err := i.decorator.delete(ctx, name, scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load 预先加载功能值
+func (i *interaction) Load(ctx context.Context, names ...string) error {
+	for _, scope := range i.scopes {
+		for _, name := range names {
+			if _, err := i.decorator.get(ctx, name, scope); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Package driver 提供 features 包协议的参考实现
+//
+// 包含 array 内存驱动、database 数据库驱动、在驱动之上提供作用域检查和进程内缓存的 Decorator，
+// 以及按配置创建存储的 Manager。
+//
+// 包结构：
+// - driver.go - 配置读取辅助函数
+// - decorator.go - Decorator 功能开关实现和作用域检查
+// - array.go - ArrayDriver 内存驱动
+// - database.go - DatabaseDriver 数据库驱动和 FeatureRecord 表结构
+// - manager.go - Manager 实现
+//
+// 配置示例：
+//
+//	manager := driver.NewManager(map[string]map[string]interface{}{
+//		"array":    {"driver": "array"},
+//		"database": {"driver": "database", "db": db, "table": "features"},
+//	}, "database")
+package driver
+
+import "fmt"
+
+// stringOption 读取字符串配置
+func stringOption(config map[string]interface{}, key, fallback string) string {
+	if value, ok := config[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// clientOption 读取对象配置
+func clientOption[T any](config map[string]interface{}, key string) (T, error) {
+	client, ok := config[key].(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("features: store config %q must be %T, got %T", key, zero, config[key])
+	}
+	return client, nil
+}
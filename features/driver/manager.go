@@ -0,0 +1,114 @@
+package driver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/features"
+)
+
+// Manager 功能开关管理器实现
+//
+// 存储在首次使用时创建并缓存，内置 array、database 驱动。
+// 通过 Define 定义的功能会应用到已创建和之后创建的所有存储。
+type Manager struct {
+	mu           sync.RWMutex
+	config       map[string]map[string]interface{}
+	defaultStore string
+	stores       map[string]*Decorator
+	resolvers    map[string]func(config map[string]interface{}) (features.Driver, error)
+	definitions  map[string]features.Resolver
+}
+
+var _ features.Manager = (*Manager)(nil)
+
+// NewManager 创建功能开关管理器
+//
+// config 为存储名称到存储配置的映射，"driver" 为驱动名称。
+func NewManager(config map[string]map[string]interface{}, defaultStore string) *Manager {
+	m := &Manager{
+		config:       config,
+		defaultStore: defaultStore,
+		stores:       make(map[string]*Decorator),
+		resolvers:    make(map[string]func(config map[string]interface{}) (features.Driver, error)),
+		definitions:  make(map[string]features.Resolver),
+	}
+	m.Extend("array", func(config map[string]interface{}) (features.Driver, error) {
+		return NewArrayDriver(), nil
+	})
+	m.Extend("database", func(config map[string]interface{}) (features.Driver, error) {
+		return newDatabaseDriver(config)
+	})
+	return m
+}
+
+// Store 获取存储的功能开关，不传名称时使用默认存储
+func (m *Manager) Store(name ...string) (features.Features, error) {
+	store := m.GetDefaultDriver()
+	if len(name) > 0 && name[0] != "" {
+		store = name[0]
+	}
+
+	m.mu.RLock()
+	decorator, ok := m.stores[store]
+	m.mu.RUnlock()
+	if ok {
+		return decorator, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if decorator, ok := m.stores[store]; ok {
+		return decorator, nil
+	}
+	config, ok := m.config[store]
+	if !ok {
+		return nil, fmt.Errorf("features: store [%s] is not defined", store)
+	}
+	driverName, _ := config["driver"].(string)
+	resolver, ok := m.resolvers[driverName]
+	if !ok {
+		return nil, fmt.Errorf("features: driver [%s] is not supported", driverName)
+	}
+	driver, err := resolver(config)
+	if err != nil {
+		return nil, err
+	}
+	for feature, definition := range m.definitions {
+		driver.Define(feature, definition)
+	}
+	decorator = NewDecorator(store, driver)
+	m.stores[store] = decorator
+	return decorator, nil
+}
+
+// Define 在所有存储上定义功能
+func (m *Manager) Define(name string, resolver features.Resolver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.definitions[name] = resolver
+	for _, decorator := range m.stores {
+		decorator.Define(name, resolver)
+	}
+}
+
+// Extend 注册驱动
+func (m *Manager) Extend(driver string, factory func(config map[string]interface{}) (features.Driver, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resolvers[driver] = factory
+}
+
+// GetDefaultDriver 获取默认存储名称
+func (m *Manager) GetDefaultDriver() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultStore
+}
+
+// SetDefaultDriver 设置默认存储名称
+func (m *Manager) SetDefaultDriver(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultStore = name
+}
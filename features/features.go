@@ -0,0 +1,240 @@
+// Package features 提供 Laravel Pennant 风格的功能开关协议定义
+//
+// 功能通过 Define 注册解析器，首次检查某个作用域（用户、团队等）时调用解析器得到值并保存，
+// 之后对同一作用域的检查直接读取保存的值，保证同一用户看到的结果稳定。
+// 值为 false 或 nil 时功能未启用，其他值都视为启用，字符串等值可以用作 A/B 测试的变体。
+//
+// 包结构：
+// - features.go - Features、Interaction、Driver、Manager 接口和默认功能开关
+// - helpers.go - 作用域序列化、中间件和视图模板函数
+//
+// 子包 driver 提供 array、database 驱动和 Manager 实现。
+//
+// 使用示例：
+//
+//	manager := driver.NewManager(map[string]map[string]interface{}{
+//		"array":    {"driver": "array"},
+//		"database": {"driver": "database", "db": db, "table": "features"},
+//	}, "database")
+//
+//	manager.Define("new-api", func(ctx context.Context, scope interface{}) (interface{}, error) {
+//		user, _ := scope.(*User)
+//		return user != nil && user.IsInternal(), nil
+//	})
+//	manager.Define("purchase-button", func(ctx context.Context, scope interface{}) (interface{}, error) {
+//		return []string{"blue", "green", "red"}[rand.IntN(3)], nil
+//	})
+//
+//	feature, _ := manager.Store()
+//	features.SetDefault(feature)
+//
+//	active, err := feature.Active(ctx, "new-api") // 默认作用域为 auth.UserFromContext(ctx)
+//	color, err := feature.For(team).Value(ctx, "purchase-button")
+//	err = feature.When(ctx, "new-api", func(value interface{}) error {
+//		return serveNewAPI(w, r)
+//	}, func() error {
+//		return serveLegacyAPI(w, r)
+//	})
+//
+//	mux.Handle("GET /api/v2/", features.EnsureFeaturesAreActive(feature, "new-api")(apiV2))
+//	views.Funcs(features.Funcs(feature))
+//	// {{ if feature "new-api" .User }} ... {{ end }}
+package features
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrNoDefault 未设置默认功能开关
+var ErrNoDefault = errors.New("features: no default features, call features.SetDefault")
+
+// Resolver 功能解析器，返回作用域的初始值
+type Resolver func(ctx context.Context, scope interface{}) (interface{}, error)
+
+// Features 功能开关接口，对应 Laravel Pennant 的 Decorator
+//
+// 不带作用域的方法使用 ResolveScopeUsing 设置的默认作用域。
+type Features interface {
+	// Define 定义功能
+	Define(name string, resolver Resolver)
+
+	// Defined 已定义的功能名称
+	Defined() []string
+
+	// For 指定作用域，传入多个作用域时检查所有作用域，不传时为空作用域（nil）
+	For(scopes ...interface{}) Interaction
+
+	// Active 功能是否对默认作用域启用
+	Active(ctx context.Context, name string) (bool, error)
+
+	// Inactive 功能是否对默认作用域未启用
+	Inactive(ctx context.Context, name string) (bool, error)
+
+	// Value 默认作用域的功能值
+	Value(ctx context.Context, name string) (interface{}, error)
+
+	// When 功能启用时调用 whenActive，否则调用 whenInactive（可以为 nil）
+	When(ctx context.Context, name string, whenActive func(value interface{}) error, whenInactive func() error) error
+
+	// Activate 为默认作用域启用功能，value 为空时值为 true
+	Activate(ctx context.Context, name string, value ...interface{}) error
+
+	// Deactivate 为默认作用域停用功能
+	Deactivate(ctx context.Context, name string) error
+
+	// ActivateForEveryone 为所有已保存值的作用域启用功能
+	ActivateForEveryone(ctx context.Context, name string, value ...interface{}) error
+
+	// DeactivateForEveryone 为所有已保存值的作用域停用功能
+	DeactivateForEveryone(ctx context.Context, name string) error
+
+	// Purge 删除功能保存的所有值，names 为空时删除所有功能的值
+	Purge(ctx context.Context, names ...string) error
+
+	// FlushCache 清空进程内缓存的值
+	FlushCache()
+
+	// ResolveScopeUsing 设置默认作用域的解析函数
+	ResolveScopeUsing(resolver func(ctx context.Context) interface{})
+}
+
+// Interaction 指定作用域的功能检查
+type Interaction interface {
+	// Active 功能是否对所有作用域启用
+	Active(ctx context.Context, name string) (bool, error)
+
+	// AllAreActive 所有功能是否都对所有作用域启用
+	AllAreActive(ctx context.Context, names ...string) (bool, error)
+
+	// SomeAreActive 每个作用域是否都至少启用了其中一个功能
+	SomeAreActive(ctx context.Context, names ...string) (bool, error)
+
+	// Inactive 功能是否对所有作用域未启用
+	Inactive(ctx context.Context, name string) (bool, error)
+
+	// AllAreInactive 所有功能是否都对所有作用域未启用
+	AllAreInactive(ctx context.Context, names ...string) (bool, error)
+
+	// SomeAreInactive 每个作用域是否都至少停用了其中一个功能
+	SomeAreInactive(ctx context.Context, names ...string) (bool, error)
+
+	// Value 第一个作用域的功能值
+	Value(ctx context.Context, name string) (interface{}, error)
+
+	// Values 第一个作用域的多个功能值
+	Values(ctx context.Context, names ...string) (map[string]interface{}, error)
+
+	// When 功能对所有作用域启用时调用 whenActive，否则调用 whenInactive（可以为 nil）
+	When(ctx context.Context, name string, whenActive func(value interface{}) error, whenInactive func() error) error
+
+	// Activate 为所有作用域启用功能，value 为空时值为 true
+	Activate(ctx context.Context, name string, value ...interface{}) error
+
+	// Deactivate 为所有作用域停用功能
+	Deactivate(ctx context.Context, name string) error
+
+	// Forget 删除所有作用域保存的值，下次检查时重新解析
+	Forget(ctx context.Context, name string) error
+
+	// Load 预先加载功能值，减少之后检查的查询
+	Load(ctx context.Context, names ...string) error
+}
+
+// Driver 功能值存储驱动
+//
+// scopeKey 为 SerializeScope 序列化后的作用域，scope 为原始作用域，传给解析器。
+// Get 在没有保存值时调用解析器并保存结果，功能未定义时返回 false。
+type Driver interface {
+	// Define 定义功能
+	Define(name string, resolver Resolver)
+
+	// Defined 已定义的功能名称
+	Defined() []string
+
+	// Get 获取作用域的功能值，没有保存值时解析并保存
+	Get(ctx context.Context, name string, scope interface{}, scopeKey string) (interface{}, error)
+
+	// Set 保存作用域的功能值
+	Set(ctx context.Context, name string, scopeKey string, value interface{}) error
+
+	// SetForAllScopes 更新所有已保存作用域的功能值
+	SetForAllScopes(ctx context.Context, name string, value interface{}) error
+
+	// Delete 删除作用域的功能值
+	Delete(ctx context.Context, name string, scopeKey string) error
+
+	// Purge 删除功能的所有值，names 为空时删除所有值
+	Purge(ctx context.Context, names ...string) error
+}
+
+// Manager 功能开关管理器接口，按配置创建并缓存各存储的 Features
+type Manager interface {
+	// Store 获取存储的功能开关，不传名称时使用默认存储
+	Store(name ...string) (Features, error)
+
+	// Define 在所有存储上定义功能
+	Define(name string, resolver Resolver)
+
+	// Extend 注册驱动
+	Extend(driver string, factory func(config map[string]interface{}) (Driver, error))
+
+	// GetDefaultDriver 获取默认存储名称
+	GetDefaultDriver() string
+
+	// SetDefaultDriver 设置默认存储名称
+	SetDefaultDriver(name string)
+}
+
+// IsActive 值是否表示功能启用，false 和 nil 表示未启用
+func IsActive(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	active, ok := value.(bool)
+	return !ok || active
+}
+
+// defaultFeatures 默认功能开关
+var defaultFeatures atomic.Value
+
+// SetDefault 设置默认功能开关
+func SetDefault(features Features) {
+	defaultFeatures.Store(&features)
+}
+
+// Default 获取默认功能开关，未设置时返回 nil
+func Default() Features {
+	if features, ok := defaultFeatures.Load().(*Features); ok {
+		return *features
+	}
+	return nil
+}
+
+// Active 使用默认功能开关检查功能是否对默认作用域启用
+func Active(ctx context.Context, name string) (bool, error) {
+	features := Default()
+	if features == nil {
+		return false, ErrNoDefault
+	}
+	return features.Active(ctx, name)
+}
+
+// Value 使用默认功能开关获取默认作用域的功能值
+func Value(ctx context.Context, name string) (interface{}, error) {
+	features := Default()
+	if features == nil {
+		return nil, ErrNoDefault
+	}
+	return features.Value(ctx, name)
+}
+
+// When 使用默认功能开关按功能是否启用调用回调
+func When(ctx context.Context, name string, whenActive func(value interface{}) error, whenInactive func() error) error {
+	features := Default()
+	if features == nil {
+		return ErrNoDefault
+	}
+	return features.When(ctx, name, whenActive, whenInactive)
+}
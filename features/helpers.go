@@ -0,0 +1,81 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/cnote0/laraveldoc/auth"
+)
+
+// NullScope 空作用域的序列化值，与 Pennant 一致
+const NullScope = "__laravel_null"
+
+// Scopeable 自定义作用域标识的类型
+type Scopeable interface {
+	// FeatureScope 作用域标识，相同标识的作用域共享功能值
+	FeatureScope() string
+}
+
+// SerializeScope 把作用域序列化为存储使用的字符串
+//
+// nil 为 NullScope；Scopeable 使用 FeatureScope；
+// auth.Authenticatable 为 "类型名|唯一标识"，例如 "main.User|1"；其他值使用 fmt 格式化。
+func SerializeScope(scope interface{}) string {
+	if scope == nil {
+		return NullScope
+	}
+	if value := reflect.ValueOf(scope); value.Kind() == reflect.Pointer && value.IsNil() {
+		return NullScope
+	}
+	switch s := scope.(type) {
+	case Scopeable:
+		return s.FeatureScope()
+	case auth.Authenticatable:
+		return fmt.Sprintf("%s|%v", reflect.Indirect(reflect.ValueOf(s)).Type().String(), s.GetAuthIdentifier())
+	case string:
+		return s
+	}
+	return fmt.Sprint(scope)
+}
+
+// EnsureFeaturesAreActive 所有功能对请求的默认作用域启用时才继续处理，否则返回 400
+//
+// 对应 Pennant 的 EnsureFeaturesAreActive 中间件，默认作用域通常为 auth.UserFromContext，
+// 应放在认证中间件之后。
+func EnsureFeaturesAreActive(features Features, names ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, name := range names {
+				active, err := features.Active(r.Context(), name)
+				if err != nil {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					return
+				}
+				if !active {
+					http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Funcs 视图模板函数，对应 Pennant 的 @feature Blade 指令
+//
+// 模板中没有请求 context，作用域需要显式传入，不传时为空作用域：
+//
+//	{{ if feature "new-api" .User }} ... {{ end }}
+//	{{ if eq (feature_value "purchase-button" .User) "blue" }} ... {{ end }}
+func Funcs(features Features) map[string]interface{} {
+	return map[string]interface{}{
+		"feature": func(name string, scope ...interface{}) (bool, error) {
+			return features.For(scope...).Active(context.Background(), name)
+		},
+		"feature_value": func(name string, scope ...interface{}) (interface{}, error) {
+			return features.For(scope...).Value(context.Background(), name)
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package driver
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// stubArgon2 确定性的 Argon2IDKeyFunc 替身，输出随每个参数变化，只用于测试 PHC 编码和参数传递
+func stubArgon2(password, salt []byte, time, memory uint32, threads uint8, keyLen uint32) []byte {
+	var params [9]byte
+	binary.BigEndian.PutUint32(params[0:], time)
+	binary.BigEndian.PutUint32(params[4:], memory)
+	params[8] = threads
+	var key []byte
+	for block := byte(0); uint32(len(key)) < keyLen; block++ {
+		h := sha256.New()
+		h.Write([]byte{block})
+		h.Write(params[:])
+		h.Write(salt)
+		h.Write(password)
+		key = h.Sum(key)
+	}
+	return key[:keyLen]
+}
+
+func TestArgon2idHasherDefaults(t *testing.T) {
+	h := NewArgon2idHasher(stubArgon2, Argon2Options{})
+	hashed, err := h.Make("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hashed, "$argon2id$v=19$m=65536,t=4,p=1$") {
+		t.Fatalf("Make() = %q, want Laravel's default parameters", hashed)
+	}
+}
+
+func TestArgon2idHasherRoundTrip(t *testing.T) {
+	options := Argon2Options{Memory: 1024, Time: 2, Threads: 2}
+	h := NewArgon2idHasher(stubArgon2, options)
+	hashed, err := h.Make("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, ok := parsePHC(hashed, "argon2id")
+	if !ok {
+		t.Fatalf("parsePHC(%q) failed", hashed)
+	}
+	if parsed.version != 19 || parsed.params["m"] != 1024 || parsed.params["t"] != 2 || parsed.params["p"] != 2 {
+		t.Errorf("parsed = %+v", parsed)
+	}
+	if len(parsed.salt) != 16 || len(parsed.hash) != 32 {
+		t.Errorf("salt %d bytes, hash %d bytes, want 16 and 32", len(parsed.salt), len(parsed.hash))
+	}
+	if got := parsed.String("m", "t", "p"); got != hashed {
+		t.Errorf("String() = %q, want %q", got, hashed)
+	}
+
+	if !h.Check("secret", hashed) {
+		t.Error("Check(secret) = false, want true")
+	}
+	if h.Check("wrong", hashed) {
+		t.Error("Check(wrong) = true, want false")
+	}
+	// Check 使用哈希中记录的参数，而不是当前配置
+	if !NewArgon2idHasher(stubArgon2, Argon2Options{}).Check("secret", hashed) {
+		t.Error("Check() with different options = false, want true")
+	}
+
+	if h.NeedsRehash(hashed) {
+		t.Error("NeedsRehash() = true for the current options")
+	}
+	if !NewArgon2idHasher(stubArgon2, Argon2Options{Memory: 2048, Time: 2, Threads: 2}).NeedsRehash(hashed) {
+		t.Error("NeedsRehash() = false after memory changed")
+	}
+
+	info := h.Info(hashed)
+	if info.Algorithm != "argon2id" || info.Options["memory_cost"] != 1024 || info.Options["time_cost"] != 2 || info.Options["threads"] != 2 {
+		t.Errorf("Info() = %+v", info)
+	}
+}
+
+func TestArgon2idHasherRejectsMalformedHashes(t *testing.T) {
+	h := NewArgon2idHasher(stubArgon2, Argon2Options{Memory: 1024, Time: 2, Threads: 1})
+	hashed, err := h.Make("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(hashed, "$")
+	tests := map[string]string{
+		"empty":           "",
+		"other algorithm": strings.Replace(hashed, "$argon2id$", "$argon2i$", 1),
+		"other version":   strings.Replace(hashed, "$v=19$", "$v=16$", 1),
+		"zero memory":     strings.Replace(hashed, "m=1024", "m=0", 1),
+		"too many lanes":  strings.Replace(hashed, "p=1", "p=256", 1),
+		"bad salt":        strings.Join([]string{"", parts[1], parts[2], parts[3], "!!!", parts[5]}, "$"),
+		"empty hash":      strings.Join([]string{"", parts[1], parts[2], parts[3], parts[4], ""}, "$"),
+		"missing field":   strings.Join(parts[:5], "$"),
+		"tampered hash":   strings.Join([]string{"", parts[1], parts[2], parts[3], parts[4], "A" + parts[5][1:]}, "$"),
+	}
+	if parts[5][0] == 'A' {
+		tests["tampered hash"] = strings.Join([]string{"", parts[1], parts[2], parts[3], parts[4], "B" + parts[5][1:]}, "$")
+	}
+	for name, value := range tests {
+		if h.Check("secret", value) {
+			t.Errorf("%s: Check(%q) = true, want false", name, value)
+		}
+	}
+	if !h.NeedsRehash("not a hash") {
+		t.Error("NeedsRehash() = false for a malformed hash")
+	}
+}
@@ -0,0 +1,87 @@
+package driver
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/hashing"
+)
+
+// BcryptGenerateFunc bcrypt 生成函数，签名与 golang.org/x/crypto/bcrypt.GenerateFromPassword 一致
+type BcryptGenerateFunc func(password []byte, cost int) ([]byte, error)
+
+// BcryptCompareFunc bcrypt 校验函数，签名与 golang.org/x/crypto/bcrypt.CompareHashAndPassword 一致
+type BcryptCompareFunc func(hashedPassword, password []byte) error
+
+// BcryptHasher bcrypt 哈希
+//
+// 哈希格式为 $2a$12$...，也能校验 PHP 生成的 $2y$ 前缀哈希（两者算法相同）。
+// bcrypt 只使用密码的前 72 字节，与 PHP 行为一致。
+type BcryptHasher struct {
+	generate BcryptGenerateFunc
+	compare  BcryptCompareFunc
+	rounds   int
+}
+
+var _ hashing.Hasher = (*BcryptHasher)(nil)
+
+// NewBcryptHasher 创建 bcrypt 哈希，rounds 为成本参数，小于 4 时使用 12（Laravel 默认值）
+func NewBcryptHasher(generate BcryptGenerateFunc, compare BcryptCompareFunc, rounds int) *BcryptHasher {
+	if rounds < 4 {
+		rounds = 12
+	}
+	return &BcryptHasher{generate: generate, compare: compare, rounds: rounds}
+}
+
+// Make 生成哈希
+func (h *BcryptHasher) Make(value string) (string, error) {
+	hashed, err := h.generate([]byte(value), h.rounds)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// Check 校验明文与哈希是否匹配
+func (h *BcryptHasher) Check(value, hashedValue string) bool {
+	if _, ok := bcryptCost(hashedValue); !ok {
+		return false
+	}
+	// x/crypto 只识别 $2a$、$2b$，PHP 生成的 $2y$ 算法相同，改写前缀后校验
+	if strings.HasPrefix(hashedValue, "$2y$") {
+		hashedValue = "$2a$" + hashedValue[4:]
+	}
+	return h.compare([]byte(hashedValue), []byte(value)) == nil
+}
+
+// NeedsRehash 成本参数与当前配置不同时需要重新生成
+func (h *BcryptHasher) NeedsRehash(hashedValue string) bool {
+	cost, ok := bcryptCost(hashedValue)
+	return !ok || cost != h.rounds
+}
+
+// Info 解析哈希的算法和参数
+func (h *BcryptHasher) Info(hashedValue string) hashing.Info {
+	cost, ok := bcryptCost(hashedValue)
+	if !ok {
+		return hashing.Info{}
+	}
+	return hashing.Info{Algorithm: hashing.DriverBcrypt, Options: map[string]int{"cost": cost}}
+}
+
+// bcryptCost 解析 bcrypt 哈希的成本参数
+func bcryptCost(hashedValue string) (int, bool) {
+	if len(hashedValue) != 60 || hashedValue[0] != '$' || hashedValue[1] != '2' || hashedValue[6] != '$' {
+		return 0, false
+	}
+	switch hashedValue[2] {
+	case 'a', 'b', 'y':
+	default:
+		return 0, false
+	}
+	cost, err := strconv.Atoi(hashedValue[4:6])
+	if err != nil || hashedValue[3] != '$' {
+		return 0, false
+	}
+	return cost, true
+}
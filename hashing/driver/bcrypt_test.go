@@ -0,0 +1,124 @@
+package driver
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// bcryptAlphabet bcrypt 使用的 base64 字母表
+var bcryptAlphabet = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").WithPadding(base64.NoPadding)
+
+// errMismatch 替身校验失败
+var errMismatch = errors.New("hashedPassword is not the hash of the given password")
+
+// stubBcrypt bcrypt 替身：格式与 $2a$ 哈希相同，长度 60，哈希部分由成本和密码决定（不加盐）
+type stubBcrypt struct {
+	compared []string
+}
+
+// generate 实现 BcryptGenerateFunc
+func (s *stubBcrypt) generate(password []byte, cost int) ([]byte, error) {
+	return []byte(stubBcryptHash("2a", password, cost)), nil
+}
+
+// compare 实现 BcryptCompareFunc，记录收到的哈希以检查前缀改写
+func (s *stubBcrypt) compare(hashedPassword, password []byte) error {
+	s.compared = append(s.compared, string(hashedPassword))
+	var cost int
+	if _, err := fmt.Sscanf(string(hashedPassword[4:6]), "%d", &cost); err != nil {
+		return err
+	}
+	if string(hashedPassword) != stubBcryptHash(string(hashedPassword[1:3]), password, cost) {
+		return errMismatch
+	}
+	return nil
+}
+
+// stubBcryptHash 生成 60 字节的替身哈希
+func stubBcryptHash(prefix string, password []byte, cost int) string {
+	sum := sha256.Sum256(append([]byte(fmt.Sprint(cost)), password...))
+	encoded := bcryptAlphabet.EncodeToString(append(sum[:], sum[:8]...))
+	return fmt.Sprintf("$%s$%02d$%s", prefix, cost, encoded[:53])
+}
+
+func TestBcryptHasherRoundTrip(t *testing.T) {
+	stub := &stubBcrypt{}
+	h := NewBcryptHasher(stub.generate, stub.compare, 10)
+	hashed, err := h.Make("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hashed) != 60 || !strings.HasPrefix(hashed, "$2a$10$") {
+		t.Fatalf("Make() = %q, want a 60 byte $2a$10$ hash", hashed)
+	}
+	if !h.Check("secret", hashed) {
+		t.Error("Check(secret) = false, want true")
+	}
+	if h.Check("wrong", hashed) {
+		t.Error("Check(wrong) = true, want false")
+	}
+	if h.NeedsRehash(hashed) {
+		t.Error("NeedsRehash() = true for the current cost")
+	}
+	if !NewBcryptHasher(stub.generate, stub.compare, 12).NeedsRehash(hashed) {
+		t.Error("NeedsRehash() = false after the cost changed")
+	}
+	if info := h.Info(hashed); info.Algorithm != "bcrypt" || info.Options["cost"] != 10 {
+		t.Errorf("Info() = %+v", info)
+	}
+}
+
+func TestBcryptHasherDefaultRounds(t *testing.T) {
+	stub := &stubBcrypt{}
+	hashed, err := NewBcryptHasher(stub.generate, stub.compare, 0).Make("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hashed, "$2a$12$") {
+		t.Errorf("Make() = %q, want Laravel's default cost 12", hashed)
+	}
+}
+
+// PHP 的 password_hash 生成 $2y$ 前缀，校验前改写为 x/crypto 识别的 $2a$
+func TestBcryptHasherChecksPHPHashes(t *testing.T) {
+	stub := &stubBcrypt{}
+	h := NewBcryptHasher(stub.generate, stub.compare, 10)
+	php := "$2y$" + stubBcryptHash("2a", []byte("secret"), 10)[4:]
+	if !h.Check("secret", php) {
+		t.Fatal("Check($2y$ hash) = false, want true")
+	}
+	if got := stub.compared[len(stub.compared)-1]; !strings.HasPrefix(got, "$2a$10$") {
+		t.Errorf("compare received %q, want the $2a$ prefix", got)
+	}
+	if info := h.Info(php); info.Options["cost"] != 10 {
+		t.Errorf("Info($2y$ hash) = %+v", info)
+	}
+}
+
+func TestBcryptHasherRejectsMalformedHashes(t *testing.T) {
+	stub := &stubBcrypt{}
+	h := NewBcryptHasher(stub.generate, stub.compare, 10)
+	hashed, err := h.Make("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range map[string]string{
+		"empty":        "",
+		"truncated":    hashed[:59],
+		"other prefix": "$2x$" + hashed[4:],
+		"bad cost":     "$2a$1x$" + hashed[7:],
+		"argon2id":     "$argon2id$v=19$m=65536,t=4,p=1$c2FsdA$aGFzaA",
+	} {
+		before := len(stub.compared)
+		if h.Check("secret", value) {
+			t.Errorf("%s: Check(%q) = true, want false", name, value)
+		}
+		if len(stub.compared) != before {
+			t.Errorf("%s: malformed hash was passed to compare", name)
+		}
+	}
+}
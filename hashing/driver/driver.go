@@ -0,0 +1,137 @@
+// Package driver 提供 hashing 包协议的参考实现
+//
+// scrypt 驱动只使用标准库；bcrypt 和 argon2id 的核心算法位于 golang.org/x/crypto，
+// 为了不给协议模块引入依赖，由使用方注入对应函数，本包负责哈希格式、参数和 NeedsRehash。
+// argon2id 和 scrypt 使用 PHC 字符串格式，与 PHP 的 password_hash 和 passlib 兼容。
+//
+// 包结构：
+// - driver.go - 配置读取、盐生成和 PHC 格式辅助函数
+// - bcrypt.go - BcryptHasher
+// - argon2.go - Argon2idHasher
+// - scrypt.go - ScryptHasher
+// - scrypt_kdf.go - RFC 7914 scrypt 密钥派生
+// - manager.go - Manager 实现
+//
+// 配置示例：
+//
+//	import (
+//		"golang.org/x/crypto/argon2"
+//		"golang.org/x/crypto/bcrypt"
+//	)
+//
+//	manager := driver.NewManager(map[string]map[string]interface{}{
+//		"bcrypt":   {"rounds": 12, "generate": bcrypt.GenerateFromPassword, "compare": bcrypt.CompareHashAndPassword},
+//		"argon2id": {"memory": 65536, "time": 4, "threads": 1, "key": argon2.IDKey},
+//		"scrypt":   {"cost": 32768},
+//	}, "bcrypt")
+//	hashing.SetDefaultManager(manager)
+//	authManager.SetHasher(manager)
+package driver
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// intOption 读取整数配置
+func intOption(config map[string]interface{}, key string, fallback int) int {
+	switch value := config[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	}
+	return fallback
+}
+
+// clientOption 读取函数等对象配置
+func clientOption[T any](config map[string]interface{}, key string) (T, error) {
+	client, ok := config[key].(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("hashing: config %q must be %T, got %T", key, zero, config[key])
+	}
+	return client, nil
+}
+
+// salt 生成随机盐
+func salt(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// phc PHC 字符串格式的哈希：$id[$v=version]$params$salt$hash
+type phc struct {
+	id      string
+	version int
+	params  map[string]int
+	salt    []byte
+	hash    []byte
+}
+
+// String 编码为 PHC 字符串，盐和哈希使用无填充的标准 base64
+func (p phc) String(order ...string) string {
+	var b strings.Builder
+	b.WriteString("$" + p.id)
+	if p.version != 0 {
+		b.WriteString("$v=" + strconv.Itoa(p.version))
+	}
+	b.WriteString("$")
+	for i, name := range order {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(name + "=" + strconv.Itoa(p.params[name]))
+	}
+	b.WriteString("$" + base64.RawStdEncoding.EncodeToString(p.salt))
+	b.WriteString("$" + base64.RawStdEncoding.EncodeToString(p.hash))
+	return b.String()
+}
+
+// parsePHC 解析 PHC 字符串，id 不匹配或格式错误时返回 false
+func parsePHC(value, id string) (phc, bool) {
+	parts := strings.Split(value, "$")
+	if len(parts) < 5 || parts[0] != "" || parts[1] != id {
+		return phc{}, false
+	}
+	result := phc{id: id, params: make(map[string]int)}
+	rest := parts[2:]
+	if strings.HasPrefix(rest[0], "v=") {
+		version, err := strconv.Atoi(rest[0][2:])
+		if err != nil {
+			return phc{}, false
+		}
+		result.version = version
+		rest = rest[1:]
+	}
+	if len(rest) != 3 {
+		return phc{}, false
+	}
+	for _, pair := range strings.Split(rest[0], ",") {
+		name, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return phc{}, false
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return phc{}, false
+		}
+		result.params[name] = n
+	}
+	var err error
+	if result.salt, err = base64.RawStdEncoding.DecodeString(rest[1]); err != nil {
+		return phc{}, false
+	}
+	if result.hash, err = base64.RawStdEncoding.DecodeString(rest[2]); err != nil || len(result.hash) == 0 {
+		return phc{}, false
+	}
+	return result, true
+}
@@ -0,0 +1,166 @@
+package driver
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/hashing"
+)
+
+// Manager 哈希管理器实现
+//
+// 驱动在首次使用时创建并缓存，config 为驱动名称到参数的映射，与 Laravel 的 config/hashing.php 对应：
+// bcrypt 读取 "rounds"、"generate"、"compare"；argon2id 读取 "memory"、"time"、"threads"、"key"；
+// scrypt 读取 "cost"、"block_size"、"parallelism"。
+type Manager struct {
+	mu            sync.RWMutex
+	config        map[string]map[string]interface{}
+	defaultDriver string
+	drivers       map[string]hashing.Hasher
+	factories     map[string]func(config map[string]interface{}) (hashing.Hasher, error)
+}
+
+var _ hashing.Manager = (*Manager)(nil)
+
+// NewManager 创建哈希管理器
+func NewManager(config map[string]map[string]interface{}, defaultDriver string) *Manager {
+	m := &Manager{
+		config:        config,
+		defaultDriver: defaultDriver,
+		drivers:       make(map[string]hashing.Hasher),
+		factories:     make(map[string]func(config map[string]interface{}) (hashing.Hasher, error)),
+	}
+	m.Extend(hashing.DriverBcrypt, func(config map[string]interface{}) (hashing.Hasher, error) {
+		generate, err := clientOption[func(password []byte, cost int) ([]byte, error)](config, "generate")
+		if err != nil {
+			return nil, err
+		}
+		compare, err := clientOption[func(hashedPassword, password []byte) error](config, "compare")
+		if err != nil {
+			return nil, err
+		}
+		return NewBcryptHasher(generate, compare, intOption(config, "rounds", 12)), nil
+	})
+	m.Extend(hashing.DriverArgon2id, func(config map[string]interface{}) (hashing.Hasher, error) {
+		key, err := clientOption[func(password, salt []byte, time, memory uint32, threads uint8, keyLen uint32) []byte](config, "key")
+		if err != nil {
+			return nil, err
+		}
+		return NewArgon2idHasher(key, Argon2Options{
+			Memory:  intOption(config, "memory", 0),
+			Time:    intOption(config, "time", 0),
+			Threads: intOption(config, "threads", 0),
+		}), nil
+	})
+	m.Extend(hashing.DriverScrypt, func(config map[string]interface{}) (hashing.Hasher, error) {
+		return NewScryptHasher(ScryptOptions{
+			Cost:        intOption(config, "cost", 0),
+			BlockSize:   intOption(config, "block_size", 0),
+			Parallelism: intOption(config, "parallelism", 0),
+		}), nil
+	})
+	return m
+}
+
+// Driver 获取驱动，不传名称时使用默认驱动
+func (m *Manager) Driver(name ...string) (hashing.Hasher, error) {
+	driverName := m.GetDefaultDriver()
+	if len(name) > 0 && name[0] != "" {
+		driverName = name[0]
+	}
+
+	m.mu.RLock()
+	hasher, ok := m.drivers[driverName]
+	m.mu.RUnlock()
+	if ok {
+		return hasher, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hasher, ok := m.drivers[driverName]; ok {
+		return hasher, nil
+	}
+	config, ok := m.config[driverName]
+	if !ok {
+		return nil, fmt.Errorf("hashing: driver [%s] is not configured", driverName)
+	}
+	factory, ok := m.factories[driverName]
+	if !ok {
+		return nil, fmt.Errorf("hashing: driver [%s] is not supported", driverName)
+	}
+	hasher, err := factory(config)
+	if err != nil {
+		return nil, err
+	}
+	m.drivers[driverName] = hasher
+	return hasher, nil
+}
+
+// GetDefaultDriver 获取默认驱动名称
+func (m *Manager) GetDefaultDriver() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultDriver
+}
+
+// SetDefaultDriver 设置默认驱动名称
+func (m *Manager) SetDefaultDriver(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultDriver = name
+}
+
+// Extend 注册驱动
+func (m *Manager) Extend(driver string, factory func(config map[string]interface{}) (hashing.Hasher, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.factories[driver] = factory
+}
+
+// Make 使用默认驱动生成哈希
+func (m *Manager) Make(value string) (string, error) {
+	hasher, err := m.Driver()
+	if err != nil {
+		return "", err
+	}
+	return hasher.Make(value)
+}
+
+// Check 按哈希自身的算法选择驱动校验，算法未配置时返回 false
+func (m *Manager) Check(value, hashedValue string) bool {
+	if value == "" || hashedValue == "" {
+		return false
+	}
+	hasher, err := m.Driver(algorithmOf(hashedValue))
+	return err == nil && hasher.Check(value, hashedValue)
+}
+
+// NeedsRehash 哈希的算法或参数与默认驱动不同时需要重新生成
+func (m *Manager) NeedsRehash(hashedValue string) bool {
+	hasher, err := m.Driver()
+	return err == nil && hasher.NeedsRehash(hashedValue)
+}
+
+// Info 解析哈希的算法和参数
+func (m *Manager) Info(hashedValue string) hashing.Info {
+	hasher, err := m.Driver(algorithmOf(hashedValue))
+	if err != nil {
+		return hashing.Info{}
+	}
+	return hasher.Info(hashedValue)
+}
+
+// algorithmOf 按前缀识别哈希算法，无法识别时返回默认驱动名（空字符串）
+func algorithmOf(hashedValue string) string {
+	switch {
+	case strings.HasPrefix(hashedValue, "$2a$"), strings.HasPrefix(hashedValue, "$2b$"), strings.HasPrefix(hashedValue, "$2y$"):
+		return hashing.DriverBcrypt
+	case strings.HasPrefix(hashedValue, "$argon2id$"):
+		return hashing.DriverArgon2id
+	case strings.HasPrefix(hashedValue, "$scrypt$"):
+		return hashing.DriverScrypt
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package driver
+
+import (
+	"crypto/subtle"
+	"math/bits"
+
+	"github.com/cnote0/laraveldoc/hashing"
+)
+
+// ScryptOptions scrypt 参数
+type ScryptOptions struct {
+	// Cost CPU/内存成本 N，必须是 2 的幂，默认 32768
+	Cost int
+
+	// BlockSize 块大小 r，默认 8
+	BlockSize int
+
+	// Parallelism 并行度 p，默认 1
+	Parallelism int
+}
+
+// ScryptHasher scrypt 哈希，格式为 $scrypt$ln=15,r=8,p=1$salt$hash
+type ScryptHasher struct {
+	options ScryptOptions
+}
+
+var _ hashing.Hasher = (*ScryptHasher)(nil)
+
+// NewScryptHasher 创建 scrypt 哈希
+func NewScryptHasher(options ScryptOptions) *ScryptHasher {
+	if options.Cost <= 1 {
+		options.Cost = 32768
+	}
+	if options.BlockSize <= 0 {
+		options.BlockSize = 8
+	}
+	if options.Parallelism <= 0 {
+		options.Parallelism = 1
+	}
+	return &ScryptHasher{options: options}
+}
+
+// Make 生成哈希
+func (h *ScryptHasher) Make(value string) (string, error) {
+	s, err := salt(16)
+	if err != nil {
+		return "", err
+	}
+	key, err := scryptKey([]byte(value), s, h.options.Cost, h.options.BlockSize, h.options.Parallelism, 32)
+	if err != nil {
+		return "", err
+	}
+	return phc{
+		id:     hashing.DriverScrypt,
+		params: map[string]int{"ln": bits.Len(uint(h.options.Cost)) - 1, "r": h.options.BlockSize, "p": h.options.Parallelism},
+		salt:   s,
+		hash:   key,
+	}.String("ln", "r", "p"), nil
+}
+
+// Check 校验明文与哈希是否匹配
+func (h *ScryptHasher) Check(value, hashedValue string) bool {
+	parsed, ok := parsePHC(hashedValue, hashing.DriverScrypt)
+	if !ok || parsed.params["ln"] <= 0 || parsed.params["ln"] >= 32 {
+		return false
+	}
+	key, err := scryptKey([]byte(value), parsed.salt, 1<<parsed.params["ln"], parsed.params["r"], parsed.params["p"], len(parsed.hash))
+	return err == nil && subtle.ConstantTimeCompare(key, parsed.hash) == 1
+}
+
+// NeedsRehash 哈希参数与当前配置不同时需要重新生成
+func (h *ScryptHasher) NeedsRehash(hashedValue string) bool {
+	parsed, ok := parsePHC(hashedValue, hashing.DriverScrypt)
+	return !ok ||
+		1<<parsed.params["ln"] != h.options.Cost ||
+		parsed.params["r"] != h.options.BlockSize ||
+		parsed.params["p"] != h.options.Parallelism
+}
+
+// Info 解析哈希的算法和参数
+func (h *ScryptHasher) Info(hashedValue string) hashing.Info {
+	parsed, ok := parsePHC(hashedValue, hashing.DriverScrypt)
+	if !ok {
+		return hashing.Info{}
+	}
+	return hashing.Info{Algorithm: hashing.DriverScrypt, Options: parsed.params}
+}
@@ -0,0 +1,163 @@
+package driver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// scryptKey 按 RFC 7914 计算 scrypt 派生密钥
+func scryptKey(password, salt []byte, n, r, p, keyLen int) ([]byte, error) {
+	if n <= 1 || n&(n-1) != 0 {
+		return nil, errors.New("hashing: scrypt N must be > 1 and a power of 2")
+	}
+	if uint64(r)*uint64(p) >= 1<<30 || r > (1<<31-1)/128/p || r > (1<<31-1)/256 || n > (1<<31-1)/128/r {
+		return nil, errors.New("hashing: scrypt parameters are too large")
+	}
+
+	xy := make([]uint32, 64*r)
+	v := make([]uint32, 32*n*r)
+	b := pbkdf2SHA256(password, salt, 1, p*128*r)
+	for i := 0; i < p; i++ {
+		smix(b[i*128*r:], r, n, v, xy)
+	}
+	return pbkdf2SHA256(password, b, 1, keyLen), nil
+}
+
+// pbkdf2SHA256 PBKDF2-HMAC-SHA256
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	var counter [4]byte
+	dk := make([]byte, 0, blocks*hashLen)
+	u := make([]byte, hashLen)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Write(counter[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+		for i := 2; i <= iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for x := range u {
+				t[x] ^= u[x]
+			}
+		}
+	}
+	return dk[:keyLen]
+}
+
+// smix scrypt 的 ROMix
+func smix(b []byte, r, n int, v, xy []uint32) {
+	var tmp [16]uint32
+	length := 32 * r
+	x := xy
+	y := xy[length:]
+
+	for i := 0; i < length; i++ {
+		x[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	for i := 0; i < n; i += 2 {
+		copy(v[i*length:], x[:length])
+		blockMix(&tmp, x, y, r)
+		copy(v[(i+1)*length:], y[:length])
+		blockMix(&tmp, y, x, r)
+	}
+	for i := 0; i < n; i += 2 {
+		j := int(integerify(x, r) & uint64(n-1))
+		blockXOR(x, v[j*length:], length)
+		blockMix(&tmp, x, y, r)
+
+		j = int(integerify(y, r) & uint64(n-1))
+		blockXOR(y, v[j*length:], length)
+		blockMix(&tmp, y, x, r)
+	}
+	for i, value := range x[:length] {
+		binary.LittleEndian.PutUint32(b[i*4:], value)
+	}
+}
+
+// blockMix scrypt 的 BlockMix，偶数块写入前半部分，奇数块写入后半部分
+func blockMix(tmp *[16]uint32, in, out []uint32, r int) {
+	copy(tmp[:], in[(2*r-1)*16:])
+	for i := 0; i < 2*r; i += 2 {
+		salsaXOR(tmp, in[i*16:], out[i*8:])
+		salsaXOR(tmp, in[i*16+16:], out[i*8+r*16:])
+	}
+}
+
+// blockXOR dst ^= src
+func blockXOR(dst, src []uint32, n int) {
+	for i, value := range src[:n] {
+		dst[i] ^= value
+	}
+}
+
+// integerify 取最后一个 64 字节块的前 8 字节作为整数
+func integerify(b []uint32, r int) uint64 {
+	j := (2*r - 1) * 16
+	return uint64(b[j]) | uint64(b[j+1])<<32
+}
+
+// salsaXOR 对 tmp ^ in 执行 Salsa20/8，结果写入 tmp 和 out
+func salsaXOR(tmp *[16]uint32, in, out []uint32) {
+	var w [16]uint32
+	for i := range w {
+		w[i] = tmp[i] ^ in[i]
+	}
+	x := w
+	for i := 0; i < 8; i += 2 {
+		x[4] ^= bits.RotateLeft32(x[0]+x[12], 7)
+		x[8] ^= bits.RotateLeft32(x[4]+x[0], 9)
+		x[12] ^= bits.RotateLeft32(x[8]+x[4], 13)
+		x[0] ^= bits.RotateLeft32(x[12]+x[8], 18)
+
+		x[9] ^= bits.RotateLeft32(x[5]+x[1], 7)
+		x[13] ^= bits.RotateLeft32(x[9]+x[5], 9)
+		x[1] ^= bits.RotateLeft32(x[13]+x[9], 13)
+		x[5] ^= bits.RotateLeft32(x[1]+x[13], 18)
+
+		x[14] ^= bits.RotateLeft32(x[10]+x[6], 7)
+		x[2] ^= bits.RotateLeft32(x[14]+x[10], 9)
+		x[6] ^= bits.RotateLeft32(x[2]+x[14], 13)
+		x[10] ^= bits.RotateLeft32(x[6]+x[2], 18)
+
+		x[3] ^= bits.RotateLeft32(x[15]+x[11], 7)
+		x[7] ^= bits.RotateLeft32(x[3]+x[15], 9)
+		x[11] ^= bits.RotateLeft32(x[7]+x[3], 13)
+		x[15] ^= bits.RotateLeft32(x[11]+x[7], 18)
+
+		x[1] ^= bits.RotateLeft32(x[0]+x[3], 7)
+		x[2] ^= bits.RotateLeft32(x[1]+x[0], 9)
+		x[3] ^= bits.RotateLeft32(x[2]+x[1], 13)
+		x[0] ^= bits.RotateLeft32(x[3]+x[2], 18)
+
+		x[6] ^= bits.RotateLeft32(x[5]+x[4], 7)
+		x[7] ^= bits.RotateLeft32(x[6]+x[5], 9)
+		x[4] ^= bits.RotateLeft32(x[7]+x[6], 13)
+		x[5] ^= bits.RotateLeft32(x[4]+x[7], 18)
+
+		x[11] ^= bits.RotateLeft32(x[10]+x[9], 7)
+		x[8] ^= bits.RotateLeft32(x[11]+x[10], 9)
+		x[9] ^= bits.RotateLeft32(x[8]+x[11], 13)
+		x[10] ^= bits.RotateLeft32(x[9]+x[8], 18)
+
+		x[12] ^= bits.RotateLeft32(x[15]+x[14], 7)
+		x[13] ^= bits.RotateLeft32(x[12]+x[15], 9)
+		x[14] ^= bits.RotateLeft32(x[13]+x[12], 13)
+		x[15] ^= bits.RotateLeft32(x[14]+x[13], 18)
+	}
+	for i := range x {
+		x[i] += w[i]
+		tmp[i] = x[i]
+		out[i] = x[i]
+	}
+}
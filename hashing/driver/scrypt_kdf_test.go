@@ -0,0 +1,125 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// unhex 解码测试向量，忽略空白
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// RFC 7914 第 11 节的 PBKDF2-HMAC-SHA256 测试向量
+func TestPBKDF2SHA256RFC7914(t *testing.T) {
+	tests := []struct {
+		password, salt string
+		iterations     int
+		want           string
+	}{
+		{"passwd", "salt", 1, `
+			55 ac 04 6e 56 e3 08 9f ec 16 91 c2 25 44 b6 05
+			f9 41 85 21 6d de 04 65 e6 8b 9d 57 c2 0d ac bc
+			49 ca 9c cc f1 79 b6 45 99 16 64 b3 9d 77 ef 31
+			7c 71 b8 45 b1 e3 0b d5 09 11 20 41 d3 a1 97 83`},
+		{"Password", "NaCl", 80000, `
+			4d dc d8 f6 0b 98 be 21 83 0c ee 5e f2 27 01 f9
+			64 1a 44 18 d0 4c 04 14 ae ff 08 87 6b 34 ab 56
+			a1 d4 25 a1 22 58 33 54 9a db 84 1b 51 c9 b3 17
+			6a 27 2b de bb a1 d0 78 47 8f 62 b3 97 f3 3c 8d`},
+	}
+	for _, tt := range tests {
+		want := unhex(t, tt.want)
+		got := pbkdf2SHA256([]byte(tt.password), []byte(tt.salt), tt.iterations, len(want))
+		if !bytes.Equal(got, want) {
+			t.Errorf("pbkdf2SHA256(%q, %q, %d) = %x, want %x", tt.password, tt.salt, tt.iterations, got, want)
+		}
+	}
+}
+
+// RFC 7914 第 12 节的 scrypt 测试向量
+//
+// 第四个向量（N=1048576）需要 1 GiB 内存，不在这里运行。
+func TestScryptKeyRFC7914(t *testing.T) {
+	tests := []struct {
+		password, salt string
+		n, r, p        int
+		want           string
+	}{
+		{"", "", 16, 1, 1, `
+			77 d6 57 62 38 65 7b 20 3b 19 ca 42 c1 8a 04 97
+			f1 6b 48 44 e3 07 4a e8 df df fa 3f ed e2 14 42
+			fc d0 06 9d ed 09 48 f8 32 6a 75 3a 0f c8 1f 17
+			e8 d3 e0 fb 2e 0d 36 28 cf 35 e2 0c 38 d1 89 06`},
+		{"password", "NaCl", 1024, 8, 16, `
+			fd ba be 1c 9d 34 72 00 78 56 e7 19 0d 01 e9 fe
+			7c 6a d7 cb c8 23 78 30 e7 73 76 63 4b 37 31 62
+			2e af 30 d9 2e 22 a3 88 6f f1 09 27 9d 98 30 da
+			c7 27 af b9 4a 83 ee 6d 83 60 cb df a2 cc 06 40`},
+		{"pleaseletmein", "SodiumChloride", 16384, 8, 1, `
+			70 23 bd cb 3a fd 73 48 46 1c 06 cd 81 fd 38 eb
+			fd a8 fb ba 90 4f 8e 3e a9 b5 43 f6 54 5d a1 f2
+			d5 43 29 55 61 3f 0f cf 62 d4 97 05 24 2a 9a f9
+			e6 1e 85 dc 0d 65 1e 40 df cf 01 7b 45 57 58 87`},
+	}
+	for _, tt := range tests {
+		want := unhex(t, tt.want)
+		got, err := scryptKey([]byte(tt.password), []byte(tt.salt), tt.n, tt.r, tt.p, len(want))
+		if err != nil {
+			t.Fatalf("scryptKey(%q, %q, N=%d): %v", tt.password, tt.salt, tt.n, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("scryptKey(%q, %q, N=%d, r=%d, p=%d) = %x, want %x", tt.password, tt.salt, tt.n, tt.r, tt.p, got, want)
+		}
+	}
+}
+
+func TestScryptKeyRejectsInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name    string
+		n, r, p int
+	}{
+		{"N not a power of 2", 1000, 8, 1},
+		{"N is 1", 1, 8, 1},
+		{"r*p too large", 16, 1 << 20, 1 << 10},
+		{"N*r too large", 1 << 30, 8, 1},
+	}
+	for _, tt := range tests {
+		if _, err := scryptKey([]byte("password"), []byte("salt"), tt.n, tt.r, tt.p, 32); err == nil {
+			t.Errorf("%s: scryptKey(N=%d, r=%d, p=%d) returned no error", tt.name, tt.n, tt.r, tt.p)
+		}
+	}
+}
+
+func TestScryptHasherRoundTrip(t *testing.T) {
+	h := NewScryptHasher(ScryptOptions{Cost: 16, BlockSize: 1, Parallelism: 1})
+	hashed, err := h.Make("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hashed, "$scrypt$ln=4,r=1,p=1$") {
+		t.Fatalf("Make() = %q, want $scrypt$ln=4,r=1,p=1$ prefix", hashed)
+	}
+	if !h.Check("secret", hashed) {
+		t.Error("Check(secret) = false, want true")
+	}
+	if h.Check("wrong", hashed) {
+		t.Error("Check(wrong) = true, want false")
+	}
+	if h.NeedsRehash(hashed) {
+		t.Error("NeedsRehash() = true for the current options")
+	}
+	if !NewScryptHasher(ScryptOptions{Cost: 32, BlockSize: 1, Parallelism: 1}).NeedsRehash(hashed) {
+		t.Error("NeedsRehash() = false after the cost changed")
+	}
+	if info := h.Info(hashed); info.Algorithm != "scrypt" || info.Options["ln"] != 4 {
+		t.Errorf("Info() = %+v", info)
+	}
+}
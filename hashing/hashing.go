@@ -0,0 +1,96 @@
+// Package hashing 提供 Laravel 风格的密码哈希协议定义
+//
+// Hasher 负责生成和校验密码哈希，NeedsRehash 判断旧哈希是否需要按当前参数重新生成，
+// 用于在登录成功后平滑升级算法或成本参数。Manager 按配置选择驱动，对应 Laravel 的 HashManager，
+// 本身也实现 Hasher，可以直接交给 auth 的用户提供者使用。
+//
+// 包结构：
+// - hashing.go - Hasher、Info、Manager 接口、驱动名称和默认管理器
+//
+// 子包 driver 提供 bcrypt、argon2id、scrypt 三种驱动和 Manager 实现。
+//
+// 使用示例：
+//
+//	hasher := container.MustMake("hash").(hashing.Manager)
+//	hashed, _ := hasher.Make("secret")
+//	if hasher.Check("secret", hashed) && hasher.NeedsRehash(hashed) {
+//		hashed, _ = hasher.Make("secret")
+//	}
+package hashing
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// 内置驱动名称
+const (
+	// DriverBcrypt bcrypt，Laravel 的默认驱动
+	DriverBcrypt = "bcrypt"
+
+	// DriverArgon2id Argon2id
+	DriverArgon2id = "argon2id"
+
+	// DriverScrypt scrypt
+	DriverScrypt = "scrypt"
+)
+
+// ErrNoManager 没有设置默认哈希管理器
+var ErrNoManager = errors.New("hashing: no default manager, call hashing.SetDefaultManager")
+
+// Hasher 密码哈希接口
+type Hasher interface {
+	// Make 生成哈希
+	Make(value string) (string, error)
+
+	// Check 校验明文与哈希是否匹配，哈希不属于本驱动的算法时返回 false
+	Check(value, hashedValue string) bool
+
+	// NeedsRehash 哈希是否需要按当前算法和参数重新生成
+	NeedsRehash(hashedValue string) bool
+
+	// Info 解析哈希的算法和参数
+	Info(hashedValue string) Info
+}
+
+// Info 哈希信息
+type Info struct {
+	// Algorithm 算法名称，无法识别时为空
+	Algorithm string
+
+	// Options 算法参数，例如 bcrypt 的 cost、argon2id 的 memory/time/threads
+	Options map[string]int
+}
+
+// Manager 哈希管理器接口
+//
+// 作为 Hasher 使用时，Make 和 NeedsRehash 使用默认驱动；Check 按哈希自身的算法选择已配置的驱动，
+// 因此切换默认驱动后旧哈希仍然可以校验，并在 NeedsRehash 中被识别为需要升级。
+type Manager interface {
+	Hasher
+
+	// Driver 获取驱动，不传名称时使用默认驱动
+	Driver(name ...string) (Hasher, error)
+
+	// GetDefaultDriver 获取默认驱动名称
+	GetDefaultDriver() string
+
+	// SetDefaultDriver 设置默认驱动名称
+	SetDefaultDriver(name string)
+}
+
+// defaultManager 默认哈希管理器
+var defaultManager atomic.Value
+
+// SetDefaultManager 设置默认哈希管理器
+func SetDefaultManager(manager Manager) {
+	defaultManager.Store(&manager)
+}
+
+// DefaultManager 获取默认哈希管理器，未设置时返回 nil
+func DefaultManager() Manager {
+	if manager, ok := defaultManager.Load().(*Manager); ok {
+		return *manager
+	}
+	return nil
+}
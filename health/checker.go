@@ -0,0 +1,137 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Checker 健康检查器实现
+type Checker struct {
+	mu      sync.RWMutex
+	checks  []Check
+	timeout time.Duration
+}
+
+var _ HealthChecker = (*Checker)(nil)
+
+// NewChecker 创建健康检查器，每项检查默认超时 5 秒
+func NewChecker() *Checker {
+	return &Checker{timeout: 5 * time.Second}
+}
+
+// Timeout 设置每项检查的超时
+func (c *Checker) Timeout(timeout time.Duration) *Checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.timeout = timeout
+	return c
+}
+
+// Register 注册检查
+func (c *Checker) Register(checks ...Check) HealthChecker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, check := range checks {
+		replaced := false
+		for i, existing := range c.checks {
+			if existing.Name() == check.Name() {
+				c.checks[i], replaced = check, true
+				break
+			}
+		}
+		if !replaced {
+			c.checks = append(c.checks, check)
+		}
+	}
+	return c
+}
+
+// Checks 已注册的检查
+func (c *Checker) Checks() []Check {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Check{}, c.checks...)
+}
+
+// Run 并发执行检查
+//
+// 未注册的检查名称记录为失败。检查超时或 panic 时记录为失败，
+// 超时的检查不等待结束，检查应遵守 ctx 的取消。
+func (c *Checker) Run(ctx context.Context, names ...string) Report {
+	c.mu.RLock()
+	timeout := c.timeout
+	c.mu.RUnlock()
+
+	checks := c.Checks()
+	var missing []string
+	if len(names) > 0 {
+		byName := make(map[string]Check, len(checks))
+		for _, check := range checks {
+			byName[check.Name()] = check
+		}
+		checks = checks[:0:0]
+		for _, name := range names {
+			if check, ok := byName[name]; ok {
+				checks = append(checks, check)
+			} else {
+				missing = append(missing, name)
+			}
+		}
+	}
+
+	start := time.Now()
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, check, timeout)
+		}()
+	}
+	wg.Wait()
+	for _, name := range missing {
+		results = append(results, CheckResult{Name: name, Result: Failed(fmt.Sprintf("Check [%s] is not registered.", name))})
+	}
+
+	report := Report{Status: StatusOK, Checks: results, CheckedAt: start, Latency: time.Since(start)}
+	for _, result := range results {
+		if result.Status.severity() > report.Status.severity() {
+			report.Status = result.Status
+		}
+	}
+	return report
+}
+
+// run 执行一项检查
+func run(ctx context.Context, check Check, timeout time.Duration) CheckResult {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan Result, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- Failed(fmt.Sprintf("Check panicked: %v", recovered))
+			}
+		}()
+		done <- check.Run(ctx)
+	}()
+
+	var result Result
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result = Failed(fmt.Sprintf("Check did not complete in time: %v", ctx.Err()))
+	}
+	if result.Status == "" {
+		result.Status = StatusOK
+	}
+	return CheckResult{Name: check.Name(), Result: result, Latency: time.Since(start)}
+}
@@ -0,0 +1,214 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/queue"
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// CheckFunc 函数形式的检查
+type CheckFunc struct {
+	name string
+	fn   func(ctx context.Context) Result
+}
+
+// NewCheckFunc 创建返回完整结果的回调检查
+func NewCheckFunc(name string, fn func(ctx context.Context) Result) *CheckFunc {
+	return &CheckFunc{name: name, fn: fn}
+}
+
+// Func 创建回调检查，返回错误时失败
+func Func(name string, fn func(ctx context.Context) error) *CheckFunc {
+	return NewCheckFunc(name, func(ctx context.Context) Result {
+		if err := fn(ctx); err != nil {
+			return Failed(err.Error())
+		}
+		return OK()
+	})
+}
+
+// Name 检查名称
+func (c *CheckFunc) Name() string {
+	return c.name
+}
+
+// Run 执行回调
+func (c *CheckFunc) Run(ctx context.Context) Result {
+	return c.fn(ctx)
+}
+
+// Database 数据库检查，Ping 连接并附带连接池状态
+func Database(name string, db database.DB) *CheckFunc {
+	return NewCheckFunc(name, func(ctx context.Context) Result {
+		sqlDB, err := db.SqlDB()
+		if err != nil {
+			return Failed(err.Error())
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return Failed(fmt.Sprintf("Could not connect to the database: %v", err))
+		}
+		stats := sqlDB.Stats()
+		return OK().
+			WithMeta("open_connections", stats.OpenConnections).
+			WithMeta("in_use", stats.InUse).
+			WithMeta("idle", stats.Idle)
+	})
+}
+
+// RedisClient Redis 客户端，与 queue/driver.RedisClient 相同，可以复用同一个适配器
+type RedisClient interface {
+	// Eval 执行 Lua 脚本
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+}
+
+// Redis Redis 检查，执行 PING
+func Redis(name string, client RedisClient) *CheckFunc {
+	return NewCheckFunc(name, func(ctx context.Context) Result {
+		reply, err := client.Eval(ctx, "return redis.call('ping')", nil)
+		if err != nil {
+			return Failed(fmt.Sprintf("Could not connect to Redis: %v", err))
+		}
+		if reply != "PONG" {
+			return Failed(fmt.Sprintf("Unexpected PING reply: %v", reply))
+		}
+		return OK()
+	})
+}
+
+// Cache 缓存检查，写入、读取并删除一个临时键
+func Cache(name string, store cache.Store) *CheckFunc {
+	return NewCheckFunc(name, func(ctx context.Context) Result {
+		key := "health-check:" + str.Random(16)
+		value := str.Random(16)
+		if err := store.Put(ctx, key, value, time.Minute); err != nil {
+			return Failed(fmt.Sprintf("Could not write to the cache: %v", err))
+		}
+		defer store.Forget(ctx, key)
+		got, ok, err := store.Get(ctx, key)
+		if err != nil {
+			return Failed(fmt.Sprintf("Could not read from the cache: %v", err))
+		}
+		if !ok || got != value {
+			return Failed("The cache did not return the value that was written.")
+		}
+		return OK()
+	})
+}
+
+// QueueCheck 队列检查，检查连接可用并按待处理任务数量报告警告或失败
+type QueueCheck struct {
+	name       string
+	manager    queue.Manager
+	connection string
+	queue      string
+	warnAbove  int64
+	failAbove  int64
+}
+
+// Queue 创建队列检查，connection 为空时使用默认连接，queueName 为空时使用连接的默认队列
+func Queue(name string, manager queue.Manager, connection, queueName string) *QueueCheck {
+	return &QueueCheck{name: name, manager: manager, connection: connection, queue: queueName}
+}
+
+// WarnAbove 待处理任务超过 size 时警告
+func (c *QueueCheck) WarnAbove(size int64) *QueueCheck {
+	c.warnAbove = size
+	return c
+}
+
+// FailAbove 待处理任务超过 size 时失败
+func (c *QueueCheck) FailAbove(size int64) *QueueCheck {
+	c.failAbove = size
+	return c
+}
+
+// Name 检查名称
+func (c *QueueCheck) Name() string {
+	return c.name
+}
+
+// Run 查询队列长度
+func (c *QueueCheck) Run(ctx context.Context) Result {
+	var names []string
+	if c.connection != "" {
+		names = append(names, c.connection)
+	}
+	q, err := c.manager.Connection(names...)
+	if err != nil {
+		return Failed(err.Error())
+	}
+	size, err := q.Size(ctx, c.queue)
+	if err != nil {
+		return Failed(fmt.Sprintf("Could not read the queue size: %v", err))
+	}
+	var result Result
+	switch {
+	case c.failAbove > 0 && size > c.failAbove:
+		result = Failed(fmt.Sprintf("The queue has %d pending jobs, more than %d.", size, c.failAbove))
+	case c.warnAbove > 0 && size > c.warnAbove:
+		result = Warning(fmt.Sprintf("The queue has %d pending jobs, more than %d.", size, c.warnAbove))
+	default:
+		result = OK()
+	}
+	return result.WithMeta("size", size)
+}
+
+// DiskSpaceCheck 磁盘空间检查，按已用百分比报告警告或失败
+type DiskSpaceCheck struct {
+	name      string
+	path      string
+	warnAbove float64
+	failAbove float64
+}
+
+// DiskSpace 创建磁盘空间检查，默认已用超过 70% 时警告，超过 90% 时失败
+func DiskSpace(name, path string) *DiskSpaceCheck {
+	return &DiskSpaceCheck{name: name, path: path, warnAbove: 70, failAbove: 90}
+}
+
+// WarnAbove 已用百分比超过 percent 时警告
+func (c *DiskSpaceCheck) WarnAbove(percent float64) *DiskSpaceCheck {
+	c.warnAbove = percent
+	return c
+}
+
+// FailAbove 已用百分比超过 percent 时失败
+func (c *DiskSpaceCheck) FailAbove(percent float64) *DiskSpaceCheck {
+	c.failAbove = percent
+	return c
+}
+
+// Name 检查名称
+func (c *DiskSpaceCheck) Name() string {
+	return c.name
+}
+
+// Run 读取磁盘使用情况
+func (c *DiskSpaceCheck) Run(ctx context.Context) Result {
+	total, free, err := diskUsage(c.path)
+	if err != nil {
+		return Failed(fmt.Sprintf("Could not read disk usage of %s: %v", c.path, err))
+	}
+	if total == 0 {
+		return Failed(fmt.Sprintf("Could not read disk usage of %s: total size is 0", c.path))
+	}
+	used := float64(total-free) / float64(total) * 100
+	var result Result
+	switch {
+	case used > c.failAbove:
+		result = Failed(fmt.Sprintf("The disk is almost full (%.0f%% used).", used))
+	case used > c.warnAbove:
+		result = Warning(fmt.Sprintf("The disk is almost full (%.0f%% used).", used))
+	default:
+		result = OK()
+	}
+	return result.
+		WithMeta("used_percent", float64(int(used*100))/100).
+		WithMeta("free_bytes", free).
+		WithMeta("total_bytes", total)
+}
@@ -0,0 +1,57 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// ErrUnhealthy 存在失败的检查，health:check 以非 0 退出码退出
+var ErrUnhealthy = errors.New("health: one or more checks failed")
+
+// RegisterCommands 注册 health:check 命令
+//
+// 命令执行检查并输出每项检查的状态和耗时，存在失败的检查时返回 ErrUnhealthy，
+// 可以直接作为 Kubernetes 的 exec 探针使用。
+func RegisterCommands(artisan application.ArtisanInterface, checker HealthChecker) {
+	artisan.Register("health:check").
+		SetDescription("Run the registered health checks").
+		AddOption("check", "", application.InputOptionValueRequired, "Only run the given checks (comma separated)", "").
+		AddOption("json", "", application.InputOptionValueNone, "Output the report as JSON", false).
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			var names []string
+			if value, _ := input.GetOption("check").(string); value != "" {
+				names = strings.Split(value, ",")
+			}
+			report := checker.Run(context.Background(), names...)
+
+			if asJSON, _ := input.GetOption("json").(bool); asJSON {
+				encoded, err := json.Marshal(report)
+				if err != nil {
+					return err
+				}
+				if err := output.WriteLine(string(encoded), application.VerbosityNormal); err != nil {
+					return err
+				}
+			} else {
+				for _, result := range report.Checks {
+					line := fmt.Sprintf("%-8s %s (%s)", strings.ToUpper(string(result.Status)), result.Name, result.Latency.Round(time.Millisecond))
+					if result.Message != "" {
+						line += ": " + result.Message
+					}
+					if err := output.WriteLine(line, application.VerbosityNormal); err != nil {
+						return err
+					}
+				}
+			}
+			if !report.Healthy() {
+				return ErrUnhealthy
+			}
+			return nil
+		})
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package health
+
+import "errors"
+
+// diskUsage 当前平台不支持磁盘空间统计
+func diskUsage(path string) (total, free uint64, err error) {
+	return 0, 0, errors.ErrUnsupported
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package health
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var getDiskFreeSpaceEx = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDiskFreeSpaceExW")
+
+// diskUsage 磁盘总空间和调用者可用空间（字节）
+func diskUsage(path string) (total, free uint64, err error) {
+	name, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	var available, totalBytes, totalFree uint64
+	ok, _, callErr := getDiskFreeSpaceEx.Call(
+		uintptr(unsafe.Pointer(name)),
+		uintptr(unsafe.Pointer(&available)),
+		uintptr(unsafe.Pointer(&totalBytes)),
+		uintptr(unsafe.Pointer(&totalFree)),
+	)
+	if ok == 0 {
+		return 0, 0, callErr
+	}
+	return totalBytes, available, nil
+}
@@ -0,0 +1,163 @@
+// Package health 提供健康检查
+//
+// 应用把数据库、Redis、缓存、队列、磁盘空间等检查注册到 HealthChecker，
+// 由 /health 就绪检查路由、health:check 命令或 Kubernetes 探针执行。
+// 所有检查并发执行，每项检查有独立的超时，结果汇总为整体状态并附带每项检查的耗时。
+//
+// 主要特性：
+// - 内置数据库、Redis、缓存、队列、磁盘空间检查和自定义回调检查
+// - ok、warning、failed 三种状态，整体状态取最差的一项
+// - 每项检查的耗时（latency）、说明和附加数据
+// - 检查 panic 和超时记录为失败
+// - /up 存活检查和 /health 就绪检查处理器
+// - health:check 控制台命令，检查失败时以非 0 退出码退出
+//
+// 包结构：
+// - health.go - HealthChecker 接口、Check 检查接口、Result 和 Report 结果
+// - checker.go - Checker 实现
+// - checks.go - 内置检查
+// - disk_unix.go、disk_windows.go、disk_other.go - 磁盘空间统计
+// - http.go - /up 和 /health 处理器
+// - command.go - health:check 命令
+//
+// 使用示例：
+//
+//	checker := health.NewChecker().Register(
+//		health.Database("mysql", db),
+//		health.Redis("redis", redisAdapter),
+//		health.Cache("cache", store),
+//		health.Queue("queue", manager, "redis", "default").WarnAbove(1000),
+//		health.DiskSpace("disk", "/").WarnAbove(70).FailAbove(90),
+//		health.Func("payments", func(ctx context.Context) error {
+//			return payments.Ping(ctx)
+//		}),
+//	)
+//
+//	mux.Handle("GET /up", health.Liveness())
+//	mux.Handle("GET /health", checker.Handler())
+//	health.RegisterCommands(artisan, checker)
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// Status 检查状态
+type Status string
+
+// 检查状态，按严重程度递增
+const (
+	StatusOK      Status = "ok"
+	StatusWarning Status = "warning"
+	StatusFailed  Status = "failed"
+)
+
+// severity 状态的严重程度
+func (s Status) severity() int {
+	switch s {
+	case StatusOK:
+		return 0
+	case StatusWarning:
+		return 1
+	}
+	return 2
+}
+
+// HealthChecker 健康检查器接口
+type HealthChecker interface {
+	// Register 注册检查，同名检查会被替换
+	Register(checks ...Check) HealthChecker
+
+	// Checks 已注册的检查，按注册顺序
+	Checks() []Check
+
+	// Run 执行检查，names 为空时执行所有检查
+	Run(ctx context.Context, names ...string) Report
+}
+
+// Check 一项检查
+type Check interface {
+	// Name 检查名称
+	Name() string
+
+	// Run 执行检查
+	Run(ctx context.Context) Result
+}
+
+// Result 一项检查的结果
+type Result struct {
+	// Status 状态
+	Status Status `json:"status"`
+
+	// Message 说明，失败时为失败原因
+	Message string `json:"message,omitempty"`
+
+	// Meta 附加数据，例如磁盘使用率、队列长度
+	Meta map[string]interface{} `json:"meta,omitempty"`
+}
+
+// OK 创建成功结果
+func OK(message ...string) Result {
+	return Result{Status: StatusOK, Message: firstMessage(message)}
+}
+
+// Warning 创建警告结果
+func Warning(message string) Result {
+	return Result{Status: StatusWarning, Message: message}
+}
+
+// Failed 创建失败结果
+func Failed(message string) Result {
+	return Result{Status: StatusFailed, Message: message}
+}
+
+// WithMeta 添加附加数据
+func (r Result) WithMeta(key string, value interface{}) Result {
+	meta := make(map[string]interface{}, len(r.Meta)+1)
+	for k, v := range r.Meta {
+		meta[k] = v
+	}
+	meta[key] = value
+	r.Meta = meta
+	return r
+}
+
+// CheckResult 报告中一项检查的结果
+type CheckResult struct {
+	// Name 检查名称
+	Name string `json:"name"`
+
+	Result
+
+	// Latency 耗时，JSON 中为纳秒
+	Latency time.Duration `json:"latency"`
+}
+
+// Report 检查报告
+type Report struct {
+	// Status 整体状态，取所有检查中最严重的状态，没有检查时为 ok
+	Status Status `json:"status"`
+
+	// Checks 各项检查的结果，按注册顺序
+	Checks []CheckResult `json:"checks"`
+
+	// CheckedAt 检查时间
+	CheckedAt time.Time `json:"checked_at"`
+
+	// Latency 总耗时，JSON 中为纳秒
+	Latency time.Duration `json:"latency"`
+}
+
+// Healthy 是否健康，警告不影响健康
+func (r Report) Healthy() bool {
+	return r.Status != StatusFailed
+}
+
+// firstMessage 可选参数中的说明
+func firstMessage(message []string) string {
+	if len(message) > 0 {
+		return message[0]
+	}
+	return ""
+}
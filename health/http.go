@@ -0,0 +1,50 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Liveness 返回 /up 存活检查处理器
+//
+// 只要进程能够处理请求就返回 200，不执行任何检查，
+// 依赖服务不可用时不应重启进程，这类检查放在就绪检查中。
+func Liveness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]Status{"status": StatusOK})
+	})
+}
+
+// Handler 返回 /health 就绪检查处理器
+//
+// 执行所有检查并返回 JSON 报告，存在失败的检查时返回 503，
+// 可以用 ?check=database,redis 只执行部分检查。
+// 报告可能包含内部信息，生产环境应只在内网暴露。
+func (c *Checker) Handler() http.Handler {
+	return Handler(c)
+}
+
+// Handler 返回执行 checker 的就绪检查处理器
+func Handler(checker HealthChecker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var names []string
+		if value := r.URL.Query().Get("check"); value != "" {
+			names = strings.Split(value, ",")
+		}
+		report := checker.Run(r.Context(), names...)
+		status := http.StatusOK
+		if !report.Healthy() {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	})
+}
+
+// writeJSON 写入 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
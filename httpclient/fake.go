@@ -0,0 +1,352 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrStrayRequest Fake 开启 PreventStrayRequests 后发送了没有匹配桩的请求
+var ErrStrayRequest = errors.New("httpclient: attempted request without a matching fake")
+
+// Stub 桩响应，根据请求生成响应
+type Stub func(req *http.Request) (*http.Response, error)
+
+// TestingT Fake 断言使用的测试接口，*testing.T 满足该接口
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// FakeResponse 创建返回固定响应的桩，body 为字符串或 []byte 时原样返回，其他值编码为 JSON
+func FakeResponse(body interface{}, status int, headers ...map[string]string) Stub {
+	var data []byte
+	contentType := ""
+	switch value := body.(type) {
+	case nil:
+	case string:
+		data = []byte(value)
+	case []byte:
+		data = value
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			panic(fmt.Errorf("httpclient: encode fake response: %w", err))
+		}
+		data, contentType = encoded, "application/json"
+	}
+	return func(req *http.Request) (*http.Response, error) {
+		header := make(http.Header)
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+		for _, h := range headers {
+			for name, value := range h {
+				header.Set(name, value)
+			}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(data)),
+			ContentLength: int64(len(data)),
+			Request:       req,
+		}, nil
+	}
+}
+
+// FailedConnection 创建模拟连接失败的桩
+func FailedConnection(err error) Stub {
+	if err == nil {
+		err = errors.New("httpclient: connection refused")
+	}
+	return func(req *http.Request) (*http.Response, error) {
+		return nil, err
+	}
+}
+
+// Sequence 按顺序返回的桩响应
+type Sequence struct {
+	mu        sync.Mutex
+	stubs     []Stub
+	whenEmpty Stub
+}
+
+// NewSequence 创建响应序列
+func NewSequence(stubs ...Stub) *Sequence {
+	return &Sequence{stubs: stubs}
+}
+
+// Push 追加固定响应
+func (s *Sequence) Push(body interface{}, status int, headers ...map[string]string) *Sequence {
+	return s.PushStub(FakeResponse(body, status, headers...))
+}
+
+// PushStub 追加桩
+func (s *Sequence) PushStub(stub Stub) *Sequence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stubs = append(s.stubs, stub)
+	return s
+}
+
+// WhenEmpty 序列用完后使用的桩，未设置时序列用完会返回错误
+func (s *Sequence) WhenEmpty(stub Stub) *Sequence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.whenEmpty = stub
+	return s
+}
+
+// IsEmpty 序列是否已用完
+func (s *Sequence) IsEmpty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.stubs) == 0
+}
+
+// Stub 把序列转换为桩
+func (s *Sequence) Stub() Stub {
+	return func(req *http.Request) (*http.Response, error) {
+		s.mu.Lock()
+		if len(s.stubs) == 0 {
+			whenEmpty := s.whenEmpty
+			s.mu.Unlock()
+			if whenEmpty == nil {
+				return nil, errors.New("httpclient: fake response sequence is empty")
+			}
+			return whenEmpty(req)
+		}
+		stub := s.stubs[0]
+		s.stubs = s.stubs[1:]
+		s.mu.Unlock()
+		return stub(req)
+	}
+}
+
+// RecordedRequest Fake 记录的请求和响应
+type RecordedRequest struct {
+	Request  *http.Request
+	Body     []byte
+	Response *http.Response
+}
+
+// Method 请求方法
+func (r *RecordedRequest) Method() string {
+	return r.Request.Method
+}
+
+// URL 完整请求地址
+func (r *RecordedRequest) URL() string {
+	return r.Request.URL.String()
+}
+
+// Header 请求头
+func (r *RecordedRequest) Header(name string) string {
+	return r.Request.Header.Get(name)
+}
+
+// HasHeader 请求头是否存在，传入 value 时还要求值相等
+func (r *RecordedRequest) HasHeader(name string, value ...string) bool {
+	values, ok := r.Request.Header[http.CanonicalHeaderKey(name)]
+	if !ok {
+		return false
+	}
+	if len(value) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON 把请求体解析到 dest
+func (r *RecordedRequest) JSON(dest interface{}) error {
+	return json.Unmarshal(r.Body, dest)
+}
+
+// Form 解析表单请求体
+func (r *RecordedRequest) Form() url.Values {
+	values, _ := url.ParseQuery(string(r.Body))
+	return values
+}
+
+// fakeRule URL 模式和桩
+type fakeRule struct {
+	pattern string
+	stub    Stub
+}
+
+// Fake 测试用的桩 RoundTripper，对应 Laravel 的 Http::fake
+//
+// 请求按添加顺序匹配第一个 URL 模式，模式中的 * 匹配任意字符，模式前自动加 *，
+// 因此 "github.com/*" 匹配 "https://github.com/laravel"。没有匹配的请求返回空的 200 响应。
+type Fake struct {
+	mu       sync.Mutex
+	rules    []fakeRule
+	recorded []*RecordedRequest
+	strict   bool
+}
+
+// Fake 把工厂切换为 Fake，已创建的 PendingRequest 在下次发送时也会使用桩
+func (f *Factory) Fake() *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fake == nil {
+		f.fake = &Fake{}
+	}
+	return f.fake
+}
+
+// Stub 为匹配 pattern 的 URL 注册桩，pattern 为 "*" 时匹配全部请求
+func (f *Fake) Stub(pattern string, stub Stub) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = append(f.rules, fakeRule{pattern: pattern, stub: stub})
+	return f
+}
+
+// PreventStrayRequests 没有匹配桩的请求返回 ErrStrayRequest
+func (f *Fake) PreventStrayRequests() *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.strict = true
+	return f
+}
+
+// RoundTrip 实现 http.RoundTripper，记录请求并返回桩响应
+func (f *Fake) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		body = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	f.mu.Lock()
+	stub := f.match(req.URL.String())
+	strict := f.strict
+	f.mu.Unlock()
+
+	var resp *http.Response
+	var err error
+	switch {
+	case stub != nil:
+		resp, err = stub(req)
+	case strict:
+		err = fmt.Errorf("%w: %s %s", ErrStrayRequest, req.Method, req.URL)
+	default:
+		resp, err = FakeResponse(nil, http.StatusOK)(req)
+	}
+	if resp != nil && resp.Request == nil {
+		resp.Request = req
+	}
+
+	f.mu.Lock()
+	f.recorded = append(f.recorded, &RecordedRequest{Request: req, Body: body, Response: resp})
+	f.mu.Unlock()
+	return resp, err
+}
+
+// match 查找第一个匹配的桩
+func (f *Fake) match(rawURL string) Stub {
+	for _, rule := range f.rules {
+		if matchPattern("*"+strings.TrimPrefix(rule.pattern, "*"), rawURL) {
+			return rule.stub
+		}
+	}
+	return nil
+}
+
+// Recorded 返回满足条件的已发送请求，filter 为 nil 时返回全部
+func (f *Fake) Recorded(filter func(r *RecordedRequest) bool) []*RecordedRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []*RecordedRequest
+	for _, r := range f.recorded {
+		if filter == nil || filter(r) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// AssertSent 断言至少发送过一个满足条件的请求
+func (f *Fake) AssertSent(t TestingT, filter func(r *RecordedRequest) bool) bool {
+	t.Helper()
+	if len(f.Recorded(filter)) == 0 {
+		t.Errorf("httpclient: an expected request was not recorded")
+		return false
+	}
+	return true
+}
+
+// AssertNotSent 断言没有发送过满足条件的请求
+func (f *Fake) AssertNotSent(t TestingT, filter func(r *RecordedRequest) bool) bool {
+	t.Helper()
+	if len(f.Recorded(filter)) > 0 {
+		t.Errorf("httpclient: an unexpected request was recorded")
+		return false
+	}
+	return true
+}
+
+// AssertSentCount 断言发送的请求总数
+func (f *Fake) AssertSentCount(t TestingT, count int) bool {
+	t.Helper()
+	if n := len(f.Recorded(nil)); n != count {
+		t.Errorf("httpclient: expected [%d] requests to be sent, but [%d] were sent", count, n)
+		return false
+	}
+	return true
+}
+
+// AssertNothingSent 断言没有发送任何请求
+func (f *Fake) AssertNothingSent(t TestingT) bool {
+	t.Helper()
+	if n := len(f.Recorded(nil)); n > 0 {
+		t.Errorf("httpclient: expected no requests to be sent, but [%d] were sent", n)
+		return false
+	}
+	return true
+}
+
+// matchPattern 匹配只含 * 通配符的模式，* 可以匹配包括 "/" 在内的任意字符
+func matchPattern(pattern, value string) bool {
+	if pattern == "*" {
+		return true
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(value, part)
+		}
+		index := strings.Index(value, part)
+		if index < 0 {
+			return false
+		}
+		value = value[index+len(part):]
+	}
+	return value == ""
+}
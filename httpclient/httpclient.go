@@ -0,0 +1,119 @@
+// Package httpclient 提供 Laravel 风格的 HTTP 客户端
+//
+// Factory 持有共享的连接池（http.Transport），每次调用 Request 得到一个 PendingRequest，
+// 通过链式方法设置请求头、认证、请求体格式、超时和重试后发送。响应体在返回前完整读取，
+// 因此 Response 可以重复读取且不需要关闭。
+//
+// 主要特性：
+// - 链式构建：WithHeaders、WithToken、AsJSON、AsForm、Timeout、Retry、WithMiddleware
+// - 基于 http.RoundTripper 的中间件
+// - Pool 并发发送多个请求
+// - Fake 按 URL 模式返回桩响应，并通过 AssertSent 等方法断言已发送的请求
+//
+// 包结构：
+// - httpclient.go - Factory、默认 Factory 和中间件类型
+// - request.go - PendingRequest 请求构建和发送
+// - response.go - Response 响应和 RequestError
+// - pool.go - Pool 并发请求
+// - fake.go - Fake、Stub、Sequence 和测试断言
+//
+// 使用示例：
+//
+//	client := httpclient.NewFactory(nil)
+//
+//	resp, err := client.Request().WithToken(token).AcceptJSON().
+//		Timeout(5*time.Second).Retry(3, 100*time.Millisecond).
+//		Post(ctx, "https://api.example.com/users", map[string]string{"name": "Taylor"})
+//	if err == nil {
+//		err = resp.Throw()
+//	}
+//
+//	results := client.Pool(ctx, func(pool *httpclient.Pool) {
+//		pool.As("users", func(ctx context.Context, r *httpclient.PendingRequest) (*httpclient.Response, error) {
+//			return r.Get(ctx, "https://api.example.com/users")
+//		})
+//	})
+//
+//	// 测试中
+//	fake := client.Fake().Stub("api.example.com/*", httpclient.FakeResponse(map[string]int{"id": 1}, 200))
+//	fake.AssertSent(t, func(r *httpclient.RecordedRequest) bool {
+//		return r.Method() == "POST" && r.URL() == "https://api.example.com/users"
+//	})
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Middleware 客户端中间件，包装下一层 RoundTripper
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc 函数形式的 http.RoundTripper
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip 实现 http.RoundTripper
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Factory HTTP 客户端工厂，对应 Laravel 的 Illuminate\Http\Client\Factory
+type Factory struct {
+	mu         sync.RWMutex
+	transport  http.RoundTripper
+	middleware []Middleware
+	fake       *Fake
+}
+
+// NewFactory 创建工厂，transport 为 nil 时使用带连接池的默认 Transport
+func NewFactory(transport http.RoundTripper) *Factory {
+	if transport == nil {
+		pooled := http.DefaultTransport.(*http.Transport).Clone()
+		pooled.MaxIdleConns = 100
+		pooled.MaxIdleConnsPerHost = 32
+		pooled.IdleConnTimeout = 90 * time.Second
+		transport = pooled
+	}
+	return &Factory{transport: transport}
+}
+
+// GlobalMiddleware 添加对所有请求生效的中间件
+func (f *Factory) GlobalMiddleware(middleware ...Middleware) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.middleware = append(f.middleware, middleware...)
+	return f
+}
+
+// Request 创建新的 PendingRequest
+func (f *Factory) Request() *PendingRequest {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return newPendingRequest(f, append([]Middleware(nil), f.middleware...))
+}
+
+// roundTripper 当前使用的底层 RoundTripper，Fake 后为桩
+func (f *Factory) roundTripper() http.RoundTripper {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.fake != nil {
+		return f.fake
+	}
+	return f.transport
+}
+
+// defaultFactory 默认工厂
+var defaultFactory atomic.Value
+
+// SetDefaultFactory 设置默认工厂
+func SetDefaultFactory(factory *Factory) {
+	defaultFactory.Store(factory)
+}
+
+// DefaultFactory 获取默认工厂，未设置时返回 nil
+func DefaultFactory() *Factory {
+	factory, _ := defaultFactory.Load().(*Factory)
+	return factory
+}
@@ -0,0 +1,78 @@
+package httpclient
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// PoolRequest 池中的一个请求，request 为工厂新建的 PendingRequest
+type PoolRequest func(ctx context.Context, request *PendingRequest) (*Response, error)
+
+// PoolResult 池中请求的结果
+type PoolResult struct {
+	Response *Response
+	Err      error
+}
+
+// Pool 并发请求池，对应 Laravel 的 Http::pool
+type Pool struct {
+	names       []string
+	requests    []PoolRequest
+	concurrency int
+}
+
+// Add 添加请求，结果以添加顺序的下标（"0"、"1"……）为键
+func (p *Pool) Add(request PoolRequest) *Pool {
+	return p.As(strconv.Itoa(len(p.requests)), request)
+}
+
+// As 添加命名请求
+func (p *Pool) As(name string, request PoolRequest) *Pool {
+	p.names = append(p.names, name)
+	p.requests = append(p.requests, request)
+	return p
+}
+
+// Concurrency 设置最大并发数，0 表示不限制
+func (p *Pool) Concurrency(n int) *Pool {
+	p.concurrency = n
+	return p
+}
+
+// Pool 并发发送 build 中添加的请求，等待全部完成后按名称返回结果
+func (f *Factory) Pool(ctx context.Context, build func(pool *Pool)) map[string]PoolResult {
+	pool := &Pool{}
+	build(pool)
+
+	results := make([]PoolResult, len(pool.requests))
+	var sem chan struct{}
+	if pool.concurrency > 0 {
+		sem = make(chan struct{}, pool.concurrency)
+	}
+	var wg sync.WaitGroup
+	for i, request := range pool.requests {
+		wg.Add(1)
+		go func(i int, request PoolRequest) {
+			defer wg.Done()
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					results[i] = PoolResult{Err: ctx.Err()}
+					return
+				}
+			}
+			resp, err := request(ctx, f.Request())
+			results[i] = PoolResult{Response: resp, Err: err}
+		}(i, request)
+	}
+	wg.Wait()
+
+	named := make(map[string]PoolResult, len(results))
+	for i, name := range pool.names {
+		named[name] = results[i]
+	}
+	return named
+}
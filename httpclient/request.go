@@ -0,0 +1,327 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 请求体格式
+const (
+	bodyJSON = "json"
+	bodyForm = "form"
+	bodyRaw  = "raw"
+)
+
+// PendingRequest 待发送的请求，对应 Laravel 的 PendingRequest
+//
+// 链式方法修改并返回同一个实例，不要在多个 goroutine 中共享。
+type PendingRequest struct {
+	factory     *Factory
+	baseURL     string
+	headers     http.Header
+	query       url.Values
+	bodyFormat  string
+	body        []byte
+	contentType string
+	timeout     time.Duration
+	tries       int
+	backoff     time.Duration
+	retryWhen   func(attempt int, resp *Response, err error) bool
+	middleware  []Middleware
+}
+
+// newPendingRequest 创建请求，默认 JSON 请求体、30 秒超时
+func newPendingRequest(factory *Factory, middleware []Middleware) *PendingRequest {
+	return &PendingRequest{
+		factory:    factory,
+		headers:    make(http.Header),
+		query:      make(url.Values),
+		bodyFormat: bodyJSON,
+		timeout:    30 * time.Second,
+		tries:      1,
+		middleware: middleware,
+	}
+}
+
+// BaseURL 设置基础地址，相对 URL 基于它解析
+func (p *PendingRequest) BaseURL(baseURL string) *PendingRequest {
+	p.baseURL = baseURL
+	return p
+}
+
+// WithHeaders 添加请求头
+func (p *PendingRequest) WithHeaders(headers map[string]string) *PendingRequest {
+	for name, value := range headers {
+		p.headers.Set(name, value)
+	}
+	return p
+}
+
+// WithHeader 添加单个请求头
+func (p *PendingRequest) WithHeader(name, value string) *PendingRequest {
+	p.headers.Set(name, value)
+	return p
+}
+
+// WithToken 设置 Authorization 头，tokenType 默认为 Bearer
+func (p *PendingRequest) WithToken(token string, tokenType ...string) *PendingRequest {
+	scheme := "Bearer"
+	if len(tokenType) > 0 && tokenType[0] != "" {
+		scheme = tokenType[0]
+	}
+	p.headers.Set("Authorization", scheme+" "+token)
+	return p
+}
+
+// WithBasicAuth 设置 HTTP Basic 认证
+func (p *PendingRequest) WithBasicAuth(username, password string) *PendingRequest {
+	p.headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	return p
+}
+
+// WithQuery 添加查询参数
+func (p *PendingRequest) WithQuery(query map[string]string) *PendingRequest {
+	for name, value := range query {
+		p.query.Set(name, value)
+	}
+	return p
+}
+
+// Accept 设置 Accept 头
+func (p *PendingRequest) Accept(contentType string) *PendingRequest {
+	p.headers.Set("Accept", contentType)
+	return p
+}
+
+// AcceptJSON 期望 JSON 响应
+func (p *PendingRequest) AcceptJSON() *PendingRequest {
+	return p.Accept("application/json")
+}
+
+// AsJSON 以 JSON 编码请求数据（默认）
+func (p *PendingRequest) AsJSON() *PendingRequest {
+	p.bodyFormat = bodyJSON
+	return p
+}
+
+// AsForm 以 application/x-www-form-urlencoded 编码请求数据
+func (p *PendingRequest) AsForm() *PendingRequest {
+	p.bodyFormat = bodyForm
+	return p
+}
+
+// WithBody 直接使用原始请求体，之后传给 Post 等方法的数据被忽略
+func (p *PendingRequest) WithBody(body []byte, contentType string) *PendingRequest {
+	p.bodyFormat = bodyRaw
+	p.body = body
+	p.contentType = contentType
+	return p
+}
+
+// Timeout 设置单次尝试的超时，0 表示不限制
+func (p *PendingRequest) Timeout(timeout time.Duration) *PendingRequest {
+	p.timeout = timeout
+	return p
+}
+
+// Retry 失败时重试，times 为总尝试次数，两次尝试之间等待 backoff
+//
+// 默认在连接错误和 4xx、5xx 响应时重试，可以通过 RetryWhen 修改条件。
+func (p *PendingRequest) Retry(times int, backoff time.Duration) *PendingRequest {
+	if times < 1 {
+		times = 1
+	}
+	p.tries = times
+	p.backoff = backoff
+	return p
+}
+
+// RetryWhen 设置是否重试的判断，attempt 从 1 开始
+func (p *PendingRequest) RetryWhen(when func(attempt int, resp *Response, err error) bool) *PendingRequest {
+	p.retryWhen = when
+	return p
+}
+
+// WithMiddleware 添加中间件，先添加的在外层
+func (p *PendingRequest) WithMiddleware(middleware ...Middleware) *PendingRequest {
+	p.middleware = append(p.middleware, middleware...)
+	return p
+}
+
+// Get 发送 GET 请求
+func (p *PendingRequest) Get(ctx context.Context, rawURL string, query ...map[string]string) (*Response, error) {
+	for _, q := range query {
+		p.WithQuery(q)
+	}
+	return p.Send(ctx, http.MethodGet, rawURL, nil)
+}
+
+// Head 发送 HEAD 请求
+func (p *PendingRequest) Head(ctx context.Context, rawURL string, query ...map[string]string) (*Response, error) {
+	for _, q := range query {
+		p.WithQuery(q)
+	}
+	return p.Send(ctx, http.MethodHead, rawURL, nil)
+}
+
+// Post 发送 POST 请求
+func (p *PendingRequest) Post(ctx context.Context, rawURL string, data interface{}) (*Response, error) {
+	return p.Send(ctx, http.MethodPost, rawURL, data)
+}
+
+// Put 发送 PUT 请求
+func (p *PendingRequest) Put(ctx context.Context, rawURL string, data interface{}) (*Response, error) {
+	return p.Send(ctx, http.MethodPut, rawURL, data)
+}
+
+// Patch 发送 PATCH 请求
+func (p *PendingRequest) Patch(ctx context.Context, rawURL string, data interface{}) (*Response, error) {
+	return p.Send(ctx, http.MethodPatch, rawURL, data)
+}
+
+// Delete 发送 DELETE 请求
+func (p *PendingRequest) Delete(ctx context.Context, rawURL string, data ...interface{}) (*Response, error) {
+	var body interface{}
+	if len(data) > 0 {
+		body = data[0]
+	}
+	return p.Send(ctx, http.MethodDelete, rawURL, body)
+}
+
+// Send 发送请求，data 为 nil 时不发送请求体
+//
+// 返回的错误只表示请求没有得到响应（连接失败、超时等），4xx、5xx 响应通过 Response.Throw 转换为错误。
+func (p *PendingRequest) Send(ctx context.Context, method, rawURL string, data interface{}) (*Response, error) {
+	target, err := p.resolveURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	body, contentType, err := p.encode(data)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Transport: p.transport()}
+
+	var resp *Response
+	for attempt := 1; ; attempt++ {
+		resp, err = p.attempt(ctx, client, method, target, body, contentType)
+		if attempt >= p.tries || !p.shouldRetry(attempt, resp, err) {
+			return resp, err
+		}
+		if p.backoff > 0 {
+			timer := time.NewTimer(p.backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return resp, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// attempt 发送一次请求并读取完整响应体
+func (p *PendingRequest) attempt(ctx context.Context, client *http.Client, method, target string, body []byte, contentType string) (*Response, error) {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range p.headers {
+		req.Header[name] = append([]string(nil), values...)
+	}
+	if contentType != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	raw, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer raw.Body.Close()
+	data, err := io.ReadAll(raw.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{raw: raw, body: data}, nil
+}
+
+// shouldRetry 是否重试
+func (p *PendingRequest) shouldRetry(attempt int, resp *Response, err error) bool {
+	if p.retryWhen != nil {
+		return p.retryWhen(attempt, resp, err)
+	}
+	return err != nil || resp.Failed()
+}
+
+// transport 组装中间件和底层 RoundTripper
+func (p *PendingRequest) transport() http.RoundTripper {
+	transport := p.factory.roundTripper()
+	for i := len(p.middleware) - 1; i >= 0; i-- {
+		transport = p.middleware[i](transport)
+	}
+	return transport
+}
+
+// resolveURL 基于 BaseURL 解析地址并合并查询参数
+func (p *PendingRequest) resolveURL(rawURL string) (string, error) {
+	if p.baseURL != "" && !strings.Contains(rawURL, "://") {
+		rawURL = strings.TrimRight(p.baseURL, "/") + "/" + strings.TrimLeft(rawURL, "/")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if len(p.query) > 0 {
+		query := u.Query()
+		for name, values := range p.query {
+			query[name] = values
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.String(), nil
+}
+
+// encode 按请求体格式编码数据
+func (p *PendingRequest) encode(data interface{}) ([]byte, string, error) {
+	if p.bodyFormat == bodyRaw {
+		return p.body, p.contentType, nil
+	}
+	if data == nil {
+		return nil, "", nil
+	}
+	if p.bodyFormat == bodyForm {
+		switch values := data.(type) {
+		case url.Values:
+			return []byte(values.Encode()), "application/x-www-form-urlencoded", nil
+		case map[string]string:
+			form := make(url.Values, len(values))
+			for name, value := range values {
+				form.Set(name, value)
+			}
+			return []byte(form.Encode()), "application/x-www-form-urlencoded", nil
+		default:
+			return nil, "", fmt.Errorf("httpclient: form data must be url.Values or map[string]string, got %T", data)
+		}
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, "application/json", nil
+}
@@ -0,0 +1,100 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Response 已读取完整响应体的响应
+type Response struct {
+	raw  *http.Response
+	body []byte
+}
+
+// Raw 底层 http.Response，Body 已读取并关闭
+func (r *Response) Raw() *http.Response {
+	return r.raw
+}
+
+// Status 状态码
+func (r *Response) Status() int {
+	return r.raw.StatusCode
+}
+
+// Body 响应体字符串
+func (r *Response) Body() string {
+	return string(r.body)
+}
+
+// Bytes 响应体字节
+func (r *Response) Bytes() []byte {
+	return r.body
+}
+
+// JSON 把响应体解析到 dest
+func (r *Response) JSON(dest interface{}) error {
+	return json.Unmarshal(r.body, dest)
+}
+
+// Header 获取响应头
+func (r *Response) Header(name string) string {
+	return r.raw.Header.Get(name)
+}
+
+// Headers 获取全部响应头
+func (r *Response) Headers() http.Header {
+	return r.raw.Header
+}
+
+// OK 状态码是否为 200
+func (r *Response) OK() bool {
+	return r.Status() == http.StatusOK
+}
+
+// Successful 状态码是否为 2xx
+func (r *Response) Successful() bool {
+	return r.Status() >= 200 && r.Status() < 300
+}
+
+// Redirect 状态码是否为 3xx
+func (r *Response) Redirect() bool {
+	return r.Status() >= 300 && r.Status() < 400
+}
+
+// Failed 状态码是否为 4xx 或 5xx
+func (r *Response) Failed() bool {
+	return r.ClientError() || r.ServerError()
+}
+
+// ClientError 状态码是否为 4xx
+func (r *Response) ClientError() bool {
+	return r.Status() >= 400 && r.Status() < 500
+}
+
+// ServerError 状态码是否为 5xx
+func (r *Response) ServerError() bool {
+	return r.Status() >= 500
+}
+
+// Throw 响应失败时返回 *RequestError，否则返回 nil
+func (r *Response) Throw() error {
+	if r.Failed() {
+		return &RequestError{Response: r}
+	}
+	return nil
+}
+
+// RequestError 4xx、5xx 响应转换的错误，对应 Laravel 的 RequestException
+type RequestError struct {
+	Response *Response
+}
+
+// Error 实现 error 接口，包含截断后的响应体
+func (e *RequestError) Error() string {
+	body := e.Response.Body()
+	if len(body) > 200 {
+		body = body[:200] + "..."
+	}
+	return fmt.Sprintf("httpclient: HTTP request returned status code %d: %s", e.Response.Status(), body)
+}
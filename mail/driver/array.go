@@ -0,0 +1,48 @@
+package driver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/mail"
+)
+
+// ArrayTransport 将邮件保存在内存中，用于测试
+type ArrayTransport struct {
+	mu       sync.Mutex
+	messages []*mail.Message
+}
+
+var _ mail.Transport = (*ArrayTransport)(nil)
+
+// NewArrayTransport 创建内存传输
+func NewArrayTransport() *ArrayTransport {
+	return &ArrayTransport{}
+}
+
+// String 传输名称
+func (t *ArrayTransport) String() string {
+	return "array"
+}
+
+// Send 保存邮件
+func (t *ArrayTransport) Send(ctx context.Context, message *mail.Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = append(t.messages, message)
+	return nil
+}
+
+// Messages 已发送的邮件
+func (t *ArrayTransport) Messages() []*mail.Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*mail.Message{}, t.messages...)
+}
+
+// Flush 清空已发送的邮件
+func (t *ArrayTransport) Flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = nil
+}
@@ -0,0 +1,102 @@
+// Package driver 提供 mail 包协议的参考实现
+//
+// 包含 smtp、log、array、ses、mailgun 五种传输，以及 Mailer 和 Manager 的实现。
+// ses 传输只依赖本包定义的 SESClient 接口，由使用方为 aws-sdk-go-v2 等客户端编写适配器；
+// smtp 和 mailgun 传输只使用标准库。
+//
+// 包结构：
+// - driver.go - 配置读取辅助函数
+// - mailer.go - Mailer 实现，负责渲染和排队
+// - manager.go - Manager 实现
+// - smtp.go - SMTPTransport SMTP 传输
+// - log.go - LogTransport 日志传输
+// - array.go - ArrayTransport 内存传输
+// - ses.go - SESTransport SES 传输和 SESClient 接口
+// - mailgun.go - MailgunTransport Mailgun HTTP API 传输
+//
+// 配置示例：
+//
+//	manager := driver.NewManager(map[string]map[string]interface{}{
+//		"smtp":    {"transport": "smtp", "host": "smtp.mailgun.org", "port": 587, "username": user, "password": pass},
+//		"ses":     {"transport": "ses", "client": sesAdapter, "configuration_set": "transactional"},
+//		"mailgun": {"transport": "mailgun", "domain": "mg.example.com", "secret": key},
+//		"log":     {"transport": "log", "logger": logger},
+//		"array":   {"transport": "array"},
+//	}, "smtp")
+//	manager.AlwaysFrom(mail.NewAddress("hello@example.com", "Example"))
+//	manager.SetViews(view.NewTemplateFactory(os.DirFS("resources/views")))
+//	mail.SetDefaultManager(manager)
+package driver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/mail"
+)
+
+// stringOption 读取字符串配置
+func stringOption(config map[string]interface{}, key, fallback string) string {
+	if value, ok := config[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// intOption 读取整数配置
+func intOption(config map[string]interface{}, key string, fallback int) int {
+	switch value := config[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	}
+	return fallback
+}
+
+// durationOption 读取时长配置，整数按秒解释（与 Laravel 配置一致）
+func durationOption(config map[string]interface{}, key string, fallback time.Duration) time.Duration {
+	switch value := config[key].(type) {
+	case time.Duration:
+		return value
+	case int:
+		return time.Duration(value) * time.Second
+	case int64:
+		return time.Duration(value) * time.Second
+	case float64:
+		return time.Duration(value * float64(time.Second))
+	case string:
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+// addressOption 读取地址配置，支持 mail.Address 和 "Name <email>" 字符串
+func addressOption(config map[string]interface{}, key string) (mail.Address, error) {
+	switch value := config[key].(type) {
+	case nil:
+		return mail.Address{}, nil
+	case mail.Address:
+		return value, nil
+	case string:
+		if value == "" {
+			return mail.Address{}, nil
+		}
+		return mail.ParseAddress(value)
+	}
+	return mail.Address{}, fmt.Errorf("mail: config %q must be mail.Address or string, got %T", key, config[key])
+}
+
+// clientOption 读取客户端对象配置
+func clientOption[T any](config map[string]interface{}, key string) (T, error) {
+	client, ok := config[key].(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("mail: transport config %q must be %T, got %T", key, zero, config[key])
+	}
+	return client, nil
+}
@@ -0,0 +1,55 @@
+package driver
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/mail"
+)
+
+// Logger 日志接口，application.LoggerInterface 满足此接口
+type Logger interface {
+	Debug(message string, context map[string]interface{}) error
+}
+
+// stdLogger 使用标准库 log 输出
+type stdLogger struct{}
+
+func (stdLogger) Debug(message string, context map[string]interface{}) error {
+	log.Printf("%s\n%s", message, context["message"])
+	return nil
+}
+
+// LogTransport 将完整的 MIME 邮件写入日志，不实际发送
+type LogTransport struct {
+	logger Logger
+}
+
+var _ mail.Transport = (*LogTransport)(nil)
+
+// NewLogTransport 创建日志传输，配置项 logger 未设置时使用标准库 log
+func NewLogTransport(config map[string]interface{}) *LogTransport {
+	logger, ok := config["logger"].(Logger)
+	if !ok {
+		logger = stdLogger{}
+	}
+	return &LogTransport{logger: logger}
+}
+
+// String 传输名称
+func (t *LogTransport) String() string {
+	return "log"
+}
+
+// Send 写入日志
+func (t *LogTransport) Send(ctx context.Context, message *mail.Message) error {
+	raw, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+	return t.logger.Debug("mail: "+message.Subject, map[string]interface{}{
+		"to":      strings.Join(message.Recipients(), ", "),
+		"message": string(raw),
+	})
+}
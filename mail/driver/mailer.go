@@ -0,0 +1,196 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cnote0/laraveldoc/mail"
+	"github.com/cnote0/laraveldoc/queue"
+	"github.com/cnote0/laraveldoc/view"
+)
+
+// errNoViews 邮件使用了视图但没有设置视图工厂
+var errNoViews = errors.New("mail: mailable uses a view but no view factory is configured")
+
+// Mailer Mailer 实现
+type Mailer struct {
+	name      string
+	transport mail.Transport
+	views     view.Factory
+	queue     queue.Manager
+	from      mail.Address
+	replyTo   mail.Address
+}
+
+var _ mail.Mailer = (*Mailer)(nil)
+
+// NewMailer 创建 Mailer，views 为 nil 时只能发送 HTMLString、TextString 内容
+func NewMailer(name string, transport mail.Transport, views view.Factory) *Mailer {
+	return &Mailer{name: name, transport: transport, views: views}
+}
+
+// AlwaysFrom 设置全局发件人，Envelope 未指定发件人时使用
+func (m *Mailer) AlwaysFrom(address mail.Address) *Mailer {
+	m.from = address
+	return m
+}
+
+// AlwaysReplyTo 设置全局回复地址，Envelope 未指定回复地址时使用
+func (m *Mailer) AlwaysReplyTo(address mail.Address) *Mailer {
+	m.replyTo = address
+	return m
+}
+
+// SetQueue 设置投递排队邮件使用的队列管理器，未设置时使用 queue.DefaultManager
+func (m *Mailer) SetQueue(manager queue.Manager) *Mailer {
+	m.queue = manager
+	return m
+}
+
+// Name Mailer 名称
+func (m *Mailer) Name() string {
+	return m.name
+}
+
+// Transport 获取传输
+func (m *Mailer) Transport() mail.Transport {
+	return m.transport
+}
+
+// To 指定收件人
+func (m *Mailer) To(addresses ...mail.Address) *mail.PendingMail {
+	return mail.NewPendingMail(m).To(addresses...)
+}
+
+// Cc 指定抄送
+func (m *Mailer) Cc(addresses ...mail.Address) *mail.PendingMail {
+	return mail.NewPendingMail(m).Cc(addresses...)
+}
+
+// Bcc 指定密送
+func (m *Mailer) Bcc(addresses ...mail.Address) *mail.PendingMail {
+	return mail.NewPendingMail(m).Bcc(addresses...)
+}
+
+// Send 发送邮件，实现 queue.ShouldQueue 的邮件投递到队列
+func (m *Mailer) Send(ctx context.Context, mailable mail.Mailable) error {
+	return mail.NewPendingMail(m).Send(ctx, mailable)
+}
+
+// SendNow 立即发送邮件
+func (m *Mailer) SendNow(ctx context.Context, mailable mail.Mailable) error {
+	return mail.NewPendingMail(m).SendNow(ctx, mailable)
+}
+
+// Queue 将邮件投递到队列
+func (m *Mailer) Queue(ctx context.Context, mailable mail.Mailable) (string, error) {
+	return mail.NewPendingMail(m).Queue(ctx, mailable)
+}
+
+// Later 延迟投递邮件
+func (m *Mailer) Later(ctx context.Context, delay time.Duration, mailable mail.Mailable) (string, error) {
+	return mail.NewPendingMail(m).Later(ctx, delay, mailable)
+}
+
+// Render 渲染邮件
+func (m *Mailer) Render(ctx context.Context, mailable mail.Mailable) (*mail.Message, error) {
+	envelope := mailable.Envelope()
+	message := &mail.Message{
+		From:     envelope.From,
+		To:       append([]mail.Address{}, envelope.To...),
+		Cc:       append([]mail.Address{}, envelope.Cc...),
+		Bcc:      append([]mail.Address{}, envelope.Bcc...),
+		ReplyTo:  append([]mail.Address{}, envelope.ReplyTo...),
+		Subject:  envelope.Subject,
+		Headers:  envelope.Headers,
+		Tags:     envelope.Tags,
+		Metadata: envelope.Metadata,
+	}
+	if message.From.IsZero() {
+		message.From = m.from
+	}
+	if len(message.ReplyTo) == 0 && !m.replyTo.IsZero() {
+		message.ReplyTo = []mail.Address{m.replyTo}
+	}
+
+	content := mailable.Content()
+	message.HTML, message.Text = content.HTMLString, content.TextString
+	if message.HTML == "" && content.View != "" {
+		html, err := m.render(content.View, content.With)
+		if err != nil {
+			return nil, err
+		}
+		message.HTML = html
+	}
+	if message.Text == "" && content.Text != "" {
+		text, err := m.render(content.Text, content.With)
+		if err != nil {
+			return nil, err
+		}
+		message.Text = text
+	}
+	if content.Markdown != "" && (message.HTML == "" || message.Text == "") {
+		source, err := m.render(content.Markdown, content.With)
+		if err != nil {
+			return nil, err
+		}
+		if message.HTML == "" {
+			if message.HTML, err = mail.RenderMarkdown(source, message.Subject); err != nil {
+				return nil, err
+			}
+		}
+		if message.Text == "" {
+			message.Text = source
+		}
+	}
+
+	for _, attachment := range mailable.Attachments() {
+		loaded, err := attachment.Load()
+		if err != nil {
+			return nil, err
+		}
+		message.Attachments = append(message.Attachments, loaded)
+	}
+	return message, nil
+}
+
+// render 渲染视图
+func (m *Mailer) render(name string, data map[string]interface{}) (string, error) {
+	if m.views == nil {
+		return "", errNoViews
+	}
+	return m.views.Render(name, data)
+}
+
+// SendMessage 通过传输发送邮件
+func (m *Mailer) SendMessage(ctx context.Context, message *mail.Message) error {
+	if message.From.IsZero() {
+		message.From = m.from
+	}
+	return m.transport.Send(ctx, message)
+}
+
+// QueueMessage 将已渲染的邮件投递到队列
+func (m *Mailer) QueueMessage(ctx context.Context, message *mail.Message, options mail.QueueOptions) (string, error) {
+	manager := m.queue
+	if manager == nil {
+		manager = queue.DefaultManager()
+	}
+	if manager == nil {
+		return "", queue.ErrNoManager
+	}
+	var names []string
+	if options.Connection != "" {
+		names = append(names, options.Connection)
+	}
+	q, err := manager.Connection(names...)
+	if err != nil {
+		return "", err
+	}
+	job := &mail.SendQueuedMessage{Mailer: m.name, Message: message}
+	if options.Delay > 0 {
+		return q.Later(ctx, options.Delay, job, options.Queue)
+	}
+	return q.Push(ctx, job, options.Queue)
+}
@@ -0,0 +1,105 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/mail"
+)
+
+// MailgunTransport Mailgun HTTP API 传输，使用 messages.mime 接口发送完整的 MIME 邮件
+type MailgunTransport struct {
+	domain   string
+	secret   string
+	endpoint string
+	client   *http.Client
+}
+
+var _ mail.Transport = (*MailgunTransport)(nil)
+
+// NewMailgunTransport 创建 Mailgun 传输
+//
+// 配置项：domain、secret、endpoint（默认 api.mailgun.net，欧洲区为 api.eu.mailgun.net）、
+// scheme（默认 https）、client（*http.Client）、timeout（默认 30 秒）。
+func NewMailgunTransport(config map[string]interface{}) (*MailgunTransport, error) {
+	domain, secret := stringOption(config, "domain", ""), stringOption(config, "secret", "")
+	if domain == "" || secret == "" {
+		return nil, fmt.Errorf("mail: mailgun transport requires domain and secret")
+	}
+	client, ok := config["client"].(*http.Client)
+	if !ok {
+		client = &http.Client{Timeout: durationOption(config, "timeout", 30*time.Second)}
+	}
+	return &MailgunTransport{
+		domain:   domain,
+		secret:   secret,
+		endpoint: stringOption(config, "scheme", "https") + "://" + stringOption(config, "endpoint", "api.mailgun.net"),
+		client:   client,
+	}, nil
+}
+
+// String 传输名称
+func (t *MailgunTransport) String() string {
+	return "mailgun"
+}
+
+// Send 发送邮件
+func (t *MailgunTransport) Send(ctx context.Context, message *mail.Message) error {
+	raw, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for _, recipient := range message.Recipients() {
+		if err := form.WriteField("to", recipient); err != nil {
+			return err
+		}
+	}
+	for _, tag := range message.Tags {
+		if err := form.WriteField("o:tag", tag); err != nil {
+			return err
+		}
+	}
+	for key, value := range message.Metadata {
+		if err := form.WriteField("v:"+key, value); err != nil {
+			return err
+		}
+	}
+	file, err := form.CreateFormFile("message", "message.mime")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(raw); err != nil {
+		return err
+	}
+	if err := form.Close(); err != nil {
+		return err
+	}
+
+	url := strings.TrimRight(t.endpoint, "/") + "/v3/" + t.domain + "/messages.mime"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("api", t.secret)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("mail: mailgun request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("mail: mailgun responded %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
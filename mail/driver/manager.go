@@ -0,0 +1,156 @@
+package driver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/mail"
+	"github.com/cnote0/laraveldoc/queue"
+	"github.com/cnote0/laraveldoc/view"
+)
+
+// Manager 邮件管理器实现
+//
+// Mailer 在首次使用时创建并缓存，内置 smtp、log、array、ses、mailgun 传输。
+// 每个 mailer 的配置可以包含 from 和 reply_to，覆盖 AlwaysFrom、AlwaysReplyTo 设置的全局地址。
+type Manager struct {
+	mu            sync.RWMutex
+	config        map[string]map[string]interface{}
+	defaultMailer string
+	mailers       map[string]*Mailer
+	factories     map[string]func(config map[string]interface{}) (mail.Transport, error)
+	views         view.Factory
+	queue         queue.Manager
+	from          mail.Address
+	replyTo       mail.Address
+}
+
+var _ mail.Manager = (*Manager)(nil)
+
+// NewManager 创建邮件管理器，config 为 mailer 名称到配置的映射
+func NewManager(config map[string]map[string]interface{}, defaultMailer string) *Manager {
+	m := &Manager{
+		config:        config,
+		defaultMailer: defaultMailer,
+		mailers:       make(map[string]*Mailer),
+		factories:     make(map[string]func(config map[string]interface{}) (mail.Transport, error)),
+	}
+	m.Extend(mail.TransportSMTP, func(config map[string]interface{}) (mail.Transport, error) {
+		return NewSMTPTransport(config)
+	})
+	m.Extend(mail.TransportLog, func(config map[string]interface{}) (mail.Transport, error) {
+		return NewLogTransport(config), nil
+	})
+	m.Extend(mail.TransportArray, func(config map[string]interface{}) (mail.Transport, error) {
+		return NewArrayTransport(), nil
+	})
+	m.Extend(mail.TransportSES, func(config map[string]interface{}) (mail.Transport, error) {
+		return NewSESTransport(config)
+	})
+	m.Extend(mail.TransportMailgun, func(config map[string]interface{}) (mail.Transport, error) {
+		return NewMailgunTransport(config)
+	})
+	return m
+}
+
+// Mailer 获取 Mailer，不传名称时使用默认 Mailer
+func (m *Manager) Mailer(name ...string) (mail.Mailer, error) {
+	mailerName := m.GetDefaultMailer()
+	if len(name) > 0 && name[0] != "" {
+		mailerName = name[0]
+	}
+
+	m.mu.RLock()
+	mailer, ok := m.mailers[mailerName]
+	m.mu.RUnlock()
+	if ok {
+		return mailer, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if mailer, ok := m.mailers[mailerName]; ok {
+		return mailer, nil
+	}
+	config, ok := m.config[mailerName]
+	if !ok {
+		return nil, fmt.Errorf("mail: mailer [%s] is not defined", mailerName)
+	}
+	transportName, _ := config["transport"].(string)
+	factory, ok := m.factories[transportName]
+	if !ok {
+		return nil, fmt.Errorf("mail: transport [%s] is not supported", transportName)
+	}
+	transport, err := factory(config)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := addressOption(config, "from")
+	if err != nil {
+		return nil, err
+	}
+	if from.IsZero() {
+		from = m.from
+	}
+	replyTo, err := addressOption(config, "reply_to")
+	if err != nil {
+		return nil, err
+	}
+	if replyTo.IsZero() {
+		replyTo = m.replyTo
+	}
+
+	mailer = NewMailer(mailerName, transport, m.views).AlwaysFrom(from).AlwaysReplyTo(replyTo).SetQueue(m.queue)
+	m.mailers[mailerName] = mailer
+	return mailer, nil
+}
+
+// GetDefaultMailer 获取默认 Mailer 名称
+func (m *Manager) GetDefaultMailer() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultMailer
+}
+
+// SetDefaultMailer 设置默认 Mailer 名称
+func (m *Manager) SetDefaultMailer(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultMailer = name
+}
+
+// Extend 注册传输
+func (m *Manager) Extend(transport string, factory func(config map[string]interface{}) (mail.Transport, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.factories[transport] = factory
+}
+
+// SetViews 设置视图工厂，需要在获取 Mailer 前调用
+func (m *Manager) SetViews(views view.Factory) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.views = views
+}
+
+// SetQueue 设置投递排队邮件使用的队列管理器，需要在获取 Mailer 前调用
+func (m *Manager) SetQueue(manager queue.Manager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queue = manager
+}
+
+// AlwaysFrom 设置全局发件人，需要在获取 Mailer 前调用
+func (m *Manager) AlwaysFrom(address mail.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.from = address
+}
+
+// AlwaysReplyTo 设置全局回复地址，需要在获取 Mailer 前调用
+func (m *Manager) AlwaysReplyTo(address mail.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.replyTo = address
+}
@@ -0,0 +1,74 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/cnote0/laraveldoc/mail"
+)
+
+// SESRawEmail SES SendRawEmail 请求参数
+type SESRawEmail struct {
+	// Source 发件人地址
+	Source string
+
+	// Destinations 所有收件人地址，包括 Bcc
+	Destinations []string
+
+	// Data 完整的 MIME 邮件
+	Data []byte
+
+	// Tags 消息标签，来自 Message.Metadata
+	Tags map[string]string
+
+	// ConfigurationSet 配置集名称
+	ConfigurationSet string
+}
+
+// SESClient Amazon SES 客户端接口
+//
+// 使用方为 aws-sdk-go-v2 的 ses 或 sesv2 客户端编写适配器。
+type SESClient interface {
+	// SendRawEmail 发送原始邮件，返回消息 ID
+	SendRawEmail(ctx context.Context, input SESRawEmail) (string, error)
+}
+
+// SESTransport Amazon SES 传输
+type SESTransport struct {
+	client           SESClient
+	configurationSet string
+}
+
+var _ mail.Transport = (*SESTransport)(nil)
+
+// NewSESTransport 创建 SES 传输，配置项：client（SESClient）、configuration_set
+func NewSESTransport(config map[string]interface{}) (*SESTransport, error) {
+	client, err := clientOption[SESClient](config, "client")
+	if err != nil {
+		return nil, err
+	}
+	return &SESTransport{
+		client:           client,
+		configurationSet: stringOption(config, "configuration_set", ""),
+	}, nil
+}
+
+// String 传输名称
+func (t *SESTransport) String() string {
+	return "ses"
+}
+
+// Send 发送邮件
+func (t *SESTransport) Send(ctx context.Context, message *mail.Message) error {
+	raw, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+	_, err = t.client.SendRawEmail(ctx, SESRawEmail{
+		Source:           message.From.String(),
+		Destinations:     message.Recipients(),
+		Data:             raw,
+		Tags:             message.Metadata,
+		ConfigurationSet: t.configurationSet,
+	})
+	return err
+}
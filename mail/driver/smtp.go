@@ -0,0 +1,129 @@
+package driver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/cnote0/laraveldoc/mail"
+)
+
+// SMTPTransport SMTP 传输
+//
+// scheme 为 "smtps" 时使用隐式 TLS（通常为 465 端口），否则在服务器支持时使用 STARTTLS。
+type SMTPTransport struct {
+	host        string
+	port        int
+	scheme      string
+	username    string
+	password    string
+	localDomain string
+	timeout     time.Duration
+	tlsConfig   *tls.Config
+}
+
+var _ mail.Transport = (*SMTPTransport)(nil)
+
+// NewSMTPTransport 创建 SMTP 传输
+//
+// 配置项：host、port（默认 587）、scheme（smtp 或 smtps）、username、password、
+// local_domain（HELO 使用的域名）、timeout（默认 30 秒）、tls（*tls.Config）。
+func NewSMTPTransport(config map[string]interface{}) (*SMTPTransport, error) {
+	host := stringOption(config, "host", "")
+	if host == "" {
+		return nil, fmt.Errorf("mail: smtp transport requires host")
+	}
+	t := &SMTPTransport{
+		host:        host,
+		port:        intOption(config, "port", 587),
+		scheme:      stringOption(config, "scheme", "smtp"),
+		username:    stringOption(config, "username", ""),
+		password:    stringOption(config, "password", ""),
+		localDomain: stringOption(config, "local_domain", "localhost"),
+		timeout:     durationOption(config, "timeout", 30*time.Second),
+	}
+	if tlsConfig, ok := config["tls"].(*tls.Config); ok {
+		t.tlsConfig = tlsConfig
+	} else {
+		t.tlsConfig = &tls.Config{ServerName: host}
+	}
+	return t, nil
+}
+
+// String 传输名称
+func (t *SMTPTransport) String() string {
+	return t.scheme + "://" + net.JoinHostPort(t.host, strconv.Itoa(t.port))
+}
+
+// Send 发送邮件
+func (t *SMTPTransport) Send(ctx context.Context, message *mail.Message) error {
+	raw, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	address := net.JoinHostPort(t.host, strconv.Itoa(t.port))
+	var conn net.Conn
+	if t.scheme == "smtps" {
+		dialer := &tls.Dialer{Config: t.tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return fmt.Errorf("mail: smtp dial %s: %w", address, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("mail: smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	if err := client.Hello(t.localDomain); err != nil {
+		return err
+	}
+	if t.scheme != "smtps" {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(t.tlsConfig); err != nil {
+				return fmt.Errorf("mail: smtp starttls: %w", err)
+			}
+		}
+	}
+	if t.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", t.username, t.password, t.host)); err != nil {
+			return fmt.Errorf("mail: smtp auth: %w", err)
+		}
+	}
+	if err := client.Mail(message.From.Email); err != nil {
+		return err
+	}
+	for _, recipient := range message.Recipients() {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("mail: smtp rcpt %s: %w", recipient, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(raw); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,411 @@
+package mail
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// TestingT Fake 使用的测试接口，*testing.T 和 *testing.B 都满足
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// SentMail FakeMail 记录的邮件
+//
+// 通过 SendMessage、QueueMessage 发送的已渲染邮件记录在 Message 中，Mailable 为 nil。
+type SentMail struct {
+	Mailable Mailable
+	Message  *Message
+
+	// Mailer 发送使用的 Mailer 名称
+	Mailer string
+
+	// To、Cc、Bcc 信封和 PendingMail 指定的收件人
+	To  []Address
+	Cc  []Address
+	Bcc []Address
+
+	// Queued 邮件被投递到队列，Options 为排队设置
+	Queued  bool
+	Options QueueOptions
+}
+
+// HasTo 收件人中是否包含 email
+func (m *SentMail) HasTo(email string) bool {
+	return hasAddress(m.To, email)
+}
+
+// HasCc 抄送中是否包含 email
+func (m *SentMail) HasCc(email string) bool {
+	return hasAddress(m.Cc, email)
+}
+
+// HasBcc 密送中是否包含 email
+func (m *SentMail) HasBcc(email string) bool {
+	return hasAddress(m.Bcc, email)
+}
+
+// FakeMail 测试用的假邮件管理器，对应 Laravel 的 Mail::fake
+//
+// 邮件不渲染也不发送，只记录 Mailable 和收件人。
+// 实现 queue.ShouldQueue 的邮件调用 Send 时记为已排队，与 Laravel 一致。
+type FakeMail struct {
+	t TestingT
+
+	mu            sync.Mutex
+	defaultMailer string
+	sent          []*SentMail
+}
+
+// Fake 把默认邮件管理器替换为假邮件管理器，测试结束时恢复原管理器
+//
+// 访问器为 "mail.manager" 的门面同时替换为假邮件管理器，"mailer" 的门面替换为默认的 FakeMailer。
+//
+// 使用示例：
+//
+//	func TestOrderShipment(t *testing.T) {
+//		fake := mail.Fake(t)
+//		// 执行发货
+//		fake.AssertQueued(&OrderShipped{}, func(m *mail.SentMail) bool {
+//			return m.HasTo("taylor@example.com")
+//		})
+//		fake.AssertNotSent(&OrderCancelled{})
+//	}
+func Fake(t TestingT) *FakeMail {
+	t.Helper()
+	fake := NewFakeMail(t)
+	previous := DefaultManager()
+	if previous != nil {
+		fake.defaultMailer = previous.GetDefaultMailer()
+	}
+	SetDefaultManager(fake)
+	t.Cleanup(func() {
+		SetDefaultManager(previous)
+	})
+	application.SwapFacade(t, "mail.manager", fake)
+	application.SwapFacade(t, "mailer", &FakeMailer{fake: fake, name: fake.defaultMailer})
+	return fake
+}
+
+// NewFakeMail 创建假邮件管理器，默认 Mailer 为 smtp
+func NewFakeMail(t TestingT) *FakeMail {
+	return &FakeMail{t: t, defaultMailer: TransportSMTP}
+}
+
+// Mailer 获取记录到 FakeMail 的 Mailer，不传名称时使用默认 Mailer
+func (f *FakeMail) Mailer(name ...string) (Mailer, error) {
+	mailer := f.GetDefaultMailer()
+	if len(name) > 0 && name[0] != "" {
+		mailer = name[0]
+	}
+	return &FakeMailer{fake: f, name: mailer}, nil
+}
+
+// GetDefaultMailer 获取默认 Mailer 名称
+func (f *FakeMail) GetDefaultMailer() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.defaultMailer
+}
+
+// SetDefaultMailer 设置默认 Mailer 名称
+func (f *FakeMail) SetDefaultMailer(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.defaultMailer = name
+}
+
+// Extend 假邮件管理器不使用传输，忽略注册
+func (f *FakeMail) Extend(transport string, factory func(config map[string]interface{}) (Transport, error)) {
+}
+
+// Sent 返回与 mailable 同类型且满足条件的已发送和已排队邮件，mailable 为 nil 时不按类型过滤
+func (f *FakeMail) Sent(mailable Mailable, filter ...func(m *SentMail) bool) []*SentMail {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	typ := mailableType(mailable)
+	var matched []*SentMail
+	for _, sent := range f.sent {
+		if typ != nil && mailableType(sent.Mailable) != typ {
+			continue
+		}
+		if len(filter) > 0 && filter[0] != nil && !filter[0](sent) {
+			continue
+		}
+		matched = append(matched, sent)
+	}
+	return matched
+}
+
+// AssertSent 断言立即发送过与 mailable 同类型的邮件，可选的 filter 进一步筛选
+func (f *FakeMail) AssertSent(mailable Mailable, filter ...func(m *SentMail) bool) bool {
+	f.t.Helper()
+	if len(f.sentNow(mailable, filter...)) == 0 {
+		f.t.Errorf("mail: the expected [%s] mailable was not sent", mailableName(mailable))
+		return false
+	}
+	return true
+}
+
+// AssertSentTimes 断言与 mailable 同类型的邮件立即发送了 times 次
+func (f *FakeMail) AssertSentTimes(mailable Mailable, times int) bool {
+	f.t.Helper()
+	if n := len(f.sentNow(mailable)); n != times {
+		f.t.Errorf("mail: the expected [%s] mailable was sent [%d] times instead of [%d] times", mailableName(mailable), n, times)
+		return false
+	}
+	return true
+}
+
+// AssertNotSent 断言没有立即发送过与 mailable 同类型且满足条件的邮件
+func (f *FakeMail) AssertNotSent(mailable Mailable, filter ...func(m *SentMail) bool) bool {
+	f.t.Helper()
+	if len(f.sentNow(mailable, filter...)) > 0 {
+		f.t.Errorf("mail: the unexpected [%s] mailable was sent", mailableName(mailable))
+		return false
+	}
+	return true
+}
+
+// AssertNothingSent 断言没有立即发送任何邮件
+func (f *FakeMail) AssertNothingSent() bool {
+	f.t.Helper()
+	if n := len(f.sentNow(nil)); n > 0 {
+		f.t.Errorf("mail: [%d] unexpected mailables were sent", n)
+		return false
+	}
+	return true
+}
+
+// AssertQueued 断言排队过与 mailable 同类型的邮件，可选的 filter 进一步筛选
+func (f *FakeMail) AssertQueued(mailable Mailable, filter ...func(m *SentMail) bool) bool {
+	f.t.Helper()
+	if len(f.queued(mailable, filter...)) == 0 {
+		f.t.Errorf("mail: the expected [%s] mailable was not queued", mailableName(mailable))
+		return false
+	}
+	return true
+}
+
+// AssertQueuedTimes 断言与 mailable 同类型的邮件排队了 times 次
+func (f *FakeMail) AssertQueuedTimes(mailable Mailable, times int) bool {
+	f.t.Helper()
+	if n := len(f.queued(mailable)); n != times {
+		f.t.Errorf("mail: the expected [%s] mailable was queued [%d] times instead of [%d] times", mailableName(mailable), n, times)
+		return false
+	}
+	return true
+}
+
+// AssertNotQueued 断言没有排队过与 mailable 同类型且满足条件的邮件
+func (f *FakeMail) AssertNotQueued(mailable Mailable, filter ...func(m *SentMail) bool) bool {
+	f.t.Helper()
+	if len(f.queued(mailable, filter...)) > 0 {
+		f.t.Errorf("mail: the unexpected [%s] mailable was queued", mailableName(mailable))
+		return false
+	}
+	return true
+}
+
+// AssertNothingQueued 断言没有排队任何邮件
+func (f *FakeMail) AssertNothingQueued() bool {
+	f.t.Helper()
+	if n := len(f.queued(nil)); n > 0 {
+		f.t.Errorf("mail: [%d] unexpected mailables were queued", n)
+		return false
+	}
+	return true
+}
+
+// AssertNothingOutgoing 断言既没有发送也没有排队任何邮件
+func (f *FakeMail) AssertNothingOutgoing() bool {
+	f.t.Helper()
+	if n := len(f.Sent(nil)); n > 0 {
+		f.t.Errorf("mail: [%d] unexpected mailables were sent or queued", n)
+		return false
+	}
+	return true
+}
+
+// sentNow 立即发送的邮件
+func (f *FakeMail) sentNow(mailable Mailable, filter ...func(m *SentMail) bool) []*SentMail {
+	return f.Sent(mailable, func(m *SentMail) bool {
+		return !m.Queued && (len(filter) == 0 || filter[0] == nil || filter[0](m))
+	})
+}
+
+// queued 排队的邮件
+func (f *FakeMail) queued(mailable Mailable, filter ...func(m *SentMail) bool) []*SentMail {
+	return f.Sent(mailable, func(m *SentMail) bool {
+		return m.Queued && (len(filter) == 0 || filter[0] == nil || filter[0](m))
+	})
+}
+
+// record 记录邮件，排队的邮件返回随机的任务 ID
+func (f *FakeMail) record(sent *SentMail) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, sent)
+	if sent.Queued {
+		return queue.NewUUID()
+	}
+	return ""
+}
+
+// FakeMailer FakeMail 的 Mailer
+type FakeMailer struct {
+	fake *FakeMail
+	name string
+}
+
+// Name Mailer 名称
+func (m *FakeMailer) Name() string {
+	return m.name
+}
+
+// To 指定收件人
+func (m *FakeMailer) To(addresses ...Address) *PendingMail {
+	return NewPendingMail(m).To(addresses...)
+}
+
+// Cc 指定抄送
+func (m *FakeMailer) Cc(addresses ...Address) *PendingMail {
+	return NewPendingMail(m).Cc(addresses...)
+}
+
+// Bcc 指定密送
+func (m *FakeMailer) Bcc(addresses ...Address) *PendingMail {
+	return NewPendingMail(m).Bcc(addresses...)
+}
+
+// Send 记录邮件，实现 queue.ShouldQueue 的邮件记为已排队
+func (m *FakeMailer) Send(ctx context.Context, mailable Mailable) error {
+	return NewPendingMail(m).Send(ctx, mailable)
+}
+
+// SendNow 记录立即发送的邮件
+func (m *FakeMailer) SendNow(ctx context.Context, mailable Mailable) error {
+	return NewPendingMail(m).SendNow(ctx, mailable)
+}
+
+// Queue 记录排队的邮件
+func (m *FakeMailer) Queue(ctx context.Context, mailable Mailable) (string, error) {
+	return NewPendingMail(m).Queue(ctx, mailable)
+}
+
+// Later 记录延迟排队的邮件
+func (m *FakeMailer) Later(ctx context.Context, delay time.Duration, mailable Mailable) (string, error) {
+	return NewPendingMail(m).Later(ctx, delay, mailable)
+}
+
+// Render 只使用信封和直接指定的 HTMLString、TextString 生成邮件，不渲染视图
+func (m *FakeMailer) Render(ctx context.Context, mailable Mailable) (*Message, error) {
+	envelope := mailable.Envelope()
+	content := mailable.Content()
+	return &Message{
+		From:        envelope.From,
+		To:          append([]Address{}, envelope.To...),
+		Cc:          append([]Address{}, envelope.Cc...),
+		Bcc:         append([]Address{}, envelope.Bcc...),
+		ReplyTo:     append([]Address{}, envelope.ReplyTo...),
+		Subject:     envelope.Subject,
+		HTML:        content.HTMLString,
+		Text:        content.TextString,
+		Attachments: mailable.Attachments(),
+		Headers:     envelope.Headers,
+		Tags:        envelope.Tags,
+		Metadata:    envelope.Metadata,
+	}, nil
+}
+
+// SendMessage 记录已渲染的邮件
+func (m *FakeMailer) SendMessage(ctx context.Context, message *Message) error {
+	m.fake.record(m.messageRecord(message, false, QueueOptions{}))
+	return nil
+}
+
+// QueueMessage 记录排队的已渲染邮件
+func (m *FakeMailer) QueueMessage(ctx context.Context, message *Message, options QueueOptions) (string, error) {
+	return m.fake.record(m.messageRecord(message, true, options)), nil
+}
+
+// Transport 返回把邮件记录到 FakeMail 的传输
+func (m *FakeMailer) Transport() Transport {
+	return fakeTransport{mailer: m}
+}
+
+// recordPending 记录 PendingMail，收件人为信封与 PendingMail 指定的收件人之和
+func (m *FakeMailer) recordPending(p *PendingMail, mailable Mailable, queued bool, options QueueOptions) string {
+	envelope := mailable.Envelope()
+	return m.fake.record(&SentMail{
+		Mailable: mailable,
+		Mailer:   m.name,
+		To:       append(append([]Address{}, envelope.To...), p.to...),
+		Cc:       append(append([]Address{}, envelope.Cc...), p.cc...),
+		Bcc:      append(append([]Address{}, envelope.Bcc...), p.bcc...),
+		Queued:   queued,
+		Options:  options,
+	})
+}
+
+// messageRecord 已渲染邮件的记录
+func (m *FakeMailer) messageRecord(message *Message, queued bool, options QueueOptions) *SentMail {
+	return &SentMail{
+		Message: message,
+		Mailer:  m.name,
+		To:      message.To,
+		Cc:      message.Cc,
+		Bcc:     message.Bcc,
+		Queued:  queued,
+		Options: options,
+	}
+}
+
+// fakeTransport FakeMailer 的传输
+type fakeTransport struct {
+	mailer *FakeMailer
+}
+
+// Send 记录邮件
+func (t fakeTransport) Send(ctx context.Context, message *Message) error {
+	return t.mailer.SendMessage(ctx, message)
+}
+
+// String 传输名称
+func (t fakeTransport) String() string {
+	return "fake"
+}
+
+// hasAddress 地址列表中是否包含 email
+func hasAddress(addresses []Address, email string) bool {
+	for _, address := range addresses {
+		if address.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// mailableType 邮件的类型，指针和值类型视为同一类型
+func mailableType(mailable Mailable) reflect.Type {
+	t := reflect.TypeOf(mailable)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// mailableName 邮件的类型名，用于断言消息
+func mailableName(mailable Mailable) string {
+	if t := mailableType(mailable); t != nil {
+		return t.String()
+	}
+	return "<nil>"
+}
@@ -0,0 +1,46 @@
+// Package mail 提供 Laravel 风格的邮件系统协议定义
+//
+// 本包定义 Mailable 邮件、Mailer 发送器、Transport 传输和 Manager 管理器的接口，
+// 设计参考 Laravel 的 Illuminate\Mail。传输、发送器和管理器的实现位于子包 driver。
+//
+// 主要特性：
+// - Mailable 契约（Envelope、Content、Attachments）
+// - 视图、纯文本和 Markdown 邮件内容
+// - 实现 queue.ShouldQueue 的邮件自动投递到队列发送
+// - smtp、log、array、ses、mailgun 传输
+// - MIME 多部分邮件编码
+// - FakeMail 测试替身，记录发送和排队的邮件并提供断言
+//
+// 包结构：
+// - message.go - Address 地址、Message 已渲染的邮件和 MIME 编码
+// - mailable.go - Mailable 邮件接口、Envelope、Content 和 Attachment
+// - mailer.go - Mailer、Transport、Manager 接口和 PendingMail
+// - queued.go - SendQueuedMessage 队列发送任务
+// - markdown.go - Markdown 邮件渲染
+// - fake.go - FakeMail 假邮件管理器和测试断言
+//
+// 使用示例：
+//
+//	type OrderShipped struct {
+//		queue.Queued
+//		Order *Order `json:"order"`
+//	}
+//
+//	func (m *OrderShipped) Envelope() mail.Envelope {
+//		return mail.Envelope{Subject: "Order Shipped"}
+//	}
+//
+//	func (m *OrderShipped) Content() mail.Content {
+//		return mail.Content{
+//			Markdown: "emails.orders.shipped",
+//			With:     map[string]interface{}{"order": m.Order},
+//		}
+//	}
+//
+//	func (m *OrderShipped) Attachments() []mail.Attachment {
+//		return []mail.Attachment{mail.AttachFromPath("/invoices/" + m.Order.Invoice).As("invoice.pdf")}
+//	}
+//
+//	mailer, _ := manager.Mailer()
+//	err := mailer.To(mail.NewAddress(user.Email, user.Name)).Send(ctx, &OrderShipped{Order: order})
+package mail
@@ -0,0 +1,120 @@
+package mail
+
+import (
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+)
+
+// Mailable 可发送的邮件
+//
+// 对应 Laravel 的 Mailable 类。实现 queue.ShouldQueue（例如嵌入 queue.Queued）的邮件
+// 调用 Send 时会投递到队列，由 Worker 发送。
+type Mailable interface {
+	// Envelope 邮件信封：发件人、收件人和主题
+	Envelope() Envelope
+
+	// Content 邮件内容：视图、纯文本或 Markdown
+	Content() Content
+
+	// Attachments 附件
+	Attachments() []Attachment
+}
+
+// Envelope 邮件信封
+//
+// From 为空时使用 Mailer 配置的全局发件人。To、Cc、Bcc 与 PendingMail 指定的收件人合并。
+type Envelope struct {
+	From     Address
+	To       []Address
+	Cc       []Address
+	Bcc      []Address
+	ReplyTo  []Address
+	Subject  string
+	Tags     []string
+	Metadata map[string]string
+	Headers  map[string]string
+}
+
+// Content 邮件内容
+//
+// View、Text、Markdown 为视图名称，通过 view.Factory 渲染；
+// HTMLString、TextString 为直接使用的内容。
+// 只设置 Markdown 时，HTML 由 Markdown 转换并套用邮件布局，纯文本使用 Markdown 原文。
+type Content struct {
+	// View HTML 视图名称
+	View string
+
+	// Text 纯文本视图名称
+	Text string
+
+	// Markdown Markdown 视图名称
+	Markdown string
+
+	// HTMLString 直接使用的 HTML 内容
+	HTMLString string
+
+	// TextString 直接使用的纯文本内容
+	TextString string
+
+	// With 传递给视图的数据
+	With map[string]interface{}
+}
+
+// Attachment 邮件附件
+//
+// Path 不为空时在渲染邮件时读取文件内容到 Data。
+type Attachment struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type,omitempty"`
+	Data        []byte `json:"data,omitempty"`
+	Path        string `json:"path,omitempty"`
+
+	// ContentID 不为空时作为内联附件，可以在 HTML 中通过 cid:ContentID 引用
+	ContentID string `json:"content_id,omitempty"`
+}
+
+// AttachFromPath 从文件创建附件
+func AttachFromPath(path string) Attachment {
+	return Attachment{Name: filepath.Base(path), Path: path}
+}
+
+// AttachData 从内存数据创建附件
+func AttachData(data []byte, name string) Attachment {
+	return Attachment{Name: name, Data: data}
+}
+
+// As 设置附件文件名
+func (a Attachment) As(name string) Attachment {
+	a.Name = name
+	return a
+}
+
+// WithMime 设置附件 MIME 类型
+func (a Attachment) WithMime(contentType string) Attachment {
+	a.ContentType = contentType
+	return a
+}
+
+// Inline 设置为内联附件
+func (a Attachment) Inline(contentID string) Attachment {
+	a.ContentID = contentID
+	return a
+}
+
+// Load 读取 Path 指定的文件并推断 MIME 类型
+func (a Attachment) Load() (Attachment, error) {
+	if a.Path != "" && a.Data == nil {
+		data, err := os.ReadFile(a.Path)
+		if err != nil {
+			return a, fmt.Errorf("mail: attachment %s: %w", a.Path, err)
+		}
+		a.Data = data
+	}
+	a.Path = ""
+	if a.ContentType == "" {
+		a.ContentType = mime.TypeByExtension(filepath.Ext(a.Name))
+	}
+	return a, nil
+}
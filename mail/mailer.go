@@ -0,0 +1,237 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// 内置传输名称
+const (
+	// TransportSMTP 通过 SMTP 服务器发送
+	TransportSMTP = "smtp"
+
+	// TransportLog 将邮件写入日志，不实际发送
+	TransportLog = "log"
+
+	// TransportArray 将邮件保存在内存中，用于测试
+	TransportArray = "array"
+
+	// TransportSES 通过 Amazon SES 发送
+	TransportSES = "ses"
+
+	// TransportMailgun 通过 Mailgun HTTP API 发送
+	TransportMailgun = "mailgun"
+)
+
+// ErrNoManager 没有设置默认邮件管理器
+var ErrNoManager = errors.New("mail: no default manager, call mail.SetDefaultManager")
+
+// Transport 邮件传输
+type Transport interface {
+	// Send 发送已渲染的邮件
+	Send(ctx context.Context, message *Message) error
+
+	// String 传输名称，用于日志
+	String() string
+}
+
+// Mailer 邮件发送器接口
+//
+// 对应 Laravel 的 Illuminate\Mail\Mailer，每个 Mailer 对应 config/mail.php 中的一个 mailer。
+type Mailer interface {
+	// Name Mailer 名称
+	Name() string
+
+	// To 指定收件人，返回 PendingMail
+	To(addresses ...Address) *PendingMail
+
+	// Cc 指定抄送，返回 PendingMail
+	Cc(addresses ...Address) *PendingMail
+
+	// Bcc 指定密送，返回 PendingMail
+	Bcc(addresses ...Address) *PendingMail
+
+	// Send 发送邮件，实现 queue.ShouldQueue 的邮件投递到队列
+	Send(ctx context.Context, mailable Mailable) error
+
+	// SendNow 立即发送邮件，即使实现了 queue.ShouldQueue
+	SendNow(ctx context.Context, mailable Mailable) error
+
+	// Queue 将邮件投递到队列，返回任务 ID
+	Queue(ctx context.Context, mailable Mailable) (string, error)
+
+	// Later 延迟投递邮件，返回任务 ID
+	Later(ctx context.Context, delay time.Duration, mailable Mailable) (string, error)
+
+	// Render 渲染邮件，填充全局发件人并读取附件
+	Render(ctx context.Context, mailable Mailable) (*Message, error)
+
+	// SendMessage 通过传输发送已渲染的邮件
+	SendMessage(ctx context.Context, message *Message) error
+
+	// QueueMessage 将已渲染的邮件投递到队列，返回任务 ID
+	QueueMessage(ctx context.Context, message *Message, options QueueOptions) (string, error)
+
+	// Transport 获取传输
+	Transport() Transport
+}
+
+// Manager 邮件管理器接口
+//
+// 配置与 Laravel 的 config/mail.php 的 mailers 对应，每个 mailer 的 "transport" 字段决定使用的传输。
+type Manager interface {
+	// Mailer 获取 Mailer，不传名称时使用默认 Mailer
+	Mailer(name ...string) (Mailer, error)
+
+	// GetDefaultMailer 获取默认 Mailer 名称
+	GetDefaultMailer() string
+
+	// SetDefaultMailer 设置默认 Mailer 名称
+	SetDefaultMailer(name string)
+
+	// Extend 注册传输
+	Extend(transport string, factory func(config map[string]interface{}) (Transport, error))
+}
+
+// QueueOptions 邮件投递到队列时使用的连接、队列和延迟
+type QueueOptions struct {
+	Connection string
+	Queue      string
+	Delay      time.Duration
+}
+
+// QueueOptionsFor 获取邮件的排队设置，第二个返回值表示邮件是否实现了 queue.ShouldQueue
+func QueueOptionsFor(mailable Mailable) (QueueOptions, bool) {
+	var options QueueOptions
+	if route, ok := mailable.(queue.QueueRoute); ok {
+		options.Connection, options.Queue = route.ViaConnection(), route.ViaQueue()
+	}
+	if delayed, ok := mailable.(queue.DelayedJob); ok {
+		options.Delay = delayed.Delay()
+	}
+	_, shouldQueue := mailable.(queue.ShouldQueue)
+	return options, shouldQueue
+}
+
+// PendingMail 指定了收件人、等待发送的邮件
+//
+// 使用示例：
+//
+//	mailer.To(user).Cc(manager).Send(ctx, &InvoicePaid{Invoice: invoice})
+type PendingMail struct {
+	mailer Mailer
+	to     []Address
+	cc     []Address
+	bcc    []Address
+}
+
+// NewPendingMail 创建 PendingMail
+func NewPendingMail(mailer Mailer) *PendingMail {
+	return &PendingMail{mailer: mailer}
+}
+
+// To 添加收件人
+func (p *PendingMail) To(addresses ...Address) *PendingMail {
+	p.to = append(p.to, addresses...)
+	return p
+}
+
+// Cc 添加抄送
+func (p *PendingMail) Cc(addresses ...Address) *PendingMail {
+	p.cc = append(p.cc, addresses...)
+	return p
+}
+
+// Bcc 添加密送
+func (p *PendingMail) Bcc(addresses ...Address) *PendingMail {
+	p.bcc = append(p.bcc, addresses...)
+	return p
+}
+
+// Send 发送邮件，实现 queue.ShouldQueue 的邮件投递到队列
+func (p *PendingMail) Send(ctx context.Context, mailable Mailable) error {
+	options, shouldQueue := QueueOptionsFor(mailable)
+	if shouldQueue {
+		_, err := p.queue(ctx, mailable, options)
+		return err
+	}
+	return p.SendNow(ctx, mailable)
+}
+
+// SendNow 立即发送邮件
+func (p *PendingMail) SendNow(ctx context.Context, mailable Mailable) error {
+	if recorder, ok := p.mailer.(pendingRecorder); ok {
+		recorder.recordPending(p, mailable, false, QueueOptions{})
+		return nil
+	}
+	message, err := p.render(ctx, mailable)
+	if err != nil {
+		return err
+	}
+	return p.mailer.SendMessage(ctx, message)
+}
+
+// Queue 将邮件投递到队列
+func (p *PendingMail) Queue(ctx context.Context, mailable Mailable) (string, error) {
+	options, _ := QueueOptionsFor(mailable)
+	return p.queue(ctx, mailable, options)
+}
+
+// Later 延迟投递邮件
+func (p *PendingMail) Later(ctx context.Context, delay time.Duration, mailable Mailable) (string, error) {
+	options, _ := QueueOptionsFor(mailable)
+	options.Delay = delay
+	return p.queue(ctx, mailable, options)
+}
+
+// queue 渲染后投递到队列
+//
+// 邮件在投递时渲染，队列中保存的是 Message 而不是 Mailable，
+// 因此 Mailable 不需要在队列注册表中注册。
+func (p *PendingMail) queue(ctx context.Context, mailable Mailable, options QueueOptions) (string, error) {
+	if recorder, ok := p.mailer.(pendingRecorder); ok {
+		return recorder.recordPending(p, mailable, true, options), nil
+	}
+	message, err := p.render(ctx, mailable)
+	if err != nil {
+		return "", err
+	}
+	return p.mailer.QueueMessage(ctx, message, options)
+}
+
+// render 渲染邮件并合并收件人
+func (p *PendingMail) render(ctx context.Context, mailable Mailable) (*Message, error) {
+	message, err := p.mailer.Render(ctx, mailable)
+	if err != nil {
+		return nil, err
+	}
+	message.To = append(message.To, p.to...)
+	message.Cc = append(message.Cc, p.cc...)
+	message.Bcc = append(message.Bcc, p.bcc...)
+	return message, nil
+}
+
+// pendingRecorder 直接记录 PendingMail 而不渲染邮件的 Mailer，由 FakeMailer 实现
+type pendingRecorder interface {
+	recordPending(p *PendingMail, mailable Mailable, queued bool, options QueueOptions) string
+}
+
+// defaultManager 默认邮件管理器
+var defaultManager atomic.Value
+
+// SetDefaultManager 设置默认邮件管理器，排队发送的邮件由 Worker 通过它获取 Mailer
+func SetDefaultManager(manager Manager) {
+	defaultManager.Store(&manager)
+}
+
+// DefaultManager 获取默认邮件管理器，未设置时返回 nil
+func DefaultManager() Manager {
+	if manager, ok := defaultManager.Load().(*Manager); ok {
+		return *manager
+	}
+	return nil
+}
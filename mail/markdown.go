@@ -0,0 +1,154 @@
+package mail
+
+import (
+	"bytes"
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// Markdown 邮件支持的语法：标题、段落、无序和有序列表、引用、分隔线、代码块，
+// 以及行内的粗体、斜体、行内代码和链接。以 "<" 开头的行作为 HTML 原样输出，
+// 其余文本会转义，因此视图数据插入到普通文本中是安全的。
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ulPattern      = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	olPattern      = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	hrPattern      = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	linkPattern    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldPattern    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	italicPattern  = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// MarkdownToHTML 将 Markdown 转换为 HTML 片段
+func MarkdownToHTML(source string) string {
+	var out strings.Builder
+	var paragraph []string
+	var list string
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + inline(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if list != "" {
+			out.WriteString("</" + list + ">\n")
+			list = ""
+		}
+	}
+	openList := func(tag string) {
+		if list != tag {
+			closeList()
+			out.WriteString("<" + tag + ">\n")
+			list = tag
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flushParagraph()
+			closeList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+		case strings.HasPrefix(trimmed, "<"):
+			flushParagraph()
+			closeList()
+			out.WriteString(trimmed + "\n")
+		case hrPattern.MatchString(trimmed):
+			flushParagraph()
+			closeList()
+			out.WriteString("<hr>\n")
+		case headingPattern.MatchString(trimmed):
+			flushParagraph()
+			closeList()
+			m := headingPattern.FindStringSubmatch(trimmed)
+			level := string(rune('0' + len(m[1])))
+			out.WriteString("<h" + level + ">" + inline(m[2]) + "</h" + level + ">\n")
+		case ulPattern.MatchString(trimmed):
+			flushParagraph()
+			openList("ul")
+			out.WriteString("<li>" + inline(ulPattern.FindStringSubmatch(trimmed)[1]) + "</li>\n")
+		case olPattern.MatchString(trimmed):
+			flushParagraph()
+			openList("ol")
+			out.WriteString("<li>" + inline(olPattern.FindStringSubmatch(trimmed)[1]) + "</li>\n")
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			closeList()
+			out.WriteString("<blockquote>" + inline(strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))) + "</blockquote>\n")
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+	closeList()
+	return out.String()
+}
+
+// inline 转换行内语法，反引号中的代码不做处理
+func inline(text string) string {
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		escaped := html.EscapeString(part)
+		if i%2 == 1 && i < len(parts)-1 {
+			parts[i] = "<code>" + escaped + "</code>"
+			continue
+		}
+		escaped = linkPattern.ReplaceAllString(escaped, `<a href="$2">$1</a>`)
+		escaped = boldPattern.ReplaceAllString(escaped, "<strong>$1$2</strong>")
+		escaped = italicPattern.ReplaceAllString(escaped, "<em>$1$2</em>")
+		if i%2 == 1 {
+			escaped = "`" + escaped
+		}
+		parts[i] = escaped
+	}
+	return strings.Join(parts, "")
+}
+
+// markdownLayout Markdown 邮件的默认布局
+var markdownLayout = template.Must(template.New("mail").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>{{.Title}}</title>
+</head>
+<body style="margin:0;padding:0;background-color:#edf2f7;font-family:-apple-system,'Segoe UI',Roboto,Helvetica,Arial,sans-serif;color:#3d4852;">
+<table width="100%" cellpadding="0" cellspacing="0" role="presentation" style="background-color:#edf2f7;">
+<tr><td align="center" style="padding:32px 16px;">
+<table width="570" cellpadding="0" cellspacing="0" role="presentation" style="background-color:#ffffff;border-radius:4px;">
+<tr><td style="padding:32px;font-size:16px;line-height:1.5;">
+{{.Body}}
+</td></tr>
+</table>
+</td></tr>
+</table>
+</body>
+</html>
+`))
+
+// RenderMarkdown 将 Markdown 转换为套用邮件布局的完整 HTML 文档
+func RenderMarkdown(source, title string) (string, error) {
+	var buf bytes.Buffer
+	err := markdownLayout.Execute(&buf, map[string]interface{}{
+		"Title": title,
+		"Body":  template.HTML(MarkdownToHTML(source)),
+	})
+	return buf.String(), err
+}
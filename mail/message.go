@@ -0,0 +1,259 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Address 邮件地址
+type Address struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email"`
+}
+
+// NewAddress 创建邮件地址
+func NewAddress(email, name string) Address {
+	return Address{Name: name, Email: email}
+}
+
+// ParseAddress 解析 "Name <email>" 或 "email" 格式的地址
+func ParseAddress(address string) (Address, error) {
+	parsed, err := netmail.ParseAddress(address)
+	if err != nil {
+		return Address{}, err
+	}
+	return Address{Name: parsed.Name, Email: parsed.Address}, nil
+}
+
+// String 格式化为邮件头使用的地址，非 ASCII 名称按 RFC 2047 编码
+func (a Address) String() string {
+	return (&netmail.Address{Name: a.Name, Address: a.Email}).String()
+}
+
+// IsZero 地址是否为空
+func (a Address) IsZero() bool {
+	return a.Email == ""
+}
+
+// Message 渲染完成、可以直接交给 Transport 发送的邮件
+//
+// Message 可以 JSON 序列化，排队发送时作为任务载荷。
+type Message struct {
+	From        Address           `json:"from"`
+	To          []Address         `json:"to,omitempty"`
+	Cc          []Address         `json:"cc,omitempty"`
+	Bcc         []Address         `json:"bcc,omitempty"`
+	ReplyTo     []Address         `json:"reply_to,omitempty"`
+	Subject     string            `json:"subject"`
+	HTML        string            `json:"html,omitempty"`
+	Text        string            `json:"text,omitempty"`
+	Attachments []Attachment      `json:"attachments,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+
+	// Tags 和 Metadata 由支持的传输（如 Mailgun、SES）附加到邮件上
+	Tags     []string          `json:"tags,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Recipients 所有收件人地址（To、Cc、Bcc），用于 SMTP RCPT TO
+func (m *Message) Recipients() []string {
+	recipients := make([]string, 0, len(m.To)+len(m.Cc)+len(m.Bcc))
+	for _, list := range [][]Address{m.To, m.Cc, m.Bcc} {
+		for _, address := range list {
+			recipients = append(recipients, address.Email)
+		}
+	}
+	return recipients
+}
+
+// Bytes 编码为 RFC 5322 MIME 邮件，Bcc 不写入邮件头
+//
+// 同时有 HTML 和纯文本时使用 multipart/alternative，有附件时外层使用 multipart/mixed。
+func (m *Message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	header := textproto.MIMEHeader{}
+	header.Set("From", m.From.String())
+	setAddresses(header, "To", m.To)
+	setAddresses(header, "Cc", m.Cc)
+	setAddresses(header, "Reply-To", m.ReplyTo)
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", messageID(m.From.Email))
+	header.Set("MIME-Version", "1.0")
+	for key, value := range m.Headers {
+		header.Set(key, value)
+	}
+
+	bodyHeader, body, err := m.body()
+	if err != nil {
+		return nil, err
+	}
+	if len(m.Attachments) == 0 {
+		for key, values := range bodyHeader {
+			header[key] = values
+		}
+		writeHeader(&buf, header)
+		buf.Write(body)
+		return buf.Bytes(), nil
+	}
+
+	mixed := multipart.NewWriter(&buf)
+	header.Set("Content-Type", "multipart/mixed; boundary="+mixed.Boundary())
+	writeHeader(&buf, header)
+	part, err := mixed.CreatePart(bodyHeader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(body); err != nil {
+		return nil, err
+	}
+	for _, attachment := range m.Attachments {
+		if err := writeAttachment(mixed, attachment); err != nil {
+			return nil, err
+		}
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// body 编码正文部分（单部分或 multipart/alternative），返回正文的内容头和内容
+func (m *Message) body() (textproto.MIMEHeader, []byte, error) {
+	var buf bytes.Buffer
+	header := textproto.MIMEHeader{}
+	if m.HTML != "" && m.Text != "" {
+		alternative := multipart.NewWriter(&buf)
+		header.Set("Content-Type", "multipart/alternative; boundary="+alternative.Boundary())
+		for _, part := range []struct{ contentType, body string }{
+			{"text/plain; charset=utf-8", m.Text},
+			{"text/html; charset=utf-8", m.HTML},
+		} {
+			w, err := alternative.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {part.contentType},
+				"Content-Transfer-Encoding": {"quoted-printable"},
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := writeQuotedPrintable(w, part.body); err != nil {
+				return nil, nil, err
+			}
+		}
+		if err := alternative.Close(); err != nil {
+			return nil, nil, err
+		}
+		return header, buf.Bytes(), nil
+	}
+
+	contentType, body := "text/plain; charset=utf-8", m.Text
+	if m.HTML != "" {
+		contentType, body = "text/html; charset=utf-8", m.HTML
+	}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	if err := writeQuotedPrintable(&buf, body); err != nil {
+		return nil, nil, err
+	}
+	return header, buf.Bytes(), nil
+}
+
+// writeAttachment 写入 base64 编码的附件
+func writeAttachment(w *multipart.Writer, attachment Attachment) error {
+	disposition := "attachment"
+	header := textproto.MIMEHeader{}
+	if attachment.ContentID != "" {
+		disposition = "inline"
+		header.Set("Content-ID", "<"+attachment.ContentID+">")
+	}
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Name}))
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	for len(encoded) > 76 {
+		if _, err := fmt.Fprintf(part, "%s\r\n", encoded[:76]); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err = fmt.Fprintf(part, "%s\r\n", encoded)
+	return err
+}
+
+// writeQuotedPrintable 以 quoted-printable 编码写入正文
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// headerSpelling textproto 规范化后与 RFC 惯用写法不同的邮件头
+var headerSpelling = map[string]string{
+	"Message-Id":   "Message-ID",
+	"Mime-Version": "MIME-Version",
+	"Content-Id":   "Content-ID",
+}
+
+// writeHeader 按固定顺序写入邮件头
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := key
+		if spelled, ok := headerSpelling[key]; ok {
+			name = spelled
+		}
+		for _, value := range header[key] {
+			fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+// setAddresses 设置地址列表邮件头
+func setAddresses(header textproto.MIMEHeader, key string, addresses []Address) {
+	if len(addresses) == 0 {
+		return
+	}
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = address.String()
+	}
+	header.Set(key, strings.Join(formatted, ", "))
+}
+
+// messageID 生成 Message-ID
+func messageID(from string) string {
+	domain := "localhost"
+	if i := strings.LastIndex(from, "@"); i >= 0 {
+		domain = from[i+1:]
+	}
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return "<" + hex.EncodeToString(b[:]) + "@" + domain + ">"
+}
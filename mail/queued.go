@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+func init() {
+	queue.Register(&SendQueuedMessage{})
+}
+
+// SendQueuedMessage 发送已渲染邮件的队列任务
+//
+// Worker 通过 DefaultManager 获取 Mailer 发送邮件，因此 Worker 进程需要调用 SetDefaultManager。
+type SendQueuedMessage struct {
+	queue.Queueable
+
+	// Mailer 发送使用的 Mailer 名称
+	Mailer string `json:"mailer"`
+
+	// Message 已渲染的邮件
+	Message *Message `json:"message"`
+}
+
+// JobName 固定任务名称
+func (j *SendQueuedMessage) JobName() string {
+	return "mail.SendQueuedMessage"
+}
+
+// Handle 发送邮件
+func (j *SendQueuedMessage) Handle(ctx context.Context) error {
+	manager := DefaultManager()
+	if manager == nil {
+		return ErrNoManager
+	}
+	mailer, err := manager.Mailer(j.Mailer)
+	if err != nil {
+		return err
+	}
+	return mailer.SendMessage(ctx, j.Message)
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/cnote0/laraveldoc/cache"
+)
+
+// cacheStore 记录命中率的缓存存储
+type cacheStore struct {
+	cache.Store
+	hits   *Counter
+	misses *Counter
+	writes *Counter
+	ratio  *Gauge
+}
+
+// CacheStore 包装 store，记录 cache_hits_total、cache_misses_total、cache_writes_total
+// 和 cache_hit_ratio，标签为存储名称
+//
+// 返回的存储只实现 cache.Store，store 实现的锁和限流等扩展接口需要继续使用原存储。
+func CacheStore(registry MetricsRegistry, name string, store cache.Store) cache.Store {
+	return &cacheStore{
+		Store:  store,
+		hits:   registry.Counter("cache_hits_total", "Total number of cache hits.", "store").With(name),
+		misses: registry.Counter("cache_misses_total", "Total number of cache misses.", "store").With(name),
+		writes: registry.Counter("cache_writes_total", "Total number of cache writes.", "store").With(name),
+		ratio:  registry.Gauge("cache_hit_ratio", "Ratio of cache hits to cache reads.", "store").With(name),
+	}
+}
+
+// Get 记录命中或未命中并更新命中率
+func (s *cacheStore) Get(ctx context.Context, key string) (interface{}, bool, error) {
+	value, ok, err := s.Store.Get(ctx, key)
+	if err != nil {
+		return value, ok, err
+	}
+	if ok {
+		s.hits.Inc()
+	} else {
+		s.misses.Inc()
+	}
+	hits, misses := s.hits.Value(), s.misses.Value()
+	s.ratio.Set(hits / (hits + misses))
+	return value, ok, nil
+}
+
+// Put 记录写入
+func (s *cacheStore) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	err := s.Store.Put(ctx, key, value, ttl)
+	if err == nil {
+		s.writes.Inc()
+	}
+	return err
+}
+
+// Forever 记录写入
+func (s *cacheStore) Forever(ctx context.Context, key string, value interface{}) error {
+	err := s.Store.Forever(ctx, key, value)
+	if err == nil {
+		s.writes.Inc()
+	}
+	return err
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// QueryLogger 查询指标，实现 database.LoggerInterface
+//
+// 记录 db_queries_total、db_query_errors_total 和 db_query_duration_seconds，
+// 标签为语句类型（select、insert、update、delete 等）。日志调用转发给 inner。
+type QueryLogger struct {
+	inner    database.LoggerInterface
+	queries  *CounterVec
+	errors   *CounterVec
+	duration *HistogramVec
+}
+
+var _ database.LoggerInterface = (*QueryLogger)(nil)
+
+// NewQueryLogger 创建查询指标，通过 database.SessionConfig 的 Logger 安装，inner 可以为 nil
+func NewQueryLogger(registry MetricsRegistry, inner database.LoggerInterface) *QueryLogger {
+	return &QueryLogger{
+		inner:    inner,
+		queries:  registry.Counter("db_queries_total", "Total number of executed database queries.", "operation"),
+		errors:   registry.Counter("db_query_errors_total", "Total number of failed database queries.", "operation"),
+		duration: registry.Histogram("db_query_duration_seconds", "Database query duration in seconds.", nil, "operation"),
+	}
+}
+
+// LogMode 设置内部日志的级别
+func (l *QueryLogger) LogMode(level string) database.LoggerInterface {
+	if l.inner == nil {
+		return l
+	}
+	clone := *l
+	clone.inner = l.inner.LogMode(level)
+	return &clone
+}
+
+// Info 转发信息日志
+func (l *QueryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.inner != nil {
+		l.inner.Info(ctx, msg, data...)
+	}
+}
+
+// Warn 转发警告日志
+func (l *QueryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.inner != nil {
+		l.inner.Warn(ctx, msg, data...)
+	}
+}
+
+// Error 转发错误日志
+func (l *QueryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.inner != nil {
+		l.inner.Error(ctx, msg, data...)
+	}
+}
+
+// Trace 记录查询次数、错误和耗时
+func (l *QueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	if l.inner != nil {
+		l.inner.Trace(ctx, begin, fc, err)
+	}
+	sql, _ := fc()
+	operation := queryOperation(sql)
+	l.queries.With(operation).Inc()
+	l.duration.With(operation).ObserveDuration(elapsed)
+	if err != nil {
+		l.errors.With(operation).Inc()
+	}
+}
+
+// queryOperation 语句的第一个关键字，小写
+func queryOperation(sql string) string {
+	fields := strings.Fields(strings.TrimLeft(sql, "( \t\n"))
+	if len(fields) == 0 {
+		return "unknown"
+	}
+	switch operation := strings.ToLower(fields[0]); operation {
+	case "select", "insert", "update", "delete", "replace", "with", "create", "alter", "drop", "begin", "commit", "rollback", "savepoint":
+		return operation
+	}
+	return "other"
+}
+
+// RegisterDatabase 注册连接池状态收集器，抓取时读取 db 的 sql.DBStats，标签为连接名称
+func RegisterDatabase(registry MetricsRegistry, connection string, db database.DB) {
+	registry.Register(CollectorFunc(func(ctx context.Context) ([]MetricFamily, error) {
+		sqlDB, err := db.SqlDB()
+		if err != nil {
+			return nil, err
+		}
+		stats := sqlDB.Stats()
+		labels := map[string]string{"connection": connection}
+		gauge := func(name, help string, value float64) MetricFamily {
+			return MetricFamily{Name: name, Help: help, Type: TypeGauge, Samples: []Sample{{Labels: labels, Value: value}}}
+		}
+		counter := func(name, help string, value float64) MetricFamily {
+			return MetricFamily{Name: name, Help: help, Type: TypeCounter, Samples: []Sample{{Labels: labels, Value: value}}}
+		}
+		return []MetricFamily{
+			gauge("db_connections_max_open", "Maximum number of open connections to the database.", float64(stats.MaxOpenConnections)),
+			gauge("db_connections_open", "Number of established connections, both in use and idle.", float64(stats.OpenConnections)),
+			gauge("db_connections_in_use", "Number of connections currently in use.", float64(stats.InUse)),
+			gauge("db_connections_idle", "Number of idle connections.", float64(stats.Idle)),
+			counter("db_connections_wait_total", "Total number of connections waited for.", float64(stats.WaitCount)),
+			counter("db_connections_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", stats.WaitDuration.Seconds()),
+			counter("db_connections_max_idle_closed_total", "Total number of connections closed due to SetMaxIdleConns.", float64(stats.MaxIdleClosed)),
+			counter("db_connections_max_lifetime_closed_total", "Total number of connections closed due to SetConnMaxLifetime.", float64(stats.MaxLifetimeClosed)),
+		}, nil
+	}))
+}
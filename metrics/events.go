@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// eventDispatcher 记录派发次数的事件分发器
+type eventDispatcher struct {
+	application.EventDispatcher
+	dispatched *CounterVec
+}
+
+// EventDispatcher 包装 dispatcher，记录 events_dispatched_total，标签为事件名称
+//
+// 事件名称为空时使用事件的类型名称。
+func EventDispatcher(registry MetricsRegistry, dispatcher application.EventDispatcher) application.EventDispatcher {
+	return &eventDispatcher{
+		EventDispatcher: dispatcher,
+		dispatched:      registry.Counter("events_dispatched_total", "Total number of dispatched events.", "event"),
+	}
+}
+
+// Dispatch 记录并派发事件
+func (d *eventDispatcher) Dispatch(event interface{}, eventName string) interface{} {
+	d.dispatched.With(eventLabel(event, eventName)).Inc()
+	return d.EventDispatcher.Dispatch(event, eventName)
+}
+
+// DispatchWithContext 记录并派发事件
+func (d *eventDispatcher) DispatchWithContext(ctx context.Context, event interface{}, eventName string) interface{} {
+	d.dispatched.With(eventLabel(event, eventName)).Inc()
+	return d.EventDispatcher.DispatchWithContext(ctx, event, eventName)
+}
+
+// eventLabel 事件名称，为空时使用事件的类型名称
+func eventLabel(event interface{}, eventName string) string {
+	if eventName != "" || event == nil {
+		return eventName
+	}
+	return reflect.TypeOf(event).String()
+}
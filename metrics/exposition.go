@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType Prometheus 文本格式的内容类型
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Handler 返回以 Prometheus 文本格式输出 registry 指标的处理器，registry 为 nil 时使用 DefaultRegistry
+//
+// 收集器出错时仍然输出其他指标，错误以注释行附在末尾。
+// 处理器不做鉴权，应只在内网暴露或放在需要认证的路由分组下。
+func Handler(registry MetricsRegistry) http.Handler {
+	if registry == nil {
+		registry = DefaultRegistry
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		families, err := registry.Gather(r.Context())
+		w.Header().Set("Content-Type", ContentType)
+		w.Header().Set("Cache-Control", "no-store")
+		_ = Write(w, families)
+		if err != nil {
+			for _, line := range strings.Split(err.Error(), "\n") {
+				_, _ = io.WriteString(w, "# collector error: "+line+"\n")
+			}
+		}
+	})
+}
+
+// Write 以 Prometheus 文本格式写入指标
+func Write(w io.Writer, families []MetricFamily) error {
+	b := bufio.NewWriter(w)
+	for _, mf := range families {
+		if mf.Help != "" {
+			b.WriteString("# HELP " + mf.Name + " " + helpEscaper.Replace(mf.Help) + "\n")
+		}
+		b.WriteString("# TYPE " + mf.Name + " " + mf.Type + "\n")
+		for _, sample := range mf.Samples {
+			if sample.Histogram == nil {
+				writeSample(b, mf.Name, sample.Labels, "", "", sample.Value)
+				continue
+			}
+			for _, bucket := range sample.Histogram.Buckets {
+				writeSample(b, mf.Name+"_bucket", sample.Labels, "le", formatFloat(bucket.UpperBound), float64(bucket.Count))
+			}
+			writeSample(b, mf.Name+"_bucket", sample.Labels, "le", "+Inf", float64(sample.Histogram.Count))
+			writeSample(b, mf.Name+"_sum", sample.Labels, "", "", sample.Histogram.Sum)
+			writeSample(b, mf.Name+"_count", sample.Labels, "", "", float64(sample.Histogram.Count))
+		}
+	}
+	return b.Flush()
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// writeSample 写入一行样本，extraName 不为空时追加一个标签（直方图的 le）
+func writeSample(b *bufio.Writer, name string, labels map[string]string, extraName, extraValue string, value float64) {
+	b.WriteString(name)
+	names := make([]string, 0, len(labels))
+	for label := range labels {
+		names = append(names, label)
+	}
+	sort.Strings(names)
+	if len(names) > 0 || extraName != "" {
+		b.WriteByte('{')
+		for i, label := range names {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(label + `="` + labelEscaper.Replace(labels[label]) + `"`)
+		}
+		if extraName != "" {
+			if len(names) > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(extraName + `="` + extraValue + `"`)
+		}
+		b.WriteByte('}')
+	}
+	b.WriteString(" " + formatFloat(value) + "\n")
+}
+
+// formatFloat 格式化样本值
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
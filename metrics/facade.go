@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"github.com/cnote0/laraveldoc/facade"
+)
+
+// FacadeObserver 门面调用观察者，记录 facade_calls_total 和 facade_call_duration_seconds，
+// 标签为门面访问器和方法名，调用次数另有 status 标签（ok 或 error）
+//
+// 使用示例：
+//
+//	facade.Observe(metrics.FacadeObserver(registry))
+//	facade.Observe(facade.Sample(0.05, metrics.FacadeObserver(registry)))
+func FacadeObserver(registry MetricsRegistry) facade.CallObserver {
+	calls := registry.Counter("facade_calls_total", "Total number of facade method calls.", "facade", "method", "status")
+	duration := registry.Histogram("facade_call_duration_seconds", "Facade method call duration in seconds.", nil, "facade", "method")
+	return func(accessor string, record facade.CallRecord) {
+		status := "ok"
+		if record.Error != nil {
+			status = "error"
+		}
+		calls.With(accessor, record.Method, status).Inc()
+		duration.With(accessor, record.Method).ObserveDuration(record.Duration)
+	}
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HTTPMetrics HTTP 请求指标
+//
+// 记录 http_requests_total 和 http_request_duration_seconds，标签为路由名称、请求方法和状态码。
+// 路由名称依次取自 SetRouteName 设置的名称和 http.ServeMux 匹配的模式，都没有时为 unmatched，
+// 不使用原始路径以免标签数量无限增长。
+type HTTPMetrics struct {
+	requests *CounterVec
+	duration *HistogramVec
+	inflight *GaugeVec
+}
+
+// NewHTTPMetrics 创建 HTTP 请求指标，buckets 为空时使用 DefaultBuckets
+func NewHTTPMetrics(registry MetricsRegistry, buckets ...float64) *HTTPMetrics {
+	return &HTTPMetrics{
+		requests: registry.Counter("http_requests_total", "Total number of HTTP requests.", "route", "method", "status"),
+		duration: registry.Histogram("http_request_duration_seconds", "HTTP request latency in seconds.", buckets, "route", "method", "status"),
+		inflight: registry.Gauge("http_requests_in_flight", "Number of HTTP requests being served."),
+	}
+}
+
+// Middleware 记录请求指标的中间件，panic 的请求按 500 记录后继续抛出
+func (m *HTTPMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		name := new(string)
+		r = r.WithContext(context.WithValue(r.Context(), routeNameKey{}, name))
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		m.inflight.With().Inc()
+
+		defer func() {
+			m.inflight.With().Dec()
+			recovered := recover()
+			status := recorder.status
+			if recovered != nil && !recorder.wroteHeader {
+				status = http.StatusInternalServerError
+			}
+			route := *name
+			if route == "" {
+				route = r.Pattern
+			}
+			if route == "" {
+				route = "unmatched"
+			}
+			labels := []string{route, r.Method, strconv.Itoa(status)}
+			m.requests.With(labels...).Inc()
+			m.duration.With(labels...).ObserveDuration(time.Since(start))
+			if recovered != nil {
+				panic(recovered)
+			}
+		}()
+		next.ServeHTTP(recorder, r)
+	})
+}
+
+type routeNameKey struct{}
+
+// SetRouteName 设置当前请求在指标中使用的路由名称，应在路由匹配后调用
+//
+// ctx 不是经过 HTTPMetrics.Middleware 的请求 ctx 时忽略。
+func SetRouteName(ctx context.Context, name string) {
+	if holder, ok := ctx.Value(routeNameKey{}).(*string); ok {
+		*holder = name
+	}
+}
+
+// statusRecorder 记录响应状态码
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader 记录状态码
+func (w *statusRecorder) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status, w.wroteHeader = status, true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write 写入正文
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush 支持流式响应
+func (w *statusRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap 供 http.ResponseController 获取原始 ResponseWriter
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,144 @@
+// Package metrics 提供 Prometheus 格式的应用指标
+//
+// 指标注册到 MetricsRegistry，由 Handler 以 Prometheus 文本格式输出，
+// 挂载到 /metrics 路由供 Prometheus 抓取。除了应用自定义的计数器、仪表和直方图，
+// 本包还为 HTTP 请求、数据库、缓存、队列和事件提供内置的指标收集。
+//
+// 主要特性：
+// - Counter、Gauge、Histogram 指标和标签
+// - Collector 在抓取时计算的指标（连接池状态、队列深度等）
+// - HTTP 请求耗时和状态码（按路由名称）
+// - 数据库查询耗时、错误和连接池状态
+// - 缓存命中、未命中和命中率
+// - 队列深度、任务吞吐量和处理耗时
+// - 事件派发次数
+// - 门面调用次数、错误和耗时
+//
+// 包结构：
+// - metrics.go - MetricsRegistry 注册表接口、Collector 收集器接口和指标数据结构
+// - registry.go - Registry 注册表实现
+// - vec.go - CounterVec、GaugeVec、HistogramVec 带标签的指标
+// - exposition.go - Prometheus 文本格式输出和 /metrics 处理器
+// - http.go - HTTPMetrics 请求指标中间件
+// - database.go - QueryLogger 查询指标和连接池收集器
+// - cache.go - 缓存命中率指标
+// - queue.go - 队列深度和任务吞吐量指标
+// - events.go - 事件派发指标
+// - facade.go - 门面调用指标
+//
+// 使用示例：
+//
+//	registry := metrics.NewRegistry()
+//
+//	orders := registry.Counter("orders_placed_total", "Number of placed orders.", "channel")
+//	orders.With("web").Inc()
+//
+//	handler := metrics.NewHTTPMetrics(registry).Middleware(mux)
+//	db = db.Session(&database.SessionConfig{Logger: metrics.NewQueryLogger(registry, nil)})
+//	metrics.RegisterDatabase(registry, "mysql", db)
+//	store = metrics.CacheStore(registry, "redis", store)
+//	metrics.RegisterQueueDepth(registry, manager, map[string][]string{"redis": {"high", "default"}})
+//	metrics.WatchQueue(registry, worker)
+//	events = metrics.EventDispatcher(registry, events)
+//	facade.Observe(metrics.FacadeObserver(registry))
+//
+//	mux.Handle("GET /metrics", metrics.Handler(registry))
+package metrics
+
+import (
+	"context"
+)
+
+// 指标类型
+const (
+	TypeCounter   = "counter"
+	TypeGauge     = "gauge"
+	TypeHistogram = "histogram"
+)
+
+// DefaultBuckets 默认直方图分桶（秒），与 Prometheus 客户端一致
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// DefaultRegistry 默认注册表
+var DefaultRegistry = NewRegistry()
+
+// MetricsRegistry 指标注册表接口
+//
+// 同名指标重复注册时返回已注册的指标，类型或标签不一致时 panic。
+type MetricsRegistry interface {
+	// Counter 注册只增不减的计数器
+	Counter(name, help string, labels ...string) *CounterVec
+
+	// Gauge 注册可增可减的仪表
+	Gauge(name, help string, labels ...string) *GaugeVec
+
+	// Histogram 注册直方图，buckets 为空时使用 DefaultBuckets
+	Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec
+
+	// Register 注册在抓取时计算指标的收集器
+	Register(collector Collector)
+
+	// Gather 收集所有指标，按名称排序
+	Gather(ctx context.Context) ([]MetricFamily, error)
+}
+
+// Collector 收集器，在每次抓取时计算指标
+type Collector interface {
+	Collect(ctx context.Context) ([]MetricFamily, error)
+}
+
+// CollectorFunc 函数形式的收集器
+type CollectorFunc func(ctx context.Context) ([]MetricFamily, error)
+
+// Collect 调用函数本身
+func (f CollectorFunc) Collect(ctx context.Context) ([]MetricFamily, error) {
+	return f(ctx)
+}
+
+// MetricFamily 同名指标的集合
+type MetricFamily struct {
+	// Name 指标名称
+	Name string
+
+	// Help 说明
+	Help string
+
+	// Type 指标类型
+	Type string
+
+	// Samples 样本
+	Samples []Sample
+}
+
+// Sample 一个样本
+type Sample struct {
+	// Labels 标签
+	Labels map[string]string
+
+	// Value 计数器和仪表的值
+	Value float64
+
+	// Histogram 直方图的值，只有直方图指标设置
+	Histogram *HistogramValue
+}
+
+// HistogramValue 直方图的值
+type HistogramValue struct {
+	// Buckets 各分桶上限和累计数量
+	Buckets []Bucket
+
+	// Count 观测次数
+	Count uint64
+
+	// Sum 观测值之和
+	Sum float64
+}
+
+// Bucket 直方图分桶
+type Bucket struct {
+	// UpperBound 上限（包含）
+	UpperBound float64
+
+	// Count 不大于上限的观测次数
+	Count uint64
+}
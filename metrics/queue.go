@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// RegisterQueueDepth 注册队列深度收集器，抓取时查询 queues 中每个连接和队列的待处理任务数量
+//
+// 记录为 queue_depth，标签为连接和队列名称。
+func RegisterQueueDepth(registry MetricsRegistry, manager queue.Manager, queues map[string][]string) {
+	connections := make([]string, 0, len(queues))
+	for connection := range queues {
+		connections = append(connections, connection)
+	}
+	sort.Strings(connections)
+
+	registry.Register(CollectorFunc(func(ctx context.Context) ([]MetricFamily, error) {
+		family := MetricFamily{Name: "queue_depth", Help: "Number of pending jobs on the queue.", Type: TypeGauge}
+		var errs []error
+		for _, connection := range connections {
+			q, err := manager.Connection(connection)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, name := range queues[connection] {
+				size, err := q.Size(ctx, name)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				family.Samples = append(family.Samples, Sample{
+					Labels: map[string]string{"connection": connection, "queue": name},
+					Value:  float64(size),
+				})
+			}
+		}
+		return []MetricFamily{family}, errors.Join(errs...)
+	}))
+}
+
+// WatchQueue 监听 worker 事件，记录任务吞吐量和处理耗时
+//
+// 记录 queue_jobs_processed_total、queue_jobs_failed_total、queue_jobs_released_total
+// 和 queue_job_duration_seconds，标签为连接、队列和任务名称。
+func WatchQueue(registry MetricsRegistry, worker queue.Worker) {
+	processed := registry.Counter("queue_jobs_processed_total", "Total number of successfully processed jobs.", "connection", "queue", "job")
+	failed := registry.Counter("queue_jobs_failed_total", "Total number of failed jobs.", "connection", "queue", "job")
+	released := registry.Counter("queue_jobs_released_total", "Total number of jobs released back onto the queue after an exception.", "connection", "queue", "job")
+	duration := registry.Histogram("queue_job_duration_seconds", "Job processing duration in seconds.", nil, "connection", "queue", "job")
+
+	var mu sync.Mutex
+	started := make(map[string]time.Time)
+	observe := func(job queue.QueuedJob, counter *CounterVec) {
+		labels := []string{job.ConnectionName(), job.Queue(), job.Name()}
+		counter.With(labels...).Inc()
+		key := jobKey(job)
+		mu.Lock()
+		start, ok := started[key]
+		delete(started, key)
+		mu.Unlock()
+		if ok {
+			duration.With(labels...).ObserveDuration(time.Since(start))
+		}
+	}
+
+	worker.Listen(func(ctx context.Context, event interface{}) {
+		switch e := event.(type) {
+		case queue.JobProcessing:
+			mu.Lock()
+			started[jobKey(e.Job)] = time.Now()
+			mu.Unlock()
+		case queue.JobProcessed:
+			observe(e.Job, processed)
+		case queue.JobReleasedAfterException:
+			observe(e.Job, released)
+		case queue.JobFailed:
+			observe(e.Job, failed)
+		}
+	})
+}
+
+// jobKey 任务在 Worker 内的唯一标识
+func jobKey(job queue.QueuedJob) string {
+	return job.ConnectionName() + "\x00" + job.Queue() + "\x00" + job.ID()
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRegexp  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Registry 注册表实现
+type Registry struct {
+	mu         sync.RWMutex
+	families   map[string]*family
+	collectors []Collector
+}
+
+var _ MetricsRegistry = (*Registry)(nil)
+
+// NewRegistry 创建注册表
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// Counter 注册计数器
+func (r *Registry) Counter(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{family: r.register(name, help, TypeCounter, nil, labels)}
+}
+
+// Gauge 注册仪表
+func (r *Registry) Gauge(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{family: r.register(name, help, TypeGauge, nil, labels)}
+}
+
+// Histogram 注册直方图
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = slices.Clone(buckets)
+	sort.Float64s(buckets)
+	return &HistogramVec{family: r.register(name, help, TypeHistogram, buckets, labels)}
+}
+
+// Register 注册收集器
+func (r *Registry) Register(collector Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, collector)
+}
+
+// Gather 收集所有指标
+//
+// 收集器出错时跳过该收集器并继续，返回已收集的指标和合并后的错误。
+// 收集器返回与已注册指标同名的指标时合并样本。
+func (r *Registry) Gather(ctx context.Context) ([]MetricFamily, error) {
+	r.mu.RLock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	collectors := slices.Clone(r.collectors)
+	r.mu.RUnlock()
+
+	byName := make(map[string]*MetricFamily, len(families))
+	var result []*MetricFamily
+	for _, f := range families {
+		mf := f.gather()
+		byName[mf.Name] = &mf
+		result = append(result, &mf)
+	}
+
+	var errs []error
+	for _, collector := range collectors {
+		collected, err := collector.Collect(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, mf := range collected {
+			if existing, ok := byName[mf.Name]; ok {
+				existing.Samples = append(existing.Samples, mf.Samples...)
+				continue
+			}
+			mf := mf
+			byName[mf.Name] = &mf
+			result = append(result, &mf)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	gathered := make([]MetricFamily, len(result))
+	for i, mf := range result {
+		gathered[i] = *mf
+	}
+	return gathered, errors.Join(errs...)
+}
+
+// register 注册指标，同名指标已存在时返回已注册的指标
+func (r *Registry) register(name, help, typ string, buckets []float64, labels []string) *family {
+	if !metricNameRegexp.MatchString(name) {
+		panic(fmt.Sprintf("metrics: invalid metric name %q", name))
+	}
+	for _, label := range labels {
+		if !labelNameRegexp.MatchString(label) || strings.HasPrefix(label, "__") || (typ == TypeHistogram && label == "le") {
+			panic(fmt.Sprintf("metrics: invalid label name %q for metric %s", label, name))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.families[name]; ok {
+		if existing.typ != typ || !slices.Equal(existing.labels, labels) || !slices.Equal(existing.buckets, buckets) {
+			panic(fmt.Sprintf("metrics: metric %s is already registered as %s with labels %v", name, existing.typ, existing.labels))
+		}
+		return existing
+	}
+	f := &family{
+		name:     name,
+		help:     help,
+		typ:      typ,
+		labels:   slices.Clone(labels),
+		buckets:  buckets,
+		children: make(map[string]sampler),
+	}
+	r.families[name] = f
+	return f
+}
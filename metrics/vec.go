@@ -0,0 +1,222 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// family 一个已注册的指标和它的所有标签组合
+type family struct {
+	name    string
+	help    string
+	typ     string
+	labels  []string
+	buckets []float64
+
+	mu       sync.RWMutex
+	children map[string]sampler
+}
+
+// sampler 一组标签值对应的指标
+type sampler interface {
+	sample(labels map[string]string) Sample
+}
+
+// child 获取标签值对应的指标，不存在时用 create 创建
+func (f *family) child(values []string, create func() sampler) sampler {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: metric %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	f.mu.RLock()
+	s, ok := f.children[key]
+	f.mu.RUnlock()
+	if ok {
+		return s
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.children[key]; ok {
+		return s
+	}
+	s = create()
+	f.children[key] = s
+	return s
+}
+
+// gather 转换为 MetricFamily，样本按标签值排序
+func (f *family) gather() MetricFamily {
+	f.mu.RLock()
+	keys := make([]string, 0, len(f.children))
+	for key := range f.children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	samples := make([]Sample, 0, len(keys))
+	for _, key := range keys {
+		labels := make(map[string]string, len(f.labels))
+		if len(f.labels) > 0 {
+			for i, value := range strings.Split(key, "\xff") {
+				labels[f.labels[i]] = value
+			}
+		}
+		samples = append(samples, f.children[key].sample(labels))
+	}
+	f.mu.RUnlock()
+	return MetricFamily{Name: f.name, Help: f.help, Type: f.typ, Samples: samples}
+}
+
+// CounterVec 带标签的计数器
+type CounterVec struct {
+	family *family
+}
+
+// With 获取标签值对应的计数器，标签值按注册时的标签顺序传入
+func (v *CounterVec) With(values ...string) *Counter {
+	return v.family.child(values, func() sampler { return &Counter{} }).(*Counter)
+}
+
+// Counter 计数器
+type Counter struct {
+	bits atomic.Uint64
+}
+
+// Inc 加 1
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add 增加，value 为负数时 panic
+func (c *Counter) Add(value float64) {
+	if value < 0 {
+		panic("metrics: counter cannot decrease")
+	}
+	addFloat(&c.bits, value)
+}
+
+// Value 当前值
+func (c *Counter) Value() float64 {
+	return math.Float64frombits(c.bits.Load())
+}
+
+func (c *Counter) sample(labels map[string]string) Sample {
+	return Sample{Labels: labels, Value: c.Value()}
+}
+
+// GaugeVec 带标签的仪表
+type GaugeVec struct {
+	family *family
+}
+
+// With 获取标签值对应的仪表
+func (v *GaugeVec) With(values ...string) *Gauge {
+	return v.family.child(values, func() sampler { return &Gauge{} }).(*Gauge)
+}
+
+// Gauge 仪表
+type Gauge struct {
+	bits atomic.Uint64
+}
+
+// Set 设置值
+func (g *Gauge) Set(value float64) {
+	g.bits.Store(math.Float64bits(value))
+}
+
+// Inc 加 1
+func (g *Gauge) Inc() {
+	g.Add(1)
+}
+
+// Dec 减 1
+func (g *Gauge) Dec() {
+	g.Add(-1)
+}
+
+// Add 增加，value 可以为负数
+func (g *Gauge) Add(value float64) {
+	addFloat(&g.bits, value)
+}
+
+// Value 当前值
+func (g *Gauge) Value() float64 {
+	return math.Float64frombits(g.bits.Load())
+}
+
+func (g *Gauge) sample(labels map[string]string) Sample {
+	return Sample{Labels: labels, Value: g.Value()}
+}
+
+// HistogramVec 带标签的直方图
+type HistogramVec struct {
+	family *family
+}
+
+// With 获取标签值对应的直方图
+func (v *HistogramVec) With(values ...string) *Histogram {
+	buckets := v.family.buckets
+	return v.family.child(values, func() sampler {
+		return &Histogram{buckets: buckets, counts: make([]uint64, len(buckets))}
+	}).(*Histogram)
+}
+
+// Histogram 直方图
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+// Observe 记录一次观测
+func (h *Histogram) Observe(value float64) {
+	i := sort.SearchFloat64s(h.buckets, value)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if i < len(h.counts) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += value
+}
+
+// ObserveDuration 以秒为单位记录耗时
+func (h *Histogram) ObserveDuration(d time.Duration) {
+	h.Observe(d.Seconds())
+}
+
+// Value 当前值，分桶数量为累计值
+func (h *Histogram) Value() HistogramValue {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	value := HistogramValue{Buckets: make([]Bucket, len(h.buckets)), Count: h.count, Sum: h.sum}
+	var cumulative uint64
+	for i, upper := range h.buckets {
+		cumulative += h.counts[i]
+		value.Buckets[i] = Bucket{UpperBound: upper, Count: cumulative}
+	}
+	return value
+}
+
+func (h *Histogram) sample(labels map[string]string) Sample {
+	value := h.Value()
+	return Sample{Labels: labels, Histogram: &value}
+}
+
+// addFloat 原子地为以位模式保存的浮点数增加 value
+func addFloat(bits *atomic.Uint64, value float64) {
+	for {
+		old := bits.Load()
+		if bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+value)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	nextType    = reflect.TypeOf(Next(nil))
+	stringType  = reflect.TypeOf("")
+)
+
+// invoke 通过反射调用对象阶段的方法
+func invoke(ctx context.Context, target interface{}, method string, passable interface{}, next Next, params []string, carried *error) (interface{}, error) {
+	fn := reflect.ValueOf(target).MethodByName(method)
+	if !fn.IsValid() {
+		return nil, fmt.Errorf("pipeline: %T has no method %s", target, method)
+	}
+	ft := fn.Type()
+
+	in := 0
+	var args []reflect.Value
+	if ft.NumIn() > 0 && ft.In(0) == contextType {
+		args = append(args, reflect.ValueOf(&ctx).Elem())
+		in++
+	}
+	if ft.NumIn() < in+2 {
+		return nil, fmt.Errorf("pipeline: %T.%s must accept passable and next", target, method)
+	}
+	value, err := valueFor(passable, ft.In(in))
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: %T.%s: %w", target, method, err)
+	}
+	nextValue, err := adaptNext(ft.In(in+1), ctx, next, carried)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline: %T.%s: %w", target, method, err)
+	}
+	args = append(args, value, nextValue)
+	in += 2
+
+	// 固定的字符串参数缺少时传空字符串，可变参数接收剩余的全部参数
+	fixed := ft.NumIn()
+	if ft.IsVariadic() {
+		fixed--
+	}
+	for ; in < fixed; in++ {
+		if ft.In(in) != stringType {
+			return nil, fmt.Errorf("pipeline: %T.%s: parameter %d must be string", target, method, in)
+		}
+		param := ""
+		if len(params) > 0 {
+			param, params = params[0], params[1:]
+		}
+		args = append(args, reflect.ValueOf(param))
+	}
+	if ft.IsVariadic() {
+		if ft.In(fixed).Elem() != stringType {
+			return nil, fmt.Errorf("pipeline: %T.%s: variadic parameter must be ...string", target, method)
+		}
+		for _, param := range params {
+			args = append(args, reflect.ValueOf(param))
+		}
+	}
+
+	return results(fn.Call(args))
+}
+
+// results 把方法返回值转换为 (interface{}, error)
+func results(out []reflect.Value) (interface{}, error) {
+	var result interface{}
+	var err error
+	for _, v := range out {
+		if v.Type() == errorType {
+			err, _ = v.Interface().(error)
+			continue
+		}
+		result = v.Interface()
+	}
+	return result, err
+}
+
+// valueFor 把值转换为参数类型，nil 转换为零值
+func valueFor(v interface{}, t reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		return reflect.Zero(t), nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Type().AssignableTo(t) {
+		if t.Kind() == reflect.Interface {
+			converted := reflect.New(t).Elem()
+			converted.Set(rv)
+			return converted, nil
+		}
+		return rv, nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %T as %s", v, t)
+}
+
+// adaptNext 把 Next 适配为方法期望的 next 函数类型
+//
+// 函数类型不能返回错误时，内层的错误记录到 carried，由 Then 返回。
+func adaptNext(t reflect.Type, ctx context.Context, next Next, carried *error) (reflect.Value, error) {
+	if t == nextType {
+		return reflect.ValueOf(next), nil
+	}
+	if t.Kind() != reflect.Func || t.NumIn() < 1 || t.NumIn() > 2 || (t.NumIn() == 2 && t.In(0) != contextType) {
+		return reflect.Value{}, fmt.Errorf("next must be pipeline.Next or func([context.Context,] passable) result")
+	}
+	returnsError := false
+	for i := 0; i < t.NumOut(); i++ {
+		returnsError = returnsError || t.Out(i) == errorType
+	}
+	return reflect.MakeFunc(t, func(in []reflect.Value) []reflect.Value {
+		callCtx := ctx
+		if t.NumIn() == 2 {
+			if c, ok := in[0].Interface().(context.Context); ok {
+				callCtx = c
+			}
+		}
+		result, err := next(callCtx, in[len(in)-1].Interface())
+		if err != nil && !returnsError && *carried == nil {
+			*carried = err
+		}
+		out := make([]reflect.Value, t.NumOut())
+		for i := range out {
+			ot := t.Out(i)
+			if ot == errorType {
+				out[i] = reflect.Zero(ot)
+				if err != nil {
+					out[i] = reflect.ValueOf(&err).Elem()
+				}
+				continue
+			}
+			value, convErr := valueFor(result, ot)
+			if convErr != nil {
+				value = reflect.Zero(ot)
+			}
+			out[i] = value
+		}
+		return out
+	}), nil
+}
@@ -0,0 +1,166 @@
+// Package pipeline 提供 Laravel 风格的管道，对应 Illuminate\Pipeline\Pipeline
+//
+// 管道把一个对象（passable）依次传过一组阶段（pipe），每个阶段可以在调用 next 前后加入逻辑，
+// 也可以不调用 next 直接返回结果，最后到达目的地（destination）。HTTP 中间件、命令总线管道和
+// 队列任务中间件都是管道。
+//
+// Pipeline 是动态版本，阶段可以是：
+// - Func 或同签名的函数
+// - 字符串 "name" 或 "name:param1,param2"，从容器解析 name，参数作为额外的字符串实参传给方法
+// - 任意对象，通过反射调用 Via 指定的方法（默认 Handle）
+//
+// 反射调用的方法签名为 Handle([ctx context.Context,] passable P, next N[, params ...string])，
+// P 只要能接收 passable 即可，N 可以是 Next 或形如 func([ctx,] P) (R[, error]) 的任意函数类型，
+// 返回值可以是 (R, error)、R 或 error。
+//
+// Compose 是泛型版本，阶段和目的地的类型在编译期检查，没有反射开销，
+// bus、queue 和 routing 的中间件链都基于它构建。
+//
+// 包结构：
+// - pipeline.go - Pipeline 动态管道和 Func 阶段
+// - invoke.go - 对象阶段的反射调用和 next 适配
+// - typed.go - Handler、Pipe 泛型阶段和 Compose
+//
+// 使用示例：
+//
+//	result, err := pipeline.New(app).
+//		WithContext(ctx).
+//		Send(order).
+//		Through("validate", "discount:10", &ApplyTax{}, pipeline.Func(logStage)).
+//		Via("Handle").
+//		Then(func(ctx context.Context, passable interface{}) (interface{}, error) {
+//			return checkout(ctx, passable.(*Order))
+//		})
+//
+//	// 泛型版本
+//	handler := pipeline.Compose([]pipeline.Pipe[*Order, *Receipt]{validate, discount}, checkout)
+//	receipt, err := handler(ctx, order)
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// ErrNoContainer 使用字符串阶段但没有设置容器
+var ErrNoContainer = errors.New("pipeline: no container to resolve string pipes")
+
+// Next 调用管道中的下一层
+type Next func(ctx context.Context, passable interface{}) (interface{}, error)
+
+// Func 函数形式的阶段
+type Func func(ctx context.Context, passable interface{}, next Next) (interface{}, error)
+
+// Pipeline 动态管道
+//
+// Pipeline 是一次性的构建器，不应在多个 goroutine 中同时修改。
+type Pipeline struct {
+	container container.Container
+	ctx       context.Context
+	passable  interface{}
+	pipes     []interface{}
+	method    string
+}
+
+// New 创建管道，container 用于解析字符串阶段，可以为 nil
+func New(c container.Container) *Pipeline {
+	return &Pipeline{container: c, ctx: context.Background(), method: "Handle"}
+}
+
+// WithContext 设置传给各阶段的上下文
+func (p *Pipeline) WithContext(ctx context.Context) *Pipeline {
+	p.ctx = ctx
+	return p
+}
+
+// Send 设置要传过管道的对象
+func (p *Pipeline) Send(passable interface{}) *Pipeline {
+	p.passable = passable
+	return p
+}
+
+// Through 设置阶段，按顺序由外到内执行
+func (p *Pipeline) Through(pipes ...interface{}) *Pipeline {
+	p.pipes = append([]interface{}{}, pipes...)
+	return p
+}
+
+// Pipe 追加阶段
+func (p *Pipeline) Pipe(pipes ...interface{}) *Pipeline {
+	p.pipes = append(p.pipes, pipes...)
+	return p
+}
+
+// Via 设置对象阶段调用的方法名，默认 Handle
+func (p *Pipeline) Via(method string) *Pipeline {
+	p.method = method
+	return p
+}
+
+// Then 依次通过所有阶段后调用 destination，返回最外层阶段的结果
+//
+// 如果某个阶段的 next 函数类型不能返回错误，内层产生的错误会在这里返回。
+func (p *Pipeline) Then(destination Next) (interface{}, error) {
+	var carried error
+	handler := destination
+	for i := len(p.pipes) - 1; i >= 0; i-- {
+		handler = p.carry(p.pipes[i], handler, &carried)
+	}
+	result, err := handler(p.ctx, p.passable)
+	if err == nil {
+		err = carried
+	}
+	return result, err
+}
+
+// ThenReturn 通过所有阶段后返回最终的对象
+func (p *Pipeline) ThenReturn() (interface{}, error) {
+	return p.Then(func(ctx context.Context, passable interface{}) (interface{}, error) {
+		return passable, nil
+	})
+}
+
+// carry 用一个阶段包裹 next
+func (p *Pipeline) carry(pipe interface{}, next Next, carried *error) Next {
+	switch fn := pipe.(type) {
+	case Func:
+		return func(ctx context.Context, passable interface{}) (interface{}, error) {
+			return fn(ctx, passable, next)
+		}
+	case func(context.Context, interface{}, Next) (interface{}, error):
+		return func(ctx context.Context, passable interface{}) (interface{}, error) {
+			return fn(ctx, passable, next)
+		}
+	}
+	return func(ctx context.Context, passable interface{}) (interface{}, error) {
+		target, params := pipe, []string(nil)
+		if name, ok := pipe.(string); ok {
+			var err error
+			if target, params, err = p.resolve(name); err != nil {
+				return nil, err
+			}
+		}
+		return invoke(ctx, target, p.method, passable, next, params, carried)
+	}
+}
+
+// resolve 从容器解析 "name:param1,param2" 形式的阶段
+func (p *Pipeline) resolve(pipe string) (interface{}, []string, error) {
+	if p.container == nil {
+		return nil, nil, ErrNoContainer
+	}
+	name, rest, found := strings.Cut(pipe, ":")
+	var params []string
+	if found {
+		params = strings.Split(rest, ",")
+	}
+	target, err := p.container.Make(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pipeline: resolve pipe [%s]: %w", name, err)
+	}
+	return target, params, nil
+}
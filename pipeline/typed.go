@@ -0,0 +1,31 @@
+package pipeline
+
+import "context"
+
+// Handler 泛型管道中的处理函数，也是目的地的类型
+type Handler[T, R any] func(ctx context.Context, passable T) (R, error)
+
+// Pipe 泛型阶段
+type Pipe[T, R any] interface {
+	Handle(ctx context.Context, passable T, next Handler[T, R]) (R, error)
+}
+
+// PipeFunc 函数形式的泛型阶段
+type PipeFunc[T, R any] func(ctx context.Context, passable T, next Handler[T, R]) (R, error)
+
+// Handle 调用函数本身
+func (f PipeFunc[T, R]) Handle(ctx context.Context, passable T, next Handler[T, R]) (R, error) {
+	return f(ctx, passable, next)
+}
+
+// Compose 用阶段包裹目的地，pipes[0] 在最外层
+func Compose[T, R any](pipes []Pipe[T, R], destination Handler[T, R]) Handler[T, R] {
+	handler := destination
+	for i := len(pipes) - 1; i >= 0; i-- {
+		pipe, next := pipes[i], handler
+		handler = func(ctx context.Context, passable T) (R, error) {
+			return pipe.Handle(ctx, passable, next)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,271 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrStrayProcess Fake 开启 PreventStrayProcesses 后运行了没有匹配桩的命令
+var ErrStrayProcess = errors.New("process: attempted process without a matching fake")
+
+// Stub 桩，根据命令生成结果
+type Stub func(process *RecordedProcess) (*Result, error)
+
+// TestingT Fake 断言使用的测试接口，*testing.T 满足该接口
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// FakeResult 创建返回固定结果的桩
+func FakeResult(output string, exitCode int, errorOutput ...string) Stub {
+	return func(process *RecordedProcess) (*Result, error) {
+		return &Result{
+			command:     process.Command,
+			exitCode:    exitCode,
+			output:      output,
+			errorOutput: strings.Join(errorOutput, ""),
+		}, nil
+	}
+}
+
+// Sequence 按顺序返回的桩结果
+type Sequence struct {
+	mu        sync.Mutex
+	stubs     []Stub
+	whenEmpty Stub
+}
+
+// NewSequence 创建结果序列
+func NewSequence(stubs ...Stub) *Sequence {
+	return &Sequence{stubs: stubs}
+}
+
+// Push 追加固定结果
+func (s *Sequence) Push(output string, exitCode int, errorOutput ...string) *Sequence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stubs = append(s.stubs, FakeResult(output, exitCode, errorOutput...))
+	return s
+}
+
+// WhenEmpty 序列用完后使用的桩，未设置时序列用完会返回错误
+func (s *Sequence) WhenEmpty(stub Stub) *Sequence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.whenEmpty = stub
+	return s
+}
+
+// Stub 把序列转换为桩
+func (s *Sequence) Stub() Stub {
+	return func(process *RecordedProcess) (*Result, error) {
+		s.mu.Lock()
+		if len(s.stubs) == 0 {
+			whenEmpty := s.whenEmpty
+			s.mu.Unlock()
+			if whenEmpty == nil {
+				return nil, errors.New("process: fake result sequence is empty")
+			}
+			return whenEmpty(process)
+		}
+		stub := s.stubs[0]
+		s.stubs = s.stubs[1:]
+		s.mu.Unlock()
+		return stub(process)
+	}
+}
+
+// RecordedProcess Fake 记录的进程
+type RecordedProcess struct {
+	Command string
+	Path    string
+	Env     map[string]string
+	Result  *Result
+}
+
+// fakeRule 命令模式和桩
+type fakeRule struct {
+	pattern string
+	stub    Stub
+}
+
+// Fake 测试用的进程桩，对应 Laravel 的 Process::fake
+//
+// 命令按添加顺序匹配第一个模式，模式中的 * 匹配任意字符。没有匹配的命令返回输出为空、状态码为 0 的结果。
+type Fake struct {
+	mu       sync.Mutex
+	rules    []fakeRule
+	recorded []*RecordedProcess
+	strict   bool
+}
+
+// Fake 把工厂切换为 Fake，之后运行的进程都不会真正执行
+func (f *Factory) Fake() *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fake == nil {
+		f.fake = &Fake{}
+	}
+	return f.fake
+}
+
+// Stub 为匹配 pattern 的命令注册桩，pattern 为 "*" 时匹配全部命令
+func (f *Fake) Stub(pattern string, stub Stub) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = append(f.rules, fakeRule{pattern: pattern, stub: stub})
+	return f
+}
+
+// PreventStrayProcesses 没有匹配桩的命令返回 ErrStrayProcess
+func (f *Fake) PreventStrayProcesses() *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.strict = true
+	return f
+}
+
+// start 记录进程并返回已结束的 Running，输出回调按行收到桩的输出
+func (f *Fake) start(p *PendingProcess, command []string) (*Running, error) {
+	recorded := &RecordedProcess{Command: commandString(command), Path: p.dir, Env: p.env}
+
+	f.mu.Lock()
+	var stub Stub
+	for _, rule := range f.rules {
+		if matchPattern(rule.pattern, recorded.Command) {
+			stub = rule.stub
+			break
+		}
+	}
+	strict := f.strict
+	f.mu.Unlock()
+
+	var result *Result
+	var err error
+	switch {
+	case stub != nil:
+		result, err = stub(recorded)
+	case strict:
+		err = fmt.Errorf("%w: %s", ErrStrayProcess, recorded.Command)
+	default:
+		result, err = FakeResult("", 0)(recorded)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.command = recorded.Command
+	recorded.Result = result
+
+	f.mu.Lock()
+	f.recorded = append(f.recorded, recorded)
+	f.mu.Unlock()
+
+	if p.onOutput != nil {
+		for _, writer := range []*lineWriter{
+			{stream: Stdout, quiet: true, callback: p.onOutput},
+			{stream: Stderr, quiet: true, callback: p.onOutput},
+		} {
+			output := result.output
+			if writer.stream == Stderr {
+				output = result.errorOutput
+			}
+			_, _ = writer.Write([]byte(output))
+			writer.flush()
+		}
+	}
+	running := &Running{command: recorded.Command, done: make(chan struct{}), result: result}
+	close(running.done)
+	return running, nil
+}
+
+// Recorded 返回满足条件的已运行进程，filter 为 nil 时返回全部
+func (f *Fake) Recorded(filter func(p *RecordedProcess) bool) []*RecordedProcess {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []*RecordedProcess
+	for _, p := range f.recorded {
+		if filter == nil || filter(p) {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+// AssertRan 断言运行过匹配 pattern 的命令
+func (f *Fake) AssertRan(t TestingT, pattern string) bool {
+	t.Helper()
+	return f.AssertRanMatching(t, commandMatches(pattern))
+}
+
+// AssertRanMatching 断言运行过满足条件的命令
+func (f *Fake) AssertRanMatching(t TestingT, filter func(p *RecordedProcess) bool) bool {
+	t.Helper()
+	if len(f.Recorded(filter)) == 0 {
+		t.Errorf("process: an expected process was not invoked")
+		return false
+	}
+	return true
+}
+
+// AssertRanTimes 断言匹配 pattern 的命令运行了 times 次
+func (f *Fake) AssertRanTimes(t TestingT, pattern string, times int) bool {
+	t.Helper()
+	if n := len(f.Recorded(commandMatches(pattern))); n != times {
+		t.Errorf("process: expected process [%s] to run [%d] times, but it ran [%d] times", pattern, times, n)
+		return false
+	}
+	return true
+}
+
+// AssertNotRan 断言没有运行过匹配 pattern 的命令
+func (f *Fake) AssertNotRan(t TestingT, pattern string) bool {
+	t.Helper()
+	if len(f.Recorded(commandMatches(pattern))) > 0 {
+		t.Errorf("process: an unexpected process [%s] was invoked", pattern)
+		return false
+	}
+	return true
+}
+
+// AssertNothingRan 断言没有运行任何命令
+func (f *Fake) AssertNothingRan(t TestingT) bool {
+	t.Helper()
+	if n := len(f.Recorded(nil)); n > 0 {
+		t.Errorf("process: expected no processes to run, but [%d] were invoked", n)
+		return false
+	}
+	return true
+}
+
+// commandMatches 按模式匹配命令的过滤函数
+func commandMatches(pattern string) func(p *RecordedProcess) bool {
+	return func(p *RecordedProcess) bool {
+		return matchPattern(pattern, p.Command)
+	}
+}
+
+// matchPattern 匹配只含 * 通配符的模式
+func matchPattern(pattern, value string) bool {
+	if pattern == "*" {
+		return true
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(value, part)
+		}
+		index := strings.Index(value, part)
+		if index < 0 {
+			return false
+		}
+		value = value[index+len(part):]
+	}
+	return value == ""
+}
@@ -0,0 +1,235 @@
+package process
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PendingProcess 待运行的进程，对应 Laravel 的 PendingProcess
+//
+// 链式方法修改并返回同一个实例，不要在多个 goroutine 中共享。
+type PendingProcess struct {
+	factory  *Factory
+	command  []string
+	dir      string
+	timeout  time.Duration
+	env      map[string]string
+	input    io.Reader
+	quiet    bool
+	onOutput func(stream OutputType, line string)
+}
+
+// Command 设置命令，Run 和 Start 未传命令时使用，主要用于 Pool
+func (p *PendingProcess) Command(command ...string) *PendingProcess {
+	p.command = command
+	return p
+}
+
+// Path 设置工作目录
+func (p *PendingProcess) Path(dir string) *PendingProcess {
+	p.dir = dir
+	return p
+}
+
+// Timeout 设置超时，0 表示不限制
+func (p *PendingProcess) Timeout(timeout time.Duration) *PendingProcess {
+	p.timeout = timeout
+	return p
+}
+
+// Forever 不限制运行时间
+func (p *PendingProcess) Forever() *PendingProcess {
+	return p.Timeout(0)
+}
+
+// Env 添加环境变量，与当前进程的环境变量合并
+func (p *PendingProcess) Env(env map[string]string) *PendingProcess {
+	if p.env == nil {
+		p.env = make(map[string]string, len(env))
+	}
+	for name, value := range env {
+		p.env[name] = value
+	}
+	return p
+}
+
+// Input 设置标准输入
+func (p *PendingProcess) Input(input io.Reader) *PendingProcess {
+	p.input = input
+	return p
+}
+
+// InputString 以字符串作为标准输入
+func (p *PendingProcess) InputString(input string) *PendingProcess {
+	return p.Input(strings.NewReader(input))
+}
+
+// Quietly 不保存输出，适合输出量很大的进程，输出回调仍然生效
+func (p *PendingProcess) Quietly() *PendingProcess {
+	p.quiet = true
+	return p
+}
+
+// OnOutput 设置输出回调，按行实时接收标准输出和标准错误，行尾不含换行符
+func (p *PendingProcess) OnOutput(callback func(stream OutputType, line string)) *PendingProcess {
+	p.onOutput = callback
+	return p
+}
+
+// Run 运行进程并等待结束
+//
+// 进程以非 0 状态码退出不视为错误，通过 Result.Failed 或 Result.Throw 判断；
+// 返回的错误表示进程无法启动、超时（ErrTimedOut）或 ctx 被取消。
+func (p *PendingProcess) Run(ctx context.Context, command ...string) (*Result, error) {
+	running, err := p.Start(ctx, command...)
+	if err != nil {
+		return nil, err
+	}
+	return running.Wait()
+}
+
+// Start 异步启动进程
+func (p *PendingProcess) Start(ctx context.Context, command ...string) (*Running, error) {
+	if len(command) == 0 {
+		command = p.command
+	}
+	if len(command) == 0 {
+		return nil, errors.New("process: no command given")
+	}
+	if fake := p.factory.currentFake(); fake != nil {
+		return fake.start(p, command)
+	}
+
+	runCtx, cancel := ctx, context.CancelFunc(func() {})
+	if p.timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, p.timeout)
+	}
+	cmd := buildCommand(runCtx, command)
+	cmd.Dir = p.dir
+	if len(p.env) > 0 {
+		cmd.Env = os.Environ()
+		for name, value := range p.env {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+	}
+	cmd.Stdin = p.input
+	stdout := &lineWriter{stream: Stdout, quiet: p.quiet, callback: p.onOutput}
+	stderr := &lineWriter{stream: Stderr, quiet: p.quiet, callback: p.onOutput}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	// 进程被杀死后，仍持有输出管道的子进程不会阻塞 Wait 超过该时间
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, err
+	}
+	running := &Running{
+		command: commandString(command),
+		process: cmd.Process,
+		stdout:  stdout,
+		stderr:  stderr,
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer cancel()
+		defer close(running.done)
+		err := cmd.Wait()
+		stdout.flush()
+		stderr.flush()
+		running.result = &Result{
+			command:     running.command,
+			exitCode:    cmd.ProcessState.ExitCode(),
+			output:      stdout.String(),
+			errorOutput: stderr.String(),
+		}
+		var exitErr *exec.ExitError
+		switch {
+		case errors.Is(runCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil:
+			running.err = ErrTimedOut
+		case ctx.Err() != nil:
+			running.err = ctx.Err()
+		case err != nil && !errors.As(err, &exitErr):
+			running.err = err
+		}
+	}()
+	return running, nil
+}
+
+// buildCommand 单个字符串通过 sh -c 执行，多个参数直接执行
+func buildCommand(ctx context.Context, command []string) *exec.Cmd {
+	if len(command) == 1 {
+		return exec.CommandContext(ctx, "sh", "-c", command[0])
+	}
+	return exec.CommandContext(ctx, command[0], command[1:]...)
+}
+
+// commandString 命令的字符串形式，用于结果、日志和 Fake 匹配
+func commandString(command []string) string {
+	if len(command) == 1 {
+		return command[0]
+	}
+	parts := make([]string, len(command))
+	for i, arg := range command {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`|&;<>()*?") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		parts[i] = arg
+	}
+	return strings.Join(parts, " ")
+}
+
+// lineWriter 保存输出并按行调用回调
+type lineWriter struct {
+	mu       sync.Mutex
+	stream   OutputType
+	quiet    bool
+	callback func(stream OutputType, line string)
+	buf      bytes.Buffer
+	partial  []byte
+}
+
+// Write 实现 io.Writer
+func (w *lineWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.quiet {
+		w.buf.Write(data)
+	}
+	if w.callback == nil {
+		return len(data), nil
+	}
+	w.partial = append(w.partial, data...)
+	for {
+		index := bytes.IndexByte(w.partial, '\n')
+		if index < 0 {
+			break
+		}
+		w.callback(w.stream, strings.TrimSuffix(string(w.partial[:index]), "\r"))
+		w.partial = w.partial[index+1:]
+	}
+	return len(data), nil
+}
+
+// flush 把最后一个不以换行结尾的行交给回调
+func (w *lineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.callback != nil && len(w.partial) > 0 {
+		w.callback(w.stream, string(w.partial))
+		w.partial = nil
+	}
+}
+
+// String 已保存的输出
+func (w *lineWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
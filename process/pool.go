@@ -0,0 +1,77 @@
+package process
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// PoolResult 池中进程的结果
+type PoolResult struct {
+	Result *Result
+	Err    error
+}
+
+// Pool 并发进程池，对应 Laravel 的 Process::pool
+type Pool struct {
+	factory     *Factory
+	names       []string
+	processes   []*PendingProcess
+	concurrency int
+}
+
+// Add 添加进程，结果以添加顺序的下标（"0"、"1"……）为键，返回的 PendingProcess 可以继续设置
+func (p *Pool) Add(command ...string) *PendingProcess {
+	return p.As(strconv.Itoa(len(p.processes)), command...)
+}
+
+// As 添加命名进程
+func (p *Pool) As(name string, command ...string) *PendingProcess {
+	process := p.factory.New().Command(command...)
+	p.names = append(p.names, name)
+	p.processes = append(p.processes, process)
+	return process
+}
+
+// Concurrency 设置最大并发数，0 表示不限制
+func (p *Pool) Concurrency(n int) *Pool {
+	p.concurrency = n
+	return p
+}
+
+// Pool 并发运行 build 中添加的进程，等待全部结束后按名称返回结果
+func (f *Factory) Pool(ctx context.Context, build func(pool *Pool)) map[string]PoolResult {
+	pool := &Pool{factory: f}
+	build(pool)
+
+	results := make([]PoolResult, len(pool.processes))
+	var sem chan struct{}
+	if pool.concurrency > 0 {
+		sem = make(chan struct{}, pool.concurrency)
+	}
+	var wg sync.WaitGroup
+	for i, process := range pool.processes {
+		wg.Add(1)
+		go func(i int, process *PendingProcess) {
+			defer wg.Done()
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					results[i] = PoolResult{Err: ctx.Err()}
+					return
+				}
+			}
+			result, err := process.Run(ctx)
+			results[i] = PoolResult{Result: result, Err: err}
+		}(i, process)
+	}
+	wg.Wait()
+
+	named := make(map[string]PoolResult, len(results))
+	for i, name := range pool.names {
+		named[name] = results[i]
+	}
+	return named
+}
@@ -0,0 +1,112 @@
+// Package process 提供 Laravel 风格的外部进程调用
+//
+// Factory 创建 PendingProcess，通过链式方法设置工作目录、超时、环境变量和标准输入后
+// 同步运行（Run）或异步启动（Start）。命令只有一个字符串时通过 sh -c 执行，
+// 可以使用管道和重定向；传入多个参数时直接执行，不经过 shell。
+//
+// 主要特性：
+// - 链式构建：Path、Timeout、Env、Input、Quietly、OnOutput
+// - 输出回调按行实时接收标准输出和标准错误
+// - Pool 并发运行多个进程
+// - Fake 按命令模式返回桩结果，并通过 AssertRan 等方法断言已运行的命令
+//
+// 包结构：
+// - process.go - Factory、默认 Factory 和输出类型
+// - pending.go - PendingProcess 进程构建、Run 和 Start
+// - result.go - Result 结果、Running 运行中的进程和错误
+// - pool.go - Pool 并发进程
+// - fake.go - Fake、Stub、Sequence 和测试断言
+//
+// 使用示例：
+//
+//	processes := process.NewFactory()
+//
+//	result, err := processes.Path("/var/www").Timeout(time.Minute).
+//		Env(map[string]string{"APP_ENV": "production"}).
+//		Run(ctx, "php artisan migrate --force")
+//	if err == nil {
+//		err = result.Throw()
+//	}
+//
+//	_, err = processes.New().OnOutput(func(stream process.OutputType, line string) {
+//		log.Println(stream, line)
+//	}).Run(ctx, "npm", "run", "build")
+//
+//	results := processes.Pool(ctx, func(pool *process.Pool) {
+//		pool.As("first", "bash import-1.sh")
+//		pool.As("second", "bash import-2.sh").Path("/srv/import")
+//	})
+//
+//	// 测试中
+//	fake := processes.Fake().Stub("git *", process.FakeResult("ok", 0))
+//	fake.AssertRan(t, "git pull*")
+package process
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OutputType 输出流类型
+type OutputType string
+
+const (
+	// Stdout 标准输出
+	Stdout OutputType = "out"
+
+	// Stderr 标准错误
+	Stderr OutputType = "err"
+)
+
+// Factory 进程工厂，对应 Laravel 的 Illuminate\Process\Factory
+type Factory struct {
+	mu   sync.RWMutex
+	fake *Fake
+}
+
+// NewFactory 创建进程工厂
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+// New 创建新的 PendingProcess，默认超时 60 秒
+func (f *Factory) New() *PendingProcess {
+	return &PendingProcess{factory: f, timeout: 60 * time.Second}
+}
+
+// Path 创建在 dir 目录中运行的 PendingProcess
+func (f *Factory) Path(dir string) *PendingProcess {
+	return f.New().Path(dir)
+}
+
+// Timeout 创建带超时的 PendingProcess
+func (f *Factory) Timeout(timeout time.Duration) *PendingProcess {
+	return f.New().Timeout(timeout)
+}
+
+// Env 创建带环境变量的 PendingProcess
+func (f *Factory) Env(env map[string]string) *PendingProcess {
+	return f.New().Env(env)
+}
+
+// currentFake 当前的 Fake，未开启时为 nil
+func (f *Factory) currentFake() *Fake {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.fake
+}
+
+// defaultFactory 默认工厂
+var defaultFactory atomic.Value
+
+// SetDefaultFactory 设置默认工厂
+func SetDefaultFactory(factory *Factory) {
+	defaultFactory.Store(factory)
+}
+
+// DefaultFactory 获取默认工厂，未设置时返回 nil
+func DefaultFactory() *Factory {
+	factory, _ := defaultFactory.Load().(*Factory)
+	return factory
+}
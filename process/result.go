@@ -0,0 +1,153 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrTimedOut 进程超过超时时间被终止，对应 Laravel 的 ProcessTimedOutException
+var ErrTimedOut = errors.New("process: the process exceeded the timeout")
+
+// Result 进程运行结果
+type Result struct {
+	command     string
+	exitCode    int
+	output      string
+	errorOutput string
+}
+
+// Command 运行的命令
+func (r *Result) Command() string {
+	return r.command
+}
+
+// ExitCode 退出状态码，进程被信号终止时为 -1
+func (r *Result) ExitCode() int {
+	return r.exitCode
+}
+
+// Output 标准输出
+func (r *Result) Output() string {
+	return r.output
+}
+
+// ErrorOutput 标准错误
+func (r *Result) ErrorOutput() string {
+	return r.errorOutput
+}
+
+// Successful 是否以 0 状态码退出
+func (r *Result) Successful() bool {
+	return r.exitCode == 0
+}
+
+// Failed 是否以非 0 状态码退出
+func (r *Result) Failed() bool {
+	return !r.Successful()
+}
+
+// SeeInOutput 标准输出是否包含 s
+func (r *Result) SeeInOutput(s string) bool {
+	return strings.Contains(r.output, s)
+}
+
+// SeeInErrorOutput 标准错误是否包含 s
+func (r *Result) SeeInErrorOutput(s string) bool {
+	return strings.Contains(r.errorOutput, s)
+}
+
+// Throw 进程失败时返回 *FailedError，否则返回 nil
+func (r *Result) Throw() error {
+	if r.Failed() {
+		return &FailedError{Result: r}
+	}
+	return nil
+}
+
+// FailedError 进程以非 0 状态码退出，对应 Laravel 的 ProcessFailedException
+type FailedError struct {
+	Result *Result
+}
+
+// Error 实现 error 接口
+func (e *FailedError) Error() string {
+	message := fmt.Sprintf("process: command %q failed with exit code %d", e.Result.command, e.Result.exitCode)
+	if output := strings.TrimSpace(e.Result.errorOutput); output != "" {
+		message += ": " + output
+	}
+	return message
+}
+
+// Running 运行中的进程，对应 Laravel 的 InvokedProcess
+type Running struct {
+	command string
+	process *os.Process
+	stdout  *lineWriter
+	stderr  *lineWriter
+	done    chan struct{}
+	result  *Result
+	err     error
+}
+
+// Command 运行的命令
+func (r *Running) Command() string {
+	return r.command
+}
+
+// PID 进程 ID，Fake 进程为 0
+func (r *Running) PID() int {
+	if r.process == nil {
+		return 0
+	}
+	return r.process.Pid
+}
+
+// Running 进程是否仍在运行
+func (r *Running) Running() bool {
+	select {
+	case <-r.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Output 目前为止的标准输出
+func (r *Running) Output() string {
+	if r.stdout == nil {
+		return r.result.output
+	}
+	return r.stdout.String()
+}
+
+// ErrorOutput 目前为止的标准错误
+func (r *Running) ErrorOutput() string {
+	if r.stderr == nil {
+		return r.result.errorOutput
+	}
+	return r.stderr.String()
+}
+
+// Signal 向进程发送信号
+func (r *Running) Signal(sig os.Signal) error {
+	if r.process == nil || !r.Running() {
+		return nil
+	}
+	return r.process.Signal(sig)
+}
+
+// Kill 立即终止进程
+func (r *Running) Kill() error {
+	if r.process == nil || !r.Running() {
+		return nil
+	}
+	return r.process.Kill()
+}
+
+// Wait 等待进程结束
+func (r *Running) Wait() (*Result, error) {
+	<-r.done
+	return r.result, r.err
+}
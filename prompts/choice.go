@@ -0,0 +1,206 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Confirm 是否确认，接受 yes/no、y/n（不区分大小写），输入为空时使用默认值
+func (p *Prompter) Confirm(label string, defaultValue bool, opts ...Option) (bool, error) {
+	c := newConfig(opts)
+	if !p.interactive {
+		return defaultValue, nil
+	}
+	shown := "no"
+	if defaultValue {
+		shown = "yes"
+	}
+	for {
+		p.question(label+" (yes/no)", shown, c.hint)
+		line, err := p.readLine(false)
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "":
+			return defaultValue, nil
+		case "y", "yes", "true", "1":
+			return true, nil
+		case "n", "no", "false", "0":
+			return false, nil
+		}
+		p.printError(errors.New("Please answer yes or no."))
+	}
+}
+
+// Choice 从选项中选择一个，输入序号或选项值
+//
+// 输入既不是序号也不是选项值时作为搜索词：只有一个选项包含它时选中该选项，
+// 多个选项包含它时只列出这些选项并重新提问。
+func (p *Prompter) Choice(label string, choices []string, opts ...Option) (string, error) {
+	if len(choices) == 0 {
+		return "", fmt.Errorf("prompts: %s: no choices", label)
+	}
+	c := newConfig(opts)
+	if !p.interactive {
+		if !c.hasDefault {
+			return "", fmt.Errorf("prompts: %s: no default choice", label)
+		}
+		value, err := match(choices, c.value)
+		if err == nil {
+			err = c.check(value)
+		}
+		if err != nil {
+			return "", fmt.Errorf("prompts: %s: %w", label, err)
+		}
+		return value, nil
+	}
+	visible := choices
+	for {
+		p.question(label, c.value, c.hint)
+		p.list(visible)
+		line, err := p.readLine(false)
+		if err != nil {
+			return "", err
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			if !c.hasDefault {
+				p.printError(errors.New("Please choose an option."))
+				continue
+			}
+			answer = c.value
+		}
+		value, err := match(visible, answer)
+		if err != nil {
+			if filtered := search(visible, answer); len(filtered) > 1 {
+				visible = filtered
+				continue
+			}
+			p.printError(err)
+			visible = choices
+			continue
+		}
+		if err := c.check(value); err != nil {
+			p.printError(err)
+			continue
+		}
+		return value, nil
+	}
+}
+
+// MultiChoice 从选项中选择多个，用逗号分隔序号或选项值，结果按选项顺序排列
+//
+// 不带 Required 时可以不选；每一项也可以是只匹配一个选项的搜索词。
+func (p *Prompter) MultiChoice(label string, choices []string, opts ...Option) ([]string, error) {
+	c := newConfig(opts)
+	if !p.interactive {
+		selected, err := matchAll(choices, c.value)
+		if err == nil {
+			err = c.check(strings.Join(selected, ","))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("prompts: %s: %w", label, err)
+		}
+		return selected, nil
+	}
+	for {
+		p.question(label, c.value, strings.TrimSpace(c.hint+" (separate multiple choices with commas)"))
+		p.list(choices)
+		line, err := p.readLine(false)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(line) == "" {
+			line = c.value
+		}
+		selected, err := matchAll(choices, line)
+		if err == nil {
+			err = c.check(strings.Join(selected, ","))
+		}
+		if err != nil {
+			p.printError(err)
+			continue
+		}
+		return selected, nil
+	}
+}
+
+// Search 输入搜索词，从 options 返回的结果中选择一个，对应 Laravel Prompts 的 search
+func (p *Prompter) Search(label string, options func(query string) []string, opts ...Option) (string, error) {
+	c := newConfig(opts)
+	if !p.interactive {
+		return c.nonInteractive(label)
+	}
+	for {
+		query, err := p.text(label, &config{hint: c.hint}, false)
+		if err != nil {
+			return "", err
+		}
+		results := options(query)
+		if len(results) == 0 {
+			p.printError(errors.New("No results."))
+			continue
+		}
+		return p.Choice("Select a result", results, Validate(c.check))
+	}
+}
+
+// list 列出选项
+func (p *Prompter) list(choices []string) {
+	width := len(strconv.Itoa(len(choices)))
+	for i, choice := range choices {
+		fmt.Fprintf(p.out, "  [%*d] %s\n", width, i+1, choice)
+	}
+}
+
+// match 按序号、选项值（不区分大小写）或唯一包含的搜索词匹配选项
+func match(choices []string, answer string) (string, error) {
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(choices) {
+		return choices[n-1], nil
+	}
+	for _, choice := range choices {
+		if strings.EqualFold(choice, answer) {
+			return choice, nil
+		}
+	}
+	if filtered := search(choices, answer); len(filtered) == 1 {
+		return filtered[0], nil
+	}
+	return "", fmt.Errorf("Value %q is invalid.", answer)
+}
+
+// matchAll 匹配逗号分隔的多个选项，结果按选项顺序排列并去重
+func matchAll(choices []string, answer string) ([]string, error) {
+	chosen := make(map[string]bool)
+	for _, part := range strings.Split(answer, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		value, err := match(choices, part)
+		if err != nil {
+			return nil, err
+		}
+		chosen[value] = true
+	}
+	selected := make([]string, 0, len(chosen))
+	for _, choice := range choices {
+		if chosen[choice] {
+			selected = append(selected, choice)
+		}
+	}
+	return selected, nil
+}
+
+// search 包含搜索词（不区分大小写）的选项
+func search(choices []string, query string) []string {
+	var filtered []string
+	for _, choice := range choices {
+		if strings.Contains(strings.ToLower(choice), strings.ToLower(query)) {
+			filtered = append(filtered, choice)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,23 @@
+//go:build !windows
+
+package prompts
+
+import (
+	"os"
+	"os/exec"
+)
+
+// disableEcho 通过 stty 关闭终端回显，返回恢复函数
+func disableEcho(terminal *os.File) (func(), error) {
+	if err := stty(terminal, "-echo"); err != nil {
+		return nil, err
+	}
+	return func() { _ = stty(terminal, "echo") }, nil
+}
+
+// stty 对终端执行 stty
+func stty(terminal *os.File, args ...string) error {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = terminal
+	return cmd.Run()
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package prompts
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// enableEchoInput 控制台回显输入标志
+const enableEchoInput = 0x0004
+
+var (
+	kernel32           = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+)
+
+// disableEcho 关闭控制台回显，返回恢复函数
+func disableEcho(terminal *os.File) (func(), error) {
+	handle := terminal.Fd()
+	var mode uint32
+	if ok, _, err := procGetConsoleMode.Call(handle, uintptr(unsafe.Pointer(&mode))); ok == 0 {
+		return nil, err
+	}
+	if ok, _, err := procSetConsoleMode.Call(handle, uintptr(mode&^enableEchoInput)); ok == 0 {
+		return nil, err
+	}
+	return func() { _, _, _ = procSetConsoleMode.Call(handle, uintptr(mode)) }, nil
+}
@@ -0,0 +1,276 @@
+// Package prompts 提供命令行交互式提示，对应 Laravel Prompts
+//
+// 提示按行读取输入，不依赖终端的原始模式，因此在终端、管道和 CI 中行为一致：
+// 终端中 Secret 会关闭回显，Anticipate 和 Suggest 支持输入前缀后按 Tab 再回车补全；
+// 非交互模式（命令带 --no-interaction，或通过 SetInteractive(false) 关闭）不读取输入，直接返回默认值，
+// 默认值无法通过校验时返回错误。
+//
+// 包结构：
+// - prompts.go - Prompter、选项和默认提示器
+// - text.go - Ask、Secret、Anticipate、Suggest 文本输入
+// - choice.go - Confirm、Choice、MultiChoice、Search 选择
+// - echo_unix.go、echo_windows.go - 关闭终端回显
+//
+// 使用示例：
+//
+//	artisan.Register("user:create").
+//		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+//			p := prompts.ForCommand(input, output)
+//			name, err := p.Ask("What is your name?", prompts.Required(), prompts.Validate(func(value string) error {
+//				if len(value) > 255 {
+//					return errors.New("The name must not exceed 255 characters.")
+//				}
+//				return nil
+//			}))
+//			password, err := p.Secret("What is the password?", prompts.Required())
+//			role, err := p.Choice("What role should the user have?", []string{"Member", "Contributor", "Owner"}, prompts.Default("Member"))
+//			permissions, err := p.MultiChoice("What permissions should be assigned?", []string{"Read", "Create", "Update", "Delete"})
+//			if ok, err := p.Confirm("Create the user?", true); err != nil || !ok {
+//				return err
+//			}
+//			...
+//		})
+package prompts
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// ErrCancelled 输入已结束（例如 Ctrl+D），提示被取消
+var ErrCancelled = errors.New("prompts: cancelled")
+
+// Prompter 交互式提示器
+type Prompter struct {
+	reader      *bufio.Reader
+	terminal    *os.File
+	out         io.Writer
+	interactive bool
+}
+
+// New 创建提示器，in 为终端（*os.File）时 Secret 会关闭回显
+func New(in io.Reader, out io.Writer) *Prompter {
+	p := &Prompter{reader: bufio.NewReader(in), out: out, interactive: true}
+	if file, ok := in.(*os.File); ok && isTerminal(file) {
+		p.terminal = file
+	}
+	return p
+}
+
+// ForCommand 创建命令使用的提示器，从标准输入读取，写入命令输出，
+// 交互模式跟随 input.IsInteractive()，可以在 CommandInterface.Interact 中使用
+func ForCommand(input application.InputInterface, output application.OutputInterface) *Prompter {
+	p := New(os.Stdin, outputWriter{output: output})
+	p.interactive = input.IsInteractive()
+	return p
+}
+
+// SetInteractive 设置交互模式
+func (p *Prompter) SetInteractive(interactive bool) *Prompter {
+	p.interactive = interactive
+	return p
+}
+
+// IsInteractive 是否为交互模式
+func (p *Prompter) IsInteractive() bool {
+	return p.interactive
+}
+
+// IsTerminal 输入是否为终端
+func (p *Prompter) IsTerminal() bool {
+	return p.terminal != nil
+}
+
+// Option 提示选项
+type Option func(*config)
+
+// config 提示配置
+type config struct {
+	value      string
+	hasDefault bool
+	required   string
+	validators []func(value string) error
+	hint       string
+}
+
+// Default 默认值，输入为空和非交互模式时使用；MultiChoice 的多个默认值用逗号分隔
+func Default(value string) Option {
+	return func(c *config) {
+		c.value = value
+		c.hasDefault = true
+	}
+}
+
+// Required 要求输入非空，message 为校验失败的提示，默认为 "Required."
+func Required(message ...string) Option {
+	return func(c *config) {
+		c.required = "Required."
+		if len(message) > 0 && message[0] != "" {
+			c.required = message[0]
+		}
+	}
+}
+
+// Validate 校验输入，返回错误时显示错误信息并重新提示
+func Validate(validator func(value string) error) Option {
+	return func(c *config) {
+		c.validators = append(c.validators, validator)
+	}
+}
+
+// Hint 显示在问题下方的提示文字
+func Hint(text string) Option {
+	return func(c *config) {
+		c.hint = text
+	}
+}
+
+// newConfig 应用选项
+func newConfig(opts []Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// check 校验值
+func (c *config) check(value string) error {
+	if c.required != "" && strings.TrimSpace(value) == "" {
+		return errors.New(c.required)
+	}
+	for _, validate := range c.validators {
+		if err := validate(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nonInteractive 非交互模式下返回校验后的默认值
+func (c *config) nonInteractive(label string) (string, error) {
+	if err := c.check(c.value); err != nil {
+		return "", fmt.Errorf("prompts: %s: %w", label, err)
+	}
+	return c.value, nil
+}
+
+// question 输出问题，格式与 Symfony 的 SymfonyStyle 一致
+func (p *Prompter) question(label, defaultValue, hint string) {
+	line := " " + label
+	if defaultValue != "" {
+		line += " [" + defaultValue + "]"
+	}
+	fmt.Fprintln(p.out, line+":")
+	if hint != "" {
+		fmt.Fprintln(p.out, "  "+hint)
+	}
+}
+
+// printError 输出校验错误
+func (p *Prompter) printError(err error) {
+	fmt.Fprintf(p.out, " [ERROR] %s\n\n", err.Error())
+}
+
+// readLine 读取一行，secret 为 true 且输入为终端时关闭回显
+func (p *Prompter) readLine(secret bool) (string, error) {
+	fmt.Fprint(p.out, " > ")
+	if secret && p.terminal != nil {
+		// 无法关闭回显时按 Symfony 的 hiddenFallback 继续读取可见输入
+		if restore, err := disableEcho(p.terminal); err == nil {
+			defer func() {
+				restore()
+				fmt.Fprintln(p.out)
+			}()
+		}
+	}
+	line, err := p.reader.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		if errors.Is(err, io.EOF) {
+			return "", ErrCancelled
+		}
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// isTerminal 文件是否为终端
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// outputWriter 把命令输出适配为 io.Writer
+type outputWriter struct {
+	output application.OutputInterface
+}
+
+// Write 写入命令输出
+func (w outputWriter) Write(p []byte) (int, error) {
+	if err := w.output.Write([]string{string(p)}, false, application.VerbosityNormal); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// defaultPrompter 默认提示器
+var defaultPrompter atomic.Value
+
+// SetDefaultPrompter 设置默认提示器
+func SetDefaultPrompter(p *Prompter) {
+	defaultPrompter.Store(p)
+}
+
+// DefaultPrompter 获取默认提示器，未设置时为读取标准输入、写入标准输出的提示器
+func DefaultPrompter() *Prompter {
+	if p, ok := defaultPrompter.Load().(*Prompter); ok {
+		return p
+	}
+	p := New(os.Stdin, os.Stdout)
+	if defaultPrompter.CompareAndSwap(nil, p) {
+		return p
+	}
+	return defaultPrompter.Load().(*Prompter)
+}
+
+// Ask 使用默认提示器提问
+func Ask(label string, opts ...Option) (string, error) {
+	return DefaultPrompter().Ask(label, opts...)
+}
+
+// Secret 使用默认提示器读取隐藏输入
+func Secret(label string, opts ...Option) (string, error) {
+	return DefaultPrompter().Secret(label, opts...)
+}
+
+// Confirm 使用默认提示器确认
+func Confirm(label string, defaultValue bool, opts ...Option) (bool, error) {
+	return DefaultPrompter().Confirm(label, defaultValue, opts...)
+}
+
+// Choice 使用默认提示器单选
+func Choice(label string, choices []string, opts ...Option) (string, error) {
+	return DefaultPrompter().Choice(label, choices, opts...)
+}
+
+// MultiChoice 使用默认提示器多选
+func MultiChoice(label string, choices []string, opts ...Option) ([]string, error) {
+	return DefaultPrompter().MultiChoice(label, choices, opts...)
+}
+
+// Search 使用默认提示器搜索选择
+func Search(label string, options func(query string) []string, opts ...Option) (string, error) {
+	return DefaultPrompter().Search(label, options, opts...)
+}
+
+// Anticipate 使用默认提示器提问并补全
+func Anticipate(label string, suggestions []string, opts ...Option) (string, error) {
+	return DefaultPrompter().Anticipate(label, suggestions, opts...)
+}
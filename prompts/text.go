@@ -0,0 +1,103 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxSuggestionsShown 提问时直接列出的建议数量上限
+const maxSuggestionsShown = 10
+
+// Ask 提问并读取一行文本，输入为空时使用默认值，校验失败时显示错误并重新提问
+func (p *Prompter) Ask(label string, opts ...Option) (string, error) {
+	return p.text(label, newConfig(opts), false)
+}
+
+// Secret 读取隐藏输入（密码等），输入为终端时关闭回显，默认值不会显示
+func (p *Prompter) Secret(label string, opts ...Option) (string, error) {
+	return p.text(label, newConfig(opts), true)
+}
+
+// text 读取文本输入
+func (p *Prompter) text(label string, c *config, secret bool) (string, error) {
+	if !p.interactive {
+		return c.nonInteractive(label)
+	}
+	shown := c.value
+	if secret {
+		shown = ""
+	}
+	for {
+		p.question(label, shown, c.hint)
+		line, err := p.readLine(secret)
+		if err != nil {
+			return "", err
+		}
+		if line == "" {
+			line = c.value
+		}
+		if err := c.check(line); err != nil {
+			p.printError(err)
+			continue
+		}
+		return line, nil
+	}
+}
+
+// Anticipate 提问并根据建议列表补全，输入前缀后按 Tab 再回车补全为唯一匹配（不区分大小写）的建议
+//
+// 输入不必是建议中的值，需要限制取值时使用 Choice。
+func (p *Prompter) Anticipate(label string, suggestions []string, opts ...Option) (string, error) {
+	return p.Suggest(label, func(input string) []string {
+		var matches []string
+		for _, suggestion := range suggestions {
+			if strings.HasPrefix(strings.ToLower(suggestion), strings.ToLower(input)) {
+				matches = append(matches, suggestion)
+			}
+		}
+		return matches
+	}, opts...)
+}
+
+// Suggest 提问并使用回调补全，options 返回与输入匹配的建议，对应 Laravel Prompts 的 suggest
+//
+// 输入中的 Tab 之前的部分作为补全前缀：唯一匹配时使用该建议，多个匹配时列出并重新提问。
+func (p *Prompter) Suggest(label string, options func(input string) []string, opts ...Option) (string, error) {
+	c := newConfig(opts)
+	if !p.interactive {
+		return c.nonInteractive(label)
+	}
+	hint := c.hint
+	if all := options(""); len(all) > 0 && len(all) <= maxSuggestionsShown {
+		hint = strings.TrimSpace(hint + " (" + strings.Join(all, ", ") + ")")
+	}
+	for {
+		p.question(label, c.value, hint)
+		line, err := p.readLine(false)
+		if err != nil {
+			return "", err
+		}
+		if prefix, _, tab := strings.Cut(line, "\t"); tab {
+			matches := options(prefix)
+			switch len(matches) {
+			case 0:
+				p.printError(fmt.Errorf("No suggestions match %q.", prefix))
+				continue
+			case 1:
+				line = matches[0]
+				fmt.Fprintf(p.out, " > %s\n", line)
+			default:
+				hint = strings.Join(matches, ", ")
+				continue
+			}
+		}
+		if line == "" {
+			line = c.value
+		}
+		if err := c.check(line); err != nil {
+			p.printError(err)
+			continue
+		}
+		return line, nil
+	}
+}
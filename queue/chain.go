@@ -0,0 +1,199 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cnote0/laraveldoc/appcontext"
+)
+
+// ChainCatcher 任务链失败回调
+//
+// 回调对象与任务一样序列化到载荷中，需要通过 RegisterCatcher 注册类型，
+// 在任务链中任一任务最终失败时由 Worker 调用，之后的任务不再执行。
+//
+// 使用示例：
+//
+//	type NotifyImportFailed struct {
+//		ImportID uint `json:"import_id"`
+//	}
+//
+//	func (c *NotifyImportFailed) Catch(ctx context.Context, err error) {
+//		notifier.ImportFailed(ctx, c.ImportID, err)
+//	}
+//
+//	queue.RegisterCatcher(&NotifyImportFailed{})
+type ChainCatcher interface {
+	Catch(ctx context.Context, err error)
+}
+
+// ErrNoManager 没有设置默认队列管理器
+var ErrNoManager = errors.New("queue: no default manager, call queue.SetDefaultManager or use DispatchUsing")
+
+// PendingChain 待投递的任务链
+//
+// 任务按顺序执行，前一个任务成功后才投递下一个。剩余任务保存在载荷的 Chained 中，
+// 因此任务链可以跨进程和跨 Worker 继续执行。
+//
+// 使用示例：
+//
+//	id, err := queue.Chain([]queue.Job{
+//		&ProcessPodcast{PodcastID: 1},
+//		&OptimizePodcast{PodcastID: 1},
+//		&ReleasePodcast{PodcastID: 1},
+//	}).OnQueue("podcasts").Catch(&NotifyPodcastFailed{PodcastID: 1}).Dispatch(ctx)
+type PendingChain struct {
+	jobs       []Job
+	connection string
+	queue      string
+	delay      time.Duration
+	catchers   []ChainCatcher
+	registry   *Registry
+}
+
+// Chain 创建任务链
+func Chain(jobs []Job) *PendingChain {
+	return &PendingChain{jobs: jobs, registry: DefaultRegistry}
+}
+
+// OnConnection 设置任务链使用的连接
+func (c *PendingChain) OnConnection(name string) *PendingChain {
+	c.connection = name
+	return c
+}
+
+// OnQueue 设置任务链使用的队列
+func (c *PendingChain) OnQueue(name string) *PendingChain {
+	c.queue = name
+	return c
+}
+
+// Delay 延迟投递第一个任务
+func (c *PendingChain) Delay(delay time.Duration) *PendingChain {
+	c.delay = delay
+	return c
+}
+
+// Catch 添加失败回调，可以多次调用
+func (c *PendingChain) Catch(catcher ChainCatcher) *PendingChain {
+	c.catchers = append(c.catchers, catcher)
+	return c
+}
+
+// WithRegistry 设置编码任务使用的注册表，默认为 DefaultRegistry
+func (c *PendingChain) WithRegistry(registry *Registry) *PendingChain {
+	c.registry = registry
+	return c
+}
+
+// Payload 创建第一个任务的载荷，剩余任务编码到 Chained 中
+func (c *PendingChain) Payload() (*Payload, error) {
+	if len(c.jobs) == 0 {
+		return nil, errors.New("queue: chain has no jobs")
+	}
+
+	callbacks := make([]Callback, 0, len(c.catchers))
+	for _, catcher := range c.catchers {
+		callback, err := c.registry.Callback(catcher)
+		if err != nil {
+			return nil, err
+		}
+		callbacks = append(callbacks, callback)
+	}
+
+	first, err := c.registry.Payload(c.jobs[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range c.jobs[1:] {
+		payload, err := c.registry.Payload(job)
+		if err != nil {
+			return nil, err
+		}
+		body, err := payload.Encode()
+		if err != nil {
+			return nil, err
+		}
+		first.Chained = append(first.Chained, body)
+	}
+	first.ChainConnection = c.connection
+	first.ChainQueue = c.queue
+	first.ChainCatchCallbacks = callbacks
+	return first, nil
+}
+
+// Dispatch 使用默认队列管理器投递任务链，返回第一个任务的 ID
+func (c *PendingChain) Dispatch(ctx context.Context) (string, error) {
+	manager := DefaultManager()
+	if manager == nil {
+		return "", ErrNoManager
+	}
+	return c.DispatchUsing(ctx, manager)
+}
+
+// DispatchUsing 使用指定的队列管理器投递任务链
+func (c *PendingChain) DispatchUsing(ctx context.Context, manager Manager) (string, error) {
+	payload, err := c.Payload()
+	if err != nil {
+		return "", err
+	}
+	if payload.Context, err = appcontext.Dehydrate(ctx); err != nil {
+		return "", err
+	}
+	body, err := payload.Encode()
+	if err != nil {
+		return "", err
+	}
+	q, err := manager.Connection(c.connection)
+	if err != nil {
+		return "", err
+	}
+	if c.delay > 0 {
+		return q.LaterRaw(ctx, c.delay, body, c.queue)
+	}
+	return q.PushRaw(ctx, body, c.queue)
+}
+
+// NextInChain 任务链中的下一个任务载荷，没有后续任务时返回 nil
+func (p *Payload) NextInChain() (*Payload, error) {
+	if len(p.Chained) == 0 {
+		return nil, nil
+	}
+	next, err := DecodePayload(p.Chained[0])
+	if err != nil {
+		return nil, err
+	}
+	next.Chained = p.Chained[1:]
+	next.ChainConnection = p.ChainConnection
+	next.ChainQueue = p.ChainQueue
+	next.ChainCatchCallbacks = p.ChainCatchCallbacks
+	next.Context = p.Context
+	return next, nil
+}
+
+// DispatchNextJobInChain 投递任务链中的下一个任务
+//
+// 任务链指定了其他连接时通过 manager 获取连接，否则投递到 current。
+// 由 Worker 和同步驱动在任务成功后调用。
+func DispatchNextJobInChain(ctx context.Context, manager Manager, current Queue, payload *Payload) error {
+	next, err := payload.NextInChain()
+	if err != nil || next == nil {
+		return err
+	}
+	target := current
+	if next.ChainConnection != "" && next.ChainConnection != current.ConnectionName() {
+		if manager == nil {
+			return ErrNoManager
+		}
+		if target, err = manager.Connection(next.ChainConnection); err != nil {
+			return err
+		}
+	}
+	body, err := next.Encode()
+	if err != nil {
+		return err
+	}
+	_, err = target.PushRaw(ctx, body, next.ChainQueue)
+	return err
+}
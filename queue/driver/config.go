@@ -0,0 +1,213 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// ErrInvalidConfig 连接配置无效，NewConnectionConfig 和 ConnectionConfig.Validate 返回的错误满足 errors.Is
+var ErrInvalidConfig = errors.New("queue: invalid connection config")
+
+// ConnectionConfig 队列连接配置，对应 config/queue.php 的 connections.* 项
+//
+// 由 NewConnectionConfig 创建，ToMap 转换为 NewManager 接受的连接配置，
+// 字段只在对应的驱动中有意义，为其他驱动设置时返回错误。
+type ConnectionConfig struct {
+	// Driver 驱动名称，queue.DriverSync 等内置驱动或 Manager.Extend 注册的驱动（driver）
+	Driver string
+
+	// Queue 默认队列，默认 "default"（queue）
+	Queue string
+
+	// RetryAfter 保留超时，超时未完成的任务重新可用，默认 90 秒；memory、database、redis（retry_after）
+	RetryAfter time.Duration
+
+	// Registry 任务注册表，为空时使用 queue.DefaultRegistry（registry）
+	Registry *queue.Registry
+
+	// DB 数据库连接，database 必需（db）
+	DB database.DB
+
+	// Table 任务表名，默认 "jobs"；database（table）
+	Table string
+
+	// Client RedisClient 或 SQSClient，redis 和 sqs 必需（client）
+	Client interface{}
+
+	// Prefix、Suffix 队列地址前缀和队列名后缀；sqs（prefix、suffix）
+	Prefix string
+	Suffix string
+}
+
+// Option 队列连接配置选项
+type Option func(*ConnectionConfig)
+
+// WithQueue 默认队列
+func WithQueue(name string) Option {
+	return func(c *ConnectionConfig) {
+		c.Queue = name
+	}
+}
+
+// WithRetryAfter 保留超时
+func WithRetryAfter(retryAfter time.Duration) Option {
+	return func(c *ConnectionConfig) {
+		c.RetryAfter = retryAfter
+	}
+}
+
+// WithRegistry 任务注册表
+func WithRegistry(registry *queue.Registry) Option {
+	return func(c *ConnectionConfig) {
+		c.Registry = registry
+	}
+}
+
+// WithDB database 驱动的数据库连接和任务表名，table 为空时使用 "jobs"
+func WithDB(db database.DB, table string) Option {
+	return func(c *ConnectionConfig) {
+		c.DB = db
+		c.Table = table
+	}
+}
+
+// WithRedisClient redis 驱动的客户端
+func WithRedisClient(client RedisClient) Option {
+	return func(c *ConnectionConfig) {
+		c.Client = client
+	}
+}
+
+// WithSQSClient sqs 驱动的客户端、队列地址前缀和队列名后缀
+func WithSQSClient(client SQSClient, prefix, suffix string) Option {
+	return func(c *ConnectionConfig) {
+		c.Client = client
+		c.Prefix = prefix
+		c.Suffix = suffix
+	}
+}
+
+// NewConnectionConfig 应用选项、补全缺省值并校验连接配置
+//
+// 示例：
+//
+//	redis, err := driver.NewConnectionConfig(queue.DriverRedis,
+//		driver.WithRedisClient(redisAdapter),
+//		driver.WithRetryAfter(5*time.Minute),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	manager := driver.NewManager(driver.Connections(map[string]*driver.ConnectionConfig{"redis": redis}), "redis")
+func NewConnectionConfig(driverName string, opts ...Option) (*ConnectionConfig, error) {
+	config := &ConnectionConfig{Driver: driverName}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.Queue == "" {
+		config.Queue = "default"
+	}
+	if config.RetryAfter == 0 && retries(config.Driver) {
+		config.RetryAfter = 90 * time.Second
+	}
+	if config.Table == "" && config.Driver == queue.DriverDatabase {
+		config.Table = "jobs"
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// retries 驱动是否支持保留超时
+func retries(driverName string) bool {
+	return driverName == queue.DriverMemory || driverName == queue.DriverDatabase || driverName == queue.DriverRedis
+}
+
+// Validate 校验内置驱动必需的客户端和只属于其他驱动的字段，Extend 注册的驱动只校验驱动名称
+func (c *ConnectionConfig) Validate() error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidConfig, fmt.Sprintf(format, args...))
+	}
+	if c.Driver == "" {
+		return invalid("driver is required")
+	}
+	if c.RetryAfter < 0 {
+		return invalid("retry_after must not be negative")
+	}
+	builtin := true
+	switch c.Driver {
+	case queue.DriverDatabase:
+		if c.DB == nil {
+			return invalid("database driver requires db")
+		}
+	case queue.DriverRedis:
+		if _, ok := c.Client.(RedisClient); !ok {
+			return invalid("redis driver requires a RedisClient, got %T", c.Client)
+		}
+	case queue.DriverSQS:
+		if _, ok := c.Client.(SQSClient); !ok {
+			return invalid("sqs driver requires an SQSClient, got %T", c.Client)
+		}
+	case queue.DriverSync, queue.DriverMemory:
+	default:
+		builtin = false
+	}
+	if !builtin {
+		return nil
+	}
+	switch {
+	case c.RetryAfter != 0 && !retries(c.Driver):
+		return invalid("retry_after is not supported by the %s driver", c.Driver)
+	case (c.DB != nil || c.Table != "") && c.Driver != queue.DriverDatabase:
+		return invalid("db and table are only used by the database driver, not %s", c.Driver)
+	case c.Client != nil && c.Driver != queue.DriverRedis && c.Driver != queue.DriverSQS:
+		return invalid("client is not used by the %s driver", c.Driver)
+	case (c.Prefix != "" || c.Suffix != "") && c.Driver != queue.DriverSQS:
+		return invalid("prefix and suffix are only used by the sqs driver, not %s", c.Driver)
+	}
+	return nil
+}
+
+// ToMap 转换为 NewManager 接受的连接配置，未设置的项省略
+func (c *ConnectionConfig) ToMap() map[string]interface{} {
+	config := map[string]interface{}{"driver": c.Driver}
+	if c.Queue != "" {
+		config["queue"] = c.Queue
+	}
+	if c.RetryAfter != 0 {
+		config["retry_after"] = c.RetryAfter
+	}
+	if c.Registry != nil {
+		config["registry"] = c.Registry
+	}
+	if c.DB != nil {
+		config["db"] = c.DB
+	}
+	if c.Table != "" {
+		config["table"] = c.Table
+	}
+	if c.Client != nil {
+		config["client"] = c.Client
+	}
+	if c.Prefix != "" {
+		config["prefix"] = c.Prefix
+	}
+	if c.Suffix != "" {
+		config["suffix"] = c.Suffix
+	}
+	return config
+}
+
+// Connections 把连接名称到 ConnectionConfig 的映射转换为 NewManager 接受的配置
+func Connections(configs map[string]*ConnectionConfig) map[string]map[string]interface{} {
+	connections := make(map[string]map[string]interface{}, len(configs))
+	for name, config := range configs {
+		connections[name] = config.ToMap()
+	}
+	return connections
+}
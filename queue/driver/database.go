@@ -0,0 +1,189 @@
+package driver
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// JobRecord jobs 表结构，与 Laravel 的 queue:table 迁移一致
+type JobRecord struct {
+	ID          uint   `gorm:"primarykey"`
+	Queue       string `gorm:"index;size:255"`
+	Payload     string `gorm:"type:text"`
+	Attempts    int
+	ReservedAt  *int64
+	AvailableAt int64
+	CreatedAt   int64
+}
+
+// TableName 表名
+func (JobRecord) TableName() string {
+	return "jobs"
+}
+
+// DatabaseQueue 数据库驱动
+//
+// 取出任务时通过 attempts 列做乐观锁，多个 Worker 并发取任务时
+// 只有一个能保留成功，不需要数据库支持 SELECT ... FOR UPDATE SKIP LOCKED。
+type DatabaseQueue struct {
+	base
+
+	db         database.DB
+	table      string
+	retryAfter time.Duration
+	now        func() time.Time
+}
+
+var (
+	_ queue.Queue             = (*DatabaseQueue)(nil)
+	_ queue.VisibilityTimeout = (*DatabaseQueue)(nil)
+)
+
+// NewDatabaseQueue 创建数据库队列
+//
+// 支持的配置：db 数据库连接（必需）、table 表名（默认 jobs）、queue 默认队列、
+// retry_after 保留超时（默认 90 秒）、registry 任务注册表。
+func NewDatabaseQueue(config map[string]interface{}) (*DatabaseQueue, error) {
+	db, err := clientOption[database.DB](config, "db")
+	if err != nil {
+		return nil, err
+	}
+	return &DatabaseQueue{
+		base:       newBase(config),
+		db:         db,
+		table:      stringOption(config, "table", "jobs"),
+		retryAfter: durationOption(config, "retry_after", 90*time.Second),
+		now:        time.Now,
+	}, nil
+}
+
+// Size 队列中的任务数量
+func (q *DatabaseQueue) Size(ctx context.Context, queueName string) (int64, error) {
+	var count int64
+	err := q.db.WithContext(ctx).Table(q.table).Where("queue = ?", q.queueName(queueName)).Count(&count).Error()
+	return count, err
+}
+
+// RetryAfter 保留超时
+func (q *DatabaseQueue) RetryAfter() time.Duration {
+	return q.retryAfter
+}
+
+// Push 投递任务，DelayedJob 按其延迟投递
+func (q *DatabaseQueue) Push(ctx context.Context, job queue.Job, queueName string) (string, error) {
+	return q.Later(ctx, queue.DelayOf(job), job, queueName)
+}
+
+// PushRaw 投递已编码的载荷
+func (q *DatabaseQueue) PushRaw(ctx context.Context, payload []byte, queueName string) (string, error) {
+	return q.insert(ctx, payload, queueName, 0)
+}
+
+// LaterRaw 延迟投递已编码的载荷
+func (q *DatabaseQueue) LaterRaw(ctx context.Context, delay time.Duration, payload []byte, queueName string) (string, error) {
+	return q.insert(ctx, payload, queueName, delay)
+}
+
+// Later 延迟投递任务
+func (q *DatabaseQueue) Later(ctx context.Context, delay time.Duration, job queue.Job, queueName string) (string, error) {
+	body, err := q.encode(ctx, job)
+	if body == nil {
+		return "", err
+	}
+	return q.insert(ctx, body, queueName, delay)
+}
+
+// Bulk 在一个批量插入中投递任务
+func (q *DatabaseQueue) Bulk(ctx context.Context, jobs []queue.Job, queueName string) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	records := make([]JobRecord, 0, len(jobs))
+	for _, job := range jobs {
+		body, err := q.encode(ctx, job)
+		if err != nil {
+			return err
+		}
+		if body == nil {
+			continue
+		}
+		records = append(records, q.record(body, queueName, queue.DelayOf(job)))
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	return q.db.WithContext(ctx).Table(q.table).Create(&records).Error()
+}
+
+func (q *DatabaseQueue) record(body []byte, queueName string, delay time.Duration) JobRecord {
+	now := q.now()
+	return JobRecord{
+		Queue:       q.queueName(queueName),
+		Payload:     string(body),
+		AvailableAt: now.Add(delay).Unix(),
+		CreatedAt:   now.Unix(),
+	}
+}
+
+func (q *DatabaseQueue) insert(ctx context.Context, body []byte, queueName string, delay time.Duration) (string, error) {
+	record := q.record(body, queueName, delay)
+	if err := q.db.WithContext(ctx).Table(q.table).Create(&record).Error(); err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(uint64(record.ID), 10), nil
+}
+
+// Pop 取出下一个可用任务
+func (q *DatabaseQueue) Pop(ctx context.Context, queueName string) (queue.QueuedJob, error) {
+	name := q.queueName(queueName)
+	db := q.db.WithContext(ctx)
+
+	for {
+		now := q.now().Unix()
+		var records []JobRecord
+		err := db.Table(q.table).
+			Where("queue = ? AND reserved_at IS NULL AND available_at <= ?", name, now).
+			Or("queue = ? AND reserved_at <= ?", name, now-int64(q.retryAfter/time.Second)).
+			Order("id asc").
+			Limit(1).
+			Find(&records).Error()
+		if err != nil || len(records) == 0 {
+			return nil, err
+		}
+
+		record := records[0]
+		reserve := db.Table(q.table).
+			Where("id = ? AND attempts = ?", record.ID, record.Attempts).
+			Updates(map[string]interface{}{"reserved_at": now, "attempts": record.Attempts + 1})
+		if err := reserve.Error(); err != nil {
+			return nil, err
+		}
+		if reserve.RowsAffected() == 0 {
+			continue // 已被其他 Worker 保留
+		}
+
+		return q.job(record, record.Attempts+1)
+	}
+}
+
+// job 把记录包装为 QueuedJob
+func (q *DatabaseQueue) job(record JobRecord, attempts int) (queue.QueuedJob, error) {
+	id := strconv.FormatUint(uint64(record.ID), 10)
+	j, err := newJob(id, record.Queue, q.connection, []byte(record.Payload), q.registry)
+	if err != nil {
+		return nil, err
+	}
+	j.payload.Attempts = attempts
+	j.deleteFn = func(ctx context.Context) error {
+		return q.db.WithContext(ctx).Table(q.table).Where("id = ?", record.ID).Delete(&JobRecord{}).Error()
+	}
+	j.releaseFn = func(ctx context.Context, delay time.Duration) error {
+		return q.db.WithContext(ctx).Table(q.table).Where("id = ?", record.ID).
+			Updates(map[string]interface{}{"reserved_at": nil, "available_at": q.now().Add(delay).Unix()}).Error()
+	}
+	return j, nil
+}
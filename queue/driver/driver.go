@@ -0,0 +1,129 @@
+// Package driver 提供 queue 包协议的参考实现
+//
+// 包含 sync、memory、database、redis、sqs 五种驱动，以及 Worker、Manager
+// 和失败任务记录器的实现。redis 和 sqs 驱动只依赖本包定义的 RedisClient 和 SQSClient
+// 接口，由使用方为 go-redis、aws-sdk-go-v2 等客户端编写适配器。
+//
+// 包结构：
+// - driver.go - 配置读取辅助函数
+// - config.go - ConnectionConfig 函数式选项、缺省值和按驱动的配置校验
+// - job.go - QueuedJob 通用实现
+// - sync.go - SyncQueue 同步驱动
+// - memory.go - MemoryQueue 内存驱动
+// - database.go - DatabaseQueue 数据库驱动和 JobRecord 表结构
+// - redis.go - RedisQueue Redis 驱动和 RedisClient 接口
+// - sqs.go - SQSQueue SQS 驱动和 SQSClient 接口
+// - worker.go - Worker 实现
+// - manager.go - Manager 实现
+// - failed.go - 内存和数据库失败任务记录器
+// - signals_unix.go、signals_windows.go - Worker 进程信号处理
+//
+// 连接配置示例：
+//
+//	manager := driver.NewManager(map[string]map[string]interface{}{
+//		"sync":     {"driver": "sync"},
+//		"database": {"driver": "database", "db": db, "table": "jobs", "queue": "default", "retry_after": 90},
+//		"redis":    {"driver": "redis", "client": redisAdapter, "queue": "default", "retry_after": 90},
+//		"sqs":      {"driver": "sqs", "client": sqsAdapter, "prefix": "https://sqs.us-east-1.amazonaws.com/1234", "queue": "default"},
+//	}, "redis")
+//
+//	worker := driver.NewWorker(manager)
+//	err := worker.Daemon(ctx, "redis", "high,default", queue.WorkerOptions{Concurrency: 8})
+package driver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/appcontext"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// stringOption 读取字符串配置
+func stringOption(config map[string]interface{}, key, fallback string) string {
+	if value, ok := config[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// durationOption 读取时长配置，整数按秒解释（与 Laravel 配置一致）
+func durationOption(config map[string]interface{}, key string, fallback time.Duration) time.Duration {
+	switch value := config[key].(type) {
+	case time.Duration:
+		return value
+	case int:
+		return time.Duration(value) * time.Second
+	case int64:
+		return time.Duration(value) * time.Second
+	case float64:
+		return time.Duration(value * float64(time.Second))
+	case string:
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+// clientOption 读取客户端对象配置
+func clientOption[T any](config map[string]interface{}, key string) (T, error) {
+	client, ok := config[key].(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("queue: connection config %q must be %T, got %T", key, zero, config[key])
+	}
+	return client, nil
+}
+
+// base 各驱动共用的连接名称、默认队列和任务注册表
+type base struct {
+	connection   string
+	defaultQueue string
+	registry     *queue.Registry
+}
+
+// newBase 从连接配置创建，"registry" 未配置时使用 queue.DefaultRegistry
+func newBase(config map[string]interface{}) base {
+	registry, ok := config["registry"].(*queue.Registry)
+	if !ok {
+		registry = queue.DefaultRegistry
+	}
+	return base{defaultQueue: stringOption(config, "queue", "default"), registry: registry}
+}
+
+// ConnectionName 连接名称
+func (b *base) ConnectionName() string {
+	return b.connection
+}
+
+// SetConnectionName 设置连接名称
+func (b *base) SetConnectionName(name string) {
+	b.connection = name
+}
+
+// queueName 空队列名使用默认队列
+func (b *base) queueName(name string) string {
+	if name == "" {
+		return b.defaultQueue
+	}
+	return name
+}
+
+// encode 创建并编码任务载荷
+//
+// 唯一任务的锁被占用时返回 nil, nil，调用方应跳过该任务。
+func (b *base) encode(ctx context.Context, job queue.Job) ([]byte, error) {
+	if ok, err := queue.AcquireUniqueLock(ctx, job); !ok || err != nil {
+		return nil, err
+	}
+	payload, err := b.registry.Payload(job)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Context, err = appcontext.Dehydrate(ctx); err != nil {
+		return nil, err
+	}
+	return payload.Encode()
+}
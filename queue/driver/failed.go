@@ -0,0 +1,175 @@
+package driver
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// MemoryFailedJobProvider 内存失败任务记录器
+type MemoryFailedJobProvider struct {
+	mu   sync.Mutex
+	seq  uint
+	jobs []queue.FailedJob
+	now  func() time.Time
+}
+
+var _ queue.FailedJobProvider = (*MemoryFailedJobProvider)(nil)
+
+// NewMemoryFailedJobProvider 创建内存失败任务记录器
+func NewMemoryFailedJobProvider() *MemoryFailedJobProvider {
+	return &MemoryFailedJobProvider{now: time.Now}
+}
+
+// Log 记录失败任务
+func (p *MemoryFailedJobProvider) Log(ctx context.Context, connection, queueName string, payload []byte, err error) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.seq++
+	p.jobs = append(p.jobs, failedJob(p.seq, connection, queueName, payload, err, p.now()))
+	return p.jobs[len(p.jobs)-1].UUID, nil
+}
+
+// All 获取所有失败任务，按失败时间倒序
+func (p *MemoryFailedJobProvider) All(ctx context.Context) ([]queue.FailedJob, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	jobs := append([]queue.FailedJob(nil), p.jobs...)
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].ID > jobs[j].ID })
+	return jobs, nil
+}
+
+// Find 按 UUID 查找失败任务
+func (p *MemoryFailedJobProvider) Find(ctx context.Context, uuid string) (*queue.FailedJob, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, job := range p.jobs {
+		if job.UUID == uuid {
+			job := job
+			return &job, nil
+		}
+	}
+	return nil, nil
+}
+
+// Forget 删除失败任务
+func (p *MemoryFailedJobProvider) Forget(ctx context.Context, uuid string) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, job := range p.jobs {
+		if job.UUID == uuid {
+			p.jobs = append(p.jobs[:i:i], p.jobs[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Flush 清空失败任务
+func (p *MemoryFailedJobProvider) Flush(ctx context.Context, olderThan time.Duration) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if olderThan <= 0 {
+		p.jobs = nil
+		return nil
+	}
+	cutoff := p.now().Add(-olderThan)
+	kept := p.jobs[:0]
+	for _, job := range p.jobs {
+		if !job.FailedAt.Before(cutoff) {
+			kept = append(kept, job)
+		}
+	}
+	p.jobs = kept
+	return nil
+}
+
+// DatabaseFailedJobProvider 数据库失败任务记录器，使用 failed_jobs 表
+type DatabaseFailedJobProvider struct {
+	db    database.DB
+	table string
+	now   func() time.Time
+}
+
+var _ queue.FailedJobProvider = (*DatabaseFailedJobProvider)(nil)
+
+// NewDatabaseFailedJobProvider 创建数据库失败任务记录器，table 为空时使用 failed_jobs
+func NewDatabaseFailedJobProvider(db database.DB, table string) *DatabaseFailedJobProvider {
+	if table == "" {
+		table = queue.FailedJob{}.TableName()
+	}
+	return &DatabaseFailedJobProvider{db: db, table: table, now: time.Now}
+}
+
+// Log 记录失败任务
+func (p *DatabaseFailedJobProvider) Log(ctx context.Context, connection, queueName string, payload []byte, err error) (string, error) {
+	record := failedJob(0, connection, queueName, payload, err, p.now())
+	if err := p.db.WithContext(ctx).Table(p.table).Create(&record).Error(); err != nil {
+		return "", err
+	}
+	return record.UUID, nil
+}
+
+// All 获取所有失败任务，按失败时间倒序
+func (p *DatabaseFailedJobProvider) All(ctx context.Context) ([]queue.FailedJob, error) {
+	var jobs []queue.FailedJob
+	err := p.db.WithContext(ctx).Table(p.table).Order("id desc").Find(&jobs).Error()
+	return jobs, err
+}
+
+// Find 按 UUID 查找失败任务
+func (p *DatabaseFailedJobProvider) Find(ctx context.Context, uuid string) (*queue.FailedJob, error) {
+	var jobs []queue.FailedJob
+	if err := p.db.WithContext(ctx).Table(p.table).Where("uuid = ?", uuid).Limit(1).Find(&jobs).Error(); err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return &jobs[0], nil
+}
+
+// Forget 删除失败任务
+func (p *DatabaseFailedJobProvider) Forget(ctx context.Context, uuid string) (bool, error) {
+	result := p.db.WithContext(ctx).Table(p.table).Where("uuid = ?", uuid).Delete(&queue.FailedJob{})
+	return result.RowsAffected() > 0, result.Error()
+}
+
+// Flush 清空失败任务
+func (p *DatabaseFailedJobProvider) Flush(ctx context.Context, olderThan time.Duration) error {
+	query := p.db.WithContext(ctx).Table(p.table)
+	if olderThan > 0 {
+		query = query.Where("failed_at < ?", p.now().Add(-olderThan))
+	} else {
+		query = query.Session(&database.SessionConfig{AllowGlobalUpdate: true})
+	}
+	return query.Delete(&queue.FailedJob{}).Error()
+}
+
+// failedJob 创建失败任务记录，UUID 优先使用载荷中的 UUID
+func failedJob(id uint, connection, queueName string, payload []byte, err error, now time.Time) queue.FailedJob {
+	uuid := ""
+	if decoded, decodeErr := queue.DecodePayload(payload); decodeErr == nil {
+		uuid = decoded.UUID
+	}
+	if uuid == "" {
+		uuid = queue.NewUUID()
+	}
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	return queue.FailedJob{
+		ID:         id,
+		UUID:       uuid,
+		Connection: connection,
+		Queue:      queueName,
+		Payload:    string(payload),
+		Exception:  message,
+		FailedAt:   now,
+	}
+}
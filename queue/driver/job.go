@@ -0,0 +1,123 @@
+package driver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// job QueuedJob 的通用实现，删除和释放由驱动通过回调完成
+type job struct {
+	id         string
+	queue      string
+	connection string
+	raw        []byte
+	payload    *queue.Payload
+	registry   *queue.Registry
+
+	deleteFn  func(ctx context.Context) error
+	releaseFn func(ctx context.Context, delay time.Duration) error
+
+	mu       sync.Mutex
+	deleted  bool
+	released bool
+	failed   bool
+}
+
+var _ queue.QueuedJob = (*job)(nil)
+
+// newJob 解码载荷并创建任务
+func newJob(id, queueName, connection string, raw []byte, registry *queue.Registry) (*job, error) {
+	payload, err := queue.DecodePayload(raw)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		id = payload.UUID
+	}
+	return &job{id: id, queue: queueName, connection: connection, raw: raw, payload: payload, registry: registry}, nil
+}
+
+func (j *job) ID() string                  { return j.id }
+func (j *job) UUID() string                { return j.payload.UUID }
+func (j *job) Payload() *queue.Payload     { return j.payload }
+func (j *job) RawBody() []byte             { return j.raw }
+func (j *job) Name() string                { return j.payload.DisplayName }
+func (j *job) Queue() string               { return j.queue }
+func (j *job) ConnectionName() string      { return j.connection }
+func (j *job) Attempts() int               { return j.payload.Attempts }
+func (j *job) MaxTries() int               { return j.payload.MaxTries }
+func (j *job) Backoff() []time.Duration    { return j.payload.Backoff }
+func (j *job) Timeout() time.Duration      { return j.payload.Timeout }
+func (j *job) Resolve() (queue.Job, error) { return j.registry.Resolve(j.payload) }
+
+func (j *job) Release(ctx context.Context, delay time.Duration) error {
+	j.mu.Lock()
+	j.released = true
+	j.mu.Unlock()
+	if j.releaseFn == nil {
+		return nil
+	}
+	return j.releaseFn(ctx, delay)
+}
+
+func (j *job) IsReleased() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.released
+}
+
+func (j *job) Delete(ctx context.Context) error {
+	j.mu.Lock()
+	j.deleted = true
+	j.mu.Unlock()
+	if j.deleteFn == nil {
+		return nil
+	}
+	return j.deleteFn(ctx)
+}
+
+func (j *job) IsDeleted() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.deleted
+}
+
+func (j *job) IsDeletedOrReleased() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.deleted || j.released
+}
+
+func (j *job) MarkAsFailed() {
+	j.mu.Lock()
+	j.failed = true
+	j.mu.Unlock()
+}
+
+func (j *job) HasFailed() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.failed
+}
+
+// Fail 标记失败并删除任务，然后调用任务的 Failed 方法、释放唯一锁并调用任务链的失败回调
+func (j *job) Fail(ctx context.Context, err error) error {
+	j.MarkAsFailed()
+	if j.IsDeleted() {
+		return nil
+	}
+	deleteErr := j.Delete(ctx)
+	if instance, resolveErr := j.Resolve(); resolveErr == nil {
+		instance.Failed(ctx, err)
+		_ = releaseUniqueLock(ctx, instance)
+	}
+	if catchers, resolveErr := j.registry.ResolveCatchers(j.payload); resolveErr == nil {
+		for _, catcher := range catchers {
+			catcher.Catch(ctx, err)
+		}
+	}
+	return deleteErr
+}
@@ -0,0 +1,131 @@
+package driver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// Manager 队列管理器实现
+//
+// 连接在首次使用时创建并缓存，内置 sync、memory、database、redis、sqs 驱动。
+type Manager struct {
+	mu                sync.RWMutex
+	config            map[string]map[string]interface{}
+	defaultConnection string
+	connections       map[string]queue.Queue
+	resolvers         map[string]func(config map[string]interface{}, name string) (queue.Queue, error)
+	failer            queue.FailedJobProvider
+}
+
+var _ queue.Manager = (*Manager)(nil)
+
+// NewManager 创建队列管理器
+//
+// config 为连接名称到连接配置的映射，失败任务默认记录在内存中，可通过 SetFailer 替换。
+func NewManager(config map[string]map[string]interface{}, defaultConnection string) *Manager {
+	m := &Manager{
+		config:            config,
+		defaultConnection: defaultConnection,
+		connections:       make(map[string]queue.Queue),
+		resolvers:         make(map[string]func(config map[string]interface{}, name string) (queue.Queue, error)),
+		failer:            NewMemoryFailedJobProvider(),
+	}
+	m.Extend(queue.DriverSync, func(config map[string]interface{}, name string) (queue.Queue, error) {
+		return NewSyncQueue(config), nil
+	})
+	m.Extend(queue.DriverMemory, func(config map[string]interface{}, name string) (queue.Queue, error) {
+		return NewMemoryQueue(config), nil
+	})
+	m.Extend(queue.DriverDatabase, func(config map[string]interface{}, name string) (queue.Queue, error) {
+		return NewDatabaseQueue(config)
+	})
+	m.Extend(queue.DriverRedis, func(config map[string]interface{}, name string) (queue.Queue, error) {
+		return NewRedisQueue(config)
+	})
+	m.Extend(queue.DriverSQS, func(config map[string]interface{}, name string) (queue.Queue, error) {
+		return NewSQSQueue(config)
+	})
+	return m
+}
+
+// Connection 获取连接，不传名称时使用默认连接
+func (m *Manager) Connection(name ...string) (queue.Queue, error) {
+	connection := m.GetDefaultConnection()
+	if len(name) > 0 && name[0] != "" {
+		connection = name[0]
+	}
+
+	m.mu.RLock()
+	q, ok := m.connections[connection]
+	m.mu.RUnlock()
+	if ok {
+		return q, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if q, ok := m.connections[connection]; ok {
+		return q, nil
+	}
+	config, ok := m.config[connection]
+	if !ok {
+		return nil, fmt.Errorf("queue: connection [%s] is not defined", connection)
+	}
+	driver, _ := config["driver"].(string)
+	resolver, ok := m.resolvers[driver]
+	if !ok {
+		return nil, fmt.Errorf("queue: driver [%s] is not supported", driver)
+	}
+	q, err := resolver(config, connection)
+	if err != nil {
+		return nil, err
+	}
+	q.SetConnectionName(connection)
+	m.connections[connection] = q
+	return q, nil
+}
+
+// GetDefaultConnection 获取默认连接名称
+func (m *Manager) GetDefaultConnection() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultConnection
+}
+
+// SetDefaultConnection 设置默认连接名称
+func (m *Manager) SetDefaultConnection(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultConnection = name
+}
+
+// Extend 注册驱动
+func (m *Manager) Extend(driver string, resolver func(config map[string]interface{}, name string) (queue.Queue, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resolvers[driver] = resolver
+}
+
+// Connected 是否已创建连接
+func (m *Manager) Connected(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.connections[name]
+	return ok
+}
+
+// Failer 获取失败任务记录器
+func (m *Manager) Failer() queue.FailedJobProvider {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.failer
+}
+
+// SetFailer 设置失败任务记录器
+func (m *Manager) SetFailer(failer queue.FailedJobProvider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failer = failer
+}
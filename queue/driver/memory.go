@@ -0,0 +1,168 @@
+package driver
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// MemoryQueue 进程内存驱动
+//
+// 任务保存在内存中，进程退出后丢失。支持延迟、保留超时（retry_after）和释放重试，
+// 行为与 database、redis 驱动一致，适合单进程部署和测试。
+type MemoryQueue struct {
+	base
+
+	retryAfter time.Duration
+	now        func() time.Time
+
+	mu     sync.Mutex
+	seq    int64
+	queues map[string][]*memoryEntry
+}
+
+// memoryEntry 内存中的任务记录
+type memoryEntry struct {
+	id          string
+	body        []byte
+	attempts    int
+	availableAt time.Time
+	reservedAt  time.Time
+}
+
+var (
+	_ queue.Queue             = (*MemoryQueue)(nil)
+	_ queue.VisibilityTimeout = (*MemoryQueue)(nil)
+)
+
+// NewMemoryQueue 创建内存队列
+//
+// 支持的配置：queue 默认队列、retry_after 保留超时（默认 90 秒）、registry 任务注册表。
+func NewMemoryQueue(config map[string]interface{}) *MemoryQueue {
+	return &MemoryQueue{
+		base:       newBase(config),
+		retryAfter: durationOption(config, "retry_after", 90*time.Second),
+		now:        time.Now,
+		queues:     make(map[string][]*memoryEntry),
+	}
+}
+
+// Size 队列中的任务数量
+func (q *MemoryQueue) Size(ctx context.Context, queueName string) (int64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return int64(len(q.queues[q.queueName(queueName)])), nil
+}
+
+// RetryAfter 保留超时
+func (q *MemoryQueue) RetryAfter() time.Duration {
+	return q.retryAfter
+}
+
+// Push 投递任务，DelayedJob 按其延迟投递
+func (q *MemoryQueue) Push(ctx context.Context, job queue.Job, queueName string) (string, error) {
+	return q.Later(ctx, queue.DelayOf(job), job, queueName)
+}
+
+// PushRaw 投递已编码的载荷
+func (q *MemoryQueue) PushRaw(ctx context.Context, payload []byte, queueName string) (string, error) {
+	return q.push(payload, queueName, 0), nil
+}
+
+// LaterRaw 延迟投递已编码的载荷
+func (q *MemoryQueue) LaterRaw(ctx context.Context, delay time.Duration, payload []byte, queueName string) (string, error) {
+	return q.push(payload, queueName, delay), nil
+}
+
+// Later 延迟投递任务
+func (q *MemoryQueue) Later(ctx context.Context, delay time.Duration, job queue.Job, queueName string) (string, error) {
+	body, err := q.encode(ctx, job)
+	if body == nil {
+		return "", err
+	}
+	return q.push(body, queueName, delay), nil
+}
+
+// Bulk 批量投递任务
+func (q *MemoryQueue) Bulk(ctx context.Context, jobs []queue.Job, queueName string) error {
+	for _, job := range jobs {
+		if _, err := q.Push(ctx, job, queueName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *MemoryQueue) push(body []byte, queueName string, delay time.Duration) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.seq++
+	entry := &memoryEntry{
+		id:          strconv.FormatInt(q.seq, 10),
+		body:        body,
+		availableAt: q.now().Add(delay),
+	}
+	name := q.queueName(queueName)
+	q.queues[name] = append(q.queues[name], entry)
+	return entry.id
+}
+
+// Pop 取出下一个可用任务，保留超时的任务会被重新取出
+func (q *MemoryQueue) Pop(ctx context.Context, queueName string) (queue.QueuedJob, error) {
+	name := q.queueName(queueName)
+
+	q.mu.Lock()
+	now := q.now()
+	var entry *memoryEntry
+	for _, e := range q.queues[name] {
+		available := e.reservedAt.IsZero() && !e.availableAt.After(now)
+		expired := !e.reservedAt.IsZero() && !e.reservedAt.Add(q.retryAfter).After(now)
+		if available || expired {
+			entry = e
+			break
+		}
+	}
+	if entry == nil {
+		q.mu.Unlock()
+		return nil, nil
+	}
+	entry.attempts++
+	entry.reservedAt = now
+	body := entry.body
+	attempts := entry.attempts
+	q.mu.Unlock()
+
+	j, err := newJob(entry.id, name, q.connection, body, q.registry)
+	if err != nil {
+		return nil, err
+	}
+	j.payload.Attempts = attempts
+	j.deleteFn = func(ctx context.Context) error {
+		q.remove(name, entry)
+		return nil
+	}
+	j.releaseFn = func(ctx context.Context, delay time.Duration) error {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		entry.reservedAt = time.Time{}
+		entry.availableAt = q.now().Add(delay)
+		return nil
+	}
+	return j, nil
+}
+
+// remove 删除任务记录
+func (q *MemoryQueue) remove(name string, entry *memoryEntry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entries := q.queues[name]
+	for i, e := range entries {
+		if e == entry {
+			q.queues[name] = append(entries[:i:i], entries[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// RedisClient Redis 驱动需要的客户端能力
+//
+// 所有操作都通过 Lua 脚本完成，保证取出和保留的原子性。
+// 适配 go-redis 时注意把 redis.Nil 转换为 nil, nil：
+//
+//	type goRedis struct{ client *redis.Client }
+//
+//	func (r goRedis) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+//		result, err := r.client.Eval(ctx, script, keys, args...).Result()
+//		if errors.Is(err, redis.Nil) {
+//			return nil, nil
+//		}
+//		return result, err
+//	}
+type RedisClient interface {
+	// Eval 执行 Lua 脚本，整数返回 int64，字符串返回 string，数组返回 []interface{}
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+}
+
+// Redis 驱动使用的 Lua 脚本，与 Laravel 的 Illuminate\Queue\LuaScripts 一致
+const (
+	// redisSizeScript 队列长度，KEYS: 队列、延迟集合、保留集合
+	redisSizeScript = `return redis.call('llen', KEYS[1]) + redis.call('zcard', KEYS[2]) + redis.call('zcard', KEYS[3])`
+
+	// redisPushScript 投递任务，KEYS: 队列，ARGV: 载荷
+	redisPushScript = `return redis.call('rpush', KEYS[1], ARGV[1])`
+
+	// redisLaterScript 延迟投递任务，KEYS: 延迟集合，ARGV: 可用时间、载荷
+	redisLaterScript = `return redis.call('zadd', KEYS[1], ARGV[1], ARGV[2])`
+
+	// redisPopScript 取出任务，递增尝试次数并放入保留集合
+	// KEYS: 队列、保留集合，ARGV: 保留超时时间点
+	redisPopScript = `
+local job = redis.call('lpop', KEYS[1])
+local reserved = false
+if(job ~= false) then
+    reserved = cjson.decode(job)
+    reserved['attempts'] = reserved['attempts'] + 1
+    reserved = cjson.encode(reserved)
+    redis.call('zadd', KEYS[2], ARGV[1], reserved)
+end
+return {job, reserved}`
+
+	// redisMigrateScript 把到期的任务从有序集合移回队列
+	// KEYS: 有序集合、队列，ARGV: 当前时间
+	redisMigrateScript = `
+local val = redis.call('zrangebyscore', KEYS[1], '-inf', ARGV[1])
+if(next(val) ~= nil) then
+    redis.call('zremrangebyrank', KEYS[1], 0, #val - 1)
+    for i = 1, #val, 100 do
+        redis.call('rpush', KEYS[2], unpack(val, i, math.min(i+99, #val)))
+    end
+end
+return val`
+
+	// redisReleaseScript 释放任务，KEYS: 延迟集合、保留集合，ARGV: 保留载荷、可用时间
+	redisReleaseScript = `
+redis.call('zrem', KEYS[2], ARGV[1])
+redis.call('zadd', KEYS[1], ARGV[2], ARGV[1])
+return true`
+
+	// redisDeleteScript 删除已保留的任务，KEYS: 保留集合，ARGV: 保留载荷
+	redisDeleteScript = `return redis.call('zrem', KEYS[1], ARGV[1])`
+)
+
+// RedisQueue Redis 驱动
+//
+// 键的布局与 Laravel 相同：queues:{name} 为待处理列表，
+// queues:{name}:delayed 和 queues:{name}:reserved 为以时间戳为分数的有序集合。
+type RedisQueue struct {
+	base
+
+	client     RedisClient
+	retryAfter time.Duration
+	now        func() time.Time
+}
+
+var (
+	_ queue.Queue             = (*RedisQueue)(nil)
+	_ queue.VisibilityTimeout = (*RedisQueue)(nil)
+)
+
+// NewRedisQueue 创建 Redis 队列
+//
+// 支持的配置：client RedisClient（必需）、queue 默认队列、
+// retry_after 保留超时（默认 90 秒）、registry 任务注册表。
+func NewRedisQueue(config map[string]interface{}) (*RedisQueue, error) {
+	client, err := clientOption[RedisClient](config, "client")
+	if err != nil {
+		return nil, err
+	}
+	return &RedisQueue{
+		base:       newBase(config),
+		client:     client,
+		retryAfter: durationOption(config, "retry_after", 90*time.Second),
+		now:        time.Now,
+	}, nil
+}
+
+// key 队列列表的键
+func (q *RedisQueue) key(queueName string) string {
+	return "queues:" + q.queueName(queueName)
+}
+
+// Size 队列中的任务数量，包括延迟和已保留的任务
+func (q *RedisQueue) Size(ctx context.Context, queueName string) (int64, error) {
+	key := q.key(queueName)
+	result, err := q.client.Eval(ctx, redisSizeScript, []string{key, key + ":delayed", key + ":reserved"})
+	if err != nil {
+		return 0, err
+	}
+	size, _ := result.(int64)
+	return size, nil
+}
+
+// RetryAfter 保留超时
+func (q *RedisQueue) RetryAfter() time.Duration {
+	return q.retryAfter
+}
+
+// Push 投递任务，DelayedJob 按其延迟投递
+func (q *RedisQueue) Push(ctx context.Context, job queue.Job, queueName string) (string, error) {
+	return q.Later(ctx, queue.DelayOf(job), job, queueName)
+}
+
+// PushRaw 投递已编码的载荷
+func (q *RedisQueue) PushRaw(ctx context.Context, payload []byte, queueName string) (string, error) {
+	return q.push(ctx, payload, queueName, 0)
+}
+
+// LaterRaw 延迟投递已编码的载荷
+func (q *RedisQueue) LaterRaw(ctx context.Context, delay time.Duration, payload []byte, queueName string) (string, error) {
+	return q.push(ctx, payload, queueName, delay)
+}
+
+// Later 延迟投递任务
+func (q *RedisQueue) Later(ctx context.Context, delay time.Duration, job queue.Job, queueName string) (string, error) {
+	body, err := q.encode(ctx, job)
+	if body == nil {
+		return "", err
+	}
+	return q.push(ctx, body, queueName, delay)
+}
+
+// Bulk 批量投递任务
+func (q *RedisQueue) Bulk(ctx context.Context, jobs []queue.Job, queueName string) error {
+	for _, job := range jobs {
+		if _, err := q.Push(ctx, job, queueName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *RedisQueue) push(ctx context.Context, body []byte, queueName string, delay time.Duration) (string, error) {
+	payload, err := queue.DecodePayload(body)
+	if err != nil {
+		return "", err
+	}
+	key := q.key(queueName)
+	if delay > 0 {
+		_, err = q.client.Eval(ctx, redisLaterScript, []string{key + ":delayed"}, q.score(delay), string(body))
+	} else {
+		_, err = q.client.Eval(ctx, redisPushScript, []string{key}, string(body))
+	}
+	return payload.UUID, err
+}
+
+// score 有序集合分数，即可用时间的 Unix 秒
+func (q *RedisQueue) score(delay time.Duration) string {
+	return strconv.FormatInt(q.now().Add(delay).Unix(), 10)
+}
+
+// Pop 迁移到期的延迟和保留任务后取出下一个任务
+func (q *RedisQueue) Pop(ctx context.Context, queueName string) (queue.QueuedJob, error) {
+	key := q.key(queueName)
+	now := q.score(0)
+	for _, from := range []string{key + ":delayed", key + ":reserved"} {
+		if _, err := q.client.Eval(ctx, redisMigrateScript, []string{from, key}, now); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := q.client.Eval(ctx, redisPopScript, []string{key, key + ":reserved"}, q.score(q.retryAfter))
+	if err != nil {
+		return nil, err
+	}
+	values, ok := result.([]interface{})
+	if !ok || len(values) < 2 {
+		return nil, nil
+	}
+	reserved := redisString(values[1])
+	if reserved == "" {
+		return nil, nil
+	}
+
+	j, err := newJob("", q.queueName(queueName), q.connection, []byte(reserved), q.registry)
+	if err != nil {
+		return nil, err
+	}
+	j.deleteFn = func(ctx context.Context) error {
+		_, err := q.client.Eval(ctx, redisDeleteScript, []string{key + ":reserved"}, reserved)
+		return err
+	}
+	j.releaseFn = func(ctx context.Context, delay time.Duration) error {
+		_, err := q.client.Eval(ctx, redisReleaseScript, []string{key + ":delayed", key + ":reserved"}, reserved, q.score(delay))
+		return err
+	}
+	return j, nil
+}
+
+// redisString 把脚本返回值转换为字符串，Lua 的 false 返回空字符串
+func redisString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case nil, bool, int64:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
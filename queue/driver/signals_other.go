@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package driver
+
+// listenForSignals 处理进程信号，返回取消监听的函数
+//
+// js/wasm、plan9 等平台没有可用的进程信号，不做处理。
+func (w *Worker) listenForSignals() func() {
+	return func() {}
+}
@@ -0,0 +1,39 @@
+//go:build unix
+
+package driver
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// listenForSignals 处理进程信号，返回取消监听的函数
+//
+// SIGTERM、SIGINT、SIGQUIT 停止 Worker，SIGUSR2 暂停，SIGCONT 恢复。
+func (w *Worker) listenForSignals() func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGUSR2, syscall.SIGCONT)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				switch sig {
+				case syscall.SIGUSR2:
+					w.Pause()
+				case syscall.SIGCONT:
+					w.Resume()
+				default:
+					w.Stop()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
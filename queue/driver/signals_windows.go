@@ -0,0 +1,29 @@
+//go:build windows
+
+package driver
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// listenForSignals 处理进程信号，返回取消监听的函数
+//
+// Windows 不支持 SIGUSR2 和 SIGCONT，只处理中断和终止信号。
+func (w *Worker) listenForSignals() func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+			w.Stop()
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
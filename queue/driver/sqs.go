@@ -0,0 +1,145 @@
+package driver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// SQSMessage SQS 消息
+type SQSMessage struct {
+	MessageID     string
+	ReceiptHandle string
+	Body          string
+
+	// ReceiveCount ApproximateReceiveCount 属性，作为任务的尝试次数
+	ReceiveCount int
+}
+
+// SQSClient SQS 驱动需要的客户端能力
+//
+// 由使用方基于 aws-sdk-go-v2 等 SDK 实现，queueURL 为完整的队列地址。
+type SQSClient interface {
+	// SendMessage 发送消息，delay 最大 15 分钟
+	SendMessage(ctx context.Context, queueURL, body string, delay time.Duration) (string, error)
+
+	// ReceiveMessage 接收一条消息，需要请求 ApproximateReceiveCount 属性，没有消息时返回 nil, nil
+	ReceiveMessage(ctx context.Context, queueURL string) (*SQSMessage, error)
+
+	// DeleteMessage 删除消息
+	DeleteMessage(ctx context.Context, queueURL, receiptHandle string) error
+
+	// ChangeMessageVisibility 修改消息的可见性超时
+	ChangeMessageVisibility(ctx context.Context, queueURL, receiptHandle string, timeout time.Duration) error
+
+	// ApproximateNumberOfMessages 队列中的大致消息数量
+	ApproximateNumberOfMessages(ctx context.Context, queueURL string) (int64, error)
+}
+
+// SQSQueue Amazon SQS 驱动
+//
+// 保留超时由 SQS 队列的可见性超时控制，retry_after 配置不生效。
+type SQSQueue struct {
+	base
+
+	client SQSClient
+	prefix string
+	suffix string
+}
+
+var _ queue.Queue = (*SQSQueue)(nil)
+
+// NewSQSQueue 创建 SQS 队列
+//
+// 支持的配置：client SQSClient（必需）、prefix 队列地址前缀、suffix 队列名后缀、
+// queue 默认队列、registry 任务注册表。
+func NewSQSQueue(config map[string]interface{}) (*SQSQueue, error) {
+	client, err := clientOption[SQSClient](config, "client")
+	if err != nil {
+		return nil, err
+	}
+	return &SQSQueue{
+		base:   newBase(config),
+		client: client,
+		prefix: strings.TrimSuffix(stringOption(config, "prefix", ""), "/"),
+		suffix: stringOption(config, "suffix", ""),
+	}, nil
+}
+
+// QueueURL 获取队列地址，队列名本身是完整地址时直接返回
+func (q *SQSQueue) QueueURL(queueName string) string {
+	name := q.queueName(queueName)
+	if strings.HasPrefix(name, "https://") || strings.HasPrefix(name, "http://") {
+		return name
+	}
+	if q.suffix != "" && !strings.HasSuffix(name, q.suffix) {
+		name += q.suffix
+	}
+	if q.prefix == "" {
+		return name
+	}
+	return q.prefix + "/" + name
+}
+
+// Size 队列中的大致消息数量
+func (q *SQSQueue) Size(ctx context.Context, queueName string) (int64, error) {
+	return q.client.ApproximateNumberOfMessages(ctx, q.QueueURL(queueName))
+}
+
+// Push 投递任务，DelayedJob 按其延迟投递
+func (q *SQSQueue) Push(ctx context.Context, job queue.Job, queueName string) (string, error) {
+	return q.Later(ctx, queue.DelayOf(job), job, queueName)
+}
+
+// PushRaw 投递已编码的载荷
+func (q *SQSQueue) PushRaw(ctx context.Context, payload []byte, queueName string) (string, error) {
+	return q.client.SendMessage(ctx, q.QueueURL(queueName), string(payload), 0)
+}
+
+// LaterRaw 延迟投递已编码的载荷，SQS 的延迟上限为 15 分钟
+func (q *SQSQueue) LaterRaw(ctx context.Context, delay time.Duration, payload []byte, queueName string) (string, error) {
+	return q.client.SendMessage(ctx, q.QueueURL(queueName), string(payload), delay)
+}
+
+// Later 延迟投递任务，SQS 的延迟上限为 15 分钟
+func (q *SQSQueue) Later(ctx context.Context, delay time.Duration, job queue.Job, queueName string) (string, error) {
+	body, err := q.encode(ctx, job)
+	if body == nil {
+		return "", err
+	}
+	return q.client.SendMessage(ctx, q.QueueURL(queueName), string(body), delay)
+}
+
+// Bulk 批量投递任务
+func (q *SQSQueue) Bulk(ctx context.Context, jobs []queue.Job, queueName string) error {
+	for _, job := range jobs {
+		if _, err := q.Push(ctx, job, queueName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pop 接收下一条消息
+func (q *SQSQueue) Pop(ctx context.Context, queueName string) (queue.QueuedJob, error) {
+	url := q.QueueURL(queueName)
+	message, err := q.client.ReceiveMessage(ctx, url)
+	if err != nil || message == nil {
+		return nil, err
+	}
+
+	j, err := newJob(message.MessageID, q.queueName(queueName), q.connection, []byte(message.Body), q.registry)
+	if err != nil {
+		return nil, err
+	}
+	j.payload.Attempts = message.ReceiveCount
+	j.deleteFn = func(ctx context.Context) error {
+		return q.client.DeleteMessage(ctx, url, message.ReceiptHandle)
+	}
+	j.releaseFn = func(ctx context.Context, delay time.Duration) error {
+		return q.client.ChangeMessageVisibility(ctx, url, message.ReceiptHandle, delay)
+	}
+	return j, nil
+}
@@ -0,0 +1,112 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/appcontext"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// SyncQueue 同步驱动，投递时立即在当前 goroutine 执行任务
+//
+// 任务出错时直接标记失败并调用 Failed，不会重试，Later 忽略延迟。
+// 任务链中的后续任务同样立即执行，切换连接时使用 queue.DefaultManager。
+// 适合本地开发和测试环境。
+type SyncQueue struct {
+	base
+}
+
+var _ queue.Queue = (*SyncQueue)(nil)
+
+// NewSyncQueue 创建同步队列
+func NewSyncQueue(config map[string]interface{}) *SyncQueue {
+	return &SyncQueue{base: newBase(config)}
+}
+
+// Size 同步队列始终为空
+func (q *SyncQueue) Size(ctx context.Context, queueName string) (int64, error) {
+	return 0, nil
+}
+
+// Push 立即执行任务
+func (q *SyncQueue) Push(ctx context.Context, job queue.Job, queueName string) (string, error) {
+	body, err := q.encode(ctx, job)
+	if body == nil {
+		return "", err
+	}
+	return q.PushRaw(ctx, body, queueName)
+}
+
+// PushRaw 立即执行载荷对应的任务
+func (q *SyncQueue) PushRaw(ctx context.Context, payload []byte, queueName string) (string, error) {
+	j, err := newJob("", q.queueName(queueName), q.connection, payload, q.registry)
+	if err != nil {
+		return "", err
+	}
+	j.payload.Attempts = 1
+
+	ctx, err = appcontext.Hydrate(ctx, j.payload.Context)
+	if err != nil {
+		return j.id, err
+	}
+	instance, err := j.Resolve()
+	if err != nil {
+		return j.id, err
+	}
+	if err := handleThroughMiddleware(ctx, j, instance); err != nil {
+		_ = j.Fail(ctx, err)
+		return j.id, err
+	}
+	if err := releaseUniqueLock(ctx, instance); err != nil {
+		return j.id, err
+	}
+	return j.id, queue.DispatchNextJobInChain(ctx, queue.DefaultManager(), q, j.payload)
+}
+
+// LaterRaw 同步驱动忽略延迟，立即执行
+func (q *SyncQueue) LaterRaw(ctx context.Context, delay time.Duration, payload []byte, queueName string) (string, error) {
+	return q.PushRaw(ctx, payload, queueName)
+}
+
+// Later 同步驱动忽略延迟，立即执行
+func (q *SyncQueue) Later(ctx context.Context, delay time.Duration, job queue.Job, queueName string) (string, error) {
+	return q.Push(ctx, job, queueName)
+}
+
+// Bulk 依次执行任务，遇到错误时停止
+func (q *SyncQueue) Bulk(ctx context.Context, jobs []queue.Job, queueName string) error {
+	for _, job := range jobs {
+		if _, err := q.Push(ctx, job, queueName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pop 同步队列没有待处理任务
+func (q *SyncQueue) Pop(ctx context.Context, queueName string) (queue.QueuedJob, error) {
+	return nil, nil
+}
+
+// handleThroughMiddleware 通过任务声明的中间件执行任务，panic 转换为错误
+func handleThroughMiddleware(ctx context.Context, job queue.QueuedJob, instance queue.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("queue: job panicked: %v", r)
+		}
+	}()
+	if m, ok := instance.(queue.HasMiddleware); ok {
+		return queue.ThroughMiddleware(ctx, job, m.Middleware(), instance.Handle)
+	}
+	return instance.Handle(ctx)
+}
+
+// releaseUniqueLock 任务完成后释放唯一锁，处理前已释放锁的任务除外
+func releaseUniqueLock(ctx context.Context, instance queue.Job) error {
+	if _, ok := instance.(queue.ShouldBeUniqueUntilProcessing); ok {
+		return nil
+	}
+	return queue.ReleaseUniqueLock(ctx, instance)
+}
@@ -0,0 +1,412 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cnote0/laraveldoc/appcontext"
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// Worker queue.Worker 实现
+//
+// 并发处理时每个 goroutine 独立轮询队列，按队列先后顺序取任务。
+// 任务超时后 Worker 不再等待该任务，但无法强制终止不检查 ctx 的 Handle，
+// 其 goroutine 会继续运行直到返回。
+type Worker struct {
+	manager queue.Manager
+
+	mu        sync.RWMutex
+	listeners []func(ctx context.Context, event interface{})
+
+	stopping chan struct{}
+	stopOnce sync.Once
+	paused   atomic.Bool
+
+	cache cache.Store
+}
+
+var _ queue.Worker = (*Worker)(nil)
+
+// NewWorker 创建 Worker
+func NewWorker(manager queue.Manager) *Worker {
+	return &Worker{manager: manager, stopping: make(chan struct{})}
+}
+
+// Listen 注册事件监听器
+func (w *Worker) Listen(listener func(ctx context.Context, event interface{})) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, listener)
+}
+
+// SetCache 设置用于检查 queue:restart 信号的缓存存储
+func (w *Worker) SetCache(store cache.Store) {
+	w.cache = store
+}
+
+// Stop 通知 Worker 处理完当前任务后停止，停止后的 Worker 不能再次启动
+func (w *Worker) Stop() {
+	w.stopOnce.Do(func() { close(w.stopping) })
+}
+
+// Pause 暂停取新任务
+func (w *Worker) Pause() {
+	w.paused.Store(true)
+}
+
+// Resume 恢复取任务
+func (w *Worker) Resume() {
+	w.paused.Store(false)
+}
+
+// restartTime 缓存中的重启时间，未设置缓存或读取失败时返回空字符串
+func (w *Worker) restartTime(ctx context.Context) string {
+	if w.cache == nil {
+		return ""
+	}
+	value, ok, err := w.cache.Get(ctx, queue.RestartKey)
+	if err != nil || !ok {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// memoryExceeded 堆内存是否超过上限（MB）
+func memoryExceeded(limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc >= uint64(limit)*1024*1024
+}
+
+// fire 触发事件
+func (w *Worker) fire(ctx context.Context, event interface{}) {
+	w.mu.RLock()
+	listeners := w.listeners
+	w.mu.RUnlock()
+	for _, listener := range listeners {
+		listener(ctx, event)
+	}
+}
+
+// Daemon 持续处理任务
+//
+// 以下情况会在处理完当前任务后退出：ctx 取消、调用 Stop、收到终止信号、
+// 满足 MaxJobs/MaxTime/StopWhenEmpty、内存超过 Memory（返回 ErrMemoryLimitExceeded）、
+// 通过 queue.Restart 请求重启。队列后端断线时立即退出，返回的错误满足 errors.Is(err, queue.ErrConnectionLost)。
+func (w *Worker) Daemon(ctx context.Context, connection, queueNames string, options queue.WorkerOptions) error {
+	options = withDefaults(options)
+	q, err := w.manager.Connection(connection)
+	if err != nil {
+		return err
+	}
+	if v, ok := q.(queue.VisibilityTimeout); ok && v.RetryAfter() > 0 && options.Timeout >= v.RetryAfter() {
+		return queue.ErrTimeoutExceedsRetryAfter
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if options.MaxTime > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, options.MaxTime)
+		defer cancelTimeout()
+	}
+	if !options.IgnoreSignals {
+		defer w.listenForSignals()()
+	}
+	lastRestart := w.restartTime(ctx)
+
+	var processed int64
+	var wg sync.WaitGroup
+	errs := make(chan error, options.Concurrency)
+	stopWith := func(err error) {
+		if err != nil {
+			errs <- err
+		}
+		cancel()
+	}
+	for i := 0; i < options.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if w.shouldStop(ctx) {
+					return
+				}
+				if w.paused.Load() {
+					w.sleep(ctx, options.Sleep)
+					continue
+				}
+				if w.restartTime(ctx) != lastRestart {
+					stopWith(nil)
+					return
+				}
+				w.fire(ctx, queue.Looping{ConnectionName: connection, Queue: queueNames})
+
+				job, err := w.nextJob(ctx, q, queueNames)
+				if err != nil {
+					if ctx.Err() == nil {
+						stopWith(err)
+					}
+					return
+				}
+				if job == nil {
+					if options.StopWhenEmpty {
+						stopWith(nil)
+						return
+					}
+					w.sleep(ctx, options.Sleep)
+					continue
+				}
+
+				// 已取出的任务需要完整处理，不受停止信号影响
+				if err := w.Process(context.WithoutCancel(ctx), connection, job, options); err != nil {
+					stopWith(connectionError(err))
+					return
+				}
+				if options.MaxJobs > 0 && atomic.AddInt64(&processed, 1) >= int64(options.MaxJobs) {
+					stopWith(nil)
+					return
+				}
+				if memoryExceeded(options.Memory) {
+					stopWith(queue.ErrMemoryLimitExceeded)
+					return
+				}
+				if options.Rest > 0 {
+					w.sleep(ctx, options.Rest)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	err = <-errs
+	status := queue.ExitSuccess
+	switch {
+	case errors.Is(err, queue.ErrMemoryLimitExceeded):
+		status = queue.ExitMemoryLimit
+	case err != nil:
+		status = queue.ExitError
+	}
+	w.fire(context.Background(), queue.WorkerStopping{Status: status})
+	return err
+}
+
+// RunNextJob 处理下一个任务
+func (w *Worker) RunNextJob(ctx context.Context, connection, queueNames string, options queue.WorkerOptions) error {
+	options = withDefaults(options)
+	q, err := w.manager.Connection(connection)
+	if err != nil {
+		return err
+	}
+	job, err := w.nextJob(ctx, q, queueNames)
+	if err != nil || job == nil {
+		if err == nil {
+			w.sleep(ctx, options.Sleep)
+		}
+		return err
+	}
+	return w.Process(ctx, connection, job, options)
+}
+
+// nextJob 按优先级依次从各队列取任务
+func (w *Worker) nextJob(ctx context.Context, q queue.Queue, queueNames string) (queue.QueuedJob, error) {
+	for _, name := range queue.ParseQueues(queueNames) {
+		job, err := q.Pop(ctx, name)
+		if err != nil || job != nil {
+			return job, connectionError(err)
+		}
+	}
+	return nil, nil
+}
+
+// connectionError 把断线错误（见 database.IsLostConnection）包装为 queue.ErrConnectionLost，保留原始错误
+func connectionError(err error) error {
+	if err == nil || errors.Is(err, queue.ErrConnectionLost) || !database.IsLostConnection(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", queue.ErrConnectionLost, err)
+}
+
+// Process 处理任务
+//
+// 任务通过其声明的中间件执行，成功后释放唯一锁并投递任务链中的下一个任务；出错时未超过最大尝试次数则按 Backoff 释放，
+// 否则标记失败、调用任务链的失败回调并记录到 FailedJobProvider。
+// 返回的错误只表示队列后端操作失败，任务本身的错误通过事件报告。
+func (w *Worker) Process(ctx context.Context, connection string, job queue.QueuedJob, options queue.WorkerOptions) error {
+	options = withDefaults(options)
+	w.fire(ctx, queue.JobProcessing{ConnectionName: connection, Job: job})
+
+	maxTries := job.MaxTries()
+	if maxTries == 0 {
+		maxTries = options.MaxTries
+	}
+	if maxTries > 0 && job.Attempts() > maxTries {
+		return w.fail(ctx, connection, job, queue.ErrMaxAttemptsExceeded)
+	}
+
+	ctx, err := appcontext.Hydrate(ctx, job.Payload().Context)
+	if err != nil {
+		return w.fail(ctx, connection, job, err)
+	}
+	instance, err := job.Resolve()
+	if err == nil {
+		if _, ok := instance.(queue.ShouldBeUniqueUntilProcessing); ok {
+			if err := queue.ReleaseUniqueLock(ctx, instance); err != nil {
+				return err
+			}
+		}
+		err = w.run(ctx, job, instance, options)
+	}
+	if err == nil {
+		if !job.IsDeletedOrReleased() {
+			if err := job.Delete(ctx); err != nil {
+				return err
+			}
+		}
+		if !job.IsReleased() {
+			if err := releaseUniqueLock(ctx, instance); err != nil {
+				return err
+			}
+			if err := w.dispatchNextJobInChain(ctx, connection, job); err != nil {
+				return err
+			}
+		}
+		w.fire(ctx, queue.JobProcessed{ConnectionName: connection, Job: job})
+		return nil
+	}
+
+	w.fire(ctx, queue.JobExceptionOccurred{ConnectionName: connection, Job: job, Err: err})
+	if job.HasFailed() {
+		return nil
+	}
+	if maxTries > 0 && job.Attempts() >= maxTries {
+		return w.fail(ctx, connection, job, err)
+	}
+	if job.IsDeletedOrReleased() {
+		return nil
+	}
+
+	backoff := job.Backoff()
+	if len(backoff) == 0 {
+		backoff = options.Backoff
+	}
+	delay := queue.BackoffFor(backoff, job.Attempts())
+	if err := job.Release(ctx, delay); err != nil {
+		return err
+	}
+	w.fire(ctx, queue.JobReleasedAfterException{ConnectionName: connection, Job: job, Delay: delay})
+	return nil
+}
+
+// dispatchNextJobInChain 任务成功后投递任务链中的下一个任务
+func (w *Worker) dispatchNextJobInChain(ctx context.Context, connection string, job queue.QueuedJob) error {
+	if len(job.Payload().Chained) == 0 {
+		return nil
+	}
+	q, err := w.manager.Connection(connection)
+	if err != nil {
+		return err
+	}
+	return queue.DispatchNextJobInChain(ctx, w.manager, q, job.Payload())
+}
+
+// run 在超时限制内通过任务中间件执行任务
+func (w *Worker) run(ctx context.Context, job queue.QueuedJob, instance queue.Job, options queue.WorkerOptions) error {
+	timeout := job.Timeout()
+	if timeout == 0 {
+		timeout = options.Timeout
+	}
+	// 超过保留超时的任务会被其他 Worker 再次取出，执行时间不能超过 retry_after
+	if q, err := w.manager.Connection(job.ConnectionName()); err == nil {
+		if v, ok := q.(queue.VisibilityTimeout); ok && v.RetryAfter() > 0 && timeout > v.RetryAfter() {
+			timeout = v.RetryAfter()
+		}
+	}
+	// 任务使用独立的 context，Worker 停止时正在执行的任务不会被取消
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- handleThroughMiddleware(jobCtx, job, instance)
+	}()
+	select {
+	case err := <-done:
+		if errors.Is(err, context.DeadlineExceeded) && jobCtx.Err() != nil {
+			return queue.ErrTimeout
+		}
+		return err
+	case <-jobCtx.Done():
+		return queue.ErrTimeout
+	}
+}
+
+// fail 标记任务失败并记录
+func (w *Worker) fail(ctx context.Context, connection string, job queue.QueuedJob, err error) error {
+	if failErr := job.Fail(ctx, err); failErr != nil {
+		return failErr
+	}
+	if failer := w.manager.Failer(); failer != nil {
+		if _, logErr := failer.Log(ctx, connection, job.Queue(), job.RawBody(), err); logErr != nil {
+			return logErr
+		}
+	}
+	w.fire(ctx, queue.JobFailed{ConnectionName: connection, Job: job, Err: err})
+	return nil
+}
+
+// shouldStop 是否应该停止
+func (w *Worker) shouldStop(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-w.stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// sleep 休眠，可被停止信号打断
+func (w *Worker) sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	case <-w.stopping:
+	}
+}
+
+// withDefaults 为未设置的选项填充默认值
+func withDefaults(options queue.WorkerOptions) queue.WorkerOptions {
+	defaults := queue.DefaultWorkerOptions()
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaults.Concurrency
+	}
+	if options.Sleep <= 0 {
+		options.Sleep = defaults.Sleep
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaults.Timeout
+	}
+	if options.Name == "" {
+		options.Name = defaults.Name
+	}
+	if options.Memory == 0 {
+		options.Memory = defaults.Memory
+	}
+	return options
+}
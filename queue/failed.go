@@ -0,0 +1,42 @@
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+// FailedJob 失败任务记录
+type FailedJob struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	UUID       string    `gorm:"uniqueIndex;size:36" json:"uuid"`
+	Connection string    `json:"connection"`
+	Queue      string    `json:"queue"`
+	Payload    string    `gorm:"type:text" json:"payload"`
+	Exception  string    `gorm:"type:text" json:"exception"`
+	FailedAt   time.Time `json:"failed_at"`
+}
+
+// TableName 表名
+func (FailedJob) TableName() string {
+	return "failed_jobs"
+}
+
+// FailedJobProvider 失败任务记录接口
+//
+// 对应 Laravel 的 queue:failed、queue:retry、queue:forget 和 queue:flush 命令。
+type FailedJobProvider interface {
+	// Log 记录失败任务，返回记录 ID
+	Log(ctx context.Context, connection, queue string, payload []byte, err error) (string, error)
+
+	// All 获取所有失败任务，按失败时间倒序
+	All(ctx context.Context) ([]FailedJob, error)
+
+	// Find 按 UUID 查找失败任务，不存在时返回 nil, nil
+	Find(ctx context.Context, uuid string) (*FailedJob, error)
+
+	// Forget 删除失败任务
+	Forget(ctx context.Context, uuid string) (bool, error)
+
+	// Flush 清空失败任务，olderThan 大于 0 时只删除早于该时长的记录
+	Flush(ctx context.Context, olderThan time.Duration) error
+}
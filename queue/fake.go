@@ -0,0 +1,285 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// TestingT Fake 使用的测试接口，*testing.T 和 *testing.B 都满足
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// PushedJob FakeQueue 记录的已投递任务
+//
+// 通过 PushRaw、LaterRaw 投递的载荷解码后记录，Job 为 nil，Name 取自载荷。
+type PushedJob struct {
+	Job        Job
+	Name       string
+	Connection string
+	Queue      string
+	Delay      time.Duration
+}
+
+// FakeQueue 测试用的假队列管理器，对应 Laravel 的 Queue::fake
+//
+// 所有连接共享同一份投递记录，任务只被记录，不会执行。
+type FakeQueue struct {
+	t TestingT
+
+	mu                sync.Mutex
+	defaultConnection string
+	pushed            []*PushedJob
+}
+
+// Fake 把默认队列管理器替换为假队列，测试结束时恢复原管理器
+//
+// 访问器为 "queue" 的门面（例如 facades.Queue）同时替换为假队列。
+//
+// 使用示例：
+//
+//	func TestOrderShipping(t *testing.T) {
+//		fake := queue.Fake(t)
+//		// 执行发货
+//		fake.AssertPushed(&ShipOrder{}, func(job *queue.PushedJob) bool {
+//			return job.Job.(*ShipOrder).OrderID == 1
+//		})
+//		fake.AssertPushedOn("shipping", &ShipOrder{})
+//		fake.AssertNotPushed(&CancelOrder{})
+//	}
+func Fake(t TestingT) *FakeQueue {
+	t.Helper()
+	fake := NewFakeQueue(t)
+	previous := DefaultManager()
+	if previous != nil {
+		fake.defaultConnection = previous.GetDefaultConnection()
+	}
+	SetDefaultManager(fake)
+	t.Cleanup(func() {
+		SetDefaultManager(previous)
+	})
+	application.SwapFacade(t, "queue", fake)
+	return fake
+}
+
+// NewFakeQueue 创建假队列管理器，默认连接为 sync
+func NewFakeQueue(t TestingT) *FakeQueue {
+	return &FakeQueue{t: t, defaultConnection: DriverSync}
+}
+
+// Connection 获取连接，不传名称时使用默认连接
+func (f *FakeQueue) Connection(name ...string) (Queue, error) {
+	connection := f.GetDefaultConnection()
+	if len(name) > 0 && name[0] != "" {
+		connection = name[0]
+	}
+	return &fakeConnection{fake: f, name: connection}, nil
+}
+
+// GetDefaultConnection 获取默认连接名称
+func (f *FakeQueue) GetDefaultConnection() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.defaultConnection
+}
+
+// SetDefaultConnection 设置默认连接名称
+func (f *FakeQueue) SetDefaultConnection(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.defaultConnection = name
+}
+
+// Extend 假队列不使用驱动，忽略注册
+func (f *FakeQueue) Extend(driver string, resolver func(config map[string]interface{}, name string) (Queue, error)) {
+}
+
+// Connected 假队列的连接总是可用
+func (f *FakeQueue) Connected(name string) bool {
+	return true
+}
+
+// Failer 假队列不记录失败任务
+func (f *FakeQueue) Failer() FailedJobProvider {
+	return nil
+}
+
+// Pushed 返回与 job 同名且满足条件的已投递任务，job 为 nil 时不按名称过滤
+func (f *FakeQueue) Pushed(job Job, filter ...func(job *PushedJob) bool) []*PushedJob {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var name string
+	if job != nil {
+		name = JobName(job)
+	}
+	var matched []*PushedJob
+	for _, pushed := range f.pushed {
+		if name != "" && pushed.Name != name {
+			continue
+		}
+		if len(filter) > 0 && filter[0] != nil && !filter[0](pushed) {
+			continue
+		}
+		matched = append(matched, pushed)
+	}
+	return matched
+}
+
+// AssertPushed 断言投递过与 job 同名的任务，可选的 filter 进一步筛选
+func (f *FakeQueue) AssertPushed(job Job, filter ...func(job *PushedJob) bool) bool {
+	f.t.Helper()
+	if len(f.Pushed(job, filter...)) == 0 {
+		f.t.Errorf("queue: the expected [%s] job was not pushed", JobName(job))
+		return false
+	}
+	return true
+}
+
+// AssertPushedOn 断言投递过与 job 同名的任务到 queue 队列
+func (f *FakeQueue) AssertPushedOn(queue string, job Job, filter ...func(job *PushedJob) bool) bool {
+	f.t.Helper()
+	matched := f.Pushed(job, filter...)
+	for _, pushed := range matched {
+		if pushed.Queue == queue {
+			return true
+		}
+	}
+	f.t.Errorf("queue: the expected [%s] job was not pushed to queue [%s]", JobName(job), queue)
+	return false
+}
+
+// AssertPushedTimes 断言与 job 同名的任务投递了 times 次
+func (f *FakeQueue) AssertPushedTimes(job Job, times int) bool {
+	f.t.Helper()
+	if n := len(f.Pushed(job)); n != times {
+		f.t.Errorf("queue: the expected [%s] job was pushed [%d] times instead of [%d] times", JobName(job), n, times)
+		return false
+	}
+	return true
+}
+
+// AssertNotPushed 断言没有投递过与 job 同名且满足条件的任务
+func (f *FakeQueue) AssertNotPushed(job Job, filter ...func(job *PushedJob) bool) bool {
+	f.t.Helper()
+	if len(f.Pushed(job, filter...)) > 0 {
+		f.t.Errorf("queue: the unexpected [%s] job was pushed", JobName(job))
+		return false
+	}
+	return true
+}
+
+// AssertCount 断言共投递了 count 个任务
+func (f *FakeQueue) AssertCount(count int) bool {
+	f.t.Helper()
+	if n := len(f.Pushed(nil)); n != count {
+		f.t.Errorf("queue: expected [%d] jobs to be pushed, but found [%d] instead", count, n)
+		return false
+	}
+	return true
+}
+
+// AssertNothingPushed 断言没有投递任何任务
+func (f *FakeQueue) AssertNothingPushed() bool {
+	f.t.Helper()
+	if n := len(f.Pushed(nil)); n > 0 {
+		f.t.Errorf("queue: [%d] unexpected jobs were pushed", n)
+		return false
+	}
+	return true
+}
+
+// AssertNothingQueued 同 AssertNothingPushed
+func (f *FakeQueue) AssertNothingQueued() bool {
+	f.t.Helper()
+	return f.AssertNothingPushed()
+}
+
+// record 记录投递的任务
+func (f *FakeQueue) record(pushed *PushedJob) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pushed = append(f.pushed, pushed)
+	return NewUUID()
+}
+
+// fakeConnection FakeQueue 的连接
+type fakeConnection struct {
+	fake *FakeQueue
+	name string
+}
+
+// Size 连接上指定队列的已投递任务数量
+func (c *fakeConnection) Size(ctx context.Context, queue string) (int64, error) {
+	var size int64
+	for _, pushed := range c.fake.Pushed(nil) {
+		if pushed.Connection == c.name && pushed.Queue == queue {
+			size++
+		}
+	}
+	return size, nil
+}
+
+// Push 记录任务
+func (c *fakeConnection) Push(ctx context.Context, job Job, queue string) (string, error) {
+	return c.Later(ctx, DelayOf(job), job, queue)
+}
+
+// PushRaw 解码并记录载荷
+func (c *fakeConnection) PushRaw(ctx context.Context, payload []byte, queue string) (string, error) {
+	return c.LaterRaw(ctx, 0, payload, queue)
+}
+
+// Later 记录延迟任务
+func (c *fakeConnection) Later(ctx context.Context, delay time.Duration, job Job, queue string) (string, error) {
+	return c.fake.record(&PushedJob{
+		Job:        job,
+		Name:       JobName(job),
+		Connection: c.name,
+		Queue:      queue,
+		Delay:      delay,
+	}), nil
+}
+
+// LaterRaw 解码并记录延迟载荷
+func (c *fakeConnection) LaterRaw(ctx context.Context, delay time.Duration, payload []byte, queue string) (string, error) {
+	decoded, err := DecodePayload(payload)
+	if err != nil {
+		return "", err
+	}
+	return c.fake.record(&PushedJob{
+		Name:       decoded.Job,
+		Connection: c.name,
+		Queue:      queue,
+		Delay:      delay,
+	}), nil
+}
+
+// Bulk 逐个记录任务
+func (c *fakeConnection) Bulk(ctx context.Context, jobs []Job, queue string) error {
+	for _, job := range jobs {
+		if _, err := c.Push(ctx, job, queue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pop 假队列中的任务不会被取出
+func (c *fakeConnection) Pop(ctx context.Context, queue string) (QueuedJob, error) {
+	return nil, nil
+}
+
+// ConnectionName 连接名称
+func (c *fakeConnection) ConnectionName() string {
+	return c.name
+}
+
+// SetConnectionName 设置连接名称
+func (c *fakeConnection) SetConnectionName(name string) {
+	c.name = name
+}
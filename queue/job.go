@@ -0,0 +1,160 @@
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+// Job 队列任务接口
+//
+// 任务在投递时序列化为 JSON，由 Worker 通过 Registry 反序列化后调用 Handle。
+// 嵌入 Queueable 即可获得除 Handle 外所有方法的默认实现。
+type Job interface {
+	// Handle 执行任务，返回错误时按 Tries 和 Backoff 重试
+	Handle(ctx context.Context) error
+
+	// Failed 任务最终失败（超过尝试次数或被标记失败）时调用
+	Failed(ctx context.Context, err error)
+
+	// Tries 最大尝试次数，0 表示使用 Worker 的默认值
+	Tries() int
+
+	// Backoff 重试前的等待时间，第 n 次重试取第 n 个值，超出时取最后一个，
+	// 为空表示使用 Worker 的默认值
+	Backoff() []time.Duration
+
+	// Timeout 单次执行的超时时间，0 表示使用 Worker 的默认值
+	Timeout() time.Duration
+}
+
+// NamedJob 自定义注册名称的任务或回调
+//
+// 默认使用 "包路径.类型名" 作为任务名称，重命名或移动类型会导致已入队的任务无法解析，
+// 实现此接口可以固定名称。
+type NamedJob interface {
+	JobName() string
+}
+
+// Queueable Job 的默认实现，嵌入到任务结构体中使用
+//
+// 使用示例：
+//
+//	type ProcessPodcast struct {
+//		queue.Queueable
+//		PodcastID uint `json:"podcast_id"`
+//	}
+//
+//	func (j *ProcessPodcast) Handle(ctx context.Context) error { ... }
+//
+//	// 按需覆盖默认值
+//	func (j *ProcessPodcast) Backoff() []time.Duration {
+//		return []time.Duration{time.Second, 5 * time.Second, 10 * time.Second}
+//	}
+type Queueable struct{}
+
+// Failed 默认不做处理
+func (Queueable) Failed(ctx context.Context, err error) {}
+
+// Tries 默认使用 Worker 的设置
+func (Queueable) Tries() int { return 0 }
+
+// Backoff 默认使用 Worker 的设置
+func (Queueable) Backoff() []time.Duration { return nil }
+
+// Timeout 默认使用 Worker 的设置
+func (Queueable) Timeout() time.Duration { return 0 }
+
+// ShouldQueue 标记命令、邮件、事件监听器等应投递到队列异步执行
+//
+// 命令总线、邮件、事件分发器遇到实现此接口的对象时会将其投递到队列，
+// 而不是在当前 goroutine 中同步执行。
+type ShouldQueue interface {
+	ShouldQueue()
+}
+
+// QueueRoute 指定投递时使用的连接和队列，为空时使用默认值
+type QueueRoute interface {
+	ViaConnection() string
+	ViaQueue() string
+}
+
+// Queued 需要排队的任务的默认实现，实现 Job（除 Handle 外）、ShouldQueue 和 QueueRoute
+//
+// 使用示例：
+//
+//	type SendInvoice struct {
+//		queue.Queued
+//		InvoiceID uint `json:"invoice_id"`
+//	}
+//
+//	cmd := &SendInvoice{InvoiceID: 1}
+//	cmd.OnQueue("billing")
+//	bus.Dispatch(ctx, cmd)
+type Queued struct {
+	Queueable
+
+	// QueueConnection 投递使用的连接，只在投递时使用，不会序列化到载荷中
+	QueueConnection string `json:"-"`
+
+	// QueueName 投递使用的队列，只在投递时使用，不会序列化到载荷中
+	QueueName string `json:"-"`
+}
+
+// ShouldQueue 标记为需要排队
+func (Queued) ShouldQueue() {}
+
+// ViaConnection 投递使用的连接
+func (q Queued) ViaConnection() string { return q.QueueConnection }
+
+// ViaQueue 投递使用的队列
+func (q Queued) ViaQueue() string { return q.QueueName }
+
+// OnConnection 设置投递使用的连接
+func (q *Queued) OnConnection(connection string) { q.QueueConnection = connection }
+
+// OnQueue 设置投递使用的队列
+func (q *Queued) OnQueue(queue string) { q.QueueName = queue }
+
+// DelayedJob 自带投递延迟的任务
+//
+// Push 投递此类任务时等同于 Later(Delay(), ...)，对应 Laravel 任务的 $delay 属性。
+type DelayedJob interface {
+	Delay() time.Duration
+}
+
+// DelayOf 任务自带的投递延迟，未实现 DelayedJob 时返回 0
+func DelayOf(job Job) time.Duration {
+	if delayed, ok := job.(DelayedJob); ok && delayed.Delay() > 0 {
+		return delayed.Delay()
+	}
+	return 0
+}
+
+// Until 距离指定时间的延迟，用于按时间点投递或释放任务，时间已过时返回 0
+//
+// 示例：
+//
+//	q.Later(ctx, queue.Until(time.Date(2024, 1, 1, 9, 0, 0, 0, loc)), job, "")
+//	job.Release(ctx, queue.Until(nextWindow))
+func Until(t time.Time) time.Duration {
+	if d := time.Until(t); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// BackoffFor 计算第 attempt 次尝试失败后的等待时间
+//
+// attempt 从 1 开始，超出 backoff 长度时取最后一个值，backoff 为空时返回 0。
+func BackoffFor(backoff []time.Duration, attempt int) time.Duration {
+	if len(backoff) == 0 {
+		return 0
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > len(backoff) {
+		return backoff[len(backoff)-1]
+	}
+	return backoff[attempt-1]
+}
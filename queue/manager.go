@@ -0,0 +1,69 @@
+package queue
+
+import "sync/atomic"
+
+// 内置驱动名称
+const (
+	// DriverSync 同步驱动，投递时立即在当前 goroutine 执行
+	DriverSync = "sync"
+
+	// DriverMemory 进程内存驱动
+	DriverMemory = "memory"
+
+	// DriverDatabase 数据库驱动，任务存储在 jobs 表
+	DriverDatabase = "database"
+
+	// DriverRedis Redis 驱动
+	DriverRedis = "redis"
+
+	// DriverSQS Amazon SQS 驱动
+	DriverSQS = "sqs"
+)
+
+// Manager 队列管理器接口
+//
+// 连接配置与 Laravel 的 config/queue.php 对应，每个连接的 "driver" 字段决定使用的驱动。
+//
+// 使用示例：
+//
+//	manager.Extend("kafka", func(config map[string]interface{}, name string) (queue.Queue, error) {
+//		return NewKafkaQueue(config)
+//	})
+//
+//	q, err := manager.Connection("redis")
+//	q.Push(ctx, job, "emails")
+type Manager interface {
+	// Connection 获取连接，不传名称时使用默认连接
+	Connection(name ...string) (Queue, error)
+
+	// GetDefaultConnection 获取默认连接名称
+	GetDefaultConnection() string
+
+	// SetDefaultConnection 设置默认连接名称
+	SetDefaultConnection(name string)
+
+	// Extend 注册驱动
+	Extend(driver string, resolver func(config map[string]interface{}, name string) (Queue, error))
+
+	// Connected 是否已创建连接
+	Connected(name string) bool
+
+	// Failer 获取失败任务记录器
+	Failer() FailedJobProvider
+}
+
+// defaultManager 默认队列管理器
+var defaultManager atomic.Value
+
+// SetDefaultManager 设置默认队列管理器，供 Chain(...).Dispatch 等便捷方法使用
+func SetDefaultManager(manager Manager) {
+	defaultManager.Store(&manager)
+}
+
+// DefaultManager 获取默认队列管理器，未设置时返回 nil
+func DefaultManager() Manager {
+	if manager, ok := defaultManager.Load().(*Manager); ok {
+		return *manager
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+// QueueMetrics 队列指标收集器接口
+//
+// 收集器监听 Worker 事件，统计各队列的吞吐量、执行耗时分位数、等待时间和失败数，
+// 并保留最近处理的任务快照，类似 Laravel Horizon 的仪表盘数据。
+type QueueMetrics interface {
+	// Watch 监听 Worker 事件
+	Watch(worker Worker)
+
+	// Snapshot 获取当前指标快照
+	Snapshot(ctx context.Context) (*MetricsSnapshot, error)
+
+	// Reset 清空已收集的指标
+	Reset()
+}
+
+// MetricsSnapshot 指标快照
+type MetricsSnapshot struct {
+	// GeneratedAt 生成时间
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// Processed 处理成功的任务总数
+	Processed int64 `json:"processed"`
+
+	// Failed 失败的任务总数
+	Failed int64 `json:"failed"`
+
+	// Throughput 最近一分钟每分钟处理的任务数
+	Throughput float64 `json:"throughput_per_minute"`
+
+	// Queues 各队列指标
+	Queues []QueueStats `json:"queues"`
+
+	// RecentJobs 最近处理的任务，按完成时间倒序
+	RecentJobs []JobSnapshot `json:"recent_jobs"`
+
+	// RecentFailed 最近失败的任务，按失败时间倒序
+	RecentFailed []JobSnapshot `json:"recent_failed"`
+}
+
+// QueueStats 单个队列的指标
+type QueueStats struct {
+	Connection string `json:"connection"`
+	Queue      string `json:"queue"`
+
+	// Pending 队列中的任务数量，无法获取时为 -1
+	Pending int64 `json:"pending"`
+
+	// Processed 处理成功的任务数
+	Processed int64 `json:"processed"`
+
+	// Failed 失败的任务数
+	Failed int64 `json:"failed"`
+
+	// Released 出错后释放重试的次数
+	Released int64 `json:"released"`
+
+	// Throughput 最近一分钟每分钟处理的任务数
+	Throughput float64 `json:"throughput_per_minute"`
+
+	// Runtime 执行耗时分位数
+	Runtime RuntimePercentiles `json:"runtime"`
+
+	// Wait 最近取出的任务从投递到开始处理的等待时间（毫秒），反映队列积压
+	Wait float64 `json:"wait_ms"`
+}
+
+// RuntimePercentiles 执行耗时分位数（毫秒）
+type RuntimePercentiles struct {
+	P50 float64 `json:"p50_ms"`
+	P95 float64 `json:"p95_ms"`
+	P99 float64 `json:"p99_ms"`
+	Max float64 `json:"max_ms"`
+}
+
+// 任务快照状态
+const (
+	JobStatusProcessing = "processing"
+	JobStatusCompleted  = "completed"
+	JobStatusReleased   = "released"
+	JobStatusFailed     = "failed"
+)
+
+// JobSnapshot 任务快照
+type JobSnapshot struct {
+	ID         string    `json:"id"`
+	UUID       string    `json:"uuid"`
+	Name       string    `json:"name"`
+	Connection string    `json:"connection"`
+	Queue      string    `json:"queue"`
+	Status     string    `json:"status"`
+	Attempts   int       `json:"attempts"`
+	PushedAt   time.Time `json:"pushed_at"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+
+	// Runtime 执行耗时（毫秒）
+	Runtime float64 `json:"runtime_ms"`
+
+	// Error 错误信息
+	Error string `json:"error,omitempty"`
+}
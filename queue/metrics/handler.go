@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// Handler 返回 JSON 仪表盘处理器
+//
+// 路由（相对于挂载路径）：
+// - GET /          完整快照
+// - GET /queues    各队列指标，支持 ?connection= 和 ?queue= 过滤
+// - GET /jobs      最近处理的任务
+// - GET /failed    最近失败的任务
+//
+// 处理器不做鉴权，挂载时应放在需要认证的路由分组下。
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		snapshot, err := c.Snapshot(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var body interface{}
+		switch strings.Trim(r.URL.Path, "/") {
+		case "":
+			body = snapshot
+		case "queues":
+			body = filterQueues(snapshot.Queues, r.URL.Query().Get("connection"), r.URL.Query().Get("queue"))
+		case "jobs":
+			body = snapshot.RecentJobs
+		case "failed":
+			body = snapshot.RecentFailed
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
+
+// filterQueues 按连接和队列名过滤
+func filterQueues(queues []queue.QueueStats, connection, queueName string) []queue.QueueStats {
+	result := make([]queue.QueueStats, 0, len(queues))
+	for _, stats := range queues {
+		if (connection == "" || stats.Connection == connection) && (queueName == "" || stats.Queue == queueName) {
+			result = append(result, stats)
+		}
+	}
+	return result
+}
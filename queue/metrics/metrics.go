@@ -0,0 +1,316 @@
+// Package metrics 提供 queue.QueueMetrics 的进程内实现和 JSON 仪表盘接口
+//
+// 包结构：
+// - metrics.go - Collector 指标收集器
+// - handler.go - Handler JSON 仪表盘 HTTP 处理器
+//
+// 使用示例：
+//
+//	collector := metrics.NewCollector(manager, metrics.Options{
+//		Queues: map[string][]string{"redis": {"high", "default"}},
+//	})
+//	collector.Watch(worker)
+//
+//	http.Handle("/horizon/api/", http.StripPrefix("/horizon/api", collector.Handler()))
+//	go worker.Daemon(ctx, "redis", "high,default", queue.WorkerOptions{Concurrency: 4})
+package metrics
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// Options 收集器选项
+type Options struct {
+	// Queues 需要统计待处理数量的连接和队列，即使还没有处理过任务也会出现在快照中
+	Queues map[string][]string
+
+	// RecentJobs 保留的最近任务数量，默认 50
+	RecentJobs int
+
+	// Samples 每个队列用于计算分位数的耗时样本数量，默认 1000
+	Samples int
+}
+
+// Collector 进程内指标收集器
+//
+// 多个 Worker 可以共享同一个收集器。指标只保存在内存中，
+// 多进程部署时每个进程的收集器只反映本进程的 Worker。
+type Collector struct {
+	manager queue.Manager
+	options Options
+	now     func() time.Time
+
+	mu           sync.Mutex
+	queues       map[queueKey]*queueStats
+	inflight     map[string]*queue.JobSnapshot
+	recent       []queue.JobSnapshot
+	recentFailed []queue.JobSnapshot
+}
+
+var _ queue.QueueMetrics = (*Collector)(nil)
+
+type queueKey struct {
+	connection string
+	queue      string
+}
+
+type queueStats struct {
+	processed   int64
+	failed      int64
+	released    int64
+	runtimes    []time.Duration
+	next        int
+	completions []time.Time
+	wait        time.Duration
+}
+
+// NewCollector 创建指标收集器，manager 用于查询队列的待处理数量，可以为 nil
+func NewCollector(manager queue.Manager, options Options) *Collector {
+	if options.RecentJobs <= 0 {
+		options.RecentJobs = 50
+	}
+	if options.Samples <= 0 {
+		options.Samples = 1000
+	}
+	return &Collector{
+		manager:  manager,
+		options:  options,
+		now:      time.Now,
+		queues:   make(map[queueKey]*queueStats),
+		inflight: make(map[string]*queue.JobSnapshot),
+	}
+}
+
+// Watch 监听 Worker 事件
+func (c *Collector) Watch(worker queue.Worker) {
+	worker.Listen(c.record)
+}
+
+// Reset 清空已收集的指标
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queues = make(map[queueKey]*queueStats)
+	c.inflight = make(map[string]*queue.JobSnapshot)
+	c.recent = nil
+	c.recentFailed = nil
+}
+
+// record 处理 Worker 事件
+func (c *Collector) record(ctx context.Context, event interface{}) {
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch e := event.(type) {
+	case queue.JobProcessing:
+		payload := e.Job.Payload()
+		c.inflight[inflightKey(e.Job)] = &queue.JobSnapshot{
+			ID:         e.Job.ID(),
+			UUID:       e.Job.UUID(),
+			Name:       e.Job.Name(),
+			Connection: e.ConnectionName,
+			Queue:      e.Job.Queue(),
+			Status:     queue.JobStatusProcessing,
+			Attempts:   e.Job.Attempts(),
+			PushedAt:   payload.PushedAt,
+			StartedAt:  now,
+		}
+		if !payload.PushedAt.IsZero() {
+			c.stats(e.ConnectionName, e.Job.Queue()).wait = now.Sub(payload.PushedAt)
+		}
+
+	case queue.JobProcessed:
+		snapshot := c.finish(e.Job, now, queue.JobStatusCompleted, nil)
+		if e.Job.IsReleased() {
+			snapshot.Status = queue.JobStatusReleased
+			c.stats(e.ConnectionName, e.Job.Queue()).released++
+			c.remember(snapshot, false)
+			return
+		}
+		stats := c.stats(e.ConnectionName, e.Job.Queue())
+		stats.processed++
+		stats.completions = append(stats.completions, now)
+		c.sample(stats, now.Sub(snapshot.StartedAt))
+		c.remember(snapshot, false)
+
+	case queue.JobReleasedAfterException:
+		c.stats(e.ConnectionName, e.Job.Queue()).released++
+		c.remember(c.finish(e.Job, now, queue.JobStatusReleased, nil), false)
+
+	case queue.JobFailed:
+		stats := c.stats(e.ConnectionName, e.Job.Queue())
+		stats.failed++
+		snapshot := c.finish(e.Job, now, queue.JobStatusFailed, e.Err)
+		c.sample(stats, now.Sub(snapshot.StartedAt))
+		c.remember(snapshot, true)
+	}
+}
+
+// finish 结束正在处理的任务快照
+func (c *Collector) finish(job queue.QueuedJob, now time.Time, status string, err error) queue.JobSnapshot {
+	key := inflightKey(job)
+	snapshot, ok := c.inflight[key]
+	if !ok {
+		snapshot = &queue.JobSnapshot{
+			ID:         job.ID(),
+			UUID:       job.UUID(),
+			Name:       job.Name(),
+			Connection: job.ConnectionName(),
+			Queue:      job.Queue(),
+			Attempts:   job.Attempts(),
+			PushedAt:   job.Payload().PushedAt,
+			StartedAt:  now,
+		}
+	}
+	delete(c.inflight, key)
+	snapshot.Status = status
+	snapshot.FinishedAt = now
+	snapshot.Runtime = milliseconds(now.Sub(snapshot.StartedAt))
+	if err != nil {
+		snapshot.Error = err.Error()
+	}
+	return *snapshot
+}
+
+// remember 记录最近任务
+func (c *Collector) remember(snapshot queue.JobSnapshot, failed bool) {
+	c.recent = prepend(c.recent, snapshot, c.options.RecentJobs)
+	if failed {
+		c.recentFailed = prepend(c.recentFailed, snapshot, c.options.RecentJobs)
+	}
+}
+
+// sample 记录耗时样本，超过样本数量时覆盖最旧的样本
+func (c *Collector) sample(stats *queueStats, runtime time.Duration) {
+	if len(stats.runtimes) < c.options.Samples {
+		stats.runtimes = append(stats.runtimes, runtime)
+		return
+	}
+	stats.runtimes[stats.next] = runtime
+	stats.next = (stats.next + 1) % c.options.Samples
+}
+
+// stats 获取队列统计，调用方持有锁
+func (c *Collector) stats(connection, queueName string) *queueStats {
+	key := queueKey{connection, queueName}
+	stats, ok := c.queues[key]
+	if !ok {
+		stats = &queueStats{}
+		c.queues[key] = stats
+	}
+	return stats
+}
+
+// Snapshot 获取当前指标快照
+func (c *Collector) Snapshot(ctx context.Context) (*queue.MetricsSnapshot, error) {
+	c.mu.Lock()
+	now := c.now()
+	for connection, names := range c.options.Queues {
+		for _, name := range names {
+			c.stats(connection, name)
+		}
+	}
+
+	snapshot := &queue.MetricsSnapshot{
+		GeneratedAt:  now,
+		RecentJobs:   append([]queue.JobSnapshot{}, c.recent...),
+		RecentFailed: append([]queue.JobSnapshot{}, c.recentFailed...),
+	}
+	for key, stats := range c.queues {
+		stats.completions = trimBefore(stats.completions, now.Add(-time.Minute))
+		snapshot.Queues = append(snapshot.Queues, queue.QueueStats{
+			Connection: key.connection,
+			Queue:      key.queue,
+			Pending:    -1,
+			Processed:  stats.processed,
+			Failed:     stats.failed,
+			Released:   stats.released,
+			Throughput: float64(len(stats.completions)),
+			Runtime:    percentiles(stats.runtimes),
+			Wait:       milliseconds(stats.wait),
+		})
+		snapshot.Processed += stats.processed
+		snapshot.Failed += stats.failed
+		snapshot.Throughput += float64(len(stats.completions))
+	}
+	c.mu.Unlock()
+
+	sort.Slice(snapshot.Queues, func(i, j int) bool {
+		a, b := snapshot.Queues[i], snapshot.Queues[j]
+		if a.Connection != b.Connection {
+			return a.Connection < b.Connection
+		}
+		return a.Queue < b.Queue
+	})
+
+	// 查询待处理数量可能访问外部存储，不在锁内进行
+	if c.manager != nil {
+		for i := range snapshot.Queues {
+			stats := &snapshot.Queues[i]
+			q, err := c.manager.Connection(stats.Connection)
+			if err != nil {
+				continue
+			}
+			if size, err := q.Size(ctx, stats.Queue); err == nil {
+				stats.Pending = size
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+// inflightKey 正在处理的任务键，同一任务重试时 ID 不变但不会并发处理
+func inflightKey(job queue.QueuedJob) string {
+	return job.ConnectionName() + "\x00" + job.Queue() + "\x00" + job.ID()
+}
+
+// percentiles 计算耗时分位数
+func percentiles(samples []time.Duration) queue.RuntimePercentiles {
+	if len(samples) == 0 {
+		return queue.RuntimePercentiles{}
+	}
+	sorted := append([]time.Duration{}, samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	at := func(p float64) float64 {
+		index := int(p*float64(len(sorted))+0.5) - 1
+		if index < 0 {
+			index = 0
+		}
+		if index >= len(sorted) {
+			index = len(sorted) - 1
+		}
+		return milliseconds(sorted[index])
+	}
+	return queue.RuntimePercentiles{
+		P50: at(0.50),
+		P95: at(0.95),
+		P99: at(0.99),
+		Max: milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// trimBefore 去掉早于 cutoff 的时间点，times 按时间升序
+func trimBefore(times []time.Time, cutoff time.Time) []time.Time {
+	i := sort.Search(len(times), func(i int) bool { return !times[i].Before(cutoff) })
+	return append(times[:0], times[i:]...)
+}
+
+// prepend 在列表头部插入并限制长度
+func prepend(list []queue.JobSnapshot, snapshot queue.JobSnapshot, limit int) []queue.JobSnapshot {
+	list = append([]queue.JobSnapshot{snapshot}, list...)
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// milliseconds 转换为毫秒
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
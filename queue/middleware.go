@@ -0,0 +1,317 @@
+package queue
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/pipeline"
+)
+
+// Middleware 任务中间件
+//
+// 中间件包裹任务的 Handle 调用，可以在执行前后加入逻辑，或通过 job.Release
+// 把任务放回队列而不执行。
+type Middleware interface {
+	Handle(ctx context.Context, job QueuedJob, next func(ctx context.Context) error) error
+}
+
+// MiddlewareFunc 函数形式的中间件
+type MiddlewareFunc func(ctx context.Context, job QueuedJob, next func(ctx context.Context) error) error
+
+// Handle 调用函数本身
+func (f MiddlewareFunc) Handle(ctx context.Context, job QueuedJob, next func(ctx context.Context) error) error {
+	return f(ctx, job, next)
+}
+
+// HasMiddleware 声明中间件的任务
+//
+// 使用示例：
+//
+//	func (j *ProcessPodcast) Middleware() []queue.Middleware {
+//		return []queue.Middleware{
+//			queue.WithoutOverlapping(strconv.Itoa(int(j.PodcastID))).ReleaseAfter(time.Minute),
+//			queue.RateLimited(cache.PerMinute(10).By("podcasts")),
+//		}
+//	}
+type HasMiddleware interface {
+	Middleware() []Middleware
+}
+
+// ThroughMiddleware 依次通过中间件执行 handler
+func ThroughMiddleware(ctx context.Context, job QueuedJob, middleware []Middleware, handler func(ctx context.Context) error) error {
+	stages := make([]pipeline.Pipe[QueuedJob, struct{}], len(middleware))
+	for i, m := range middleware {
+		stages[i] = pipeline.PipeFunc[QueuedJob, struct{}](func(ctx context.Context, job QueuedJob, next pipeline.Handler[QueuedJob, struct{}]) (struct{}, error) {
+			return struct{}{}, m.Handle(ctx, job, func(ctx context.Context) error {
+				_, err := next(ctx, job)
+				return err
+			})
+		})
+	}
+	_, err := pipeline.Compose(stages, func(ctx context.Context, job QueuedJob) (struct{}, error) {
+		return struct{}{}, handler(ctx)
+	})(ctx, job)
+	return err
+}
+
+var (
+	defaultLocks   atomic.Value
+	defaultLimiter atomic.Value
+	fallbackStore  = cache.NewMemoryStore()
+)
+
+// SetLockProvider 设置任务中间件和唯一任务默认使用的锁提供者
+//
+// 未设置时使用进程内的 cache.MemoryStore，多进程部署时应设置为共享存储。
+func SetLockProvider(locks cache.LockProvider) {
+	defaultLocks.Store(&locks)
+}
+
+// SetRateLimiter 设置任务中间件默认使用的限流器
+//
+// 未设置时使用进程内的 cache.MemoryStore，多进程部署时应设置为共享存储。
+func SetRateLimiter(limiter cache.RateLimiter) {
+	defaultLimiter.Store(&limiter)
+}
+
+// lockProvider 默认锁提供者
+func lockProvider() cache.LockProvider {
+	if locks, ok := defaultLocks.Load().(*cache.LockProvider); ok {
+		return *locks
+	}
+	return fallbackStore
+}
+
+// rateLimiter 默认限流器
+func rateLimiter() cache.RateLimiter {
+	if limiter, ok := defaultLimiter.Load().(*cache.RateLimiter); ok {
+		return *limiter
+	}
+	return fallbackStore
+}
+
+// WithoutOverlappingMiddleware 防止同一键的任务并发执行
+type WithoutOverlappingMiddleware struct {
+	key          string
+	releaseAfter time.Duration
+	expiresAfter time.Duration
+	release      bool
+	shared       bool
+	locks        cache.LockProvider
+}
+
+// WithoutOverlapping 创建防重叠中间件
+//
+// 同一任务类型和键的任务已在执行时，当前任务被放回队列，
+// 默认立即重新可见，可通过 ReleaseAfter 设置延迟。
+func WithoutOverlapping(key string) *WithoutOverlappingMiddleware {
+	return &WithoutOverlappingMiddleware{key: key, release: true}
+}
+
+// ReleaseAfter 设置任务放回队列后的延迟
+func (m *WithoutOverlappingMiddleware) ReleaseAfter(delay time.Duration) *WithoutOverlappingMiddleware {
+	m.releaseAfter = delay
+	return m
+}
+
+// DontRelease 锁被占用时直接丢弃任务，不放回队列
+func (m *WithoutOverlappingMiddleware) DontRelease() *WithoutOverlappingMiddleware {
+	m.release = false
+	return m
+}
+
+// ExpireAfter 设置锁的过期时间，防止 Worker 崩溃后锁无法释放
+func (m *WithoutOverlappingMiddleware) ExpireAfter(ttl time.Duration) *WithoutOverlappingMiddleware {
+	m.expiresAfter = ttl
+	return m
+}
+
+// Shared 在不同任务类型之间共享同一个键
+func (m *WithoutOverlappingMiddleware) Shared() *WithoutOverlappingMiddleware {
+	m.shared = true
+	return m
+}
+
+// Using 设置锁提供者
+func (m *WithoutOverlappingMiddleware) Using(locks cache.LockProvider) *WithoutOverlappingMiddleware {
+	m.locks = locks
+	return m
+}
+
+// Handle 获取锁后执行任务
+func (m *WithoutOverlappingMiddleware) Handle(ctx context.Context, job QueuedJob, next func(ctx context.Context) error) error {
+	locks := m.locks
+	if locks == nil {
+		locks = lockProvider()
+	}
+	name := "laravel-queue-overlap:" + job.Name() + ":" + m.key
+	if m.shared {
+		name = "laravel-queue-overlap:" + m.key
+	}
+
+	lock := locks.Lock(name, m.expiresAfter)
+	ok, err := lock.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if m.release {
+			return job.Release(ctx, m.releaseAfter)
+		}
+		return nil
+	}
+	defer lock.Release(context.WithoutCancel(ctx))
+	return next(ctx)
+}
+
+// RateLimitedMiddleware 按限流规则执行任务
+type RateLimitedMiddleware struct {
+	limits  []cache.Limit
+	release bool
+	limiter cache.RateLimiter
+}
+
+// RateLimited 创建限流中间件
+//
+// 任一规则超限时任务被放回队列，延迟到该规则的窗口重置。
+// 规则没有设置 Key 时按任务类型限流。
+func RateLimited(limits ...cache.Limit) *RateLimitedMiddleware {
+	return &RateLimitedMiddleware{limits: limits, release: true}
+}
+
+// DontRelease 超限时直接丢弃任务，不放回队列
+func (m *RateLimitedMiddleware) DontRelease() *RateLimitedMiddleware {
+	m.release = false
+	return m
+}
+
+// Using 设置限流器
+func (m *RateLimitedMiddleware) Using(limiter cache.RateLimiter) *RateLimitedMiddleware {
+	m.limiter = limiter
+	return m
+}
+
+// Handle 检查限流后执行任务
+func (m *RateLimitedMiddleware) Handle(ctx context.Context, job QueuedJob, next func(ctx context.Context) error) error {
+	limiter := m.limiter
+	if limiter == nil {
+		limiter = rateLimiter()
+	}
+
+	keys := make([]string, len(m.limits))
+	for i, limit := range m.limits {
+		keys[i] = "laravel-queue-rate:" + limit.Key
+		if limit.Key == "" {
+			keys[i] += job.Name()
+		}
+		tooMany, err := limiter.TooManyAttempts(ctx, keys[i], limit.MaxAttempts)
+		if err != nil {
+			return err
+		}
+		if tooMany {
+			if !m.release {
+				return nil
+			}
+			delay, err := limiter.AvailableIn(ctx, keys[i])
+			if err != nil {
+				return err
+			}
+			return job.Release(ctx, delay)
+		}
+	}
+	for i, limit := range m.limits {
+		if _, err := limiter.Hit(ctx, keys[i], limit.Decay); err != nil {
+			return err
+		}
+	}
+	return next(ctx)
+}
+
+// ThrottlesExceptionsMiddleware 任务连续出错时暂停执行
+type ThrottlesExceptionsMiddleware struct {
+	maxAttempts int
+	decay       time.Duration
+	key         string
+	backoff     time.Duration
+	when        func(err error) bool
+	limiter     cache.RateLimiter
+}
+
+// ThrottlesExceptions 创建异常节流中间件
+//
+// 任务在 decay 时间内出错 maxAttempts 次后，后续执行被推迟到窗口重置。
+// 出错的任务会被放回队列（延迟 Backoff），错误不再向上传递，
+// 因此应配合 Tries 或超时限制任务的总尝试次数。任务成功时清除计数。
+//
+// 使用示例：
+//
+//	func (j *SyncWithCRM) Middleware() []queue.Middleware {
+//		return []queue.Middleware{
+//			queue.ThrottlesExceptions(10, 5*time.Minute).Backoff(time.Minute),
+//		}
+//	}
+func ThrottlesExceptions(maxAttempts int, decay time.Duration) *ThrottlesExceptionsMiddleware {
+	return &ThrottlesExceptionsMiddleware{maxAttempts: maxAttempts, decay: decay}
+}
+
+// By 设置节流键，默认按任务类型
+func (m *ThrottlesExceptionsMiddleware) By(key string) *ThrottlesExceptionsMiddleware {
+	m.key = key
+	return m
+}
+
+// Backoff 设置出错后放回队列的延迟
+func (m *ThrottlesExceptionsMiddleware) Backoff(delay time.Duration) *ThrottlesExceptionsMiddleware {
+	m.backoff = delay
+	return m
+}
+
+// When 只对满足条件的错误节流，其他错误直接返回
+func (m *ThrottlesExceptionsMiddleware) When(when func(err error) bool) *ThrottlesExceptionsMiddleware {
+	m.when = when
+	return m
+}
+
+// Using 设置限流器
+func (m *ThrottlesExceptionsMiddleware) Using(limiter cache.RateLimiter) *ThrottlesExceptionsMiddleware {
+	m.limiter = limiter
+	return m
+}
+
+// Handle 执行任务并对错误节流
+func (m *ThrottlesExceptionsMiddleware) Handle(ctx context.Context, job QueuedJob, next func(ctx context.Context) error) error {
+	limiter := m.limiter
+	if limiter == nil {
+		limiter = rateLimiter()
+	}
+	key := "laravel-queue-throttle:" + m.key
+	if m.key == "" {
+		key += job.Name()
+	}
+
+	tooMany, err := limiter.TooManyAttempts(ctx, key, m.maxAttempts)
+	if err != nil {
+		return err
+	}
+	if tooMany {
+		delay, err := limiter.AvailableIn(ctx, key)
+		if err != nil {
+			return err
+		}
+		return job.Release(ctx, delay)
+	}
+
+	err = next(ctx)
+	if err == nil {
+		return limiter.Clear(ctx, key)
+	}
+	if m.when != nil && !m.when(err) {
+		return err
+	}
+	if _, hitErr := limiter.Hit(ctx, key, m.decay); hitErr != nil {
+		return hitErr
+	}
+	return job.Release(ctx, m.backoff)
+}
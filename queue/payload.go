@@ -0,0 +1,256 @@
+package queue
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Payload 任务载荷，即存储在队列后端中的 JSON 结构
+type Payload struct {
+	// UUID 任务唯一标识
+	UUID string `json:"uuid"`
+
+	// DisplayName 显示名称
+	DisplayName string `json:"displayName"`
+
+	// Job 任务注册名称
+	Job string `json:"job"`
+
+	// Data 任务结构体的 JSON
+	Data json.RawMessage `json:"data"`
+
+	// Attempts 已尝试次数，由驱动在取出任务时递增
+	Attempts int `json:"attempts"`
+
+	// MaxTries 最大尝试次数，0 表示使用 Worker 的默认值
+	MaxTries int `json:"maxTries,omitempty"`
+
+	// Backoff 重试等待时间
+	Backoff []time.Duration `json:"backoff,omitempty"`
+
+	// Timeout 执行超时时间
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// PushedAt 投递时间
+	PushedAt time.Time `json:"pushedAt"`
+
+	// Context 投递时的 appcontext 数据，处理任务时还原到任务的上下文
+	Context json.RawMessage `json:"context,omitempty"`
+
+	// Chained 任务链中剩余任务的载荷，当前任务成功后依次投递
+	Chained []json.RawMessage `json:"chained,omitempty"`
+
+	// ChainConnection 任务链使用的连接，为空时使用当前连接
+	ChainConnection string `json:"chainConnection,omitempty"`
+
+	// ChainQueue 任务链使用的队列，为空时使用连接的默认队列
+	ChainQueue string `json:"chainQueue,omitempty"`
+
+	// ChainCatchCallbacks 任务链中任一任务失败时调用的回调
+	ChainCatchCallbacks []Callback `json:"chainCatchCallbacks,omitempty"`
+}
+
+// Callback 序列化的回调对象，如 ChainCatcher
+type Callback struct {
+	// Name 注册名称
+	Name string `json:"name"`
+
+	// Data 回调结构体的 JSON
+	Data json.RawMessage `json:"data"`
+}
+
+// Registry 任务注册表，负责任务和回调与载荷之间的转换
+//
+// 使用示例：
+//
+//	registry := queue.NewRegistry()
+//	registry.Register(&SendWelcomeEmail{})
+//
+//	payload, err := registry.Payload(&SendWelcomeEmail{UserID: 1})
+//	job, err := registry.Resolve(payload)
+type Registry struct {
+	mu   sync.RWMutex
+	jobs map[string]reflect.Type
+}
+
+// NewRegistry 创建任务注册表
+func NewRegistry() *Registry {
+	return &Registry{jobs: make(map[string]reflect.Type)}
+}
+
+// DefaultRegistry 默认任务注册表
+var DefaultRegistry = NewRegistry()
+
+// Register 在默认注册表中注册任务类型
+func Register(jobs ...Job) {
+	DefaultRegistry.Register(jobs...)
+}
+
+// RegisterCatcher 在默认注册表中注册任务链失败回调类型
+func RegisterCatcher(catchers ...ChainCatcher) {
+	DefaultRegistry.RegisterCatcher(catchers...)
+}
+
+// Register 注册任务类型，传入的值只用于获取类型
+func (r *Registry) Register(jobs ...Job) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range jobs {
+		r.jobs[JobName(job)] = reflect.TypeOf(job)
+	}
+}
+
+// RegisterCatcher 注册任务链失败回调类型
+func (r *Registry) RegisterCatcher(catchers ...ChainCatcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, catcher := range catchers {
+		r.jobs[typeName(catcher)] = reflect.TypeOf(catcher)
+	}
+}
+
+// Has 是否已注册
+func (r *Registry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.jobs[name]
+	return ok
+}
+
+// Payload 创建任务载荷
+func (r *Registry) Payload(job Job) (*Payload, error) {
+	name := JobName(job)
+	if !r.Has(name) {
+		return nil, fmt.Errorf("queue: job %s is not registered", name)
+	}
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("queue: encode job %s: %w", name, err)
+	}
+	return &Payload{
+		UUID:        NewUUID(),
+		DisplayName: name,
+		Job:         name,
+		Data:        data,
+		MaxTries:    job.Tries(),
+		Backoff:     job.Backoff(),
+		Timeout:     job.Timeout(),
+		PushedAt:    time.Now(),
+	}, nil
+}
+
+// Resolve 根据载荷还原任务
+func (r *Registry) Resolve(payload *Payload) (Job, error) {
+	instance, err := r.instance(payload.Job, payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	job, ok := instance.(Job)
+	if !ok {
+		return nil, fmt.Errorf("queue: %s is not a job", payload.Job)
+	}
+	return job, nil
+}
+
+// Callback 序列化回调对象
+func (r *Registry) Callback(callback interface{}) (Callback, error) {
+	name := typeName(callback)
+	if !r.Has(name) {
+		return Callback{}, fmt.Errorf("queue: callback %s is not registered", name)
+	}
+	data, err := json.Marshal(callback)
+	if err != nil {
+		return Callback{}, fmt.Errorf("queue: encode callback %s: %w", name, err)
+	}
+	return Callback{Name: name, Data: data}, nil
+}
+
+// ResolveCatchers 还原载荷中的任务链失败回调
+func (r *Registry) ResolveCatchers(payload *Payload) ([]ChainCatcher, error) {
+	catchers := make([]ChainCatcher, 0, len(payload.ChainCatchCallbacks))
+	for _, callback := range payload.ChainCatchCallbacks {
+		instance, err := r.instance(callback.Name, callback.Data)
+		if err != nil {
+			return nil, err
+		}
+		catcher, ok := instance.(ChainCatcher)
+		if !ok {
+			return nil, fmt.Errorf("queue: %s is not a chain catcher", callback.Name)
+		}
+		catchers = append(catchers, catcher)
+	}
+	return catchers, nil
+}
+
+// instance 按注册名称创建实例并解码数据
+func (r *Registry) instance(name string, data json.RawMessage) (interface{}, error) {
+	r.mu.RLock()
+	typ, ok := r.jobs[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("queue: %s is not registered", name)
+	}
+
+	ptr := typ.Kind() == reflect.Ptr
+	if ptr {
+		typ = typ.Elem()
+	}
+	value := reflect.New(typ)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, value.Interface()); err != nil {
+			return nil, fmt.Errorf("queue: decode %s: %w", name, err)
+		}
+	}
+	if !ptr {
+		value = value.Elem()
+	}
+	return value.Interface(), nil
+}
+
+// JobName 获取任务名称
+func JobName(job Job) string {
+	return typeName(job)
+}
+
+// typeName 注册名称，实现 NamedJob 时使用自定义名称，否则为 "包路径.类型名"
+func typeName(v interface{}) string {
+	if named, ok := v.(NamedJob); ok {
+		return named.JobName()
+	}
+	typ := reflect.TypeOf(v)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.PkgPath() + "." + typ.Name()
+}
+
+// Encode 编码载荷
+func (p *Payload) Encode() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// DecodePayload 解码载荷
+func DecodePayload(body []byte) (*Payload, error) {
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("queue: decode payload: %w", err)
+	}
+	return &payload, nil
+}
+
+// NewUUID 生成随机的 UUID v4
+func NewUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
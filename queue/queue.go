@@ -0,0 +1,55 @@
+// Package queue 提供 Laravel 风格的队列系统协议定义
+//
+// 本包定义队列任务、队列连接、队列管理器和 Worker 的接口，
+// 设计参考 Laravel 的 Illuminate\Queue。驱动和 Worker 的参考实现位于子包 driver，
+// Horizon 风格的指标收集和 JSON 仪表盘位于子包 metrics。
+//
+// 主要特性：
+// - Job 任务契约（Handle、Failed、Tries、Backoff、Timeout）
+// - 任务链和失败回调
+// - 任务中间件和唯一任务
+// - 延迟投递、多队列优先级和保留超时（retry_after）
+// - Queue 队列连接（Push、Later、Bulk、Pop）
+// - Worker 可配置并发的任务处理器，支持优雅退出、内存上限和 queue:restart
+// - 失败任务记录
+// - 吞吐量、耗时分位数和积压指标
+// - FakeQueue 测试替身，记录投递的任务并提供断言
+// - sync、memory、database、redis、sqs 驱动
+//
+// 包结构：
+// - job.go - Job 任务接口、ShouldQueue 排队标记和 Queueable、Queued 默认实现
+// - payload.go - Payload 任务载荷和 Registry 任务注册表
+// - chain.go - PendingChain 任务链和 ChainCatcher 失败回调
+// - middleware.go - Middleware 任务中间件和 WithoutOverlapping、RateLimited、ThrottlesExceptions
+// - unique.go - ShouldBeUnique 唯一任务
+// - queue_interface.go - Queue 队列连接接口和 QueuedJob 已入队任务接口
+// - manager.go - Manager 队列管理器接口和驱动名称
+// - worker.go - Worker 接口、WorkerOptions 选项和 Worker 事件
+// - failed.go - FailedJobProvider 失败任务记录接口
+// - metrics.go - QueueMetrics 指标收集器接口和快照结构体
+// - fake.go - FakeQueue 假队列和测试断言
+//
+// 使用示例：
+//
+//	type SendWelcomeEmail struct {
+//		queue.Queueable
+//		UserID uint `json:"user_id"`
+//	}
+//
+//	func (j *SendWelcomeEmail) Handle(ctx context.Context) error {
+//		return mailer.SendWelcome(ctx, j.UserID)
+//	}
+//
+//	func (j *SendWelcomeEmail) Tries() int { return 3 }
+//
+//	// 注册任务类型，Worker 据此反序列化任务
+//	queue.Register(&SendWelcomeEmail{})
+//
+//	// 投递任务
+//	q, _ := manager.Connection("redis")
+//	q.Push(ctx, &SendWelcomeEmail{UserID: 1}, "emails")
+//	q.Later(ctx, 10*time.Minute, &SendWelcomeEmail{UserID: 2}, "emails")
+//
+//	// 启动 Worker
+//	worker.Daemon(ctx, "redis", "emails,default", queue.WorkerOptions{Concurrency: 4})
+package queue
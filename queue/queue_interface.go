@@ -0,0 +1,117 @@
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+// Queue 队列连接接口
+//
+// queue 参数为空字符串时使用连接配置中的默认队列。
+type Queue interface {
+	// Size 队列中的任务数量，包括延迟和已保留的任务
+	Size(ctx context.Context, queue string) (int64, error)
+
+	// Push 投递任务，返回任务 ID
+	//
+	// 实现了 DelayedJob 的任务按其延迟投递。
+	// 唯一任务（ShouldBeUnique）的锁被占用时不入队，返回空 ID 和 nil 错误。
+	Push(ctx context.Context, job Job, queue string) (string, error)
+
+	// PushRaw 投递已编码的载荷，返回任务 ID
+	PushRaw(ctx context.Context, payload []byte, queue string) (string, error)
+
+	// Later 延迟 delay 后投递任务
+	Later(ctx context.Context, delay time.Duration, job Job, queue string) (string, error)
+
+	// LaterRaw 延迟 delay 后投递已编码的载荷
+	LaterRaw(ctx context.Context, delay time.Duration, payload []byte, queue string) (string, error)
+
+	// Bulk 批量投递任务
+	Bulk(ctx context.Context, jobs []Job, queue string) error
+
+	// Pop 取出下一个任务，队列为空时返回 nil, nil
+	//
+	// 取出的任务处于保留状态，超过连接的 retry_after 时间仍未删除或释放的任务会重新可见。
+	Pop(ctx context.Context, queue string) (QueuedJob, error)
+
+	// ConnectionName 连接名称
+	ConnectionName() string
+
+	// SetConnectionName 设置连接名称，由 Manager 在创建连接时调用
+	SetConnectionName(name string)
+}
+
+// VisibilityTimeout 有保留超时的队列连接
+//
+// 取出的任务在 RetryAfter 时间内未被删除或释放时会重新可见并被再次取出，
+// 因此任务的执行超时必须小于 RetryAfter，否则同一任务可能被并发执行。
+// memory、database 和 redis 驱动实现此接口，对应连接配置中的 retry_after。
+type VisibilityTimeout interface {
+	RetryAfter() time.Duration
+}
+
+// QueuedJob 从队列中取出的任务
+//
+// 对应 Laravel 的 Illuminate\Contracts\Queue\Job，由驱动实现。
+type QueuedJob interface {
+	// ID 任务 ID
+	ID() string
+
+	// UUID 载荷中的 UUID
+	UUID() string
+
+	// Payload 解码后的载荷
+	Payload() *Payload
+
+	// RawBody 原始载荷
+	RawBody() []byte
+
+	// Name 任务名称
+	Name() string
+
+	// Queue 所在队列
+	Queue() string
+
+	// ConnectionName 所在连接
+	ConnectionName() string
+
+	// Attempts 已尝试次数（包含本次）
+	Attempts() int
+
+	// MaxTries 最大尝试次数，0 表示未指定
+	MaxTries() int
+
+	// Backoff 重试等待时间
+	Backoff() []time.Duration
+
+	// Timeout 执行超时时间，0 表示未指定
+	Timeout() time.Duration
+
+	// Resolve 还原任务
+	Resolve() (Job, error)
+
+	// Release 释放任务回队列，delay 后重新可见
+	Release(ctx context.Context, delay time.Duration) error
+
+	// IsReleased 是否已释放
+	IsReleased() bool
+
+	// Delete 从队列中删除任务
+	Delete(ctx context.Context) error
+
+	// IsDeleted 是否已删除
+	IsDeleted() bool
+
+	// IsDeletedOrReleased 是否已删除或释放
+	IsDeletedOrReleased() bool
+
+	// MarkAsFailed 标记为失败
+	MarkAsFailed()
+
+	// HasFailed 是否已失败
+	HasFailed() bool
+
+	// Fail 标记为失败、删除任务并调用任务的 Failed 方法
+	Fail(ctx context.Context, err error) error
+}
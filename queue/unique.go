@@ -0,0 +1,78 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"github.com/cnote0/laraveldoc/cache"
+)
+
+// ShouldBeUnique 唯一任务
+//
+// 投递时获取以任务类型和 UniqueID 为键的锁，锁被占用时任务不会入队。
+// 锁在任务成功或最终失败后释放，重试期间保持。
+//
+// 使用示例：
+//
+//	type UpdateSearchIndex struct {
+//		queue.Queueable
+//		ProductID uint `json:"product_id"`
+//	}
+//
+//	func (j *UpdateSearchIndex) UniqueID() string        { return strconv.Itoa(int(j.ProductID)) }
+//	func (j *UpdateSearchIndex) UniqueFor() time.Duration { return time.Hour }
+type ShouldBeUnique interface {
+	// UniqueID 唯一标识，与任务类型组合成锁的键
+	UniqueID() string
+
+	// UniqueFor 锁的最长持有时间，0 表示不过期
+	UniqueFor() time.Duration
+}
+
+// ShouldBeUniqueUntilProcessing 开始处理前即释放锁的唯一任务
+//
+// 适合只需要避免重复排队、允许处理期间再次投递的任务。
+type ShouldBeUniqueUntilProcessing interface {
+	ShouldBeUnique
+
+	// UniqueUntilProcessing 标记方法
+	UniqueUntilProcessing()
+}
+
+// UniqueVia 为唯一任务指定锁提供者，未实现时使用 SetLockProvider 设置的默认值
+type UniqueVia interface {
+	UniqueVia() cache.LockProvider
+}
+
+// UniqueLockKey 唯一任务锁的键
+func UniqueLockKey(job ShouldBeUnique) string {
+	return "laravel_unique_job:" + typeName(job) + ":" + job.UniqueID()
+}
+
+// AcquireUniqueLock 为唯一任务获取锁，非唯一任务直接返回 true
+//
+// 由驱动在投递任务前调用，返回 false 时任务不应入队。
+func AcquireUniqueLock(ctx context.Context, job Job) (bool, error) {
+	unique, ok := job.(ShouldBeUnique)
+	if !ok {
+		return true, nil
+	}
+	return uniqueLocks(job).Lock(UniqueLockKey(unique), unique.UniqueFor()).Get(ctx)
+}
+
+// ReleaseUniqueLock 释放唯一任务的锁，非唯一任务不做处理
+func ReleaseUniqueLock(ctx context.Context, job Job) error {
+	unique, ok := job.(ShouldBeUnique)
+	if !ok {
+		return nil
+	}
+	return uniqueLocks(job).Lock(UniqueLockKey(unique), 0).ForceRelease(ctx)
+}
+
+// uniqueLocks 唯一任务使用的锁提供者
+func uniqueLocks(job Job) cache.LockProvider {
+	if via, ok := job.(UniqueVia); ok {
+		return via.UniqueVia()
+	}
+	return lockProvider()
+}
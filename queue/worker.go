@@ -0,0 +1,227 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/cache"
+)
+
+var (
+	// ErrMaxAttemptsExceeded 超过最大尝试次数
+	ErrMaxAttemptsExceeded = errors.New("queue: job has been attempted too many times")
+
+	// ErrTimeout 任务执行超时
+	ErrTimeout = errors.New("queue: job has timed out")
+
+	// ErrMemoryLimitExceeded Worker 内存超过 WorkerOptions.Memory，应由进程管理器重启
+	ErrMemoryLimitExceeded = errors.New("queue: worker memory limit exceeded")
+
+	// ErrTimeoutExceedsRetryAfter Worker 超时时间不小于连接的 retry_after
+	ErrTimeoutExceedsRetryAfter = errors.New("queue: worker timeout must be shorter than the connection retry_after")
+
+	// ErrConnectionLost 队列后端的连接已断开，Worker 以此错误停止，应由进程管理器重启
+	ErrConnectionLost = errors.New("queue: lost connection to the queue backend")
+)
+
+// WorkerOptions Worker 选项，对应 queue:work 命令的参数
+type WorkerOptions struct {
+	// Name Worker 名称
+	Name string
+
+	// Concurrency 并发处理的任务数量，默认 1
+	Concurrency int
+
+	// Sleep 队列为空时的休眠时间，默认 3 秒
+	Sleep time.Duration
+
+	// Backoff 任务未指定时使用的重试等待时间
+	Backoff []time.Duration
+
+	// MaxTries 任务未指定时使用的最大尝试次数，0 表示不限制
+	MaxTries int
+
+	// Timeout 任务未指定时使用的执行超时时间，默认 60 秒
+	Timeout time.Duration
+
+	// MaxJobs 处理指定数量的任务后停止，0 表示不限制
+	MaxJobs int
+
+	// MaxTime 运行指定时长后停止，0 表示不限制
+	MaxTime time.Duration
+
+	// Rest 每个任务之间的休息时间
+	Rest time.Duration
+
+	// StopWhenEmpty 队列为空时停止
+	StopWhenEmpty bool
+
+	// Memory 内存上限（MB），超过后处理完当前任务即退出，默认 128
+	Memory int
+
+	// IgnoreSignals 不处理进程信号
+	//
+	// 默认情况下 Daemon 收到 SIGTERM、SIGINT、SIGQUIT 时处理完当前任务后退出，
+	// 收到 SIGUSR2 时暂停、SIGCONT 时恢复（仅 Unix）。
+	IgnoreSignals bool
+}
+
+// Worker 退出状态，对应 queue:work 命令的进程退出码
+const (
+	// ExitSuccess 正常退出
+	ExitSuccess = 0
+
+	// ExitError 出错退出
+	ExitError = 1
+
+	// ExitMemoryLimit 超过内存上限退出
+	ExitMemoryLimit = 12
+)
+
+// RestartKey queue:restart 在缓存中写入重启时间的键
+const RestartKey = "illuminate:queue:restart"
+
+// Restart 通知所有 Worker 在处理完当前任务后退出，对应 queue:restart 命令
+//
+// Worker 启动时记录缓存中的重启时间，每轮取任务前比较，时间变化即退出，
+// 由 Supervisor 等进程管理器重新拉起以加载新代码。
+func Restart(ctx context.Context, store cache.Store) error {
+	return store.Forever(ctx, RestartKey, time.Now().UnixNano())
+}
+
+// DefaultWorkerOptions 默认 Worker 选项
+func DefaultWorkerOptions() WorkerOptions {
+	return WorkerOptions{
+		Name:        "default",
+		Concurrency: 1,
+		Sleep:       3 * time.Second,
+		Timeout:     60 * time.Second,
+		Memory:      128,
+	}
+}
+
+// Worker 队列任务处理器
+//
+// queue 参数支持逗号分隔的多个队列，按先后顺序决定优先级，如 "high,default,low"：
+// 每次取任务都从第一个队列开始，只有前面的队列为空时才处理后面的队列。
+//
+// 连接实现 VisibilityTimeout 时，Daemon 要求 WorkerOptions.Timeout 小于 RetryAfter，
+// 否则返回 ErrTimeoutExceedsRetryAfter；任务自身的 Timeout 超过 RetryAfter 时会被截断。
+type Worker interface {
+	// Daemon 持续处理任务，直到 ctx 取消、调用 Stop 或满足 MaxJobs/MaxTime/StopWhenEmpty 条件
+	Daemon(ctx context.Context, connection, queue string, options WorkerOptions) error
+
+	// RunNextJob 处理下一个任务，对应 queue:work --once
+	RunNextJob(ctx context.Context, connection, queue string, options WorkerOptions) error
+
+	// Process 处理指定任务
+	Process(ctx context.Context, connection string, job QueuedJob, options WorkerOptions) error
+
+	// Listen 注册 Worker 事件监听器
+	//
+	// event 为 JobProcessing、JobProcessed、JobExceptionOccurred、JobReleasedAfterException、
+	// JobFailed、Looping、WorkerStopping 之一，均实现 Event 接口。
+	Listen(listener func(ctx context.Context, event interface{}))
+
+	// Stop 通知 Worker 处理完当前任务后停止
+	Stop()
+
+	// Pause 暂停取新任务，正在处理的任务不受影响
+	Pause()
+
+	// Resume 恢复取任务
+	Resume()
+}
+
+// ParseQueues 解析逗号分隔的队列列表，去除空白和重复项，保持优先级顺序
+//
+// 空字符串表示连接的默认队列。
+func ParseQueues(queues string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(queues, ",") {
+		name = strings.TrimSpace(name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// Event Worker 事件
+//
+// 所有事件都实现此接口，EventName 返回 Laravel 风格的事件名称，
+// 便于转发到事件系统或监控。
+type Event interface {
+	EventName() string
+}
+
+// JobProcessing 任务开始处理事件
+type JobProcessing struct {
+	ConnectionName string
+	Job            QueuedJob
+}
+
+// JobProcessed 任务处理完成事件
+type JobProcessed struct {
+	ConnectionName string
+	Job            QueuedJob
+}
+
+// JobExceptionOccurred 任务处理出错事件
+type JobExceptionOccurred struct {
+	ConnectionName string
+	Job            QueuedJob
+	Err            error
+}
+
+// JobReleasedAfterException 任务出错后被释放重试事件
+type JobReleasedAfterException struct {
+	ConnectionName string
+	Job            QueuedJob
+	Delay          time.Duration
+}
+
+// JobFailed 任务最终失败事件
+type JobFailed struct {
+	ConnectionName string
+	Job            QueuedJob
+	Err            error
+}
+
+// Looping Worker 每轮取任务前的事件
+type Looping struct {
+	ConnectionName string
+	Queue          string
+}
+
+// WorkerStopping Worker 停止事件
+type WorkerStopping struct {
+	// Status 退出状态，见 ExitSuccess、ExitError、ExitMemoryLimit
+	Status int
+}
+
+// EventName 事件名称
+func (JobProcessing) EventName() string { return "job.processing" }
+
+// EventName 事件名称
+func (JobProcessed) EventName() string { return "job.processed" }
+
+// EventName 事件名称
+func (JobExceptionOccurred) EventName() string { return "job.exceptionOccurred" }
+
+// EventName 事件名称
+func (JobReleasedAfterException) EventName() string { return "job.releasedAfterException" }
+
+// EventName 事件名称
+func (JobFailed) EventName() string { return "job.failed" }
+
+// EventName 事件名称
+func (Looping) EventName() string { return "worker.looping" }
+
+// EventName 事件名称
+func (WorkerStopping) EventName() string { return "worker.stopping" }
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// cachedRepository 缓存查询结果的仓储装饰器
+type cachedRepository[T any] struct {
+	inner    Repository[T]
+	store    cache.Store
+	ttl      time.Duration
+	prefix   string
+	criteria []Criteria
+}
+
+// Cached 用缓存装饰仓储，Find、FindBy、All 和 Paginate 的结果按 ttl 缓存，ttl 为 0 表示永不过期
+//
+// 缓存值编码为 JSON，键以 "repository:<表名>:<版本号>:" 开头。
+// Create、Update 和 Delete 成功后递增表的版本号，此前缓存的查询不再命中，随 ttl 过期。
+// 带有未实现 CacheKeyer 的查询条件（例如 CriteriaFunc）时直接查询 inner。
+// 记录不存在的结果不缓存。
+func Cached[T any](inner Repository[T], store cache.Store, ttl time.Duration) Repository[T] {
+	return &cachedRepository[T]{
+		inner:  inner,
+		store:  store,
+		ttl:    ttl,
+		prefix: "repository:" + database.TableName(new(T)),
+	}
+}
+
+// remember 读取缓存，未命中时调用 load 并写入缓存
+func remember[T, V any](ctx context.Context, r *cachedRepository[T], operation string, load func() (V, error)) (V, error) {
+	var value V
+	key, cacheable, err := r.key(ctx, operation)
+	if err != nil {
+		return value, err
+	}
+	if !cacheable {
+		return load()
+	}
+	raw, ok, err := r.get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+	if ok && json.Unmarshal([]byte(raw), &value) == nil {
+		return value, nil
+	}
+	if value, err = load(); err != nil {
+		return value, err
+	}
+	return value, r.put(ctx, key, value)
+}
+
+// key 当前版本下操作的缓存键，查询条件不能缓存时返回 false
+func (r *cachedRepository[T]) key(ctx context.Context, operation string) (string, bool, error) {
+	criteria, ok := cacheKey(r.criteria)
+	if !ok {
+		return "", false, nil
+	}
+	version, _, err := r.store.Get(ctx, r.prefix+":version")
+	if err != nil {
+		return "", false, fmt.Errorf("repository: cache version: %w", err)
+	}
+	if version == nil {
+		version = 0
+	}
+	return fmt.Sprintf("%s:%v:%s:%s", r.prefix, version, operation, criteria), true, nil
+}
+
+// get 读取缓存的 JSON
+func (r *cachedRepository[T]) get(ctx context.Context, key string) (string, bool, error) {
+	value, ok, err := r.store.Get(ctx, key)
+	if err != nil {
+		return "", false, fmt.Errorf("repository: cache get %s: %w", key, err)
+	}
+	if !ok {
+		return "", false, nil
+	}
+	switch v := value.(type) {
+	case string:
+		return v, true, nil
+	case []byte:
+		return string(v), true, nil
+	}
+	return "", false, nil
+}
+
+// put 把值编码为 JSON 写入缓存
+func (r *cachedRepository[T]) put(ctx context.Context, key string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("repository: cache encode %s: %w", key, err)
+	}
+	if err := r.store.Put(ctx, key, string(raw), r.ttl); err != nil {
+		return fmt.Errorf("repository: cache put %s: %w", key, err)
+	}
+	return nil
+}
+
+// flush 递增版本号
+func (r *cachedRepository[T]) flush(ctx context.Context) error {
+	if _, err := r.store.Increment(ctx, r.prefix+":version", 1); err != nil {
+		return fmt.Errorf("repository: cache flush: %w", err)
+	}
+	return nil
+}
+
+// Find 按主键查找
+func (r *cachedRepository[T]) Find(ctx context.Context, id interface{}) (*T, error) {
+	return remember(ctx, r, fmt.Sprintf("find:%v", id), func() (*T, error) {
+		return r.inner.Find(ctx, id)
+	})
+}
+
+// FindBy 查找列等于 value 的全部记录
+func (r *cachedRepository[T]) FindBy(ctx context.Context, column string, value interface{}) ([]T, error) {
+	return remember(ctx, r, fmt.Sprintf("find_by:%s:%v", column, value), func() ([]T, error) {
+		return r.inner.FindBy(ctx, column, value)
+	})
+}
+
+// All 查找全部记录
+func (r *cachedRepository[T]) All(ctx context.Context) ([]T, error) {
+	return remember(ctx, r, "all", func() ([]T, error) {
+		return r.inner.All(ctx)
+	})
+}
+
+// Paginate 分页查找
+func (r *cachedRepository[T]) Paginate(ctx context.Context, page, perPage int) (*Page[T], error) {
+	return remember(ctx, r, fmt.Sprintf("paginate:%d:%d", page, perPage), func() (*Page[T], error) {
+		return r.inner.Paginate(ctx, page, perPage)
+	})
+}
+
+// Create 插入模型并使缓存失效
+func (r *cachedRepository[T]) Create(ctx context.Context, model *T) error {
+	if err := r.inner.Create(ctx, model); err != nil {
+		return err
+	}
+	return r.flush(ctx)
+}
+
+// Update 更新模型并使缓存失效
+func (r *cachedRepository[T]) Update(ctx context.Context, model *T, attributes map[string]interface{}) error {
+	if err := r.inner.Update(ctx, model, attributes); err != nil {
+		return err
+	}
+	return r.flush(ctx)
+}
+
+// Delete 删除模型并使缓存失效
+func (r *cachedRepository[T]) Delete(ctx context.Context, model *T) error {
+	if err := r.inner.Delete(ctx, model); err != nil {
+		return err
+	}
+	return r.flush(ctx)
+}
+
+// WithCriteria 返回追加了查询条件的缓存仓储，与原仓储共享版本号
+func (r *cachedRepository[T]) WithCriteria(criteria ...Criteria) Repository[T] {
+	combined := make([]Criteria, 0, len(r.criteria)+len(criteria))
+	combined = append(append(combined, r.criteria...), criteria...)
+	return &cachedRepository[T]{
+		inner:    r.inner.WithCriteria(criteria...),
+		store:    r.store,
+		ttl:      r.ttl,
+		prefix:   r.prefix,
+		criteria: combined,
+	}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// Criteria 查询条件对象，对应 l5-repository 的 CriteriaInterface
+//
+// Apply 在查询上追加条件并返回新的查询。需要被 Cached 缓存的条件还要实现 CacheKeyer，
+// 本包提供的条件都实现了 CacheKeyer。
+type Criteria interface {
+	Apply(db database.DB) database.DB
+}
+
+// CacheKeyer 可以缓存的查询条件，相同的键表示相同的条件
+type CacheKeyer interface {
+	CacheKey() string
+}
+
+// CriteriaFunc 函数形式的查询条件，没有缓存键，带有 CriteriaFunc 的查询不经过缓存
+type CriteriaFunc func(db database.DB) database.DB
+
+// Apply 调用函数
+func (f CriteriaFunc) Apply(db database.DB) database.DB {
+	return f(db)
+}
+
+// Named 带有缓存键的函数形式查询条件，key 在所有条件中唯一，例如 "published" 或 "author:42"
+func Named(key string, apply func(db database.DB) database.DB) Criteria {
+	return &criteria{key: key, apply: apply}
+}
+
+// criteria 带有缓存键的查询条件
+type criteria struct {
+	key   string
+	apply func(db database.DB) database.DB
+}
+
+// Apply 调用函数
+func (c *criteria) Apply(db database.DB) database.DB {
+	return c.apply(db)
+}
+
+// CacheKey 实现 CacheKeyer
+func (c *criteria) CacheKey() string {
+	return c.key
+}
+
+// Where 列条件，Where("status", "published") 为等于，Where("votes", ">", 100) 指定运算符
+func Where(column string, args ...interface{}) Criteria {
+	operator, value := "=", interface{}(nil)
+	switch len(args) {
+	case 1:
+		value = args[0]
+	case 2:
+		operator, _ = args[0].(string)
+		value = args[1]
+	}
+	operator = strings.ToUpper(strings.TrimSpace(operator))
+	key := fmt.Sprintf("where:%s:%s:%v", column, operator, value)
+	if value == nil {
+		return Named(key, func(db database.DB) database.DB {
+			return db.Where(column + " IS NULL")
+		})
+	}
+	return Named(key, func(db database.DB) database.DB {
+		return db.Where(column+" "+operator+" ?", value)
+	})
+}
+
+// WhereIn 列的值在 values 中
+func WhereIn(column string, values ...interface{}) Criteria {
+	return Named(fmt.Sprintf("where_in:%s:%v", column, values), func(db database.DB) database.DB {
+		return db.Where(column+" IN ?", values)
+	})
+}
+
+// OrderBy 按列排序，direction 为 "asc" 或 "desc"，默认 "asc"
+func OrderBy(column string, direction ...string) Criteria {
+	dir := "asc"
+	if len(direction) > 0 && strings.EqualFold(direction[0], "desc") {
+		dir = "desc"
+	}
+	return Named("order:"+column+":"+dir, func(db database.DB) database.DB {
+		return db.Order(column + " " + dir)
+	})
+}
+
+// Latest 按列倒序，默认 created_at
+func Latest(column ...string) Criteria {
+	if len(column) == 0 {
+		return OrderBy("created_at", "desc")
+	}
+	return OrderBy(column[0], "desc")
+}
+
+// Oldest 按列正序，默认 created_at
+func Oldest(column ...string) Criteria {
+	if len(column) == 0 {
+		return OrderBy("created_at")
+	}
+	return OrderBy(column[0])
+}
+
+// With 预加载关联，对应 Eloquent 的 with
+func With(relations ...string) Criteria {
+	return Named("with:"+strings.Join(relations, ","), func(db database.DB) database.DB {
+		for _, relation := range relations {
+			db = db.Preload(relation)
+		}
+		return db
+	})
+}
+
+// Limit 限制返回的记录数
+func Limit(n int) Criteria {
+	return Named(fmt.Sprintf("limit:%d", n), func(db database.DB) database.DB {
+		return db.Limit(n)
+	})
+}
+
+// cacheKey 查询条件的缓存键，有条件没有实现 CacheKeyer 时返回 false
+func cacheKey(criteria []Criteria) (string, bool) {
+	keys := make([]string, len(criteria))
+	for i, c := range criteria {
+		keyer, ok := c.(CacheKeyer)
+		if !ok {
+			return "", false
+		}
+		keys[i] = keyer.CacheKey()
+	}
+	return strings.Join(keys, "|"), true
+}
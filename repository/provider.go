@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// Abstract 模型 T 的仓储在容器中的服务标识符，为 "repository." 加表名，例如 "repository.posts"
+func Abstract[T any]() string {
+	return "repository." + database.TableName(new(T))
+}
+
+// Bind 以 Abstract[T]() 把模型 T 的仓储绑定为单例，仓储使用容器中的 "database"
+func Bind[T any](c container.Container) error {
+	return bind[T](c, nil, 0)
+}
+
+// BindCached 与 Bind 相同，仓储用 Cached 装饰
+func BindCached[T any](c container.Container, store cache.Store, ttl time.Duration) error {
+	return bind[T](c, store, ttl)
+}
+
+// bind 绑定仓储，store 为 nil 时不缓存
+func bind[T any](c container.Container, store cache.Store, ttl time.Duration) error {
+	return c.Singleton(Abstract[T](), func(c container.Container) interface{} {
+		db := c.MustMake("database").(database.DB)
+		repository := New[T](db)
+		if store != nil {
+			repository = Cached(repository, store, ttl)
+		}
+		return repository
+	})
+}
+
+// Make 从容器解析模型 T 的仓储
+func Make[T any](c container.Container) (Repository[T], error) {
+	abstract := Abstract[T]()
+	resolved, err := c.Make(abstract)
+	if err != nil {
+		return nil, fmt.Errorf("repository: resolve %s: %w", abstract, err)
+	}
+	repository, ok := resolved.(Repository[T])
+	if !ok {
+		return nil, fmt.Errorf("repository: %s is %T, not a repository of %T", abstract, resolved, *new(T))
+	}
+	return repository, nil
+}
+
+// Binder 一个模型的仓储绑定，由 For 创建
+type Binder interface {
+	bind(c container.Container, store cache.Store, ttl time.Duration) error
+}
+
+// binder 模型 T 的仓储绑定
+type binder[T any] struct{}
+
+// bind 绑定模型 T 的仓储
+func (binder[T]) bind(c container.Container, store cache.Store, ttl time.Duration) error {
+	return bind[T](c, store, ttl)
+}
+
+// For 模型 T 的仓储绑定，交给 ServiceProvider.Repositories
+func For[T any]() Binder {
+	return binder[T]{}
+}
+
+// ServiceProvider 为每个模型绑定仓储，Cache 不为空时用 Cached 装饰
+type ServiceProvider struct {
+	// Repositories 需要绑定仓储的模型
+	Repositories []Binder
+
+	// Cache 缓存查询结果的存储，为空时不缓存
+	Cache cache.Store
+
+	// TTL 缓存时间，0 表示永不过期
+	TTL time.Duration
+}
+
+// Register 绑定仓储
+func (p *ServiceProvider) Register(c container.Container) error {
+	for _, repository := range p.Repositories {
+		if err := repository.bind(c, p.Cache, p.TTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Boot 无需启动逻辑
+func (p *ServiceProvider) Boot(c container.Container) error {
+	return nil
+}
+
+// Provides 不提供延迟加载的服务
+func (p *ServiceProvider) Provides() []string {
+	return nil
+}
+
+// IsDeferred 不延迟加载
+func (p *ServiceProvider) IsDeferred() bool {
+	return false
+}
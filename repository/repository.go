@@ -0,0 +1,260 @@
+// Package repository 提供仓储模式的协议定义和基于 database.DB 的泛型实现
+//
+// Repository[T] 把模型 T 的查询和持久化封装在统一的接口后面，业务代码依赖接口而不是 DB，
+// 测试时可以替换为内存实现。查询条件以 Criteria 对象表示，可以组合并复用，
+// 例如 "已发布"、"按作者过滤"、"最新优先"。本仓库没有 EloquentBuilder 的实现，
+// Criteria 作用在 database.DB 查询上（通过 DB.Scopes），在 gormbridge 和 memdb 上都可以使用。
+//
+// 主要特性：
+// - Find、FindBy、All、Paginate、Create、Update、Delete 泛型仓储接口
+// - Criteria 查询条件对象，WithCriteria 返回带条件的新仓储，原仓储不受影响
+// - Cached 缓存装饰器，写操作递增版本号使已缓存的查询失效
+// - Bind 和 ServiceProvider 按模型表名把仓储绑定到容器（"repository.posts"）
+//
+// 包结构：
+// - repository.go - Repository 接口、Page 分页结果、ModelNotFoundError 和 DB 实现
+// - criteria.go - Criteria 接口和常用条件（Where、WhereIn、OrderBy、Latest、With、Limit）
+// - cache.go - Cached 缓存装饰器
+// - provider.go - Bind、Make 容器绑定和 ServiceProvider
+//
+// 使用示例：
+//
+//	posts := repository.New[Post](db)
+//
+//	published := posts.WithCriteria(
+//		repository.Where("published", true),
+//		repository.Latest("published_at"),
+//	)
+//	page, err := published.Paginate(ctx, 1, 15)
+//
+//	post, err := posts.Find(ctx, 42)
+//	if errors.Is(err, repository.ErrNotFound) {
+//		return exceptions.NotFound()
+//	}
+//
+//	// 容器绑定，查询结果缓存 10 分钟
+//	app.Register(&repository.ServiceProvider{
+//		Repositories: []repository.Binder{repository.For[Post](), repository.For[Comment]()},
+//		Cache:        cache.NewMemoryStore(),
+//		TTL:          10 * time.Minute,
+//	})
+//	posts, err := repository.Make[Post](app)
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// ErrNotFound 用于 errors.Is 判断记录不存在
+var ErrNotFound = errors.New("repository: record not found")
+
+// ModelNotFoundError 记录不存在，对应 Laravel 的 ModelNotFoundException
+//
+// 实现 exceptions.StatusCoder，异常处理器把它渲染为 404。
+type ModelNotFoundError struct {
+	// Table 模型的表名
+	Table string
+
+	// ID 查找的主键，为 nil 时错误信息中省略
+	ID interface{}
+}
+
+// Error 实现 error 接口
+func (e *ModelNotFoundError) Error() string {
+	if e.ID == nil {
+		return fmt.Sprintf("repository: no query results for %s", e.Table)
+	}
+	return fmt.Sprintf("repository: no query results for %s %v", e.Table, e.ID)
+}
+
+// StatusCode 404
+func (e *ModelNotFoundError) StatusCode() int {
+	return http.StatusNotFound
+}
+
+// Is 与 ErrNotFound 和 database.ErrRecordNotFound 相同
+func (e *ModelNotFoundError) Is(target error) bool {
+	return target == ErrNotFound || target == database.ErrRecordNotFound
+}
+
+// Repository 模型 T 的仓储
+type Repository[T any] interface {
+	// Find 按主键查找，不存在时返回 *ModelNotFoundError，字符串主键按 id 列比较
+	Find(ctx context.Context, id interface{}) (*T, error)
+
+	// FindBy 查找列等于 value 的全部记录
+	FindBy(ctx context.Context, column string, value interface{}) ([]T, error)
+
+	// All 查找全部记录
+	All(ctx context.Context) ([]T, error)
+
+	// Paginate 分页查找，page 从 1 开始
+	Paginate(ctx context.Context, page, perPage int) (*Page[T], error)
+
+	// Create 插入模型，自增主键回填到模型
+	Create(ctx context.Context, model *T) error
+
+	// Update 更新模型，attributes 为空时保存模型的全部字段
+	Update(ctx context.Context, model *T, attributes map[string]interface{}) error
+
+	// Delete 删除模型，模型使用软删除时设置 deleted_at
+	Delete(ctx context.Context, model *T) error
+
+	// WithCriteria 返回追加了查询条件的仓储，条件作用于 Find、FindBy、All 和 Paginate
+	WithCriteria(criteria ...Criteria) Repository[T]
+}
+
+// Page 分页结果，对应 LengthAwarePaginator
+type Page[T any] struct {
+	// Items 当前页的记录
+	Items []T `json:"data"`
+
+	// Total 记录总数
+	Total int64 `json:"total"`
+
+	// PerPage 每页数量
+	PerPage int `json:"per_page"`
+
+	// CurrentPage 当前页码
+	CurrentPage int `json:"current_page"`
+}
+
+// LastPage 最后一页的页码，没有记录时为 1
+func (p *Page[T]) LastPage() int {
+	if p.PerPage <= 0 || p.Total == 0 {
+		return 1
+	}
+	return int((p.Total + int64(p.PerPage) - 1) / int64(p.PerPage))
+}
+
+// HasMorePages 是否还有下一页
+func (p *Page[T]) HasMorePages() bool {
+	return p.CurrentPage < p.LastPage()
+}
+
+// dbRepository 基于 database.DB 的仓储
+type dbRepository[T any] struct {
+	db       database.DB
+	table    string
+	criteria []Criteria
+}
+
+// New 创建基于 database.DB 的模型 T 的仓储
+func New[T any](db database.DB) Repository[T] {
+	return &dbRepository[T]{db: db, table: database.TableName(new(T))}
+}
+
+// query 应用查询条件的查询
+func (r *dbRepository[T]) query(ctx context.Context) database.DB {
+	db := r.db.WithContext(ctx).Model(new(T))
+	if len(r.criteria) == 0 {
+		return db
+	}
+	scopes := make([]func(database.DB) database.DB, len(r.criteria))
+	for i, criteria := range r.criteria {
+		scopes[i] = criteria.Apply
+	}
+	return db.Scopes(scopes...)
+}
+
+// Find 按主键查找
+func (r *dbRepository[T]) Find(ctx context.Context, id interface{}) (*T, error) {
+	var models []T
+	query := r.query(ctx).Limit(1)
+	var result database.DB
+	if _, ok := id.(string); ok {
+		// 字符串主键（UUID、ULID）不能作为内联条件，按 id 列比较
+		result = query.Where("id = ?", id).Find(&models)
+	} else {
+		result = query.Find(&models, id)
+	}
+	if err := result.Error(); err != nil {
+		return nil, fmt.Errorf("repository: find %s %v: %w", r.table, id, err)
+	}
+	if len(models) == 0 {
+		return nil, &ModelNotFoundError{Table: r.table, ID: id}
+	}
+	return &models[0], nil
+}
+
+// FindBy 查找列等于 value 的全部记录
+func (r *dbRepository[T]) FindBy(ctx context.Context, column string, value interface{}) ([]T, error) {
+	var models []T
+	if err := r.query(ctx).Where(column+" = ?", value).Find(&models).Error(); err != nil {
+		return nil, fmt.Errorf("repository: find %s by %s: %w", r.table, column, err)
+	}
+	return models, nil
+}
+
+// All 查找全部记录
+func (r *dbRepository[T]) All(ctx context.Context) ([]T, error) {
+	var models []T
+	if err := r.query(ctx).Find(&models).Error(); err != nil {
+		return nil, fmt.Errorf("repository: all %s: %w", r.table, err)
+	}
+	return models, nil
+}
+
+// Paginate 分页查找，page 小于 1 时为 1，perPage 小于 1 时为 15
+func (r *dbRepository[T]) Paginate(ctx context.Context, page, perPage int) (*Page[T], error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 15
+	}
+	result := &Page[T]{PerPage: perPage, CurrentPage: page}
+	if err := r.query(ctx).Count(&result.Total).Error(); err != nil {
+		return nil, fmt.Errorf("repository: count %s: %w", r.table, err)
+	}
+	items := []T{}
+	if result.Total > int64((page-1)*perPage) {
+		if err := r.query(ctx).Offset((page - 1) * perPage).Limit(perPage).Find(&items).Error(); err != nil {
+			return nil, fmt.Errorf("repository: paginate %s: %w", r.table, err)
+		}
+	}
+	result.Items = items
+	return result, nil
+}
+
+// Create 插入模型
+func (r *dbRepository[T]) Create(ctx context.Context, model *T) error {
+	if err := r.db.WithContext(ctx).Create(model).Error(); err != nil {
+		return fmt.Errorf("repository: create %s: %w", r.table, err)
+	}
+	return nil
+}
+
+// Update 更新模型
+func (r *dbRepository[T]) Update(ctx context.Context, model *T, attributes map[string]interface{}) error {
+	var result database.DB
+	if len(attributes) == 0 {
+		result = r.db.WithContext(ctx).Save(model)
+	} else {
+		result = r.db.WithContext(ctx).Model(model).Updates(attributes)
+	}
+	if err := result.Error(); err != nil {
+		return fmt.Errorf("repository: update %s: %w", r.table, err)
+	}
+	return nil
+}
+
+// Delete 删除模型
+func (r *dbRepository[T]) Delete(ctx context.Context, model *T) error {
+	if err := r.db.WithContext(ctx).Delete(model).Error(); err != nil {
+		return fmt.Errorf("repository: delete %s: %w", r.table, err)
+	}
+	return nil
+}
+
+// WithCriteria 返回追加了查询条件的仓储
+func (r *dbRepository[T]) WithCriteria(criteria ...Criteria) Repository[T] {
+	combined := make([]Criteria, 0, len(r.criteria)+len(criteria))
+	combined = append(append(combined, r.criteria...), criteria...)
+	return &dbRepository[T]{db: r.db, table: r.table, criteria: combined}
+}
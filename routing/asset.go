@@ -0,0 +1,245 @@
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrManifestFormat 清单文件不是 Vite 或 Mix 格式
+var ErrManifestFormat = errors.New("routing: unrecognized asset manifest format")
+
+// Manifest 前端构建清单，把源文件路径映射为带版本的构建产物路径
+//
+// 支持两种格式，加载时自动识别：
+//   - Vite（build/manifest.json）：{"resources/js/app.js": {"file": "assets/app-4ed993c7.js"}}，
+//     产物路径相对构建目录
+//   - Mix（mix-manifest.json）：{"/js/app.js": "/js/app.js?id=6e6f6a1b"}，产物路径相对公共目录
+//
+// 清单文件的修改时间变化后（重新构建）自动重新加载，修改时间最多每秒检查一次。
+type Manifest struct {
+	path      string
+	directory string
+
+	mu      sync.RWMutex
+	modTime time.Time
+	checked time.Time
+	entries map[string]string
+}
+
+// manifestCheckInterval 检查清单文件修改时间的最小间隔
+const manifestCheckInterval = time.Second
+
+// viteChunk Vite 清单中的一项
+type viteChunk struct {
+	File string `json:"file"`
+}
+
+// NewManifest 创建清单，file 为清单文件路径，buildDirectory 为 Vite 产物相对公共目录的路径（例如 "build"），
+// Mix 清单忽略 buildDirectory
+func NewManifest(file string, buildDirectory string) (*Manifest, error) {
+	m := &Manifest{path: file, directory: strings.Trim(buildDirectory, "/")}
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Resolve 获取源文件对应的构建产物路径（以 "/" 开头，相对公共目录），不在清单中时返回 false
+func (m *Manifest) Resolve(asset string) (string, bool) {
+	if m.stale() {
+		// 加载失败时继续使用旧清单，构建过程中清单可能暂时不完整
+		_ = m.load()
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if resolved, ok := m.entries[strings.TrimPrefix(asset, "/")]; ok {
+		return resolved, true
+	}
+	return "", false
+}
+
+// stale 距上次检查超过 manifestCheckInterval 且清单文件的修改时间已经变化
+func (m *Manifest) stale() bool {
+	m.mu.Lock()
+	now := time.Now()
+	if now.Sub(m.checked) < manifestCheckInterval {
+		m.mu.Unlock()
+		return false
+	}
+	m.checked = now
+	loaded := m.modTime
+	m.mu.Unlock()
+
+	info, err := os.Stat(m.path)
+	return err == nil && !info.ModTime().Equal(loaded)
+}
+
+// load 读取并解析清单文件
+func (m *Manifest) load() error {
+	info, err := os.Stat(m.path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	entries := make(map[string]string, len(raw))
+	for key, value := range raw {
+		source := strings.TrimPrefix(key, "/")
+		var mix string
+		if json.Unmarshal(value, &mix) == nil {
+			entries[source] = "/" + strings.TrimPrefix(mix, "/")
+			continue
+		}
+		var chunk viteChunk
+		if json.Unmarshal(value, &chunk) != nil || chunk.File == "" {
+			return ErrManifestFormat
+		}
+		entries[source] = "/" + path.Join(m.directory, chunk.File)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = entries
+	m.modTime = info.ModTime()
+	m.checked = time.Now()
+	return nil
+}
+
+// AssetConfig 资源 URL 配置，对应 config/app.php 的 asset_url 和 Vite 配置
+type AssetConfig struct {
+	// Root 应用根地址，例如 "https://example.com"；为空时使用当前请求的协议和主机
+	Root string
+
+	// CDN 环境名到资源根地址（ASSET_URL）的映射，"*" 匹配其他环境；没有匹配时使用 Root
+	CDN map[string]string
+
+	// Environment 当前环境，用于选择 CDN
+	Environment string
+
+	// Manifest 构建清单，为 nil 时不做版本替换
+	Manifest *Manifest
+
+	// Proxies 受信任的反向代理，用于从请求判断协议和主机
+	Proxies *TrustedProxies
+}
+
+// AssetURL 生成资源 URL，实现 UrlGenerator 的 Asset 和 SecureAsset
+//
+// 路径在清单中时替换为带版本的构建产物路径（缓存失效），然后拼接资源根地址：
+// 当前环境的 CDN、Root 或当前请求的协议和主机。已经是完整 URL 的路径原样返回。
+// 没有 CDN 时，协议按受信任代理的 X-Forwarded-Proto 判断，TLS 终止在代理的部署也能生成 https 地址。
+//
+// 使用示例：
+//
+//	manifest, _ := routing.NewManifest("public/build/manifest.json", "build")
+//	proxies, _ := routing.NewTrustedProxies("10.0.0.0/8")
+//	assets := routing.NewAssetURL(routing.AssetConfig{
+//		CDN:         map[string]string{"production": "https://cdn.example.com"},
+//		Environment: app.Environment(),
+//		Manifest:    manifest,
+//		Proxies:     proxies,
+//	})
+//	assets.SetRequest(routing.NewRequest(r))
+//	src := assets.Asset("resources/js/app.js", false) // https://cdn.example.com/build/assets/app-4ed993c7.js
+type AssetURL struct {
+	config AssetConfig
+
+	mu      sync.RWMutex
+	request RequestInterface
+}
+
+// NewAssetURL 创建资源 URL 生成器
+func NewAssetURL(config AssetConfig) *AssetURL {
+	return &AssetURL{config: config}
+}
+
+// SetRequest 设置当前请求，用于推断根地址
+func (a *AssetURL) SetRequest(request RequestInterface) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.request = request
+}
+
+// GetRequest 获取当前请求
+func (a *AssetURL) GetRequest() RequestInterface {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.request
+}
+
+// Asset 生成资源 URL，secure 为 true 时强制使用 https
+func (a *AssetURL) Asset(asset string, secure bool) string {
+	if isAbsoluteURL(asset) {
+		return asset
+	}
+	resolved := "/" + strings.TrimPrefix(asset, "/")
+	if a.config.Manifest != nil {
+		if versioned, ok := a.config.Manifest.Resolve(asset); ok {
+			resolved = versioned
+		}
+	}
+	root := a.root()
+	if secure {
+		root = forceHTTPS(root)
+	}
+	return strings.TrimSuffix(root, "/") + resolved
+}
+
+// SecureAsset 生成 https 资源 URL
+func (a *AssetURL) SecureAsset(asset string) string {
+	return a.Asset(asset, true)
+}
+
+// root 资源根地址：当前环境的 CDN、Root 或当前请求的协议和主机，都没有时为空（生成相对路径）
+func (a *AssetURL) root() string {
+	if cdn, ok := a.config.CDN[a.config.Environment]; ok && cdn != "" {
+		return cdn
+	}
+	if cdn := a.config.CDN["*"]; cdn != "" {
+		return cdn
+	}
+	if a.config.Root != "" {
+		return a.config.Root
+	}
+	request := a.GetRequest()
+	if request == nil {
+		return ""
+	}
+	if carrier, ok := request.(interface{ HTTPRequest() *http.Request }); ok {
+		r := carrier.HTTPRequest()
+		return a.config.Proxies.Scheme(r) + "://" + a.config.Proxies.Host(r)
+	}
+	if host := request.GetHeader("Host"); host != "" {
+		return "http://" + host
+	}
+	return ""
+}
+
+// isAbsoluteURL 路径是否为带协议的完整 URL 或协议相对 URL
+func isAbsoluteURL(value string) bool {
+	if strings.HasPrefix(value, "//") {
+		return true
+	}
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// forceHTTPS 把 http 根地址替换为 https，协议相对地址和相对地址保持不变
+func forceHTTPS(root string) string {
+	if rest, ok := strings.CutPrefix(root, "http://"); ok {
+		return "https://" + rest
+	}
+	return root
+}
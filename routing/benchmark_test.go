@@ -0,0 +1,137 @@
+package routing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/pipeline"
+	"github.com/cnote0/laraveldoc/routing"
+	"github.com/cnote0/laraveldoc/support/benchmark"
+)
+
+// 每次请求的内存分配预算，超出时 TestAllocationBudget 失败
+//
+// 调高预算前先确认新增的分配是必要的。
+const (
+	// matchBudget 路由匹配：匹配本身不应分配
+	matchBudget = 0
+
+	// statsBudget 记录匹配耗时：只做原子加法
+	statsBudget = 0
+
+	// pipelineBudget 经过两个从容器解析的中间件，包含 NewRequest、管道的构建和 Handle 的反射调用
+	pipelineBudget = 36
+
+	// bodyLimitsBudget 请求体大小限制中间件
+	bodyLimitsBudget = 6
+)
+
+// staticRoutes 按方法和路径精确匹配的路由集合，只实现 Match
+type staticRoutes struct {
+	routing.RouteCollection
+	routes map[string]map[string]routing.Route
+}
+
+// Match 实现 routing.RouteCollection
+func (r *staticRoutes) Match(request routing.RequestInterface) routing.Route {
+	return r.routes[request.GetMethod()][request.GetPath()]
+}
+
+// stubRoute 只用于标识匹配结果的路由
+type stubRoute struct {
+	routing.Route
+	name string
+}
+
+// middlewareContainer 只实现 Make 的容器，按名称返回中间件
+type middlewareContainer struct {
+	container.Container
+	middleware map[string]interface{}
+}
+
+// Make 实现 container.Container
+func (c *middlewareContainer) Make(abstract interface{}) (interface{}, error) {
+	if m, ok := c.middleware[abstract.(string)]; ok {
+		return m, nil
+	}
+	return nil, container.NewBindingNotFoundError(abstract)
+}
+
+// passThrough 直接调用下一层的中间件
+type passThrough struct{}
+
+// Handle 实现中间件
+func (passThrough) Handle(ctx context.Context, request routing.RequestInterface, next pipeline.Next) (interface{}, error) {
+	return next(ctx, request)
+}
+
+// dispatchSuite 请求热路径上的用例
+func dispatchSuite() *benchmark.Suite {
+	routes := &staticRoutes{routes: map[string]map[string]routing.Route{
+		http.MethodGet: {
+			"/":          &stubRoute{name: "home"},
+			"/users/1":   &stubRoute{name: "users.show"},
+			"/users/1/e": &stubRoute{name: "users.edit"},
+		},
+	}}
+	request := routing.NewRequest(httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	stats := &routing.MatchStats{}
+
+	c := &middlewareContainer{middleware: map[string]interface{}{
+		"auth":     passThrough{},
+		"throttle": passThrough{},
+	}}
+	response := routing.NewResponse("ok", http.StatusOK)
+	kernel := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := routing.ThroughMiddleware(c, routing.NewRequest(r), []string{"auth", "throttle"}, func(routing.RequestInterface) routing.ResponseInterface {
+			return response
+		})
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	limits := routing.BodyLimits{MaxBodySize: 1 << 20}.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	return benchmark.NewSuite(
+		benchmark.RouteMatch("match GET /users/1", routes, request, matchBudget),
+		benchmark.Case{
+			Name:      "observe match time",
+			Run:       func() { stats.Observe(120*time.Microsecond, true) },
+			MaxAllocs: statsBudget,
+		},
+		benchmark.Middleware("two middleware", kernel, httptest.NewRequest(http.MethodGet, "/users/1", nil), pipelineBudget),
+		benchmark.Middleware("body limits", limits, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("name=taylor")), bodyLimitsBudget),
+	)
+}
+
+func TestAllocationBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation budget runs each case for the default benchmark time")
+	}
+	report := dispatchSuite().Run()
+	t.Log("\n" + report.String())
+	if err := report.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkDispatch(b *testing.B) {
+	dispatchSuite().Benchmark(b)
+}
+
+func BenchmarkMatchStatsObserveParallel(b *testing.B) {
+	stats := &routing.MatchStats{}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			stats.Observe(120*time.Microsecond, true)
+		}
+	})
+}
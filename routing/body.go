@@ -0,0 +1,151 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrBodyTooLarge 请求体超过 BodyLimits.MaxBodySize
+var ErrBodyTooLarge = errors.New("routing: request body too large")
+
+// BodyLimits 请求体大小限制和解析策略，对应 PHP 的 post_max_size 和 upload_max_filesize
+type BodyLimits struct {
+	// MaxBodySize 请求体的最大字节数，0 表示不限制
+	MaxBodySize int64
+
+	// MultipartMemory 解析 multipart 表单时保存在内存中的最大字节数，超出部分写入临时文件，
+	// 0 表示 DefaultMultipartMemory
+	MultipartMemory int64
+}
+
+// multipartMemory 解析 multipart 表单使用的内存上限
+func (l BodyLimits) multipartMemory() int64 {
+	if l.MultipartMemory > 0 {
+		return l.MultipartMemory
+	}
+	return DefaultMultipartMemory
+}
+
+// Middleware 限制请求体大小，超出时自动返回 413
+//
+// Content-Length 已经超出时直接返回 413，不调用 next。否则请求体在读取到超出的部分时返回错误，
+// Request 的惰性解析（第一次读取输入时）因此得到 ErrBodyTooLarge；此后 next 写出的响应
+// 被替换为 413，next 没有写出响应时也返回 413。限制放入 context，NewRequest 据此设置 multipart 内存上限。
+//
+// 嵌套的 Middleware 只能收紧限制：外层已经按自己的上限检查了 Content-Length 并包装了请求体，
+// 内层更大的上限不会生效。需要放宽限制的路由不能经过更严格的外层，应当分别为各路由设置限制。
+//
+// 使用示例：
+//
+//	limits := routing.BodyLimits{MaxBodySize: 8 << 20, MultipartMemory: 4 << 20}
+//	handler := limits.Middleware(router)
+//
+//	// 上传路由使用更大的限制，不经过上面的全局限制
+//	mux := http.NewServeMux()
+//	mux.Handle("/", routing.BodyLimits{MaxBodySize: 8 << 20}.Middleware(router))
+//	mux.Handle("/uploads", routing.BodyLimits{MaxBodySize: 512 << 20}.Middleware(uploadHandler))
+func (l BodyLimits) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(WithBodyLimits(r.Context(), l))
+		if l.MaxBodySize <= 0 || r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.ContentLength > l.MaxBodySize {
+			writeTooLarge(w)
+			return
+		}
+		body := &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, l.MaxBodySize)}
+		r.Body = body
+		lw := &limitWriter{ResponseWriter: w, body: body}
+		next.ServeHTTP(lw, r)
+		if body.exceeded.Load() {
+			lw.commit()
+		}
+	})
+}
+
+// limitedBody 记录是否读取到超出限制的部分
+type limitedBody struct {
+	io.ReadCloser
+	exceeded atomic.Bool
+}
+
+// Read 读取请求体
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var tooLarge *http.MaxBytesError
+	if err != nil && errors.As(err, &tooLarge) {
+		b.exceeded.Store(true)
+	}
+	return n, err
+}
+
+// limitWriter 请求体超出限制后把响应替换为 413
+type limitWriter struct {
+	http.ResponseWriter
+	body *limitedBody
+
+	once    sync.Once
+	replace bool
+}
+
+// WriteHeader 写出状态码
+func (w *limitWriter) WriteHeader(code int) {
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.commit()
+	if !w.replace {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+// Write 写出响应体，响应被替换时丢弃
+func (w *limitWriter) Write(p []byte) (int, error) {
+	w.commit()
+	if w.replace {
+		return len(p), nil
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// commit 第一次写出时决定是否替换响应
+func (w *limitWriter) commit() {
+	w.once.Do(func() {
+		if w.body.exceeded.Load() {
+			w.replace = true
+			writeTooLarge(w.ResponseWriter)
+		}
+	})
+}
+
+// Unwrap 支持 http.ResponseController
+func (w *limitWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// writeTooLarge 写出 413 响应，并要求关闭连接以免继续读取剩余的请求体
+func writeTooLarge(w http.ResponseWriter) {
+	w.Header().Set("Connection", "close")
+	http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+}
+
+// bodyLimitsKey context 中请求体限制的键
+type bodyLimitsKey struct{}
+
+// WithBodyLimits 把请求体限制放入 context
+func WithBodyLimits(ctx context.Context, limits BodyLimits) context.Context {
+	return context.WithValue(ctx, bodyLimitsKey{}, limits)
+}
+
+// BodyLimitsFromContext 从 context 获取请求体限制，没有时为零值（不限制）
+func BodyLimitsFromContext(ctx context.Context) BodyLimits {
+	limits, _ := ctx.Value(bodyLimitsKey{}).(BodyLimits)
+	return limits
+}
@@ -0,0 +1,319 @@
+package routing
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Encoder 创建压缩编码写入器，level 为 Compression 配置的压缩级别
+//
+// 写入器可以实现 Flush() error，流式响应调用 Flush 时会先刷新编码器中的数据。
+type Encoder func(w io.Writer, level int) (io.WriteCloser, error)
+
+// CompressionConfig 响应压缩配置
+type CompressionConfig struct {
+	// Level 压缩级别，默认 flate.DefaultCompression，由各编码器解释
+	Level int
+
+	// MinSize 响应体达到该字节数才压缩，默认 1024
+	MinSize int
+
+	// ExcludedTypes 不压缩的 MIME 类型，"image/*" 形式匹配整类，为空时使用 DefaultExcludedTypes
+	ExcludedTypes []string
+}
+
+// DefaultExcludedTypes 默认不压缩的 MIME 类型：已经压缩的格式和事件流
+//
+// image/svg+xml 是文本格式，不在排除之列。
+var DefaultExcludedTypes = []string{
+	"image/*", "video/*", "audio/*", "font/woff", "font/woff2",
+	"application/zip", "application/gzip", "application/x-gzip", "application/x-brotli",
+	"application/zstd", "application/x-7z-compressed", "application/x-rar-compressed",
+	"text/event-stream",
+}
+
+// Compression 响应压缩中间件，根据 Accept-Encoding 协商编码并以流的方式压缩响应体
+//
+// 内置 gzip 和 deflate，其他编码（例如 br、zstd）通过 RegisterEncoder 接入。
+// 响应体先缓冲到 MinSize 字节再决定是否压缩：不足 MinSize 的响应、已设置 Content-Encoding、
+// 部分内容（206）、无内容的状态码和 ExcludedTypes 中的类型原样发送。
+// 处理器调用 Flush 时立即开始压缩，流式响应不会被缓冲。
+// Response.Send 写入的响应同样经过压缩，较大的 JSON 响应不会以未压缩的形式发送。
+//
+// 使用示例：
+//
+//	compression := routing.NewCompression(routing.CompressionConfig{MinSize: 512})
+//	compression.RegisterEncoder("br", func(w io.Writer, level int) (io.WriteCloser, error) {
+//		return brotli.NewWriterLevel(w, brotli.DefaultCompression), nil
+//	})
+//	http.ListenAndServe(":8080", compression.Middleware(mux))
+type Compression struct {
+	config CompressionConfig
+
+	mu       sync.RWMutex
+	encoders map[string]Encoder
+	order    []string
+}
+
+// NewCompression 创建响应压缩中间件
+func NewCompression(config CompressionConfig) *Compression {
+	if config.Level == 0 {
+		config.Level = flate.DefaultCompression
+	}
+	if config.MinSize <= 0 {
+		config.MinSize = 1024
+	}
+	if config.ExcludedTypes == nil {
+		config.ExcludedTypes = DefaultExcludedTypes
+	}
+	c := &Compression{config: config, encoders: make(map[string]Encoder)}
+	c.RegisterEncoder("deflate", func(w io.Writer, level int) (io.WriteCloser, error) {
+		return flate.NewWriter(w, level)
+	})
+	c.RegisterEncoder("gzip", func(w io.Writer, level int) (io.WriteCloser, error) {
+		return gzip.NewWriterLevel(w, level)
+	})
+	return c
+}
+
+// RegisterEncoder 注册编码，后注册的编码在客户端同等接受时优先使用，同名编码被替换
+func (c *Compression) RegisterEncoder(name string, encoder Encoder) *Compression {
+	name = strings.ToLower(name)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.encoders[name]; ok {
+		for i, existing := range c.order {
+			if existing == name {
+				c.order = append(c.order[:i], c.order[i+1:]...)
+				break
+			}
+		}
+	}
+	c.encoders[name] = encoder
+	c.order = append([]string{name}, c.order...)
+	return c
+}
+
+// Negotiate 根据 Accept-Encoding 选择编码，q 值最高者优先，同等时按服务端优先级，没有可用编码时返回空字符串
+func (c *Compression) Negotiate(acceptEncoding string) string {
+	accepted := parseAcceptEncoding(acceptEncoding)
+	if len(accepted) == 0 {
+		return ""
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	best, bestQ := "", 0.0
+	for _, name := range c.order {
+		q, ok := accepted[name]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// Middleware 压缩响应的中间件
+func (c *Compression) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := c.Negotiate(r.Header.Get("Accept-Encoding"))
+		w.Header().Add("Vary", "Accept-Encoding")
+		if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		c.mu.RLock()
+		encoder := c.encoders[encoding]
+		c.mu.RUnlock()
+
+		cw := &compressWriter{ResponseWriter: w, compression: c, encoding: encoding, encoder: encoder}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// excluded MIME 类型是否不压缩
+func (c *Compression) excluded(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	for _, pattern := range c.config.ExcludedTypes {
+		if pattern == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") && mediaType != "image/svg+xml" {
+			return true
+		}
+	}
+	return false
+}
+
+// compressWriter 缓冲响应体直到能决定是否压缩
+type compressWriter struct {
+	http.ResponseWriter
+	compression *Compression
+	encoding    string
+	encoder     Encoder
+
+	status  int
+	buffer  bytes.Buffer
+	decided bool
+	writer  io.WriteCloser
+}
+
+// WriteHeader 记录状态码，信息性状态码和无需压缩的状态码直接发送
+func (w *compressWriter) WriteHeader(status int) {
+	if status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if w.status != 0 || w.decided {
+		return
+	}
+	w.status = status
+	if status == http.StatusNoContent || status == http.StatusNotModified || status == http.StatusPartialContent {
+		_ = w.passthrough()
+	}
+}
+
+// Write 缓冲或压缩响应体
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.decided {
+		w.buffer.Write(b)
+		if !w.eligible() {
+			return len(b), w.passthrough()
+		}
+		if w.buffer.Len() < w.compression.config.MinSize {
+			return len(b), nil
+		}
+		return len(b), w.compress()
+	}
+	if w.writer != nil {
+		return w.writer.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush 立即决定是否压缩并刷新已写入的数据
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		if w.eligible() {
+			_ = w.compress()
+		} else {
+			_ = w.passthrough()
+		}
+	}
+	if flusher, ok := w.writer.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap 供 http.ResponseController 访问底层的 ResponseWriter
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// eligible 根据响应头和已缓冲的内容判断是否可以压缩，没有 Content-Type 时按内容检测并设置
+func (w *compressWriter) eligible() bool {
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+	if length, err := strconv.Atoi(header.Get("Content-Length")); err == nil && length < w.compression.config.MinSize {
+		return false
+	}
+	contentType := header.Get("Content-Type")
+	if contentType == "" && w.buffer.Len() > 0 {
+		contentType = http.DetectContentType(w.buffer.Bytes())
+		header.Set("Content-Type", contentType)
+	}
+	return !w.compression.excluded(contentType)
+}
+
+// compress 发送压缩响应头和已缓冲的内容
+func (w *compressWriter) compress() error {
+	w.decided = true
+	writer, err := w.encoder(w.ResponseWriter, w.compression.config.Level)
+	if err != nil {
+		return w.send()
+	}
+	w.writer = writer
+	header := w.Header()
+	header.Del("Content-Length")
+	header.Set("Content-Encoding", w.encoding)
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, err = w.writer.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+	return err
+}
+
+// passthrough 原样发送响应头和已缓冲的内容
+func (w *compressWriter) passthrough() error {
+	w.decided = true
+	return w.send()
+}
+
+// send 发送状态码和缓冲的内容
+func (w *compressWriter) send() error {
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+	return err
+}
+
+// close 处理器返回后发送不足 MinSize 的响应或结束压缩流
+func (w *compressWriter) close() {
+	if !w.decided {
+		_ = w.passthrough()
+	}
+	if w.writer != nil {
+		_ = w.writer.Close()
+	}
+}
+
+// parseAcceptEncoding 解析 Accept-Encoding 为编码到 q 值的映射，q 为 0 的编码表示拒绝
+func parseAcceptEncoding(header string) map[string]float64 {
+	accepted := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		accepted[name] = q
+	}
+	return accepted
+}
@@ -0,0 +1,271 @@
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// ErrNoURLGenerator 控制器没有设置 URL 生成器，不能重定向到命名路由
+var ErrNoURLGenerator = errors.New("routing: controller has no url generator")
+
+// Controller 可选的控制器基础类型，对应 Laravel 的 Illuminate\Routing\Controller
+//
+// 嵌入 Controller 后可以在构造函数中声明控制器级别的中间件（Middleware、AuthorizeResource），
+// 在动作中使用 ValidateWith、Response 和 Redirect。ControllerRoutes 展开路由时按动作的方法名
+// 追加匹配的控制器中间件，因此控制器需要以指针注册。零值可以直接使用。
+//
+// 使用示例：
+//
+//	type PostController struct {
+//		routing.Controller
+//		posts PostRepository
+//	}
+//
+//	func NewPostController(posts PostRepository, urls routing.UrlGenerator) *PostController {
+//		c := &PostController{posts: posts}
+//		c.SetURLGenerator(urls)
+//		c.Middleware("auth").Except("Index", "Show")
+//		c.AuthorizeResource("Post", "post")
+//		return c
+//	}
+//
+//	func (c *PostController) Store(w http.ResponseWriter, r *http.Request) {
+//		input, err := c.ValidateWith(r, postRules)
+//		if err != nil {
+//			exceptions.AbortWith(err)
+//		}
+//		post := c.posts.Create(input)
+//		redirect, _ := c.Redirect(r).Route("posts.show", map[string]interface{}{"post": post.ID})
+//		redirect.With("status", "Post created!").WriteTo(w)
+//	}
+type Controller struct {
+	mu         sync.Mutex
+	middleware []*ControllerMiddleware
+	urls       UrlGenerator
+}
+
+// ControllerMiddleware 控制器级别的中间件声明，Only 和 Except 为动作的方法名
+type ControllerMiddleware struct {
+	// Middleware 中间件，格式与路由中间件相同，例如 "can:update,post"
+	Middleware []string
+
+	// OnlyMethods 只应用于这些动作，为空时应用于全部动作
+	OnlyMethods []string
+
+	// ExceptMethods 不应用于这些动作
+	ExceptMethods []string
+}
+
+// HasMiddleware 声明控制器级别中间件的控制器，嵌入 Controller 即满足该接口
+type HasMiddleware interface {
+	GetMiddleware() []ControllerMiddleware
+}
+
+var _ HasMiddleware = (*Controller)(nil)
+
+// Middleware 声明应用于全部动作的中间件，返回的声明可以用 Only 或 Except 限定动作
+func (c *Controller) Middleware(middleware ...string) *ControllerMiddleware {
+	declaration := &ControllerMiddleware{Middleware: middleware}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.middleware = append(c.middleware, declaration)
+	return declaration
+}
+
+// Only 只应用于这些动作
+func (m *ControllerMiddleware) Only(methods ...string) *ControllerMiddleware {
+	m.OnlyMethods = append(m.OnlyMethods, methods...)
+	return m
+}
+
+// Except 不应用于这些动作
+func (m *ControllerMiddleware) Except(methods ...string) *ControllerMiddleware {
+	m.ExceptMethods = append(m.ExceptMethods, methods...)
+	return m
+}
+
+// AppliesTo 是否应用于动作，method 为空表示动作不是控制器方法，只有没有 Only 限定的中间件应用
+func (m ControllerMiddleware) AppliesTo(method string) bool {
+	if method == "" {
+		return len(m.OnlyMethods) == 0
+	}
+	if len(m.OnlyMethods) > 0 && !containsString(m.OnlyMethods, method) {
+		return false
+	}
+	return !containsString(m.ExceptMethods, method)
+}
+
+// GetMiddleware 控制器声明的中间件，按声明顺序
+func (c *Controller) GetMiddleware() []ControllerMiddleware {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	declarations := make([]ControllerMiddleware, len(c.middleware))
+	for i, declaration := range c.middleware {
+		declarations[i] = *declaration
+	}
+	return declarations
+}
+
+// resourceAbilities 资源控制器动作对应的授权能力，按 Laravel 的 resourceAbilityMap
+var resourceAbilities = []struct {
+	method  string
+	ability string
+	model   bool
+}{
+	{"Index", "viewAny", false},
+	{"Show", "view", true},
+	{"Create", "create", false},
+	{"Store", "create", false},
+	{"Edit", "update", true},
+	{"Update", "update", true},
+	{"Destroy", "delete", true},
+}
+
+// AuthorizeResource 为资源控制器的动作声明 can 中间件，对应 Laravel 的 authorizeResource
+//
+// Index、Create、Store 按模型名授权（"can:viewAny,Post"），Show、Edit、Update、Destroy 按路由参数中的模型授权
+// （"can:update,post"）。parameter 默认为模型名的蛇形命名，与 ResourceRoutes 的参数名一致。
+func (c *Controller) AuthorizeResource(model string, parameter ...string) {
+	name := str.Snake(model)
+	if len(parameter) > 0 && parameter[0] != "" {
+		name = parameter[0]
+	}
+	for _, ability := range resourceAbilities {
+		target := model
+		if ability.model {
+			target = name
+		}
+		c.Middleware("can:" + ability.ability + "," + target).Only(ability.method)
+	}
+}
+
+// Validator 校验请求输入，未通过时返回错误，例如 *exceptions.ValidationError
+type Validator interface {
+	Validate(input map[string]interface{}) error
+}
+
+// ValidatorFunc 函数形式的 Validator
+type ValidatorFunc func(input map[string]interface{}) error
+
+// Validate 实现 Validator
+func (f ValidatorFunc) Validate(input map[string]interface{}) error {
+	return f(input)
+}
+
+// ValidateWith 用 validator 校验请求输入（查询参数和请求体），通过时返回输入，对应 Laravel 的 validateWith
+//
+// 请求体无法解析时返回解析错误（例如 ErrBodyTooLarge）。validator 返回的错误原样返回，
+// 返回 *exceptions.ValidationError 时可以交给 exceptions.AbortWith 或异常处理器渲染为 422 或跳转。
+func (c *Controller) ValidateWith(r *http.Request, validator Validator) (map[string]interface{}, error) {
+	request := NewRequest(r)
+	input := request.All()
+	if err := request.ParseError(); err != nil {
+		return nil, err
+	}
+	if err := validator.Validate(input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// SetURLGenerator 设置 Redirect 生成命名路由 URL 使用的 URL 生成器
+func (c *Controller) SetURLGenerator(urls UrlGenerator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.urls = urls
+}
+
+// Response 响应工厂，对应 Laravel 的 response()
+func (c *Controller) Response() ResponseFactory {
+	return ResponseFactory{}
+}
+
+// Redirect 当前请求的重定向工厂，对应 Laravel 的 redirect()
+func (c *Controller) Redirect(r *http.Request) *Redirector {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &Redirector{request: NewRequest(r), urls: c.urls}
+}
+
+// ResponseFactory 创建常用的响应
+type ResponseFactory struct{}
+
+// Make 创建响应，status 为 0 时使用 200
+func (ResponseFactory) Make(content string, status int) *Response {
+	return NewResponse(content, status)
+}
+
+// JSON 把 data 编码为 JSON 响应，status 为 0 时使用 200
+func (ResponseFactory) JSON(data interface{}, status int) (*Response, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	response := NewResponse(string(body), status)
+	response.SetHeader("Content-Type", "application/json")
+	return response, nil
+}
+
+// NoContent 204 响应
+func (ResponseFactory) NoContent() *Response {
+	return NewResponse("", http.StatusNoContent)
+}
+
+// Redirector 创建重定向响应，闪存数据写入请求的会话
+type Redirector struct {
+	request RequestInterface
+	urls    UrlGenerator
+}
+
+// To 重定向到 url
+func (r *Redirector) To(url string) *Redirect {
+	return NewRedirect(r.request, url, 0)
+}
+
+// Away 重定向到外部 url
+func (r *Redirector) Away(url string) *Redirect {
+	redirect := NewRedirect(r.request, "", 0)
+	redirect.Away(url)
+	return redirect
+}
+
+// Back 重定向到上一个 URL
+func (r *Redirector) Back() *Redirect {
+	redirect := NewRedirect(r.request, "", 0)
+	redirect.Back()
+	return redirect
+}
+
+// Home 重定向到首页
+func (r *Redirector) Home() *Redirect {
+	redirect := NewRedirect(r.request, "", 0)
+	redirect.Home()
+	return redirect
+}
+
+// Route 重定向到命名路由，没有设置 URL 生成器时返回 ErrNoURLGenerator
+func (r *Redirector) Route(name string, parameters map[string]interface{}) (*Redirect, error) {
+	if r.urls == nil {
+		return nil, ErrNoURLGenerator
+	}
+	return NewRedirect(r.request, r.urls.Route(name, parameters, true), 0), nil
+}
+
+// controllerMiddleware 控制器对动作 method 声明的中间件
+func controllerMiddleware(controller interface{}, method string) []string {
+	declaring, ok := controller.(HasMiddleware)
+	if !ok {
+		return nil
+	}
+	var middleware []string
+	for _, declaration := range declaring.GetMiddleware() {
+		if declaration.AppliesTo(method) {
+			middleware = append(middleware, declaration.Middleware...)
+		}
+	}
+	return middleware
+}
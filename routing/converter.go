@@ -0,0 +1,386 @@
+package routing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/support/enum"
+)
+
+// ErrParamConversion 路由参数无法转换为声明的类型，路由器应当按未匹配处理（404）
+var ErrParamConversion = errors.New("routing: route parameter conversion failed")
+
+// ParamError 路由参数转换失败
+type ParamError struct {
+	// Name 参数名
+	Name string
+
+	// Value 原始值
+	Value string
+
+	// Err 转换器返回的错误
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("%v: {%s} = %q: %v", ErrParamConversion, e.Name, e.Value, e.Err)
+}
+
+// Unwrap 支持 errors.Is(err, ErrParamConversion) 和取出转换器的错误
+func (e *ParamError) Unwrap() []error {
+	return []error{ErrParamConversion, e.Err}
+}
+
+// ParamConverter 路由参数转换器，把 URI 中的字符串参数转换为类型化的值
+type ParamConverter interface {
+	// Regex 参数的约束正则表达式，路由没有为参数设置约束时使用
+	Regex() string
+
+	// Convert 转换参数值
+	Convert(value string) (interface{}, error)
+}
+
+// converterFunc 由正则表达式和转换函数构成的转换器
+type converterFunc struct {
+	regex   string
+	convert func(string) (interface{}, error)
+}
+
+// Regex 实现 ParamConverter
+func (c converterFunc) Regex() string {
+	return c.regex
+}
+
+// Convert 实现 ParamConverter
+func (c converterFunc) Convert(value string) (interface{}, error) {
+	return c.convert(value)
+}
+
+// NewConverter 由约束正则表达式和转换函数创建转换器
+func NewConverter(regex string, convert func(value string) (interface{}, error)) ParamConverter {
+	return converterFunc{regex: regex, convert: convert}
+}
+
+// 内置转换器
+var (
+	// IntConverter 转换为 int
+	IntConverter = NewConverter(`-?[0-9]+`, func(value string) (interface{}, error) {
+		return strconv.Atoi(value)
+	})
+
+	// Int64Converter 转换为 int64
+	Int64Converter = NewConverter(`-?[0-9]+`, func(value string) (interface{}, error) {
+		return strconv.ParseInt(value, 10, 64)
+	})
+
+	// UintConverter 转换为 uint64
+	UintConverter = NewConverter(`[0-9]+`, func(value string) (interface{}, error) {
+		return strconv.ParseUint(value, 10, 64)
+	})
+
+	// FloatConverter 转换为 float64
+	FloatConverter = NewConverter(`-?[0-9]+(\.[0-9]+)?`, func(value string) (interface{}, error) {
+		return strconv.ParseFloat(value, 64)
+	})
+
+	// UUIDConverter 转换为 UUID
+	UUIDConverter = NewConverter(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`, func(value string) (interface{}, error) {
+		return ParseUUID(value)
+	})
+
+	// DateConverter 把 "2006-01-02" 格式的日期转换为 time.Time（UTC）
+	DateConverter = TimeConverter(time.DateOnly, `[0-9]{4}-[0-9]{2}-[0-9]{2}`)
+)
+
+// TimeConverter 按 layout 转换为 time.Time（没有时区信息时为 UTC），regex 为参数约束，
+// 为空时不约束（layout 中可能包含 "/" 以外的任意字符）
+func TimeConverter(layout string, regex string) ParamConverter {
+	if regex == "" {
+		regex = `[^/]+`
+	}
+	return NewConverter(regex, func(value string) (interface{}, error) {
+		return time.Parse(layout, value)
+	})
+}
+
+// EnumConverter 转换为枚举类型 T 的枚举值，参数约束为全部枚举值的底层值
+//
+//	converters.Pattern("status", routing.EnumConverter[OrderStatus]())
+//	status := routing.EnumParam[OrderStatus](params, "status")
+func EnumConverter[T enum.Enumerable[T]]() ParamConverter {
+	values := enum.Values[T]()
+	alternatives := make([]string, len(values))
+	for i, value := range values {
+		alternatives[i] = regexp.QuoteMeta(value)
+	}
+	return NewConverter("("+strings.Join(alternatives, "|")+")", func(value string) (interface{}, error) {
+		return enum.From[T](value)
+	})
+}
+
+// UUID 16 字节的 UUID
+type UUID [16]byte
+
+// ParseUUID 解析 "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx" 格式的 UUID
+func ParseUUID(value string) (UUID, error) {
+	var u UUID
+	if len(value) != 36 || value[8] != '-' || value[13] != '-' || value[18] != '-' || value[23] != '-' {
+		return u, fmt.Errorf("invalid UUID %q", value)
+	}
+	digits := value[0:8] + value[9:13] + value[14:18] + value[19:23] + value[24:]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return u, fmt.Errorf("invalid UUID %q", value)
+	}
+	return u, nil
+}
+
+// String 小写的标准格式
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// routeParamPattern URI 中的参数占位符，例如 {id}、{slug?}
+var routeParamPattern = regexp.MustCompile(`\{(\w+)\??\}`)
+
+// Converters 路由器级的参数转换器注册表，对应 Laravel 的 Router::pattern，
+// Router.Pattern 的实现可以直接使用
+//
+// 按参数名注册转换器后，所有包含该参数的路由在编译时确定各参数的转换器，结果按路由缓存，
+// 每个请求只执行转换，不再查找注册表。路由没有为参数设置约束时使用转换器的正则表达式，
+// 因此 {id} 注册 IntConverter 后 /users/abc 不会匹配。
+//
+// 使用示例：
+//
+//	converters := routing.NewConverters()
+//	converters.Pattern("id", routing.IntConverter)
+//	converters.Pattern("order", routing.UUIDConverter)
+//
+//	// 路由器匹配到 route 和原始参数后
+//	r, err := converters.Compile(route).Bind(r, rawParams) // 出错时返回 404
+//
+//	// 动作中直接取得类型化的值
+//	func (c *OrderController) Show(w http.ResponseWriter, r *http.Request) {
+//		params := routing.RouteParams(r.Context())
+//		id := params.Int("id")
+//		order := params.UUID("order")
+//	}
+type Converters struct {
+	mu         sync.RWMutex
+	converters map[string]ParamConverter
+	compiled   sync.Map
+}
+
+// NewConverters 创建转换器注册表
+func NewConverters() *Converters {
+	return &Converters{converters: make(map[string]ParamConverter)}
+}
+
+// Pattern 为参数名注册转换器，清空已编译的缓存
+func (c *Converters) Pattern(name string, converter ParamConverter) *Converters {
+	c.mu.Lock()
+	c.converters[name] = converter
+	c.mu.Unlock()
+	c.compiled.Range(func(key, _ interface{}) bool {
+		c.compiled.Delete(key)
+		return true
+	})
+	return c
+}
+
+// Get 获取参数名的转换器
+func (c *Converters) Get(name string) (ParamConverter, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	converter, ok := c.converters[name]
+	return converter, ok
+}
+
+// Compile 编译路由的参数转换器，结果按路由缓存
+//
+// 为没有约束的参数设置转换器的正则表达式。缓存以路由为键，编译后再修改路由的 URI 不会更新缓存；
+// 动态类型不可比较的路由不缓存。
+func (c *Converters) Compile(route Route) *RouteConverters {
+	cacheable := route != nil && reflect.TypeOf(route).Comparable()
+	if cacheable {
+		if compiled, ok := c.compiled.Load(route); ok {
+			return compiled.(*RouteConverters)
+		}
+	}
+	compiled := &RouteConverters{}
+	if route == nil {
+		return compiled
+	}
+	wheres := route.GetWhere()
+	for _, match := range routeParamPattern.FindAllStringSubmatch(route.GetURI(), -1) {
+		name := match[1]
+		converter, ok := c.Get(name)
+		if !ok {
+			continue
+		}
+		if _, constrained := wheres[name]; !constrained {
+			route.Where(name, converter.Regex())
+		}
+		compiled.names = append(compiled.names, name)
+		compiled.converters = append(compiled.converters, converter)
+	}
+	if cacheable {
+		if existing, loaded := c.compiled.LoadOrStore(route, compiled); loaded {
+			return existing.(*RouteConverters)
+		}
+	}
+	return compiled
+}
+
+// RouteConverters 一条路由编译后的参数转换器
+type RouteConverters struct {
+	names      []string
+	converters []ParamConverter
+}
+
+// Names 有转换器的参数名
+func (rc *RouteConverters) Names() []string {
+	return append([]string(nil), rc.names...)
+}
+
+// Convert 转换匹配到的原始参数，没有转换器的参数保持字符串，可选参数缺失时不转换
+func (rc *RouteConverters) Convert(raw map[string]string) (Params, error) {
+	params := make(Params, len(raw))
+	for name, value := range raw {
+		params[name] = value
+	}
+	for i, name := range rc.names {
+		value, ok := raw[name]
+		if !ok || value == "" {
+			continue
+		}
+		converted, err := rc.converters[i].Convert(value)
+		if err != nil {
+			return nil, &ParamError{Name: name, Value: value, Err: err}
+		}
+		params[name] = converted
+	}
+	return params, nil
+}
+
+// Bind 转换参数并放入请求的 context，转换失败时返回 *ParamError
+func (rc *RouteConverters) Bind(r *http.Request, raw map[string]string) (*http.Request, error) {
+	params, err := rc.Convert(raw)
+	if err != nil {
+		return r, err
+	}
+	return r.WithContext(WithRouteParams(r.Context(), params)), nil
+}
+
+// Params 转换后的路由参数
+type Params map[string]interface{}
+
+// Get 获取参数值
+func (p Params) Get(name string) (interface{}, bool) {
+	value, ok := p[name]
+	return value, ok
+}
+
+// String 获取字符串参数，类型化的参数按 fmt.Sprint 格式化
+func (p Params) String(name string) string {
+	switch value := p[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Int 获取 IntConverter 转换的参数，类型不符时为 0
+func (p Params) Int(name string) int {
+	value, _ := p[name].(int)
+	return value
+}
+
+// Int64 获取 Int64Converter 转换的参数，类型不符时为 0
+func (p Params) Int64(name string) int64 {
+	value, _ := p[name].(int64)
+	return value
+}
+
+// Uint 获取 UintConverter 转换的参数，类型不符时为 0
+func (p Params) Uint(name string) uint64 {
+	value, _ := p[name].(uint64)
+	return value
+}
+
+// Float 获取 FloatConverter 转换的参数，类型不符时为 0
+func (p Params) Float(name string) float64 {
+	value, _ := p[name].(float64)
+	return value
+}
+
+// UUID 获取 UUIDConverter 转换的参数，类型不符时为零值
+func (p Params) UUID(name string) UUID {
+	value, _ := p[name].(UUID)
+	return value
+}
+
+// Time 获取 DateConverter、TimeConverter 转换的参数，类型不符时为零值
+func (p Params) Time(name string) time.Time {
+	value, _ := p[name].(time.Time)
+	return value
+}
+
+// EnumParam 获取 EnumConverter 转换的参数，类型不符时为零值
+func EnumParam[T enum.Enumerable[T]](p Params, name string) T {
+	value, _ := p[name].(T)
+	return value
+}
+
+// routeParamsKey context 中路由参数的键
+type routeParamsKey struct{}
+
+// WithRouteParams 把路由参数放入 context
+func WithRouteParams(ctx context.Context, params Params) context.Context {
+	return context.WithValue(ctx, routeParamsKey{}, params)
+}
+
+// RouteParams 从 context 获取路由参数，没有时返回空的 Params
+func RouteParams(ctx context.Context) Params {
+	if params, ok := ctx.Value(routeParamsKey{}).(Params); ok {
+		return params
+	}
+	return Params{}
+}
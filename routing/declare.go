@@ -0,0 +1,213 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidRouteDef 控制器的路由声明无效
+var ErrInvalidRouteDef = errors.New("routing: invalid route definition")
+
+// RouteTag 声明路由的占位字段类型，不占用内存，字段的结构体标签描述路由
+//
+// 名为 "_" 的字段声明控制器的分组属性：prefix、name（名称前缀）、middleware（空格分隔）。
+// 其他字段各声明一条路由，标签：
+//
+//	route       "方法 URI"，多个方法用 "|" 分隔，ANY 表示任意方法，例如 "GET|HEAD /users/{id}"
+//	action      处理方法名，默认为字段名首字母大写（字段 show 对应方法 Show）
+//	name        路由名称，加上分组的名称前缀
+//	middleware  空格分隔的中间件，追加在分组中间件之后，中间件参数仍用逗号，例如 "auth can:delete,user"
+//	where       空格分隔的参数约束，例如 "id=[0-9]+ slug=[a-z-]+"
+//
+// 使用示例：
+//
+//	type UserController struct {
+//		_       routing.RouteTag `prefix:"/users" name:"users." middleware:"auth verified"`
+//		index   routing.RouteTag `route:"GET /" name:"index"`
+//		show    routing.RouteTag `route:"GET /{id}" name:"show" where:"id=[0-9]+"`
+//		destroy routing.RouteTag `route:"DELETE /{id}" name:"destroy" middleware:"can:delete,user"`
+//
+//		users UserRepository
+//	}
+//
+//	func (c *UserController) Index(w http.ResponseWriter, r *http.Request) { ... }
+//
+//	err := router.RegisterController(&UserController{users: repo})
+type RouteTag struct{}
+
+// RouteDef 一条路由声明
+type RouteDef struct {
+	// Methods HTTP 方法，为空时为 GET
+	Methods []string
+
+	// URI 相对控制器前缀的路径
+	URI string
+
+	// Action 处理动作：控制器的方法名（string）或可直接注册的动作（例如 http.HandlerFunc）
+	Action interface{}
+
+	// Name 路由名称，加上控制器的名称前缀
+	Name string
+
+	// Middleware 中间件，追加在控制器中间件之后
+	Middleware []string
+
+	// Where 参数约束
+	Where map[string]string
+}
+
+// RouteProvider 通过方法声明路由的控制器，与 RouteTag 字段可以同时使用，
+// Routes 返回的路由排在字段声明的路由之后
+type RouteProvider interface {
+	Routes() []RouteDef
+}
+
+// ControllerRoutes 展开控制器声明的路由，返回带完整 URI、名称和中间件的路由声明
+//
+// 方法名形式的 Action 被替换为绑定到 controller 的方法值；使用指针接收者的方法需要传入指针。
+// 实现了 HasMiddleware 的控制器（嵌入 Controller）按方法名追加控制器级别的中间件，排在路由中间件之后。
+// 声明格式错误或方法不存在时返回 ErrInvalidRouteDef。
+func ControllerRoutes(controller interface{}) ([]RouteDef, error) {
+	value := reflect.ValueOf(controller)
+	structType := value.Type()
+	for structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is not a struct", ErrInvalidRouteDef, controller)
+	}
+
+	var group RouteDef
+	var defs []RouteDef
+	tagType := reflect.TypeOf(RouteTag{})
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Type != tagType {
+			continue
+		}
+		if field.Name == "_" {
+			group = RouteDef{
+				URI:        field.Tag.Get("prefix"),
+				Name:       field.Tag.Get("name"),
+				Middleware: splitList(field.Tag.Get("middleware")),
+			}
+			continue
+		}
+		def, err := parseRouteTag(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s.%s: %v", ErrInvalidRouteDef, structType.Name(), field.Name, err)
+		}
+		defs = append(defs, def)
+	}
+	if provider, ok := controller.(RouteProvider); ok {
+		defs = append(defs, provider.Routes()...)
+	}
+
+	expanded := make([]RouteDef, 0, len(defs))
+	for _, def := range defs {
+		action := def.Action
+		method, _ := action.(string)
+		if method != "" {
+			bound := value.MethodByName(method)
+			if !bound.IsValid() {
+				return nil, fmt.Errorf("%w: %T has no method %s", ErrInvalidRouteDef, controller, method)
+			}
+			action = bound.Interface()
+		}
+		if action == nil {
+			return nil, fmt.Errorf("%w: %T route %q has no action", ErrInvalidRouteDef, controller, def.URI)
+		}
+		methods := def.Methods
+		if len(methods) == 0 {
+			methods = []string{http.MethodGet}
+		}
+		var name string
+		if def.Name != "" {
+			name = group.Name + def.Name
+		}
+		expanded = append(expanded, RouteDef{
+			Methods:    methods,
+			URI:        joinURI(group.URI, def.URI),
+			Action:     action,
+			Name:       name,
+			Middleware: append(append(append([]string(nil), group.Middleware...), def.Middleware...), controllerMiddleware(controller, method)...),
+			Where:      def.Where,
+		})
+	}
+	return expanded, nil
+}
+
+// RegisterRoutes 把路由声明注册到路由器，返回注册的路由，Router.RegisterController 的实现可以直接使用
+func RegisterRoutes(router Router, defs []RouteDef) []Route {
+	routes := make([]Route, 0, len(defs))
+	for _, def := range defs {
+		var route Route
+		if len(def.Methods) == 1 && def.Methods[0] == "ANY" {
+			route = router.Any(def.URI, def.Action)
+		} else {
+			route = router.Match(def.Methods, def.URI, def.Action)
+		}
+		if def.Name != "" {
+			route.Name(def.Name)
+		}
+		if len(def.Middleware) > 0 {
+			route.Middleware(def.Middleware...)
+		}
+		for name, expression := range def.Where {
+			route.Where(name, expression)
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// parseRouteTag 解析路由字段的标签
+func parseRouteTag(field reflect.StructField) (RouteDef, error) {
+	spec := strings.TrimSpace(field.Tag.Get("route"))
+	methods, uri, ok := strings.Cut(spec, " ")
+	if !ok || strings.TrimSpace(uri) == "" {
+		return RouteDef{}, fmt.Errorf(`route tag must be "METHOD /uri", got %q`, spec)
+	}
+	def := RouteDef{
+		URI:        strings.TrimSpace(uri),
+		Action:     field.Tag.Get("action"),
+		Name:       field.Tag.Get("name"),
+		Middleware: splitList(field.Tag.Get("middleware")),
+	}
+	for _, method := range strings.Split(methods, "|") {
+		def.Methods = append(def.Methods, strings.ToUpper(strings.TrimSpace(method)))
+	}
+	if def.Action == "" {
+		r, size := utf8.DecodeRuneInString(field.Name)
+		def.Action = string(unicode.ToUpper(r)) + field.Name[size:]
+	}
+	for _, constraint := range splitList(field.Tag.Get("where")) {
+		name, expression, ok := strings.Cut(constraint, "=")
+		if !ok {
+			return RouteDef{}, fmt.Errorf(`where tag must be "name=pattern", got %q`, constraint)
+		}
+		if def.Where == nil {
+			def.Where = make(map[string]string)
+		}
+		def.Where[strings.TrimSpace(name)] = strings.TrimSpace(expression)
+	}
+	return def, nil
+}
+
+// splitList 拆分空格分隔的标签值
+//
+// where 约束的正则表达式中不能包含空格，需要时使用 \s。
+func splitList(value string) []string {
+	return strings.Fields(value)
+}
+
+// joinURI 拼接前缀和路径，结果以 "/" 开头且除根路径外不以 "/" 结尾
+func joinURI(prefix, uri string) string {
+	return path.Join("/", prefix, uri)
+}
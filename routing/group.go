@@ -0,0 +1,395 @@
+package routing
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// GroupAttributes 路由分组属性
+type GroupAttributes struct {
+	// Prefix URI 前缀，嵌套时用 "/" 连接
+	Prefix string
+
+	// Name 路由名称前缀，嵌套时直接拼接，例如 "api." + "users."
+	Name string
+
+	// Namespace 控制器命名空间，嵌套时用 "\" 连接，以 "\" 开头时替换外层命名空间
+	Namespace string
+
+	// Domain 域名，内层设置时替换外层
+	Domain string
+
+	// Middleware 中间件，内层追加在外层之后
+	Middleware []string
+
+	// WithoutMiddleware 排除的中间件，内层追加在外层之后
+	WithoutMiddleware []string
+
+	// Where 参数约束，同名时内层优先
+	Where map[string]string
+}
+
+// Merge 把内层分组属性合并到外层（接收者），返回新的属性，规则与 Laravel 的 RouteGroup::merge 相同
+func (g GroupAttributes) Merge(inner GroupAttributes) GroupAttributes {
+	merged := GroupAttributes{
+		Prefix:            joinPrefix(g.Prefix, inner.Prefix),
+		Name:              g.Name + inner.Name,
+		Namespace:         joinNamespace(g.Namespace, inner.Namespace),
+		Domain:            g.Domain,
+		Middleware:        append(append([]string(nil), g.Middleware...), inner.Middleware...),
+		WithoutMiddleware: append(append([]string(nil), g.WithoutMiddleware...), inner.WithoutMiddleware...),
+	}
+	if inner.Domain != "" {
+		merged.Domain = inner.Domain
+	}
+	if len(g.Where)+len(inner.Where) > 0 {
+		merged.Where = make(map[string]string, len(g.Where)+len(inner.Where))
+		for name, expression := range g.Where {
+			merged.Where[name] = expression
+		}
+		for name, expression := range inner.Where {
+			merged.Where[name] = expression
+		}
+	}
+	return merged
+}
+
+// ToMap 转换为 Router.Group 使用的属性映射
+func (g GroupAttributes) ToMap() map[string]interface{} {
+	attributes := make(map[string]interface{})
+	if g.Prefix != "" {
+		attributes["prefix"] = g.Prefix
+	}
+	if g.Name != "" {
+		attributes["as"] = g.Name
+	}
+	if g.Namespace != "" {
+		attributes["namespace"] = g.Namespace
+	}
+	if g.Domain != "" {
+		attributes["domain"] = g.Domain
+	}
+	if len(g.Middleware) > 0 {
+		attributes["middleware"] = append([]string(nil), g.Middleware...)
+	}
+	if len(g.WithoutMiddleware) > 0 {
+		attributes["excluded_middleware"] = append([]string(nil), g.WithoutMiddleware...)
+	}
+	if len(g.Where) > 0 {
+		where := make(map[string]string, len(g.Where))
+		for name, expression := range g.Where {
+			where[name] = expression
+		}
+		attributes["where"] = where
+	}
+	return attributes
+}
+
+// GroupAttributesFrom 解析 Router.Group 的属性映射
+//
+// 支持的键：prefix、as（或 name）、namespace、domain、middleware、excluded_middleware（或 without_middleware）、where。
+// 中间件可以是字符串或字符串切片，where 可以是 map[string]string 或 map[string]interface{}。
+func GroupAttributesFrom(attributes map[string]interface{}) GroupAttributes {
+	g := GroupAttributes{
+		Prefix:    attributeString(attributes["prefix"]),
+		Namespace: attributeString(attributes["namespace"]),
+		Domain:    attributeString(attributes["domain"]),
+	}
+	if name, ok := attributes["as"]; ok {
+		g.Name = attributeString(name)
+	} else {
+		g.Name = attributeString(attributes["name"])
+	}
+	g.Middleware = attributeStrings(attributes["middleware"])
+	g.WithoutMiddleware = append(attributeStrings(attributes["excluded_middleware"]), attributeStrings(attributes["without_middleware"])...)
+	switch where := attributes["where"].(type) {
+	case map[string]string:
+		g.Where = make(map[string]string, len(where))
+		for name, expression := range where {
+			g.Where[name] = expression
+		}
+	case map[string]interface{}:
+		g.Where = make(map[string]string, len(where))
+		for name, expression := range where {
+			g.Where[name] = attributeString(expression)
+		}
+	}
+	return g
+}
+
+// GroupStack 分组属性栈，供 Router 实现在嵌套的 Group 回调中合并属性并应用到路由
+//
+//	func (r *router) Group(attributes map[string]interface{}, callback func(routing.Router)) routing.Router {
+//		r.groups.Group(routing.GroupAttributesFrom(attributes), func() { callback(r) })
+//		return r
+//	}
+//
+//	func (r *router) Get(uri string, action interface{}) routing.Route {
+//		return r.groups.Apply(newRoute([]string{"GET", "HEAD"}, uri, action))
+//	}
+type GroupStack struct {
+	mu    sync.Mutex
+	stack []GroupAttributes
+}
+
+// Push 压入分组，属性与当前分组合并
+func (s *GroupStack) Push(attributes GroupAttributes) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stack = append(s.stack, s.current().Merge(attributes))
+}
+
+// Pop 弹出最内层分组
+func (s *GroupStack) Pop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.stack) > 0 {
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+}
+
+// Group 在分组中执行 callback，返回前弹出分组（callback panic 时也会弹出）
+func (s *GroupStack) Group(attributes GroupAttributes, callback func()) {
+	s.Push(attributes)
+	defer s.Pop()
+	callback()
+}
+
+// Current 当前合并后的分组属性，不在分组中时为零值
+func (s *GroupStack) Current() GroupAttributes {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current()
+}
+
+// HasGroup 是否在分组中
+func (s *GroupStack) HasGroup() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.stack) > 0
+}
+
+// Apply 把当前分组属性应用到路由：URI 加前缀，名称加前缀，分组中间件在路由中间件之前，
+// 路由自己的参数约束优先，路由没有域名时使用分组域名
+func (s *GroupStack) Apply(route Route) Route {
+	group := s.Current()
+	route.SetPrefix(joinPrefix(group.Prefix, route.GetPrefix()))
+	route.SetURI(joinURI(group.Prefix, route.GetURI()))
+	if route.GetName() != "" || group.Name != "" {
+		route.SetName(group.Name + route.GetName())
+	}
+	if len(group.Middleware) > 0 {
+		route.SetMiddleware(append(append([]string(nil), group.Middleware...), route.GetMiddleware()...))
+	}
+	if len(group.WithoutMiddleware) > 0 {
+		route.WithoutMiddleware(group.WithoutMiddleware...)
+	}
+	if group.Domain != "" {
+		route.Domain(group.Domain)
+	}
+	if len(group.Where) > 0 {
+		where := make(map[string]string, len(group.Where))
+		for name, expression := range group.Where {
+			where[name] = expression
+		}
+		for name, expression := range route.GetWhere() {
+			where[name] = expression
+		}
+		route.SetWhere(where)
+	}
+	return route
+}
+
+// current 当前分组，调用方持有锁
+func (s *GroupStack) current() GroupAttributes {
+	if len(s.stack) == 0 {
+		return GroupAttributes{}
+	}
+	return s.stack[len(s.stack)-1]
+}
+
+// RouteRegistrar 分组属性的流式构建器，对应 Laravel 的 Illuminate\Routing\RouteRegistrar
+//
+// Router 的 Prefix、Middleware、Name、Domain、Where、Namespace 返回 RouteRegistrar，
+// 链式设置属性后调用 Group 注册一组路由，或直接注册单条路由。属性按 GroupAttributes.Merge 的规则
+// 与外层分组合并，因此嵌套的分组得到正确的前缀、名称和中间件。
+//
+// 使用示例：
+//
+//	router.Prefix("/api").Middleware("auth").Name("api.").Domain("api.example.com").Group(func(r routing.Router) {
+//		r.Get("/user", userController.Show).Name("user") // api.user，/api/user
+//		r.Prefix("/admin").Name("admin.").Middleware("can:admin").Group(func(r routing.Router) {
+//			r.Get("/stats", statsController.Index).Name("stats") // api.admin.stats，/api/admin/stats，auth、can:admin
+//		})
+//	})
+type RouteRegistrar struct {
+	router     Router
+	attributes GroupAttributes
+}
+
+// NewRouteRegistrar 创建绑定到 router 的构建器，供 Router 实现的 Prefix 等方法使用
+func NewRouteRegistrar(router Router) *RouteRegistrar {
+	return &RouteRegistrar{router: router}
+}
+
+// Prefix 设置 URI 前缀，多次调用时连接
+func (r *RouteRegistrar) Prefix(prefix string) *RouteRegistrar {
+	r.attributes.Prefix = joinPrefix(r.attributes.Prefix, prefix)
+	return r
+}
+
+// Name 设置路由名称前缀，多次调用时拼接
+func (r *RouteRegistrar) Name(name string) *RouteRegistrar {
+	r.attributes.Name += name
+	return r
+}
+
+// As Name 的别名
+func (r *RouteRegistrar) As(name string) *RouteRegistrar {
+	return r.Name(name)
+}
+
+// Namespace 设置控制器命名空间
+func (r *RouteRegistrar) Namespace(namespace string) *RouteRegistrar {
+	r.attributes.Namespace = joinNamespace(r.attributes.Namespace, namespace)
+	return r
+}
+
+// Domain 设置域名
+func (r *RouteRegistrar) Domain(domain string) *RouteRegistrar {
+	r.attributes.Domain = domain
+	return r
+}
+
+// Middleware 追加中间件
+func (r *RouteRegistrar) Middleware(middleware ...string) *RouteRegistrar {
+	r.attributes.Middleware = append(r.attributes.Middleware, middleware...)
+	return r
+}
+
+// WithoutMiddleware 追加排除的中间件
+func (r *RouteRegistrar) WithoutMiddleware(middleware ...string) *RouteRegistrar {
+	r.attributes.WithoutMiddleware = append(r.attributes.WithoutMiddleware, middleware...)
+	return r
+}
+
+// Where 添加参数约束
+func (r *RouteRegistrar) Where(name string, expression string) *RouteRegistrar {
+	if r.attributes.Where == nil {
+		r.attributes.Where = make(map[string]string)
+	}
+	r.attributes.Where[name] = expression
+	return r
+}
+
+// Attributes 已设置的分组属性
+func (r *RouteRegistrar) Attributes() GroupAttributes {
+	return GroupAttributes{}.Merge(r.attributes)
+}
+
+// Group 以已设置的属性注册一组路由
+func (r *RouteRegistrar) Group(callback func(Router)) Router {
+	return r.router.Group(r.attributes.ToMap(), callback)
+}
+
+// Get 以已设置的属性注册 GET 路由
+func (r *RouteRegistrar) Get(uri string, action interface{}) Route {
+	return r.register(func(router Router) Route { return router.Get(uri, action) })
+}
+
+// Post 以已设置的属性注册 POST 路由
+func (r *RouteRegistrar) Post(uri string, action interface{}) Route {
+	return r.register(func(router Router) Route { return router.Post(uri, action) })
+}
+
+// Put 以已设置的属性注册 PUT 路由
+func (r *RouteRegistrar) Put(uri string, action interface{}) Route {
+	return r.register(func(router Router) Route { return router.Put(uri, action) })
+}
+
+// Patch 以已设置的属性注册 PATCH 路由
+func (r *RouteRegistrar) Patch(uri string, action interface{}) Route {
+	return r.register(func(router Router) Route { return router.Patch(uri, action) })
+}
+
+// Delete 以已设置的属性注册 DELETE 路由
+func (r *RouteRegistrar) Delete(uri string, action interface{}) Route {
+	return r.register(func(router Router) Route { return router.Delete(uri, action) })
+}
+
+// Options 以已设置的属性注册 OPTIONS 路由
+func (r *RouteRegistrar) Options(uri string, action interface{}) Route {
+	return r.register(func(router Router) Route { return router.Options(uri, action) })
+}
+
+// Any 以已设置的属性注册任意方法路由
+func (r *RouteRegistrar) Any(uri string, action interface{}) Route {
+	return r.register(func(router Router) Route { return router.Any(uri, action) })
+}
+
+// Match 以已设置的属性注册指定方法路由
+func (r *RouteRegistrar) Match(methods []string, uri string, action interface{}) Route {
+	return r.register(func(router Router) Route { return router.Match(methods, uri, action) })
+}
+
+// register 在只包含这一条路由的分组中注册路由
+func (r *RouteRegistrar) register(add func(Router) Route) Route {
+	var route Route
+	r.Group(func(router Router) { route = add(router) })
+	return route
+}
+
+// joinPrefix 连接前缀，结果去掉首尾的 "/"，与 Laravel 的前缀格式一致
+func joinPrefix(outer, inner string) string {
+	outer, inner = strings.Trim(outer, "/"), strings.Trim(inner, "/")
+	switch {
+	case outer == "":
+		return inner
+	case inner == "":
+		return outer
+	}
+	return outer + "/" + inner
+}
+
+// joinNamespace 连接命名空间，inner 以 "\" 开头时替换 outer
+func joinNamespace(outer, inner string) string {
+	switch {
+	case inner == "":
+		return outer
+	case strings.HasPrefix(inner, `\`) || outer == "":
+		return strings.TrimPrefix(inner, `\`)
+	}
+	return strings.TrimSuffix(outer, `\`) + `\` + inner
+}
+
+// attributeString 属性值转换为字符串
+func attributeString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// attributeStrings 属性值转换为字符串切片，支持字符串、[]string 和 []interface{}
+func attributeStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []string:
+		return append([]string(nil), v...)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, attributeString(item))
+		}
+		return items
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/pipeline"
+)
+
+// ThroughMiddleware 让请求依次通过中间件后到达 destination，HTTP 内核和路由器用它执行中间件栈
+//
+// middleware 为中间件名称，可以带参数，例如 "auth"、"throttle:60,1"，名称从容器解析。
+// 解析出的对象需要实现 Middleware，或者 Handle 方法额外接收字符串参数：
+//
+//	func (m *Throttle) Handle(request routing.RequestInterface, next func(routing.RequestInterface) routing.ResponseInterface, limit, decay string) routing.ResponseInterface
+func ThroughMiddleware(c container.Container, request RequestInterface, middleware []string, destination func(RequestInterface) ResponseInterface) (ResponseInterface, error) {
+	pipes := make([]interface{}, len(middleware))
+	for i, name := range middleware {
+		pipes[i] = name
+	}
+	ctx := request.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result, err := pipeline.New(c).WithContext(ctx).Send(request).Through(pipes...).
+		Then(func(ctx context.Context, passable interface{}) (interface{}, error) {
+			req, ok := passable.(RequestInterface)
+			if !ok {
+				return nil, fmt.Errorf("routing: middleware passed %T instead of a request", passable)
+			}
+			return destination(req), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	response, _ := result.(ResponseInterface)
+	return response, nil
+}
@@ -0,0 +1,100 @@
+package routing
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedProxies 受信任的反向代理，对应 Laravel 的 TrustProxies 中间件配置
+//
+// 只有连接来自受信任代理时才采用 X-Forwarded-Proto、X-Forwarded-Host 请求头，
+// 否则客户端可以伪造这些请求头让应用生成错误的 URL。
+type TrustedProxies struct {
+	all      bool
+	networks []*net.IPNet
+}
+
+// NewTrustedProxies 创建受信任代理列表，proxies 为 IP 或 CIDR，"*" 信任所有连接（应用只能经由代理访问时使用）
+func NewTrustedProxies(proxies ...string) (*TrustedProxies, error) {
+	t := &TrustedProxies{}
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		switch {
+		case proxy == "*" || proxy == "**":
+			t.all = true
+		case strings.Contains(proxy, "/"):
+			_, network, err := net.ParseCIDR(proxy)
+			if err != nil {
+				return nil, err
+			}
+			t.networks = append(t.networks, network)
+		default:
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, &net.ParseError{Type: "IP address", Text: proxy}
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			t.networks = append(t.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		}
+	}
+	return t, nil
+}
+
+// Trusts 请求的连接是否来自受信任代理，nil 不信任任何代理
+func (t *TrustedProxies) Trusts(r *http.Request) bool {
+	if t == nil {
+		return false
+	}
+	if t.all {
+		return true
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range t.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scheme 请求的协议：连接为 TLS 时为 https，来自受信任代理时采用 X-Forwarded-Proto，否则为 http
+func (t *TrustedProxies) Scheme(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	if t.Trusts(r) {
+		// 多级代理时 X-Forwarded-Proto 为逗号分隔的列表，第一项为客户端使用的协议
+		proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ",")
+		if proto = strings.ToLower(strings.TrimSpace(proto)); proto == "https" || proto == "http" {
+			return proto
+		}
+	}
+	return "http"
+}
+
+// Host 请求的主机：来自受信任代理时采用 X-Forwarded-Host，否则为 Host 请求头
+func (t *TrustedProxies) Host(r *http.Request) string {
+	if t.Trusts(r) {
+		host, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Host"), ",")
+		if host = strings.TrimSpace(host); host != "" {
+			return host
+		}
+	}
+	return r.Host
+}
+
+// IsSecure 请求是否经由 HTTPS
+func (t *TrustedProxies) IsSecure(r *http.Request) bool {
+	return t.Scheme(r) == "https"
+}
@@ -0,0 +1,177 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+
+	"github.com/cnote0/laraveldoc/session"
+)
+
+// Redirect RedirectResponse 的实现，对应 Laravel 的 Illuminate\Http\RedirectResponse
+//
+// 闪存数据写入请求 context 中的会话（session.Manager.Middleware），没有启用会话的请求中
+// With、WithInput 和 WithErrors 不做处理。Back 使用会话记录的上一个 URL，
+// 没有时依次回退到 Referer 请求头和 "/"。
+//
+// 使用示例：
+//
+//	func (c *ProfileController) Update(w http.ResponseWriter, r *http.Request) {
+//		request := routing.NewRequest(r)
+//		if request.GetInput("email", "") == "" {
+//			redirect := routing.NewRedirect(request, "", 0)
+//			redirect.Back().WithInput(nil).WithErrors(map[string]string{"email": "The email field is required."})
+//			redirect.WriteTo(w)
+//			return
+//		}
+//		routing.NewRedirect(request, "/profile", 0).With("status", "Profile updated!").WriteTo(w)
+//	}
+type Redirect struct {
+	*Response
+	request RequestInterface
+	target  string
+}
+
+var _ RedirectResponse = (*Redirect)(nil)
+
+// NewRedirect 创建跳转到 url 的重定向响应，status 为 0 时使用 302
+//
+// request 提供会话和 Back、Refresh 使用的 URL，可以为 nil。
+func NewRedirect(request RequestInterface, url string, status int) *Redirect {
+	if status == 0 {
+		status = http.StatusFound
+	}
+	r := &Redirect{Response: NewResponse("", status), request: request}
+	r.SetTargetUrl(url)
+	return r
+}
+
+// GetTargetUrl 获取目标 URL
+func (r *Redirect) GetTargetUrl() string {
+	return r.target
+}
+
+// SetTargetUrl 设置目标 URL，同时设置 Location 响应头和跳转页面内容
+func (r *Redirect) SetTargetUrl(url string) RedirectResponse {
+	r.target = url
+	escaped := html.EscapeString(url)
+	r.SetHeader("Location", url)
+	r.SetHeader("Content-Type", "text/html; charset=utf-8")
+	r.SetContent(fmt.Sprintf(`<!DOCTYPE html>
+<html>
+    <head>
+        <meta charset="UTF-8" />
+        <meta http-equiv="refresh" content="0;url='%s'" />
+        <title>Redirecting to %s</title>
+    </head>
+    <body>
+        Redirecting to <a href="%s">%s</a>.
+    </body>
+</html>`, escaped, escaped, escaped, escaped))
+	return r
+}
+
+// With 把数据闪存到会话，下一个请求通过 session.Store.Get 读取
+func (r *Redirect) With(key string, value interface{}) RedirectResponse {
+	r.session().Flash(key, value)
+	return r
+}
+
+// WithInput 把输入闪存到会话，下一个请求通过 Request.Old 读取；input 为 nil 时使用当前请求的全部输入
+func (r *Redirect) WithInput(input map[string]interface{}) RedirectResponse {
+	if input == nil && r.request != nil {
+		input = r.request.All()
+	}
+	r.session().FlashInput(input)
+	return r
+}
+
+// WithErrors 把错误信息合并到会话闪存的 ErrorBag，下一个请求通过 session.Store.Errors 读取
+//
+// errors 可以是 session.ErrorBag、map[string][]string、map[string]string、
+// 实现了 ErrorMessages 的值（例如 *exceptions.ValidationError）、字符串或 error，
+// 后两者记为 "default" 字段的错误信息。
+func (r *Redirect) WithErrors(errors interface{}) RedirectResponse {
+	r.session().FlashErrors(errorBag(errors))
+	return r
+}
+
+// WithCookies 添加 Cookie
+func (r *Redirect) WithCookies(cookies []Cookie) RedirectResponse {
+	for _, cookie := range cookies {
+		r.WithCookie(cookie)
+	}
+	return r
+}
+
+// Away 跳转到外部 URL
+func (r *Redirect) Away(url string) RedirectResponse {
+	return r.SetTargetUrl(url)
+}
+
+// Back 跳转到上一个 URL
+func (r *Redirect) Back() RedirectResponse {
+	fallback := "/"
+	if r.request != nil {
+		if referer := r.request.GetHeader("Referer"); referer != "" {
+			fallback = referer
+		}
+	}
+	return r.SetTargetUrl(r.session().PreviousURL(fallback))
+}
+
+// Home 跳转到首页
+func (r *Redirect) Home() RedirectResponse {
+	return r.SetTargetUrl("/")
+}
+
+// Refresh 跳转到当前请求的 URI
+func (r *Redirect) Refresh() RedirectResponse {
+	if r.request == nil {
+		return r.Home()
+	}
+	return r.SetTargetUrl(r.request.GetURI())
+}
+
+// session 请求的会话，没有时返回 nil（nil *session.Store 的写入被忽略）
+func (r *Redirect) session() *session.Store {
+	if r.request == nil {
+		return nil
+	}
+	return session.FromContext(r.request.Context())
+}
+
+// ErrorMessages 提供字段到错误信息映射的错误，WithErrors 识别该接口
+type ErrorMessages interface {
+	ErrorMessages() map[string][]string
+}
+
+// errorBag 把 WithErrors 的参数转换为 ErrorBag
+func errorBag(value interface{}) session.ErrorBag {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case session.ErrorBag:
+		return v
+	case map[string][]string:
+		return session.ErrorBag(v)
+	case map[string]string:
+		bag := make(session.ErrorBag, len(v))
+		for field, message := range v {
+			bag.Add(field, message)
+		}
+		return bag
+	case ErrorMessages:
+		return session.ErrorBag(v.ErrorMessages())
+	case string:
+		return session.ErrorBag{"default": {v}}
+	case error:
+		var messages ErrorMessages
+		if errors.As(v, &messages) {
+			return session.ErrorBag(messages.ErrorMessages())
+		}
+		return session.ErrorBag{"default": {v.Error()}}
+	}
+	return session.ErrorBag{"default": {fmt.Sprint(value)}}
+}
@@ -0,0 +1,306 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cnote0/laraveldoc/session"
+	"github.com/cnote0/laraveldoc/support/arr"
+)
+
+// DefaultMultipartMemory 解析 multipart 表单时保存在内存中的最大字节数，超出部分写入临时文件
+const DefaultMultipartMemory = 32 << 20
+
+// UploadedFileFactory 把 multipart 文件包装为 UploadedFile
+type UploadedFileFactory func(header *multipart.FileHeader) UploadedFile
+
+// uploadedFileFactory Request.File 使用的工厂，由 storage 包在 init 中注册
+var uploadedFileFactory atomic.Pointer[UploadedFileFactory]
+
+// SetUploadedFileFactory 设置 Request.File 包装上传文件使用的工厂
+//
+// 导入 storage 包时自动设置为 storage.NewUploadedFile。
+func SetUploadedFileFactory(factory UploadedFileFactory) {
+	uploadedFileFactory.Store(&factory)
+}
+
+// Request 基于 *http.Request 的 RequestInterface 实现
+//
+// 输入由查询参数和请求体合并而成，同名时请求体优先；请求体按 Content-Type 解析为
+// JSON、URL 编码表单或 multipart 表单，在第一次读取输入时才解析，只读取路由参数或请求头的动作
+// 不会读取请求体。解析失败时输入中不含请求体，错误通过 ParseError 获取；
+// multipart 表单的内存上限取自 context 中的 BodyLimits（BodyLimits.Middleware 放入）。
+// 会话（session.Manager.Middleware 放入 context）中的旧输入通过 Old 读取。
+//
+// 使用示例：
+//
+//	func (c *ProfileController) Update(w http.ResponseWriter, r *http.Request) {
+//		request := routing.NewRequest(r)
+//		name := request.GetInput("user.name", "")
+//		if request.HasFile("avatar") {
+//			path, err := request.File("avatar").Store("avatars", "")
+//		}
+//	}
+type Request struct {
+	request  *http.Request
+	route    Route
+	resolver func() Route
+
+	parse *requestInput
+}
+
+// requestInput 解析后的输入，WithContext 复制的请求共享同一份
+type requestInput struct {
+	once  sync.Once
+	input map[string]interface{}
+	files map[string][]*multipart.FileHeader
+	err   error
+}
+
+var _ RequestInterface = (*Request)(nil)
+
+// NewRequest 包装 *http.Request
+func NewRequest(r *http.Request) *Request {
+	return &Request{request: r, parse: &requestInput{}}
+}
+
+// HTTPRequest 获取底层的 *http.Request
+func (r *Request) HTTPRequest() *http.Request {
+	return r.request
+}
+
+// GetMethod 获取 HTTP 方法
+func (r *Request) GetMethod() string {
+	return r.request.Method
+}
+
+// GetURI 获取包含查询字符串的请求 URI
+func (r *Request) GetURI() string {
+	return r.request.URL.RequestURI()
+}
+
+// GetPath 获取路径
+func (r *Request) GetPath() string {
+	return r.request.URL.Path
+}
+
+// GetQuery 获取查询字符串
+func (r *Request) GetQuery() string {
+	return r.request.URL.RawQuery
+}
+
+// GetHeaders 获取请求头
+func (r *Request) GetHeaders() map[string][]string {
+	return r.request.Header
+}
+
+// GetHeader 获取指定请求头
+func (r *Request) GetHeader(name string) string {
+	return r.request.Header.Get(name)
+}
+
+// HasHeader 是否有指定请求头
+func (r *Request) HasHeader(name string) bool {
+	_, ok := r.request.Header[http.CanonicalHeaderKey(name)]
+	return ok
+}
+
+// GetInput 获取输入，支持点号路径，不存在时返回 defaultValue
+func (r *Request) GetInput(key string, defaultValue interface{}) interface{} {
+	return arr.Get(r.input().input, key, defaultValue)
+}
+
+// All 获取所有输入的副本
+func (r *Request) All() map[string]interface{} {
+	input := r.input().input
+	all := make(map[string]interface{}, len(input))
+	for key, value := range input {
+		all[key] = value
+	}
+	return all
+}
+
+// Has 输入是否存在
+func (r *Request) Has(key string) bool {
+	return arr.Has(r.input().input, key)
+}
+
+// Old 获取会话中闪存的旧输入，支持点号路径，没有会话或旧输入时返回 defaultValue
+func (r *Request) Old(key string, defaultValue interface{}) interface{} {
+	return session.FromContext(r.request.Context()).OldInput(key, defaultValue)
+}
+
+// Session 获取请求的会话，没有启用会话时返回 nil
+func (r *Request) Session() *session.Store {
+	return session.FromContext(r.request.Context())
+}
+
+// File 获取上传文件，没有该文件或没有设置 UploadedFileFactory 时返回 nil
+func (r *Request) File(key string) UploadedFile {
+	headers := r.input().files[key]
+	factory := uploadedFileFactory.Load()
+	if len(headers) == 0 || factory == nil {
+		return nil
+	}
+	return (*factory)(headers[0])
+}
+
+// ParseError 解析请求体的错误，请求体超过 BodyLimits.MaxBodySize 时包装 ErrBodyTooLarge；
+// 尚未解析时先解析
+func (r *Request) ParseError() error {
+	return r.input().err
+}
+
+// HasFile 是否有上传文件
+func (r *Request) HasFile(key string) bool {
+	return len(r.input().files[key]) > 0
+}
+
+// Cookie 获取 Cookie
+func (r *Request) Cookie(name string, defaultValue string) string {
+	cookie, err := r.request.Cookie(name)
+	if err != nil {
+		return defaultValue
+	}
+	return cookie.Value
+}
+
+// GetCookies 获取所有 Cookie，同名时取第一个
+func (r *Request) GetCookies() map[string]string {
+	cookies := make(map[string]string)
+	for _, cookie := range r.request.Cookies() {
+		if _, ok := cookies[cookie.Name]; !ok {
+			cookies[cookie.Name] = cookie.Value
+		}
+	}
+	return cookies
+}
+
+// IP 获取客户端 IP，即连接的远端地址
+func (r *Request) IP() string {
+	host, _, err := net.SplitHostPort(r.request.RemoteAddr)
+	if err != nil {
+		return r.request.RemoteAddr
+	}
+	return host
+}
+
+// UserAgent 获取用户代理
+func (r *Request) UserAgent() string {
+	return r.request.UserAgent()
+}
+
+// GetRoute 获取匹配的路由，未设置时使用路由解析器
+func (r *Request) GetRoute() Route {
+	if r.route == nil && r.resolver != nil {
+		return r.resolver()
+	}
+	return r.route
+}
+
+// SetRoute 设置匹配的路由
+func (r *Request) SetRoute(route Route) {
+	r.route = route
+}
+
+// GetRouteResolver 获取路由解析器
+func (r *Request) GetRouteResolver() func() Route {
+	return r.resolver
+}
+
+// SetRouteResolver 设置路由解析器
+func (r *Request) SetRouteResolver(resolver func() Route) {
+	r.resolver = resolver
+}
+
+// Context 获取请求的 context
+func (r *Request) Context() context.Context {
+	return r.request.Context()
+}
+
+// WithContext 返回使用 ctx 的请求副本，副本与原请求共享解析后的输入
+func (r *Request) WithContext(ctx context.Context) RequestInterface {
+	copied := *r
+	copied.request = r.request.WithContext(ctx)
+	return &copied
+}
+
+// input 第一次调用时解析查询参数和请求体
+func (r *Request) input() *requestInput {
+	r.parse.once.Do(func() {
+		input := make(map[string]interface{})
+		mergeValues(input, r.request.URL.Query())
+		r.parseBody(input)
+		r.parse.input = input
+	})
+	return r.parse
+}
+
+// parseBody 按 Content-Type 解析请求体，解析失败时忽略请求体并记录错误
+func (r *Request) parseBody(input map[string]interface{}) {
+	if r.request.Body == nil || r.request.Body == http.NoBody {
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.request.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var body map[string]interface{}
+		err := json.NewDecoder(r.request.Body).Decode(&body)
+		if err != nil && !errors.Is(err, io.EOF) {
+			r.parse.err = bodyError(err)
+			return
+		}
+		for key, value := range body {
+			input[key] = value
+		}
+	case mediaType == "multipart/form-data":
+		limits := BodyLimitsFromContext(r.request.Context())
+		if err := r.request.ParseMultipartForm(limits.multipartMemory()); err != nil {
+			r.parse.err = bodyError(err)
+			return
+		}
+		mergeValues(input, r.request.MultipartForm.Value)
+		r.parse.files = r.request.MultipartForm.File
+	case mediaType == "application/x-www-form-urlencoded":
+		if err := r.request.ParseForm(); err != nil {
+			r.parse.err = bodyError(err)
+			return
+		}
+		mergeValues(input, r.request.PostForm)
+	}
+}
+
+// bodyError 请求体超出限制时包装为 ErrBodyTooLarge
+func bodyError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return fmt.Errorf("%w: %w", ErrBodyTooLarge, err)
+	}
+	return err
+}
+
+// mergeValues 合并查询参数或表单值，单个值保存为字符串，多个值保存为 []interface{}
+func mergeValues(input map[string]interface{}, values map[string][]string) {
+	for key, list := range values {
+		name := strings.TrimSuffix(key, "[]")
+		if len(list) == 1 && name == key {
+			input[key] = list[0]
+			continue
+		}
+		items := make([]interface{}, len(list))
+		for i, value := range list {
+			items[i] = value
+		}
+		input[name] = items
+	}
+}
@@ -0,0 +1,135 @@
+package routing
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// resourceActions 资源控制器的动作，按 Laravel 的注册顺序
+var resourceActions = []string{"index", "create", "store", "show", "edit", "update", "destroy"}
+
+// ResourceOptions 资源路由选项，对应 Route::resource 的 only、except、parameters、names
+type ResourceOptions struct {
+	// Only 只注册这些动作
+	Only []string
+
+	// Except 不注册这些动作
+	Except []string
+
+	// Parameters 资源名到路由参数名的映射，默认为资源名的单数形式
+	Parameters map[string]string
+
+	// Names 动作到路由名称的映射，默认为 "资源名.动作"
+	Names map[string]string
+
+	// API 不注册 create 和 edit，对应 Route::apiResource
+	API bool
+}
+
+// ResourceOptionsFrom 解析 Router.Resource 的选项映射
+//
+// 支持的键：only、except（字符串或字符串切片）、parameters、names（map[string]string 或 map[string]interface{}）。
+func ResourceOptionsFrom(options map[string]interface{}) ResourceOptions {
+	return ResourceOptions{
+		Only:       attributeStrings(options["only"]),
+		Except:     attributeStrings(options["except"]),
+		Parameters: attributeMap(options["parameters"]),
+		Names:      attributeMap(options["names"]),
+	}
+}
+
+// ResourceRoutes 资源控制器的路由声明，Router.Resource 和 Router.APIResource 的实现可以直接交给 RegisterRoutes
+//
+// 参数名为资源名的单数形式，嵌套资源用点号分隔，动作为 "控制器@方法"（方法名首字母大写）：
+//
+//	routing.ResourceRoutes("photos.comments", "CommentController", routing.ResourceOptions{})
+//	// GET    /photos/{photo}/comments                  photos.comments.index   CommentController@Index
+//	// GET    /photos/{photo}/comments/{comment}        photos.comments.show    CommentController@Show
+//	// ...
+func ResourceRoutes(name, controller string, options ResourceOptions) []RouteDef {
+	segments := strings.Split(strings.Trim(name, "/"), ".")
+	prefix := ""
+	for _, segment := range segments[:len(segments)-1] {
+		prefix += "/" + segment + "/{" + resourceParameter(segment, options) + "}"
+	}
+	resource := segments[len(segments)-1]
+	base := prefix + "/" + resource
+	item := base + "/{" + resourceParameter(resource, options) + "}"
+	routeName := strings.Join(segments, ".")
+
+	routes := map[string]struct {
+		uri     string
+		methods []string
+	}{
+		"index":   {base, []string{http.MethodGet}},
+		"create":  {base + "/create", []string{http.MethodGet}},
+		"store":   {base, []string{http.MethodPost}},
+		"show":    {item, []string{http.MethodGet}},
+		"edit":    {item + "/edit", []string{http.MethodGet}},
+		"update":  {item, []string{http.MethodPut, http.MethodPatch}},
+		"destroy": {item, []string{http.MethodDelete}},
+	}
+	var defs []RouteDef
+	for _, action := range resourceActions {
+		if !includesAction(action, options) {
+			continue
+		}
+		defName := routeName + "." + action
+		if custom, ok := options.Names[action]; ok {
+			defName = custom
+		}
+		defs = append(defs, RouteDef{
+			Methods: routes[action].methods,
+			URI:     routes[action].uri,
+			Action:  controller + "@" + str.Ucfirst(action),
+			Name:    defName,
+		})
+	}
+	return defs
+}
+
+// resourceParameter 资源的路由参数名
+func resourceParameter(resource string, options ResourceOptions) string {
+	if parameter, ok := options.Parameters[resource]; ok {
+		return parameter
+	}
+	return str.Snake(str.Singular(resource))
+}
+
+// includesAction 是否注册动作
+func includesAction(action string, options ResourceOptions) bool {
+	if options.API && (action == "create" || action == "edit") {
+		return false
+	}
+	if len(options.Only) > 0 && !containsString(options.Only, action) {
+		return false
+	}
+	return !containsString(options.Except, action)
+}
+
+// containsString 切片是否包含字符串
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeMap 解析字符串映射
+func attributeMap(value interface{}) map[string]string {
+	switch m := value.(type) {
+	case map[string]string:
+		return m
+	case map[string]interface{}:
+		result := make(map[string]string, len(m))
+		for key, v := range m {
+			result[key] = attributeString(v)
+		}
+		return result
+	}
+	return nil
+}
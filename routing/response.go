@@ -0,0 +1,248 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrNoResponseWriter 响应没有关联 http.ResponseWriter
+var ErrNoResponseWriter = errors.New("routing: response has no writer, call SetWriter or WriteTo")
+
+// ErrResponseSent 响应头已经发送
+var ErrResponseSent = errors.New("routing: response headers already sent")
+
+// Response 基于 net/http 的 ResponseInterface 实现
+//
+// 响应在 Send 时写入 SetWriter 关联的 http.ResponseWriter，WriteTo 关联并发送。
+//
+// 使用示例：
+//
+//	response := routing.NewResponse("Hello", http.StatusOK).
+//		SetHeader("Content-Type", "text/plain; charset=utf-8")
+//	err := response.WriteTo(w)
+type Response struct {
+	status  int
+	header  http.Header
+	content string
+	cookies []*http.Cookie
+
+	writer      http.ResponseWriter
+	headersSent bool
+}
+
+var _ ResponseInterface = (*Response)(nil)
+
+// NewResponse 创建响应，status 为 0 时使用 200
+func NewResponse(content string, status int) *Response {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &Response{status: status, header: make(http.Header), content: content}
+}
+
+// GetContent 获取内容
+func (r *Response) GetContent() string {
+	return r.content
+}
+
+// SetContent 设置内容
+func (r *Response) SetContent(content string) ResponseInterface {
+	r.content = content
+	return r
+}
+
+// GetStatusCode 获取状态码
+func (r *Response) GetStatusCode() int {
+	return r.status
+}
+
+// SetStatusCode 设置状态码
+func (r *Response) SetStatusCode(code int) ResponseInterface {
+	r.status = code
+	return r
+}
+
+// GetHeaders 获取响应头
+func (r *Response) GetHeaders() map[string][]string {
+	return r.header
+}
+
+// SetHeader 设置响应头
+func (r *Response) SetHeader(name string, value string) ResponseInterface {
+	r.header.Set(name, value)
+	return r
+}
+
+// AddHeader 添加响应头
+func (r *Response) AddHeader(name string, value string) ResponseInterface {
+	r.header.Add(name, value)
+	return r
+}
+
+// RemoveHeader 移除响应头
+func (r *Response) RemoveHeader(name string) ResponseInterface {
+	r.header.Del(name)
+	return r
+}
+
+// WithCookie 添加 Cookie，同名同路径同域名的 Cookie 会被替换
+func (r *Response) WithCookie(cookie Cookie) ResponseInterface {
+	r.setCookie(HTTPCookie(cookie))
+	return r
+}
+
+// WithoutCookie 添加使浏览器删除 Cookie 的过期 Cookie
+func (r *Response) WithoutCookie(name string) ResponseInterface {
+	r.setCookie(&http.Cookie{Name: name, Path: "/", MaxAge: -1})
+	return r
+}
+
+// Cookies 获取响应的 Cookie
+func (r *Response) Cookies() []*http.Cookie {
+	return r.cookies
+}
+
+// SetWriter 关联发送响应的 http.ResponseWriter
+func (r *Response) SetWriter(w http.ResponseWriter) *Response {
+	r.writer = w
+	return r
+}
+
+// WriteTo 关联 w 并发送响应
+func (r *Response) WriteTo(w http.ResponseWriter) error {
+	r.writer = w
+	return r.Send()
+}
+
+// Send 发送响应头和内容
+func (r *Response) Send() error {
+	if err := r.SendHeaders(); err != nil {
+		return err
+	}
+	return r.SendContent()
+}
+
+// SendContent 发送内容
+func (r *Response) SendContent() error {
+	if r.writer == nil {
+		return ErrNoResponseWriter
+	}
+	if !r.headersSent {
+		if err := r.SendHeaders(); err != nil {
+			return err
+		}
+	}
+	_, err := r.writer.Write([]byte(r.content))
+	return err
+}
+
+// SendHeaders 发送状态码、响应头和 Cookie，重复调用返回 ErrResponseSent
+func (r *Response) SendHeaders() error {
+	if r.writer == nil {
+		return ErrNoResponseWriter
+	}
+	if r.headersSent {
+		return ErrResponseSent
+	}
+	header := r.writer.Header()
+	for name, values := range r.header {
+		header[name] = append([]string(nil), values...)
+	}
+	for _, cookie := range r.cookies {
+		http.SetCookie(r.writer, cookie)
+	}
+	r.headersSent = true
+	r.writer.WriteHeader(r.status)
+	return nil
+}
+
+// setCookie 添加 Cookie，替换名称、路径和域名都相同的 Cookie
+func (r *Response) setCookie(cookie *http.Cookie) {
+	for i, existing := range r.cookies {
+		if existing.Name == cookie.Name && existing.Path == cookie.Path && existing.Domain == cookie.Domain {
+			r.cookies[i] = cookie
+			return
+		}
+	}
+	r.cookies = append(r.cookies, cookie)
+}
+
+// HTTPCookie 把 Cookie 转换为 *http.Cookie
+func HTTPCookie(cookie Cookie) *http.Cookie {
+	if c, ok := cookie.(*httpCookie); ok {
+		copied := *c.cookie
+		return &copied
+	}
+	converted := &http.Cookie{
+		Name:     cookie.GetName(),
+		Value:    cookie.GetValue(),
+		Domain:   cookie.GetDomain(),
+		Path:     cookie.GetPath(),
+		Secure:   cookie.IsSecure(),
+		HttpOnly: cookie.IsHttpOnly(),
+		SameSite: sameSite(cookie.GetSameSite()),
+	}
+	if expires := cookie.GetExpiresTime(); expires != 0 {
+		converted.Expires = time.Unix(expires, 0)
+	}
+	return converted
+}
+
+// CookieFrom 把 *http.Cookie 包装为 Cookie
+func CookieFrom(cookie *http.Cookie) Cookie {
+	copied := *cookie
+	return &httpCookie{cookie: &copied}
+}
+
+// httpCookie 基于 *http.Cookie 的 Cookie
+type httpCookie struct {
+	cookie *http.Cookie
+}
+
+func (c *httpCookie) GetName() string   { return c.cookie.Name }
+func (c *httpCookie) GetValue() string  { return c.cookie.Value }
+func (c *httpCookie) GetDomain() string { return c.cookie.Domain }
+func (c *httpCookie) GetPath() string   { return c.cookie.Path }
+func (c *httpCookie) IsSecure() bool    { return c.cookie.Secure }
+func (c *httpCookie) IsHttpOnly() bool  { return c.cookie.HttpOnly }
+
+// GetExpiresTime 过期时间的 Unix 秒，会话 Cookie 为 0，删除 Cookie 时为过去的时间
+func (c *httpCookie) GetExpiresTime() int64 {
+	switch {
+	case c.cookie.MaxAge < 0:
+		return 1
+	case c.cookie.MaxAge > 0:
+		return time.Now().Add(time.Duration(c.cookie.MaxAge) * time.Second).Unix()
+	case !c.cookie.Expires.IsZero():
+		return c.cookie.Expires.Unix()
+	}
+	return 0
+}
+
+// GetSameSite SameSite 属性：lax、strict、none 或空
+func (c *httpCookie) GetSameSite() string {
+	switch c.cookie.SameSite {
+	case http.SameSiteLaxMode:
+		return "lax"
+	case http.SameSiteStrictMode:
+		return "strict"
+	case http.SameSiteNoneMode:
+		return "none"
+	}
+	return ""
+}
+
+// sameSite 解析 SameSite 属性
+func sameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	}
+	return http.SameSiteDefaultMode
+}
@@ -11,6 +11,20 @@
 // - 路由缓存和优化
 // - 请求和响应处理
 // - URL 生成和重定向
+// - WebSocket 路由和 RFC 6455 连接升级
+// - 签名 URL 和 signed 中间件
+// - 基于 net/http 的 Request、Response 实现
+// - 重定向响应，闪存数据、旧输入和错误信息写入会话
+// - 响应压缩中间件，协商 gzip、deflate 和自定义编码
+// - 静态文件和单页应用回退，支持 embed.FS
+// - 控制器通过结构体标签或 Routes 方法声明路由
+// - 可选的控制器基础类型：控制器级别中间件、资源授权、输入校验和响应、重定向工厂
+// - 基于 Vite/Mix 清单的资源版本、按环境配置的 CDN 和受信任代理的协议识别
+// - 流式分组属性构建器，嵌套分组连接前缀、拼接名称、追加中间件
+// - 按参数名注册的类型转换器，每条路由编译一次，动作直接取得 int、UUID、time.Time 等类型的参数
+// - 运行时的路由匹配耗时分位数统计
+// - API 版本：路径、Accept 厂商媒体类型或查询参数指定版本，弃用版本的 Deprecation/Sunset 响应头
+// - 请求体大小限制（超出时返回 413）、multipart 内存上限和惰性请求体解析
 //
 // 使用示例：
 //
@@ -25,13 +39,12 @@
 //	router.Delete("/users/{id}", userController.Delete)
 //
 //	// 路由分组
-//	api := router.Group("/api/v1")
-//	api.Middleware("auth", "throttle")
-//	api.Get("/profile", profileController.Show)
+//	router.Prefix("/api/v1").Middleware("auth", "throttle").Name("api.").Group(func(r routing.Router) {
+//		r.Get("/profile", profileController.Show).Name("profile")
+//	})
 //
 //	// 子域名路由
-//	admin := router.Domain("admin.example.com")
-//	admin.Get("/dashboard", adminController.Dashboard)
+//	router.Domain("admin.example.com").Get("/dashboard", adminController.Dashboard)
 //
 //	// 资源路由
 //	router.Resource("/posts", postController)
@@ -47,6 +60,7 @@ package routing
 
 import (
 	"context"
+	"io/fs"
 )
 
 // Router 路由器接口
@@ -104,26 +118,32 @@ type Router interface {
 	// Match 注册指定方法路由
 	Match(methods []string, uri string, action interface{}) Route
 
-	// Group 路由分组
+	// Group 路由分组，属性映射的键见 GroupAttributesFrom，嵌套分组按 GroupAttributes.Merge 合并
 	Group(attributes map[string]interface{}, callback func(Router)) Router
 
-	// Prefix 设置路由前缀
-	Prefix(prefix string) Router
+	// Prefix 设置路由前缀，返回分组属性构建器
+	Prefix(prefix string) *RouteRegistrar
 
-	// Middleware 设置中间件
-	Middleware(middleware ...string) Router
+	// Middleware 设置中间件，返回分组属性构建器
+	Middleware(middleware ...string) *RouteRegistrar
 
-	// Namespace 设置命名空间
-	Namespace(namespace string) Router
+	// Namespace 设置命名空间，返回分组属性构建器
+	Namespace(namespace string) *RouteRegistrar
 
-	// Name 设置路由名称前缀
-	Name(name string) Router
+	// Name 设置路由名称前缀，返回分组属性构建器
+	Name(name string) *RouteRegistrar
 
-	// Domain 设置域名
-	Domain(domain string) Router
+	// Domain 设置域名，返回分组属性构建器
+	Domain(domain string) *RouteRegistrar
+
+	// Where 设置路由参数约束，返回分组属性构建器
+	Where(name string, expression string) *RouteRegistrar
+
+	// Version 返回注册 API 版本路由的构建器，见 Versioning
+	Version(version string) *RouteRegistrar
 
-	// Where 设置路由参数约束
-	Where(name string, expression string) Router
+	// Pattern 为参数名注册类型转换器，包含该参数的路由匹配后参数以转换后的值出现在 RouteParams 中
+	Pattern(name string, converter ParamConverter) Router
 
 	// Resource 资源路由
 	Resource(name string, controller string, options map[string]interface{}) RouteCollection
@@ -143,6 +163,24 @@ type Router interface {
 	// Fallback 回退路由
 	Fallback(action interface{}) Route
 
+	// WebSocket 注册 WebSocket 路由，GET 请求升级后交给 handler 处理
+	WebSocket(uri string, handler WebSocketHandler) Route
+
+	// Static 注册静态文件路由，prefix 下的 GET、HEAD 请求由 dir 目录中的文件响应（StaticHandler）
+	Static(prefix string, dir string) Route
+
+	// StaticFS 注册静态文件路由，文件来自 fsys，例如 embed.FS
+	StaticFS(prefix string, fsys fs.FS) Route
+
+	// Spa 注册单页应用回退路由，未匹配的 GET 请求返回入口文件 entry，入口所在目录中的文件按静态文件发送（SpaHandler）
+	Spa(entry string) Route
+
+	// SpaFS 注册单页应用回退路由，入口文件和静态文件来自 fsys
+	SpaFS(fsys fs.FS, entry string) Route
+
+	// RegisterController 注册控制器通过 RouteTag 字段或 Routes 方法声明的路由（ControllerRoutes）
+	RegisterController(controller interface{}) (RouteCollection, error)
+
 	// GetRoutes 获取所有路由
 	GetRoutes() RouteCollection
 
@@ -151,6 +189,9 @@ type Router interface {
 
 	// DispatchToRoute 分发到路由
 	DispatchToRoute(request RequestInterface) ResponseInterface
+
+	// Stats 路由匹配耗时统计，见 MatchStats
+	Stats() Stats
 }
 
 // Route 路由接口
@@ -335,12 +376,18 @@ type RequestInterface interface {
 	// Has 检查是否有输入数据
 	Has(key string) bool
 
+	// Old 获取上一个请求闪存到会话的旧输入
+	Old(key string, defaultValue interface{}) interface{}
+
 	// File 获取上传文件
 	File(key string) UploadedFile
 
 	// HasFile 检查是否有上传文件
 	HasFile(key string) bool
 
+	// ParseError 获取解析请求体的错误，请求体在第一次读取输入时才解析
+	ParseError() error
+
 	// Cookie 获取Cookie
 	Cookie(name string, defaultValue string) string
 
@@ -0,0 +1,246 @@
+package routing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StaticOptions 静态文件服务配置
+type StaticOptions struct {
+	// Index 目录请求使用的索引文件，默认 "index.html"
+	Index string
+
+	// MaxAge 普通文件的缓存时间，默认 1 小时；HTML 文件总是 no-cache，每次向服务器确认
+	MaxAge time.Duration
+
+	// Immutable 内容永不变化的文件（文件名带内容哈希的构建产物），使用 path.Match 模式匹配
+	// 相对路径，例如 "assets/*"，匹配的文件缓存一年并带 immutable
+	Immutable []string
+
+	// AllowHidden 允许访问以 "." 开头的文件和目录，默认禁止（例如 .env、.git）
+	AllowHidden bool
+}
+
+// StaticHandler 静态文件处理器，对应 Router.Static 和 Router.StaticFS
+//
+// 文件来自 fs.FS：磁盘目录使用 os.DirFS，嵌入的文件使用 embed.FS（通常配合 fs.Sub 去掉目录前缀）。
+// 请求路径经过清理后必须是 fs.ValidPath，".." 和隐藏文件返回 404，目录只返回索引文件，不列出内容。
+// 响应带 Last-Modified 和弱 ETag，支持条件请求和 Range 请求。
+//
+// 处理器按请求路径查找文件，挂载在前缀下时使用 http.StripPrefix：
+//
+//	//go:embed dist
+//	var dist embed.FS
+//
+//	assets, _ := fs.Sub(dist, "dist")
+//	static := routing.NewStaticHandler(assets, routing.StaticOptions{Immutable: []string{"assets/*"}})
+//	mux.Handle("/build/", http.StripPrefix("/build", static))
+type StaticHandler struct {
+	fsys    fs.FS
+	options StaticOptions
+
+	// hashes 没有修改时间的文件（embed.FS）的内容哈希，按文件名缓存
+	hashes sync.Map
+}
+
+// NewStaticHandler 创建静态文件处理器
+func NewStaticHandler(fsys fs.FS, options StaticOptions) *StaticHandler {
+	if options.Index == "" {
+		options.Index = "index.html"
+	}
+	if options.MaxAge == 0 {
+		options.MaxAge = time.Hour
+	}
+	return &StaticHandler{fsys: fsys, options: options}
+}
+
+// NewStaticDir 创建服务磁盘目录 dir 的静态文件处理器
+func NewStaticDir(dir string, options StaticOptions) *StaticHandler {
+	return NewStaticHandler(os.DirFS(dir), options)
+}
+
+// ServeHTTP 发送请求路径对应的文件，不存在时返回 404，只接受 GET 和 HEAD
+func (h *StaticHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	name, ok := h.Resolve(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if err := h.ServeFile(w, r, name); err != nil {
+		http.NotFound(w, r)
+	}
+}
+
+// Resolve 把请求路径解析为 fs.FS 中存在的文件名，目录解析为其索引文件，
+// 路径不安全或文件不存在时返回 false
+func (h *StaticHandler) Resolve(requestPath string) (string, bool) {
+	name, ok := h.clean(requestPath)
+	if !ok {
+		return "", false
+	}
+	info, err := fs.Stat(h.fsys, name)
+	if err != nil {
+		return "", false
+	}
+	if info.IsDir() {
+		index := path.Join(name, h.options.Index)
+		if info, err := fs.Stat(h.fsys, index); err != nil || info.IsDir() {
+			return "", false
+		}
+		return index, true
+	}
+	return name, true
+}
+
+// ServeFile 发送 fs.FS 中的文件，设置缓存响应头
+func (h *StaticHandler) ServeFile(w http.ResponseWriter, r *http.Request, name string) error {
+	file, err := h.fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fs.ErrNotExist
+	}
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		return errors.New("routing: static file does not implement io.Seeker")
+	}
+
+	etag, err := h.etag(name, info, content)
+	if err != nil {
+		return err
+	}
+
+	header := w.Header()
+	header.Set("Cache-Control", h.cacheControl(name))
+	if header.Get("ETag") == "" {
+		header.Set("ETag", etag)
+	}
+	header.Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), content)
+	return nil
+}
+
+// etag 文件的弱 ETag，由修改时间和大小生成
+//
+// embed.FS 中文件的修改时间为零值（ServeContent 也不会设置 Last-Modified），
+// 此时使用内容的 SHA-256 前缀，并按文件名缓存。
+func (h *StaticHandler) etag(name string, info fs.FileInfo, content io.ReadSeeker) (string, error) {
+	if !info.ModTime().IsZero() {
+		return fmt.Sprintf(`W/"%x-%x"`, info.ModTime().UnixNano(), info.Size()), nil
+	}
+	if cached, ok := h.hashes.Load(name); ok {
+		return cached.(string), nil
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	etag := `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+	h.hashes.Store(name, etag)
+	return etag, nil
+}
+
+// cacheControl 文件的 Cache-Control 响应头
+func (h *StaticHandler) cacheControl(name string) string {
+	if strings.HasSuffix(name, ".html") || strings.HasSuffix(name, ".htm") {
+		return "no-cache"
+	}
+	for _, pattern := range h.options.Immutable {
+		if matched, _ := path.Match(pattern, name); matched {
+			return "public, max-age=31536000, immutable"
+		}
+	}
+	return "public, max-age=" + strconv.Itoa(int(h.options.MaxAge/time.Second))
+}
+
+// clean 把请求路径转换为 fs.FS 中的文件名，拒绝 ".."、NUL、反斜杠和隐藏文件
+func (h *StaticHandler) clean(requestPath string) (string, bool) {
+	if strings.ContainsAny(requestPath, "\x00\\") {
+		return "", false
+	}
+	for _, segment := range strings.Split(requestPath, "/") {
+		if segment == ".." {
+			return "", false
+		}
+		if !h.options.AllowHidden && strings.HasPrefix(segment, ".") && segment != "." {
+			return "", false
+		}
+	}
+	name := strings.TrimPrefix(path.Clean("/"+requestPath), "/")
+	if name == "" {
+		name = "."
+	}
+	return name, fs.ValidPath(name)
+}
+
+// SpaHandler 单页应用处理器，对应 Router.Spa 和 Router.SpaFS
+//
+// 存在的静态文件按 StaticHandler 发送；其他 GET、HEAD 请求返回入口文件（通常是 index.html），
+// 由前端路由处理 URL。带扩展名的不存在路径（例如缺失的 .js）返回 404，
+// 避免把 HTML 当作脚本返回。入口文件带 no-cache，部署新版本后浏览器立即获取。
+//
+//	http.ListenAndServe(":8080", routing.NewSpaHandler(os.DirFS("public"), "index.html", routing.StaticOptions{}))
+type SpaHandler struct {
+	static *StaticHandler
+	entry  string
+}
+
+// NewSpaHandler 创建单页应用处理器，entry 为 fs.FS 中入口文件的路径
+func NewSpaHandler(fsys fs.FS, entry string, options StaticOptions) *SpaHandler {
+	return &SpaHandler{static: NewStaticHandler(fsys, options), entry: strings.TrimPrefix(entry, "/")}
+}
+
+// NewSpaEntry 从入口文件的磁盘路径创建单页应用处理器，入口文件所在目录作为静态文件目录
+func NewSpaEntry(entry string, options StaticOptions) *SpaHandler {
+	dir, name := path.Split(strings.ReplaceAll(entry, "\\", "/"))
+	if dir == "" {
+		dir = "."
+	}
+	return NewSpaHandler(os.DirFS(dir), name, options)
+}
+
+// ServeHTTP 发送静态文件或入口文件
+func (h *SpaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if name, ok := h.static.Resolve(r.URL.Path); ok {
+		if err := h.static.ServeFile(w, r, name); err == nil {
+			return
+		}
+	}
+	if path.Ext(r.URL.Path) != "" {
+		http.NotFound(w, r)
+		return
+	}
+	if err := h.static.ServeFile(w, r, h.entry); err != nil {
+		http.NotFound(w, r)
+	}
+}
@@ -0,0 +1,158 @@
+package routing
+
+import (
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// Stats 路由匹配耗时统计快照
+type Stats struct {
+	// Count 匹配次数
+	Count uint64
+
+	// Misses 没有匹配到路由的次数
+	Misses uint64
+
+	// Mean 平均耗时
+	Mean time.Duration
+
+	// P50 中位数耗时
+	P50 time.Duration
+
+	// P90 90 分位耗时
+	P90 time.Duration
+
+	// P99 99 分位耗时
+	P99 time.Duration
+
+	// Max 最大耗时
+	Max time.Duration
+}
+
+// statsSubBuckets 每个 2 的幂区间再细分的桶数，分位数的相对误差不超过 1/statsSubBuckets
+const statsSubBuckets = 8
+
+// statsBuckets 桶总数，覆盖 int64 纳秒的全部范围
+const statsBuckets = 64 * statsSubBuckets
+
+// MatchStats 路由匹配耗时记录器，Router.Stats 的实现可以直接使用
+//
+// 耗时记录在对数分桶的直方图中，Observe 只做原子加法，不加锁也不分配内存，
+// 可以放在每个请求的匹配路径上。分位数取所在桶的上界，相对误差在 12.5% 以内。
+//
+// 使用示例：
+//
+//	func (r *router) match(request routing.RequestInterface) routing.Route {
+//		start := time.Now()
+//		route := r.routes.Match(request)
+//		r.stats.Observe(time.Since(start), route != nil)
+//		return route
+//	}
+//
+//	func (r *router) Stats() routing.Stats {
+//		return r.stats.Snapshot()
+//	}
+type MatchStats struct {
+	count   atomic.Uint64
+	misses  atomic.Uint64
+	total   atomic.Uint64
+	max     atomic.Uint64
+	buckets [statsBuckets]atomic.Uint64
+}
+
+// Observe 记录一次匹配
+func (s *MatchStats) Observe(d time.Duration, matched bool) {
+	if d < 0 {
+		d = 0
+	}
+	ns := uint64(d)
+	s.count.Add(1)
+	if !matched {
+		s.misses.Add(1)
+	}
+	s.total.Add(ns)
+	for {
+		current := s.max.Load()
+		if ns <= current || s.max.CompareAndSwap(current, ns) {
+			break
+		}
+	}
+	s.buckets[statsBucket(ns)].Add(1)
+}
+
+// Snapshot 当前统计，并发 Observe 时各字段之间可能相差正在记录的几次匹配
+func (s *MatchStats) Snapshot() Stats {
+	var counts [statsBuckets]uint64
+	var observed uint64
+	for i := range s.buckets {
+		counts[i] = s.buckets[i].Load()
+		observed += counts[i]
+	}
+	stats := Stats{
+		Count:  s.count.Load(),
+		Misses: s.misses.Load(),
+		Max:    time.Duration(s.max.Load()),
+	}
+	if stats.Count > 0 {
+		stats.Mean = time.Duration(s.total.Load() / stats.Count)
+	}
+	stats.P50 = statsPercentile(&counts, observed, 0.50, stats.Max)
+	stats.P90 = statsPercentile(&counts, observed, 0.90, stats.Max)
+	stats.P99 = statsPercentile(&counts, observed, 0.99, stats.Max)
+	return stats
+}
+
+// Reset 清空统计
+func (s *MatchStats) Reset() {
+	s.count.Store(0)
+	s.misses.Store(0)
+	s.total.Store(0)
+	s.max.Store(0)
+	for i := range s.buckets {
+		s.buckets[i].Store(0)
+	}
+}
+
+// statsBucket 耗时所在的桶：小于 statsSubBuckets 纳秒时每纳秒一个桶，
+// 否则按最高位确定 2 的幂区间，再按其后 3 位细分
+func statsBucket(ns uint64) int {
+	if ns < statsSubBuckets {
+		return int(ns)
+	}
+	exponent := bits.Len64(ns) - 1
+	mantissa := (ns >> (exponent - 3)) & (statsSubBuckets - 1)
+	return (exponent-2)*statsSubBuckets + int(mantissa)
+}
+
+// statsBucketUpper 桶的上界（包含）
+func statsBucketUpper(bucket int) uint64 {
+	if bucket < statsSubBuckets {
+		return uint64(bucket)
+	}
+	exponent := bucket/statsSubBuckets + 2
+	mantissa := uint64(bucket % statsSubBuckets)
+	return (statsSubBuckets+mantissa+1)<<(exponent-3) - 1
+}
+
+// statsPercentile 计算分位数，结果不超过 max
+func statsPercentile(counts *[statsBuckets]uint64, observed uint64, quantile float64, max time.Duration) time.Duration {
+	if observed == 0 {
+		return 0
+	}
+	rank := uint64(quantile*float64(observed) + 0.5)
+	if rank == 0 {
+		rank = 1
+	}
+	var seen uint64
+	for i, count := range counts {
+		seen += count
+		if seen >= rank {
+			if upper := statsBucketUpper(i); upper < uint64(max) {
+				return time.Duration(upper)
+			}
+			return max
+		}
+	}
+	return max
+}
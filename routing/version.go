@@ -0,0 +1,344 @@
+package routing
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VersionStrategy 客户端指定 API 版本的方式
+type VersionStrategy int
+
+const (
+	// VersionByURI 版本在路径中，例如 /api/v2/users
+	VersionByURI VersionStrategy = iota
+
+	// VersionByHeader 版本在 Accept 请求头的厂商媒体类型中，例如 application/vnd.app.v2+json
+	VersionByHeader
+
+	// VersionByQuery 版本在查询参数中，例如 /api/users?version=v2
+	VersionByQuery
+)
+
+// APIVersion 一个 API 版本
+type APIVersion struct {
+	// Name 版本名，例如 "v2"
+	Name string
+
+	// Deprecated 是否已弃用，弃用的版本在响应中带 Deprecation 响应头
+	Deprecated bool
+
+	// DeprecatedAt 弃用时间，非零时 Deprecation 响应头为该时间（RFC 9745），否则为 "true"
+	DeprecatedAt time.Time
+
+	// Sunset 停止服务时间，非零时带 Sunset 响应头（RFC 8594）
+	Sunset time.Time
+
+	// Link 迁移说明地址，非空时带 Link: <url>; rel="deprecation" 响应头
+	Link string
+}
+
+// VersioningConfig API 版本配置
+type VersioningConfig struct {
+	// Strategy 客户端指定版本的方式
+	Strategy VersionStrategy
+
+	// Prefix 版本之前的路径前缀，例如 "api"
+	Prefix string
+
+	// Vendor VersionByHeader 时媒体类型的厂商名，默认 "app"
+	Vendor string
+
+	// QueryParam VersionByQuery 时的查询参数名，默认 "version"
+	QueryParam string
+
+	// Default 请求没有指定版本时使用的版本（VersionByHeader、VersionByQuery），为空时要求指定版本
+	Default string
+}
+
+// VersionedRoute 路由与版本的对应关系
+type VersionedRoute struct {
+	// Version 版本名
+	Version string
+
+	// Methods HTTP 方法
+	Methods []string
+
+	// URI 路由的 URI
+	URI string
+
+	// Name 路由名称
+	Name string
+
+	// Deprecated 版本是否已弃用
+	Deprecated bool
+}
+
+// Versioning API 版本管理，Router.Version 的实现可以直接使用
+//
+// 无论采用哪种方式指定版本，每个版本的路由都注册在 "前缀/版本" 之下，名称以 "版本." 开头。
+// VersionByHeader 和 VersionByQuery 时，Middleware 在路由匹配之前把请求的路径改写为带版本的内部路径，
+// 因此不同版本的同名路由可以共存，路由器不需要感知版本。
+//
+// 使用示例：
+//
+//	versioning := routing.NewVersioning(routing.VersioningConfig{
+//		Strategy: routing.VersionByHeader,
+//		Prefix:   "api",
+//		Vendor:   "acme",
+//		Default:  "v2",
+//	})
+//	versioning.Deprecate("v1", time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), "https://acme.dev/migrate-v2")
+//
+//	versioning.Registrar(router, "v1").Group(func(r routing.Router) {
+//		r.Get("/users", v1.Users).Name("users") // v1.users
+//	})
+//	versioning.Registrar(router, "v2").Group(func(r routing.Router) {
+//		r.Get("/users", v2.Users).Name("users") // v2.users
+//	})
+//
+//	// Accept: application/vnd.acme.v1+json 的 GET /api/users 匹配 /api/v1/users，响应带 Deprecation、Sunset 和 Link
+//	handler := versioning.Middleware(routerHandler)
+type Versioning struct {
+	config VersioningConfig
+	accept *regexp.Regexp
+
+	mu       sync.RWMutex
+	versions map[string]APIVersion
+}
+
+// NewVersioning 创建 API 版本管理
+func NewVersioning(config VersioningConfig) *Versioning {
+	if config.Vendor == "" {
+		config.Vendor = "app"
+	}
+	if config.QueryParam == "" {
+		config.QueryParam = "version"
+	}
+	config.Prefix = strings.Trim(config.Prefix, "/")
+	return &Versioning{
+		config:   config,
+		accept:   regexp.MustCompile(`^application/vnd\.` + regexp.QuoteMeta(config.Vendor) + `\.([A-Za-z0-9_.-]+?)(\+[a-z]+)?$`),
+		versions: make(map[string]APIVersion),
+	}
+}
+
+// Define 定义版本，覆盖同名版本
+func (v *Versioning) Define(version APIVersion) *Versioning {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.versions[version.Name] = version
+	return v
+}
+
+// Deprecate 把版本标记为弃用，sunset 和 link 可以为零值
+func (v *Versioning) Deprecate(name string, sunset time.Time, link string) *Versioning {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	version := v.versions[name]
+	version.Name = name
+	version.Deprecated = true
+	version.Sunset = sunset
+	version.Link = link
+	v.versions[name] = version
+	return v
+}
+
+// Version 获取版本
+func (v *Versioning) Version(name string) (APIVersion, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	version, ok := v.versions[name]
+	return version, ok
+}
+
+// Versions 所有版本，按名称排序
+func (v *Versioning) Versions() []APIVersion {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	versions := make([]APIVersion, 0, len(v.versions))
+	for _, version := range v.versions {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Name < versions[j].Name })
+	return versions
+}
+
+// Registrar 返回注册版本路由的构建器，前缀为 "前缀/版本"，名称前缀为 "版本."，版本未定义时自动定义
+func (v *Versioning) Registrar(router Router, name string) *RouteRegistrar {
+	v.mu.Lock()
+	if _, ok := v.versions[name]; !ok {
+		v.versions[name] = APIVersion{Name: name}
+	}
+	v.mu.Unlock()
+	return NewRouteRegistrar(router).Prefix(v.config.Prefix).Prefix(name).Name(name + ".")
+}
+
+// Resolve 获取请求指定的版本，没有指定时使用默认版本；ok 为 false 表示请求没有指定版本且没有默认版本
+//
+// VersionByHeader 和 VersionByQuery 时，路径中已经带有已定义的版本（直接访问内部路径）则以路径为准。
+// VersionByURI 时返回的版本可能未定义，调用方需要用 Version 检查。
+func (v *Versioning) Resolve(r *http.Request) (string, bool) {
+	segment := v.segment(r.URL.Path)
+	if v.config.Strategy == VersionByURI {
+		return segment, segment != ""
+	}
+	if _, defined := v.Version(segment); defined && segment != "" {
+		return segment, true
+	}
+	var name string
+	if v.config.Strategy == VersionByHeader {
+		name = v.fromAccept(r.Header.Values("Accept"))
+	} else {
+		name = r.URL.Query().Get(v.config.QueryParam)
+	}
+	if name == "" {
+		name = v.config.Default
+	}
+	return name, name != ""
+}
+
+// Middleware 解析请求的版本，放入 context，为弃用的版本添加响应头
+//
+// VersionByHeader 和 VersionByQuery 时把路径改写为带版本的内部路径；版本未定义时
+// VersionByHeader 返回 406，VersionByQuery 返回 400，VersionByURI 交给路由器（通常为 404）。
+// 路径不在前缀之下的请求原样传递。
+func (v *Versioning) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !v.covers(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if v.config.Strategy == VersionByHeader {
+			w.Header().Add("Vary", "Accept")
+		}
+		name, ok := v.Resolve(r)
+		version, defined := v.Version(name)
+		if !ok || !defined {
+			switch v.config.Strategy {
+			case VersionByHeader:
+				http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+			case VersionByQuery:
+				http.Error(w, "unsupported API version", http.StatusBadRequest)
+			default:
+				next.ServeHTTP(w, r)
+			}
+			return
+		}
+		if version.Deprecated {
+			writeDeprecation(w.Header(), version)
+		}
+		r = r.WithContext(WithAPIVersion(r.Context(), version.Name))
+		if v.config.Strategy != VersionByURI {
+			r = v.rewrite(r, version.Name)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Map 路由与版本的对应关系，不属于任何已定义版本的路由不包含在结果中
+func (v *Versioning) Map(routes []Route) []VersionedRoute {
+	var mapped []VersionedRoute
+	for _, route := range routes {
+		version, ok := v.Version(v.segment(route.GetURI()))
+		if !ok {
+			continue
+		}
+		mapped = append(mapped, VersionedRoute{
+			Version:    version.Name,
+			Methods:    route.GetMethods(),
+			URI:        route.GetURI(),
+			Name:       route.GetName(),
+			Deprecated: version.Deprecated,
+		})
+	}
+	return mapped
+}
+
+// segment 路径中前缀之后的第一段，路径不在前缀之下时为空
+func (v *Versioning) segment(requestPath string) string {
+	rest := strings.TrimPrefix(requestPath, "/")
+	if v.config.Prefix != "" {
+		var ok bool
+		if rest, ok = strings.CutPrefix(rest, v.config.Prefix+"/"); !ok {
+			return ""
+		}
+	}
+	segment, _, _ := strings.Cut(rest, "/")
+	return segment
+}
+
+// covers 路径是否在前缀之下
+func (v *Versioning) covers(requestPath string) bool {
+	if v.config.Prefix == "" {
+		return true
+	}
+	rest := strings.TrimPrefix(requestPath, "/")
+	return rest == v.config.Prefix || strings.HasPrefix(rest, v.config.Prefix+"/")
+}
+
+// fromAccept 从 Accept 请求头的厂商媒体类型中取版本
+func (v *Versioning) fromAccept(values []string) string {
+	for _, value := range values {
+		for _, mediaRange := range strings.Split(value, ",") {
+			mediaType, _, _ := strings.Cut(mediaRange, ";")
+			if match := v.accept.FindStringSubmatch(strings.TrimSpace(mediaType)); match != nil {
+				return match[1]
+			}
+		}
+	}
+	return ""
+}
+
+// rewrite 把版本插入前缀之后，路径中已经带有该版本时不改写
+func (v *Versioning) rewrite(r *http.Request, version string) *http.Request {
+	rest := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"), v.config.Prefix)
+	rest = strings.TrimPrefix(rest, "/")
+	if rest == version || strings.HasPrefix(rest, version+"/") {
+		return r
+	}
+	rewritten := new(http.Request)
+	*rewritten = *r
+	u := *r.URL
+	u.Path = joinURI(joinPrefix(v.config.Prefix, version), rest)
+	if strings.HasSuffix(r.URL.Path, "/") && rest != "" {
+		u.Path += "/"
+	}
+	u.RawPath = ""
+	rewritten.URL = &u
+	return rewritten
+}
+
+// writeDeprecation 添加弃用相关的响应头
+func writeDeprecation(header http.Header, version APIVersion) {
+	if version.DeprecatedAt.IsZero() {
+		header.Set("Deprecation", "true")
+	} else {
+		header.Set("Deprecation", "@"+strconv.FormatInt(version.DeprecatedAt.Unix(), 10))
+	}
+	if !version.Sunset.IsZero() {
+		header.Set("Sunset", version.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if version.Link != "" {
+		header.Add("Link", "<"+version.Link+`>; rel="deprecation"`)
+	}
+}
+
+// apiVersionKey context 中 API 版本的键
+type apiVersionKey struct{}
+
+// WithAPIVersion 把请求的 API 版本放入 context
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionKey{}, version)
+}
+
+// APIVersionFromContext 从 context 获取请求的 API 版本，没有时为空
+func APIVersionFromContext(ctx context.Context) string {
+	version, _ := ctx.Value(apiVersionKey{}).(string)
+	return version
+}
@@ -0,0 +1,419 @@
+package routing
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WebSocket 消息类型（RFC 6455 操作码）
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+// WebSocket 关闭状态码
+const (
+	CloseNormal          = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseUnsupportedData = 1003
+	CloseInvalidPayload  = 1007
+	ClosePolicyViolation = 1008
+	CloseMessageTooBig   = 1009
+	CloseInternalError   = 1011
+)
+
+// WebSocket 错误
+var (
+	// ErrNotWebSocket 请求不是 WebSocket 升级请求
+	ErrNotWebSocket = errors.New("routing: not a websocket handshake")
+
+	// ErrOriginNotAllowed Origin 校验失败
+	ErrOriginNotAllowed = errors.New("routing: websocket origin not allowed")
+)
+
+// CloseError 对端发送的关闭帧
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("routing: websocket closed: %d %s", e.Code, e.Reason)
+}
+
+// WebSocketConn WebSocket 连接接口
+//
+// ReadMessage 只能由一个 goroutine 调用；WriteMessage、WriteJSON 和 Close 可以并发调用。
+type WebSocketConn interface {
+	// ReadMessage 读取一条完整的文本或二进制消息，自动回复 Ping 和关闭帧
+	ReadMessage() (messageType int, data []byte, err error)
+
+	// WriteMessage 写入一条消息
+	WriteMessage(messageType int, data []byte) error
+
+	// WriteJSON 以文本消息写入 JSON
+	WriteJSON(v interface{}) error
+
+	// Close 发送关闭帧并关闭连接
+	Close(code int, reason string) error
+
+	// Subprotocol 协商的子协议
+	Subprotocol() string
+
+	// Request 升级前的 HTTP 请求
+	Request() *http.Request
+
+	// Context 连接的上下文，连接关闭后取消
+	Context() context.Context
+}
+
+// WebSocketHandler WebSocket 路由处理器，返回时连接被关闭
+type WebSocketHandler func(ctx context.Context, conn WebSocketConn)
+
+// WebSocketOptions 连接升级选项
+type WebSocketOptions struct {
+	// CheckOrigin 校验 Origin，为 nil 时只允许与 Host 相同的 Origin 或没有 Origin 的请求
+	CheckOrigin func(r *http.Request) bool
+
+	// Subprotocols 服务端支持的子协议，按优先级排列
+	Subprotocols []string
+
+	// MaxMessageSize 单条消息的最大字节数，默认 1 MiB
+	MaxMessageSize int64
+
+	// WriteTimeout 单次写入超时，默认 10 秒
+	WriteTimeout time.Duration
+}
+
+// websocketGUID RFC 6455 握手使用的固定 GUID
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// IsWebSocketRequest 是否为 WebSocket 升级请求
+func IsWebSocketRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		headerContains(r.Header, "Connection", "upgrade") &&
+		headerContains(r.Header, "Upgrade", "websocket")
+}
+
+// UpgradeWebSocket 将 HTTP 请求升级为 WebSocket 连接
+//
+// 升级失败时已向客户端写入错误响应。
+func UpgradeWebSocket(w http.ResponseWriter, r *http.Request, options WebSocketOptions) (WebSocketConn, error) {
+	if !IsWebSocketRequest(r) {
+		http.Error(w, "websocket upgrade required", http.StatusUpgradeRequired)
+		return nil, ErrNotWebSocket
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusBadRequest)
+		return nil, ErrNotWebSocket
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "invalid websocket key", http.StatusBadRequest)
+		return nil, ErrNotWebSocket
+	}
+	checkOrigin := options.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = sameOrigin
+	}
+	if !checkOrigin(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return nil, ErrOriginNotAllowed
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("routing: response writer does not support hijacking")
+	}
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	subprotocol := selectSubprotocol(r, options.Subprotocols)
+	accept := sha1.Sum([]byte(key + websocketGUID))
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(accept[:]) + "\r\n"
+	if subprotocol != "" {
+		response += "Sec-WebSocket-Protocol: " + subprotocol + "\r\n"
+	}
+	if _, err := rw.WriteString(response + "\r\n"); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	if options.MaxMessageSize <= 0 {
+		options.MaxMessageSize = 1 << 20
+	}
+	if options.WriteTimeout <= 0 {
+		options.WriteTimeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	return &websocketConn{
+		conn:        netConn,
+		reader:      rw.Reader,
+		request:     r,
+		subprotocol: subprotocol,
+		options:     options,
+		ctx:         ctx,
+		cancel:      cancel,
+	}, nil
+}
+
+// WebSocketHTTPHandler 将 WebSocketHandler 转换为 http.Handler，供路由实现或 net/http 直接使用
+func WebSocketHTTPHandler(handler WebSocketHandler, options WebSocketOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := UpgradeWebSocket(w, r, options)
+		if err != nil {
+			return
+		}
+		defer conn.Close(CloseNormal, "")
+		handler(conn.Context(), conn)
+	})
+}
+
+// websocketConn 服务端 WebSocket 连接
+type websocketConn struct {
+	conn        net.Conn
+	reader      *bufio.Reader
+	request     *http.Request
+	subprotocol string
+	options     WebSocketOptions
+	ctx         context.Context
+	cancel      context.CancelFunc
+
+	writeMu sync.Mutex
+	closed  bool
+}
+
+func (c *websocketConn) Subprotocol() string      { return c.subprotocol }
+func (c *websocketConn) Request() *http.Request   { return c.request }
+func (c *websocketConn) Context() context.Context { return c.ctx }
+
+// ReadMessage 读取一条完整消息
+func (c *websocketConn) ReadMessage() (int, []byte, error) {
+	var messageType int
+	var message []byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			c.cancel()
+			return 0, nil, err
+		}
+		switch opcode {
+		case PingMessage:
+			if err := c.writeFrame(PongMessage, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			closeErr := &CloseError{Code: 1005}
+			if len(payload) >= 2 {
+				closeErr.Code = int(binary.BigEndian.Uint16(payload))
+				closeErr.Reason = string(payload[2:])
+			}
+			_ = c.Close(closeErr.Code, "")
+			return 0, nil, closeErr
+		case 0:
+			if messageType == 0 {
+				return 0, nil, c.fail(CloseProtocolError, "unexpected continuation frame")
+			}
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, c.fail(CloseProtocolError, "expected continuation frame")
+			}
+			messageType = opcode
+		default:
+			return 0, nil, c.fail(CloseProtocolError, "unknown opcode")
+		}
+
+		if int64(len(message)+len(payload)) > c.options.MaxMessageSize {
+			return 0, nil, c.fail(CloseMessageTooBig, "message too big")
+		}
+		message = append(message, payload...)
+		if fin {
+			if messageType == TextMessage && !utf8.Valid(message) {
+				return 0, nil, c.fail(CloseInvalidPayload, "invalid utf-8")
+			}
+			return messageType, message, nil
+		}
+	}
+}
+
+// readFrame 读取一帧，客户端帧必须带掩码
+func (c *websocketConn) readFrame() (bool, int, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin := header[0]&0x80 != 0
+	opcode := int(header[0] & 0x0f)
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, c.fail(CloseProtocolError, "reserved bits set")
+	}
+	if header[1]&0x80 == 0 {
+		return false, 0, nil, c.fail(CloseProtocolError, "client frame not masked")
+	}
+
+	length := int64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+	if opcode >= CloseMessage && (length > 125 || !fin) {
+		return false, 0, nil, c.fail(CloseProtocolError, "invalid control frame")
+	}
+	if length > c.options.MaxMessageSize || length < 0 {
+		return false, 0, nil, c.fail(CloseMessageTooBig, "message too big")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// WriteMessage 写入一条消息
+func (c *websocketConn) WriteMessage(messageType int, data []byte) error {
+	return c.writeFrame(messageType, data)
+}
+
+// WriteJSON 以文本消息写入 JSON
+func (c *websocketConn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(TextMessage, data)
+}
+
+// writeFrame 写入一个不分片、不带掩码的帧
+func (c *websocketConn) writeFrame(opcode int, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+
+	header := []byte{0x80 | byte(opcode), 0}
+	switch {
+	case len(payload) < 126:
+		header[1] = byte(len(payload))
+	case len(payload) <= 0xffff:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(len(payload)))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(len(payload)))
+	}
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.options.WriteTimeout))
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close 发送关闭帧并关闭连接
+func (c *websocketConn) Close(code int, reason string) error {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	_ = c.writeFrame(CloseMessage, append(payload, reason...))
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	c.cancel()
+	return c.conn.Close()
+}
+
+// fail 以协议错误关闭连接
+func (c *websocketConn) fail(code int, reason string) error {
+	_ = c.Close(code, reason)
+	return &CloseError{Code: code, Reason: reason}
+}
+
+// sameOrigin 默认 Origin 校验：没有 Origin 或 Origin 的主机与 Host 相同
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// selectSubprotocol 选择客户端和服务端都支持的第一个子协议
+func selectSubprotocol(r *http.Request, supported []string) string {
+	for _, requested := range strings.Split(r.Header.Get("Sec-WebSocket-Protocol"), ",") {
+		requested = strings.TrimSpace(requested)
+		for _, protocol := range supported {
+			if requested == protocol {
+				return protocol
+			}
+		}
+	}
+	return ""
+}
+
+// headerContains 逗号分隔的请求头是否包含指定值（不区分大小写）
+func headerContains(header http.Header, name, value string) bool {
+	for _, field := range header.Values(name) {
+		for _, token := range strings.Split(field, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// RegisterCommands 注册 schedule:run、schedule:work、schedule:list 命令
+func RegisterCommands(artisan application.ArtisanInterface, s *Schedule) {
+	artisan.Register("schedule:run").
+		SetDescription("Run the scheduled commands").
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			s.Run(context.Background(), output)
+			return nil
+		})
+
+	artisan.Register("schedule:work").
+		SetDescription("Start the schedule worker").
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return s.Work(ctx, output)
+		})
+
+	artisan.Register("schedule:list").
+		SetDescription("List all scheduled tasks").
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			s.List(output)
+			return nil
+		})
+}
+
+// Run 执行当前分钟到期的任务并等待后台任务完成，对应 schedule:run
+func (s *Schedule) Run(ctx context.Context, output application.OutputInterface) {
+	now := s.now()
+	results := s.RunDueEvents(ctx, now)
+	if len(results) == 0 {
+		writeLine(output, "No scheduled commands are ready to run.")
+		return
+	}
+	for _, result := range results {
+		summary := result.Event.GetSummary()
+		switch {
+		case result.Skipped:
+			writeLine(output, fmt.Sprintf("%s Skipping [%s]", now.Format(time.DateTime), summary))
+		case result.Err != nil:
+			writeLine(output, fmt.Sprintf("%s Running [%s] FAIL %s: %v", now.Format(time.DateTime), summary, result.Duration.Round(time.Millisecond), result.Err))
+		case result.Event.background:
+			writeLine(output, fmt.Sprintf("%s Running [%s] in background", now.Format(time.DateTime), summary))
+		default:
+			writeLine(output, fmt.Sprintf("%s Running [%s] DONE %s", now.Format(time.DateTime), summary, result.Duration.Round(time.Millisecond)))
+		}
+	}
+	s.Wait()
+}
+
+// Work 在每分钟开始时执行 Run，直到 ctx 取消，对应 schedule:work
+//
+// 每分钟的执行在独立的 goroutine 中进行，耗时超过一分钟的任务不会推迟下一分钟的调度。
+// 退出前等待正在执行的任务完成。
+func (s *Schedule) Work(ctx context.Context, output application.OutputInterface) error {
+	writeLine(output, "Running scheduled tasks every minute.")
+	var running sync.WaitGroup
+	defer running.Wait()
+	for {
+		now := s.now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			s.Run(context.WithoutCancel(ctx), output)
+		}()
+	}
+}
+
+// List 输出所有任务的表达式、摘要、下一次执行时间和执行约束，对应 schedule:list
+//
+//	0 13 * * *      emails:send                              Next Due: 2024-05-01 13:00:00 CST (in 2h30m) [without overlapping, one server]
+func (s *Schedule) List(output application.OutputInterface) {
+	events := s.Events()
+	if len(events) == 0 {
+		writeLine(output, "No scheduled tasks have been defined.")
+		return
+	}
+	now := s.now()
+	for _, event := range events {
+		next, err := event.NextRunDate(now)
+		due := "invalid expression"
+		if err == nil {
+			due = fmt.Sprintf("Next Due: %s (in %s)", next.Format(time.DateTime+" MST"), next.Sub(now).Round(time.Second))
+		}
+		if flags := event.flags(); len(flags) > 0 {
+			due += " [" + strings.Join(flags, ", ") + "]"
+		}
+		writeLine(output, fmt.Sprintf("%-15s %-40s %s", event.Expression(), event.GetSummary(), due))
+	}
+}
+
+// writeLine 写入一行，output 为 nil 时忽略
+func writeLine(output application.OutputInterface, message string) {
+	if output != nil {
+		_ = output.WriteLine(message, application.VerbosityNormal)
+	}
+}
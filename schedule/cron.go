@@ -0,0 +1,199 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronExpression 解析后的 cron 表达式
+//
+// 支持标准五段格式（分 时 日 月 周），每段可以使用 *、?、数字、范围（1-5）、
+// 步长（*/15、1-30/5）、列表（1,15,30）以及月份和星期的英文缩写（jan、mon）。
+// 周字段中 0 和 7 都表示星期日。日和周同时受限时，满足任意一个即匹配（与 Vixie cron 一致）。
+// 同时支持 @yearly、@annually、@monthly、@weekly、@daily、@midnight、@hourly 简写。
+type CronExpression struct {
+	expression string
+	minute     uint64
+	hour       uint64
+	dom        uint64
+	month      uint64
+	dow        uint64
+	domStar    bool
+	dowStar    bool
+}
+
+var cronNicknames = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// ParseCron 解析 cron 表达式
+func ParseCron(expression string) (*CronExpression, error) {
+	normalized := strings.TrimSpace(expression)
+	if nickname, ok := cronNicknames[strings.ToLower(normalized)]; ok {
+		normalized = nickname
+	}
+	fields := strings.Fields(normalized)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule: invalid cron expression %q: expected 5 fields", expression)
+	}
+
+	c := &CronExpression{expression: expression}
+	var err error
+	if c.minute, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("schedule: invalid minute in %q: %w", expression, err)
+	}
+	if c.hour, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("schedule: invalid hour in %q: %w", expression, err)
+	}
+	if c.dom, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("schedule: invalid day of month in %q: %w", expression, err)
+	}
+	if c.month, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("schedule: invalid month in %q: %w", expression, err)
+	}
+	if c.dow, err = parseField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("schedule: invalid day of week in %q: %w", expression, err)
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	// 与 Vixie cron 一致，以 * 开头的字段（包括 */2）视为不限制，日和周按 AND 组合
+	c.domStar = strings.HasPrefix(fields[2], "*") || fields[2] == "?"
+	c.dowStar = strings.HasPrefix(fields[4], "*") || fields[4] == "?"
+	return c, nil
+}
+
+// MustParseCron 解析 cron 表达式，失败时 panic
+func MustParseCron(expression string) *CronExpression {
+	c, err := ParseCron(expression)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// String 原始表达式
+func (c *CronExpression) String() string {
+	return c.expression
+}
+
+// Matches 时间是否匹配（精确到分钟），使用 t 自身的时区
+func (c *CronExpression) Matches(t time.Time) bool {
+	return c.minute&(1<<uint(t.Minute())) != 0 &&
+		c.hour&(1<<uint(t.Hour())) != 0 &&
+		c.month&(1<<uint(t.Month())) != 0 &&
+		c.dayMatches(t)
+}
+
+// Next 严格晚于 t 的下一个匹配时间，使用 t 自身的时区，5 年内没有匹配时返回零值
+func (c *CronExpression) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches 日和周的匹配规则
+func (c *CronExpression) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parseField 解析一个字段为位图
+func parseField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			part = part[:i]
+		}
+
+		var start, end int
+		switch {
+		case part == "*" || part == "?":
+			start, end = min, max
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if start, err = fieldValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			if end, err = fieldValue(bounds[1], names); err != nil {
+				return 0, err
+			}
+		default:
+			value, err := fieldValue(part, names)
+			if err != nil {
+				return 0, err
+			}
+			start, end = value, value
+			if step > 1 {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// fieldValue 解析数字或名称
+func fieldValue(value string, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return n, nil
+}
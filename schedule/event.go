@@ -0,0 +1,428 @@
+package schedule
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrOverlapping 任务的上一次执行尚未结束
+var ErrOverlapping = errors.New("schedule: event is still running")
+
+// Event 计划任务
+//
+// 频率方法修改 cron 表达式的对应字段，可以组合使用，例如 Weekdays().DailyAt("9:30")。
+// 默认表达式为 "* * * * *"（每分钟）。
+type Event struct {
+	schedule    *Schedule
+	expression  string
+	location    *time.Location
+	description string
+	command     string
+	run         func(ctx context.Context) error
+
+	filters            []func(ctx context.Context) bool
+	rejects            []func(ctx context.Context) bool
+	withoutOverlapping bool
+	expiresAt          time.Duration
+	onOneServer        bool
+	background         bool
+
+	before    []func(ctx context.Context)
+	after     []func(ctx context.Context, err error)
+	onSuccess []func(ctx context.Context)
+	onFailure []func(ctx context.Context, err error)
+}
+
+// newEvent 创建计划任务
+func newEvent(schedule *Schedule, command string, run func(ctx context.Context) error) *Event {
+	return &Event{
+		schedule:   schedule,
+		expression: "* * * * *",
+		command:    command,
+		run:        run,
+		expiresAt:  24 * time.Hour,
+	}
+}
+
+// Cron 设置 cron 表达式
+func (e *Event) Cron(expression string) *Event {
+	e.expression = expression
+	return e
+}
+
+// Expression 当前的 cron 表达式
+func (e *Event) Expression() string {
+	return e.expression
+}
+
+// splice 替换表达式的第 position 段（从 1 开始）
+func (e *Event) splice(position int, value string) *Event {
+	fields := strings.Fields(e.expression)
+	if len(fields) != 5 {
+		fields = strings.Fields("* * * * *")
+	}
+	fields[position-1] = value
+	e.expression = strings.Join(fields, " ")
+	return e
+}
+
+// EveryMinute 每分钟
+func (e *Event) EveryMinute() *Event { return e.splice(1, "*") }
+
+// EveryTwoMinutes 每两分钟
+func (e *Event) EveryTwoMinutes() *Event { return e.splice(1, "*/2") }
+
+// EveryFiveMinutes 每五分钟
+func (e *Event) EveryFiveMinutes() *Event { return e.splice(1, "*/5") }
+
+// EveryTenMinutes 每十分钟
+func (e *Event) EveryTenMinutes() *Event { return e.splice(1, "*/10") }
+
+// EveryFifteenMinutes 每十五分钟
+func (e *Event) EveryFifteenMinutes() *Event { return e.splice(1, "*/15") }
+
+// EveryThirtyMinutes 每三十分钟
+func (e *Event) EveryThirtyMinutes() *Event { return e.splice(1, "0,30") }
+
+// Hourly 每小时整点
+func (e *Event) Hourly() *Event { return e.splice(1, "0") }
+
+// HourlyAt 每小时的第 minute 分钟
+func (e *Event) HourlyAt(minute int) *Event { return e.splice(1, strconv.Itoa(minute)) }
+
+// EveryTwoHours 每两小时
+func (e *Event) EveryTwoHours() *Event { return e.splice(1, "0").splice(2, "*/2") }
+
+// EverySixHours 每六小时
+func (e *Event) EverySixHours() *Event { return e.splice(1, "0").splice(2, "*/6") }
+
+// Daily 每天零点
+func (e *Event) Daily() *Event { return e.splice(1, "0").splice(2, "0") }
+
+// At DailyAt 的别名
+func (e *Event) At(clock string) *Event { return e.DailyAt(clock) }
+
+// DailyAt 每天的指定时间，格式为 "13:00" 或 "13"
+func (e *Event) DailyAt(clock string) *Event {
+	hour, minute := parseClock(clock)
+	return e.splice(2, hour).splice(1, minute)
+}
+
+// TwiceDaily 每天的 first 点和 second 点
+func (e *Event) TwiceDaily(first, second int) *Event {
+	return e.splice(1, "0").splice(2, fmt.Sprintf("%d,%d", first, second))
+}
+
+// Weekdays 周一到周五
+func (e *Event) Weekdays() *Event { return e.splice(5, "1-5") }
+
+// Weekends 周六和周日
+func (e *Event) Weekends() *Event { return e.splice(5, "6,0") }
+
+// Mondays 每周一
+func (e *Event) Mondays() *Event { return e.Days(time.Monday) }
+
+// Tuesdays 每周二
+func (e *Event) Tuesdays() *Event { return e.Days(time.Tuesday) }
+
+// Wednesdays 每周三
+func (e *Event) Wednesdays() *Event { return e.Days(time.Wednesday) }
+
+// Thursdays 每周四
+func (e *Event) Thursdays() *Event { return e.Days(time.Thursday) }
+
+// Fridays 每周五
+func (e *Event) Fridays() *Event { return e.Days(time.Friday) }
+
+// Saturdays 每周六
+func (e *Event) Saturdays() *Event { return e.Days(time.Saturday) }
+
+// Sundays 每周日
+func (e *Event) Sundays() *Event { return e.Days(time.Sunday) }
+
+// Days 指定星期
+func (e *Event) Days(days ...time.Weekday) *Event {
+	values := make([]string, len(days))
+	for i, day := range days {
+		values[i] = strconv.Itoa(int(day))
+	}
+	return e.splice(5, strings.Join(values, ","))
+}
+
+// Weekly 每周日零点
+func (e *Event) Weekly() *Event { return e.splice(1, "0").splice(2, "0").splice(5, "0") }
+
+// WeeklyOn 每周的指定星期和时间
+func (e *Event) WeeklyOn(day time.Weekday, clock string) *Event {
+	return e.DailyAt(clock).Days(day)
+}
+
+// Monthly 每月 1 日零点
+func (e *Event) Monthly() *Event { return e.splice(1, "0").splice(2, "0").splice(3, "1") }
+
+// MonthlyOn 每月的指定日期和时间
+func (e *Event) MonthlyOn(day int, clock string) *Event {
+	return e.DailyAt(clock).splice(3, strconv.Itoa(day))
+}
+
+// Quarterly 每季度第一天零点
+func (e *Event) Quarterly() *Event {
+	return e.splice(1, "0").splice(2, "0").splice(3, "1").splice(4, "1-12/3")
+}
+
+// Yearly 每年 1 月 1 日零点
+func (e *Event) Yearly() *Event {
+	return e.splice(1, "0").splice(2, "0").splice(3, "1").splice(4, "1")
+}
+
+// Timezone 设置计算执行时间使用的时区，默认使用 Schedule 的时区
+func (e *Event) Timezone(location *time.Location) *Event {
+	e.location = location
+	return e
+}
+
+// Between 只在每天的 start 到 end 之间执行，格式为 "HH:MM"，支持跨越午夜
+func (e *Event) Between(start, end string) *Event {
+	return e.When(func(ctx context.Context) bool { return e.inTimeInterval(start, end) })
+}
+
+// UnlessBetween 不在每天的 start 到 end 之间执行
+func (e *Event) UnlessBetween(start, end string) *Event {
+	return e.Skip(func(ctx context.Context) bool { return e.inTimeInterval(start, end) })
+}
+
+// When 回调返回 true 时才执行
+func (e *Event) When(filter func(ctx context.Context) bool) *Event {
+	e.filters = append(e.filters, filter)
+	return e
+}
+
+// Skip 回调返回 true 时跳过
+func (e *Event) Skip(reject func(ctx context.Context) bool) *Event {
+	e.rejects = append(e.rejects, reject)
+	return e
+}
+
+// WithoutOverlapping 上一次执行未结束时跳过本次执行
+//
+// 通过 Schedule 的缓存锁实现，expiresAt 为锁的过期时间（默认 24 小时），
+// 防止进程崩溃后锁永远不被释放。
+func (e *Event) WithoutOverlapping(expiresAt ...time.Duration) *Event {
+	e.withoutOverlapping = true
+	if len(expiresAt) > 0 && expiresAt[0] > 0 {
+		e.expiresAt = expiresAt[0]
+	}
+	return e
+}
+
+// OnOneServer 多台服务器同时运行 schedule:run 时只在其中一台执行
+//
+// 需要所有服务器共享同一个缓存存储（如 Redis）。
+func (e *Event) OnOneServer() *Event {
+	e.onOneServer = true
+	return e
+}
+
+// RunInBackground 在后台 goroutine 中执行，不阻塞同一分钟内的其他任务
+func (e *Event) RunInBackground() *Event {
+	e.background = true
+	return e
+}
+
+// Name 设置任务名称，回调任务使用 WithoutOverlapping 或 OnOneServer 时需要设置
+func (e *Event) Name(name string) *Event {
+	e.description = name
+	return e
+}
+
+// Description 设置任务描述，与 Name 相同
+func (e *Event) Description(description string) *Event {
+	return e.Name(description)
+}
+
+// Before 执行前调用
+func (e *Event) Before(callback func(ctx context.Context)) *Event {
+	e.before = append(e.before, callback)
+	return e
+}
+
+// After 执行后调用，无论成功或失败
+func (e *Event) After(callback func(ctx context.Context, err error)) *Event {
+	e.after = append(e.after, callback)
+	return e
+}
+
+// OnSuccess 执行成功后调用
+func (e *Event) OnSuccess(callback func(ctx context.Context)) *Event {
+	e.onSuccess = append(e.onSuccess, callback)
+	return e
+}
+
+// OnFailure 执行失败后调用
+func (e *Event) OnFailure(callback func(ctx context.Context, err error)) *Event {
+	e.onFailure = append(e.onFailure, callback)
+	return e
+}
+
+// GetSummary 任务摘要，优先使用名称，其次使用命令
+func (e *Event) GetSummary() string {
+	if e.description != "" {
+		return e.description
+	}
+	return e.command
+}
+
+// flags 执行约束的描述，用于 schedule:list
+func (e *Event) flags() []string {
+	var flags []string
+	if e.withoutOverlapping {
+		flags = append(flags, "without overlapping")
+	}
+	if e.onOneServer {
+		flags = append(flags, "one server")
+	}
+	if e.background {
+		flags = append(flags, "background")
+	}
+	return flags
+}
+
+// Location 计算执行时间使用的时区
+func (e *Event) Location() *time.Location {
+	if e.location != nil {
+		return e.location
+	}
+	return e.schedule.location
+}
+
+// IsDue 在指定时间是否应该执行（只检查 cron 表达式）
+func (e *Event) IsDue(now time.Time) bool {
+	cron, err := ParseCron(e.expression)
+	if err != nil {
+		return false
+	}
+	return cron.Matches(now.In(e.Location()))
+}
+
+// NextRunDate 下一次执行时间
+func (e *Event) NextRunDate(now time.Time) (time.Time, error) {
+	cron, err := ParseCron(e.expression)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cron.Next(now.In(e.Location())), nil
+}
+
+// FiltersPass When 和 Skip 条件是否通过
+func (e *Event) FiltersPass(ctx context.Context) bool {
+	for _, filter := range e.filters {
+		if !filter(ctx) {
+			return false
+		}
+	}
+	for _, reject := range e.rejects {
+		if reject(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+// MutexName 防重叠锁的名称
+func (e *Event) MutexName() string {
+	sum := sha1.Sum([]byte(e.expression + e.GetSummary()))
+	return "framework/schedule-" + hex.EncodeToString(sum[:])
+}
+
+// Run 执行任务
+//
+// WithoutOverlapping 的任务在上一次执行未结束时返回 ErrOverlapping；
+// RunInBackground 的任务立即返回，执行结果通过钩子获取。
+func (e *Event) Run(ctx context.Context) error {
+	release := func() {}
+	if e.withoutOverlapping {
+		locks := e.schedule.lockProvider()
+		lock := locks.Lock(e.MutexName(), e.expiresAt)
+		acquired, err := lock.Get(ctx)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			return ErrOverlapping
+		}
+		release = func() { _, _ = lock.Release(context.WithoutCancel(ctx)) }
+	}
+
+	if e.background {
+		e.schedule.wg.Add(1)
+		go func() {
+			defer e.schedule.wg.Done()
+			defer release()
+			_ = e.execute(ctx)
+		}()
+		return nil
+	}
+	defer release()
+	return e.execute(ctx)
+}
+
+// execute 调用钩子和任务
+func (e *Event) execute(ctx context.Context) (err error) {
+	for _, callback := range e.before {
+		callback(ctx)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("schedule: %s panicked: %v", e.GetSummary(), r)
+		}
+		for _, callback := range e.after {
+			callback(ctx, err)
+		}
+		if err != nil {
+			for _, callback := range e.onFailure {
+				callback(ctx, err)
+			}
+			return
+		}
+		for _, callback := range e.onSuccess {
+			callback(ctx)
+		}
+	}()
+	return e.run(ctx)
+}
+
+// inTimeInterval 当前时间是否在每天的时间区间内
+func (e *Event) inTimeInterval(start, end string) bool {
+	now := e.schedule.now().In(e.Location())
+	current := now.Hour()*60 + now.Minute()
+	from, to := clockMinutes(start), clockMinutes(end)
+	if from <= to {
+		return current >= from && current <= to
+	}
+	return current >= from || current <= to
+}
+
+// parseClock 解析 "13:00" 为小时和分钟字段
+func parseClock(clock string) (string, string) {
+	parts := strings.SplitN(clock, ":", 2)
+	hour, _ := strconv.Atoi(parts[0])
+	minute := 0
+	if len(parts) == 2 {
+		minute, _ = strconv.Atoi(parts[1])
+	}
+	return strconv.Itoa(hour), strconv.Itoa(minute)
+}
+
+// clockMinutes 将 "13:30" 转换为当天的分钟数
+func clockMinutes(clock string) int {
+	hour, minute := parseClock(clock)
+	h, _ := strconv.Atoi(hour)
+	m, _ := strconv.Atoi(minute)
+	return h*60 + m
+}
@@ -0,0 +1,52 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// ScheduleBuilder 声明计划任务的接口，由 *Schedule 实现
+type ScheduleBuilder interface {
+	// UseTimezone 设置所有任务的默认时区
+	UseTimezone(location *time.Location) *Schedule
+
+	// Command 调度 Artisan 命令
+	Command(command string, parameters ...map[string]interface{}) *Event
+
+	// Exec 调度外部命令
+	Exec(name string, args ...string) *Event
+
+	// Call 调度回调
+	Call(callback func(ctx context.Context) error) *Event
+
+	// Job 调度队列任务
+	Job(job queue.Job, queueName ...string) *Event
+}
+
+var _ ScheduleBuilder = (*Schedule)(nil)
+
+// Kernel 声明计划任务的控制台内核，对应 Laravel Console\Kernel 的 schedule 方法
+//
+// application 包不能依赖 schedule 包，因此 Schedule 钩子定义在此处，
+// 应用的控制台内核在实现 application.ConsoleKernel 的同时实现该方法：
+//
+//	func (k *ConsoleKernel) Schedule(s schedule.ScheduleBuilder) {
+//		s.Command("emails:send").DailyAt("13:00").WithoutOverlapping()
+//		s.Job(&GenerateReports{}).Hourly().OnOneServer()
+//	}
+type Kernel interface {
+	application.ConsoleKernel
+
+	// Schedule 声明计划任务
+	Schedule(schedule ScheduleBuilder)
+}
+
+// FromKernel 创建调度器，以 kernel 执行 Artisan 命令并通过 kernel.Schedule 声明任务
+func FromKernel(kernel Kernel) *Schedule {
+	s := NewSchedule().SetConsole(kernel)
+	kernel.Schedule(s)
+	return s
+}
@@ -0,0 +1,260 @@
+// Package schedule 提供 Laravel 风格的任务调度
+//
+// 在代码中定义计划任务，由 cron 每分钟运行一次 schedule:run，
+// 或者长期运行 schedule:work，调度器在每分钟开始时执行到期的任务。
+//
+// 主要特性：
+// - Artisan 命令、外部命令、回调和队列任务调度
+// - cron 表达式解析和链式频率方法（DailyAt、Weekdays、EveryFiveMinutes 等）
+// - 按任务或全局设置时区
+// - WithoutOverlapping 防重叠、OnOneServer 单服务器执行（基于缓存锁）
+// - RunInBackground 后台执行
+// - Before、After、OnSuccess、OnFailure 钩子
+// - schedule:run、schedule:work、schedule:list 控制台命令
+// - 在控制台内核的 Schedule 方法中声明任务
+//
+// 包结构：
+// - schedule.go - Schedule 调度器
+// - event.go - Event 计划任务和频率方法
+// - cron.go - CronExpression cron 表达式解析
+// - command.go - schedule:run、schedule:work、schedule:list 命令
+// - kernel.go - ScheduleBuilder 和控制台内核的 Schedule 钩子
+//
+// 使用示例：
+//
+//	s := schedule.NewSchedule()
+//	s.SetConsole(consoleKernel)
+//	s.SetCache(redisStore) // OnOneServer 需要共享的缓存
+//
+//	s.Command("emails:send", map[string]interface{}{"--force": true}).DailyAt("13:00")
+//	s.Call(func(ctx context.Context) error {
+//		return db.WithContext(ctx).Where("created_at < ?", time.Now().AddDate(0, 0, -30)).Delete(&Log{}).Error()
+//	}).Name("prune-logs").Daily().WithoutOverlapping().OnOneServer()
+//	s.Job(&GenerateReports{}, "reports").Weekdays().Hourly().Between("8:00", "18:00")
+//	s.Exec("/usr/local/bin/backup.sh").Timezone(shanghai).At("3:00").RunInBackground().
+//		OnFailure(func(ctx context.Context, err error) { alert(err) })
+//
+//	schedule.RegisterCommands(artisan, s)
+//
+//	// 或者在控制台内核中声明任务
+//	s := schedule.FromKernel(consoleKernel)
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// Schedule 调度器
+type Schedule struct {
+	mu       sync.Mutex
+	events   []*Event
+	location *time.Location
+	console  application.ConsoleKernel
+	queue    queue.Manager
+	locks    cache.LockProvider
+	now      func() time.Time
+	wg       sync.WaitGroup
+}
+
+// fallbackLocks 未设置缓存时使用的进程内锁
+var fallbackLocks = cache.NewMemoryStore()
+
+// NewSchedule 创建调度器，默认使用本地时区
+func NewSchedule() *Schedule {
+	return &Schedule{location: time.Local, now: time.Now}
+}
+
+// UseTimezone 设置所有任务的默认时区
+func (s *Schedule) UseTimezone(location *time.Location) *Schedule {
+	s.location = location
+	return s
+}
+
+// SetConsole 设置执行 Artisan 命令的控制台内核
+func (s *Schedule) SetConsole(console application.ConsoleKernel) *Schedule {
+	s.console = console
+	return s
+}
+
+// SetQueue 设置投递队列任务使用的队列管理器，未设置时使用 queue.DefaultManager
+func (s *Schedule) SetQueue(manager queue.Manager) *Schedule {
+	s.queue = manager
+	return s
+}
+
+// SetCache 设置 WithoutOverlapping 和 OnOneServer 使用的锁
+//
+// 未设置时使用进程内存锁，OnOneServer 只能在单个进程内生效。
+func (s *Schedule) SetCache(locks cache.LockProvider) *Schedule {
+	s.locks = locks
+	return s
+}
+
+// SetClock 设置判断任务到期和记录运行时间使用的时钟，nil 恢复为 time.Now，用于测试
+func (s *Schedule) SetClock(now func() time.Time) *Schedule {
+	if now == nil {
+		now = time.Now
+	}
+	s.now = now
+	return s
+}
+
+// lockProvider 获取锁提供者
+func (s *Schedule) lockProvider() cache.LockProvider {
+	if s.locks != nil {
+		return s.locks
+	}
+	return fallbackLocks
+}
+
+// add 注册任务
+func (s *Schedule) add(event *Event) *Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return event
+}
+
+// Command 调度 Artisan 命令，通过 SetConsole 设置的控制台内核调用，退出码非 0 视为失败
+func (s *Schedule) Command(command string, parameters ...map[string]interface{}) *Event {
+	var params map[string]interface{}
+	if len(parameters) > 0 {
+		params = parameters[0]
+	}
+	return s.add(newEvent(s, command, func(ctx context.Context) error {
+		if s.console == nil {
+			return errors.New("schedule: no console kernel, call Schedule.SetConsole")
+		}
+		code, err := s.console.Call(command, params)
+		if err != nil {
+			return err
+		}
+		if code != 0 {
+			return fmt.Errorf("schedule: command %s exited with code %d", command, code)
+		}
+		return nil
+	}))
+}
+
+// Exec 调度外部命令，失败时错误信息包含命令输出
+func (s *Schedule) Exec(name string, args ...string) *Event {
+	summary := strings.TrimSpace(name + " " + strings.Join(args, " "))
+	return s.add(newEvent(s, summary, func(ctx context.Context) error {
+		output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("schedule: %s: %w: %s", summary, err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}))
+}
+
+// Call 调度回调
+func (s *Schedule) Call(callback func(ctx context.Context) error) *Event {
+	return s.add(newEvent(s, "Closure", callback))
+}
+
+// Job 调度队列任务，到期时将任务投递到队列
+//
+// 连接取自任务的 queue.QueueRoute，queueName 为空时使用任务指定的队列或默认队列。
+func (s *Schedule) Job(job queue.Job, queueName ...string) *Event {
+	return s.add(newEvent(s, queue.JobName(job), func(ctx context.Context) error {
+		manager := s.queue
+		if manager == nil {
+			manager = queue.DefaultManager()
+		}
+		if manager == nil {
+			return queue.ErrNoManager
+		}
+		var connection, name string
+		if route, ok := job.(queue.QueueRoute); ok {
+			connection, name = route.ViaConnection(), route.ViaQueue()
+		}
+		if len(queueName) > 0 && queueName[0] != "" {
+			name = queueName[0]
+		}
+		var names []string
+		if connection != "" {
+			names = append(names, connection)
+		}
+		q, err := manager.Connection(names...)
+		if err != nil {
+			return err
+		}
+		_, err = q.Push(ctx, job, name)
+		return err
+	}))
+}
+
+// Events 所有任务
+func (s *Schedule) Events() []*Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Event{}, s.events...)
+}
+
+// DueEvents 在指定时间到期的任务（只检查 cron 表达式）
+func (s *Schedule) DueEvents(now time.Time) []*Event {
+	var due []*Event
+	for _, event := range s.Events() {
+		if event.IsDue(now) {
+			due = append(due, event)
+		}
+	}
+	return due
+}
+
+// RunResult 一个任务的执行结果
+type RunResult struct {
+	Event    *Event
+	Skipped  bool
+	Err      error
+	Duration time.Duration
+}
+
+// RunDueEvents 执行指定时间到期的任务
+//
+// 条件不满足、已在其他服务器执行或上一次执行未结束的任务标记为 Skipped。
+// 后台任务不等待完成，调用 Wait 等待。
+func (s *Schedule) RunDueEvents(ctx context.Context, now time.Time) []RunResult {
+	var results []RunResult
+	for _, event := range s.DueEvents(now) {
+		if !event.FiltersPass(ctx) {
+			results = append(results, RunResult{Event: event, Skipped: true})
+			continue
+		}
+		if event.onOneServer && !s.claimServer(ctx, event, now) {
+			results = append(results, RunResult{Event: event, Skipped: true})
+			continue
+		}
+		start := time.Now()
+		err := event.Run(ctx)
+		result := RunResult{Event: event, Err: err, Duration: time.Since(start)}
+		if errors.Is(err, ErrOverlapping) {
+			result.Skipped, result.Err = true, nil
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// claimServer 为本分钟的执行取得锁，锁不主动释放，过期前其他服务器无法取得
+func (s *Schedule) claimServer(ctx context.Context, event *Event, now time.Time) bool {
+	name := event.MutexName() + now.In(event.Location()).Format("200601021504")
+	acquired, err := s.lockProvider().Lock(name, time.Hour).Get(ctx)
+	return err == nil && acquired
+}
+
+// Wait 等待所有后台任务完成
+func (s *Schedule) Wait() {
+	s.wg.Wait()
+}
@@ -0,0 +1,223 @@
+package scout
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Builder 搜索构建器，对应 Laravel Scout 的 Builder
+//
+// 构建器不是并发安全的，每次搜索创建一个。
+type Builder struct {
+	scout *Scout
+	model Searchable
+	query Query
+}
+
+// Within 使用自定义索引代替模型的 SearchableAs
+func (b *Builder) Within(index string) *Builder {
+	b.query.Index = index
+	return b
+}
+
+// Where 添加等值过滤，字段需要在引擎中可过滤（例如 Meilisearch 的 filterableAttributes）
+func (b *Builder) Where(field string, value interface{}) *Builder {
+	if b.query.Wheres == nil {
+		b.query.Wheres = make(map[string]interface{})
+	}
+	b.query.Wheres[field] = value
+	return b
+}
+
+// WhereIn 添加包含过滤
+func (b *Builder) WhereIn(field string, values ...interface{}) *Builder {
+	if b.query.WhereIns == nil {
+		b.query.WhereIns = make(map[string][]interface{})
+	}
+	b.query.WhereIns[field] = values
+	return b
+}
+
+// OrderBy 添加排序，direction 为 "desc" 时倒序，否则正序
+func (b *Builder) OrderBy(column string, direction string) *Builder {
+	b.query.Orders = append(b.query.Orders, Order{Column: column, Descending: strings.EqualFold(direction, "desc")})
+	return b
+}
+
+// Latest 按字段倒序，默认为 created_at
+func (b *Builder) Latest(column ...string) *Builder {
+	return b.OrderBy(firstOr(column, "created_at"), "desc")
+}
+
+// Oldest 按字段正序，默认为 created_at
+func (b *Builder) Oldest(column ...string) *Builder {
+	return b.OrderBy(firstOr(column, "created_at"), "asc")
+}
+
+// Take 限制返回的数量
+func (b *Builder) Take(limit int) *Builder {
+	b.query.Limit = limit
+	return b
+}
+
+// Query 交给引擎的搜索条件
+func (b *Builder) Query() Query {
+	return b.query
+}
+
+// Raw 执行搜索，返回引擎的结果
+func (b *Builder) Raw(ctx context.Context) (Results, error) {
+	if b.scout.config.Engine == nil {
+		return Results{}, ErrNoEngine
+	}
+	return b.scout.config.Engine.Search(ctx, b.query)
+}
+
+// Keys 执行搜索，只返回匹配的主键
+func (b *Builder) Keys(ctx context.Context) ([]string, error) {
+	results, err := b.Raw(ctx)
+	return results.IDs, err
+}
+
+// Get 执行搜索，把匹配的模型从数据库回填到 dest（模型切片的指针），顺序与引擎结果一致，
+// 索引中存在但数据库中已删除的模型被忽略
+func (b *Builder) Get(ctx context.Context, dest interface{}) error {
+	results, err := b.Raw(ctx)
+	if err != nil {
+		return err
+	}
+	return b.scout.hydrate(ctx, b.query.KeyName, results.IDs, dest)
+}
+
+// Paginate 分页执行搜索，page 从 1 开始，把当前页的模型回填到 dest
+func (b *Builder) Paginate(ctx context.Context, dest interface{}, perPage int, page int) (*Paginator, error) {
+	if perPage < 1 {
+		perPage = 15
+	}
+	if page < 1 {
+		page = 1
+	}
+	query := b.query
+	query.Limit, query.Offset = perPage, (page-1)*perPage
+	paged := &Builder{scout: b.scout, model: b.model, query: query}
+	results, err := paged.Raw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.scout.hydrate(ctx, query.KeyName, results.IDs, dest); err != nil {
+		return nil, err
+	}
+	return &Paginator{Total: results.Total, PerPage: perPage, CurrentPage: page}, nil
+}
+
+// Paginator 分页信息
+type Paginator struct {
+	// Total 匹配的总数
+	Total int64 `json:"total"`
+
+	// PerPage 每页数量
+	PerPage int `json:"per_page"`
+
+	// CurrentPage 当前页，从 1 开始
+	CurrentPage int `json:"current_page"`
+}
+
+// LastPage 最后一页，没有结果时为 1
+func (p *Paginator) LastPage() int {
+	if p.Total <= 0 {
+		return 1
+	}
+	return int((p.Total + int64(p.PerPage) - 1) / int64(p.PerPage))
+}
+
+// HasMorePages 是否还有下一页
+func (p *Paginator) HasMorePages() bool {
+	return p.CurrentPage < p.LastPage()
+}
+
+// hydrate 按主键从数据库取回模型，按 ids 的顺序写入 dest
+func (s *Scout) hydrate(ctx context.Context, keyName string, ids []string, dest interface{}) error {
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return ErrInvalidDest
+	}
+	slice = slice.Elem()
+	if len(ids) == 0 {
+		slice.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+		return nil
+	}
+	if s.config.DB == nil {
+		return ErrNoDB
+	}
+	if err := s.config.DB.WithContext(ctx).Where(keyName+" IN ?", keyArgs(ids)).Find(dest).Error(); err != nil {
+		return err
+	}
+
+	position := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if _, ok := position[id]; !ok {
+			position[id] = i
+		}
+	}
+	type found struct {
+		index int
+		value reflect.Value
+	}
+	models := make([]found, 0, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		item := slice.Index(i)
+		model, ok := searchableOf(item)
+		if !ok {
+			return ErrInvalidDest
+		}
+		if index, ok := position[model.ScoutKey()]; ok {
+			models = append(models, found{index: index, value: item})
+		}
+	}
+	sort.SliceStable(models, func(i, j int) bool { return models[i].index < models[j].index })
+	ordered := reflect.MakeSlice(slice.Type(), len(models), len(models))
+	for i, model := range models {
+		ordered.Index(i).Set(model.value)
+	}
+	slice.Set(ordered)
+	return nil
+}
+
+// keyArgs 查询参数形式的主键：全部是整数时转换为 int64，以匹配整数主键列的类型，否则保持字符串
+func keyArgs(ids []string) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			for j, id := range ids {
+				args[j] = id
+			}
+			return args
+		}
+		args[i] = n
+	}
+	return args
+}
+
+// searchableOf 切片元素（模型值或模型指针）对应的 Searchable
+func searchableOf(item reflect.Value) (Searchable, bool) {
+	if model, ok := item.Interface().(Searchable); ok {
+		return model, true
+	}
+	if item.CanAddr() {
+		model, ok := item.Addr().Interface().(Searchable)
+		return model, ok
+	}
+	return nil, false
+}
+
+// firstOr 可选参数的第一个值，没有时为 fallback
+func firstOr(values []string, fallback string) string {
+	if len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return fallback
+}
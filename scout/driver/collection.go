@@ -0,0 +1,188 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/scout"
+)
+
+// CollectionEngine 内存搜索引擎，对应 Laravel Scout 的 collection 驱动，适合测试和本地开发
+//
+// 搜索词不区分大小写地匹配任意字段的字符串形式，Where 和 WhereIn 按字符串形式比较，
+// 没有排序时按主键倒序（数字主键按数值比较）。
+type CollectionEngine struct {
+	mu      sync.RWMutex
+	indexes map[string]map[string]scout.Document
+}
+
+var _ scout.Engine = (*CollectionEngine)(nil)
+
+// NewCollectionEngine 创建内存引擎
+func NewCollectionEngine() *CollectionEngine {
+	return &CollectionEngine{indexes: make(map[string]map[string]scout.Document)}
+}
+
+// Update 写入或替换文档
+func (e *CollectionEngine) Update(ctx context.Context, index string, documents []scout.Document) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	documentsByID, ok := e.indexes[index]
+	if !ok {
+		documentsByID = make(map[string]scout.Document)
+		e.indexes[index] = documentsByID
+	}
+	for _, document := range documents {
+		documentsByID[document.ID] = document
+	}
+	return nil
+}
+
+// Delete 按主键删除文档
+func (e *CollectionEngine) Delete(ctx context.Context, index string, ids []string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, id := range ids {
+		delete(e.indexes[index], id)
+	}
+	return nil
+}
+
+// Search 在内存中过滤、排序和分页
+func (e *CollectionEngine) Search(ctx context.Context, query scout.Query) (scout.Results, error) {
+	e.mu.RLock()
+	var matched []scout.Document
+	for _, document := range e.indexes[query.Index] {
+		if collectionMatches(document, query) {
+			matched = append(matched, document)
+		}
+	}
+	e.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		for _, order := range query.Orders {
+			c := compareValues(matched[i].Fields[order.Column], matched[j].Fields[order.Column])
+			if c == 0 {
+				continue
+			}
+			if order.Descending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return compareValues(matched[i].ID, matched[j].ID) > 0
+	})
+
+	results := scout.Results{Total: int64(len(matched)), Raw: matched}
+	start := query.Offset
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := len(matched)
+	if query.Limit > 0 && start+query.Limit < end {
+		end = start + query.Limit
+	}
+	results.IDs = make([]string, 0, end-start)
+	for _, document := range matched[start:end] {
+		results.IDs = append(results.IDs, document.ID)
+	}
+	return results, nil
+}
+
+// Flush 清空索引
+func (e *CollectionEngine) Flush(ctx context.Context, index string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.indexes, index)
+	return nil
+}
+
+// Documents 索引中的全部文档，用于测试断言
+func (e *CollectionEngine) Documents(index string) []scout.Document {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	documents := make([]scout.Document, 0, len(e.indexes[index]))
+	for _, document := range e.indexes[index] {
+		documents = append(documents, document)
+	}
+	sort.Slice(documents, func(i, j int) bool { return compareValues(documents[i].ID, documents[j].ID) < 0 })
+	return documents
+}
+
+// collectionMatches 文档是否满足搜索词和过滤条件
+func collectionMatches(document scout.Document, query scout.Query) bool {
+	for field, value := range query.Wheres {
+		if fmt.Sprint(document.Fields[field]) != fmt.Sprint(value) {
+			return false
+		}
+	}
+	for field, values := range query.WhereIns {
+		actual := fmt.Sprint(document.Fields[field])
+		found := false
+		for _, value := range values {
+			if actual == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if query.Search == "" {
+		return true
+	}
+	search := strings.ToLower(query.Search)
+	for _, value := range document.Fields {
+		if strings.Contains(strings.ToLower(fmt.Sprint(value)), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareValues 比较两个值，都能解析为数字时按数值比较，否则按字符串比较
+func compareValues(a, b interface{}) int {
+	sa, sb := fmt.Sprint(a), fmt.Sprint(b)
+	fa, errA := strconv.ParseFloat(sa, 64)
+	fb, errB := strconv.ParseFloat(sb, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(sa, sb)
+}
+
+// NullEngine 丢弃所有写入、搜索结果为空的引擎，用于关闭搜索
+type NullEngine struct{}
+
+var _ scout.Engine = NullEngine{}
+
+// Update 不做任何事
+func (NullEngine) Update(ctx context.Context, index string, documents []scout.Document) error {
+	return nil
+}
+
+// Delete 不做任何事
+func (NullEngine) Delete(ctx context.Context, index string, ids []string) error {
+	return nil
+}
+
+// Search 返回空结果
+func (NullEngine) Search(ctx context.Context, query scout.Query) (scout.Results, error) {
+	return scout.Results{IDs: []string{}}, nil
+}
+
+// Flush 不做任何事
+func (NullEngine) Flush(ctx context.Context, index string) error {
+	return nil
+}
@@ -0,0 +1,121 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/scout"
+)
+
+// DatabaseEngine 直接查询模型表的搜索引擎，对应 Laravel Scout 的 database 驱动
+//
+// 索引名称即表名，搜索词在 Query.Columns（模型 ToSearchableArray 的键，需要是表中的列）上
+// 执行 LIKE '%词%'，没有排序时按主键倒序。数据本来就在表中，Update、Delete、Flush 不做任何事。
+type DatabaseEngine struct {
+	db database.DB
+}
+
+var _ scout.Engine = (*DatabaseEngine)(nil)
+
+// NewDatabaseEngine 创建 database 引擎
+func NewDatabaseEngine(db database.DB) *DatabaseEngine {
+	return &DatabaseEngine{db: db}
+}
+
+// Update 数据已在表中，不做任何事
+func (e *DatabaseEngine) Update(ctx context.Context, index string, documents []scout.Document) error {
+	return nil
+}
+
+// Delete 数据已在表中，不做任何事
+func (e *DatabaseEngine) Delete(ctx context.Context, index string, ids []string) error {
+	return nil
+}
+
+// Search 在表上执行 LIKE 查询
+func (e *DatabaseEngine) Search(ctx context.Context, query scout.Query) (scout.Results, error) {
+	keyName := query.KeyName
+	if keyName == "" {
+		keyName = scout.DefaultKeyName
+	}
+
+	var total int64
+	if err := e.filtered(ctx, query).Count(&total).Error(); err != nil {
+		return scout.Results{}, err
+	}
+
+	tx := e.filtered(ctx, query)
+	if len(query.Orders) == 0 {
+		tx = tx.Order(keyName + " DESC")
+	}
+	for _, order := range query.Orders {
+		direction := " ASC"
+		if order.Descending {
+			direction = " DESC"
+		}
+		tx = tx.Order(order.Column + direction)
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+	if query.Offset > 0 {
+		tx = tx.Offset(query.Offset)
+	}
+	var keys []interface{}
+	if err := tx.Pluck(keyName, &keys).Error(); err != nil {
+		return scout.Results{}, err
+	}
+	results := scout.Results{IDs: make([]string, len(keys)), Total: total, Raw: keys}
+	for i, key := range keys {
+		if raw, ok := key.([]byte); ok {
+			key = string(raw)
+		}
+		results.IDs[i] = keyString(key)
+	}
+	return results, nil
+}
+
+// Flush 数据在表中，不做任何事
+func (e *DatabaseEngine) Flush(ctx context.Context, index string) error {
+	return nil
+}
+
+// filtered 应用搜索词和过滤条件的查询
+func (e *DatabaseEngine) filtered(ctx context.Context, query scout.Query) database.DB {
+	tx := e.db.WithContext(ctx).Table(query.Index)
+	if query.Search != "" && len(query.Columns) > 0 {
+		pattern := "%" + escapeLike(query.Search) + "%"
+		clauses := make([]string, len(query.Columns))
+		args := make([]interface{}, len(query.Columns))
+		for i, column := range query.Columns {
+			clauses[i] = column + " LIKE ?"
+			args[i] = pattern
+		}
+		tx = tx.Where("("+strings.Join(clauses, " OR ")+")", args...)
+	}
+	for _, field := range sortedKeys(query.Wheres) {
+		tx = tx.Where(fmt.Sprintf("%s = ?", field), query.Wheres[field])
+	}
+	for _, field := range sortedKeys(query.WhereIns) {
+		tx = tx.Where(fmt.Sprintf("%s IN ?", field), query.WhereIns[field])
+	}
+	return tx
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// sortedKeys map 的键，排序以保证生成的 SQL 稳定
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,167 @@
+// Package driver 提供 scout 包 Engine 的实现
+//
+// meilisearch 和 elasticsearch 驱动通过标准库调用各自的 HTTP API；database 驱动不维护独立索引，
+// 直接在模型表的可搜索列上执行 LIKE 查询，适合数据量较小的应用；collection 驱动把文档保存在内存中，
+// 适合测试和本地开发；null 驱动丢弃所有写入，搜索结果为空。
+//
+// 包结构：
+// - driver.go - NewEngine 按配置创建引擎和配置读取辅助函数
+// - meilisearch.go - MeilisearchEngine
+// - elasticsearch.go - ElasticsearchEngine
+// - database.go - DatabaseEngine
+// - collection.go - CollectionEngine、NullEngine
+//
+// 配置示例：
+//
+//	engine, err := driver.NewEngine(map[string]interface{}{
+//		"driver": "meilisearch",
+//		"host":   "http://127.0.0.1:7700",
+//		"key":    os.Getenv("MEILISEARCH_KEY"),
+//	})
+//	engine, err := driver.NewEngine(map[string]interface{}{"driver": "database", "db": db})
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/scout"
+)
+
+// 驱动名称
+const (
+	DriverMeilisearch   = "meilisearch"
+	DriverElasticsearch = "elasticsearch"
+	DriverDatabase      = "database"
+	DriverCollection    = "collection"
+	DriverNull          = "null"
+)
+
+// NewEngine 按配置创建搜索引擎，config["driver"] 为驱动名称，其余配置项见各驱动的构造函数
+func NewEngine(config map[string]interface{}) (scout.Engine, error) {
+	switch driver := stringOption(config, "driver", ""); driver {
+	case DriverMeilisearch:
+		return NewMeilisearchEngine(config)
+	case DriverElasticsearch:
+		return NewElasticsearchEngine(config)
+	case DriverDatabase:
+		db, ok := config["db"].(database.DB)
+		if !ok {
+			return nil, fmt.Errorf("scout: database driver config %q must be database.DB, got %T", "db", config["db"])
+		}
+		return NewDatabaseEngine(db), nil
+	case DriverCollection:
+		return NewCollectionEngine(), nil
+	case DriverNull:
+		return NullEngine{}, nil
+	default:
+		return nil, fmt.Errorf("scout: unsupported driver %q", driver)
+	}
+}
+
+// stringOption 读取字符串配置
+func stringOption(config map[string]interface{}, key, fallback string) string {
+	if value, ok := config[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// durationOption 读取时长配置，整数按秒解释
+func durationOption(config map[string]interface{}, key string, fallback time.Duration) time.Duration {
+	switch value := config[key].(type) {
+	case time.Duration:
+		return value
+	case int:
+		return time.Duration(value) * time.Second
+	case string:
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+// httpClientOption 读取 client 配置，没有时按 timeout（默认 30 秒）创建
+func httpClientOption(config map[string]interface{}) *http.Client {
+	if client, ok := config["client"].(*http.Client); ok {
+		return client
+	}
+	return &http.Client{Timeout: durationOption(config, "timeout", 30*time.Second)}
+}
+
+// doJSON 发送 JSON 请求并解码响应，body 为 []byte 时原样发送，out 为 nil 时丢弃响应体
+func doJSON(ctx context.Context, client *http.Client, method, url string, header http.Header, body interface{}, out interface{}) error {
+	var reader io.Reader
+	switch payload := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(payload)
+	default:
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if reader != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("scout: %s %s: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("scout: %s %s responded %s: %s", method, url, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// keyString 文档主键转换为字符串，JSON 数字按整数格式化
+func keyString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		if v == float64(int64(v)) {
+			return fmt.Sprintf("%d", int64(v))
+		}
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// documentBody 文档的请求体：可搜索属性加主键字段
+func documentBody(document scout.Document) map[string]interface{} {
+	body := make(map[string]interface{}, len(document.Fields)+1)
+	for key, value := range document.Fields {
+		body[key] = value
+	}
+	keyName := document.KeyName
+	if keyName == "" {
+		keyName = scout.DefaultKeyName
+	}
+	body[keyName] = document.ID
+	return body
+}
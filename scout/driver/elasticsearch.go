@@ -0,0 +1,187 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/scout"
+)
+
+// ElasticsearchEngine Elasticsearch（或 OpenSearch）搜索引擎
+//
+// 文档以主键作为 _id 写入；搜索词使用 multi_match 匹配全部字段，Where、WhereIn 转换为
+// bool 查询的 term、terms 过滤（字符串字段需要 keyword 类型），OrderBy 转换为 sort。
+type ElasticsearchEngine struct {
+	host   string
+	auth   string
+	client *http.Client
+}
+
+var _ scout.Engine = (*ElasticsearchEngine)(nil)
+
+// NewElasticsearchEngine 创建 Elasticsearch 引擎
+//
+// 配置项：host（默认 http://127.0.0.1:9200）、username 和 password（基本认证）或 api_key、
+// client（*http.Client）、timeout（默认 30 秒）。
+func NewElasticsearchEngine(config map[string]interface{}) (*ElasticsearchEngine, error) {
+	host := strings.TrimSuffix(stringOption(config, "host", "http://127.0.0.1:9200"), "/")
+	if _, err := url.Parse(host); err != nil {
+		return nil, fmt.Errorf("scout: invalid elasticsearch host: %w", err)
+	}
+	e := &ElasticsearchEngine{host: host, client: httpClientOption(config)}
+	if key := stringOption(config, "api_key", ""); key != "" {
+		e.auth = "ApiKey " + key
+	} else if username := stringOption(config, "username", ""); username != "" {
+		e.auth = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+stringOption(config, "password", "")))
+	}
+	return e, nil
+}
+
+// elasticsearchBulkResponse _bulk 响应
+type elasticsearchBulkResponse struct {
+	Errors bool                                `json:"errors"`
+	Items  []map[string]elasticsearchBulkItem `json:"items"`
+}
+
+// elasticsearchBulkItem _bulk 响应中的一项
+type elasticsearchBulkItem struct {
+	ID     string          `json:"_id"`
+	Status int             `json:"status"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// Update 通过 _bulk 写入或替换文档
+func (e *ElasticsearchEngine) Update(ctx context.Context, index string, documents []scout.Document) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, document := range documents {
+		action := map[string]interface{}{"index": map[string]string{"_index": index, "_id": document.ID}}
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+		if err := encoder.Encode(documentBody(document)); err != nil {
+			return err
+		}
+	}
+	return e.bulk(ctx, body.Bytes(), false)
+}
+
+// Delete 通过 _bulk 删除文档，不存在的文档不视为错误
+func (e *ElasticsearchEngine) Delete(ctx context.Context, index string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, id := range ids {
+		if err := encoder.Encode(map[string]interface{}{"delete": map[string]string{"_index": index, "_id": id}}); err != nil {
+			return err
+		}
+	}
+	return e.bulk(ctx, body.Bytes(), true)
+}
+
+// bulk 发送 _bulk 请求，部分失败时返回第一个错误
+func (e *ElasticsearchEngine) bulk(ctx context.Context, body []byte, ignoreMissing bool) error {
+	header := e.header()
+	header.Set("Content-Type", "application/x-ndjson")
+	var response elasticsearchBulkResponse
+	if err := doJSON(ctx, e.client, http.MethodPost, e.host+"/_bulk?refresh=false", header, body, &response); err != nil {
+		return err
+	}
+	if !response.Errors {
+		return nil
+	}
+	for _, item := range response.Items {
+		for action, result := range item {
+			if result.Status == http.StatusNotFound && ignoreMissing {
+				continue
+			}
+			if result.Status >= 300 {
+				return fmt.Errorf("scout: elasticsearch %s %s failed: %s", action, result.ID, result.Error)
+			}
+		}
+	}
+	return nil
+}
+
+// elasticsearchSearchResponse _search 响应
+type elasticsearchSearchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			ID string `json:"_id"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// Search 执行搜索
+func (e *ElasticsearchEngine) Search(ctx context.Context, query scout.Query) (scout.Results, error) {
+	must := []interface{}{map[string]interface{}{"match_all": map[string]interface{}{}}}
+	if query.Search != "" {
+		must = []interface{}{map[string]interface{}{"multi_match": map[string]interface{}{"query": query.Search}}}
+	}
+	filter := []interface{}{}
+	for field, value := range query.Wheres {
+		filter = append(filter, map[string]interface{}{"term": map[string]interface{}{field: value}})
+	}
+	for field, values := range query.WhereIns {
+		filter = append(filter, map[string]interface{}{"terms": map[string]interface{}{field: values}})
+	}
+	body := map[string]interface{}{
+		"query":            map[string]interface{}{"bool": map[string]interface{}{"must": must, "filter": filter}},
+		"from":             query.Offset,
+		"_source":          false,
+		"track_total_hits": true,
+	}
+	if query.Limit > 0 {
+		body["size"] = query.Limit
+	}
+	if len(query.Orders) > 0 {
+		sorts := make([]interface{}, len(query.Orders))
+		for i, order := range query.Orders {
+			direction := "asc"
+			if order.Descending {
+				direction = "desc"
+			}
+			sorts[i] = map[string]interface{}{order.Column: map[string]string{"order": direction}}
+		}
+		body["sort"] = sorts
+	}
+
+	var response elasticsearchSearchResponse
+	if err := doJSON(ctx, e.client, http.MethodPost, e.host+"/"+url.PathEscape(query.Index)+"/_search", e.header(), body, &response); err != nil {
+		return scout.Results{}, err
+	}
+	results := scout.Results{IDs: make([]string, 0, len(response.Hits.Hits)), Total: response.Hits.Total.Value, Raw: response}
+	for _, hit := range response.Hits.Hits {
+		results.IDs = append(results.IDs, hit.ID)
+	}
+	return results, nil
+}
+
+// Flush 删除索引中的全部文档
+func (e *ElasticsearchEngine) Flush(ctx context.Context, index string) error {
+	body := map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}}
+	return doJSON(ctx, e.client, http.MethodPost, e.host+"/"+url.PathEscape(index)+"/_delete_by_query", e.header(), body, nil)
+}
+
+// header 认证请求头
+func (e *ElasticsearchEngine) header() http.Header {
+	header := http.Header{}
+	if e.auth != "" {
+		header.Set("Authorization", e.auth)
+	}
+	return header
+}
@@ -0,0 +1,161 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/scout"
+)
+
+// MeilisearchEngine Meilisearch 搜索引擎
+//
+// Where、WhereIn 转换为 filter 表达式，OrderBy 转换为 sort，对应字段需要在索引设置中
+// 加入 filterableAttributes、sortableAttributes。写入是异步的，Meilisearch 返回任务后即视为成功。
+type MeilisearchEngine struct {
+	host   string
+	key    string
+	client *http.Client
+}
+
+var _ scout.Engine = (*MeilisearchEngine)(nil)
+
+// NewMeilisearchEngine 创建 Meilisearch 引擎
+//
+// 配置项：host（默认 http://127.0.0.1:7700）、key（主密钥或 API 密钥）、client（*http.Client）、timeout（默认 30 秒）。
+func NewMeilisearchEngine(config map[string]interface{}) (*MeilisearchEngine, error) {
+	host := strings.TrimSuffix(stringOption(config, "host", "http://127.0.0.1:7700"), "/")
+	if _, err := url.Parse(host); err != nil {
+		return nil, fmt.Errorf("scout: invalid meilisearch host: %w", err)
+	}
+	return &MeilisearchEngine{host: host, key: stringOption(config, "key", ""), client: httpClientOption(config)}, nil
+}
+
+// Update 写入或替换文档
+func (e *MeilisearchEngine) Update(ctx context.Context, index string, documents []scout.Document) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	bodies := make([]map[string]interface{}, len(documents))
+	for i, document := range documents {
+		bodies[i] = documentBody(document)
+	}
+	keyName := documents[0].KeyName
+	if keyName == "" {
+		keyName = scout.DefaultKeyName
+	}
+	endpoint := e.indexURL(index) + "/documents?primaryKey=" + url.QueryEscape(keyName)
+	return doJSON(ctx, e.client, http.MethodPost, endpoint, e.header(), bodies, nil)
+}
+
+// Delete 按主键删除文档
+func (e *MeilisearchEngine) Delete(ctx context.Context, index string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return doJSON(ctx, e.client, http.MethodPost, e.indexURL(index)+"/documents/delete-batch", e.header(), ids, nil)
+}
+
+// meilisearchResponse 搜索响应
+type meilisearchResponse struct {
+	Hits               []map[string]interface{} `json:"hits"`
+	EstimatedTotalHits int64                    `json:"estimatedTotalHits"`
+	TotalHits          *int64                   `json:"totalHits"`
+}
+
+// Search 执行搜索
+func (e *MeilisearchEngine) Search(ctx context.Context, query scout.Query) (scout.Results, error) {
+	keyName := query.KeyName
+	if keyName == "" {
+		keyName = scout.DefaultKeyName
+	}
+	body := map[string]interface{}{
+		"q":                    query.Search,
+		"attributesToRetrieve": []string{keyName},
+		"offset":               query.Offset,
+	}
+	if query.Limit > 0 {
+		body["limit"] = query.Limit
+	}
+	if filters := meilisearchFilters(query); len(filters) > 0 {
+		body["filter"] = filters
+	}
+	if len(query.Orders) > 0 {
+		sorts := make([]string, len(query.Orders))
+		for i, order := range query.Orders {
+			direction := "asc"
+			if order.Descending {
+				direction = "desc"
+			}
+			sorts[i] = order.Column + ":" + direction
+		}
+		body["sort"] = sorts
+	}
+
+	var response meilisearchResponse
+	if err := doJSON(ctx, e.client, http.MethodPost, e.indexURL(query.Index)+"/search", e.header(), body, &response); err != nil {
+		return scout.Results{}, err
+	}
+	results := scout.Results{IDs: make([]string, 0, len(response.Hits)), Total: response.EstimatedTotalHits, Raw: response}
+	if response.TotalHits != nil {
+		results.Total = *response.TotalHits
+	}
+	for _, hit := range response.Hits {
+		results.IDs = append(results.IDs, keyString(hit[keyName]))
+	}
+	return results, nil
+}
+
+// Flush 删除索引中的全部文档
+func (e *MeilisearchEngine) Flush(ctx context.Context, index string) error {
+	return doJSON(ctx, e.client, http.MethodDelete, e.indexURL(index)+"/documents", e.header(), nil, nil)
+}
+
+// indexURL 索引的 API 地址
+func (e *MeilisearchEngine) indexURL(index string) string {
+	return e.host + "/indexes/" + url.PathEscape(index)
+}
+
+// header 认证请求头
+func (e *MeilisearchEngine) header() http.Header {
+	header := http.Header{}
+	if e.key != "" {
+		header.Set("Authorization", "Bearer "+e.key)
+	}
+	return header
+}
+
+// meilisearchFilters 把等值和包含过滤转换为 filter 表达式，按字段排序以保证请求稳定
+func meilisearchFilters(query scout.Query) []string {
+	var filters []string
+	for field, value := range query.Wheres {
+		filters = append(filters, field+" = "+meilisearchValue(value))
+	}
+	for field, values := range query.WhereIns {
+		items := make([]string, len(values))
+		for i, value := range values {
+			items[i] = meilisearchValue(value)
+		}
+		filters = append(filters, field+" IN ["+strings.Join(items, ", ")+"]")
+	}
+	sort.Strings(filters)
+	return filters
+}
+
+// meilisearchValue filter 表达式中的值，字符串加引号
+func meilisearchValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,71 @@
+package scout
+
+import "context"
+
+// Engine 搜索引擎接口，对应 Laravel Scout 的 Engine
+//
+// 同一次 Update 的文档属于同一个索引。Search 只返回匹配的主键，模型由 Builder 从数据库回填。
+type Engine interface {
+	// Update 写入或替换文档
+	Update(ctx context.Context, index string, documents []Document) error
+
+	// Delete 按主键删除文档
+	Delete(ctx context.Context, index string, ids []string) error
+
+	// Search 执行搜索
+	Search(ctx context.Context, query Query) (Results, error)
+
+	// Flush 清空索引
+	Flush(ctx context.Context, index string) error
+}
+
+// Order 排序条件
+type Order struct {
+	// Column 字段
+	Column string
+
+	// Descending 是否倒序
+	Descending bool
+}
+
+// Query 交给引擎的搜索条件
+type Query struct {
+	// Index 索引名称
+	Index string
+
+	// KeyName 主键字段名
+	KeyName string
+
+	// Search 搜索词，为空时匹配全部
+	Search string
+
+	// Columns 可搜索的字段（模型 ToSearchableArray 的键），database 驱动在这些列上执行 LIKE
+	Columns []string
+
+	// Wheres 等值过滤
+	Wheres map[string]interface{}
+
+	// WhereIns 包含过滤
+	WhereIns map[string][]interface{}
+
+	// Orders 排序，为空时按相关度
+	Orders []Order
+
+	// Limit 最多返回的数量，0 表示由引擎决定
+	Limit int
+
+	// Offset 跳过的数量
+	Offset int
+}
+
+// Results 搜索结果
+type Results struct {
+	// IDs 匹配的主键，按相关度或 Orders 排序
+	IDs []string
+
+	// Total 匹配的总数（不受 Limit、Offset 影响），部分引擎为估计值
+	Total int64
+
+	// Raw 引擎的原始响应
+	Raw interface{}
+}
@@ -0,0 +1,133 @@
+// Package scout 提供 Laravel Scout 风格的模型全文搜索
+//
+// 模型实现 Searchable 后，Scout 在模型保存、删除时把模型的可搜索属性同步到搜索引擎
+// （直接同步或通过队列），Search 构建搜索条件，由引擎返回匹配的主键，再从数据库
+// 取回完整的模型并保持引擎给出的相关度顺序。引擎的实现位于子包 driver。
+//
+// 主要特性：
+// - Searchable 模型契约，可选 ShouldBeSearchable 条件索引和自定义主键名
+// - 模型事件（Saved、Deleted）触发同步，可以投递到队列异步执行
+// - Search(query).Where(...).WhereIn(...).OrderBy(...).Paginate() 返回数据库中的模型
+// - Import 批量导入已有数据，Flush 清空索引，WithoutSyncing 临时停止同步
+// - meilisearch、elasticsearch、database（SQL LIKE）、collection（内存）、null 驱动
+//
+// 包结构：
+// - scout.go - Searchable 模型契约、Document 文档和默认引擎
+// - engine.go - Engine 搜索引擎接口、Query 搜索条件和 Results 结果
+// - builder.go - Builder 搜索构建器、Paginator 分页结果和模型回填
+// - sync.go - Scout 同步器（模型事件观察者、导入）和 SyncJob 队列任务
+//
+// 使用示例：
+//
+//	type Post struct {
+//		database.Model
+//		Title     string `json:"title"`
+//		Body      string `json:"body"`
+//		Published bool   `json:"published"`
+//	}
+//
+//	func (p *Post) SearchableAs() string { return "posts" }
+//	func (p *Post) ScoutKey() string     { return strconv.FormatUint(uint64(p.ID), 10) }
+//	func (p *Post) ToSearchableArray() map[string]interface{} {
+//		return map[string]interface{}{"title": p.Title, "body": p.Body, "published": p.Published}
+//	}
+//
+//	engine, _ := driver.NewEngine(map[string]interface{}{"driver": "meilisearch", "host": "http://127.0.0.1:7700", "key": key})
+//	scout.SetDefaultEngine(engine) // Worker 进程执行 SyncJob 时使用
+//	s := scout.New(scout.Config{Engine: engine, DB: db, Queue: connection})
+//
+//	// 模型事件中同步
+//	events.AddListener("eloquent.saved", func(event interface{}) error {
+//		e := event.(*ModelEvent)
+//		return s.Saved(e.Context, e.Model.(scout.Searchable))
+//	}, 0)
+//
+//	// 搜索并回填模型
+//	var posts []Post
+//	page, err := s.Search(&Post{}, "laravel").Where("published", true).Paginate(ctx, &posts, 15, 1)
+package scout
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var (
+	// ErrNoEngine 没有设置搜索引擎
+	ErrNoEngine = errors.New("scout: no search engine")
+
+	// ErrNoDB 回填模型时没有设置数据库
+	ErrNoDB = errors.New("scout: no database to hydrate models")
+
+	// ErrInvalidDest 回填目标不是模型切片的指针
+	ErrInvalidDest = errors.New("scout: destination must be a pointer to a slice of searchable models")
+)
+
+// DefaultKeyName 默认的主键字段名
+const DefaultKeyName = "id"
+
+// Searchable 可搜索模型，相当于 Laravel 的 Searchable trait
+type Searchable interface {
+	// SearchableAs 索引名称，通常是表名
+	SearchableAs() string
+
+	// ScoutKey 模型主键，使用字符串以兼容 UUID 主键
+	ScoutKey() string
+
+	// ToSearchableArray 写入索引的属性
+	ToSearchableArray() map[string]interface{}
+}
+
+// ShouldBeSearchable 有条件索引的模型，返回 false 时模型不写入索引（已写入的会被移除），
+// 例如草稿文章
+type ShouldBeSearchable interface {
+	ShouldBeSearchable() bool
+}
+
+// KeyNamer 自定义主键字段名的模型，默认为 DefaultKeyName
+type KeyNamer interface {
+	ScoutKeyName() string
+}
+
+// Document 写入索引的一条文档
+type Document struct {
+	// KeyName 主键字段名
+	KeyName string `json:"key_name"`
+
+	// ID 主键
+	ID string `json:"id"`
+
+	// Fields 可搜索属性
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// DocumentFor 模型对应的文档
+func DocumentFor(model Searchable) Document {
+	return Document{KeyName: KeyName(model), ID: model.ScoutKey(), Fields: model.ToSearchableArray()}
+}
+
+// KeyName 模型的主键字段名
+func KeyName(model Searchable) string {
+	if namer, ok := model.(KeyNamer); ok {
+		if name := namer.ScoutKeyName(); name != "" {
+			return name
+		}
+	}
+	return DefaultKeyName
+}
+
+// defaultEngine 默认搜索引擎
+var defaultEngine atomic.Value
+
+// SetDefaultEngine 设置默认搜索引擎，排队的同步任务由 Worker 通过它写入索引
+func SetDefaultEngine(engine Engine) {
+	defaultEngine.Store(&engine)
+}
+
+// DefaultEngine 获取默认搜索引擎，未设置时返回 nil
+func DefaultEngine() Engine {
+	if engine, ok := defaultEngine.Load().(*Engine); ok {
+		return *engine
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package scout
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync/atomic"
+
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+func init() {
+	queue.Register(&SyncJob{})
+}
+
+// DefaultChunkSize Import 每批读取的模型数量
+const DefaultChunkSize = 500
+
+// Config Scout 配置，对应 config/scout.php
+type Config struct {
+	// Engine 搜索引擎
+	Engine Engine
+
+	// DB 回填模型和 Import 使用的数据库
+	DB database.DB
+
+	// Queue 同步使用的队列连接，为 nil 时在当前 goroutine 中同步
+	Queue queue.Queue
+
+	// QueueName 队列名称，为空时使用连接的默认队列
+	QueueName string
+
+	// ChunkSize Import 每批读取的模型数量，默认 DefaultChunkSize
+	ChunkSize int
+}
+
+// Scout 搜索同步器和搜索入口
+//
+// Saved、Deleted 是模型事件观察者，在监听器中调用；MakeSearchable、Unsearchable 手动同步。
+// 设置了 Queue 时同步被投递为 SyncJob，文档在投递时计算，Worker 通过 DefaultEngine 写入索引。
+type Scout struct {
+	config   Config
+	disabled atomic.Int32
+}
+
+// New 创建 Scout
+func New(config Config) *Scout {
+	if config.ChunkSize <= 0 {
+		config.ChunkSize = DefaultChunkSize
+	}
+	return &Scout{config: config}
+}
+
+// Engine 搜索引擎
+func (s *Scout) Engine() Engine {
+	return s.config.Engine
+}
+
+// Search 创建模型的搜索构建器，model 只用于取得索引名称、主键名和可搜索字段
+func (s *Scout) Search(model Searchable, query string) *Builder {
+	fields := model.ToSearchableArray()
+	columns := make([]string, 0, len(fields))
+	for column := range fields {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return &Builder{scout: s, model: model, query: Query{
+		Index:   model.SearchableAs(),
+		KeyName: KeyName(model),
+		Search:  query,
+		Columns: columns,
+	}}
+}
+
+// Saved 模型创建或更新后同步到索引，ShouldBeSearchable 返回 false 时从索引移除
+func (s *Scout) Saved(ctx context.Context, model Searchable) error {
+	return s.MakeSearchable(ctx, model)
+}
+
+// Deleted 模型删除后从索引移除
+func (s *Scout) Deleted(ctx context.Context, model Searchable) error {
+	return s.Unsearchable(ctx, model)
+}
+
+// Restored 软删除模型恢复后重新同步到索引
+func (s *Scout) Restored(ctx context.Context, model Searchable) error {
+	return s.MakeSearchable(ctx, model)
+}
+
+// MakeSearchable 把模型写入索引，按索引分组；ShouldBeSearchable 返回 false 的模型从索引移除
+func (s *Scout) MakeSearchable(ctx context.Context, models ...Searchable) error {
+	if !s.IsSyncingEnabled() {
+		return nil
+	}
+	for _, group := range groupByIndex(models) {
+		job := &SyncJob{Index: group.index}
+		for _, model := range group.models {
+			if conditional, ok := model.(ShouldBeSearchable); ok && !conditional.ShouldBeSearchable() {
+				job.Deletes = append(job.Deletes, model.ScoutKey())
+				continue
+			}
+			job.Documents = append(job.Documents, DocumentFor(model))
+		}
+		if err := s.dispatch(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unsearchable 把模型从索引移除
+func (s *Scout) Unsearchable(ctx context.Context, models ...Searchable) error {
+	if !s.IsSyncingEnabled() {
+		return nil
+	}
+	for _, group := range groupByIndex(models) {
+		job := &SyncJob{Index: group.index}
+		for _, model := range group.models {
+			job.Deletes = append(job.Deletes, model.ScoutKey())
+		}
+		if err := s.dispatch(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Import 分批读取 model 对应表中的全部记录并写入索引，返回导入的数量，对应 scout:import
+//
+// model 为模型指针，例如 &Post{}，用于确定模型类型；读取时使用 model 的表。
+func (s *Scout) Import(ctx context.Context, model Searchable) (int, error) {
+	if s.config.DB == nil {
+		return 0, ErrNoDB
+	}
+	modelType := reflect.TypeOf(model)
+	batch := reflect.New(reflect.SliceOf(modelType))
+	imported := 0
+	var syncErr error
+	err := s.config.DB.WithContext(ctx).Model(model).FindInBatches(batch.Interface(), s.config.ChunkSize, func(tx database.DB, _ int) error {
+		items := batch.Elem()
+		models := make([]Searchable, 0, items.Len())
+		for i := 0; i < items.Len(); i++ {
+			if m, ok := searchableOf(items.Index(i)); ok {
+				models = append(models, m)
+			}
+		}
+		if syncErr = s.MakeSearchable(ctx, models...); syncErr != nil {
+			return syncErr
+		}
+		imported += len(models)
+		return nil
+	}).Error()
+	if syncErr != nil {
+		return imported, syncErr
+	}
+	return imported, err
+}
+
+// Flush 清空模型的索引，对应 scout:flush
+func (s *Scout) Flush(ctx context.Context, model Searchable) error {
+	if s.config.Engine == nil {
+		return ErrNoEngine
+	}
+	return s.config.Engine.Flush(ctx, model.SearchableAs())
+}
+
+// IsSyncingEnabled 是否启用同步
+func (s *Scout) IsSyncingEnabled() bool {
+	return s.disabled.Load() == 0
+}
+
+// WithoutSyncing 在不同步索引的情况下执行回调，例如批量导入后统一 Import
+func (s *Scout) WithoutSyncing(callback func() error) error {
+	s.disabled.Add(1)
+	defer s.disabled.Add(-1)
+	return callback()
+}
+
+// dispatch 同步执行或投递同步任务
+func (s *Scout) dispatch(ctx context.Context, job *SyncJob) error {
+	if len(job.Documents) == 0 && len(job.Deletes) == 0 {
+		return nil
+	}
+	if s.config.Queue != nil {
+		_, err := s.config.Queue.Push(ctx, job, s.config.QueueName)
+		return err
+	}
+	if s.config.Engine == nil {
+		return ErrNoEngine
+	}
+	return job.apply(ctx, s.config.Engine)
+}
+
+// indexGroup 同一索引的模型
+type indexGroup struct {
+	index  string
+	models []Searchable
+}
+
+// groupByIndex 按索引分组，保持首次出现的顺序
+func groupByIndex(models []Searchable) []indexGroup {
+	var groups []indexGroup
+	positions := make(map[string]int)
+	for _, model := range models {
+		if model == nil {
+			continue
+		}
+		index := model.SearchableAs()
+		position, ok := positions[index]
+		if !ok {
+			position = len(groups)
+			positions[index] = position
+			groups = append(groups, indexGroup{index: index})
+		}
+		groups[position].models = append(groups[position].models, model)
+	}
+	return groups
+}
+
+// SyncJob 同步索引的队列任务
+//
+// 文档在投递时计算，Worker 通过 DefaultEngine 获取搜索引擎，因此 Worker 进程需要调用 SetDefaultEngine。
+type SyncJob struct {
+	queue.Queueable
+
+	// Index 索引名称
+	Index string `json:"index"`
+
+	// Documents 写入的文档
+	Documents []Document `json:"documents,omitempty"`
+
+	// Deletes 删除的主键
+	Deletes []string `json:"deletes,omitempty"`
+}
+
+// JobName 固定任务名称
+func (j *SyncJob) JobName() string {
+	return "scout.SyncJob"
+}
+
+// Handle 写入索引
+func (j *SyncJob) Handle(ctx context.Context) error {
+	engine := DefaultEngine()
+	if engine == nil {
+		return ErrNoEngine
+	}
+	return j.apply(ctx, engine)
+}
+
+// apply 写入和删除文档
+func (j *SyncJob) apply(ctx context.Context, engine Engine) error {
+	if len(j.Documents) > 0 {
+		if err := engine.Update(ctx, j.Index, j.Documents); err != nil {
+			return err
+		}
+	}
+	if len(j.Deletes) > 0 {
+		return engine.Delete(ctx, j.Index, j.Deletes)
+	}
+	return nil
+}
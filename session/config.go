@@ -0,0 +1,120 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrInvalidConfig 会话配置无效，NewConfig 和 Config.Validate 返回的错误满足 errors.Is
+var ErrInvalidConfig = errors.New("session: invalid config")
+
+// Option 会话配置选项
+type Option func(*Config)
+
+// WithCookie 会话 Cookie 名称
+func WithCookie(name string) Option {
+	return func(c *Config) {
+		c.Cookie = name
+	}
+}
+
+// WithLifetime 会话有效期
+func WithLifetime(lifetime time.Duration) Option {
+	return func(c *Config) {
+		c.Lifetime = lifetime
+	}
+}
+
+// WithExpireOnClose 浏览器关闭时会话 Cookie 失效
+func WithExpireOnClose() Option {
+	return func(c *Config) {
+		c.ExpireOnClose = true
+	}
+}
+
+// WithPath Cookie 路径
+func WithPath(path string) Option {
+	return func(c *Config) {
+		c.Path = path
+	}
+}
+
+// WithDomain Cookie 域名
+func WithDomain(domain string) Option {
+	return func(c *Config) {
+		c.Domain = domain
+	}
+}
+
+// WithSecure 只通过 HTTPS 发送 Cookie
+func WithSecure() Option {
+	return func(c *Config) {
+		c.Secure = true
+	}
+}
+
+// WithSameSite Cookie 的 SameSite 属性
+func WithSameSite(sameSite http.SameSite) Option {
+	return func(c *Config) {
+		c.SameSite = sameSite
+	}
+}
+
+// NewConfig 应用选项、校验会话配置并补全缺省值
+//
+// 校验在补全缺省值之前进行，负的有效期等无效值返回错误，而不是被缺省值覆盖。
+//
+// 示例：
+//
+//	config, err := session.NewConfig(session.WithLifetime(2*time.Hour), session.WithSecure())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sessions := session.NewManager(session.NewArrayHandler(), config)
+func NewConfig(opts ...Option) (Config, error) {
+	config := Config{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+	return config.withDefaults(), nil
+}
+
+// withDefaults 补全缺省值，只替换零值
+func (c Config) withDefaults() Config {
+	if c.Cookie == "" {
+		c.Cookie = "laraveldoc_session"
+	}
+	if c.Lifetime == 0 {
+		c.Lifetime = 120 * time.Minute
+	}
+	if c.Path == "" {
+		c.Path = "/"
+	}
+	if c.SameSite == 0 {
+		c.SameSite = http.SameSiteLaxMode
+	}
+	return c
+}
+
+// Validate 校验会话配置，用于直接构造的 Config
+//
+// 浏览器拒绝没有 Secure 的 SameSite=None Cookie，这种组合返回错误。
+func (c Config) Validate() error {
+	switch {
+	case c.Cookie != "" && strings.ContainsAny(c.Cookie, " \t\r\n\"(),/:;<=>?@[\\]{}"):
+		return fmt.Errorf("%w: cookie name %q contains invalid characters", ErrInvalidConfig, c.Cookie)
+	case c.Lifetime < 0:
+		return fmt.Errorf("%w: lifetime must not be negative", ErrInvalidConfig)
+	case c.Path != "" && !strings.HasPrefix(c.Path, "/"):
+		return fmt.Errorf("%w: cookie path %q must start with /", ErrInvalidConfig, c.Path)
+	case c.SameSite == http.SameSiteNoneMode && !c.Secure:
+		return fmt.Errorf("%w: SameSite=None cookies must be secure", ErrInvalidConfig)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package session
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// KeyToken 会话中保存 CSRF 令牌的键，也是表单中令牌字段的名称
+const KeyToken = "_token"
+
+// ErrTokenMismatch 请求的 CSRF 令牌与会话不一致，对应 Laravel 的 TokenMismatchException（419）
+var ErrTokenMismatch = errors.New("session: CSRF token mismatch")
+
+// Token 会话的 CSRF 令牌，没有时生成，对应 Laravel 的 csrf_token()
+func (s *Store) Token() string {
+	if s == nil {
+		return ""
+	}
+	if token, ok := s.Get(KeyToken).(string); ok && token != "" {
+		return token
+	}
+	return s.RegenerateToken()
+}
+
+// RegenerateToken 重新生成 CSRF 令牌，登录和登出后调用
+func (s *Store) RegenerateToken() string {
+	token := newID()
+	s.Put(KeyToken, token)
+	return token
+}
+
+// VerifyCSRFToken CSRF 令牌校验中间件，对应 Laravel 的 VerifyCsrfToken
+//
+// 需要放在 Manager.Middleware 之内。GET、HEAD、OPTIONS 请求和 Except 中的路径不校验；
+// 其他请求的令牌依次从表单字段 _token、X-CSRF-TOKEN 和 X-XSRF-TOKEN 请求头读取，
+// 与会话的令牌不一致时以 ErrTokenMismatch 调用 OnMismatch。
+// 每个响应都写入 XSRF-TOKEN Cookie，前端可以读取后放入 X-XSRF-TOKEN 请求头。
+type VerifyCSRFToken struct {
+	// Except 不校验的路径，支持 * 通配符，例如 "/webhooks/*"
+	Except []string
+
+	// OnMismatch 令牌不一致时的处理函数，为空时响应 419 Page Expired；
+	// 交给 exceptions.Handler.Render 时渲染为 419 错误页或 problem details
+	OnMismatch func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// Middleware 校验 CSRF 令牌
+func (v *VerifyCSRFToken) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		store := FromContext(r.Context())
+		if v.reading(r) || v.excepted(r) || tokensMatch(store, r) {
+			if store != nil {
+				http.SetCookie(w, &http.Cookie{Name: "XSRF-TOKEN", Value: store.Token(), Path: "/", SameSite: http.SameSiteLaxMode})
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		if v.OnMismatch != nil {
+			v.OnMismatch(w, r, ErrTokenMismatch)
+			return
+		}
+		http.Error(w, "Page Expired", 419)
+	})
+}
+
+// reading 是否为不修改数据的请求
+func (v *VerifyCSRFToken) reading(r *http.Request) bool {
+	return r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+}
+
+// excepted 请求路径是否在 Except 中
+func (v *VerifyCSRFToken) excepted(r *http.Request) bool {
+	for _, pattern := range v.Except {
+		if pattern != "/" {
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		if str.Is(pattern, r.URL.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokensMatch 请求的令牌是否与会话的令牌一致
+func tokensMatch(store *Store, r *http.Request) bool {
+	expected, _ := store.Get(KeyToken).(string)
+	if expected == "" {
+		return false
+	}
+	token := r.FormValue(KeyToken)
+	if token == "" {
+		token = r.Header.Get("X-CSRF-TOKEN")
+	}
+	if token == "" {
+		token = r.Header.Get("X-XSRF-TOKEN")
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
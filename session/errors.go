@@ -0,0 +1,119 @@
+package session
+
+import "sort"
+
+// ErrorBag 字段到错误信息的映射，对应 Laravel 视图中的 $errors
+//
+// 重定向的 WithErrors 把错误信息闪存为 ErrorBag，下一个请求通过 Store.Errors 读取后传给视图：
+//
+//	html, err := views.Render("profile.edit", map[string]interface{}{
+//		"errors": session.FromContext(ctx).Errors(),
+//	})
+//
+//	{{if .errors.Has "email"}}<p class="error">{{.errors.First "email"}}</p>{{end}}
+type ErrorBag map[string][]string
+
+// Has 字段是否有错误信息
+func (b ErrorBag) Has(field string) bool {
+	return len(b[field]) > 0
+}
+
+// First 字段的第一条错误信息，field 为空时返回所有字段中按字段名排序的第一条
+func (b ErrorBag) First(field string) string {
+	if field == "" {
+		if all := b.All(); len(all) > 0 {
+			return all[0]
+		}
+		return ""
+	}
+	if messages := b[field]; len(messages) > 0 {
+		return messages[0]
+	}
+	return ""
+}
+
+// Get 字段的所有错误信息
+func (b ErrorBag) Get(field string) []string {
+	return b[field]
+}
+
+// All 所有错误信息，按字段名排序
+func (b ErrorBag) All() []string {
+	var messages []string
+	for _, field := range b.Keys() {
+		messages = append(messages, b[field]...)
+	}
+	return messages
+}
+
+// Keys 有错误信息的字段，按字段名排序
+func (b ErrorBag) Keys() []string {
+	keys := make([]string, 0, len(b))
+	for field, messages := range b {
+		if len(messages) > 0 {
+			keys = append(keys, field)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Any 是否有任何错误信息
+func (b ErrorBag) Any() bool {
+	return len(b.Keys()) > 0
+}
+
+// Count 错误信息的条数
+func (b ErrorBag) Count() int {
+	count := 0
+	for _, messages := range b {
+		count += len(messages)
+	}
+	return count
+}
+
+// Add 为字段添加错误信息
+func (b ErrorBag) Add(field, message string) ErrorBag {
+	b[field] = append(b[field], message)
+	return b
+}
+
+// Merge 返回合并了 other 的新 ErrorBag，不修改 b
+func (b ErrorBag) Merge(other ErrorBag) ErrorBag {
+	merged := make(ErrorBag, len(b)+len(other))
+	for field, messages := range b {
+		merged[field] = append([]string(nil), messages...)
+	}
+	for field, messages := range other {
+		merged[field] = append(merged[field], messages...)
+	}
+	return merged
+}
+
+// toErrorBag 把会话中保存的错误信息还原为 ErrorBag，兼容 JSON 编码的会话存储
+func toErrorBag(value interface{}) ErrorBag {
+	switch v := value.(type) {
+	case ErrorBag:
+		return v
+	case map[string][]string:
+		return ErrorBag(v)
+	case map[string]interface{}:
+		bag := make(ErrorBag, len(v))
+		for field, messages := range v {
+			switch m := messages.(type) {
+			case []string:
+				bag[field] = m
+			case []interface{}:
+				for _, message := range m {
+					if message, ok := message.(string); ok {
+						bag[field] = append(bag[field], message)
+					}
+				}
+			case string:
+				bag[field] = []string{m}
+			}
+		}
+		return bag
+	}
+	return ErrorBag{}
+}
@@ -0,0 +1,82 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Handler 会话存储，对应 PHP 的 SessionHandlerInterface
+//
+// Read 在会话不存在或已过期时返回 nil 和 nil 错误。
+// 以 JSON 等格式编码的存储还原的数据是解码结果，Store 的闪存、旧输入和错误信息都能识别这种表示。
+type Handler interface {
+	// Read 读取会话数据
+	Read(ctx context.Context, id string) (map[string]interface{}, error)
+
+	// Write 写入会话数据，lifetime 后过期
+	Write(ctx context.Context, id string, data map[string]interface{}, lifetime time.Duration) error
+
+	// Destroy 删除会话
+	Destroy(ctx context.Context, id string) error
+}
+
+// ArrayHandler 内存会话存储，用于测试和单进程开发环境
+type ArrayHandler struct {
+	mu       sync.Mutex
+	sessions map[string]arraySession
+	now      func() time.Time
+}
+
+// arraySession 内存中的会话
+type arraySession struct {
+	data      map[string]interface{}
+	expiresAt time.Time
+}
+
+var _ Handler = (*ArrayHandler)(nil)
+
+// NewArrayHandler 创建内存会话存储
+func NewArrayHandler() *ArrayHandler {
+	return &ArrayHandler{sessions: make(map[string]arraySession), now: time.Now}
+}
+
+// Read 读取会话数据的副本
+func (h *ArrayHandler) Read(ctx context.Context, id string) (map[string]interface{}, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	session, ok := h.sessions[id]
+	if !ok {
+		return nil, nil
+	}
+	if !h.now().Before(session.expiresAt) {
+		delete(h.sessions, id)
+		return nil, nil
+	}
+	return copyData(session.data), nil
+}
+
+// Write 写入会话数据的副本
+func (h *ArrayHandler) Write(ctx context.Context, id string, data map[string]interface{}, lifetime time.Duration) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sessions[id] = arraySession{data: copyData(data), expiresAt: h.now().Add(lifetime)}
+	return nil
+}
+
+// Destroy 删除会话
+func (h *ArrayHandler) Destroy(ctx context.Context, id string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.sessions, id)
+	return nil
+}
+
+// copyData 复制顶层数据
+func copyData(data map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		copied[key] = value
+	}
+	return copied
+}
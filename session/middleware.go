@@ -0,0 +1,181 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Config 会话 Cookie 和有效期配置，对应 config/session.php
+//
+// 可以用 NewConfig 和 With* 选项创建，缺省值与 NewManager 相同，无效的组合返回 ErrInvalidConfig。
+type Config struct {
+	// Cookie 会话 Cookie 名称，默认 "laraveldoc_session"
+	Cookie string
+
+	// Lifetime 会话有效期，默认 120 分钟
+	Lifetime time.Duration
+
+	// ExpireOnClose 浏览器关闭时会话 Cookie 失效，不设置 Cookie 的过期时间
+	ExpireOnClose bool
+
+	// Path Cookie 路径，默认 "/"
+	Path string
+
+	// Domain Cookie 域名
+	Domain string
+
+	// Secure 只通过 HTTPS 发送 Cookie
+	Secure bool
+
+	// SameSite Cookie 的 SameSite 属性，默认 Lax
+	SameSite http.SameSite
+}
+
+// Manager 会话管理器，负责读取、保存会话和会话 Cookie
+type Manager struct {
+	handler Handler
+	config  Config
+}
+
+// NewManager 创建会话管理器，config 的零值字段使用缺省值
+//
+// NewManager 不校验配置，直接构造的 Config 应先调用 Validate。
+func NewManager(handler Handler, config Config) *Manager {
+	return &Manager{handler: handler, config: config.withDefaults()}
+}
+
+// Config 会话配置
+func (m *Manager) Config() Config {
+	return m.config
+}
+
+// Load 读取会话，id 为空、格式不正确或会话不存在时创建新会话
+func (m *Manager) Load(ctx context.Context, id string) (*Store, error) {
+	if !validID(id) {
+		return NewStore("", nil), nil
+	}
+	data, err := m.handler.Read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return NewStore("", nil), nil
+	}
+	return NewStore(id, data), nil
+}
+
+// Save 保存会话，previousID 不为空且与当前标识符不同（会话重新生成过）时删除旧会话
+func (m *Manager) Save(ctx context.Context, store *Store, previousID string) error {
+	if previousID != "" && previousID != store.ID() {
+		if err := m.handler.Destroy(ctx, previousID); err != nil {
+			return err
+		}
+	}
+	return m.handler.Write(ctx, store.ID(), store.All(), m.config.Lifetime)
+}
+
+// Cookie 会话 Cookie
+func (m *Manager) Cookie(store *Store) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     m.config.Cookie,
+		Value:    store.ID(),
+		Path:     m.config.Path,
+		Domain:   m.config.Domain,
+		Secure:   m.config.Secure,
+		HttpOnly: true,
+		SameSite: m.config.SameSite,
+	}
+	if !m.config.ExpireOnClose {
+		cookie.MaxAge = int(m.config.Lifetime / time.Second)
+	}
+	return cookie
+}
+
+// Middleware 启动会话的中间件，对应 Laravel 的 StartSession
+//
+// 请求开始时从会话 Cookie 读取会话并放入请求的 context。响应第一次写入前（处理器没有写入时在处理器返回后）：
+// GET 请求且不是 XMLHttpRequest 时把当前 URL 记为上一个 URL，删除上一个请求闪存的数据，
+// 保存会话并写入会话 Cookie。保存失败时返回 500。
+func (m *Manager) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id string
+		if cookie, err := r.Cookie(m.config.Cookie); err == nil {
+			id = cookie.Value
+		}
+		store, err := m.Load(r.Context(), id)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		sw := &sessionWriter{ResponseWriter: w}
+		sw.commit = func() {
+			if r.Method == http.MethodGet && r.Header.Get("X-Requested-With") != "XMLHttpRequest" {
+				store.SetPreviousURL(fullURL(r))
+			}
+			store.AgeFlashData()
+			if err := m.Save(r.Context(), store, id); err != nil {
+				sw.failed = true
+				return
+			}
+			http.SetCookie(w, m.Cookie(store))
+		}
+		next.ServeHTTP(sw, r.WithContext(WithStore(r.Context(), store)))
+		sw.once.Do(sw.commit)
+		if sw.failed && !sw.wrote {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	})
+}
+
+// sessionWriter 在响应第一次写入前保存会话并写入会话 Cookie
+type sessionWriter struct {
+	http.ResponseWriter
+	once   sync.Once
+	commit func()
+	failed bool
+	wrote  bool
+}
+
+// WriteHeader 保存会话后写入状态码
+func (w *sessionWriter) WriteHeader(status int) {
+	w.once.Do(w.commit)
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write 保存会话后写入内容
+func (w *sessionWriter) Write(b []byte) (int, error) {
+	w.once.Do(w.commit)
+	w.wrote = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap 供 http.ResponseController 访问底层的 ResponseWriter
+func (w *sessionWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// fullURL 请求的完整 URL
+func fullURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// validID 会话标识符是否为 40 个十六进制字符
+func validID(id string) bool {
+	if len(id) != 40 {
+		return false
+	}
+	for _, c := range id {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,358 @@
+// Package session 提供 Laravel 风格的 HTTP 会话，对应 Illuminate\Session
+//
+// Store 保存一个会话的数据，通过 context.Context 传递；Manager.Middleware 为每个请求
+// 从 Handler 读取会话、在响应中写入会话 Cookie，并在请求结束后保存。
+// 闪存数据（Flash）只保留到下一个请求结束，重定向携带的提示信息、旧输入和错误信息都以闪存保存。
+//
+// 每个请求只应由一个 goroutine 修改会话；Store 的方法并发安全，并且都可以在 nil 上调用：
+// 读取返回空值，写入被忽略，没有启用会话的请求不需要额外判断。
+//
+// 包结构：
+// - session.go - Store 会话数据、闪存、旧输入、上一个 URL 和 context 传递
+// - errors.go - ErrorBag 表单错误信息
+// - handler.go - Handler 会话存储接口和 ArrayHandler 内存实现
+// - middleware.go - Manager 会话 Cookie 和 StartSession 中间件
+// - config.go - NewConfig 函数式选项、缺省值和配置校验
+// - csrf.go - CSRF 令牌、VerifyCSRFToken 中间件和 ErrTokenMismatch
+//
+// 使用示例：
+//
+//	sessions := session.NewManager(session.NewArrayHandler(), session.Config{Lifetime: 2 * time.Hour})
+//	http.ListenAndServe(":8080", sessions.Middleware(mux))
+//
+//	func (c *ProfileController) Update(w http.ResponseWriter, r *http.Request) {
+//		store := session.FromContext(r.Context())
+//		store.Flash("status", "Profile updated!")
+//		http.Redirect(w, r, store.PreviousURL("/profile"), http.StatusFound)
+//	}
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/support/arr"
+)
+
+// 会话中框架使用的键
+const (
+	// KeyOldInput 闪存的旧输入
+	KeyOldInput = "_old_input"
+
+	// KeyErrors 闪存的表单错误信息
+	KeyErrors = "errors"
+
+	// KeyPreviousURL 上一个 GET 请求的 URL
+	KeyPreviousURL = "_previous.url"
+
+	keyFlashNew = "_flash.new"
+	keyFlashOld = "_flash.old"
+)
+
+// DefaultErrorBag 默认错误包的名称
+const DefaultErrorBag = "default"
+
+// Store 会话数据
+type Store struct {
+	mu         sync.RWMutex
+	id         string
+	attributes map[string]interface{}
+}
+
+// NewStore 创建会话，id 为空时生成新的会话标识符
+func NewStore(id string, attributes map[string]interface{}) *Store {
+	if id == "" {
+		id = newID()
+	}
+	if attributes == nil {
+		attributes = make(map[string]interface{})
+	}
+	return &Store{id: id, attributes: attributes}
+}
+
+// ID 会话标识符
+func (s *Store) ID() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.id
+}
+
+// Regenerate 生成新的会话标识符并保留数据，登录后调用以防止会话固定攻击
+func (s *Store) Regenerate() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.id = newID()
+	return s.id
+}
+
+// Get 获取数据，支持点号路径，不存在时返回 fallback（默认 nil）
+func (s *Store) Get(key string, fallback ...interface{}) interface{} {
+	if s == nil {
+		return arr.Get(nil, key, fallback...)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return arr.Get(s.attributes, key, fallback...)
+}
+
+// Has 数据是否存在且不为 nil
+func (s *Store) Has(key string) bool {
+	return s.Get(key) != nil
+}
+
+// Put 设置数据
+func (s *Store) Put(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[key] = value
+}
+
+// Pull 获取并删除数据
+func (s *Store) Pull(key string, fallback ...interface{}) interface{} {
+	value := s.Get(key, fallback...)
+	s.Forget(key)
+	return value
+}
+
+// Forget 删除数据
+func (s *Store) Forget(keys ...string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.attributes, key)
+	}
+}
+
+// All 所有数据的副本
+func (s *Store) All() map[string]interface{} {
+	if s == nil {
+		return map[string]interface{}{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	attributes := make(map[string]interface{}, len(s.attributes))
+	for key, value := range s.attributes {
+		attributes[key] = value
+	}
+	return attributes
+}
+
+// Flash 设置只保留到下一个请求结束的数据
+func (s *Store) Flash(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[key] = value
+	s.setKeys(keyFlashNew, appendKey(s.keys(keyFlashNew), key))
+	s.setKeys(keyFlashOld, removeKey(s.keys(keyFlashOld), key))
+}
+
+// Now 设置只在当前请求中可用的数据
+func (s *Store) Now(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[key] = value
+	s.setKeys(keyFlashOld, appendKey(s.keys(keyFlashOld), key))
+}
+
+// Reflash 把上一个请求闪存的数据再保留一个请求
+func (s *Store) Reflash() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := s.keys(keyFlashNew)
+	for _, key := range s.keys(keyFlashOld) {
+		keys = appendKey(keys, key)
+	}
+	s.setKeys(keyFlashNew, keys)
+	s.setKeys(keyFlashOld, nil)
+}
+
+// Keep 把上一个请求闪存的指定数据再保留一个请求
+func (s *Store) Keep(keys ...string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	flashNew, flashOld := s.keys(keyFlashNew), s.keys(keyFlashOld)
+	for _, key := range keys {
+		flashNew = appendKey(flashNew, key)
+		flashOld = removeKey(flashOld, key)
+	}
+	s.setKeys(keyFlashNew, flashNew)
+	s.setKeys(keyFlashOld, flashOld)
+}
+
+// AgeFlashData 删除上一个请求闪存的数据，本次请求闪存的数据保留到下一个请求，由中间件在保存前调用
+func (s *Store) AgeFlashData() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range s.keys(keyFlashOld) {
+		delete(s.attributes, key)
+	}
+	s.setKeys(keyFlashOld, s.keys(keyFlashNew))
+	s.setKeys(keyFlashNew, nil)
+}
+
+// FlashInput 闪存请求输入，下一个请求通过 OldInput 读取
+func (s *Store) FlashInput(input map[string]interface{}) {
+	s.Flash(KeyOldInput, input)
+}
+
+// OldInput 获取闪存的旧输入，支持点号路径，不存在时返回 fallback（默认 nil）
+func (s *Store) OldInput(key string, fallback ...interface{}) interface{} {
+	input, _ := s.Get(KeyOldInput).(map[string]interface{})
+	return arr.Get(input, key, fallback...)
+}
+
+// HasOldInput 是否有旧输入，key 为空时判断是否有任何旧输入
+func (s *Store) HasOldInput(key string) bool {
+	input, _ := s.Get(KeyOldInput).(map[string]interface{})
+	if key == "" {
+		return len(input) > 0
+	}
+	return arr.Has(input, key)
+}
+
+// PreviousURL 上一个 GET 请求的 URL，没有时返回 fallback
+func (s *Store) PreviousURL(fallback string) string {
+	if previous, ok := s.Get(KeyPreviousURL).(string); ok && previous != "" {
+		return previous
+	}
+	return fallback
+}
+
+// SetPreviousURL 设置上一个 URL，由中间件在 GET 请求结束时调用
+func (s *Store) SetPreviousURL(url string) {
+	s.Put(KeyPreviousURL, url)
+}
+
+// Errors 闪存的表单错误信息，没有时返回空的 ErrorBag
+func (s *Store) Errors() ErrorBag {
+	return s.ErrorsIn(DefaultErrorBag)
+}
+
+// FlashErrors 把错误信息合并到闪存的 ErrorBag
+func (s *Store) FlashErrors(errors ErrorBag) {
+	s.FlashErrorsIn(DefaultErrorBag, errors)
+}
+
+// ErrorsIn 命名错误包中闪存的表单错误信息，对应 Laravel 的 $errors->login
+//
+// 同一页面有多个表单时，每个表单的验证错误放在各自的错误包中，bag 为空时为默认错误包。
+func (s *Store) ErrorsIn(bag string) ErrorBag {
+	return toErrorBag(s.Get(errorBagKey(bag)))
+}
+
+// FlashErrorsIn 把错误信息合并到闪存的命名错误包
+func (s *Store) FlashErrorsIn(bag string, errors ErrorBag) {
+	if s == nil {
+		return
+	}
+	merged := s.ErrorsIn(bag).Merge(errors)
+	s.Flash(errorBagKey(bag), merged)
+}
+
+// errorBagKey 错误包在会话中的键，默认错误包为 KeyErrors，其他为 "errors_<bag>"
+func errorBagKey(bag string) string {
+	if bag == "" || bag == DefaultErrorBag {
+		return KeyErrors
+	}
+	return KeyErrors + "_" + bag
+}
+
+// keys 读取键列表，需要持有锁
+func (s *Store) keys(name string) []string {
+	switch keys := s.attributes[name].(type) {
+	case []string:
+		return append([]string(nil), keys...)
+	case []interface{}:
+		// 经过 JSON 编码的会话存储还原的键列表
+		result := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if key, ok := key.(string); ok {
+				result = append(result, key)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// setKeys 写入键列表，需要持有锁
+func (s *Store) setKeys(name string, keys []string) {
+	if len(keys) == 0 {
+		delete(s.attributes, name)
+		return
+	}
+	s.attributes[name] = keys
+}
+
+// appendKey 追加不重复的键
+func appendKey(keys []string, key string) []string {
+	for _, existing := range keys {
+		if existing == key {
+			return keys
+		}
+	}
+	return append(keys, key)
+}
+
+// removeKey 移除键
+func removeKey(keys []string, key string) []string {
+	result := keys[:0]
+	for _, existing := range keys {
+		if existing != key {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+// newID 生成 40 个十六进制字符的会话标识符
+func newID() string {
+	b := make([]byte, 20)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+type storeKey struct{}
+
+// WithStore 返回携带会话的 context
+func WithStore(ctx context.Context, store *Store) context.Context {
+	return context.WithValue(ctx, storeKey{}, store)
+}
+
+// FromContext 获取 context 中的会话，没有时返回 nil
+func FromContext(ctx context.Context) *Store {
+	if ctx == nil {
+		return nil
+	}
+	store, _ := ctx.Value(storeKey{}).(*Store)
+	return store
+}
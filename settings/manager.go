@@ -0,0 +1,121 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/cache"
+)
+
+// Config 设置管理器配置
+type Config struct {
+	// Repository 设置仓库
+	Repository Repository
+
+	// Cache 缓存存储，为 nil 时每次读取都访问仓库
+	Cache cache.Store
+
+	// CacheTTL 缓存时间，0 表示永不过期
+	CacheTTL time.Duration
+
+	// CachePrefix 缓存键前缀，默认为 "settings:"
+	CachePrefix string
+}
+
+// Manager 设置管理器，负责读取、保存和缓存设置
+type Manager struct {
+	config Config
+}
+
+// New 创建设置管理器
+func New(config Config) *Manager {
+	if config.CachePrefix == "" {
+		config.CachePrefix = "settings:"
+	}
+	return &Manager{config: config}
+}
+
+// Repository 设置仓库
+func (m *Manager) Repository() Repository {
+	return m.config.Repository
+}
+
+// Load 从缓存或仓库读取设置，写入 s 的字段
+//
+// 仓库中缺少属性时返回 *MissingSettingsError，通常需要为新属性编写设置迁移。
+func (m *Manager) Load(ctx context.Context, s Settings) error {
+	payloads, err := m.payloads(ctx, s.Group())
+	if err != nil {
+		return err
+	}
+	return decode(s, payloads)
+}
+
+// Save 保存设置的全部属性，并清除该组的缓存
+func (m *Manager) Save(ctx context.Context, s Settings) error {
+	payloads, err := encode(s)
+	if err != nil {
+		return err
+	}
+	if err := m.config.Repository.Update(ctx, s.Group(), payloads); err != nil {
+		return err
+	}
+	return m.Forget(ctx, s.Group())
+}
+
+// Refresh 清除缓存后重新从仓库读取设置
+func (m *Manager) Refresh(ctx context.Context, s Settings) error {
+	if err := m.Forget(ctx, s.Group()); err != nil {
+		return err
+	}
+	return m.Load(ctx, s)
+}
+
+// Forget 清除一组设置的缓存，仓库被其他方式修改（例如设置迁移）后调用
+func (m *Manager) Forget(ctx context.Context, group string) error {
+	if m.config.Cache == nil {
+		return nil
+	}
+	_, err := m.config.Cache.Forget(ctx, m.config.CachePrefix+group)
+	return err
+}
+
+// payloads 读取一组设置的属性，缓存中保存的是整组属性的 JSON
+func (m *Manager) payloads(ctx context.Context, group string) (map[string]json.RawMessage, error) {
+	key := m.config.CachePrefix + group
+	if m.config.Cache != nil {
+		if cached, ok, err := m.config.Cache.Get(ctx, key); err == nil && ok {
+			var payloads map[string]json.RawMessage
+			if raw, ok := cached.(string); ok && json.Unmarshal([]byte(raw), &payloads) == nil {
+				return payloads, nil
+			}
+		}
+	}
+
+	payloads, err := m.config.Repository.Properties(ctx, group)
+	if err != nil {
+		return nil, fmt.Errorf("settings: load %s: %w", group, err)
+	}
+	if m.config.Cache != nil {
+		if encoded, err := json.Marshal(payloads); err == nil {
+			_ = m.config.Cache.Put(ctx, key, string(encoded), m.config.CacheTTL)
+		}
+	}
+	return payloads, nil
+}
+
+// Get 创建并读取一组设置
+//
+//	general, err := settings.Get[GeneralSettings](ctx, manager)
+func Get[T any, PT interface {
+	*T
+	Settings
+}](ctx context.Context, m *Manager) (*T, error) {
+	s := PT(new(T))
+	if err := m.Load(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,125 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Migrator 设置迁移，对应 spatie 的 SettingsMigrator
+//
+// 属性名为 "组.属性" 形式。设置结构体新增字段时，需要在迁移中 Add 对应属性并给出初始值，
+// 否则读取设置会返回 *MissingSettingsError。迁移直接修改仓库，完成后调用 Manager.Forget 清除缓存。
+//
+//	migrator := settings.NewMigrator(repository)
+//	err := migrator.Add(ctx, "general.timezone", "UTC")
+//	err = migrator.Rename(ctx, "general.site_name", "general.app_name")
+//	err = migrator.Update(ctx, "general.max_upload_mb", func(payload json.RawMessage) (interface{}, error) {
+//		var mb int
+//		err := json.Unmarshal(payload, &mb)
+//		return mb * 2, err
+//	})
+type Migrator struct {
+	repository Repository
+}
+
+// NewMigrator 创建设置迁移
+func NewMigrator(repository Repository) *Migrator {
+	return &Migrator{repository: repository}
+}
+
+// Add 添加属性，属性已存在时返回 ErrPropertyExists
+func (m *Migrator) Add(ctx context.Context, name string, value interface{}) error {
+	group, prop, err := splitName(name)
+	if err != nil {
+		return err
+	}
+	exists, err := m.repository.Exists(ctx, group, prop)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrPropertyExists, name)
+	}
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("settings: encode %s: %w", name, err)
+	}
+	return m.repository.Create(ctx, group, prop, payload)
+}
+
+// Exists 属性是否存在
+func (m *Migrator) Exists(ctx context.Context, name string) (bool, error) {
+	group, prop, err := splitName(name)
+	if err != nil {
+		return false, err
+	}
+	return m.repository.Exists(ctx, group, prop)
+}
+
+// Rename 重命名属性，可以移动到其他组，保留原值
+func (m *Migrator) Rename(ctx context.Context, from, to string) error {
+	payload, err := m.payload(ctx, from)
+	if err != nil {
+		return err
+	}
+	toGroup, toProp, err := splitName(to)
+	if err != nil {
+		return err
+	}
+	exists, err := m.repository.Exists(ctx, toGroup, toProp)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrPropertyExists, to)
+	}
+	if err := m.repository.Create(ctx, toGroup, toProp, payload); err != nil {
+		return err
+	}
+	return m.Delete(ctx, from)
+}
+
+// Update 用函数的返回值替换属性的值
+func (m *Migrator) Update(ctx context.Context, name string, fn func(payload json.RawMessage) (interface{}, error)) error {
+	payload, err := m.payload(ctx, name)
+	if err != nil {
+		return err
+	}
+	value, err := fn(payload)
+	if err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("settings: encode %s: %w", name, err)
+	}
+	group, prop, _ := splitName(name)
+	return m.repository.Update(ctx, group, map[string]json.RawMessage{prop: encoded})
+}
+
+// Delete 删除属性，属性不存在时返回 ErrPropertyNotFound
+func (m *Migrator) Delete(ctx context.Context, name string) error {
+	if _, err := m.payload(ctx, name); err != nil {
+		return err
+	}
+	group, prop, _ := splitName(name)
+	return m.repository.Delete(ctx, group, prop)
+}
+
+// payload 读取属性的值，属性不存在时返回 ErrPropertyNotFound
+func (m *Migrator) payload(ctx context.Context, name string) (json.RawMessage, error) {
+	group, prop, err := splitName(name)
+	if err != nil {
+		return nil, err
+	}
+	payloads, err := m.repository.Properties(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	payload, ok := payloads[prop]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPropertyNotFound, name)
+	}
+	return payload, nil
+}
@@ -0,0 +1,294 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// Repository 设置仓库，对应 spatie 的 SettingsRepository
+//
+// 属性值是 JSON 编码后的内容。
+type Repository interface {
+	// Properties 一组设置的全部属性
+	Properties(ctx context.Context, group string) (map[string]json.RawMessage, error)
+
+	// Exists 属性是否存在
+	Exists(ctx context.Context, group, name string) (bool, error)
+
+	// Create 创建属性
+	Create(ctx context.Context, group, name string, payload json.RawMessage) error
+
+	// Update 写入多个属性，不存在的属性被创建
+	Update(ctx context.Context, group string, payloads map[string]json.RawMessage) error
+
+	// Delete 删除属性
+	Delete(ctx context.Context, group, name string) error
+}
+
+// MemoryRepository 内存仓库，适合测试
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	groups map[string]map[string]json.RawMessage
+}
+
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository 创建内存仓库
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{groups: make(map[string]map[string]json.RawMessage)}
+}
+
+// Properties 一组设置的全部属性
+func (r *MemoryRepository) Properties(ctx context.Context, group string) (map[string]json.RawMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	payloads := make(map[string]json.RawMessage, len(r.groups[group]))
+	for name, payload := range r.groups[group] {
+		payloads[name] = append(json.RawMessage(nil), payload...)
+	}
+	return payloads, nil
+}
+
+// Exists 属性是否存在
+func (r *MemoryRepository) Exists(ctx context.Context, group, name string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.groups[group][name]
+	return ok, nil
+}
+
+// Create 创建属性
+func (r *MemoryRepository) Create(ctx context.Context, group, name string, payload json.RawMessage) error {
+	return r.Update(ctx, group, map[string]json.RawMessage{name: payload})
+}
+
+// Update 写入多个属性
+func (r *MemoryRepository) Update(ctx context.Context, group string, payloads map[string]json.RawMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.groups[group] == nil {
+		r.groups[group] = make(map[string]json.RawMessage)
+	}
+	for name, payload := range payloads {
+		r.groups[group][name] = append(json.RawMessage(nil), payload...)
+	}
+	return nil
+}
+
+// Delete 删除属性
+func (r *MemoryRepository) Delete(ctx context.Context, group, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.groups[group], name)
+	return nil
+}
+
+// SettingRecord settings 表结构，与 spatie/laravel-settings 的迁移一致
+type SettingRecord struct {
+	ID        uint   `gorm:"primaryKey"`
+	Group     string `gorm:"column:group;size:255;uniqueIndex:settings_group_name_unique"`
+	Name      string `gorm:"size:255;uniqueIndex:settings_group_name_unique"`
+	Locked    bool   `gorm:"default:false"`
+	Payload   string `gorm:"type:json"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName 表名
+func (SettingRecord) TableName() string {
+	return "settings"
+}
+
+// DatabaseRepository 数据库仓库，每个属性是 settings 表中的一行
+type DatabaseRepository struct {
+	db    database.DB
+	table string
+	now   func() time.Time
+}
+
+var _ Repository = (*DatabaseRepository)(nil)
+
+// NewDatabaseRepository 创建数据库仓库，table 为空时使用 settings
+func NewDatabaseRepository(db database.DB, table string) *DatabaseRepository {
+	if table == "" {
+		table = SettingRecord{}.TableName()
+	}
+	return &DatabaseRepository{db: db, table: table, now: time.Now}
+}
+
+// Migrate 创建 settings 表
+func (r *DatabaseRepository) Migrate(ctx context.Context) error {
+	return r.query(ctx).AutoMigrate(&SettingRecord{})
+}
+
+// Properties 一组设置的全部属性
+func (r *DatabaseRepository) Properties(ctx context.Context, group string) (map[string]json.RawMessage, error) {
+	var records []SettingRecord
+	if err := r.query(ctx).Where("`group` = ?", group).Find(&records).Error(); err != nil {
+		return nil, err
+	}
+	payloads := make(map[string]json.RawMessage, len(records))
+	for _, record := range records {
+		payloads[record.Name] = json.RawMessage(record.Payload)
+	}
+	return payloads, nil
+}
+
+// Exists 属性是否存在
+func (r *DatabaseRepository) Exists(ctx context.Context, group, name string) (bool, error) {
+	var count int64
+	if err := r.query(ctx).Where("`group` = ? AND name = ?", group, name).Count(&count).Error(); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Create 创建属性
+func (r *DatabaseRepository) Create(ctx context.Context, group, name string, payload json.RawMessage) error {
+	now := r.now()
+	record := SettingRecord{Group: group, Name: name, Payload: string(payload), CreatedAt: now, UpdatedAt: now}
+	return r.query(ctx).Create(&record).Error()
+}
+
+// Update 在事务中写入多个属性，不存在的属性被创建
+func (r *DatabaseRepository) Update(ctx context.Context, group string, payloads map[string]json.RawMessage) error {
+	return r.db.WithContext(ctx).Transaction(func(tx database.DB) error {
+		repository := &DatabaseRepository{db: tx, table: r.table, now: r.now}
+		for name, payload := range payloads {
+			result := tx.Table(r.table).Where("`group` = ? AND name = ?", group, name).Updates(map[string]interface{}{
+				"payload":    string(payload),
+				"updated_at": r.now(),
+			})
+			if err := result.Error(); err != nil {
+				return err
+			}
+			if result.RowsAffected() > 0 {
+				continue
+			}
+			if err := repository.Create(ctx, group, name, payload); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete 删除属性
+func (r *DatabaseRepository) Delete(ctx context.Context, group, name string) error {
+	return r.query(ctx).Where("`group` = ? AND name = ?", group, name).Delete(&SettingRecord{}).Error()
+}
+
+// query 表查询
+func (r *DatabaseRepository) query(ctx context.Context) database.DB {
+	return r.db.WithContext(ctx).Table(r.table)
+}
+
+// JSONColumnRepository 把全部设置保存在一行的 JSON 列中，例如租户表的 settings 列
+//
+// 列内容为 {"组名": {"属性名": 值}}。写入是读取、修改、写回，并发修改同一行时后写入的覆盖先写入的。
+type JSONColumnRepository struct {
+	db     database.DB
+	table  string
+	column string
+	key    string
+	id     interface{}
+}
+
+var _ Repository = (*JSONColumnRepository)(nil)
+
+// NewJSONColumnRepository 创建 JSON 列仓库，行由 key = id 定位
+//
+//	repository := settings.NewJSONColumnRepository(db, "tenants", "settings", "id", tenant.ID)
+func NewJSONColumnRepository(db database.DB, table, column, key string, id interface{}) *JSONColumnRepository {
+	return &JSONColumnRepository{db: db, table: table, column: column, key: key, id: id}
+}
+
+// Properties 一组设置的全部属性
+func (r *JSONColumnRepository) Properties(ctx context.Context, group string) (map[string]json.RawMessage, error) {
+	groups, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payloads := groups[group]
+	if payloads == nil {
+		payloads = make(map[string]json.RawMessage)
+	}
+	return payloads, nil
+}
+
+// Exists 属性是否存在
+func (r *JSONColumnRepository) Exists(ctx context.Context, group, name string) (bool, error) {
+	groups, err := r.load(ctx)
+	if err != nil {
+		return false, err
+	}
+	_, ok := groups[group][name]
+	return ok, nil
+}
+
+// Create 创建属性
+func (r *JSONColumnRepository) Create(ctx context.Context, group, name string, payload json.RawMessage) error {
+	return r.Update(ctx, group, map[string]json.RawMessage{name: payload})
+}
+
+// Update 写入多个属性
+func (r *JSONColumnRepository) Update(ctx context.Context, group string, payloads map[string]json.RawMessage) error {
+	return r.modify(ctx, func(groups map[string]map[string]json.RawMessage) {
+		if groups[group] == nil {
+			groups[group] = make(map[string]json.RawMessage)
+		}
+		for name, payload := range payloads {
+			groups[group][name] = payload
+		}
+	})
+}
+
+// Delete 删除属性
+func (r *JSONColumnRepository) Delete(ctx context.Context, group, name string) error {
+	return r.modify(ctx, func(groups map[string]map[string]json.RawMessage) {
+		delete(groups[group], name)
+	})
+}
+
+// load 读取并解析 JSON 列，列为空时返回空映射
+func (r *JSONColumnRepository) load(ctx context.Context) (map[string]map[string]json.RawMessage, error) {
+	var values []string
+	if err := r.query(ctx).Limit(1).Pluck(r.column, &values).Error(); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("settings: no %s row with %s = %v", r.table, r.key, r.id)
+	}
+	groups := make(map[string]map[string]json.RawMessage)
+	if values[0] == "" || values[0] == "null" {
+		return groups, nil
+	}
+	if err := json.Unmarshal([]byte(values[0]), &groups); err != nil {
+		return nil, fmt.Errorf("settings: decode %s.%s: %w", r.table, r.column, err)
+	}
+	return groups, nil
+}
+
+// modify 读取、修改并写回 JSON 列
+func (r *JSONColumnRepository) modify(ctx context.Context, fn func(map[string]map[string]json.RawMessage)) error {
+	groups, err := r.load(ctx)
+	if err != nil {
+		return err
+	}
+	fn(groups)
+	encoded, err := json.Marshal(groups)
+	if err != nil {
+		return err
+	}
+	return r.query(ctx).UpdateColumn(r.column, string(encoded)).Error()
+}
+
+// query 定位到设置所在行的查询
+func (r *JSONColumnRepository) query(ctx context.Context) database.DB {
+	return r.db.WithContext(ctx).Table(r.table).Where(r.key+" = ?", r.id)
+}
@@ -0,0 +1,196 @@
+// Package settings 提供 spatie/laravel-settings 风格的强类型应用设置
+//
+// 一组设置是一个实现 Settings 的结构体，每个导出字段是一个属性，以 JSON 保存在仓库中
+// （settings 表的一行一个属性，或业务表的一个 JSON 列），读取时经过缓存。
+// 属性名取 json 标签，没有标签时为字段名的 snake_case，json:"-" 的字段不保存。
+//
+// 主要特性：
+// - 强类型的设置结构体，按组（Group）保存
+// - 数据库表、JSON 列和内存三种仓库
+// - 通过 cache.Store 缓存整组设置，保存时自动失效
+// - 运行时读取、修改和保存设置
+// - 设置迁移：添加、重命名、更新和删除属性
+//
+// 包结构：
+// - settings.go - Settings 接口、错误定义、属性反射和默认管理器
+// - repository.go - Repository 仓库接口、MemoryRepository、DatabaseRepository 和 JSONColumnRepository
+// - manager.go - Manager 设置的读取、保存和缓存
+// - migrator.go - Migrator 设置迁移
+//
+// 使用示例：
+//
+//	type GeneralSettings struct {
+//		SiteName    string `json:"site_name"`
+//		SiteActive  bool   `json:"site_active"`
+//		MaxUploadMB int    `json:"max_upload_mb"`
+//	}
+//
+//	func (*GeneralSettings) Group() string { return "general" }
+//
+//	repository := settings.NewDatabaseRepository(db, "")
+//	repository.Migrate(ctx)
+//
+//	// 迁移中添加新属性
+//	migrator := settings.NewMigrator(repository)
+//	migrator.Add(ctx, "general.site_name", "Laravel")
+//	migrator.Add(ctx, "general.site_active", true)
+//	migrator.Add(ctx, "general.max_upload_mb", 10)
+//
+//	manager := settings.New(settings.Config{Repository: repository, Cache: store, CacheTTL: time.Hour})
+//	general, err := settings.Get[GeneralSettings](ctx, manager)
+//
+//	general.SiteActive = false
+//	err = manager.Save(ctx, general)
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+var (
+	// ErrMissingSettings 仓库中缺少设置结构体需要的属性，通常是忘记运行设置迁移
+	ErrMissingSettings = errors.New("settings: missing settings properties")
+
+	// ErrPropertyExists 迁移添加的属性已存在
+	ErrPropertyExists = errors.New("settings: property already exists")
+
+	// ErrPropertyNotFound 迁移操作的属性不存在
+	ErrPropertyNotFound = errors.New("settings: property not found")
+
+	// ErrInvalidSettings 设置不是结构体指针
+	ErrInvalidSettings = errors.New("settings: settings must be a non-nil struct pointer")
+)
+
+// Settings 一组设置，对应 spatie 的 Settings 类
+type Settings interface {
+	// Group 设置组名，仓库中按组保存属性
+	Group() string
+}
+
+// MissingSettingsError 仓库中缺少的属性
+type MissingSettingsError struct {
+	// Group 设置组名
+	Group string
+
+	// Properties 缺少的属性名，已排序
+	Properties []string
+}
+
+// Error 实现 error 接口
+func (e *MissingSettingsError) Error() string {
+	return fmt.Sprintf("%v: %s [%s]", ErrMissingSettings, e.Group, strings.Join(e.Properties, ", "))
+}
+
+// Unwrap 支持 errors.Is(err, ErrMissingSettings)
+func (e *MissingSettingsError) Unwrap() error {
+	return ErrMissingSettings
+}
+
+// property 设置结构体的一个属性
+type property struct {
+	name  string
+	index []int
+}
+
+// properties 设置结构体的属性，按名称排序
+func properties(v reflect.Value) []property {
+	t := v.Type()
+	var props []property
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = str.Snake(field.Name)
+		}
+		props = append(props, property{name: name, index: field.Index})
+	}
+	sort.Slice(props, func(i, j int) bool { return props[i].name < props[j].name })
+	return props
+}
+
+// structOf 设置的结构体值
+func structOf(s Settings) (reflect.Value, error) {
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, ErrInvalidSettings
+	}
+	return v.Elem(), nil
+}
+
+// encode 把设置编码为属性名到 JSON 的映射
+func encode(s Settings) (map[string]json.RawMessage, error) {
+	v, err := structOf(s)
+	if err != nil {
+		return nil, err
+	}
+	payloads := make(map[string]json.RawMessage)
+	for _, prop := range properties(v) {
+		payload, err := json.Marshal(v.FieldByIndex(prop.index).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("settings: encode %s.%s: %w", s.Group(), prop.name, err)
+		}
+		payloads[prop.name] = payload
+	}
+	return payloads, nil
+}
+
+// decode 把属性写入设置，缺少属性时返回 *MissingSettingsError
+func decode(s Settings, payloads map[string]json.RawMessage) error {
+	v, err := structOf(s)
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, prop := range properties(v) {
+		payload, ok := payloads[prop.name]
+		if !ok {
+			missing = append(missing, prop.name)
+			continue
+		}
+		if err := json.Unmarshal(payload, v.FieldByIndex(prop.index).Addr().Interface()); err != nil {
+			return fmt.Errorf("settings: decode %s.%s: %w", s.Group(), prop.name, err)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingSettingsError{Group: s.Group(), Properties: missing}
+	}
+	return nil
+}
+
+// splitName 拆分 "组.属性" 形式的名称
+func splitName(name string) (string, string, error) {
+	group, prop, ok := strings.Cut(name, ".")
+	if !ok || group == "" || prop == "" {
+		return "", "", fmt.Errorf("settings: property name %q must be in the form group.name", name)
+	}
+	return group, prop, nil
+}
+
+// defaultManager 默认设置管理器
+var defaultManager atomic.Value
+
+// SetDefault 设置默认管理器
+func SetDefault(manager *Manager) {
+	defaultManager.Store(manager)
+}
+
+// Default 获取默认管理器，未设置时返回 nil
+func Default() *Manager {
+	manager, _ := defaultManager.Load().(*Manager)
+	return manager
+}
@@ -0,0 +1,199 @@
+package sleep
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/support/timetravel"
+)
+
+// TestingT FakeSleeper 使用的测试接口，*testing.T 和 *testing.B 都满足
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// FakeSleeper 测试用的 Sleeper，对应 Laravel 的 Sleep::fake
+//
+// 休眠立即返回，只记录请求的时长并推进虚拟时间；Until 按虚拟时间计算时长。
+// context 已取消时与真实实现一样返回其错误，不记录休眠。
+type FakeSleeper struct {
+	t TestingT
+
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+	travel *timetravel.TimeTravel
+}
+
+var _ Sleeper = (*FakeSleeper)(nil)
+
+// Fake 把默认 Sleeper 和容器中的 "sleep" 替换为 FakeSleeper，测试结束时恢复，c 可以为 nil
+//
+// 使用示例：
+//
+//	func TestRetry(t *testing.T) {
+//		fake := sleep.Fake(t, app)
+//		// 执行带退避的重试
+//		fake.AssertSleptTimes(2)
+//		fake.AssertSequence(100*time.Millisecond, 200*time.Millisecond)
+//	}
+func Fake(t TestingT, c container.Container) *FakeSleeper {
+	t.Helper()
+	fake := NewFakeSleeper(t)
+	previous := Default()
+	SetDefault(fake)
+
+	var previousBinding Sleeper
+	if c != nil {
+		if c.Bound("sleep") {
+			previousBinding = FromContainer(c)
+		}
+		if err := c.Instance("sleep", fake); err != nil {
+			t.Errorf("sleep: swap sleep: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		SetDefault(previous)
+		if c != nil && previousBinding != nil {
+			_ = c.Instance("sleep", previousBinding)
+		}
+	})
+	return fake
+}
+
+// NewFakeSleeper 创建 FakeSleeper，虚拟时间从当前时间开始
+func NewFakeSleeper(t TestingT) *FakeSleeper {
+	return &FakeSleeper{t: t, now: time.Now()}
+}
+
+// SyncWith 休眠时同步推进测试时钟，使缓存过期和数据库时间戳与休眠一致
+func (f *FakeSleeper) SyncWith(travel *timetravel.TimeTravel) *FakeSleeper {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.travel = travel
+	f.now = travel.Now()
+	return f
+}
+
+// For 记录休眠并推进虚拟时间
+func (f *FakeSleeper) For(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d < 0 {
+		d = 0
+	}
+	f.mu.Lock()
+	f.sleeps = append(f.sleeps, d)
+	f.now = f.now.Add(d)
+	travel := f.travel
+	f.mu.Unlock()
+	if travel != nil {
+		travel.Travel(d)
+	}
+	return nil
+}
+
+// Until 按虚拟时间休眠到指定时间
+func (f *FakeSleeper) Until(ctx context.Context, t time.Time) error {
+	return f.For(ctx, t.Sub(f.Now()))
+}
+
+// Now 当前虚拟时间
+func (f *FakeSleeper) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Sleeps 记录的休眠时长，按请求顺序
+func (f *FakeSleeper) Sleeps() []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Duration(nil), f.sleeps...)
+}
+
+// Total 休眠总时长
+func (f *FakeSleeper) Total() time.Duration {
+	var total time.Duration
+	for _, d := range f.Sleeps() {
+		total += d
+	}
+	return total
+}
+
+// AssertSlept 断言休眠过 d，times 指定时断言恰好 times 次
+func (f *FakeSleeper) AssertSlept(d time.Duration, times ...int) bool {
+	f.t.Helper()
+	n := 0
+	for _, slept := range f.Sleeps() {
+		if slept == d {
+			n++
+		}
+	}
+	if len(times) > 0 {
+		if n != times[0] {
+			f.t.Errorf("sleep: the expected sleep of [%s] was found [%d] times instead of [%d] times", d, n, times[0])
+			return false
+		}
+		return true
+	}
+	if n == 0 {
+		f.t.Errorf("sleep: the expected sleep of [%s] was not found", d)
+		return false
+	}
+	return true
+}
+
+// AssertSleptTimes 断言休眠了 times 次
+func (f *FakeSleeper) AssertSleptTimes(times int) bool {
+	f.t.Helper()
+	if n := len(f.Sleeps()); n != times {
+		f.t.Errorf("sleep: expected [%d] sleeps but found [%d]", times, n)
+		return false
+	}
+	return true
+}
+
+// AssertSequence 断言休眠序列与 durations 完全相同
+func (f *FakeSleeper) AssertSequence(durations ...time.Duration) bool {
+	f.t.Helper()
+	sleeps := f.Sleeps()
+	if len(sleeps) != len(durations) {
+		f.t.Errorf("sleep: expected sleep sequence %v but found %v", durations, sleeps)
+		return false
+	}
+	for i := range sleeps {
+		if sleeps[i] != durations[i] {
+			f.t.Errorf("sleep: expected sleep sequence %v but found %v", durations, sleeps)
+			return false
+		}
+	}
+	return true
+}
+
+// AssertNeverSlept 断言没有休眠过
+func (f *FakeSleeper) AssertNeverSlept() bool {
+	f.t.Helper()
+	if n := len(f.Sleeps()); n > 0 {
+		f.t.Errorf("sleep: expected no sleeps but found [%d]", n)
+		return false
+	}
+	return true
+}
+
+// AssertInsomniac 断言没有休眠过或只有零时长的休眠
+func (f *FakeSleeper) AssertInsomniac() bool {
+	f.t.Helper()
+	for _, d := range f.Sleeps() {
+		if d != 0 {
+			f.t.Errorf("sleep: unexpected sleep of [%s] found", d)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+// Package sleep 提供可替换的休眠服务，对应 Laravel 的 Sleep
+//
+// 重试、退避和轮询代码通过 Sleeper 休眠而不是直接调用 time.Sleep，测试中替换为 FakeSleeper 后
+// 休眠立即返回，只记录请求的时长并推进虚拟时间，断言休眠序列而不产生真实延迟。
+//
+// 主要特性：
+// - For、Until 休眠指定时长或到指定时间，可以被 context 取消
+// - 默认 Sleeper 和容器绑定 "sleep"，ServiceProvider 注册到容器
+// - FakeSleeper 记录休眠、推进虚拟时间，可以同步推进 timetravel 测试时钟
+//
+// 包结构：
+// - sleep.go - Sleeper 接口、真实实现、默认 Sleeper 和 ServiceProvider
+// - fake.go - FakeSleeper 和测试断言
+//
+// 使用示例：
+//
+//	type Client struct {
+//		Sleeper sleep.Sleeper
+//	}
+//
+//	func (c *Client) Fetch(ctx context.Context) error {
+//		for attempt := 1; ; attempt++ {
+//			if err := c.try(ctx); err == nil || attempt == 3 {
+//				return err
+//			}
+//			if err := c.Sleeper.For(ctx, time.Duration(attempt)*time.Second); err != nil {
+//				return err
+//			}
+//		}
+//	}
+//
+//	// 测试中
+//	fake := sleep.Fake(t, app)
+//	client := &Client{Sleeper: sleep.FromContainer(app)}
+//	client.Fetch(ctx)
+//	fake.AssertSequence(time.Second, 2*time.Second)
+package sleep
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// Sleeper 休眠服务
+type Sleeper interface {
+	// For 休眠指定时长，时长不大于 0 时立即返回，context 取消时返回其错误
+	For(ctx context.Context, d time.Duration) error
+
+	// Until 休眠到指定时间，时间已过时立即返回，context 取消时返回其错误
+	Until(ctx context.Context, t time.Time) error
+}
+
+// realSleeper 使用真实计时器的 Sleeper
+type realSleeper struct{}
+
+// Real 使用真实计时器的 Sleeper
+var Real Sleeper = realSleeper{}
+
+// For 实现 Sleeper
+func (realSleeper) For(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Until 实现 Sleeper
+func (s realSleeper) Until(ctx context.Context, t time.Time) error {
+	return s.For(ctx, time.Until(t))
+}
+
+// defaultSleeper 默认 Sleeper
+var defaultSleeper atomic.Value
+
+// SetDefault 设置默认 Sleeper，nil 恢复为 Real
+func SetDefault(sleeper Sleeper) {
+	if sleeper == nil {
+		sleeper = Real
+	}
+	defaultSleeper.Store(&sleeper)
+}
+
+// Default 获取默认 Sleeper，未设置时为 Real
+func Default() Sleeper {
+	if sleeper, ok := defaultSleeper.Load().(*Sleeper); ok {
+		return *sleeper
+	}
+	return Real
+}
+
+// For 使用默认 Sleeper 休眠指定时长
+func For(ctx context.Context, d time.Duration) error {
+	return Default().For(ctx, d)
+}
+
+// Until 使用默认 Sleeper 休眠到指定时间
+func Until(ctx context.Context, t time.Time) error {
+	return Default().Until(ctx, t)
+}
+
+// FromContainer 从容器解析 "sleep"，未绑定或类型不符时返回默认 Sleeper
+func FromContainer(c container.Container) Sleeper {
+	if c != nil && c.Bound("sleep") {
+		if resolved, err := c.Make("sleep"); err == nil {
+			if sleeper, ok := resolved.(Sleeper); ok {
+				return sleeper
+			}
+		}
+	}
+	return Default()
+}
+
+// ServiceProvider 以 "sleep" 绑定 Sleeper，Sleeper 为空时绑定默认 Sleeper
+type ServiceProvider struct {
+	Sleeper Sleeper
+}
+
+// Register 绑定 Sleeper
+func (p *ServiceProvider) Register(c container.Container) error {
+	sleeper := p.Sleeper
+	if sleeper == nil {
+		sleeper = Default()
+	}
+	return c.Instance("sleep", sleeper)
+}
+
+// Boot 无需引导
+func (p *ServiceProvider) Boot(c container.Container) error {
+	return nil
+}
+
+// Provides 提供的服务
+func (p *ServiceProvider) Provides() []string {
+	return []string{"sleep"}
+}
+
+// IsDeferred 不延迟加载
+func (p *ServiceProvider) IsDeferred() bool {
+	return false
+}
@@ -0,0 +1,78 @@
+// Package driver 提供 storage 包协议的参考实现
+//
+// local 驱动基于 os 包，可见性映射为文件权限；s3 驱动直接通过 net/http 调用 S3 REST API，
+// 使用 AWS Signature Version 4 签名，兼容 MinIO、Cloudflare R2 等 S3 兼容服务，
+// 不依赖 AWS SDK。两种驱动都只使用标准库。
+//
+// 包结构：
+// - driver.go - 配置读取和路径辅助函数
+// - local.go - LocalFilesystem 本地磁盘
+// - s3.go - S3Filesystem S3 兼容对象存储
+// - sigv4.go - AWS Signature Version 4 签名和预签名 URL
+// - manager.go - Manager 实现
+// - fake.go - FakeFilesystem 测试用假磁盘和断言
+//
+// 配置示例：
+//
+//	manager := driver.NewManager(map[string]map[string]interface{}{
+//		"local":  {"driver": "local", "root": "storage/app"},
+//		"public": {"driver": "local", "root": "storage/app/public", "url": "https://example.com/storage", "visibility": "public"},
+//		"s3": {
+//			"driver": "s3", "key": key, "secret": secret, "region": "us-east-1", "bucket": "assets",
+//			"endpoint": "http://localhost:9000", "use_path_style_endpoint": true,
+//		},
+//	}, "local")
+//	storage.SetDefaultManager(manager)
+package driver
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// stringOption 读取字符串配置
+func stringOption(config map[string]interface{}, key, fallback string) string {
+	if value, ok := config[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// boolOption 读取布尔配置
+func boolOption(config map[string]interface{}, key string, fallback bool) bool {
+	if value, ok := config[key].(bool); ok {
+		return value
+	}
+	return fallback
+}
+
+// optionalOption 读取可选的对象配置，类型不匹配时返回错误
+func optionalOption[T any](config map[string]interface{}, key string) (T, error) {
+	var zero T
+	value, exists := config[key]
+	if !exists || value == nil {
+		return zero, nil
+	}
+	client, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("storage: config %q must be %T, got %T", key, zero, value)
+	}
+	return client, nil
+}
+
+// cleanPath 规范化磁盘内路径，去掉首尾的 "/"，拒绝包含 ".." 的路径
+func cleanPath(p string) (string, error) {
+	p = strings.ReplaceAll(p, "\\", "/")
+	for _, segment := range strings.Split(p, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("storage: path %q is outside of the root", p)
+		}
+	}
+	return strings.Trim(path.Clean("/"+p), "/"), nil
+}
+
+// joinURL 拼接 URL 和路径
+func joinURL(base, p string) string {
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(p, "/")
+}
@@ -0,0 +1,154 @@
+package driver
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+
+	"github.com/cnote0/laraveldoc/routing"
+	"github.com/cnote0/laraveldoc/storage"
+)
+
+// TestingT 假磁盘使用的测试接口，*testing.T 和 *testing.B 都满足
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// FakeFilesystem 测试用的假磁盘，对应 Laravel 的 Storage::fake
+//
+// 文件写入临时目录，测试结束时删除临时目录并恢复原磁盘。
+type FakeFilesystem struct {
+	*LocalFilesystem
+	t TestingT
+}
+
+// Fake 把默认存储管理器中的磁盘替换为假磁盘，不传名称时替换默认磁盘
+//
+// 使用示例：
+//
+//	func TestAvatarUpload(t *testing.T) {
+//		disk := driver.Fake(t, "avatars")
+//		// 执行上传
+//		disk.AssertExists("avatars/1.png")
+//		disk.AssertMissing("avatars/2.png")
+//		disk.AssertCount("avatars", 1, false)
+//	}
+func Fake(t TestingT, disk ...string) *FakeFilesystem {
+	t.Helper()
+	manager := storage.DefaultManager()
+	if manager == nil {
+		panic(storage.ErrNoManager)
+	}
+	return FakeOn(t, manager, disk...)
+}
+
+// FakeOn 把指定管理器中的磁盘替换为假磁盘
+func FakeOn(t TestingT, manager storage.Manager, disk ...string) *FakeFilesystem {
+	t.Helper()
+	name := manager.GetDefaultDisk()
+	if len(disk) > 0 && disk[0] != "" {
+		name = disk[0]
+	}
+	fake := NewFakeFilesystem(t)
+	manager.Set(name, fake)
+	t.Cleanup(func() {
+		manager.ForgetDisk(name)
+	})
+	return fake
+}
+
+// NewFakeFilesystem 创建基于临时目录的假磁盘，测试结束时自动删除
+func NewFakeFilesystem(t TestingT) *FakeFilesystem {
+	t.Helper()
+	root, err := os.MkdirTemp("", "storage-fake-*")
+	if err != nil {
+		panic(fmt.Errorf("storage: create fake disk: %w", err))
+	}
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	local, err := NewLocalFilesystem(map[string]interface{}{
+		"root":   root,
+		"signer": routing.NewURLSigner(key),
+	})
+	if err != nil {
+		panic(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(root)
+	})
+	return &FakeFilesystem{LocalFilesystem: local, t: t}
+}
+
+// AssertExists 断言文件存在
+func (f *FakeFilesystem) AssertExists(paths ...string) bool {
+	f.t.Helper()
+	ok := true
+	for _, p := range paths {
+		if exists, err := f.Exists(context.Background(), p); err != nil || !exists {
+			f.t.Errorf("storage: unable to find a file or directory at path [%s]", p)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertContent 断言文件存在且内容一致
+func (f *FakeFilesystem) AssertContent(path string, content string) bool {
+	f.t.Helper()
+	data, err := f.Get(context.Background(), path)
+	if err != nil {
+		f.t.Errorf("storage: unable to find a file at path [%s]", path)
+		return false
+	}
+	if string(data) != content {
+		f.t.Errorf("storage: file [%s] was found, but contents %q do not match %q", path, data, content)
+		return false
+	}
+	return true
+}
+
+// AssertMissing 断言文件不存在
+func (f *FakeFilesystem) AssertMissing(paths ...string) bool {
+	f.t.Helper()
+	ok := true
+	for _, p := range paths {
+		if exists, err := f.Exists(context.Background(), p); err != nil || exists {
+			f.t.Errorf("storage: found unexpected file or directory at path [%s]", p)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertCount 断言目录中的文件数量，recursive 为 true 时包含子目录
+func (f *FakeFilesystem) AssertCount(directory string, count int, recursive bool) bool {
+	f.t.Helper()
+	files, err := f.Files(context.Background(), directory, recursive)
+	if err != nil {
+		f.t.Errorf("storage: list [%s]: %v", directory, err)
+		return false
+	}
+	if len(files) != count {
+		f.t.Errorf("storage: expected [%d] files at [%s], but found [%d]", count, directory, len(files))
+		return false
+	}
+	return true
+}
+
+// AssertDirectoryEmpty 断言目录中没有文件
+func (f *FakeFilesystem) AssertDirectoryEmpty(directory string) bool {
+	f.t.Helper()
+	files, err := f.Files(context.Background(), directory, true)
+	if err != nil {
+		f.t.Errorf("storage: list [%s]: %v", directory, err)
+		return false
+	}
+	if len(files) != 0 {
+		f.t.Errorf("storage: directory [%s] is not empty", directory)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,346 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/routing"
+	"github.com/cnote0/laraveldoc/storage"
+)
+
+// 可见性对应的文件和目录权限，与 Laravel 的 local 驱动默认值一致
+var localPermissions = map[string]struct{ file, dir fs.FileMode }{
+	storage.VisibilityPublic:  {0o644, 0o755},
+	storage.VisibilityPrivate: {0o600, 0o700},
+}
+
+// LocalFilesystem 本地磁盘
+type LocalFilesystem struct {
+	root       string
+	url        string
+	visibility string
+	signer     *routing.URLSigner
+}
+
+var _ storage.Filesystem = (*LocalFilesystem)(nil)
+
+// NewLocalFilesystem 创建本地磁盘
+//
+// 配置项：root（根目录，必填）、url（公开访问地址前缀）、visibility（默认可见性，默认 public）、
+// signer（*routing.URLSigner，设置后支持 TemporaryURL，需要自行注册校验签名并返回文件的路由）。
+func NewLocalFilesystem(config map[string]interface{}) (*LocalFilesystem, error) {
+	root := stringOption(config, "root", "")
+	if root == "" {
+		return nil, errors.New("storage: local disk requires a root")
+	}
+	visibility := stringOption(config, "visibility", storage.VisibilityPublic)
+	if _, ok := localPermissions[visibility]; !ok {
+		return nil, fmt.Errorf("storage: invalid visibility %q", visibility)
+	}
+	signer, err := optionalOption[*routing.URLSigner](config, "signer")
+	if err != nil {
+		return nil, err
+	}
+	return &LocalFilesystem{
+		root:       root,
+		url:        stringOption(config, "url", ""),
+		visibility: visibility,
+		signer:     signer,
+	}, nil
+}
+
+// Path 文件在本地的完整路径
+func (l *LocalFilesystem) Path(p string) (string, error) {
+	cleaned, err := cleanPath(p)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(l.root, filepath.FromSlash(cleaned)), nil
+}
+
+// Exists 文件是否存在
+func (l *LocalFilesystem) Exists(ctx context.Context, p string) (bool, error) {
+	full, err := l.Path(p)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(full)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Get 读取文件内容
+func (l *LocalFilesystem) Get(ctx context.Context, p string) ([]byte, error) {
+	full, err := l.Path(p)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(full)
+	return data, notFound(p, err)
+}
+
+// ReadStream 以流的方式读取文件
+func (l *LocalFilesystem) ReadStream(ctx context.Context, p string) (io.ReadCloser, error) {
+	full, err := l.Path(p)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(full)
+	if err != nil {
+		return nil, notFound(p, err)
+	}
+	return file, nil
+}
+
+// Put 写入文件
+func (l *LocalFilesystem) Put(ctx context.Context, p string, contents []byte, options ...storage.WriteOptions) error {
+	return l.write(p, options, func(file *os.File) error {
+		_, err := file.Write(contents)
+		return err
+	})
+}
+
+// PutStream 以流的方式写入文件
+func (l *LocalFilesystem) PutStream(ctx context.Context, p string, contents io.Reader, options ...storage.WriteOptions) error {
+	return l.write(p, options, func(file *os.File) error {
+		_, err := io.Copy(file, contents)
+		return err
+	})
+}
+
+// write 先写入同目录下的临时文件再重命名，避免读到写了一半的文件
+func (l *LocalFilesystem) write(p string, options []storage.WriteOptions, fill func(file *os.File) error) error {
+	full, err := l.Path(p)
+	if err != nil {
+		return err
+	}
+	perms, ok := localPermissions[storage.Visibility(options, l.visibility)]
+	if !ok {
+		return fmt.Errorf("storage: invalid visibility %q", storage.Visibility(options, l.visibility))
+	}
+	if err := os.MkdirAll(filepath.Dir(full), localPermissions[l.visibility].dir); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(full), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if err := fill(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Chmod(perms.file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), full)
+}
+
+// Delete 删除文件
+func (l *LocalFilesystem) Delete(ctx context.Context, paths ...string) error {
+	for _, p := range paths {
+		full, err := l.Path(p)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Copy 复制文件，保留权限
+func (l *LocalFilesystem) Copy(ctx context.Context, from, to string) error {
+	source, err := l.Path(from)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return notFound(from, err)
+	}
+	visibility := storage.VisibilityPrivate
+	if info.Mode().Perm()&0o044 != 0 {
+		visibility = storage.VisibilityPublic
+	}
+	reader, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return l.PutStream(ctx, to, reader, storage.WriteOptions{Visibility: visibility})
+}
+
+// Move 移动文件
+func (l *LocalFilesystem) Move(ctx context.Context, from, to string) error {
+	source, err := l.Path(from)
+	if err != nil {
+		return err
+	}
+	target, err := l.Path(to)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), localPermissions[l.visibility].dir); err != nil {
+		return err
+	}
+	return notFound(from, os.Rename(source, target))
+}
+
+// Size 文件大小
+func (l *LocalFilesystem) Size(ctx context.Context, p string) (int64, error) {
+	info, err := l.stat(p)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// LastModified 最后修改时间
+func (l *LocalFilesystem) LastModified(ctx context.Context, p string) (time.Time, error) {
+	info, err := l.stat(p)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// GetVisibility 按权限判断可见性，其他用户可读时为 public
+func (l *LocalFilesystem) GetVisibility(ctx context.Context, p string) (string, error) {
+	info, err := l.stat(p)
+	if err != nil {
+		return "", err
+	}
+	if info.Mode().Perm()&0o004 != 0 {
+		return storage.VisibilityPublic, nil
+	}
+	return storage.VisibilityPrivate, nil
+}
+
+// SetVisibility 设置可见性
+func (l *LocalFilesystem) SetVisibility(ctx context.Context, p, visibility string) error {
+	perms, ok := localPermissions[visibility]
+	if !ok {
+		return fmt.Errorf("storage: invalid visibility %q", visibility)
+	}
+	info, err := l.stat(p)
+	if err != nil {
+		return err
+	}
+	full, _ := l.Path(p)
+	if info.IsDir() {
+		return os.Chmod(full, perms.dir)
+	}
+	return os.Chmod(full, perms.file)
+}
+
+// URL 公开访问地址，未配置 url 时返回以 /storage 开头的相对地址
+func (l *LocalFilesystem) URL(p string) string {
+	cleaned, _ := cleanPath(p)
+	if l.url == "" {
+		return "/storage/" + cleaned
+	}
+	return joinURL(l.url, cleaned)
+}
+
+// TemporaryURL 使用配置的 signer 生成临时签名地址
+func (l *LocalFilesystem) TemporaryURL(ctx context.Context, p string, expiration time.Duration) (string, error) {
+	if l.signer == nil {
+		return "", storage.ErrUnsupported
+	}
+	return l.signer.TemporarySign(l.URL(p), expiration)
+}
+
+// Files 列出目录中的文件
+func (l *LocalFilesystem) Files(ctx context.Context, directory string, recursive bool) ([]string, error) {
+	return l.list(directory, recursive, false)
+}
+
+// Directories 列出目录中的子目录
+func (l *LocalFilesystem) Directories(ctx context.Context, directory string, recursive bool) ([]string, error) {
+	return l.list(directory, recursive, true)
+}
+
+// list 列出目录项，返回相对于根目录的路径，临时文件被忽略
+func (l *LocalFilesystem) list(directory string, recursive, dirs bool) ([]string, error) {
+	base, err := l.Path(directory)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	err = filepath.WalkDir(base, func(full string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && full == base {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if full == base {
+			return nil
+		}
+		if entry.IsDir() == dirs && !strings.HasPrefix(entry.Name(), ".upload-") {
+			rel, _ := filepath.Rel(l.root, full)
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		if entry.IsDir() && !recursive {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	sort.Strings(paths)
+	return paths, err
+}
+
+// MakeDirectory 创建目录
+func (l *LocalFilesystem) MakeDirectory(ctx context.Context, p string) error {
+	full, err := l.Path(p)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(full, localPermissions[l.visibility].dir)
+}
+
+// DeleteDirectory 删除目录
+func (l *LocalFilesystem) DeleteDirectory(ctx context.Context, p string) error {
+	full, err := l.Path(p)
+	if err != nil {
+		return err
+	}
+	if full == filepath.Clean(l.root) {
+		return errors.New("storage: refusing to delete the disk root")
+	}
+	return os.RemoveAll(full)
+}
+
+// stat 获取文件信息
+func (l *LocalFilesystem) stat(p string) (fs.FileInfo, error) {
+	full, err := l.Path(p)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(full)
+	return info, notFound(p, err)
+}
+
+// notFound 把文件不存在的错误转换为 storage.ErrNotFound
+func notFound(p string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", storage.ErrNotFound, p)
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package driver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/storage"
+)
+
+// Manager 存储管理器实现
+//
+// 磁盘在首次使用时创建并缓存，内置 local 和 s3 驱动。
+type Manager struct {
+	mu          sync.RWMutex
+	config      map[string]map[string]interface{}
+	defaultDisk string
+	disks       map[string]storage.Filesystem
+	factories   map[string]func(config map[string]interface{}) (storage.Filesystem, error)
+}
+
+var _ storage.Manager = (*Manager)(nil)
+
+// NewManager 创建存储管理器，config 为磁盘名称到配置的映射
+func NewManager(config map[string]map[string]interface{}, defaultDisk string) *Manager {
+	m := &Manager{
+		config:      config,
+		defaultDisk: defaultDisk,
+		disks:       make(map[string]storage.Filesystem),
+		factories:   make(map[string]func(config map[string]interface{}) (storage.Filesystem, error)),
+	}
+	m.Extend(storage.DriverLocal, func(config map[string]interface{}) (storage.Filesystem, error) {
+		return NewLocalFilesystem(config)
+	})
+	m.Extend(storage.DriverS3, func(config map[string]interface{}) (storage.Filesystem, error) {
+		return NewS3Filesystem(config)
+	})
+	return m
+}
+
+// Disk 获取磁盘，不传名称时使用默认磁盘
+func (m *Manager) Disk(name ...string) (storage.Filesystem, error) {
+	diskName := m.GetDefaultDisk()
+	if len(name) > 0 && name[0] != "" {
+		diskName = name[0]
+	}
+
+	m.mu.RLock()
+	disk, ok := m.disks[diskName]
+	m.mu.RUnlock()
+	if ok {
+		return disk, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if disk, ok := m.disks[diskName]; ok {
+		return disk, nil
+	}
+	config, ok := m.config[diskName]
+	if !ok {
+		return nil, fmt.Errorf("storage: disk [%s] does not have a configured driver", diskName)
+	}
+	driverName, _ := config["driver"].(string)
+	factory, ok := m.factories[driverName]
+	if !ok {
+		return nil, fmt.Errorf("storage: driver [%s] is not supported", driverName)
+	}
+	disk, err := factory(config)
+	if err != nil {
+		return nil, err
+	}
+	m.disks[diskName] = disk
+	return disk, nil
+}
+
+// GetDefaultDisk 获取默认磁盘名称
+func (m *Manager) GetDefaultDisk() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.defaultDisk
+}
+
+// SetDefaultDisk 设置默认磁盘名称
+func (m *Manager) SetDefaultDisk(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultDisk = name
+}
+
+// Extend 注册驱动
+func (m *Manager) Extend(driver string, factory func(config map[string]interface{}) (storage.Filesystem, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.factories[driver] = factory
+}
+
+// Set 用指定实例替换磁盘
+func (m *Manager) Set(name string, disk storage.Filesystem) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.disks[name] = disk
+}
+
+// ForgetDisk 移除已创建的磁盘实例
+func (m *Manager) ForgetDisk(names ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, name := range names {
+		delete(m.disks, name)
+	}
+}
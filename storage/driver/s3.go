@@ -0,0 +1,534 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cnote0/laraveldoc/storage"
+)
+
+// s3ACL 可见性对应的预设 ACL
+var s3ACL = map[string]string{
+	storage.VisibilityPublic:  "public-read",
+	storage.VisibilityPrivate: "private",
+}
+
+// S3Filesystem S3 兼容对象存储
+//
+// 目录是对象键的前缀，MakeDirectory 写入以 "/" 结尾的空对象作为目录占位。
+type S3Filesystem struct {
+	client     *http.Client
+	signer     *sigV4
+	endpoint   *url.URL
+	bucket     string
+	root       string
+	url        string
+	pathStyle  bool
+	visibility string
+	now        func() time.Time
+}
+
+var _ storage.Filesystem = (*S3Filesystem)(nil)
+
+// NewS3Filesystem 创建 S3 磁盘
+//
+// 配置项：key、secret、token、region（默认 us-east-1）、bucket（必填）、
+// endpoint（默认 https://s3.{region}.amazonaws.com）、use_path_style_endpoint、
+// root（键前缀）、url（公开访问地址前缀，例如 CDN）、visibility（默认 private）、http_client。
+func NewS3Filesystem(config map[string]interface{}) (*S3Filesystem, error) {
+	bucket := stringOption(config, "bucket", "")
+	if bucket == "" {
+		return nil, errors.New("storage: s3 disk requires a bucket")
+	}
+	region := stringOption(config, "region", "us-east-1")
+	endpoint, err := url.Parse(stringOption(config, "endpoint", "https://s3."+region+".amazonaws.com"))
+	if err != nil {
+		return nil, fmt.Errorf("storage: invalid s3 endpoint: %w", err)
+	}
+	visibility := stringOption(config, "visibility", storage.VisibilityPrivate)
+	if _, ok := s3ACL[visibility]; !ok {
+		return nil, fmt.Errorf("storage: invalid visibility %q", visibility)
+	}
+	client, err := optionalOption[*http.Client](config, "http_client")
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	root, err := cleanPath(stringOption(config, "root", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &S3Filesystem{
+		client: client,
+		signer: &sigV4{
+			key:     stringOption(config, "key", ""),
+			secret:  stringOption(config, "secret", ""),
+			token:   stringOption(config, "token", ""),
+			region:  region,
+			service: "s3",
+		},
+		endpoint:   endpoint,
+		bucket:     bucket,
+		root:       root,
+		url:        stringOption(config, "url", ""),
+		pathStyle:  boolOption(config, "use_path_style_endpoint", false),
+		visibility: visibility,
+		now:        time.Now,
+	}, nil
+}
+
+// Exists 对象是否存在
+func (s *S3Filesystem) Exists(ctx context.Context, p string) (bool, error) {
+	_, err := s.head(ctx, p)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Get 读取对象内容
+func (s *S3Filesystem) Get(ctx context.Context, p string) ([]byte, error) {
+	body, err := s.ReadStream(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// ReadStream 以流的方式读取对象
+func (s *S3Filesystem) ReadStream(ctx context.Context, p string) (io.ReadCloser, error) {
+	key, err := s.key(p)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Put 写入对象
+func (s *S3Filesystem) Put(ctx context.Context, p string, contents []byte, options ...storage.WriteOptions) error {
+	return s.put(ctx, p, bytes.NewReader(contents), int64(len(contents)), options)
+}
+
+// PutStream 以流的方式写入对象
+//
+// S3 的 PutObject 需要预先知道长度：可以 Seek 的 Reader（例如上传文件）直接流式发送，
+// 否则先写入临时文件。
+func (s *S3Filesystem) PutStream(ctx context.Context, p string, contents io.Reader, options ...storage.WriteOptions) error {
+	if seeker, ok := contents.(io.Seeker); ok {
+		current, err := seeker.Seek(0, io.SeekCurrent)
+		if err == nil {
+			end, err := seeker.Seek(0, io.SeekEnd)
+			if err == nil {
+				if _, err := seeker.Seek(current, io.SeekStart); err == nil {
+					return s.put(ctx, p, contents, end-current, options)
+				}
+			}
+		}
+	}
+	spool, err := os.CreateTemp("", "storage-s3-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+	size, err := io.Copy(spool, contents)
+	if err != nil {
+		return err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return s.put(ctx, p, spool, size, options)
+}
+
+// put 发送 PutObject 请求
+func (s *S3Filesystem) put(ctx context.Context, p string, body io.Reader, size int64, options []storage.WriteOptions) error {
+	key, err := s.key(p)
+	if err != nil {
+		return err
+	}
+	acl, ok := s3ACL[storage.Visibility(options, s.visibility)]
+	if !ok {
+		return fmt.Errorf("storage: invalid visibility %q", storage.Visibility(options, s.visibility))
+	}
+	header := http.Header{"X-Amz-Acl": {acl}}
+	contentType := ""
+	if len(options) > 0 {
+		contentType = options[0].ContentType
+	}
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(key))
+	}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, nil, header, body, size)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Delete 删除对象
+func (s *S3Filesystem) Delete(ctx context.Context, paths ...string) error {
+	for _, p := range paths {
+		key, err := s.key(p)
+		if err != nil {
+			return err
+		}
+		resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, nil, 0)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+	return nil
+}
+
+// Copy 服务端复制对象，目标对象使用磁盘默认可见性
+func (s *S3Filesystem) Copy(ctx context.Context, from, to string) error {
+	source, err := s.key(from)
+	if err != nil {
+		return err
+	}
+	target, err := s.key(to)
+	if err != nil {
+		return err
+	}
+	header := http.Header{
+		"X-Amz-Copy-Source": {"/" + s.bucket + "/" + awsEscape(source, false)},
+		"X-Amz-Acl":         {s3ACL[s.visibility]},
+	}
+	resp, err := s.do(ctx, http.MethodPut, target, nil, header, nil, 0)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// CopyObject 可能在返回 200 后才在响应体中报告错误
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return s3BodyError(body)
+}
+
+// Move 复制后删除源对象
+func (s *S3Filesystem) Move(ctx context.Context, from, to string) error {
+	if err := s.Copy(ctx, from, to); err != nil {
+		return err
+	}
+	return s.Delete(ctx, from)
+}
+
+// Size 对象大小
+func (s *S3Filesystem) Size(ctx context.Context, p string) (int64, error) {
+	header, err := s.head(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+}
+
+// LastModified 最后修改时间
+func (s *S3Filesystem) LastModified(ctx context.Context, p string) (time.Time, error) {
+	header, err := s.head(ctx, p)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return http.ParseTime(header.Get("Last-Modified"))
+}
+
+// GetVisibility 读取对象 ACL，AllUsers 有 READ 权限时为 public
+func (s *S3Filesystem) GetVisibility(ctx context.Context, p string) (string, error) {
+	key, err := s.key(p)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, url.Values{"acl": {""}}, nil, nil, 0)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var policy struct {
+		Grants []struct {
+			URI        string `xml:"Grantee>URI"`
+			Permission string `xml:"Permission"`
+		} `xml:"AccessControlList>Grant"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&policy); err != nil {
+		return "", err
+	}
+	for _, grant := range policy.Grants {
+		if strings.HasSuffix(grant.URI, "/global/AllUsers") && (grant.Permission == "READ" || grant.Permission == "FULL_CONTROL") {
+			return storage.VisibilityPublic, nil
+		}
+	}
+	return storage.VisibilityPrivate, nil
+}
+
+// SetVisibility 设置对象 ACL
+func (s *S3Filesystem) SetVisibility(ctx context.Context, p, visibility string) error {
+	acl, ok := s3ACL[visibility]
+	if !ok {
+		return fmt.Errorf("storage: invalid visibility %q", visibility)
+	}
+	key, err := s.key(p)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, url.Values{"acl": {""}}, http.Header{"X-Amz-Acl": {acl}}, nil, 0)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// URL 公开访问地址，配置了 url 时使用它作为前缀
+func (s *S3Filesystem) URL(p string) string {
+	key, _ := s.key(p)
+	if s.url != "" {
+		return joinURL(s.url, awsEscape(key, false))
+	}
+	return s.objectURL(key, nil).String()
+}
+
+// TemporaryURL 预签名的 GET 地址，最长 7 天
+func (s *S3Filesystem) TemporaryURL(ctx context.Context, p string, expiration time.Duration) (string, error) {
+	if expiration <= 0 || expiration > 7*24*time.Hour {
+		return "", fmt.Errorf("storage: s3 temporary url expiration must be between 1s and 7 days, got %s", expiration)
+	}
+	key, err := s.key(p)
+	if err != nil {
+		return "", err
+	}
+	return s.signer.presign(http.MethodGet, s.objectURL(key, nil), s.now(), expiration), nil
+}
+
+// Files 列出前缀下的对象
+func (s *S3Filesystem) Files(ctx context.Context, directory string, recursive bool) ([]string, error) {
+	files, _, err := s.list(ctx, directory, recursive)
+	return files, err
+}
+
+// Directories 列出前缀下的子目录
+func (s *S3Filesystem) Directories(ctx context.Context, directory string, recursive bool) ([]string, error) {
+	files, dirs, err := s.list(ctx, directory, recursive)
+	if err != nil || !recursive {
+		return dirs, err
+	}
+	// 递归列举时没有 CommonPrefixes，从对象键和目录占位对象推导目录
+	prefix, _ := cleanPath(directory)
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		seen[dir] = true
+	}
+	for _, file := range files {
+		for dir := path.Dir(file); dir != "." && dir != prefix; dir = path.Dir(dir) {
+			seen[dir] = true
+		}
+	}
+	result := make([]string, 0, len(seen))
+	for dir := range seen {
+		result = append(result, dir)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// MakeDirectory 写入目录占位对象
+func (s *S3Filesystem) MakeDirectory(ctx context.Context, p string) error {
+	cleaned, err := cleanPath(p)
+	if err != nil {
+		return err
+	}
+	return s.put(ctx, cleaned+"/", bytes.NewReader(nil), 0, nil)
+}
+
+// DeleteDirectory 删除前缀下的全部对象
+func (s *S3Filesystem) DeleteDirectory(ctx context.Context, p string) error {
+	cleaned, err := cleanPath(p)
+	if err != nil {
+		return err
+	}
+	if cleaned == "" {
+		return errors.New("storage: refusing to delete the disk root")
+	}
+	files, _, err := s.list(ctx, cleaned, true)
+	if err != nil {
+		return err
+	}
+	return s.Delete(ctx, append(files, cleaned+"/")...)
+}
+
+// list 使用 ListObjectsV2 列举，返回相对于 root 的文件和目录
+func (s *S3Filesystem) list(ctx context.Context, directory string, recursive bool) ([]string, []string, error) {
+	prefix, err := s.key(directory)
+	if err != nil {
+		return nil, nil, err
+	}
+	if prefix != "" {
+		prefix += "/"
+	}
+	var files, dirs []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if !recursive {
+			query.Set("delimiter", "/")
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		var result struct {
+			Contents              []struct{ Key string }    `xml:"Contents"`
+			CommonPrefixes        []struct{ Prefix string } `xml:"CommonPrefixes"`
+			IsTruncated           bool                      `xml:"IsTruncated"`
+			NextContinuationToken string                    `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, object := range result.Contents {
+			switch {
+			case !strings.HasSuffix(object.Key, "/"):
+				files = append(files, s.relative(object.Key))
+			case object.Key != prefix:
+				dirs = append(dirs, strings.TrimSuffix(s.relative(object.Key), "/"))
+			}
+		}
+		for _, common := range result.CommonPrefixes {
+			dirs = append(dirs, strings.TrimSuffix(s.relative(common.Prefix), "/"))
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	sort.Strings(files)
+	sort.Strings(dirs)
+	return files, dirs, nil
+}
+
+// head 发送 HeadObject 请求
+func (s *S3Filesystem) head(ctx context.Context, p string) (http.Header, error) {
+	key, err := s.key(p)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodHead, key, nil, nil, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp.Header, nil
+}
+
+// key 磁盘路径对应的对象键
+func (s *S3Filesystem) key(p string) (string, error) {
+	cleaned, err := cleanPath(p)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(path.Join(s.root, cleaned), "/"), nil
+}
+
+// relative 对象键对应的磁盘路径
+func (s *S3Filesystem) relative(key string) string {
+	if s.root == "" {
+		return key
+	}
+	return strings.TrimPrefix(key, s.root+"/")
+}
+
+// objectURL 对象地址，路径风格为 endpoint/bucket/key，否则为 bucket.endpoint/key
+func (s *S3Filesystem) objectURL(key string, query url.Values) *url.URL {
+	u := *s.endpoint
+	objectPath := "/" + key
+	if s.pathStyle {
+		objectPath = "/" + s.bucket
+		if key != "" {
+			objectPath += "/" + key
+		}
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + objectPath
+	u.RawPath = awsEscape(u.Path, false)
+	u.RawQuery = canonicalQuery(query)
+	return &u
+}
+
+// do 发送签名请求，非 2xx 响应转换为错误，404 满足 errors.Is(err, storage.ErrNotFound)
+func (s *S3Filesystem) do(ctx context.Context, method, key string, query url.Values, header http.Header, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key, query).String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if size >= 0 {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	s.signer.sign(req, s.now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", storage.ErrNotFound, key)
+	}
+	if err := s3BodyError(data); err != nil {
+		return nil, fmt.Errorf("%w (status %d)", err, resp.StatusCode)
+	}
+	return nil, fmt.Errorf("storage: s3 %s %s: status %d", method, key, resp.StatusCode)
+}
+
+// s3BodyError 解析 S3 的 XML 错误响应，不是错误响应时返回 nil
+func s3BodyError(body []byte) error {
+	var result struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if xml.Unmarshal(body, &result) != nil || result.XMLName.Local != "Error" {
+		return nil
+	}
+	return fmt.Errorf("storage: s3 %s: %s", result.Code, result.Message)
+}
@@ -0,0 +1,152 @@
+package driver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unsignedPayload 不对请求体计算摘要，允许流式上传
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// sigV4 AWS Signature Version 4 签名器
+type sigV4 struct {
+	key     string
+	secret  string
+	token   string
+	region  string
+	service string
+}
+
+// sign 为请求添加 Authorization 头，签名 host 和全部 x-amz-* 头
+func (s *sigV4) sign(req *http.Request, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	}
+	if s.token != "" {
+		req.Header.Set("X-Amz-Security-Token", s.token)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	scope := s.scope(now)
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.key+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+s.signature(now, amzDate, scope, canonical))
+}
+
+// presign 生成预签名 URL，只签名 host 头
+func (s *sigV4) presign(method string, u *url.URL, now time.Time, expiration time.Duration) string {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	scope := s.scope(now)
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.key+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiration/time.Second)))
+	query.Set("X-Amz-SignedHeaders", "host")
+	if s.token != "" {
+		query.Set("X-Amz-Security-Token", s.token)
+	}
+	canonical := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	query.Set("X-Amz-Signature", s.signature(now, amzDate, scope, canonical))
+	signed := *u
+	signed.RawQuery = canonicalQuery(query)
+	return signed.String()
+}
+
+// scope 凭证范围
+func (s *sigV4) scope(now time.Time) string {
+	return now.UTC().Format("20060102") + "/" + s.region + "/" + s.service + "/aws4_request"
+}
+
+// signature 计算签名
+func (s *sigV4) signature(now time.Time, amzDate, scope, canonical string) string {
+	digest := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(digest[:])
+	key := hmacSHA256([]byte("AWS4"+s.secret), now.UTC().Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s.service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// hmacSHA256 计算 HMAC-SHA256
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery 按名称排序并使用 RFC 3986 编码的查询字符串
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, awsEscape(key, true)+"="+awsEscape(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsEscape 按 AWS 的规则编码，只保留 A-Z a-z 0-9 - _ . ~，encodeSlash 为 false 时保留 "/"
+func awsEscape(value string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+		}
+	}
+	return b.String()
+}
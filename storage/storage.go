@@ -0,0 +1,175 @@
+// Package storage 提供 Laravel 风格的文件存储协议定义
+//
+// Filesystem 对应 Laravel 的 Illuminate\Contracts\Filesystem\Filesystem，统一本地磁盘和对象存储的读写、
+// 复制移动、元数据、URL 和目录列举。Manager 按 config/filesystems.php 的 disks 配置创建磁盘，
+// 每个磁盘的 "driver" 字段决定使用的驱动。文件可以设置 public/private 可见性，
+// 本地驱动映射为文件权限，S3 驱动映射为对象 ACL。
+//
+// 包结构：
+// - storage.go - Filesystem、Manager 接口、WriteOptions、可见性和默认管理器
+// - upload.go - UploadedFile 上传文件，流式保存到磁盘，内容检测 MIME 类型、图片尺寸和内容哈希文件名
+// - validation.go - 上传文件校验规则（image、mimes、max、dimensions 等）
+//
+// 子包 driver 提供 local 和 S3 兼容驱动、Manager 实现，以及测试中替换磁盘的 Fake。
+//
+// 使用示例：
+//
+//	disk, _ := storage.DefaultManager().Disk("s3")
+//	_ = disk.Put(ctx, "avatars/1.png", data, storage.WriteOptions{Visibility: storage.VisibilityPublic})
+//	url := disk.URL("avatars/1.png")
+//	link, _ := disk.TemporaryURL(ctx, "invoices/2024.pdf", 5*time.Minute)
+//
+//	path, err := storage.NewUploadedFile(header).Store("avatars", "s3")
+//
+//	errors, err := storage.ValidateFiles(request, map[string]string{"avatar": "required|image|mimes:jpg,png|max:2048"})
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// 内置驱动名称
+const (
+	// DriverLocal 本地磁盘
+	DriverLocal = "local"
+
+	// DriverS3 Amazon S3 及兼容服务（MinIO、R2 等）
+	DriverS3 = "s3"
+)
+
+// 文件可见性
+const (
+	// VisibilityPublic 公开
+	VisibilityPublic = "public"
+
+	// VisibilityPrivate 私有
+	VisibilityPrivate = "private"
+)
+
+var (
+	// ErrNoManager 没有设置默认存储管理器
+	ErrNoManager = errors.New("storage: no default manager, call storage.SetDefaultManager")
+
+	// ErrNotFound 文件不存在
+	ErrNotFound = errors.New("storage: file not found")
+
+	// ErrUnsupported 驱动不支持该操作
+	ErrUnsupported = errors.New("storage: operation not supported by this driver")
+)
+
+// WriteOptions 写入选项
+type WriteOptions struct {
+	// Visibility 可见性，为空时使用磁盘配置的默认可见性
+	Visibility string
+
+	// ContentType 内容类型，为空时按扩展名推断
+	ContentType string
+}
+
+// Filesystem 文件系统接口
+//
+// 路径使用 "/" 分隔并相对于磁盘根目录，读取不存在的文件时返回的错误满足 errors.Is(err, ErrNotFound)。
+type Filesystem interface {
+	// Exists 文件是否存在
+	Exists(ctx context.Context, path string) (bool, error)
+
+	// Get 读取文件内容
+	Get(ctx context.Context, path string) ([]byte, error)
+
+	// ReadStream 以流的方式读取文件，调用方负责关闭
+	ReadStream(ctx context.Context, path string) (io.ReadCloser, error)
+
+	// Put 写入文件，目录不存在时自动创建
+	Put(ctx context.Context, path string, contents []byte, options ...WriteOptions) error
+
+	// PutStream 以流的方式写入文件
+	PutStream(ctx context.Context, path string, contents io.Reader, options ...WriteOptions) error
+
+	// Delete 删除文件，不存在的文件被忽略
+	Delete(ctx context.Context, paths ...string) error
+
+	// Copy 复制文件
+	Copy(ctx context.Context, from, to string) error
+
+	// Move 移动文件
+	Move(ctx context.Context, from, to string) error
+
+	// Size 文件大小（字节）
+	Size(ctx context.Context, path string) (int64, error)
+
+	// LastModified 最后修改时间
+	LastModified(ctx context.Context, path string) (time.Time, error)
+
+	// GetVisibility 获取文件可见性
+	GetVisibility(ctx context.Context, path string) (string, error)
+
+	// SetVisibility 设置文件可见性
+	SetVisibility(ctx context.Context, path, visibility string) error
+
+	// URL 文件的公开访问地址
+	URL(path string) string
+
+	// TemporaryURL 有效期为 expiration 的临时访问地址，驱动不支持时返回 ErrUnsupported
+	TemporaryURL(ctx context.Context, path string, expiration time.Duration) (string, error)
+
+	// Files 列出目录中的文件，recursive 为 true 时包含子目录中的文件
+	Files(ctx context.Context, directory string, recursive bool) ([]string, error)
+
+	// Directories 列出目录中的子目录，recursive 为 true 时包含所有层级
+	Directories(ctx context.Context, directory string, recursive bool) ([]string, error)
+
+	// MakeDirectory 创建目录
+	MakeDirectory(ctx context.Context, path string) error
+
+	// DeleteDirectory 删除目录及其中的全部文件
+	DeleteDirectory(ctx context.Context, path string) error
+}
+
+// Manager 存储管理器接口，对应 Laravel 的 FilesystemManager
+type Manager interface {
+	// Disk 获取磁盘，不传名称时使用默认磁盘
+	Disk(name ...string) (Filesystem, error)
+
+	// GetDefaultDisk 获取默认磁盘名称
+	GetDefaultDisk() string
+
+	// SetDefaultDisk 设置默认磁盘名称
+	SetDefaultDisk(name string)
+
+	// Extend 注册驱动
+	Extend(driver string, factory func(config map[string]interface{}) (Filesystem, error))
+
+	// Set 用指定实例替换磁盘，测试中用于把磁盘换成假磁盘
+	Set(name string, disk Filesystem)
+
+	// ForgetDisk 移除已创建的磁盘实例，下次获取时按配置重新创建
+	ForgetDisk(names ...string)
+}
+
+// Visibility 取写入选项中的可见性，未指定时返回 fallback
+func Visibility(options []WriteOptions, fallback string) string {
+	if len(options) > 0 && options[0].Visibility != "" {
+		return options[0].Visibility
+	}
+	return fallback
+}
+
+// defaultManager 默认存储管理器
+var defaultManager atomic.Value
+
+// SetDefaultManager 设置默认存储管理器
+func SetDefaultManager(manager Manager) {
+	defaultManager.Store(&manager)
+}
+
+// DefaultManager 获取默认存储管理器，未设置时返回 nil
+func DefaultManager() Manager {
+	if manager, ok := defaultManager.Load().(*Manager); ok {
+		return *manager
+	}
+	return nil
+}
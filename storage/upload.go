@@ -0,0 +1,350 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/routing"
+)
+
+// ErrNotImage 上传文件不是能读取尺寸的图片
+var ErrNotImage = errors.New("storage: the file is not a decodable image")
+
+// UploadedFile 基于 multipart.FileHeader 的上传文件，实现 routing.UploadedFile
+//
+// 保存时直接把上传内容以流的方式写入磁盘，不会整体读入内存；
+// 内容哈希同样以流的方式计算，MIME 类型只读取文件开头的 512 字节检测。
+type UploadedFile struct {
+	header *multipart.FileHeader
+
+	sniff    sync.Once
+	detected string
+}
+
+var _ routing.UploadedFile = (*UploadedFile)(nil)
+
+// NewUploadedFile 创建上传文件
+func NewUploadedFile(header *multipart.FileHeader) *UploadedFile {
+	return &UploadedFile{header: header}
+}
+
+// GetClientOriginalName 获取原始文件名
+func (f *UploadedFile) GetClientOriginalName() string {
+	return filepath.Base(f.header.Filename)
+}
+
+// GetClientOriginalExtension 获取原始扩展名，不含点
+func (f *UploadedFile) GetClientOriginalExtension() string {
+	return strings.TrimPrefix(filepath.Ext(f.header.Filename), ".")
+}
+
+// GetSize 获取文件大小
+func (f *UploadedFile) GetSize() int64 {
+	return f.header.Size
+}
+
+// GetMimeType 获取客户端声明的 MIME 类型
+func (f *UploadedFile) GetMimeType() string {
+	return f.header.Header.Get("Content-Type")
+}
+
+// GetDetectedMimeType 根据文件内容检测的 MIME 类型，不含参数，无法读取时为空
+//
+// 与 GetMimeType 不同，检测结果不受客户端控制，用于 mimes、mimetypes 和 image 校验。
+func (f *UploadedFile) GetDetectedMimeType() string {
+	f.sniff.Do(func() {
+		file, err := f.header.Open()
+		if err != nil {
+			return
+		}
+		defer file.Close()
+		head := make([]byte, 512)
+		n, err := io.ReadFull(file, head)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return
+		}
+		f.detected = detectMimeType(head[:n])
+	})
+	return f.detected
+}
+
+// GuessExtension 根据检测的 MIME 类型推断扩展名，不含点，无法推断时为空
+func (f *UploadedFile) GuessExtension() string {
+	if extensions := mimeExtensions(f.GetDetectedMimeType()); len(extensions) > 0 {
+		return extensions[0]
+	}
+	return ""
+}
+
+// IsImage 内容是否为 jpeg、png、gif、bmp、webp 图片，allowSVG 为 true 时也接受 SVG
+func (f *UploadedFile) IsImage(allowSVG bool) bool {
+	switch f.GetDetectedMimeType() {
+	case "image/jpeg", "image/png", "image/gif", "image/bmp", "image/webp":
+		return true
+	case "image/svg+xml":
+		return allowSVG
+	}
+	return false
+}
+
+// Dimensions 读取图片的宽和高，只解码图片头部，支持 jpeg、png、gif，其他格式返回 ErrNotImage
+func (f *UploadedFile) Dimensions() (width, height int, err error) {
+	file, err := f.header.Open()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return 0, 0, ErrNotImage
+	}
+	return config.Width, config.Height, nil
+}
+
+// ContentHash 以流的方式计算文件内容的 SHA-256，返回十六进制字符串
+func (f *UploadedFile) ContentHash() (string, error) {
+	file, err := f.header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// ContentHashName 以内容哈希作为文件名，保留扩展名，相同内容得到相同文件名
+func (f *UploadedFile) ContentHashName() (string, error) {
+	name, err := f.ContentHash()
+	if err != nil {
+		return "", err
+	}
+	if ext := f.extension(); ext != "" {
+		name += "." + ext
+	}
+	return name, nil
+}
+
+// IsValid 文件能否打开
+func (f *UploadedFile) IsValid() bool {
+	file, err := f.header.Open()
+	if err != nil {
+		return false
+	}
+	return file.Close() == nil
+}
+
+// HashName 生成随机文件名，保留扩展名
+func (f *UploadedFile) HashName() string {
+	b := make([]byte, 20)
+	_, _ = rand.Read(b)
+	name := hex.EncodeToString(b)
+	if ext := f.extension(); ext != "" {
+		name += "." + ext
+	}
+	return name
+}
+
+// Store 使用随机文件名保存到 disk 磁盘的 directory 目录，disk 为空时使用默认磁盘，返回文件路径
+func (f *UploadedFile) Store(directory string, disk string) (string, error) {
+	filesystem, err := resolveDisk(disk)
+	if err != nil {
+		return "", err
+	}
+	return f.StoreOn(context.Background(), filesystem, directory, f.HashName())
+}
+
+// StoreAs 以 name 为文件名保存到默认磁盘的 directory 目录，返回文件路径
+func (f *UploadedFile) StoreAs(directory string, name string) (string, error) {
+	filesystem, err := resolveDisk("")
+	if err != nil {
+		return "", err
+	}
+	return f.StoreOn(context.Background(), filesystem, directory, name)
+}
+
+// StoreOn 以流的方式保存到指定磁盘，name 为空时使用随机文件名，返回文件路径
+func (f *UploadedFile) StoreOn(ctx context.Context, disk Filesystem, directory, name string, options ...WriteOptions) (string, error) {
+	if name == "" {
+		name = f.HashName()
+	}
+	file, err := f.header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	option := WriteOptions{ContentType: f.GetMimeType()}
+	if len(options) > 0 {
+		option.Visibility = options[0].Visibility
+		if options[0].ContentType != "" {
+			option.ContentType = options[0].ContentType
+		}
+	}
+	target := strings.TrimPrefix(path.Join(directory, name), "/")
+	if err := disk.PutStream(ctx, target, file, option); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// StoreHashed 以内容哈希为文件名保存到磁盘的 directory 目录，返回文件路径
+//
+// 同一内容只保存一份：目标文件已经存在时不再写入，直接返回其路径。
+func (f *UploadedFile) StoreHashed(ctx context.Context, disk Filesystem, directory string, options ...WriteOptions) (string, error) {
+	name, err := f.ContentHashName()
+	if err != nil {
+		return "", err
+	}
+	target := strings.TrimPrefix(path.Join(directory, name), "/")
+	exists, err := disk.Exists(ctx, target)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return target, nil
+	}
+	return f.StoreOn(ctx, disk, directory, name, options...)
+}
+
+// Move 把文件移动到本地目录
+func (f *UploadedFile) Move(directory string, name string) error {
+	if name == "" {
+		name = f.GetClientOriginalName()
+	}
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return err
+	}
+	file, err := f.header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	target, err := os.Create(filepath.Join(directory, filepath.Base(name)))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(target, file); err != nil {
+		target.Close()
+		return err
+	}
+	return target.Close()
+}
+
+// GetPathname 获取临时文件路径，上传内容保存在内存中时为空
+func (f *UploadedFile) GetPathname() string {
+	file, err := f.header.Open()
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	if osFile, ok := file.(*os.File); ok {
+		return osFile.Name()
+	}
+	return ""
+}
+
+// GetRealPath 获取临时文件的绝对路径
+func (f *UploadedFile) GetRealPath() string {
+	pathname := f.GetPathname()
+	if pathname == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(pathname)
+	if err != nil {
+		return pathname
+	}
+	return abs
+}
+
+// extension 优先使用原始扩展名，否则按 MIME 类型推断
+func (f *UploadedFile) extension() string {
+	if ext := f.GetClientOriginalExtension(); ext != "" {
+		return strings.ToLower(ext)
+	}
+	if extensions, _ := mime.ExtensionsByType(f.GetMimeType()); len(extensions) > 0 {
+		return strings.TrimPrefix(extensions[0], ".")
+	}
+	return ""
+}
+
+// detectMimeType 检测内容的 MIME 类型，在 http.DetectContentType 的基础上识别 SVG
+func detectMimeType(head []byte) string {
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if (detected == "text/xml" || detected == "text/plain") && bytes.Contains(bytes.ToLower(head), []byte("<svg")) {
+		return "image/svg+xml"
+	}
+	return detected
+}
+
+// commonExtensions 常见 MIME 类型的首选扩展名，系统 MIME 表不完整时使用
+var commonExtensions = map[string][]string{
+	"image/jpeg":       {"jpg", "jpeg"},
+	"image/png":        {"png"},
+	"image/gif":        {"gif"},
+	"image/bmp":        {"bmp"},
+	"image/webp":       {"webp"},
+	"image/svg+xml":    {"svg"},
+	"application/pdf":  {"pdf"},
+	"application/zip":  {"zip"},
+	"text/plain":       {"txt"},
+	"text/html":        {"html", "htm"},
+	"text/xml":         {"xml"},
+	"application/json": {"json"},
+	"video/mp4":        {"mp4"},
+	"audio/mpeg":       {"mp3"},
+}
+
+// mimeExtensions MIME 类型对应的扩展名，不含点，首选扩展名在前
+func mimeExtensions(mimeType string) []string {
+	extensions := append([]string(nil), commonExtensions[mimeType]...)
+	known, _ := mime.ExtensionsByType(mimeType)
+	for _, ext := range known {
+		ext = strings.TrimPrefix(ext, ".")
+		found := false
+		for _, existing := range extensions {
+			found = found || existing == ext
+		}
+		if !found {
+			extensions = append(extensions, ext)
+		}
+	}
+	return extensions
+}
+
+// resolveDisk 通过默认管理器获取磁盘
+func resolveDisk(name string) (Filesystem, error) {
+	manager := DefaultManager()
+	if manager == nil {
+		return nil, ErrNoManager
+	}
+	if name == "" {
+		return manager.Disk()
+	}
+	return manager.Disk(name)
+}
+
+// init 把 UploadedFile 注册为 routing.Request.File 使用的上传文件
+func init() {
+	routing.SetUploadedFileFactory(func(header *multipart.FileHeader) routing.UploadedFile {
+		return NewUploadedFile(header)
+	})
+}
@@ -0,0 +1,353 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/routing"
+)
+
+// ErrInvalidRule 文件校验规则无法解析
+var ErrInvalidRule = errors.New("storage: invalid file validation rule")
+
+// FileRule 上传文件校验规则，对应 Laravel 验证器中作用于文件的规则
+type FileRule interface {
+	// Passes 文件是否通过校验
+	Passes(file *UploadedFile) bool
+
+	// Message 校验失败的错误信息，attribute 为字段名
+	Message(attribute string) string
+}
+
+// fileRule 由规则字符串解析的 FileRule
+type fileRule struct {
+	passes  func(file *UploadedFile) bool
+	message string
+}
+
+func (r *fileRule) Passes(file *UploadedFile) bool { return r.passes(file) }
+
+func (r *fileRule) Message(attribute string) string {
+	return strings.ReplaceAll(r.message, ":attribute", displayName(attribute))
+}
+
+// displayName 错误信息中的字段名，下划线替换为空格
+func displayName(attribute string) string {
+	return strings.ReplaceAll(attribute, "_", " ")
+}
+
+// ParseFileRule 解析规则字符串，支持：
+//
+//	file                       是可以打开的上传文件
+//	image[:allow_svg]          jpeg、png、gif、bmp、webp 图片，allow_svg 时也接受 SVG
+//	mimes:jpg,png              根据内容推断的扩展名在列表中，jpg 与 jpeg 等价
+//	mimetypes:image/png,video/* 根据内容检测的 MIME 类型在列表中，支持 "类型/*"
+//	max:2048 / min:10 / size:100 / between:10,2048  文件大小，单位 KB
+//	dimensions:min_width=100,max_width=1000,min_height=100,max_height=1000,width=300,height=200,ratio=3/2
+//
+// 无法识别的规则返回 ErrInvalidRule。
+func ParseFileRule(rule string) (FileRule, error) {
+	name, parameter, _ := strings.Cut(strings.TrimSpace(rule), ":")
+	var params []string
+	if parameter != "" {
+		params = strings.Split(parameter, ",")
+	}
+	invalid := fmt.Errorf("%w: %q", ErrInvalidRule, rule)
+
+	switch name {
+	case "file":
+		return &fileRule{
+			passes:  func(file *UploadedFile) bool { return file.IsValid() },
+			message: "The :attribute field must be a file.",
+		}, nil
+	case "image":
+		allowSVG := len(params) > 0 && params[0] == "allow_svg"
+		return &fileRule{
+			passes:  func(file *UploadedFile) bool { return file.IsImage(allowSVG) },
+			message: "The :attribute field must be an image.",
+		}, nil
+	case "mimes":
+		if len(params) == 0 {
+			return nil, invalid
+		}
+		return &fileRule{
+			passes:  func(file *UploadedFile) bool { return matchesExtension(file, params) },
+			message: "The :attribute field must be a file of type: " + strings.Join(params, ", ") + ".",
+		}, nil
+	case "mimetypes":
+		if len(params) == 0 {
+			return nil, invalid
+		}
+		return &fileRule{
+			passes:  func(file *UploadedFile) bool { return matchesMimeType(file.GetDetectedMimeType(), params) },
+			message: "The :attribute field must be a file of type: " + strings.Join(params, ", ") + ".",
+		}, nil
+	case "max", "min", "size":
+		if len(params) != 1 {
+			return nil, invalid
+		}
+		limit, err := strconv.ParseFloat(params[0], 64)
+		if err != nil {
+			return nil, invalid
+		}
+		return sizeRule(name, limit), nil
+	case "between":
+		if len(params) != 2 {
+			return nil, invalid
+		}
+		lower, err1 := strconv.ParseFloat(params[0], 64)
+		upper, err2 := strconv.ParseFloat(params[1], 64)
+		if err1 != nil || err2 != nil {
+			return nil, invalid
+		}
+		return &fileRule{
+			passes: func(file *UploadedFile) bool {
+				size := kilobytes(file)
+				return size >= lower && size <= upper
+			},
+			message: fmt.Sprintf("The :attribute field must be between %s and %s kilobytes.", params[0], params[1]),
+		}, nil
+	case "dimensions":
+		constraints, err := parseDimensions(params)
+		if err != nil {
+			return nil, invalid
+		}
+		return &fileRule{
+			passes:  constraints.passes,
+			message: "The :attribute field has invalid image dimensions.",
+		}, nil
+	}
+	return nil, invalid
+}
+
+// Validate 按规则校验文件，返回全部校验失败的错误信息，规则无法解析时返回错误
+//
+// 每个规则可以是单个规则或用 "|" 分隔的多个规则。
+//
+//	messages, err := file.Validate("avatar", "image|mimes:jpg,png|max:2048", "dimensions:ratio=3/2")
+func (f *UploadedFile) Validate(attribute string, rules ...string) ([]string, error) {
+	var messages []string
+	for _, rule := range splitRules(rules) {
+		parsed, err := ParseFileRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		if !parsed.Passes(f) {
+			messages = append(messages, parsed.Message(attribute))
+		}
+	}
+	return messages, nil
+}
+
+// ValidateFiles 按字段校验请求中的上传文件，返回字段到错误信息的映射，全部通过时返回空映射
+//
+// 除 ParseFileRule 支持的规则外，还支持 required（文件必须上传）和 nullable（只是说明可以不上传）；
+// 没有上传文件的字段跳过其他规则。返回值可以直接用于 exceptions.NewValidationError。
+//
+//	errors, err := storage.ValidateFiles(request, map[string]string{
+//		"avatar": "required|image|max:2048|dimensions:min_width=100,ratio=1",
+//	})
+//	if len(errors) > 0 {
+//		return exceptions.NewValidationError(errors)
+//	}
+func ValidateFiles(request routing.RequestInterface, rules map[string]string) (map[string][]string, error) {
+	failures := make(map[string][]string)
+	for field, fieldRules := range rules {
+		var fileRules []string
+		required := false
+		for _, rule := range splitRules([]string{fieldRules}) {
+			switch rule {
+			case "required":
+				required = true
+			case "nullable":
+			default:
+				fileRules = append(fileRules, rule)
+			}
+		}
+
+		uploaded, _ := request.File(field).(*UploadedFile)
+		if uploaded == nil {
+			if required {
+				failures[field] = append(failures[field], "The "+displayName(field)+" field is required.")
+			}
+			// 规则本身仍需校验，避免拼写错误的规则只在上传文件时暴露
+			for _, rule := range fileRules {
+				if _, err := ParseFileRule(rule); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		messages, err := uploaded.Validate(field, fileRules...)
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) > 0 {
+			failures[field] = append(failures[field], messages...)
+		}
+	}
+	return failures, nil
+}
+
+// splitRules 拆分 "|" 分隔的规则并去掉空规则
+func splitRules(rules []string) []string {
+	var split []string
+	for _, rule := range rules {
+		for _, part := range strings.Split(rule, "|") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
+
+// sizeRule max、min 和 size 规则
+func sizeRule(name string, limit float64) FileRule {
+	value := strconv.FormatFloat(limit, 'f', -1, 64)
+	switch name {
+	case "max":
+		return &fileRule{
+			passes:  func(file *UploadedFile) bool { return kilobytes(file) <= limit },
+			message: "The :attribute field must not be greater than " + value + " kilobytes.",
+		}
+	case "min":
+		return &fileRule{
+			passes:  func(file *UploadedFile) bool { return kilobytes(file) >= limit },
+			message: "The :attribute field must be at least " + value + " kilobytes.",
+		}
+	}
+	return &fileRule{
+		passes:  func(file *UploadedFile) bool { return kilobytes(file) == limit },
+		message: "The :attribute field must be " + value + " kilobytes.",
+	}
+}
+
+// kilobytes 文件大小，单位 KB
+func kilobytes(file *UploadedFile) float64 {
+	return float64(file.GetSize()) / 1024
+}
+
+// matchesExtension 根据内容推断的扩展名是否在列表中
+func matchesExtension(file *UploadedFile, allowed []string) bool {
+	for _, ext := range mimeExtensions(file.GetDetectedMimeType()) {
+		for _, candidate := range allowed {
+			if strings.EqualFold(strings.TrimSpace(candidate), ext) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesMimeType MIME 类型是否在列表中，列表项可以是 "类型/*"
+func matchesMimeType(detected string, allowed []string) bool {
+	if detected == "" {
+		return false
+	}
+	for _, candidate := range allowed {
+		candidate = strings.ToLower(strings.TrimSpace(candidate))
+		if candidate == detected {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(candidate, "/*"); ok && strings.HasPrefix(detected, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// dimensionConstraints dimensions 规则的约束，0 表示不限制
+type dimensionConstraints struct {
+	width, height        int
+	minWidth, maxWidth   int
+	minHeight, maxHeight int
+	ratio                float64
+}
+
+// parseDimensions 解析 dimensions 规则的参数
+func parseDimensions(params []string) (*dimensionConstraints, error) {
+	if len(params) == 0 {
+		return nil, ErrInvalidRule
+	}
+	c := &dimensionConstraints{}
+	for _, param := range params {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			return nil, ErrInvalidRule
+		}
+		if key == "ratio" {
+			ratio, err := parseRatio(value)
+			if err != nil {
+				return nil, err
+			}
+			c.ratio = ratio
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, ErrInvalidRule
+		}
+		switch key {
+		case "width":
+			c.width = n
+		case "height":
+			c.height = n
+		case "min_width":
+			c.minWidth = n
+		case "max_width":
+			c.maxWidth = n
+		case "min_height":
+			c.minHeight = n
+		case "max_height":
+			c.maxHeight = n
+		default:
+			return nil, ErrInvalidRule
+		}
+	}
+	return c, nil
+}
+
+// parseRatio 解析 "3/2" 或 "1.5" 形式的宽高比
+func parseRatio(value string) (float64, error) {
+	numerator, denominator, fraction := strings.Cut(value, "/")
+	n, err := strconv.ParseFloat(numerator, 64)
+	if err != nil || n <= 0 {
+		return 0, ErrInvalidRule
+	}
+	if !fraction {
+		return n, nil
+	}
+	d, err := strconv.ParseFloat(denominator, 64)
+	if err != nil || d <= 0 {
+		return 0, ErrInvalidRule
+	}
+	return n / d, nil
+}
+
+// passes 图片尺寸是否满足全部约束，宽高比的容差与 Laravel 相同：1 / (较短边 + 1)
+func (c *dimensionConstraints) passes(file *UploadedFile) bool {
+	width, height, err := file.Dimensions()
+	if err != nil || width == 0 || height == 0 {
+		return false
+	}
+	switch {
+	case c.width > 0 && width != c.width,
+		c.height > 0 && height != c.height,
+		c.minWidth > 0 && width < c.minWidth,
+		c.maxWidth > 0 && width > c.maxWidth,
+		c.minHeight > 0 && height < c.minHeight,
+		c.maxHeight > 0 && height > c.maxHeight:
+		return false
+	}
+	if c.ratio > 0 {
+		tolerance := 1 / float64(min(width, height)+1)
+		if math.Abs(c.ratio-float64(width)/float64(height)) > tolerance {
+			return false
+		}
+	}
+	return true
+}
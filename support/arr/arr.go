@@ -0,0 +1,173 @@
+// Package arr 提供 Laravel 风格的嵌套 map 辅助函数，对应 Illuminate\Support\Arr
+//
+// 嵌套数据以 map[string]interface{} 和 []interface{} 表示（与 encoding/json 解码结果一致），
+// 路径以点号分隔，切片元素以下标表示，例如 "users.0.name"。
+//
+// 包结构：
+// - arr.go - Dot、Undot、Get、Has、Set、Forget、Only、Except
+//
+// 使用示例：
+//
+//	data := map[string]interface{}{"user": map[string]interface{}{"name": "Taylor", "roles": []interface{}{"admin"}}}
+//	arr.Get(data, "user.name")               // "Taylor"
+//	arr.Get(data, "user.roles.0")            // "admin"
+//	arr.Get(data, "user.email", "none")      // "none"
+//	arr.Dot(data)                            // {"user.name": "Taylor", "user.roles.0": "admin"}
+//	arr.Only(input, "email", "password")
+package arr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dot 把嵌套数据展开为以点号路径为键的单层 map，空 map 和空切片保留为值
+func Dot(data map[string]interface{}, prepend ...string) map[string]interface{} {
+	prefix := ""
+	if len(prepend) > 0 {
+		prefix = prepend[0]
+	}
+	result := make(map[string]interface{})
+	dot(result, prefix, data)
+	return result
+}
+
+// dot 递归展开
+func dot(result map[string]interface{}, prefix string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) > 0 {
+			for key, item := range v {
+				dot(result, prefix+key+".", item)
+			}
+			return
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			for i, item := range v {
+				dot(result, prefix+strconv.Itoa(i)+".", item)
+			}
+			return
+		}
+	}
+	result[strings.TrimSuffix(prefix, ".")] = value
+}
+
+// Undot 把点号路径为键的单层 map 还原为嵌套 map，下标路径也还原为 map 的键
+func Undot(data map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range data {
+		Set(result, key, value)
+	}
+	return result
+}
+
+// Get 按点号路径获取值，路径不存在时返回 fallback（默认 nil）
+//
+// 完整的键优先于路径，因此包含点号的键也可以直接获取。
+func Get(data map[string]interface{}, key string, fallback ...interface{}) interface{} {
+	if value, ok := lookup(data, key); ok {
+		return value
+	}
+	if len(fallback) > 0 {
+		return fallback[0]
+	}
+	return nil
+}
+
+// Has 路径是否存在
+func Has(data map[string]interface{}, key string) bool {
+	_, ok := lookup(data, key)
+	return ok
+}
+
+// lookup 按路径查找
+func lookup(data map[string]interface{}, key string) (interface{}, bool) {
+	if data == nil {
+		return nil, false
+	}
+	if value, ok := data[key]; ok {
+		return value, true
+	}
+	var current interface{} = data
+	for _, segment := range strings.Split(key, ".") {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			value, ok := v[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			current = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Set 按点号路径设置值，缺少的中间层创建为 map，非 map 的中间值会被替换
+func Set(data map[string]interface{}, key string, value interface{}) {
+	segments := strings.Split(key, ".")
+	current := data
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := current[segment].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			current[segment] = next
+		}
+		current = next
+	}
+	current[segments[len(segments)-1]] = value
+}
+
+// Forget 按点号路径删除，路径不存在时不做任何事
+func Forget(data map[string]interface{}, keys ...string) {
+	for _, key := range keys {
+		if _, ok := data[key]; ok {
+			delete(data, key)
+			continue
+		}
+		segments := strings.Split(key, ".")
+		current := data
+		for _, segment := range segments[:len(segments)-1] {
+			next, ok := current[segment].(map[string]interface{})
+			if !ok {
+				current = nil
+				break
+			}
+			current = next
+		}
+		if current != nil {
+			delete(current, segments[len(segments)-1])
+		}
+	}
+}
+
+// Only 只保留指定的顶层键
+func Only[V any](data map[string]V, keys ...string) map[string]V {
+	result := make(map[string]V, len(keys))
+	for _, key := range keys {
+		if value, ok := data[key]; ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// Except 去掉指定的顶层键
+func Except[V any](data map[string]V, keys ...string) map[string]V {
+	result := make(map[string]V, len(data))
+	for key, value := range data {
+		result[key] = value
+	}
+	for _, key := range keys {
+		delete(result, key)
+	}
+	return result
+}
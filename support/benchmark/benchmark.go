@@ -0,0 +1,276 @@
+// Package benchmark 提供耗时测量和带内存分配预算的基准测试套件，对应 Illuminate\Support\Benchmark
+//
+// Measure 和 Value 用于在代码中临时测量耗时。Suite 把一组基准测试用例放在一起运行，
+// 每个用例可以声明每次操作的内存分配次数上限，超出预算时 Report.Err 返回 ErrOverBudget，
+// 在 CI 的测试中调用即可防止请求热路径上的分配悄悄增加。
+//
+// 内置用例覆盖每个请求都会经过的三个环节：路由匹配（RouteMatch）、中间件管道（Middleware）
+// 和容器解析（ContainerResolve）。
+//
+// 本包导入了 testing，以便用 testing.Benchmark 和 testing.AllocsPerRun 测量，
+// 测量结果与 go test -bench 一致。它必须是可导入的普通包而不是 _test.go：
+// 框架只定义了路由和容器的接口，预算要由应用在自己的测试中针对自己的路由器、
+// 中间件栈和容器运行。testing 的命令行参数只在 testing.Init 中注册，
+// 非测试程序导入本包不会增加参数，但仍会链接 testing，因此只应在测试和基准工具中导入。
+// 框架自身的预算见 routing 和 container 包的 TestAllocationBudget。
+//
+// 包结构：
+// - benchmark.go - Measure、Value、Suite、Case、Report 和内置用例
+//
+// 使用示例：
+//
+//	suite := benchmark.NewSuite(
+//		benchmark.RouteMatch("match /users/{id}", routes, request, 2),
+//		benchmark.Middleware("web middleware", kernelHandler, httptest.NewRequest("GET", "/users/1", nil), 20),
+//		benchmark.ContainerResolve("resolve cache", app, "cache", 0),
+//	)
+//
+//	// 测试中强制预算
+//	func TestAllocationBudget(t *testing.T) {
+//		if err := suite.Run().Err(); err != nil {
+//			t.Fatal(err)
+//		}
+//	}
+//
+//	// go test -bench . 时作为子基准测试运行
+//	func BenchmarkDispatch(b *testing.B) {
+//		suite.Benchmark(b)
+//	}
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cnote0/laraveldoc/container"
+	"github.com/cnote0/laraveldoc/routing"
+)
+
+// ErrOverBudget 用例每次操作的内存分配次数超出预算
+var ErrOverBudget = errors.New("benchmark: allocation budget exceeded")
+
+// Measure 运行 fn iterations 次，返回平均耗时，iterations 小于 1 时按 1 次
+func Measure(fn func(), iterations int) time.Duration {
+	if iterations < 1 {
+		iterations = 1
+	}
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		fn()
+	}
+	return time.Since(start) / time.Duration(iterations)
+}
+
+// Value 运行一次 fn，返回结果和耗时
+func Value[T any](fn func() T) (T, time.Duration) {
+	start := time.Now()
+	value := fn()
+	return value, time.Since(start)
+}
+
+// NoBudget 不限制内存分配次数
+const NoBudget = -1
+
+// Case 基准测试用例
+type Case struct {
+	// Name 用例名称
+	Name string
+
+	// Run 被测量的一次操作
+	Run func()
+
+	// MaxAllocs 每次操作允许的内存分配次数，NoBudget 表示不限制
+	MaxAllocs int64
+}
+
+// Result 用例的测量结果
+type Result struct {
+	// Name 用例名称
+	Name string
+
+	// N 运行次数
+	N int
+
+	// NsPerOp 每次操作的纳秒数
+	NsPerOp int64
+
+	// AllocsPerOp 每次操作的内存分配次数
+	AllocsPerOp int64
+
+	// BytesPerOp 每次操作分配的字节数
+	BytesPerOp int64
+
+	// MaxAllocs 分配次数预算
+	MaxAllocs int64
+}
+
+// OverBudget 是否超出分配预算
+func (r Result) OverBudget() bool {
+	return r.MaxAllocs != NoBudget && r.AllocsPerOp > r.MaxAllocs
+}
+
+// String 与 go test -bench 输出相同的格式
+func (r Result) String() string {
+	line := fmt.Sprintf("%s\t%d\t%d ns/op\t%d B/op\t%d allocs/op", r.Name, r.N, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+	if r.MaxAllocs != NoBudget {
+		line += fmt.Sprintf(" (budget %d)", r.MaxAllocs)
+	}
+	return line
+}
+
+// Report 套件的测量结果
+type Report struct {
+	Results []Result
+}
+
+// Err 有用例超出分配预算时返回包装 ErrOverBudget 的错误，列出所有超出的用例
+func (r Report) Err() error {
+	var over []string
+	for _, result := range r.Results {
+		if result.OverBudget() {
+			over = append(over, fmt.Sprintf("%s: %d allocs/op > %d", result.Name, result.AllocsPerOp, result.MaxAllocs))
+		}
+	}
+	if len(over) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrOverBudget, strings.Join(over, "; "))
+}
+
+// String 每个用例一行
+func (r Report) String() string {
+	lines := make([]string, len(r.Results))
+	for i, result := range r.Results {
+		lines[i] = result.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Suite 基准测试套件
+type Suite struct {
+	cases []Case
+}
+
+// NewSuite 创建套件
+func NewSuite(cases ...Case) *Suite {
+	return &Suite{cases: cases}
+}
+
+// Add 添加用例
+func (s *Suite) Add(cases ...Case) *Suite {
+	s.cases = append(s.cases, cases...)
+	return s
+}
+
+// Run 依次运行所有用例，每个用例按 go test 的默认基准时间（1 秒）运行
+func (s *Suite) Run() Report {
+	report := Report{Results: make([]Result, 0, len(s.cases))}
+	for _, c := range s.cases {
+		run := c.Run
+		measured := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				run()
+			}
+		})
+		report.Results = append(report.Results, Result{
+			Name:        c.Name,
+			N:           measured.N,
+			NsPerOp:     measured.NsPerOp(),
+			AllocsPerOp: measured.AllocsPerOp(),
+			BytesPerOp:  measured.AllocedBytesPerOp(),
+			MaxAllocs:   c.MaxAllocs,
+		})
+	}
+	return report
+}
+
+// Benchmark 把每个用例作为 b 的子基准测试运行，超出分配预算的用例报告为失败
+func (s *Suite) Benchmark(b *testing.B) {
+	for _, c := range s.cases {
+		c := c
+		b.Run(c.Name, func(b *testing.B) {
+			if c.MaxAllocs != NoBudget {
+				if allocs := int64(testing.AllocsPerRun(100, c.Run)); allocs > c.MaxAllocs {
+					b.Errorf("%v: %d allocs/op > %d", ErrOverBudget, allocs, c.MaxAllocs)
+				}
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				c.Run()
+			}
+		})
+	}
+}
+
+// RouteMatch 路由匹配用例，request 应当能匹配到路由，没有匹配时 Run 会 panic 以免测量到错误的路径
+func RouteMatch(name string, routes routing.RouteCollection, request routing.RequestInterface, maxAllocs int64) Case {
+	return Case{
+		Name: name,
+		Run: func() {
+			if routes.Match(request) == nil {
+				panic(fmt.Sprintf("benchmark: %s %s matches no route", request.GetMethod(), request.GetPath()))
+			}
+		},
+		MaxAllocs: maxAllocs,
+	}
+}
+
+// Middleware 中间件管道用例，每次操作把 request 交给 handler，响应写入可复用的空 ResponseWriter，
+// 分配次数只包含管道本身
+func Middleware(name string, handler http.Handler, request *http.Request, maxAllocs int64) Case {
+	w := &discardWriter{header: make(http.Header)}
+	return Case{
+		Name: name,
+		Run: func() {
+			clear(w.header)
+			w.status = 0
+			handler.ServeHTTP(w, request)
+		},
+		MaxAllocs: maxAllocs,
+	}
+}
+
+// ContainerResolve 容器解析用例，解析失败时 Run 会 panic
+func ContainerResolve(name string, c container.Container, abstract interface{}, maxAllocs int64) Case {
+	return Case{
+		Name: name,
+		Run: func() {
+			if _, err := c.Make(abstract); err != nil {
+				panic(fmt.Sprintf("benchmark: resolve %v: %v", abstract, err))
+			}
+		},
+		MaxAllocs: maxAllocs,
+	}
+}
+
+// discardWriter 丢弃响应体的 ResponseWriter
+type discardWriter struct {
+	header http.Header
+	status int
+}
+
+// Header 实现 http.ResponseWriter
+func (w *discardWriter) Header() http.Header {
+	return w.header
+}
+
+// Write 实现 http.ResponseWriter
+func (w *discardWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return len(p), nil
+}
+
+// WriteHeader 实现 http.ResponseWriter
+func (w *discardWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
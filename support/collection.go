@@ -0,0 +1,328 @@
+package support
+
+import (
+	"cmp"
+	"encoding/json"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// Collection 集合，对应 Laravel 的 Illuminate\Support\Collection
+type Collection[T any] struct {
+	items []T
+}
+
+// Collect 创建集合，不复制传入的切片
+func Collect[T any](items []T) *Collection[T] {
+	return &Collection[T]{items: items}
+}
+
+// Of 以参数创建集合
+func Of[T any](items ...T) *Collection[T] {
+	return &Collection[T]{items: items}
+}
+
+// All 集合中的所有元素
+func (c *Collection[T]) All() []T {
+	return c.items
+}
+
+// Seq 按顺序遍历元素的迭代器
+func (c *Collection[T]) Seq() iter.Seq[T] {
+	return slices.Values(c.items)
+}
+
+// Lazy 转换为惰性集合
+func (c *Collection[T]) Lazy() *LazyCollection[T] {
+	return Lazy(c.Seq())
+}
+
+// Count 元素数量
+func (c *Collection[T]) Count() int {
+	return len(c.items)
+}
+
+// IsEmpty 是否为空
+func (c *Collection[T]) IsEmpty() bool {
+	return len(c.items) == 0
+}
+
+// IsNotEmpty 是否不为空
+func (c *Collection[T]) IsNotEmpty() bool {
+	return len(c.items) > 0
+}
+
+// Get 按位置获取元素，位置不存在时返回 false
+func (c *Collection[T]) Get(index int) (T, bool) {
+	if index < 0 || index >= len(c.items) {
+		var zero T
+		return zero, false
+	}
+	return c.items[index], true
+}
+
+// First 第一个满足条件的元素，不传条件时返回第一个元素
+func (c *Collection[T]) First(predicate ...func(item T, index int) bool) (T, bool) {
+	for i, item := range c.items {
+		if len(predicate) == 0 || predicate[0](item, i) {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Last 最后一个满足条件的元素，不传条件时返回最后一个元素
+func (c *Collection[T]) Last(predicate ...func(item T, index int) bool) (T, bool) {
+	for i := len(c.items) - 1; i >= 0; i-- {
+		if len(predicate) == 0 || predicate[0](c.items[i], i) {
+			return c.items[i], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Each 遍历元素，回调返回 false 时停止
+func (c *Collection[T]) Each(callback func(item T, index int) bool) *Collection[T] {
+	for i, item := range c.items {
+		if !callback(item, i) {
+			break
+		}
+	}
+	return c
+}
+
+// Filter 保留满足条件的元素
+func (c *Collection[T]) Filter(predicate func(item T, index int) bool) *Collection[T] {
+	items := make([]T, 0, len(c.items))
+	for i, item := range c.items {
+		if predicate(item, i) {
+			items = append(items, item)
+		}
+	}
+	return Collect(items)
+}
+
+// Reject 去掉满足条件的元素
+func (c *Collection[T]) Reject(predicate func(item T, index int) bool) *Collection[T] {
+	return c.Filter(func(item T, index int) bool { return !predicate(item, index) })
+}
+
+// Partition 按条件分为满足和不满足的两个集合
+func (c *Collection[T]) Partition(predicate func(item T, index int) bool) (*Collection[T], *Collection[T]) {
+	var pass, fail []T
+	for i, item := range c.items {
+		if predicate(item, i) {
+			pass = append(pass, item)
+		} else {
+			fail = append(fail, item)
+		}
+	}
+	return Collect(pass), Collect(fail)
+}
+
+// Contains 是否有元素满足条件
+func (c *Collection[T]) Contains(predicate func(item T, index int) bool) bool {
+	_, ok := c.First(predicate)
+	return ok
+}
+
+// Every 是否所有元素都满足条件，空集合返回 true
+func (c *Collection[T]) Every(predicate func(item T, index int) bool) bool {
+	return !c.Contains(func(item T, index int) bool { return !predicate(item, index) })
+}
+
+// Sort 按 less 稳定排序
+func (c *Collection[T]) Sort(less func(a, b T) bool) *Collection[T] {
+	items := slices.Clone(c.items)
+	sort.SliceStable(items, func(i, j int) bool { return less(items[i], items[j]) })
+	return Collect(items)
+}
+
+// Reverse 反转顺序
+func (c *Collection[T]) Reverse() *Collection[T] {
+	items := slices.Clone(c.items)
+	slices.Reverse(items)
+	return Collect(items)
+}
+
+// Take 取前 limit 个元素，limit 为负数时取最后 -limit 个
+func (c *Collection[T]) Take(limit int) *Collection[T] {
+	if limit < 0 {
+		return c.Slice(len(c.items)+limit, -limit)
+	}
+	return c.Slice(0, limit)
+}
+
+// Skip 跳过前 count 个元素
+func (c *Collection[T]) Skip(count int) *Collection[T] {
+	return c.Slice(count, len(c.items))
+}
+
+// Slice 从 offset 开始取 length 个元素，offset 为负数时从末尾计算
+func (c *Collection[T]) Slice(offset, length int) *Collection[T] {
+	if offset < 0 {
+		offset = max(len(c.items)+offset, 0)
+	}
+	offset = min(offset, len(c.items))
+	end := min(offset+max(length, 0), len(c.items))
+	return Collect(slices.Clone(c.items[offset:end]))
+}
+
+// Push 在末尾追加元素
+func (c *Collection[T]) Push(items ...T) *Collection[T] {
+	return Collect(append(slices.Clone(c.items), items...))
+}
+
+// Merge 合并另一个集合
+func (c *Collection[T]) Merge(other *Collection[T]) *Collection[T] {
+	return c.Push(other.items...)
+}
+
+// MarshalJSON 编码为 JSON 数组
+func (c *Collection[T]) MarshalJSON() ([]byte, error) {
+	if c.items == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c.items)
+}
+
+// UnmarshalJSON 从 JSON 数组解码
+func (c *Collection[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &c.items)
+}
+
+// Map 转换每个元素
+func Map[T, U any](c *Collection[T], callback func(item T, index int) U) *Collection[U] {
+	items := make([]U, len(c.items))
+	for i, item := range c.items {
+		items[i] = callback(item, i)
+	}
+	return Collect(items)
+}
+
+// FlatMap 转换每个元素并展开一层
+func FlatMap[T, U any](c *Collection[T], callback func(item T, index int) []U) *Collection[U] {
+	var items []U
+	for i, item := range c.items {
+		items = append(items, callback(item, i)...)
+	}
+	return Collect(items)
+}
+
+// Chunk 按 size 分块，最后一块可能不足 size
+func Chunk[T any](c *Collection[T], size int) *Collection[[]T] {
+	if size <= 0 {
+		return Collect[[]T](nil)
+	}
+	chunks := make([][]T, 0, (len(c.items)+size-1)/size)
+	for chunk := range slices.Chunk(c.items, size) {
+		chunks = append(chunks, slices.Clone(chunk))
+	}
+	return Collect(chunks)
+}
+
+// Reduce 把元素归约为单个值
+func Reduce[T, A any](c *Collection[T], callback func(carry A, item T, index int) A, initial A) A {
+	carry := initial
+	for i, item := range c.items {
+		carry = callback(carry, item, i)
+	}
+	return carry
+}
+
+// GroupBy 按键分组，组内保持原顺序
+func GroupBy[T any, K comparable](c *Collection[T], key func(item T) K) map[K]*Collection[T] {
+	groups := make(map[K]*Collection[T])
+	for _, item := range c.items {
+		k := key(item)
+		group, ok := groups[k]
+		if !ok {
+			group = Collect[T](nil)
+			groups[k] = group
+		}
+		group.items = append(group.items, item)
+	}
+	return groups
+}
+
+// KeyBy 以键索引元素，键重复时保留最后一个
+func KeyBy[T any, K comparable](c *Collection[T], key func(item T) K) map[K]T {
+	keyed := make(map[K]T, len(c.items))
+	for _, item := range c.items {
+		keyed[key(item)] = item
+	}
+	return keyed
+}
+
+// Unique 去重，保留第一次出现的元素
+func Unique[T comparable](c *Collection[T]) *Collection[T] {
+	return UniqueBy(c, func(item T) T { return item })
+}
+
+// UniqueBy 按键去重，保留第一次出现的元素
+func UniqueBy[T any, K comparable](c *Collection[T], key func(item T) K) *Collection[T] {
+	seen := make(map[K]struct{}, len(c.items))
+	items := make([]T, 0, len(c.items))
+	for _, item := range c.items {
+		k := key(item)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		items = append(items, item)
+	}
+	return Collect(items)
+}
+
+// SortBy 按键升序稳定排序
+func SortBy[T any, K cmp.Ordered](c *Collection[T], key func(item T) K) *Collection[T] {
+	return c.Sort(func(a, b T) bool { return key(a) < key(b) })
+}
+
+// SortByDesc 按键降序稳定排序
+func SortByDesc[T any, K cmp.Ordered](c *Collection[T], key func(item T) K) *Collection[T] {
+	return c.Sort(func(a, b T) bool { return key(a) > key(b) })
+}
+
+// Sum 元素之和
+func Sum[T Number](c *Collection[T]) T {
+	return SumBy(c, func(item T) T { return item })
+}
+
+// SumBy 按取值函数求和
+func SumBy[T any, N Number](c *Collection[T], value func(item T) N) N {
+	var sum N
+	for _, item := range c.items {
+		sum += value(item)
+	}
+	return sum
+}
+
+// Avg 按取值函数求平均值，空集合返回 0
+func Avg[T any, N Number](c *Collection[T], value func(item T) N) float64 {
+	if len(c.items) == 0 {
+		return 0
+	}
+	return float64(SumBy(c, value)) / float64(len(c.items))
+}
+
+// Min 最小的元素，空集合返回 false
+func Min[T cmp.Ordered](c *Collection[T]) (T, bool) {
+	if len(c.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return slices.Min(c.items), true
+}
+
+// Max 最大的元素，空集合返回 false
+func Max[T cmp.Ordered](c *Collection[T]) (T, bool) {
+	if len(c.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return slices.Max(c.items), true
+}
@@ -0,0 +1,437 @@
+// Package datetime 提供 Carbon 风格的不可变日期时间类型
+//
+// DateTime 包装 time.Time，所有方法返回新值而不修改原值，可以安全地在 goroutine 间共享。
+// Now、Today 和解析函数使用默认时区（ConfigureTimezone 从 app.timezone 读取），时钟可以在测试中替换。
+// DateTime 可以直接作为模型字段：实现了 sql.Scanner、driver.Valuer 和 JSON 序列化，
+// 模型序列化的 date、datetime:格式 转换和 SerializesDates 也会按时间处理它。
+//
+// 主要特性：
+// - AddDays、AddMonths、SubHours 等算术，AddMonths 不会溢出到下个月
+// - StartOfDay、StartOfWeek、StartOfMonth、EndOfMonth 等边界
+// - IsWeekend、IsToday、IsPast、Between 等比较
+// - DiffInDays 等差值和 DiffForHumans 相对时间描述
+//
+// 包结构：
+// - datetime.go - DateTime 类型、构造函数、默认时区和时钟、算术、边界和比较
+// - diff.go - 差值和 DiffForHumans
+// - encoding.go - JSON、文本和数据库序列化
+//
+// 使用示例：
+//
+//	datetime.ConfigureTimezone(config) // app.timezone，例如 "Asia/Shanghai"
+//
+//	due := datetime.Now().AddDays(30).EndOfDay()
+//	due.IsWeekend()
+//	due.DiffForHumans()                      // "in 4 weeks"
+//	datetime.Now().StartOfMonth().ToDateString() // "2024-05-01"
+//
+//	type Invoice struct {
+//		database.Model
+//		DueAt datetime.DateTime `json:"due_at"`
+//	}
+package datetime
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// 常用格式
+const (
+	// DateFormat 日期
+	DateFormat = time.DateOnly
+
+	// DateTimeFormat 日期和时间，数据库的默认格式
+	DateTimeFormat = time.DateTime
+
+	// JSONFormat JSON 序列化的格式，与 database.DefaultDateFormat 相同
+	JSONFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// DateTime 不可变的日期时间
+type DateTime struct {
+	t time.Time
+}
+
+var (
+	// defaultLocation 默认时区
+	defaultLocation atomic.Pointer[time.Location]
+
+	// clock 当前时间来源
+	clock atomic.Pointer[func() time.Time]
+)
+
+// SetDefaultLocation 设置 Now、Today 和解析函数使用的默认时区，nil 恢复为 time.Local
+func SetDefaultLocation(location *time.Location) {
+	defaultLocation.Store(location)
+}
+
+// DefaultLocation 默认时区，未设置时为 time.Local
+func DefaultLocation() *time.Location {
+	if location := defaultLocation.Load(); location != nil {
+		return location
+	}
+	return time.Local
+}
+
+// ConfigRepository 读取配置的接口，application.Config 满足该接口
+type ConfigRepository interface {
+	Get(key string, defaultValue interface{}) interface{}
+}
+
+// ConfigureTimezone 按配置 app.timezone 设置默认时区，未配置时不修改
+func ConfigureTimezone(config ConfigRepository) error {
+	name, _ := config.Get("app.timezone", "").(string)
+	if name == "" {
+		return nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("datetime: app.timezone: %w", err)
+	}
+	SetDefaultLocation(location)
+	return nil
+}
+
+// SetClock 设置当前时间来源，nil 恢复为 time.Now，用于测试（可以传入 timetravel 的 Now）
+func SetClock(now func() time.Time) {
+	if now == nil {
+		clock.Store(nil)
+		return
+	}
+	clock.Store(&now)
+}
+
+// Now 默认时区的当前时间
+func Now() DateTime {
+	now := time.Now
+	if c := clock.Load(); c != nil {
+		now = *c
+	}
+	return DateTime{t: now().In(DefaultLocation())}
+}
+
+// Today 默认时区的今天零点
+func Today() DateTime {
+	return Now().StartOfDay()
+}
+
+// Tomorrow 默认时区的明天零点
+func Tomorrow() DateTime {
+	return Today().AddDays(1)
+}
+
+// Yesterday 默认时区的昨天零点
+func Yesterday() DateTime {
+	return Today().SubDays(1)
+}
+
+// FromTime 包装 time.Time，保留其时区
+func FromTime(t time.Time) DateTime {
+	return DateTime{t: t}
+}
+
+// Unix 默认时区中的 Unix 时间戳
+func Unix(seconds int64) DateTime {
+	return DateTime{t: time.Unix(seconds, 0).In(DefaultLocation())}
+}
+
+// Create 在默认时区创建时间
+func Create(year int, month time.Month, day, hour, minute, second int) DateTime {
+	return DateTime{t: time.Date(year, month, day, hour, minute, second, 0, DefaultLocation())}
+}
+
+// Parse 按格式解析，没有时区信息时使用默认时区
+func Parse(layout, value string) (DateTime, error) {
+	t, err := time.ParseInLocation(layout, value, DefaultLocation())
+	if err != nil {
+		return DateTime{}, err
+	}
+	return DateTime{t: t}, nil
+}
+
+// ParseAny 依次尝试 RFC 3339、"2006-01-02 15:04:05"、"2006-01-02T15:04:05" 和 "2006-01-02"
+func ParseAny(value string) (DateTime, error) {
+	for _, layout := range []string{time.RFC3339Nano, DateTimeFormat, "2006-01-02T15:04:05", DateFormat} {
+		if d, err := Parse(layout, value); err == nil {
+			return d, nil
+		}
+	}
+	return DateTime{}, fmt.Errorf("datetime: cannot parse %q", value)
+}
+
+// Time 对应的 time.Time
+func (d DateTime) Time() time.Time {
+	return d.t
+}
+
+// IsZero 是否为零值
+func (d DateTime) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// In 转换到时区
+func (d DateTime) In(location *time.Location) DateTime {
+	return DateTime{t: d.t.In(location)}
+}
+
+// Timezone 按时区名转换，例如 "Europe/Paris"
+func (d DateTime) Timezone(name string) (DateTime, error) {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return d, err
+	}
+	return d.In(location), nil
+}
+
+// UTC 转换到 UTC
+func (d DateTime) UTC() DateTime {
+	return DateTime{t: d.t.UTC()}
+}
+
+// Year 年
+func (d DateTime) Year() int {
+	return d.t.Year()
+}
+
+// Month 月
+func (d DateTime) Month() time.Month {
+	return d.t.Month()
+}
+
+// Day 日
+func (d DateTime) Day() int {
+	return d.t.Day()
+}
+
+// Hour 时
+func (d DateTime) Hour() int {
+	return d.t.Hour()
+}
+
+// Minute 分
+func (d DateTime) Minute() int {
+	return d.t.Minute()
+}
+
+// Second 秒
+func (d DateTime) Second() int {
+	return d.t.Second()
+}
+
+// Weekday 星期
+func (d DateTime) Weekday() time.Weekday {
+	return d.t.Weekday()
+}
+
+// DaysInMonth 当月的天数
+func (d DateTime) DaysInMonth() int {
+	return time.Date(d.t.Year(), d.t.Month()+1, 0, 0, 0, 0, 0, d.t.Location()).Day()
+}
+
+// Add 加上时长
+func (d DateTime) Add(duration time.Duration) DateTime {
+	return DateTime{t: d.t.Add(duration)}
+}
+
+// AddSeconds 加上秒数
+func (d DateTime) AddSeconds(n int) DateTime {
+	return d.Add(time.Duration(n) * time.Second)
+}
+
+// SubSeconds 减去秒数
+func (d DateTime) SubSeconds(n int) DateTime {
+	return d.AddSeconds(-n)
+}
+
+// AddMinutes 加上分钟数
+func (d DateTime) AddMinutes(n int) DateTime {
+	return d.Add(time.Duration(n) * time.Minute)
+}
+
+// SubMinutes 减去分钟数
+func (d DateTime) SubMinutes(n int) DateTime {
+	return d.AddMinutes(-n)
+}
+
+// AddHours 加上小时数
+func (d DateTime) AddHours(n int) DateTime {
+	return d.Add(time.Duration(n) * time.Hour)
+}
+
+// SubHours 减去小时数
+func (d DateTime) SubHours(n int) DateTime {
+	return d.AddHours(-n)
+}
+
+// AddDays 加上天数，按日历计算，跨越夏令时切换时保持钟面时间
+func (d DateTime) AddDays(n int) DateTime {
+	return DateTime{t: d.t.AddDate(0, 0, n)}
+}
+
+// SubDays 减去天数
+func (d DateTime) SubDays(n int) DateTime {
+	return d.AddDays(-n)
+}
+
+// AddWeeks 加上周数
+func (d DateTime) AddWeeks(n int) DateTime {
+	return d.AddDays(7 * n)
+}
+
+// SubWeeks 减去周数
+func (d DateTime) SubWeeks(n int) DateTime {
+	return d.AddWeeks(-n)
+}
+
+// AddMonths 加上月数，日超过目标月的天数时取目标月的最后一天（1 月 31 日加一个月为 2 月 28 或 29 日）
+func (d DateTime) AddMonths(n int) DateTime {
+	year, month, day := d.t.Date()
+	first := time.Date(year, month+time.Month(n), 1, d.t.Hour(), d.t.Minute(), d.t.Second(), d.t.Nanosecond(), d.t.Location())
+	target := DateTime{t: first}
+	return DateTime{t: first.AddDate(0, 0, min(day, target.DaysInMonth())-1)}
+}
+
+// SubMonths 减去月数，规则同 AddMonths
+func (d DateTime) SubMonths(n int) DateTime {
+	return d.AddMonths(-n)
+}
+
+// AddYears 加上年数，2 月 29 日在非闰年为 2 月 28 日
+func (d DateTime) AddYears(n int) DateTime {
+	return d.AddMonths(12 * n)
+}
+
+// SubYears 减去年数
+func (d DateTime) SubYears(n int) DateTime {
+	return d.AddYears(-n)
+}
+
+// StartOfDay 当天零点
+func (d DateTime) StartOfDay() DateTime {
+	year, month, day := d.t.Date()
+	return DateTime{t: time.Date(year, month, day, 0, 0, 0, 0, d.t.Location())}
+}
+
+// EndOfDay 当天最后一纳秒
+func (d DateTime) EndOfDay() DateTime {
+	return d.StartOfDay().AddDays(1).Add(-time.Nanosecond)
+}
+
+// StartOfWeek 本周一零点
+func (d DateTime) StartOfWeek() DateTime {
+	offset := (int(d.t.Weekday()) + 6) % 7
+	return d.StartOfDay().SubDays(offset)
+}
+
+// EndOfWeek 本周日最后一纳秒
+func (d DateTime) EndOfWeek() DateTime {
+	return d.StartOfWeek().AddDays(7).Add(-time.Nanosecond)
+}
+
+// StartOfMonth 本月第一天零点
+func (d DateTime) StartOfMonth() DateTime {
+	year, month, _ := d.t.Date()
+	return DateTime{t: time.Date(year, month, 1, 0, 0, 0, 0, d.t.Location())}
+}
+
+// EndOfMonth 本月最后一天最后一纳秒
+func (d DateTime) EndOfMonth() DateTime {
+	start := d.StartOfMonth()
+	return DateTime{t: start.t.AddDate(0, 1, 0).Add(-time.Nanosecond)}
+}
+
+// StartOfYear 今年第一天零点
+func (d DateTime) StartOfYear() DateTime {
+	return DateTime{t: time.Date(d.t.Year(), time.January, 1, 0, 0, 0, 0, d.t.Location())}
+}
+
+// EndOfYear 今年最后一天最后一纳秒
+func (d DateTime) EndOfYear() DateTime {
+	return DateTime{t: time.Date(d.t.Year()+1, time.January, 1, 0, 0, 0, 0, d.t.Location()).Add(-time.Nanosecond)}
+}
+
+// Before 是否早于 other
+func (d DateTime) Before(other DateTime) bool {
+	return d.t.Before(other.t)
+}
+
+// After 是否晚于 other
+func (d DateTime) After(other DateTime) bool {
+	return d.t.After(other.t)
+}
+
+// Equal 是否为同一时刻，不比较时区
+func (d DateTime) Equal(other DateTime) bool {
+	return d.t.Equal(other.t)
+}
+
+// Between 是否在 start 和 end 之间（包含两端）
+func (d DateTime) Between(start, end DateTime) bool {
+	return !d.t.Before(start.t) && !d.t.After(end.t)
+}
+
+// IsWeekend 是否为周六或周日
+func (d DateTime) IsWeekend() bool {
+	weekday := d.t.Weekday()
+	return weekday == time.Saturday || weekday == time.Sunday
+}
+
+// IsWeekday 是否为周一到周五
+func (d DateTime) IsWeekday() bool {
+	return !d.IsWeekend()
+}
+
+// IsSameDay 在 d 的时区中是否与 other 为同一天
+func (d DateTime) IsSameDay(other DateTime) bool {
+	y1, m1, d1 := d.t.Date()
+	y2, m2, d2 := other.t.In(d.t.Location()).Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// IsToday 是否为今天
+func (d DateTime) IsToday() bool {
+	return d.IsSameDay(Now())
+}
+
+// IsPast 是否早于当前时间
+func (d DateTime) IsPast() bool {
+	return d.t.Before(Now().t)
+}
+
+// IsFuture 是否晚于当前时间
+func (d DateTime) IsFuture() bool {
+	return d.t.After(Now().t)
+}
+
+// IsLeapYear 是否为闰年
+func (d DateTime) IsLeapYear() bool {
+	year := d.t.Year()
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// Format 按 Go 的格式输出
+func (d DateTime) Format(layout string) string {
+	return d.t.Format(layout)
+}
+
+// ToDateString "2006-01-02"
+func (d DateTime) ToDateString() string {
+	return d.t.Format(DateFormat)
+}
+
+// ToDateTimeString "2006-01-02 15:04:05"
+func (d DateTime) ToDateTimeString() string {
+	return d.t.Format(DateTimeFormat)
+}
+
+// ToISO8601String RFC 3339 格式
+func (d DateTime) ToISO8601String() string {
+	return d.t.Format(time.RFC3339)
+}
+
+// String "2006-01-02 15:04:05"，对应 Carbon 的 __toString
+func (d DateTime) String() string {
+	return d.ToDateTimeString()
+}
@@ -0,0 +1,117 @@
+package datetime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// DiffInSeconds 与 other 相差的秒数，d 早于 other 时为正
+func (d DateTime) DiffInSeconds(other DateTime) int64 {
+	return int64(other.t.Sub(d.t) / time.Second)
+}
+
+// DiffInMinutes 与 other 相差的分钟数，d 早于 other 时为正
+func (d DateTime) DiffInMinutes(other DateTime) int64 {
+	return int64(other.t.Sub(d.t) / time.Minute)
+}
+
+// DiffInHours 与 other 相差的小时数，d 早于 other 时为正
+func (d DateTime) DiffInHours(other DateTime) int64 {
+	return int64(other.t.Sub(d.t) / time.Hour)
+}
+
+// DiffInDays 与 other 相差的完整天数，按日历计算，不受夏令时影响，d 早于 other 时为正
+func (d DateTime) DiffInDays(other DateTime) int64 {
+	from, to := d, other.In(d.t.Location())
+	sign := int64(1)
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+	days := int64(to.StartOfDay().t.Sub(from.StartOfDay().t).Round(time.Hour) / (24 * time.Hour))
+	if days > 0 && clockOf(to.t) < clockOf(from.t) {
+		days--
+	}
+	return sign * days
+}
+
+// DiffInMonths 与 other 相差的完整月数，d 早于 other 时为正
+func (d DateTime) DiffInMonths(other DateTime) int64 {
+	from, to := d, other.In(d.t.Location())
+	sign := int64(1)
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+	months := int64((to.Year()-from.Year())*12 + int(to.Month()-from.Month()))
+	if months > 0 && from.AddMonths(int(months)).After(to) {
+		months--
+	}
+	return sign * months
+}
+
+// DiffInYears 与 other 相差的完整年数，d 早于 other 时为正
+func (d DateTime) DiffInYears(other DateTime) int64 {
+	return d.DiffInMonths(other) / 12
+}
+
+// humanUnits DiffForHumans 使用的单位，从大到小
+var humanUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// DiffForHumans 相对当前时间的描述，对应 Carbon 的 diffForHumans
+//
+// 过去的时间为 "3 days ago"，将来的时间为 "in 2 hours"，不足一秒为 "just now"。
+// 传入 other 时相对 other 描述，为 "3 days before" 或 "2 hours after"。
+func (d DateTime) DiffForHumans(other ...DateTime) string {
+	relativeToNow := len(other) == 0
+	base := Now()
+	if !relativeToNow {
+		base = other[0]
+	}
+	diff := d.t.Sub(base.t)
+	future := diff > 0
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff < time.Second {
+		if relativeToNow {
+			return "just now"
+		}
+		return "0 seconds after"
+	}
+
+	var phrase string
+	for _, unit := range humanUnits {
+		if diff >= unit.duration {
+			count := int(diff / unit.duration)
+			phrase = fmt.Sprintf("%d %s", count, str.PluralCount(unit.name, count))
+			break
+		}
+	}
+	switch {
+	case relativeToNow && future:
+		return "in " + phrase
+	case relativeToNow:
+		return phrase + " ago"
+	case future:
+		return phrase + " after"
+	}
+	return phrase + " before"
+}
+
+// clockOf 一天中的时刻
+func clockOf(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
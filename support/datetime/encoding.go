@@ -0,0 +1,93 @@
+package datetime
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+var (
+	_ json.Marshaler   = DateTime{}
+	_ json.Unmarshaler = (*DateTime)(nil)
+	_ sql.Scanner      = (*DateTime)(nil)
+	_ driver.Valuer    = DateTime{}
+)
+
+// MarshalJSON 按 JSONFormat 序列化，零值为 null
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.t.Format(JSONFormat))
+}
+
+// UnmarshalJSON 解析 null 或 ParseAny 支持的字符串
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = DateTime{}
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("datetime: %w", err)
+	}
+	return d.setString(value)
+}
+
+// MarshalText 实现 encoding.TextMarshaler，格式为 RFC 3339
+func (d DateTime) MarshalText() ([]byte, error) {
+	if d.t.IsZero() {
+		return nil, nil
+	}
+	return []byte(d.t.Format(time.RFC3339Nano)), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (d *DateTime) UnmarshalText(data []byte) error {
+	return d.setString(string(data))
+}
+
+// Scan 实现 sql.Scanner，支持 time.Time、字符串和 []byte，NULL 为零值
+//
+// 没有时区信息的字符串按默认时区解析，驱动返回的 time.Time 保留其时区。
+func (d *DateTime) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = DateTime{}
+	case time.Time:
+		*d = DateTime{t: v}
+	case string:
+		return d.setString(v)
+	case []byte:
+		return d.setString(string(v))
+	case int64:
+		*d = Unix(v)
+	default:
+		return fmt.Errorf("datetime: cannot scan %T", src)
+	}
+	return nil
+}
+
+// Value 实现 driver.Valuer，零值为 NULL
+func (d DateTime) Value() (driver.Value, error) {
+	if d.t.IsZero() {
+		return nil, nil
+	}
+	return d.t, nil
+}
+
+// setString 解析字符串，空字符串为零值
+func (d *DateTime) setString(value string) error {
+	if value == "" {
+		*d = DateTime{}
+		return nil
+	}
+	parsed, err := ParseAny(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,310 @@
+// Package decimal 提供精确的十进制数类型，用于金额等不能经过 float64 的数值
+//
+// Decimal 由任意精度的整数和小数位数组成，加减乘不会丢失精度，除法按指定位数四舍五入。
+// Decimal 是不可变的值类型，所有运算返回新值。它实现了 sql.Scanner、driver.Valuer 和 JSON 序列化，
+// 可以直接作为模型字段；JSON 中序列化为字符串，避免 JavaScript 按浮点数解析。
+// 模型序列化的 decimal:2 转换输出保留两位小数的字符串，database.MoneyColumn 提供对应的列定义。
+//
+// 主要特性：
+// - Add、Sub、Mul 精确运算，Div 按位数四舍五入
+// - Round、Truncate、StringFixed 按小数位数取整和格式化
+// - Allocate 按比例分配金额，分配结果之和等于原值
+// - NullDecimal 表示可以为 NULL 的列
+//
+// 包结构：
+// - decimal.go - Decimal 类型、解析和运算
+// - encoding.go - JSON、文本和数据库序列化，NullDecimal
+//
+// 使用示例：
+//
+//	price := decimal.MustParse("19.99")
+//	total := price.Mul(decimal.FromInt(3)).Add(decimal.MustParse("4.50")) // 64.47
+//	tax, _ := total.Div(decimal.FromInt(11), 2)                         // 5.86
+//	total.StringFixed(2)                                                // "64.47"
+//	shares := decimal.MustParse("100.00").Allocate(2, 1)                // 66.67、33.33
+//
+//	type Product struct {
+//		database.Model
+//		Price decimal.Decimal `json:"price"`
+//	}
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrDivisionByZero 除数为零
+var ErrDivisionByZero = errors.New("decimal: division by zero")
+
+// Decimal 精确的十进制数，值为 value × 10^-scale，零值为 0
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+// Zero 0
+var Zero = Decimal{}
+
+// New 由整数和小数位数创建，New(1999, 2) 为 19.99
+func New(unscaled int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{value: new(big.Int).Mul(big.NewInt(unscaled), pow10(-scale))}
+	}
+	return Decimal{value: big.NewInt(unscaled), scale: scale}
+}
+
+// FromInt 由整数创建
+func FromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// Parse 解析十进制字符串，支持符号、小数点和科学计数法（"-12.50"、"1e3"），保留原有的小数位数
+func Parse(value string) (Decimal, error) {
+	s := strings.TrimSpace(value)
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		var err error
+		if exponent, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil {
+			return Decimal{}, fmt.Errorf("decimal: cannot parse %q", value)
+		}
+	}
+	integer, fraction, _ := strings.Cut(mantissa, ".")
+	digits := strings.TrimLeft(integer, "+-")
+	if digits+fraction == "" || strings.ContainsAny(digits+fraction, "+-") || len(integer)-len(digits) > 1 {
+		return Decimal{}, fmt.Errorf("decimal: cannot parse %q", value)
+	}
+	unscaled, ok := new(big.Int).SetString(integer+fraction, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("decimal: cannot parse %q", value)
+	}
+	scale := int64(len(fraction)) - exponent
+	if scale < 0 {
+		return Decimal{value: unscaled.Mul(unscaled, pow10(int32(-scale)))}, nil
+	}
+	return Decimal{value: unscaled, scale: int32(scale)}, nil
+}
+
+// MustParse 解析十进制字符串，失败时 panic，用于常量
+func MustParse(value string) Decimal {
+	d, err := Parse(value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Scale 小数位数
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign 符号：-1、0 或 1
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+// IsZero 是否为 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// IsNegative 是否小于 0
+func (d Decimal) IsNegative() bool {
+	return d.Sign() < 0
+}
+
+// Add 加法，结果的小数位数为两者中较大的
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{value: a.Add(a, b), scale: scale}
+}
+
+// Sub 减法，结果的小数位数为两者中较大的
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{value: a.Sub(a, b), scale: scale}
+}
+
+// Mul 乘法，结果的小数位数为两者之和
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.unscaled(), other.unscaled()), scale: d.scale + other.scale}
+}
+
+// Div 除法，结果保留 places 位小数并四舍五入（远离零），除数为零时返回 ErrDivisionByZero
+func (d Decimal) Div(other Decimal, places int32) (Decimal, error) {
+	if other.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	// d / other = (d.value × 10^(other.scale + places + 1 - d.scale)) / other.value × 10^-(places + 1)
+	shift := other.scale + places + 1 - d.scale
+	numerator := new(big.Int).Set(d.unscaled())
+	denominator := new(big.Int).Set(other.unscaled())
+	if shift >= 0 {
+		numerator.Mul(numerator, pow10(shift))
+	} else {
+		denominator.Mul(denominator, pow10(-shift))
+	}
+	quotient := new(big.Int).Quo(numerator, denominator)
+	return Decimal{value: quotient, scale: places + 1}.Round(places), nil
+}
+
+// Neg 相反数
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.unscaled()), scale: d.scale}
+}
+
+// Abs 绝对值
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.unscaled()), scale: d.scale}
+}
+
+// Round 四舍五入（远离零）到 places 位小数，places 大于当前位数时补零
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d.rescale(places)
+	}
+	divisor := pow10(d.scale - places)
+	quotient, remainder := new(big.Int).QuoRem(d.unscaled(), divisor, new(big.Int))
+	// |remainder| × 2 >= divisor 时进位
+	if remainder.Abs(remainder).Lsh(remainder, 1).Cmp(divisor) >= 0 {
+		if d.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Decimal{value: quotient, scale: places}
+}
+
+// Truncate 截断到 places 位小数（向零取整）
+func (d Decimal) Truncate(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	return Decimal{value: new(big.Int).Quo(d.unscaled(), pow10(d.scale-places)), scale: places}
+}
+
+// Allocate 按比例分配，结果保留原有的小数位数，余下的最小单位依次分给前面的份额，结果之和等于原值
+//
+//	decimal.MustParse("100.00").Allocate(1, 1, 1) // 33.34、33.33、33.33
+func (d Decimal) Allocate(ratios ...int64) []Decimal {
+	total := int64(0)
+	for _, ratio := range ratios {
+		total += ratio
+	}
+	shares := make([]Decimal, len(ratios))
+	if total == 0 {
+		for i := range shares {
+			shares[i] = Decimal{scale: d.scale}
+		}
+		return shares
+	}
+	remainder := new(big.Int).Set(d.unscaled())
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(d.unscaled(), big.NewInt(ratio))
+		share.Quo(share, big.NewInt(total))
+		shares[i] = Decimal{value: share, scale: d.scale}
+		remainder.Sub(remainder, share)
+	}
+	unit := big.NewInt(int64(remainder.Sign()))
+	for i := 0; remainder.Sign() != 0; i = (i + 1) % len(shares) {
+		shares[i] = Decimal{value: new(big.Int).Add(shares[i].value, unit), scale: d.scale}
+		remainder.Sub(remainder, unit)
+	}
+	return shares
+}
+
+// Cmp 比较：d < other 为 -1，相等为 0，d > other 为 1，不考虑小数位数（1.50 等于 1.5）
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+// Equal 数值是否相等
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// LessThan 是否小于 other
+func (d Decimal) LessThan(other Decimal) bool {
+	return d.Cmp(other) < 0
+}
+
+// GreaterThan 是否大于 other
+func (d Decimal) GreaterThan(other Decimal) bool {
+	return d.Cmp(other) > 0
+}
+
+// String 保留原有小数位数的十进制字符串，例如 "19.90"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.unscaled()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits
+	}
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// StringFixed 四舍五入到 places 位小数后的字符串，StringFixed(2) 为 "19.90"
+func (d Decimal) StringFixed(places int32) string {
+	return d.Round(places).String()
+}
+
+// Int64 整数部分（向零取整），超出 int64 范围时结果未定义
+func (d Decimal) Int64() int64 {
+	return d.Truncate(0).unscaled().Int64()
+}
+
+// Float64 近似的浮点数，只用于展示和统计，不要用于金额运算
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.unscaled(), pow10(d.scale)).Float64()
+	return f
+}
+
+// Sum 求和
+func Sum(values ...Decimal) Decimal {
+	total := Zero
+	for _, value := range values {
+		total = total.Add(value)
+	}
+	return total
+}
+
+// unscaled 整数部分，零值 Decimal 为 0；返回的值不能修改
+func (d Decimal) unscaled() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// rescale 把小数位数增加到 scale
+func (d Decimal) rescale(scale int32) Decimal {
+	if scale <= d.scale {
+		return d
+	}
+	return Decimal{value: new(big.Int).Mul(d.unscaled(), pow10(scale-d.scale)), scale: scale}
+}
+
+// align 把两个数对齐到相同的小数位数，返回可以修改的整数副本
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := max(a.scale, b.scale)
+	return new(big.Int).Set(a.rescale(scale).unscaled()), new(big.Int).Set(b.rescale(scale).unscaled()), scale
+}
+
+// pow10 10 的 n 次方
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,155 @@
+package decimal
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+var (
+	_ json.Marshaler   = Decimal{}
+	_ json.Unmarshaler = (*Decimal)(nil)
+	_ sql.Scanner      = (*Decimal)(nil)
+	_ driver.Valuer    = Decimal{}
+)
+
+// MarshalJSON 序列化为字符串，例如 "19.90"
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON 解析字符串或数字，数字按原文解析，不经过 float64
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (d *Decimal) UnmarshalText(data []byte) error {
+	parsed, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan 实现 sql.Scanner，支持字符串、[]byte、整数和浮点数
+//
+// DECIMAL 和 NUMERIC 列在 MySQL、PostgreSQL 驱动中以文本返回，不会丢失精度；
+// SQLite 按 REAL 存储的值以 float64 返回，按最短的十进制表示转换。NULL 返回错误，可以为 NULL 的列使用 NullDecimal。
+func (d *Decimal) Scan(src interface{}) error {
+	parsed, err := FromValue(src)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value 实现 driver.Valuer，以字符串写入，由数据库转换为 DECIMAL
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// FromValue 把数据库或属性值转换为 Decimal，支持 Decimal、字符串、[]byte、整数和浮点数
+func FromValue(value interface{}) (Decimal, error) {
+	switch v := value.(type) {
+	case Decimal:
+		return v, nil
+	case *Decimal:
+		if v != nil {
+			return *v, nil
+		}
+	case string:
+		return Parse(v)
+	case []byte:
+		return Parse(string(v))
+	case int:
+		return FromInt(int64(v)), nil
+	case int32:
+		return FromInt(int64(v)), nil
+	case int64:
+		return FromInt(v), nil
+	case float32:
+		return Parse(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		return Parse(strconv.FormatFloat(v, 'f', -1, 64))
+	case json.Number:
+		return Parse(v.String())
+	}
+	return Decimal{}, fmt.Errorf("decimal: cannot convert %T", value)
+}
+
+// NullDecimal 可以为 NULL 的 Decimal，对应 sql.NullString 等类型
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+var (
+	_ json.Marshaler   = NullDecimal{}
+	_ json.Unmarshaler = (*NullDecimal)(nil)
+	_ sql.Scanner      = (*NullDecimal)(nil)
+	_ driver.Valuer    = NullDecimal{}
+)
+
+// Scan 实现 sql.Scanner，NULL 时 Valid 为 false
+func (n *NullDecimal) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullDecimal{}
+		return nil
+	}
+	if err := n.Decimal.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value 实现 driver.Valuer，Valid 为 false 时为 NULL
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Decimal.Value()
+}
+
+// MarshalJSON Valid 为 false 时为 null
+func (n NullDecimal) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Decimal.MarshalJSON()
+}
+
+// UnmarshalJSON null 时 Valid 为 false
+func (n *NullDecimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullDecimal{}
+		return nil
+	}
+	if err := n.Decimal.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
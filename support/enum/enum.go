@@ -0,0 +1,212 @@
+// Package enum 提供枚举类型辅助，对应 Laravel 的 backed enum（from、tryFrom、cases）
+//
+// Go 没有枚举，惯用写法是具名的字符串或整数类型加一组常量。类型在值接收者上实现 Cases
+// 返回全部枚举值后即满足 Enumerable，可以用于属性赋值（database.Fill 拒绝非枚举值）、
+// 迁移的枚举列（database.EnumColumn）、验证规则（Rule 生成 in: 规则）和路由参数绑定（routing.EnumConverter）。
+//
+// 主要特性：
+// - From、TryFrom 按底层值查找枚举值，字符串可以匹配整数枚举
+// - Values、Rule、Validate 派生验证规则和校验
+// - CasesOf、Convert 基于反射，供不知道具体类型的调用方使用
+//
+// 包结构：
+// - enum.go - Enumerable 约束、泛型辅助函数和反射辅助函数
+//
+// 使用示例：
+//
+//	type Status string
+//
+//	const (
+//		Active   Status = "active"
+//		Archived Status = "archived"
+//	)
+//
+//	func (Status) Cases() []Status { return []Status{Active, Archived} }
+//
+//	status, err := enum.From[Status]("active") // Active
+//	enum.Rule[Status]()                         // "in:active,archived"
+//	enum.Valid(Status("deleted"))               // false
+package enum
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidValue 值不是枚举值
+var ErrInvalidValue = errors.New("enum: invalid value")
+
+// ValueError 值不是枚举的任何一个枚举值
+type ValueError struct {
+	// Type 枚举类型名
+	Type string
+
+	// Value 原始值
+	Value interface{}
+}
+
+// Error 实现 error 接口
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("enum: %#v is not a valid backing value for enum %s", e.Value, e.Type)
+}
+
+// Unwrap 支持 errors.Is(err, ErrInvalidValue)
+func (e *ValueError) Unwrap() error {
+	return ErrInvalidValue
+}
+
+// Backing 枚举的底层类型
+type Backing interface {
+	~string | ~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Enumerable 枚举类型约束：底层为字符串或整数，在值接收者上实现 Cases，零值也可以调用
+type Enumerable[T any] interface {
+	Backing
+
+	// Cases 全部枚举值，按声明顺序
+	Cases() []T
+}
+
+// Cases 枚举的全部枚举值
+func Cases[T Enumerable[T]]() []T {
+	var zero T
+	return zero.Cases()
+}
+
+// Valid 是否为枚举值
+func Valid[T Enumerable[T]](value T) bool {
+	for _, c := range Cases[T]() {
+		if c == value {
+			return true
+		}
+	}
+	return false
+}
+
+// From 按底层值获取枚举值，value 可以是枚举类型、字符串或数字，不是枚举值时返回 *ValueError
+func From[T Enumerable[T]](value interface{}) (T, error) {
+	var zero T
+	v, err := Convert(reflect.TypeOf(zero), value)
+	if err != nil {
+		return zero, err
+	}
+	return v.Interface().(T), nil
+}
+
+// TryFrom 按底层值获取枚举值，不是枚举值时返回 false
+func TryFrom[T Enumerable[T]](value interface{}) (T, bool) {
+	c, err := From[T](value)
+	return c, err == nil
+}
+
+// Values 全部枚举值的底层值文本
+func Values[T Enumerable[T]]() []string {
+	cases := Cases[T]()
+	values := make([]string, len(cases))
+	for i, c := range cases {
+		values[i] = backingText(reflect.ValueOf(c))
+	}
+	return values
+}
+
+// Rule 验证规则 "in:a,b,c"，对应 Laravel 的 Rule::enum
+func Rule[T Enumerable[T]]() string {
+	return "in:" + strings.Join(Values[T](), ",")
+}
+
+// Validate 校验输入是否为枚举值，用于请求验证，不通过时返回 *ValueError
+func Validate[T Enumerable[T]](value interface{}) error {
+	_, err := From[T](value)
+	return err
+}
+
+// CasesOf 通过反射获取类型的全部枚举值，类型不满足 Enumerable 时返回 false
+func CasesOf(t reflect.Type) ([]reflect.Value, bool) {
+	if t == nil || !isBacking(t.Kind()) {
+		return nil, false
+	}
+	method, ok := t.MethodByName("Cases")
+	if !ok || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0) != reflect.SliceOf(t) {
+		return nil, false
+	}
+	list := method.Func.Call([]reflect.Value{reflect.Zero(t)})[0]
+	cases := make([]reflect.Value, list.Len())
+	for i := range cases {
+		cases[i] = list.Index(i)
+	}
+	return cases, true
+}
+
+// Convert 通过反射把值转换为枚举类型 t 的枚举值
+//
+// 同类型的值按值比较；字符串和 []byte 与枚举值的底层值文本比较；整数枚举还接受整数和没有小数部分的浮点数。
+func Convert(t reflect.Type, value interface{}) (reflect.Value, error) {
+	cases, ok := CasesOf(t)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("enum: %v does not implement Cases() []%v", t, t)
+	}
+	v := reflect.ValueOf(value)
+	for _, c := range cases {
+		if matches(c, v) {
+			return c, nil
+		}
+	}
+	return reflect.Value{}, &ValueError{Type: t.String(), Value: value}
+}
+
+// matches 枚举值是否与输入相等
+func matches(c, v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	if v.Type() == c.Type() {
+		return v.Equal(c)
+	}
+	if _, ok := CasesOf(v.Type()); ok {
+		// 其他枚举类型的值不匹配
+		return false
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String() == backingText(c)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()) == backingText(c)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return c.Kind() != reflect.String && strconv.FormatInt(v.Int(), 10) == backingText(c)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return c.Kind() != reflect.String && strconv.FormatUint(v.Uint(), 10) == backingText(c)
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		return c.Kind() != reflect.String && f == float64(int64(f)) && strconv.FormatInt(int64(f), 10) == backingText(c)
+	}
+	return false
+}
+
+// backingText 枚举值的底层值文本，不使用类型的 String 方法
+func backingText(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+	return strconv.FormatInt(v.Int(), 10)
+}
+
+// isBacking 是否为枚举的底层类型
+func isBacking(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package support
+
+import (
+	"iter"
+	"slices"
+)
+
+// LazyCollection 惰性集合，对应 Laravel 的 Illuminate\Support\LazyCollection
+//
+// 元素在遍历时才由迭代器产生，Filter、Take 等方法只组合迭代器，
+// 直到 Collect、Each、First、Count 等终结操作才开始求值。源迭代器只能遍历一次时，
+// 惰性集合也只能求值一次。
+type LazyCollection[T any] struct {
+	seq iter.Seq[T]
+}
+
+// Lazy 以迭代器创建惰性集合
+func Lazy[T any](seq iter.Seq[T]) *LazyCollection[T] {
+	return &LazyCollection[T]{seq: seq}
+}
+
+// LazyRange 产生 [start, end] 范围内整数的惰性集合，end 小于 start 时递减
+func LazyRange(start, end int) *LazyCollection[int] {
+	return Lazy(func(yield func(int) bool) {
+		step := 1
+		if end < start {
+			step = -1
+		}
+		for i := start; ; i += step {
+			if !yield(i) || i == end {
+				return
+			}
+		}
+	})
+}
+
+// Seq 元素迭代器
+func (l *LazyCollection[T]) Seq() iter.Seq[T] {
+	return l.seq
+}
+
+// Collect 求值并转换为集合
+func (l *LazyCollection[T]) Collect() *Collection[T] {
+	return Collect(slices.Collect(l.seq))
+}
+
+// All 求值并返回所有元素
+func (l *LazyCollection[T]) All() []T {
+	return slices.Collect(l.seq)
+}
+
+// Each 遍历元素，回调返回 false 时停止
+func (l *LazyCollection[T]) Each(callback func(item T) bool) {
+	for item := range l.seq {
+		if !callback(item) {
+			return
+		}
+	}
+}
+
+// First 第一个满足条件的元素，不传条件时返回第一个元素
+func (l *LazyCollection[T]) First(predicate ...func(item T) bool) (T, bool) {
+	for item := range l.seq {
+		if len(predicate) == 0 || predicate[0](item) {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Count 求值并返回元素数量
+func (l *LazyCollection[T]) Count() int {
+	count := 0
+	for range l.seq {
+		count++
+	}
+	return count
+}
+
+// Filter 保留满足条件的元素
+func (l *LazyCollection[T]) Filter(predicate func(item T) bool) *LazyCollection[T] {
+	return Lazy(func(yield func(T) bool) {
+		for item := range l.seq {
+			if predicate(item) && !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+// Reject 去掉满足条件的元素
+func (l *LazyCollection[T]) Reject(predicate func(item T) bool) *LazyCollection[T] {
+	return l.Filter(func(item T) bool { return !predicate(item) })
+}
+
+// Take 取前 limit 个元素，取够后不再从源迭代器读取
+func (l *LazyCollection[T]) Take(limit int) *LazyCollection[T] {
+	return Lazy(func(yield func(T) bool) {
+		if limit <= 0 {
+			return
+		}
+		taken := 0
+		for item := range l.seq {
+			if !yield(item) {
+				return
+			}
+			if taken++; taken >= limit {
+				return
+			}
+		}
+	})
+}
+
+// TakeWhile 取元素直到条件不满足
+func (l *LazyCollection[T]) TakeWhile(predicate func(item T) bool) *LazyCollection[T] {
+	return Lazy(func(yield func(T) bool) {
+		for item := range l.seq {
+			if !predicate(item) || !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+// Skip 跳过前 count 个元素
+func (l *LazyCollection[T]) Skip(count int) *LazyCollection[T] {
+	return Lazy(func(yield func(T) bool) {
+		skipped := 0
+		for item := range l.seq {
+			if skipped < count {
+				skipped++
+				continue
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+// LazyMap 惰性转换每个元素
+func LazyMap[T, U any](l *LazyCollection[T], callback func(item T) U) *LazyCollection[U] {
+	return Lazy(func(yield func(U) bool) {
+		for item := range l.seq {
+			if !yield(callback(item)) {
+				return
+			}
+		}
+	})
+}
+
+// LazyChunk 按 size 分块，每块在凑满后产生
+func LazyChunk[T any](l *LazyCollection[T], size int) *LazyCollection[[]T] {
+	return Lazy(func(yield func([]T) bool) {
+		if size <= 0 {
+			return
+		}
+		chunk := make([]T, 0, size)
+		for item := range l.seq {
+			chunk = append(chunk, item)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	})
+}
+
+// LazyUnique 惰性去重，已出现的元素保存在内存中
+func LazyUnique[T comparable](l *LazyCollection[T]) *LazyCollection[T] {
+	return Lazy(func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for item := range l.seq {
+			if _, ok := seen[item]; ok {
+				continue
+			}
+			seen[item] = struct{}{}
+			if !yield(item) {
+				return
+			}
+		}
+	})
+}
+
+// LazyReduce 求值并把元素归约为单个值
+func LazyReduce[T, A any](l *LazyCollection[T], callback func(carry A, item T) A, initial A) A {
+	carry := initial
+	for item := range l.seq {
+		carry = callback(carry, item)
+	}
+	return carry
+}
@@ -0,0 +1,104 @@
+package str
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// crockford ULID 使用的 Crockford Base32 字母表
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// randomAlphabet Random 使用的字母表
+const randomAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	ulidPattern = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+)
+
+// now 生成有序标识使用的时钟
+var now = time.Now
+
+// UUID 生成随机的 UUID v4
+func UUID() string {
+	var b [16]byte
+	readRandom(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return formatUUID(b)
+}
+
+// OrderedUUID 生成按时间排序的 UUID v7，适合作为数据库索引键
+func OrderedUUID() string {
+	var b [16]byte
+	readRandom(b[6:])
+	ms := uint64(now().UnixMilli())
+	b[0], b[1], b[2], b[3], b[4], b[5] = byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
+	b[6] = b[6]&0x0f | 0x70
+	b[8] = b[8]&0x3f | 0x80
+	return formatUUID(b)
+}
+
+// IsUUID 是否为 UUID 格式
+func IsUUID(value string) bool {
+	return uuidPattern.MatchString(value)
+}
+
+// ULID 生成 ULID：48 位毫秒时间戳加 80 位随机数，以 26 个 Crockford Base32 字符表示
+func ULID() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(now().UnixMilli())<<16)
+	readRandom(b[6:])
+
+	// 128 位数据前补 2 个 0 位凑成 130 位，每 5 位一个字符
+	var out [26]byte
+	for i := range out {
+		var v byte
+		for bit := i*5 - 2; bit < i*5+3; bit++ {
+			v <<= 1
+			if bit >= 0 {
+				v |= b[bit/8] >> (7 - bit%8) & 1
+			}
+		}
+		out[i] = crockford[v]
+	}
+	return string(out[:])
+}
+
+// IsULID 是否为 ULID 格式
+func IsULID(value string) bool {
+	return ulidPattern.MatchString(value)
+}
+
+// Random 生成由字母和数字组成的随机字符串，使用加密安全的随机数
+func Random(length int) string {
+	var b strings.Builder
+	b.Grow(length)
+	limit := big.NewInt(int64(len(randomAlphabet)))
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			panic(err)
+		}
+		b.WriteByte(randomAlphabet[n.Int64()])
+	}
+	return b.String()
+}
+
+// readRandom 填充随机字节，系统随机源不可用时 panic
+func readRandom(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+}
+
+// formatUUID 格式化为 8-4-4-4-12 形式
+func formatUUID(b [16]byte) string {
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
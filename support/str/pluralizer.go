@@ -0,0 +1,170 @@
+package str
+
+import (
+	"strings"
+	"unicode"
+)
+
+// irregularPlurals 不规则复数，也包括按通用规则会出错的单词
+var irregularPlurals = map[string]string{
+	// 元音变化
+	"child": "children", "foot": "feet", "goose": "geese", "louse": "lice", "man": "men",
+	"mouse": "mice", "ox": "oxen", "person": "people", "tooth": "teeth", "woman": "women",
+	// f、fe 结尾
+	"calf": "calves", "elf": "elves", "half": "halves", "knife": "knives", "leaf": "leaves",
+	"life": "lives", "loaf": "loaves", "self": "selves", "shelf": "shelves", "thief": "thieves",
+	"wife": "wives", "wolf": "wolves",
+	// o 结尾加 es
+	"echo": "echoes", "hero": "heroes", "potato": "potatoes", "tomato": "tomatoes", "veto": "vetoes",
+	// 拉丁语和希腊语
+	"alumnus": "alumni", "analysis": "analyses", "appendix": "appendices", "axis": "axes",
+	"bacterium": "bacteria", "cactus": "cacti", "crisis": "crises", "criterion": "criteria",
+	"curriculum": "curricula", "diagnosis": "diagnoses", "ellipsis": "ellipses", "focus": "foci",
+	"fungus": "fungi", "hypothesis": "hypotheses", "index": "indices", "matrix": "matrices",
+	"medium": "media", "nucleus": "nuclei", "oasis": "oases", "parenthesis": "parentheses",
+	"phenomenon": "phenomena", "radius": "radii", "stimulus": "stimuli", "syllabus": "syllabi",
+	"synopsis": "synopses", "thesis": "theses", "vertex": "vertices",
+	// 单数以 s 结尾
+	"alias": "aliases", "atlas": "atlases", "bias": "biases", "bonus": "bonuses", "bus": "buses",
+	"apparatus": "apparatuses", "campus": "campuses", "canvas": "canvases", "census": "censuses",
+	"chorus": "choruses", "circus": "circuses", "gas": "gases", "lens": "lenses", "octopus": "octopuses",
+	"prospectus": "prospectuses", "status": "statuses", "virus": "viruses", "walrus": "walruses",
+	// 复数去掉 es 或 ies 规则会出错
+	"ache": "aches", "cache": "caches", "cookie": "cookies", "movie": "movies", "niche": "niches",
+	"quiz": "quizzes", "rookie": "rookies", "zombie": "zombies",
+	// 以 man、men 结尾的普通单词
+	"abdomen": "abdomens", "german": "germans", "human": "humans", "omen": "omens", "roman": "romans",
+	"shaman": "shamans", "specimen": "specimens", "talisman": "talismans",
+}
+
+// irregularSingulars 由 irregularPlurals 反转
+var irregularSingulars = func() map[string]string {
+	singulars := make(map[string]string, len(irregularPlurals))
+	for singular, plural := range irregularPlurals {
+		singulars[plural] = singular
+	}
+	return singulars
+}()
+
+// compoundIrregulars 可以作为复合词末尾的不规则单词，例如 salesperson、fireman、grandchild，
+// 较长的单词在前，使 woman 先于 man 匹配
+var compoundIrregulars = []string{"person", "child", "goose", "mouse", "tooth", "woman", "foot", "man"}
+
+// uncountables 单复数同形的单词
+var uncountables = map[string]bool{
+	"audio": true, "cattle": true, "data": true, "deer": true, "education": true, "equipment": true,
+	"feedback": true, "fish": true, "information": true, "knowledge": true, "metadata": true,
+	"money": true, "moose": true, "news": true, "police": true, "rice": true, "series": true,
+	"sheep": true, "software": true, "species": true, "traffic": true,
+}
+
+// Plural 英文单词的复数形式，只转换最后一个单词，对应 Str::plural
+//
+// 覆盖常见的规则和不规则变化，"user_profile" 为 "user_profiles"，"Category" 为 "Categories"，
+// "salesperson" 为 "salespeople"。保留单词原有的大小写形式。
+func Plural(value string) string {
+	return inflect(value, pluralWord)
+}
+
+// PluralCount count 为 1 或 -1 时返回原字符串，否则返回复数形式，对应 Str::plural($value, $count)
+func PluralCount(value string, count int) string {
+	if count == 1 || count == -1 {
+		return value
+	}
+	return Plural(value)
+}
+
+// Singular 英文单词的单数形式，只转换最后一个单词，对应 Str::singular
+//
+// "users" 为 "user"，"Categories" 为 "Category"，"people" 为 "person"，已经是单数的单词保持不变。
+func Singular(value string) string {
+	return inflect(value, singularWord)
+}
+
+// pluralWord 小写单词的复数形式
+func pluralWord(word string) string {
+	if plural, ok := irregularPlurals[word]; ok {
+		return plural
+	}
+	if _, ok := irregularSingulars[word]; ok {
+		return word
+	}
+	for _, suffix := range compoundIrregulars {
+		if len(word) > len(suffix) && strings.HasSuffix(word, suffix) {
+			return word[:len(word)-len(suffix)] + irregularPlurals[suffix]
+		}
+	}
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !isVowel(word[len(word)-2]):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "sis"):
+		return word[:len(word)-3] + "ses"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	}
+	return word + "s"
+}
+
+// singularWord 小写单词的单数形式
+func singularWord(word string) string {
+	if singular, ok := irregularSingulars[word]; ok {
+		return singular
+	}
+	if _, ok := irregularPlurals[word]; ok {
+		return word
+	}
+	for _, suffix := range compoundIrregulars {
+		plural := irregularPlurals[suffix]
+		if len(word) > len(plural) && strings.HasSuffix(word, plural) {
+			return word[:len(word)-len(plural)] + suffix
+		}
+	}
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4 && !isVowel(word[len(word)-4]):
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zzes"),
+		strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "s") && len(word) > 1:
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// inflect 转换最后一个单词并保留其大小写形式
+func inflect(value string, transform func(word string) string) string {
+	runes := []rune(value)
+	start := len(runes)
+	for start > 0 && unicode.IsLetter(runes[start-1]) {
+		start--
+	}
+	// 大驼峰命名从最后一个大写字母开始
+	for i := len(runes) - 1; i > start; i-- {
+		if unicode.IsUpper(runes[i]) {
+			start = i
+			break
+		}
+	}
+	prefix, word := string(runes[:start]), string(runes[start:])
+	lower := strings.ToLower(word)
+	if lower == "" || uncountables[lower] {
+		return value
+	}
+
+	result := transform(lower)
+	switch {
+	case word == strings.ToUpper(word) && len(word) > 1:
+		result = strings.ToUpper(result)
+	case unicode.IsUpper([]rune(word)[0]):
+		result = Ucfirst(result)
+	}
+	return prefix + result
+}
+
+// isVowel 是否为小写元音字母
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
@@ -0,0 +1,439 @@
+// Package str 提供 Laravel 风格的字符串辅助函数，对应 Illuminate\Support\Str
+//
+// 大小写转换按单词切分：非字母数字字符、小写到大写的边界和连续大写缩写的末尾都是单词边界，
+// 因此 "userID"、"user_id"、"User ID" 的 Snake 结果都是 "user_id"，"HTTPServer" 为 "http_server"。
+// 长度相关的函数按字符（rune）计算，不按字节。
+//
+// 包结构：
+// - str.go - 大小写转换、Slug、Limit、Mask、Between、Pad 等字符串函数
+// - pluralizer.go - 英文单复数转换（Plural、Singular）
+// - stringable.go - Stringable 链式字符串包装（Of）
+// - id.go - UUID、有序 UUID、ULID 和随机字符串
+//
+// 使用示例：
+//
+//	str.Slug("Laravel 5 Framework")          // "laravel-5-framework"
+//	str.Camel("foo_bar")                     // "fooBar"
+//	str.Snake("fooBar")                      // "foo_bar"
+//	str.Limit("The quick brown fox", 9)      // "The quick..."
+//	str.Mask("taylor@example.com", "*", 3)   // "tay***************"
+//	str.Mask("taylor@example.com", "*", -15, 3) // "tay***@example.com"
+//	str.Plural("person")                     // "people"
+//	str.Singular("categories")               // "category"
+//	str.Of("hello world").Title().Slug().Limit(10).String() // "hello-worl..."
+//	id := str.ULID()                         // "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+package str
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// words 把字符串切分为单词
+func words(value string) []string {
+	var result []string
+	runes := []rune(value)
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			result = append(result, string(runes[start:end]))
+			start = -1
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush(i)
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush(i)
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(runes))
+	return result
+}
+
+// Snake 转换为蛇形命名，delimiter 默认为下划线
+func Snake(value string, delimiter ...string) string {
+	sep := "_"
+	if len(delimiter) > 0 {
+		sep = delimiter[0]
+	}
+	parts := words(value)
+	for i, part := range parts {
+		parts[i] = strings.ToLower(part)
+	}
+	return strings.Join(parts, sep)
+}
+
+// Kebab 转换为短横线命名
+func Kebab(value string) string {
+	return Snake(value, "-")
+}
+
+// Studly 转换为大驼峰命名
+func Studly(value string) string {
+	parts := words(value)
+	for i, part := range parts {
+		parts[i] = Ucfirst(strings.ToLower(part))
+	}
+	return strings.Join(parts, "")
+}
+
+// Camel 转换为小驼峰命名
+func Camel(value string) string {
+	return Lcfirst(Studly(value))
+}
+
+// Title 每个单词首字母大写，保留原有的分隔字符
+func Title(value string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range strings.ToLower(value) {
+		if upper && unicode.IsLetter(r) {
+			r = unicode.ToUpper(r)
+		}
+		upper = unicode.IsSpace(r) || r == '-' || r == '_'
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Ucfirst 首字母大写
+func Ucfirst(value string) string {
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 {
+		return value
+	}
+	return string(unicode.ToUpper(r)) + value[size:]
+}
+
+// Lcfirst 首字母小写
+func Lcfirst(value string) string {
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 {
+		return value
+	}
+	return string(unicode.ToLower(r)) + value[size:]
+}
+
+// Slug 生成 URL 友好的 slug，separator 默认为短横线
+//
+// "@" 替换为 "at"，字母和数字之外的字符视为分隔，连续分隔合并为一个。
+// 非 ASCII 字母会保留（不做音译），例如 "你好 世界" 为 "你好-世界"。
+func Slug(title string, separator ...string) string {
+	sep := "-"
+	if len(separator) > 0 {
+		sep = separator[0]
+	}
+	title = strings.ReplaceAll(strings.ToLower(title), "@", sep+"at"+sep)
+	var b strings.Builder
+	pending := false
+	for _, r := range title {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pending && b.Len() > 0 {
+				b.WriteString(sep)
+			}
+			pending = false
+			b.WriteRune(r)
+			continue
+		}
+		pending = true
+	}
+	return b.String()
+}
+
+// Limit 截断为最多 limit 个字符，截断时去掉末尾空白并追加 end（默认 "..."）
+func Limit(value string, limit int, end ...string) string {
+	suffix := "..."
+	if len(end) > 0 {
+		suffix = end[0]
+	}
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	runes := []rune(value)
+	return strings.TrimRightFunc(string(runes[:max(limit, 0)]), unicode.IsSpace) + suffix
+}
+
+// Words 保留前 count 个单词，截断时追加 end（默认 "..."）
+func Words(value string, count int, end ...string) string {
+	suffix := "..."
+	if len(end) > 0 {
+		suffix = end[0]
+	}
+	fields := strings.Fields(value)
+	if len(fields) <= count {
+		return value
+	}
+	return strings.Join(fields[:max(count, 0)], " ") + suffix
+}
+
+// Mask 用 character 的第一个字符遮盖从 index 开始的 length 个字符
+//
+// index 为负数时从末尾计算；不传 length 时遮盖到末尾，length 为负数时保留末尾 -length 个字符。
+func Mask(value, character string, index int, length ...int) string {
+	mask, _ := utf8.DecodeRuneInString(character)
+	if character == "" {
+		return value
+	}
+	runes := []rune(value)
+	n := len(runes)
+	start := index
+	if start < 0 {
+		start = max(n+start, 0)
+	}
+	if start >= n {
+		return value
+	}
+	end := n
+	if len(length) > 0 {
+		if length[0] < 0 {
+			end = n + length[0]
+		} else {
+			end = start + length[0]
+		}
+	}
+	end = min(end, n)
+	for i := start; i < end; i++ {
+		runes[i] = mask
+	}
+	return string(runes)
+}
+
+// Before 第一次出现 search 之前的部分，找不到时返回原字符串
+func Before(subject, search string) string {
+	before, _, found := strings.Cut(subject, search)
+	if !found || search == "" {
+		return subject
+	}
+	return before
+}
+
+// After 第一次出现 search 之后的部分，找不到时返回原字符串
+func After(subject, search string) string {
+	_, after, found := strings.Cut(subject, search)
+	if !found || search == "" {
+		return subject
+	}
+	return after
+}
+
+// Start 确保以 prefix 开头，已有的多个 prefix 合并为一个
+func Start(value, prefix string) string {
+	if prefix == "" {
+		return value
+	}
+	for strings.HasPrefix(value, prefix) {
+		value = value[len(prefix):]
+	}
+	return prefix + value
+}
+
+// Finish 确保以 cap 结尾，已有的多个 cap 合并为一个
+func Finish(value, cap string) string {
+	if cap == "" {
+		return value
+	}
+	for strings.HasSuffix(value, cap) {
+		value = value[:len(value)-len(cap)]
+	}
+	return value + cap
+}
+
+// Is 是否匹配模式，模式中的 "*" 匹配任意字符
+func Is(pattern, value string) bool {
+	if pattern == value {
+		return true
+	}
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return false
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// BeforeLast 最后一次出现 search 之前的部分，找不到时返回原字符串
+func BeforeLast(subject, search string) string {
+	i := strings.LastIndex(subject, search)
+	if i < 0 || search == "" {
+		return subject
+	}
+	return subject[:i]
+}
+
+// AfterLast 最后一次出现 search 之后的部分，找不到时返回原字符串
+func AfterLast(subject, search string) string {
+	i := strings.LastIndex(subject, search)
+	if i < 0 || search == "" {
+		return subject
+	}
+	return subject[i+len(search):]
+}
+
+// Between 第一个 from 之后、其后最后一个 to 之前的部分，from 或 to 为空时返回原字符串
+func Between(subject, from, to string) string {
+	if from == "" || to == "" {
+		return subject
+	}
+	return BeforeLast(After(subject, from), to)
+}
+
+// Squish 去掉首尾空白，并把连续的空白合并为一个空格
+func Squish(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// Headline 按单词切分后每个单词首字母大写并以空格连接，例如 "email_notification_sent" 为 "Email Notification Sent"
+func Headline(value string) string {
+	parts := words(value)
+	for i, part := range parts {
+		parts[i] = Ucfirst(strings.ToLower(part))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Length 字符数
+func Length(value string) int {
+	return utf8.RuneCountInString(value)
+}
+
+// Substr 从 start 开始的 length 个字符，start 为负数时从末尾计算，不传 length 时到末尾
+func Substr(value string, start int, length ...int) string {
+	runes := []rune(value)
+	n := len(runes)
+	if start < 0 {
+		start = max(n+start, 0)
+	}
+	if start >= n {
+		return ""
+	}
+	end := n
+	if len(length) > 0 {
+		if length[0] < 0 {
+			end = n + length[0]
+		} else {
+			end = start + length[0]
+		}
+	}
+	end = min(end, n)
+	if end <= start {
+		return ""
+	}
+	return string(runes[start:end])
+}
+
+// Reverse 按字符反转
+func Reverse(value string) string {
+	runes := []rune(value)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// PadLeft 在左侧用 pad 填充到 length 个字符，pad 默认为空格
+func PadLeft(value string, length int, pad ...string) string {
+	return padding(length-Length(value), pad) + value
+}
+
+// PadRight 在右侧用 pad 填充到 length 个字符，pad 默认为空格
+func PadRight(value string, length int, pad ...string) string {
+	return value + padding(length-Length(value), pad)
+}
+
+// PadBoth 在两侧用 pad 填充到 length 个字符，右侧多填的一个字符，pad 默认为空格
+func PadBoth(value string, length int, pad ...string) string {
+	total := length - Length(value)
+	if total <= 0 {
+		return value
+	}
+	return padding(total/2, pad) + value + padding(total-total/2, pad)
+}
+
+// padding 由 pad 重复组成的 n 个字符
+func padding(n int, pad []string) string {
+	fill := " "
+	if len(pad) > 0 && pad[0] != "" {
+		fill = pad[0]
+	}
+	if n <= 0 {
+		return ""
+	}
+	return string([]rune(strings.Repeat(fill, n))[:n])
+}
+
+// ReplaceFirst 替换第一次出现的 search
+func ReplaceFirst(subject, search, replace string) string {
+	if search == "" {
+		return subject
+	}
+	return strings.Replace(subject, search, replace, 1)
+}
+
+// ReplaceLast 替换最后一次出现的 search
+func ReplaceLast(subject, search, replace string) string {
+	i := strings.LastIndex(subject, search)
+	if i < 0 || search == "" {
+		return subject
+	}
+	return subject[:i] + replace + subject[i+len(search):]
+}
+
+// Wrap 在前后加上 before 和 after，不传 after 时与 before 相同
+func Wrap(value, before string, after ...string) string {
+	end := before
+	if len(after) > 0 {
+		end = after[0]
+	}
+	return before + value + end
+}
+
+// ContainsAny 是否包含任意一个 needle
+func ContainsAny(haystack string, needles ...string) bool {
+	for _, needle := range needles {
+		if needle != "" && strings.Contains(haystack, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartsWith 是否以任意一个 needle 开头
+func StartsWith(haystack string, needles ...string) bool {
+	for _, needle := range needles {
+		if needle != "" && strings.HasPrefix(haystack, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// EndsWith 是否以任意一个 needle 结尾
+func EndsWith(haystack string, needles ...string) bool {
+	for _, needle := range needles {
+		if needle != "" && strings.HasSuffix(haystack, needle) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,337 @@
+package str
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Stringable 不可变的字符串包装，链式调用字符串函数，对应 Laravel 的 Str::of
+//
+// 每个方法返回新的 Stringable，不修改原值；String 取出结果。
+//
+//	str.Of("hello world").Title().Slug().Limit(10).String() // "hello-worl..."
+//	str.Of("user_profile").Studly().Plural().String()      // "UserProfiles"
+type Stringable struct {
+	value string
+}
+
+// Of 创建 Stringable
+func Of(value string) Stringable {
+	return Stringable{value: value}
+}
+
+// String 字符串值
+func (s Stringable) String() string {
+	return s.value
+}
+
+// Append 在末尾追加
+func (s Stringable) Append(values ...string) Stringable {
+	return Of(s.value + strings.Join(values, ""))
+}
+
+// Prepend 在开头插入
+func (s Stringable) Prepend(values ...string) Stringable {
+	return Of(strings.Join(values, "") + s.value)
+}
+
+// Lower 转换为小写
+func (s Stringable) Lower() Stringable {
+	return Of(strings.ToLower(s.value))
+}
+
+// Upper 转换为大写
+func (s Stringable) Upper() Stringable {
+	return Of(strings.ToUpper(s.value))
+}
+
+// Title 每个单词首字母大写
+func (s Stringable) Title() Stringable {
+	return Of(Title(s.value))
+}
+
+// Headline 转换为以空格分隔、首字母大写的标题
+func (s Stringable) Headline() Stringable {
+	return Of(Headline(s.value))
+}
+
+// Snake 转换为蛇形命名
+func (s Stringable) Snake(delimiter ...string) Stringable {
+	return Of(Snake(s.value, delimiter...))
+}
+
+// Kebab 转换为短横线命名
+func (s Stringable) Kebab() Stringable {
+	return Of(Kebab(s.value))
+}
+
+// Studly 转换为大驼峰命名
+func (s Stringable) Studly() Stringable {
+	return Of(Studly(s.value))
+}
+
+// Camel 转换为小驼峰命名
+func (s Stringable) Camel() Stringable {
+	return Of(Camel(s.value))
+}
+
+// Ucfirst 首字母大写
+func (s Stringable) Ucfirst() Stringable {
+	return Of(Ucfirst(s.value))
+}
+
+// Lcfirst 首字母小写
+func (s Stringable) Lcfirst() Stringable {
+	return Of(Lcfirst(s.value))
+}
+
+// Slug 生成 URL 友好的 slug
+func (s Stringable) Slug(separator ...string) Stringable {
+	return Of(Slug(s.value, separator...))
+}
+
+// Plural 复数形式，count 为 1 或 -1 时保持不变
+func (s Stringable) Plural(count ...int) Stringable {
+	if len(count) > 0 {
+		return Of(PluralCount(s.value, count[0]))
+	}
+	return Of(Plural(s.value))
+}
+
+// Singular 单数形式
+func (s Stringable) Singular() Stringable {
+	return Of(Singular(s.value))
+}
+
+// Limit 截断为最多 limit 个字符
+func (s Stringable) Limit(limit int, end ...string) Stringable {
+	return Of(Limit(s.value, limit, end...))
+}
+
+// Words 保留前 count 个单词
+func (s Stringable) Words(count int, end ...string) Stringable {
+	return Of(Words(s.value, count, end...))
+}
+
+// Mask 遮盖部分字符
+func (s Stringable) Mask(character string, index int, length ...int) Stringable {
+	return Of(Mask(s.value, character, index, length...))
+}
+
+// Substr 截取部分字符
+func (s Stringable) Substr(start int, length ...int) Stringable {
+	return Of(Substr(s.value, start, length...))
+}
+
+// Before 第一次出现 search 之前的部分
+func (s Stringable) Before(search string) Stringable {
+	return Of(Before(s.value, search))
+}
+
+// BeforeLast 最后一次出现 search 之前的部分
+func (s Stringable) BeforeLast(search string) Stringable {
+	return Of(BeforeLast(s.value, search))
+}
+
+// After 第一次出现 search 之后的部分
+func (s Stringable) After(search string) Stringable {
+	return Of(After(s.value, search))
+}
+
+// AfterLast 最后一次出现 search 之后的部分
+func (s Stringable) AfterLast(search string) Stringable {
+	return Of(AfterLast(s.value, search))
+}
+
+// Between from 和 to 之间的部分
+func (s Stringable) Between(from, to string) Stringable {
+	return Of(Between(s.value, from, to))
+}
+
+// Start 确保以 prefix 开头
+func (s Stringable) Start(prefix string) Stringable {
+	return Of(Start(s.value, prefix))
+}
+
+// Finish 确保以 cap 结尾
+func (s Stringable) Finish(cap string) Stringable {
+	return Of(Finish(s.value, cap))
+}
+
+// Wrap 在前后加上 before 和 after
+func (s Stringable) Wrap(before string, after ...string) Stringable {
+	return Of(Wrap(s.value, before, after...))
+}
+
+// Replace 替换全部 search
+func (s Stringable) Replace(search, replace string) Stringable {
+	return Of(strings.ReplaceAll(s.value, search, replace))
+}
+
+// ReplaceFirst 替换第一次出现的 search
+func (s Stringable) ReplaceFirst(search, replace string) Stringable {
+	return Of(ReplaceFirst(s.value, search, replace))
+}
+
+// ReplaceLast 替换最后一次出现的 search
+func (s Stringable) ReplaceLast(search, replace string) Stringable {
+	return Of(ReplaceLast(s.value, search, replace))
+}
+
+// ReplaceMatches 按正则表达式替换，replace 中可以使用 $1 引用分组
+func (s Stringable) ReplaceMatches(pattern *regexp.Regexp, replace string) Stringable {
+	return Of(pattern.ReplaceAllString(s.value, replace))
+}
+
+// Remove 删除全部 search
+func (s Stringable) Remove(search ...string) Stringable {
+	value := s.value
+	for _, item := range search {
+		value = strings.ReplaceAll(value, item, "")
+	}
+	return Of(value)
+}
+
+// Trim 去掉首尾的 cutset 字符，不传时去掉空白
+func (s Stringable) Trim(cutset ...string) Stringable {
+	if len(cutset) > 0 {
+		return Of(strings.Trim(s.value, cutset[0]))
+	}
+	return Of(strings.TrimSpace(s.value))
+}
+
+// Ltrim 去掉开头的 cutset 字符，不传时去掉空白
+func (s Stringable) Ltrim(cutset ...string) Stringable {
+	if len(cutset) > 0 {
+		return Of(strings.TrimLeft(s.value, cutset[0]))
+	}
+	return Of(strings.TrimLeft(s.value, " \t\n\r\v\f"))
+}
+
+// Rtrim 去掉末尾的 cutset 字符，不传时去掉空白
+func (s Stringable) Rtrim(cutset ...string) Stringable {
+	if len(cutset) > 0 {
+		return Of(strings.TrimRight(s.value, cutset[0]))
+	}
+	return Of(strings.TrimRight(s.value, " \t\n\r\v\f"))
+}
+
+// Squish 去掉首尾空白并合并连续空白
+func (s Stringable) Squish() Stringable {
+	return Of(Squish(s.value))
+}
+
+// PadLeft 在左侧填充到 length 个字符
+func (s Stringable) PadLeft(length int, pad ...string) Stringable {
+	return Of(PadLeft(s.value, length, pad...))
+}
+
+// PadRight 在右侧填充到 length 个字符
+func (s Stringable) PadRight(length int, pad ...string) Stringable {
+	return Of(PadRight(s.value, length, pad...))
+}
+
+// PadBoth 在两侧填充到 length 个字符
+func (s Stringable) PadBoth(length int, pad ...string) Stringable {
+	return Of(PadBoth(s.value, length, pad...))
+}
+
+// Repeat 重复 times 次
+func (s Stringable) Repeat(times int) Stringable {
+	return Of(strings.Repeat(s.value, max(times, 0)))
+}
+
+// Reverse 按字符反转
+func (s Stringable) Reverse() Stringable {
+	return Of(Reverse(s.value))
+}
+
+// Pipe 把值交给 callback 转换
+func (s Stringable) Pipe(callback func(value string) string) Stringable {
+	return Of(callback(s.value))
+}
+
+// When condition 为 true 时应用 callback
+func (s Stringable) When(condition bool, callback func(s Stringable) Stringable) Stringable {
+	if condition {
+		return callback(s)
+	}
+	return s
+}
+
+// WhenEmpty 为空字符串时应用 callback
+func (s Stringable) WhenEmpty(callback func(s Stringable) Stringable) Stringable {
+	return s.When(s.IsEmpty(), callback)
+}
+
+// WhenNotEmpty 不为空字符串时应用 callback
+func (s Stringable) WhenNotEmpty(callback func(s Stringable) Stringable) Stringable {
+	return s.When(s.IsNotEmpty(), callback)
+}
+
+// Explode 按 separator 拆分
+func (s Stringable) Explode(separator string) []string {
+	return strings.Split(s.value, separator)
+}
+
+// Match 正则表达式第一个分组的匹配，没有分组时为整个匹配，未匹配时为空
+func (s Stringable) Match(pattern *regexp.Regexp) Stringable {
+	matches := pattern.FindStringSubmatch(s.value)
+	switch len(matches) {
+	case 0:
+		return Of("")
+	case 1:
+		return Of(matches[0])
+	}
+	return Of(matches[1])
+}
+
+// Test 是否匹配正则表达式
+func (s Stringable) Test(pattern *regexp.Regexp) bool {
+	return pattern.MatchString(s.value)
+}
+
+// Is 是否匹配模式，模式中的 "*" 匹配任意字符
+func (s Stringable) Is(pattern string) bool {
+	return Is(pattern, s.value)
+}
+
+// Exactly 是否与 value 完全相同
+func (s Stringable) Exactly(value string) bool {
+	return s.value == value
+}
+
+// Contains 是否包含任意一个 needle
+func (s Stringable) Contains(needles ...string) bool {
+	return ContainsAny(s.value, needles...)
+}
+
+// StartsWith 是否以任意一个 needle 开头
+func (s Stringable) StartsWith(needles ...string) bool {
+	return StartsWith(s.value, needles...)
+}
+
+// EndsWith 是否以任意一个 needle 结尾
+func (s Stringable) EndsWith(needles ...string) bool {
+	return EndsWith(s.value, needles...)
+}
+
+// Length 字符数
+func (s Stringable) Length() int {
+	return Length(s.value)
+}
+
+// IsEmpty 是否为空字符串
+func (s Stringable) IsEmpty() bool {
+	return s.value == ""
+}
+
+// IsNotEmpty 是否不为空字符串
+func (s Stringable) IsNotEmpty() bool {
+	return s.value != ""
+}
+
+// MarshalText 实现 encoding.TextMarshaler，JSON 中序列化为字符串
+func (s Stringable) MarshalText() ([]byte, error) {
+	return []byte(s.value), nil
+}
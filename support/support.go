@@ -0,0 +1,37 @@
+// Package support 提供 Laravel 风格的集合和通用辅助函数
+//
+// Collection 对应 Laravel 的 Illuminate\Support\Collection，LazyCollection 基于 iter.Seq 按需求值，
+// 适合处理大文件或大结果集。Go 的方法不能声明额外的类型参数，
+// 因此改变元素类型或需要额外约束的操作（Map、Reduce、Chunk、GroupBy、Unique、Sum 等）是包级函数。
+// 集合的方法和函数都返回新集合，不修改原集合。
+//
+// 包结构：
+// - support.go - Number 数值约束
+// - collection.go - Collection 集合和包级集合函数
+// - lazy.go - LazyCollection 惰性集合
+//
+// 子包 str 提供字符串辅助函数（Str），子包 arr 提供嵌套 map 的点号路径辅助函数（Arr），
+// 子包 benchmark 提供耗时测量和带内存分配预算的基准测试套件（Benchmark），
+// 子包 enum 提供枚举类型的查找、验证规则和反射辅助（Enumerable），
+// 子包 timetravel 提供测试用的可控时钟（Freeze、Travel、TravelTo），
+// 子包 datetime 提供不可变的日期时间类型（DateTime），子包 decimal 提供精确的十进制数（Decimal），
+// 两者都可以直接作为模型字段。
+//
+// 使用示例：
+//
+//	users := support.Collect(list)
+//	active := users.Filter(func(u User, _ int) bool { return u.Active })
+//	names := support.Map(active, func(u User, _ int) string { return u.Name })
+//	byTeam := support.GroupBy(users, func(u User) int64 { return u.TeamID })
+//	total := support.SumBy(users, func(u User) float64 { return u.Balance })
+//
+//	// 惰性集合：逐行读取，只保留需要的前 10 条
+//	errors := support.Lazy(lines).Filter(func(l string) bool { return strings.Contains(l, "ERROR") }).Take(10).Collect()
+package support
+
+// Number 可以求和和求平均值的数值类型
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
@@ -0,0 +1,156 @@
+// Package timetravel 提供测试用的可控时钟，对应 Laravel 测试中的 freezeTime、travel 和 travelTo
+//
+// TimeTravel 的 Now 可以作为任何 func() time.Time 时钟使用，并可以一次性接入数据库会话（NowFunc）、
+// 内存缓存（过期时间）和调度器（任务到期判断），使时间戳和过期逻辑的测试结果确定。
+// 数据库会话基于传入的连接创建，因此可以接在 dbtest.RefreshDatabase 返回的事务连接上，
+// 写入仍在同一事务中，测试结束时一起回滚。
+//
+// 主要特性：
+// - Freeze 冻结时间，Travel 按时长前进或后退，TravelTo 跳到指定时间，TravelBack 恢复真实时间
+// - 未冻结时时间按真实时钟继续流逝，只叠加偏移
+// - 测试结束时自动把接入的缓存和调度器恢复为 time.Now
+//
+// 包结构：
+// - timetravel.go - TimeTravel 可控时钟和各组件的接入
+//
+// 使用示例：
+//
+//	func TestTokenExpires(t *testing.T) {
+//		tt := timetravel.New(t)
+//		tt.Freeze()
+//
+//		db := tt.Session(dbtest.RefreshDatabase(t, connection))
+//		store := cache.NewMemoryStore()
+//		tt.Cache(store)
+//
+//		store.Put(ctx, "token", "abc", time.Hour)
+//		tt.Travel(61 * time.Minute)
+//		// 缓存项已过期，db 写入的 created_at、updated_at 为旅行后的时间
+//	}
+package timetravel
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/schedule"
+)
+
+// TimeTravel 测试用的可控时钟，并发安全
+type TimeTravel struct {
+	mu      sync.Mutex
+	frozen  bool
+	current time.Time
+	offset  time.Duration
+	resets  []func()
+}
+
+// New 创建使用真实时间的时钟，测试结束时把接入的缓存和调度器恢复为 time.Now
+func New(t testing.TB) *TimeTravel {
+	t.Helper()
+	tt := &TimeTravel{}
+	t.Cleanup(tt.reset)
+	return tt
+}
+
+// Now 当前的测试时间
+func (tt *TimeTravel) Now() time.Time {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	return tt.now()
+}
+
+// Freeze 在当前测试时间冻结时间并返回冻结的时间，对应 freezeTime
+func (tt *TimeTravel) Freeze() time.Time {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	tt.current = tt.now()
+	tt.frozen = true
+	return tt.current
+}
+
+// Travel 按时长前进，负数为后退，冻结状态保持不变
+func (tt *TimeTravel) Travel(d time.Duration) *TimeTravel {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	if tt.frozen {
+		tt.current = tt.current.Add(d)
+	} else {
+		tt.offset += d
+	}
+	return tt
+}
+
+// TravelTo 跳到指定时间，冻结状态保持不变；未冻结时时间从该时刻起继续流逝
+func (tt *TimeTravel) TravelTo(t time.Time) *TimeTravel {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	if tt.frozen {
+		tt.current = t
+	} else {
+		tt.offset = time.Until(t)
+	}
+	return tt
+}
+
+// TravelBack 解除冻结并恢复真实时间
+func (tt *TimeTravel) TravelBack() *TimeTravel {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	tt.frozen = false
+	tt.current = time.Time{}
+	tt.offset = 0
+	return tt
+}
+
+// Session 基于连接创建使用测试时间的会话，自动维护的时间戳（created_at、updated_at、deleted_at）使用测试时间
+func (tt *TimeTravel) Session(db database.DB) database.DB {
+	return db.Session(&database.SessionConfig{NowFunc: tt.Now})
+}
+
+// Cache 让内存缓存的过期时间、锁和限流器使用测试时间
+func (tt *TimeTravel) Cache(stores ...*cache.MemoryStore) *TimeTravel {
+	for _, store := range stores {
+		store.SetClock(tt.Now)
+		tt.onReset(func() { store.SetClock(nil) })
+	}
+	return tt
+}
+
+// Schedule 让调度器按测试时间判断任务是否到期
+func (tt *TimeTravel) Schedule(schedules ...*schedule.Schedule) *TimeTravel {
+	for _, s := range schedules {
+		s.SetClock(tt.Now)
+		tt.onReset(func() { s.SetClock(nil) })
+	}
+	return tt
+}
+
+// now 当前的测试时间，调用方持有锁
+func (tt *TimeTravel) now() time.Time {
+	if tt.frozen {
+		return tt.current
+	}
+	return time.Now().Add(tt.offset)
+}
+
+// onReset 记录测试结束时的恢复操作
+func (tt *TimeTravel) onReset(reset func()) {
+	tt.mu.Lock()
+	tt.resets = append(tt.resets, reset)
+	tt.mu.Unlock()
+}
+
+// reset 恢复接入的组件
+func (tt *TimeTravel) reset() {
+	tt.mu.Lock()
+	resets := tt.resets
+	tt.resets = nil
+	tt.mu.Unlock()
+	for _, reset := range resets {
+		reset()
+	}
+}
@@ -0,0 +1,159 @@
+package telescope
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// maxLimit 浏览接口单次最多返回的记录数
+const maxLimit = 200
+
+// Gate 浏览接口的访问控制，返回 false 时响应 403
+type Gate func(r *http.Request) bool
+
+// DefaultGate 非生产环境允许所有请求，生产环境只允许 authorized 返回 true 的请求
+//
+// authorized 为 nil 时生产环境拒绝所有请求。
+func DefaultGate(app application.Application, authorized func(r *http.Request) bool) Gate {
+	return func(r *http.Request) bool {
+		if app != nil && !app.IsProduction() {
+			return true
+		}
+		return authorized != nil && authorized(r)
+	}
+}
+
+// Handler 返回 JSON 浏览接口
+//
+// 路由（相对于挂载路径）：
+// - GET    /entries        记录列表，支持 ?type=、?tag=、?batch_id=、?before= 和 ?limit= 过滤
+// - GET    /entries/{uuid} 单条记录和同一批次的其他记录
+// - DELETE /entries        清空记录
+// - POST   /pause          暂停记录
+// - POST   /resume         恢复记录
+//
+// gate 为 nil 时拒绝所有请求。浏览接口自身的请求不应被记录，
+// 挂载路径需要加入 IgnorePaths。
+func (t *Telescope) Handler(gate Gate) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if gate == nil || !gate(r) {
+			writeJSON(w, http.StatusForbidden, map[string]string{"message": "Forbidden."})
+			return
+		}
+		ctx := WithoutRecording(r.Context())
+		route := strings.Trim(r.URL.Path, "/")
+
+		switch {
+		case route == "entries" && (r.Method == http.MethodGet || r.Method == http.MethodHead):
+			query, err := entryQuery(r)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+				return
+			}
+			entries, err := t.repository.Get(ctx, query)
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, map[string]string{"message": err.Error()})
+				return
+			}
+			var next interface{}
+			if len(entries) == query.Limit {
+				next = entries[len(entries)-1].Sequence
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"entries":   entries,
+				"next":      next,
+				"recording": t.IsRecording(),
+			})
+
+		case route == "entries" && r.Method == http.MethodDelete:
+			if err := t.repository.Clear(ctx); err != nil {
+				writeJSON(w, http.StatusInternalServerError, map[string]string{"message": err.Error()})
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		case strings.HasPrefix(route, "entries/") && (r.Method == http.MethodGet || r.Method == http.MethodHead):
+			entry, err := t.repository.Find(ctx, strings.TrimPrefix(route, "entries/"))
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, map[string]string{"message": err.Error()})
+				return
+			}
+			if entry == nil {
+				writeJSON(w, http.StatusNotFound, map[string]string{"message": "Entry not found."})
+				return
+			}
+			related, err := t.repository.Get(ctx, EntryQuery{BatchID: entry.BatchID, Limit: maxLimit})
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, map[string]string{"message": err.Error()})
+				return
+			}
+			batch := make([]Entry, 0, len(related))
+			for _, e := range related {
+				if e.UUID != entry.UUID {
+					batch = append(batch, e)
+				}
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"entry": entry, "batch": batch})
+
+		case (route == "pause" || route == "resume") && r.Method == http.MethodPost:
+			if route == "pause" {
+				t.Pause()
+			} else {
+				t.Resume()
+			}
+			writeJSON(w, http.StatusOK, map[string]bool{"recording": t.IsRecording()})
+
+		case route == "entries" || strings.HasPrefix(route, "entries/") || route == "pause" || route == "resume":
+			writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"message": http.StatusText(http.StatusMethodNotAllowed)})
+
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": http.StatusText(http.StatusNotFound)})
+		}
+	})
+}
+
+// entryQuery 从查询参数读取查询条件
+func entryQuery(r *http.Request) (EntryQuery, error) {
+	values := r.URL.Query()
+	query := EntryQuery{
+		Type:    values.Get("type"),
+		Tag:     values.Get("tag"),
+		BatchID: values.Get("batch_id"),
+		Limit:   defaultLimit,
+	}
+	if before := values.Get("before"); before != "" {
+		sequence, err := strconv.ParseInt(before, 10, 64)
+		if err != nil {
+			return query, errInvalidParameter("before")
+		}
+		query.Before = sequence
+	}
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return query, errInvalidParameter("limit")
+		}
+		query.Limit = min(n, maxLimit)
+	}
+	return query, nil
+}
+
+// errInvalidParameter 查询参数无效
+type errInvalidParameter string
+
+// Error 实现 error 接口
+func (e errInvalidParameter) Error() string {
+	return "The " + string(e) + " parameter is invalid."
+}
+
+// writeJSON 写入 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,260 @@
+package telescope
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/database"
+)
+
+// defaultLimit 查询未指定数量时返回的记录数
+const defaultLimit = 50
+
+// MemoryRepository 内存存储
+//
+// 最多保留 limit 条记录，超出时丢弃最早的记录。适合开发环境和测试，
+// 多进程部署时每个进程只能看到自己的记录。
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	limit    int
+	sequence int64
+	entries  []Entry
+}
+
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository 创建内存存储，limit 不大于 0 时保留 1000 条
+func NewMemoryRepository(limit int) *MemoryRepository {
+	if limit <= 0 {
+		limit = 1000
+	}
+	return &MemoryRepository{limit: limit}
+}
+
+// Store 保存记录
+func (m *MemoryRepository) Store(ctx context.Context, entries []Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, entry := range entries {
+		m.sequence++
+		entry.Sequence = m.sequence
+		m.entries = append(m.entries, entry)
+	}
+	if overflow := len(m.entries) - m.limit; overflow > 0 {
+		m.entries = append([]Entry{}, m.entries[overflow:]...)
+	}
+	return nil
+}
+
+// Find 按 UUID 查找记录
+func (m *MemoryRepository) Find(ctx context.Context, uuid string) (*Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for i := range m.entries {
+		if m.entries[i].UUID == uuid {
+			entry := m.entries[i]
+			return &entry, nil
+		}
+	}
+	return nil, nil
+}
+
+// Get 按条件查询记录
+func (m *MemoryRepository) Get(ctx context.Context, query EntryQuery) ([]Entry, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make([]Entry, 0, limit)
+	for i := len(m.entries) - 1; i >= 0 && len(result) < limit; i-- {
+		entry := m.entries[i]
+		if (query.Type == "" || entry.Type == query.Type) &&
+			(query.Tag == "" || entry.HasTag(query.Tag)) &&
+			(query.BatchID == "" || entry.BatchID == query.BatchID) &&
+			(query.Before <= 0 || entry.Sequence < query.Before) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// Prune 删除指定时间之前的记录
+func (m *MemoryRepository) Prune(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.entries[:0]
+	for _, entry := range m.entries {
+		if !entry.CreatedAt.Before(before) {
+			kept = append(kept, entry)
+		}
+	}
+	pruned := int64(len(m.entries) - len(kept))
+	m.entries = kept
+	return pruned, nil
+}
+
+// Clear 删除所有记录
+func (m *MemoryRepository) Clear(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = nil
+	return nil
+}
+
+// EntryRecord telescope_entries 表结构
+//
+// 标签以 JSON 数组保存，按标签查询使用 LIKE 匹配，标签中的 % 和 _ 按通配符处理。
+type EntryRecord struct {
+	Sequence  int64     `gorm:"primarykey;autoIncrement" json:"sequence"`
+	UUID      string    `gorm:"uniqueIndex;size:36" json:"uuid"`
+	BatchID   string    `gorm:"index;size:36" json:"batch_id"`
+	Type      string    `gorm:"index;size:20" json:"type"`
+	Content   string    `gorm:"type:text" json:"content"`
+	Tags      string    `gorm:"type:text" json:"tags"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName 表名
+func (EntryRecord) TableName() string {
+	return "telescope_entries"
+}
+
+// DatabaseRepository 数据库存储，使用 telescope_entries 表
+//
+// 存储自身的查询使用 WithoutRecording 标记的 ctx，不会被 QueryWatcher 记录。
+type DatabaseRepository struct {
+	db    database.DB
+	table string
+}
+
+var _ Repository = (*DatabaseRepository)(nil)
+
+// NewDatabaseRepository 创建数据库存储，table 为空时使用 telescope_entries
+func NewDatabaseRepository(db database.DB, table string) *DatabaseRepository {
+	if table == "" {
+		table = EntryRecord{}.TableName()
+	}
+	return &DatabaseRepository{db: db, table: table}
+}
+
+// Migrate 创建记录表
+func (r *DatabaseRepository) Migrate(ctx context.Context) error {
+	return r.query(ctx).AutoMigrate(&EntryRecord{})
+}
+
+// Store 保存记录
+func (r *DatabaseRepository) Store(ctx context.Context, entries []Entry) error {
+	for _, entry := range entries {
+		content, err := json.Marshal(entry.Content)
+		if err != nil {
+			return err
+		}
+		tags, err := json.Marshal(entry.Tags)
+		if err != nil {
+			return err
+		}
+		record := EntryRecord{
+			UUID:      entry.UUID,
+			BatchID:   entry.BatchID,
+			Type:      entry.Type,
+			Content:   string(content),
+			Tags:      string(tags),
+			CreatedAt: entry.CreatedAt,
+		}
+		if err := r.query(ctx).Create(&record).Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find 按 UUID 查找记录
+func (r *DatabaseRepository) Find(ctx context.Context, uuid string) (*Entry, error) {
+	var records []EntryRecord
+	if err := r.query(ctx).Where("uuid = ?", uuid).Limit(1).Find(&records).Error(); err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	entry, err := records[0].entry()
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Get 按条件查询记录
+func (r *DatabaseRepository) Get(ctx context.Context, query EntryQuery) ([]Entry, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	tx := r.query(ctx)
+	if query.Type != "" {
+		tx = tx.Where("type = ?", query.Type)
+	}
+	if query.Tag != "" {
+		tag, _ := json.Marshal(query.Tag)
+		tx = tx.Where("tags LIKE ?", "%"+string(tag)+"%")
+	}
+	if query.BatchID != "" {
+		tx = tx.Where("batch_id = ?", query.BatchID)
+	}
+	if query.Before > 0 {
+		tx = tx.Where("sequence < ?", query.Before)
+	}
+	var records []EntryRecord
+	if err := tx.Order("sequence desc").Limit(limit).Find(&records).Error(); err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(records))
+	for _, record := range records {
+		entry, err := record.entry()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Prune 删除指定时间之前的记录
+func (r *DatabaseRepository) Prune(ctx context.Context, before time.Time) (int64, error) {
+	result := r.query(ctx).Where("created_at < ?", before).Delete(&EntryRecord{})
+	return result.RowsAffected(), result.Error()
+}
+
+// Clear 删除所有记录
+func (r *DatabaseRepository) Clear(ctx context.Context) error {
+	return r.query(ctx).Session(&database.SessionConfig{AllowGlobalUpdate: true}).Delete(&EntryRecord{}).Error()
+}
+
+// query 创建不被记录的查询
+func (r *DatabaseRepository) query(ctx context.Context) database.DB {
+	return r.db.WithContext(WithoutRecording(ctx)).Table(r.table)
+}
+
+// entry 转换为记录
+func (record EntryRecord) entry() (Entry, error) {
+	entry := Entry{
+		Sequence:  record.Sequence,
+		UUID:      record.UUID,
+		BatchID:   record.BatchID,
+		Type:      record.Type,
+		CreatedAt: record.CreatedAt,
+	}
+	if err := json.Unmarshal([]byte(record.Content), &entry.Content); err != nil {
+		return Entry{}, err
+	}
+	if record.Tags != "" {
+		if err := json.Unmarshal([]byte(record.Tags), &entry.Tags); err != nil {
+			return Entry{}, err
+		}
+	}
+	return entry, nil
+}
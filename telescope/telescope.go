@@ -0,0 +1,348 @@
+// Package telescope 提供 Laravel Telescope 风格的调试记录器
+//
+// 记录器通过一组观察者收集应用运行时的请求、数据库查询、队列任务、缓存操作、
+// 日志、事件和错误，写入存储驱动，并提供 JSON 接口浏览记录。
+// 同一个请求内产生的记录共享批次 ID，可以从请求记录查看它触发的所有查询和事件。
+//
+// 主要特性：
+// - 请求、查询、任务、缓存、日志、事件、错误和门面调用观察者
+// - 按批次关联同一请求内的记录，按请求 ID 标签关联跨服务的记录
+// - 敏感请求头和参数隐藏、路径忽略、记录过滤和自定义标签
+// - 内存和数据库存储驱动，支持按时间清理
+// - JSON 浏览接口，默认只在非生产环境开放
+//
+// 包结构：
+// - telescope.go - Entry 记录、Repository 存储接口和 Telescope 记录器
+// - storage.go - MemoryRepository 内存存储和 DatabaseRepository 数据库存储
+// - watchers.go - 请求、查询、任务、缓存、日志、事件、错误和门面调用观察者
+// - api.go - Gate 访问控制和 JSON 浏览接口
+//
+// 使用示例：
+//
+//	t := telescope.New(telescope.NewDatabaseRepository(db, ""))
+//	t.IgnorePaths("/telescope/*", "/health")
+//
+//	db = db.Session(&database.SessionConfig{Logger: t.QueryWatcher(nil)})
+//	store = t.CacheStore(store)
+//	logger = t.Logger(logger)
+//	events = t.EventDispatcher(events)
+//	t.WatchQueue(worker)
+//	exceptionHandler.AddReporter(t.Reporter())
+//	facade.Observe(facade.SlowerThan(50*time.Millisecond, t.FacadeObserver()))
+//
+//	gate := telescope.DefaultGate(app, func(r *http.Request) bool {
+//		user, _ := guard.User(r.Context())
+//		return user != nil && user.GetAuthIdentifier() == 1
+//	})
+//	mux.Handle("/telescope/api/", http.StripPrefix("/telescope/api", t.Handler(gate)))
+//	http.ListenAndServe(":8080", t.Middleware(mux))
+package telescope
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/appcontext"
+	"github.com/cnote0/laraveldoc/support/str"
+)
+
+// 记录类型
+const (
+	TypeRequest   = "request"
+	TypeQuery     = "query"
+	TypeJob       = "job"
+	TypeCache     = "cache"
+	TypeLog       = "log"
+	TypeEvent     = "event"
+	TypeException = "exception"
+	TypeFacade    = "facade"
+)
+
+// Entry 一条记录
+type Entry struct {
+	// Sequence 存储分配的递增序号，用于分页
+	Sequence int64 `json:"sequence"`
+
+	// UUID 记录唯一标识
+	UUID string `json:"uuid"`
+
+	// BatchID 批次 ID，同一请求内的记录相同
+	BatchID string `json:"batch_id"`
+
+	// Type 记录类型
+	Type string `json:"type"`
+
+	// Content 记录内容，必须可以编码为 JSON
+	Content map[string]interface{} `json:"content"`
+
+	// Tags 标签
+	Tags []string `json:"tags"`
+
+	// CreatedAt 记录时间
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HasTag 是否包含标签
+func (e Entry) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// EntryQuery 记录查询条件
+type EntryQuery struct {
+	// Type 记录类型，为空时不限制
+	Type string
+
+	// Tag 标签，为空时不限制
+	Tag string
+
+	// BatchID 批次 ID，为空时不限制
+	BatchID string
+
+	// Before 只返回序号小于该值的记录，为 0 时不限制
+	Before int64
+
+	// Limit 最多返回的数量，默认 50
+	Limit int
+}
+
+// Repository 记录存储接口
+type Repository interface {
+	// Store 保存记录，由存储分配 Sequence
+	Store(ctx context.Context, entries []Entry) error
+
+	// Find 按 UUID 查找记录，不存在时返回 nil
+	Find(ctx context.Context, uuid string) (*Entry, error)
+
+	// Get 按条件查询记录，按序号倒序
+	Get(ctx context.Context, query EntryQuery) ([]Entry, error)
+
+	// Prune 删除指定时间之前的记录，返回删除的数量
+	Prune(ctx context.Context, before time.Time) (int64, error)
+
+	// Clear 删除所有记录
+	Clear(ctx context.Context) error
+}
+
+// Telescope 记录器
+//
+// 观察者把记录交给 Record，记录经过过滤和打标签后写入存储。
+// 写入失败不会影响应用，错误交给 ReportErrorsUsing 设置的回调。
+type Telescope struct {
+	repository Repository
+	now        func() time.Time
+
+	mu            sync.RWMutex
+	recording     bool
+	filters       []func(Entry) bool
+	taggers       []func(Entry) []string
+	ignorePaths   []string
+	hiddenHeaders map[string]bool
+	hiddenParams  map[string]bool
+	slowQuery     time.Duration
+	onError       func(error)
+}
+
+// New 创建记录器，默认开始记录
+//
+// 默认隐藏 Authorization、Cookie 等请求头和 password 等请求参数，
+// 超过 100 毫秒的查询标记为 slow。
+func New(repository Repository) *Telescope {
+	t := &Telescope{
+		repository:    repository,
+		now:           time.Now,
+		recording:     true,
+		hiddenHeaders: make(map[string]bool),
+		hiddenParams:  make(map[string]bool),
+		slowQuery:     100 * time.Millisecond,
+	}
+	t.HideRequestHeaders("authorization", "cookie", "php-auth-pw", "proxy-authorization", "x-csrf-token", "x-xsrf-token")
+	t.HideRequestParameters("password", "password_confirmation", "current_password", "_token")
+	return t
+}
+
+// Repository 获取存储
+func (t *Telescope) Repository() Repository {
+	return t.repository
+}
+
+// Pause 暂停记录
+func (t *Telescope) Pause() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recording = false
+}
+
+// Resume 恢复记录
+func (t *Telescope) Resume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recording = true
+}
+
+// IsRecording 是否正在记录
+func (t *Telescope) IsRecording() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.recording
+}
+
+// Filter 添加过滤器，任意过滤器返回 false 的记录不保存
+func (t *Telescope) Filter(filter func(entry Entry) bool) *Telescope {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.filters = append(t.filters, filter)
+	return t
+}
+
+// Tag 添加标签回调，返回的标签追加到记录上
+func (t *Telescope) Tag(tagger func(entry Entry) []string) *Telescope {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.taggers = append(t.taggers, tagger)
+	return t
+}
+
+// IgnorePaths 设置不记录的请求路径，支持 path.Match 通配符，以 /* 结尾时匹配所有子路径
+func (t *Telescope) IgnorePaths(patterns ...string) *Telescope {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ignorePaths = append(t.ignorePaths, patterns...)
+	return t
+}
+
+// HideRequestHeaders 设置需要隐藏的请求头，不区分大小写
+func (t *Telescope) HideRequestHeaders(names ...string) *Telescope {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, name := range names {
+		t.hiddenHeaders[strings.ToLower(name)] = true
+	}
+	return t
+}
+
+// HideRequestParameters 设置需要隐藏的请求参数
+func (t *Telescope) HideRequestParameters(names ...string) *Telescope {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, name := range names {
+		t.hiddenParams[name] = true
+	}
+	return t
+}
+
+// SlowQueryThreshold 设置慢查询阈值，耗时达到阈值的查询带有 slow 标签
+func (t *Telescope) SlowQueryThreshold(threshold time.Duration) *Telescope {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.slowQuery = threshold
+	return t
+}
+
+// ReportErrorsUsing 设置写入失败时的回调
+func (t *Telescope) ReportErrorsUsing(callback func(error)) *Telescope {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onError = callback
+	return t
+}
+
+// Record 记录一条内容
+//
+// 批次 ID 取自 ctx，ctx 中没有批次时记录单独成批。
+// ctx 中有请求 ID（appcontext.RequestIDMiddleware）时记录带有 "request_id:<ID>" 标签，
+// 用于按请求 ID 查找跨服务的记录。暂停记录或 ctx 由 WithoutRecording 标记时忽略。
+func (t *Telescope) Record(ctx context.Context, entryType string, content map[string]interface{}, tags ...string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !t.IsRecording() || !recordingEnabled(ctx) {
+		return
+	}
+	batchID := BatchID(ctx)
+	if batchID == "" {
+		batchID = str.OrderedUUID()
+	}
+	entry := Entry{
+		UUID:      str.OrderedUUID(),
+		BatchID:   batchID,
+		Type:      entryType,
+		Content:   content,
+		Tags:      append([]string{}, tags...),
+		CreatedAt: t.now(),
+	}
+	if id := appcontext.RequestID(ctx); id != "" {
+		entry.Tags = append(entry.Tags, "request_id:"+id)
+	}
+
+	t.mu.RLock()
+	filters, taggers, onError := t.filters, t.taggers, t.onError
+	t.mu.RUnlock()
+	for _, filter := range filters {
+		if !filter(entry) {
+			return
+		}
+	}
+	for _, tagger := range taggers {
+		for _, tag := range tagger(entry) {
+			if !entry.HasTag(tag) {
+				entry.Tags = append(entry.Tags, tag)
+			}
+		}
+	}
+
+	if err := t.repository.Store(WithoutRecording(ctx), []Entry{entry}); err != nil && onError != nil {
+		onError(err)
+	}
+}
+
+// ignored 请求路径是否被忽略
+func (t *Telescope) ignored(requestPath string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, pattern := range t.ignorePaths {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && (requestPath == prefix || strings.HasPrefix(requestPath, prefix+"/")) {
+			return true
+		}
+		if matched, _ := path.Match(pattern, requestPath); matched {
+			return true
+		}
+	}
+	return false
+}
+
+type batchKey struct{}
+
+type withoutRecordingKey struct{}
+
+// WithBatch 返回开始新批次的 ctx，之后使用该 ctx 记录的内容共享批次 ID
+func WithBatch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, batchKey{}, str.OrderedUUID())
+}
+
+// BatchID 获取 ctx 中的批次 ID，没有时返回空字符串
+func BatchID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(batchKey{}).(string)
+	return id
+}
+
+// WithoutRecording 返回不记录的 ctx，存储自身的查询使用它避免循环记录
+func WithoutRecording(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutRecordingKey{}, true)
+}
+
+// recordingEnabled ctx 是否允许记录
+func recordingEnabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(withoutRecordingKey{}).(bool)
+	return !disabled
+}
@@ -0,0 +1,620 @@
+package telescope
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cnote0/laraveldoc/application"
+	"github.com/cnote0/laraveldoc/cache"
+	"github.com/cnote0/laraveldoc/database"
+	"github.com/cnote0/laraveldoc/exceptions"
+	"github.com/cnote0/laraveldoc/facade"
+	"github.com/cnote0/laraveldoc/queue"
+)
+
+// sizeLimit 请求和响应正文最多记录的字节数
+const sizeLimit = 64 << 10
+
+// hiddenValue 隐藏内容的占位符
+const hiddenValue = "********"
+
+// Middleware 请求观察者
+//
+// 为请求开始新批次，请求处理中使用 r.Context() 记录的查询、缓存和事件都归入该批次。
+// 记录请求方法、地址、请求头、参数、响应状态、响应内容和耗时，正文超过 64KB 时截断为提示。
+func (t *Telescope) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !t.IsRecording() || t.ignored(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		start := t.now()
+		r = r.WithContext(WithBatch(r.Context()))
+		payload := t.requestPayload(r)
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		defer func() {
+			status := recorder.status
+			recovered := recover()
+			if recovered != nil && !recorder.wroteHeader {
+				status = http.StatusInternalServerError
+			}
+			t.Record(r.Context(), TypeRequest, map[string]interface{}{
+				"ip_address":       clientIP(r),
+				"uri":              r.URL.RequestURI(),
+				"method":           r.Method,
+				"headers":          t.requestHeaders(r.Header),
+				"payload":          payload,
+				"response_status":  status,
+				"response_headers": flattenHeaders(recorder.Header()),
+				"response":         responseContent(recorder),
+				"duration":         milliseconds(t.now().Sub(start)),
+			}, "status:"+fmt.Sprint(status))
+			if recovered != nil {
+				panic(recovered)
+			}
+		}()
+		next.ServeHTTP(recorder, r)
+	})
+}
+
+// requestHeaders 转换请求头并隐藏敏感内容
+func (t *Telescope) requestHeaders(header http.Header) map[string]string {
+	headers := flattenHeaders(header)
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for name := range headers {
+		if t.hiddenHeaders[name] {
+			headers[name] = hiddenValue
+		}
+	}
+	return headers
+}
+
+// requestPayload 读取查询参数和表单、JSON 正文，读取后恢复正文供后续处理器使用
+func (t *Telescope) requestPayload(r *http.Request) map[string]interface{} {
+	payload := make(map[string]interface{})
+	for key, values := range r.URL.Query() {
+		payload[key] = singleValue(values)
+	}
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(io.LimitReader(r.Body, sizeLimit+1))
+		r.Body = readCloser{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+		if err == nil && len(body) <= sizeLimit {
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			switch {
+			case mediaType == "application/x-www-form-urlencoded":
+				if form, err := url.ParseQuery(string(body)); err == nil {
+					for key, values := range form {
+						payload[key] = singleValue(values)
+					}
+				}
+			case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+				var fields map[string]interface{}
+				if json.Unmarshal(body, &fields) == nil {
+					for key, value := range fields {
+						payload[key] = value
+					}
+				}
+			}
+		}
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for key := range payload {
+		if t.hiddenParams[key] {
+			payload[key] = hiddenValue
+		}
+	}
+	return payload
+}
+
+// readCloser 组合读取器和原正文的 Close
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// responseRecorder 记录响应状态和正文
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	size        int
+}
+
+// WriteHeader 记录状态码
+func (w *responseRecorder) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status, w.wroteHeader = status, true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write 记录不超过限制的正文
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	if w.body.Len() <= sizeLimit {
+		w.body.Write(b[:min(len(b), sizeLimit+1-w.body.Len())])
+	}
+	w.size += len(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush 支持流式响应
+func (w *responseRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap 供 http.ResponseController 获取原始 ResponseWriter
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// responseContent 响应内容，JSON 解码后记录，文本原样记录，其他类型只记录说明
+func responseContent(w *responseRecorder) interface{} {
+	if w.size == 0 {
+		return "Empty Response"
+	}
+	if w.size > sizeLimit {
+		return "Purged By Telescope"
+	}
+	mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var decoded interface{}
+		if json.Unmarshal(w.body.Bytes(), &decoded) == nil {
+			return decoded
+		}
+	case mediaType == "text/html":
+		return "HTML Response"
+	case strings.HasPrefix(mediaType, "text/"):
+		return w.body.String()
+	}
+	if mediaType == "" {
+		return "Empty Response"
+	}
+	return mediaType + " Response"
+}
+
+// QueryWatcher 查询观察者，实现 database.LoggerInterface
+//
+// 通过 database.SessionConfig 的 Logger 安装，日志调用转发给 inner。
+type QueryWatcher struct {
+	telescope *Telescope
+	inner     database.LoggerInterface
+}
+
+var _ database.LoggerInterface = (*QueryWatcher)(nil)
+
+// QueryWatcher 创建查询观察者，inner 为 nil 时只记录查询
+func (t *Telescope) QueryWatcher(inner database.LoggerInterface) *QueryWatcher {
+	return &QueryWatcher{telescope: t, inner: inner}
+}
+
+// LogMode 设置内部日志的级别
+func (q *QueryWatcher) LogMode(level string) database.LoggerInterface {
+	if q.inner == nil {
+		return q
+	}
+	return &QueryWatcher{telescope: q.telescope, inner: q.inner.LogMode(level)}
+}
+
+// Info 转发信息日志
+func (q *QueryWatcher) Info(ctx context.Context, msg string, data ...interface{}) {
+	if q.inner != nil {
+		q.inner.Info(ctx, msg, data...)
+	}
+}
+
+// Warn 转发警告日志
+func (q *QueryWatcher) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if q.inner != nil {
+		q.inner.Warn(ctx, msg, data...)
+	}
+}
+
+// Error 转发错误日志
+func (q *QueryWatcher) Error(ctx context.Context, msg string, data ...interface{}) {
+	if q.inner != nil {
+		q.inner.Error(ctx, msg, data...)
+	}
+}
+
+// Trace 记录查询语句、影响行数和耗时，达到慢查询阈值时添加 slow 标签
+func (q *QueryWatcher) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if q.inner != nil {
+		q.inner.Trace(ctx, begin, fc, err)
+	}
+	if ctx == nil || !recordingEnabled(ctx) || !q.telescope.IsRecording() {
+		return
+	}
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	content := map[string]interface{}{
+		"sql":           sql,
+		"rows_affected": rows,
+		"time":          milliseconds(elapsed),
+	}
+	var tags []string
+	if err != nil {
+		content["error"] = err.Error()
+		tags = append(tags, "failed")
+	}
+	q.telescope.mu.RLock()
+	slow := q.telescope.slowQuery
+	q.telescope.mu.RUnlock()
+	if slow > 0 && elapsed >= slow {
+		content["slow"] = true
+		tags = append(tags, "slow")
+	}
+	q.telescope.Record(ctx, TypeQuery, content, tags...)
+}
+
+// WatchQueue 任务观察者，记录 Worker 处理完成和失败的任务
+//
+// 任务记录带有任务名称标签，Worker 内的记录不与派发任务的请求共享批次。
+func (t *Telescope) WatchQueue(worker queue.Worker) {
+	var mu sync.Mutex
+	started := make(map[string]time.Time)
+	begin := func(job queue.QueuedJob) {
+		mu.Lock()
+		defer mu.Unlock()
+		started[jobKey(job)] = t.now()
+	}
+	finish := func(job queue.QueuedJob) time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		key := jobKey(job)
+		start, ok := started[key]
+		delete(started, key)
+		if !ok {
+			return 0
+		}
+		return t.now().Sub(start)
+	}
+
+	worker.Listen(func(ctx context.Context, event interface{}) {
+		switch e := event.(type) {
+		case queue.JobProcessing:
+			begin(e.Job)
+		case queue.JobProcessed:
+			t.recordJob(ctx, e.Job, "processed", finish(e.Job), nil)
+		case queue.JobReleasedAfterException:
+			finish(e.Job)
+		case queue.JobFailed:
+			t.recordJob(ctx, e.Job, "failed", finish(e.Job), e.Err)
+		}
+	})
+}
+
+// recordJob 记录任务
+func (t *Telescope) recordJob(ctx context.Context, job queue.QueuedJob, status string, duration time.Duration, err error) {
+	content := map[string]interface{}{
+		"status":     status,
+		"name":       job.Name(),
+		"connection": job.ConnectionName(),
+		"queue":      job.Queue(),
+		"attempts":   job.Attempts(),
+		"tries":      job.MaxTries(),
+		"timeout":    job.Timeout().Seconds(),
+		"duration":   milliseconds(duration),
+	}
+	if payload := job.Payload(); payload != nil {
+		content["data"] = rawJSON(payload.Data)
+	}
+	tags := []string{job.Name()}
+	if err != nil {
+		content["exception"] = err.Error()
+		tags = append(tags, "failed")
+	}
+	t.Record(ctx, TypeJob, content, tags...)
+}
+
+// jobKey 任务在 Worker 内的唯一标识
+func jobKey(job queue.QueuedJob) string {
+	return job.ConnectionName() + "\x00" + job.Queue() + "\x00" + job.ID()
+}
+
+// cacheStore 缓存观察者
+type cacheStore struct {
+	cache.Store
+	telescope *Telescope
+}
+
+// CacheStore 缓存观察者，包装 store 并记录命中、未命中、写入和删除
+//
+// 返回的存储只实现 cache.Store，store 实现的锁和限流等扩展接口需要继续使用原存储。
+func (t *Telescope) CacheStore(store cache.Store) cache.Store {
+	return &cacheStore{Store: store, telescope: t}
+}
+
+// Get 记录命中或未命中
+func (s *cacheStore) Get(ctx context.Context, key string) (interface{}, bool, error) {
+	value, ok, err := s.Store.Get(ctx, key)
+	if err == nil {
+		if ok {
+			s.record(ctx, "hit", key, map[string]interface{}{"value": value})
+		} else {
+			s.record(ctx, "missed", key, nil)
+		}
+	}
+	return value, ok, err
+}
+
+// Put 记录写入
+func (s *cacheStore) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	err := s.Store.Put(ctx, key, value, ttl)
+	if err == nil {
+		s.record(ctx, "set", key, map[string]interface{}{"value": value, "expiration": ttl.Seconds()})
+	}
+	return err
+}
+
+// Forever 记录永久写入
+func (s *cacheStore) Forever(ctx context.Context, key string, value interface{}) error {
+	err := s.Store.Forever(ctx, key, value)
+	if err == nil {
+		s.record(ctx, "set", key, map[string]interface{}{"value": value})
+	}
+	return err
+}
+
+// Increment 记录自增
+func (s *cacheStore) Increment(ctx context.Context, key string, by int64) (int64, error) {
+	value, err := s.Store.Increment(ctx, key, by)
+	if err == nil {
+		s.record(ctx, "set", key, map[string]interface{}{"value": value})
+	}
+	return value, err
+}
+
+// Forget 记录删除
+func (s *cacheStore) Forget(ctx context.Context, key string) (bool, error) {
+	ok, err := s.Store.Forget(ctx, key)
+	if err == nil && ok {
+		s.record(ctx, "forget", key, nil)
+	}
+	return ok, err
+}
+
+// record 记录缓存操作
+func (s *cacheStore) record(ctx context.Context, operation, key string, extra map[string]interface{}) {
+	content := map[string]interface{}{"type": operation, "key": key}
+	for name, value := range extra {
+		content[name] = value
+	}
+	s.telescope.Record(ctx, TypeCache, content, operation)
+}
+
+// logger 日志观察者
+type logger struct {
+	inner     application.LoggerInterface
+	telescope *Telescope
+	context   map[string]interface{}
+}
+
+// Logger 日志观察者，包装 inner 并记录每条日志，inner 为 nil 时只记录
+//
+// 日志接口不携带 ctx，日志记录单独成批。
+func (t *Telescope) Logger(inner application.LoggerInterface) application.LoggerInterface {
+	return &logger{inner: inner, telescope: t}
+}
+
+// Emergency 记录紧急日志
+func (l *logger) Emergency(message string, context map[string]interface{}) error {
+	return l.Log("emergency", message, context)
+}
+
+// Alert 记录警报日志
+func (l *logger) Alert(message string, context map[string]interface{}) error {
+	return l.Log("alert", message, context)
+}
+
+// Critical 记录严重错误日志
+func (l *logger) Critical(message string, context map[string]interface{}) error {
+	return l.Log("critical", message, context)
+}
+
+// Error 记录错误日志
+func (l *logger) Error(message string, context map[string]interface{}) error {
+	return l.Log("error", message, context)
+}
+
+// Warning 记录警告日志
+func (l *logger) Warning(message string, context map[string]interface{}) error {
+	return l.Log("warning", message, context)
+}
+
+// Notice 记录通知日志
+func (l *logger) Notice(message string, context map[string]interface{}) error {
+	return l.Log("notice", message, context)
+}
+
+// Info 记录信息日志
+func (l *logger) Info(message string, context map[string]interface{}) error {
+	return l.Log("info", message, context)
+}
+
+// Debug 记录调试日志
+func (l *logger) Debug(message string, context map[string]interface{}) error {
+	return l.Log("debug", message, context)
+}
+
+// Log 记录日志并转发给内部日志
+func (l *logger) Log(level string, message string, fields map[string]interface{}) error {
+	merged := make(map[string]interface{}, len(l.context)+len(fields))
+	for key, value := range l.context {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	l.telescope.Record(context.Background(), TypeLog, map[string]interface{}{
+		"level":   level,
+		"message": message,
+		"context": merged,
+	}, level)
+	if l.inner == nil {
+		return nil
+	}
+	return l.inner.Log(level, message, fields)
+}
+
+// WithContext 创建带上下文的日志观察者
+func (l *logger) WithContext(context map[string]interface{}) application.LoggerInterface {
+	merged := make(map[string]interface{}, len(l.context)+len(context))
+	for key, value := range l.context {
+		merged[key] = value
+	}
+	for key, value := range context {
+		merged[key] = value
+	}
+	child := &logger{telescope: l.telescope, context: merged}
+	if l.inner != nil {
+		child.inner = l.inner.WithContext(context)
+	}
+	return child
+}
+
+// eventDispatcher 事件观察者
+type eventDispatcher struct {
+	application.EventDispatcher
+	telescope *Telescope
+}
+
+// EventDispatcher 事件观察者，包装 dispatcher 并记录派发的事件、载荷和监听器数量
+func (t *Telescope) EventDispatcher(dispatcher application.EventDispatcher) application.EventDispatcher {
+	return &eventDispatcher{EventDispatcher: dispatcher, telescope: t}
+}
+
+// Dispatch 记录并派发事件
+func (d *eventDispatcher) Dispatch(event interface{}, eventName string) interface{} {
+	d.record(context.Background(), event, eventName)
+	return d.EventDispatcher.Dispatch(event, eventName)
+}
+
+// DispatchWithContext 记录并派发事件，记录归入 ctx 的批次
+func (d *eventDispatcher) DispatchWithContext(ctx context.Context, event interface{}, eventName string) interface{} {
+	d.record(ctx, event, eventName)
+	return d.EventDispatcher.DispatchWithContext(ctx, event, eventName)
+}
+
+// record 记录事件
+func (d *eventDispatcher) record(ctx context.Context, event interface{}, eventName string) {
+	name := eventName
+	if name == "" && event != nil {
+		name = reflect.TypeOf(event).String()
+	}
+	d.telescope.Record(ctx, TypeEvent, map[string]interface{}{
+		"name":      name,
+		"payload":   marshalValue(event),
+		"listeners": len(d.GetListeners(name)),
+	}, name)
+}
+
+// Reporter 错误观察者，作为 exceptions.Handler 的上报器安装
+func (t *Telescope) Reporter() exceptions.Reporter {
+	return exceptions.ReporterFunc(func(ctx context.Context, err error, context map[string]interface{}) error {
+		content := map[string]interface{}{
+			"class":   exceptions.ErrorType(err),
+			"message": err.Error(),
+			"context": context,
+		}
+		if frames := exceptions.Frames(err); len(frames) > 0 {
+			content["file"], content["line"] = frames[0].File, frames[0].Line
+			trace := make([]string, 0, len(frames))
+			for _, frame := range frames {
+				trace = append(trace, fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function))
+			}
+			content["trace"] = trace
+		}
+		t.Record(ctx, TypeException, content, exceptions.ErrorType(err))
+		return nil
+	})
+}
+
+// flattenHeaders 把多值头合并为逗号分隔的字符串，名称转为小写
+func flattenHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		headers[strings.ToLower(name)] = strings.Join(values, ", ")
+	}
+	return headers
+}
+
+// singleValue 单值参数记录为字符串，多值参数记录为数组
+func singleValue(values []string) interface{} {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return values
+}
+
+// FacadeObserver 门面调用观察者，记录门面访问器、方法、耗时和错误
+//
+// 调用通过 CallMethodWithContext 发起时记录归入 ctx 的批次。
+func (t *Telescope) FacadeObserver() facade.CallObserver {
+	return func(accessor string, record facade.CallRecord) {
+		ctx := record.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		content := map[string]interface{}{
+			"facade":   accessor,
+			"method":   record.Method,
+			"duration": milliseconds(record.Duration),
+		}
+		tags := []string{accessor}
+		if record.Error != nil {
+			content["exception"] = record.Error.Error()
+			tags = append(tags, "failed")
+		}
+		t.Record(ctx, TypeFacade, content, tags...)
+	}
+}
+
+// clientIP 客户端 IP，不信任代理头
+func clientIP(r *http.Request) string {
+	host := r.RemoteAddr
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	return strings.Trim(host, "[]")
+}
+
+// marshalValue 把值转换为可编码为 JSON 的形式，无法编码时记录为字符串
+func marshalValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%+v", value)
+	}
+	return json.RawMessage(data)
+}
+
+// rawJSON 原样记录合法的 JSON，否则记录为字符串
+func rawJSON(data []byte) interface{} {
+	if json.Valid(data) {
+		return json.RawMessage(data)
+	}
+	return string(data)
+}
+
+// milliseconds 毫秒数，保留两位小数
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()/10) / 100
+}
@@ -0,0 +1,45 @@
+package tinker
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/application"
+)
+
+// RegisterCommand 注册 tinker 命令
+//
+// --execute 求值给定的代码并退出，否则从标准输入读取，直到 exit、输入结束或 Ctrl+C。
+func RegisterCommand(artisan application.ArtisanInterface, shell *Shell) {
+	artisan.Register("tinker").
+		SetDescription("Interact with your application").
+		AddOption("execute", "", application.InputOptionValueRequired, "Execute the given code", "").
+		SetCode(func(input application.InputInterface, output application.OutputInterface) error {
+			out := outputWriter{output: output}
+			if code, _ := input.GetOption("execute").(string); strings.TrimSpace(code) != "" {
+				return shell.Execute(code, out)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			if err := output.WriteLine("Go tinker session. Type ls to list variables, exit to quit.", application.VerbosityNormal); err != nil {
+				return err
+			}
+			return shell.Run(ctx, os.Stdin, out)
+		})
+}
+
+// outputWriter 把 OutputInterface 适配为 io.Writer
+type outputWriter struct {
+	output application.OutputInterface
+}
+
+// Write 实现 io.Writer
+func (w outputWriter) Write(p []byte) (int, error) {
+	if err := w.output.Write([]string{string(p)}, false, application.VerbosityNormal); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
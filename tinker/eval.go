@@ -0,0 +1,664 @@
+package tinker
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// evalSource 解析并执行语句，返回最后一条表达式语句的结果
+func (s *Shell) evalSource(code string) ([]reflect.Value, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package tinker\nfunc _() {\n"+code+"\n}", 0)
+	if err != nil {
+		return nil, fmt.Errorf("syntax error: %s", stripPosition(err.Error()))
+	}
+	var results []reflect.Value
+	for _, stmt := range file.Decls[0].(*ast.FuncDecl).Body.List {
+		results = nil
+		switch stmt := stmt.(type) {
+		case *ast.ExprStmt:
+			if results, err = s.evalMulti(stmt.X); err != nil {
+				return nil, err
+			}
+			// 与 Laravel Tinker 一致，调用成功时不输出末尾的 nil error
+			if n := len(results); n > 1 && results[n-1].Type() == errorType {
+				results = results[:n-1]
+			}
+		case *ast.AssignStmt:
+			if err := s.assign(stmt); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported statement %T", stmt)
+		}
+	}
+	return results, nil
+}
+
+// assign 短变量声明和赋值
+func (s *Shell) assign(stmt *ast.AssignStmt) error {
+	if stmt.Tok != token.DEFINE && stmt.Tok != token.ASSIGN {
+		return fmt.Errorf("unsupported assignment %s", stmt.Tok)
+	}
+	var values []reflect.Value
+	if len(stmt.Rhs) == 1 && len(stmt.Lhs) > 1 {
+		multi, err := s.evalMulti(stmt.Rhs[0])
+		if err != nil && !isReturned(err) {
+			return err
+		}
+		values = multi
+	} else {
+		for _, expr := range stmt.Rhs {
+			v, err := s.eval(expr)
+			if err != nil {
+				return err
+			}
+			values = append(values, v)
+		}
+	}
+	if len(values) != len(stmt.Lhs) {
+		return fmt.Errorf("assignment mismatch: %d variables but %d values", len(stmt.Lhs), len(values))
+	}
+	for i, lhs := range stmt.Lhs {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("cannot assign to %s", exprString(lhs))
+		}
+		if ident.Name == "_" {
+			continue
+		}
+		if _, exists := s.vars[ident.Name]; !exists && stmt.Tok == token.ASSIGN {
+			return fmt.Errorf("undefined: %s", ident.Name)
+		}
+		value := values[i]
+		if !value.IsValid() {
+			value = reflect.Zero(reflect.TypeOf((*interface{})(nil)).Elem())
+		}
+		v := reflect.New(value.Type()).Elem()
+		v.Set(value)
+		s.vars[ident.Name] = v
+	}
+	return nil
+}
+
+// returnedError 调用的最后一个返回值为非 nil 的 error
+//
+// 表达式语句输出该错误；赋值时错误值照常赋给变量，不中断求值。
+type returnedError struct {
+	err error
+}
+
+// Error 实现 error 接口
+func (e *returnedError) Error() string {
+	return e.err.Error()
+}
+
+// isReturned 是否为调用返回的 error
+func isReturned(err error) bool {
+	var returned *returnedError
+	return errors.As(err, &returned)
+}
+
+// evalMulti 求值可能返回多个值的表达式
+//
+// 函数调用的最后一个返回值为非 nil 的 error 时，表达式语句输出该错误。
+func (s *Shell) evalMulti(expr ast.Expr) ([]reflect.Value, error) {
+	if call, ok := unparen(expr).(*ast.CallExpr); ok {
+		return s.call(call)
+	}
+	v, err := s.eval(expr)
+	if err != nil {
+		return nil, err
+	}
+	return []reflect.Value{v}, nil
+}
+
+// eval 求值单值表达式
+func (s *Shell) eval(expr ast.Expr) (reflect.Value, error) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		return literal(expr)
+	case *ast.Ident:
+		return s.ident(expr.Name)
+	case *ast.ParenExpr:
+		return s.eval(expr.X)
+	case *ast.SelectorExpr:
+		return s.selector(expr)
+	case *ast.CallExpr:
+		results, err := s.call(expr)
+		if err != nil && !isReturned(err) {
+			return reflect.Value{}, err
+		}
+		if len(results) != 1 {
+			return reflect.Value{}, fmt.Errorf("%s returns %d values, expected 1", exprString(expr.Fun), len(results))
+		}
+		return results[0], nil
+	case *ast.IndexExpr:
+		return s.index(expr)
+	case *ast.UnaryExpr:
+		return s.unary(expr)
+	case *ast.BinaryExpr:
+		return s.binary(expr)
+	case *ast.CompositeLit:
+		return s.composite(expr)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported expression %s", exprString(expr))
+	}
+}
+
+// literal 字面量，整数为 int，浮点数为 float64
+func literal(lit *ast.BasicLit) (reflect.Value, error) {
+	switch lit.Kind {
+	case token.INT:
+		n, err := strconv.ParseInt(lit.Value, 0, 64)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(int(n)), nil
+	case token.FLOAT:
+		f, err := strconv.ParseFloat(lit.Value, 64)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(f), nil
+	case token.STRING:
+		str, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(str), nil
+	case token.CHAR:
+		r, _, _, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\'')
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(r), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported literal %s", lit.Value)
+	}
+}
+
+// ident 变量和预定义标识符
+func (s *Shell) ident(name string) (reflect.Value, error) {
+	if v, ok := s.vars[name]; ok {
+		return v, nil
+	}
+	switch name {
+	case "nil":
+		return reflect.Value{}, nil
+	case "true":
+		return reflect.ValueOf(true), nil
+	case "false":
+		return reflect.ValueOf(false), nil
+	}
+	return reflect.Value{}, fmt.Errorf("undefined: %s", name)
+}
+
+// selector 字段或方法
+func (s *Shell) selector(expr *ast.SelectorExpr) (reflect.Value, error) {
+	x, err := s.eval(expr.X)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	name := expr.Sel.Name
+	x = unwrap(x)
+	if !x.IsValid() {
+		return reflect.Value{}, fmt.Errorf("%s is nil", exprString(expr.X))
+	}
+
+	if method := x.MethodByName(name); method.IsValid() {
+		return method, nil
+	}
+	if x.Kind() != reflect.Pointer && !x.CanAddr() {
+		addressable := reflect.New(x.Type())
+		addressable.Elem().Set(x)
+		x = addressable.Elem()
+	}
+	if x.CanAddr() {
+		if method := x.Addr().MethodByName(name); method.IsValid() {
+			return method, nil
+		}
+	}
+
+	for x.Kind() == reflect.Pointer {
+		if x.IsNil() {
+			return reflect.Value{}, fmt.Errorf("%s is nil", exprString(expr.X))
+		}
+		x = x.Elem()
+	}
+	if x.Kind() == reflect.Struct {
+		if field, ok := x.Type().FieldByName(name); ok && field.IsExported() {
+			return x.FieldByIndex(field.Index), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("%s has no exported field or method %s", x.Type(), name)
+}
+
+// call 函数和方法调用，以及内置的 len 和 new
+func (s *Shell) call(expr *ast.CallExpr) ([]reflect.Value, error) {
+	if ident, ok := expr.Fun.(*ast.Ident); ok {
+		if _, defined := s.vars[ident.Name]; !defined {
+			switch ident.Name {
+			case "len":
+				return s.builtinLen(expr)
+			case "new":
+				return s.builtinNew(expr)
+			}
+		}
+	}
+
+	fn, err := s.eval(expr.Fun)
+	if err != nil {
+		return nil, err
+	}
+	fn = unwrap(fn)
+	if !fn.IsValid() || fn.Kind() != reflect.Func {
+		return nil, fmt.Errorf("cannot call non-function %s", exprString(expr.Fun))
+	}
+	if fn.IsNil() {
+		return nil, fmt.Errorf("%s is nil", exprString(expr.Fun))
+	}
+
+	t := fn.Type()
+	if expr.Ellipsis.IsValid() {
+		return nil, errors.New("spread arguments are not supported")
+	}
+	if len(expr.Args) < t.NumIn()-1 || (!t.IsVariadic() && len(expr.Args) != t.NumIn()) {
+		return nil, fmt.Errorf("wrong argument count calling %s: have %d, want %d", exprString(expr.Fun), len(expr.Args), t.NumIn())
+	}
+	args := make([]reflect.Value, len(expr.Args))
+	for i, arg := range expr.Args {
+		v, err := s.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		in := t.In(min(i, t.NumIn()-1))
+		if t.IsVariadic() && i >= t.NumIn()-1 {
+			in = in.Elem()
+		}
+		if args[i], err = convert(v, in); err != nil {
+			return nil, fmt.Errorf("argument %d of %s: %w", i+1, exprString(expr.Fun), err)
+		}
+	}
+
+	results := fn.Call(args)
+	if n := len(results); n > 0 && t.Out(n-1) == errorType && !results[n-1].IsNil() {
+		return results, &returnedError{err: results[n-1].Interface().(error)}
+	}
+	return results, nil
+}
+
+// builtinLen 内置函数 len
+func (s *Shell) builtinLen(expr *ast.CallExpr) ([]reflect.Value, error) {
+	if len(expr.Args) != 1 {
+		return nil, errors.New("len expects 1 argument")
+	}
+	v, err := s.eval(expr.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	v = unwrap(v)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return []reflect.Value{reflect.ValueOf(v.Len())}, nil
+	default:
+		return nil, fmt.Errorf("invalid argument for len: %s", exprString(expr.Args[0]))
+	}
+}
+
+// builtinNew 内置函数 new，只支持已注册的类型
+func (s *Shell) builtinNew(expr *ast.CallExpr) ([]reflect.Value, error) {
+	if len(expr.Args) != 1 {
+		return nil, errors.New("new expects 1 argument")
+	}
+	t, err := s.typeOf(expr.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	return []reflect.Value{reflect.New(t)}, nil
+}
+
+// index 索引
+func (s *Shell) index(expr *ast.IndexExpr) (reflect.Value, error) {
+	x, err := s.eval(expr.X)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	key, err := s.eval(expr.Index)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	x = unwrap(x)
+	if x.Kind() == reflect.Pointer && x.Type().Elem().Kind() == reflect.Array {
+		x = x.Elem()
+	}
+	switch x.Kind() {
+	case reflect.Map:
+		k, err := convert(key, x.Type().Key())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if v := x.MapIndex(k); v.IsValid() {
+			return v, nil
+		}
+		return reflect.Zero(x.Type().Elem()), nil
+	case reflect.Slice, reflect.Array, reflect.String:
+		key = unwrap(key)
+		if !key.IsValid() || !key.CanInt() {
+			return reflect.Value{}, fmt.Errorf("invalid index %s", exprString(expr.Index))
+		}
+		i := int(key.Int())
+		if i < 0 || i >= x.Len() {
+			return reflect.Value{}, fmt.Errorf("index out of range [%d] with length %d", i, x.Len())
+		}
+		return x.Index(i), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("cannot index %s", exprString(expr.X))
+	}
+}
+
+// unary 一元运算
+func (s *Shell) unary(expr *ast.UnaryExpr) (reflect.Value, error) {
+	x, err := s.eval(expr.X)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if expr.Op == token.AND {
+		if !x.IsValid() {
+			return reflect.Value{}, errors.New("cannot take the address of nil")
+		}
+		if !x.CanAddr() {
+			addressable := reflect.New(x.Type())
+			addressable.Elem().Set(x)
+			return addressable, nil
+		}
+		return x.Addr(), nil
+	}
+
+	x = unwrap(x)
+	switch {
+	case expr.Op == token.NOT && x.Kind() == reflect.Bool:
+		return reflect.ValueOf(!x.Bool()).Convert(x.Type()), nil
+	case expr.Op == token.SUB && x.CanInt():
+		return reflect.ValueOf(-x.Int()).Convert(x.Type()), nil
+	case expr.Op == token.SUB && x.CanFloat():
+		return reflect.ValueOf(-x.Float()).Convert(x.Type()), nil
+	case expr.Op == token.ADD && (x.CanInt() || x.CanFloat()):
+		return x, nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported operation %s%s", expr.Op, exprString(expr.X))
+	}
+}
+
+// binary 二元运算
+func (s *Shell) binary(expr *ast.BinaryExpr) (reflect.Value, error) {
+	x, err := s.eval(expr.X)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	x = unwrap(x)
+	if expr.Op == token.LAND || expr.Op == token.LOR {
+		if x.Kind() != reflect.Bool {
+			return reflect.Value{}, fmt.Errorf("non-boolean %s used with %s", exprString(expr.X), expr.Op)
+		}
+		if (expr.Op == token.LAND) != x.Bool() {
+			return reflect.ValueOf(x.Bool()), nil
+		}
+		y, err := s.eval(expr.Y)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if y = unwrap(y); y.Kind() != reflect.Bool {
+			return reflect.Value{}, fmt.Errorf("non-boolean %s used with %s", exprString(expr.Y), expr.Op)
+		}
+		return reflect.ValueOf(y.Bool()), nil
+	}
+
+	y, err := s.eval(expr.Y)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	y = unwrap(y)
+
+	switch {
+	case x.IsValid() && y.IsValid() && x.Kind() == reflect.String && y.Kind() == reflect.String:
+		return stringOp(expr.Op, x.String(), y.String())
+	case x.IsValid() && y.IsValid() && (x.CanInt() || x.CanFloat()) && (y.CanInt() || y.CanFloat()):
+		return numberOp(expr.Op, x, y)
+	case expr.Op == token.EQL || expr.Op == token.NEQ:
+		equal, err := equals(x, y)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(equal == (expr.Op == token.EQL)), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported operation %s", exprString(expr))
+	}
+}
+
+// stringOp 字符串拼接和比较
+func stringOp(op token.Token, x, y string) (reflect.Value, error) {
+	switch op {
+	case token.ADD:
+		return reflect.ValueOf(x + y), nil
+	case token.EQL:
+		return reflect.ValueOf(x == y), nil
+	case token.NEQ:
+		return reflect.ValueOf(x != y), nil
+	case token.LSS:
+		return reflect.ValueOf(x < y), nil
+	case token.LEQ:
+		return reflect.ValueOf(x <= y), nil
+	case token.GTR:
+		return reflect.ValueOf(x > y), nil
+	case token.GEQ:
+		return reflect.ValueOf(x >= y), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("operator %s not defined on strings", op)
+	}
+}
+
+// numberOp 数值运算，任一操作数为浮点数时按 float64 计算，否则按 int64 计算并保留左操作数的类型
+func numberOp(op token.Token, x, y reflect.Value) (reflect.Value, error) {
+	if x.CanFloat() || y.CanFloat() {
+		a, b := toFloat(x), toFloat(y)
+		switch op {
+		case token.ADD:
+			return reflect.ValueOf(a + b), nil
+		case token.SUB:
+			return reflect.ValueOf(a - b), nil
+		case token.MUL:
+			return reflect.ValueOf(a * b), nil
+		case token.QUO:
+			return reflect.ValueOf(a / b), nil
+		}
+		return compare(op, a, b)
+	}
+
+	a, b := x.Int(), y.Int()
+	var result int64
+	switch op {
+	case token.ADD:
+		result = a + b
+	case token.SUB:
+		result = a - b
+	case token.MUL:
+		result = a * b
+	case token.QUO, token.REM:
+		if b == 0 {
+			return reflect.Value{}, errors.New("integer divide by zero")
+		}
+		if op == token.QUO {
+			result = a / b
+		} else {
+			result = a % b
+		}
+	default:
+		return compare(op, a, b)
+	}
+	return reflect.ValueOf(result).Convert(x.Type()), nil
+}
+
+// compare 数值比较
+func compare[T int64 | float64](op token.Token, a, b T) (reflect.Value, error) {
+	switch op {
+	case token.EQL:
+		return reflect.ValueOf(a == b), nil
+	case token.NEQ:
+		return reflect.ValueOf(a != b), nil
+	case token.LSS:
+		return reflect.ValueOf(a < b), nil
+	case token.LEQ:
+		return reflect.ValueOf(a <= b), nil
+	case token.GTR:
+		return reflect.ValueOf(a > b), nil
+	case token.GEQ:
+		return reflect.ValueOf(a >= b), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported operator %s", op)
+	}
+}
+
+// toFloat 数值转为 float64
+func toFloat(v reflect.Value) float64 {
+	if v.CanFloat() {
+		return v.Float()
+	}
+	return float64(v.Int())
+}
+
+// equals 比较任意两个值，nil 与可为 nil 的值比较
+func equals(x, y reflect.Value) (bool, error) {
+	switch {
+	case !x.IsValid() && !y.IsValid():
+		return true, nil
+	case !x.IsValid():
+		return isNil(y), nil
+	case !y.IsValid():
+		return isNil(x), nil
+	case !x.Type().Comparable() || !y.Type().Comparable():
+		return false, fmt.Errorf("cannot compare %s and %s", x.Type(), y.Type())
+	default:
+		return x.Interface() == y.Interface(), nil
+	}
+}
+
+// isNil 值是否为 nil
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// composite 已注册类型的复合字面量，只支持结构体的键值形式
+func (s *Shell) composite(expr *ast.CompositeLit) (reflect.Value, error) {
+	t, err := s.typeOf(expr.Type)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if t.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("composite literals are only supported for structs, not %s", t)
+	}
+	v := reflect.New(t).Elem()
+	for _, elt := range expr.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%s literal must use field names", t)
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("invalid field name %s", exprString(kv.Key))
+		}
+		field, ok := t.FieldByName(key.Name)
+		if !ok || !field.IsExported() {
+			return reflect.Value{}, fmt.Errorf("%s has no exported field %s", t, key.Name)
+		}
+		value, err := s.eval(kv.Value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		converted, err := convert(value, field.Type)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("field %s: %w", key.Name, err)
+		}
+		v.FieldByIndex(field.Index).Set(converted)
+	}
+	return v, nil
+}
+
+// typeOf 已注册的类型
+func (s *Shell) typeOf(expr ast.Expr) (reflect.Type, error) {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %s", exprString(expr))
+	}
+	t, ok := s.types[ident.Name]
+	if !ok {
+		return nil, fmt.Errorf("undefined type %s, register it with Shell.Type", ident.Name)
+	}
+	return t, nil
+}
+
+// convert 把值转换为参数类型，数值和字符串之间按 Go 的转换规则处理
+func convert(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if !v.IsValid() {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+			return reflect.Zero(t), nil
+		}
+		return reflect.Value{}, fmt.Errorf("cannot use nil as %s", t)
+	}
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if inner := unwrap(v); inner.IsValid() && inner.Type().AssignableTo(t) {
+		return inner, nil
+	}
+	numeric := func(k reflect.Kind) bool { return k >= reflect.Int && k <= reflect.Float64 }
+	if (numeric(v.Kind()) && numeric(t.Kind())) || (v.Kind() == reflect.String && t.Kind() == reflect.String) {
+		return v.Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %s as %s", v.Type(), t)
+}
+
+// unwrap 取出接口中的具体值
+func unwrap(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
+
+// unparen 去掉括号
+func unparen(expr ast.Expr) ast.Expr {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.X
+	}
+}
+
+// exprString 表达式的源码形式，用于错误信息
+func exprString(expr ast.Expr) string {
+	return types.ExprString(expr)
+}
+
+// stripPosition 去掉解析错误中的位置前缀
+func stripPosition(message string) string {
+	if i := strings.Index(message, ": "); i >= 0 && strings.Count(message[:i], ":") >= 1 {
+		return message[i+2:]
+	}
+	return message
+}
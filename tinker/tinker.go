@@ -0,0 +1,222 @@
+// Package tinker 提供 Laravel Tinker 风格的交互式命令行
+//
+// tinker 命令启动应用后进入交互式会话，可以解析容器中的服务、调用门面和模型的方法，
+// 用于在预发布环境中快速查看数据和调用服务。
+//
+// Go 没有标准库内置的解释器，而本模块不引入第三方依赖，因此没有使用 yaegi：
+// 会话不执行完整的 Go 代码，而是使用 go/parser 解析每一行，再通过反射对表达式求值。
+// 不支持函数字面量、控制流语句和导入包，需要时应用可以自行接入 yaegi。支持的语法：
+// - 字面量、已注册的变量、nil、true、false
+// - 字段访问、方法和函数调用（包括可变参数），多返回值中最后一个非 nil 的 error 作为错误输出
+// - 索引（map、切片、数组、字符串）、一元运算（-、!、&）、算术、比较和逻辑运算
+// - 已注册类型的复合字面量 User{Name: "Taylor"} 和 new(User)，以及 len
+// - 短变量声明和赋值 user := ...、user, err := ...、x = ...
+//
+// 会话命令：ls 列出变量和类型，exit 或 quit 退出。
+//
+// 包结构：
+// - tinker.go - Shell 会话
+// - eval.go - 表达式求值
+// - command.go - tinker 命令
+//
+// 使用示例：
+//
+//	shell := tinker.New(app)
+//	shell.Set("db", db)
+//	shell.Set("Cache", cacheManager)
+//	shell.Type("User", models.User{})
+//	tinker.RegisterCommand(artisan, shell)
+//
+//	// go run ./cmd/artisan tinker
+//	// > user := User{Name: "Taylor", Email: "taylor@example.com"}
+//	// > db.WithContext(ctx).Create(&user).Error()
+//	// = nil
+//	// > app.Make("cache")
+//	// = &{...}
+package tinker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cnote0/laraveldoc/container"
+)
+
+// prompt 输入提示符
+const prompt = "> "
+
+// Shell 交互式会话
+//
+// 同一个 Shell 不能在多个 goroutine 中同时使用。
+type Shell struct {
+	vars  map[string]reflect.Value
+	types map[string]reflect.Type
+}
+
+// New 创建会话，预置变量 app（容器）和 ctx（context.Background()）
+func New(app container.Container) *Shell {
+	s := &Shell{vars: make(map[string]reflect.Value), types: make(map[string]reflect.Type)}
+	if app != nil {
+		s.Set("app", app)
+	}
+	s.Set("ctx", context.Background())
+	return s
+}
+
+// Set 注册变量，例如数据库连接、门面或服务实例
+func (s *Shell) Set(name string, value interface{}) *Shell {
+	v := reflect.New(reflect.TypeOf(&value).Elem()).Elem()
+	if value != nil {
+		v = reflect.New(reflect.TypeOf(value)).Elem()
+		v.Set(reflect.ValueOf(value))
+	}
+	s.vars[name] = v
+	return s
+}
+
+// Get 读取变量
+func (s *Shell) Get(name string) (interface{}, bool) {
+	v, ok := s.vars[name]
+	if !ok {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// Type 注册类型，sample 为该类型的值（通常是零值），用于复合字面量和 new
+func (s *Shell) Type(name string, sample interface{}) *Shell {
+	t := reflect.TypeOf(sample)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	s.types[name] = t
+	return s
+}
+
+// Run 从 in 逐行读取并求值，结果写入 out，直到输入结束、exit 或 ctx 取消
+func (s *Shell) Run(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		if _, err := io.WriteString(out, prompt); err != nil {
+			return err
+		}
+		if !scanner.Scan() {
+			_, err := io.WriteString(out, "\n")
+			if scanErr := scanner.Err(); scanErr != nil {
+				return scanErr
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		switch line {
+		case "":
+			continue
+		case "exit", "quit":
+			return nil
+		case "ls":
+			if _, err := io.WriteString(out, s.listing()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.print(out, line); err != nil {
+			return err
+		}
+	}
+}
+
+// Execute 求值一段代码并输出结果，多条语句用换行或分号分隔，对应 tinker --execute
+func (s *Shell) Execute(code string, out io.Writer) error {
+	results, err := s.Eval(code)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if _, err := fmt.Fprintln(out, "= "+format(result)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Eval 求值一行代码，返回最后一个表达式语句的结果；赋值语句没有结果
+//
+// 被调用的方法或反射求值发生 panic（例如 nil 指针、索引越界）时返回错误，会话继续运行。
+func (s *Shell) Eval(code string) (results []interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			results, err = nil, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	values, err := s.evalSource(code)
+	if err != nil {
+		return nil, err
+	}
+	results = make([]interface{}, len(values))
+	for i, v := range values {
+		if v.IsValid() && v.CanInterface() {
+			results[i] = v.Interface()
+		}
+	}
+	return results, nil
+}
+
+// print 求值并输出结果或错误
+func (s *Shell) print(out io.Writer, line string) error {
+	results, err := s.Eval(line)
+	if err != nil {
+		_, writeErr := fmt.Fprintln(out, "error: "+err.Error())
+		return writeErr
+	}
+	for _, result := range results {
+		if _, err := fmt.Fprintln(out, "= "+format(result)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listing 列出变量和类型
+func (s *Shell) listing() string {
+	var b strings.Builder
+	names := make([]string, 0, len(s.vars))
+	for name := range s.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s %s\n", name, s.vars[name].Type())
+	}
+	names = names[:0]
+	for name := range s.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "type %s %s\n", name, s.types[name])
+	}
+	return b.String()
+}
+
+// format 输出格式：字符串加引号，error 输出错误信息，其他值使用 %+v
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case error:
+		return "error(" + fmt.Sprintf("%q", v.Error()) + ")"
+	default:
+		return fmt.Sprintf("%+v", v)
+	}
+}
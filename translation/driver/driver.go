@@ -0,0 +1,20 @@
+// Package driver 提供 translation 包协议的参考实现
+//
+// FileLoader 从 fs.FS 读取 JSON 格式的语言文件：分组翻译位于 "{locale}/{group}.json"，
+// 键可以嵌套，加载后以点号展开；原文作为键的翻译位于 "{locale}.json"。
+// 命名空间（扩展包）的翻译通过 AddNamespace 注册，应用可以在 "vendor/{namespace}/{locale}/{group}.json" 中覆盖。
+//
+// 包结构：
+// - loader.go - FileLoader 文件加载器
+// - translator.go - Translator 实现
+// - selector.go - 复数形式选择和各语言的复数规则
+//
+// 目录示例：
+//
+//	lang/
+//	├── en.json                 # {"I love programming.": "I love programming."}
+//	├── zh_CN.json              # {"I love programming.": "我喜欢编程。"}
+//	├── en/messages.json        # {"welcome": "Welcome, :name", "apples": "{0} no apples|{1} one apple|[2,*] :count apples"}
+//	├── en/validation.json      # {"required": "The :attribute field is required.", "attributes": {"email": "email address"}}
+//	└── vendor/courier/en/messages.json
+package driver
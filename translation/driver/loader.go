@@ -0,0 +1,127 @@
+package driver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/cnote0/laraveldoc/translation"
+)
+
+// FileLoader 基于 fs.FS 的 JSON 语言文件加载器，对应 Laravel 的 FileLoader
+type FileLoader struct {
+	mu         sync.RWMutex
+	fsys       fs.FS
+	namespaces map[string]fs.FS
+	jsonPaths  []fs.FS
+}
+
+var _ translation.Loader = (*FileLoader)(nil)
+
+// NewFileLoader 创建加载器，fsys 为语言文件根目录
+func NewFileLoader(fsys fs.FS) *FileLoader {
+	return &FileLoader{fsys: fsys, namespaces: make(map[string]fs.FS)}
+}
+
+// AddJSONPath 添加额外的 JSON 翻译目录，后添加的优先级更高，应用目录始终优先
+func (l *FileLoader) AddJSONPath(fsys fs.FS) *FileLoader {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.jsonPaths = append(l.jsonPaths, fsys)
+	return l
+}
+
+// AddNamespace 注册命名空间，hint 必须是 fs.FS
+func (l *FileLoader) AddNamespace(namespace string, hint interface{}) {
+	fsys, ok := hint.(fs.FS)
+	if !ok {
+		panic(fmt.Sprintf("translation: namespace hint must be fs.FS, got %T", hint))
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.namespaces[namespace] = fsys
+}
+
+// Namespaces 已注册的命名空间
+func (l *FileLoader) Namespaces() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	names := make([]string, 0, len(l.namespaces))
+	for name := range l.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load 加载翻译，文件不存在时返回空表
+func (l *FileLoader) Load(locale, group, namespace string) (map[string]string, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	lines := make(map[string]string)
+	switch {
+	case group == "*" && namespace == "*":
+		for _, fsys := range l.jsonPaths {
+			if err := loadJSON(fsys, locale+".json", lines, false); err != nil {
+				return nil, err
+			}
+		}
+		return lines, loadJSON(l.fsys, locale+".json", lines, false)
+	case namespace == "" || namespace == "*":
+		return lines, loadJSON(l.fsys, path.Join(locale, group+".json"), lines, true)
+	default:
+		fsys, ok := l.namespaces[namespace]
+		if !ok {
+			return lines, nil
+		}
+		if err := loadJSON(fsys, path.Join(locale, group+".json"), lines, true); err != nil {
+			return nil, err
+		}
+		// 应用目录中的 vendor 文件覆盖扩展包的翻译
+		return lines, loadJSON(l.fsys, path.Join("vendor", namespace, locale, group+".json"), lines, true)
+	}
+}
+
+// loadJSON 读取 JSON 文件合并到 lines，nested 为 true 时展开嵌套对象
+func loadJSON(fsys fs.FS, name string, lines map[string]string, nested bool) error {
+	if fsys == nil {
+		return nil
+	}
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("translation: %s: %w", name, err)
+	}
+	flatten("", decoded, lines, nested)
+	return nil
+}
+
+// flatten 把嵌套对象展开为点号分隔的键
+func flatten(prefix string, values map[string]interface{}, lines map[string]string, nested bool) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			lines[key] = v
+		case map[string]interface{}:
+			if nested {
+				flatten(key, v, lines, nested)
+			}
+		case nil:
+		default:
+			lines[key] = fmt.Sprint(v)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package driver
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// intervalPattern 匹配 {0}、[2,*]、[1,5] 形式的区间前缀
+var intervalPattern = regexp.MustCompile(`^[\{\[]([^\[\]\{\}]*)[\}\]]\s*`)
+
+// Choose 按数量从 "单数|复数" 形式的翻译中选择，对应 Laravel 的 MessageSelector
+//
+// 带区间前缀的部分优先匹配，例如 "{0} 没有苹果|[1,19] 一些苹果|[20,*] 很多苹果"；
+// 否则按语言的复数规则选择，规则给出的位置不存在时返回第一部分。
+func Choose(line string, number int, locale string) string {
+	segments := strings.Split(line, "|")
+	for _, segment := range segments {
+		if value, ok := extract(segment, number); ok {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	for i, segment := range segments {
+		segments[i] = strings.TrimSpace(intervalPattern.ReplaceAllString(segment, ""))
+	}
+	index := PluralIndex(locale, number)
+	if len(segments) == 1 || index >= len(segments) {
+		return segments[0]
+	}
+	return segments[index]
+}
+
+// extract 区间匹配时返回去掉区间前缀的内容
+func extract(segment string, number int) (string, bool) {
+	segment = strings.TrimSpace(segment)
+	match := intervalPattern.FindStringSubmatchIndex(segment)
+	if match == nil {
+		return "", false
+	}
+	condition := segment[match[2]:match[3]]
+	value := segment[match[1]:]
+
+	if !strings.Contains(condition, ",") {
+		if condition == "*" {
+			return value, true
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(condition))
+		return value, err == nil && n == number
+	}
+
+	from, to, _ := strings.Cut(condition, ",")
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "*" {
+		n, err := strconv.Atoi(to)
+		return value, to == "*" || (err == nil && number <= n)
+	}
+	min, err := strconv.Atoi(from)
+	if err != nil || number < min {
+		return "", false
+	}
+	if to == "*" {
+		return value, true
+	}
+	max, err := strconv.Atoi(to)
+	return value, err == nil && number <= max
+}
+
+// PluralIndex 按语言的复数规则返回应使用第几种形式
+//
+// 规则来自 Laravel 的 MessageSelector（源自 Zend Framework），
+// 语言不在列表中时按去掉地区后缀的语言代码查找，仍找不到时返回 0。
+func PluralIndex(locale string, number int) int {
+	locale = strings.ReplaceAll(locale, "-", "_")
+	if _, ok := pluralRules[locale]; !ok {
+		if i := strings.Index(locale, "_"); i > 0 && locale != "pt_BR" {
+			locale = locale[:i]
+		}
+	}
+	rule, ok := pluralRules[locale]
+	if !ok {
+		return 0
+	}
+	if number < 0 {
+		number = -number
+	}
+	return rule(number)
+}
+
+// pluralRules 语言代码到复数规则的映射
+var pluralRules = map[string]func(n int) int{}
+
+func init() {
+	rules := []struct {
+		locales []string
+		rule    func(n int) int
+	}{
+		{
+			[]string{"az", "az_AZ", "bo", "bo_CN", "bo_IN", "dz", "dz_BT", "id", "id_ID", "ja", "ja_JP", "jv", "ka", "ka_GE",
+				"km", "km_KH", "kn", "kn_IN", "ko", "ko_KR", "ms", "ms_MY", "th", "th_TH", "tr", "tr_CY", "tr_TR", "vi", "vi_VN",
+				"zh", "zh_CN", "zh_HK", "zh_SG", "zh_TW"},
+			func(n int) int { return 0 },
+		},
+		{
+			[]string{"af", "bn", "bg", "ca", "da", "de", "el", "en", "eo", "es", "et", "eu", "fa", "fi", "fo", "fur", "fy",
+				"gl", "gu", "ha", "he", "hu", "is", "it", "ku", "lb", "ml", "mn", "mr", "nah", "nb", "ne", "nl", "nn", "no",
+				"om", "or", "pa", "pap", "ps", "pt", "so", "sq", "sv", "sw", "ta", "te", "tk", "ur", "zu"},
+			func(n int) int { return oneOther(n == 1) },
+		},
+		{
+			[]string{"am", "bh", "fil", "fr", "gun", "hi", "hy", "ln", "mg", "nso", "pt_BR", "ti", "wa"},
+			func(n int) int { return oneOther(n == 0 || n == 1) },
+		},
+		{
+			[]string{"be", "bs", "hr", "ru", "sh", "sr", "uk"},
+			func(n int) int {
+				switch {
+				case n%10 == 1 && n%100 != 11:
+					return 0
+				case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+					return 1
+				default:
+					return 2
+				}
+			},
+		},
+		{
+			[]string{"cs", "sk"},
+			func(n int) int {
+				switch {
+				case n == 1:
+					return 0
+				case n >= 2 && n <= 4:
+					return 1
+				default:
+					return 2
+				}
+			},
+		},
+		{
+			[]string{"ga"},
+			func(n int) int {
+				switch n {
+				case 1:
+					return 0
+				case 2:
+					return 1
+				default:
+					return 2
+				}
+			},
+		},
+		{
+			[]string{"lt"},
+			func(n int) int {
+				switch {
+				case n%10 == 1 && n%100 != 11:
+					return 0
+				case n%10 >= 2 && (n%100 < 10 || n%100 >= 20):
+					return 1
+				default:
+					return 2
+				}
+			},
+		},
+		{
+			[]string{"sl"},
+			func(n int) int {
+				switch n % 100 {
+				case 1:
+					return 0
+				case 2:
+					return 1
+				case 3, 4:
+					return 2
+				default:
+					return 3
+				}
+			},
+		},
+		{
+			[]string{"mk"},
+			func(n int) int { return oneOther(n%10 == 1) },
+		},
+		{
+			[]string{"mt"},
+			func(n int) int {
+				switch {
+				case n == 1:
+					return 0
+				case n == 0 || (n%100 > 1 && n%100 < 11):
+					return 1
+				case n%100 > 10 && n%100 < 20:
+					return 2
+				default:
+					return 3
+				}
+			},
+		},
+		{
+			[]string{"lv"},
+			func(n int) int {
+				switch {
+				case n == 0:
+					return 0
+				case n%10 == 1 && n%100 != 11:
+					return 1
+				default:
+					return 2
+				}
+			},
+		},
+		{
+			[]string{"pl"},
+			func(n int) int {
+				switch {
+				case n == 1:
+					return 0
+				case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+					return 1
+				default:
+					return 2
+				}
+			},
+		},
+		{
+			[]string{"cy"},
+			func(n int) int {
+				switch n {
+				case 1:
+					return 0
+				case 2:
+					return 1
+				case 8, 11:
+					return 2
+				default:
+					return 3
+				}
+			},
+		},
+		{
+			[]string{"ro"},
+			func(n int) int {
+				switch {
+				case n == 1:
+					return 0
+				case n == 0 || (n%100 > 0 && n%100 < 20):
+					return 1
+				default:
+					return 2
+				}
+			},
+		},
+		{
+			[]string{"ar"},
+			func(n int) int {
+				switch {
+				case n == 0:
+					return 0
+				case n == 1:
+					return 1
+				case n == 2:
+					return 2
+				case n%100 >= 3 && n%100 <= 10:
+					return 3
+				case n%100 >= 11 && n%100 <= 99:
+					return 4
+				default:
+					return 5
+				}
+			},
+		},
+	}
+	for _, r := range rules {
+		for _, locale := range r.locales {
+			pluralRules[locale] = r.rule
+		}
+	}
+}
+
+// oneOther 单数返回 0，复数返回 1
+func oneOther(one bool) int {
+	if one {
+		return 0
+	}
+	return 1
+}
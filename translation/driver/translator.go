@@ -0,0 +1,236 @@
+package driver
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cnote0/laraveldoc/translation"
+)
+
+// Translator 翻译器实现，对应 Laravel 的 Illuminate\Translation\Translator
+//
+// 每个语言的分组在首次使用时加载并缓存；加载失败的分组视为空，可以通过 Load 预先加载以获得错误。
+type Translator struct {
+	mu       sync.RWMutex
+	loader   translation.Loader
+	locale   string
+	fallback string
+	loaded   map[string]map[string]string
+}
+
+var _ translation.Translator = (*Translator)(nil)
+
+// NewTranslator 创建翻译器
+func NewTranslator(loader translation.Loader, locale string) *Translator {
+	return &Translator{loader: loader, locale: locale, loaded: make(map[string]map[string]string)}
+}
+
+// SetFallback 设置备用语言
+func (t *Translator) SetFallback(fallback string) *Translator {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fallback = fallback
+	return t
+}
+
+// GetFallback 获取备用语言
+func (t *Translator) GetFallback() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.fallback
+}
+
+// GetLocale 获取当前语言
+func (t *Translator) GetLocale() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.locale
+}
+
+// SetLocale 设置当前语言
+func (t *Translator) SetLocale(locale string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.locale = locale
+}
+
+// Get 获取翻译并替换占位符
+func (t *Translator) Get(key string, replace map[string]string, locale ...string) string {
+	line, _, ok := t.line(key, t.locales(locale, true))
+	if !ok {
+		line = key
+	}
+	return makeReplacements(line, replace)
+}
+
+// Choice 按数量选择复数形式
+func (t *Translator) Choice(key string, number int, replace map[string]string, locale ...string) string {
+	line, used, ok := t.line(key, t.locales(locale, true))
+	if !ok {
+		line, used = key, t.locales(locale, false)[0]
+	}
+	values := make(map[string]string, len(replace)+1)
+	for name, value := range replace {
+		values[name] = value
+	}
+	if _, exists := values["count"]; !exists {
+		values["count"] = strconv.Itoa(number)
+	}
+	return makeReplacements(Choose(line, number, used), values)
+}
+
+// Has 是否存在翻译
+func (t *Translator) Has(key string, fallback bool, locale ...string) bool {
+	_, _, ok := t.line(key, t.locales(locale, fallback))
+	return ok
+}
+
+// AddLines 添加翻译，键为 "group.item"，JSON 翻译使用 "*.text"
+func (t *Translator) AddLines(lines map[string]string, locale string, namespace ...string) {
+	ns := "*"
+	if len(namespace) > 0 && namespace[0] != "" {
+		ns = namespace[0]
+	}
+	for key, value := range lines {
+		group, item, found := strings.Cut(key, ".")
+		if !found {
+			continue
+		}
+		groupLines := t.group(ns, group, locale)
+		t.mu.Lock()
+		groupLines[item] = value
+		t.mu.Unlock()
+	}
+}
+
+// Load 加载分组并返回加载错误，重复调用不会重新加载
+func (t *Translator) Load(namespace, group, locale string) error {
+	cacheKey := namespace + "\x00" + group + "\x00" + locale
+	t.mu.RLock()
+	_, ok := t.loaded[cacheKey]
+	t.mu.RUnlock()
+	if ok {
+		return nil
+	}
+	lines, err := t.loader.Load(locale, group, namespace)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.loaded[cacheKey]; !ok {
+		t.loaded[cacheKey] = lines
+	}
+	return nil
+}
+
+// line 在各语言中依次查找翻译，先查 JSON 翻译再查分组翻译，返回找到的翻译和语言
+func (t *Translator) line(key string, locales []string) (string, string, bool) {
+	namespace, group, item := parseKey(key)
+	for _, locale := range locales {
+		if line, ok := t.lookup("*", "*", locale, key); ok {
+			return line, locale, true
+		}
+		if item == "" {
+			continue
+		}
+		if line, ok := t.lookup(namespace, group, locale, item); ok {
+			return line, locale, true
+		}
+	}
+	return "", "", false
+}
+
+// lookup 在已加载的分组中查找
+func (t *Translator) lookup(namespace, group, locale, item string) (string, bool) {
+	lines := t.group(namespace, group, locale)
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	line, ok := lines[item]
+	return line, ok
+}
+
+// group 获取分组翻译，首次使用时加载
+func (t *Translator) group(namespace, group, locale string) map[string]string {
+	cacheKey := namespace + "\x00" + group + "\x00" + locale
+	t.mu.RLock()
+	lines, ok := t.loaded[cacheKey]
+	t.mu.RUnlock()
+	if ok {
+		return lines
+	}
+	if err := t.Load(namespace, group, locale); err != nil {
+		t.mu.Lock()
+		if _, ok := t.loaded[cacheKey]; !ok {
+			t.loaded[cacheKey] = make(map[string]string)
+		}
+		t.mu.Unlock()
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.loaded[cacheKey]
+}
+
+// locales 查找顺序：指定语言或当前语言，然后是备用语言
+func (t *Translator) locales(locale []string, fallback bool) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	primary := t.locale
+	if len(locale) > 0 && locale[0] != "" {
+		primary = locale[0]
+	}
+	if !fallback || t.fallback == "" || t.fallback == primary {
+		return []string{primary}
+	}
+	return []string{primary, t.fallback}
+}
+
+// parseKey 把 "namespace::group.item" 解析为三部分，没有命名空间时为 "*"
+func parseKey(key string) (namespace, group, item string) {
+	namespace = "*"
+	if ns, rest, found := strings.Cut(key, "::"); found {
+		namespace, key = ns, rest
+	}
+	group, item, _ = strings.Cut(key, ".")
+	return namespace, group, item
+}
+
+// makeReplacements 替换 :name、:Name、:NAME 占位符，较长的名称优先匹配
+func makeReplacements(line string, replace map[string]string) string {
+	if len(replace) == 0 || !strings.Contains(line, ":") {
+		return line
+	}
+	names := make([]string, 0, len(replace))
+	for name := range replace {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	pairs := make([]string, 0, len(names)*6)
+	for _, name := range names {
+		value := replace[name]
+		pairs = append(pairs,
+			":"+ucfirst(name), ucfirst(value),
+			":"+strings.ToUpper(name), strings.ToUpper(value),
+			":"+name, value,
+		)
+	}
+	return strings.NewReplacer(pairs...).Replace(line)
+}
+
+// ucfirst 首字母大写
+func ucfirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,83 @@
+package translation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Trans 使用默认翻译器翻译，对应 Laravel 的 trans 和 __ 函数，未设置默认翻译器时返回 key
+func Trans(key string, replace map[string]string, locale ...string) string {
+	translator := DefaultTranslator()
+	if translator == nil {
+		return key
+	}
+	return translator.Get(key, replace, locale...)
+}
+
+// TransChoice 使用默认翻译器按数量翻译，对应 Laravel 的 trans_choice 函数
+func TransChoice(key string, number int, replace map[string]string, locale ...string) string {
+	translator := DefaultTranslator()
+	if translator == nil {
+		return key
+	}
+	return translator.Choice(key, number, replace, locale...)
+}
+
+// Funcs 视图模板函数，传给 view.TemplateFactory.Funcs
+//
+// 包含 trans、__ 和 trans_choice，占位符以名称和值交替的参数传入：
+//
+//	{{ trans "messages.welcome" "name" .User.Name }}
+//	{{ trans_choice "messages.apples" .Count "color" "red" }}
+func Funcs(translator Translator) map[string]interface{} {
+	trans := func(key string, pairs ...interface{}) string {
+		return translator.Get(key, pairsToMap(pairs))
+	}
+	return map[string]interface{}{
+		"trans": trans,
+		"__":    trans,
+		"trans_choice": func(key string, number int, pairs ...interface{}) string {
+			return translator.Choice(key, number, pairsToMap(pairs))
+		},
+	}
+}
+
+// ValidationMessage 获取验证规则的错误消息
+//
+// 依次查找 "validation.custom.{attribute}.{rule}" 和 "validation.{rule}"，
+// 字段名使用 "validation.attributes.{attribute}" 的翻译，没有时把下划线替换为空格。
+// replace 为规则参数，例如 min 规则的 {"min": "8"}。找不到消息时返回 "validation.{rule}"。
+func ValidationMessage(translator Translator, rule, attribute string, replace map[string]string) string {
+	values := make(map[string]string, len(replace)+1)
+	for name, value := range replace {
+		values[name] = value
+	}
+	values["attribute"] = validationAttribute(translator, attribute)
+
+	custom := "validation.custom." + attribute + "." + rule
+	if translator.Has(custom, true) {
+		return translator.Get(custom, values)
+	}
+	return translator.Get("validation."+rule, values)
+}
+
+// validationAttribute 字段的显示名称
+func validationAttribute(translator Translator, attribute string) string {
+	key := "validation.attributes." + attribute
+	if translator.Has(key, true) {
+		return translator.Get(key, nil)
+	}
+	return strings.ReplaceAll(attribute, "_", " ")
+}
+
+// pairsToMap 把名称和值交替的参数转换为替换表
+func pairsToMap(pairs []interface{}) map[string]string {
+	if len(pairs) == 0 {
+		return nil
+	}
+	replace := make(map[string]string, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		replace[fmt.Sprint(pairs[i])] = fmt.Sprint(pairs[i+1])
+	}
+	return replace
+}
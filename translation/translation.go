@@ -0,0 +1,82 @@
+// Package translation 提供 Laravel 风格的本地化协议定义
+//
+// Translator 按 "group.key" 或 "namespace::group.key" 查找分组翻译，也可以直接用原文作为键
+// 查找 JSON 翻译；找不到时依次尝试备用语言，最后返回键本身。替换占位符 :name、:Name、:NAME
+// 分别保持原样、首字母大写和全部大写。Choice 支持 "apple|apples" 按语言的复数规则选择，
+// 以及 "{0} none|[1,19] some|[20,*] many" 形式的显式区间。
+//
+// 包结构：
+// - translation.go - Translator、Loader 接口和默认翻译器
+// - helpers.go - Trans、TransChoice 辅助函数、视图模板函数和验证消息
+//
+// 子包 driver 提供 Translator、FileLoader 和复数规则的实现。
+//
+// 使用示例：
+//
+//	translator := driver.NewTranslator(driver.NewFileLoader(os.DirFS("lang")), "zh_CN").SetFallback("en")
+//	translation.SetDefaultTranslator(translator)
+//
+//	translator.Get("messages.welcome", map[string]string{"name": "Taylor"})
+//	translator.Choice("messages.apples", 10, nil)
+//	translation.Trans("I love programming.")
+//
+//	views.Funcs(translation.Funcs(translator))
+//	// {{ trans "messages.welcome" "name" .User.Name }}
+//	// {{ trans_choice "messages.apples" .Count }}
+package translation
+
+import "sync/atomic"
+
+// Translator 翻译器接口，对应 Laravel 的 Illuminate\Contracts\Translation\Translator
+type Translator interface {
+	// Get 获取翻译并替换占位符，locale 为空时使用当前语言，找不到时返回 key
+	Get(key string, replace map[string]string, locale ...string) string
+
+	// Choice 按数量选择复数形式，:count 自动替换为 number
+	Choice(key string, number int, replace map[string]string, locale ...string) string
+
+	// Has 是否存在翻译，fallback 为 true 时也查找备用语言
+	Has(key string, fallback bool, locale ...string) bool
+
+	// GetLocale 获取当前语言
+	GetLocale() string
+
+	// SetLocale 设置当前语言
+	SetLocale(locale string)
+
+	// GetFallback 获取备用语言
+	GetFallback() string
+
+	// AddLines 添加翻译，lines 的键为 "group.key"，JSON 翻译使用 "*" 组前缀，例如 "*.Hello"
+	AddLines(lines map[string]string, locale string, namespace ...string)
+}
+
+// Loader 翻译加载器接口，对应 Laravel 的 Illuminate\Contracts\Translation\Loader
+//
+// group 和 namespace 都为 "*" 时加载 JSON 翻译；namespace 为 "*" 表示应用自身的翻译。
+type Loader interface {
+	// Load 加载指定语言、分组和命名空间的翻译，嵌套的键使用点号展开
+	Load(locale, group, namespace string) (map[string]string, error)
+
+	// AddNamespace 注册命名空间的翻译目录，用于扩展包
+	AddNamespace(namespace string, hint interface{})
+
+	// Namespaces 已注册的命名空间
+	Namespaces() []string
+}
+
+// defaultTranslator 默认翻译器
+var defaultTranslator atomic.Value
+
+// SetDefaultTranslator 设置默认翻译器
+func SetDefaultTranslator(translator Translator) {
+	defaultTranslator.Store(&translator)
+}
+
+// DefaultTranslator 获取默认翻译器，未设置时返回 nil
+func DefaultTranslator() Translator {
+	if translator, ok := defaultTranslator.Load().(*Translator); ok {
+		return *translator
+	}
+	return nil
+}
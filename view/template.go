@@ -0,0 +1,137 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+)
+
+// TemplateFactory 基于 Go 模板的视图工厂
+type TemplateFactory struct {
+	fsys       fs.FS
+	extensions []string
+	funcs      map[string]interface{}
+
+	mu     sync.RWMutex
+	shared map[string]interface{}
+	cache  map[string]func(buf *bytes.Buffer, data interface{}) error
+}
+
+var _ Factory = (*TemplateFactory)(nil)
+
+// NewTemplateFactory 创建视图工厂
+//
+// extensions 为查找视图文件时依次尝试的扩展名，默认 .html、.md、.txt。
+func NewTemplateFactory(fsys fs.FS, extensions ...string) *TemplateFactory {
+	if len(extensions) == 0 {
+		extensions = []string{".html", ".md", ".txt"}
+	}
+	return &TemplateFactory{
+		fsys:       fsys,
+		extensions: extensions,
+		funcs:      make(map[string]interface{}),
+		shared:     make(map[string]interface{}),
+		cache:      make(map[string]func(buf *bytes.Buffer, data interface{}) error),
+	}
+}
+
+// Funcs 添加模板函数，需要在首次渲染前调用
+func (f *TemplateFactory) Funcs(funcs map[string]interface{}) *TemplateFactory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for name, fn := range funcs {
+		f.funcs[name] = fn
+	}
+	f.cache = make(map[string]func(buf *bytes.Buffer, data interface{}) error)
+	return f
+}
+
+// Share 设置共享数据
+func (f *TemplateFactory) Share(key string, value interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.shared[key] = value
+}
+
+// Exists 视图是否存在
+func (f *TemplateFactory) Exists(name string) bool {
+	_, err := f.find(name)
+	return err == nil
+}
+
+// Render 渲染视图
+func (f *TemplateFactory) Render(name string, data map[string]interface{}) (string, error) {
+	execute, err := f.compile(name)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.RLock()
+	merged := make(map[string]interface{}, len(f.shared)+len(data))
+	for key, value := range f.shared {
+		merged[key] = value
+	}
+	f.mu.RUnlock()
+	for key, value := range data {
+		merged[key] = value
+	}
+
+	var buf bytes.Buffer
+	if err := execute(&buf, merged); err != nil {
+		return "", fmt.Errorf("view: render [%s]: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// compile 解析视图并缓存
+func (f *TemplateFactory) compile(name string) (func(buf *bytes.Buffer, data interface{}) error, error) {
+	f.mu.RLock()
+	execute, ok := f.cache[name]
+	f.mu.RUnlock()
+	if ok {
+		return execute, nil
+	}
+
+	file, err := f.find(name)
+	if err != nil {
+		return nil, err
+	}
+	source, err := fs.ReadFile(f.fsys, file)
+	if err != nil {
+		return nil, fmt.Errorf("view: read [%s]: %w", name, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if path.Ext(file) == ".html" {
+		tmpl, err := htmltemplate.New(file).Funcs(htmltemplate.FuncMap(f.funcs)).Parse(string(source))
+		if err != nil {
+			return nil, fmt.Errorf("view: parse [%s]: %w", name, err)
+		}
+		execute = func(buf *bytes.Buffer, data interface{}) error { return tmpl.Execute(buf, data) }
+	} else {
+		tmpl, err := texttemplate.New(file).Funcs(texttemplate.FuncMap(f.funcs)).Parse(string(source))
+		if err != nil {
+			return nil, fmt.Errorf("view: parse [%s]: %w", name, err)
+		}
+		execute = func(buf *bytes.Buffer, data interface{}) error { return tmpl.Execute(buf, data) }
+	}
+	f.cache[name] = execute
+	return execute, nil
+}
+
+// find 查找视图文件
+func (f *TemplateFactory) find(name string) (string, error) {
+	base := strings.ReplaceAll(name, ".", "/")
+	for _, ext := range f.extensions {
+		if _, err := fs.Stat(f.fsys, base+ext); err == nil {
+			return base + ext, nil
+		}
+	}
+	return "", fmt.Errorf("%w: [%s]", ErrViewNotFound, name)
+}
@@ -0,0 +1,39 @@
+// Package view 提供 Laravel 风格的视图工厂协议定义和基于 Go 模板的实现
+//
+// 视图名称使用点号分隔目录，例如 "emails.welcome" 对应 "emails/welcome.html"。
+// 扩展名决定模板引擎：.html 使用 html/template 自动转义，其他扩展名使用 text/template。
+//
+// 主要特性：
+// - 点号视图名称解析
+// - 共享数据（View::share）
+// - 自定义模板函数
+// - 解析结果缓存
+//
+// 包结构：
+// - view.go - Factory 视图工厂接口
+// - template.go - TemplateFactory 基于 html/template、text/template 的实现
+//
+// 使用示例：
+//
+//	views := view.NewTemplateFactory(os.DirFS("resources/views"))
+//	views.Share("app_name", "Acme")
+//
+//	html, err := views.Render("emails.welcome", map[string]interface{}{"user": user})
+package view
+
+import "errors"
+
+// ErrViewNotFound 视图不存在
+var ErrViewNotFound = errors.New("view: not found")
+
+// Factory 视图工厂接口
+type Factory interface {
+	// Exists 视图是否存在
+	Exists(name string) bool
+
+	// Render 渲染视图，data 与共享数据合并，同名时 data 优先
+	Render(name string, data map[string]interface{}) (string, error)
+
+	// Share 设置所有视图共享的数据
+	Share(key string, value interface{})
+}